@@ -5,28 +5,87 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	"context"
+	"encoding/json"
 	"log"
+	"net"
 	"net/http"
+	"net/netip"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 
 	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/attendance"
+	"github.com/maksmelnyk/scheduling/internal/audit"
 	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/availability"
 	"github.com/maksmelnyk/scheduling/internal/booking"
+	"github.com/maksmelnyk/scheduling/internal/bookinglinks"
+	"github.com/maksmelnyk/scheduling/internal/broadcast"
+	"github.com/maksmelnyk/scheduling/internal/broker"
+	"github.com/maksmelnyk/scheduling/internal/cache"
+	"github.com/maksmelnyk/scheduling/internal/calendar"
+	"github.com/maksmelnyk/scheduling/internal/cancellationrules"
+	"github.com/maksmelnyk/scheduling/internal/cancellations"
+	"github.com/maksmelnyk/scheduling/internal/catalog"
+	"github.com/maksmelnyk/scheduling/internal/checkin"
+	"github.com/maksmelnyk/scheduling/internal/dashboard"
 	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/migrations"
+	"github.com/maksmelnyk/scheduling/internal/deadletters"
+	"github.com/maksmelnyk/scheduling/internal/delegation"
+	"github.com/maksmelnyk/scheduling/internal/documents"
+	"github.com/maksmelnyk/scheduling/internal/escalations"
+	"github.com/maksmelnyk/scheduling/internal/extensions"
+	"github.com/maksmelnyk/scheduling/internal/favorites"
+	"github.com/maksmelnyk/scheduling/internal/feeds"
+	"github.com/maksmelnyk/scheduling/internal/forecasts"
+	"github.com/maksmelnyk/scheduling/internal/freezes"
+	"github.com/maksmelnyk/scheduling/internal/grpc"
+	"github.com/maksmelnyk/scheduling/internal/idempotency"
+	"github.com/maksmelnyk/scheduling/internal/inbox"
+	"github.com/maksmelnyk/scheduling/internal/invoices"
+	"github.com/maksmelnyk/scheduling/internal/jobs"
+	"github.com/maksmelnyk/scheduling/internal/locations"
+	"github.com/maksmelnyk/scheduling/internal/logging"
+	"github.com/maksmelnyk/scheduling/internal/me"
 	"github.com/maksmelnyk/scheduling/internal/messaging"
 	"github.com/maksmelnyk/scheduling/internal/messaging/handlers"
 	"github.com/maksmelnyk/scheduling/internal/middleware"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+	"github.com/maksmelnyk/scheduling/internal/offboarding"
+	"github.com/maksmelnyk/scheduling/internal/onboarding"
+	"github.com/maksmelnyk/scheduling/internal/organizations"
+	"github.com/maksmelnyk/scheduling/internal/outbox"
+	"github.com/maksmelnyk/scheduling/internal/payouts"
+	"github.com/maksmelnyk/scheduling/internal/ratelimit"
+	"github.com/maksmelnyk/scheduling/internal/readonly"
+	"github.com/maksmelnyk/scheduling/internal/reports"
+	"github.com/maksmelnyk/scheduling/internal/runbook"
+	"github.com/maksmelnyk/scheduling/internal/sampling"
 	"github.com/maksmelnyk/scheduling/internal/schedule"
+	"github.com/maksmelnyk/scheduling/internal/schema"
+	"github.com/maksmelnyk/scheduling/internal/sessionnotes"
+	"github.com/maksmelnyk/scheduling/internal/sessiontypes"
+	"github.com/maksmelnyk/scheduling/internal/sharing"
+	"github.com/maksmelnyk/scheduling/internal/slotpolicy"
+	"github.com/maksmelnyk/scheduling/internal/snapshots"
+	"github.com/maksmelnyk/scheduling/internal/suggestions"
+	"github.com/maksmelnyk/scheduling/internal/taskrunner"
+	"github.com/maksmelnyk/scheduling/internal/taxes"
 	"github.com/maksmelnyk/scheduling/internal/telemetry"
+	"github.com/maksmelnyk/scheduling/internal/threads"
+	"github.com/maksmelnyk/scheduling/internal/userdeletion"
+	"github.com/maksmelnyk/scheduling/internal/waitlist"
+	"github.com/maksmelnyk/scheduling/internal/webhooks"
+	"github.com/maksmelnyk/scheduling/internal/widgets"
 )
 
 // @title SCHEDULING
@@ -40,7 +99,11 @@ import (
 func main() {
 	// --- Config & Context ---
 	cfg := config.LoadConfig()
-	ctx, cancel := context.WithCancel(context.Background())
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+	// Background jobs and consumers run within ctx and see every tenant, requests are scoped to their own
+	ctx, cancel := context.WithCancel(database.WithAllTenants(context.Background()))
 	defer cancel()
 
 	docs.SwaggerInfo.Host = "localhost:" + cfg.Server.Port
@@ -57,16 +120,46 @@ func main() {
 	}()
 
 	// --- Http Client Setup ---
-	httpClient := &http.Client{
-		Timeout: 10 * time.Second,
+	httpClient, err := telemetry.NewHTTPClient(&cfg.HttpClient)
+	if err != nil {
+		tel.Logger.Panicf("HTTP client init error: %s", err)
 	}
 
+	meter := otel.GetMeterProvider().Meter(cfg.Server.Name)
+
+	// --- Background Tasks ---
+	taskRunner := taskrunner.NewRunner(tel.Logger, otel.GetTracerProvider().Tracer(cfg.Server.Name))
+
 	// --- Auth JWT Validator ---
-	jwksProvider := auth.NewJWKManager(cfg.Keycloak.JwksURI, time.Hour)
-	validator := auth.NewJWTValidator(jwksProvider, cfg.Keycloak.Issuer, cfg.Keycloak.Audience)
+	jwksProvider, err := auth.NewJWKManager(
+		cfg.Keycloak.JwksURI,
+		time.Duration(cfg.Keycloak.JwksCacheTTLSeconds)*time.Second,
+		time.Duration(cfg.Keycloak.JwksRefreshMinIntervalSeconds)*time.Second,
+		time.Duration(cfg.Keycloak.JwksMaxStaleSeconds)*time.Second,
+		tel.Logger,
+		meter,
+	)
+	if err != nil {
+		tel.Logger.Panicf("JWKS cache init error: %s", err)
+	}
+	tokenTTL := time.Duration(cfg.Keycloak.TokenCacheTTLSeconds) * time.Second
+	validator, err := auth.NewJWTValidator(jwksProvider, cfg.Keycloak.Issuer, cfg.Keycloak.Audience, tokenTTL, cfg.Keycloak.TokenCacheMaxEntries, meter)
+	if err != nil {
+		tel.Logger.Panicf("Token cache init error: %s", err)
+	}
 
 	// --- Database ---
-	db, err := database.NewPgSqlDb(&cfg.Postgres)
+	failover := database.NewFailoverMonitor(tel.Logger, &cfg.Postgres)
+	pool, err := database.NewPgxPool(ctx, &cfg.Postgres, failover)
+	if err != nil {
+		tel.Logger.Panicf("Postgresql pool init error: %s", err)
+	}
+	defer pool.Close()
+	if err := database.RegisterPoolMetrics(meter, pool); err != nil {
+		tel.Logger.Panicf("Database pool metrics init error: %s", err)
+	}
+
+	db, err := database.NewPgSqlDb(pool, &cfg.Postgres, &cfg.Tenancy)
 	if err != nil {
 		tel.Logger.Panicf("Postgresql init error: %s", err)
 	}
@@ -75,9 +168,34 @@ func main() {
 			tel.Logger.Panicf("Postgresql close error: %s", err)
 		}
 	}()
+	if err := database.InitQueryMetrics(meter); err != nil {
+		tel.Logger.Panicf("Database metrics init error: %s", err)
+	}
+
+	// --- Database Migrations ---
+	if cfg.Migration.RunOnStartup {
+		runner, err := migrations.NewRunner(db, tel.Logger)
+		if err != nil {
+			tel.Logger.Panicf("Migrations load error: %s", err)
+		}
+		if _, err := runner.Up(ctx); err != nil {
+			tel.Logger.Panicf("Migrations apply error: %s", err)
+		}
+	}
+
+	// --- Schema Version Check ---
+	schemaService, err := schema.InitializeSchemaService(tel.Logger.Module("schema"), db, &cfg.Migration)
+	if err != nil {
+		tel.Logger.Panicf("Migrations load error: %s", err)
+	}
+	if cfg.Migration.VerifyOnStartup {
+		if _, err := schemaService.Verify(ctx); err != nil {
+			tel.Logger.Errorf("Failed to verify schema version: %v", err)
+		}
+	}
 
 	// --- RabbitMQ Connection Setup ---
-	connProvider := messaging.NewConnectionProvider(&cfg.RabbitMq, tel.Logger)
+	connProvider := messaging.NewConnectionProvider(&cfg.RabbitMq, tel.Logger.Module("messaging"))
 	if err := connProvider.Connect(ctx); err != nil {
 		tel.Logger.Errorf("Failed to connect to RabbitMQ: %v", err)
 		os.Exit(1)
@@ -89,7 +207,29 @@ func main() {
 	}()
 
 	// --- RabbitMQ Publisher Setup ---
-	publisher := messaging.NewPublisher(connProvider, &cfg.RabbitMq, tel.Logger)
+	messagingMetrics, err := messaging.NewMetrics(meter)
+	if err != nil {
+		tel.Logger.Panicf("Messaging metrics init error: %s", err)
+	}
+	auditService := audit.InitializeAuditService(tel.Logger.Module("audit"), db, &cfg.Audit)
+	// In degraded mode events the broker does not accept are queued to the outbox instead of failing requests
+	var eventOutbox messaging.Outbox
+	if cfg.Degradation.Enabled {
+		eventOutbox = outbox.NewOutboxRepository(db)
+	}
+	publisher := messaging.NewPublisher(connProvider, &cfg.RabbitMq, tel.Logger.Module("messaging"), auditService, eventOutbox, messagingMetrics)
+	deadLetterService := deadletters.InitializeDeadLetterService(tel.Logger.Module("deadletters"), db, publisher)
+	freezeService := freezes.InitializeFreezeService(tel.Logger.Module("freezes"), db)
+	slotPolicyService := slotpolicy.InitializeSlotPolicyService(tel.Logger.Module("slotpolicy"), db)
+	runbookService := runbook.InitializeRunbookService(tel.Logger.Module("runbook"), db, &cfg.Expiry)
+	broadcaster := broadcast.NewBroadcaster(tel.Logger.Module("broadcast"), pool, db, &cfg.Broadcast)
+	samplingService := sampling.InitializeSamplingService(tel.Logger.Module("sampling"), tel.Sampler, broadcaster)
+	loggingService := logging.InitializeLoggingService(tel.Logger.Module("logging"), broadcaster)
+	readOnlyMode := readonly.NewSwitch(&cfg.ReadOnly)
+	readOnlyService := readonly.InitializeReadOnlyService(tel.Logger.Module("readonly"), readOnlyMode, broadcaster)
+	if readOnlyMode.ReadOnly() {
+		tel.Logger.Warnf("Starting in read-only mode: %s", cfg.ReadOnly.Reason)
+	}
 	if err := publisher.Initialize(ctx); err != nil {
 		tel.Logger.Errorf("Failed to initialize publisher: %v", err)
 		os.Exit(1)
@@ -100,14 +240,143 @@ func main() {
 		}
 	}()
 
-	schedulerService := schedule.InitializeScheduleService(tel.Logger, db, &cfg.External, httpClient, publisher)
-	bookingService := booking.InitializeBookingService(tel.Logger, db, &cfg.External, httpClient, publisher)
+	// --- Redis Schedule Cache ---
+	// Without a Redis URL schedules are always read from Postgres
+	var redisClient *cache.RedisClient
+	if cfg.Redis.Url != "" {
+		redisClient, err = cache.NewRedisClient(&cfg.Redis)
+		if err != nil {
+			tel.Logger.Panicf("Redis init error: %s", err)
+		}
+		if err := redisClient.Ping(ctx); err != nil {
+			tel.Logger.Warnf("Redis is unreachable, schedules are served from Postgres until it is back: %v", err)
+		}
+		defer redisClient.Close()
+	}
+	scheduleCache, err := cache.NewSharedCache(tel.Logger, redisClient, "schedule", time.Duration(cfg.Redis.ScheduleTTLSeconds)*time.Second, meter)
+	if err != nil {
+		tel.Logger.Panicf("Schedule cache init error: %s", err)
+	}
+
+	// --- Event Throttle ---
+	// Events of a type over its cap wait in the outbox, so a bulk operation cannot hold back the other events
+	var eventThrottle *messaging.Throttle
+	if cfg.Throttle.Enabled {
+		var throttleLimiter ratelimit.Limiter = ratelimit.NewMemoryLimiter()
+		if cfg.Throttle.Shared && redisClient != nil {
+			throttleLimiter = ratelimit.NewRedisLimiter(tel.Logger.Module("messaging"), redisClient)
+		}
+		eventThrottle, err = messaging.NewThrottle(tel.Logger.Module("messaging"), throttleLimiter, &cfg.Throttle)
+		if err != nil {
+			tel.Logger.Panicf("Event throttle init error: %s", err)
+		}
+		publisher.ThrottleWith(eventThrottle, outbox.NewOutboxRepository(db))
+	}
 
-	messageHandler := handlers.NewMessageHandler(tel.Logger, bookingService)
+	renderer := documents.NewRenderer()
+	checkInCodes := checkin.NewSigner(cfg.CheckIn.SigningKey)
+	feedTokens := feeds.NewSigner(cfg.CalendarFeed.SigningKey)
+	bookingLinkTokens := bookinglinks.NewSigner(cfg.BookingLink.SigningKey)
+	catalogService, err := catalog.InitializeCatalogService(tel.Logger.Module("catalog"), db, &cfg.External, &cfg.Degradation, httpClient, meter)
+	if err != nil {
+		tel.Logger.Panicf("Learning client metrics init error: %s", err)
+	}
+	webhookService := webhooks.InitializeWebhookService(tel.Logger.Module("webhooks"), db, &cfg.Webhook)
+	webhookJob := webhooks.InitializeDeliveryJob(tel.Logger.Module("webhooks"), db, httpClient, &cfg.Webhook)
+	schedulerService := schedule.InitializeScheduleService(tel.Logger.Module("schedule"), db, catalogService, publisher, renderer, feedTokens, &cfg.Location, scheduleCache, webhookService, slotPolicyService)
+	notificationService := notifications.InitializeNotificationService(tel.Logger.Module("notifications"), db, &cfg.Notification, publisher)
+	backgroundNotifier := notifications.NewBackgroundNotifier(notificationService, taskRunner)
+	taxService := taxes.InitializeTaxService(tel.Logger.Module("taxes"), db)
+	invoiceService := invoices.InitializeInvoiceService(tel.Logger.Module("invoices"), db, &cfg.Invoice, publisher, taxService)
+	waitlistService := waitlist.InitializeWaitlistService(tel.Logger.Module("waitlist"), db, publisher, backgroundNotifier, &cfg.Waitlist)
+	offerSweepJob := waitlist.InitializeOfferSweepJob(tel.Logger.Module("waitlist"), db, waitlistService, &cfg.Waitlist)
+	bookingService, err := booking.InitializeBookingService(tel.Logger.Module("booking"), db, catalogService, publisher, invoiceService, taxService, renderer, backgroundNotifier, checkInCodes, feedTokens, bookingLinkTokens, waitlistService, &cfg.Hold, scheduleCache, webhookService, slotPolicyService, meter)
+	if err != nil {
+		tel.Logger.Panicf("Booking metrics init error: %s", err)
+	}
+	serviceTokens := auth.NewServiceTokenSource(cfg.Keycloak.TokenURI, cfg.Keycloak.ClientId, cfg.Keycloak.ClientSecret, httpClient)
+	expiryJob, err := booking.InitializePendingExpiryJob(tel.Logger.Module("booking"), db, &cfg.External, &cfg.Expiry, httpClient, serviceTokens, notificationService, waitlistService, publisher, meter)
+	if err != nil {
+		tel.Logger.Panicf("Booking expiry metrics init error: %s", err)
+	}
+	holdSweepJob := booking.InitializeHoldSweepJob(tel.Logger.Module("booking"), db, &cfg.Hold)
+	sealJob := audit.InitializeSealJob(tel.Logger.Module("audit"), db, &cfg.Audit)
+	payoutService := payouts.InitializePayoutService(tel.Logger.Module("payouts"), db, &cfg.Payout, publisher)
+	attendanceService := attendance.InitializeAttendanceService(tel.Logger.Module("attendance"), db, &cfg.CheckIn, publisher, checkInCodes)
+	sessionNoteService := sessionnotes.InitializeSessionNoteService(tel.Logger.Module("sessionnotes"), db, publisher)
+	onboardingService := onboarding.InitializeOnboardingService(tel.Logger.Module("onboarding"), db)
+	meService := me.InitializeMeService(tel.Logger.Module("me"), db, notificationService)
+	cancellationService, err := cancellations.InitializeCancellationService(tel.Logger.Module("cancellations"), db, publisher, backgroundNotifier, scheduleCache, webhookService, meter)
+	if err != nil {
+		tel.Logger.Panicf("Cancellation metrics init error: %s", err)
+	}
+	threadService := threads.InitializeThreadService(tel.Logger.Module("threads"), db, &cfg.Thread, backgroundNotifier)
+	retentionJob := threads.InitializeThreadRetentionJob(tel.Logger.Module("threads"), db, &cfg.Thread)
+	escalationService := escalations.InitializeEscalationService(tel.Logger.Module("escalations"), db)
+	escalationJob := escalations.InitializeEscalationJob(tel.Logger.Module("escalations"), db, &cfg.Escalation, notificationService)
+	extensionService := extensions.InitializeExtensionService(tel.Logger.Module("extensions"), db, publisher, backgroundNotifier)
+	availabilityService := availability.InitializeAvailabilityService(tel.Logger.Module("availability"), db, scheduleCache)
+	offboardingService := offboarding.InitializeOffboardingService(tel.Logger.Module("offboarding"), db, publisher)
+	offboardingJob := offboarding.InitializeOffboardingJob(tel.Logger.Module("offboarding"), db, &cfg.Offboarding, publisher, notificationService)
+	suggestionService := suggestions.InitializeSuggestionService(tel.Logger.Module("suggestions"), db, slotPolicyService)
+	favoriteService := favorites.InitializeFavoriteService(tel.Logger.Module("favorites"), db, notificationService)
+	sessionTypeService := sessiontypes.InitializeSessionTypeService(tel.Logger.Module("sessiontypes"), db)
+	cancellationRuleService := cancellationrules.InitializeCancellationRuleService(tel.Logger.Module("cancellationrules"), db)
+	locationService := locations.InitializeLocationService(tel.Logger.Module("locations"), db)
+	shareLinkService := sharing.InitializeShareLinkService(tel.Logger.Module("sharing"), db, &cfg.Sharing)
+	bookingLinkService := bookinglinks.InitializeBookingLinkService(tel.Logger.Module("bookinglinks"), db, &cfg.BookingLink)
+	organizationService := organizations.InitializeOrganizationService(tel.Logger.Module("organizations"), db)
+	grantService := delegation.InitializeGrantService(tel.Logger.Module("delegation"), db, organizationService)
+	usageRecorder := widgets.InitializeUsageRecorder(tel.Logger.Module("widgets"), db, &cfg.Widget)
+	widgetService := widgets.InitializeWidgetService(tel.Logger.Module("widgets"), db, &cfg.Widget, shareLinkService, usageRecorder)
+	tombstonePurgeJob := widgets.InitializeTombstonePurgeJob(tel.Logger.Module("widgets"), db, &cfg.Widget)
+	calendarService := calendar.InitializeCalendarService(tel.Logger.Module("calendar"), db)
+	projectionJob := calendar.InitializeProjectionJob(tel.Logger.Module("calendar"), db, &cfg.Calendar)
+	forecastService := forecasts.InitializeForecastService(tel.Logger.Module("forecasts"), db)
+	forecastJob := forecasts.InitializeForecastJob(tel.Logger.Module("forecasts"), db, &cfg.Forecast)
+	relayJob := outbox.InitializeRelayJob(tel.Logger.Module("outbox"), db, publisher, eventThrottle, &cfg.Degradation)
+	snapshotService := snapshots.InitializeSnapshotService(tel.Logger.Module("snapshots"), db, publisher)
+	reportService, err := reports.InitializeReportService(tel.Logger.Module("reports"), db, &cfg.Report, cfg.Payout.Currency, meter)
+	if err != nil {
+		tel.Logger.Panicf("Report cache init error: %s", err)
+	}
+	dashboardService := dashboard.InitializeDashboardService(tel.Logger.Module("dashboard"), db, payoutService, reportService)
+
+	userDeletionService, err := userdeletion.InitializeUserDeletionService(tel.Logger.Module("userdeletion"), db, meter, notificationService)
+	if err != nil {
+		tel.Logger.Panicf("User deletion metrics init error: %s", err)
+	}
+
+	brokerService, err := broker.InitializeBrokerService(tel.Logger.Module("broker"), &cfg.RabbitMq, httpClient, meter)
+	if err != nil {
+		tel.Logger.Panicf("Broker metrics init error: %s", err)
+	}
+
+	inboxService, err := inbox.InitializeInboxService(tel.Logger.Module("inbox"), db, &cfg.Inbox, meter)
+	if err != nil {
+		tel.Logger.Panicf("Inbox metrics init error: %s", err)
+	}
+	inboxJob := inbox.InitializeInboxRetentionJob(tel.Logger.Module("inbox"), db, &cfg.Inbox)
+	idempotencyStore := idempotency.InitializeIdempotencyStore(db)
+	idempotencyJob := idempotency.InitializeIdempotencyRetentionJob(tel.Logger.Module("idempotency"), db, &cfg.Idempotency)
+	reminderJob := jobs.InitializeReminderJob(tel.Logger.Module("jobs"), db, publisher, &cfg.Reminder, &cfg.Notification)
+	jobScheduler := jobs.InitializeScheduler(tel.Logger.Module("jobs"), reminderJob, &cfg.Reminder)
+	slaJob, err := booking.InitializeSLAMonitorJob(tel.Logger.Module("booking"), db, publisher, &cfg.SLA, meter)
+	if err != nil {
+		tel.Logger.Panicf("Booking SLA metrics init error: %s", err)
+	}
+	jobScheduler.Every("booking-sla-alerts", time.Duration(cfg.SLA.IntervalSeconds)*time.Second, slaJob.RaiseAlerts)
+	jobScheduler.Every("booking-sla-alert-purge", time.Hour, slaJob.PurgeAlerts)
+	jobScheduler.Every("widget-usage-flush", time.Duration(cfg.Widget.UsageFlushSeconds)*time.Second, usageRecorder.Flush)
+	jobScheduler.Every("widget-usage-purge", time.Hour, usageRecorder.PurgeUsage)
+	jobScheduler.PauseWhile(readOnlyMode.ReadOnly)
+
+	messageHandler := handlers.NewMessageHandler(tel.Logger, bookingService, userDeletionService, catalogService)
 
 	// --- RabbitMQ Consumer Setup ---
-	consumerRoutingKeys := []string{messaging.PaymentToSchedulingPattern}
-	consumer := messaging.NewConsumer(connProvider, &cfg.RabbitMq, tel.Logger, consumerRoutingKeys)
+	consumerRoutingKeys := []string{messaging.PaymentToSchedulingPattern, messaging.ProfileToSchedulingPattern, messaging.LearningToSchedulingPattern}
+	consumer := messaging.NewConsumer(connProvider, &cfg.RabbitMq, tel.Logger.Module("messaging"), consumerRoutingKeys, inboxService, auditService, messagingMetrics)
+	consumer.PauseWhile(readOnlyMode.ReadOnly)
 	if err := consumer.Initialize(ctx); err != nil {
 		tel.Logger.Errorf("Failed to initialize consumer: %v", err)
 		os.Exit(1)
@@ -127,8 +396,81 @@ func main() {
 		}
 	}()
 
+	// --- Pending Booking Expiry ---
+	go expiryJob.Run(ctx)
+
+	// --- Booking Hold Sweeper ---
+	go holdSweepJob.Run(ctx)
+
+	// --- Audit Trail Sealing ---
+	go sealJob.Run(ctx)
+
+	// --- Waitlist Offer Sweeper ---
+	go offerSweepJob.Run(ctx)
+
+	// --- Booking Thread Retention ---
+	go retentionJob.Run(ctx)
+
+	// --- Booking Escalation Rules ---
+	go escalationJob.Run(ctx)
+
+	// --- Teacher Offboarding ---
+	go offboardingJob.Run(ctx)
+
+	// --- Availability Tombstone Retention ---
+	go tombstonePurgeJob.Run(ctx)
+
+	// --- Consumer Inbox Retention ---
+	go inboxJob.Run(ctx)
+
+	// --- Idempotency Key Retention ---
+	go idempotencyJob.Run(ctx)
+
+	// --- Database Failover ---
+	go failover.Run(ctx)
+
+	// --- Instance Broadcasts and JWKS Refresh ---
+	// A rotation seen by one instance makes the others fetch the key set right away instead of at its TTL
+	jwksProvider.OnRotation(func(ctx context.Context) {
+		validator.ForgetTokens()
+		if err := broadcaster.Broadcast(ctx, broadcast.SignalJWKS, nil); err != nil {
+			tel.Logger.Errorf("Failed to broadcast JWKS rotation: %v", err)
+		}
+	})
+	broadcaster.Subscribe(broadcast.SignalJWKS, func(ctx context.Context, _ json.RawMessage) error {
+		validator.ForgetTokens()
+		return jwksProvider.Bust(ctx)
+	})
+	broadcaster.Subscribe(broadcast.SignalLogLevels, loggingService.ApplyLogLevels)
+	broadcaster.Subscribe(broadcast.SignalSampling, samplingService.ApplySamplingPolicy)
+	broadcaster.Subscribe(broadcast.SignalReadOnly, readOnlyService.ApplyReadOnlyMode)
+	go broadcaster.Run(ctx)
+	go jwksProvider.Run(ctx)
+
+	// --- Calendar Month Projection ---
+	go projectionJob.Run(ctx)
+
+	// --- Demand Forecasting ---
+	go forecastJob.Run(ctx)
+
+	// --- Event Outbox Relay ---
+	go relayJob.Run(ctx)
+
+	// --- Webhook Delivery ---
+	go webhookJob.Run(ctx)
+
+	// --- Session Reminders, Booking SLA Alerts and Widget Usage ---
+	go jobScheduler.Run(ctx)
+	defer func() {
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer flushCancel()
+		if err := usageRecorder.Flush(flushCtx); err != nil {
+			tel.Logger.Errorf("Error flushing widget API usage: %v", err)
+		}
+	}()
+
 	// --- RabbitMQ DLQ Consumer Setup ---
-	dlqConsumer := messaging.NewDeadLetterConsumer(connProvider, &cfg.RabbitMq, tel.Logger)
+	dlqConsumer := messaging.NewDeadLetterConsumer(connProvider, &cfg.RabbitMq, tel.Logger.Module("messaging"), deadLetterService)
 
 	if err := dlqConsumer.Initialize(ctx); err != nil {
 		tel.Logger.Errorf("Failed to initialize DLQ consumer: %v", err)
@@ -152,25 +494,61 @@ func main() {
 	// --- HTTP Router Setup ---
 	router := chi.NewRouter()
 
-	corsMiddleware := cors.New(cors.Options{
-		AllowedOrigins:   cfg.CORS.AllowOrigin,
-		AllowedMethods:   cfg.CORS.AllowMethods,
-		AllowedHeaders:   cfg.CORS.AllowHeaders,
-		AllowCredentials: cfg.CORS.AllowCredentials,
-	})
-
-	router.Use(corsMiddleware.Handler)
 	router.Use(chiMiddleware.CleanPath)
 	router.Use(chiMiddleware.Recoverer)
 	router.Use(otelhttp.NewMiddleware("HTTPServer",
 		otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string { return r.Method + " " + r.URL.Path }),
 		otelhttp.WithMeterProvider(otel.GetMeterProvider()),
 	))
-	router.Use(middleware.LoggingMiddleware(tel.Logger))
-	router.Use(middleware.AuthMiddleware(validator, tel.Logger, []string{"/swagger", "/health"}))
+	router.Use(middleware.LoggingMiddleware(tel.Logger.Module("middleware")))
+	router.Use(middleware.DegradedMiddleware)
+	router.Use(middleware.FailoverMiddleware(failover))
+	router.Use(middleware.ReadOnlyMiddleware(readOnlyMode, cfg.ReadOnly.RetryAfterSeconds, []string{readonly.AdminPath}))
+	router.Use(middleware.LocaleMiddleware)
+	router.Use(middleware.AuthMiddleware(validator, tel.Logger.Module("middleware"), append([]string{sharing.PublicPathPrefix, bookinglinks.PublicPathPrefix, widgets.PublicPathPrefix, schedule.CalendarFeedPublicPath, booking.CalendarFeedPublicPath}, cfg.Server.PublicPaths...), &cfg.Tenancy))
+	router.Use(middleware.ActingEducatorMiddleware(grantService, tel.Logger.Module("middleware")))
+	if cfg.RateLimit.Enabled {
+		// Buckets are shared through Redis so the limit holds across replicas, otherwise each replica keeps its own
+		var limiter ratelimit.Limiter = ratelimit.NewMemoryLimiter()
+		if cfg.RateLimit.Shared && redisClient != nil {
+			limiter = ratelimit.NewRedisLimiter(tel.Logger, redisClient)
+		}
+		var trustedProxies []netip.Prefix
+		if cfg.RateLimit.TrustForwardedFor {
+			trustedProxies, err = middleware.ParseTrustedProxies(cfg.RateLimit.TrustedProxies)
+			if err != nil {
+				tel.Logger.Panicf("Rate limit trusted proxies error: %s", err)
+			}
+		}
+		bookingPolicy := ratelimit.PerMinute(cfg.RateLimit.BookingPerMinute, cfg.RateLimit.BookingBurst)
+		searchPolicy := ratelimit.PerMinute(cfg.RateLimit.SearchPerMinute, cfg.RateLimit.SearchBurst)
+		router.Use(middleware.RateLimitMiddleware(limiter, []middleware.RateLimitRoute{
+			{Name: "booking", Method: http.MethodPost, Pattern: "/api/v1/bookings", Policy: bookingPolicy},
+			{Name: "booking", Method: http.MethodPost, Pattern: "/api/v1/bookings/holds", Policy: bookingPolicy},
+			{Name: "booking", Method: http.MethodPost, Pattern: "/api/v1/bookings/links/*", Policy: bookingPolicy},
+			{Name: "availability-search", Method: http.MethodGet, Pattern: "/api/v1/schedules/availability/search", Policy: searchPolicy},
+		}, trustedProxies, tel.Logger))
+	}
+
+	// --- CORS Policies ---
+	// Each mount answers CORS with the policy of its route group. Public widget endpoints answer it themselves
+	// against the allowed origins of each educator.
+	apiCors := middleware.CORSMiddleware(&cfg.CORS.Api)
+	adminCors := middleware.CORSMiddleware(&cfg.CORS.Admin)
+	publicCors := middleware.RouteMiddleware(
+		[]string{sharing.PublicPathPrefix, bookinglinks.PublicPathPrefix, schedule.CalendarFeedPublicPath, booking.CalendarFeedPublicPath},
+		middleware.CORSMiddleware(&cfg.CORS.Public), apiCors,
+	)
+	widgetCors := middleware.RouteMiddleware([]string{widgets.PublicPathPrefix}, nil, apiCors)
 
 	// --- Mount Routes ---
-	router.Get("/swagger/*", httpSwagger.WrapHandler)
+	if cfg.Server.SwaggerEnabled {
+		router.With(apiCors).Get("/swagger/*", httpSwagger.WrapHandler)
+	}
+
+	if tel.MetricsHandler != nil {
+		router.Handle("/metrics", tel.MetricsHandler)
+	}
 
 	router.Get("/health/liveness", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -182,18 +560,101 @@ func main() {
 			http.Error(w, "db not ready", http.StatusServiceUnavailable)
 			return
 		}
+		if !schemaService.Ready() {
+			http.Error(w, "schema version mismatch", http.StatusServiceUnavailable)
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("ready"))
 	})
 
-	router.Mount("/api/v1/schedules", schedule.InitializeScheduleHTTPHandler(schedulerService))
-	router.Mount("/api/v1/bookings", booking.InitializeBookingHTTPHandler(bookingService))
+	// Opt-in per module, mutating requests of these routes commit or roll back as a whole
+	requestTx := middleware.TransactionMiddleware(db, tel.Logger.Module("middleware"))
+	// Mutations of these routes can be retried safely by sending the same Idempotency-Key
+	idempotent := middleware.IdempotencyMiddleware(idempotencyStore, &cfg.Idempotency, tel.Logger.Module("middleware"))
+
+	router.With(publicCors, idempotent, requestTx).Mount("/api/v1/schedules", schedule.InitializeScheduleHTTPHandler(schedulerService))
+	router.With(publicCors, idempotent, requestTx).Mount("/api/v1/bookings", booking.InitializeBookingHTTPHandler(bookingService))
+	router.With(apiCors).Mount("/api/v1/payouts", payouts.InitializePayoutHTTPHandler(payoutService))
+	router.With(apiCors).Mount("/api/v1/invoices", invoices.InitializeInvoiceHTTPHandler(invoiceService))
+	router.With(apiCors).Mount("/api/v1/taxes", taxes.InitializeTaxHTTPHandler(taxService))
+	router.With(adminCors).Mount("/api/v1/reports", reports.InitializeReportHTTPHandler(reportService))
+	router.With(apiCors).Mount("/api/v1/dashboard", dashboard.InitializeDashboardHTTPHandler(dashboardService))
+	router.With(apiCors).Mount("/api/v1/notifications", notifications.InitializeNotificationHTTPHandler(notificationService))
+	router.With(apiCors).Mount("/api/v1/attendance", attendance.InitializeAttendanceHTTPHandler(attendanceService))
+	router.With(apiCors).Mount("/api/v1/session-notes", sessionnotes.InitializeSessionNoteHTTPHandler(sessionNoteService))
+	router.With(apiCors).Mount("/api/v1/onboarding", onboarding.InitializeOnboardingHTTPHandler(onboardingService))
+	router.With(apiCors).Mount("/api/v1/me", me.InitializeMeHTTPHandler(meService))
+	router.With(apiCors).Mount("/api/v1/favorites", favorites.InitializeFavoriteHTTPHandler(favoriteService))
+	router.With(apiCors).Mount("/api/v1/bulk-cancellations", cancellations.InitializeCancellationHTTPHandler(cancellationService))
+	router.With(apiCors).Mount("/api/v1/threads", threads.InitializeThreadHTTPHandler(threadService))
+	router.With(apiCors).Mount("/api/v1/escalation-rules", escalations.InitializeEscalationHTTPHandler(escalationService))
+	router.With(apiCors).Mount("/api/v1/extensions", extensions.InitializeExtensionHTTPHandler(extensionService))
+	router.With(apiCors).Mount("/api/v1/waitlists", waitlist.InitializeWaitlistHTTPHandler(waitlistService))
+	router.With(apiCors).Mount("/api/v1/availability", availability.InitializeAvailabilityHTTPHandler(availabilityService))
+	router.With(apiCors).Mount("/api/v1/offboardings", offboarding.InitializeOffboardingHTTPHandler(offboardingService))
+	router.With(apiCors).Mount("/api/v1/suggestions", suggestions.InitializeSuggestionHTTPHandler(suggestionService))
+	router.With(apiCors).Mount("/api/v1/session-types", sessiontypes.InitializeSessionTypeHTTPHandler(sessionTypeService))
+	router.With(apiCors).Mount("/api/v1/cancellation-rules", cancellationrules.InitializeCancellationRuleHTTPHandler(cancellationRuleService))
+	router.With(apiCors).Mount("/api/v1/locations", locations.InitializeLocationHTTPHandler(locationService))
+	router.With(apiCors).Mount("/api/v1/snapshots", snapshots.InitializeSnapshotHTTPHandler(snapshotService))
+	router.With(publicCors).Mount("/api/v1/share-links", sharing.InitializeShareLinkHTTPHandler(shareLinkService))
+	router.With(publicCors).Mount("/api/v1/booking-links", bookinglinks.InitializeBookingLinkHTTPHandler(bookingLinkService))
+	router.With(apiCors).Mount("/api/v1/webhooks", webhooks.InitializeWebhookHTTPHandler(webhookService))
+	router.With(widgetCors).Mount("/api/v1/widgets", widgets.InitializeWidgetHTTPHandler(widgetService))
+	router.With(apiCors, requestTx).Mount("/api/v1/organizations", organizations.InitializeOrganizationHTTPHandler(organizationService))
+	router.With(apiCors, requestTx).Mount("/api/v1/grants", delegation.InitializeGrantHTTPHandler(grantService))
+	router.With(adminCors).Mount("/api/v1/broker", broker.InitializeBrokerHTTPHandler(brokerService))
+	router.With(adminCors).Mount("/api/v1/audit", audit.InitializeAuditHTTPHandler(auditService))
+	router.With(adminCors).Mount("/api/v1/admin/dlq", deadletters.InitializeDeadLetterHTTPHandler(deadLetterService))
+	router.With(adminCors).Mount("/api/v1/admin/anomalies", runbook.InitializeRunbookHTTPHandler(runbookService))
+	router.With(adminCors).Mount("/api/v1/admin/sampling", sampling.InitializeSamplingHTTPHandler(samplingService))
+	router.With(adminCors).Mount("/api/v1/admin/logging", logging.InitializeLoggingHTTPHandler(loggingService))
+	router.With(adminCors).Mount(readonly.AdminPath, readonly.InitializeReadOnlyHTTPHandler(readOnlyService))
+	router.With(adminCors).Mount("/api/v1/admin/webhooks", webhooks.InitializeAdminWebhookHTTPHandler(webhookService))
+	router.With(adminCors).Mount("/api/v1/admin/audit", audit.InitializeAdminAuditHTTPHandler(auditService))
+	router.With(adminCors).Mount("/api/v1/admin/booking-freezes", freezes.InitializeFreezeHTTPHandler(freezeService))
+	router.With(adminCors).Mount("/api/v1/admin/slot-policy", slotpolicy.InitializeSlotPolicyHTTPHandler(slotPolicyService))
+	router.With(adminCors).Mount("/api/v1/schema", schema.InitializeSchemaHTTPHandler(schemaService))
+	router.With(apiCors).Mount("/api/v1/calendar", calendar.InitializeCalendarHTTPHandler(calendarService))
+	router.With(apiCors).Mount("/api/v1/forecasts", forecasts.InitializeForecastHTTPHandler(forecastService))
 
 	// --- HTTP Server ---
+	// With an internal port the health checks and admin endpoints are only served there, off the public port
 	srv := &http.Server{
 		Addr:    ":" + cfg.Server.Port,
 		Handler: router,
 	}
+	var internalSrv *http.Server
+	if cfg.Server.InternalPort != "" {
+		srv.Handler = middleware.ExposureMiddleware(cfg.Server.InternalPaths, false)(router)
+		internalSrv = &http.Server{
+			Addr:    ":" + cfg.Server.InternalPort,
+			Handler: middleware.ExposureMiddleware(cfg.Server.InternalPaths, true)(router),
+		}
+		go func() {
+			tel.Logger.Infof("Starting internal server on :%s", cfg.Server.InternalPort)
+			if err := internalSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				tel.Logger.Errorf("Internal server failed: %v", err)
+			}
+		}()
+	}
+
+	// --- gRPC Server ---
+	if cfg.Grpc.ServiceToken == "" {
+		tel.Logger.Warn("GRPC_SERVICE_TOKEN is not set, gRPC calls are not authenticated")
+	}
+	grpcServer := grpc.InitializeGrpcServer(tel.Logger.Module("grpc"), db, bookingService, readOnlyMode, &cfg.Grpc, &cfg.Tenancy)
+	grpcListener, err := net.Listen("tcp", ":"+cfg.Grpc.Port)
+	if err != nil {
+		tel.Logger.Panicf("gRPC listen error: %s", err)
+	}
+	go func() {
+		tel.Logger.Infof("Starting gRPC server on :%s", cfg.Grpc.Port)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			tel.Logger.Errorf("gRPC server failed: %v", err)
+		}
+	}()
 
 	// --- Signal Handling ---
 	signals := make(chan os.Signal, 1)
@@ -220,6 +681,22 @@ func main() {
 	} else {
 		tel.Logger.Info("HTTP server shutdown completed")
 	}
+	if internalSrv != nil {
+		if err := internalSrv.Shutdown(shutdownCtx); err != nil {
+			tel.Logger.Errorf("Internal HTTP server shutdown error: %v", err)
+		}
+	}
+
+	// --- Graceful gRPC Shutdown ---
+	grpcServer.GracefulStop()
+	tel.Logger.Info("gRPC server shutdown completed")
+
+	// --- Drain Background Tasks ---
+	if err := taskRunner.Shutdown(shutdownCtx); err != nil {
+		tel.Logger.Errorf("Background task shutdown error: %v", err)
+	} else {
+		tel.Logger.Info("Background tasks completed")
+	}
 
 	tel.Logger.Info("Graceful shutdown complete.")
 }
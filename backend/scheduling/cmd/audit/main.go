@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/audit"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const usage = `usage: audit <command>
+
+commands:
+  verify    check that no audit trail entry was altered, removed or reordered
+  seal      sign the changes and booking status transitions not sealed yet`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg := config.LoadConfig()
+	// Operator tools work across every tenant
+	ctx := database.WithAllTenants(context.Background())
+
+	appLogger, err := logger.NewAppLogger(cfg.Log, nil)
+	if err != nil {
+		log.Fatalf("failed to initialize logger: %v", err)
+	}
+
+	pool, err := database.NewPgxPool(ctx, &cfg.Postgres, nil)
+	if err != nil {
+		log.Fatalf("Postgresql pool init error: %v", err)
+	}
+	defer pool.Close()
+
+	db, err := database.NewPgSqlDb(pool, &cfg.Postgres, &cfg.Tenancy)
+	if err != nil {
+		log.Fatalf("Postgresql init error: %v", err)
+	}
+	defer db.Close()
+
+	service := audit.InitializeAuditService(appLogger, db, &cfg.Audit)
+
+	switch os.Args[1] {
+	case "verify":
+		result, err := service.VerifyChain(ctx)
+		if err != nil {
+			log.Fatalf("Audit trail verification error: %v", err)
+		}
+		if !result.Valid {
+			fmt.Printf("audit trail broken%s: %s (%d entries checked)\n", brokenAt(result), *result.Problem, result.Checked)
+			os.Exit(1)
+		}
+		fmt.Printf("audit trail intact: %d entries checked, %d recorded before signing, %d not sealed yet\n", result.Checked, result.Unsigned, result.Pending)
+	case "seal":
+		if err := audit.InitializeSealJob(appLogger, db, &cfg.Audit).Seal(ctx); err != nil {
+			log.Fatalf("Audit trail sealing error: %v", err)
+		}
+		fmt.Println("audit trail sealed")
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// brokenAt describes where the trail broke, by chain and entry when known
+func brokenAt(result *audit.ChainVerificationResponse) string {
+	var at string
+	if result.Chain != nil {
+		at += " on chain " + *result.Chain
+	}
+	if result.BrokenAtId != nil {
+		at += fmt.Sprintf(" at entry %d", *result.BrokenAtId)
+	}
+	return at
+}
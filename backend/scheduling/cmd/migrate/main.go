@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/migrations"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  up        apply all pending migrations
+  down [n]  revert the last n applied migrations (default 1)
+  status    list migrations and whether they are applied`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg := config.LoadConfig()
+	// Operator tools work across every tenant
+	ctx := database.WithAllTenants(context.Background())
+
+	appLogger, err := logger.NewAppLogger(cfg.Log, nil)
+	if err != nil {
+		log.Fatalf("failed to initialize logger: %v", err)
+	}
+
+	pool, err := database.NewPgxPool(ctx, &cfg.Postgres, nil)
+	if err != nil {
+		log.Fatalf("Postgresql pool init error: %v", err)
+	}
+	defer pool.Close()
+
+	db, err := database.NewPgSqlDb(pool, &cfg.Postgres, &cfg.Tenancy)
+	if err != nil {
+		log.Fatalf("Postgresql init error: %v", err)
+	}
+	defer db.Close()
+
+	runner, err := migrations.NewRunner(db, appLogger)
+	if err != nil {
+		log.Fatalf("Migrations load error: %v", err)
+	}
+
+	switch os.Args[1] {
+	case "up":
+		count, err := runner.Up(ctx)
+		if err != nil {
+			log.Fatalf("Migrations apply error: %v", err)
+		}
+		fmt.Printf("applied %d migrations\n", count)
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			if steps, err = strconv.Atoi(os.Args[2]); err != nil || steps < 1 {
+				log.Fatalf("invalid number of steps: %s", os.Args[2])
+			}
+		}
+		count, err := runner.Down(ctx, steps)
+		if err != nil {
+			log.Fatalf("Migrations revert error: %v", err)
+		}
+		fmt.Printf("reverted %d migrations\n", count)
+	case "status":
+		statuses, err := runner.Status(ctx)
+		if err != nil {
+			log.Fatalf("Migrations status error: %v", err)
+		}
+		printStatus(statuses)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func printStatus(statuses []*migrations.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MIGRATION\tSTATUS\tAPPLIED AT\tNOTES")
+	for _, s := range statuses {
+		state, appliedAt, notes := "pending", "", ""
+		if s.Applied {
+			state = "applied"
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		if s.Modified {
+			notes = "modified after apply"
+		} else if !s.Reversible {
+			notes = "no down script"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Id, state, appliedAt, notes)
+	}
+	w.Flush()
+}
@@ -1,28 +1,80 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
 )
 
 type Config struct {
-	Server    ServerConfig
-	CORS      CORSConfig
-	Postgres  PostgresConfig
-	Keycloak  KeycloakConfig
-	Log       LogConfig
-	Telemetry TelemetryConfig
-	RabbitMq  RabbitMqConfig
-	External  ExternalServiceConfig
+	Server       ServerConfig
+	CORS         CORSConfig
+	Postgres     PostgresConfig
+	Keycloak     KeycloakConfig
+	Log          LogConfig
+	Telemetry    TelemetryConfig
+	RabbitMq     RabbitMqConfig
+	External     ExternalServiceConfig
+	Payout       PayoutConfig
+	Invoice      InvoiceConfig
+	Report       ReportConfig
+	Notification NotificationConfig
+	CheckIn      CheckInConfig
+	Location     LocationConfig
+	Expiry       BookingExpiryConfig
+	Sharing      SharingConfig
+	Widget       WidgetConfig
+	Thread       ThreadConfig
+	Escalation   EscalationConfig
+	Offboarding  OffboardingConfig
+	Inbox        InboxConfig
+	HttpClient   HttpClientConfig
+	Migration    MigrationConfig
+	Hold         BookingHoldConfig
+	Calendar     CalendarProjectionConfig
+	CalendarFeed CalendarFeedConfig
+	Degradation  DegradationConfig
+	Waitlist     WaitlistConfig
+	Forecast     ForecastConfig
+	Grpc         GrpcConfig
+	Idempotency  IdempotencyConfig
+	Redis        RedisConfig
+	BookingLink  BookingLinkConfig
+	Audit        AuditConfig
+	Webhook      WebhookConfig
+	Reminder     ReminderConfig
+	SLA          BookingSLAConfig
+	RateLimit    RateLimitConfig
+	Broadcast    BroadcastConfig
+	ReadOnly     ReadOnlyConfig
+	Throttle     EventThrottleConfig
+	Tenancy      TenancyConfig
 }
 
 type ServerConfig struct {
 	Port string
 	Name string
+	// PublicPaths are the operational path prefixes reachable without a token, such as the health checks.
+	// The public endpoints of the modules, such as share links, are reachable without one regardless.
+	PublicPaths []string
+	// SwaggerEnabled serves the API documentation on /swagger, it is usually disabled in production
+	SwaggerEnabled bool
+	// InternalPort serves the InternalPaths on a listener of their own, such as the health checks and the
+	// admin endpoints, and keeps them off Port. They are served on Port when empty.
+	InternalPort  string
+	InternalPaths []string
 }
 
+// CORSConfig holds the origin policies of the route groups. Public routes are reachable without signing in,
+// such as share links and calendar feeds, admin routes are the mounts restricted to administrators.
 type CORSConfig struct {
+	Api    CORSPolicy
+	Public CORSPolicy
+	Admin  CORSPolicy
+}
+
+type CORSPolicy struct {
 	AllowCredentials bool
 	AllowOrigin      []string
 	AllowHeaders     []string
@@ -35,19 +87,62 @@ type PostgresConfig struct {
 	User     string
 	Password string
 	DbName   string
-	PgDriver string
+
+	MaxConns                 int
+	MinConns                 int
+	MaxConnLifetimeSeconds   int
+	MaxConnIdleSeconds       int
+	HealthCheckPeriodSeconds int
+	AcquireTimeoutMs         int
+
+	TargetSessionAttrs   string
+	FailoverMaxAttempts  int
+	FailoverBackoffMs    int
+	FailoverMaxBackoffMs int
 }
 
 type KeycloakConfig struct {
-	JwksURI  string
-	Issuer   string
-	Audience string
+	JwksURI      string
+	Issuer       string
+	Audience     string
+	TokenURI     string
+	ClientId     string
+	ClientSecret string
+	// JwksCacheTTLSeconds is how long the signing keys are used before the key set is fetched again
+	JwksCacheTTLSeconds int
+	// JwksRefreshMinIntervalSeconds rate limits fetches of the key set, including those triggered by tokens
+	// signed with an unknown key
+	JwksRefreshMinIntervalSeconds int
+	// JwksMaxStaleSeconds is how long past the TTL the keys are still used while the key set cannot be fetched
+	JwksMaxStaleSeconds int
+	// TokenCacheTTLSeconds bounds how long a validated token is trusted without checking its signature
+	// again, never past its expiry
+	TokenCacheTTLSeconds int
+	TokenCacheMaxEntries int
 }
 
 type LogConfig struct {
 	EnableCentralStorage bool
 	ServiceName          string
 	Level                string
+	// ModuleLevels overrides the level for modules, as 'booking=debug,webhooks=warn'
+	ModuleLevels string
+}
+
+// ParseModuleLevels reads ModuleLevels, written as 'module=level' pairs separated by commas
+func (c *LogConfig) ParseModuleLevels() (map[string]string, error) {
+	levels := map[string]string{}
+	for _, pair := range strings.Split(c.ModuleLevels, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		module, level, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("module '%s' must be written as module=level", pair)
+		}
+		levels[strings.TrimSpace(module)] = strings.TrimSpace(level)
+	}
+	return levels, nil
 }
 
 type TelemetryConfig struct {
@@ -55,6 +150,33 @@ type TelemetryConfig struct {
 	EnableOtelTracing bool
 	EnableOtelMetrics bool
 	EnableOtelLogging bool
+	// EnablePrometheusMetrics exposes the metrics on /metrics for scraping, alongside the OTLP export
+	EnablePrometheusMetrics bool
+	// TraceSampler is always, never, ratio, parent_ratio or tail, see telemetry.SamplingPolicy
+	TraceSampler     string
+	TraceSampleRatio float64
+	// TraceRouteRatios overrides the ratio for path prefixes, as '/api/v1/availability=0.01,...'
+	TraceRouteRatios string
+}
+
+// RouteRatios reads TraceRouteRatios, written as 'prefix=ratio' pairs separated by commas
+func (c *TelemetryConfig) RouteRatios() (map[string]float64, error) {
+	ratios := map[string]float64{}
+	for _, pair := range strings.Split(c.TraceRouteRatios, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		prefix, rawRatio, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("route '%s' must be written as prefix=ratio", pair)
+		}
+		ratio, err := strconv.ParseFloat(strings.TrimSpace(rawRatio), 64)
+		if err != nil {
+			return nil, fmt.Errorf("ratio of route '%s' is not a number", prefix)
+		}
+		ratios[strings.TrimSpace(prefix)] = ratio
+	}
+	return ratios, nil
 }
 
 type RabbitMqConfig struct {
@@ -71,12 +193,352 @@ type RabbitMqConfig struct {
 	MaxRetryIntervalMs      int
 	RetryMultiplier         float64
 	PrefetchCount           int
+	PrefetchAutoTune        bool
+	PrefetchMin             int
+	PrefetchMax             int
+	PrefetchTargetLatencyMs int
+	PrefetchTuneSeconds     int
 	PublishConfirmTimeoutMs int
 	ConcurrentConsumers     int
+	RpcTimeoutMs            int
+	ManagementUrl           string
+	ManagementUserName      string
+	ManagementPassword      string
+	ManagementQueuePrefix   string
+	ManagementCacheSeconds  int
+	// TenantRoutingKeys suffixes the routing keys of events published for a tenant with the tenant, so the
+	// broker can route tenants apart. Bindings ending in '#' keep matching.
+	TenantRoutingKeys bool
+	// EventContentTypes publishes event types in another content type than JSON, as
+	// 'BOOKING_STATUS_CHANGED=application/x-protobuf,...', once their consumers read it
+	EventContentTypes string
+}
+
+// ParseEventContentTypes reads EventContentTypes, written as 'type=contentType' pairs separated by commas
+func (c *RabbitMqConfig) ParseEventContentTypes() (map[string]string, error) {
+	contentTypes := map[string]string{}
+	for _, pair := range strings.Split(c.EventContentTypes, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		eventType, contentType, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("event type '%s' must be written as type=contentType", pair)
+		}
+		contentTypes[strings.TrimSpace(eventType)] = strings.TrimSpace(contentType)
+	}
+	return contentTypes, nil
 }
 
 type ExternalServiceConfig struct {
-	LearningServiceUrl string
+	LearningServiceUrl             string
+	LearningMaxAttempts            int
+	LearningAttemptTimeoutMs       int
+	LearningBudgetMs               int
+	LearningBackoffMs              int
+	LearningMaxBackoffMs           int
+	LearningBreakerFailures        int
+	LearningBreakerCooldownSeconds int
+	PaymentServiceUrl              string
+	PaymentBreakerFailures         int
+	PaymentBreakerCooldownSeconds  int
+}
+
+type PayoutConfig struct {
+	CommissionPercent float64
+	FixedFee          float64
+	Currency          string
+}
+
+type InvoiceConfig struct {
+	NumberPrefix string
+	Currency     string
+}
+
+type ReportConfig struct {
+	CacheTTLSeconds int
+	CacheMaxEntries int
+}
+
+type NotificationConfig struct {
+	DefaultChannels        []string
+	DefaultReminderOffsets []int
+	DefaultLanguage        string
+	DefaultTimezone        string
+	DefaultTimeFormat      string
+}
+
+type CheckInConfig struct {
+	SigningKey   string
+	EarlyMinutes int
+}
+
+type LocationConfig struct {
+	TravelSpeedKmh         float64
+	MinTravelBufferMinutes int
+}
+
+type SharingConfig struct {
+	SigningKey   string
+	MaxRangeDays int
+}
+
+type BookingLinkConfig struct {
+	SigningKey      string
+	MaxUses         int
+	MaxValidityDays int
+}
+
+// AuditConfig holds the key the audit trail entries are signed with and how often the changes and booking
+// status transitions written by triggers are sealed on their chains
+type AuditConfig struct {
+	SigningKey          string
+	SealIntervalSeconds int
+}
+
+type WidgetConfig struct {
+	CacheMaxAgeSeconds        int
+	DefaultRateLimitPerMinute int
+	MaxRangeDays              int
+	PollRateLimitPerMinute    int
+	PollMaxChanges            int
+	TombstoneRetentionDays    int
+	TombstonePurgeMinutes     int
+	// UsageFlushSeconds is how often the API key usage counted in memory is written, and so how far the
+	// usage integrators see lags behind
+	UsageFlushSeconds  int
+	UsageRetentionDays int
+}
+
+type ThreadConfig struct {
+	RetentionDays        int
+	MaxMessageLength     int
+	PurgeIntervalMinutes int
+}
+
+// RedisConfig enables the shared cache of educator schedules when Url is set
+type RedisConfig struct {
+	Url           string
+	PoolSize      int
+	DialTimeoutMs int
+	OpTimeoutMs   int
+	// ScheduleTTLSeconds bounds how long a cached schedule is served, which also bounds how stale it can
+	// get through changes that do not invalidate it
+	ScheduleTTLSeconds int
+}
+
+type EscalationConfig struct {
+	IntervalSeconds int
+	BatchSize       int
+}
+
+type OffboardingConfig struct {
+	IntervalSeconds int
+	BatchSize       int
+}
+
+type ReminderConfig struct {
+	IntervalSeconds int
+	BatchSize       int
+	// CatchUpMinutes is how late a reminder is still sent, after the service was down when it fell due
+	CatchUpMinutes       int
+	RetentionDays        int
+	PurgeIntervalMinutes int
+}
+
+type InboxConfig struct {
+	DedupWindowHours int
+	// RetentionGraceHours keeps records past the dedup window, so redeliveries arriving after it are
+	// still recognized and counted as late
+	RetentionGraceHours  int
+	PurgeIntervalMinutes int
+}
+
+type HttpClientConfig struct {
+	TimeoutSeconds             int
+	MaxIdleConns               int
+	MaxIdleConnsPerHost        int
+	MaxConnsPerHost            int
+	IdleConnTimeoutSeconds     int
+	TLSHandshakeTimeoutSeconds int
+	TLSMinVersion              string
+	TLSCAFile                  string
+}
+
+type BookingHoldConfig struct {
+	TTLMinutes           int
+	SweepIntervalSeconds int
+}
+
+type CalendarProjectionConfig struct {
+	IntervalSeconds int
+	// LagSeconds is how far before the watermark changes are read again, covering transactions that commit late
+	LagSeconds int
+}
+
+type WaitlistConfig struct {
+	// ClaimWindowMinutes is how long a freed place is offered to the next waitlisted student, 0 books it for them directly
+	ClaimWindowMinutes   int
+	SweepIntervalSeconds int
+	SweepBatchSize       int
+}
+
+type ForecastConfig struct {
+	IntervalSeconds int
+	// HorizonDays is how many days ahead demand is forecast, starting today
+	HorizonDays int
+	// LookbackDays is how many past days of bookings the lead times and weekday demand are learned from
+	LookbackDays int
+}
+
+type IdempotencyConfig struct {
+	// TTLHours is how long a response is replayed for retries with the same Idempotency-Key
+	TTLHours int
+	// LockTimeoutSeconds is how long a key stays locked by a request that never completed, such as one
+	// interrupted by a restart, before a retry may run it again
+	LockTimeoutSeconds   int
+	PurgeIntervalMinutes int
+}
+
+// WebhookConfig governs the delivery of webhooks to subscribed external systems
+type WebhookConfig struct {
+	DeliveryIntervalSeconds int
+	BatchSize               int
+	TimeoutMs               int
+	// MaxAttempts is how often a delivery is attempted before it is marked failed
+	MaxAttempts       int
+	BackoffSeconds    int
+	MaxBackoffSeconds int
+	RetentionDays     int
+	MaxSubscriptions  int
+	// AllowInsecureUrls accepts plain http URLs, for local development only
+	AllowInsecureUrls bool
+}
+
+type GrpcConfig struct {
+	Port string
+	// ServiceToken is the shared secret backend services send as the authorization of their calls, no
+	// authorization is required when it is empty
+	ServiceToken string
+}
+
+type CalendarFeedConfig struct {
+	SigningKey string
+}
+
+// DegradationConfig governs how requests are served while the learning service or the broker is down
+type DegradationConfig struct {
+	Enabled bool
+	// CatalogFallbackTTLSeconds is how long learning service answers are kept to be served when it is unavailable
+	CatalogFallbackTTLSeconds  int
+	CatalogFallbackMaxEntries  int
+	OutboxRelayIntervalSeconds int
+	OutboxBatchSize            int
+}
+
+type MigrationConfig struct {
+	RunOnStartup bool
+	// VerifyOnStartup fails readiness while the database schema differs from the migrations of the binary
+	VerifyOnStartup bool
+}
+
+type BookingExpiryConfig struct {
+	PendingTTLMinutes int
+	IntervalSeconds   int
+	BatchSize         int
+}
+
+// BookingSLAConfig holds how long bookings may stay pending before they breach their SLA: awaiting the
+// educator's approval, or held awaiting payment
+type BookingSLAConfig struct {
+	ApprovalMinutes int
+	PaymentMinutes  int
+	// AlertsEnabled publishes an alert event for every booking or hold breaching its SLA, metrics are
+	// always recorded
+	AlertsEnabled   bool
+	IntervalSeconds int
+	BatchSize       int
+}
+
+// RateLimitConfig bounds how fast a single client, the signed in user and their IP address each, can create
+// bookings and search availability. Every limit is a token bucket refilled at the per minute rate and
+// holding up to the burst.
+type RateLimitConfig struct {
+	Enabled          bool
+	BookingPerMinute int
+	BookingBurst     int
+	SearchPerMinute  int
+	SearchBurst      int
+	// Shared keeps the buckets in Redis, so the limits hold across replicas. It needs REDIS_URL, buckets are
+	// kept per replica while Redis is unreachable.
+	Shared bool
+	// TrustForwardedFor takes the client IP address from the X-Forwarded-For header set by the gateway
+	TrustForwardedFor bool
+	// TrustedProxies are the CIDRs of the gateway and load balancers. The header is only read from them, and
+	// the client is the rightmost address in it not within them.
+	TrustedProxies []string
+}
+
+// BroadcastConfig governs the signals instances send each other over Postgres LISTEN/NOTIFY to drop cached
+// keys and apply runtime settings changed on one of them
+type BroadcastConfig struct {
+	Enabled bool
+	Channel string
+	// ReconnectSeconds is the wait before listening again after the listening connection was lost
+	ReconnectSeconds int
+}
+
+// ReadOnlyConfig puts the service into read-only mode from the start, such as while the database is restored
+// from a backup or a region fails over. Admins can also switch the mode at runtime.
+type ReadOnlyConfig struct {
+	Enabled bool
+	// Reason is reported with the mode, for operators and clients
+	Reason string
+	// RetryAfterSeconds is sent to clients whose writes are refused
+	RetryAfterSeconds int
+}
+
+// EventThrottleConfig caps how many events of a type are published a minute, so a bulk operation such as
+// cancelling a whole term cannot flood the broker and hold back time-sensitive events. Events over the cap
+// wait in the outbox and are relayed as the cap allows.
+type EventThrottleConfig struct {
+	Enabled bool
+	// TypeLimits caps event types, as 'BOOKINGS_CANCELLED=120,...' events a minute. Types not listed are
+	// published unthrottled.
+	TypeLimits string
+	// BurstSeconds is how many seconds worth of events of a type can be published at once
+	BurstSeconds int
+	// Shared keeps the buckets in Redis, so the caps hold across replicas. It needs REDIS_URL.
+	Shared bool
+}
+
+// TenancyConfig hosts several schools on one deployment. Every user belongs to the tenant named by a claim of
+// their token, and the schedules and bookings of a tenant are hidden from the others. Requests and calls
+// without a tenant see none of them, only background work sees every tenant.
+type TenancyConfig struct {
+	Enabled bool
+	// Claim is the token claim holding the tenant of the user, requests with tokens without it are refused
+	Claim string
+}
+
+// ParseTypeLimits reads TypeLimits, written as 'type=perMinute' pairs separated by commas
+func (c *EventThrottleConfig) ParseTypeLimits() (map[string]int, error) {
+	limits := map[string]int{}
+	for _, pair := range strings.Split(c.TypeLimits, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		eventType, rawLimit, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("event type '%s' must be written as type=perMinute", pair)
+		}
+		perMinute, err := strconv.Atoi(strings.TrimSpace(rawLimit))
+		if err != nil {
+			return nil, fmt.Errorf("limit of event type '%s' is not a number", eventType)
+		}
+		limits[strings.TrimSpace(eventType)] = perMinute
+	}
+	return limits, nil
 }
 
 func GetEnvWithDefault[T any](key string, defaultValue T) T {
@@ -93,19 +555,19 @@ func GetEnvWithDefault[T any](key string, defaultValue T) T {
 		if v, err := strconv.Atoi(value); err == nil {
 			result = any(v).(T)
 		} else {
-			result = defaultValue
+			result = invalidEnvValue(key, value, defaultValue)
 		}
 	case bool:
 		if v, err := strconv.ParseBool(value); err == nil {
 			result = any(v).(T)
 		} else {
-			result = defaultValue
+			result = invalidEnvValue(key, value, defaultValue)
 		}
 	case float64:
 		if v, err := strconv.ParseFloat(value, 64); err == nil {
 			result = any(v).(T)
 		} else {
-			result = defaultValue
+			result = invalidEnvValue(key, value, defaultValue)
 		}
 	default:
 		result = defaultValue
@@ -114,45 +576,101 @@ func GetEnvWithDefault[T any](key string, defaultValue T) T {
 	return result
 }
 
+// invalidEnvValue records a value that does not parse for Validate to report, and falls back to the default
+func invalidEnvValue[T any](key string, value string, defaultValue T) T {
+	invalidEnv = append(invalidEnv, fmt.Sprintf("%s '%s' is not a valid %T", key, value, defaultValue))
+	return defaultValue
+}
+
 func LoadConfig() Config {
+	invalidEnv = nil
+
 	serverConfig := ServerConfig{
-		Port: GetEnvWithDefault("SCHEDULING_PORT", "8084"),
-		Name: GetEnvWithDefault("SCHEDULING_NAME", "scheduling-service"),
+		Port:           GetEnvWithDefault("SCHEDULING_PORT", "8084"),
+		Name:           GetEnvWithDefault("SCHEDULING_NAME", "scheduling-service"),
+		PublicPaths:    splitList(GetEnvWithDefault("SCHEDULING_PUBLIC_PATHS", "/swagger,/health,/metrics")),
+		SwaggerEnabled: GetEnvWithDefault("SCHEDULING_SWAGGER_ENABLED", true),
+		InternalPort:   GetEnvWithDefault("SCHEDULING_INTERNAL_PORT", ""),
+		InternalPaths:  splitList(GetEnvWithDefault("SCHEDULING_INTERNAL_PATHS", "/health,/metrics,/api/v1/admin")),
 	}
 
+	apiOrigins := GetEnvWithDefault("ALLOWED_ORIGINS", "")
+	apiHeaders := GetEnvWithDefault("ALLOWED_HEADERS", "")
+	apiMethods := GetEnvWithDefault("ALLOWED_METHODS", "")
+	apiCredentials := GetEnvWithDefault("ALLOWED_CREDENTIALS", true)
 	corsConfig := CORSConfig{
-		AllowCredentials: GetEnvWithDefault("ALLOWED_CREDENTIALS", true),
-		AllowOrigin:      strings.Split(GetEnvWithDefault("ALLOWED_ORIGINS", ""), ","),
-		AllowHeaders:     strings.Split(GetEnvWithDefault("ALLOWED_HEADERS", ""), ","),
-		AllowMethods:     strings.Split(GetEnvWithDefault("ALLOWED_METHODS", ""), ","),
+		Api: CORSPolicy{
+			AllowCredentials: apiCredentials,
+			AllowOrigin:      strings.Split(apiOrigins, ","),
+			AllowHeaders:     strings.Split(apiHeaders, ","),
+			AllowMethods:     strings.Split(apiMethods, ","),
+		},
+		Public: CORSPolicy{
+			AllowCredentials: GetEnvWithDefault("PUBLIC_ALLOWED_CREDENTIALS", false),
+			AllowOrigin:      strings.Split(GetEnvWithDefault("PUBLIC_ALLOWED_ORIGINS", "*"), ","),
+			AllowHeaders:     strings.Split(GetEnvWithDefault("PUBLIC_ALLOWED_HEADERS", "Accept,Accept-Language,Content-Type"), ","),
+			AllowMethods:     strings.Split(GetEnvWithDefault("PUBLIC_ALLOWED_METHODS", "GET,OPTIONS"), ","),
+		},
+		// Admin routes follow the API policy unless narrowed down, typically to the origin of the back office
+		Admin: CORSPolicy{
+			AllowCredentials: GetEnvWithDefault("ADMIN_ALLOWED_CREDENTIALS", apiCredentials),
+			AllowOrigin:      strings.Split(GetEnvWithDefault("ADMIN_ALLOWED_ORIGINS", apiOrigins), ","),
+			AllowHeaders:     strings.Split(GetEnvWithDefault("ADMIN_ALLOWED_HEADERS", apiHeaders), ","),
+			AllowMethods:     strings.Split(GetEnvWithDefault("ADMIN_ALLOWED_METHODS", apiMethods), ","),
+		},
 	}
 
 	postgresConfig := PostgresConfig{
 		Host:     GetEnvWithDefault("POSTGRES_HOST", "localhost"),
 		Port:     GetEnvWithDefault("POSTGRES_PORT", "5432"),
-		PgDriver: GetEnvWithDefault("POSTGRES_DRIVER", ""),
 		DbName:   GetEnvWithDefault("SCHEDULING_DB_NAME", "scheduling"),
 		User:     GetEnvWithDefault("SCHEDULING_DB_USER", "postgres"),
 		Password: GetEnvWithDefault("SCHEDULING_DB_PASS", ""),
+
+		MaxConns:                 GetEnvWithDefault("POSTGRES_POOL_MAX_CONNS", 60),
+		MinConns:                 GetEnvWithDefault("POSTGRES_POOL_MIN_CONNS", 5),
+		MaxConnLifetimeSeconds:   GetEnvWithDefault("POSTGRES_POOL_MAX_CONN_LIFETIME_SECONDS", 120),
+		MaxConnIdleSeconds:       GetEnvWithDefault("POSTGRES_POOL_MAX_CONN_IDLE_SECONDS", 20),
+		HealthCheckPeriodSeconds: GetEnvWithDefault("POSTGRES_POOL_HEALTH_CHECK_SECONDS", 30),
+		AcquireTimeoutMs:         GetEnvWithDefault("POSTGRES_POOL_ACQUIRE_TIMEOUT_MS", 5000),
+
+		TargetSessionAttrs:   GetEnvWithDefault("POSTGRES_TARGET_SESSION_ATTRS", "read-write"),
+		FailoverMaxAttempts:  GetEnvWithDefault("POSTGRES_FAILOVER_MAX_ATTEMPTS", 10),
+		FailoverBackoffMs:    GetEnvWithDefault("POSTGRES_FAILOVER_BACKOFF_MS", 500),
+		FailoverMaxBackoffMs: GetEnvWithDefault("POSTGRES_FAILOVER_MAX_BACKOFF_MS", 5000),
 	}
 
 	keycloakConfig := KeycloakConfig{
-		JwksURI:  GetEnvWithDefault("KEYCLOAK_JWKS_URI", ""),
-		Issuer:   GetEnvWithDefault("KEYCLOAK_ISSUER_URI", ""),
-		Audience: GetEnvWithDefault("KEYCLOAK_AUDIENCE", ""),
+		JwksURI:      GetEnvWithDefault("KEYCLOAK_JWKS_URI", ""),
+		Issuer:       GetEnvWithDefault("KEYCLOAK_ISSUER_URI", ""),
+		Audience:     GetEnvWithDefault("KEYCLOAK_AUDIENCE", ""),
+		TokenURI:     GetEnvWithDefault("KEYCLOAK_TOKEN_URI", ""),
+		ClientId:     GetEnvWithDefault("SCHEDULING_CLIENT_ID", "scheduling-service"),
+		ClientSecret: GetEnvWithDefault("SCHEDULING_CLIENT_SECRET", ""),
+
+		JwksCacheTTLSeconds:           GetEnvWithDefault("KEYCLOAK_JWKS_CACHE_TTL_SECONDS", 3600),
+		JwksRefreshMinIntervalSeconds: GetEnvWithDefault("KEYCLOAK_JWKS_REFRESH_MIN_INTERVAL_SECONDS", 10),
+		JwksMaxStaleSeconds:           GetEnvWithDefault("KEYCLOAK_JWKS_MAX_STALE_SECONDS", 21600),
+		TokenCacheTTLSeconds:          GetEnvWithDefault("TOKEN_CACHE_TTL_SECONDS", 60),
+		TokenCacheMaxEntries:          GetEnvWithDefault("TOKEN_CACHE_MAX_ENTRIES", 10000),
 	}
 
 	logConfig := LogConfig{
 		EnableCentralStorage: GetEnvWithDefault("LOG_ENABLE_CENTRAL_STORAGE", false),
 		ServiceName:          GetEnvWithDefault("SCHEDULING_NAME", "scheduling-service"),
 		Level:                GetEnvWithDefault("SCHEDULING_LOG_LEVEL", "info"),
+		ModuleLevels:         GetEnvWithDefault("SCHEDULING_LOG_MODULE_LEVELS", ""),
 	}
 
 	telemetryConfig := TelemetryConfig{
-		OtelEndpoint:      GetEnvWithDefault("OTEL_GRPC_URL", "http://localhost:4317"),
-		EnableOtelTracing: GetEnvWithDefault("SCHEDULING_OTEL_TRACING", true),
-		EnableOtelMetrics: GetEnvWithDefault("SCHEDULING_OTEL_METRICS", true),
-		EnableOtelLogging: GetEnvWithDefault("SCHEDULING_OTEL_LOGGING", true),
+		OtelEndpoint:            GetEnvWithDefault("OTEL_GRPC_URL", "http://localhost:4317"),
+		EnableOtelTracing:       GetEnvWithDefault("SCHEDULING_OTEL_TRACING", true),
+		EnableOtelMetrics:       GetEnvWithDefault("SCHEDULING_OTEL_METRICS", true),
+		EnableOtelLogging:       GetEnvWithDefault("SCHEDULING_OTEL_LOGGING", true),
+		EnablePrometheusMetrics: GetEnvWithDefault("SCHEDULING_PROMETHEUS_METRICS", true),
+		TraceSampler:            GetEnvWithDefault("SCHEDULING_OTEL_TRACE_SAMPLER", "always"),
+		TraceSampleRatio:        GetEnvWithDefault("SCHEDULING_OTEL_TRACE_SAMPLE_RATIO", 1.0),
+		TraceRouteRatios:        GetEnvWithDefault("SCHEDULING_OTEL_TRACE_ROUTE_RATIOS", ""),
 	}
 
 	rabbitMqConfig := RabbitMqConfig{
@@ -169,13 +687,280 @@ func LoadConfig() Config {
 		MaxRetryIntervalMs:      GetEnvWithDefault("RABBITMQ_MAX_RETRY_INTERVAL", 10000),
 		RetryMultiplier:         GetEnvWithDefault("RABBITMQ_RETRY_MULTIPLIER", 2.0),
 		PrefetchCount:           GetEnvWithDefault("RABBITMQ_PREFETCH_COUNT", 10),
+		PrefetchAutoTune:        GetEnvWithDefault("RABBITMQ_PREFETCH_AUTO_TUNE", false),
+		PrefetchMin:             GetEnvWithDefault("RABBITMQ_PREFETCH_MIN", 1),
+		PrefetchMax:             GetEnvWithDefault("RABBITMQ_PREFETCH_MAX", 50),
+		PrefetchTargetLatencyMs: GetEnvWithDefault("RABBITMQ_PREFETCH_TARGET_LATENCY_MS", 500),
+		PrefetchTuneSeconds:     GetEnvWithDefault("RABBITMQ_PREFETCH_TUNE_INTERVAL_SECONDS", 10),
 		PublishConfirmTimeoutMs: GetEnvWithDefault("RABBITMQ_PUBLISH_CONFIRM_TIMEOUT", 5000),
 		ConcurrentConsumers:     GetEnvWithDefault("RABBITMQ_CONCURRENT_CONSUMERS", 3),
+		RpcTimeoutMs:            GetEnvWithDefault("RABBITMQ_RPC_TIMEOUT", 5000),
+		ManagementUrl:           GetEnvWithDefault("RABBITMQ_MANAGEMENT_URL", ""),
+		ManagementUserName:      GetEnvWithDefault("RABBITMQ_MANAGEMENT_USER", ""),
+		ManagementPassword:      GetEnvWithDefault("RABBITMQ_MANAGEMENT_PASS", ""),
+		ManagementQueuePrefix:   GetEnvWithDefault("RABBITMQ_MANAGEMENT_QUEUE_PREFIX", "scheduling-"),
+		ManagementCacheSeconds:  GetEnvWithDefault("RABBITMQ_MANAGEMENT_CACHE_SECONDS", 15),
+		TenantRoutingKeys:       GetEnvWithDefault("RABBITMQ_TENANT_ROUTING_KEYS", false),
+		EventContentTypes:       GetEnvWithDefault("RABBITMQ_EVENT_CONTENT_TYPES", ""),
 	}
 
 	externalServiceConfig := ExternalServiceConfig{
-		LearningServiceUrl: GetEnvWithDefault("LEARNING_URL", ""),
+		LearningServiceUrl:             GetEnvWithDefault("LEARNING_URL", ""),
+		LearningMaxAttempts:            GetEnvWithDefault("LEARNING_MAX_ATTEMPTS", 3),
+		LearningAttemptTimeoutMs:       GetEnvWithDefault("LEARNING_ATTEMPT_TIMEOUT_MS", 1500),
+		LearningBudgetMs:               GetEnvWithDefault("LEARNING_BUDGET_MS", 3000),
+		LearningBackoffMs:              GetEnvWithDefault("LEARNING_BACKOFF_MS", 100),
+		LearningMaxBackoffMs:           GetEnvWithDefault("LEARNING_MAX_BACKOFF_MS", 500),
+		LearningBreakerFailures:        GetEnvWithDefault("LEARNING_BREAKER_FAILURES", 5),
+		LearningBreakerCooldownSeconds: GetEnvWithDefault("LEARNING_BREAKER_COOLDOWN_SECONDS", 30),
+		PaymentServiceUrl:              GetEnvWithDefault("PAYMENT_URL", ""),
+		PaymentBreakerFailures:         GetEnvWithDefault("PAYMENT_BREAKER_FAILURES", 5),
+		PaymentBreakerCooldownSeconds:  GetEnvWithDefault("PAYMENT_BREAKER_COOLDOWN_SECONDS", 60),
+	}
+
+	payoutConfig := PayoutConfig{
+		CommissionPercent: GetEnvWithDefault("PAYOUT_COMMISSION_PERCENT", 15.0),
+		FixedFee:          GetEnvWithDefault("PAYOUT_FIXED_FEE", 0.0),
+		Currency:          GetEnvWithDefault("BILLING_CURRENCY", "USD"),
+	}
+
+	invoiceConfig := InvoiceConfig{
+		NumberPrefix: GetEnvWithDefault("INVOICE_NUMBER_PREFIX", "INV"),
+		Currency:     GetEnvWithDefault("BILLING_CURRENCY", "USD"),
+	}
+
+	reportConfig := ReportConfig{
+		CacheTTLSeconds: GetEnvWithDefault("REPORT_CACHE_TTL_SECONDS", 300),
+		CacheMaxEntries: GetEnvWithDefault("REPORT_CACHE_MAX_ENTRIES", 1000),
+	}
+
+	notificationConfig := NotificationConfig{
+		DefaultChannels:        strings.Split(GetEnvWithDefault("NOTIFICATION_DEFAULT_CHANNELS", "email"), ","),
+		DefaultReminderOffsets: splitInts(GetEnvWithDefault("NOTIFICATION_DEFAULT_REMINDER_OFFSETS", "1440,60")),
+		DefaultLanguage:        GetEnvWithDefault("NOTIFICATION_DEFAULT_LANGUAGE", "en"),
+		DefaultTimezone:        GetEnvWithDefault("NOTIFICATION_DEFAULT_TIMEZONE", "UTC"),
+		DefaultTimeFormat:      GetEnvWithDefault("NOTIFICATION_DEFAULT_TIME_FORMAT", "24h"),
+	}
+
+	checkInConfig := CheckInConfig{
+		SigningKey:   GetEnvWithDefault("CHECKIN_SIGNING_KEY", ""),
+		EarlyMinutes: GetEnvWithDefault("CHECKIN_EARLY_MINUTES", 30),
+	}
+
+	locationConfig := LocationConfig{
+		TravelSpeedKmh:         GetEnvWithDefault("LOCATION_TRAVEL_SPEED_KMH", 30.0),
+		MinTravelBufferMinutes: GetEnvWithDefault("LOCATION_MIN_TRAVEL_BUFFER_MINUTES", 15),
+	}
+
+	expiryConfig := BookingExpiryConfig{
+		PendingTTLMinutes: GetEnvWithDefault("BOOKING_PENDING_TTL_MINUTES", 1440),
+		IntervalSeconds:   GetEnvWithDefault("BOOKING_EXPIRY_INTERVAL_SECONDS", 300),
+		BatchSize:         GetEnvWithDefault("BOOKING_EXPIRY_BATCH_SIZE", 100),
+	}
+
+	slaConfig := BookingSLAConfig{
+		ApprovalMinutes: GetEnvWithDefault("BOOKING_SLA_APPROVAL_MINUTES", 720),
+		PaymentMinutes:  GetEnvWithDefault("BOOKING_SLA_PAYMENT_MINUTES", 10),
+		AlertsEnabled:   GetEnvWithDefault("BOOKING_SLA_ALERTS_ENABLED", false),
+		IntervalSeconds: GetEnvWithDefault("BOOKING_SLA_INTERVAL_SECONDS", 60),
+		BatchSize:       GetEnvWithDefault("BOOKING_SLA_BATCH_SIZE", 100),
+	}
+
+	rateLimitConfig := RateLimitConfig{
+		Enabled:           GetEnvWithDefault("RATE_LIMIT_ENABLED", true),
+		BookingPerMinute:  GetEnvWithDefault("RATE_LIMIT_BOOKING_PER_MINUTE", 20),
+		BookingBurst:      GetEnvWithDefault("RATE_LIMIT_BOOKING_BURST", 5),
+		SearchPerMinute:   GetEnvWithDefault("RATE_LIMIT_SEARCH_PER_MINUTE", 60),
+		SearchBurst:       GetEnvWithDefault("RATE_LIMIT_SEARCH_BURST", 20),
+		Shared:            GetEnvWithDefault("RATE_LIMIT_SHARED", false),
+		TrustForwardedFor: GetEnvWithDefault("RATE_LIMIT_TRUST_FORWARDED_FOR", false),
+		TrustedProxies:    splitList(GetEnvWithDefault("RATE_LIMIT_TRUSTED_PROXIES", "10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,127.0.0.0/8,::1/128,fc00::/7")),
+	}
+
+	broadcastConfig := BroadcastConfig{
+		Enabled:          GetEnvWithDefault("BROADCAST_ENABLED", true),
+		Channel:          GetEnvWithDefault("BROADCAST_CHANNEL", "scheduling_broadcast"),
+		ReconnectSeconds: GetEnvWithDefault("BROADCAST_RECONNECT_SECONDS", 5),
+	}
+
+	readOnlyConfig := ReadOnlyConfig{
+		Enabled:           GetEnvWithDefault("READ_ONLY_ENABLED", false),
+		Reason:            GetEnvWithDefault("READ_ONLY_REASON", ""),
+		RetryAfterSeconds: GetEnvWithDefault("READ_ONLY_RETRY_AFTER_SECONDS", 60),
+	}
+
+	throttleConfig := EventThrottleConfig{
+		Enabled:      GetEnvWithDefault("EVENT_THROTTLE_ENABLED", true),
+		TypeLimits:   GetEnvWithDefault("EVENT_THROTTLE_TYPE_LIMITS", "BOOKINGS_CANCELLED=120,BOOKING_CANCELLATION_SETTLED=300,BOOKING_STATUS_CHANGED=600,NOTIFICATION_REQUESTED=600"),
+		BurstSeconds: GetEnvWithDefault("EVENT_THROTTLE_BURST_SECONDS", 10),
+		Shared:       GetEnvWithDefault("EVENT_THROTTLE_SHARED", false),
+	}
+
+	tenancyConfig := TenancyConfig{
+		Enabled: GetEnvWithDefault("TENANCY_ENABLED", false),
+		Claim:   GetEnvWithDefault("TENANCY_CLAIM", "tenant_id"),
+	}
+
+	sharingConfig := SharingConfig{
+		SigningKey:   GetEnvWithDefault("SHARE_LINK_SIGNING_KEY", ""),
+		MaxRangeDays: GetEnvWithDefault("SHARE_LINK_MAX_RANGE_DAYS", 90),
+	}
+
+	bookingLinkConfig := BookingLinkConfig{
+		SigningKey:      GetEnvWithDefault("BOOKING_LINK_SIGNING_KEY", ""),
+		MaxUses:         GetEnvWithDefault("BOOKING_LINK_MAX_USES", 50),
+		MaxValidityDays: GetEnvWithDefault("BOOKING_LINK_MAX_VALIDITY_DAYS", 90),
+	}
+
+	auditConfig := AuditConfig{
+		SigningKey:          GetEnvWithDefault("AUDIT_SIGNING_KEY", ""),
+		SealIntervalSeconds: GetEnvWithDefault("AUDIT_SEAL_INTERVAL_SECONDS", 5),
+	}
+
+	widgetConfig := WidgetConfig{
+		CacheMaxAgeSeconds:        GetEnvWithDefault("WIDGET_CACHE_MAX_AGE_SECONDS", 60),
+		DefaultRateLimitPerMinute: GetEnvWithDefault("WIDGET_DEFAULT_RATE_LIMIT", 120),
+		MaxRangeDays:              GetEnvWithDefault("WIDGET_MAX_RANGE_DAYS", 31),
+		PollRateLimitPerMinute:    GetEnvWithDefault("WIDGET_POLL_RATE_LIMIT", 6),
+		PollMaxChanges:            GetEnvWithDefault("WIDGET_POLL_MAX_CHANGES", 500),
+		TombstoneRetentionDays:    GetEnvWithDefault("WIDGET_POLL_TOMBSTONE_RETENTION_DAYS", 30),
+		TombstonePurgeMinutes:     GetEnvWithDefault("WIDGET_POLL_TOMBSTONE_PURGE_MINUTES", 60),
+		UsageFlushSeconds:         GetEnvWithDefault("WIDGET_USAGE_FLUSH_SECONDS", 60),
+		UsageRetentionDays:        GetEnvWithDefault("WIDGET_USAGE_RETENTION_DAYS", 90),
 	}
 
-	return Config{serverConfig, corsConfig, postgresConfig, keycloakConfig, logConfig, telemetryConfig, rabbitMqConfig, externalServiceConfig}
+	threadConfig := ThreadConfig{
+		RetentionDays:        GetEnvWithDefault("THREAD_RETENTION_DAYS", 180),
+		MaxMessageLength:     GetEnvWithDefault("THREAD_MAX_MESSAGE_LENGTH", 2000),
+		PurgeIntervalMinutes: GetEnvWithDefault("THREAD_PURGE_INTERVAL_MINUTES", 60),
+	}
+
+	escalationConfig := EscalationConfig{
+		IntervalSeconds: GetEnvWithDefault("ESCALATION_INTERVAL_SECONDS", 60),
+		BatchSize:       GetEnvWithDefault("ESCALATION_BATCH_SIZE", 100),
+	}
+
+	offboardingConfig := OffboardingConfig{
+		IntervalSeconds: GetEnvWithDefault("OFFBOARDING_INTERVAL_SECONDS", 60),
+		BatchSize:       GetEnvWithDefault("OFFBOARDING_BATCH_SIZE", 100),
+	}
+
+	reminderConfig := ReminderConfig{
+		IntervalSeconds:      GetEnvWithDefault("REMINDER_INTERVAL_SECONDS", 60),
+		BatchSize:            GetEnvWithDefault("REMINDER_BATCH_SIZE", 200),
+		CatchUpMinutes:       GetEnvWithDefault("REMINDER_CATCH_UP_MINUTES", 360),
+		RetentionDays:        GetEnvWithDefault("REMINDER_RETENTION_DAYS", 14),
+		PurgeIntervalMinutes: GetEnvWithDefault("REMINDER_PURGE_INTERVAL_MINUTES", 60),
+	}
+
+	inboxConfig := InboxConfig{
+		DedupWindowHours:     GetEnvWithDefault("INBOX_DEDUP_WINDOW_HOURS", 72),
+		RetentionGraceHours:  GetEnvWithDefault("INBOX_RETENTION_GRACE_HOURS", 24),
+		PurgeIntervalMinutes: GetEnvWithDefault("INBOX_PURGE_INTERVAL_MINUTES", 60),
+	}
+
+	httpClientConfig := HttpClientConfig{
+		TimeoutSeconds:             GetEnvWithDefault("HTTP_CLIENT_TIMEOUT_SECONDS", 10),
+		MaxIdleConns:               GetEnvWithDefault("HTTP_CLIENT_MAX_IDLE_CONNS", 100),
+		MaxIdleConnsPerHost:        GetEnvWithDefault("HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST", 20),
+		MaxConnsPerHost:            GetEnvWithDefault("HTTP_CLIENT_MAX_CONNS_PER_HOST", 50),
+		IdleConnTimeoutSeconds:     GetEnvWithDefault("HTTP_CLIENT_IDLE_CONN_TIMEOUT_SECONDS", 90),
+		TLSHandshakeTimeoutSeconds: GetEnvWithDefault("HTTP_CLIENT_TLS_HANDSHAKE_TIMEOUT_SECONDS", 10),
+		TLSMinVersion:              GetEnvWithDefault("HTTP_CLIENT_TLS_MIN_VERSION", "1.2"),
+		TLSCAFile:                  GetEnvWithDefault("HTTP_CLIENT_TLS_CA_FILE", ""),
+	}
+
+	migrationConfig := MigrationConfig{
+		RunOnStartup:    GetEnvWithDefault("MIGRATIONS_RUN_ON_STARTUP", false),
+		VerifyOnStartup: GetEnvWithDefault("MIGRATIONS_VERIFY_ON_STARTUP", true),
+	}
+
+	calendarConfig := CalendarProjectionConfig{
+		IntervalSeconds: GetEnvWithDefault("CALENDAR_PROJECTION_INTERVAL_SECONDS", 30),
+		LagSeconds:      GetEnvWithDefault("CALENDAR_PROJECTION_LAG_SECONDS", 60),
+	}
+
+	calendarFeedConfig := CalendarFeedConfig{
+		SigningKey: GetEnvWithDefault("CALENDAR_FEED_SIGNING_KEY", ""),
+	}
+
+	degradationConfig := DegradationConfig{
+		Enabled:                    GetEnvWithDefault("DEGRADED_MODE_ENABLED", true),
+		CatalogFallbackTTLSeconds:  GetEnvWithDefault("DEGRADED_CATALOG_FALLBACK_TTL_SECONDS", 3600),
+		CatalogFallbackMaxEntries:  GetEnvWithDefault("DEGRADED_CATALOG_FALLBACK_MAX_ENTRIES", 10000),
+		OutboxRelayIntervalSeconds: GetEnvWithDefault("OUTBOX_RELAY_INTERVAL_SECONDS", 10),
+		OutboxBatchSize:            GetEnvWithDefault("OUTBOX_BATCH_SIZE", 100),
+	}
+
+	waitlistConfig := WaitlistConfig{
+		ClaimWindowMinutes:   GetEnvWithDefault("WAITLIST_CLAIM_WINDOW_MINUTES", 60),
+		SweepIntervalSeconds: GetEnvWithDefault("WAITLIST_SWEEP_INTERVAL_SECONDS", 60),
+		SweepBatchSize:       GetEnvWithDefault("WAITLIST_SWEEP_BATCH_SIZE", 100),
+	}
+
+	forecastConfig := ForecastConfig{
+		IntervalSeconds: GetEnvWithDefault("FORECAST_INTERVAL_SECONDS", 3600),
+		HorizonDays:     GetEnvWithDefault("FORECAST_HORIZON_DAYS", 28),
+		LookbackDays:    GetEnvWithDefault("FORECAST_LOOKBACK_DAYS", 90),
+	}
+
+	grpcConfig := GrpcConfig{
+		Port:         GetEnvWithDefault("GRPC_PORT", "9084"),
+		ServiceToken: GetEnvWithDefault("GRPC_SERVICE_TOKEN", ""),
+	}
+
+	idempotencyConfig := IdempotencyConfig{
+		TTLHours:             GetEnvWithDefault("IDEMPOTENCY_TTL_HOURS", 24),
+		LockTimeoutSeconds:   GetEnvWithDefault("IDEMPOTENCY_LOCK_TIMEOUT_SECONDS", 60),
+		PurgeIntervalMinutes: GetEnvWithDefault("IDEMPOTENCY_PURGE_INTERVAL_MINUTES", 60),
+	}
+
+	redisConfig := RedisConfig{
+		Url:                GetEnvWithDefault("REDIS_URL", ""),
+		PoolSize:           GetEnvWithDefault("REDIS_POOL_SIZE", 10),
+		DialTimeoutMs:      GetEnvWithDefault("REDIS_DIAL_TIMEOUT_MS", 500),
+		OpTimeoutMs:        GetEnvWithDefault("REDIS_OP_TIMEOUT_MS", 200),
+		ScheduleTTLSeconds: GetEnvWithDefault("REDIS_SCHEDULE_TTL_SECONDS", 60),
+	}
+
+	webhookConfig := WebhookConfig{
+		DeliveryIntervalSeconds: GetEnvWithDefault("WEBHOOK_DELIVERY_INTERVAL_SECONDS", 10),
+		BatchSize:               GetEnvWithDefault("WEBHOOK_DELIVERY_BATCH_SIZE", 50),
+		TimeoutMs:               GetEnvWithDefault("WEBHOOK_TIMEOUT_MS", 5000),
+		MaxAttempts:             GetEnvWithDefault("WEBHOOK_MAX_ATTEMPTS", 8),
+		BackoffSeconds:          GetEnvWithDefault("WEBHOOK_BACKOFF_SECONDS", 30),
+		MaxBackoffSeconds:       GetEnvWithDefault("WEBHOOK_MAX_BACKOFF_SECONDS", 3600),
+		RetentionDays:           GetEnvWithDefault("WEBHOOK_DELIVERY_RETENTION_DAYS", 30),
+		MaxSubscriptions:        GetEnvWithDefault("WEBHOOK_MAX_SUBSCRIPTIONS", 10),
+		AllowInsecureUrls:       GetEnvWithDefault("WEBHOOK_ALLOW_INSECURE_URLS", false),
+	}
+
+	holdConfig := BookingHoldConfig{
+		TTLMinutes:           GetEnvWithDefault("BOOKING_HOLD_TTL_MINUTES", 15),
+		SweepIntervalSeconds: GetEnvWithDefault("BOOKING_HOLD_SWEEP_INTERVAL_SECONDS", 60),
+	}
+
+	return Config{serverConfig, corsConfig, postgresConfig, keycloakConfig, logConfig, telemetryConfig, rabbitMqConfig, externalServiceConfig, payoutConfig, invoiceConfig, reportConfig, notificationConfig, checkInConfig, locationConfig, expiryConfig, sharingConfig, widgetConfig, threadConfig, escalationConfig, offboardingConfig, inboxConfig, httpClientConfig, migrationConfig, holdConfig, calendarConfig, calendarFeedConfig, degradationConfig, waitlistConfig, forecastConfig, grpcConfig, idempotencyConfig, redisConfig, bookingLinkConfig, auditConfig, webhookConfig, reminderConfig, slaConfig, rateLimitConfig, broadcastConfig, readOnlyConfig, throttleConfig, tenancyConfig}
+}
+
+// splitList parses a comma separated list, skipping empty entries
+func splitList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// splitInts parses a comma separated list of integers, skipping malformed entries
+func splitInts(value string) []int {
+	var result []int
+	for _, part := range strings.Split(value, ",") {
+		if v, err := strconv.Atoi(strings.TrimSpace(part)); err == nil {
+			result = append(result, v)
+		}
+	}
+	return result
 }
@@ -0,0 +1,347 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// invalidEnv holds the environment variables the last LoadConfig could not parse and replaced by their default
+var invalidEnv []string
+
+// ValidationError lists every problem found in the configuration, so a deployment can be fixed in one go
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+type validator struct {
+	problems []string
+}
+
+func (v *validator) addf(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(name string, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf("%s is required", name)
+	}
+}
+
+// url checks an absolute URL with one of the given schemes, an empty value passes unless required
+func (v *validator) url(name string, value string, required bool, schemes ...string) {
+	if value == "" {
+		if required {
+			v.addf("%s is required", name)
+		}
+		return
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		v.addf("%s '%s' is not an absolute URL", name, value)
+		return
+	}
+	for _, scheme := range schemes {
+		if parsed.Scheme == scheme {
+			return
+		}
+	}
+	v.addf("%s '%s' must use one of the schemes %s", name, value, strings.Join(schemes, ", "))
+}
+
+func (v *validator) port(name string, value string) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		v.addf("%s '%s' is not a valid port", name, value)
+	}
+}
+
+func (v *validator) positive(name string, value int) {
+	if value <= 0 {
+		v.addf("%s must be greater than zero, got %d", name, value)
+	}
+}
+
+func (v *validator) nonNegative(name string, value int) {
+	if value < 0 {
+		v.addf("%s must not be negative, got %d", name, value)
+	}
+}
+
+func (v *validator) oneOf(name string, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.addf("%s '%s' must be one of %s", name, value, strings.Join(allowed, ", "))
+}
+
+func (v *validator) ratio(name string, value float64) {
+	if value < 0 || value > 1 {
+		v.addf("%s %g must be between 0 and 1", name, value)
+	}
+}
+
+// minSigningKeyLength keeps signed tokens from being forged by guessing a short key
+const minSigningKeyLength = 32
+
+func (v *validator) signingKey(name string, value string) {
+	if len(value) < minSigningKeyLength {
+		v.addf("%s must be at least %d characters", name, minSigningKeyLength)
+	}
+}
+
+// Validate checks the configuration before anything is started, and reports every missing or malformed
+// setting at once instead of the service failing on first use. Environment variables LoadConfig could not
+// parse are reported too.
+func (c *Config) Validate() error {
+	v := &validator{problems: append([]string(nil), invalidEnv...)}
+
+	v.port("SCHEDULING_PORT", c.Server.Port)
+	v.port("GRPC_PORT", c.Grpc.Port)
+	if c.Server.Port == c.Grpc.Port {
+		v.addf("SCHEDULING_PORT and GRPC_PORT must differ, both are %s", c.Server.Port)
+	}
+	v.required("SCHEDULING_NAME", c.Server.Name)
+	for _, prefix := range c.Server.PublicPaths {
+		// A bare slash would leave every route without authentication
+		if !strings.HasPrefix(prefix, "/") || prefix == "/" {
+			v.addf("SCHEDULING_PUBLIC_PATHS entry '%s' must be a path prefix below /", prefix)
+		}
+	}
+	if c.Server.InternalPort != "" {
+		v.port("SCHEDULING_INTERNAL_PORT", c.Server.InternalPort)
+		if c.Server.InternalPort == c.Server.Port || c.Server.InternalPort == c.Grpc.Port {
+			v.addf("SCHEDULING_INTERNAL_PORT must differ from SCHEDULING_PORT and GRPC_PORT, got %s", c.Server.InternalPort)
+		}
+		for _, prefix := range c.Server.InternalPaths {
+			if !strings.HasPrefix(prefix, "/") || prefix == "/" {
+				v.addf("SCHEDULING_INTERNAL_PATHS entry '%s' must be a path prefix below /", prefix)
+			}
+		}
+	}
+
+	v.required("POSTGRES_HOST", c.Postgres.Host)
+	v.port("POSTGRES_PORT", c.Postgres.Port)
+	v.required("SCHEDULING_DB_NAME", c.Postgres.DbName)
+	v.required("SCHEDULING_DB_USER", c.Postgres.User)
+	v.required("SCHEDULING_DB_PASS", c.Postgres.Password)
+	v.positive("POSTGRES_POOL_MAX_CONNS", c.Postgres.MaxConns)
+	v.nonNegative("POSTGRES_POOL_MIN_CONNS", c.Postgres.MinConns)
+	if c.Postgres.MinConns > c.Postgres.MaxConns {
+		v.addf("POSTGRES_POOL_MIN_CONNS %d exceeds POSTGRES_POOL_MAX_CONNS %d", c.Postgres.MinConns, c.Postgres.MaxConns)
+	}
+	v.oneOf("POSTGRES_TARGET_SESSION_ATTRS", c.Postgres.TargetSessionAttrs, "read-write", "primary", "any", "prefer-standby")
+	v.positive("POSTGRES_FAILOVER_MAX_ATTEMPTS", c.Postgres.FailoverMaxAttempts)
+	v.positive("POSTGRES_FAILOVER_BACKOFF_MS", c.Postgres.FailoverBackoffMs)
+	if c.Postgres.FailoverMaxBackoffMs < c.Postgres.FailoverBackoffMs {
+		v.addf("POSTGRES_FAILOVER_MAX_BACKOFF_MS %d is below POSTGRES_FAILOVER_BACKOFF_MS %d", c.Postgres.FailoverMaxBackoffMs, c.Postgres.FailoverBackoffMs)
+	}
+
+	v.url("KEYCLOAK_JWKS_URI", c.Keycloak.JwksURI, true, "http", "https")
+	v.required("KEYCLOAK_ISSUER_URI", c.Keycloak.Issuer)
+	v.url("KEYCLOAK_TOKEN_URI", c.Keycloak.TokenURI, false, "http", "https")
+	v.positive("KEYCLOAK_JWKS_CACHE_TTL_SECONDS", c.Keycloak.JwksCacheTTLSeconds)
+	v.positive("KEYCLOAK_JWKS_REFRESH_MIN_INTERVAL_SECONDS", c.Keycloak.JwksRefreshMinIntervalSeconds)
+	v.nonNegative("KEYCLOAK_JWKS_MAX_STALE_SECONDS", c.Keycloak.JwksMaxStaleSeconds)
+	if c.Keycloak.JwksRefreshMinIntervalSeconds >= c.Keycloak.JwksCacheTTLSeconds {
+		v.addf("KEYCLOAK_JWKS_REFRESH_MIN_INTERVAL_SECONDS must be below KEYCLOAK_JWKS_CACHE_TTL_SECONDS")
+	}
+	v.nonNegative("TOKEN_CACHE_TTL_SECONDS", c.Keycloak.TokenCacheTTLSeconds)
+	v.positive("TOKEN_CACHE_MAX_ENTRIES", c.Keycloak.TokenCacheMaxEntries)
+
+	v.oneOf("SCHEDULING_LOG_LEVEL", c.Log.Level, "debug", "info", "warn", "error")
+	if modules, err := c.Log.ParseModuleLevels(); err != nil {
+		v.addf("SCHEDULING_LOG_MODULE_LEVELS %v", err)
+	} else {
+		for module, level := range modules {
+			v.oneOf("SCHEDULING_LOG_MODULE_LEVELS "+module, level, "debug", "info", "warn", "error")
+		}
+	}
+	if c.Telemetry.EnableOtelTracing || c.Telemetry.EnableOtelMetrics || c.Telemetry.EnableOtelLogging {
+		v.url("OTEL_GRPC_URL", c.Telemetry.OtelEndpoint, true, "http", "https")
+	}
+	v.oneOf("SCHEDULING_OTEL_TRACE_SAMPLER", c.Telemetry.TraceSampler, "always", "never", "ratio", "parent_ratio", "tail")
+	v.ratio("SCHEDULING_OTEL_TRACE_SAMPLE_RATIO", c.Telemetry.TraceSampleRatio)
+	if routes, err := c.Telemetry.RouteRatios(); err != nil {
+		v.addf("SCHEDULING_OTEL_TRACE_ROUTE_RATIOS %v", err)
+	} else {
+		for prefix, ratio := range routes {
+			v.ratio("SCHEDULING_OTEL_TRACE_ROUTE_RATIOS "+prefix, ratio)
+		}
+	}
+
+	v.required("RABBITMQ_HOST", c.RabbitMq.HostName)
+	v.port("RABBITMQ_PORT", strconv.Itoa(c.RabbitMq.Port))
+	v.required("RABBITMQ_USER", c.RabbitMq.UserName)
+	v.required("RABBITMQ_EXCHANGE", c.RabbitMq.Exchange)
+	v.required("RABBITMQ_DLQ_EXCHANGE", c.RabbitMq.DeadLetterExchange)
+	if c.RabbitMq.Exchange != "" && c.RabbitMq.Exchange == c.RabbitMq.DeadLetterExchange {
+		v.addf("RABBITMQ_EXCHANGE and RABBITMQ_DLQ_EXCHANGE must differ, both are '%s'", c.RabbitMq.Exchange)
+	}
+	v.nonNegative("RABBITMQ_RETRY_COUNT", c.RabbitMq.RetryCount)
+	if c.RabbitMq.RetryMultiplier < 1 {
+		v.addf("RABBITMQ_RETRY_MULTIPLIER must be at least 1, got %g", c.RabbitMq.RetryMultiplier)
+	}
+	v.positive("RABBITMQ_PREFETCH_COUNT", c.RabbitMq.PrefetchCount)
+	if c.RabbitMq.PrefetchAutoTune && (c.RabbitMq.PrefetchMin <= 0 || c.RabbitMq.PrefetchMin > c.RabbitMq.PrefetchMax) {
+		v.addf("RABBITMQ_PREFETCH_MIN %d and RABBITMQ_PREFETCH_MAX %d must form a positive range", c.RabbitMq.PrefetchMin, c.RabbitMq.PrefetchMax)
+	}
+	v.positive("RABBITMQ_PUBLISH_CONFIRM_TIMEOUT", c.RabbitMq.PublishConfirmTimeoutMs)
+	v.positive("RABBITMQ_CONCURRENT_CONSUMERS", c.RabbitMq.ConcurrentConsumers)
+	v.positive("RABBITMQ_RPC_TIMEOUT", c.RabbitMq.RpcTimeoutMs)
+	v.url("RABBITMQ_MANAGEMENT_URL", c.RabbitMq.ManagementUrl, false, "http", "https")
+	if contentTypes, err := c.RabbitMq.ParseEventContentTypes(); err != nil {
+		v.addf("RABBITMQ_EVENT_CONTENT_TYPES %v", err)
+	} else {
+		for eventType, contentType := range contentTypes {
+			v.oneOf("RABBITMQ_EVENT_CONTENT_TYPES "+eventType, contentType, "application/json", "application/x-protobuf")
+		}
+	}
+
+	v.url("LEARNING_URL", c.External.LearningServiceUrl, true, "http", "https")
+	v.url("PAYMENT_URL", c.External.PaymentServiceUrl, true, "http", "https")
+	v.positive("LEARNING_MAX_ATTEMPTS", c.External.LearningMaxAttempts)
+	v.positive("LEARNING_ATTEMPT_TIMEOUT_MS", c.External.LearningAttemptTimeoutMs)
+	if c.External.LearningBudgetMs < c.External.LearningAttemptTimeoutMs {
+		v.addf("LEARNING_BUDGET_MS %d is below LEARNING_ATTEMPT_TIMEOUT_MS %d", c.External.LearningBudgetMs, c.External.LearningAttemptTimeoutMs)
+	}
+	v.positive("LEARNING_BREAKER_FAILURES", c.External.LearningBreakerFailures)
+	v.positive("PAYMENT_BREAKER_FAILURES", c.External.PaymentBreakerFailures)
+
+	if len(c.Payout.Currency) != 3 || strings.ToUpper(c.Payout.Currency) != c.Payout.Currency {
+		v.addf("BILLING_CURRENCY '%s' must be an ISO 4217 code such as USD", c.Payout.Currency)
+	}
+	if c.Payout.CommissionPercent < 0 || c.Payout.CommissionPercent > 100 {
+		v.addf("PAYOUT_COMMISSION_PERCENT must be between 0 and 100, got %g", c.Payout.CommissionPercent)
+	}
+
+	if _, err := time.LoadLocation(c.Notification.DefaultTimezone); err != nil {
+		v.addf("NOTIFICATION_DEFAULT_TIMEZONE '%s' is not a known time zone", c.Notification.DefaultTimezone)
+	}
+	v.oneOf("NOTIFICATION_DEFAULT_TIME_FORMAT", c.Notification.DefaultTimeFormat, "12h", "24h")
+	for _, offset := range c.Notification.DefaultReminderOffsets {
+		v.positive("NOTIFICATION_DEFAULT_REMINDER_OFFSETS entry", offset)
+	}
+
+	v.signingKey("CHECKIN_SIGNING_KEY", c.CheckIn.SigningKey)
+	v.signingKey("SHARE_LINK_SIGNING_KEY", c.Sharing.SigningKey)
+	v.signingKey("BOOKING_LINK_SIGNING_KEY", c.BookingLink.SigningKey)
+	v.signingKey("CALENDAR_FEED_SIGNING_KEY", c.CalendarFeed.SigningKey)
+	v.signingKey("AUDIT_SIGNING_KEY", c.Audit.SigningKey)
+	v.positive("SHARE_LINK_MAX_RANGE_DAYS", c.Sharing.MaxRangeDays)
+	v.positive("BOOKING_LINK_MAX_USES", c.BookingLink.MaxUses)
+	v.positive("BOOKING_LINK_MAX_VALIDITY_DAYS", c.BookingLink.MaxValidityDays)
+
+	v.positive("WEBHOOK_DELIVERY_INTERVAL_SECONDS", c.Webhook.DeliveryIntervalSeconds)
+	v.positive("WEBHOOK_DELIVERY_BATCH_SIZE", c.Webhook.BatchSize)
+	v.positive("WEBHOOK_TIMEOUT_MS", c.Webhook.TimeoutMs)
+	v.positive("WEBHOOK_MAX_ATTEMPTS", c.Webhook.MaxAttempts)
+	v.positive("WEBHOOK_BACKOFF_SECONDS", c.Webhook.BackoffSeconds)
+	if c.Webhook.MaxBackoffSeconds < c.Webhook.BackoffSeconds {
+		v.addf("WEBHOOK_MAX_BACKOFF_SECONDS %d is below WEBHOOK_BACKOFF_SECONDS %d", c.Webhook.MaxBackoffSeconds, c.Webhook.BackoffSeconds)
+	}
+	v.positive("WEBHOOK_DELIVERY_RETENTION_DAYS", c.Webhook.RetentionDays)
+	v.positive("WEBHOOK_MAX_SUBSCRIPTIONS", c.Webhook.MaxSubscriptions)
+
+	v.positive("REMINDER_BATCH_SIZE", c.Reminder.BatchSize)
+	v.positive("REMINDER_CATCH_UP_MINUTES", c.Reminder.CatchUpMinutes)
+	v.positive("REMINDER_RETENTION_DAYS", c.Reminder.RetentionDays)
+
+	v.oneOf("HTTP_CLIENT_TLS_MIN_VERSION", c.HttpClient.TLSMinVersion, "1.2", "1.3")
+	v.positive("HTTP_CLIENT_TIMEOUT_SECONDS", c.HttpClient.TimeoutSeconds)
+
+	if c.RateLimit.Enabled {
+		v.positive("RATE_LIMIT_BOOKING_PER_MINUTE", c.RateLimit.BookingPerMinute)
+		v.positive("RATE_LIMIT_BOOKING_BURST", c.RateLimit.BookingBurst)
+		v.positive("RATE_LIMIT_SEARCH_PER_MINUTE", c.RateLimit.SearchPerMinute)
+		v.positive("RATE_LIMIT_SEARCH_BURST", c.RateLimit.SearchBurst)
+		if c.RateLimit.Shared && c.Redis.Url == "" {
+			v.addf("RATE_LIMIT_SHARED requires REDIS_URL")
+		}
+		if c.RateLimit.TrustForwardedFor && len(c.RateLimit.TrustedProxies) == 0 {
+			v.addf("RATE_LIMIT_TRUST_FORWARDED_FOR requires RATE_LIMIT_TRUSTED_PROXIES")
+		}
+		for _, cidr := range c.RateLimit.TrustedProxies {
+			if _, err := netip.ParsePrefix(cidr); err != nil {
+				v.addf("RATE_LIMIT_TRUSTED_PROXIES entry %q is not a CIDR", cidr)
+			}
+		}
+	}
+
+	if c.Broadcast.Enabled {
+		v.required("BROADCAST_CHANNEL", c.Broadcast.Channel)
+		v.positive("BROADCAST_RECONNECT_SECONDS", c.Broadcast.ReconnectSeconds)
+	}
+	v.positive("READ_ONLY_RETRY_AFTER_SECONDS", c.ReadOnly.RetryAfterSeconds)
+
+	if c.Tenancy.Enabled {
+		v.required("TENANCY_CLAIM", c.Tenancy.Claim)
+	}
+
+	if c.Throttle.Enabled {
+		if limits, err := c.Throttle.ParseTypeLimits(); err != nil {
+			v.addf("EVENT_THROTTLE_TYPE_LIMITS %v", err)
+		} else {
+			for eventType, perMinute := range limits {
+				v.positive("EVENT_THROTTLE_TYPE_LIMITS "+eventType, perMinute)
+			}
+		}
+		v.positive("EVENT_THROTTLE_BURST_SECONDS", c.Throttle.BurstSeconds)
+		if c.Throttle.Shared && c.Redis.Url == "" {
+			v.addf("EVENT_THROTTLE_SHARED requires REDIS_URL")
+		}
+	}
+
+	v.url("REDIS_URL", c.Redis.Url, false, "redis")
+	if c.Redis.Url != "" {
+		v.positive("REDIS_POOL_SIZE", c.Redis.PoolSize)
+		v.positive("REDIS_SCHEDULE_TTL_SECONDS", c.Redis.ScheduleTTLSeconds)
+	}
+
+	// Background jobs tick at these intervals, a ticker panics on zero
+	v.positive("BOOKING_EXPIRY_INTERVAL_SECONDS", c.Expiry.IntervalSeconds)
+	v.positive("ESCALATION_INTERVAL_SECONDS", c.Escalation.IntervalSeconds)
+	v.positive("OFFBOARDING_INTERVAL_SECONDS", c.Offboarding.IntervalSeconds)
+	v.positive("REMINDER_INTERVAL_SECONDS", c.Reminder.IntervalSeconds)
+	v.positive("REMINDER_PURGE_INTERVAL_MINUTES", c.Reminder.PurgeIntervalMinutes)
+	v.positive("INBOX_PURGE_INTERVAL_MINUTES", c.Inbox.PurgeIntervalMinutes)
+	v.positive("INBOX_DEDUP_WINDOW_HOURS", c.Inbox.DedupWindowHours)
+	v.nonNegative("INBOX_RETENTION_GRACE_HOURS", c.Inbox.RetentionGraceHours)
+	v.positive("THREAD_PURGE_INTERVAL_MINUTES", c.Thread.PurgeIntervalMinutes)
+	v.positive("BOOKING_HOLD_SWEEP_INTERVAL_SECONDS", c.Hold.SweepIntervalSeconds)
+	v.positive("AUDIT_SEAL_INTERVAL_SECONDS", c.Audit.SealIntervalSeconds)
+	v.positive("CALENDAR_PROJECTION_INTERVAL_SECONDS", c.Calendar.IntervalSeconds)
+	v.positive("OUTBOX_RELAY_INTERVAL_SECONDS", c.Degradation.OutboxRelayIntervalSeconds)
+	v.positive("WAITLIST_SWEEP_INTERVAL_SECONDS", c.Waitlist.SweepIntervalSeconds)
+	v.positive("FORECAST_INTERVAL_SECONDS", c.Forecast.IntervalSeconds)
+	v.positive("IDEMPOTENCY_PURGE_INTERVAL_MINUTES", c.Idempotency.PurgeIntervalMinutes)
+	v.positive("WIDGET_POLL_TOMBSTONE_PURGE_MINUTES", c.Widget.TombstonePurgeMinutes)
+	v.positive("WIDGET_USAGE_FLUSH_SECONDS", c.Widget.UsageFlushSeconds)
+	v.positive("WIDGET_USAGE_RETENTION_DAYS", c.Widget.UsageRetentionDays)
+	v.positive("BOOKING_PENDING_TTL_MINUTES", c.Expiry.PendingTTLMinutes)
+	v.positive("BOOKING_HOLD_TTL_MINUTES", c.Hold.TTLMinutes)
+	v.positive("BOOKING_SLA_INTERVAL_SECONDS", c.SLA.IntervalSeconds)
+	v.positive("BOOKING_SLA_BATCH_SIZE", c.SLA.BatchSize)
+	v.positive("BOOKING_SLA_APPROVAL_MINUTES", c.SLA.ApprovalMinutes)
+	v.positive("BOOKING_SLA_PAYMENT_MINUTES", c.SLA.PaymentMinutes)
+	// Holds expire after their TTL, a longer payment SLA could never be breached
+	if c.SLA.PaymentMinutes >= c.Hold.TTLMinutes {
+		v.addf("BOOKING_SLA_PAYMENT_MINUTES %d must be below BOOKING_HOLD_TTL_MINUTES %d", c.SLA.PaymentMinutes, c.Hold.TTLMinutes)
+	}
+	v.nonNegative("WAITLIST_CLAIM_WINDOW_MINUTES", c.Waitlist.ClaimWindowMinutes)
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
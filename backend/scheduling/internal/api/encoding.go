@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxPooledBuffer keeps unusually large responses from pinning their buffers in the pool
+const maxPooledBuffer = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() any { return bytes.NewBuffer(make([]byte, 0, 4096)) },
+}
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// JSONAppender is implemented by responses of hot read paths that encode themselves without reflection.
+// The output must match what encoding/json produces for the same value.
+type JSONAppender interface {
+	AppendJSON(dst []byte) []byte
+}
+
+// AppendUUID appends a UUID as a JSON string
+func AppendUUID(dst []byte, id uuid.UUID) []byte {
+	var buf [38]byte
+	buf[0], buf[37] = '"', '"'
+	hex.Encode(buf[1:9], id[:4])
+	buf[9] = '-'
+	hex.Encode(buf[10:14], id[4:6])
+	buf[14] = '-'
+	hex.Encode(buf[15:19], id[6:8])
+	buf[19] = '-'
+	hex.Encode(buf[20:24], id[8:10])
+	buf[24] = '-'
+	hex.Encode(buf[25:37], id[10:])
+	return append(dst, buf[:]...)
+}
+
+// AppendTime appends a time as a JSON string in the RFC 3339 format encoding/json uses
+func AppendTime(dst []byte, t time.Time) []byte {
+	dst = append(dst, '"')
+	dst = t.AppendFormat(dst, time.RFC3339Nano)
+	return append(dst, '"')
+}
+
+// AppendInt64Ptr appends an optional integer, null when it is not set
+func AppendInt64Ptr(dst []byte, v *int64) []byte {
+	if v == nil {
+		return append(dst, "null"...)
+	}
+	return strconv.AppendInt(dst, *v, 10)
+}
+
+// AppendFloat appends a float the way encoding/json does, in exponent form below 1e-6 and from 1e21 on
+func AppendFloat(dst []byte, v float64) []byte {
+	abs := math.Abs(v)
+	if abs == 0 || (abs >= 1e-6 && abs < 1e21) {
+		return strconv.AppendFloat(dst, v, 'f', -1, 64)
+	}
+
+	dst = strconv.AppendFloat(dst, v, 'e', -1, 64)
+	// Clean up e-09 to e-9 like encoding/json
+	if n := len(dst); n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+		dst[n-2] = dst[n-1]
+		dst = dst[:n-1]
+	}
+	return dst
+}
+
+// AppendString appends a JSON string. Plain ASCII is copied as is, anything encoding/json would escape is
+// left to it, so the output matches including its HTML escaping.
+func AppendString(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 0x20 || c >= 0x80 || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			encoded, _ := json.Marshal(s)
+			return append(dst, encoded...)
+		}
+	}
+	dst = append(dst, '"')
+	dst = append(dst, s...)
+	return append(dst, '"')
+}
+
+// AppendArray appends a slice of self-encoding values, null for a nil slice like encoding/json
+func AppendArray[T JSONAppender](dst []byte, items []T) []byte {
+	if items == nil {
+		return append(dst, "null"...)
+	}
+	dst = append(dst, '[')
+	for i, item := range items {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = item.AppendJSON(dst)
+	}
+	return append(dst, ']')
+}
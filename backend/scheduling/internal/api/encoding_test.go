@@ -0,0 +1,67 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func assertMatchesMarshal(t *testing.T, v any, got []byte) {
+	t.Helper()
+	want, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal(%#v): %v", v, err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("encoding of %#v\n got: %s\nwant: %s", v, got, want)
+	}
+}
+
+func TestAppendFloatMatchesEncodingJson(t *testing.T) {
+	for _, v := range []float64{0, -0.0, 1, -1, 0.1, 12.5, 99.99, 1e-6, 1e-7, 1.5e-9, -2e-7, 1e20, 1e21, 1.2345e22, -1e21, 123456789.125} {
+		assertMatchesMarshal(t, v, AppendFloat(nil, v))
+	}
+}
+
+func TestAppendStringMatchesEncodingJson(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"eyJpZCI6NDJ9",
+		`quote " and backslash \`,
+		"<script>&</script>",
+		"tab\tnewline\ncontrol\x01",
+		"unicode é ✓ and    ",
+		"invalid \xff utf-8",
+	} {
+		assertMatchesMarshal(t, s, AppendString(nil, s))
+	}
+}
+
+func TestAppendTimeMatchesEncodingJson(t *testing.T) {
+	zone := time.FixedZone("UTC+5:30", 5*3600+30*60)
+	for _, v := range []time.Time{
+		{},
+		time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 14, 9, 0, 0, 123456789, time.UTC),
+		time.Date(2026, 10, 14, 9, 0, 0, 100, zone),
+		time.Date(2026, 3, 1, 23, 59, 59, 500000000, time.FixedZone("", -8*3600)),
+		time.Now(),
+	} {
+		assertMatchesMarshal(t, v, AppendTime(nil, v))
+	}
+}
+
+func TestAppendUUIDMatchesEncodingJson(t *testing.T) {
+	for _, id := range []uuid.UUID{uuid.Nil, uuid.New(), uuid.Max} {
+		assertMatchesMarshal(t, id, AppendUUID(nil, id))
+	}
+}
+
+func TestAppendInt64PtrMatchesEncodingJson(t *testing.T) {
+	v := int64(-42)
+	for _, p := range []*int64{nil, &v} {
+		assertMatchesMarshal(t, p, AppendInt64Ptr(nil, p))
+	}
+}
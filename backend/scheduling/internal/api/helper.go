@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/formatting"
 )
 
 func ParseUUIDParam(w http.ResponseWriter, r *http.Request, paramName string) (uuid.UUID, error) {
@@ -48,3 +51,143 @@ func ParseTimeQuery(w http.ResponseWriter, r *http.Request, queryName string, fo
 	}
 	return date, nil
 }
+
+func ParseIntQuery(w http.ResponseWriter, r *http.Request, queryName string, defaultValue int) (int, error) {
+	intStr := r.URL.Query().Get(queryName)
+	if intStr == "" {
+		return defaultValue, nil
+	}
+
+	val, err := strconv.Atoi(intStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid format for query parameter '%s', expected an integer, received: '%s'", queryName, intStr)
+	}
+	return val, nil
+}
+
+func ParseLongQuery(w http.ResponseWriter, r *http.Request, queryName string) (int64, error) {
+	intStr := r.URL.Query().Get(queryName)
+	if intStr == "" {
+		return 0, fmt.Errorf("missing or empty query parameter '%s', expected an integer", queryName)
+	}
+
+	val, err := strconv.ParseInt(intStr, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid format for query parameter '%s', expected an integer, received: '%s'", queryName, intStr)
+	}
+	return val, nil
+}
+
+func ParseUUIDQuery(w http.ResponseWriter, r *http.Request, queryName string) (uuid.UUID, error) {
+	idStr := r.URL.Query().Get(queryName)
+	if idStr == "" {
+		return uuid.Nil, fmt.Errorf("missing or empty query parameter '%s', expected a valid UUID", queryName)
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid format for query parameter '%s', expected a valid UUID, received: '%s'", queryName, idStr)
+	}
+	return id, nil
+}
+
+// ParseIfMatch parses the version a write applies to from the If-Match header, given as the version number
+// or its entity tag, such as 3 or "3"
+func ParseIfMatch(w http.ResponseWriter, r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" {
+		return 0, fmt.Errorf("missing If-Match header, expected the version of the resource")
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(value, "W/"), `"`), 10, 64)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid If-Match header, expected the version of the resource, received: '%s'", value)
+	}
+	return version, nil
+}
+
+// ParseTimezone resolves the time zone a caller wants times in, from the 'timezone' query parameter or a
+// 'Prefer: timezone=<IANA name>' header, defaulting to UTC. A zone taken from the header is acknowledged
+// with a Preference-Applied header.
+func ParseTimezone(w http.ResponseWriter, r *http.Request) (*time.Location, error) {
+	name := r.URL.Query().Get("timezone")
+	fromPrefer := false
+	if name == "" {
+		name = preferredTimezone(r.Header.Values("Prefer"))
+		fromPrefer = name != ""
+	}
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone '%s', expected an IANA time zone name", name)
+	}
+	if fromPrefer {
+		w.Header().Add("Preference-Applied", "timezone="+loc.String())
+	}
+	return loc, nil
+}
+
+// ParseTimeFormat resolves the clock a caller wants times of day written in, from the 'timeFormat' query
+// parameter or a 'Prefer: time-format=12h' header. It is empty when neither is given, leaving it to the
+// user's preferences. A format taken from the header is acknowledged with a Preference-Applied header.
+func ParseTimeFormat(w http.ResponseWriter, r *http.Request) (formatting.Clock, error) {
+	value := r.URL.Query().Get("timeFormat")
+	fromPrefer := false
+	if value == "" {
+		value = preferenceValue(r.Header.Values("Prefer"), "time-format")
+		fromPrefer = value != ""
+	}
+	if value == "" {
+		return "", nil
+	}
+
+	clock := formatting.Clock(strings.ToLower(value))
+	if !clock.Valid() {
+		return "", fmt.Errorf("invalid time format '%s', expected 12h or 24h", value)
+	}
+	if fromPrefer {
+		w.Header().Add("Preference-Applied", "time-format="+string(clock))
+	}
+	return clock, nil
+}
+
+// preferredTimezone extracts the timezone preference from Prefer header values
+func preferredTimezone(values []string) string {
+	return preferenceValue(values, "timezone")
+}
+
+// preferenceValue extracts the value of a preference from Prefer header values
+func preferenceValue(values []string, name string) string {
+	for _, value := range values {
+		for _, preference := range strings.Split(value, ",") {
+			key, val, ok := strings.Cut(strings.TrimSpace(preference), "=")
+			if ok && strings.EqualFold(strings.TrimSpace(key), name) {
+				val, _, _ = strings.Cut(val, ";")
+				return strings.Trim(strings.TrimSpace(val), `"`)
+			}
+		}
+	}
+	return ""
+}
+
+// ParseLocalTimeQuery parses a timestamp query parameter in RFC 3339 format, or as a wall clock time
+// without offset (YYYY-MM-DDTHH:MM[:SS]) or a date (YYYY-MM-DD) interpreted in the given time zone
+func ParseLocalTimeQuery(w http.ResponseWriter, r *http.Request, queryName string, loc *time.Location) (time.Time, error) {
+	dateStr := r.URL.Query().Get(queryName)
+	if dateStr == "" {
+		return time.Time{}, fmt.Errorf("missing or empty query parameter '%s', expected a timestamp in format '%s'", queryName, time.RFC3339)
+	}
+
+	if date, err := time.Parse(time.RFC3339, dateStr); err == nil {
+		return date, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", time.DateOnly} {
+		if date, err := time.ParseInLocation(layout, dateStr, loc); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid format for query parameter '%s', expected a timestamp in format '%s', received: '%s'", queryName, time.RFC3339, dateStr)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
 )
@@ -31,6 +32,13 @@ func WriteError(w http.ResponseWriter, err error) {
 	case *apperrors.UnprocessedEntityError:
 		status = http.StatusUnprocessableEntity
 		payload = e
+	case *apperrors.TooManyRequestsError:
+		status = http.StatusTooManyRequests
+		payload = e
+		w.Header().Set("Retry-After", strconv.Itoa(e.RetryAfterSeconds))
+	case *apperrors.ServiceUnavailableError:
+		status = http.StatusServiceUnavailable
+		payload = e
 	case *apperrors.ValidationError:
 		status = http.StatusUnprocessableEntity
 		payload = e
@@ -56,11 +64,35 @@ func WriteError(w http.ResponseWriter, err error) {
 	}
 }
 
-// WriteJson writes a JSON response with the given status code and data
+// WriteJson writes a JSON response with the given status code and data. The payload is encoded into a
+// pooled buffer and written at once with its length, payloads implementing JSONAppender skip reflection.
 func WriteJson(w http.ResponseWriter, status int, payload any) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if appender, ok := payload.(JSONAppender); ok {
+		b := appender.AppendJSON(buf.AvailableBuffer())
+		buf.Write(append(b, '\n'))
+	} else if err := json.NewEncoder(buf).Encode(payload); err != nil {
+		WriteError(w, err)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
 	w.WriteHeader(status)
-	err := json.NewEncoder(w).Encode(payload)
+	_, err := w.Write(buf.Bytes())
+	if err != nil {
+		return
+	}
+}
+
+// WriteFile writes a binary response served inline with the given content type and file name
+func WriteFile(w http.ResponseWriter, contentType string, fileName string, data []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `inline; filename="`+fileName+`"`)
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write(data)
 	if err != nil {
 		return
 	}
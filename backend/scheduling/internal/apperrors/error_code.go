@@ -17,4 +17,45 @@ const (
 	ErrWorkingPeriodHours       = "ERROR_WORKING_PERIOD_HOURS"
 	ErrWorkingPeriodHasEvent    = "ERROR_WORKING_PERIOD_HAS_EVENT"
 	ErrProductNotSchedulable    = "ERROR_PRODUCT_NOT_SCHEDULABLE"
+	ErrPayoutPeriod             = "ERROR_PAYOUT_PERIOD"
+	ErrReportPeriod             = "ERROR_REPORT_PERIOD"
+	ErrAttendanceNotAllowed     = "ERROR_ATTENDANCE_NOT_ALLOWED"
+	ErrSessionNoteNotAllowed    = "ERROR_SESSION_NOTE_NOT_ALLOWED"
+	ErrCheckInCodeInvalid       = "ERROR_CHECK_IN_CODE_INVALID"
+	ErrCheckInAlreadyUsed       = "ERROR_CHECK_IN_ALREADY_USED"
+	ErrSessionTypeInvalid       = "ERROR_SESSION_TYPE_INVALID"
+	ErrLocationInvalid          = "ERROR_LOCATION_INVALID"
+	ErrLocationTravelBuffer     = "ERROR_LOCATION_TRAVEL_BUFFER"
+	ErrShareLinkInvalid         = "ERROR_SHARE_LINK_INVALID"
+	ErrRateLimited              = "ERROR_RATE_LIMITED"
+	ErrWidgetOriginNotAllowed   = "ERROR_WIDGET_ORIGIN_NOT_ALLOWED"
+	ErrOrganizationMember       = "ERROR_ORGANIZATION_MEMBER"
+	ErrGrantInvalid             = "ERROR_GRANT_INVALID"
+	ErrRebookUnavailable        = "ERROR_REBOOK_UNAVAILABLE"
+	ErrBulkCancellationStale    = "ERROR_BULK_CANCELLATION_STALE"
+	ErrThreadClosed             = "ERROR_THREAD_CLOSED"
+	ErrExtensionUnavailable     = "ERROR_EXTENSION_UNAVAILABLE"
+	ErrAvailabilityConflict     = "ERROR_AVAILABILITY_CONFLICT"
+	ErrEducatorOffboarding      = "ERROR_EDUCATOR_OFFBOARDING"
+	ErrBrokerStatsDisabled      = "ERROR_BROKER_STATS_DISABLED"
+	ErrBookingHoldExpired       = "ERROR_BOOKING_HOLD_EXPIRED"
+	ErrCalendarFeedInvalid      = "ERROR_CALENDAR_FEED_INVALID"
+	ErrDependencyUnavailable    = "ERROR_DEPENDENCY_UNAVAILABLE"
+	ErrWaitlistUnavailable      = "ERROR_WAITLIST_UNAVAILABLE"
+	ErrCancellationNotAllowed   = "ERROR_CANCELLATION_NOT_ALLOWED"
+	ErrScheduledEventFull       = "ERROR_SCHEDULED_EVENT_FULL"
+	ErrScheduledEventCapacity   = "ERROR_SCHEDULED_EVENT_CAPACITY"
+	ErrIdempotencyKeyInvalid    = "ERROR_IDEMPOTENCY_KEY_INVALID"
+	ErrIdempotencyKeyReused     = "ERROR_IDEMPOTENCY_KEY_REUSED"
+	ErrIdempotencyKeyInProgress = "ERROR_IDEMPOTENCY_KEY_IN_PROGRESS"
+	ErrBookingLinkInvalid       = "ERROR_BOOKING_LINK_INVALID"
+	ErrBookingLinkUsed          = "ERROR_BOOKING_LINK_USED"
+	ErrTracingDisabled          = "ERROR_TRACING_DISABLED"
+	ErrWebhookLimitReached      = "ERROR_WEBHOOK_LIMIT_REACHED"
+	ErrVersionRequired          = "ERROR_VERSION_REQUIRED"
+	ErrVersionConflict          = "ERROR_VERSION_CONFLICT"
+	ErrBookingsFrozen           = "ERROR_BOOKINGS_FROZEN"
+	ErrLogModuleUnknown         = "ERROR_LOG_MODULE_UNKNOWN"
+	ErrBookingTransition        = "ERROR_BOOKING_TRANSITION"
+	ErrSlotGranularity          = "ERROR_SLOT_GRANULARITY"
 )
@@ -83,6 +83,25 @@ func NewUnprocessedEntity(msg, code string, err ...error) *UnprocessedEntityErro
 	return &UnprocessedEntityError{baseError: wrapError(msg, code, err...)}
 }
 
+// --- TooManyRequestsError ---
+type TooManyRequestsError struct {
+	baseError
+	RetryAfterSeconds int `json:"retryAfterSeconds"`
+}
+
+func NewTooManyRequests(msg string, retryAfterSeconds int, err ...error) *TooManyRequestsError {
+	return &TooManyRequestsError{baseError: wrapError(msg, ErrRateLimited, err...), RetryAfterSeconds: retryAfterSeconds}
+}
+
+// --- ServiceUnavailableError ---
+type ServiceUnavailableError struct {
+	baseError
+}
+
+func NewServiceUnavailable(msg string, err ...error) *ServiceUnavailableError {
+	return &ServiceUnavailableError{baseError: wrapError(msg, ErrDependencyUnavailable, err...)}
+}
+
 // --- ValidationError ---
 type ValidationErrorDetail struct {
 	Field   string `json:"field"`
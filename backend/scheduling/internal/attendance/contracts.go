@@ -0,0 +1,95 @@
+package attendance
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// swagger:model AttendanceRequest
+type AttendanceRequest struct {
+	Outcome int     `json:"outcome"`
+	Note    *string `json:"note"`
+}
+
+// swagger:model CheckInRequest
+type CheckInRequest struct {
+	Code string `json:"code"`
+}
+
+// swagger:model CheckInResponse
+type CheckInResponse struct {
+	BookingId   int64     `json:"bookingId"`
+	StudentId   uuid.UUID `json:"studentId"`
+	Title       string    `json:"title"`
+	StartTime   time.Time `json:"startTime"`
+	EndTime     time.Time `json:"endTime"`
+	CheckedInAt time.Time `json:"checkedInAt"`
+}
+
+// swagger:model AttendanceReportResponse
+type AttendanceReportResponse struct {
+	EducatorId     uuid.UUID                    `json:"educatorId"`
+	StudentId      uuid.UUID                    `json:"studentId"`
+	TotalSessions  int                          `json:"totalSessions"`
+	Attended       int                          `json:"attended"`
+	Missed         int                          `json:"missed"`
+	Rescheduled    int                          `json:"rescheduled"`
+	Unrecorded     int                          `json:"unrecorded"`
+	AttendanceRate float64                      `json:"attendanceRate"`
+	Sessions       []*AttendanceSessionResponse `json:"sessions"`
+}
+
+// swagger:model AttendanceSessionResponse
+type AttendanceSessionResponse struct {
+	BookingId int64     `json:"bookingId"`
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Outcome   *int      `json:"outcome"`
+	Note      *string   `json:"note"`
+}
+
+func (a *AttendanceRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if a.Outcome < int(entities.Attended) || a.Outcome > int(entities.Rescheduled) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Outcome",
+			Message: "must be 0 (attended), 1 (missed) or 2 (rescheduled)",
+		})
+	}
+
+	if a.Note != nil && len(*a.Note) > 1000 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Note",
+			Message: "must not exceed 1000 characters",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Attendance request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
+
+func (c *CheckInRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if c.Code == "" {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Code",
+			Message: "must not be empty",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Check-in request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package attendance
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type AttendanceHandler struct {
+	service *AttendanceService
+}
+
+func NewAttendanceHandler(service *AttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{service: service}
+}
+
+// RecordAttendance records the attendance outcome of a booking.
+// @Summary      Record attendance
+// @Description  Sets whether the student attended, missed or rescheduled a started approved booking of the educator.
+// @Tags         Attendance
+// @Accept       json
+// @Produce      json
+// @Param        bookingId   path      int                true  "Booking ID"
+// @Param        attendance  body      AttendanceRequest  true  "Attendance outcome"
+// @Success      204         "Attendance recorded successfully"
+// @Failure      400         {object}  error              "Invalid input"
+// @Failure      404         {object}  error              "Booking not found"
+// @Router       /api/v1/attendance/bookings/{bookingId} [put]
+// @Security 	 BearerAuth
+func (h *AttendanceHandler) RecordAttendance(w http.ResponseWriter, r *http.Request) {
+	bookingId, err := api.ParseLongParam(w, r, "bookingId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *AttendanceRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.RecordAttendance(r.Context(), bookingId, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckIn checks in a booking by its scanned QR code.
+// @Summary      Check in booking
+// @Description  Verifies the signed check-in code of an approved booking of the educator and marks the student as attended. Each code can be used once, from shortly before the session starts until it ends.
+// @Tags         Attendance
+// @Accept       json
+// @Produce      json
+// @Param        checkIn  body      CheckInRequest   true  "Scanned check-in code"
+// @Success      200      {object}  CheckInResponse  "Checked in booking"
+// @Failure      400      {object}  error            "Invalid check-in code"
+// @Failure      404      {object}  error            "Booking not found"
+// @Failure      409      {object}  error            "Booking already checked in"
+// @Router       /api/v1/attendance/check-in [post]
+// @Security 	 BearerAuth
+func (h *AttendanceHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	var request *CheckInRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	response, err := h.service.CheckIn(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, response)
+}
+
+// GetAttendanceReport retrieves the attendance report of a student with an educator.
+// @Summary      Retrieve attendance report
+// @Description  Counts attended, missed, rescheduled and unrecorded sessions between the educator and the student. Available to both parties and admins.
+// @Tags         Attendance
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  query     string                    true  "Educator ID (UUID)"
+// @Param        studentId   query     string                    true  "Student ID (UUID)"
+// @Success      200         {object}  AttendanceReportResponse  "Attendance report"
+// @Failure      400         {object}  error                     "Invalid input parameters"
+// @Router       /api/v1/attendance/report [get]
+// @Security 	 BearerAuth
+func (h *AttendanceHandler) GetAttendanceReport(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDQuery(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	studentId, err := api.ParseUUIDQuery(w, r, "studentId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	report, err := h.service.GetAttendanceReport(r.Context(), educatorId, studentId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, report)
+}
@@ -0,0 +1,94 @@
+package attendance
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToAttendance(b *entities.Booking, r *AttendanceRequest) *entities.Attendance {
+	return &entities.Attendance{
+		BookingId:  b.Id,
+		EducatorId: b.EducatorId,
+		StudentId:  b.StudentId,
+		Outcome:    entities.AttendanceOutcome(r.Outcome),
+		Note:       r.Note,
+		RecordedAt: time.Now().UTC(),
+		UpdatedAt:  time.Now().UTC(),
+	}
+}
+
+func MapBookingToCheckIn(b *entities.Booking, checkedInAt time.Time) *entities.Attendance {
+	return &entities.Attendance{
+		BookingId:   b.Id,
+		EducatorId:  b.EducatorId,
+		StudentId:   b.StudentId,
+		Outcome:     entities.Attended,
+		CheckedInAt: &checkedInAt,
+		RecordedAt:  checkedInAt,
+		UpdatedAt:   checkedInAt,
+	}
+}
+
+func MapBookingToCheckInResponse(b *entities.Booking, checkedInAt time.Time) *CheckInResponse {
+	return &CheckInResponse{
+		BookingId:   b.Id,
+		StudentId:   b.StudentId,
+		Title:       b.Title,
+		StartTime:   b.StartTime,
+		EndTime:     b.EndTime,
+		CheckedInAt: checkedInAt,
+	}
+}
+
+func MapSessionToResponse(s *SessionAttendance) *AttendanceSessionResponse {
+	var outcome *int
+	if s.Outcome != nil {
+		value := int(*s.Outcome)
+		outcome = &value
+	}
+
+	return &AttendanceSessionResponse{
+		BookingId: s.BookingId,
+		Title:     s.Title,
+		StartTime: s.StartTime,
+		EndTime:   s.EndTime,
+		Outcome:   outcome,
+		Note:      s.Note,
+	}
+}
+
+// MapSessionsToReport counts outcomes of the sessions; the attendance rate covers recorded sessions only
+func MapSessionsToReport(educatorId, studentId uuid.UUID, sessions []*SessionAttendance) *AttendanceReportResponse {
+	report := &AttendanceReportResponse{
+		EducatorId:    educatorId,
+		StudentId:     studentId,
+		TotalSessions: len(sessions),
+		Sessions:      make([]*AttendanceSessionResponse, len(sessions)),
+	}
+
+	for i, s := range sessions {
+		report.Sessions[i] = MapSessionToResponse(s)
+
+		if s.Outcome == nil {
+			report.Unrecorded++
+			continue
+		}
+		switch *s.Outcome {
+		case entities.Attended:
+			report.Attended++
+		case entities.Missed:
+			report.Missed++
+		case entities.Rescheduled:
+			report.Rescheduled++
+		}
+	}
+
+	if held := report.Attended + report.Missed; held > 0 {
+		report.AttendanceRate = math.Round(float64(report.Attended)/float64(held)*10000) / 100
+	}
+	return report
+}
@@ -0,0 +1,29 @@
+package attendance
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/checkin"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializeAttendanceService(
+	log logger.Logger,
+	db *sqlx.DB,
+	cfg *config.CheckInConfig,
+	publisher *messaging.Publisher,
+	codes *checkin.Signer,
+) *AttendanceService {
+	repo := NewAttendanceRepository(db)
+	service := NewAttendanceService(log, repo, cfg, publisher, codes)
+	return service
+}
+
+func InitializeAttendanceHTTPHandler(service *AttendanceService) http.Handler {
+	handler := NewAttendanceHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,86 @@
+package attendance
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// SessionAttendance holds a past approved booking together with its recorded outcome, if any
+type SessionAttendance struct {
+	BookingId int64                       `db:"booking_id"`
+	Title     string                      `db:"title"`
+	StartTime time.Time                   `db:"start_time"`
+	EndTime   time.Time                   `db:"end_time"`
+	Outcome   *entities.AttendanceOutcome `db:"outcome"`
+	Note      *string                     `db:"note"`
+}
+
+type AttendanceRepo struct {
+	db *sqlx.DB
+}
+
+func NewAttendanceRepository(db *sqlx.DB) *AttendanceRepo {
+	return &AttendanceRepo{db: db}
+}
+
+// GetEducatorBookingById retrieves a single booking by its Id and EducatorId
+func (r *AttendanceRepo) GetEducatorBookingById(ctx context.Context, educatorId uuid.UUID, id int64) (*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at
+		FROM booking
+		WHERE id = $1 AND educator_id = $2
+	`
+	return database.FetchSingle[entities.Booking](ctx, r.db, query, id, educatorId)
+}
+
+// GetSessionAttendance retrieves started approved bookings between an educator and a student with their outcomes
+func (r *AttendanceRepo) GetSessionAttendance(ctx context.Context, educatorId, studentId uuid.UUID, before time.Time) ([]*SessionAttendance, error) {
+	const query = `
+		SELECT b.id AS booking_id, COALESCE(b.title, '') AS title, b.start_time, b.end_time, a.outcome, a.note
+		FROM booking b
+		LEFT JOIN attendance a ON a.booking_id = b.id
+		WHERE b.educator_id = $1 AND b.student_id = $2 AND b.status = ANY($3) AND b.start_time <= $4
+		ORDER BY b.start_time DESC
+	`
+	return database.FetchMultiple[SessionAttendance](ctx, r.db, query, educatorId, studentId, entities.ConfirmedStatuses, before)
+}
+
+// UpsertAttendance records or replaces the attendance outcome of a booking
+func (r *AttendanceRepo) UpsertAttendance(ctx context.Context, attendance *entities.Attendance) error {
+	const query = `
+		INSERT INTO attendance (booking_id, educator_id, student_id, outcome, note, recorded_at, updated_at)
+		VALUES (:booking_id, :educator_id, :student_id, :outcome, :note, :recorded_at, :updated_at)
+		ON CONFLICT (booking_id) DO UPDATE
+		SET outcome = EXCLUDED.outcome, note = EXCLUDED.note, updated_at = EXCLUDED.updated_at
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, attendance)
+}
+
+// CheckInAttendance marks a booking as attended through its check-in code. It returns false when
+// the booking has already been checked in, so a code cannot be used twice.
+func (r *AttendanceRepo) CheckInAttendance(ctx context.Context, attendance *entities.Attendance) (bool, error) {
+	const query = `
+		INSERT INTO attendance (booking_id, educator_id, student_id, outcome, note, checked_in_at, recorded_at, updated_at)
+		VALUES (:booking_id, :educator_id, :student_id, :outcome, :note, :checked_in_at, :recorded_at, :updated_at)
+		ON CONFLICT (booking_id) DO UPDATE
+		SET outcome = EXCLUDED.outcome, checked_in_at = EXCLUDED.checked_in_at, updated_at = EXCLUDED.updated_at
+		WHERE attendance.checked_in_at IS NULL
+	`
+	result, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, attendance)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
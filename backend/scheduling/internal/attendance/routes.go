@@ -0,0 +1,21 @@
+package attendance
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *AttendanceHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/report", handler.GetAttendanceReport)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Put("/bookings/{bookingId}", handler.RecordAttendance)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Post("/check-in", handler.CheckIn)
+
+	return r
+}
@@ -0,0 +1,161 @@
+package attendance
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/checkin"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+type AttendanceRepository interface {
+	GetEducatorBookingById(ctx context.Context, educatorId uuid.UUID, id int64) (*entities.Booking, error)
+	GetSessionAttendance(ctx context.Context, educatorId, studentId uuid.UUID, before time.Time) ([]*SessionAttendance, error)
+	UpsertAttendance(ctx context.Context, attendance *entities.Attendance) error
+	CheckInAttendance(ctx context.Context, attendance *entities.Attendance) (bool, error)
+}
+
+type AttendanceService struct {
+	log       logger.Logger
+	repo      AttendanceRepository
+	cfg       *config.CheckInConfig
+	publisher *messaging.Publisher
+	codes     *checkin.Signer
+}
+
+func NewAttendanceService(
+	log logger.Logger,
+	repo AttendanceRepository,
+	cfg *config.CheckInConfig,
+	publisher *messaging.Publisher,
+	codes *checkin.Signer,
+) *AttendanceService {
+	return &AttendanceService{log: log, repo: repo, cfg: cfg, publisher: publisher, codes: codes}
+}
+
+// RecordAttendance stores the outcome of a started approved booking and publishes it for the learning service
+func (s *AttendanceService) RecordAttendance(ctx context.Context, bookingId int64, request *AttendanceRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	booking, err := s.repo.GetEducatorBookingById(ctx, userId, bookingId)
+	if err != nil {
+		log.Error("failed to get booking", err)
+		return err
+	}
+
+	if !booking.Status.IsConfirmed() {
+		return apperrors.NewUnprocessedEntity("Attendance can be recorded for approved bookings only", apperrors.ErrAttendanceNotAllowed)
+	}
+
+	if booking.StartTime.After(time.Now().UTC()) {
+		return apperrors.NewUnprocessedEntity("Attendance cannot be recorded before the session starts", apperrors.ErrAttendanceNotAllowed)
+	}
+
+	attendance := MapRequestToAttendance(booking, request)
+	if err := s.repo.UpsertAttendance(ctx, attendance); err != nil {
+		log.Error("failed to save attendance", err)
+		return err
+	}
+
+	s.publishAttendance(ctx, booking, attendance.Outcome)
+	return nil
+}
+
+// CheckIn verifies a scanned check-in code of the educator's approved booking and marks the student
+// as attended. Codes are accepted from shortly before the session starts until it ends, once.
+func (s *AttendanceService) CheckIn(ctx context.Context, request *CheckInRequest) (*CheckInResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	bookingId, err := s.codes.Verify(request.Code)
+	if err != nil {
+		log.Error("failed to verify check-in code", err)
+		return nil, err
+	}
+
+	booking, err := s.repo.GetEducatorBookingById(ctx, userId, bookingId)
+	if err != nil {
+		log.Error("failed to get booking", err)
+		return nil, err
+	}
+
+	if booking.Status != entities.Approved {
+		return nil, apperrors.NewUnprocessedEntity("Only approved bookings can be checked in", apperrors.ErrAttendanceNotAllowed)
+	}
+
+	now := time.Now().UTC()
+	opensAt := booking.StartTime.Add(-time.Duration(s.cfg.EarlyMinutes) * time.Minute)
+	if now.Before(opensAt) || !now.Before(booking.EndTime) {
+		return nil, apperrors.NewUnprocessedEntity("Check-in is only possible around the session time", apperrors.ErrAttendanceNotAllowed)
+	}
+
+	checkedIn, err := s.repo.CheckInAttendance(ctx, MapBookingToCheckIn(booking, now))
+	if err != nil {
+		log.Error("failed to check in attendance", err)
+		return nil, err
+	}
+
+	if !checkedIn {
+		return nil, apperrors.NewConflict("Booking has already been checked in", apperrors.ErrCheckInAlreadyUsed)
+	}
+
+	s.publishAttendance(ctx, booking, entities.Attended)
+	return MapBookingToCheckInResponse(booking, now), nil
+}
+
+// GetAttendanceReport summarizes attendance of a student with an educator; available to both parties and admins
+func (s *AttendanceService) GetAttendanceReport(ctx context.Context, educatorId, studentId uuid.UUID) (*AttendanceReportResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if userId != educatorId && userId != studentId && !auth.HasRole(ctx, auth.AdminRole) {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	sessions, err := s.repo.GetSessionAttendance(ctx, educatorId, studentId, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to get session attendance", err)
+		return nil, err
+	}
+
+	return MapSessionsToReport(educatorId, studentId, sessions), nil
+}
+
+func (s *AttendanceService) publishAttendance(ctx context.Context, booking *entities.Booking, outcome entities.AttendanceOutcome) {
+	err := s.publisher.Publish(
+		ctx,
+		messaging.AttendanceKey,
+		messaging.NewAttendanceRecordedEvent(
+			booking.Id,
+			booking.EducatorId.String(),
+			booking.StudentId.String(),
+			booking.EnrollmentId,
+			booking.ProductId,
+			outcome.String(),
+			booking.StartTime.Format(time.RFC3339),
+		),
+	)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("failed to publish attendance event", err)
+	}
+}
@@ -0,0 +1,170 @@
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+const (
+	chainPrefix           = "ora:event-audit:v1:"
+	changeChainPrefix     = "ora:change-audit:v1:"
+	transitionChainPrefix = "ora:booking-status:v1:"
+	headPrefix            = "ora:audit-chain-head:v1:"
+)
+
+// Chain signs audit trail entries with HMAC-SHA256 over their fields and the signature of the entry before
+// them on their chain. Altering an entry breaks its signature, deleting or reordering entries breaks the link
+// of the next one, and deleting the last entries no longer matches the signed head of the chain.
+type Chain struct {
+	key []byte
+}
+
+func NewChain(key string) *Chain {
+	return &Chain{key: []byte(key)}
+}
+
+// Sign returns the signature of a message recorded after the entry signed with previous, empty for the first
+func (c *Chain) Sign(previous string, a *entities.EventAudit) string {
+	return c.sign(chainPrefix+previous, canonicalEntry(a))
+}
+
+// SignChange returns the signature of a change sealed after the change signed with previous
+func (c *Chain) SignChange(previous string, a *entities.ChangeAudit) string {
+	return c.sign(changeChainPrefix+previous, canonicalChange(a))
+}
+
+// SignTransition returns the signature of a status transition sealed after the one signed with previous
+func (c *Chain) SignTransition(previous string, t *entities.BookingStatusTransition) string {
+	return c.sign(transitionChainPrefix+previous, canonicalTransition(t))
+}
+
+// SignHead returns the signature of a chain head over its chain, position and latest signature
+func (c *Chain) SignHead(h *entities.AuditChainHead) string {
+	return c.sign(headPrefix, strings.Join([]string{h.ChainType, h.ChainKey, strconv.FormatInt(h.Sequence, 10), h.Signature}, "\n"))
+}
+
+// Valid reports whether a message still carries the signature it was recorded with
+func (c *Chain) Valid(a *entities.EventAudit) bool {
+	return c.valid(a.Signature, c.Sign(stringValue(a.PreviousSignature), a))
+}
+
+// ValidChange reports whether a change still carries the signature it was sealed with
+func (c *Chain) ValidChange(a *entities.ChangeAudit) bool {
+	return c.valid(a.Signature, c.SignChange(stringValue(a.PreviousSignature), a))
+}
+
+// ValidTransition reports whether a status transition still carries the signature it was sealed with
+func (c *Chain) ValidTransition(t *entities.BookingStatusTransition) bool {
+	return c.valid(t.Signature, c.SignTransition(stringValue(t.PreviousSignature), t))
+}
+
+// ValidHead reports whether a chain head was left as it was signed
+func (c *Chain) ValidHead(h *entities.AuditChainHead) bool {
+	return hmac.Equal([]byte(h.HeadSignature), []byte(c.SignHead(h)))
+}
+
+func (c *Chain) sign(prefix, canonical string) string {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(prefix + "\n" + canonical))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *Chain) valid(signature *string, expected string) bool {
+	if signature == nil {
+		return false
+	}
+	return hmac.Equal([]byte(*signature), []byte(expected))
+}
+
+// canonicalEntry lists the signed fields in a fixed order. Timestamps are cut to the microseconds Postgres
+// keeps, so an entry read back signs the same as when it was written. The chain sequence is signed only for
+// entries that have one, so entries of the former single chain keep their signatures.
+func canonicalEntry(a *entities.EventAudit) string {
+	fields := []string{
+		a.EventId,
+		a.EventType,
+		a.RoutingKey,
+		a.Direction,
+		a.CorrelationId,
+		stringValue(a.CausationId),
+		stringValue(a.ActorType),
+		stringValue(a.ActorId),
+		canonicalTime(a.OccurredAt),
+		canonicalTime(a.RecordedAt),
+	}
+	if a.ChainSequence != nil {
+		fields = append(fields, strconv.FormatInt(*a.ChainSequence, 10))
+	}
+	return strings.Join(fields, "\n")
+}
+
+// canonicalChange lists the signed fields of a change, the values as the jsonb text Postgres returns
+func canonicalChange(a *entities.ChangeAudit) string {
+	return strings.Join([]string{
+		strconv.FormatInt(a.Id, 10),
+		a.EntityType,
+		strconv.FormatInt(a.EntityId, 10),
+		a.Action,
+		uuidValue(a.EducatorId),
+		uuidValue(a.StudentId),
+		uuidValue(a.ActorId),
+		string(a.OldValues),
+		string(a.NewValues),
+		canonicalTime(a.ChangedAt),
+		int64Value(a.ChainSequence),
+	}, "\n")
+}
+
+func canonicalTransition(t *entities.BookingStatusTransition) string {
+	from := ""
+	if t.FromStatus != nil {
+		from = strconv.Itoa(int(*t.FromStatus))
+	}
+	return strings.Join([]string{
+		strconv.FormatInt(t.Id, 10),
+		strconv.FormatInt(t.BookingId, 10),
+		from,
+		strconv.Itoa(int(t.ToStatus)),
+		uuidValue(t.ActorId),
+		canonicalTime(t.ChangedAt),
+		int64Value(t.ChainSequence),
+	}, "\n")
+}
+
+// changeChainKey is the key of the chain the changes of an entity are sealed on
+func changeChainKey(entityType string, entityId int64) string {
+	return entityType + ":" + strconv.FormatInt(entityId, 10)
+}
+
+func canonicalTime(t time.Time) string {
+	return t.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func uuidValue(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func int64Value(i *int64) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.FormatInt(*i, 10)
+}
@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func TestSignKeepsSingleChainSignatures(t *testing.T) {
+	chain := NewChain("test-signing-key")
+	entry := newTestEvent("booking-1", 0)
+
+	// Entries without a chain sequence were signed before the trail was chained per correlation
+	mac := hmac.New(sha256.New, []byte("test-signing-key"))
+	mac.Write([]byte(chainPrefix + "previous\n" + canonicalEntry(entry)))
+	if got, want := chain.Sign("previous", entry), hex.EncodeToString(mac.Sum(nil)); got != want {
+		t.Fatalf("Sign() = %s, want the signature of the single chain %s", got, want)
+	}
+
+	sequence := int64(1)
+	entry.ChainSequence = &sequence
+	if chain.Sign("previous", entry) == hex.EncodeToString(mac.Sum(nil)) {
+		t.Fatal("the chain sequence is not signed")
+	}
+}
+
+// signedEntries are a change, a status transition and a chain head as sealed
+type signedEntries struct {
+	change     *entities.ChangeAudit
+	transition *entities.BookingStatusTransition
+	head       *entities.AuditChainHead
+}
+
+func newSignedEntries(chain *Chain) *signedEntries {
+	sequence := int64(2)
+	previous := "previous"
+	changedAt := time.Date(2026, 10, 14, 9, 0, 0, 123456000, time.UTC)
+	educatorId := uuid.New()
+	from := entities.Pending
+
+	change := &entities.ChangeAudit{
+		Id:                7,
+		EntityType:        entities.ChangeAuditBooking,
+		EntityId:          42,
+		Action:            "UPDATE",
+		EducatorId:        &educatorId,
+		OldValues:         json.RawMessage(`{"status": 0}`),
+		NewValues:         json.RawMessage(`{"status": 1}`),
+		ChangedAt:         changedAt,
+		ChainSequence:     &sequence,
+		PreviousSignature: &previous,
+	}
+	changeSignature := chain.SignChange(previous, change)
+	change.Signature = &changeSignature
+
+	transition := &entities.BookingStatusTransition{
+		Id:                8,
+		BookingId:         42,
+		FromStatus:        &from,
+		ToStatus:          entities.Approved,
+		ChangedAt:         changedAt,
+		ChainSequence:     &sequence,
+		PreviousSignature: &previous,
+	}
+	transitionSignature := chain.SignTransition(previous, transition)
+	transition.Signature = &transitionSignature
+
+	head := &entities.AuditChainHead{
+		ChainType: entities.AuditChainChange,
+		ChainKey:  changeChainKey(change.EntityType, change.EntityId),
+		Sequence:  sequence,
+		Signature: changeSignature,
+	}
+	head.HeadSignature = chain.SignHead(head)
+
+	return &signedEntries{change: change, transition: transition, head: head}
+}
+
+func TestValidDetectsTamperedEntries(t *testing.T) {
+	chain := NewChain("test-signing-key")
+	validChange := func(e *signedEntries) bool { return chain.ValidChange(e.change) }
+	validTransition := func(e *signedEntries) bool { return chain.ValidTransition(e.transition) }
+	validHead := func(e *signedEntries) bool { return chain.ValidHead(e.head) }
+
+	tests := []struct {
+		name   string
+		valid  func(e *signedEntries) bool
+		tamper func(e *signedEntries)
+	}{
+		{"change values", validChange, func(e *signedEntries) { e.change.NewValues = json.RawMessage(`{"status": 2}`) }},
+		{"change actor", validChange, func(e *signedEntries) { actorId := uuid.New(); e.change.ActorId = &actorId }},
+		{"change time", validChange, func(e *signedEntries) { e.change.ChangedAt = e.change.ChangedAt.Add(time.Microsecond) }},
+		{"change sequence", validChange, func(e *signedEntries) { other := int64(3); e.change.ChainSequence = &other }},
+		{"change link", validChange, func(e *signedEntries) { other := "other"; e.change.PreviousSignature = &other }},
+		{"transition status", validTransition, func(e *signedEntries) { e.transition.ToStatus = entities.Cancelled }},
+		{"transition booking", validTransition, func(e *signedEntries) { e.transition.BookingId = 43 }},
+		{"transition first status", validTransition, func(e *signedEntries) { e.transition.FromStatus = nil }},
+		{"head sequence", validHead, func(e *signedEntries) { e.head.Sequence = 1 }},
+		{"head signature", validHead, func(e *signedEntries) { e.head.Signature = *e.transition.Signature }},
+		{"head chain", validHead, func(e *signedEntries) { e.head.ChainKey = changeChainKey(entities.ChangeAuditBooking, 43) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := newSignedEntries(chain)
+			if !tt.valid(entries) {
+				t.Fatal("untouched entry is not valid")
+			}
+			tt.tamper(entries)
+			if tt.valid(entries) {
+				t.Fatal("tampered entry is still valid")
+			}
+		})
+	}
+}
+
+func TestValidRejectsOtherKeys(t *testing.T) {
+	entries := newSignedEntries(NewChain("test-signing-key"))
+	other := NewChain("other-key")
+	if other.ValidChange(entries.change) || other.ValidTransition(entries.transition) || other.ValidHead(entries.head) {
+		t.Fatal("entry signed with another key is valid")
+	}
+}
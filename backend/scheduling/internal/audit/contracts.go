@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChangeAuditQuery holds the filters and page of a change audit listing as given in the query string
+type ChangeAuditQuery struct {
+	EntityType *string
+	EntityId   *int64
+	UserId     *uuid.UUID
+	ActorId    *uuid.UUID
+	From       *time.Time
+	To         *time.Time
+	Cursor     string
+	Limit      int
+}
+
+// swagger:model CorrelationTrailResponse
+type CorrelationTrailResponse struct {
+	CorrelationId string                `json:"correlationId"`
+	Events        []*EventAuditResponse `json:"events"`
+}
+
+// swagger:model EventAuditResponse
+type EventAuditResponse struct {
+	EventId     string    `json:"eventId"`
+	EventType   string    `json:"eventType"`
+	RoutingKey  string    `json:"routingKey"`
+	Direction   string    `json:"direction"`
+	CausationId *string   `json:"causationId"`
+	ActorType   *string   `json:"actorType"`
+	ActorId     *string   `json:"actorId"`
+	OccurredAt  time.Time `json:"occurredAt"`
+}
+
+// swagger:model ChainVerificationResponse
+type ChainVerificationResponse struct {
+	Valid    bool  `json:"valid"`
+	Checked  int64 `json:"checked"`
+	Unsigned int64 `json:"unsigned"`
+	// Pending counts the changes and status transitions not sealed on their chain yet
+	Pending    int64  `json:"pending"`
+	BrokenAtId *int64 `json:"brokenAtId"`
+	// Chain is the type and key of the broken chain, null for messages recorded before chaining per correlation
+	Chain   *string `json:"chain"`
+	Problem *string `json:"problem"`
+}
+
+// swagger:model ChangeAuditPageResponse
+type ChangeAuditPageResponse struct {
+	Items []*ChangeAuditResponse `json:"items"`
+	// NextCursor fetches the page of older changes, null on the last page
+	NextCursor *string `json:"nextCursor"`
+}
+
+// swagger:model ChangeAuditResponse
+type ChangeAuditResponse struct {
+	Id         int64      `json:"id"`
+	EntityType string     `json:"entityType"`
+	EntityId   int64      `json:"entityId"`
+	Action     string     `json:"action"`
+	EducatorId *uuid.UUID `json:"educatorId"`
+	StudentId  *uuid.UUID `json:"studentId"`
+	// ActorId is the user who made the change, null for changes made by the system
+	ActorId *uuid.UUID `json:"actorId"`
+	// OldValues holds the changed columns before the change, null for creations
+	OldValues json.RawMessage `json:"oldValues" swaggertype:"object"`
+	// NewValues holds the changed columns after the change, null for hard deletes
+	NewValues json.RawMessage `json:"newValues" swaggertype:"object"`
+	ChangedAt time.Time       `json:"changedAt"`
+}
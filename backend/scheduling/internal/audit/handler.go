@@ -0,0 +1,146 @@
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type AuditHandler struct {
+	service *AuditService
+}
+
+func NewAuditHandler(service *AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// GetCorrelationTrail retrieves the messages of a correlation.
+// @Summary      Retrieve correlation trail
+// @Description  Returns the published and consumed messages sharing a correlation id in the order they occurred, with their causation ids and actors.
+// @Tags         Audit
+// @Accept       json
+// @Produce      json
+// @Param        correlationId  path      string                    true  "Correlation ID"
+// @Success      200            {object}  CorrelationTrailResponse  "Correlation trail"
+// @Router       /api/v1/audit/correlations/{correlationId} [get]
+// @Security 	 BearerAuth
+func (h *AuditHandler) GetCorrelationTrail(w http.ResponseWriter, r *http.Request) {
+	trail, err := h.service.GetCorrelationTrail(r.Context(), chi.URLParam(r, "correlationId"))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, trail)
+}
+
+// VerifyChain verifies the audit trail signatures.
+// @Summary      Verify audit trail
+// @Description  Checks that no message, change or booking status transition of the audit trail was altered, removed or reordered since it was recorded, including entries removed from the end of a chain, and reports the first entry breaking its chain.
+// @Tags         Audit
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ChainVerificationResponse  "Verification result"
+// @Router       /api/v1/audit/verify [get]
+// @Security 	 BearerAuth
+func (h *AuditHandler) VerifyChain(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.VerifyChain(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, result)
+}
+
+// GetChanges lists recorded changes of schedules, events and bookings.
+// @Summary      List change audit
+// @Description  Returns who created, changed or deleted working periods, scheduled events and bookings and when, newest first. Updates carry only the changed columns. Changes made by the system have no actor.
+// @Tags         Audit
+// @Accept       json
+// @Produce      json
+// @Param        entityType  query     string  false  "WORKING_PERIOD, SCHEDULED_EVENT or BOOKING"
+// @Param        entityId    query     int     false  "Entity ID, requires entityType"
+// @Param        userId      query     string  false  "Educator or student whose entities changed (UUID)"
+// @Param        actorId     query     string  false  "User who made the changes (UUID)"
+// @Param        from        query     string  false  "Changed at or after, in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        to          query     string  false  "Changed before, in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        cursor      query     string  false  "Cursor returned by the previous page"
+// @Param        limit       query     int     false  "Page size, 50 by default and at most 500"
+// @Success      200         {object}  ChangeAuditPageResponse  "Change audit page"
+// @Failure      400         {object}  error                    "Invalid input"
+// @Router       /api/v1/admin/audit [get]
+// @Security 	 BearerAuth
+func (h *AuditHandler) GetChanges(w http.ResponseWriter, r *http.Request) {
+	query, err := parseChangeAuditQuery(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	page, err := h.service.GetChanges(r.Context(), query)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, page)
+}
+
+func parseChangeAuditQuery(w http.ResponseWriter, r *http.Request) (*ChangeAuditQuery, error) {
+	values := r.URL.Query()
+	query := &ChangeAuditQuery{Cursor: values.Get("cursor")}
+
+	if entityType := values.Get("entityType"); entityType != "" {
+		query.EntityType = &entityType
+	}
+	if value := values.Get("entityId"); value != "" {
+		entityId, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid format for query parameter 'entityId', expected an integer, received: '%s'", value)
+		}
+		query.EntityId = &entityId
+	}
+	if values.Get("userId") != "" {
+		userId, err := api.ParseUUIDQuery(w, r, "userId")
+		if err != nil {
+			return nil, err
+		}
+		query.UserId = &userId
+	}
+	if values.Get("actorId") != "" {
+		actorId, err := api.ParseUUIDQuery(w, r, "actorId")
+		if err != nil {
+			return nil, err
+		}
+		query.ActorId = &actorId
+	}
+	if values.Get("from") != "" {
+		from, err := api.ParseTimeQuery(w, r, "from", time.RFC3339)
+		if err != nil {
+			return nil, err
+		}
+		query.From = &from
+	}
+	if values.Get("to") != "" {
+		to, err := api.ParseTimeQuery(w, r, "to", time.RFC3339)
+		if err != nil {
+			return nil, err
+		}
+		query.To = &to
+	}
+
+	limit, err := api.ParseIntQuery(w, r, "limit", defaultChangePageSize)
+	if err != nil {
+		return nil, err
+	}
+	query.Limit = limit
+
+	return query, nil
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func MapRecordToEventAudit(r *messaging.EventRecord) *entities.EventAudit {
+	audit := &entities.EventAudit{
+		EventId:       r.EventId,
+		EventType:     r.EventType,
+		RoutingKey:    r.RoutingKey,
+		Direction:     r.Direction,
+		CorrelationId: r.CorrelationId,
+		OccurredAt:    r.OccurredAt,
+		RecordedAt:    time.Now().UTC(),
+	}
+	if r.CausationId != "" {
+		audit.CausationId = &r.CausationId
+	}
+	if r.Actor != nil {
+		audit.ActorType = &r.Actor.Type
+		if r.Actor.Id != "" {
+			audit.ActorId = &r.Actor.Id
+		}
+	}
+	return audit
+}
+
+func MapEventAuditToResponse(a *entities.EventAudit) *EventAuditResponse {
+	return &EventAuditResponse{
+		EventId:     a.EventId,
+		EventType:   a.EventType,
+		RoutingKey:  a.RoutingKey,
+		Direction:   a.Direction,
+		CausationId: a.CausationId,
+		ActorType:   a.ActorType,
+		ActorId:     a.ActorId,
+		OccurredAt:  a.OccurredAt,
+	}
+}
+
+func MapEventAuditsToResponse(as []*entities.EventAudit) []*EventAuditResponse {
+	response := make([]*EventAuditResponse, 0, len(as))
+	for _, a := range as {
+		response = append(response, MapEventAuditToResponse(a))
+	}
+	return response
+}
+
+func MapChangeAuditToResponse(c *entities.ChangeAudit) *ChangeAuditResponse {
+	response := &ChangeAuditResponse{
+		Id:         c.Id,
+		EntityType: c.EntityType,
+		EntityId:   c.EntityId,
+		Action:     c.Action,
+		EducatorId: c.EducatorId,
+		StudentId:  c.StudentId,
+		ActorId:    c.ActorId,
+		ChangedAt:  c.ChangedAt,
+	}
+	if c.OldValues != nil {
+		response.OldValues = json.RawMessage(c.OldValues)
+	}
+	if c.NewValues != nil {
+		response.NewValues = json.RawMessage(c.NewValues)
+	}
+	return response
+}
+
+func MapChangeAuditsToResponse(cs []*entities.ChangeAudit) []*ChangeAuditResponse {
+	response := make([]*ChangeAuditResponse, 0, len(cs))
+	for _, c := range cs {
+		response = append(response, MapChangeAuditToResponse(c))
+	}
+	return response
+}
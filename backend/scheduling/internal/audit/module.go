@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeAuditService(log logger.Logger, db *sqlx.DB, cfg *config.AuditConfig) *AuditService {
+	repo := NewAuditRepository(db)
+	return NewAuditService(log, repo, NewChain(cfg.SigningKey))
+}
+
+func InitializeSealJob(log logger.Logger, db *sqlx.DB, cfg *config.AuditConfig) *SealJob {
+	repo := NewAuditRepository(db)
+	return NewSealJob(log, repo, NewChain(cfg.SigningKey), cfg)
+}
+
+func InitializeAuditHTTPHandler(service *AuditService) http.Handler {
+	handler := NewAuditHandler(service)
+	return Routes(handler)
+}
+
+func InitializeAdminAuditHTTPHandler(service *AuditService) http.Handler {
+	handler := NewAuditHandler(service)
+	return AdminRoutes(handler)
+}
@@ -0,0 +1,387 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type AuditRepo struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepository(db *sqlx.DB) *AuditRepo {
+	return &AuditRepo{db: db}
+}
+
+// AddEventAudit stores the envelope of a published or consumed message, signed on the chain of its
+// correlation after the latest message recorded for it. Only appends to the same correlation wait on each other.
+func (r *AuditRepo) AddEventAudit(ctx context.Context, record *entities.EventAudit, chain *Chain) error {
+	const insertQuery = `
+		INSERT INTO event_audit (event_id, event_type, routing_key, direction, correlation_id, causation_id, actor_type, actor_id, occurred_at, recorded_at, chain_sequence, previous_signature, signature)
+		VALUES (:event_id, :event_type, :routing_key, :direction, :correlation_id, :causation_id, :actor_type, :actor_id, :occurred_at, :recorded_at, :chain_sequence, :previous_signature, :signature)
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	if err := lockChain(ctx, tx, entities.AuditChainEvent, record.CorrelationId); err != nil {
+		return err
+	}
+	head, err := getChainHead(ctx, tx, entities.AuditChainEvent, record.CorrelationId)
+	if err != nil {
+		return err
+	}
+
+	sequence, previous := nextLink(head)
+	record.ChainSequence = &sequence
+	record.PreviousSignature = previous
+	signature := chain.Sign(stringValue(previous), record)
+	record.Signature = &signature
+
+	if _, err := tx.NamedExecContext(ctx, insertQuery, record); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	if err := saveChainHead(ctx, tx, chain, entities.AuditChainEvent, record.CorrelationId, sequence, signature); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	return nil
+}
+
+// SealChangeAudits signs a batch of the oldest unsigned changes in the order they were made, each on the
+// chain of its entity, and returns how many were signed. One instance seals at a time, the others skip the
+// batch, so a chain is always extended in the order of its changes.
+func (r *AuditRepo) SealChangeAudits(ctx context.Context, chain *Chain, limit int) (int, error) {
+	const selectQuery = `
+		SELECT id, entity_type, entity_id, action, educator_id, student_id, actor_id, old_values, new_values, changed_at
+		FROM change_audit
+		WHERE signature IS NULL
+		ORDER BY id
+		LIMIT $1
+	`
+	const updateQuery = `
+		UPDATE change_audit SET chain_sequence = $2, previous_signature = $3, signature = $4 WHERE id = $1
+	`
+
+	return r.seal(ctx, "change_audit", entities.AuditChainChange, chain, func(tx database.Tx, heads *chainHeads) (int, error) {
+		var changes []*entities.ChangeAudit
+		if err := tx.SelectContext(ctx, &changes, selectQuery, limit); err != nil {
+			return 0, apperrors.NewInternal(err)
+		}
+		for _, change := range changes {
+			key := changeChainKey(change.EntityType, change.EntityId)
+			sequence, previous, err := heads.next(key)
+			if err != nil {
+				return 0, err
+			}
+			change.ChainSequence = &sequence
+			change.PreviousSignature = previous
+			signature := chain.SignChange(stringValue(previous), change)
+			if _, err := tx.ExecContext(ctx, updateQuery, change.Id, sequence, previous, signature); err != nil {
+				return 0, apperrors.NewInternal(err)
+			}
+			heads.advance(key, sequence, signature)
+		}
+		return len(changes), nil
+	})
+}
+
+// SealBookingTransitions signs a batch of the oldest unsigned status transitions, each on the chain of its
+// booking, and returns how many were signed
+func (r *AuditRepo) SealBookingTransitions(ctx context.Context, chain *Chain, limit int) (int, error) {
+	const selectQuery = `
+		SELECT id, booking_id, from_status, to_status, actor_id, changed_at
+		FROM booking_status_transition
+		WHERE signature IS NULL
+		ORDER BY id
+		LIMIT $1
+	`
+	const updateQuery = `
+		UPDATE booking_status_transition SET chain_sequence = $2, previous_signature = $3, signature = $4 WHERE id = $1
+	`
+
+	return r.seal(ctx, "booking_status_transition", entities.AuditChainBookingStatus, chain, func(tx database.Tx, heads *chainHeads) (int, error) {
+		var transitions []*entities.BookingStatusTransition
+		if err := tx.SelectContext(ctx, &transitions, selectQuery, limit); err != nil {
+			return 0, apperrors.NewInternal(err)
+		}
+		for _, transition := range transitions {
+			key := strconv.FormatInt(transition.BookingId, 10)
+			sequence, previous, err := heads.next(key)
+			if err != nil {
+				return 0, err
+			}
+			transition.ChainSequence = &sequence
+			transition.PreviousSignature = previous
+			signature := chain.SignTransition(stringValue(previous), transition)
+			result, err := tx.ExecContext(ctx, updateQuery, transition.Id, sequence, previous, signature)
+			if err != nil {
+				return 0, apperrors.NewInternal(err)
+			}
+			// A row removed with its booking meanwhile leaves its chain as it was
+			if affected, err := result.RowsAffected(); err != nil {
+				return 0, apperrors.NewInternal(err)
+			} else if affected > 0 {
+				heads.advance(key, sequence, signature)
+			}
+		}
+		return len(transitions), nil
+	})
+}
+
+// seal runs a sealing batch of a table in a transaction, skipped when another instance is sealing the table,
+// and stores the heads of the chains the batch extended
+func (r *AuditRepo) seal(ctx context.Context, table, chainType string, chain *Chain, batch func(tx database.Tx, heads *chainHeads) (int, error)) (int, error) {
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err := tx.GetContext(ctx, &locked, `SELECT pg_try_advisory_xact_lock(hashtextextended('audit_seal:' || $1::text, 0))`, table); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	heads := &chainHeads{
+		ctx:       ctx,
+		tx:        tx,
+		chainType: chainType,
+		heads:     make(map[string]*entities.AuditChainHead),
+		changed:   make(map[string]*entities.AuditChainHead),
+	}
+	sealed, err := batch(tx, heads)
+	if err != nil {
+		return 0, err
+	}
+	for key, head := range heads.changed {
+		if err := saveChainHead(ctx, tx, chain, chainType, key, head.Sequence, head.Signature); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return sealed, nil
+}
+
+// chainHeads keeps the heads of the chains a sealing batch reads and extends, each read once per batch
+type chainHeads struct {
+	ctx       context.Context
+	tx        database.Tx
+	chainType string
+	heads     map[string]*entities.AuditChainHead
+	changed   map[string]*entities.AuditChainHead
+}
+
+// next returns the sequence and previous signature of the entry appended next to a chain
+func (h *chainHeads) next(key string) (int64, *string, error) {
+	head, ok := h.heads[key]
+	if !ok {
+		var err error
+		if head, err = getChainHead(h.ctx, h.tx, h.chainType, key); err != nil {
+			return 0, nil, err
+		}
+		h.heads[key] = head
+	}
+	sequence, previous := nextLink(head)
+	return sequence, previous, nil
+}
+
+// advance moves the head of a chain to the entry just appended
+func (h *chainHeads) advance(key string, sequence int64, signature string) {
+	head := &entities.AuditChainHead{ChainType: h.chainType, ChainKey: key, Sequence: sequence, Signature: signature}
+	h.heads[key] = head
+	h.changed[key] = head
+}
+
+// lockChain serializes appends to one chain until the transaction ends
+func lockChain(ctx context.Context, tx database.Tx, chainType, key string) error {
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1::text || ':' || $2::text, 0))`, chainType, key); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	return nil
+}
+
+// getChainHead retrieves the head of a chain, nil for a chain without entries
+func getChainHead(ctx context.Context, tx database.Tx, chainType, key string) (*entities.AuditChainHead, error) {
+	const query = `
+		SELECT chain_type, chain_key, sequence, signature, head_signature, updated_at
+		FROM audit_chain_head
+		WHERE chain_type = $1 AND chain_key = $2
+	`
+	var head entities.AuditChainHead
+	if err := tx.GetContext(ctx, &head, query, chainType, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return &head, nil
+}
+
+// saveChainHead moves the signed head of a chain to its latest entry
+func saveChainHead(ctx context.Context, tx database.Tx, chain *Chain, chainType, key string, sequence int64, signature string) error {
+	const query = `
+		INSERT INTO audit_chain_head (chain_type, chain_key, sequence, signature, head_signature, updated_at)
+		VALUES ($1, $2, $3, $4, $5, current_timestamp)
+		ON CONFLICT (chain_type, chain_key) DO UPDATE
+		SET sequence = EXCLUDED.sequence, signature = EXCLUDED.signature, head_signature = EXCLUDED.head_signature, updated_at = EXCLUDED.updated_at
+	`
+	head := &entities.AuditChainHead{ChainType: chainType, ChainKey: key, Sequence: sequence, Signature: signature}
+	if _, err := tx.ExecContext(ctx, query, chainType, key, sequence, signature, chain.SignHead(head)); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	return nil
+}
+
+// nextLink returns the sequence and previous signature of the entry following a head, nil for a new chain
+func nextLink(head *entities.AuditChainHead) (int64, *string) {
+	if head == nil {
+		return 1, nil
+	}
+	return head.Sequence + 1, &head.Signature
+}
+
+// GetCorrelationTrail retrieves every recorded message of a correlation in the order they occurred
+func (r *AuditRepo) GetCorrelationTrail(ctx context.Context, correlationId string, limit int) ([]*entities.EventAudit, error) {
+	const query = `
+		SELECT id, event_id, event_type, routing_key, direction, correlation_id, causation_id, actor_type, actor_id, occurred_at, recorded_at, previous_signature, signature
+		FROM event_audit
+		WHERE correlation_id = $1
+		ORDER BY occurred_at, id
+		LIMIT $2
+	`
+	return database.FetchMultiple[entities.EventAudit](ctx, r.db, query, correlationId, limit)
+}
+
+// GetEventAuditsAfter retrieves a batch of the entries recorded before the trail was chained per correlation,
+// in the order they were stored, starting after an id
+func (r *AuditRepo) GetEventAuditsAfter(ctx context.Context, afterId int64, limit int) ([]*entities.EventAudit, error) {
+	const query = `
+		SELECT id, event_id, event_type, routing_key, direction, correlation_id, causation_id, actor_type, actor_id, occurred_at, recorded_at, previous_signature, signature
+		FROM event_audit
+		WHERE id > $1 AND chain_sequence IS NULL
+		ORDER BY id
+		LIMIT $2
+	`
+	return database.FetchMultiple[entities.EventAudit](ctx, r.db, query, afterId, limit)
+}
+
+// chainTables holds the table the entries of each chain type are kept in and the expression of their chain key
+var chainTables = map[string]struct{ table, key string }{
+	entities.AuditChainEvent:         {table: "event_audit", key: "correlation_id"},
+	entities.AuditChainChange:        {table: "change_audit", key: "entity_type || ':' || entity_id"},
+	entities.AuditChainBookingStatus: {table: "booking_status_transition", key: "booking_id::text"},
+}
+
+// GetChainHeadsAfter retrieves a batch of the heads of a chain type ordered by key, starting after a key
+func (r *AuditRepo) GetChainHeadsAfter(ctx context.Context, chainType, afterKey string, limit int) ([]*entities.AuditChainHead, error) {
+	const query = `
+		SELECT chain_type, chain_key, sequence, signature, head_signature, updated_at
+		FROM audit_chain_head
+		WHERE chain_type = $1 AND chain_key > $2
+		ORDER BY chain_key
+		LIMIT $3
+	`
+	return database.FetchMultiple[entities.AuditChainHead](ctx, r.db, query, chainType, afterKey, limit)
+}
+
+// GetEventChain retrieves the chained messages of a correlation in chain order
+func (r *AuditRepo) GetEventChain(ctx context.Context, correlationId string) ([]*entities.EventAudit, error) {
+	const query = `
+		SELECT id, event_id, event_type, routing_key, direction, correlation_id, causation_id, actor_type, actor_id, occurred_at, recorded_at, chain_sequence, previous_signature, signature
+		FROM event_audit
+		WHERE correlation_id = $1 AND chain_sequence IS NOT NULL
+		ORDER BY chain_sequence, id
+	`
+	return database.FetchMultiple[entities.EventAudit](ctx, r.db, query, correlationId)
+}
+
+// GetChangeChain retrieves the sealed changes of an entity in chain order, the key as made by changeChainKey
+func (r *AuditRepo) GetChangeChain(ctx context.Context, key string) ([]*entities.ChangeAudit, error) {
+	const query = `
+		SELECT id, entity_type, entity_id, action, educator_id, student_id, actor_id, old_values, new_values, changed_at, chain_sequence, previous_signature, signature
+		FROM change_audit
+		WHERE entity_type = split_part($1, ':', 1) AND entity_id = split_part($1, ':', 2)::bigint AND chain_sequence IS NOT NULL
+		ORDER BY chain_sequence, id
+	`
+	return database.FetchMultiple[entities.ChangeAudit](ctx, r.db, query, key)
+}
+
+// GetTransitionChain retrieves the sealed status transitions of a booking in chain order
+func (r *AuditRepo) GetTransitionChain(ctx context.Context, bookingId string) ([]*entities.BookingStatusTransition, error) {
+	const query = `
+		SELECT id, booking_id, from_status, to_status, actor_id, changed_at, chain_sequence, previous_signature, signature
+		FROM booking_status_transition
+		WHERE booking_id = $1::bigint AND chain_sequence IS NOT NULL
+		ORDER BY chain_sequence, id
+	`
+	return database.FetchMultiple[entities.BookingStatusTransition](ctx, r.db, query, bookingId)
+}
+
+// GetHeadlessChainEntry retrieves the id of the oldest chained entry of a chain type whose chain has no head,
+// nil when every chain still has one
+func (r *AuditRepo) GetHeadlessChainEntry(ctx context.Context, chainType string) (*int64, error) {
+	t := chainTables[chainType]
+	query := `
+		SELECT min(e.id) FROM ` + t.table + ` e
+		WHERE e.chain_sequence IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM audit_chain_head h WHERE h.chain_type = $1 AND h.chain_key = ` + t.key + `)
+	`
+	var id *int64
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &id, query, chainType); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return id, nil
+}
+
+// CountUnsealed counts the entries of a chain type not signed yet
+func (r *AuditRepo) CountUnsealed(ctx context.Context, chainType string) (int64, error) {
+	query := `SELECT count(*) FROM ` + chainTables[chainType].table + ` WHERE signature IS NULL`
+	var count int64
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &count, query); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return count, nil
+}
+
+// GetChangeAudits retrieves a page of changes matching the filters, newest first, older than beforeId when set.
+// The user filter matches changes of an educator's or a student's entities.
+func (r *AuditRepo) GetChangeAudits(ctx context.Context, filter *ChangeAuditQuery, beforeId *int64) ([]*entities.ChangeAudit, error) {
+	const query = `
+		SELECT id, entity_type, entity_id, action, educator_id, student_id, actor_id, old_values, new_values, changed_at
+		FROM change_audit
+		WHERE ($1::text IS NULL OR entity_type = $1)
+			AND ($2::bigint IS NULL OR entity_id = $2)
+			AND ($3::uuid IS NULL OR educator_id = $3 OR student_id = $3)
+			AND ($4::uuid IS NULL OR actor_id = $4)
+			AND ($5::timestamptz IS NULL OR changed_at >= $5)
+			AND ($6::timestamptz IS NULL OR changed_at < $6)
+			AND ($7::bigint IS NULL OR id < $7)
+		ORDER BY id DESC
+		LIMIT $8
+	`
+	return database.FetchMultiple[entities.ChangeAudit](
+		ctx, r.db, query,
+		filter.EntityType, filter.EntityId, filter.UserId, filter.ActorId, filter.From, filter.To, beforeId, filter.Limit,
+	)
+}
@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *AuditHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.AdminRole))
+	r.Get("/correlations/{correlationId}", handler.GetCorrelationTrail)
+	r.Get("/verify", handler.VerifyChain)
+
+	return r
+}
+
+func AdminRoutes(handler *AuditHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.AdminRole))
+	r.Get("/", handler.GetChanges)
+
+	return r
+}
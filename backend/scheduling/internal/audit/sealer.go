@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const sealBatchSize = 500
+
+type SealRepository interface {
+	SealChangeAudits(ctx context.Context, chain *Chain, limit int) (int, error)
+	SealBookingTransitions(ctx context.Context, chain *Chain, limit int) (int, error)
+}
+
+// SealJob periodically signs the changes and booking status transitions the database triggers record. The
+// triggers cannot hold the signing key, so entries stay unsigned until the next seal.
+type SealJob struct {
+	log   logger.Logger
+	repo  SealRepository
+	chain *Chain
+	cfg   *config.AuditConfig
+}
+
+func NewSealJob(log logger.Logger, repo SealRepository, chain *Chain, cfg *config.AuditConfig) *SealJob {
+	return &SealJob{log: log, repo: repo, chain: chain, cfg: cfg}
+}
+
+// Run seals the recorded entries on every interval until the context is cancelled
+func (j *SealJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.SealIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.Seal(ctx); err != nil {
+				j.log.Errorf("Failed to seal audit entries: %v", err)
+			}
+		}
+	}
+}
+
+// Seal signs every unsigned change and status transition in batches
+func (j *SealJob) Seal(ctx context.Context) error {
+	for _, seal := range []func(ctx context.Context, chain *Chain, limit int) (int, error){j.repo.SealChangeAudits, j.repo.SealBookingTransitions} {
+		for {
+			sealed, err := seal(ctx, j.chain, sealBatchSize)
+			if err != nil {
+				return err
+			}
+			if sealed < sealBatchSize {
+				break
+			}
+		}
+	}
+	return nil
+}
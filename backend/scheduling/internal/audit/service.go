@@ -0,0 +1,334 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+const (
+	maxTrailLength  = 1000
+	verifyBatchSize = 1000
+
+	defaultChangePageSize = 50
+	maxChangePageSize     = 500
+)
+
+var changeEntityTypes = []string{entities.ChangeAuditWorkingPeriod, entities.ChangeAuditScheduledEvent, entities.ChangeAuditBooking}
+
+var chainTypes = []string{entities.AuditChainEvent, entities.AuditChainChange, entities.AuditChainBookingStatus}
+
+type AuditRepository interface {
+	AddEventAudit(ctx context.Context, record *entities.EventAudit, chain *Chain) error
+	GetCorrelationTrail(ctx context.Context, correlationId string, limit int) ([]*entities.EventAudit, error)
+	GetEventAuditsAfter(ctx context.Context, afterId int64, limit int) ([]*entities.EventAudit, error)
+	GetChainHeadsAfter(ctx context.Context, chainType, afterKey string, limit int) ([]*entities.AuditChainHead, error)
+	GetEventChain(ctx context.Context, correlationId string) ([]*entities.EventAudit, error)
+	GetChangeChain(ctx context.Context, key string) ([]*entities.ChangeAudit, error)
+	GetTransitionChain(ctx context.Context, bookingId string) ([]*entities.BookingStatusTransition, error)
+	GetHeadlessChainEntry(ctx context.Context, chainType string) (*int64, error)
+	CountUnsealed(ctx context.Context, chainType string) (int64, error)
+	GetChangeAudits(ctx context.Context, filter *ChangeAuditQuery, beforeId *int64) ([]*entities.ChangeAudit, error)
+}
+
+// AuditService keeps the envelopes of published and consumed messages, indexed by correlation and
+// causation id, so a booking lifecycle spanning several services can be followed from one id
+type AuditService struct {
+	log   logger.Logger
+	repo  AuditRepository
+	chain *Chain
+}
+
+func NewAuditService(log logger.Logger, repo AuditRepository, chain *Chain) *AuditService {
+	return &AuditService{log: log, repo: repo, chain: chain}
+}
+
+// RecordEvent stores a message envelope. Failures are logged and never fail publishing or consuming.
+func (s *AuditService) RecordEvent(ctx context.Context, record *messaging.EventRecord) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.repo.AddEventAudit(ctx, MapRecordToEventAudit(record), s.chain); err != nil {
+		log.Errorf("Failed to record %s event %s in the audit trail: %v", record.Direction, record.EventId, err)
+	}
+}
+
+// GetCorrelationTrail returns the recorded messages of a correlation in the order they occurred
+func (s *AuditService) GetCorrelationTrail(ctx context.Context, correlationId string) (*CorrelationTrailResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	records, err := s.repo.GetCorrelationTrail(ctx, correlationId, maxTrailLength)
+	if err != nil {
+		log.Error("failed to get correlation trail", err)
+		return nil, err
+	}
+
+	return &CorrelationTrailResponse{
+		CorrelationId: correlationId,
+		Events:        MapEventAuditsToResponse(records),
+	}, nil
+}
+
+// GetChanges returns a page of recorded changes of working periods, scheduled events and bookings, newest
+// first, so disputes can be settled from who changed what and when
+func (s *AuditService) GetChanges(ctx context.Context, query *ChangeAuditQuery) (*ChangeAuditPageResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if query.EntityType != nil && !slices.Contains(changeEntityTypes, *query.EntityType) {
+		return nil, apperrors.NewBadRequestError("Unknown entity type "+*query.EntityType, apperrors.ErrParameterInvalid)
+	}
+	if query.EntityId != nil && query.EntityType == nil {
+		return nil, apperrors.NewBadRequestError("entityId requires entityType", apperrors.ErrParameterInvalid)
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultChangePageSize
+	}
+	if query.Limit < 0 || query.Limit > maxChangePageSize {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Limit must be between 1 and %d", maxChangePageSize), apperrors.ErrParameterInvalid)
+	}
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, apperrors.NewBadRequestError("From must be before to", apperrors.ErrParameterInvalid)
+	}
+
+	var beforeId *int64
+	if query.Cursor != "" {
+		id, err := strconv.ParseInt(query.Cursor, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, apperrors.NewBadRequestError("Invalid cursor", apperrors.ErrParameterInvalid)
+		}
+		beforeId = &id
+	}
+
+	changes, err := s.repo.GetChangeAudits(ctx, query, beforeId)
+	if err != nil {
+		log.Error("failed to get change audits", err)
+		return nil, err
+	}
+
+	page := &ChangeAuditPageResponse{Items: MapChangeAuditsToResponse(changes)}
+	if len(changes) == query.Limit {
+		cursor := strconv.FormatInt(changes[len(changes)-1].Id, 10)
+		page.NextCursor = &cursor
+	}
+	return page, nil
+}
+
+// VerifyChain checks the whole audit trail: messages, changes and booking status transitions. Every chain must
+// keep the signature of each entry, link each entry to the one before it and reach its signed head, so
+// altered, removed or reordered entries and entries cut from the end of a chain are all reported. The first
+// remaining entry of a chain anchors it, since entries before it may have been removed by retention.
+func (s *AuditService) VerifyChain(ctx context.Context) (*ChainVerificationResponse, error) {
+	result := &ChainVerificationResponse{Valid: true}
+
+	if err := s.verifySingleChain(ctx, result); err != nil {
+		return nil, err
+	}
+	for _, chainType := range chainTypes {
+		if !result.Valid {
+			break
+		}
+		if err := s.verifyChains(ctx, chainType, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// verifySingleChain walks the messages recorded before the trail was chained per correlation, which were
+// chained in the order they were stored. Entries recorded before signing was introduced are counted as unsigned.
+func (s *AuditService) verifySingleChain(ctx context.Context, result *ChainVerificationResponse) error {
+	log := logger.FromContext(ctx, s.log)
+
+	var previous *string
+	var afterId int64
+
+	for {
+		entries, err := s.repo.GetEventAuditsAfter(ctx, afterId, verifyBatchSize)
+		if err != nil {
+			log.Error("failed to get audit trail entries", err)
+			return err
+		}
+
+		for _, entry := range entries {
+			result.Checked++
+			if problem := s.verifyEntry(entry, previous); problem != "" {
+				breakChain(result, nil, &entry.Id, problem)
+				return nil
+			}
+			if entry.Signature == nil {
+				result.Unsigned++
+				continue
+			}
+			previous = entry.Signature
+		}
+
+		if len(entries) < verifyBatchSize {
+			return nil
+		}
+		afterId = entries[len(entries)-1].Id
+	}
+}
+
+// verifyEntry returns why an entry of the single chain breaks it, empty when it is intact
+func (s *AuditService) verifyEntry(entry *entities.EventAudit, previous *string) string {
+	if entry.Signature == nil {
+		if previous != nil {
+			return "entry is not signed"
+		}
+		return ""
+	}
+	if !s.chain.Valid(entry) {
+		return "entry was altered after it was recorded"
+	}
+	if previous != nil && stringValue(entry.PreviousSignature) != *previous {
+		return "entry does not follow the entry stored before it, entries were removed or reordered"
+	}
+	return ""
+}
+
+// verifyChains walks every chain of a chain type from its head, then checks that no chained entry lost the
+// head of its chain. Changes and transitions not sealed yet are counted as pending.
+func (s *AuditService) verifyChains(ctx context.Context, chainType string, result *ChainVerificationResponse) error {
+	log := logger.FromContext(ctx, s.log)
+
+	var afterKey string
+	for {
+		heads, err := s.repo.GetChainHeadsAfter(ctx, chainType, afterKey, verifyBatchSize)
+		if err != nil {
+			log.Error("failed to get audit chain heads", err)
+			return err
+		}
+
+		for _, head := range heads {
+			links, err := s.chainLinks(ctx, head)
+			if err != nil {
+				log.Errorf("failed to get the entries of audit chain %s %s: %v", head.ChainType, head.ChainKey, err)
+				return err
+			}
+			result.Checked += int64(len(links))
+			if brokenAt, problem := s.verifyLinks(head, links); problem != "" {
+				breakChain(result, head, brokenAt, problem)
+				return nil
+			}
+		}
+
+		if len(heads) < verifyBatchSize {
+			break
+		}
+		afterKey = heads[len(heads)-1].ChainKey
+	}
+
+	headless, err := s.repo.GetHeadlessChainEntry(ctx, chainType)
+	if err != nil {
+		log.Error("failed to check audit chain heads", err)
+		return err
+	}
+	if headless != nil {
+		breakChain(result, &entities.AuditChainHead{ChainType: chainType}, headless, "the head of the chain of the entry was removed")
+		return nil
+	}
+
+	if chainType != entities.AuditChainEvent {
+		pending, err := s.repo.CountUnsealed(ctx, chainType)
+		if err != nil {
+			log.Error("failed to count unsealed audit entries", err)
+			return err
+		}
+		result.Pending += pending
+	}
+	return nil
+}
+
+// chainLink is an entry of any chain as the verification walks it
+type chainLink struct {
+	id        int64
+	sequence  int64
+	previous  *string
+	signature *string
+	// valid reports whether the entry still carries the signature it was recorded with
+	valid bool
+}
+
+// chainLinks retrieves the entries of the chain of a head in chain order
+func (s *AuditService) chainLinks(ctx context.Context, head *entities.AuditChainHead) ([]chainLink, error) {
+	var links []chainLink
+	switch head.ChainType {
+	case entities.AuditChainEvent:
+		entries, err := s.repo.GetEventChain(ctx, head.ChainKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			links = append(links, chainLink{e.Id, *e.ChainSequence, e.PreviousSignature, e.Signature, s.chain.Valid(e)})
+		}
+	case entities.AuditChainChange:
+		changes, err := s.repo.GetChangeChain(ctx, head.ChainKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range changes {
+			links = append(links, chainLink{c.Id, *c.ChainSequence, c.PreviousSignature, c.Signature, s.chain.ValidChange(c)})
+		}
+	case entities.AuditChainBookingStatus:
+		transitions, err := s.repo.GetTransitionChain(ctx, head.ChainKey)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range transitions {
+			links = append(links, chainLink{t.Id, *t.ChainSequence, t.PreviousSignature, t.Signature, s.chain.ValidTransition(t)})
+		}
+	}
+	return links, nil
+}
+
+// verifyLinks returns the entry breaking a chain and why, an empty problem when the chain is intact. Entries
+// appended after the head was read follow it and are checked like the others.
+func (s *AuditService) verifyLinks(head *entities.AuditChainHead, links []chainLink) (*int64, string) {
+	if !s.chain.ValidHead(head) {
+		return nil, "chain head was altered"
+	}
+
+	var previous *chainLink
+	reachedHead := false
+	for i := range links {
+		link := &links[i]
+		if !link.valid {
+			return &link.id, "entry was altered after it was recorded"
+		}
+		if previous != nil && (link.sequence != previous.sequence+1 || stringValue(link.previous) != stringValue(previous.signature)) {
+			return &link.id, "entry does not follow the entry before it on its chain, entries were removed or reordered"
+		}
+		if link.sequence == head.Sequence {
+			if stringValue(link.signature) != head.Signature {
+				return &link.id, "entry does not match the head of its chain"
+			}
+			reachedHead = true
+		}
+		previous = link
+	}
+
+	if !reachedHead {
+		if previous != nil {
+			return &previous.id, "entries after this one were removed from the end of the chain"
+		}
+		return nil, "every entry of the chain was removed"
+	}
+	return nil, ""
+}
+
+// breakChain reports the first problem found, head is nil for the single chain
+func breakChain(result *ChainVerificationResponse, head *entities.AuditChainHead, brokenAt *int64, problem string) {
+	result.Valid = false
+	result.BrokenAtId = brokenAt
+	result.Problem = &problem
+	if head != nil {
+		chain := strings.TrimSpace(head.ChainType + " " + head.ChainKey)
+		result.Chain = &chain
+	}
+}
@@ -0,0 +1,221 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// fakeAuditRepo keeps chained messages in memory, appending them the way AuditRepo does
+type fakeAuditRepo struct {
+	AuditRepository
+	single []*entities.EventAudit
+	events map[string][]*entities.EventAudit
+	heads  map[string]*entities.AuditChainHead
+	nextId int64
+}
+
+func newFakeAuditRepo() *fakeAuditRepo {
+	return &fakeAuditRepo{events: make(map[string][]*entities.EventAudit), heads: make(map[string]*entities.AuditChainHead)}
+}
+
+func (f *fakeAuditRepo) AddEventAudit(_ context.Context, record *entities.EventAudit, chain *Chain) error {
+	f.nextId++
+	record.Id = f.nextId
+	sequence, previous := nextLink(f.heads[record.CorrelationId])
+	record.ChainSequence = &sequence
+	record.PreviousSignature = previous
+	signature := chain.Sign(stringValue(previous), record)
+	record.Signature = &signature
+	f.events[record.CorrelationId] = append(f.events[record.CorrelationId], record)
+
+	head := &entities.AuditChainHead{ChainType: entities.AuditChainEvent, ChainKey: record.CorrelationId, Sequence: sequence, Signature: signature}
+	head.HeadSignature = chain.SignHead(head)
+	f.heads[record.CorrelationId] = head
+	return nil
+}
+
+func (f *fakeAuditRepo) GetEventAuditsAfter(_ context.Context, afterId int64, limit int) ([]*entities.EventAudit, error) {
+	var entries []*entities.EventAudit
+	for _, e := range f.single {
+		if e.Id > afterId && len(entries) < limit {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func (f *fakeAuditRepo) GetChainHeadsAfter(_ context.Context, chainType, afterKey string, _ int) ([]*entities.AuditChainHead, error) {
+	if chainType != entities.AuditChainEvent || afterKey != "" {
+		return nil, nil
+	}
+	var heads []*entities.AuditChainHead
+	for _, head := range f.heads {
+		heads = append(heads, head)
+	}
+	return heads, nil
+}
+
+func (f *fakeAuditRepo) GetEventChain(_ context.Context, correlationId string) ([]*entities.EventAudit, error) {
+	return f.events[correlationId], nil
+}
+
+func (f *fakeAuditRepo) GetHeadlessChainEntry(_ context.Context, chainType string) (*int64, error) {
+	if chainType != entities.AuditChainEvent {
+		return nil, nil
+	}
+	for key, entries := range f.events {
+		if _, ok := f.heads[key]; !ok && len(entries) > 0 {
+			return &entries[0].Id, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeAuditRepo) CountUnsealed(context.Context, string) (int64, error) {
+	return 0, nil
+}
+
+func newTestEvent(correlationId string, n int) *entities.EventAudit {
+	at := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC).Add(time.Duration(n) * time.Second)
+	return &entities.EventAudit{
+		EventId:       correlationId + "-" + strconv.Itoa(n),
+		EventType:     "booking.status",
+		RoutingKey:    "booking.status",
+		Direction:     "PUBLISHED",
+		CorrelationId: correlationId,
+		OccurredAt:    at,
+		RecordedAt:    at,
+	}
+}
+
+// newTestTrail records three messages on each of two correlations. The fake repository never fails, so
+// the service logs nothing and needs no logger.
+func newTestTrail(t *testing.T) (*AuditService, *fakeAuditRepo) {
+	t.Helper()
+	repo := newFakeAuditRepo()
+	service := NewAuditService(nil, repo, NewChain("test-signing-key"))
+	for n := range 3 {
+		for _, correlationId := range []string{"booking-1", "booking-2"} {
+			if err := repo.AddEventAudit(context.Background(), newTestEvent(correlationId, n), service.chain); err != nil {
+				t.Fatalf("AddEventAudit: %v", err)
+			}
+		}
+	}
+	return service, repo
+}
+
+// entryAt is the position of an entry on its chain as it was recorded
+type entryAt struct {
+	correlationId string
+	index         int
+}
+
+func TestVerifyChain(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(repo *fakeAuditRepo)
+		// wantChain is empty for an intact trail
+		wantChain string
+		// wantBrokenAt is nil when the problem is not with an entry
+		wantBrokenAt *entryAt
+	}{
+		{
+			name:   "intact trail",
+			tamper: func(*fakeAuditRepo) {},
+		},
+		{
+			name:         "altered entry",
+			tamper:       func(repo *fakeAuditRepo) { repo.events["booking-1"][1].EventType = "booking.cancelled" },
+			wantChain:    "EVENT booking-1",
+			wantBrokenAt: &entryAt{"booking-1", 1},
+		},
+		{
+			name: "removed entry",
+			tamper: func(repo *fakeAuditRepo) {
+				entries := repo.events["booking-2"]
+				repo.events["booking-2"] = []*entities.EventAudit{entries[0], entries[2]}
+			},
+			wantChain:    "EVENT booking-2",
+			wantBrokenAt: &entryAt{"booking-2", 2},
+		},
+		{
+			name: "reordered entries",
+			tamper: func(repo *fakeAuditRepo) {
+				entries := repo.events["booking-1"]
+				entries[1], entries[2] = entries[2], entries[1]
+			},
+			wantChain:    "EVENT booking-1",
+			wantBrokenAt: &entryAt{"booking-1", 2},
+		},
+		{
+			name:         "removed last entry",
+			tamper:       func(repo *fakeAuditRepo) { repo.events["booking-1"] = repo.events["booking-1"][:2] },
+			wantChain:    "EVENT booking-1",
+			wantBrokenAt: &entryAt{"booking-1", 1},
+		},
+		{
+			name: "head moved back over a removed last entry",
+			tamper: func(repo *fakeAuditRepo) {
+				repo.events["booking-1"] = repo.events["booking-1"][:2]
+				head := repo.heads["booking-1"]
+				head.Sequence = 2
+				head.Signature = *repo.events["booking-1"][1].Signature
+			},
+			wantChain: "EVENT booking-1",
+		},
+		{
+			name:      "removed chain",
+			tamper:    func(repo *fakeAuditRepo) { delete(repo.events, "booking-2") },
+			wantChain: "EVENT booking-2",
+		},
+		{
+			name:         "removed head",
+			tamper:       func(repo *fakeAuditRepo) { delete(repo.heads, "booking-2") },
+			wantChain:    "EVENT",
+			wantBrokenAt: &entryAt{"booking-2", 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newTestTrail(t)
+			var wantId *int64
+			if tt.wantBrokenAt != nil {
+				wantId = &repo.events[tt.wantBrokenAt.correlationId][tt.wantBrokenAt.index].Id
+			}
+			tt.tamper(repo)
+
+			result, err := service.VerifyChain(context.Background())
+			if err != nil {
+				t.Fatalf("VerifyChain: %v", err)
+			}
+
+			if tt.wantChain == "" {
+				if !result.Valid || result.Problem != nil {
+					t.Fatalf("intact trail reported broken: %s", stringValue(result.Problem))
+				}
+				if result.Checked != 6 {
+					t.Fatalf("Checked = %d, want 6", result.Checked)
+				}
+				return
+			}
+
+			if result.Valid || result.Problem == nil {
+				t.Fatal("tampered trail reported intact")
+			}
+			if got := stringValue(result.Chain); got != tt.wantChain {
+				t.Fatalf("Chain = %q, want %q (%s)", got, tt.wantChain, *result.Problem)
+			}
+			switch {
+			case wantId == nil && result.BrokenAtId != nil:
+				t.Fatalf("BrokenAtId = %d, want none (%s)", *result.BrokenAtId, *result.Problem)
+			case wantId != nil && (result.BrokenAtId == nil || *result.BrokenAtId != *wantId):
+				t.Fatalf("BrokenAtId = %v, want %d (%s)", result.BrokenAtId, *wantId, *result.Problem)
+			}
+		})
+	}
+}
@@ -12,6 +12,8 @@ type userIdKey string
 
 const UserIdKey userIdKey = "user_id"
 const UserRolesKey userClaimsKey = "user_roles"
+const ActorIdKey userIdKey = "actor_id"
+const TenantIdKey userClaimsKey = "tenant_id"
 
 func GetUserID(ctx context.Context) (uuid.UUID, error) {
 	userId, ok := ctx.Value(UserIdKey).(uuid.UUID)
@@ -20,3 +22,38 @@ func GetUserID(ctx context.Context) (uuid.UUID, error) {
 	}
 	return userId, nil
 }
+
+// GetActorID returns the authenticated user behind the request. It differs from GetUserID only while
+// an organization admin acts on behalf of a teacher.
+func GetActorID(ctx context.Context) (uuid.UUID, error) {
+	if actorId, ok := ctx.Value(ActorIdKey).(uuid.UUID); ok {
+		return actorId, nil
+	}
+	return GetUserID(ctx)
+}
+
+// WithTenant stores the tenant the work within ctx is done for
+func WithTenant(ctx context.Context, tenantId string) context.Context {
+	return context.WithValue(ctx, TenantIdKey, tenantId)
+}
+
+// GetTenantID returns the tenant of the request or message, false when it has none such as in a
+// single-tenant deployment or background jobs
+func GetTenantID(ctx context.Context) (string, bool) {
+	tenantId, ok := ctx.Value(TenantIdKey).(string)
+	return tenantId, ok && tenantId != ""
+}
+
+func HasRole(ctx context.Context, role string) bool {
+	roles, ok := ctx.Value(UserRolesKey).([]any)
+	if !ok {
+		return false
+	}
+
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
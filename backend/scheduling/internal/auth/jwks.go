@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
@@ -10,6 +11,11 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
 )
 
 // JWK represents a single JSON Web Key
@@ -24,36 +30,178 @@ type JWKSet struct {
 	Keys []JWK `json:"keys"`
 }
 
-// JWKManager handles fetching and caching JWKs
+// refreshAhead is the share of the TTL after which the key set is refreshed in the background, so requests
+// never wait for an expired set while the identity provider is reachable
+const refreshAhead = 0.8
+
+// JWKManager fetches and caches the JWK set. The set is refreshed in the background before it expires, and
+// right away when a token is signed with an unknown kid so key rotations are picked up without waiting for
+// the TTL. Fetches are rate limited, and while the set cannot be fetched the last keys keep being used for
+// up to maxStale past their TTL.
 type JWKManager struct {
-	jwksURI    string
-	cache      JWKSet
-	cacheMutex sync.RWMutex
-	cacheTTL   time.Duration
-	lastUpdate time.Time
+	jwksURI     string
+	ttl         time.Duration
+	minInterval time.Duration
+	maxStale    time.Duration
+	log         logger.Logger
+	refreshes   metric.Int64Counter
+	// rotated is called after a fetch found keys added or removed, to tell other instances
+	rotated func(ctx context.Context)
+
+	mu        sync.RWMutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+
+	// refreshMu serializes fetches, requests arriving during a fetch wait for it instead of starting their own
+	refreshMu   sync.Mutex
+	lastAttempt time.Time
+	lastErr     error
 }
 
 // NewJWKManager initializes a new JWKManager
-func NewJWKManager(jwksURI string, cacheTTL time.Duration) *JWKManager {
+func NewJWKManager(
+	jwksURI string,
+	ttl time.Duration,
+	minInterval time.Duration,
+	maxStale time.Duration,
+	log logger.Logger,
+	meter metric.Meter,
+) (*JWKManager, error) {
+	refreshes, err := meter.Int64Counter("scheduling.auth.jwks.refreshes",
+		metric.WithDescription("Fetches of the JWK set, by outcome"))
+	if err != nil {
+		return nil, err
+	}
 	return &JWKManager{
-		jwksURI:  jwksURI,
-		cacheTTL: cacheTTL,
+		jwksURI:     jwksURI,
+		ttl:         ttl,
+		minInterval: minInterval,
+		maxStale:    maxStale,
+		log:         log,
+		refreshes:   refreshes,
+	}, nil
+}
+
+// OnRotation sets the function called after a fetch found keys added or removed, it must be set before Run
+func (j *JWKManager) OnRotation(rotated func(ctx context.Context)) {
+	j.rotated = rotated
+}
+
+// Bust fetches the key set again because another instance saw it rotate. The fetch is still rate limited and
+// does not report the rotation again.
+func (j *JWKManager) Bust(ctx context.Context) error {
+	return j.refresh(ctx, false)
+}
+
+// GetJWK retrieves the key by kid. An unknown kid or an expired set triggers a refresh; when the refresh
+// fails, a known key is still returned within the stale allowance.
+func (j *JWKManager) GetJWK(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	if key, age, ok := j.lookup(kid); ok && age < j.ttl {
+		return key, nil
 	}
+
+	err := j.refresh(ctx, true)
+	if key, age, ok := j.lookup(kid); ok && age < j.ttl+j.maxStale {
+		return key, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, errors.New("key with the given kid not found")
 }
 
-// GetJWK fetches and caches the JWK set, and retrieves the key by kid
-func (j *JWKManager) GetJWK(kid string) (*rsa.PublicKey, error) {
-	j.cacheMutex.RLock()
-	if time.Since(j.lastUpdate) < j.cacheTTL && j.cache.Keys != nil {
-		defer j.cacheMutex.RUnlock()
-		return j.findKeyByID(kid)
+// Run refreshes the key set before it expires until the context is cancelled, retrying failed fetches on
+// the minimum interval
+func (j *JWKManager) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(j.nextRefresh()):
+		}
+
+		if err := j.refresh(ctx, true); err != nil && ctx.Err() == nil {
+			j.log.Errorf("Failed to refresh JWKs: %v", err)
+		}
 	}
-	j.cacheMutex.RUnlock()
+}
+
+func (j *JWKManager) lookup(kid string) (*rsa.PublicKey, time.Duration, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
 
-	j.cacheMutex.Lock()
-	defer j.cacheMutex.Unlock()
+	key, ok := j.keys[kid]
+	return key, time.Since(j.fetchedAt), ok
+}
+
+// nextRefresh is the time until the set is due for a background refresh
+func (j *JWKManager) nextRefresh() time.Duration {
+	j.mu.RLock()
+	fetchedAt := j.fetchedAt
+	j.mu.RUnlock()
 
-	resp, err := http.Get(j.jwksURI)
+	if fetchedAt.IsZero() {
+		return j.minInterval
+	}
+	wait := time.Until(fetchedAt.Add(time.Duration(float64(j.ttl) * refreshAhead)))
+	return max(wait, j.minInterval)
+}
+
+// refresh fetches the key set unless a fetch was attempted within the minimum interval, in which case the
+// outcome of that fetch is returned. A changed set is reported to the rotation function when announce is set.
+func (j *JWKManager) refresh(ctx context.Context, announce bool) error {
+	j.refreshMu.Lock()
+	defer j.refreshMu.Unlock()
+
+	if !j.lastAttempt.IsZero() && time.Since(j.lastAttempt) < j.minInterval {
+		return j.lastErr
+	}
+	j.lastAttempt = time.Now()
+
+	keys, err := j.fetch(ctx)
+	if err != nil {
+		j.lastErr = err
+		j.refreshes.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", "failed")))
+		return err
+	}
+
+	j.mu.Lock()
+	rotated := j.keys != nil && !sameKids(j.keys, keys)
+	j.keys = keys
+	j.fetchedAt = j.lastAttempt
+	j.mu.Unlock()
+
+	j.lastErr = nil
+	j.refreshes.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", "fetched")))
+	if rotated {
+		j.log.Infof("JWK set rotated, %d keys in use", len(keys))
+		if announce && j.rotated != nil {
+			j.rotated(ctx)
+		}
+	}
+	return nil
+}
+
+func sameKids(a, b map[string]*rsa.PublicKey) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for kid := range a {
+		if _, ok := b[kid]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// fetch retrieves the key set and converts its keys, skipping the ones that cannot be converted
+func (j *JWKManager) fetch(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.jwksURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKs: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch JWKs: %w", err)
 	}
@@ -67,20 +215,20 @@ func (j *JWKManager) GetJWK(kid string) (*rsa.PublicKey, error) {
 	if err := json.NewDecoder(resp.Body).Decode(&jwkSet); err != nil {
 		return nil, fmt.Errorf("failed to parse JWKs: %w", err)
 	}
+	if len(jwkSet.Keys) == 0 {
+		return nil, errors.New("failed to parse JWKs: key set is empty")
+	}
 
-	j.cache = jwkSet
-	j.lastUpdate = time.Now()
-	return j.findKeyByID(kid)
-}
-
-// findKeyByID finds a key in the cached JWK set by its kid
-func (j *JWKManager) findKeyByID(kid string) (*rsa.PublicKey, error) {
-	for _, key := range j.cache.Keys {
-		if key.Kid == kid {
-			return convertJWKToPublicKey(key)
+	keys := make(map[string]*rsa.PublicKey, len(jwkSet.Keys))
+	for _, jwk := range jwkSet.Keys {
+		key, err := convertJWKToPublicKey(jwk)
+		if err != nil {
+			j.log.Warnf("Skipping JWK %s: %v", jwk.Kid, err)
+			continue
 		}
+		keys[jwk.Kid] = key
 	}
-	return nil, errors.New("key with the given kid not found")
+	return keys, nil
 }
 
 // convertJWKToPublicKey converts a JWK to an rsa.PublicKey
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Permission scopes what a user may do, either through their own roles or on behalf of an educator
+type Permission string
+
+const (
+	ManageSchedulePermission Permission = "ManageSchedule"
+	ViewBookingsPermission   Permission = "ViewBookings"
+	// BookSessionsPermission is held by students booking sessions for themselves
+	BookSessionsPermission Permission = "BookSessions"
+	// FullAccessPermission is held by organization admins acting for their teachers
+	FullAccessPermission Permission = "FullAccess"
+)
+
+type permissionsKey string
+
+const PermissionsKey permissionsKey = "permissions"
+
+// DelegatedPermissions are the permissions an educator may grant to an assistant
+var DelegatedPermissions = []Permission{ManageSchedulePermission, ViewBookingsPermission}
+
+// rolePermissions are the permissions a user holds through their own roles
+var rolePermissions = map[string][]Permission{
+	UserRole:     {BookSessionsPermission},
+	EducatorRole: {ManageSchedulePermission, ViewBookingsPermission},
+}
+
+// HasPermission reports whether the current user holds a permission. While acting on behalf of an educator only
+// the granted permissions count, where full access implies any permission. Otherwise the user's roles decide.
+func HasPermission(ctx context.Context, permission Permission) bool {
+	if granted, ok := ctx.Value(PermissionsKey).([]Permission); ok {
+		return slices.Contains(granted, permission) || slices.Contains(granted, FullAccessPermission)
+	}
+
+	roles, _ := ctx.Value(UserRolesKey).([]any)
+	for _, r := range roles {
+		role, _ := r.(string)
+		if slices.Contains(rolePermissions[role], permission) {
+			return true
+		}
+	}
+	return false
+}
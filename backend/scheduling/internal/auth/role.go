@@ -1,7 +1,14 @@
 package auth
 
 var (
-	UserRole    = "ROLE_USER"
+	UserRole     = "ROLE_USER"
 	EducatorRole = "ROLE_EDUCATOR"
-	AdminRole   = "ROLE_ADMIN"
+	AdminRole    = "ROLE_ADMIN"
 )
+
+// OrganizationAdminRole is granted in context, not by the identity provider, while an organization
+// admin acts on behalf of one of the organization's teachers
+var OrganizationAdminRole = "ROLE_ORG_ADMIN"
+
+// DelegateRole is granted in context while a user acts on behalf of an educator through a grant
+var DelegateRole = "ROLE_DELEGATE"
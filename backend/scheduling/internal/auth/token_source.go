@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryLeeway refreshes service tokens slightly before they expire
+const tokenExpiryLeeway = 30 * time.Second
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// ServiceTokenSource obtains and caches access tokens for service-to-service calls
+// using the OAuth2 client credentials grant
+type ServiceTokenSource struct {
+	tokenURI     string
+	clientId     string
+	clientSecret string
+	httpClient   *http.Client
+	mu           sync.Mutex
+	token        string
+	expiresAt    time.Time
+}
+
+// NewServiceTokenSource initializes a new ServiceTokenSource
+func NewServiceTokenSource(tokenURI, clientId, clientSecret string, httpClient *http.Client) *ServiceTokenSource {
+	return &ServiceTokenSource{
+		tokenURI:     tokenURI,
+		clientId:     clientId,
+		clientSecret: clientSecret,
+		httpClient:   httpClient,
+	}
+}
+
+// AuthorizationHeader returns a bearer authorization header value, requesting a new token when the cached one expired
+func (s *ServiceTokenSource) AuthorizationHeader(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Before(s.expiresAt) {
+		return "Bearer " + s.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.clientId},
+		"client_secret": {s.clientSecret},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request service token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.New("Service token request failed with status:" + resp.Status)
+	}
+
+	var token tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode service token: %w", err)
+	}
+
+	s.token = token.AccessToken
+	s.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenExpiryLeeway)
+	return "Bearer " + s.token, nil
+}
@@ -1,32 +1,56 @@
 package auth
 
 import (
+	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/internal/cache"
 )
 
 type JWTValidator struct {
 	jwkManager *JWKManager
 	issuer     string
 	audience   string
+	tokens     *cache.Cache[[sha256.Size]byte, map[string]any]
+	tokenTTL   time.Duration
+}
+
+// NewJWTValidator initializes a JWTValidator. Validated tokens are remembered by hash for up to tokenTTL,
+// so repeated requests with the same token skip the signature check.
+func NewJWTValidator(jwkManager *JWKManager, issuer, audience string, tokenTTL time.Duration, maxTokens int, meter metric.Meter) (*JWTValidator, error) {
+	tokens, err := cache.New[[sha256.Size]byte, map[string]any]("tokens", cache.Options{TTL: tokenTTL, MaxEntries: maxTokens}, meter)
+	if err != nil {
+		return nil, err
+	}
+	return &JWTValidator{jwkManager: jwkManager, issuer: issuer, audience: audience, tokens: tokens, tokenTTL: tokenTTL}, nil
 }
 
-// NewJWTValidator initializes a JWTValidator
-func NewJWTValidator(jwkManager *JWKManager, issuer, audience string) *JWTValidator {
-	return &JWTValidator{jwkManager: jwkManager, issuer: issuer, audience: audience}
+// ForgetTokens drops the validated tokens, so tokens signed with a key removed from the set are checked again
+func (v *JWTValidator) ForgetTokens() {
+	v.tokens.Clear()
 }
 
-// ValidateToken validates a JWT token using the JWKManager
-func (v *JWTValidator) ValidateToken(tokenString string) (map[string]any, error) {
+// ValidateToken validates a JWT token using the JWKManager. The claims are shared between requests with
+// the same token and must not be modified.
+func (v *JWTValidator) ValidateToken(ctx context.Context, tokenString string) (map[string]any, error) {
+	key := sha256.Sum256([]byte(tokenString))
+	if claims, ok := v.tokens.Get(ctx, key); ok {
+		return claims, nil
+	}
+
 	x := func(token *jwt.Token) (any, error) {
 		kid, ok := token.Header["kid"].(string)
 		if !ok {
 			return nil, errors.New("kid header is missing")
 		}
 
-		return v.jwkManager.GetJWK(kid)
+		return v.jwkManager.GetJWK(ctx, kid)
 	}
 
 	parsedToken, err := jwt.Parse(tokenString, x, jwt.WithAudience(v.audience), jwt.WithIssuer(v.issuer))
@@ -44,5 +68,42 @@ func (v *JWTValidator) ValidateToken(tokenString string) (map[string]any, error)
 		return nil, fmt.Errorf("failed to parse claims")
 	}
 
+	v.remember(ctx, key, claims)
 	return claims, nil
 }
+
+// remember caches validated claims until the token expires, at most for the token TTL
+func (v *JWTValidator) remember(ctx context.Context, key [sha256.Size]byte, claims jwt.MapClaims) {
+	if v.tokenTTL <= 0 {
+		return
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return
+	}
+	if ttl := min(time.Until(exp.Time), v.tokenTTL); ttl > 0 {
+		v.tokens.SetWithTTL(ctx, key, claims, ttl)
+	}
+}
+
+// Roles returns the realm roles of the validated claims together with the client roles granted on the audience
+func (v *JWTValidator) Roles(claims map[string]any) []any {
+	var roles []any
+
+	if realmAccess, ok := claims["realm_access"].(map[string]any); ok {
+		if realmRoles, ok := realmAccess["roles"].([]any); ok {
+			roles = append(roles, realmRoles...)
+		}
+	}
+
+	if resourceAccess, ok := claims["resource_access"].(map[string]any); ok {
+		if client, ok := resourceAccess[v.audience].(map[string]any); ok {
+			if clientRoles, ok := client["roles"].([]any); ok {
+				roles = append(roles, clientRoles...)
+			}
+		}
+	}
+
+	return roles
+}
@@ -0,0 +1,65 @@
+package availability
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+type window struct {
+	start time.Time
+	end   time.Time
+}
+
+// horizon returns the range the rules are laid over: from the start of the teacher's current local day
+// for the given number of weeks
+func horizon(loc *time.Location, now time.Time, weeks int) (time.Time, time.Time) {
+	local := now.In(loc)
+	firstDay := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	return firstDay.UTC(), firstDay.AddDate(0, 0, weeks*7).UTC()
+}
+
+// ruleWindows lays the weekly rules over every day of the horizon in the teacher's time zone, leaving
+// out blackout dates. Rules are expected to be validated.
+func ruleWindows(rules []*entities.AvailabilityRule, blackouts map[string]bool, loc *time.Location, from time.Time, weeks int) []window {
+	firstDay := from.In(loc)
+
+	var result []window
+	for i := range weeks * 7 {
+		day := time.Date(firstDay.Year(), firstDay.Month(), firstDay.Day()+i, 0, 0, 0, 0, loc)
+		if blackouts[day.Format(dateLayout)] {
+			continue
+		}
+		for _, r := range rules {
+			if r.DayOfWeek != int(day.Weekday()) {
+				continue
+			}
+			start, _ := timeutils.ParseClock(r.StartClock)
+			end, _ := timeutils.ParseClock(r.EndClock)
+			result = append(result, window{start: wallClock(day, start, loc), end: wallClock(day, end, loc)})
+		}
+	}
+	return result
+}
+
+// conflictReason tells why a session would no longer fit the proposed availability, or returns an empty
+// string when a rule window covers it
+func conflictReason(start, end time.Time, loc *time.Location, blackouts map[string]bool, windows []window) string {
+	if blackouts[start.In(loc).Format(dateLayout)] {
+		return BlackoutConflict
+	}
+	for _, w := range windows {
+		if timeutils.IsWithinPeriod(start, end, w.start, w.end) {
+			return ""
+		}
+	}
+	return OutsideAvailabilityConflict
+}
+
+// wallClock resolves an offset from midnight on a local day, so that DST transitions keep the
+// configured hours instead of shifting them
+func wallClock(day time.Time, offset time.Duration, loc *time.Location) time.Time {
+	minutes := int(offset / time.Minute)
+	return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, loc).UTC()
+}
@@ -0,0 +1,191 @@
+package availability
+
+import (
+	"slices"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+const (
+	dateLayout       = "2006-01-02"
+	defaultWeeks     = 4
+	maxWeeks         = 12
+	maxRules         = 50
+	maxBlackoutDates = 100
+
+	// BlackoutConflict marks a session falling on a blackout date
+	BlackoutConflict = "BLACKOUT_DATE"
+	// OutsideAvailabilityConflict marks a session not covered by any rule window
+	OutsideAvailabilityConflict = "OUTSIDE_AVAILABILITY"
+
+	noAvailabilityWarning  = "NO_AVAILABILITY"
+	replacedPeriodsWarning = "WORKING_PERIODS_REPLACED"
+	blackoutOutsideWarning = "BLACKOUT_OUTSIDE_HORIZON"
+	blackoutInPastWarning  = "BLACKOUT_IN_PAST"
+	bookingKind            = "booking"
+	eventKind              = "event"
+)
+
+// swagger:model AvailabilityRulesRequest
+type AvailabilityRulesRequest struct {
+	Timezone      string                     `json:"timezone"`
+	Weeks         int                        `json:"weeks"`
+	Rules         []*AvailabilityRuleRequest `json:"rules"`
+	BlackoutDates []*BlackoutDateRequest     `json:"blackoutDates"`
+}
+
+// swagger:model AvailabilityRuleRequest
+type AvailabilityRuleRequest struct {
+	Day   int    `json:"day"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// swagger:model BlackoutDateRequest
+type BlackoutDateRequest struct {
+	Date   string  `json:"date"`
+	Reason *string `json:"reason"`
+}
+
+// swagger:model AvailabilityRulesResponse
+type AvailabilityRulesResponse struct {
+	Timezone      string                      `json:"timezone"`
+	Weeks         int                         `json:"weeks"`
+	Rules         []*AvailabilityRuleResponse `json:"rules"`
+	BlackoutDates []*BlackoutDateResponse     `json:"blackoutDates"`
+	UpdatedAt     time.Time                   `json:"updatedAt"`
+}
+
+// swagger:model AvailabilityRuleResponse
+type AvailabilityRuleResponse struct {
+	Day   int    `json:"day"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// swagger:model BlackoutDateResponse
+type BlackoutDateResponse struct {
+	Date   string  `json:"date"`
+	Reason *string `json:"reason"`
+}
+
+// swagger:model AvailabilityValidationResponse
+type AvailabilityValidationResponse struct {
+	Valid           bool                            `json:"valid"`
+	WorkingPeriods  int                             `json:"workingPeriods"`
+	ReplacedPeriods int                             `json:"replacedPeriods"`
+	Conflicts       []*AvailabilityConflictResponse `json:"conflicts"`
+	Warnings        []*AvailabilityWarningResponse  `json:"warnings"`
+}
+
+// swagger:model AvailabilityConflictResponse
+type AvailabilityConflictResponse struct {
+	Kind      string    `json:"kind"`
+	Id        int64     `json:"id"`
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Reason    string    `json:"reason"`
+}
+
+// swagger:model AvailabilityWarningResponse
+type AvailabilityWarningResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (a *AvailabilityRulesRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if _, err := time.LoadLocation(a.Timezone); a.Timezone == "" || err != nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Timezone",
+			Message: "must be a valid IANA time zone",
+		})
+	}
+
+	if a.Weeks < 0 || a.Weeks > maxWeeks {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Weeks",
+			Message: "must be between 1 and 12",
+		})
+	}
+
+	if len(a.Rules) > maxRules {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Rules",
+			Message: "must contain at most 50 rules",
+		})
+	}
+
+	rulesValid := true
+	for _, r := range a.Rules {
+		start, errStart := timeutils.ParseClock(r.Start)
+		end, errEnd := timeutils.ParseClock(r.End)
+		if r.Day < int(time.Sunday) || r.Day > int(time.Saturday) || errStart != nil || errEnd != nil || start >= end {
+			rulesValid = false
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "Rules",
+				Message: "must have a day between 0 (Sunday) and 6 (Saturday) and start before end in HH:MM format",
+			})
+			break
+		}
+	}
+
+	if rulesValid && hasOverlappingRules(a.Rules) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Rules",
+			Message: "must not overlap on the same day",
+		})
+	}
+
+	if len(a.BlackoutDates) > maxBlackoutDates {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "BlackoutDates",
+			Message: "must contain at most 100 dates",
+		})
+	}
+
+	dates := make([]string, 0, len(a.BlackoutDates))
+	for _, b := range a.BlackoutDates {
+		if _, err := time.Parse(dateLayout, b.Date); err != nil || (b.Reason != nil && len(*b.Reason) > 200) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "BlackoutDates",
+				Message: "must have dates in YYYY-MM-DD format and reasons of at most 200 characters",
+			})
+			break
+		}
+		if slices.Contains(dates, b.Date) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "BlackoutDates",
+				Message: "must not contain duplicate dates",
+			})
+			break
+		}
+		dates = append(dates, b.Date)
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Availability rules request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
+
+// hasOverlappingRules reports whether two rules of the same day overlap. Rules are expected to be parsable.
+func hasOverlappingRules(rules []*AvailabilityRuleRequest) bool {
+	for i, a := range rules {
+		aStart, _ := timeutils.ParseClock(a.Start)
+		aEnd, _ := timeutils.ParseClock(a.End)
+		for _, b := range rules[i+1:] {
+			bStart, _ := timeutils.ParseClock(b.Start)
+			bEnd, _ := timeutils.ParseClock(b.End)
+			if a.Day == b.Day && aStart < bEnd && bStart < aEnd {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package availability
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type AvailabilityHandler struct {
+	service *AvailabilityService
+}
+
+func NewAvailabilityHandler(service *AvailabilityService) *AvailabilityHandler {
+	return &AvailabilityHandler{service: service}
+}
+
+// GetMyRules retrieves the saved availability rules of the current educator.
+// @Summary      Retrieve availability rules
+// @Description  Retrieves the educator's weekly availability rules, blackout dates, time zone and horizon.
+// @Tags         Availability
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  AvailabilityRulesResponse  "Availability rules"
+// @Failure      404  {object}  error                      "No availability rules saved"
+// @Router       /api/v1/availability/rules [get]
+// @Security 	 BearerAuth
+func (h *AvailabilityHandler) GetMyRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.GetMyRules(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, rules)
+}
+
+// ValidateRules simulates a proposed set of availability rules.
+// @Summary      Validate availability rules
+// @Description  Projects the proposed rules and blackout dates over the horizon without saving them. Returns upcoming bookings and events they would no longer cover as conflicts, plus warnings about the resulting schedule.
+// @Tags         Availability
+// @Accept       json
+// @Produce      json
+// @Param        rules  body      AvailabilityRulesRequest        true  "Proposed availability rules"
+// @Success      200    {object}  AvailabilityValidationResponse  "Projected conflicts and warnings"
+// @Failure      400    {object}  error                           "Invalid input"
+// @Router       /api/v1/availability/rules/validate [post]
+// @Security 	 BearerAuth
+func (h *AvailabilityHandler) ValidateRules(w http.ResponseWriter, r *http.Request) {
+	var request *AvailabilityRulesRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	response, err := h.service.ValidateRules(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, response)
+}
+
+// SaveRules saves availability rules.
+// @Summary      Save availability rules
+// @Description  Saves the rules and blackout dates and regenerates the working periods of the horizon, replacing upcoming periods without sessions. Rejected when upcoming sessions would no longer be covered.
+// @Tags         Availability
+// @Accept       json
+// @Produce      json
+// @Param        rules  body      AvailabilityRulesRequest        true  "Availability rules"
+// @Success      200    {object}  AvailabilityValidationResponse  "Applied schedule summary"
+// @Failure      400    {object}  error                           "Invalid input"
+// @Failure      422    {object}  error                           "Rules conflict with upcoming sessions"
+// @Router       /api/v1/availability/rules [put]
+// @Security 	 BearerAuth
+func (h *AvailabilityHandler) SaveRules(w http.ResponseWriter, r *http.Request) {
+	var request *AvailabilityRulesRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	response, err := h.service.SaveRules(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, response)
+}
@@ -0,0 +1,84 @@
+package availability
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToRuleSet(educatorId uuid.UUID, r *AvailabilityRulesRequest) *entities.AvailabilityRuleSet {
+	weeks := r.Weeks
+	if weeks == 0 {
+		weeks = defaultWeeks
+	}
+
+	now := time.Now().UTC()
+	return &entities.AvailabilityRuleSet{
+		EducatorId: educatorId,
+		Timezone:   r.Timezone,
+		Weeks:      weeks,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func MapRequestToRules(educatorId uuid.UUID, r *AvailabilityRulesRequest) []*entities.AvailabilityRule {
+	rules := make([]*entities.AvailabilityRule, len(r.Rules))
+	for i, rule := range r.Rules {
+		rules[i] = &entities.AvailabilityRule{
+			EducatorId: educatorId,
+			DayOfWeek:  rule.Day,
+			StartClock: rule.Start,
+			EndClock:   rule.End,
+		}
+	}
+	return rules
+}
+
+// MapRequestToBlackoutDates maps blackout dates of a validated request
+func MapRequestToBlackoutDates(educatorId uuid.UUID, r *AvailabilityRulesRequest) []*entities.BlackoutDate {
+	dates := make([]*entities.BlackoutDate, len(r.BlackoutDates))
+	for i, b := range r.BlackoutDates {
+		date, _ := time.Parse(dateLayout, b.Date)
+		dates[i] = &entities.BlackoutDate{
+			EducatorId:   educatorId,
+			BlackoutDate: date,
+			Reason:       b.Reason,
+		}
+	}
+	return dates
+}
+
+func MapWindowsToWorkingPeriods(educatorId uuid.UUID, windows []window) []*entities.WorkingPeriod {
+	now := time.Now().UTC()
+	periods := make([]*entities.WorkingPeriod, len(windows))
+	for i, w := range windows {
+		periods[i] = &entities.WorkingPeriod{
+			UserId:    educatorId,
+			StartTime: w.start,
+			EndTime:   w.end,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	}
+	return periods
+}
+
+func MapRuleSetToResponse(set *entities.AvailabilityRuleSet, rules []*entities.AvailabilityRule, dates []*entities.BlackoutDate) *AvailabilityRulesResponse {
+	response := &AvailabilityRulesResponse{
+		Timezone:      set.Timezone,
+		Weeks:         set.Weeks,
+		Rules:         make([]*AvailabilityRuleResponse, len(rules)),
+		BlackoutDates: make([]*BlackoutDateResponse, len(dates)),
+		UpdatedAt:     set.UpdatedAt,
+	}
+	for i, r := range rules {
+		response.Rules[i] = &AvailabilityRuleResponse{Day: r.DayOfWeek, Start: r.StartClock, End: r.EndClock}
+	}
+	for i, d := range dates {
+		response.BlackoutDates[i] = &BlackoutDateResponse{Date: d.BlackoutDate.Format(dateLayout), Reason: d.Reason}
+	}
+	return response
+}
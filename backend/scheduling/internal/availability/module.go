@@ -0,0 +1,20 @@
+package availability
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeAvailabilityService(log logger.Logger, db *sqlx.DB, schedules ScheduleInvalidator) *AvailabilityService {
+	repo := NewAvailabilityRepository(db)
+	service := NewAvailabilityService(log, repo, schedules)
+	return service
+}
+
+func InitializeAvailabilityHTTPHandler(service *AvailabilityService) http.Handler {
+	handler := NewAvailabilityHandler(service)
+	return Routes(handler)
+}
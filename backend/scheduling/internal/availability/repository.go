@@ -0,0 +1,177 @@
+package availability
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// PeriodUsage holds a working period and whether any booking or scheduled event refers to it
+type PeriodUsage struct {
+	Id        int64     `db:"id"`
+	StartTime time.Time `db:"start_time"`
+	EndTime   time.Time `db:"end_time"`
+	Used      bool      `db:"used"`
+}
+
+type AvailabilityRepo struct {
+	db *sqlx.DB
+}
+
+func NewAvailabilityRepository(db *sqlx.DB) *AvailabilityRepo {
+	return &AvailabilityRepo{db: db}
+}
+
+// GetRuleSet retrieves the saved availability rule set of an educator
+func (r *AvailabilityRepo) GetRuleSet(ctx context.Context, educatorId uuid.UUID) (*entities.AvailabilityRuleSet, error) {
+	const query = `
+		SELECT educator_id, timezone, weeks, created_at, updated_at
+		FROM availability_rule_set
+		WHERE educator_id = $1
+	`
+	return database.FetchSingle[entities.AvailabilityRuleSet](ctx, r.db, query, educatorId)
+}
+
+// GetRules retrieves the weekly availability rules of an educator
+func (r *AvailabilityRepo) GetRules(ctx context.Context, educatorId uuid.UUID) ([]*entities.AvailabilityRule, error) {
+	const query = `
+		SELECT id, educator_id, day_of_week, start_clock, end_clock
+		FROM availability_rule
+		WHERE educator_id = $1
+		ORDER BY day_of_week, start_clock
+	`
+	return database.FetchMultiple[entities.AvailabilityRule](ctx, r.db, query, educatorId)
+}
+
+// GetBlackoutDates retrieves the blackout dates of an educator
+func (r *AvailabilityRepo) GetBlackoutDates(ctx context.Context, educatorId uuid.UUID) ([]*entities.BlackoutDate, error) {
+	const query = `
+		SELECT educator_id, blackout_date, reason
+		FROM blackout_date
+		WHERE educator_id = $1
+		ORDER BY blackout_date
+	`
+	return database.FetchMultiple[entities.BlackoutDate](ctx, r.db, query, educatorId)
+}
+
+// GetUpcomingBookings retrieves not cancelled bookings of an educator starting within a range
+func (r *AvailabilityRepo) GetUpcomingBookings(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, COALESCE(title, '') AS title, start_time, end_time, status, price, created_at, updated_at
+		FROM booking
+		WHERE educator_id = $1 AND status <> $2 AND scheduled_event_id IS NULL AND start_time >= $3 AND start_time < $4
+		ORDER BY start_time
+	`
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, educatorId, entities.Cancelled, from, to)
+}
+
+// GetUpcomingScheduledEvents retrieves scheduled events of an educator starting within a range
+func (r *AvailabilityRepo) GetUpcomingScheduledEvents(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.ScheduledEvent, error) {
+	const query = `
+		SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, COALESCE(title, '') AS title, working_period_id, start_time, end_time, max_participants, price, created_at, updated_at
+		FROM scheduled_event
+		WHERE user_id = $1 AND start_time >= $2 AND start_time < $3 AND deleted_at IS NULL
+		ORDER BY start_time
+	`
+	return database.FetchMultiple[entities.ScheduledEvent](ctx, r.db, query, educatorId, from, to)
+}
+
+// GetWorkingPeriodUsage retrieves working periods of an educator intersecting a range, with whether
+// they are referenced by bookings or scheduled events
+func (r *AvailabilityRepo) GetWorkingPeriodUsage(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*PeriodUsage, error) {
+	const query = `
+		SELECT wp.id, wp.start_time, wp.end_time,
+			EXISTS (SELECT 1 FROM booking b WHERE b.working_period_id = wp.id)
+				OR EXISTS (SELECT 1 FROM scheduled_event se WHERE se.working_period_id = wp.id AND se.deleted_at IS NULL) AS used
+		FROM working_period wp
+		WHERE wp.user_id = $1 AND wp.start_time < $3 AND wp.end_time > $2 AND wp.deleted_at IS NULL
+		ORDER BY wp.start_time
+	`
+	return database.FetchMultiple[PeriodUsage](ctx, r.db, query, educatorId, from, to)
+}
+
+// SaveRuleSet replaces the educator's rules and blackout dates, removes the given unused working periods
+// and adds the generated ones in one transaction. Periods that got a booking or event in the meantime are kept.
+func (r *AvailabilityRepo) SaveRuleSet(
+	ctx context.Context,
+	set *entities.AvailabilityRuleSet,
+	rules []*entities.AvailabilityRule,
+	dates []*entities.BlackoutDate,
+	removedPeriodIds []int64,
+	workingPeriods []*entities.WorkingPeriod,
+) error {
+	const ruleSetQuery = `
+		INSERT INTO availability_rule_set (educator_id, timezone, weeks, created_at, updated_at)
+		VALUES (:educator_id, :timezone, :weeks, :created_at, :updated_at)
+		ON CONFLICT (educator_id) DO UPDATE
+		SET timezone = EXCLUDED.timezone, weeks = EXCLUDED.weeks, updated_at = EXCLUDED.updated_at
+	`
+	const deleteRulesQuery = `DELETE FROM availability_rule WHERE educator_id = $1`
+	const deleteDatesQuery = `DELETE FROM blackout_date WHERE educator_id = $1`
+	const ruleQuery = `
+		INSERT INTO availability_rule (educator_id, day_of_week, start_clock, end_clock)
+		VALUES (:educator_id, :day_of_week, :start_clock, :end_clock)
+	`
+	const dateQuery = `
+		INSERT INTO blackout_date (educator_id, blackout_date, reason)
+		VALUES (:educator_id, :blackout_date, :reason)
+	`
+	removePeriodsQuery := database.WithTombstones(database.TombstoneWorkingPeriod, `
+		UPDATE working_period wp SET deleted_at = current_timestamp
+		WHERE wp.user_id = $1 AND wp.id = ANY($2) AND wp.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM booking b WHERE b.working_period_id = wp.id)
+		AND NOT EXISTS (SELECT 1 FROM scheduled_event se WHERE se.working_period_id = wp.id AND se.deleted_at IS NULL)
+	`)
+	const workingPeriodQuery = `
+		INSERT INTO working_period (user_id, start_time, end_time, created_at, updated_at)
+		VALUES (:user_id, :start_time, :end_time, :created_at, :updated_at)
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.NamedExecContext(ctx, ruleSetQuery, set); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	for _, query := range []string{deleteRulesQuery, deleteDatesQuery} {
+		if _, err := tx.ExecContext(ctx, query, set.EducatorId); err != nil {
+			return apperrors.NewInternal(err)
+		}
+	}
+	for _, rule := range rules {
+		if _, err := tx.NamedExecContext(ctx, ruleQuery, rule); err != nil {
+			return apperrors.NewInternal(err)
+		}
+	}
+	for _, date := range dates {
+		if _, err := tx.NamedExecContext(ctx, dateQuery, date); err != nil {
+			return apperrors.NewInternal(err)
+		}
+	}
+
+	if len(removedPeriodIds) > 0 {
+		if _, err := tx.ExecContext(ctx, removePeriodsQuery, set.EducatorId, pq.Array(removedPeriodIds)); err != nil {
+			return apperrors.NewInternal(err)
+		}
+	}
+	for _, wp := range workingPeriods {
+		if _, err := tx.NamedExecContext(ctx, workingPeriodQuery, wp); err != nil {
+			return apperrors.NewInternal(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package availability
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *AvailabilityHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.EducatorRole))
+	r.Get("/rules", handler.GetMyRules)
+	r.Post("/rules/validate", handler.ValidateRules)
+	r.Put("/rules", handler.SaveRules)
+
+	return r
+}
@@ -0,0 +1,257 @@
+package availability
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+type AvailabilityRepository interface {
+	GetRuleSet(ctx context.Context, educatorId uuid.UUID) (*entities.AvailabilityRuleSet, error)
+	GetRules(ctx context.Context, educatorId uuid.UUID) ([]*entities.AvailabilityRule, error)
+	GetBlackoutDates(ctx context.Context, educatorId uuid.UUID) ([]*entities.BlackoutDate, error)
+	GetUpcomingBookings(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.Booking, error)
+	GetUpcomingScheduledEvents(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.ScheduledEvent, error)
+	GetWorkingPeriodUsage(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*PeriodUsage, error)
+	SaveRuleSet(
+		ctx context.Context,
+		set *entities.AvailabilityRuleSet,
+		rules []*entities.AvailabilityRule,
+		dates []*entities.BlackoutDate,
+		removedPeriodIds []int64,
+		workingPeriods []*entities.WorkingPeriod,
+	) error
+}
+
+// ScheduleInvalidator drops the cached schedules of an educator after their bookings or slots changed
+type ScheduleInvalidator interface {
+	Invalidate(ctx context.Context, owner string)
+}
+
+type AvailabilityService struct {
+	log       logger.Logger
+	repo      AvailabilityRepository
+	schedules ScheduleInvalidator
+}
+
+func NewAvailabilityService(log logger.Logger, repo AvailabilityRepository, schedules ScheduleInvalidator) *AvailabilityService {
+	return &AvailabilityService{log: log, repo: repo, schedules: schedules}
+}
+
+// simulation holds the projected outcome of saving a rule set
+type simulation struct {
+	response         *AvailabilityValidationResponse
+	set              *entities.AvailabilityRuleSet
+	rules            []*entities.AvailabilityRule
+	dates            []*entities.BlackoutDate
+	removedPeriodIds []int64
+	workingPeriods   []*entities.WorkingPeriod
+}
+
+func (s *AvailabilityService) GetMyRules(ctx context.Context) (*AvailabilityRulesResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	set, err := s.repo.GetRuleSet(ctx, userId)
+	if err != nil {
+		log.Error("failed to get availability rule set", err)
+		return nil, err
+	}
+
+	rules, err := s.repo.GetRules(ctx, userId)
+	if err != nil {
+		log.Error("failed to get availability rules", err)
+		return nil, err
+	}
+
+	dates, err := s.repo.GetBlackoutDates(ctx, userId)
+	if err != nil {
+		log.Error("failed to get blackout dates", err)
+		return nil, err
+	}
+
+	return MapRuleSetToResponse(set, rules, dates), nil
+}
+
+// ValidateRules projects the proposed rules over the horizon without saving them and reports upcoming
+// sessions they would no longer cover, along with warnings about the resulting schedule
+func (s *AvailabilityService) ValidateRules(ctx context.Context, request *AvailabilityRulesRequest) (*AvailabilityValidationResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	sim, err := s.simulate(ctx, userId, request, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to simulate availability rules", err)
+		return nil, err
+	}
+
+	return sim.response, nil
+}
+
+// SaveRules saves the rules and blackout dates and regenerates the working periods of the horizon: future
+// periods without sessions are replaced by the rule windows. Rules conflicting with upcoming sessions are
+// rejected, so teachers resolve those sessions first.
+func (s *AvailabilityService) SaveRules(ctx context.Context, request *AvailabilityRulesRequest) (*AvailabilityValidationResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	sim, err := s.simulate(ctx, userId, request, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to simulate availability rules", err)
+		return nil, err
+	}
+
+	if !sim.response.Valid {
+		return nil, apperrors.NewUnprocessedEntity("Availability rules conflict with upcoming sessions", apperrors.ErrAvailabilityConflict)
+	}
+
+	if err := s.repo.SaveRuleSet(ctx, sim.set, sim.rules, sim.dates, sim.removedPeriodIds, sim.workingPeriods); err != nil {
+		log.Error("failed to save availability rules", err)
+		return nil, err
+	}
+	s.schedules.Invalidate(ctx, userId.String())
+
+	return sim.response, nil
+}
+
+func (s *AvailabilityService) simulate(ctx context.Context, userId uuid.UUID, request *AvailabilityRulesRequest, now time.Time) (*simulation, error) {
+	loc, _ := time.LoadLocation(request.Timezone)
+
+	sim := &simulation{
+		set:   MapRequestToRuleSet(userId, request),
+		rules: MapRequestToRules(userId, request),
+		dates: MapRequestToBlackoutDates(userId, request),
+		response: &AvailabilityValidationResponse{
+			Conflicts: []*AvailabilityConflictResponse{},
+			Warnings:  []*AvailabilityWarningResponse{},
+		},
+	}
+
+	blackouts := make(map[string]bool, len(sim.dates))
+	for _, d := range request.BlackoutDates {
+		blackouts[d.Date] = true
+	}
+
+	from, to := horizon(loc, now, sim.set.Weeks)
+	windows := ruleWindows(sim.rules, blackouts, loc, from, sim.set.Weeks)
+
+	bookings, err := s.repo.GetUpcomingBookings(ctx, userId, now, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bookings {
+		if reason := conflictReason(b.StartTime, b.EndTime, loc, blackouts, windows); reason != "" {
+			sim.response.Conflicts = append(sim.response.Conflicts, &AvailabilityConflictResponse{
+				Kind: bookingKind, Id: b.Id, Title: b.Title, StartTime: b.StartTime, EndTime: b.EndTime, Reason: reason,
+			})
+		}
+	}
+
+	events, err := s.repo.GetUpcomingScheduledEvents(ctx, userId, now, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		if reason := conflictReason(e.StartTime, e.EndTime, loc, blackouts, windows); reason != "" {
+			sim.response.Conflicts = append(sim.response.Conflicts, &AvailabilityConflictResponse{
+				Kind: eventKind, Id: e.Id, Title: e.Title, StartTime: e.StartTime, EndTime: e.EndTime, Reason: reason,
+			})
+		}
+	}
+
+	periods, err := s.repo.GetWorkingPeriodUsage(ctx, userId, now, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []*PeriodUsage
+	for _, p := range periods {
+		if !p.Used && !p.StartTime.Before(now) && p.StartTime.Before(to) {
+			sim.removedPeriodIds = append(sim.removedPeriodIds, p.Id)
+			continue
+		}
+		kept = append(kept, p)
+	}
+
+	var created []window
+	for _, w := range windows {
+		if w.start.Before(now) || overlapsAny(w, kept) {
+			continue
+		}
+		created = append(created, w)
+	}
+	sim.workingPeriods = MapWindowsToWorkingPeriods(userId, created)
+
+	sim.response.Valid = len(sim.response.Conflicts) == 0
+	sim.response.WorkingPeriods = len(created)
+	sim.response.ReplacedPeriods = len(sim.removedPeriodIds)
+	sim.response.Warnings = buildWarnings(request, sim, loc, now, to)
+
+	return sim, nil
+}
+
+func buildWarnings(request *AvailabilityRulesRequest, sim *simulation, loc *time.Location, now, to time.Time) []*AvailabilityWarningResponse {
+	warnings := []*AvailabilityWarningResponse{}
+
+	if sim.response.WorkingPeriods == 0 {
+		warnings = append(warnings, &AvailabilityWarningResponse{
+			Code:    noAvailabilityWarning,
+			Message: fmt.Sprintf("The rules create no new working periods within the next %d weeks", sim.set.Weeks),
+		})
+	}
+
+	if sim.response.ReplacedPeriods > 0 {
+		warnings = append(warnings, &AvailabilityWarningResponse{
+			Code:    replacedPeriodsWarning,
+			Message: fmt.Sprintf("%d upcoming working periods without sessions are replaced by the rules", sim.response.ReplacedPeriods),
+		})
+	}
+
+	today := now.In(loc).Format(dateLayout)
+	last := to.In(loc).Format(dateLayout)
+	for _, d := range request.BlackoutDates {
+		switch {
+		case d.Date < today:
+			warnings = append(warnings, &AvailabilityWarningResponse{
+				Code:    blackoutInPastWarning,
+				Message: fmt.Sprintf("Blackout date %s is in the past", d.Date),
+			})
+		case d.Date >= last:
+			warnings = append(warnings, &AvailabilityWarningResponse{
+				Code:    blackoutOutsideWarning,
+				Message: fmt.Sprintf("Blackout date %s is outside the %d week horizon", d.Date, sim.set.Weeks),
+			})
+		}
+	}
+
+	return warnings
+}
+
+func overlapsAny(w window, periods []*PeriodUsage) bool {
+	for _, p := range periods {
+		if timeutils.IsOverlapping(w.start, w.end, p.StartTime, p.EndTime) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package booking
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+	"github.com/maksmelnyk/scheduling/internal/webhooks"
+)
+
+// Parties a booking is cancelled by
+const (
+	cancelledByStudent  = "student"
+	cancelledByEducator = "educator"
+)
+
+// QuoteMyCancellation evaluates the cancellation rule of a booking of the current user without cancelling it
+func (s *BookingService) QuoteMyCancellation(ctx context.Context, id int64) (*BookingCancellationResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	booking, rule, err := s.getOwnCancellableBooking(ctx, id)
+	if err != nil {
+		log.Error("Failed to get cancellable booking", err)
+		return nil, err
+	}
+
+	return evaluateCancellation(rule, booking, time.Now().UTC())
+}
+
+// CancelMyBooking cancels a booking of the current user when its cancellation rule allows it. The refund and
+// penalty are published to the payment service, the educator is notified and the freed place of a scheduled
+// event is passed on to its waitlist. Side effect failures are logged only. The reason given is stored for
+// the cancellation reason analytics.
+func (s *BookingService) CancelMyBooking(ctx context.Context, id int64, request *CancelMyBookingRequest) (*BookingCancellationResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	booking, rule, err := s.getOwnCancellableBooking(ctx, id)
+	if err != nil {
+		log.Error("Failed to get cancellable booking", err)
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	terms, err := evaluateCancellation(rule, booking, now)
+	if err != nil {
+		log.Error("Cancellation rejected by rule", err)
+		return nil, err
+	}
+
+	cancelled, err := s.repo.CancelStudentBooking(ctx, booking.Id, booking.StudentId, MapCancelMyBookingRequestToEntity(request, booking.Id, now))
+	if err != nil {
+		log.Error("Failed to cancel booking", err)
+		return nil, err
+	}
+	if !cancelled {
+		return nil, apperrors.NewConflict("Booking is already cancelled", apperrors.ErrBookingStatus)
+	}
+	s.metrics.recordCancelled(ctx, reasonStudent)
+	s.schedules.Invalidate(ctx, booking.EducatorId.String())
+	from := booking.Status
+	booking.Status = entities.Cancelled
+	publishTransition(ctx, log, s.publisher, booking, from, now)
+	s.dispatchBookings(ctx, webhooks.EventBookingCancelled, booking)
+
+	s.publishCancellationSettled(ctx, booking, cancelledByStudent, terms)
+	s.notifyEducatorOfCancellation(ctx, booking)
+	promoteWaitlist(ctx, log, s.waitlist, booking)
+
+	return terms, nil
+}
+
+func (s *BookingService) getOwnCancellableBooking(ctx context.Context, id int64) (*entities.Booking, *entities.CancellationRule, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	booking, err := s.repo.GetBookingById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if booking.StudentId != userId {
+		return nil, nil, apperrors.NewForbidden("Access denied")
+	}
+
+	if booking.Status == entities.Cancelled {
+		return nil, nil, apperrors.NewConflict("Booking is already cancelled", apperrors.ErrBookingStatus)
+	}
+	if err := checkTransition(booking, entities.Cancelled, time.Now().UTC()); err != nil {
+		return nil, nil, err
+	}
+
+	rule, err := s.repo.GetCancellationRule(ctx, booking.EducatorId, booking.SessionTypeId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return booking, rule, nil
+}
+
+// evaluateCancellation applies a cancellation rule to a booking cancelled at now. Cancellations with less
+// than the minimum notice are rejected, within the free cancellation window the penalty percentage of the
+// price is kept and the rest refunded. Without a rule a booking is refunded in full until it starts.
+func evaluateCancellation(rule *entities.CancellationRule, booking *entities.Booking, now time.Time) (*BookingCancellationResponse, error) {
+	terms := fullRefund(booking)
+	if rule != nil {
+		terms.RuleId = &rule.Id
+		terms.FreeUntil = booking.StartTime.Add(-time.Duration(rule.FreeCancellationHours) * time.Hour)
+		terms.CancellableUntil = booking.StartTime.Add(-time.Duration(rule.MinNoticeHours) * time.Hour)
+	}
+
+	if !now.Before(terms.CancellableUntil) {
+		return nil, apperrors.NewUnprocessedEntity("Booking can no longer be cancelled", apperrors.ErrCancellationNotAllowed)
+	}
+
+	if rule != nil && !now.Before(terms.FreeUntil) {
+		terms.PenaltyPercent = rule.PenaltyPercent
+		terms.PenaltyAmount = math.Round(booking.Price*rule.PenaltyPercent) / 100
+		terms.RefundAmount = math.Round((booking.Price-terms.PenaltyAmount)*100) / 100
+	}
+
+	return terms, nil
+}
+
+// fullRefund is the outcome of a cancellation free of charge, such as one made by the educator
+func fullRefund(booking *entities.Booking) *BookingCancellationResponse {
+	return &BookingCancellationResponse{
+		BookingId:        booking.Id,
+		Price:            booking.Price,
+		RefundAmount:     booking.Price,
+		FreeUntil:        booking.StartTime,
+		CancellableUntil: booking.StartTime,
+	}
+}
+
+func (s *BookingService) publishCancellationSettled(ctx context.Context, booking *entities.Booking, cancelledBy string, terms *BookingCancellationResponse) {
+	log := logger.FromContext(ctx, s.log)
+
+	err := s.publisher.Publish(
+		ctx,
+		messaging.CancellationKey,
+		messaging.NewCancellationSettledEvent(
+			booking.Id,
+			booking.StudentId.String(),
+			booking.EducatorId.String(),
+			booking.ProductId,
+			cancelledBy,
+			terms.RuleId,
+			terms.Price,
+			terms.RefundAmount,
+			terms.PenaltyAmount,
+		),
+	)
+	if err != nil {
+		log.Errorf("Failed to publish cancellation settlement of booking %d: %v", booking.Id, err)
+	}
+}
+
+func (s *BookingService) notifyEducatorOfCancellation(ctx context.Context, booking *entities.Booking) {
+	log := logger.FromContext(ctx, s.log)
+
+	data := map[string]string{
+		"bookingId": strconv.FormatInt(booking.Id, 10),
+		"title":     booking.Title,
+		"startTime": booking.StartTime.UTC().Format(time.RFC3339),
+	}
+
+	if err := s.notifier.Notify(ctx, booking.EducatorId, notifications.BookingCancelledNotification, data); err != nil {
+		log.Errorf("Failed to notify educator about cancelled booking %d: %v", booking.Id, err)
+	}
+}
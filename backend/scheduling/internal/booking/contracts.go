@@ -1,19 +1,124 @@
 package booking
 
 import (
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/schedule"
+	"github.com/maksmelnyk/scheduling/internal/taxes"
 )
 
 // swagger:model BookingRequest
 type BookingRequest struct {
 	EnrollmentId    int64
 	WorkingPeriodId int64
+	SessionTypeId   *int64
 	StartTime       time.Time
 	EndTime         time.Time
 }
 
+// swagger:model LinkBookingRequest
+type LinkBookingRequest struct {
+	EnrollmentId int64
+}
+
+// swagger:model CancelMyBookingRequest
+type CancelMyBookingRequest struct {
+	Reason int     `json:"reason"`
+	Note   *string `json:"note"`
+}
+
+// BookingListQuery holds the filters, sort order and page of a booking listing as given in the query string
+type BookingListQuery struct {
+	EducatorId *uuid.UUID
+	StudentId  *uuid.UUID
+	Statuses   []int
+	From       *time.Time
+	To         *time.Time
+	Sort       string
+	Cursor     string
+	Limit      int
+}
+
+// swagger:model BookingPageResponse
+type BookingPageResponse struct {
+	Items []*schedule.BookingResponse `json:"items"`
+	// NextCursor fetches the page after this one, null on the last page
+	NextCursor *string `json:"nextCursor"`
+}
+
+// swagger:model BookingQuoteResponse
+type BookingQuoteResponse struct {
+	ProductId *int64              `json:"productId"`
+	Title     string              `json:"title"`
+	StartTime time.Time           `json:"startTime"`
+	EndTime   time.Time           `json:"endTime"`
+	Price     float64             `json:"price"`
+	Tax       *taxes.TaxBreakdown `json:"tax"`
+}
+
+// swagger:model BookingHoldResponse
+type BookingHoldResponse struct {
+	Id        int64     `json:"id"`
+	ProductId int64     `json:"productId"`
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Price     float64   `json:"price"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// swagger:model BookingCheckInCodeResponse
+type BookingCheckInCodeResponse struct {
+	BookingId int64  `json:"bookingId"`
+	Code      string `json:"code"`
+}
+
+// swagger:model BookingCancellationResponse
+type BookingCancellationResponse struct {
+	BookingId        int64     `json:"bookingId"`
+	RuleId           *int64    `json:"ruleId"`
+	Price            float64   `json:"price"`
+	RefundAmount     float64   `json:"refundAmount"`
+	PenaltyAmount    float64   `json:"penaltyAmount"`
+	PenaltyPercent   float64   `json:"penaltyPercent"`
+	FreeUntil        time.Time `json:"freeUntil"`
+	CancellableUntil time.Time `json:"cancellableUntil"`
+}
+
+// swagger:model ScheduledEventParticipantsResponse
+type ScheduledEventParticipantsResponse struct {
+	ScheduledEventId int64                  `json:"scheduledEventId"`
+	Title            string                 `json:"title"`
+	StartTime        time.Time              `json:"startTime"`
+	EndTime          time.Time              `json:"endTime"`
+	Capacity         int                    `json:"capacity"`
+	Enrolled         int                    `json:"enrolled"`
+	Offered          int                    `json:"offered"`
+	Available        int                    `json:"available"`
+	Participants     []*ParticipantResponse `json:"participants"`
+}
+
+// swagger:model ParticipantResponse
+type ParticipantResponse struct {
+	BookingId int64     `json:"bookingId"`
+	StudentId uuid.UUID `json:"studentId"`
+	Status    string    `json:"status"`
+	BookedAt  time.Time `json:"bookedAt"`
+}
+
+// swagger:model BookingStatusTransitionResponse
+type BookingStatusTransitionResponse struct {
+	FromStatus *string    `json:"fromStatus"`
+	ToStatus   string     `json:"toStatus"`
+	ActorId    *uuid.UUID `json:"actorId"`
+	ChangedAt  time.Time  `json:"changedAt"`
+}
+
 func (b *BookingRequest) Validate() error {
 	var errors []apperrors.ValidationErrorDetail
 
@@ -60,3 +165,45 @@ func (b *BookingRequest) Validate() error {
 
 	return nil
 }
+
+func (c *CancelMyBookingRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if c.Reason < int(entities.ReasonScheduleConflict) || c.Reason > int(entities.ReasonOther) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Reason",
+			Message: "must be 0 (schedule conflict), 1 (illness), 2 (found alternative), 3 (price), 4 (educator fit), 5 (no longer needed) or 6 (other)",
+		})
+	}
+
+	if c.Reason == int(entities.ReasonOther) && (c.Note == nil || strings.TrimSpace(*c.Note) == "") {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Note",
+			Message: "must not be empty when the reason is other",
+		})
+	}
+
+	if c.Note != nil && len(*c.Note) > 1000 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Note",
+			Message: "must not exceed 1000 characters",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Cancellation request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
+
+func (b *LinkBookingRequest) Validate() error {
+	if b.EnrollmentId <= 0 {
+		return apperrors.NewValidation("Booking request data failed validation", apperrors.ErrValidationFailed, []apperrors.ValidationErrorDetail{{
+			Field:   "EnrollmentId",
+			Message: "must be greater than zero",
+		}})
+	}
+
+	return nil
+}
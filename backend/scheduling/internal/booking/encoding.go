@@ -0,0 +1,21 @@
+package booking
+
+import (
+	"github.com/maksmelnyk/scheduling/internal/api"
+)
+
+// AppendJSON encodes a page of the booking listing without reflection
+func (p *BookingPageResponse) AppendJSON(dst []byte) []byte {
+	if p == nil {
+		return append(dst, "null"...)
+	}
+	dst = append(dst, `{"items":`...)
+	dst = api.AppendArray(dst, p.Items)
+	dst = append(dst, `,"nextCursor":`...)
+	if p.NextCursor == nil {
+		dst = append(dst, "null"...)
+	} else {
+		dst = api.AppendString(dst, *p.NextCursor)
+	}
+	return append(dst, '}')
+}
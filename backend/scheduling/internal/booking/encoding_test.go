@@ -0,0 +1,88 @@
+package booking
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/schedule"
+)
+
+func newTestBookings(n int) []*schedule.BookingResponse {
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	educatorId := uuid.New()
+	items := make([]*schedule.BookingResponse, n)
+	for i := range items {
+		sessionTypeId := int64(i % 3)
+		items[i] = &schedule.BookingResponse{
+			Id:              int64(i + 1),
+			EducatorId:      educatorId,
+			StudentId:       uuid.New(),
+			ProductId:       int64(100 + i),
+			SessionTypeId:   &sessionTypeId,
+			WorkingPeriodId: 9,
+			StartTime:       start.Add(time.Duration(i) * time.Hour),
+			EndTime:         start.Add(time.Duration(i)*time.Hour + 45*time.Minute),
+			Status:          i % 5,
+			Price:           25.5,
+			Version:         1,
+		}
+	}
+	return items
+}
+
+func TestBookingPageResponseMatchesEncodingJson(t *testing.T) {
+	cursor := "eyJpZCI6NDIsInN0YXJ0IjoiMjAyNi0xMC0xNCJ9"
+	escaped := `a"b\c<d>&e` + "\n é"
+	cases := map[string]*BookingPageResponse{
+		"nil page":       nil,
+		"nil items":      {},
+		"empty items":    {Items: []*schedule.BookingResponse{}},
+		"nil item":       {Items: []*schedule.BookingResponse{nil}},
+		"last page":      {Items: newTestBookings(2)},
+		"next cursor":    {Items: newTestBookings(3), NextCursor: &cursor},
+		"escaped cursor": {Items: []*schedule.BookingResponse{}, NextCursor: &escaped},
+		"time formatting": {Items: []*schedule.BookingResponse{{
+			StartTime: time.Date(2026, 10, 14, 9, 0, 0, 5000, time.FixedZone("", 5*3600+45*60)),
+			EndTime:   time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local),
+		}}},
+	}
+
+	for name, page := range cases {
+		t.Run(name, func(t *testing.T) {
+			want, err := json.Marshal(page)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			if got := page.AppendJSON(nil); string(got) != string(want) {
+				t.Errorf("AppendJSON\n got: %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkBookingPageResponse(b *testing.B) {
+	cursor := "eyJpZCI6NDIsInN0YXJ0IjoiMjAyNi0xMC0xNCJ9"
+	for _, n := range []int{20, 100} {
+		page := &BookingPageResponse{Items: newTestBookings(n), NextCursor: &cursor}
+
+		b.Run(fmt.Sprintf("AppendJSON/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 4096)
+			for b.Loop() {
+				buf = page.AppendJSON(buf[:0])
+			}
+		})
+		b.Run(fmt.Sprintf("encoding/json/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := json.Marshal(page); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+	"github.com/maksmelnyk/scheduling/internal/payments"
+)
+
+type PendingExpiryRepository interface {
+	GetExpiredPendingBookings(ctx context.Context, defaultTTLMinutes int, now time.Time, afterCreatedAt time.Time, afterId int64, limit int) ([]*entities.Booking, error)
+	ExpirePendingBooking(ctx context.Context, id int64, from entities.BookingStatus, now time.Time) (bool, error)
+}
+
+// PaymentStatusProvider queries payment state directly from the payment service
+type PaymentStatusProvider interface {
+	GetPaymentStatus(ctx context.Context, userId uuid.UUID, productId int64, scheduledEventId *int64) (*payments.PaymentStatusResponse, error)
+}
+
+// PendingExpiryJob periodically cancels pending bookings and bookings awaiting payment that were not
+// confirmed in time
+type PendingExpiryJob struct {
+	log       logger.Logger
+	repo      PendingExpiryRepository
+	payments  PaymentStatusProvider
+	notifier  Notifier
+	waitlist  WaitlistPromoter
+	publisher *messaging.Publisher
+	cfg       *config.BookingExpiryConfig
+	metrics   *bookingMetrics
+}
+
+func NewPendingExpiryJob(
+	log logger.Logger,
+	repo PendingExpiryRepository,
+	payments PaymentStatusProvider,
+	notifier Notifier,
+	waitlist WaitlistPromoter,
+	publisher *messaging.Publisher,
+	cfg *config.BookingExpiryConfig,
+	metrics *bookingMetrics,
+) *PendingExpiryJob {
+	return &PendingExpiryJob{
+		log:       log,
+		repo:      repo,
+		payments:  payments,
+		notifier:  notifier,
+		waitlist:  waitlist,
+		publisher: publisher,
+		cfg:       cfg,
+		metrics:   metrics,
+	}
+}
+
+// Run expires pending bookings on every interval until the context is cancelled
+func (j *PendingExpiryJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.ExpirePendingBookings(ctx); err != nil {
+				j.log.Errorf("Failed to expire pending bookings: %v", err)
+			}
+		}
+	}
+}
+
+// ExpirePendingBookings cancels the expired pending bookings batch by batch. A session type's confirmation
+// deadline takes precedence over the global TTL. The payment service is consulted first, so a booking
+// whose payment event is only delayed is not cancelled while the broker is down. Paid bookings are never
+// cancelled here, the student would not be refunded; they are confirmed once the payment event arrives.
+func (j *PendingExpiryJob) ExpirePendingBookings(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	var afterCreatedAt time.Time
+	var afterId int64
+	for {
+		bookings, err := j.repo.GetExpiredPendingBookings(ctx, j.cfg.PendingTTLMinutes, now, afterCreatedAt, afterId, j.cfg.BatchSize)
+		if err != nil {
+			logger.FromContext(ctx, j.log).Error("failed to get expired pending bookings", err)
+			return err
+		}
+		if err := j.expireBatch(ctx, bookings, now); err != nil {
+			return err
+		}
+		if len(bookings) == 0 || len(bookings) < j.cfg.BatchSize {
+			return nil
+		}
+
+		last := bookings[len(bookings)-1]
+		afterCreatedAt, afterId = last.CreatedAt, last.Id
+	}
+}
+
+// expireBatch cancels the bookings of a batch whose payment does not keep them
+func (j *PendingExpiryJob) expireBatch(ctx context.Context, bookings []*entities.Booking, now time.Time) error {
+	log := logger.FromContext(ctx, j.log)
+
+	for _, b := range bookings {
+		paymentStatus, err := j.paymentStatus(ctx, b)
+		if err != nil {
+			log.Warnf("Skipping expiry of booking %d, payment status unknown: %v", b.Id, err)
+			continue
+		}
+		if paymentStatus == payments.Completed {
+			log.Warnf("Keeping pending booking %d, its payment is completed", b.Id)
+			continue
+		}
+		if paymentStatus == payments.Pending && b.StartTime.After(now) {
+			log.Infof("Keeping pending booking %d, its payment is in progress", b.Id)
+			continue
+		}
+
+		expired, err := j.repo.ExpirePendingBooking(ctx, b.Id, b.Status, now)
+		if err != nil {
+			log.Error("failed to expire pending booking", err)
+			return err
+		}
+		if expired {
+			from := b.Status
+			b.Status = entities.Cancelled
+			publishTransition(ctx, log, j.publisher, b, from, now)
+			j.metrics.recordCancelled(ctx, reasonExpired)
+			j.metrics.recordPendingResolved(ctx, b.CreatedAt, reasonExpired)
+			j.notifyStudent(ctx, b)
+			promoteWaitlist(ctx, log, j.waitlist, b)
+		}
+	}
+
+	return nil
+}
+
+// paymentStatus returns the status the payment service knows for the payment of the booking, empty when
+// there is none
+func (j *PendingExpiryJob) paymentStatus(ctx context.Context, booking *entities.Booking) (string, error) {
+	status, err := j.payments.GetPaymentStatus(ctx, booking.StudentId, booking.ProductId, booking.ScheduledEventId)
+	if errors.Is(err, payments.ErrPaymentNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return status.Status, nil
+}
+
+// notifyStudent informs the student that the booking expired; failures are logged and do not stop the job
+func (j *PendingExpiryJob) notifyStudent(ctx context.Context, booking *entities.Booking) {
+	log := logger.FromContext(ctx, j.log)
+
+	data := map[string]string{
+		"bookingId": strconv.FormatInt(booking.Id, 10),
+		"title":     booking.Title,
+		"startTime": booking.StartTime.UTC().Format(time.RFC3339),
+	}
+
+	if err := j.notifier.Notify(ctx, booking.StudentId, notifications.BookingCancelledNotification, data); err != nil {
+		log.Errorf("Failed to notify student about expired booking %d: %v", booking.Id, err)
+	}
+}
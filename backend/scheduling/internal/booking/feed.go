@@ -0,0 +1,52 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/documents"
+	"github.com/maksmelnyk/scheduling/internal/feeds"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/schedule"
+)
+
+// GetMyCalendarFeed renders the bookings of the student a feed token was issued for as an iCalendar feed.
+// The token stands in for the bearer token, which calendar apps can not send.
+func (s *BookingService) GetMyCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	studentId, tenantId, err := s.feeds.Verify(feeds.ScopeStudentBookings, token)
+	if err != nil {
+		return nil, err
+	}
+	if tenantId != "" {
+		ctx = auth.WithTenant(ctx, tenantId)
+	}
+
+	from, to := feeds.Window(time.Now().UTC())
+	bookings, err := s.repo.GetStudentBookingsWithin(ctx, studentId, from, to)
+	if err != nil {
+		log.Error("failed to get bookings", err)
+		return nil, err
+	}
+
+	feed := &documents.CalendarFeed{Name: "Ora bookings", Events: make([]*documents.CalendarEvent, len(bookings))}
+	for i, b := range bookings {
+		feed.Events[i] = schedule.MapBookingToCalendarEvent(b)
+	}
+	return s.renderer.RenderCalendarFeed(feed), nil
+}
+
+// GetMyCalendarFeedLink returns the subscription link of the current student's bookings feed
+func (s *BookingService) GetMyCalendarFeedLink(ctx context.Context) (*schedule.CalendarFeedResponse, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	tenantId, _ := auth.GetTenantID(ctx)
+	token := s.feeds.Sign(feeds.ScopeStudentBookings, tenantId, userId)
+	return schedule.MapCalendarFeedToResponse(CalendarFeedPublicPath, token), nil
+}
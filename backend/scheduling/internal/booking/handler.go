@@ -2,7 +2,12 @@ package booking
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
 
 	"github.com/maksmelnyk/scheduling/internal/api"
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
@@ -17,13 +22,98 @@ func NewBookingHandler(service *BookingService) *BookingHandler {
 	return &BookingHandler{service: service}
 }
 
+// ListBookings retrieves a page of bookings.
+// @Summary      List bookings
+// @Description  Returns the bookings the current user takes part in as educator or student, or any bookings for admins, filtered and sorted as requested. Pages are keyed on the sort order, pass nextCursor of a page as cursor to fetch the next one.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        status      query     []int   false  "Booking statuses to include, repeatable"  collectionFormat(multi)
+// @Param        educatorId  query     string  false  "Educator ID"
+// @Param        studentId   query     string  false  "Student ID"
+// @Param        from        query     string  false  "Bookings starting at or after, in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        to          query     string  false  "Bookings starting before, in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        sort        query     string  false  "startTime, -startTime, createdAt or -createdAt, defaults to -startTime"
+// @Param        cursor      query     string  false  "Cursor of the page to fetch, from nextCursor of the previous page"
+// @Param        limit       query     int     false  "Number of bookings to return, at most 200, defaults to 50"
+// @Success      200         {object}  BookingPageResponse  "Bookings"
+// @Failure      400         {object}  error                "Invalid input parameters"
+// @Router       /api/v1/bookings/ [get]
+// @Security 	 BearerAuth
+func (h *BookingHandler) ListBookings(w http.ResponseWriter, r *http.Request) {
+	query, err := parseBookingListQuery(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	page, err := h.service.ListBookings(r.Context(), query)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, page)
+}
+
+func parseBookingListQuery(w http.ResponseWriter, r *http.Request) (*BookingListQuery, error) {
+	values := r.URL.Query()
+	query := &BookingListQuery{Sort: values.Get("sort"), Cursor: values.Get("cursor")}
+
+	for _, value := range values["status"] {
+		status, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid format for query parameter 'status', expected an integer, received: '%s'", value)
+		}
+		query.Statuses = append(query.Statuses, status)
+	}
+
+	if values.Get("educatorId") != "" {
+		educatorId, err := api.ParseUUIDQuery(w, r, "educatorId")
+		if err != nil {
+			return nil, err
+		}
+		query.EducatorId = &educatorId
+	}
+	if values.Get("studentId") != "" {
+		studentId, err := api.ParseUUIDQuery(w, r, "studentId")
+		if err != nil {
+			return nil, err
+		}
+		query.StudentId = &studentId
+	}
+	if values.Get("from") != "" {
+		from, err := api.ParseTimeQuery(w, r, "from", time.RFC3339)
+		if err != nil {
+			return nil, err
+		}
+		query.From = &from
+	}
+	if values.Get("to") != "" {
+		to, err := api.ParseTimeQuery(w, r, "to", time.RFC3339)
+		if err != nil {
+			return nil, err
+		}
+		query.To = &to
+	}
+
+	limit, err := api.ParseIntQuery(w, r, "limit", defaultBookingPageSize)
+	if err != nil {
+		return nil, err
+	}
+	query.Limit = limit
+
+	return query, nil
+}
+
 // AddBooking adds a new booking based on the provided request details.
 // @Summary      Add a new booking
-// @Description  Creates a new booking entry using the provided booking information.
+// @Description  Creates a new booking entry using the provided booking information. Times must carry a UTC offset and are stored in UTC.
 // @Tags         Booking
 // @Accept       json
 // @Produce      json
 // @Param        booking  body      BookingRequest  true  "Booking details"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
 // @Success      201      {string}  string          "Booking created successfully"
 // @Failure      400      {object}  error 			"Invalid input"
 // @Router       /api/v1/bookings/ [post]
@@ -48,52 +138,517 @@ func (h *BookingHandler) AddBooking(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 }
 
-// ConfirmBooking.
-// @Summary      Confirm booking
-// @Description  Confirms an existing booking by setting its status to 'approved'.
+// QuoteBooking prices a prospective booking.
+// @Summary      Quote a booking
+// @Description  Returns the price of the requested booking with the tax breakdown applicable to the current user.
 // @Tags         Booking
 // @Accept       json
 // @Produce      json
-// @Param        id      path      int     true  "Booking ID"
-// @Success      201     {string}  string  "Status updated successfully"
-// @Failure      400     {object}  error   "Invalid input"
-// @Router       /api/v1/bookings/{id}/confirm [post]
+// @Param        booking   body      BookingRequest        true   "Booking details"
+// @Param        timezone  query     string                false  "IANA time zone of returned times, also accepted as 'Prefer: timezone=' header; defaults to UTC"
+// @Success      200       {object}  BookingQuoteResponse  "Booking quote"
+// @Failure      400       {object}  error                 "Invalid input"
+// @Router       /api/v1/bookings/quote [post]
 // @Security 	 BearerAuth
-func (h *BookingHandler) ConfirmBooking(w http.ResponseWriter, r *http.Request) {
+func (h *BookingHandler) QuoteBooking(w http.ResponseWriter, r *http.Request) {
+	var request *BookingRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	loc, err := api.ParseTimezone(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	quote, err := h.service.QuoteBooking(r.Context(), request, r.Header.Get("Authorization"), loc)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, quote)
+}
+
+// PlaceBookingHold reserves a slot while the student completes payment.
+// @Summary      Place a booking hold
+// @Description  Holds the requested slot for the current user for a limited time. The slot is unavailable to others until the hold is confirmed, released or expires.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        booking  body      BookingRequest       true  "Booking details"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      201      {object}  BookingHoldResponse  "Booking hold"
+// @Failure      400      {object}  error                "Invalid input"
+// @Failure      409      {object}  error                "Slot already booked or held"
+// @Router       /api/v1/bookings/holds [post]
+// @Security 	 BearerAuth
+func (h *BookingHandler) PlaceBookingHold(w http.ResponseWriter, r *http.Request) {
+	var request *BookingRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	hold, err := h.service.PlaceBookingHold(r.Context(), request, r.Header.Get("Authorization"))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, hold)
+}
+
+// ConfirmBookingHold converts a booking hold into a booking.
+// @Summary      Confirm a booking hold
+// @Description  Turns an unexpired hold of the current user into a pending booking.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                       true  "Booking hold ID"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      201  {object}  schedule.BookingResponse  "Created booking"
+// @Failure      400  {object}  error                     "Invalid input parameters"
+// @Failure      404  {object}  error                     "Booking hold not found"
+// @Failure      422  {object}  error                     "Booking hold expired"
+// @Router       /api/v1/bookings/holds/{id}/confirm [post]
+// @Security 	 BearerAuth
+func (h *BookingHandler) ConfirmBookingHold(w http.ResponseWriter, r *http.Request) {
 	id, err := api.ParseLongParam(w, r, "id")
 	if err != nil {
 		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
 		return
 	}
 
-	err = h.service.UpdateBookingStatus(r.Context(), id, int(entities.Approved))
+	booking, err := h.service.ConfirmBookingHold(r.Context(), id)
 	if err != nil {
 		api.WriteError(w, err)
+		return
 	}
+
+	api.WriteJson(w, http.StatusCreated, booking)
+}
+
+// BookWithLink books the slot of a booking link.
+// @Summary      Book with a booking link
+// @Description  Books the slot a booking link offers for the given enrollment, which must be with the educator who created the link. Each student may use a link once, and the link stops accepting bookings once its uses run out.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        token    path      string              true  "Booking link token"
+// @Param        booking  body      LinkBookingRequest  true  "Enrollment to book"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      201      {string}  string              "Booking created successfully"
+// @Failure      400      {object}  error               "Invalid input"
+// @Failure      404      {object}  error               "Booking link not found"
+// @Failure      409      {object}  error               "Booking link used up or already used"
+// @Router       /api/v1/bookings/links/{token} [post]
+// @Security 	 BearerAuth
+func (h *BookingHandler) BookWithLink(w http.ResponseWriter, r *http.Request) {
+	var request *LinkBookingRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.BookWithLink(r.Context(), chi.URLParam(r, "token"), request, r.Header.Get("Authorization"))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
 	w.WriteHeader(http.StatusCreated)
 }
 
+// ReleaseBookingHold releases a booking hold.
+// @Summary      Release a booking hold
+// @Description  Frees a slot held by the current user before the hold expires.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int     true  "Booking hold ID"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      204  "Booking hold released"
+// @Failure      400  {object}  error   "Invalid input parameters"
+// @Failure      404  {object}  error   "Booking hold not found"
+// @Router       /api/v1/bookings/holds/{id} [delete]
+// @Security 	 BearerAuth
+func (h *BookingHandler) ReleaseBookingHold(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	if err := h.service.ReleaseBookingHold(r.Context(), id); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBookingConfirmationDocument renders a booking confirmation.
+// @Summary      Download booking confirmation PDF
+// @Description  Renders the booking details with a check-in QR code as a PDF document. Available to the student, the educator and admins.
+// @Tags         Booking
+// @Accept       json
+// @Produce      application/pdf
+// @Param        id        path      int     true   "Booking ID"
+// @Param        timezone  query     string  false  "IANA time zone of printed times, also accepted as 'Prefer: timezone=' header; defaults to UTC"
+// @Success      200       {file}    file    "Booking confirmation PDF"
+// @Failure      400       {object}  error   "Invalid input parameters"
+// @Failure      404       {object}  error   "Booking not found"
+// @Router       /api/v1/bookings/{id}/confirmation.pdf [get]
+// @Security 	 BearerAuth
+func (h *BookingHandler) GetBookingConfirmationDocument(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	loc, err := api.ParseTimezone(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	document, err := h.service.GetBookingConfirmationDocument(r.Context(), id, loc)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteFile(w, "application/pdf", fmt.Sprintf("booking-%d.pdf", id), document)
+}
+
+// GetMyCalendarFeed renders a student's bookings as an iCalendar feed.
+// @Summary      Student iCalendar feed
+// @Description  Renders the bookings of the student the feed token was issued for, from a week ago to 90 days ahead, as an iCalendar feed. Authenticated by the signed feed token instead of a bearer token, for subscriptions from calendar apps.
+// @Tags         Booking
+// @Produce      text/calendar
+// @Param        token  query     string  true  "Signed feed token"
+// @Success      200    {file}    file    "iCalendar feed"
+// @Failure      404    {object}  error   "Calendar feed not found"
+// @Router       /api/v1/bookings/my/calendar.ics [get]
+func (h *BookingHandler) GetMyCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.service.GetMyCalendarFeed(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteFile(w, "text/calendar; charset=utf-8", "bookings.ics", feed)
+}
+
+// GetMyCalendarFeedLink retrieves the calendar feed subscription link of the current student.
+// @Summary      Retrieve my bookings calendar feed link
+// @Description  Returns the iCalendar feed URL of the student's bookings with its signed token. Anyone holding the link can read the feed.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  schedule.CalendarFeedResponse  "Calendar feed link"
+// @Failure      401  {object}  error                          "Unauthorized"
+// @Router       /api/v1/bookings/my/calendar-feed [get]
+// @Security 	 BearerAuth
+func (h *BookingHandler) GetMyCalendarFeedLink(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.service.GetMyCalendarFeedLink(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, feed)
+}
+
+// GetBookingCheckInCode retrieves the check-in code of a booking.
+// @Summary      Retrieve booking check-in code
+// @Description  Returns the signed code encoded in the booking's check-in QR code, for display in the app. Available to the student, the educator and admins.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                         true  "Booking ID"
+// @Success      200  {object}  BookingCheckInCodeResponse  "Check-in code"
+// @Failure      400  {object}  error                       "Invalid input parameters"
+// @Failure      404  {object}  error                       "Booking not found"
+// @Router       /api/v1/bookings/{id}/check-in-code [get]
+// @Security 	 BearerAuth
+func (h *BookingHandler) GetBookingCheckInCode(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	code, err := h.service.GetBookingCheckInCode(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, code)
+}
+
+// ConfirmBooking.
+// @Summary      Confirm booking
+// @Description  Confirms a pending booking, or one awaiting payment, by setting its status to 'approved'.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true  "Booking ID"
+// @Param        If-Match  header  string  true  "Version of the booking the status update applies to"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      201     {string}  string  "Status updated successfully"
+// @Failure      400     {object}  error   "Invalid input"
+// @Failure      409     {object}  error   "Booking was modified in the meantime"
+// @Failure      422     {object}  error   "Booking can not move to this status"
+// @Router       /api/v1/bookings/{id}/confirm [post]
+// @Security 	 BearerAuth
+func (h *BookingHandler) ConfirmBooking(w http.ResponseWriter, r *http.Request) {
+	h.transitionBooking(w, r, entities.Approved)
+}
+
 // CancelBooking.
 // @Summary      Cancel booking
-// @Description  Cancels an existing booking by setting its status to 'cancelled'.
+// @Description  Cancels a pending, awaiting payment or approved booking by setting its status to 'cancelled'. The student is refunded in full.
 // @Tags         Booking
 // @Accept       json
 // @Produce      json
 // @Param        id      path      int     true  "Booking ID"
+// @Param        If-Match  header  string  true  "Version of the booking the status update applies to"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
 // @Success      201     {string}  string  "Status updated successfully"
 // @Failure      400     {object}  error   "Invalid input"
+// @Failure      409     {object}  error   "Booking was modified in the meantime"
+// @Failure      422     {object}  error   "Booking can not move to this status"
 // @Router       /api/v1/bookings/{id}/cancel [post]
 // @Security 	 BearerAuth
 func (h *BookingHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
+	h.transitionBooking(w, r, entities.Cancelled)
+}
+
+// RequestBookingPayment.
+// @Summary      Request booking payment
+// @Description  Moves a pending booking to 'awaiting_payment' and asks the student to pay before the booking is confirmed.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true  "Booking ID"
+// @Param        If-Match  header  string  true  "Version of the booking the status update applies to"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      201     {string}  string  "Status updated successfully"
+// @Failure      400     {object}  error   "Invalid input"
+// @Failure      409     {object}  error   "Booking was modified in the meantime"
+// @Failure      422     {object}  error   "Booking can not move to this status"
+// @Router       /api/v1/bookings/{id}/request-payment [post]
+// @Security 	 BearerAuth
+func (h *BookingHandler) RequestBookingPayment(w http.ResponseWriter, r *http.Request) {
+	h.transitionBooking(w, r, entities.AwaitingPayment)
+}
+
+// CompleteBooking.
+// @Summary      Complete booking
+// @Description  Closes an approved booking whose session ended by setting its status to 'completed'.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true  "Booking ID"
+// @Param        If-Match  header  string  true  "Version of the booking the status update applies to"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      201     {string}  string  "Status updated successfully"
+// @Failure      400     {object}  error   "Invalid input"
+// @Failure      409     {object}  error   "Booking was modified in the meantime"
+// @Failure      422     {object}  error   "Booking can not move to this status, or its session has not ended"
+// @Router       /api/v1/bookings/{id}/complete [post]
+// @Security 	 BearerAuth
+func (h *BookingHandler) CompleteBooking(w http.ResponseWriter, r *http.Request) {
+	h.transitionBooking(w, r, entities.Completed)
+}
+
+// MarkBookingNoShow.
+// @Summary      Mark booking as no show
+// @Description  Closes an approved booking whose session ended without the student by setting its status to 'no_show'.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true  "Booking ID"
+// @Param        If-Match  header  string  true  "Version of the booking the status update applies to"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      201     {string}  string  "Status updated successfully"
+// @Failure      400     {object}  error   "Invalid input"
+// @Failure      409     {object}  error   "Booking was modified in the meantime"
+// @Failure      422     {object}  error   "Booking can not move to this status, or its session has not ended"
+// @Router       /api/v1/bookings/{id}/no-show [post]
+// @Security 	 BearerAuth
+func (h *BookingHandler) MarkBookingNoShow(w http.ResponseWriter, r *http.Request) {
+	h.transitionBooking(w, r, entities.NoShow)
+}
+
+// transitionBooking moves the booking of the path to the given status at the version of the If-Match header
+func (h *BookingHandler) transitionBooking(w http.ResponseWriter, r *http.Request, status entities.BookingStatus) {
 	id, err := api.ParseLongParam(w, r, "id")
 	if err != nil {
 		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
 		return
 	}
 
-	err = h.service.UpdateBookingStatus(r.Context(), id, int(entities.Cancelled))
+	version, err := api.ParseIfMatch(w, r)
 	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrVersionRequired))
+		return
+	}
+
+	if err := h.service.UpdateBookingStatus(r.Context(), id, status, version); err != nil {
 		api.WriteError(w, err)
+		return
 	}
 	w.WriteHeader(http.StatusCreated)
 }
+
+// GetBookingTransitions.
+// @Summary      Get booking status history
+// @Description  Returns every status a booking went through, oldest first, starting with the status it was created with. Available to the educator and the student of the booking and to admins.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                                 true  "Booking ID"
+// @Success      200  {array}   BookingStatusTransitionResponse     "Status transitions"
+// @Failure      400  {object}  error                               "Invalid input"
+// @Failure      403  {object}  error                               "Access denied"
+// @Failure      404  {object}  error                               "Booking not found"
+// @Router       /api/v1/bookings/{id}/transitions [get]
+// @Security 	 BearerAuth
+func (h *BookingHandler) GetBookingTransitions(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	transitions, err := h.service.GetBookingTransitions(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, transitions)
+}
+
+// QuoteMyCancellation.
+// @Summary      Quote booking cancellation
+// @Description  Evaluates the educator's cancellation rule for a booking of the current user without cancelling it, returning the refund and penalty that would apply now.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                          true  "Booking ID"
+// @Success      200  {object}  BookingCancellationResponse  "Cancellation terms"
+// @Failure      409  {object}  error                        "Booking is already cancelled"
+// @Failure      422  {object}  error                        "Booking can no longer be cancelled"
+// @Router       /api/v1/bookings/my/{id}/cancellation [get]
+// @Security 	 BearerAuth
+func (h *BookingHandler) QuoteMyCancellation(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	terms, err := h.service.QuoteMyCancellation(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, terms)
+}
+
+// CancelMyBooking.
+// @Summary      Cancel my booking
+// @Description  Cancels a booking of the current user under the educator's cancellation rule. Cancellations with less than the minimum notice are rejected; within the free cancellation window the penalty is kept and the rest refunded. A reason from the cancellation taxonomy is required, with a note when the reason is other.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                          true  "Booking ID"
+// @Param        cancellation  body  CancelMyBookingRequest  true  "Cancellation reason"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      200  {object}  BookingCancellationResponse  "Applied cancellation terms"
+// @Failure      400  {object}  error                        "Invalid cancellation reason"
+// @Failure      409  {object}  error                        "Booking is already cancelled"
+// @Failure      422  {object}  error                        "Booking can no longer be cancelled"
+// @Router       /api/v1/bookings/my/{id}/cancel [post]
+// @Security 	 BearerAuth
+func (h *BookingHandler) CancelMyBooking(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *CancelMyBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	terms, err := h.service.CancelMyBooking(r.Context(), id, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, terms)
+}
+
+// GetScheduledEventParticipants retrieves the participants of a scheduled event.
+// @Summary      Retrieve scheduled event participants
+// @Description  Returns the students enrolled in a scheduled event of the current educator with its capacity, the places offered from its waitlist and the places still available.
+// @Tags         Booking
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                                 true  "Scheduled event ID"
+// @Success      200  {object}  ScheduledEventParticipantsResponse  "Participants"
+// @Failure      403  {object}  error                               "Scheduled event of another educator"
+// @Failure      404  {object}  error                               "Scheduled event not found"
+// @Router       /api/v1/bookings/events/{id}/participants [get]
+// @Security 	 BearerAuth
+func (h *BookingHandler) GetScheduledEventParticipants(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	participants, err := h.service.GetScheduledEventParticipants(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, participants)
+}
@@ -0,0 +1,66 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const holdSweepBatchSize = 1000
+
+type HoldSweepRepository interface {
+	PurgeExpiredHolds(ctx context.Context, now time.Time, limit int) (int64, error)
+}
+
+// HoldSweepJob periodically releases booking holds that were not confirmed before they expired
+type HoldSweepJob struct {
+	log  logger.Logger
+	repo HoldSweepRepository
+	cfg  *config.BookingHoldConfig
+}
+
+func NewHoldSweepJob(log logger.Logger, repo HoldSweepRepository, cfg *config.BookingHoldConfig) *HoldSweepJob {
+	return &HoldSweepJob{log: log, repo: repo, cfg: cfg}
+}
+
+// Run releases expired holds on every interval until the context is cancelled
+func (j *HoldSweepJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.SweepIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.ReleaseExpiredHolds(ctx); err != nil {
+				j.log.Errorf("Failed to release expired booking holds: %v", err)
+			}
+		}
+	}
+}
+
+// ReleaseExpiredHolds deletes expired holds in batches. Expired holds already stop blocking their slot,
+// so the sweep only keeps the table small.
+func (j *HoldSweepJob) ReleaseExpiredHolds(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	var total int64
+	for {
+		removed, err := j.repo.PurgeExpiredHolds(ctx, now, holdSweepBatchSize)
+		if err != nil {
+			return err
+		}
+		total += removed
+		if removed < holdSweepBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		j.log.Infof("Released %d expired booking holds", total)
+	}
+	return nil
+}
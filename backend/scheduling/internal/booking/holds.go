@@ -0,0 +1,144 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/schedule"
+	"github.com/maksmelnyk/scheduling/internal/webhooks"
+)
+
+// PlaceBookingHold reserves the requested slot for the current user until the hold TTL elapses.
+// The request is validated like a booking, the slot stays unavailable to others while held.
+func (s *BookingService) PlaceBookingHold(ctx context.Context, request *BookingRequest, authHeader string) (*BookingHoldResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if err := s.ensureNoExistingBooking(ctx, request.EnrollmentId); err != nil {
+		log.Error("Booking already exists", err)
+		return nil, err
+	}
+
+	metadata, err := s.getBookingMetadata(ctx, request, authHeader)
+	if err != nil {
+		log.Error("Failed to get booking metadata", err)
+		return nil, err
+	}
+
+	educatorId, err := uuid.Parse(metadata.EducatorId)
+	if err != nil {
+		log.Error("Failed to parse educator ID", err)
+		return nil, err
+	}
+
+	if err := s.ensureEducatorAcceptsBookings(ctx, educatorId); err != nil {
+		log.Error("Educator does not accept bookings", err)
+		return nil, err
+	}
+
+	buffers, err := s.validateBookingTiming(ctx, educatorId, request)
+	if err != nil {
+		log.Error("Invalid booking time", err)
+		return nil, err
+	}
+
+	if err := s.validateSessionType(ctx, educatorId, request.SessionTypeId); err != nil {
+		log.Error("Invalid session type", err)
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	ttl := time.Duration(s.holdCfg.TTLMinutes) * time.Minute
+	hold := MapRequestToBookingHold(request, userId, educatorId, *metadata.ProductId, metadata.Title, metadata.Price, now, now.Add(ttl))
+
+	hold.Id, err = s.repo.AddBookingHold(ctx, hold, buffers.Gap(), now)
+	if err != nil {
+		log.Error("Failed to add booking hold", err)
+		return nil, err
+	}
+
+	return MapBookingHoldToResponse(hold), nil
+}
+
+// ConfirmBookingHold converts a hold of the current user into a pending booking
+func (s *BookingService) ConfirmBookingHold(ctx context.Context, id int64) (*schedule.BookingResponse, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	booking, err := s.confirmBookingHold(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule.MapBookingToResponse(booking), nil
+}
+
+// ConfirmPaidBookingHold converts the hold a completed payment was made for into a pending booking
+func (s *BookingService) ConfirmPaidBookingHold(ctx context.Context, event *messaging.BookingHoldPaidEvent) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := uuid.Parse(event.UserId)
+	if err != nil {
+		log.Error("Failed to parse user ID", err)
+		return err
+	}
+
+	_, err = s.confirmBookingHold(ctx, event.HoldId, userId)
+	return err
+}
+
+// ConfirmStudentBookingHold converts a hold of the given student into a pending booking, for backend services
+// confirming it on the student's behalf
+func (s *BookingService) ConfirmStudentBookingHold(ctx context.Context, id int64, studentId uuid.UUID) (*entities.Booking, error) {
+	return s.confirmBookingHold(ctx, id, studentId)
+}
+
+// ReleaseBookingHold frees a slot held by the current user before the hold expires
+func (s *BookingService) ReleaseBookingHold(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	released, err := s.repo.DeleteBookingHold(ctx, id, userId)
+	if err != nil {
+		log.Error("Failed to release booking hold", err)
+		return err
+	}
+
+	if !released {
+		return apperrors.NewNotFound("Booking hold not found", apperrors.ErrResourceNotFound)
+	}
+	return nil
+}
+
+func (s *BookingService) confirmBookingHold(ctx context.Context, id int64, studentId uuid.UUID) (*entities.Booking, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	booking, err := s.repo.ConfirmBookingHold(ctx, id, studentId, time.Now().UTC())
+	if err != nil {
+		log.Error("Failed to confirm booking hold", err)
+		return nil, err
+	}
+
+	s.metrics.recordCreated(ctx, sourceHold, 1)
+	s.schedules.Invalidate(ctx, booking.EducatorId.String())
+	s.dispatchBookings(ctx, webhooks.EventBookingCreated, booking)
+	log.Infof("Booking hold %d confirmed as booking %d", id, booking.Id)
+	return booking, nil
+}
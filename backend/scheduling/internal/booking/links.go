@@ -0,0 +1,98 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/bookinglinks"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/webhooks"
+)
+
+// BookWithLink books the slot of a booking link for the current user. The enrollment must belong to the
+// educator who created the link, and the link counts one use per student until it is used up.
+func (s *BookingService) BookWithLink(ctx context.Context, token string, request *LinkBookingRequest, authHeader string) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	linkId, linkTenantId, err := s.links.Verify(token)
+	if err != nil {
+		return err
+	}
+	if tenantId, _ := auth.GetTenantID(ctx); linkTenantId != tenantId {
+		return apperrors.NewNotFound("Booking link not found", apperrors.ErrBookingLinkInvalid)
+	}
+
+	link, err := s.repo.GetBookingLinkById(ctx, linkId)
+	if err != nil {
+		log.Error("Failed to get booking link", err)
+		return err
+	}
+
+	now := time.Now().UTC()
+	if !bookinglinks.Usable(link, now) {
+		return apperrors.NewNotFound("Booking link not found", apperrors.ErrBookingLinkInvalid)
+	}
+
+	if err := s.ensureNoExistingBooking(ctx, request.EnrollmentId); err != nil {
+		log.Error("Booking already exists", err)
+		return err
+	}
+
+	bookingRequest := &BookingRequest{
+		EnrollmentId:    request.EnrollmentId,
+		WorkingPeriodId: link.WorkingPeriodId,
+		SessionTypeId:   link.SessionTypeId,
+		StartTime:       link.StartTime,
+		EndTime:         link.EndTime,
+	}
+
+	metadata, err := s.getBookingMetadata(ctx, bookingRequest, authHeader)
+	if err != nil {
+		log.Error("Failed to get booking metadata", err)
+		return err
+	}
+
+	educatorId, err := uuid.Parse(metadata.EducatorId)
+	if err != nil {
+		log.Error("Failed to parse educator ID", err)
+		return err
+	}
+
+	if educatorId != link.EducatorId {
+		return apperrors.NewUnprocessedEntity("Enrollment is not with the educator of the booking link", apperrors.ErrBookingLinkInvalid)
+	}
+
+	if err := s.ensureEducatorAcceptsBookings(ctx, educatorId); err != nil {
+		log.Error("Educator does not accept bookings", err)
+		return err
+	}
+
+	buffers, err := s.validateBookingTiming(ctx, educatorId, bookingRequest)
+	if err != nil {
+		log.Error("Invalid booking time", err)
+		return err
+	}
+
+	booking := MapRequestToBooking(bookingRequest, userId, educatorId, *metadata.ProductId, metadata.Title, metadata.Price)
+
+	id, err := s.repo.AddLinkBooking(ctx, booking, link.Id, buffers.Gap(), now)
+	if err != nil {
+		log.Error("Failed to add link booking", err)
+		return err
+	}
+	booking.Id = id
+	s.schedules.Invalidate(ctx, educatorId.String())
+	s.dispatchBookings(ctx, webhooks.EventBookingCreated, booking)
+
+	s.metrics.recordCreated(ctx, sourceLink, 1)
+	return nil
+}
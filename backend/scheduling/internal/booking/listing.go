@@ -0,0 +1,178 @@
+package booking
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/schedule"
+)
+
+const (
+	defaultBookingPageSize = 50
+	maxBookingPageSize     = 200
+)
+
+// Sort orders of booking listings, a leading '-' sorts descending
+const (
+	SortStartTimeDesc = "-startTime"
+	SortStartTime     = "startTime"
+	SortCreatedAtDesc = "-createdAt"
+	SortCreatedAt     = "createdAt"
+)
+
+// bookingSortColumns maps the sort orders to the column the listing is keyed on, ties are broken by id
+var bookingSortColumns = map[string]string{
+	SortStartTimeDesc: "start_time",
+	SortStartTime:     "start_time",
+	SortCreatedAtDesc: "created_at",
+	SortCreatedAt:     "created_at",
+}
+
+// BookingCursor is the position after the last booking of a page, in the sort order of the listing
+type BookingCursor struct {
+	Sort  string
+	Value time.Time
+	Id    int64
+}
+
+func encodeBookingCursor(c *BookingCursor) string {
+	raw := fmt.Sprintf("%s:%d:%d", c.Sort, c.Value.UnixMicro(), c.Id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeBookingCursor(value string) (*BookingCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed booking cursor")
+	}
+	micros, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BookingCursor{Sort: parts[0], Value: time.UnixMicro(micros).UTC(), Id: id}, nil
+}
+
+// BookingSearch selects a page of bookings. Bookings match when they belong to ParticipantId as educator or
+// student, when set, and to every other filter that is set.
+type BookingSearch struct {
+	ParticipantId *uuid.UUID
+	EducatorId    *uuid.UUID
+	StudentId     *uuid.UUID
+	Statuses      []int
+	From          *time.Time
+	To            *time.Time
+	Sort          string
+	After         *BookingCursor
+	Limit         int
+}
+
+// ListBookings returns a page of the bookings the caller takes part in, or of any bookings for admins,
+// with the cursor of the next page when there are more
+func (s *BookingService) ListBookings(ctx context.Context, query *BookingListQuery) (*BookingPageResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	search, err := newBookingSearch(query)
+	if err != nil {
+		return nil, err
+	}
+	if !auth.HasRole(ctx, auth.AdminRole) {
+		search.ParticipantId = &userId
+	}
+
+	// One extra booking tells whether another page follows
+	limit := search.Limit
+	search.Limit++
+	bookings, err := s.repo.SearchBookings(ctx, search)
+	if err != nil {
+		log.Error("failed to search bookings", err)
+		return nil, err
+	}
+
+	response := &BookingPageResponse{}
+	if len(bookings) > limit {
+		bookings = bookings[:limit]
+		last := bookings[limit-1]
+		next := encodeBookingCursor(&BookingCursor{Sort: search.Sort, Value: bookingSortValue(search.Sort, last), Id: last.Id})
+		response.NextCursor = &next
+	}
+	response.Items = schedule.MapBookingsToResponse(bookings)
+	return response, nil
+}
+
+func newBookingSearch(query *BookingListQuery) (*BookingSearch, error) {
+	search := &BookingSearch{
+		EducatorId: query.EducatorId,
+		StudentId:  query.StudentId,
+		Statuses:   query.Statuses,
+		From:       query.From,
+		To:         query.To,
+		Sort:       query.Sort,
+		Limit:      query.Limit,
+	}
+
+	if search.Sort == "" {
+		search.Sort = SortStartTimeDesc
+	}
+	if _, ok := bookingSortColumns[search.Sort]; !ok {
+		return nil, apperrors.NewBadRequestError("Unknown sort order "+search.Sort, apperrors.ErrParameterInvalid)
+	}
+
+	if search.Limit == 0 {
+		search.Limit = defaultBookingPageSize
+	}
+	if search.Limit < 0 || search.Limit > maxBookingPageSize {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Limit must be between 1 and %d", maxBookingPageSize), apperrors.ErrParameterInvalid)
+	}
+
+	for _, status := range search.Statuses {
+		if status < int(entities.Pending) || status > int(entities.NoShow) {
+			return nil, apperrors.NewBadRequestError("Invalid booking status", apperrors.ErrParameterInvalid)
+		}
+	}
+
+	if search.From != nil && search.To != nil && !search.From.Before(*search.To) {
+		return nil, apperrors.NewBadRequestError("From must be before to", apperrors.ErrParameterInvalid)
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeBookingCursor(query.Cursor)
+		if err != nil || cursor.Sort != search.Sort {
+			return nil, apperrors.NewBadRequestError("Invalid cursor", apperrors.ErrParameterInvalid)
+		}
+		search.After = cursor
+	}
+
+	return search, nil
+}
+
+func bookingSortValue(sort string, b *entities.Booking) time.Time {
+	if bookingSortColumns[sort] == "created_at" {
+		return b.CreatedAt
+	}
+	return b.StartTime
+}
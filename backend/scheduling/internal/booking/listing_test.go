@@ -0,0 +1,247 @@
+package booking
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func TestBookingCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor BookingCursor
+		want   time.Time
+	}{
+		{"descending start time", BookingCursor{SortStartTimeDesc, time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC), 42}, time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)},
+		{"ascending creation time", BookingCursor{SortCreatedAt, time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC), 1}, time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC)},
+		{"nanoseconds are cut to the microseconds Postgres keeps", BookingCursor{SortStartTime, time.Date(2026, 10, 14, 9, 0, 0, 123456789, time.UTC), 7}, time.Date(2026, 10, 14, 9, 0, 0, 123456000, time.UTC)},
+		{"other time zones come back in UTC", BookingCursor{SortCreatedAtDesc, time.Date(2026, 10, 14, 11, 0, 0, 0, time.FixedZone("CEST", 2*3600)), 9}, time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)},
+		{"times before 1970", BookingCursor{SortStartTime, time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), 3}, time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{"largest id", BookingCursor{SortStartTimeDesc, time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC), 1<<63 - 1}, time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeBookingCursor(&tt.cursor)
+			if strings.ContainsAny(encoded, "+/=") {
+				t.Fatalf("cursor %q is not safe in a query string", encoded)
+			}
+
+			decoded, err := decodeBookingCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeBookingCursor: %v", err)
+			}
+			if decoded.Sort != tt.cursor.Sort || decoded.Id != tt.cursor.Id || !decoded.Value.Equal(tt.want) || decoded.Value.Location() != time.UTC {
+				t.Fatalf("decoded cursor = %+v, want sort %s, value %s and id %d", decoded, tt.cursor.Sort, tt.want, tt.cursor.Id)
+			}
+		})
+	}
+}
+
+func TestDecodeBookingCursorRejectsMalformed(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := map[string]string{
+		"not base64":           "not a cursor!",
+		"padded base64":        base64.URLEncoding.EncodeToString([]byte("startTime:1:1")),
+		"missing id":           encode("startTime:1760432400000000"),
+		"extra part":           encode("startTime:1760432400000000:1:2"),
+		"time is not a number": encode("startTime:yesterday:1"),
+		"id is not a number":   encode("startTime:1760432400000000:abc"),
+		"id overflows":         encode("startTime:1760432400000000:9223372036854775808"),
+		"empty":                "",
+	}
+
+	for name, cursor := range tests {
+		t.Run(name, func(t *testing.T) {
+			if decoded, err := decodeBookingCursor(cursor); err == nil {
+				t.Fatalf("decodeBookingCursor(%q) = %+v, want an error", cursor, decoded)
+			}
+		})
+	}
+}
+
+func TestNewBookingSearch(t *testing.T) {
+	from := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	descCursor := encodeBookingCursor(&BookingCursor{SortStartTimeDesc, from, 5})
+	ascCursor := encodeBookingCursor(&BookingCursor{SortCreatedAt, from, 5})
+
+	tests := []struct {
+		name    string
+		query   BookingListQuery
+		wantErr bool
+		check   func(t *testing.T, s *BookingSearch)
+	}{
+		{"defaults", BookingListQuery{}, false, func(t *testing.T, s *BookingSearch) {
+			if s.Sort != SortStartTimeDesc || s.Limit != defaultBookingPageSize || s.After != nil {
+				t.Fatalf("search = %+v, want the newest first %d bookings", s, defaultBookingPageSize)
+			}
+		}},
+		{"every sort order", BookingListQuery{Sort: SortCreatedAt}, false, nil},
+		{"unknown sort order", BookingListQuery{Sort: "price"}, true, nil},
+		{"sort by a column name", BookingListQuery{Sort: "start_time"}, true, nil},
+		{"largest page", BookingListQuery{Limit: maxBookingPageSize}, false, nil},
+		{"page too large", BookingListQuery{Limit: maxBookingPageSize + 1}, true, nil},
+		{"negative page", BookingListQuery{Limit: -1}, true, nil},
+		{"every status", BookingListQuery{Statuses: []int{int(entities.Pending), int(entities.NoShow)}}, false, nil},
+		{"status below the known ones", BookingListQuery{Statuses: []int{-1}}, true, nil},
+		{"status above the known ones", BookingListQuery{Statuses: []int{int(entities.NoShow) + 1}}, true, nil},
+		{"range", BookingListQuery{From: &from, To: &to}, false, nil},
+		{"open ended range", BookingListQuery{From: &from}, false, nil},
+		{"empty range", BookingListQuery{From: &from, To: &from}, true, nil},
+		{"inverted range", BookingListQuery{From: &to, To: &from}, true, nil},
+		{"cursor of the listing", BookingListQuery{Cursor: descCursor}, false, func(t *testing.T, s *BookingSearch) {
+			if s.After == nil || s.After.Id != 5 || !s.After.Value.Equal(from) {
+				t.Fatalf("After = %+v, want the position of the cursor", s.After)
+			}
+		}},
+		{"cursor of another sort order", BookingListQuery{Cursor: ascCursor}, true, nil},
+		{"cursor of the default sort given with another one", BookingListQuery{Sort: SortStartTime, Cursor: descCursor}, true, nil},
+		{"malformed cursor", BookingListQuery{Cursor: "abc"}, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			search, err := newBookingSearch(&tt.query)
+			if tt.wantErr {
+				var badRequest *apperrors.BadRequestError
+				if !errors.As(err, &badRequest) {
+					t.Fatalf("newBookingSearch() = %v, want a bad request error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newBookingSearch() = %v, want no error", err)
+			}
+			if tt.check != nil {
+				tt.check(t, search)
+			}
+		})
+	}
+}
+
+// fakeListingRepo searches bookings in memory with the keyset semantics of SearchBookings
+type fakeListingRepo struct {
+	BookingRepository
+	bookings []*entities.Booking
+}
+
+func (f *fakeListingRepo) SearchBookings(_ context.Context, search *BookingSearch) ([]*entities.Booking, error) {
+	descending := strings.HasPrefix(search.Sort, "-")
+	compare := func(a, b *entities.Booking) int {
+		c := bookingSortValue(search.Sort, a).Compare(bookingSortValue(search.Sort, b))
+		if c == 0 {
+			c = int(a.Id - b.Id)
+		}
+		if descending {
+			return -c
+		}
+		return c
+	}
+
+	var matches []*entities.Booking
+	for _, b := range f.bookings {
+		if search.ParticipantId != nil && b.EducatorId != *search.ParticipantId && b.StudentId != *search.ParticipantId {
+			continue
+		}
+		if len(search.Statuses) > 0 && !slices.Contains(search.Statuses, int(b.Status)) {
+			continue
+		}
+		if search.After != nil && compare(b, &entities.Booking{Id: search.After.Id, StartTime: search.After.Value, CreatedAt: search.After.Value}) <= 0 {
+			continue
+		}
+		matches = append(matches, b)
+	}
+	slices.SortFunc(matches, compare)
+	return matches[:min(len(matches), search.Limit)], nil
+}
+
+func TestListBookingsPagesThroughTies(t *testing.T) {
+	educatorId := uuid.New()
+	studentId := uuid.New()
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	// Bookings share start and creation times, so only the id keeps the pages apart
+	var bookings []*entities.Booking
+	for i := range 7 {
+		bookings = append(bookings, &entities.Booking{
+			Id:         int64(i + 1),
+			EducatorId: educatorId,
+			StudentId:  studentId,
+			StartTime:  start.Add(time.Duration(i/3) * time.Hour),
+			CreatedAt:  start.Add(-time.Duration(i%2) * time.Hour),
+			Status:     entities.Approved,
+		})
+	}
+	// A booking of someone else is never listed for the educator
+	bookings = append(bookings, &entities.Booking{Id: 8, EducatorId: uuid.New(), StudentId: uuid.New(), StartTime: start, CreatedAt: start})
+
+	service := &BookingService{repo: &fakeListingRepo{bookings: bookings}}
+	ctx := context.WithValue(context.Background(), auth.UserIdKey, educatorId)
+
+	tests := []struct {
+		sort string
+		want []int64
+	}{
+		{SortStartTime, []int64{1, 2, 3, 4, 5, 6, 7}},
+		{SortStartTimeDesc, []int64{7, 6, 5, 4, 3, 2, 1}},
+		{SortCreatedAt, []int64{2, 4, 6, 1, 3, 5, 7}},
+		{SortCreatedAtDesc, []int64{7, 5, 3, 1, 6, 4, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			var got []int64
+			cursor := ""
+			for pages := 0; ; pages++ {
+				if pages > len(tt.want) {
+					t.Fatalf("listing did not end, got %v so far", got)
+				}
+				page, err := service.ListBookings(ctx, &BookingListQuery{Sort: tt.sort, Limit: 2, Cursor: cursor})
+				if err != nil {
+					t.Fatalf("ListBookings: %v", err)
+				}
+				for _, item := range page.Items {
+					got = append(got, item.Id)
+				}
+				if page.NextCursor == nil {
+					break
+				}
+				cursor = *page.NextCursor
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("listed bookings %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListBookingsLastFullPageHasNoCursor(t *testing.T) {
+	userId := uuid.New()
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	bookings := []*entities.Booking{
+		{Id: 1, EducatorId: userId, StartTime: start},
+		{Id: 2, EducatorId: userId, StartTime: start.Add(time.Hour)},
+	}
+	service := &BookingService{repo: &fakeListingRepo{bookings: bookings}}
+	ctx := context.WithValue(context.Background(), auth.UserIdKey, userId)
+
+	page, err := service.ListBookings(ctx, &BookingListQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("ListBookings: %v", err)
+	}
+	if len(page.Items) != 2 || page.NextCursor != nil {
+		t.Fatalf("page has %d bookings and cursor %v, want both bookings and no cursor", len(page.Items), page.NextCursor)
+	}
+}
@@ -1,11 +1,15 @@
 package booking
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/documents"
+	"github.com/maksmelnyk/scheduling/internal/products"
+	"github.com/maksmelnyk/scheduling/internal/taxes"
 )
 
 func MapRequestToBooking(
@@ -14,6 +18,7 @@ func MapRequestToBooking(
 	educatorId uuid.UUID,
 	productId int64,
 	title string,
+	price float64,
 ) *entities.Booking {
 	return &entities.Booking{
 		StudentId:       studentId,
@@ -21,15 +26,71 @@ func MapRequestToBooking(
 		ProductId:       productId,
 		Title:           title,
 		EnrollmentId:    &b.EnrollmentId,
+		SessionTypeId:   b.SessionTypeId,
 		WorkingPeriodId: b.WorkingPeriodId,
-		StartTime:       b.StartTime,
-		EndTime:         b.EndTime,
+		StartTime:       b.StartTime.UTC(),
+		EndTime:         b.EndTime.UTC(),
 		Status:          entities.Pending,
+		Price:           price,
 		CreatedAt:       time.Now().UTC(),
 		UpdatedAt:       time.Now().UTC(),
 	}
 }
 
+func MapRequestToBookingHold(
+	b *BookingRequest,
+	studentId uuid.UUID,
+	educatorId uuid.UUID,
+	productId int64,
+	title string,
+	price float64,
+	now time.Time,
+	expiresAt time.Time,
+) *entities.BookingHold {
+	return &entities.BookingHold{
+		StudentId:       studentId,
+		EducatorId:      educatorId,
+		ProductId:       productId,
+		Title:           title,
+		EnrollmentId:    b.EnrollmentId,
+		SessionTypeId:   b.SessionTypeId,
+		WorkingPeriodId: b.WorkingPeriodId,
+		StartTime:       b.StartTime.UTC(),
+		EndTime:         b.EndTime.UTC(),
+		Price:           price,
+		ExpiresAt:       expiresAt,
+		CreatedAt:       now,
+	}
+}
+
+func MapBookingHoldToResponse(h *entities.BookingHold) *BookingHoldResponse {
+	return &BookingHoldResponse{
+		Id:        h.Id,
+		ProductId: h.ProductId,
+		Title:     h.Title,
+		StartTime: h.StartTime,
+		EndTime:   h.EndTime,
+		Price:     h.Price,
+		ExpiresAt: h.ExpiresAt,
+	}
+}
+
+// MapCancelMyBookingRequestToEntity keeps the note only when it is not blank
+func MapCancelMyBookingRequestToEntity(r *CancelMyBookingRequest, bookingId int64, now time.Time) *entities.BookingCancellationReason {
+	var note *string
+	if r.Note != nil && strings.TrimSpace(*r.Note) != "" {
+		trimmed := strings.TrimSpace(*r.Note)
+		note = &trimmed
+	}
+
+	return &entities.BookingCancellationReason{
+		BookingId: bookingId,
+		Reason:    entities.CancellationReason(r.Reason),
+		Note:      note,
+		CreatedAt: now,
+	}
+}
+
 func MapScheduledEventToBooking(e *entities.ScheduledEvent, studentId uuid.UUID) *entities.Booking {
 	return &entities.Booking{
 		StudentId:        studentId,
@@ -37,10 +98,12 @@ func MapScheduledEventToBooking(e *entities.ScheduledEvent, studentId uuid.UUID)
 		ProductId:        e.ProductId,
 		Title:            e.Title,
 		ScheduledEventId: &e.Id,
+		SessionTypeId:    e.SessionTypeId,
 		WorkingPeriodId:  e.WorkingPeriodId,
 		StartTime:        e.StartTime,
 		EndTime:          e.EndTime,
 		Status:           entities.Approved,
+		Price:            e.Price,
 		CreatedAt:        time.Now().UTC(),
 		UpdatedAt:        time.Now().UTC(),
 	}
@@ -57,3 +120,76 @@ func MapScheduledEventsToBookings(e []*entities.ScheduledEvent, studentId uuid.U
 	}
 	return response
 }
+
+func MapParticipantsToResponse(e *entities.ScheduledEvent, participants []*entities.Booking, offered int) *ScheduledEventParticipantsResponse {
+	response := &ScheduledEventParticipantsResponse{
+		ScheduledEventId: e.Id,
+		Title:            e.Title,
+		StartTime:        e.StartTime,
+		EndTime:          e.EndTime,
+		Capacity:         e.MaxParticipants,
+		Enrolled:         len(participants),
+		Offered:          offered,
+		Available:        max(e.MaxParticipants-len(participants)-offered, 0),
+		Participants:     make([]*ParticipantResponse, len(participants)),
+	}
+	for i, p := range participants {
+		response.Participants[i] = &ParticipantResponse{
+			BookingId: p.Id,
+			StudentId: p.StudentId,
+			Status:    p.Status.String(),
+			BookedAt:  p.CreatedAt,
+		}
+	}
+	return response
+}
+
+func MapMetadataToQuote(
+	b *BookingRequest,
+	metadata *products.EnrollmentBookingMetadataResponse,
+	tax *taxes.TaxBreakdown,
+	loc *time.Location,
+) *BookingQuoteResponse {
+	return &BookingQuoteResponse{
+		ProductId: metadata.ProductId,
+		Title:     metadata.Title,
+		StartTime: b.StartTime.In(loc),
+		EndTime:   b.EndTime.In(loc),
+		Price:     metadata.Price,
+		Tax:       tax,
+	}
+}
+
+func MapBookingToConfirmationDocument(b *entities.Booking, checkInCode string, loc *time.Location) *documents.BookingConfirmation {
+	return &documents.BookingConfirmation{
+		BookingId:  b.Id,
+		Title:      b.Title,
+		EducatorId: b.EducatorId.String(),
+		StudentId:  b.StudentId.String(),
+		StartTime:  b.StartTime.In(loc),
+		EndTime:    b.EndTime.In(loc),
+		Status:     b.Status.String(),
+		Price:      b.Price,
+		QRPayload:  checkInCode,
+		IssuedAt:   time.Now().In(loc),
+	}
+}
+
+func MapTransitionsToResponses(transitions []*entities.BookingStatusTransition) []*BookingStatusTransitionResponse {
+	responses := make([]*BookingStatusTransitionResponse, 0, len(transitions))
+	for _, t := range transitions {
+		var from *string
+		if t.FromStatus != nil {
+			status := t.FromStatus.String()
+			from = &status
+		}
+
+		responses = append(responses, &BookingStatusTransitionResponse{
+			FromStatus: from,
+			ToStatus:   t.ToStatus.String(),
+			ActorId:    t.ActorId,
+			ChangedAt:  t.ChangedAt,
+		})
+	}
+	return responses
+}
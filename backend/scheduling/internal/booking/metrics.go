@@ -0,0 +1,105 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// Sources of created bookings
+const (
+	sourceDirect = "direct"
+	sourceHold   = "hold"
+	sourceAuto   = "auto"
+	sourceLink   = "link"
+)
+
+// Reasons of cancelled bookings
+const (
+	reasonEducator = "educator"
+	reasonExpired  = "expired"
+	reasonStudent  = "student"
+)
+
+// utilizationWindow is how far ahead the slot utilization gauge looks
+const utilizationWindow = 7 * 24 * time.Hour
+
+type SlotUtilizationRepository interface {
+	GetSlotUtilization(ctx context.Context, from time.Time, to time.Time) (*SlotUtilization, error)
+}
+
+type bookingMetrics struct {
+	created         metric.Int64Counter
+	cancelled       metric.Int64Counter
+	pendingDuration metric.Float64Histogram
+}
+
+func newBookingMetrics(meter metric.Meter) (*bookingMetrics, error) {
+	created, err := meter.Int64Counter("scheduling.bookings.created",
+		metric.WithDescription("Bookings created, by source"))
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := meter.Int64Counter("scheduling.bookings.cancelled",
+		metric.WithDescription("Bookings cancelled, by reason"))
+	if err != nil {
+		return nil, err
+	}
+
+	pendingDuration, err := meter.Float64Histogram("scheduling.bookings.pending.duration",
+		metric.WithDescription("Time bookings waited for approval until approved, cancelled or expired, by outcome"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(60, 300, 900, 3600, 4*3600, 12*3600, 24*3600, 72*3600))
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookingMetrics{created: created, cancelled: cancelled, pendingDuration: pendingDuration}, nil
+}
+
+func (m *bookingMetrics) recordCreated(ctx context.Context, source string, count int) {
+	m.created.Add(ctx, int64(count), metric.WithAttributes(attribute.String("source", source)))
+}
+
+func (m *bookingMetrics) recordCancelled(ctx context.Context, reason string) {
+	m.cancelled.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", reason)))
+}
+
+// recordPendingResolved records how long a booking awaited approval once it leaves the pending state
+func (m *bookingMetrics) recordPendingResolved(ctx context.Context, pendingSince time.Time, outcome string) {
+	m.pendingDuration.Record(ctx, time.Since(pendingSince).Seconds(), metric.WithAttributes(
+		attribute.String("state", stateAwaitingApproval),
+		attribute.String("outcome", outcome),
+	))
+}
+
+// registerSlotUtilization reports the share of working time in the coming week that is taken by bookings.
+// A failed query skips the observation instead of failing the whole collection.
+func registerSlotUtilization(meter metric.Meter, log logger.Logger, repo SlotUtilizationRepository) error {
+	utilization, err := meter.Float64ObservableGauge("scheduling.slots.utilization",
+		metric.WithDescription("Share of working time in the next 7 days taken by active bookings"),
+		metric.WithUnit("1"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		now := time.Now().UTC()
+		usage, err := repo.GetSlotUtilization(ctx, now, now.Add(utilizationWindow))
+		if err != nil {
+			log.Errorf("Failed to collect slot utilization metrics: %v", err)
+			return nil
+		}
+
+		if usage.AvailableMinutes > 0 {
+			o.ObserveFloat64(utilization, usage.BookedMinutes/usage.AvailableMinutes)
+		}
+		return nil
+	}, utilization)
+	return err
+}
@@ -4,24 +4,90 @@ import (
 	"net/http"
 
 	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/metric"
 
 	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/bookinglinks"
+	"github.com/maksmelnyk/scheduling/internal/checkin"
+	"github.com/maksmelnyk/scheduling/internal/documents"
+	"github.com/maksmelnyk/scheduling/internal/feeds"
 	"github.com/maksmelnyk/scheduling/internal/logger"
 	"github.com/maksmelnyk/scheduling/internal/messaging"
-	"github.com/maksmelnyk/scheduling/internal/products"
+	"github.com/maksmelnyk/scheduling/internal/payments"
 )
 
 func InitializeBookingService(
 	log logger.Logger,
 	db *sqlx.DB,
-	cfg *config.ExternalServiceConfig,
+	products EnrollmentMetadataProvider,
+	publisher *messaging.Publisher,
+	invoices InvoiceGenerator,
+	taxes TaxCalculator,
+	renderer *documents.Renderer,
+	notifier Notifier,
+	codes *checkin.Signer,
+	feeds *feeds.Signer,
+	links *bookinglinks.Signer,
+	waitlist WaitlistPromoter,
+	holdCfg *config.BookingHoldConfig,
+	schedules ScheduleInvalidator,
+	webhooks WebhookDispatcher,
+	granularity SlotGranularityProvider,
+	meter metric.Meter,
+) (*BookingService, error) {
+	metrics, err := newBookingMetrics(meter)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := NewBookingRepository(db)
+	if err := registerSlotUtilization(meter, log, repo); err != nil {
+		return nil, err
+	}
+
+	service := NewBookingService(log, repo, products, publisher, invoices, taxes, renderer, notifier, codes, feeds, links, waitlist, holdCfg, schedules, webhooks, granularity, metrics)
+	return service, nil
+}
+
+func InitializePendingExpiryJob(
+	log logger.Logger,
+	db *sqlx.DB,
+	externalCfg *config.ExternalServiceConfig,
+	cfg *config.BookingExpiryConfig,
 	httpClient *http.Client,
+	tokens payments.TokenSource,
+	notifier Notifier,
+	waitlist WaitlistPromoter,
+	publisher *messaging.Publisher,
+	meter metric.Meter,
+) (*PendingExpiryJob, error) {
+	metrics, err := newBookingMetrics(meter)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := NewBookingRepository(db)
+	client := payments.NewPaymentServiceClient(*externalCfg, httpClient, tokens)
+	return NewPendingExpiryJob(log, repo, client, notifier, waitlist, publisher, cfg, metrics), nil
+}
+
+func InitializeSLAMonitorJob(
+	log logger.Logger,
+	db *sqlx.DB,
 	publisher *messaging.Publisher,
-) *BookingService {
+	cfg *config.BookingSLAConfig,
+	meter metric.Meter,
+) (*SLAMonitorJob, error) {
+	repo := NewBookingRepository(db)
+	if err := registerPendingStateMetrics(meter, log, repo, cfg); err != nil {
+		return nil, err
+	}
+	return NewSLAMonitorJob(log, repo, publisher, cfg), nil
+}
+
+func InitializeHoldSweepJob(log logger.Logger, db *sqlx.DB, cfg *config.BookingHoldConfig) *HoldSweepJob {
 	repo := NewBookingRepository(db)
-	client := products.NewProductServiceClient(*cfg, httpClient)
-	service := NewBookingService(log, repo, client, publisher)
-	return service
+	return NewHoldSweepJob(log, repo, cfg)
 }
 
 func InitializeBookingHTTPHandler(service *BookingService) http.Handler {
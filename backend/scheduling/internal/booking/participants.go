@@ -0,0 +1,45 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// GetScheduledEventParticipants retrieves the students enrolled in a scheduled event of the current educator
+// together with its capacity and remaining places
+func (s *BookingService) GetScheduledEventParticipants(ctx context.Context, scheduledEventId int64) (*ScheduledEventParticipantsResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	event, err := s.repo.GetScheduledEventById(ctx, scheduledEventId)
+	if err != nil {
+		log.Error("Failed to retrieve scheduled event", err)
+		return nil, err
+	}
+
+	if event.UserId != userId && !auth.HasRole(ctx, auth.AdminRole) {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	participants, err := s.repo.GetScheduledEventParticipants(ctx, scheduledEventId)
+	if err != nil {
+		log.Error("Failed to retrieve scheduled event participants", err)
+		return nil, err
+	}
+
+	offered, err := s.repo.CountOfferedPlaces(ctx, scheduledEventId, time.Now().UTC())
+	if err != nil {
+		log.Error("Failed to count offered places", err)
+		return nil, err
+	}
+
+	return MapParticipantsToResponse(event, participants, offered), nil
+}
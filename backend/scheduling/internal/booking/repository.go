@@ -2,17 +2,47 @@ package booking
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
 	"github.com/maksmelnyk/scheduling/internal/database"
 	"github.com/maksmelnyk/scheduling/internal/database/entities"
 )
 
+// SlotUtilization holds working time and the part of it taken by active bookings and scheduled events
+type SlotUtilization struct {
+	AvailableMinutes float64 `db:"available_minutes"`
+	BookedMinutes    float64 `db:"booked_minutes"`
+}
+
+// PendingStateStats holds the bookings in a pending state, the oldest of them and those past the SLA
+type PendingStateStats struct {
+	State       string     `db:"state"`
+	Pending     int64      `db:"pending"`
+	OldestSince *time.Time `db:"oldest_since"`
+	Breached    int64      `db:"breached"`
+}
+
+// SLABreach holds a booking awaiting approval or a hold awaiting payment pending longer than its SLA
+type SLABreach struct {
+	State        string    `db:"state"`
+	EntityId     int64     `db:"entity_id"`
+	EducatorId   uuid.UUID `db:"educator_id"`
+	StudentId    uuid.UUID `db:"student_id"`
+	StartTime    time.Time `db:"start_time"`
+	PendingSince time.Time `db:"pending_since"`
+}
+
 type BookingRepo struct {
 	db *sqlx.DB
 }
@@ -21,10 +51,20 @@ func NewBookingRepository(db *sqlx.DB) *BookingRepo {
 	return &BookingRepo{db: db}
 }
 
+// GetBookingById retrieves a single booking by its Id
+func (r *BookingRepo) GetBookingById(ctx context.Context, id int64) (*entities.Booking, error) {
+	const query = `
+        SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at
+        FROM booking
+        WHERE id = $1
+    `
+	return database.FetchSingle[entities.Booking](ctx, r.db, query, id)
+}
+
 // GetEducatorBookingById GetBookingById retrieves a single booking by its Id and EducatorId
 func (r *BookingRepo) GetEducatorBookingById(ctx context.Context, educatorId uuid.UUID, id int64) (*entities.Booking, error) {
 	const query = `
-        SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, working_period_id, start_time, end_time, status, created_at, updated_at
+        SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at
         FROM booking
         WHERE id = $1 AND educator_Id = $2
     `
@@ -36,37 +76,74 @@ func (r *BookingRepo) GetWorkingPeriodById(ctx context.Context, userId uuid.UUID
 	const query = `
         SELECT id, user_id, start_time, end_time, created_at, updated_at
         FROM working_period
-        WHERE user_id = $1 AND id = $2
+        WHERE user_id = $1 AND id = $2 AND deleted_at IS NULL
     `
 	return database.FetchSingle[entities.WorkingPeriod](ctx, r.db, query, userId, id)
 }
 
-// GetBookingsByUserId retrieves bookings for a specific user
-func (r *BookingRepo) GetBookingsByUserId(ctx context.Context, userId uuid.UUID, upcomingAfter *time.Time, skip int, take int) ([]*entities.Booking, error) {
+// SearchBookings retrieves a page of bookings matching the search, keyed on the sort column and id so pages
+// stay fast however deep the listing goes
+func (r *BookingRepo) SearchBookings(ctx context.Context, search *BookingSearch) ([]*entities.Booking, error) {
 	query := `
-		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, working_period_id, start_time, end_time, status, created_at, updated_at
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at
 		FROM booking
-		WHERE student_id = $1
+		WHERE TRUE
 	`
-	args := []any{userId}
-	argIndex := 2
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
 
-	if upcomingAfter != nil {
-		query += fmt.Sprintf(" AND start_time > $%d", argIndex)
-		args = append(args, *upcomingAfter)
-		argIndex++
+	if search.ParticipantId != nil {
+		p := arg(*search.ParticipantId)
+		query += fmt.Sprintf(" AND (educator_id = %s OR student_id = %s)", p, p)
+	}
+	if search.EducatorId != nil {
+		query += " AND educator_id = " + arg(*search.EducatorId)
+	}
+	if search.StudentId != nil {
+		query += " AND student_id = " + arg(*search.StudentId)
+	}
+	if len(search.Statuses) > 0 {
+		query += " AND status = ANY(" + arg(pq.Array(search.Statuses)) + ")"
+	}
+	if search.From != nil {
+		query += " AND start_time >= " + arg(*search.From)
+	}
+	if search.To != nil {
+		query += " AND start_time < " + arg(*search.To)
+	}
+
+	column := bookingSortColumns[search.Sort]
+	direction, comparison := "ASC", ">"
+	if strings.HasPrefix(search.Sort, "-") {
+		direction, comparison = "DESC", "<"
+	}
+	if search.After != nil {
+		query += fmt.Sprintf(" AND (%s, id) %s (%s, %s)", column, comparison, arg(search.After.Value), arg(search.After.Id))
 	}
 
-	query += fmt.Sprintf(" ORDER BY start_time DESC OFFSET $%d LIMIT $%d", argIndex, argIndex+1)
-	args = append(args, skip, take)
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT %s", column, direction, direction, arg(search.Limit))
 
 	return database.FetchMultiple[entities.Booking](ctx, r.db, query, args...)
 }
 
+// GetStudentBookingsWithin retrieves bookings of a student intersecting a date range
+func (r *BookingRepo) GetStudentBookingsWithin(ctx context.Context, studentId uuid.UUID, from, to time.Time) ([]*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at
+		FROM booking
+		WHERE student_id = $1 AND start_time < $3 AND end_time > $2
+		ORDER BY start_time
+	`
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, studentId, from, to)
+}
+
 // GetWorkingPeriodBookings retrieves bookings for a specific working period
 func (r *BookingRepo) GetWorkingPeriodBookings(ctx context.Context, workingPeriodId int64) ([]*entities.Booking, error) {
 	const query = `
-		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, working_period_id, start_time, end_time, status, created_at, updated_at
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, start_time, end_time, status, price, version, created_at, updated_at
 		FROM booking
 		WHERE working_period_id = $1
 	`
@@ -76,31 +153,59 @@ func (r *BookingRepo) GetWorkingPeriodBookings(ctx context.Context, workingPerio
 // GetScheduledEvents retrieves scheduled events for a specific working period
 func (r *BookingRepo) GetWorkingPeriodScheduledEvents(ctx context.Context, workingPeriodId int64) ([]*entities.ScheduledEvent, error) {
 	const query = `
-        SELECT id, user_id, product_id, lesson_id, title, working_period_id, start_time, end_time, max_participants, created_at, updated_at
+        SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, created_at, updated_at
         FROM scheduled_event
-        WHERE working_period_id = $1
+        WHERE working_period_id = $1 AND deleted_at IS NULL
     `
 	return database.FetchMultiple[entities.ScheduledEvent](ctx, r.db, query, workingPeriodId)
 }
 
 func (r *BookingRepo) GetLessonsScheduledEvents(ctx context.Context, lessonIds []int64) ([]*entities.ScheduledEvent, error) {
 	const query = `
-		SELECT id, user_id, product_id, lesson_id, title, working_period_id, start_time, end_time, max_participants, created_at, updated_at
+		SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, created_at, updated_at
 		FROM scheduled_event
-		WHERE lesson_id = ANY($1)
+		WHERE lesson_id = ANY($1) AND deleted_at IS NULL
 	`
 	return database.FetchMultiple[entities.ScheduledEvent](ctx, r.db, query, pq.Array(lessonIds))
 }
 
 func (r *BookingRepo) GetScheduledEventById(ctx context.Context, id int64) (*entities.ScheduledEvent, error) {
 	const query = `
-		SELECT id, user_id, product_id, lesson_id, title, working_period_id, start_time, end_time, max_participants, created_at, updated_at
+		SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, created_at, updated_at
 		FROM scheduled_event
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 	return database.FetchSingle[entities.ScheduledEvent](ctx, r.db, query, id)
 }
 
+// IsEducatorOffboarding checks whether an offboarding has been started for the educator
+func (r *BookingRepo) IsEducatorOffboarding(ctx context.Context, educatorId uuid.UUID) (bool, error) {
+	const query = `
+		SELECT COUNT(*) > 0
+		FROM teacher_offboarding
+		WHERE educator_id = $1
+	`
+	return database.CheckExists(ctx, r.db, query, educatorId)
+}
+
+// GetActiveBookingFreezes retrieves the freezes covering the educator at a moment, platform-wide ones included,
+// the one ending last first
+func (r *BookingRepo) GetActiveBookingFreezes(ctx context.Context, educatorId uuid.UUID, now time.Time) ([]*entities.BookingFreeze, error) {
+	const query = `
+		SELECT f.id, f.message, f.reason, f.educator_ids::text[] AS educator_ids, f.organization_id, f.starts_at, f.ends_at,
+			f.created_by, f.created_at, f.lifted_by, f.lifted_at
+		FROM booking_freeze f
+		WHERE f.lifted_at IS NULL AND f.starts_at <= $2 AND f.ends_at > $2
+			AND (
+				(cardinality(f.educator_ids) = 0 AND f.organization_id IS NULL)
+				OR $1 = ANY(f.educator_ids)
+				OR EXISTS (SELECT 1 FROM organization_member om WHERE om.organization_id = f.organization_id AND om.user_id = $1)
+			)
+		ORDER BY f.ends_at DESC
+	`
+	return database.FetchMultiple[entities.BookingFreeze](ctx, r.db, query, educatorId, now)
+}
+
 func (r *BookingRepo) HasBookingByEnrollmentId(ctx context.Context, enrollmentId int64) (bool, error) {
 	const query = `
 		SELECT COUNT(*) > 0
@@ -110,27 +215,601 @@ func (r *BookingRepo) HasBookingByEnrollmentId(ctx context.Context, enrollmentId
 	return database.CheckExists(ctx, r.db, query, enrollmentId, entities.Approved, entities.Pending)
 }
 
-// AddBooking adds a new booking
-func (r *BookingRepo) AddBooking(ctx context.Context, booking *entities.Booking) error {
-	const query = `
-        INSERT INTO booking (educator_id, student_id, product_id, enrollment_id, scheduled_event_id, working_period_id, title, start_time, end_time, status, created_at, updated_at)
-        VALUES (:educator_id, :student_id, :product_id, :enrollment_id, :scheduled_event_id, :working_period_id, :title, :start_time, :end_time, :status, :created_at, :updated_at)
+// AddBooking adds a new booking and returns its Id. The working period is locked, as AddBookingHold does,
+// while the slot widened by the gap between sessions is checked against bookings, scheduled events and
+// unexpired holds, so a concurrent booking or hold cannot take it in the meantime.
+func (r *BookingRepo) AddBooking(ctx context.Context, booking *entities.Booking, gap time.Duration, now time.Time) (int64, error) {
+	const insertQuery = `
+        INSERT INTO booking (educator_id, student_id, product_id, enrollment_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+        RETURNING id
     `
-	return database.ExecNamedQuery(ctx, r.db, query, booking)
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	if err := lockFreeSlot(ctx, tx, booking.WorkingPeriodId, booking.StartTime.Add(-gap), booking.EndTime.Add(gap), now); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = tx.GetContext(ctx, &id, insertQuery,
+		booking.EducatorId, booking.StudentId, booking.ProductId, booking.EnrollmentId, booking.ScheduledEventId, booking.SessionTypeId,
+		booking.WorkingPeriodId, booking.Title, booking.StartTime, booking.EndTime, booking.Status, booking.Price, booking.CreatedAt, booking.UpdatedAt)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return id, nil
 }
 
-// AddBookings adds multiple bookings
-func (r *BookingRepo) AddBookings(ctx context.Context, bookings []*entities.Booking) error {
-	items := make([]any, len(bookings))
+// AddEventBookings books students into scheduled events and returns the booking Ids in order. Each event is
+// locked, in Id order to rule out deadlocks, while its places are counted, so concurrent bookings queue up
+// and no event is booked beyond its capacity. Places offered from the waitlist count as taken.
+func (r *BookingRepo) AddEventBookings(ctx context.Context, bookings []*entities.Booking, now time.Time) ([]int64, error) {
+	const lockEventQuery = `SELECT max_participants FROM scheduled_event WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	const takenQuery = `
+		SELECT (SELECT COUNT(*) FROM booking WHERE scheduled_event_id = $1 AND status != $2)
+			+ (SELECT COUNT(*) FROM waitlist_entry WHERE scheduled_event_id = $1 AND status = $3 AND offer_expires_at > $4)
+	`
+	const bookedQuery = `SELECT COUNT(*) > 0 FROM booking WHERE scheduled_event_id = $1 AND student_id = $2 AND status != $3`
+	const insertBookingQuery = `
+		INSERT INTO booking (educator_id, student_id, product_id, enrollment_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	requested := make(map[int64]int)
+	for _, b := range bookings {
+		requested[*b.ScheduledEventId]++
+	}
+	eventIds := slices.Sorted(maps.Keys(requested))
+
+	for _, eventId := range eventIds {
+		var capacity int
+		if err := tx.GetContext(ctx, &capacity, lockEventQuery, eventId); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, apperrors.NewNotFound("Scheduled event not found", apperrors.ErrResourceNotFound)
+			}
+			return nil, apperrors.NewInternal(err)
+		}
+
+		var taken int
+		if err := tx.GetContext(ctx, &taken, takenQuery, eventId, entities.Cancelled, entities.WaitlistOffered, now); err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+		if taken+requested[eventId] > capacity {
+			return nil, apperrors.NewConflict(fmt.Sprintf("Scheduled event %d is fully booked", eventId), apperrors.ErrScheduledEventFull)
+		}
+	}
+
+	ids := make([]int64, len(bookings))
 	for i, b := range bookings {
-		items[i] = b
+		var booked bool
+		if err := tx.GetContext(ctx, &booked, bookedQuery, *b.ScheduledEventId, b.StudentId, entities.Cancelled); err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+		if booked {
+			return nil, apperrors.NewConflict(fmt.Sprintf("Scheduled event %d is already booked", *b.ScheduledEventId), apperrors.ErrBookingAlreadyExists)
+		}
+
+		err := tx.GetContext(ctx, &ids[i], insertBookingQuery,
+			b.EducatorId, b.StudentId, b.ProductId, b.EnrollmentId, b.ScheduledEventId, b.SessionTypeId, b.WorkingPeriodId,
+			b.Title, b.StartTime, b.EndTime, b.Status, b.Price, b.CreatedAt, b.UpdatedAt)
+		if err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return ids, nil
+}
+
+// GetScheduledEventParticipants retrieves the active bookings of a scheduled event, in the order they were made
+func (r *BookingRepo) GetScheduledEventParticipants(ctx context.Context, scheduledEventId int64) ([]*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, product_id, scheduled_event_id, session_type_id, enrollment_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at
+		FROM booking
+		WHERE scheduled_event_id = $1 AND status != $2
+		ORDER BY created_at, id
+	`
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, scheduledEventId, entities.Cancelled)
+}
+
+// CountOfferedPlaces counts the places of a scheduled event offered from its waitlist and not yet expired
+func (r *BookingRepo) CountOfferedPlaces(ctx context.Context, scheduledEventId int64, now time.Time) (int, error) {
+	const query = `SELECT COUNT(*) FROM waitlist_entry WHERE scheduled_event_id = $1 AND status = $2 AND offer_expires_at > $3`
+	var count int
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &count, query, scheduledEventId, entities.WaitlistOffered, now); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return count, nil
+}
+
+// SetBookingStatus updates status of a booking while it still has the given version. It returns false when
+// the booking has been modified in the meantime.
+func (r *BookingRepo) SetBookingStatus(ctx context.Context, id int64, educatorId uuid.UUID, status int, version int64) (bool, error) {
+	const query = `UPDATE booking SET status = $3, version = version + 1, updated_at = $4 WHERE id = $1 and educator_Id = $2 AND version = $5`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, id, educatorId, status, time.Now().UTC(), version)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
+
+// GetBookingTransitions retrieves the recorded status changes of a booking, oldest first
+func (r *BookingRepo) GetBookingTransitions(ctx context.Context, bookingId int64) ([]*entities.BookingStatusTransition, error) {
+	const query = `
+		SELECT id, booking_id, from_status, to_status, actor_id, changed_at
+		FROM booking_status_transition
+		WHERE booking_id = $1
+		ORDER BY id
+	`
+	return database.FetchMultiple[entities.BookingStatusTransition](ctx, r.db, query, bookingId)
+}
+
+// SessionTypeExists checks that an active session type belongs to the educator
+func (r *BookingRepo) SessionTypeExists(ctx context.Context, educatorId uuid.UUID, id int64) (bool, error) {
+	const query = `SELECT EXISTS (SELECT 1 FROM session_type WHERE id = $1 AND educator_id = $2 AND archived_at IS NULL)`
+	return database.CheckExists(ctx, r.db, query, id, educatorId)
+}
+
+// GetSessionBuffers retrieves the session buffers of an educator, which are zero without a scheduling policy
+func (r *BookingRepo) GetSessionBuffers(ctx context.Context, educatorId uuid.UUID) (*entities.SchedulingPolicy, error) {
+	const query = `
+		SELECT $1::uuid AS educator_id, COALESCE(MAX(buffer_before_minutes), 0) AS buffer_before_minutes,
+			COALESCE(MAX(buffer_minutes), 0) AS buffer_minutes
+		FROM scheduling_policy
+		WHERE educator_id = $1
+	`
+	return database.FetchSingle[entities.SchedulingPolicy](ctx, r.db, query, educatorId)
+}
+
+// GetExpiredPendingBookings retrieves bookings pending or awaiting payment past their confirmation deadline
+// or already started, oldest first and after the given creation time and Id, so bookings kept by a batch do
+// not hold back the ones after them. The deadline of the booking's session type applies, the default TTL
+// otherwise.
+func (r *BookingRepo) GetExpiredPendingBookings(ctx context.Context, defaultTTLMinutes int, now time.Time, afterCreatedAt time.Time, afterId int64, limit int) ([]*entities.Booking, error) {
+	const query = `
+		SELECT b.id, b.educator_id, b.student_id, b.enrollment_id, b.product_id, b.scheduled_event_id, b.session_type_id, b.working_period_id, b.title, b.start_time, b.end_time, b.status, b.price, b.version, b.created_at, b.updated_at
+		FROM booking b
+		LEFT JOIN session_type st ON st.id = b.session_type_id
+		WHERE b.status = ANY($1) AND (b.created_at + make_interval(mins => COALESCE(st.confirmation_deadline_minutes, $2)) < $3 OR b.start_time <= $3)
+			AND (b.created_at, b.id) > ($4, $5)
+		ORDER BY b.created_at, b.id
+		LIMIT $6
+	`
+	unconfirmed := pq.Int64Array{int64(entities.Pending), int64(entities.AwaitingPayment)}
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, unconfirmed, defaultTTLMinutes, now, afterCreatedAt, afterId, limit)
+}
+
+// ExpirePendingBooking cancels a booking only while it still has the given status. It returns false when
+// the booking has moved on in the meantime.
+func (r *BookingRepo) ExpirePendingBooking(ctx context.Context, id int64, from entities.BookingStatus, now time.Time) (bool, error) {
+	const query = `UPDATE booking SET status = $1, version = version + 1, updated_at = $2 WHERE id = $3 AND status = $4`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, entities.Cancelled, now, id, from)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
+
+// GetCancellationRule retrieves the cancellation rule of an educator for a session type, falling back to the
+// educator's default rule. Nil is returned when neither exists.
+func (r *BookingRepo) GetCancellationRule(ctx context.Context, educatorId uuid.UUID, sessionTypeId *int64) (*entities.CancellationRule, error) {
+	const query = `
+		SELECT id, educator_id, session_type_id, min_notice_hours, free_cancellation_hours, penalty_percent, created_at, updated_at
+		FROM cancellation_rule
+		WHERE educator_id = $1 AND (session_type_id = $2 OR session_type_id IS NULL)
+		ORDER BY session_type_id NULLS LAST
+		LIMIT 1
+	`
+	var rule entities.CancellationRule
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &rule, query, educatorId, sessionTypeId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return &rule, nil
+}
+
+// CancelStudentBooking cancels an open booking of a student, see entities.OpenStatuses, and stores the reason
+// given for it. It returns false when the booking has been cancelled or closed in the meantime.
+func (r *BookingRepo) CancelStudentBooking(ctx context.Context, id int64, studentId uuid.UUID, reason *entities.BookingCancellationReason) (bool, error) {
+	const query = `
+		WITH cancelled AS (
+			UPDATE booking SET status = $1, version = version + 1, updated_at = $2
+			WHERE id = $3 AND student_id = $4 AND status = ANY($5)
+			RETURNING id
+		)
+		INSERT INTO booking_cancellation_reason (booking_id, reason, note, created_at)
+		SELECT id, $6, $7, $2 FROM cancelled
+	`
+	result, err := database.Conn(ctx, r.db).ExecContext(
+		ctx, query,
+		entities.Cancelled, reason.CreatedAt, id, studentId, entities.OpenStatuses, reason.Reason, reason.Note,
+	)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
+
+// HasActiveHoldOverlap checks whether an unexpired hold covers part of the given time in a working period
+func (r *BookingRepo) HasActiveHoldOverlap(ctx context.Context, workingPeriodId int64, start, end time.Time, now time.Time) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1 FROM booking_hold
+			WHERE working_period_id = $1 AND expires_at > $4 AND start_time < $3 AND end_time > $2
+		)
+	`
+	return database.CheckExists(ctx, r.db, query, workingPeriodId, start, end, now)
+}
+
+// AddBookingHold stores a hold and returns its Id. The working period is locked while the slot widened by
+// the gap between sessions is checked against bookings, scheduled events and unexpired holds, so two
+// students cannot hold it at once.
+func (r *BookingRepo) AddBookingHold(ctx context.Context, hold *entities.BookingHold, gap time.Duration, now time.Time) (int64, error) {
+	const insertQuery = `
+		INSERT INTO booking_hold (educator_id, student_id, enrollment_id, product_id, working_period_id, session_type_id, title, start_time, end_time, price, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	if err := lockFreeSlot(ctx, tx, hold.WorkingPeriodId, hold.StartTime.Add(-gap), hold.EndTime.Add(gap), now); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = tx.GetContext(ctx, &id, insertQuery,
+		hold.EducatorId, hold.StudentId, hold.EnrollmentId, hold.ProductId, hold.WorkingPeriodId, hold.SessionTypeId,
+		hold.Title, hold.StartTime, hold.EndTime, hold.Price, hold.ExpiresAt, hold.CreatedAt)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return id, nil
+}
+
+// lockFreeSlot locks a working period within the transaction and checks that no booking, scheduled event or
+// unexpired hold of it overlaps the given time
+func lockFreeSlot(ctx context.Context, tx database.Tx, workingPeriodId int64, start, end time.Time, now time.Time) error {
+	const lockPeriodQuery = `SELECT id FROM working_period WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	const conflictQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM booking
+			WHERE working_period_id = $1 AND status <> $2 AND start_time < $4 AND end_time > $3
+		) OR EXISTS (
+			SELECT 1 FROM scheduled_event
+			WHERE working_period_id = $1 AND start_time < $4 AND end_time > $3 AND deleted_at IS NULL
+		) OR EXISTS (
+			SELECT 1 FROM booking_hold
+			WHERE working_period_id = $1 AND expires_at > $5 AND start_time < $4 AND end_time > $3
+		)
+	`
+
+	var periodId int64
+	if err := tx.GetContext(ctx, &periodId, lockPeriodQuery, workingPeriodId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NewNotFound("Working period not found", apperrors.ErrResourceNotFound)
+		}
+		return apperrors.NewInternal(err)
+	}
+
+	var conflict bool
+	if err := tx.GetContext(ctx, &conflict, conflictQuery, workingPeriodId, entities.Cancelled, start, end, now); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	if conflict {
+		return apperrors.NewConflict("Slot is already booked or held", apperrors.ErrBookingHours)
+	}
+	return nil
+}
+
+// ConfirmBookingHold converts an unexpired hold of the student into a pending booking and removes the hold.
+// The hold is locked so a concurrent confirmation or sweep cannot consume it twice.
+func (r *BookingRepo) ConfirmBookingHold(ctx context.Context, id int64, studentId uuid.UUID, now time.Time) (*entities.Booking, error) {
+	const lockHoldQuery = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, working_period_id, session_type_id, title, start_time, end_time, price, expires_at, created_at
+		FROM booking_hold
+		WHERE id = $1 AND student_id = $2
+		FOR UPDATE
+	`
+	const insertBookingQuery = `
+		INSERT INTO booking (educator_id, student_id, product_id, enrollment_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULL, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		RETURNING id
+	`
+	const deleteHoldQuery = `DELETE FROM booking_hold WHERE id = $1`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	var hold entities.BookingHold
+	if err := tx.GetContext(ctx, &hold, lockHoldQuery, id, studentId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Booking hold not found", apperrors.ErrResourceNotFound)
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if !hold.ExpiresAt.After(now) {
+		return nil, apperrors.NewUnprocessedEntity("Booking hold has expired", apperrors.ErrBookingHoldExpired)
+	}
+
+	booking := &entities.Booking{
+		EducatorId:      hold.EducatorId,
+		StudentId:       hold.StudentId,
+		ProductId:       hold.ProductId,
+		EnrollmentId:    &hold.EnrollmentId,
+		SessionTypeId:   hold.SessionTypeId,
+		WorkingPeriodId: hold.WorkingPeriodId,
+		Title:           hold.Title,
+		StartTime:       hold.StartTime,
+		EndTime:         hold.EndTime,
+		Status:          entities.Pending,
+		Price:           hold.Price,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	err = tx.GetContext(ctx, &booking.Id, insertBookingQuery,
+		booking.EducatorId, booking.StudentId, booking.ProductId, booking.EnrollmentId, booking.SessionTypeId,
+		booking.WorkingPeriodId, booking.Title, booking.StartTime, booking.EndTime, booking.Status, booking.Price, now)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteHoldQuery, hold.Id); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return booking, nil
+}
+
+// GetBookingLinkById retrieves a booking link, including revoked and expired ones
+func (r *BookingRepo) GetBookingLinkById(ctx context.Context, id int64) (*entities.BookingLink, error) {
+	const query = `
+		SELECT id, educator_id, working_period_id, session_type_id, label, start_time, end_time, max_uses, uses, expires_at, revoked_at, created_at
+		FROM booking_link
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.BookingLink](ctx, r.db, query, id)
+}
+
+// AddLinkBooking books a slot through a booking link. The slot is checked under the working period lock as in
+// AddBooking, and the use is counted by a conditional update, so concurrent redemptions never take the link
+// beyond its uses, and each student may use a link once.
+func (r *BookingRepo) AddLinkBooking(ctx context.Context, booking *entities.Booking, linkId int64, gap time.Duration, now time.Time) (int64, error) {
+	const useLinkQuery = `
+		UPDATE booking_link SET uses = uses + 1
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > $2 AND uses < max_uses
+	`
+	const insertBookingQuery = `
+		INSERT INTO booking (educator_id, student_id, product_id, enrollment_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULL, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id
+	`
+	const insertUseQuery = `
+		INSERT INTO booking_link_use (link_id, student_id, booking_id, used_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	if err := lockFreeSlot(ctx, tx, booking.WorkingPeriodId, booking.StartTime.Add(-gap), booking.EndTime.Add(gap), now); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, useLinkQuery, linkId, now)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	} else if affected == 0 {
+		return 0, apperrors.NewConflict("Booking link is no longer available", apperrors.ErrBookingLinkUsed)
+	}
+
+	var id int64
+	err = tx.GetContext(ctx, &id, insertBookingQuery,
+		booking.EducatorId, booking.StudentId, booking.ProductId, booking.EnrollmentId, booking.SessionTypeId,
+		booking.WorkingPeriodId, booking.Title, booking.StartTime, booking.EndTime, booking.Status, booking.Price,
+		booking.CreatedAt, booking.UpdatedAt)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	result, err = tx.ExecContext(ctx, insertUseQuery, linkId, booking.StudentId, id, now)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	} else if affected == 0 {
+		return 0, apperrors.NewConflict("Booking link has already been used", apperrors.ErrBookingLinkUsed)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return id, nil
+}
+
+// DeleteBookingHold releases a hold of the student. It returns false when no such hold exists.
+func (r *BookingRepo) DeleteBookingHold(ctx context.Context, id int64, studentId uuid.UUID) (bool, error) {
+	const query = `DELETE FROM booking_hold WHERE id = $1 AND student_id = $2`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, id, studentId)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
+
+// PurgeExpiredHolds deletes up to limit holds that expired before now and returns the number of removed rows
+func (r *BookingRepo) PurgeExpiredHolds(ctx context.Context, now time.Time, limit int) (int64, error) {
+	const query = `
+		DELETE FROM booking_hold
+		WHERE id IN (SELECT id FROM booking_hold WHERE expires_at <= $1 ORDER BY expires_at LIMIT $2)
+	`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, now, limit)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
 	}
 
-	return database.ExecInsertMany(ctx, r.db, "booking", items, "id")
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return affected, nil
 }
 
-// SetBookingStatus updates status of a booking
-func (r *BookingRepo) SetBookingStatus(ctx context.Context, id int64, educatorId uuid.UUID, status int) error {
-	const query = `UPDATE booking SET status = $3 WHERE id = $1 and educator_Id = $2`
-	return database.ExecQuery(ctx, r.db, query, id, educatorId, status)
+// GetSlotUtilization sums working period time within a period and the time occupied in it by scheduled events
+// and individual bookings that are not cancelled
+func (r *BookingRepo) GetSlotUtilization(ctx context.Context, from, to time.Time) (*SlotUtilization, error) {
+	const query = `
+		SELECT
+			(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM (LEAST(end_time, $2) - GREATEST(start_time, $1))) / 60), 0)
+			 FROM working_period
+			 WHERE start_time < $2 AND end_time > $1 AND deleted_at IS NULL) AS available_minutes,
+			(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM (end_time - start_time)) / 60), 0)
+			 FROM (
+				SELECT start_time, end_time
+				FROM booking
+				WHERE status != $3 AND scheduled_event_id IS NULL AND start_time >= $1 AND start_time < $2
+				UNION ALL
+				SELECT start_time, end_time
+				FROM scheduled_event
+				WHERE start_time >= $1 AND start_time < $2 AND deleted_at IS NULL
+			 ) occupied) AS booked_minutes
+	`
+	return database.FetchSingle[SlotUtilization](ctx, r.db, query, from, to, entities.Cancelled)
+}
+
+// GetPendingStateStats counts pending bookings and unexpired holds with the oldest of each, and those pending
+// since before the approval and payment cutoffs
+func (r *BookingRepo) GetPendingStateStats(ctx context.Context, approvalCutoff, paymentCutoff, now time.Time) ([]*PendingStateStats, error) {
+	const query = `
+		SELECT $1::text AS state, COUNT(*) AS pending, MIN(created_at) AS oldest_since,
+			COUNT(*) FILTER (WHERE created_at <= $3) AS breached
+		FROM booking
+		WHERE status = $5
+		UNION ALL
+		SELECT $2::text, COUNT(*), MIN(created_at), COUNT(*) FILTER (WHERE created_at <= $4)
+		FROM booking_hold
+		WHERE expires_at > $6
+	`
+	return database.FetchMultiple[PendingStateStats](
+		ctx, r.db, query, stateAwaitingApproval, stateAwaitingPayment, approvalCutoff, paymentCutoff, entities.Pending, now,
+	)
+}
+
+// GetUnalertedSLABreaches retrieves bookings awaiting approval since before the approval cutoff and unexpired
+// holds awaiting payment since before the payment cutoff that no alert was raised for, oldest first
+func (r *BookingRepo) GetUnalertedSLABreaches(ctx context.Context, approvalCutoff, paymentCutoff, now time.Time, limit int) ([]*SLABreach, error) {
+	const query = `
+		SELECT $1::text AS state, b.id AS entity_id, b.educator_id, b.student_id, b.start_time, b.created_at AS pending_since
+		FROM booking b
+		WHERE b.status = $5 AND b.created_at <= $3
+			AND NOT EXISTS (SELECT 1 FROM booking_sla_alert a WHERE a.state = $1 AND a.entity_id = b.id)
+		UNION ALL
+		SELECT $2::text, h.id, h.educator_id, h.student_id, h.start_time, h.created_at
+		FROM booking_hold h
+		WHERE h.created_at <= $4 AND h.expires_at > $6
+			AND NOT EXISTS (SELECT 1 FROM booking_sla_alert a WHERE a.state = $2 AND a.entity_id = h.id)
+		ORDER BY pending_since
+		LIMIT $7
+	`
+	return database.FetchMultiple[SLABreach](
+		ctx, r.db, query, stateAwaitingApproval, stateAwaitingPayment, approvalCutoff, paymentCutoff, entities.Pending, now, limit,
+	)
+}
+
+// RecordSLAAlert stores that an alert was raised for a breach; false is returned when it already was
+func (r *BookingRepo) RecordSLAAlert(ctx context.Context, breach *SLABreach, now time.Time) (bool, error) {
+	const query = `
+		INSERT INTO booking_sla_alert (state, entity_id, raised_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (state, entity_id) DO NOTHING
+	`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, breach.State, breach.EntityId, now)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
+
+// DeleteSLAAlertsBefore removes alert records raised before the cutoff and returns how many were removed
+func (r *BookingRepo) DeleteSLAAlertsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	const query = `DELETE FROM booking_sla_alert WHERE raised_at < $1`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, cutoff)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return affected, nil
 }
@@ -8,13 +8,33 @@ import (
 	"github.com/maksmelnyk/scheduling/internal/middleware"
 )
 
+// CalendarFeedPublicPath is served without authentication, see AuthMiddleware public routes
+const CalendarFeedPublicPath = "/api/v1/bookings/my/calendar.ics"
+
 func Routes(handler *BookingHandler) http.Handler {
 	r := chi.NewRouter()
 
 	// Define routes
-	r.Post("/", handler.AddBooking)
-	r.With(middleware.RoleAuthMiddleware(auth.EducatorRole)).Post("/{id}/confirm", handler.ConfirmBooking)
-	r.With(middleware.RoleAuthMiddleware(auth.EducatorRole)).Post("/{id}/cancel", handler.CancelBooking)
+	r.Get("/", handler.ListBookings)
+	r.With(middleware.RequirePermission(auth.BookSessionsPermission)).Post("/", handler.AddBooking)
+	r.With(middleware.RequirePermission(auth.BookSessionsPermission)).Post("/quote", handler.QuoteBooking)
+	r.With(middleware.RequirePermission(auth.BookSessionsPermission)).Post("/holds", handler.PlaceBookingHold)
+	r.With(middleware.RequirePermission(auth.BookSessionsPermission)).Post("/holds/{id}/confirm", handler.ConfirmBookingHold)
+	r.With(middleware.RequirePermission(auth.BookSessionsPermission)).Delete("/holds/{id}", handler.ReleaseBookingHold)
+	r.With(middleware.RequirePermission(auth.BookSessionsPermission)).Post("/links/{token}", handler.BookWithLink)
+	r.Get("/my/calendar.ics", handler.GetMyCalendarFeed)
+	r.With(middleware.RequirePermission(auth.BookSessionsPermission)).Get("/my/calendar-feed", handler.GetMyCalendarFeedLink)
+	r.With(middleware.RequirePermission(auth.BookSessionsPermission)).Get("/my/{id}/cancellation", handler.QuoteMyCancellation)
+	r.With(middleware.RequirePermission(auth.BookSessionsPermission)).Post("/my/{id}/cancel", handler.CancelMyBooking)
+	r.With(middleware.RequirePermission(auth.ViewBookingsPermission)).Get("/events/{id}/participants", handler.GetScheduledEventParticipants)
+	r.Get("/{id}/confirmation.pdf", handler.GetBookingConfirmationDocument)
+	r.Get("/{id}/check-in-code", handler.GetBookingCheckInCode)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Post("/{id}/confirm", handler.ConfirmBooking)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Post("/{id}/cancel", handler.CancelBooking)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Post("/{id}/request-payment", handler.RequestBookingPayment)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Post("/{id}/complete", handler.CompleteBooking)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Post("/{id}/no-show", handler.MarkBookingNoShow)
+	r.Get("/{id}/transitions", handler.GetBookingTransitions)
 
 	return r
 }
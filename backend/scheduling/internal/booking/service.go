@@ -2,51 +2,160 @@ package booking
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/maksmelnyk/scheduling/config"
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
 	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/bookinglinks"
+	"github.com/maksmelnyk/scheduling/internal/checkin"
 	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/documents"
+	"github.com/maksmelnyk/scheduling/internal/feeds"
 	"github.com/maksmelnyk/scheduling/internal/logger"
 	"github.com/maksmelnyk/scheduling/internal/messaging"
 	"github.com/maksmelnyk/scheduling/internal/products"
-	"github.com/maksmelnyk/scheduling/internal/schedule"
+	"github.com/maksmelnyk/scheduling/internal/taxes"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+	"github.com/maksmelnyk/scheduling/internal/webhooks"
 )
 
 type BookingRepository interface {
+	GetBookingById(ctx context.Context, id int64) (*entities.Booking, error)
 	GetEducatorBookingById(ctx context.Context, educatorId uuid.UUID, id int64) (*entities.Booking, error)
-	GetBookingsByUserId(ctx context.Context, userId uuid.UUID, upcomingAfter *time.Time, skip int, take int) ([]*entities.Booking, error)
+	SearchBookings(ctx context.Context, search *BookingSearch) ([]*entities.Booking, error)
+	GetStudentBookingsWithin(ctx context.Context, studentId uuid.UUID, from, to time.Time) ([]*entities.Booking, error)
 	GetWorkingPeriodById(ctx context.Context, userId uuid.UUID, id int64) (*entities.WorkingPeriod, error)
 	GetWorkingPeriodBookings(ctx context.Context, workingPeriodId int64) ([]*entities.Booking, error)
 	GetWorkingPeriodScheduledEvents(ctx context.Context, workingPeriodId int64) ([]*entities.ScheduledEvent, error)
 	GetLessonsScheduledEvents(ctx context.Context, lessonIds []int64) ([]*entities.ScheduledEvent, error)
 	GetScheduledEventById(ctx context.Context, id int64) (*entities.ScheduledEvent, error)
 	HasBookingByEnrollmentId(ctx context.Context, enrollmentId int64) (bool, error)
-	AddBooking(ctx context.Context, booking *entities.Booking) error
-	AddBookings(ctx context.Context, booking []*entities.Booking) error
-	SetBookingStatus(ctx context.Context, id int64, educatorId uuid.UUID, status int) error
+	AddBooking(ctx context.Context, booking *entities.Booking, gap time.Duration, now time.Time) (int64, error)
+	AddEventBookings(ctx context.Context, bookings []*entities.Booking, now time.Time) ([]int64, error)
+	GetScheduledEventParticipants(ctx context.Context, scheduledEventId int64) ([]*entities.Booking, error)
+	CountOfferedPlaces(ctx context.Context, scheduledEventId int64, now time.Time) (int, error)
+	SetBookingStatus(ctx context.Context, id int64, educatorId uuid.UUID, status int, version int64) (bool, error)
+	GetBookingTransitions(ctx context.Context, bookingId int64) ([]*entities.BookingStatusTransition, error)
+	GetCancellationRule(ctx context.Context, educatorId uuid.UUID, sessionTypeId *int64) (*entities.CancellationRule, error)
+	CancelStudentBooking(ctx context.Context, id int64, studentId uuid.UUID, reason *entities.BookingCancellationReason) (bool, error)
+	SessionTypeExists(ctx context.Context, educatorId uuid.UUID, id int64) (bool, error)
+	GetActiveBookingFreezes(ctx context.Context, educatorId uuid.UUID, now time.Time) ([]*entities.BookingFreeze, error)
+	GetSessionBuffers(ctx context.Context, educatorId uuid.UUID) (*entities.SchedulingPolicy, error)
+	IsEducatorOffboarding(ctx context.Context, educatorId uuid.UUID) (bool, error)
+	HasActiveHoldOverlap(ctx context.Context, workingPeriodId int64, start, end time.Time, now time.Time) (bool, error)
+	AddBookingHold(ctx context.Context, hold *entities.BookingHold, gap time.Duration, now time.Time) (int64, error)
+	ConfirmBookingHold(ctx context.Context, id int64, studentId uuid.UUID, now time.Time) (*entities.Booking, error)
+	DeleteBookingHold(ctx context.Context, id int64, studentId uuid.UUID) (bool, error)
+	GetBookingLinkById(ctx context.Context, id int64) (*entities.BookingLink, error)
+	AddLinkBooking(ctx context.Context, booking *entities.Booking, linkId int64, gap time.Duration, now time.Time) (int64, error)
+}
+
+// InvoiceGenerator creates invoice records for bookings once they are completed
+type InvoiceGenerator interface {
+	GenerateBookingInvoice(ctx context.Context, booking *entities.Booking) error
+}
+
+// TaxCalculator computes the tax breakdown of an amount sold by an educator to a buyer
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, educatorId uuid.UUID, buyerId uuid.UUID, amount float64) (*taxes.TaxBreakdown, error)
+}
+
+// Notifier sends user notifications according to their notification preferences
+type Notifier interface {
+	Notify(ctx context.Context, userId uuid.UUID, notificationType string, data map[string]string) error
+}
+
+// WaitlistPromoter hands the places freed by cancelled bookings to the students waiting for the scheduled event
+type WaitlistPromoter interface {
+	PromoteNext(ctx context.Context, scheduledEventId int64) error
+}
+
+// ScheduleInvalidator drops the cached schedules of an educator after their bookings or slots changed
+type ScheduleInvalidator interface {
+	Invalidate(ctx context.Context, owner string)
+}
+
+// WebhookDispatcher queues events for the webhook subscriptions of an educator
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, educatorId uuid.UUID, eventType string, data any)
+}
+
+// SlotGranularityProvider returns the session granularity set by the platform slot policy
+type SlotGranularityProvider interface {
+	SlotGranularity(ctx context.Context) (timeutils.SlotGranularity, error)
+}
+
+// EnrollmentMetadataProvider validates enrollments against the learning catalog before sessions are booked
+type EnrollmentMetadataProvider interface {
+	GetBookingMetadata(ctx context.Context, enrollmentId int64, durationMin int, authHeader string) (*products.EnrollmentBookingMetadataResponse, error)
 }
 
 type BookingService struct {
-	log       logger.Logger
-	repo      BookingRepository
-	client    *products.ProductServiceClient
-	publisher *messaging.Publisher
+	log         logger.Logger
+	repo        BookingRepository
+	products    EnrollmentMetadataProvider
+	publisher   *messaging.Publisher
+	invoices    InvoiceGenerator
+	taxes       TaxCalculator
+	renderer    *documents.Renderer
+	notifier    Notifier
+	codes       *checkin.Signer
+	feeds       *feeds.Signer
+	links       *bookinglinks.Signer
+	waitlist    WaitlistPromoter
+	holdCfg     *config.BookingHoldConfig
+	schedules   ScheduleInvalidator
+	webhooks    WebhookDispatcher
+	granularity SlotGranularityProvider
+	metrics     *bookingMetrics
 }
 
 func NewBookingService(
 	log logger.Logger,
 	repo BookingRepository,
-	client *products.ProductServiceClient,
+	products EnrollmentMetadataProvider,
 	publisher *messaging.Publisher,
+	invoices InvoiceGenerator,
+	taxes TaxCalculator,
+	renderer *documents.Renderer,
+	notifier Notifier,
+	codes *checkin.Signer,
+	feeds *feeds.Signer,
+	links *bookinglinks.Signer,
+	waitlist WaitlistPromoter,
+	holdCfg *config.BookingHoldConfig,
+	schedules ScheduleInvalidator,
+	webhooks WebhookDispatcher,
+	granularity SlotGranularityProvider,
+	metrics *bookingMetrics,
 ) *BookingService {
-	return &BookingService{log: log, repo: repo, client: client, publisher: publisher}
+	return &BookingService{
+		log:         log,
+		repo:        repo,
+		products:    products,
+		publisher:   publisher,
+		invoices:    invoices,
+		taxes:       taxes,
+		renderer:    renderer,
+		notifier:    notifier,
+		codes:       codes,
+		feeds:       feeds,
+		links:       links,
+		waitlist:    waitlist,
+		holdCfg:     holdCfg,
+		schedules:   schedules,
+		webhooks:    webhooks,
+		granularity: granularity,
+		metrics:     metrics,
+	}
 }
 
-func (s *BookingService) GetMyBookings(ctx context.Context, upcomingOnly bool, skip int, take int) ([]*schedule.BookingResponse, error) {
+// GetBookingConfirmationDocument renders the confirmation PDF of a booking for its student, educator or an admin,
+// with times in the given time zone
+func (s *BookingService) GetBookingConfirmationDocument(ctx context.Context, id int64, loc *time.Location) ([]byte, error) {
 	log := logger.FromContext(ctx, s.log)
 
 	userId, err := auth.GetUserID(ctx)
@@ -54,19 +163,73 @@ func (s *BookingService) GetMyBookings(ctx context.Context, upcomingOnly bool, s
 		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
 	}
 
-	var upcomingAfter *time.Time
-	if upcomingOnly {
-		now := time.Now().UTC()
-		upcomingAfter = &now
+	booking, err := s.repo.GetBookingById(ctx, id)
+	if err != nil {
+		log.Error("failed to get booking", err)
+		return nil, err
+	}
+
+	if booking.StudentId != userId && booking.EducatorId != userId && !auth.HasRole(ctx, auth.AdminRole) {
+		return nil, apperrors.NewForbidden("Access denied")
 	}
 
-	bookings, err := s.repo.GetBookingsByUserId(ctx, userId, upcomingAfter, skip, take)
+	document, err := s.renderer.RenderBookingConfirmation(MapBookingToConfirmationDocument(booking, s.codes.Sign(booking.Id), loc))
 	if err != nil {
-		log.Error("failed to get bookings", err)
+		log.Error("failed to render booking confirmation", err)
+		return nil, apperrors.NewInternal(err)
+	}
+
+	return document, nil
+}
+
+// GetBookingTransitions returns the status history of a booking for its student, educator or an admin
+func (s *BookingService) GetBookingTransitions(ctx context.Context, id int64) ([]*BookingStatusTransitionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	booking, err := s.repo.GetBookingById(ctx, id)
+	if err != nil {
+		log.Error("failed to get booking", err)
+		return nil, err
+	}
+
+	if booking.StudentId != userId && booking.EducatorId != userId && !auth.HasRole(ctx, auth.AdminRole) {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	transitions, err := s.repo.GetBookingTransitions(ctx, booking.Id)
+	if err != nil {
+		log.Error("failed to get booking transitions", err)
+		return nil, err
+	}
+
+	return MapTransitionsToResponses(transitions), nil
+}
+
+// GetBookingCheckInCode returns the signed check-in code of a booking for its student, educator or an admin
+func (s *BookingService) GetBookingCheckInCode(ctx context.Context, id int64) (*BookingCheckInCodeResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	booking, err := s.repo.GetBookingById(ctx, id)
+	if err != nil {
+		log.Error("failed to get booking", err)
 		return nil, err
 	}
 
-	return schedule.MapBookingsToResponse(bookings), nil
+	if booking.StudentId != userId && booking.EducatorId != userId && !auth.HasRole(ctx, auth.AdminRole) {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	return &BookingCheckInCodeResponse{BookingId: booking.Id, Code: s.codes.Sign(booking.Id)}, nil
 }
 
 func (s *BookingService) AddBooking(ctx context.Context, request *BookingRequest, authHeader string) error {
@@ -94,21 +257,75 @@ func (s *BookingService) AddBooking(ctx context.Context, request *BookingRequest
 		return err
 	}
 
-	if err := s.validateBookingTiming(ctx, educatorId, request); err != nil {
+	if err := s.ensureEducatorAcceptsBookings(ctx, educatorId); err != nil {
+		log.Error("Educator does not accept bookings", err)
+		return err
+	}
+
+	buffers, err := s.validateBookingTiming(ctx, educatorId, request)
+	if err != nil {
 		log.Error("Invalid booking time", err)
 		return err
 	}
 
-	booking := MapRequestToBooking(request, userId, educatorId, *metadata.ProductId, metadata.Title)
+	if err := s.validateSessionType(ctx, educatorId, request.SessionTypeId); err != nil {
+		log.Error("Invalid session type", err)
+		return err
+	}
+
+	booking := MapRequestToBooking(request, userId, educatorId, *metadata.ProductId, metadata.Title, metadata.Price)
 
-	if err := s.repo.AddBooking(ctx, booking); err != nil {
+	id, err := s.repo.AddBooking(ctx, booking, buffers.Gap(), time.Now().UTC())
+	if err != nil {
 		log.Error("Failed to add booking", err)
 		return err
 	}
+	booking.Id = id
+	s.schedules.Invalidate(ctx, educatorId.String())
+	s.dispatchBookings(ctx, webhooks.EventBookingCreated, booking)
 
+	s.metrics.recordCreated(ctx, sourceDirect, 1)
 	return nil
 }
 
+// QuoteBooking prices a prospective booking for the current user including the tax applicable to them,
+// returning its times in the given time zone
+func (s *BookingService) QuoteBooking(
+	ctx context.Context,
+	request *BookingRequest,
+	authHeader string,
+	loc *time.Location,
+) (*BookingQuoteResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	metadata, err := s.getBookingMetadata(ctx, request, authHeader)
+	if err != nil {
+		log.Error("Failed to get booking metadata", err)
+		return nil, err
+	}
+
+	educatorId, err := uuid.Parse(metadata.EducatorId)
+	if err != nil {
+		log.Error("Failed to parse educator ID", err)
+		return nil, err
+	}
+
+	tax, err := s.taxes.CalculateTax(ctx, educatorId, userId, metadata.Price)
+	if err != nil {
+		log.Error("Failed to calculate booking tax", err)
+		return nil, err
+	}
+
+	return MapMetadataToQuote(request, metadata, tax, loc), nil
+}
+
+// AddAutoBooking books an enrolled student into the scheduled event or lesson events of the request, all of
+// them or none when one is already full
 func (s *BookingService) AddAutoBooking(ctx context.Context, request *messaging.BookingCreationRequestedEvent) error {
 	log := logger.FromContext(ctx, s.log)
 
@@ -118,38 +335,50 @@ func (s *BookingService) AddAutoBooking(ctx context.Context, request *messaging.
 		return err
 	}
 
+	var events []*entities.ScheduledEvent
 	if request.ScheduledEventId != nil {
 		event, err := s.repo.GetScheduledEventById(ctx, *request.ScheduledEventId)
 		if err != nil {
 			log.Error("Failed to retrieve scheduled event", err)
 			return err
 		}
-
-		err = s.repo.AddBooking(ctx, MapScheduledEventToBooking(event, userId))
-		if err != nil {
-			log.Error("Failed to add booking", err)
-			return err
-		}
+		events = append(events, event)
 	}
 
 	if request.LessonIds != nil {
-		events, err := s.repo.GetLessonsScheduledEvents(ctx, request.LessonIds)
+		lessonEvents, err := s.repo.GetLessonsScheduledEvents(ctx, request.LessonIds)
 		if err != nil {
 			log.Error("Failed to retrieve scheduled events", err)
 			return err
 		}
+		events = append(events, lessonEvents...)
+	}
 
-		err = s.repo.AddBookings(ctx, MapScheduledEventsToBookings(events, userId))
-		if err != nil {
-			log.Error("Failed to add bookings", err)
-			return err
-		}
+	if len(events) == 0 {
+		return nil
+	}
+
+	bookings := MapScheduledEventsToBookings(events, userId)
+	ids, err := s.repo.AddEventBookings(ctx, bookings, time.Now().UTC())
+	if err != nil {
+		log.Error("Failed to add bookings", err)
+		return err
 	}
+	s.metrics.recordCreated(ctx, sourceAuto, len(ids))
+	s.invalidateSchedules(ctx, bookings)
+
+	for i := range ids {
+		bookings[i].Id = ids[i]
+	}
+	s.dispatchBookings(ctx, webhooks.EventBookingCreated, bookings...)
 
 	return nil
 }
 
-func (s *BookingService) UpdateBookingStatus(ctx context.Context, id int64, status int) error {
+// UpdateBookingStatus moves a booking of the educator to the given status, as far as the booking state
+// machine allows it. Every transition is published; completing generates the invoice, cancelling refunds the
+// student in full and passes the place on to the waitlist.
+func (s *BookingService) UpdateBookingStatus(ctx context.Context, id int64, status entities.BookingStatus, version int64) error {
 	log := logger.FromContext(ctx, s.log)
 
 	userId, err := auth.GetUserID(ctx)
@@ -158,34 +387,86 @@ func (s *BookingService) UpdateBookingStatus(ctx context.Context, id int64, stat
 		return apperrors.NewUnauthorized("Unauthorized user", err)
 	}
 
-	if status != int(entities.Approved) && status != int(entities.Cancelled) {
-		log.Error("Invalid booking status: " + fmt.Sprintf("%d", status))
-		return apperrors.NewBadRequestError("Invalid booking status", apperrors.ErrParameterInvalid)
-	}
-
 	booking, err := s.repo.GetEducatorBookingById(ctx, userId, id)
 	if err != nil {
 		log.Error("Failed to retrieve booking", err)
 		return err
 	}
 
-	if booking.Status != entities.Pending {
-		log.Errorf("Booking status already updated: %d", booking.Status)
-		return apperrors.NewUnprocessedEntity("Booking completed", apperrors.ErrBookingStatus)
+	if booking.Version != version {
+		return staleBookingError()
 	}
 
-	if err := s.repo.SetBookingStatus(ctx, id, userId, status); err != nil {
+	now := time.Now().UTC()
+	if err := checkTransition(booking, status, now); err != nil {
+		log.Error("Booking status change rejected", err)
+		return err
+	}
+
+	updated, err := s.repo.SetBookingStatus(ctx, id, userId, int(status), version)
+	if err != nil {
 		log.Error("Failed to update booking status", err)
 		return err
 	}
+	if !updated {
+		return staleBookingError()
+	}
+	from := booking.Status
+	booking.Status = status
+	booking.Version++
+	if from != entities.Approved && status != entities.AwaitingPayment {
+		s.metrics.recordPendingResolved(ctx, booking.CreatedAt, outcomeOf(status))
+	}
+	s.schedules.Invalidate(ctx, userId.String())
+	publishTransition(ctx, log, s.publisher, booking, from, now)
 
-	if status == int(entities.Approved) {
+	switch status {
+	case entities.Approved:
 		s.publisher.Publish(
 			ctx,
 			messaging.BookingCompletedKey,
 			messaging.NewBookingCompletedEvent(booking.StudentId.String(), *booking.EnrollmentId),
 		)
+	case entities.Completed:
+		s.generateInvoices(ctx, booking)
+	case entities.Cancelled:
+		s.dispatchBookings(ctx, webhooks.EventBookingCancelled, booking)
+		s.metrics.recordCancelled(ctx, reasonEducator)
+		s.publishCancellationSettled(ctx, booking, cancelledByEducator, fullRefund(booking))
+		promoteWaitlist(ctx, log, s.waitlist, booking)
 	}
 
+	s.notifyStudent(ctx, booking, status)
+
 	return nil
 }
+
+// outcomeOf names the status a pending booking was settled with
+func outcomeOf(status entities.BookingStatus) string {
+	if status == entities.Approved {
+		return "approved"
+	}
+	return "cancelled"
+}
+
+func staleBookingError() error {
+	return apperrors.NewConflict("Booking was modified, reload it and retry", apperrors.ErrVersionConflict)
+}
+
+// invalidateSchedules drops the cached schedules of every educator of the bookings
+func (s *BookingService) invalidateSchedules(ctx context.Context, bookings []*entities.Booking) {
+	seen := make(map[uuid.UUID]bool)
+	for _, b := range bookings {
+		if !seen[b.EducatorId] {
+			seen[b.EducatorId] = true
+			s.schedules.Invalidate(ctx, b.EducatorId.String())
+		}
+	}
+}
+
+// dispatchBookings sends a booking event to the webhook subscriptions of the educator of each booking
+func (s *BookingService) dispatchBookings(ctx context.Context, eventType string, bookings ...*entities.Booking) {
+	for _, b := range bookings {
+		s.webhooks.Dispatch(ctx, b.EducatorId, eventType, webhooks.NewBookingData(b))
+	}
+}
@@ -0,0 +1,174 @@
+package booking
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+// Pending states a booking goes through before it is settled
+const (
+	stateAwaitingPayment  = "awaiting_payment"
+	stateAwaitingApproval = "awaiting_approval"
+)
+
+// slaAlertRetention is how long raised alerts are kept, well past the longest a booking can stay pending
+const slaAlertRetention = 30 * 24 * time.Hour
+
+type SLARepository interface {
+	GetPendingStateStats(ctx context.Context, approvalCutoff, paymentCutoff, now time.Time) ([]*PendingStateStats, error)
+	GetUnalertedSLABreaches(ctx context.Context, approvalCutoff, paymentCutoff, now time.Time, limit int) ([]*SLABreach, error)
+	RecordSLAAlert(ctx context.Context, breach *SLABreach, now time.Time) (bool, error)
+	DeleteSLAAlertsBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// SLAMonitorJob raises an alert event for every booking awaiting approval and every hold awaiting payment
+// longer than its SLA
+type SLAMonitorJob struct {
+	log       logger.Logger
+	repo      SLARepository
+	publisher *messaging.Publisher
+	cfg       *config.BookingSLAConfig
+}
+
+func NewSLAMonitorJob(log logger.Logger, repo SLARepository, publisher *messaging.Publisher, cfg *config.BookingSLAConfig) *SLAMonitorJob {
+	return &SLAMonitorJob{log: log, repo: repo, publisher: publisher, cfg: cfg}
+}
+
+// RaiseAlerts publishes alerts for one batch of breaches not alerted yet. A breach is recorded before it is
+// published, so it is alerted once even when replicas race for it.
+func (j *SLAMonitorJob) RaiseAlerts(ctx context.Context) error {
+	if !j.cfg.AlertsEnabled {
+		return nil
+	}
+	log := logger.FromContext(ctx, j.log)
+
+	now := time.Now().UTC()
+	approvalCutoff, paymentCutoff := slaCutoffs(j.cfg, now)
+
+	breaches, err := j.repo.GetUnalertedSLABreaches(ctx, approvalCutoff, paymentCutoff, now, j.cfg.BatchSize)
+	if err != nil {
+		log.Error("failed to get SLA breaches", err)
+		return err
+	}
+
+	for _, b := range breaches {
+		recorded, err := j.repo.RecordSLAAlert(ctx, b, now)
+		if err != nil {
+			log.Error("failed to record SLA alert", err)
+			return err
+		}
+		if recorded {
+			j.publishAlert(ctx, b, now)
+		}
+	}
+
+	return nil
+}
+
+// PurgeAlerts removes alert records past the retention
+func (j *SLAMonitorJob) PurgeAlerts(ctx context.Context) error {
+	log := logger.FromContext(ctx, j.log)
+
+	deleted, err := j.repo.DeleteSLAAlertsBefore(ctx, time.Now().UTC().Add(-slaAlertRetention))
+	if err != nil {
+		log.Error("failed to purge SLA alerts", err)
+		return err
+	}
+	if deleted > 0 {
+		log.Infof("Purged %d booking SLA alerts", deleted)
+	}
+	return nil
+}
+
+// publishAlert enqueues a breach alert; failures are logged and do not stop the job
+func (j *SLAMonitorJob) publishAlert(ctx context.Context, breach *SLABreach, now time.Time) {
+	log := logger.FromContext(ctx, j.log)
+
+	slaMinutes := j.cfg.ApprovalMinutes
+	if breach.State == stateAwaitingPayment {
+		slaMinutes = j.cfg.PaymentMinutes
+	}
+
+	err := j.publisher.Publish(
+		ctx,
+		messaging.BookingSLAKey,
+		messaging.NewBookingSLABreachedEvent(
+			breach.State,
+			breach.EntityId,
+			breach.EducatorId.String(),
+			breach.StudentId.String(),
+			breach.StartTime.UTC().Format(time.RFC3339),
+			breach.PendingSince.UTC().Format(time.RFC3339),
+			slaMinutes,
+			int(now.Sub(breach.PendingSince).Minutes()),
+		),
+	)
+	if err != nil {
+		log.Errorf("Failed to publish SLA alert of %s %d: %v", breach.State, breach.EntityId, err)
+		return
+	}
+	log.Warnf("Booking SLA breached: %s %d pending since %s", breach.State, breach.EntityId, breach.PendingSince.Format(time.RFC3339))
+}
+
+// slaCutoffs returns the moments before which a booking awaiting approval and a hold awaiting payment
+// breach their SLA
+func slaCutoffs(cfg *config.BookingSLAConfig, now time.Time) (time.Time, time.Time) {
+	approval := now.Add(-time.Duration(cfg.ApprovalMinutes) * time.Minute)
+	payment := now.Add(-time.Duration(cfg.PaymentMinutes) * time.Minute)
+	return approval, payment
+}
+
+// registerPendingStateMetrics reports, per pending state, how many bookings are in it, how long the oldest
+// has been waiting and how many exceed the SLA. A failed query skips the observation instead of failing the
+// whole collection.
+func registerPendingStateMetrics(meter metric.Meter, log logger.Logger, repo SLARepository, cfg *config.BookingSLAConfig) error {
+	pending, err := meter.Int64ObservableGauge("scheduling.bookings.pending",
+		metric.WithDescription("Bookings awaiting approval and holds awaiting payment, by state"))
+	if err != nil {
+		return err
+	}
+
+	oldestAge, err := meter.Float64ObservableGauge("scheduling.bookings.pending.oldest_age",
+		metric.WithDescription("Time the oldest booking of a pending state has been waiting, by state"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+
+	breached, err := meter.Int64ObservableGauge("scheduling.bookings.pending.sla_breached",
+		metric.WithDescription("Bookings pending longer than the SLA of their state, by state"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		now := time.Now().UTC()
+		approvalCutoff, paymentCutoff := slaCutoffs(cfg, now)
+		stats, err := repo.GetPendingStateStats(ctx, approvalCutoff, paymentCutoff, now)
+		if err != nil {
+			log.Errorf("Failed to collect pending booking metrics: %v", err)
+			return nil
+		}
+
+		for _, s := range stats {
+			attrs := metric.WithAttributes(attribute.String("state", s.State))
+			o.ObserveInt64(pending, s.Pending, attrs)
+			o.ObserveInt64(breached, s.Breached, attrs)
+
+			age := 0.0
+			if s.OldestSince != nil {
+				age = now.Sub(*s.OldestSince).Seconds()
+			}
+			o.ObserveFloat64(oldestAge, age, attrs)
+		}
+		return nil
+	}, pending, oldestAge, breached)
+	return err
+}
@@ -0,0 +1,85 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+// transitions lists the statuses a booking can move to from each status. A booking awaits the educator's
+// confirmation, optionally a payment first, and is closed as completed or no show once the session ended.
+// Cancelled, completed and no show bookings are final.
+var transitions = map[entities.BookingStatus][]entities.BookingStatus{
+	entities.Pending:         {entities.AwaitingPayment, entities.Approved, entities.Cancelled},
+	entities.AwaitingPayment: {entities.Approved, entities.Cancelled},
+	entities.Approved:        {entities.Completed, entities.NoShow, entities.Cancelled},
+}
+
+// TransitionError is the cause of a status change rejected by the booking state machine
+type TransitionError struct {
+	From entities.BookingStatus
+	To   entities.BookingStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("booking can not move from %s to %s", e.From, e.To)
+}
+
+// canTransition tells whether the state machine allows a booking to move between the statuses
+func canTransition(from, to entities.BookingStatus) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// checkTransition rejects a status change the state machine does not allow, and closing a booking before
+// its session ended
+func checkTransition(booking *entities.Booking, to entities.BookingStatus, now time.Time) error {
+	if !canTransition(booking.Status, to) {
+		return apperrors.NewUnprocessedEntity(
+			fmt.Sprintf("Booking can not move from %s to %s", booking.Status, to),
+			apperrors.ErrBookingTransition,
+			&TransitionError{From: booking.Status, To: to},
+		)
+	}
+
+	if (to == entities.Completed || to == entities.NoShow) && now.Before(booking.EndTime) {
+		return apperrors.NewUnprocessedEntity(
+			"Booking can only be closed once its session ended",
+			apperrors.ErrBookingTransition,
+			&TransitionError{From: booking.Status, To: to},
+		)
+	}
+	return nil
+}
+
+// publishTransition announces a status change of a booking; failures are logged only, the change itself
+// already succeeded
+func publishTransition(
+	ctx context.Context,
+	log logger.Logger,
+	publisher *messaging.Publisher,
+	booking *entities.Booking,
+	from entities.BookingStatus,
+	now time.Time,
+) {
+	err := publisher.Publish(
+		ctx,
+		messaging.BookingStatusKey,
+		messaging.NewBookingStatusChangedEvent(
+			booking.Id,
+			booking.EducatorId.String(),
+			booking.StudentId.String(),
+			from.String(),
+			booking.Status.String(),
+			now.Format(time.RFC3339),
+		),
+	)
+	if err != nil {
+		log.Errorf("Failed to publish status change of booking %d: %v", booking.Id, err)
+	}
+}
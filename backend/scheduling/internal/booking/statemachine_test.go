@@ -0,0 +1,95 @@
+package booking
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+var allStatuses = []entities.BookingStatus{
+	entities.Pending,
+	entities.AwaitingPayment,
+	entities.Approved,
+	entities.Cancelled,
+	entities.Completed,
+	entities.NoShow,
+}
+
+func TestCanTransition(t *testing.T) {
+	allowed := map[[2]entities.BookingStatus]bool{
+		{entities.Pending, entities.AwaitingPayment}:   true,
+		{entities.Pending, entities.Approved}:          true,
+		{entities.Pending, entities.Cancelled}:         true,
+		{entities.AwaitingPayment, entities.Approved}:  true,
+		{entities.AwaitingPayment, entities.Cancelled}: true,
+		{entities.Approved, entities.Completed}:        true,
+		{entities.Approved, entities.NoShow}:           true,
+		{entities.Approved, entities.Cancelled}:        true,
+	}
+
+	// Every pair of statuses is checked, so a transition added to or dropped from the state machine fails here
+	for _, from := range allStatuses {
+		for _, to := range allStatuses {
+			want := allowed[[2]entities.BookingStatus{from, to}]
+			t.Run(from.String()+" to "+to.String(), func(t *testing.T) {
+				if got := canTransition(from, to); got != want {
+					t.Fatalf("canTransition(%s, %s) = %v, want %v", from, to, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	ended := now.Add(-time.Minute)
+	upcoming := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		from    entities.BookingStatus
+		to      entities.BookingStatus
+		endTime time.Time
+		wantErr bool
+	}{
+		{"approve a pending booking", entities.Pending, entities.Approved, upcoming, false},
+		{"await the payment of a pending booking", entities.Pending, entities.AwaitingPayment, upcoming, false},
+		{"approve a paid booking", entities.AwaitingPayment, entities.Approved, upcoming, false},
+		{"cancel an approved booking", entities.Approved, entities.Cancelled, upcoming, false},
+		{"complete an ended session", entities.Approved, entities.Completed, ended, false},
+		{"mark an ended session as no show", entities.Approved, entities.NoShow, ended, false},
+		{"complete an upcoming session", entities.Approved, entities.Completed, upcoming, true},
+		{"mark an upcoming session as no show", entities.Approved, entities.NoShow, upcoming, true},
+		{"complete a pending booking", entities.Pending, entities.Completed, ended, true},
+		{"move back to pending", entities.Approved, entities.Pending, upcoming, true},
+		{"stay in the same status", entities.Pending, entities.Pending, upcoming, true},
+		{"reopen a cancelled booking", entities.Cancelled, entities.Approved, upcoming, true},
+		{"cancel a completed booking", entities.Completed, entities.Cancelled, ended, true},
+		{"complete a no show", entities.NoShow, entities.Completed, ended, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := &entities.Booking{Status: tt.from, EndTime: tt.endTime}
+			err := checkTransition(booking, tt.to, now)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("checkTransition() = %v, want no error", err)
+				}
+				return
+			}
+
+			var unprocessed *apperrors.UnprocessedEntityError
+			if !errors.As(err, &unprocessed) || unprocessed.Code != apperrors.ErrBookingTransition {
+				t.Fatalf("checkTransition() = %v, want an unprocessable entity error coded %s", err, apperrors.ErrBookingTransition)
+			}
+			var transition *TransitionError
+			if !errors.As(err, &transition) || transition.From != tt.from || transition.To != tt.to {
+				t.Fatalf("checkTransition() = %v, want a transition error from %s to %s", err, tt.from, tt.to)
+			}
+		})
+	}
+}
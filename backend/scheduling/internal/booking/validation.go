@@ -3,10 +3,16 @@ package booking
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
 	"github.com/maksmelnyk/scheduling/internal/products"
 	"github.com/maksmelnyk/scheduling/internal/timeutils"
 )
@@ -23,9 +29,36 @@ func (s *BookingService) ensureNoExistingBooking(ctx context.Context, enrollment
 	return nil
 }
 
+// ensureEducatorAcceptsBookings rejects new bookings for educators that are being offboarded or archived,
+// and while a booking freeze covers the educator
+func (s *BookingService) ensureEducatorAcceptsBookings(ctx context.Context, educatorId uuid.UUID) error {
+	offboarding, err := s.repo.IsEducatorOffboarding(ctx, educatorId)
+	if err != nil {
+		return err
+	}
+
+	if offboarding {
+		return apperrors.NewUnprocessedEntity("Educator no longer accepts bookings", apperrors.ErrEducatorOffboarding)
+	}
+
+	freezes, err := s.repo.GetActiveBookingFreezes(ctx, educatorId, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	if len(freezes) > 0 {
+		freeze := freezes[0]
+		return apperrors.NewUnprocessedEntity(
+			fmt.Sprintf("New bookings are paused until %s: %s", freeze.EndsAt.UTC().Format(time.RFC3339), freeze.Message),
+			apperrors.ErrBookingsFrozen,
+		)
+	}
+	return nil
+}
+
 func (s *BookingService) getBookingMetadata(ctx context.Context, request *BookingRequest, authHeader string) (*products.EnrollmentBookingMetadataResponse, error) {
 	durationMin := int(math.Round(request.EndTime.Sub(request.StartTime).Minutes()))
-	metadata, err := s.client.GetBookingMetadata(ctx, request.EnrollmentId, durationMin, authHeader)
+	metadata, err := s.products.GetBookingMetadata(ctx, request.EnrollmentId, durationMin, authHeader)
 	if err != nil {
 		return nil, err
 	}
@@ -36,36 +69,128 @@ func (s *BookingService) getBookingMetadata(ctx context.Context, request *Bookin
 	return metadata, nil
 }
 
-func (s *BookingService) validateBookingTiming(ctx context.Context, educatorId uuid.UUID, request *BookingRequest) error {
+// validateBookingTiming checks that a booking complies with the slot policy, lies within its working period and
+// leaves the educator's session buffers clear of other bookings, held slots and scheduled events. It returns the
+// buffers, which the repository checks again while it holds the working period.
+func (s *BookingService) validateBookingTiming(ctx context.Context, educatorId uuid.UUID, request *BookingRequest) (timeutils.Buffers, error) {
+	if err := s.validateSlotGranularity(ctx, request.StartTime, request.EndTime); err != nil {
+		return timeutils.Buffers{}, err
+	}
+
 	workingPeriod, err := s.repo.GetWorkingPeriodById(ctx, educatorId, request.WorkingPeriodId)
 	if err != nil {
-		return err
+		return timeutils.Buffers{}, err
 	}
 
 	if !timeutils.IsWithinPeriod(request.StartTime, request.EndTime, workingPeriod.StartTime, workingPeriod.EndTime) {
-		return apperrors.NewUnprocessedEntity("Booking outside specified working period", apperrors.ErrBookingHours)
+		return timeutils.Buffers{}, apperrors.NewUnprocessedEntity("Booking outside specified working period", apperrors.ErrBookingHours)
 	}
 
+	policy, err := s.repo.GetSessionBuffers(ctx, educatorId)
+	if err != nil {
+		return timeutils.Buffers{}, err
+	}
+	buffers := timeutils.NewBuffers(policy.BufferBeforeMinutes, policy.BufferMinutes)
+
 	bookings, err := s.repo.GetWorkingPeriodBookings(ctx, request.WorkingPeriodId)
 	if err != nil {
-		return err
+		return timeutils.Buffers{}, err
 	}
 
 	for _, booking := range bookings {
-		if timeutils.IsOverlapping(request.StartTime, request.EndTime, booking.StartTime, booking.EndTime) {
-			return apperrors.NewUnprocessedEntity("Booking overlaps with existing booking", apperrors.ErrBookingHours)
+		if buffers.Conflicts(request.StartTime, request.EndTime, booking.StartTime, booking.EndTime) {
+			return timeutils.Buffers{}, apperrors.NewUnprocessedEntity("Booking overlaps with existing booking or its buffer", apperrors.ErrBookingHours)
 		}
 	}
 
+	gap := buffers.Gap()
+	held, err := s.repo.HasActiveHoldOverlap(ctx, request.WorkingPeriodId, request.StartTime.Add(-gap), request.EndTime.Add(gap), time.Now().UTC())
+	if err != nil {
+		return timeutils.Buffers{}, err
+	}
+
+	if held {
+		return timeutils.Buffers{}, apperrors.NewUnprocessedEntity("Booking overlaps with a held slot", apperrors.ErrBookingHours)
+	}
+
 	scheduledEvents, err := s.repo.GetWorkingPeriodScheduledEvents(ctx, request.WorkingPeriodId)
 	if err != nil {
-		return err
+		return timeutils.Buffers{}, err
 	}
 
 	for _, event := range scheduledEvents {
-		if timeutils.IsOverlapping(request.StartTime, request.EndTime, event.StartTime, event.EndTime) {
-			return apperrors.NewUnprocessedEntity("Booking overlaps with scheduled event", apperrors.ErrBookingHours)
+		if buffers.Conflicts(request.StartTime, request.EndTime, event.StartTime, event.EndTime) {
+			return timeutils.Buffers{}, apperrors.NewUnprocessedEntity("Booking overlaps with scheduled event or its buffer", apperrors.ErrBookingHours)
 		}
 	}
+	return buffers, nil
+}
+
+// validateSlotGranularity checks that a booking lasts a duration allowed by the platform slot policy and
+// starts on its alignment
+func (s *BookingService) validateSlotGranularity(ctx context.Context, start, end time.Time) error {
+	granularity, err := s.granularity.SlotGranularity(ctx)
+	if err != nil {
+		return err
+	}
+
+	if reason := granularity.Violation(start, end); reason != "" {
+		return apperrors.NewUnprocessedEntity(reason, apperrors.ErrSlotGranularity)
+	}
+	return nil
+}
+
+// validateSessionType checks that the session type of a booking, when one is given, belongs to the educator
+func (s *BookingService) validateSessionType(ctx context.Context, educatorId uuid.UUID, sessionTypeId *int64) error {
+	if sessionTypeId == nil {
+		return nil
+	}
+
+	exists, err := s.repo.SessionTypeExists(ctx, educatorId, *sessionTypeId)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return apperrors.NewUnprocessedEntity("Session type does not exist", apperrors.ErrSessionTypeInvalid)
+	}
 	return nil
 }
+
+// generateInvoices creates invoices for completed bookings; failures are logged and do not fail the booking flow
+func (s *BookingService) generateInvoices(ctx context.Context, bookings ...*entities.Booking) {
+	log := logger.FromContext(ctx, s.log)
+
+	for _, b := range bookings {
+		if err := s.invoices.GenerateBookingInvoice(ctx, b); err != nil {
+			log.Errorf("Failed to generate invoice for booking %d: %v", b.Id, err)
+		}
+	}
+}
+
+// notifyStudent informs the student about the educator's decision; failures are logged and do not fail the booking flow
+func (s *BookingService) notifyStudent(ctx context.Context, booking *entities.Booking, status entities.BookingStatus) {
+	log := logger.FromContext(ctx, s.log)
+
+	var notificationType string
+	switch status {
+	case entities.Approved:
+		notificationType = notifications.BookingConfirmedNotification
+	case entities.Cancelled:
+		notificationType = notifications.BookingCancelledNotification
+	case entities.AwaitingPayment:
+		notificationType = notifications.PaymentRequestedNotification
+	default:
+		return
+	}
+
+	data := map[string]string{
+		"bookingId": strconv.FormatInt(booking.Id, 10),
+		"title":     booking.Title,
+		"startTime": booking.StartTime.UTC().Format(time.RFC3339),
+	}
+
+	if err := s.notifier.Notify(ctx, booking.StudentId, notificationType, data); err != nil {
+		log.Errorf("Failed to notify student about booking %d: %v", booking.Id, err)
+	}
+}
@@ -0,0 +1,20 @@
+package booking
+
+import (
+	"context"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// promoteWaitlist passes the place of a cancelled scheduled event booking on to the waitlist. Failures are
+// logged only, the cancellation itself already succeeded.
+func promoteWaitlist(ctx context.Context, log logger.Logger, waitlist WaitlistPromoter, booking *entities.Booking) {
+	if booking.ScheduledEventId == nil {
+		return
+	}
+
+	if err := waitlist.PromoteNext(ctx, *booking.ScheduledEventId); err != nil {
+		log.Errorf("Failed to promote waitlist of scheduled event %d: %v", *booking.ScheduledEventId, err)
+	}
+}
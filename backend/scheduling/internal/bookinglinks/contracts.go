@@ -0,0 +1,99 @@
+package bookinglinks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// swagger:model BookingLinkRequest
+type BookingLinkRequest struct {
+	Label           *string   `json:"label"`
+	WorkingPeriodId int64     `json:"workingPeriodId"`
+	SessionTypeId   *int64    `json:"sessionTypeId"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	// MaxUses is how many bookings the link allows in total, each student may use it once
+	MaxUses   int       `json:"maxUses"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// swagger:model BookingLinkResponse
+type BookingLinkResponse struct {
+	Id              int64      `json:"id"`
+	Label           *string    `json:"label"`
+	WorkingPeriodId int64      `json:"workingPeriodId"`
+	SessionTypeId   *int64     `json:"sessionTypeId"`
+	StartTime       time.Time  `json:"startTime"`
+	EndTime         time.Time  `json:"endTime"`
+	MaxUses         int        `json:"maxUses"`
+	Uses            int        `json:"uses"`
+	ExpiresAt       time.Time  `json:"expiresAt"`
+	Token           string     `json:"token"`
+	RevokedAt       *time.Time `json:"revokedAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
+
+// swagger:model PublicBookingLinkResponse
+type PublicBookingLinkResponse struct {
+	EducatorId    string    `json:"educatorId"`
+	Label         *string   `json:"label"`
+	SessionTypeId *int64    `json:"sessionTypeId"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+	RemainingUses int       `json:"remainingUses"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+func (b *BookingLinkRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if b.Label != nil && len(strings.TrimSpace(*b.Label)) > 100 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Label",
+			Message: "must be at most 100 characters",
+		})
+	}
+
+	if b.WorkingPeriodId <= 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "WorkingPeriodId",
+			Message: "must be greater than zero",
+		})
+	}
+
+	if b.SessionTypeId != nil && *b.SessionTypeId <= 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "SessionTypeId",
+			Message: "must be greater than zero",
+		})
+	}
+
+	if b.StartTime.IsZero() || b.EndTime.IsZero() || !b.StartTime.Before(b.EndTime) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "StartTime",
+			Message: "must be set and before EndTime",
+		})
+	}
+
+	if b.MaxUses <= 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "MaxUses",
+			Message: "must be greater than zero",
+		})
+	}
+
+	if b.ExpiresAt.IsZero() {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "ExpiresAt",
+			Message: "must not be empty",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Booking link request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
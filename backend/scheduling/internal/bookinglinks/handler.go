@@ -0,0 +1,120 @@
+package bookinglinks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type BookingLinkHandler struct {
+	service *BookingLinkService
+}
+
+func NewBookingLinkHandler(service *BookingLinkService) *BookingLinkHandler {
+	return &BookingLinkHandler{service: service}
+}
+
+// GetMyBookingLinks retrieves the booking links of the current educator.
+// @Summary      Retrieve my booking links
+// @Description  Retrieves all booking links of the educator, including revoked and expired ones, newest first.
+// @Tags         BookingLink
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   BookingLinkResponse  "Booking links"
+// @Failure      401  {object}  error                "Unauthorized"
+// @Router       /api/v1/booking-links [get]
+// @Security 	 BearerAuth
+func (h *BookingLinkHandler) GetMyBookingLinks(w http.ResponseWriter, r *http.Request) {
+	links, err := h.service.GetMyBookingLinks(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, links)
+}
+
+// CreateBookingLink creates a limited-use link to a slot of the educator.
+// @Summary      Create booking link
+// @Description  Creates a signed link a student can book the slot with, such as a make-up lesson. The link allows up to maxUses bookings, one per student, until it expires or is revoked.
+// @Tags         BookingLink
+// @Accept       json
+// @Produce      json
+// @Param        link  body      BookingLinkRequest   true  "Booking link slot and limits"
+// @Success      201   {object}  BookingLinkResponse  "Created booking link"
+// @Failure      400   {object}  error                "Invalid input"
+// @Failure      422   {object}  error                "Slot or limits not allowed"
+// @Router       /api/v1/booking-links [post]
+// @Security 	 BearerAuth
+func (h *BookingLinkHandler) CreateBookingLink(w http.ResponseWriter, r *http.Request) {
+	var request *BookingLinkRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	link, err := h.service.CreateBookingLink(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, link)
+}
+
+// RevokeBookingLink revokes a booking link of the current educator.
+// @Summary      Revoke booking link
+// @Description  Revokes the booking link, so its token can no longer be resolved or booked with. Bookings already made are kept.
+// @Tags         BookingLink
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Booking link ID"
+// @Success      204  "Booking link revoked successfully"
+// @Failure      404  {object}  error  "Booking link not found"
+// @Router       /api/v1/booking-links/{id} [delete]
+// @Security 	 BearerAuth
+func (h *BookingLinkHandler) RevokeBookingLink(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.RevokeBookingLink(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetPublicBookingLink retrieves the slot offered by a booking link.
+// @Summary      Retrieve booking link slot
+// @Description  Resolves a booking link token without authentication and returns the slot it offers and how many uses remain. Revoked, expired and used up links are not found.
+// @Tags         BookingLink
+// @Accept       json
+// @Produce      json
+// @Param        token  path      string                     true  "Booking link token"
+// @Success      200    {object}  PublicBookingLinkResponse  "Booking link slot"
+// @Failure      404    {object}  error                      "Booking link not found"
+// @Router       /api/v1/booking-links/public/{token} [get]
+func (h *BookingLinkHandler) GetPublicBookingLink(w http.ResponseWriter, r *http.Request) {
+	link, err := h.service.GetPublicBookingLink(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, link)
+}
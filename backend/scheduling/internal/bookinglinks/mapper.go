@@ -0,0 +1,59 @@
+package bookinglinks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToBookingLink(request *BookingLinkRequest, educatorId uuid.UUID) *entities.BookingLink {
+	var label *string
+	if request.Label != nil {
+		trimmed := strings.TrimSpace(*request.Label)
+		label = &trimmed
+	}
+
+	return &entities.BookingLink{
+		EducatorId:      educatorId,
+		WorkingPeriodId: request.WorkingPeriodId,
+		SessionTypeId:   request.SessionTypeId,
+		Label:           label,
+		StartTime:       request.StartTime.UTC(),
+		EndTime:         request.EndTime.UTC(),
+		MaxUses:         request.MaxUses,
+		ExpiresAt:       request.ExpiresAt.UTC(),
+		CreatedAt:       time.Now().UTC(),
+	}
+}
+
+func MapBookingLinkToResponse(link *entities.BookingLink, token string) *BookingLinkResponse {
+	return &BookingLinkResponse{
+		Id:              link.Id,
+		Label:           link.Label,
+		WorkingPeriodId: link.WorkingPeriodId,
+		SessionTypeId:   link.SessionTypeId,
+		StartTime:       link.StartTime,
+		EndTime:         link.EndTime,
+		MaxUses:         link.MaxUses,
+		Uses:            link.Uses,
+		ExpiresAt:       link.ExpiresAt,
+		Token:           token,
+		RevokedAt:       link.RevokedAt,
+		CreatedAt:       link.CreatedAt,
+	}
+}
+
+func MapBookingLinkToPublicResponse(link *entities.BookingLink) *PublicBookingLinkResponse {
+	return &PublicBookingLinkResponse{
+		EducatorId:    link.EducatorId.String(),
+		Label:         link.Label,
+		SessionTypeId: link.SessionTypeId,
+		StartTime:     link.StartTime,
+		EndTime:       link.EndTime,
+		RemainingUses: link.MaxUses - link.Uses,
+		ExpiresAt:     link.ExpiresAt,
+	}
+}
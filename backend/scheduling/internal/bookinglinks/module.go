@@ -0,0 +1,21 @@
+package bookinglinks
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeBookingLinkService(log logger.Logger, db *sqlx.DB, cfg *config.BookingLinkConfig) *BookingLinkService {
+	repo := NewBookingLinkRepository(db)
+	service := NewBookingLinkService(log, repo, cfg, NewSigner(cfg.SigningKey))
+	return service
+}
+
+func InitializeBookingLinkHTTPHandler(service *BookingLinkService) http.Handler {
+	handler := NewBookingLinkHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,73 @@
+package bookinglinks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type BookingLinkRepo struct {
+	db *sqlx.DB
+}
+
+func NewBookingLinkRepository(db *sqlx.DB) *BookingLinkRepo {
+	return &BookingLinkRepo{db: db}
+}
+
+// GetBookingLinkById retrieves a booking link, including revoked and expired ones
+func (r *BookingLinkRepo) GetBookingLinkById(ctx context.Context, id int64) (*entities.BookingLink, error) {
+	const query = `
+		SELECT id, educator_id, working_period_id, session_type_id, label, start_time, end_time, max_uses, uses, expires_at, revoked_at, created_at
+		FROM booking_link
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.BookingLink](ctx, r.db, query, id)
+}
+
+// GetEducatorBookingLinks retrieves the booking links of an educator, newest first
+func (r *BookingLinkRepo) GetEducatorBookingLinks(ctx context.Context, educatorId uuid.UUID) ([]*entities.BookingLink, error) {
+	const query = `
+		SELECT id, educator_id, working_period_id, session_type_id, label, start_time, end_time, max_uses, uses, expires_at, revoked_at, created_at
+		FROM booking_link
+		WHERE educator_id = $1
+		ORDER BY created_at DESC
+	`
+	return database.FetchMultiple[entities.BookingLink](ctx, r.db, query, educatorId)
+}
+
+// AddBookingLink adds a new booking link and returns its Id
+func (r *BookingLinkRepo) AddBookingLink(ctx context.Context, link *entities.BookingLink) (int64, error) {
+	const query = `
+		INSERT INTO booking_link (educator_id, working_period_id, session_type_id, label, start_time, end_time, max_uses, expires_at, created_at)
+		VALUES (:educator_id, :working_period_id, :session_type_id, :label, :start_time, :end_time, :max_uses, :expires_at, :created_at)
+		RETURNING id
+	`
+	return database.ExecNamedQueryWithResult[int64](ctx, r.db, query, link)
+}
+
+// RevokeBookingLink revokes an active booking link of an educator
+func (r *BookingLinkRepo) RevokeBookingLink(ctx context.Context, educatorId uuid.UUID, id int64, revokedAt time.Time) error {
+	const query = `UPDATE booking_link SET revoked_at = $3 WHERE id = $1 AND educator_id = $2 AND revoked_at IS NULL`
+	return database.ExecQuery(ctx, r.db, query, id, educatorId, revokedAt)
+}
+
+// GetWorkingPeriodById retrieves a working period of an educator
+func (r *BookingLinkRepo) GetWorkingPeriodById(ctx context.Context, educatorId uuid.UUID, id int64) (*entities.WorkingPeriod, error) {
+	const query = `
+		SELECT id, user_id, start_time, end_time, recurrence_id, created_at, updated_at
+		FROM working_period
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`
+	return database.FetchSingle[entities.WorkingPeriod](ctx, r.db, query, id, educatorId)
+}
+
+// SessionTypeExists checks that an active session type belongs to the educator
+func (r *BookingLinkRepo) SessionTypeExists(ctx context.Context, educatorId uuid.UUID, id int64) (bool, error) {
+	const query = `SELECT EXISTS (SELECT 1 FROM session_type WHERE id = $1 AND educator_id = $2 AND archived_at IS NULL)`
+	return database.CheckExists(ctx, r.db, query, id, educatorId)
+}
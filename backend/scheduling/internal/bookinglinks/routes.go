@@ -0,0 +1,25 @@
+package bookinglinks
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+// PublicPathPrefix is served without authentication, see AuthMiddleware public routes
+const PublicPathPrefix = "/api/v1/booking-links/public"
+
+func Routes(handler *BookingLinkHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/public/{token}", handler.GetPublicBookingLink)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Get("/", handler.GetMyBookingLinks)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Post("/", handler.CreateBookingLink)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Delete("/{id}", handler.RevokeBookingLink)
+
+	return r
+}
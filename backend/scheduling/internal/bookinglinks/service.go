@@ -0,0 +1,164 @@
+package bookinglinks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type BookingLinkRepository interface {
+	GetBookingLinkById(ctx context.Context, id int64) (*entities.BookingLink, error)
+	GetEducatorBookingLinks(ctx context.Context, educatorId uuid.UUID) ([]*entities.BookingLink, error)
+	AddBookingLink(ctx context.Context, link *entities.BookingLink) (int64, error)
+	RevokeBookingLink(ctx context.Context, educatorId uuid.UUID, id int64, revokedAt time.Time) error
+	GetWorkingPeriodById(ctx context.Context, educatorId uuid.UUID, id int64) (*entities.WorkingPeriod, error)
+	SessionTypeExists(ctx context.Context, educatorId uuid.UUID, id int64) (bool, error)
+}
+
+type BookingLinkService struct {
+	log    logger.Logger
+	repo   BookingLinkRepository
+	cfg    *config.BookingLinkConfig
+	signer *Signer
+}
+
+func NewBookingLinkService(log logger.Logger, repo BookingLinkRepository, cfg *config.BookingLinkConfig, signer *Signer) *BookingLinkService {
+	return &BookingLinkService{log: log, repo: repo, cfg: cfg, signer: signer}
+}
+
+// CreateBookingLink creates a limited-use link to a slot within one of the educator's working periods
+func (s *BookingLinkService) CreateBookingLink(ctx context.Context, request *BookingLinkRequest) (*BookingLinkResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	now := time.Now().UTC()
+	if request.MaxUses > s.cfg.MaxUses {
+		return nil, apperrors.NewUnprocessedEntity("Booking link allows too many uses", apperrors.ErrBookingLinkInvalid)
+	}
+	if !request.ExpiresAt.After(now) || request.ExpiresAt.Sub(now) > time.Duration(s.cfg.MaxValidityDays)*24*time.Hour {
+		return nil, apperrors.NewUnprocessedEntity("Booking link expiry is out of range", apperrors.ErrBookingLinkInvalid)
+	}
+	if !request.StartTime.After(now) {
+		return nil, apperrors.NewUnprocessedEntity("Booking link slot must be in the future", apperrors.ErrBookingLinkInvalid)
+	}
+
+	period, err := s.repo.GetWorkingPeriodById(ctx, userId, request.WorkingPeriodId)
+	if err != nil {
+		log.Error("failed to get working period", err)
+		return nil, err
+	}
+	if request.StartTime.Before(period.StartTime) || request.EndTime.After(period.EndTime) {
+		return nil, apperrors.NewUnprocessedEntity("Booking link slot is outside the working period", apperrors.ErrBookingLinkInvalid)
+	}
+
+	if request.SessionTypeId != nil {
+		exists, err := s.repo.SessionTypeExists(ctx, userId, *request.SessionTypeId)
+		if err != nil {
+			log.Error("failed to check session type", err)
+			return nil, err
+		}
+		if !exists {
+			return nil, apperrors.NewUnprocessedEntity("Session type not found", apperrors.ErrSessionTypeInvalid)
+		}
+	}
+
+	link := MapRequestToBookingLink(request, userId)
+	id, err := s.repo.AddBookingLink(ctx, link)
+	if err != nil {
+		log.Error("failed to add booking link", err)
+		return nil, err
+	}
+	link.Id = id
+
+	tenantId, _ := auth.GetTenantID(ctx)
+	return MapBookingLinkToResponse(link, s.signer.Sign(tenantId, link.Id)), nil
+}
+
+func (s *BookingLinkService) GetMyBookingLinks(ctx context.Context) ([]*BookingLinkResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	links, err := s.repo.GetEducatorBookingLinks(ctx, userId)
+	if err != nil {
+		log.Error("failed to get booking links", err)
+		return nil, err
+	}
+
+	tenantId, _ := auth.GetTenantID(ctx)
+	result := make([]*BookingLinkResponse, 0, len(links))
+	for _, link := range links {
+		result = append(result, MapBookingLinkToResponse(link, s.signer.Sign(tenantId, link.Id)))
+	}
+	return result, nil
+}
+
+func (s *BookingLinkService) RevokeBookingLink(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	link, err := s.repo.GetBookingLinkById(ctx, id)
+	if err != nil {
+		log.Error("failed to get booking link", err)
+		return err
+	}
+
+	if link.EducatorId != userId {
+		return apperrors.NewForbidden("Access denied")
+	}
+
+	if err := s.repo.RevokeBookingLink(ctx, userId, id, time.Now().UTC()); err != nil {
+		log.Error("failed to revoke booking link", err)
+		return err
+	}
+	return nil
+}
+
+// GetPublicBookingLink resolves a link token into the slot it offers. Revoked, expired and used up
+// links are reported as not found, like tokens with an invalid signature.
+func (s *BookingLinkService) GetPublicBookingLink(ctx context.Context, token string) (*PublicBookingLinkResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	id, tenantId, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	if tenantId != "" {
+		ctx = auth.WithTenant(ctx, tenantId)
+	}
+
+	link, err := s.repo.GetBookingLinkById(ctx, id)
+	if err != nil {
+		log.Error("failed to get booking link", err)
+		return nil, err
+	}
+
+	if !Usable(link, time.Now().UTC()) {
+		return nil, apperrors.NewNotFound("Booking link not found", apperrors.ErrBookingLinkInvalid)
+	}
+
+	return MapBookingLinkToPublicResponse(link), nil
+}
+
+// Usable reports whether a link can still be redeemed at the given time
+func Usable(link *entities.BookingLink, now time.Time) bool {
+	return link.RevokedAt == nil && link.ExpiresAt.After(now) && link.Uses < link.MaxUses && link.StartTime.After(now)
+}
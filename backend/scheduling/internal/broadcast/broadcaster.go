@@ -0,0 +1,167 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// Kinds of signals instances send each other
+const (
+	// SignalJWKS tells that the JWK set was rotated, instances fetch it again and drop validated tokens
+	SignalJWKS = "jwks"
+	// SignalLogLevels carries the log levels set on an instance
+	SignalLogLevels = "log_levels"
+	// SignalSampling carries the trace sampling policy set on an instance
+	SignalSampling = "sampling"
+	// SignalReadOnly carries the read-only mode switched on an instance
+	SignalReadOnly = "read_only"
+)
+
+// maxPayload is the largest payload Postgres accepts for a notification
+const maxPayload = 8000
+
+// Handler applies a signal sent by another instance
+type Handler func(ctx context.Context, data json.RawMessage) error
+
+// Signal is the payload of a notification
+type Signal struct {
+	Kind     string          `json:"kind"`
+	Instance uuid.UUID       `json:"instance"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// Broadcaster sends signals to every running instance over Postgres LISTEN/NOTIFY and applies the signals
+// of the others, so caches and runtime settings converge within seconds without a restart. Signals sent
+// while an instance is not listening are lost to it, it catches up through the expiry of its caches or the
+// next change.
+type Broadcaster struct {
+	log      logger.Logger
+	pool     *pgxpool.Pool
+	db       *sqlx.DB
+	cfg      *config.BroadcastConfig
+	instance uuid.UUID
+	handlers map[string][]Handler
+}
+
+func NewBroadcaster(log logger.Logger, pool *pgxpool.Pool, db *sqlx.DB, cfg *config.BroadcastConfig) *Broadcaster {
+	return &Broadcaster{log: log, pool: pool, db: db, cfg: cfg, instance: uuid.New(), handlers: map[string][]Handler{}}
+}
+
+// Subscribe registers a handler for the signals of a kind sent by other instances, handlers must be
+// registered before Run
+func (b *Broadcaster) Subscribe(kind string, handler Handler) {
+	b.handlers[kind] = append(b.handlers[kind], handler)
+}
+
+// Broadcast sends a signal to the other instances. Within a transaction it is delivered once the transaction
+// commits, and not at all when it rolls back. A disabled broadcaster sends nothing.
+func (b *Broadcaster) Broadcast(ctx context.Context, kind string, data any) error {
+	if !b.cfg.Enabled {
+		return nil
+	}
+
+	signal := Signal{Kind: kind, Instance: b.instance}
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s signal: %w", kind, err)
+		}
+		signal.Data = raw
+	}
+
+	payload, err := json.Marshal(signal)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s signal: %w", kind, err)
+	}
+	if len(payload) > maxPayload {
+		return fmt.Errorf("%s signal of %d bytes exceeds the notification limit", kind, len(payload))
+	}
+
+	const query = `SELECT pg_notify($1, $2)`
+	if _, err := database.Conn(ctx, b.db).ExecContext(ctx, query, b.cfg.Channel, string(payload)); err != nil {
+		return fmt.Errorf("failed to broadcast %s signal: %w", kind, err)
+	}
+	return nil
+}
+
+// Run listens for signals until the context is cancelled, listening again after the connection is lost
+func (b *Broadcaster) Run(ctx context.Context) {
+	if !b.cfg.Enabled {
+		return
+	}
+
+	for {
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		b.log.Errorf("Broadcast listener stopped, listening again in %ds: %v", b.cfg.ReconnectSeconds, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Duration(b.cfg.ReconnectSeconds) * time.Second):
+		}
+	}
+}
+
+// listen takes a connection out of the pool for LISTEN and dispatches notifications until the connection
+// fails
+func (b *Broadcaster) listen(ctx context.Context) error {
+	pooled, err := b.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection is closed instead of returned, it would stay subscribed to the channel in the pool
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.cfg.Channel}.Sanitize()); err != nil {
+		return err
+	}
+	b.log.Infof("Listening for broadcasts on %s", b.cfg.Channel)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		b.dispatch(ctx, notification.Payload)
+	}
+}
+
+// dispatch applies a signal of another instance, failures are logged and do not stop listening
+func (b *Broadcaster) dispatch(ctx context.Context, payload string) {
+	var signal Signal
+	if err := json.Unmarshal([]byte(payload), &signal); err != nil {
+		b.log.Errorf("Dropping malformed broadcast: %v", err)
+		return
+	}
+	if signal.Instance == b.instance {
+		return
+	}
+
+	handlers, ok := b.handlers[signal.Kind]
+	if !ok {
+		b.log.Debugf("Ignoring %s broadcast without handlers", signal.Kind)
+		return
+	}
+	for _, handler := range handlers {
+		if err := handler(ctx, signal.Data); err != nil && !errors.Is(err, context.Canceled) {
+			b.log.Errorf("Failed to apply %s broadcast: %v", signal.Kind, err)
+		}
+	}
+	b.log.Infof("Applied %s broadcast of instance %s", signal.Kind, signal.Instance)
+}
@@ -0,0 +1,82 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/maksmelnyk/scheduling/config"
+)
+
+// QueueStats holds the figures the RabbitMQ management API reports for a single queue
+type QueueStats struct {
+	Name      string `json:"name"`
+	Messages  int64  `json:"messages"`
+	Ready     int64  `json:"messages_ready"`
+	Unacked   int64  `json:"messages_unacknowledged"`
+	Consumers int64  `json:"consumers"`
+	State     string `json:"state"`
+}
+
+type ManagementClient struct {
+	baseURL    string
+	vhost      string
+	userName   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewManagementClient creates a management API client, authenticating with the AMQP credentials unless
+// dedicated ones are configured
+func NewManagementClient(cfg *config.RabbitMqConfig, httpClient *http.Client) *ManagementClient {
+	userName, password := cfg.ManagementUserName, cfg.ManagementPassword
+	if userName == "" {
+		userName, password = cfg.UserName, cfg.Password
+	}
+
+	return &ManagementClient{
+		baseURL:    cfg.ManagementUrl,
+		vhost:      cfg.VirtualHost,
+		userName:   userName,
+		password:   password,
+		httpClient: httpClient,
+	}
+}
+
+// GetQueues retrieves the statistics of every queue in the configured virtual host
+func (c *ManagementClient) GetQueues(ctx context.Context) ([]*QueueStats, error) {
+	fullURL, err := url.JoinPath(c.baseURL, "api/queues", url.PathEscape(c.vhost))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := req.URL.Query()
+	query.Set("columns", "name,messages,messages_ready,messages_unacknowledged,consumers,state")
+	req.URL.RawQuery = query.Encode()
+	req.SetBasicAuth(c.userName, c.password)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("Request failed with status:" + resp.Status)
+	}
+
+	var queues []*QueueStats
+	if err := json.NewDecoder(resp.Body).Decode(&queues); err != nil {
+		return nil, errors.New("Failed to decode response:" + err.Error())
+	}
+
+	return queues, nil
+}
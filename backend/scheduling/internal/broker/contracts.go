@@ -0,0 +1,19 @@
+package broker
+
+import "time"
+
+// swagger:model BrokerQueuesResponse
+type BrokerQueuesResponse struct {
+	CollectedAt time.Time             `json:"collectedAt"`
+	Queues      []*QueueStatsResponse `json:"queues"`
+}
+
+// swagger:model QueueStatsResponse
+type QueueStatsResponse struct {
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Messages  int64  `json:"messages"`
+	Ready     int64  `json:"ready"`
+	Unacked   int64  `json:"unacked"`
+	Consumers int64  `json:"consumers"`
+}
@@ -0,0 +1,35 @@
+package broker
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+)
+
+type BrokerHandler struct {
+	service *BrokerService
+}
+
+func NewBrokerHandler(service *BrokerService) *BrokerHandler {
+	return &BrokerHandler{service: service}
+}
+
+// GetQueueStats retrieves statistics of the service's broker queues.
+// @Summary      Retrieve broker queue statistics
+// @Description  Returns depth, unacked messages and consumer count of the service's RabbitMQ queues from the management API. Statistics are cached for a few seconds.
+// @Tags         Broker
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  BrokerQueuesResponse  "Queue statistics"
+// @Failure      404  {object}  error                 "Management API integration not configured"
+// @Router       /api/v1/broker/queues [get]
+// @Security 	 BearerAuth
+func (h *BrokerHandler) GetQueueStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.GetQueueStats(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, stats)
+}
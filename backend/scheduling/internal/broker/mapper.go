@@ -0,0 +1,21 @@
+package broker
+
+import "time"
+
+func MapQueuesToResponse(queues []*QueueStats, collectedAt time.Time) *BrokerQueuesResponse {
+	response := &BrokerQueuesResponse{
+		CollectedAt: collectedAt,
+		Queues:      make([]*QueueStatsResponse, len(queues)),
+	}
+	for i, q := range queues {
+		response.Queues[i] = &QueueStatsResponse{
+			Name:      q.Name,
+			State:     q.State,
+			Messages:  q.Messages,
+			Ready:     q.Ready,
+			Unacked:   q.Unacked,
+			Consumers: q.Consumers,
+		}
+	}
+	return response
+}
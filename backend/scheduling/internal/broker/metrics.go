@@ -0,0 +1,47 @@
+package broker
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// registerQueueMetrics reports queue depths, unacked messages and consumer counts as gauges per queue.
+// A failed snapshot skips the observation instead of failing the whole collection.
+func registerQueueMetrics(meter metric.Meter, service *BrokerService) error {
+	ready, err := meter.Int64ObservableGauge("scheduling.broker.queue.messages_ready",
+		metric.WithDescription("Messages ready for delivery, by queue"))
+	if err != nil {
+		return err
+	}
+
+	unacked, err := meter.Int64ObservableGauge("scheduling.broker.queue.messages_unacked",
+		metric.WithDescription("Messages delivered but not yet acknowledged, by queue"))
+	if err != nil {
+		return err
+	}
+
+	consumers, err := meter.Int64ObservableGauge("scheduling.broker.queue.consumers",
+		metric.WithDescription("Consumers attached, by queue"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		queues, _, err := service.snapshot(ctx)
+		if err != nil {
+			service.log.Errorf("Failed to collect broker queue metrics: %v", err)
+			return nil
+		}
+
+		for _, q := range queues {
+			attrs := metric.WithAttributes(attribute.String("queue", q.Name))
+			o.ObserveInt64(ready, q.Ready, attrs)
+			o.ObserveInt64(unacked, q.Unacked, attrs)
+			o.ObserveInt64(consumers, q.Consumers, attrs)
+		}
+		return nil
+	}, ready, unacked, consumers)
+	return err
+}
@@ -0,0 +1,34 @@
+package broker
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// InitializeBrokerService wires the management API integration. Without a management URL the service
+// stays disabled and no metrics are registered.
+func InitializeBrokerService(
+	log logger.Logger,
+	cfg *config.RabbitMqConfig,
+	httpClient *http.Client,
+	meter metric.Meter,
+) (*BrokerService, error) {
+	if cfg.ManagementUrl == "" {
+		return NewBrokerService(log, nil, cfg), nil
+	}
+
+	service := NewBrokerService(log, NewManagementClient(cfg, httpClient), cfg)
+	if err := registerQueueMetrics(meter, service); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+func InitializeBrokerHTTPHandler(service *BrokerService) http.Handler {
+	handler := NewBrokerHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,20 @@
+package broker
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *BrokerHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.AdminRole))
+	r.Get("/queues", handler.GetQueueStats)
+
+	return r
+}
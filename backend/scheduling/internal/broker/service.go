@@ -0,0 +1,81 @@
+package broker
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// QueueStatsProvider retrieves queue statistics from the broker
+type QueueStatsProvider interface {
+	GetQueues(ctx context.Context) ([]*QueueStats, error)
+}
+
+// BrokerService exposes queue statistics of the service's queues. Snapshots are cached briefly, so
+// metric collection and the admin endpoint share a single management API call.
+type BrokerService struct {
+	log    logger.Logger
+	client QueueStatsProvider
+	cfg    *config.RabbitMqConfig
+
+	mu          sync.Mutex
+	queues      []*QueueStats
+	collectedAt time.Time
+}
+
+func NewBrokerService(log logger.Logger, client QueueStatsProvider, cfg *config.RabbitMqConfig) *BrokerService {
+	return &BrokerService{log: log, client: client, cfg: cfg}
+}
+
+// Enabled reports whether the management API integration is configured
+func (s *BrokerService) Enabled() bool {
+	return s.client != nil
+}
+
+func (s *BrokerService) GetQueueStats(ctx context.Context) (*BrokerQueuesResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if !s.Enabled() {
+		return nil, apperrors.NewNotFound("Broker statistics are not configured", apperrors.ErrBrokerStatsDisabled)
+	}
+
+	queues, collectedAt, err := s.snapshot(ctx)
+	if err != nil {
+		log.Error("failed to get broker queue statistics", err)
+		return nil, apperrors.NewInternal(err)
+	}
+
+	return MapQueuesToResponse(queues, collectedAt), nil
+}
+
+// snapshot returns the statistics of queues matching the configured prefix, fetching them again once the
+// cached snapshot is older than the cache period
+func (s *BrokerService) snapshot(ctx context.Context) ([]*QueueStats, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	if s.queues != nil && now.Sub(s.collectedAt) < time.Duration(s.cfg.ManagementCacheSeconds)*time.Second {
+		return s.queues, s.collectedAt, nil
+	}
+
+	queues, err := s.client.GetQueues(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	filtered := make([]*QueueStats, 0, len(queues))
+	for _, q := range queues {
+		if strings.HasPrefix(q.Name, s.cfg.ManagementQueuePrefix) {
+			filtered = append(filtered, q)
+		}
+	}
+
+	s.queues, s.collectedAt = filtered, now
+	return s.queues, s.collectedAt, nil
+}
@@ -0,0 +1,196 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Options bound how long and how many entries a cache keeps. A zero TTL disables caching, concurrent
+// loads are still shared. A zero MaxEntries leaves the cache unbounded.
+type Options struct {
+	TTL        time.Duration
+	MaxEntries int
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// call is a load in flight that concurrent lookups of the same key wait for
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Cache is a concurrent-safe in-memory cache with expiry and least recently used eviction. Loads of a
+// missing key through GetOrLoad are shared by every caller asking for it at the same time, so an expired
+// entry is fetched once instead of once per request.
+type Cache[K comparable, V any] struct {
+	opts     Options
+	mu       sync.Mutex
+	order    *list.List
+	entries  map[K]*list.Element
+	inflight map[K]*call[V]
+	metrics  *cacheMetrics
+}
+
+// New creates a cache the name identifies in metrics
+func New[K comparable, V any](name string, opts Options, meter metric.Meter) (*Cache[K, V], error) {
+	c := &Cache[K, V]{
+		opts:     opts,
+		order:    list.New(),
+		entries:  make(map[K]*list.Element),
+		inflight: make(map[K]*call[V]),
+	}
+
+	metrics, err := newCacheMetrics(meter, name, c.Len)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register %s cache metrics: %w", name, err)
+	}
+	c.metrics = metrics
+	return c, nil
+}
+
+// Get returns the value cached for a key unless it expired
+func (c *Cache[K, V]) Get(ctx context.Context, key K) (V, bool) {
+	c.mu.Lock()
+	value, ok := c.get(key, time.Now())
+	c.mu.Unlock()
+
+	c.metrics.recordLookup(ctx, ok)
+	return value, ok
+}
+
+// Set caches a value for the TTL of the cache
+func (c *Cache[K, V]) Set(ctx context.Context, key K, value V) {
+	c.SetWithTTL(ctx, key, value, c.opts.TTL)
+}
+
+// SetWithTTL caches a value for its own TTL, for values that must not outlive their expiry
+func (c *Cache[K, V]) SetWithTTL(ctx context.Context, key K, value V, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	evicted := c.set(key, value, ttl, time.Now())
+	c.mu.Unlock()
+
+	c.metrics.recordEvictions(ctx, evicted)
+}
+
+// Delete drops the value cached for a key
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// Clear drops every cached value, loads in flight still complete and cache their results
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.entries)
+}
+
+// Len returns the number of cached entries, expired ones not yet dropped included
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// GetOrLoad returns the value cached for a key or loads and caches it. Callers asking for a key that is
+// already being loaded wait for that load and share its result, failed loads are not cached.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, load func(ctx context.Context) (V, error)) (V, error) {
+	c.mu.Lock()
+	if value, ok := c.get(key, time.Now()); ok {
+		c.mu.Unlock()
+		c.metrics.recordLookup(ctx, true)
+		return value, nil
+	}
+	c.metrics.recordLookup(ctx, false)
+
+	if pending, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-pending.done:
+			return pending.value, pending.err
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+	}
+
+	pending := &call[V]{done: make(chan struct{})}
+	c.inflight[key] = pending
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.inflight, key)
+		c.mu.Unlock()
+		close(pending.done)
+	}()
+
+	pending.value, pending.err = load(ctx)
+	if pending.err == nil {
+		c.Set(ctx, key, pending.value)
+	}
+	return pending.value, pending.err
+}
+
+func (c *Cache[K, V]) get(key K, now time.Time) (V, bool) {
+	el, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	e := el.Value.(*entry[K, V])
+	if now.After(e.expiresAt) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// set stores a value and returns how many entries had to be evicted to make room for it
+func (c *Cache[K, V]) set(key K, value V, ttl time.Duration, now time.Time) int {
+	expiresAt := now.Add(ttl)
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*entry[K, V])
+		e.value, e.expiresAt = value, expiresAt
+		c.order.MoveToFront(el)
+		return 0
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+
+	evicted := 0
+	for c.opts.MaxEntries > 0 && c.order.Len() > c.opts.MaxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*entry[K, V]).key)
+		evicted++
+	}
+	return evicted
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type cacheMetrics struct {
+	lookups   metric.Int64Counter
+	evictions metric.Int64Counter
+	hit       metric.MeasurementOption
+	miss      metric.MeasurementOption
+	cache     metric.MeasurementOption
+}
+
+// newCacheMetrics registers the lookup and eviction counters of a named cache, and its size gauge when
+// the size is known
+func newCacheMetrics(meter metric.Meter, name string, size func() int) (*cacheMetrics, error) {
+	lookups, err := meter.Int64Counter("scheduling.cache.lookups",
+		metric.WithDescription("Cache lookups, by cache and whether they hit"))
+	if err != nil {
+		return nil, err
+	}
+
+	evictions, err := meter.Int64Counter("scheduling.cache.evictions",
+		metric.WithDescription("Entries evicted to keep caches within their size bound"))
+	if err != nil {
+		return nil, err
+	}
+
+	cacheAttr := attribute.String("cache", name)
+	if size != nil {
+		entries, err := meter.Int64ObservableGauge("scheduling.cache.entries",
+			metric.WithDescription("Entries currently held by a cache"))
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+			o.ObserveInt64(entries, int64(size()), metric.WithAttributes(cacheAttr))
+			return nil
+		}, entries)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &cacheMetrics{
+		lookups:   lookups,
+		evictions: evictions,
+		hit:       metric.WithAttributes(cacheAttr, attribute.String("result", "hit")),
+		miss:      metric.WithAttributes(cacheAttr, attribute.String("result", "miss")),
+		cache:     metric.WithAttributes(cacheAttr),
+	}, nil
+}
+
+func (m *cacheMetrics) recordLookup(ctx context.Context, hit bool) {
+	if hit {
+		m.lookups.Add(ctx, 1, m.hit)
+	} else {
+		m.lookups.Add(ctx, 1, m.miss)
+	}
+}
+
+func (m *cacheMetrics) recordEvictions(ctx context.Context, evicted int) {
+	if evicted > 0 {
+		m.evictions.Add(ctx, int64(evicted), m.cache)
+	}
+}
@@ -0,0 +1,221 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+)
+
+// errNil is the reply of Redis for missing keys
+var errNil = errors.New("redis: nil")
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// RedisClient speaks the subset of the Redis protocol the caches need over a small pool of connections.
+// Connections that fail a command are closed instead of being returned to the pool.
+type RedisClient struct {
+	addr      string
+	password  string
+	db        int
+	opTimeout time.Duration
+	dialer    net.Dialer
+	pool      chan *redisConn
+}
+
+// NewRedisClient parses a redis://[:password@]host:port[/db] URL. No connection is made until the first command.
+func NewRedisClient(cfg *config.RedisConfig) (*RedisClient, error) {
+	u, err := url.Parse(cfg.Url)
+	if err != nil || u.Scheme != "redis" || u.Host == "" {
+		return nil, fmt.Errorf("invalid redis url %q", cfg.Url)
+	}
+
+	c := &RedisClient{
+		addr:      u.Host,
+		opTimeout: time.Duration(cfg.OpTimeoutMs) * time.Millisecond,
+		dialer:    net.Dialer{Timeout: time.Duration(cfg.DialTimeoutMs) * time.Millisecond},
+		pool:      make(chan *redisConn, max(cfg.PoolSize, 1)),
+	}
+	if password, ok := u.User.Password(); ok {
+		c.password = password
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		if c.db, err = strconv.Atoi(db); err != nil {
+			return nil, fmt.Errorf("invalid redis database %q", db)
+		}
+	}
+	return c, nil
+}
+
+// Get returns the value of a key, false when it does not exist
+func (c *RedisClient) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.do(ctx, "GET", key)
+	if errors.Is(err, errNil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return reply.([]byte), true, nil
+}
+
+// Set stores a value that expires after the ttl
+func (c *RedisClient) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := c.do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// Incr increments a counter and makes it expire after the ttl, returning the new value
+func (c *RedisClient) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	reply, err := c.do(ctx, "INCR", key)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := c.do(ctx, "PEXPIRE", key, strconv.FormatInt(ttl.Milliseconds(), 10)); err != nil {
+		return 0, err
+	}
+	return reply.(int64), nil
+}
+
+// Eval runs a Lua script atomically on the given keys and arguments, returning its integer or string reply
+func (c *RedisClient) Eval(ctx context.Context, script string, keys []string, args ...string) (any, error) {
+	command := append([]string{"EVAL", script, strconv.Itoa(len(keys))}, keys...)
+	return c.do(ctx, append(command, args...)...)
+}
+
+// Ping checks that Redis answers
+func (c *RedisClient) Ping(ctx context.Context) error {
+	_, err := c.do(ctx, "PING")
+	return err
+}
+
+// Close closes the idle connections of the pool
+func (c *RedisClient) Close() error {
+	for {
+		select {
+		case rc := <-c.pool:
+			rc.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (c *RedisClient) do(ctx context.Context, args ...string) (any, error) {
+	rc, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(c.opTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	rc.conn.SetDeadline(deadline)
+
+	reply, err := rc.command(args...)
+	if err != nil && !errors.Is(err, errNil) {
+		rc.conn.Close()
+		return nil, err
+	}
+
+	c.release(rc)
+	return reply, err
+}
+
+func (c *RedisClient) acquire(ctx context.Context) (*redisConn, error) {
+	select {
+	case rc := <-c.pool:
+		return rc, nil
+	default:
+	}
+
+	conn, err := c.dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	rc := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	conn.SetDeadline(time.Now().Add(c.opTimeout))
+	if c.password != "" {
+		if _, err := rc.command("AUTH", c.password); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to authenticate to redis: %w", err)
+		}
+	}
+	if c.db != 0 {
+		if _, err := rc.command("SELECT", strconv.Itoa(c.db)); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to select redis database: %w", err)
+		}
+	}
+	return rc, nil
+}
+
+func (c *RedisClient) release(rc *redisConn) {
+	select {
+	case c.pool <- rc:
+	default:
+		rc.conn.Close()
+	}
+}
+
+// command writes a command as an array of bulk strings and reads its reply
+func (rc *redisConn) command(args ...string) (any, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := rc.conn.Write([]byte(b.String())); err != nil {
+		return nil, err
+	}
+	return rc.readReply()
+}
+
+// readReply reads a simple string, error, integer or bulk string reply
+func (rc *redisConn) readReply() (any, error) {
+	line, err := rc.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, errNil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(rc.reader, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	default:
+		return nil, fmt.Errorf("redis: unsupported reply %q", line)
+	}
+}
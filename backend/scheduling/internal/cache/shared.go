@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// SharedCache keeps JSON values in Redis, shared by every instance of the service. Entries belong to an
+// owner, such as an educator, and carry the owner's generation in their key: invalidating an owner bumps
+// the generation, which orphans all of its entries at once until they expire.
+//
+// The cache is optional. A nil SharedCache misses every lookup and ignores writes, and Redis failures are
+// logged and treated as misses so requests fall back to the database.
+type SharedCache struct {
+	log     logger.Logger
+	client  *RedisClient
+	prefix  string
+	ttl     time.Duration
+	metrics *cacheMetrics
+}
+
+// NewSharedCache creates a cache under a key prefix, nil when there is no Redis client
+func NewSharedCache(log logger.Logger, client *RedisClient, prefix string, ttl time.Duration, meter metric.Meter) (*SharedCache, error) {
+	if client == nil {
+		return nil, nil
+	}
+
+	metrics, err := newCacheMetrics(meter, prefix, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register %s cache metrics: %w", prefix, err)
+	}
+	return &SharedCache{log: log, client: client, prefix: prefix, ttl: ttl, metrics: metrics}, nil
+}
+
+// Get decodes the value cached for a key of an owner into dest and reports whether there was one
+func (c *SharedCache) Get(ctx context.Context, owner, key string, dest any) bool {
+	if c == nil {
+		return false
+	}
+
+	entryKey, err := c.entryKey(ctx, owner, key)
+	if err != nil {
+		c.warn(ctx, "read", owner, err)
+		return false
+	}
+
+	data, ok, err := c.client.Get(ctx, entryKey)
+	if err != nil {
+		c.warn(ctx, "read", owner, err)
+		return false
+	}
+	if ok {
+		ok = json.Unmarshal(data, dest) == nil
+	}
+
+	c.metrics.recordLookup(ctx, ok)
+	return ok
+}
+
+// Set caches a value for a key of an owner
+func (c *SharedCache) Set(ctx context.Context, owner, key string, value any) {
+	if c == nil {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.warn(ctx, "write", owner, err)
+		return
+	}
+
+	entryKey, err := c.entryKey(ctx, owner, key)
+	if err == nil {
+		err = c.client.Set(ctx, entryKey, data, c.ttl)
+	}
+	if err != nil {
+		c.warn(ctx, "write", owner, err)
+	}
+}
+
+// Invalidate drops every entry cached for an owner
+func (c *SharedCache) Invalidate(ctx context.Context, owner string) {
+	if c == nil {
+		return
+	}
+
+	// The generation outlives the entries written under the previous one, so it can not expire back to
+	// a generation whose entries are still cached
+	if _, err := c.client.Incr(ctx, c.generationKey(owner), 2*c.ttl); err != nil {
+		c.warn(ctx, "invalidate", owner, err)
+	}
+}
+
+func (c *SharedCache) entryKey(ctx context.Context, owner, key string) (string, error) {
+	generation, _, err := c.client.Get(ctx, c.generationKey(owner))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", c.prefix, owner, generation, key), nil
+}
+
+func (c *SharedCache) generationKey(owner string) string {
+	return fmt.Sprintf("%s:%s:generation", c.prefix, owner)
+}
+
+func (c *SharedCache) warn(ctx context.Context, op string, owner string, err error) {
+	logger.FromContext(ctx, c.log).Warnf("Failed to %s %s cache of %s: %v", op, c.prefix, owner, err)
+}
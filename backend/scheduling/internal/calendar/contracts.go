@@ -0,0 +1,15 @@
+package calendar
+
+// swagger:model CalendarMonthResponse
+type CalendarMonthResponse struct {
+	EducatorId string                 `json:"educatorId"`
+	Month      string                 `json:"month"`
+	Days       []*CalendarDayResponse `json:"days"`
+}
+
+// swagger:model CalendarDayResponse
+type CalendarDayResponse struct {
+	Date        string `json:"date"`
+	SlotCount   int    `json:"slotCount"`
+	BookedCount int    `json:"bookedCount"`
+}
@@ -0,0 +1,76 @@
+package calendar
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type CalendarHandler struct {
+	service *CalendarService
+}
+
+func NewCalendarHandler(service *CalendarService) *CalendarHandler {
+	return &CalendarHandler{service: service}
+}
+
+// GetMonthView retrieves the month overview of an educator's calendar.
+// @Summary      Retrieve calendar month view
+// @Description  Returns the number of slots and active bookings per day of the month, with days taken in the educator's time zone. Days without slots or bookings are omitted. Counts are projected from schedule changes and may lag them by a few seconds.
+// @Tags         Calendar
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string  true  "Educator ID (UUID)"
+// @Param        month       query     string  true  "Month in YYYY-MM format"
+// @Success      200         {object}  CalendarMonthResponse  "Month view"
+// @Failure      400         {object}  error                  "Invalid input parameters"
+// @Router       /api/v1/calendar/{educatorId}/month [get]
+// @Security 	 BearerAuth
+func (h *CalendarHandler) GetMonthView(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	month, err := api.ParseTimeQuery(w, r, "month", "2006-01")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	view, err := h.service.GetMonthView(r.Context(), educatorId, month)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, view)
+}
+
+// RebuildEducator recounts the whole calendar projection of an educator.
+// @Summary      Rebuild calendar projection
+// @Description  Recounts every projected day of the educator from the schedule. Meant for repairs, regular changes are projected automatically.
+// @Tags         Calendar
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path  string  true  "Educator ID (UUID)"
+// @Success      204  "Projection rebuilt"
+// @Failure      400  {object}  error  "Invalid input parameters"
+// @Router       /api/v1/calendar/{educatorId}/rebuild [post]
+// @Security 	 BearerAuth
+func (h *CalendarHandler) RebuildEducator(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	if err := h.service.RebuildEducator(r.Context(), educatorId); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,25 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapSummariesToMonthResponse(educatorId uuid.UUID, month time.Time, summaries []*entities.CalendarDaySummary) *CalendarMonthResponse {
+	response := &CalendarMonthResponse{
+		EducatorId: educatorId.String(),
+		Month:      month.Format("2006-01"),
+		Days:       make([]*CalendarDayResponse, len(summaries)),
+	}
+	for i, s := range summaries {
+		response.Days[i] = &CalendarDayResponse{
+			Date:        s.Day.Format(time.DateOnly),
+			SlotCount:   s.SlotCount,
+			BookedCount: s.BookedCount,
+		}
+	}
+	return response
+}
@@ -0,0 +1,26 @@
+package calendar
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeCalendarService(log logger.Logger, db *sqlx.DB) *CalendarService {
+	repo := NewCalendarRepository(db)
+	service := NewCalendarService(log, repo)
+	return service
+}
+
+func InitializeProjectionJob(log logger.Logger, db *sqlx.DB, cfg *config.CalendarProjectionConfig) *ProjectionJob {
+	repo := NewCalendarRepository(db)
+	return NewProjectionJob(log, repo, cfg)
+}
+
+func InitializeCalendarHTTPHandler(service *CalendarService) http.Handler {
+	handler := NewCalendarHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,115 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const projectionBatchSize = 500
+
+type ProjectionRepository interface {
+	CalendarRepository
+	GetProjectionWatermark(ctx context.Context) (*time.Time, error)
+	SetProjectionWatermark(ctx context.Context, changedAt time.Time) error
+	GetChangedDays(ctx context.Context, after time.Time) ([]*ChangedDay, error)
+	GetTimezoneChangedEducators(ctx context.Context, after time.Time) ([]*ChangedEducator, error)
+}
+
+// ProjectionJob keeps the calendar day projection up to date. Every schedule change bumps the updated
+// time of its row or leaves a tombstone, so the job reads those changes after its watermark and recounts
+// only the touched days. Changes committed late with an earlier time are caught by re-reading a short
+// lag window on every run, recounting is idempotent.
+type ProjectionJob struct {
+	log  logger.Logger
+	repo ProjectionRepository
+	cfg  *config.CalendarProjectionConfig
+}
+
+func NewProjectionJob(log logger.Logger, repo ProjectionRepository, cfg *config.CalendarProjectionConfig) *ProjectionJob {
+	return &ProjectionJob{log: log, repo: repo, cfg: cfg}
+}
+
+// Run projects schedule changes on every interval until the context is cancelled
+func (j *ProjectionJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.ProjectChanges(ctx); err != nil {
+				j.log.Errorf("Failed to project calendar changes: %v", err)
+			}
+		}
+	}
+}
+
+// ProjectChanges recounts the days changed since the watermark and advances it. The first run, without
+// a watermark, projects the whole history.
+func (j *ProjectionJob) ProjectChanges(ctx context.Context) error {
+	watermark, err := j.repo.GetProjectionWatermark(ctx)
+	if err != nil {
+		return err
+	}
+
+	after := time.Time{}
+	if watermark != nil {
+		after = watermark.Add(-time.Duration(j.cfg.LagSeconds) * time.Second)
+	}
+	now := time.Now().UTC()
+
+	changed, err := j.repo.GetChangedDays(ctx, after)
+	if err != nil {
+		return err
+	}
+
+	latest := after
+	for start := 0; start < len(changed); start += projectionBatchSize {
+		batch := changed[start:min(start+projectionBatchSize, len(changed))]
+		ids := make([]uuid.UUID, len(batch))
+		days := make([]time.Time, len(batch))
+		for i, c := range batch {
+			ids[i], days[i] = c.EducatorId, c.Day
+			if c.ChangedAt.After(latest) {
+				latest = c.ChangedAt
+			}
+		}
+		if err := j.repo.RefreshDays(ctx, ids, days, now); err != nil {
+			return err
+		}
+	}
+
+	// A time zone change moves every day of the educator, the affected days cannot be told from the change
+	if watermark != nil {
+		educators, err := j.repo.GetTimezoneChangedEducators(ctx, after)
+		if err != nil {
+			return err
+		}
+		for _, e := range educators {
+			if err := rebuildEducator(ctx, j.repo, e.EducatorId, now); err != nil {
+				return err
+			}
+			if e.ChangedAt.After(latest) {
+				latest = e.ChangedAt
+			}
+		}
+	}
+
+	if latest.After(after) {
+		if err := j.repo.SetProjectionWatermark(ctx, latest); err != nil {
+			return err
+		}
+	}
+
+	if len(changed) > 0 {
+		j.log.Debugf("Projected %d changed calendar days", len(changed))
+	}
+	return nil
+}
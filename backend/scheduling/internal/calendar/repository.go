@@ -0,0 +1,165 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// ChangedDay is a day of an educator touched by a schedule change, with the time of its latest change
+type ChangedDay struct {
+	EducatorId uuid.UUID `db:"educator_id"`
+	Day        time.Time `db:"day"`
+	ChangedAt  time.Time `db:"changed_at"`
+}
+
+// ChangedEducator is an educator whose scheduling policy changed, with the time of the change
+type ChangedEducator struct {
+	EducatorId uuid.UUID `db:"educator_id"`
+	ChangedAt  time.Time `db:"changed_at"`
+}
+
+type projectionState struct {
+	LastChangedAt *time.Time `db:"last_changed_at"`
+}
+
+type CalendarRepo struct {
+	db *sqlx.DB
+}
+
+func NewCalendarRepository(db *sqlx.DB) *CalendarRepo {
+	return &CalendarRepo{db: db}
+}
+
+// GetDaySummaries retrieves the projected days of an educator within a date range, the end being exclusive
+func (r *CalendarRepo) GetDaySummaries(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.CalendarDaySummary, error) {
+	const query = `
+		SELECT educator_id, day, slot_count, booked_count, updated_at
+		FROM calendar_day_summary
+		WHERE educator_id = $1 AND day >= $2 AND day < $3
+		ORDER BY day
+	`
+	return database.FetchMultiple[entities.CalendarDaySummary](ctx, r.db, query, educatorId, from.Format(time.DateOnly), to.Format(time.DateOnly))
+}
+
+// GetProjectionWatermark returns the latest change already projected, nil before the first projection
+func (r *CalendarRepo) GetProjectionWatermark(ctx context.Context) (*time.Time, error) {
+	const query = `SELECT last_changed_at FROM calendar_projection_state WHERE id = 1`
+	state, err := database.FetchSingle[projectionState](ctx, r.db, query)
+	if err != nil {
+		return nil, err
+	}
+	return state.LastChangedAt, nil
+}
+
+// SetProjectionWatermark records the latest projected change, never moving the watermark back
+func (r *CalendarRepo) SetProjectionWatermark(ctx context.Context, changedAt time.Time) error {
+	const query = `
+		UPDATE calendar_projection_state
+		SET last_changed_at = GREATEST(COALESCE(last_changed_at, $1), $1)
+		WHERE id = 1
+	`
+	return database.ExecQuery(ctx, r.db, query, changedAt)
+}
+
+// GetChangedDays lists the educator days whose working periods, scheduled events or bookings were created,
+// changed or removed after a point in time. Days are taken in the educator's scheduling time zone.
+func (r *CalendarRepo) GetChangedDays(ctx context.Context, after time.Time) ([]*ChangedDay, error) {
+	const query = `
+		SELECT c.educator_id, (c.start_time AT TIME ZONE COALESCE(sp.timezone, 'UTC'))::date AS day, MAX(c.changed_at) AS changed_at
+		FROM (
+			SELECT user_id AS educator_id, start_time, updated_at AS changed_at FROM working_period WHERE updated_at > $1
+			UNION ALL
+			SELECT user_id, start_time, updated_at FROM scheduled_event WHERE updated_at > $1 AND start_time IS NOT NULL
+			UNION ALL
+			SELECT educator_id, start_time, updated_at FROM booking WHERE updated_at > $1
+			UNION ALL
+			SELECT educator_id, start_time, deleted_at FROM availability_tombstone WHERE deleted_at > $1
+		) c
+		LEFT JOIN scheduling_policy sp ON sp.educator_id = c.educator_id
+		GROUP BY 1, 2
+	`
+	return database.FetchMultiple[ChangedDay](ctx, r.db, query, after)
+}
+
+// GetTimezoneChangedEducators lists educators whose scheduling policy changed after a point in time, which
+// may shift every one of their days
+func (r *CalendarRepo) GetTimezoneChangedEducators(ctx context.Context, after time.Time) ([]*ChangedEducator, error) {
+	const query = `SELECT educator_id, updated_at AS changed_at FROM scheduling_policy WHERE updated_at > $1`
+	return database.FetchMultiple[ChangedEducator](ctx, r.db, query, after)
+}
+
+// GetEducatorDays lists every day of an educator that has schedule entries or a projected summary
+func (r *CalendarRepo) GetEducatorDays(ctx context.Context, educatorId uuid.UUID) ([]time.Time, error) {
+	const query = `
+		SELECT DISTINCT (e.start_time AT TIME ZONE COALESCE(sp.timezone, 'UTC'))::date AS day
+		FROM (
+			SELECT user_id AS educator_id, start_time FROM working_period WHERE user_id = $1 AND deleted_at IS NULL
+			UNION ALL
+			SELECT user_id, start_time FROM scheduled_event WHERE user_id = $1 AND start_time IS NOT NULL AND deleted_at IS NULL
+			UNION ALL
+			SELECT educator_id, start_time FROM booking WHERE educator_id = $1
+		) e
+		LEFT JOIN scheduling_policy sp ON sp.educator_id = e.educator_id
+		UNION
+		SELECT day FROM calendar_day_summary WHERE educator_id = $1
+	`
+	var days []time.Time
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &days, query, educatorId); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return days, nil
+}
+
+// RefreshDays recounts the slots and active bookings of the given educator days from the source tables.
+// Days left without any entry are removed from the projection.
+func (r *CalendarRepo) RefreshDays(ctx context.Context, educatorIds []uuid.UUID, days []time.Time, now time.Time) error {
+	if len(educatorIds) == 0 {
+		return nil
+	}
+
+	ids := make(pq.StringArray, len(educatorIds))
+	dates := make(pq.StringArray, len(days))
+	for i := range educatorIds {
+		ids[i] = educatorIds[i].String()
+		dates[i] = days[i].Format(time.DateOnly)
+	}
+
+	const query = `
+		WITH targets AS (
+			SELECT DISTINCT t.educator_id, t.day, COALESCE(sp.timezone, 'UTC') AS tz
+			FROM unnest($1::uuid[], $2::date[]) AS t(educator_id, day)
+			LEFT JOIN scheduling_policy sp ON sp.educator_id = t.educator_id
+		), bounds AS (
+			SELECT educator_id, day, day::timestamp AT TIME ZONE tz AS day_start, (day + 1)::timestamp AT TIME ZONE tz AS day_end
+			FROM targets
+		), counts AS (
+			SELECT b.educator_id, b.day,
+				(SELECT COUNT(*) FROM working_period wp
+				 WHERE wp.user_id = b.educator_id AND wp.start_time >= b.day_start AND wp.start_time < b.day_end AND wp.deleted_at IS NULL)
+				+ (SELECT COUNT(*) FROM scheduled_event se
+				 WHERE se.user_id = b.educator_id AND se.start_time >= b.day_start AND se.start_time < b.day_end AND se.deleted_at IS NULL) AS slot_count,
+				(SELECT COUNT(*) FROM booking bk
+				 WHERE bk.educator_id = b.educator_id AND bk.status != $3 AND bk.start_time >= b.day_start AND bk.start_time < b.day_end) AS booked_count
+			FROM bounds b
+		), upserted AS (
+			INSERT INTO calendar_day_summary (educator_id, day, slot_count, booked_count, updated_at)
+			SELECT educator_id, day, slot_count, booked_count, $4
+			FROM counts
+			WHERE slot_count > 0 OR booked_count > 0
+			ON CONFLICT (educator_id, day) DO UPDATE
+			SET slot_count = EXCLUDED.slot_count, booked_count = EXCLUDED.booked_count, updated_at = EXCLUDED.updated_at
+		)
+		DELETE FROM calendar_day_summary s
+		USING counts c
+		WHERE s.educator_id = c.educator_id AND s.day = c.day AND c.slot_count = 0 AND c.booked_count = 0
+	`
+	return database.ExecQuery(ctx, r.db, query, ids, dates, entities.Cancelled, now)
+}
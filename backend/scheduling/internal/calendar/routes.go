@@ -0,0 +1,20 @@
+package calendar
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *CalendarHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/{educatorId}/month", handler.GetMonthView)
+	r.With(middleware.RequireRole(auth.AdminRole)).Post("/{educatorId}/rebuild", handler.RebuildEducator)
+
+	return r
+}
@@ -0,0 +1,72 @@
+package calendar
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type CalendarRepository interface {
+	GetDaySummaries(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.CalendarDaySummary, error)
+	GetEducatorDays(ctx context.Context, educatorId uuid.UUID) ([]time.Time, error)
+	RefreshDays(ctx context.Context, educatorIds []uuid.UUID, days []time.Time, now time.Time) error
+}
+
+// CalendarService serves the month overview of an educator from the day projection. Only days with
+// slots or bookings are returned, missing days are empty.
+type CalendarService struct {
+	log  logger.Logger
+	repo CalendarRepository
+}
+
+func NewCalendarService(log logger.Logger, repo CalendarRepository) *CalendarService {
+	return &CalendarService{log: log, repo: repo}
+}
+
+func (s *CalendarService) GetMonthView(ctx context.Context, educatorId uuid.UUID, month time.Time) (*CalendarMonthResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	summaries, err := s.repo.GetDaySummaries(ctx, educatorId, from, from.AddDate(0, 1, 0))
+	if err != nil {
+		log.Error("failed to get calendar day summaries", err)
+		return nil, err
+	}
+
+	return MapSummariesToMonthResponse(educatorId, from, summaries), nil
+}
+
+// RebuildEducator recounts every day of an educator, repairing the projection after changes it could not
+// attribute to a day, such as a slot moved to another month
+func (s *CalendarService) RebuildEducator(ctx context.Context, educatorId uuid.UUID) error {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := rebuildEducator(ctx, s.repo, educatorId, time.Now().UTC()); err != nil {
+		log.Error("failed to rebuild calendar projection", err)
+		return err
+	}
+	return nil
+}
+
+func rebuildEducator(ctx context.Context, repo CalendarRepository, educatorId uuid.UUID, now time.Time) error {
+	days, err := repo.GetEducatorDays(ctx, educatorId)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(days); start += projectionBatchSize {
+		batch := days[start:min(start+projectionBatchSize, len(days))]
+		ids := make([]uuid.UUID, len(batch))
+		for i := range ids {
+			ids[i] = educatorId
+		}
+		if err := repo.RefreshDays(ctx, ids, batch, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package cancellationrules
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+const maxNoticeHours = 30 * 24
+
+// swagger:model CancellationRuleRequest
+type CancellationRuleRequest struct {
+	SessionTypeId         *int64  `json:"sessionTypeId"`
+	MinNoticeHours        int     `json:"minNoticeHours"`
+	FreeCancellationHours int     `json:"freeCancellationHours"`
+	PenaltyPercent        float64 `json:"penaltyPercent"`
+}
+
+// swagger:model CancellationRuleResponse
+type CancellationRuleResponse struct {
+	Id                    int64     `json:"id"`
+	SessionTypeId         *int64    `json:"sessionTypeId"`
+	MinNoticeHours        int       `json:"minNoticeHours"`
+	FreeCancellationHours int       `json:"freeCancellationHours"`
+	PenaltyPercent        float64   `json:"penaltyPercent"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+}
+
+func (c *CancellationRuleRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if c.SessionTypeId != nil && *c.SessionTypeId <= 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "SessionTypeId",
+			Message: "must be greater than zero",
+		})
+	}
+
+	if c.MinNoticeHours < 0 || c.MinNoticeHours > maxNoticeHours {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "MinNoticeHours",
+			Message: "must be between 0 and 720 hours",
+		})
+	}
+
+	if c.FreeCancellationHours < 0 || c.FreeCancellationHours > maxNoticeHours {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "FreeCancellationHours",
+			Message: "must be between 0 and 720 hours",
+		})
+	} else if c.FreeCancellationHours < c.MinNoticeHours {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "FreeCancellationHours",
+			Message: "must not be less than MinNoticeHours",
+		})
+	}
+
+	if c.PenaltyPercent < 0 || c.PenaltyPercent > 100 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "PenaltyPercent",
+			Message: "must be between 0 and 100",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Cancellation rule request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package cancellationrules
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type CancellationRuleHandler struct {
+	service *CancellationRuleService
+}
+
+func NewCancellationRuleHandler(service *CancellationRuleService) *CancellationRuleHandler {
+	return &CancellationRuleHandler{service: service}
+}
+
+// GetEducatorRules retrieves the cancellation rules of an educator.
+// @Summary      Retrieve educator cancellation rules
+// @Description  Retrieves the rules students of the educator cancel under, the default rule first. A session type's rule takes precedence over the default; without any rule bookings can be cancelled free of charge until they start.
+// @Tags         CancellationRule
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string                     true  "Educator ID (UUID)"
+// @Success      200         {array}   CancellationRuleResponse   "Cancellation rules"
+// @Failure      400         {object}  error                      "Invalid input parameters"
+// @Router       /api/v1/cancellation-rules/educators/{educatorId} [get]
+// @Security 	 BearerAuth
+func (h *CancellationRuleHandler) GetEducatorRules(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	rules, err := h.service.GetEducatorRules(r.Context(), educatorId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, rules)
+}
+
+// SaveRule creates or replaces a cancellation rule.
+// @Summary      Save cancellation rule
+// @Description  Creates or replaces the educator's cancellation rule for a session type, or the default rule when no session type is given. Cancellations with less than the minimum notice are rejected, cancellations within the free cancellation window are charged the penalty percentage of the price.
+// @Tags         CancellationRule
+// @Accept       json
+// @Produce      json
+// @Param        rule  body      CancellationRuleRequest   true  "Cancellation rule"
+// @Success      200   {object}  CancellationRuleResponse  "Saved rule"
+// @Failure      400   {object}  error                     "Invalid input"
+// @Failure      422   {object}  error                     "Session type not found"
+// @Router       /api/v1/cancellation-rules [put]
+// @Security 	 BearerAuth
+func (h *CancellationRuleHandler) SaveRule(w http.ResponseWriter, r *http.Request) {
+	var request *CancellationRuleRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	rule, err := h.service.SaveRule(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, rule)
+}
+
+// DeleteRule deletes a cancellation rule.
+// @Summary      Delete cancellation rule
+// @Description  Deletes a cancellation rule of the educator. Bookings of its session type fall back to the default rule.
+// @Tags         CancellationRule
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Cancellation rule ID"
+// @Success      204  "Cancellation rule deleted successfully"
+// @Failure      404  {object}  error  "Cancellation rule not found"
+// @Router       /api/v1/cancellation-rules/{id} [delete]
+// @Security 	 BearerAuth
+func (h *CancellationRuleHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	if err := h.service.DeleteRule(r.Context(), id); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,42 @@
+package cancellationrules
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToCancellationRule(educatorId uuid.UUID, r *CancellationRuleRequest) *entities.CancellationRule {
+	now := time.Now().UTC()
+	return &entities.CancellationRule{
+		EducatorId:            educatorId,
+		SessionTypeId:         r.SessionTypeId,
+		MinNoticeHours:        r.MinNoticeHours,
+		FreeCancellationHours: r.FreeCancellationHours,
+		PenaltyPercent:        math.Round(r.PenaltyPercent*100) / 100,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+}
+
+func MapCancellationRuleToResponse(r *entities.CancellationRule) *CancellationRuleResponse {
+	return &CancellationRuleResponse{
+		Id:                    r.Id,
+		SessionTypeId:         r.SessionTypeId,
+		MinNoticeHours:        r.MinNoticeHours,
+		FreeCancellationHours: r.FreeCancellationHours,
+		PenaltyPercent:        r.PenaltyPercent,
+		UpdatedAt:             r.UpdatedAt,
+	}
+}
+
+func MapCancellationRulesToResponse(rs []*entities.CancellationRule) []*CancellationRuleResponse {
+	response := make([]*CancellationRuleResponse, len(rs))
+	for i, r := range rs {
+		response[i] = MapCancellationRuleToResponse(r)
+	}
+	return response
+}
@@ -0,0 +1,20 @@
+package cancellationrules
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeCancellationRuleService(log logger.Logger, db *sqlx.DB) *CancellationRuleService {
+	repo := NewCancellationRuleRepository(db)
+	service := NewCancellationRuleService(log, repo)
+	return service
+}
+
+func InitializeCancellationRuleHTTPHandler(service *CancellationRuleService) http.Handler {
+	handler := NewCancellationRuleHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,70 @@
+package cancellationrules
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type CancellationRuleRepo struct {
+	db *sqlx.DB
+}
+
+func NewCancellationRuleRepository(db *sqlx.DB) *CancellationRuleRepo {
+	return &CancellationRuleRepo{db: db}
+}
+
+// GetEducatorRules retrieves the cancellation rules of an educator, the default rule first
+func (r *CancellationRuleRepo) GetEducatorRules(ctx context.Context, educatorId uuid.UUID) ([]*entities.CancellationRule, error) {
+	const query = `
+		SELECT id, educator_id, session_type_id, min_notice_hours, free_cancellation_hours, penalty_percent, created_at, updated_at
+		FROM cancellation_rule
+		WHERE educator_id = $1
+		ORDER BY session_type_id NULLS FIRST
+	`
+	return database.FetchMultiple[entities.CancellationRule](ctx, r.db, query, educatorId)
+}
+
+// SessionTypeExists checks that an active session type belongs to the educator
+func (r *CancellationRuleRepo) SessionTypeExists(ctx context.Context, educatorId uuid.UUID, id int64) (bool, error) {
+	const query = `SELECT EXISTS (SELECT 1 FROM session_type WHERE id = $1 AND educator_id = $2 AND archived_at IS NULL)`
+	return database.CheckExists(ctx, r.db, query, id, educatorId)
+}
+
+// UpsertRule creates or replaces the rule of an educator for a session type, or the default rule without
+// one, and returns the stored rule
+func (r *CancellationRuleRepo) UpsertRule(ctx context.Context, rule *entities.CancellationRule) (*entities.CancellationRule, error) {
+	const query = `
+		INSERT INTO cancellation_rule (educator_id, session_type_id, min_notice_hours, free_cancellation_hours, penalty_percent, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (educator_id, session_type_id) DO UPDATE
+		SET min_notice_hours = EXCLUDED.min_notice_hours, free_cancellation_hours = EXCLUDED.free_cancellation_hours,
+			penalty_percent = EXCLUDED.penalty_percent, updated_at = EXCLUDED.updated_at
+		RETURNING id, educator_id, session_type_id, min_notice_hours, free_cancellation_hours, penalty_percent, created_at, updated_at
+	`
+	return database.FetchSingle[entities.CancellationRule](ctx, r.db, query,
+		rule.EducatorId, rule.SessionTypeId, rule.MinNoticeHours, rule.FreeCancellationHours, rule.PenaltyPercent, rule.UpdatedAt)
+}
+
+// DeleteRule removes a rule of an educator
+func (r *CancellationRuleRepo) DeleteRule(ctx context.Context, educatorId uuid.UUID, id int64) error {
+	const query = `DELETE FROM cancellation_rule WHERE id = $1 AND educator_id = $2`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, id, educatorId)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	if affected == 0 {
+		return apperrors.NewNotFound("Cancellation rule not found", apperrors.ErrResourceNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package cancellationrules
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *CancellationRuleHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/educators/{educatorId}", handler.GetEducatorRules)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Put("/", handler.SaveRule)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Delete("/{id}", handler.DeleteRule)
+
+	return r
+}
@@ -0,0 +1,87 @@
+package cancellationrules
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type CancellationRuleRepository interface {
+	GetEducatorRules(ctx context.Context, educatorId uuid.UUID) ([]*entities.CancellationRule, error)
+	SessionTypeExists(ctx context.Context, educatorId uuid.UUID, id int64) (bool, error)
+	UpsertRule(ctx context.Context, rule *entities.CancellationRule) (*entities.CancellationRule, error)
+	DeleteRule(ctx context.Context, educatorId uuid.UUID, id int64) error
+}
+
+type CancellationRuleService struct {
+	log  logger.Logger
+	repo CancellationRuleRepository
+}
+
+func NewCancellationRuleService(log logger.Logger, repo CancellationRuleRepository) *CancellationRuleService {
+	return &CancellationRuleService{log: log, repo: repo}
+}
+
+// GetEducatorRules retrieves the cancellation rules students of an educator cancel under
+func (s *CancellationRuleService) GetEducatorRules(ctx context.Context, educatorId uuid.UUID) ([]*CancellationRuleResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	rules, err := s.repo.GetEducatorRules(ctx, educatorId)
+	if err != nil {
+		log.Error("failed to get cancellation rules", err)
+		return nil, err
+	}
+
+	return MapCancellationRulesToResponse(rules), nil
+}
+
+// SaveRule creates or replaces the current educator's rule for a session type, or their default rule
+func (s *CancellationRuleService) SaveRule(ctx context.Context, request *CancellationRuleRequest) (*CancellationRuleResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if request.SessionTypeId != nil {
+		exists, err := s.repo.SessionTypeExists(ctx, userId, *request.SessionTypeId)
+		if err != nil {
+			log.Error("failed to check session type", err)
+			return nil, err
+		}
+		if !exists {
+			return nil, apperrors.NewUnprocessedEntity("Session type not found for this educator", apperrors.ErrSessionTypeInvalid)
+		}
+	}
+
+	rule, err := s.repo.UpsertRule(ctx, MapRequestToCancellationRule(userId, request))
+	if err != nil {
+		log.Error("failed to save cancellation rule", err)
+		return nil, err
+	}
+
+	return MapCancellationRuleToResponse(rule), nil
+}
+
+// DeleteRule removes a rule of the current educator
+func (s *CancellationRuleService) DeleteRule(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if err := s.repo.DeleteRule(ctx, userId, id); err != nil {
+		log.Error("failed to delete cancellation rule", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package cancellations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+const (
+	maxRange  = 366 * 24 * time.Hour
+	batchSize = 100
+)
+
+// Fingerprint identifies the set of affected bookings and their statuses, so a cancellation can be
+// rejected when bookings changed after the preview was made
+func Fingerprint(bookings []*entities.Booking) string {
+	ids := make([]string, 0, len(bookings))
+	for _, b := range bookings {
+		ids = append(ids, strconv.FormatInt(b.Id, 10)+":"+strconv.Itoa(int(b.Status)))
+	}
+	slices.Sort(ids)
+
+	h := sha256.New()
+	for _, id := range ids {
+		h.Write([]byte(id))
+		h.Write([]byte{','})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// isRefundable reports whether a booking was paid, pending bookings are released without a refund
+func isRefundable(b *entities.Booking) bool {
+	return b.Status == entities.Approved && b.Price > 0
+}
+
+// refundTotal sums the prices of the refundable bookings
+func refundTotal(bookings []*entities.Booking) float64 {
+	var total float64
+	for _, b := range bookings {
+		if isRefundable(b) {
+			total += b.Price
+		}
+	}
+	return math.Round(total*100) / 100
+}
+
+// studentCount counts the distinct students of the bookings
+func studentCount(bookings []*entities.Booking) int {
+	students := make(map[string]struct{}, len(bookings))
+	for _, b := range bookings {
+		students[b.StudentId.String()] = struct{}{}
+	}
+	return len(students)
+}
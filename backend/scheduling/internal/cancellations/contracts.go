@@ -0,0 +1,84 @@
+package cancellations
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// swagger:model BulkCancellationRequest
+type BulkCancellationRequest struct {
+	FromDate    time.Time `json:"fromDate"`
+	ToDate      time.Time `json:"toDate"`
+	Reason      *string   `json:"reason"`
+	Fingerprint string    `json:"fingerprint"`
+}
+
+// swagger:model BulkCancellationPreviewResponse
+type BulkCancellationPreviewResponse struct {
+	FromDate            time.Time                  `json:"fromDate"`
+	ToDate              time.Time                  `json:"toDate"`
+	WorkingPeriodCount  int                        `json:"workingPeriodCount"`
+	ScheduledEventCount int                        `json:"scheduledEventCount"`
+	BookingCount        int                        `json:"bookingCount"`
+	StudentCount        int                        `json:"studentCount"`
+	RefundTotal         float64                    `json:"refundTotal"`
+	Fingerprint         string                     `json:"fingerprint"`
+	Bookings            []*AffectedBookingResponse `json:"bookings"`
+}
+
+// swagger:model AffectedBookingResponse
+type AffectedBookingResponse struct {
+	Id               int64     `json:"id"`
+	StudentId        uuid.UUID `json:"studentId"`
+	ScheduledEventId *int64    `json:"scheduledEventId"`
+	Title            string    `json:"title"`
+	StartTime        time.Time `json:"startTime"`
+	EndTime          time.Time `json:"endTime"`
+	Status           string    `json:"status"`
+	Price            float64   `json:"price"`
+	Refundable       bool      `json:"refundable"`
+}
+
+// swagger:model BulkCancellationResponse
+type BulkCancellationResponse struct {
+	OperationId       string  `json:"operationId"`
+	BookingsCancelled int     `json:"bookingsCancelled"`
+	EventsReleased    int64   `json:"eventsReleased"`
+	PeriodsReleased   int64   `json:"periodsReleased"`
+	RefundTotal       float64 `json:"refundTotal"`
+	BatchesPublished  int     `json:"batchesPublished"`
+}
+
+func (b *BulkCancellationRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if b.FromDate.IsZero() || b.ToDate.IsZero() || !b.FromDate.Before(b.ToDate) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "FromDate",
+			Message: "must be set and before ToDate",
+		})
+	}
+
+	if b.ToDate.Sub(b.FromDate) > maxRange {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "ToDate",
+			Message: "must be within 366 days of FromDate",
+		})
+	}
+
+	if b.Reason != nil && len(*b.Reason) > 500 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Reason",
+			Message: "must be at most 500 characters",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Bulk cancellation request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package cancellations
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type CancellationHandler struct {
+	service *CancellationService
+}
+
+func NewCancellationHandler(service *CancellationService) *CancellationHandler {
+	return &CancellationHandler{service: service}
+}
+
+// PreviewCancellation previews cancelling the current educator's slots within a range.
+// @Summary      Preview bulk cancellation
+// @Description  Lists the bookings, scheduled events and working periods that cancelling the range would affect, with student count, refund total and a fingerprint to pass to the cancellation.
+// @Tags         BulkCancellation
+// @Accept       json
+// @Produce      json
+// @Param        cancellation  body      BulkCancellationRequest          true  "Cancellation range"
+// @Success      200           {object}  BulkCancellationPreviewResponse  "Cancellation impact"
+// @Failure      400           {object}  error                            "Invalid input"
+// @Router       /api/v1/bulk-cancellations/preview [post]
+// @Security 	 BearerAuth
+func (h *CancellationHandler) PreviewCancellation(w http.ResponseWriter, r *http.Request) {
+	var request *BulkCancellationRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	preview, err := h.service.PreviewCancellation(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, preview)
+}
+
+// ExecuteCancellation cancels the current educator's slots within a range.
+// @Summary      Execute bulk cancellation
+// @Description  Cancels the bookings and releases the scheduled events and working periods of the range. Fails with a conflict when the affected bookings no longer match the preview fingerprint.
+// @Tags         BulkCancellation
+// @Accept       json
+// @Produce      json
+// @Param        cancellation  body      BulkCancellationRequest   true  "Cancellation range and preview fingerprint"
+// @Success      200           {object}  BulkCancellationResponse  "Cancellation summary"
+// @Failure      409           {object}  error                     "Bookings changed since the preview"
+// @Router       /api/v1/bulk-cancellations [post]
+// @Security 	 BearerAuth
+func (h *CancellationHandler) ExecuteCancellation(w http.ResponseWriter, r *http.Request) {
+	var request *BulkCancellationRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	response, err := h.service.ExecuteCancellation(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, response)
+}
@@ -0,0 +1,42 @@
+package cancellations
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func MapBookingsToAffected(bookings []*entities.Booking) []*AffectedBookingResponse {
+	items := make([]*AffectedBookingResponse, 0, len(bookings))
+	for _, b := range bookings {
+		items = append(items, &AffectedBookingResponse{
+			Id:               b.Id,
+			StudentId:        b.StudentId,
+			ScheduledEventId: b.ScheduledEventId,
+			Title:            b.Title,
+			StartTime:        b.StartTime,
+			EndTime:          b.EndTime,
+			Status:           b.Status.String(),
+			Price:            b.Price,
+			Refundable:       isRefundable(b),
+		})
+	}
+	return items
+}
+
+func MapBookingsToCancelled(bookings []*entities.Booking) []*messaging.CancelledBooking {
+	items := make([]*messaging.CancelledBooking, 0, len(bookings))
+	for _, b := range bookings {
+		items = append(items, &messaging.CancelledBooking{
+			BookingId:    b.Id,
+			StudentId:    b.StudentId.String(),
+			ProductId:    b.ProductId,
+			EnrollmentId: b.EnrollmentId,
+			StartTime:    b.StartTime.UTC().Format(time.RFC3339),
+			Price:        b.Price,
+			Refundable:   isRefundable(b),
+		})
+	}
+	return items
+}
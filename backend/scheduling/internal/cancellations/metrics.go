@@ -0,0 +1,29 @@
+package cancellations
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const reasonBulk = "bulk"
+
+type cancellationMetrics struct {
+	cancelled metric.Int64Counter
+}
+
+// newCancellationMetrics shares the booking cancellation counter, bulk cancellations are told apart by their reason
+func newCancellationMetrics(meter metric.Meter) (*cancellationMetrics, error) {
+	cancelled, err := meter.Int64Counter("scheduling.bookings.cancelled",
+		metric.WithDescription("Bookings cancelled, by reason"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &cancellationMetrics{cancelled: cancelled}, nil
+}
+
+func (m *cancellationMetrics) recordCancelled(ctx context.Context, count int) {
+	m.cancelled.Add(ctx, int64(count), metric.WithAttributes(attribute.String("reason", reasonBulk)))
+}
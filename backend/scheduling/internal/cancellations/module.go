@@ -0,0 +1,35 @@
+package cancellations
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializeCancellationService(
+	log logger.Logger,
+	db *sqlx.DB,
+	publisher *messaging.Publisher,
+	notifier Notifier,
+	schedules ScheduleInvalidator,
+	webhooks WebhookDispatcher,
+	meter metric.Meter,
+) (*CancellationService, error) {
+	metrics, err := newCancellationMetrics(meter)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := NewCancellationRepository(db)
+	service := NewCancellationService(log, repo, publisher, notifier, schedules, webhooks, metrics)
+	return service, nil
+}
+
+func InitializeCancellationHTTPHandler(service *CancellationService) http.Handler {
+	handler := NewCancellationHandler(service)
+	return Routes(handler)
+}
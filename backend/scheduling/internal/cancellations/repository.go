@@ -0,0 +1,146 @@
+package cancellations
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// CancellationResult summarizes an executed bulk cancellation. Bookings hold their status from before
+// the cancellation.
+type CancellationResult struct {
+	Bookings        []*entities.Booking
+	EventsReleased  int64
+	PeriodsReleased int64
+}
+
+type CancellationRepo struct {
+	db *sqlx.DB
+}
+
+func NewCancellationRepository(db *sqlx.DB) *CancellationRepo {
+	return &CancellationRepo{db: db}
+}
+
+const affectedBookingsQuery = `
+	SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at
+	FROM booking
+	WHERE educator_id = $1 AND status = ANY($2) AND start_time >= $3 AND start_time < $4
+	ORDER BY start_time, id
+`
+
+// GetAffectedBookings retrieves open bookings of an educator starting within a range, see entities.OpenStatuses
+func (r *CancellationRepo) GetAffectedBookings(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.Booking, error) {
+	return database.FetchMultiple[entities.Booking](ctx, r.db, affectedBookingsQuery, educatorId, entities.OpenStatuses, from, to)
+}
+
+// CountScheduledEvents counts scheduled events of an educator starting within a range
+func (r *CancellationRepo) CountScheduledEvents(ctx context.Context, educatorId uuid.UUID, from, to time.Time) (int, error) {
+	const query = `
+		SELECT COUNT(*) FROM scheduled_event
+		WHERE user_id = $1 AND start_time >= $2 AND start_time < $3 AND deleted_at IS NULL
+	`
+	var count int
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &count, query, educatorId, from, to); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return count, nil
+}
+
+// CountWorkingPeriods counts working periods of an educator lying entirely within a range
+func (r *CancellationRepo) CountWorkingPeriods(ctx context.Context, educatorId uuid.UUID, from, to time.Time) (int, error) {
+	const query = `
+		SELECT COUNT(*) FROM working_period
+		WHERE user_id = $1 AND start_time >= $2 AND end_time <= $3 AND deleted_at IS NULL
+	`
+	var count int
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &count, query, educatorId, from, to); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return count, nil
+}
+
+// CancelSlots cancels the affected bookings of an educator and releases the scheduled events and working
+// periods within a range in a single transaction. The bookings are locked and compared against the
+// fingerprint of the preview first, a conflict is returned when they changed in the meantime. Working
+// periods only partially inside the range are kept.
+func (r *CancellationRepo) CancelSlots(
+	ctx context.Context,
+	educatorId uuid.UUID,
+	from time.Time,
+	to time.Time,
+	fingerprint string,
+	now time.Time,
+) (*CancellationResult, error) {
+	const cancelBookingsQuery = `
+		UPDATE booking
+		SET status = $1, version = version + 1, updated_at = $2
+		WHERE id = ANY($3)
+	`
+	releaseEventsQuery := database.WithTombstones(database.TombstoneScheduledEvent, `
+		UPDATE scheduled_event SET deleted_at = current_timestamp
+		WHERE user_id = $1 AND start_time >= $2 AND start_time < $3 AND deleted_at IS NULL
+	`)
+	releasePeriodsQuery := database.WithTombstones(database.TombstoneWorkingPeriod, `
+		UPDATE working_period wp SET deleted_at = current_timestamp
+		WHERE wp.user_id = $1 AND wp.start_time >= $2 AND wp.end_time <= $3 AND wp.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM scheduled_event se WHERE se.working_period_id = wp.id AND se.deleted_at IS NULL)
+	`)
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	var bookings []*entities.Booking
+	err = tx.SelectContext(ctx, &bookings, affectedBookingsQuery+" FOR UPDATE", educatorId, entities.OpenStatuses, from, to)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if Fingerprint(bookings) != fingerprint {
+		return nil, apperrors.NewConflict("Affected bookings changed since the preview", apperrors.ErrBulkCancellationStale)
+	}
+
+	ids := make([]int64, 0, len(bookings))
+	for _, b := range bookings {
+		ids = append(ids, b.Id)
+	}
+	if _, err := tx.ExecContext(ctx, cancelBookingsQuery, entities.Cancelled, now, pq.Array(ids)); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	result := &CancellationResult{Bookings: bookings}
+	if result.EventsReleased, err = execAffected(ctx, tx, releaseEventsQuery, educatorId, from, to); err != nil {
+		return nil, err
+	}
+	if result.PeriodsReleased, err = execAffected(ctx, tx, releasePeriodsQuery, educatorId, from, to); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return result, nil
+}
+
+func execAffected(ctx context.Context, tx database.Tx, query string, args ...any) (int64, error) {
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return affected, nil
+}
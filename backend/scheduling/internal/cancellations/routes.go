@@ -0,0 +1,20 @@
+package cancellations
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *CancellationHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.With(middleware.RequireRole(auth.EducatorRole)).Post("/preview", handler.PreviewCancellation)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Post("/", handler.ExecuteCancellation)
+
+	return r
+}
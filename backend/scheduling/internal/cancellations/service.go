@@ -0,0 +1,202 @@
+package cancellations
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+	"github.com/maksmelnyk/scheduling/internal/webhooks"
+)
+
+type CancellationRepository interface {
+	GetAffectedBookings(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.Booking, error)
+	CountScheduledEvents(ctx context.Context, educatorId uuid.UUID, from, to time.Time) (int, error)
+	CountWorkingPeriods(ctx context.Context, educatorId uuid.UUID, from, to time.Time) (int, error)
+	CancelSlots(ctx context.Context, educatorId uuid.UUID, from, to time.Time, fingerprint string, now time.Time) (*CancellationResult, error)
+}
+
+// Notifier sends user notifications according to their notification preferences
+type Notifier interface {
+	Notify(ctx context.Context, userId uuid.UUID, notificationType string, data map[string]string) error
+}
+
+// ScheduleInvalidator drops the cached schedules of an educator after their bookings or slots changed
+type ScheduleInvalidator interface {
+	Invalidate(ctx context.Context, owner string)
+}
+
+// WebhookDispatcher queues events for the webhook subscriptions of an educator
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, educatorId uuid.UUID, eventType string, data any)
+}
+
+type CancellationService struct {
+	log       logger.Logger
+	repo      CancellationRepository
+	publisher *messaging.Publisher
+	notifier  Notifier
+	schedules ScheduleInvalidator
+	webhooks  WebhookDispatcher
+	metrics   *cancellationMetrics
+}
+
+func NewCancellationService(
+	log logger.Logger,
+	repo CancellationRepository,
+	publisher *messaging.Publisher,
+	notifier Notifier,
+	schedules ScheduleInvalidator,
+	webhooks WebhookDispatcher,
+	metrics *cancellationMetrics,
+) *CancellationService {
+	return &CancellationService{log: log, repo: repo, publisher: publisher, notifier: notifier, schedules: schedules, webhooks: webhooks, metrics: metrics}
+}
+
+// PreviewCancellation lists what cancelling the educator's slots within the range would affect. Past
+// slots are never touched, so the range starts no earlier than now.
+func (s *CancellationService) PreviewCancellation(ctx context.Context, request *BulkCancellationRequest) (*BulkCancellationPreviewResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	from, to := clipRange(request.FromDate, request.ToDate)
+
+	bookings, err := s.repo.GetAffectedBookings(ctx, userId, from, to)
+	if err != nil {
+		log.Error("failed to get affected bookings", err)
+		return nil, err
+	}
+
+	events, err := s.repo.CountScheduledEvents(ctx, userId, from, to)
+	if err != nil {
+		log.Error("failed to count scheduled events", err)
+		return nil, err
+	}
+
+	periods, err := s.repo.CountWorkingPeriods(ctx, userId, from, to)
+	if err != nil {
+		log.Error("failed to count working periods", err)
+		return nil, err
+	}
+
+	return &BulkCancellationPreviewResponse{
+		FromDate:            from,
+		ToDate:              to,
+		WorkingPeriodCount:  periods,
+		ScheduledEventCount: events,
+		BookingCount:        len(bookings),
+		StudentCount:        studentCount(bookings),
+		RefundTotal:         refundTotal(bookings),
+		Fingerprint:         Fingerprint(bookings),
+		Bookings:            MapBookingsToAffected(bookings),
+	}, nil
+}
+
+// ExecuteCancellation cancels the educator's slots within the range when the affected bookings still
+// match the preview fingerprint. The cancelled bookings are published in batches for refunds and every
+// student is notified; publish and notification failures are logged only.
+func (s *CancellationService) ExecuteCancellation(ctx context.Context, request *BulkCancellationRequest) (*BulkCancellationResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if request.Fingerprint == "" {
+		return nil, apperrors.NewBadRequestError("Fingerprint of the preview is required", apperrors.ErrParameterInvalid)
+	}
+
+	from, to := clipRange(request.FromDate, request.ToDate)
+
+	result, err := s.repo.CancelSlots(ctx, userId, from, to, request.Fingerprint, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to cancel slots", err)
+		return nil, err
+	}
+	s.metrics.recordCancelled(ctx, len(result.Bookings))
+	s.schedules.Invalidate(ctx, userId.String())
+
+	operationId := uuid.New().String()
+	response := &BulkCancellationResponse{
+		OperationId:       operationId,
+		BookingsCancelled: len(result.Bookings),
+		EventsReleased:    result.EventsReleased,
+		PeriodsReleased:   result.PeriodsReleased,
+		RefundTotal:       refundTotal(result.Bookings),
+	}
+
+	response.BatchesPublished = s.publishBatches(ctx, operationId, userId, request.Reason, result.Bookings)
+
+	for _, b := range result.Bookings {
+		s.notifyStudent(ctx, b)
+
+		cancelled := *b
+		cancelled.Status = entities.Cancelled
+		s.webhooks.Dispatch(ctx, userId, webhooks.EventBookingCancelled, webhooks.NewBookingData(&cancelled))
+	}
+
+	return response, nil
+}
+
+// publishBatches publishes the cancelled bookings in fixed size batches and returns how many succeeded
+func (s *CancellationService) publishBatches(
+	ctx context.Context,
+	operationId string,
+	educatorId uuid.UUID,
+	reason *string,
+	bookings []*entities.Booking,
+) int {
+	log := logger.FromContext(ctx, s.log)
+
+	total := (len(bookings) + batchSize - 1) / batchSize
+	published := 0
+	for i := 0; i < total; i++ {
+		batch := bookings[i*batchSize : min((i+1)*batchSize, len(bookings))]
+
+		err := s.publisher.Publish(
+			ctx,
+			messaging.BulkCancellationKey,
+			messaging.NewBookingsCancelledEvent(operationId, educatorId.String(), reason, i+1, total, MapBookingsToCancelled(batch)),
+		)
+		if err != nil {
+			log.Errorf("Failed to publish bulk cancellation batch %d of %d: %v", i+1, total, err)
+			continue
+		}
+		published++
+	}
+	return published
+}
+
+func (s *CancellationService) notifyStudent(ctx context.Context, booking *entities.Booking) {
+	log := logger.FromContext(ctx, s.log)
+
+	data := map[string]string{
+		"bookingId": strconv.FormatInt(booking.Id, 10),
+		"title":     booking.Title,
+		"startTime": booking.StartTime.UTC().Format(time.RFC3339),
+	}
+
+	if err := s.notifier.Notify(ctx, booking.StudentId, notifications.BookingCancelledNotification, data); err != nil {
+		log.Errorf("Failed to notify student about cancelled booking %d: %v", booking.Id, err)
+	}
+}
+
+// clipRange moves the start of a range to now when it lies in the past
+func clipRange(from, to time.Time) (time.Time, time.Time) {
+	if now := time.Now().UTC(); now.After(from) {
+		from = now
+	}
+	return from, to
+}
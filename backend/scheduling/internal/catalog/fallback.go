@@ -0,0 +1,78 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/degraded"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/products"
+)
+
+// remoteSchedulingMetadata asks the learning service for scheduling metadata and remembers the answer
+// for the educator, to serve it again in degraded mode
+func (s *CatalogService) remoteSchedulingMetadata(
+	ctx context.Context,
+	userId uuid.UUID,
+	productId int64,
+	lessonId *int64,
+	durationMin int,
+	authHeader string,
+) (*products.ProductSchedulingMetadataResponse, error) {
+	lesson := int64(0)
+	if lessonId != nil {
+		lesson = *lessonId
+	}
+	key := fmt.Sprintf("scheduling:%s:%d:%d:%d", userId, productId, lesson, durationMin)
+
+	response, err := s.client.GetSchedulingMetadata(ctx, productId, lessonId, durationMin, authHeader)
+	if err != nil {
+		return serveFallback[products.ProductSchedulingMetadataResponse](ctx, s, key, err)
+	}
+
+	s.fallback.Set(ctx, key, response)
+	return response, nil
+}
+
+// remoteBookingMetadata asks the learning service for booking metadata and remembers the answer for the
+// student, to serve it again in degraded mode
+func (s *CatalogService) remoteBookingMetadata(
+	ctx context.Context,
+	userId uuid.UUID,
+	enrollmentId int64,
+	durationMin int,
+	authHeader string,
+) (*products.EnrollmentBookingMetadataResponse, error) {
+	key := fmt.Sprintf("booking:%s:%d:%d", userId, enrollmentId, durationMin)
+
+	response, err := s.client.GetBookingMetadata(ctx, enrollmentId, durationMin, authHeader)
+	if err != nil {
+		return serveFallback[products.EnrollmentBookingMetadataResponse](ctx, s, key, err)
+	}
+
+	s.fallback.Set(ctx, key, response)
+	return response, nil
+}
+
+// serveFallback answers a failed learning service call from the fallback cache when degraded mode is enabled
+// and the service is unavailable. Without a cached answer the request fails as unavailable instead of internal.
+func serveFallback[T any](ctx context.Context, s *CatalogService, key string, err error) (*T, error) {
+	if !s.cfg.Enabled || !errors.Is(err, products.ErrUnavailable) {
+		return nil, err
+	}
+
+	log := logger.FromContext(ctx, s.log)
+	cached, ok := s.fallback.Get(ctx, key)
+	if !ok {
+		log.Error("learning service unavailable and no cached answer", err)
+		return nil, apperrors.NewServiceUnavailable("Learning service is unavailable", err)
+	}
+
+	log.Warnf("Learning service unavailable, serving cached answer for %s: %v", key, err)
+	degraded.Mark(ctx, degraded.LearningService)
+	return cached.(*T), nil
+}
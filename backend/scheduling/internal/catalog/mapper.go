@@ -0,0 +1,52 @@
+package catalog
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func MapEventToProduct(event *messaging.ProductCatalogUpdatedEvent, educatorId uuid.UUID) *entities.CatalogProduct {
+	now := time.Now().UTC()
+
+	product := &entities.CatalogProduct{
+		ProductId:       event.ProductId,
+		EducatorId:      educatorId,
+		ProductType:     entities.ProductType(event.Type),
+		Title:           event.Title,
+		Price:           event.Price,
+		DurationMin:     event.DurationMin,
+		MaxParticipants: event.MaxParticipants,
+		Version:         event.Version,
+		UpdatedAt:       now,
+	}
+	if event.Deleted {
+		product.DeletedAt = &now
+	}
+	return product
+}
+
+func MapEventToLessons(event *messaging.ProductCatalogUpdatedEvent) []*entities.CatalogLesson {
+	lessons := make([]*entities.CatalogLesson, 0, len(event.Lessons))
+	for _, l := range event.Lessons {
+		lessons = append(lessons, &entities.CatalogLesson{
+			LessonId:    l.LessonId,
+			ProductId:   event.ProductId,
+			DurationMin: l.DurationMin,
+		})
+	}
+	return lessons
+}
+
+func MapEventToEnrollment(event *messaging.EnrollmentCreatedEvent, userId uuid.UUID) *entities.CatalogEnrollment {
+	return &entities.CatalogEnrollment{
+		EnrollmentId:     event.EnrollmentId,
+		UserId:           userId,
+		ProductId:        event.ProductId,
+		ScheduledEventId: event.ScheduledEventId,
+		CreatedAt:        time.Now().UTC(),
+	}
+}
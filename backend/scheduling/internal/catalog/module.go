@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/cache"
+	"github.com/maksmelnyk/scheduling/internal/httpclient"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/products"
+)
+
+func InitializeCatalogService(
+	log logger.Logger,
+	db *sqlx.DB,
+	cfg *config.ExternalServiceConfig,
+	degradation *config.DegradationConfig,
+	httpClient *http.Client,
+	meter metric.Meter,
+) (*CatalogService, error) {
+	repo := NewCatalogRepository(db)
+	learningClient, err := httpclient.NewClient("learning", httpClient, products.LearningPolicy(*cfg), meter)
+	if err != nil {
+		return nil, err
+	}
+	client := products.NewProductServiceClient(*cfg, learningClient)
+	fallback, err := cache.New[string, any]("catalog_fallback", cache.Options{
+		TTL:        time.Duration(degradation.CatalogFallbackTTLSeconds) * time.Second,
+		MaxEntries: degradation.CatalogFallbackMaxEntries,
+	}, meter)
+	if err != nil {
+		return nil, err
+	}
+	service := NewCatalogService(log, repo, client, degradation, fallback)
+	return service, nil
+}
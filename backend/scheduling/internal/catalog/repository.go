@@ -0,0 +1,110 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type CatalogRepo struct {
+	db *sqlx.DB
+}
+
+func NewCatalogRepository(db *sqlx.DB) *CatalogRepo {
+	return &CatalogRepo{db: db}
+}
+
+// GetProductById retrieves a product of the read model, including deleted ones
+func (r *CatalogRepo) GetProductById(ctx context.Context, productId int64) (*entities.CatalogProduct, error) {
+	const query = `
+		SELECT product_id, educator_id, product_type, title, price, duration_min, max_participants, version, deleted_at, updated_at
+		FROM catalog_product
+		WHERE product_id = $1
+	`
+	return database.FetchSingle[entities.CatalogProduct](ctx, r.db, query, productId)
+}
+
+// GetLessonById retrieves a lesson of a product
+func (r *CatalogRepo) GetLessonById(ctx context.Context, productId int64, lessonId int64) (*entities.CatalogLesson, error) {
+	const query = `SELECT lesson_id, product_id, duration_min FROM catalog_lesson WHERE lesson_id = $1 AND product_id = $2`
+	return database.FetchSingle[entities.CatalogLesson](ctx, r.db, query, lessonId, productId)
+}
+
+// GetUserEnrollmentById retrieves an enrollment owned by the user
+func (r *CatalogRepo) GetUserEnrollmentById(ctx context.Context, userId uuid.UUID, enrollmentId int64) (*entities.CatalogEnrollment, error) {
+	const query = `
+		SELECT enrollment_id, user_id, product_id, scheduled_event_id, created_at
+		FROM catalog_enrollment
+		WHERE enrollment_id = $1 AND user_id = $2
+	`
+	return database.FetchSingle[entities.CatalogEnrollment](ctx, r.db, query, enrollmentId, userId)
+}
+
+// UpsertProduct stores a product snapshot together with its lessons. Snapshots older than the stored
+// version are ignored, so out-of-order events cannot roll the read model back. It returns whether the
+// snapshot was applied.
+func (r *CatalogRepo) UpsertProduct(ctx context.Context, product *entities.CatalogProduct, lessons []*entities.CatalogLesson) (bool, error) {
+	const productQuery = `
+		INSERT INTO catalog_product (product_id, educator_id, product_type, title, price, duration_min, max_participants, version, deleted_at, updated_at)
+		VALUES (:product_id, :educator_id, :product_type, :title, :price, :duration_min, :max_participants, :version, :deleted_at, :updated_at)
+		ON CONFLICT (product_id) DO UPDATE
+		SET educator_id = EXCLUDED.educator_id, product_type = EXCLUDED.product_type, title = EXCLUDED.title, price = EXCLUDED.price,
+			duration_min = EXCLUDED.duration_min, max_participants = EXCLUDED.max_participants, version = EXCLUDED.version,
+			deleted_at = EXCLUDED.deleted_at, updated_at = EXCLUDED.updated_at
+		WHERE catalog_product.version < EXCLUDED.version
+	`
+	const deleteLessonsQuery = `DELETE FROM catalog_lesson WHERE product_id = $1`
+	const lessonQuery = `
+		INSERT INTO catalog_lesson (lesson_id, product_id, duration_min)
+		VALUES (:lesson_id, :product_id, :duration_min)
+		ON CONFLICT (lesson_id) DO UPDATE
+		SET product_id = EXCLUDED.product_id, duration_min = EXCLUDED.duration_min
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.NamedExecContext(ctx, productQuery, product)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteLessonsQuery, product.ProductId); err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	for _, lesson := range lessons {
+		if _, err := tx.NamedExecContext(ctx, lessonQuery, lesson); err != nil {
+			return false, apperrors.NewInternal(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return true, nil
+}
+
+// AddEnrollment stores an enrollment; repeated events for the same enrollment are ignored
+func (r *CatalogRepo) AddEnrollment(ctx context.Context, enrollment *entities.CatalogEnrollment) error {
+	const query = `
+		INSERT INTO catalog_enrollment (enrollment_id, user_id, product_id, scheduled_event_id, created_at)
+		VALUES (:enrollment_id, :user_id, :product_id, :scheduled_event_id, :created_at)
+		ON CONFLICT (enrollment_id) DO NOTHING
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, enrollment)
+}
@@ -0,0 +1,161 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/cache"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/products"
+)
+
+type CatalogRepository interface {
+	GetProductById(ctx context.Context, productId int64) (*entities.CatalogProduct, error)
+	GetLessonById(ctx context.Context, productId int64, lessonId int64) (*entities.CatalogLesson, error)
+	GetUserEnrollmentById(ctx context.Context, userId uuid.UUID, enrollmentId int64) (*entities.CatalogEnrollment, error)
+	UpsertProduct(ctx context.Context, product *entities.CatalogProduct, lessons []*entities.CatalogLesson) (bool, error)
+	AddEnrollment(ctx context.Context, enrollment *entities.CatalogEnrollment) error
+}
+
+// CatalogService keeps a local read model of the learning service catalog, fed by its events.
+// Metadata lookups are answered from the read model and only fall back to the learning service
+// over HTTP for products or enrollments that have not been replicated yet. In degraded mode earlier
+// answers of the learning service are served while it is unavailable.
+type CatalogService struct {
+	log      logger.Logger
+	repo     CatalogRepository
+	client   *products.ProductServiceClient
+	cfg      *config.DegradationConfig
+	fallback *cache.Cache[string, any]
+}
+
+func NewCatalogService(
+	log logger.Logger,
+	repo CatalogRepository,
+	client *products.ProductServiceClient,
+	cfg *config.DegradationConfig,
+	fallback *cache.Cache[string, any],
+) *CatalogService {
+	return &CatalogService{log: log, repo: repo, client: client, cfg: cfg, fallback: fallback}
+}
+
+func (s *CatalogService) ApplyProductUpdated(ctx context.Context, event *messaging.ProductCatalogUpdatedEvent) error {
+	log := logger.FromContext(ctx, s.log)
+
+	educatorId, err := uuid.Parse(event.EducatorId)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid educator id", apperrors.ErrParameterParsingFailed)
+	}
+
+	applied, err := s.repo.UpsertProduct(ctx, MapEventToProduct(event, educatorId), MapEventToLessons(event))
+	if err != nil {
+		log.Error("failed to update catalog product", err)
+		return err
+	}
+
+	if !applied {
+		log.Infof("Skipping stale catalog update for product %d (version %d)", event.ProductId, event.Version)
+	}
+	return nil
+}
+
+func (s *CatalogService) ApplyEnrollmentCreated(ctx context.Context, event *messaging.EnrollmentCreatedEvent) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := uuid.Parse(event.UserId)
+	if err != nil {
+		return apperrors.NewBadRequestError("Invalid user id", apperrors.ErrParameterParsingFailed)
+	}
+
+	if err := s.repo.AddEnrollment(ctx, MapEventToEnrollment(event, userId)); err != nil {
+		log.Error("failed to add catalog enrollment", err)
+		return err
+	}
+	return nil
+}
+
+// GetSchedulingMetadata validates that the current educator may schedule an event for the product
+func (s *CatalogService) GetSchedulingMetadata(
+	ctx context.Context,
+	productId int64,
+	lessonId *int64,
+	durationMin int,
+	authHeader string,
+) (*products.ProductSchedulingMetadataResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	product, err := s.repo.GetProductById(ctx, productId)
+	if isNotFound(err) {
+		log.Infof("Product %d missing from catalog read model, asking learning service", productId)
+		return s.remoteSchedulingMetadata(ctx, userId, productId, lessonId, durationMin, authHeader)
+	}
+	if err != nil {
+		log.Error("failed to get catalog product", err)
+		return nil, err
+	}
+
+	var lesson *entities.CatalogLesson
+	if product.ProductType == entities.OnlineCourse && lessonId != nil && *lessonId != 0 {
+		lesson, err = s.repo.GetLessonById(ctx, productId, *lessonId)
+		if err != nil && !isNotFound(err) {
+			log.Error("failed to get catalog lesson", err)
+			return nil, err
+		}
+	}
+
+	return schedulingMetadata(product, lesson, lessonId, userId, durationMin), nil
+}
+
+// GetBookingMetadata validates that the current user may book a session for the enrollment
+func (s *CatalogService) GetBookingMetadata(
+	ctx context.Context,
+	enrollmentId int64,
+	durationMin int,
+	authHeader string,
+) (*products.EnrollmentBookingMetadataResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	enrollment, err := s.repo.GetUserEnrollmentById(ctx, userId, enrollmentId)
+	if isNotFound(err) {
+		log.Infof("Enrollment %d missing from catalog read model, asking learning service", enrollmentId)
+		return s.remoteBookingMetadata(ctx, userId, enrollmentId, durationMin, authHeader)
+	}
+	if err != nil {
+		log.Error("failed to get catalog enrollment", err)
+		return nil, err
+	}
+
+	product, err := s.repo.GetProductById(ctx, enrollment.ProductId)
+	if isNotFound(err) {
+		log.Infof("Product %d missing from catalog read model, asking learning service", enrollment.ProductId)
+		return s.remoteBookingMetadata(ctx, userId, enrollmentId, durationMin, authHeader)
+	}
+	if err != nil {
+		log.Error("failed to get catalog product", err)
+		return nil, err
+	}
+
+	return bookingMetadata(product, durationMin), nil
+}
+
+func isNotFound(err error) bool {
+	var notFound *apperrors.NotFoundError
+	return errors.As(err, &notFound)
+}
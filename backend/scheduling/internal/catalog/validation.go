@@ -0,0 +1,75 @@
+package catalog
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/products"
+)
+
+// schedulingMetadata applies the learning service scheduling rules to a replicated product
+func schedulingMetadata(
+	product *entities.CatalogProduct,
+	lesson *entities.CatalogLesson,
+	lessonId *int64,
+	educatorId uuid.UUID,
+	durationMin int,
+) *products.ProductSchedulingMetadataResponse {
+	if product.DeletedAt != nil {
+		return &products.ProductSchedulingMetadataResponse{State: products.Invalid, ErrorMessage: "Product not found"}
+	}
+
+	if product.EducatorId != educatorId {
+		return &products.ProductSchedulingMetadataResponse{State: products.Invalid, ErrorMessage: "Invalid access to product"}
+	}
+
+	switch product.ProductType {
+	case entities.PrivateSession, entities.PreRecordedCourse:
+		return &products.ProductSchedulingMetadataResponse{State: products.Unschedulable}
+	case entities.GroupSession:
+		if product.DurationMin == nil || *product.DurationMin != durationMin {
+			return &products.ProductSchedulingMetadataResponse{State: products.Invalid, ErrorMessage: "Wrong duration"}
+		}
+	case entities.OnlineCourse:
+		if lessonId == nil || *lessonId == 0 {
+			return &products.ProductSchedulingMetadataResponse{State: products.Invalid, ErrorMessage: "Lesson is required for online courses"}
+		}
+		if lesson == nil {
+			return &products.ProductSchedulingMetadataResponse{State: products.Invalid, ErrorMessage: "Lesson not found"}
+		}
+		if lesson.DurationMin != durationMin {
+			return &products.ProductSchedulingMetadataResponse{State: products.Invalid, ErrorMessage: "Wrong duration"}
+		}
+	}
+
+	return &products.ProductSchedulingMetadataResponse{
+		State:           products.Valid,
+		Title:           product.Title,
+		MaxParticipants: product.MaxParticipants,
+		Price:           product.Price,
+	}
+}
+
+// bookingMetadata applies the learning service booking rules to the product of a replicated enrollment
+func bookingMetadata(product *entities.CatalogProduct, durationMin int) *products.EnrollmentBookingMetadataResponse {
+	if product.DeletedAt != nil {
+		return &products.EnrollmentBookingMetadataResponse{ErrorMessage: "Product not found"}
+	}
+
+	if product.ProductType != entities.PrivateSession {
+		return &products.EnrollmentBookingMetadataResponse{ErrorMessage: "Invalid product type"}
+	}
+
+	if product.DurationMin == nil || *product.DurationMin != durationMin {
+		return &products.EnrollmentBookingMetadataResponse{ErrorMessage: "Invalid duration"}
+	}
+
+	productId := product.ProductId
+	return &products.EnrollmentBookingMetadataResponse{
+		IsValid:    true,
+		EducatorId: product.EducatorId.String(),
+		ProductId:  &productId,
+		Title:      product.Title,
+		Price:      product.Price,
+	}
+}
@@ -0,0 +1,54 @@
+package checkin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+const codePrefix = "ora:checkin:v1:"
+
+// Signer issues and verifies booking check-in codes embedded in QR codes. A code carries the
+// booking id and an HMAC-SHA256 signature, so it cannot be forged for another booking.
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key string) *Signer {
+	return &Signer{key: []byte(key)}
+}
+
+// Sign returns the check-in code of a booking
+func (s *Signer) Sign(bookingId int64) string {
+	payload := codePrefix + strconv.FormatInt(bookingId, 10)
+	return payload + "." + s.signature(payload)
+}
+
+// Verify checks the signature of a check-in code and returns the booking id it was issued for
+func (s *Signer) Verify(code string) (int64, error) {
+	payload, signature, ok := strings.Cut(code, ".")
+	if !ok || !strings.HasPrefix(payload, codePrefix) {
+		return 0, apperrors.NewBadRequestError("Malformed check-in code", apperrors.ErrCheckInCodeInvalid)
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(payload))) {
+		return 0, apperrors.NewBadRequestError("Invalid check-in code signature", apperrors.ErrCheckInCodeInvalid)
+	}
+
+	bookingId, err := strconv.ParseInt(strings.TrimPrefix(payload, codePrefix), 10, 64)
+	if err != nil {
+		return 0, apperrors.NewBadRequestError(fmt.Sprintf("Invalid booking id in check-in code: %v", err), apperrors.ErrCheckInCodeInvalid)
+	}
+	return bookingId, nil
+}
+
+func (s *Signer) signature(payload string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
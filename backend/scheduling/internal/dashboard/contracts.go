@@ -0,0 +1,49 @@
+package dashboard
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/schedule"
+)
+
+// swagger:model DashboardResponse
+type DashboardResponse struct {
+	NextSessions    []*DashboardSessionResponse `json:"nextSessions"`
+	PendingRequests []*schedule.BookingResponse `json:"pendingRequests"`
+	WeekEarnings    *EarningsSummaryResponse    `json:"weekEarnings"`
+	Escalations     []*EscalationFlagResponse   `json:"escalations"`
+}
+
+// swagger:model DashboardSessionResponse
+type DashboardSessionResponse struct {
+	Kind            string    `json:"kind"`
+	Id              int64     `json:"id"`
+	ProductId       int64     `json:"productId"`
+	Title           string    `json:"title"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	Participants    int       `json:"participants"`
+	MaxParticipants int       `json:"maxParticipants"`
+}
+
+// swagger:model EscalationFlagResponse
+type EscalationFlagResponse struct {
+	BookingId   int64     `json:"bookingId"`
+	Title       string    `json:"title"`
+	StartTime   time.Time `json:"startTime"`
+	Status      string    `json:"status"`
+	RuleName    string    `json:"ruleName"`
+	Condition   string    `json:"condition"`
+	TriggeredAt time.Time `json:"triggeredAt"`
+}
+
+// swagger:model EarningsSummaryResponse
+type EarningsSummaryResponse struct {
+	PeriodStart  time.Time `json:"periodStart"`
+	PeriodEnd    time.Time `json:"periodEnd"`
+	SessionCount int       `json:"sessionCount"`
+	GrossAmount  float64   `json:"grossAmount"`
+	FeeAmount    float64   `json:"feeAmount"`
+	NetAmount    float64   `json:"netAmount"`
+	Currency     string    `json:"currency"`
+}
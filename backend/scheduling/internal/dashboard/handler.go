@@ -0,0 +1,78 @@
+package dashboard
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type DashboardHandler struct {
+	service *DashboardService
+}
+
+func NewDashboardHandler(service *DashboardService) *DashboardHandler {
+	return &DashboardHandler{service: service}
+}
+
+// GetMyDashboard retrieves home screen aggregates of the current educator.
+// @Summary      Retrieve dashboard
+// @Description  Returns the educator's next sessions, pending booking requests, bookings flagged by escalation rules and the current week's earnings summary in a single call.
+// @Tags         Dashboard
+// @Accept       json
+// @Produce      json
+// @Param        take  query     int                false  "Number of sessions and requests to return (default 5)"
+// @Success      200   {object}  DashboardResponse  "Dashboard aggregates"
+// @Failure      400   {object}  error              "Invalid input parameters"
+// @Router       /api/v1/dashboard [get]
+// @Security 	 BearerAuth
+func (h *DashboardHandler) GetMyDashboard(w http.ResponseWriter, r *http.Request) {
+	take, err := api.ParseIntQuery(w, r, "take", 5)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	dashboard, err := h.service.GetMyDashboard(r.Context(), take)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, dashboard)
+}
+
+// GetMyCancellationReasons retrieves the reasons students gave for cancelling bookings of the current educator.
+// @Summary      Retrieve my cancellation reasons
+// @Description  Breaks student cancellations of the educator's bookings made within the 'fromDate' and 'toDate' range down by reason of the cancellation taxonomy.
+// @Tags         Dashboard
+// @Accept       json
+// @Produce      json
+// @Param        fromDate  query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate    query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Success      200       {object}  reports.EducatorCancellationReasonsResponse  "Cancellation reasons"
+// @Failure      400       {object}  error                                        "Invalid input parameters"
+// @Router       /api/v1/dashboard/cancellation-reasons [get]
+// @Security 	 BearerAuth
+func (h *DashboardHandler) GetMyCancellationReasons(w http.ResponseWriter, r *http.Request) {
+	from, err := api.ParseTimeQuery(w, r, "fromDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	to, err := api.ParseTimeQuery(w, r, "toDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	reasons, err := h.service.GetMyCancellationReasons(r.Context(), from, to)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, reasons)
+}
@@ -0,0 +1,66 @@
+package dashboard
+
+import (
+	"github.com/maksmelnyk/scheduling/internal/payouts"
+)
+
+func MapSessionRowToResponse(s *SessionRow) *DashboardSessionResponse {
+	return &DashboardSessionResponse{
+		Kind:            s.Kind,
+		Id:              s.Id,
+		ProductId:       s.ProductId,
+		Title:           s.Title,
+		StartTime:       s.StartTime,
+		EndTime:         s.EndTime,
+		Participants:    s.Participants,
+		MaxParticipants: s.MaxParticipants,
+	}
+}
+
+func MapSessionRowsToResponse(ss []*SessionRow) []*DashboardSessionResponse {
+	if len(ss) == 0 {
+		return []*DashboardSessionResponse{}
+	}
+
+	response := make([]*DashboardSessionResponse, len(ss))
+	for i, s := range ss {
+		response[i] = MapSessionRowToResponse(s)
+	}
+	return response
+}
+
+func MapPayoutReportToEarnings(r *payouts.PayoutReportResponse) *EarningsSummaryResponse {
+	return &EarningsSummaryResponse{
+		PeriodStart:  r.PeriodStart,
+		PeriodEnd:    r.PeriodEnd,
+		SessionCount: r.SessionCount,
+		GrossAmount:  r.GrossAmount,
+		FeeAmount:    r.FeeAmount,
+		NetAmount:    r.NetAmount,
+		Currency:     r.Currency,
+	}
+}
+
+func MapEscalationRowToResponse(e *EscalationRow) *EscalationFlagResponse {
+	return &EscalationFlagResponse{
+		BookingId:   e.BookingId,
+		Title:       e.Title,
+		StartTime:   e.StartTime,
+		Status:      e.Status.String(),
+		RuleName:    e.RuleName,
+		Condition:   e.Condition,
+		TriggeredAt: e.TriggeredAt,
+	}
+}
+
+func MapEscalationRowsToResponse(es []*EscalationRow) []*EscalationFlagResponse {
+	if len(es) == 0 {
+		return []*EscalationFlagResponse{}
+	}
+
+	response := make([]*EscalationFlagResponse, len(es))
+	for i, e := range es {
+		response[i] = MapEscalationRowToResponse(e)
+	}
+	return response
+}
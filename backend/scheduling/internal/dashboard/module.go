@@ -0,0 +1,25 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeDashboardService(
+	log logger.Logger,
+	db *sqlx.DB,
+	earnings EarningsProvider,
+	cancellationReasons CancellationReasonProvider,
+) *DashboardService {
+	repo := NewDashboardRepository(db)
+	service := NewDashboardService(log, repo, earnings, cancellationReasons)
+	return service
+}
+
+func InitializeDashboardHTTPHandler(service *DashboardService) http.Handler {
+	handler := NewDashboardHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,90 @@
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// SessionRow holds an upcoming scheduled event or individual booking of an educator
+type SessionRow struct {
+	Kind            string    `db:"kind"`
+	Id              int64     `db:"id"`
+	ProductId       int64     `db:"product_id"`
+	Title           string    `db:"title"`
+	StartTime       time.Time `db:"start_time"`
+	EndTime         time.Time `db:"end_time"`
+	Participants    int       `db:"participants"`
+	MaxParticipants int       `db:"max_participants"`
+}
+
+// EscalationRow holds an upcoming booking flagged by one of the educator's escalation rules
+type EscalationRow struct {
+	BookingId   int64                  `db:"booking_id"`
+	Title       string                 `db:"title"`
+	StartTime   time.Time              `db:"start_time"`
+	Status      entities.BookingStatus `db:"status"`
+	RuleName    string                 `db:"rule_name"`
+	Condition   string                 `db:"condition"`
+	TriggeredAt time.Time              `db:"triggered_at"`
+}
+
+type DashboardRepo struct {
+	db *sqlx.DB
+}
+
+func NewDashboardRepository(db *sqlx.DB) *DashboardRepo {
+	return &DashboardRepo{db: db}
+}
+
+// GetUpcomingSessions retrieves the next scheduled events and approved individual bookings of an educator
+func (r *DashboardRepo) GetUpcomingSessions(ctx context.Context, educatorId uuid.UUID, after time.Time, take int) ([]*SessionRow, error) {
+	const query = `
+		SELECT kind, id, product_id, title, start_time, end_time, participants, max_participants
+		FROM (
+			SELECT 'event' AS kind, se.id, se.product_id, COALESCE(se.title, '') AS title, se.start_time, se.end_time,
+				(SELECT COUNT(*) FROM booking b WHERE b.scheduled_event_id = se.id AND b.status = $3) AS participants,
+				se.max_participants
+			FROM scheduled_event se
+			WHERE se.user_id = $1 AND se.start_time >= $2 AND se.deleted_at IS NULL
+			UNION ALL
+			SELECT 'booking' AS kind, b.id, b.product_id, COALESCE(b.title, '') AS title, b.start_time, b.end_time, 1, 1
+			FROM booking b
+			WHERE b.educator_id = $1 AND b.status = $3 AND b.scheduled_event_id IS NULL AND b.start_time >= $2
+		) sessions
+		ORDER BY start_time
+		LIMIT $4
+	`
+	return database.FetchMultiple[SessionRow](ctx, r.db, query, educatorId, after, entities.Approved, take)
+}
+
+// GetPendingBookings retrieves upcoming bookings of an educator awaiting confirmation
+func (r *DashboardRepo) GetPendingBookings(ctx context.Context, educatorId uuid.UUID, after time.Time, take int) ([]*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at
+		FROM booking
+		WHERE educator_id = $1 AND status = $2 AND start_time >= $3
+		ORDER BY start_time
+		LIMIT $4
+	`
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, educatorId, entities.Pending, after, take)
+}
+
+// GetEscalatedBookings retrieves upcoming, not cancelled bookings of an educator flagged by escalation rules
+func (r *DashboardRepo) GetEscalatedBookings(ctx context.Context, educatorId uuid.UUID, after time.Time, take int) ([]*EscalationRow, error) {
+	const query = `
+		SELECT b.id AS booking_id, COALESCE(b.title, '') AS title, b.start_time, b.status, er.name AS rule_name, er.condition, be.triggered_at
+		FROM booking_escalation be
+		JOIN escalation_rule er ON er.id = be.rule_id
+		JOIN booking b ON b.id = be.booking_id
+		WHERE er.educator_id = $1 AND be.flagged AND b.status <> $2 AND b.start_time >= $3
+		ORDER BY b.start_time, be.triggered_at
+		LIMIT $4
+	`
+	return database.FetchMultiple[EscalationRow](ctx, r.db, query, educatorId, entities.Cancelled, after, take)
+}
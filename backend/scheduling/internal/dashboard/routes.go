@@ -0,0 +1,20 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *DashboardHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.With(middleware.RequireRole(auth.EducatorRole)).Get("/", handler.GetMyDashboard)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Get("/cancellation-reasons", handler.GetMyCancellationReasons)
+
+	return r
+}
@@ -0,0 +1,116 @@
+package dashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/payouts"
+	"github.com/maksmelnyk/scheduling/internal/reports"
+	"github.com/maksmelnyk/scheduling/internal/schedule"
+)
+
+type DashboardRepository interface {
+	GetUpcomingSessions(ctx context.Context, educatorId uuid.UUID, after time.Time, take int) ([]*SessionRow, error)
+	GetPendingBookings(ctx context.Context, educatorId uuid.UUID, after time.Time, take int) ([]*entities.Booking, error)
+	GetEscalatedBookings(ctx context.Context, educatorId uuid.UUID, after time.Time, take int) ([]*EscalationRow, error)
+}
+
+// EarningsProvider computes the payout figures of an educator for a period
+type EarningsProvider interface {
+	GetPayoutReport(ctx context.Context, educatorId uuid.UUID, from time.Time, to time.Time) (*payouts.PayoutReportResponse, error)
+}
+
+// CancellationReasonProvider breaks the student cancellations of an educator for a period down by reason
+type CancellationReasonProvider interface {
+	GetEducatorCancellationReasons(ctx context.Context, educatorId uuid.UUID, from, to time.Time) (*reports.EducatorCancellationReasonsResponse, error)
+}
+
+type DashboardService struct {
+	log                 logger.Logger
+	repo                DashboardRepository
+	earnings            EarningsProvider
+	cancellationReasons CancellationReasonProvider
+}
+
+func NewDashboardService(
+	log logger.Logger,
+	repo DashboardRepository,
+	earnings EarningsProvider,
+	cancellationReasons CancellationReasonProvider,
+) *DashboardService {
+	return &DashboardService{log: log, repo: repo, earnings: earnings, cancellationReasons: cancellationReasons}
+}
+
+// GetMyDashboard aggregates the educator's home screen data: next sessions, pending requests, bookings
+// flagged by escalation rules and earnings of the current week (Monday 00:00 UTC onwards)
+func (s *DashboardService) GetMyDashboard(ctx context.Context, take int) (*DashboardResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	now := time.Now().UTC()
+
+	sessions, err := s.repo.GetUpcomingSessions(ctx, userId, now, take)
+	if err != nil {
+		log.Error("failed to get upcoming sessions", err)
+		return nil, err
+	}
+
+	pending, err := s.repo.GetPendingBookings(ctx, userId, now, take)
+	if err != nil {
+		log.Error("failed to get pending bookings", err)
+		return nil, err
+	}
+
+	escalations, err := s.repo.GetEscalatedBookings(ctx, userId, now, take)
+	if err != nil {
+		log.Error("failed to get escalated bookings", err)
+		return nil, err
+	}
+
+	weekStart := startOfWeek(now)
+	report, err := s.earnings.GetPayoutReport(ctx, userId, weekStart, weekStart.AddDate(0, 0, 7))
+	if err != nil {
+		log.Error("failed to get week earnings", err)
+		return nil, err
+	}
+
+	return &DashboardResponse{
+		NextSessions:    MapSessionRowsToResponse(sessions),
+		PendingRequests: schedule.MapBookingsToResponse(pending),
+		WeekEarnings:    MapPayoutReportToEarnings(report),
+		Escalations:     MapEscalationRowsToResponse(escalations),
+	}, nil
+}
+
+// GetMyCancellationReasons breaks the student cancellations of the educator made within a period down by reason
+func (s *DashboardService) GetMyCancellationReasons(ctx context.Context, from, to time.Time) (*reports.EducatorCancellationReasonsResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	reasons, err := s.cancellationReasons.GetEducatorCancellationReasons(ctx, userId, from, to)
+	if err != nil {
+		log.Error("failed to get cancellation reasons", err)
+		return nil, err
+	}
+	return reasons, nil
+}
+
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -offset)
+}
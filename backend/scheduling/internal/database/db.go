@@ -1,45 +1,121 @@
 package database
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
 
 	"github.com/maksmelnyk/scheduling/config"
 )
 
-const (
-	maxOpenConns    = 60
-	connMaxLifetime = 120
-	maxIdleConns    = 30
-	connMaxIdleTime = 20
-)
-
-// NewPgSqlDb Return new Postgresql db instance
-func NewPgSqlDb(cfg *config.PostgresConfig) (*sqlx.DB, error) {
-	dataSourceName := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable",
+// NewPgxPool Return new Postgresql connection pool. The failover monitor, when given, is attached to the pool
+// and reconnects it when the primary changes.
+func NewPgxPool(ctx context.Context, cfg *config.PostgresConfig, failover *FailoverMonitor) (*pgxpool.Pool, error) {
+	// Sessions run in UTC so timestamps are read back in UTC instead of the server's local zone.
+	// Host may list several servers, target_session_attrs picks the one that matches, the writable primary by default.
+	dataSourceName := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable timezone=UTC target_session_attrs=%s",
 		cfg.Host,
 		cfg.Port,
 		cfg.User,
 		cfg.DbName,
 		cfg.Password,
+		cfg.TargetSessionAttrs,
 	)
 
-	db, err := sqlx.Connect(cfg.PgDriver, dataSourceName)
+	poolConfig, err := pgxpool.ParseConfig(dataSourceName)
 	if err != nil {
 		return nil, err
 	}
 
-	db.SetMaxOpenConns(maxOpenConns)
-	db.SetConnMaxLifetime(connMaxLifetime * time.Second)
-	db.SetMaxIdleConns(maxIdleConns)
-	db.SetConnMaxIdleTime(connMaxIdleTime * time.Second)
+	poolConfig.MaxConns = int32(cfg.MaxConns)
+	poolConfig.MinConns = int32(cfg.MinConns)
+	poolConfig.MaxConnLifetime = time.Duration(cfg.MaxConnLifetimeSeconds) * time.Second
+	poolConfig.MaxConnIdleTime = time.Duration(cfg.MaxConnIdleSeconds) * time.Second
+	poolConfig.HealthCheckPeriod = time.Duration(cfg.HealthCheckPeriodSeconds) * time.Second
+	poolConfig.AfterConnect = func(_ context.Context, conn *pgx.Conn) error {
+		conn.TypeMap().RegisterType(&pgtype.Type{
+			Name:  "timestamptz",
+			OID:   pgtype.TimestamptzOID,
+			Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+		})
+		return nil
+	}
+	if failover != nil {
+		poolConfig.ConnConfig.OnPgError = failover.onPgError
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
 
-	if err = db.Ping(); err != nil {
+	if err = pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	if failover != nil {
+		failover.pool = pool
+	}
+	return pool, nil
+}
+
+// NewPgSqlDb Return new Postgresql db instance backed by the pool. Connections are pooled by pgxpool only,
+// database/sql keeps no idle connections of its own and closing the db leaves the pool open. Every connection
+// is scoped to the tenant of the context it is taken for.
+func NewPgSqlDb(pool *pgxpool.Pool, cfg *config.PostgresConfig, tenancy *config.TenancyConfig) (*sqlx.DB, error) {
+	connector := &acquireTimeoutConnector{
+		Connector:      stdlib.GetPoolConnector(pool),
+		timeout:        time.Duration(cfg.AcquireTimeoutMs) * time.Millisecond,
+		tenancyEnabled: tenancy.Enabled,
+	}
+
+	db := sqlx.NewDb(sql.OpenDB(connector), "pgx")
+	db.SetMaxIdleConns(0)
+
+	if err := db.Ping(); err != nil {
 		return nil, err
 	}
 
 	return db, nil
 }
+
+// acquireTimeoutConnector bounds the wait for a free pool connection, so a saturated pool fails
+// requests quickly instead of queueing them until their own deadline, and scopes the connection to the
+// tenant of the work it is taken for
+type acquireTimeoutConnector struct {
+	driver.Connector
+	timeout        time.Duration
+	tenancyEnabled bool
+}
+
+func (c *acquireTimeoutConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyTenantScope(ctx, conn, c.tenancyEnabled); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (c *acquireTimeoutConnector) acquire(ctx context.Context) (driver.Conn, error) {
+	if c.timeout <= 0 {
+		return c.Connector.Connect(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.Connector.Connect(ctx)
+}
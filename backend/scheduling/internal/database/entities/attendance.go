@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+)
+
+type Attendance struct {
+	BookingId   int64             `db:"booking_id"`
+	EducatorId  uuid.UUID         `db:"educator_id"`
+	StudentId   uuid.UUID         `db:"student_id"`
+	Outcome     AttendanceOutcome `db:"outcome"`
+	Note        *string           `db:"note"`
+	CheckedInAt *time.Time        `db:"checked_in_at"`
+	RecordedAt  time.Time         `db:"recorded_at"`
+	UpdatedAt   time.Time         `db:"updated_at"`
+}
+
+type AttendanceOutcome int
+
+const (
+	Attended AttendanceOutcome = iota
+	Missed
+	Rescheduled
+)
+
+func (o AttendanceOutcome) String() string {
+	switch o {
+	case Attended:
+		return "Attended"
+	case Missed:
+		return "Missed"
+	case Rescheduled:
+		return "Rescheduled"
+	default:
+		return "Unknown"
+	}
+}
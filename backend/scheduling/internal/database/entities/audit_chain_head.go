@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// Chain types of the audit trails
+const (
+	AuditChainEvent         = "EVENT"
+	AuditChainChange        = "CHANGE"
+	AuditChainBookingStatus = "BOOKING_STATUS"
+)
+
+// AuditChainHead is the latest entry of an audit chain: messages chain per correlation id, changes per
+// audited entity and status transitions per booking. The head is signed, so entries removed from the end
+// of a chain no longer match it.
+type AuditChainHead struct {
+	ChainType     string    `db:"chain_type"`
+	ChainKey      string    `db:"chain_key"`
+	Sequence      int64     `db:"sequence"`
+	Signature     string    `db:"signature"`
+	HeadSignature string    `db:"head_signature"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AvailabilityRuleSet struct {
+	EducatorId uuid.UUID `db:"educator_id"`
+	Timezone   string    `db:"timezone"`
+	Weeks      int       `db:"weeks"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+type AvailabilityRule struct {
+	Id         int64     `db:"id"`
+	EducatorId uuid.UUID `db:"educator_id"`
+	DayOfWeek  int       `db:"day_of_week"`
+	StartClock string    `db:"start_clock"`
+	EndClock   string    `db:"end_clock"`
+}
+
+type BlackoutDate struct {
+	EducatorId   uuid.UUID `db:"educator_id"`
+	BlackoutDate time.Time `db:"blackout_date"`
+	Reason       *string   `db:"reason"`
+}
@@ -5,7 +5,7 @@ import (
 
 	"github.com/google/uuid"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type Booking struct {
@@ -14,12 +14,15 @@ type Booking struct {
 	StudentId        uuid.UUID     `db:"student_id"`
 	ProductId        int64         `db:"product_id"`
 	ScheduledEventId *int64        `db:"scheduled_event_id"`
+	SessionTypeId    *int64        `db:"session_type_id"`
 	EnrollmentId     *int64        `db:"enrollment_id"` //TODO: fix later, maybe
 	WorkingPeriodId  int64         `db:"working_period_id"`
 	Title            string        `db:"title"`
 	StartTime        time.Time     `db:"start_time"`
 	EndTime          time.Time     `db:"end_time"`
 	Status           BookingStatus `db:"status"`
+	Price            float64       `db:"price"`
+	Version          int64         `db:"version"`
 	CreatedAt        time.Time     `db:"created_at"`
 	UpdatedAt        time.Time     `db:"updated_at"`
 }
@@ -30,4 +33,38 @@ const (
 	Pending BookingStatus = iota
 	Approved
 	Cancelled
+	AwaitingPayment
+	Completed
+	NoShow
 )
+
+// OpenStatuses are the statuses of bookings that still hold their place, neither cancelled nor closed after
+// the session
+var OpenStatuses = pq.Int64Array{int64(Pending), int64(AwaitingPayment), int64(Approved)}
+
+// ConfirmedStatuses are the statuses of bookings confirmed by their educator, before and after the session
+var ConfirmedStatuses = pq.Int64Array{int64(Approved), int64(Completed), int64(NoShow)}
+
+func (s BookingStatus) String() string {
+	switch s {
+	case Pending:
+		return "Pending"
+	case Approved:
+		return "Approved"
+	case Cancelled:
+		return "Cancelled"
+	case AwaitingPayment:
+		return "AwaitingPayment"
+	case Completed:
+		return "Completed"
+	case NoShow:
+		return "NoShow"
+	default:
+		return "Unknown"
+	}
+}
+
+// IsConfirmed tells whether the booking was confirmed by its educator, see ConfirmedStatuses
+func (s BookingStatus) IsConfirmed() bool {
+	return s == Approved || s == Completed || s == NoShow
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+)
+
+// BookingCancellationReason is the reason a student gave for cancelling a booking
+type BookingCancellationReason struct {
+	BookingId int64              `db:"booking_id"`
+	Reason    CancellationReason `db:"reason"`
+	Note      *string            `db:"note"`
+	CreatedAt time.Time          `db:"created_at"`
+}
+
+type CancellationReason int
+
+const (
+	ReasonScheduleConflict CancellationReason = iota
+	ReasonIllness
+	ReasonFoundAlternative
+	ReasonPrice
+	ReasonEducatorFit
+	ReasonNoLongerNeeded
+	ReasonOther
+)
+
+func (r CancellationReason) String() string {
+	switch r {
+	case ReasonScheduleConflict:
+		return "ScheduleConflict"
+	case ReasonIllness:
+		return "Illness"
+	case ReasonFoundAlternative:
+		return "FoundAlternative"
+	case ReasonPrice:
+		return "Price"
+	case ReasonEducatorFit:
+		return "EducatorFit"
+	case ReasonNoLongerNeeded:
+		return "NoLongerNeeded"
+	case ReasonOther:
+		return "Other"
+	default:
+		return "Unknown"
+	}
+}
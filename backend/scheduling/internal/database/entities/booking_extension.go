@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BookingExtension struct {
+	Id        int64           `db:"id"`
+	BookingId int64           `db:"booking_id"`
+	Minutes   int             `db:"minutes"`
+	Price     float64         `db:"price"`
+	Status    ExtensionStatus `db:"status"`
+	CreatedBy uuid.UUID       `db:"created_by"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+type ExtensionStatus int
+
+const (
+	ExtensionOffered ExtensionStatus = iota
+	ExtensionApplied
+	ExtensionDeclined
+)
+
+func (s ExtensionStatus) String() string {
+	switch s {
+	case ExtensionOffered:
+		return "Offered"
+	case ExtensionApplied:
+		return "Applied"
+	case ExtensionDeclined:
+		return "Declined"
+	default:
+		return "Unknown"
+	}
+}
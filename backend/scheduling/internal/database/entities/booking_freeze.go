@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// BookingFreeze pauses new bookings for a time window, platform-wide or for a cohort of educators given by
+// their ids or their organization
+type BookingFreeze struct {
+	Id             int64          `db:"id"`
+	Message        string         `db:"message"`
+	Reason         string         `db:"reason"`
+	EducatorIds    pq.StringArray `db:"educator_ids"`
+	OrganizationId *int64         `db:"organization_id"`
+	StartsAt       time.Time      `db:"starts_at"`
+	EndsAt         time.Time      `db:"ends_at"`
+	CreatedBy      uuid.UUID      `db:"created_by"`
+	CreatedAt      time.Time      `db:"created_at"`
+	LiftedBy       *uuid.UUID     `db:"lifted_by"`
+	LiftedAt       *time.Time     `db:"lifted_at"`
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingHold reserves a slot for a student until it is confirmed into a booking or expires
+type BookingHold struct {
+	Id              int64     `db:"id"`
+	EducatorId      uuid.UUID `db:"educator_id"`
+	StudentId       uuid.UUID `db:"student_id"`
+	EnrollmentId    int64     `db:"enrollment_id"`
+	ProductId       int64     `db:"product_id"`
+	WorkingPeriodId int64     `db:"working_period_id"`
+	SessionTypeId   *int64    `db:"session_type_id"`
+	Title           string    `db:"title"`
+	StartTime       time.Time `db:"start_time"`
+	EndTime         time.Time `db:"end_time"`
+	Price           float64   `db:"price"`
+	ExpiresAt       time.Time `db:"expires_at"`
+	CreatedAt       time.Time `db:"created_at"`
+}
@@ -0,0 +1,24 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingLink lets the students it is given to book a slot of an educator's working period, up to
+// MaxUses times in total and once per student
+type BookingLink struct {
+	Id              int64      `db:"id"`
+	EducatorId      uuid.UUID  `db:"educator_id"`
+	WorkingPeriodId int64      `db:"working_period_id"`
+	SessionTypeId   *int64     `db:"session_type_id"`
+	Label           *string    `db:"label"`
+	StartTime       time.Time  `db:"start_time"`
+	EndTime         time.Time  `db:"end_time"`
+	MaxUses         int        `db:"max_uses"`
+	Uses            int        `db:"uses"`
+	ExpiresAt       time.Time  `db:"expires_at"`
+	RevokedAt       *time.Time `db:"revoked_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
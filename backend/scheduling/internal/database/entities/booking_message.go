@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BookingMessage struct {
+	Id        int64     `db:"id"`
+	BookingId int64     `db:"booking_id"`
+	SenderId  uuid.UUID `db:"sender_id"`
+	Body      string    `db:"body"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type BookingThreadRead struct {
+	BookingId  int64     `db:"booking_id"`
+	UserId     uuid.UUID `db:"user_id"`
+	LastReadId int64     `db:"last_read_id"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
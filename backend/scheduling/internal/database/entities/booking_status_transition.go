@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BookingStatusTransition is a recorded status change of a booking. FromStatus is nil for the status the
+// booking was created with, ActorId for changes made by the system.
+type BookingStatusTransition struct {
+	Id         int64          `db:"id"`
+	BookingId  int64          `db:"booking_id"`
+	FromStatus *BookingStatus `db:"from_status"`
+	ToStatus   BookingStatus  `db:"to_status"`
+	ActorId    *uuid.UUID     `db:"actor_id"`
+	ChangedAt  time.Time      `db:"changed_at"`
+	// ChainSequence and the signatures are set once the transition is sealed on the chain of its booking
+	ChainSequence     *int64  `db:"chain_sequence"`
+	PreviousSignature *string `db:"previous_signature"`
+	Signature         *string `db:"signature"`
+}
@@ -0,0 +1,16 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarDaySummary is the month view projection of one day of an educator, in the educator's time zone
+type CalendarDaySummary struct {
+	EducatorId  uuid.UUID `db:"educator_id"`
+	Day         time.Time `db:"day"`
+	SlotCount   int       `db:"slot_count"`
+	BookedCount int       `db:"booked_count"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CancellationRule governs student cancellations of an educator's bookings. A rule without a session type
+// is the educator's default, applying to bookings whose session type has no rule of its own.
+type CancellationRule struct {
+	Id                    int64     `db:"id"`
+	EducatorId            uuid.UUID `db:"educator_id"`
+	SessionTypeId         *int64    `db:"session_type_id"`
+	MinNoticeHours        int       `db:"min_notice_hours"`
+	FreeCancellationHours int       `db:"free_cancellation_hours"`
+	PenaltyPercent        float64   `db:"penalty_percent"`
+	CreatedAt             time.Time `db:"created_at"`
+	UpdatedAt             time.Time `db:"updated_at"`
+}
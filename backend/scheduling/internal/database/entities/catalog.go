@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+)
+
+type CatalogProduct struct {
+	ProductId       int64       `db:"product_id"`
+	EducatorId      uuid.UUID   `db:"educator_id"`
+	ProductType     ProductType `db:"product_type"`
+	Title           string      `db:"title"`
+	Price           float64     `db:"price"`
+	DurationMin     *int        `db:"duration_min"`
+	MaxParticipants int         `db:"max_participants"`
+	Version         int64       `db:"version"`
+	DeletedAt       *time.Time  `db:"deleted_at"`
+	UpdatedAt       time.Time   `db:"updated_at"`
+}
+
+type CatalogLesson struct {
+	LessonId    int64 `db:"lesson_id"`
+	ProductId   int64 `db:"product_id"`
+	DurationMin int   `db:"duration_min"`
+}
+
+type CatalogEnrollment struct {
+	EnrollmentId     int64     `db:"enrollment_id"`
+	UserId           uuid.UUID `db:"user_id"`
+	ProductId        int64     `db:"product_id"`
+	ScheduledEventId *int64    `db:"scheduled_event_id"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+// ProductType mirrors the product types of the learning service
+type ProductType int
+
+const (
+	PrivateSession ProductType = iota
+	GroupSession
+	OnlineCourse
+	PreRecordedCourse
+)
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited entity types of the change audit
+const (
+	ChangeAuditWorkingPeriod  = "WORKING_PERIOD"
+	ChangeAuditScheduledEvent = "SCHEDULED_EVENT"
+	ChangeAuditBooking        = "BOOKING"
+)
+
+// ChangeAudit records a change of a working period, scheduled event or booking, written by database triggers.
+// Updates keep only the changed columns, a soft delete is recorded as a delete. Entries are only updated to be signed.
+type ChangeAudit struct {
+	Id         int64      `db:"id"`
+	EntityType string     `db:"entity_type"`
+	EntityId   int64      `db:"entity_id"`
+	Action     string     `db:"action"`
+	EducatorId *uuid.UUID `db:"educator_id"`
+	StudentId  *uuid.UUID `db:"student_id"`
+	ActorId    *uuid.UUID `db:"actor_id"`
+	OldValues  []byte     `db:"old_values"`
+	NewValues  []byte     `db:"new_values"`
+	ChangedAt  time.Time  `db:"changed_at"`
+	// ChainSequence and the signatures are set once the change is sealed on the chain of its entity
+	ChainSequence     *int64  `db:"chain_sequence"`
+	PreviousSignature *string `db:"previous_signature"`
+	Signature         *string `db:"signature"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+)
+
+type CommissionRule struct {
+	EducatorId        uuid.UUID `db:"educator_id"`
+	CommissionPercent float64   `db:"commission_percent"`
+	FixedFee          float64   `db:"fixed_fee"`
+	CreatedAt         time.Time `db:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// DeadLetter is a message rejected by a consumer, kept so operators can inspect, requeue or purge it
+type DeadLetter struct {
+	Id             int64     `db:"id"`
+	MessageId      string    `db:"message_id"`
+	CorrelationId  *string   `db:"correlation_id"`
+	MessageType    *string   `db:"message_type"`
+	Exchange       string    `db:"exchange"`
+	RoutingKey     string    `db:"routing_key"`
+	Queue          string    `db:"queue"`
+	Reason         string    `db:"reason"`
+	DeathCount     int64     `db:"death_count"`
+	Headers        []byte    `db:"headers"`
+	Body           []byte    `db:"body"`
+	DeadLetteredAt time.Time `db:"dead_lettered_at"`
+}
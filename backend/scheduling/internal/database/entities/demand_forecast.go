@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DemandForecast is the forecast demand of one upcoming day of an educator, in the educator's time zone
+type DemandForecast struct {
+	EducatorId       uuid.UUID `db:"educator_id"`
+	Day              time.Time `db:"day"`
+	AvailableMinutes int       `db:"available_minutes"`
+	BookedCount      int       `db:"booked_count"`
+	BookedMinutes    int       `db:"booked_minutes"`
+	ExpectedBookings float64   `db:"expected_bookings"`
+	ExpectedMinutes  int       `db:"expected_minutes"`
+	SuggestedSlots   int       `db:"suggested_slots"`
+	ComputedAt       time.Time `db:"computed_at"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type EscalationRule struct {
+	Id                 int64          `db:"id"`
+	EducatorId         uuid.UUID      `db:"educator_id"`
+	Name               string         `db:"name"`
+	Condition          string         `db:"condition"`
+	MinutesBeforeStart int            `db:"minutes_before_start"`
+	Channels           pq.StringArray `db:"channels"`
+	FlagDashboard      bool           `db:"flag_dashboard"`
+	Enabled            bool           `db:"enabled"`
+	CreatedAt          time.Time      `db:"created_at"`
+	UpdatedAt          time.Time      `db:"updated_at"`
+}
+
+type BookingEscalation struct {
+	RuleId      int64     `db:"rule_id"`
+	BookingId   int64     `db:"booking_id"`
+	Flagged     bool      `db:"flagged"`
+	TriggeredAt time.Time `db:"triggered_at"`
+}
@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// EventAudit is a published or consumed message envelope kept for tracing a flow across services.
+// Each entry is signed together with the signature of the entry before it on the chain of its correlation.
+type EventAudit struct {
+	Id            int64     `db:"id"`
+	EventId       string    `db:"event_id"`
+	EventType     string    `db:"event_type"`
+	RoutingKey    string    `db:"routing_key"`
+	Direction     string    `db:"direction"`
+	CorrelationId string    `db:"correlation_id"`
+	CausationId   *string   `db:"causation_id"`
+	ActorType     *string   `db:"actor_type"`
+	ActorId       *string   `db:"actor_id"`
+	OccurredAt    time.Time `db:"occurred_at"`
+	RecordedAt    time.Time `db:"recorded_at"`
+	// ChainSequence is the position of the entry on its chain, null for entries signed on the former single chain
+	ChainSequence     *int64  `db:"chain_sequence"`
+	PreviousSignature *string `db:"previous_signature"`
+	Signature         *string `db:"signature"`
+}
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// OutboxMessage is an event kept for a later publish, after the broker could not be reached
+type OutboxMessage struct {
+	Id            int64     `db:"id"`
+	EventId       string    `db:"event_id"`
+	EventType     string    `db:"event_type"`
+	RoutingKey    string    `db:"routing_key"`
+	Body          []byte    `db:"body"`
+	Attempts      int       `db:"attempts"`
+	LastError     *string   `db:"last_error"`
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+	CreatedAt     time.Time `db:"created_at"`
+}
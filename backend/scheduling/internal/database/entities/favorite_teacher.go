@@ -0,0 +1,13 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FavoriteTeacher struct {
+	StudentId  uuid.UUID `db:"student_id"`
+	EducatorId uuid.UUID `db:"educator_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+)
+
+// IdempotencyKey is a mutating request a client may retry, with the response it was answered with once
+// the request completed
+type IdempotencyKey struct {
+	UserId          uuid.UUID  `db:"user_id"`
+	Key             string     `db:"key"`
+	Fingerprint     string     `db:"fingerprint"`
+	StatusCode      *int       `db:"status_code"`
+	ResponseHeaders *string    `db:"response_headers"`
+	ResponseBody    []byte     `db:"response_body"`
+	CreatedAt       time.Time  `db:"created_at"`
+	CompletedAt     *time.Time `db:"completed_at"`
+	ExpiresAt       time.Time  `db:"expires_at"`
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+)
+
+type Invoice struct {
+	Id             int64     `db:"id"`
+	Number         string    `db:"invoice_number"`
+	BookingId      int64     `db:"booking_id"`
+	EducatorId     uuid.UUID `db:"educator_id"`
+	StudentId      uuid.UUID `db:"student_id"`
+	Currency       string    `db:"currency"`
+	Subtotal       float64   `db:"subtotal"`
+	DiscountAmount float64   `db:"discount_amount"`
+	TaxAmount      float64   `db:"tax_amount"`
+	TotalAmount    float64   `db:"total_amount"`
+	TaxCountry     *string   `db:"tax_country"`
+	TaxName        *string   `db:"tax_name"`
+	TaxRate        float64   `db:"tax_rate"`
+	ReverseCharge  bool      `db:"reverse_charge"`
+	IssuedAt       time.Time `db:"issued_at"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+type InvoiceLine struct {
+	Id             int64   `db:"id"`
+	InvoiceId      int64   `db:"invoice_id"`
+	Description    string  `db:"description"`
+	Quantity       int     `db:"quantity"`
+	UnitPrice      float64 `db:"unit_price"`
+	DiscountAmount float64 `db:"discount_amount"`
+	TaxAmount      float64 `db:"tax_amount"`
+	LineTotal      float64 `db:"line_total"`
+}
@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+)
+
+type Location struct {
+	Id         int64      `db:"id"`
+	EducatorId uuid.UUID  `db:"educator_id"`
+	Name       string     `db:"name"`
+	Address    string     `db:"address"`
+	Latitude   float64    `db:"latitude"`
+	Longitude  float64    `db:"longitude"`
+	Capacity   int        `db:"capacity"`
+	ArchivedAt *time.Time `db:"archived_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type NotificationPreference struct {
+	UserId          uuid.UUID      `db:"user_id"`
+	Channels        pq.StringArray `db:"channels"`
+	ReminderOffsets pq.Int64Array  `db:"reminder_offsets"`
+	QuietHoursStart *string        `db:"quiet_hours_start"`
+	QuietHoursEnd   *string        `db:"quiet_hours_end"`
+	Timezone        string         `db:"timezone"`
+	Language        string         `db:"language"`
+	TimeFormat      string         `db:"time_format"`
+	CreatedAt       time.Time      `db:"created_at"`
+	UpdatedAt       time.Time      `db:"updated_at"`
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Organization struct {
+	Id        int64     `db:"id"`
+	Name      string    `db:"name"`
+	CreatedBy uuid.UUID `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type OrganizationMember struct {
+	OrganizationId int64            `db:"organization_id"`
+	UserId         uuid.UUID        `db:"user_id"`
+	Role           OrganizationRole `db:"role"`
+	CreatedAt      time.Time        `db:"created_at"`
+}
+
+type OrganizationRole int
+
+const (
+	OrganizationAdmin OrganizationRole = iota
+	OrganizationTeacher
+)
+
+func (r OrganizationRole) String() string {
+	switch r {
+	case OrganizationAdmin:
+		return "Admin"
+	case OrganizationTeacher:
+		return "Teacher"
+	default:
+		return "Unknown"
+	}
+}
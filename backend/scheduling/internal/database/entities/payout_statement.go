@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+)
+
+type PayoutStatement struct {
+	Id           int64     `db:"id"`
+	EducatorId   uuid.UUID `db:"educator_id"`
+	PeriodStart  time.Time `db:"period_start"`
+	PeriodEnd    time.Time `db:"period_end"`
+	SessionCount int       `db:"session_count"`
+	GrossAmount  float64   `db:"gross_amount"`
+	FeeAmount    float64   `db:"fee_amount"`
+	NetAmount    float64   `db:"net_amount"`
+	Currency     string    `db:"currency"`
+	CreatedAt    time.Time `db:"created_at"`
+}
@@ -0,0 +1,9 @@
+package entities
+
+import "time"
+
+type ProcessedMessage struct {
+	MessageId   string    `db:"message_id"`
+	RoutingKey  string    `db:"routing_key"`
+	ProcessedAt time.Time `db:"processed_at"`
+}
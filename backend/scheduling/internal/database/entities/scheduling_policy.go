@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type SchedulingPolicy struct {
+	EducatorId     uuid.UUID     `db:"educator_id"`
+	Timezone       string        `db:"timezone"`
+	SessionLengths pq.Int64Array `db:"session_lengths"`
+	// BufferMinutes is kept free after each session, BufferBeforeMinutes before it
+	BufferMinutes           int       `db:"buffer_minutes"`
+	BufferBeforeMinutes     int       `db:"buffer_before_minutes"`
+	MinNoticeHours          int       `db:"min_notice_hours"`
+	BookingHorizonDays      int       `db:"booking_horizon_days"`
+	CancellationNoticeHours int       `db:"cancellation_notice_hours"`
+	CreatedAt               time.Time `db:"created_at"`
+	UpdatedAt               time.Time `db:"updated_at"`
+}
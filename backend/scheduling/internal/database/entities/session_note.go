@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+)
+
+type SessionNote struct {
+	Id         int64     `db:"id"`
+	BookingId  int64     `db:"booking_id"`
+	EducatorId uuid.UUID `db:"educator_id"`
+	StudentId  uuid.UUID `db:"student_id"`
+	Content    string    `db:"content"`
+	Version    int       `db:"version"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+type SessionNoteRevision struct {
+	Id       int64     `db:"id"`
+	NoteId   int64     `db:"note_id"`
+	Version  int       `db:"version"`
+	Content  string    `db:"content"`
+	EditedAt time.Time `db:"edited_at"`
+}
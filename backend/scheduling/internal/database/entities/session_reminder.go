@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sessions a reminder is sent for: a booking to its student, or to its educator when it is an individual
+// booking, and a scheduled event to its educator
+const (
+	ReminderSessionBooking = "BOOKING"
+	ReminderSessionEvent   = "EVENT"
+)
+
+const (
+	ReminderSent = "SENT"
+	// ReminderSkipped marks an offset passed while a later reminder of the session was already due, such as
+	// the day-before reminder after the service was down until an hour before the session
+	ReminderSkipped = "SKIPPED"
+	// ReminderSuppressed marks a reminder withheld at send time because the session no longer needed it
+	ReminderSuppressed = "SUPPRESSED"
+)
+
+// Reasons a due reminder is withheld at send time
+const (
+	ReminderSessionCancelled   = "CANCELLED"
+	ReminderSessionRescheduled = "RESCHEDULED"
+	ReminderStudentCheckedIn   = "CHECKED_IN"
+	// ReminderSessionMoved means the session start changed since the reminder fell due, it is recomputed
+	// for the new start instead of being recorded
+	ReminderSessionMoved = "MOVED"
+)
+
+type SessionReminder struct {
+	Id            int64     `db:"id"`
+	SessionKind   string    `db:"session_kind"`
+	SessionId     int64     `db:"session_id"`
+	UserId        uuid.UUID `db:"user_id"`
+	OffsetMinutes int       `db:"offset_minutes"`
+	DueAt         time.Time `db:"due_at"`
+	Status        string    `db:"status"`
+	CreatedAt     time.Time `db:"created_at"`
+}
@@ -0,0 +1,45 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/i18n"
+)
+
+type SessionType struct {
+	Id                          int64             `db:"id"`
+	EducatorId                  uuid.UUID         `db:"educator_id"`
+	Name                        string            `db:"name"`
+	Description                 string            `db:"description"`
+	DurationMinutes             int               `db:"duration_minutes"`
+	DefaultPrice                float64           `db:"default_price"`
+	DeliveryMode                DeliveryMode      `db:"delivery_mode"`
+	Color                       string            `db:"color"`
+	ConfirmationDeadlineMinutes *int              `db:"confirmation_deadline_minutes"`
+	Translations                i18n.Translations `db:"translations"`
+	ArchivedAt                  *time.Time        `db:"archived_at"`
+	CreatedAt                   time.Time         `db:"created_at"`
+	UpdatedAt                   time.Time         `db:"updated_at"`
+}
+
+type DeliveryMode int
+
+const (
+	Online DeliveryMode = iota
+	InPerson
+)
+
+func (m DeliveryMode) String() string {
+	switch m {
+	case Online:
+		return "Online"
+	case InPerson:
+		return "InPerson"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type ShareLink struct {
+	Id             int64         `db:"id"`
+	EducatorId     uuid.UUID     `db:"educator_id"`
+	Label          *string       `db:"label"`
+	SessionTypeIds pq.Int64Array `db:"session_type_ids"`
+	FromDate       time.Time     `db:"from_date"`
+	ToDate         time.Time     `db:"to_date"`
+	RevokedAt      *time.Time    `db:"revoked_at"`
+	CreatedAt      time.Time     `db:"created_at"`
+}
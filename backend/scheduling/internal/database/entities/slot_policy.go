@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// SlotPolicy is the platform-wide granularity of sessions: the durations they may last and the minutes
+// their start times are aligned on. Without durations any duration is allowed, without an alignment any
+// start time.
+type SlotPolicy struct {
+	AllowedDurations pq.Int64Array `db:"allowed_durations"`
+	AlignmentMinutes int           `db:"alignment_minutes"`
+	UpdatedBy        *uuid.UUID    `db:"updated_by"`
+	UpdatedAt        *time.Time    `db:"updated_at"`
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+)
+
+type TaxRule struct {
+	CountryCode   string    `db:"country_code"`
+	TaxName       string    `db:"tax_name"`
+	RatePercent   float64   `db:"rate_percent"`
+	ReverseCharge bool      `db:"reverse_charge"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+type TaxProfile struct {
+	UserId      uuid.UUID `db:"user_id"`
+	CountryCode string    `db:"country_code"`
+	TaxId       *string   `db:"tax_id"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TeacherOffboarding struct {
+	EducatorId         uuid.UUID         `db:"educator_id"`
+	Mode               string            `db:"mode"`
+	ReassignTo         *uuid.UUID        `db:"reassign_to"`
+	Status             OffboardingStatus `db:"status"`
+	InitiatedBy        uuid.UUID         `db:"initiated_by"`
+	LastBookingId      int64             `db:"last_booking_id"`
+	ReassignDone       bool              `db:"reassign_done"`
+	BookingsReassigned int               `db:"bookings_reassigned"`
+	BookingsKept       int               `db:"bookings_kept"`
+	EventsReleased     int               `db:"events_released"`
+	PeriodsReleased    int               `db:"periods_released"`
+	StartedAt          time.Time         `db:"started_at"`
+	UpdatedAt          time.Time         `db:"updated_at"`
+	ArchivedAt         *time.Time        `db:"archived_at"`
+}
+
+type OffboardingStatus int
+
+const (
+	OffboardingWindingDown OffboardingStatus = iota
+	OffboardingArchived
+)
+
+func (s OffboardingStatus) String() string {
+	switch s {
+	case OffboardingWindingDown:
+		return "WindingDown"
+	case OffboardingArchived:
+		return "Archived"
+	default:
+		return "Unknown"
+	}
+}
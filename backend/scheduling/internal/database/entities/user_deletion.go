@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	_ "github.com/lib/pq"
+)
+
+type UserDeletion struct {
+	UserId            uuid.UUID `db:"user_id"`
+	EventId           string    `db:"event_id"`
+	BookingsCancelled int       `db:"bookings_cancelled"`
+	EventsReleased    int       `db:"events_released"`
+	ProcessedAt       time.Time `db:"processed_at"`
+}
@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WaitlistEntry queues a student for a place in a fully booked scheduled event
+type WaitlistEntry struct {
+	Id               int64          `db:"id"`
+	ScheduledEventId int64          `db:"scheduled_event_id"`
+	StudentId        uuid.UUID      `db:"student_id"`
+	Status           WaitlistStatus `db:"status"`
+	OfferExpiresAt   *time.Time     `db:"offer_expires_at"`
+	BookingId        *int64         `db:"booking_id"`
+	CreatedAt        time.Time      `db:"created_at"`
+	UpdatedAt        time.Time      `db:"updated_at"`
+}
+
+type WaitlistStatus int
+
+const (
+	WaitlistWaiting WaitlistStatus = iota
+	WaitlistOffered
+	WaitlistPromoted
+	WaitlistExpired
+)
+
+func (s WaitlistStatus) String() string {
+	switch s {
+	case WaitlistWaiting:
+		return "Waiting"
+	case WaitlistOffered:
+		return "Offered"
+	case WaitlistPromoted:
+		return "Promoted"
+	case WaitlistExpired:
+		return "Expired"
+	default:
+		return "Unknown"
+	}
+}
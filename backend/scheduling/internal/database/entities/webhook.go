@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// WebhookSubscription sends the events of an educator's bookings and schedule to an external URL
+type WebhookSubscription struct {
+	Id         int64          `db:"id"`
+	EducatorId uuid.UUID      `db:"educator_id"`
+	Url        string         `db:"url"`
+	Secret     string         `db:"secret"`
+	EventTypes pq.StringArray `db:"event_types"`
+	Fields     pq.StringArray `db:"fields"`
+	Active     bool           `db:"active"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is one event sent to a subscription, kept with the outcome of its attempts as the delivery log
+type WebhookDelivery struct {
+	Id             int64      `db:"id"`
+	SubscriptionId int64      `db:"subscription_id"`
+	EventId        uuid.UUID  `db:"event_id"`
+	EventType      string     `db:"event_type"`
+	Payload        []byte     `db:"payload"`
+	Status         string     `db:"status"`
+	Attempts       int        `db:"attempts"`
+	LastStatusCode *int       `db:"last_status_code"`
+	LastError      *string    `db:"last_error"`
+	NextAttemptAt  time.Time  `db:"next_attempt_at"`
+	DeliveredAt    *time.Time `db:"delivered_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+}
+
+// DueWebhookDelivery is a claimed delivery with the endpoint it is sent to
+type DueWebhookDelivery struct {
+	WebhookDelivery
+	Url    string `db:"url"`
+	Secret string `db:"secret"`
+}
+
+// WebhookDeadLetter is a delivery that ran out of attempts, parked until it is redelivered or purged
+type WebhookDeadLetter struct {
+	Id             int64     `db:"id"`
+	DeliveryId     int64     `db:"delivery_id"`
+	SubscriptionId int64     `db:"subscription_id"`
+	EventId        uuid.UUID `db:"event_id"`
+	EventType      string    `db:"event_type"`
+	Payload        []byte    `db:"payload"`
+	Attempts       int       `db:"attempts"`
+	LastStatusCode *int      `db:"last_status_code"`
+	LastError      *string   `db:"last_error"`
+	DeadLetteredAt time.Time `db:"dead_lettered_at"`
+}
@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WidgetApiUsage struct {
+	EducatorId  uuid.UUID `db:"educator_id"`
+	KeyId       string    `db:"key_id"`
+	Endpoint    string    `db:"endpoint"`
+	BucketStart time.Time `db:"bucket_start"`
+	Requests    int64     `db:"requests"`
+	Errors      int64     `db:"errors"`
+	RateLimited int64     `db:"rate_limited"`
+}
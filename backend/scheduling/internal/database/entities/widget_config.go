@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type WidgetConfig struct {
+	EducatorId         uuid.UUID      `db:"educator_id"`
+	Enabled            bool           `db:"enabled"`
+	AllowedOrigins     pq.StringArray `db:"allowed_origins"`
+	ApiKeyHash         *string        `db:"api_key_hash"`
+	RateLimitPerMinute int            `db:"rate_limit_per_minute"`
+	CreatedAt          time.Time      `db:"created_at"`
+	UpdatedAt          time.Time      `db:"updated_at"`
+}
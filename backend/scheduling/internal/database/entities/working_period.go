@@ -9,10 +9,12 @@ import (
 )
 
 type WorkingPeriod struct {
-	Id        int64     `db:"id"`
-	UserId    uuid.UUID `db:"user_id"`
-	StartTime time.Time `db:"start_time"`
-	EndTime   time.Time `db:"end_time"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	Id           int64     `db:"id"`
+	UserId       uuid.UUID `db:"user_id"`
+	StartTime    time.Time `db:"start_time"`
+	EndTime      time.Time `db:"end_time"`
+	RecurrenceId *int64    `db:"recurrence_id"`
+	Version      int64     `db:"version"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
 }
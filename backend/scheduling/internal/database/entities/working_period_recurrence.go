@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WorkingPeriodRecurrence struct {
+	Id         int64     `db:"id"`
+	UserId     uuid.UUID `db:"user_id"`
+	RRule      string    `db:"rrule"`
+	StartClock string    `db:"start_clock"`
+	EndClock   string    `db:"end_clock"`
+	Timezone   string    `db:"timezone"`
+	StartsOn   time.Time `db:"starts_on"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// SQLSTATE codes that mean the connection no longer talks to a writable primary
+const (
+	pgReadOnlySqlTransaction = "25006"
+	pgAdminShutdown          = "57P01"
+	pgCrashShutdown          = "57P02"
+	pgCannotConnectNow       = "57P03"
+)
+
+const failoverProbeTimeout = 3 * time.Second
+
+var errStandby = errors.New("connected server is a standby")
+
+// FailoverMonitor detects a primary failover and reconnects the pool to the new primary. Writes are shed
+// while it reconnects, so they fail fast instead of hitting a read-only standby or a closed server.
+type FailoverMonitor struct {
+	log     logger.Logger
+	cfg     *config.PostgresConfig
+	pool    *pgxpool.Pool
+	trigger chan struct{}
+	shed    atomic.Bool
+}
+
+func NewFailoverMonitor(log logger.Logger, cfg *config.PostgresConfig) *FailoverMonitor {
+	return &FailoverMonitor{log: log, cfg: cfg, trigger: make(chan struct{}, 1)}
+}
+
+// ShedsWrites reports whether writes are refused while the pool reconnects to a new primary
+func (m *FailoverMonitor) ShedsWrites() bool {
+	return m.shed.Load()
+}
+
+// onPgError closes connections that hit a read-only or shutdown error and starts a reconnect.
+// Other errors keep the default behaviour of closing the connection on fatal errors only.
+func (m *FailoverMonitor) onPgError(_ *pgconn.PgConn, pgErr *pgconn.PgError) bool {
+	switch pgErr.Code {
+	case pgReadOnlySqlTransaction, pgAdminShutdown, pgCrashShutdown, pgCannotConnectNow:
+		m.Trigger()
+		return false
+	}
+	return pgErr.Severity != "FATAL"
+}
+
+// Trigger requests a reconnect, repeated requests before it runs are coalesced
+func (m *FailoverMonitor) Trigger() {
+	select {
+	case m.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Run probes the primary on every health check period and reconnects when it is gone or became a standby,
+// until the context is cancelled
+func (m *FailoverMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(m.cfg.HealthCheckPeriodSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.probe(ctx); err != nil && ctx.Err() == nil {
+				m.log.Warnf("Postgres primary probe failed: %v", err)
+				m.reconnect(ctx)
+			}
+		case <-m.trigger:
+			m.reconnect(ctx)
+		}
+	}
+}
+
+// reconnect drops every pooled connection and retries with a growing backoff until a writable primary
+// answers or the attempts run out. New connections resolve the host again, so a DNS or multi-host
+// switch to the promoted server is picked up.
+func (m *FailoverMonitor) reconnect(ctx context.Context) {
+	if m.pool == nil {
+		return
+	}
+
+	m.shed.Store(true)
+	defer m.shed.Store(false)
+	m.log.Warnf("Postgres failover suspected, reconnecting and shedding writes")
+
+	backoff := time.Duration(m.cfg.FailoverBackoffMs) * time.Millisecond
+	maxBackoff := time.Duration(m.cfg.FailoverMaxBackoffMs) * time.Millisecond
+
+	for attempt := 1; attempt <= m.cfg.FailoverMaxAttempts; attempt++ {
+		m.pool.Reset()
+
+		err := m.probe(ctx)
+		if err == nil {
+			m.log.Infof("Reconnected to the Postgres primary after %d attempt(s)", attempt)
+			m.drainTrigger()
+			return
+		}
+		m.log.Warnf("Postgres reconnect attempt %d/%d failed: %v", attempt, m.cfg.FailoverMaxAttempts, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+
+	m.log.Errorf("Postgres primary still unavailable after %d attempts, accepting writes again", m.cfg.FailoverMaxAttempts)
+}
+
+// probe checks that the pool reaches a server that accepts writes
+func (m *FailoverMonitor) probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, failoverProbeTimeout)
+	defer cancel()
+
+	var inRecovery bool
+	if err := m.pool.QueryRow(ctx, "SELECT pg_is_in_recovery()").Scan(&inRecovery); err != nil {
+		return err
+	}
+	if inRecovery {
+		return errStandby
+	}
+	return nil
+}
+
+// drainTrigger drops reconnect requests raised by connections that failed during the reconnect
+func (m *FailoverMonitor) drainTrigger() {
+	select {
+	case <-m.trigger:
+	default:
+	}
+}
@@ -7,15 +7,34 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
 )
 
+// Kinds of deleted schedule rows recorded for availability polling clients
+const (
+	TombstoneWorkingPeriod  = "WORKING_PERIOD"
+	TombstoneScheduledEvent = "SCHEDULED_EVENT"
+)
+
+// WithTombstones wraps a soft delete on working_period or scheduled_event, an UPDATE setting deleted_at, so that
+// every deleted row is recorded in availability_tombstone, letting polling clients learn about removals. Rows
+// affected stay the deleted rows.
+func WithTombstones(kind string, deleteQuery string) string {
+	return `
+		WITH deleted AS (` + deleteQuery + ` RETURNING id, user_id, start_time, end_time)
+		INSERT INTO availability_tombstone (educator_id, kind, entity_id, start_time, end_time, deleted_at)
+		SELECT user_id, '` + kind + `', id, start_time, end_time, current_timestamp FROM deleted
+	`
+}
+
 func FetchMultiple[T any](ctx context.Context, db *sqlx.DB, query string, args ...any) ([]*T, error) {
 	var results []*T
-	err := db.SelectContext(ctx, &results, query, args...)
+	defer observeQuery(ctx, opFetchMultiple, getTypeName(*new(T)), time.Now())
+	err := Conn(ctx, db).SelectContext(ctx, &results, query, args...)
 	if err != nil {
 		return nil, apperrors.NewInternal(err)
 	}
@@ -24,7 +43,8 @@ func FetchMultiple[T any](ctx context.Context, db *sqlx.DB, query string, args .
 
 func FetchSingle[T any](ctx context.Context, db *sqlx.DB, query string, args ...any) (*T, error) {
 	var result T
-	err := db.GetContext(ctx, &result, query, args...)
+	defer observeQuery(ctx, opFetchSingle, getTypeName(result), time.Now())
+	err := Conn(ctx, db).GetContext(ctx, &result, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, apperrors.NewNotFound(getTypeName(result)+" not found", apperrors.ErrResourceNotFound, err)
@@ -35,7 +55,8 @@ func FetchSingle[T any](ctx context.Context, db *sqlx.DB, query string, args ...
 }
 
 func ExecNamedQuery(ctx context.Context, db *sqlx.DB, query string, arg any) error {
-	_, err := db.NamedExecContext(ctx, query, arg)
+	defer observeQuery(ctx, opExecNamed, "", time.Now())
+	_, err := Conn(ctx, db).NamedExecContext(ctx, query, arg)
 	if err != nil {
 		return apperrors.NewInternal(err)
 	}
@@ -43,7 +64,8 @@ func ExecNamedQuery(ctx context.Context, db *sqlx.DB, query string, arg any) err
 }
 
 func ExecNamedQueryWithResult[T any](ctx context.Context, db *sqlx.DB, query string, arg any) (T, error) {
-	stmt, err := db.PrepareNamedContext(ctx, query)
+	defer observeQuery(ctx, opExecNamedResult, "", time.Now())
+	stmt, err := Conn(ctx, db).PrepareNamedContext(ctx, query)
 	if err != nil {
 		var zero T
 		return zero, apperrors.NewInternal(err)
@@ -60,7 +82,8 @@ func ExecNamedQueryWithResult[T any](ctx context.Context, db *sqlx.DB, query str
 }
 
 func ExecQuery(ctx context.Context, db *sqlx.DB, query string, args ...any) error {
-	_, err := db.ExecContext(ctx, query, args...)
+	defer observeQuery(ctx, opExec, "", time.Now())
+	_, err := Conn(ctx, db).ExecContext(ctx, query, args...)
 	if err != nil {
 		return apperrors.NewInternal(err)
 	}
@@ -72,6 +95,7 @@ func ExecInsertMany(ctx context.Context, db *sqlx.DB, table string, items []any,
 	if len(items) == 0 {
 		return nil
 	}
+	defer observeQuery(ctx, opInsertMany, table, time.Now())
 
 	columns, err := GetDBColumns(items[0], skipColumns...)
 	if err != nil {
@@ -88,16 +112,47 @@ func ExecInsertMany(ctx context.Context, db *sqlx.DB, table string, items []any,
 		return apperrors.NewInternal(err)
 	}
 
-	_, err = db.ExecContext(ctx, query, args...)
+	_, err = Conn(ctx, db).ExecContext(ctx, query, args...)
 	if err != nil {
 		return apperrors.NewInternal(err)
 	}
 	return nil
 }
 
+// ExecInsertManyReturningIds inserts multiple rows into a table and returns the generated ids in insertion order.
+func ExecInsertManyReturningIds(ctx context.Context, db *sqlx.DB, table string, items []any, skipColumns ...string) ([]int64, error) {
+	if len(items) == 0 {
+		return []int64{}, nil
+	}
+	defer observeQuery(ctx, opInsertManyReturning, table, time.Now())
+
+	columns, err := GetDBColumns(items[0], skipColumns...)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	query, err := BuildInsertQuery(table, columns, len(items), db)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	args, err := FlattenValues(items, columns)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	var ids []int64
+	err = Conn(ctx, db).SelectContext(ctx, &ids, query+" RETURNING id", args...)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return ids, nil
+}
+
 func CheckExists(ctx context.Context, db *sqlx.DB, query string, args ...any) (bool, error) {
 	var exists bool
-	err := db.GetContext(ctx, &exists, query, args...)
+	defer observeQuery(ctx, opCheckExists, "", time.Now())
+	err := Conn(ctx, db).GetContext(ctx, &exists, query, args...)
 	if err != nil {
 		return false, apperrors.NewInternal(err)
 	}
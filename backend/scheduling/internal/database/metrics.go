@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Operations of the query helpers, recorded as the operation attribute of query durations
+const (
+	opFetchMultiple       = "fetch_multiple"
+	opFetchSingle         = "fetch_single"
+	opExecNamed           = "exec_named"
+	opExecNamedResult     = "exec_named_result"
+	opExec                = "exec"
+	opInsertMany          = "insert_many"
+	opInsertManyReturning = "insert_many_returning"
+	opCheckExists         = "check_exists"
+)
+
+var queryDuration metric.Float64Histogram
+
+// InitQueryMetrics starts recording the duration of queries run through the helpers of this package.
+// Queries are not recorded until it is called.
+func InitQueryMetrics(meter metric.Meter) error {
+	histogram, err := meter.Float64Histogram("scheduling.db.query.duration",
+		metric.WithDescription("Duration of database queries run through the query helpers, by operation and entity"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+	queryDuration = histogram
+	return nil
+}
+
+func observeQuery(ctx context.Context, operation string, entity string, started time.Time) {
+	if queryDuration == nil {
+		return
+	}
+	attrs := []attribute.KeyValue{attribute.String("operation", operation)}
+	if entity != "" {
+		attrs = append(attrs, attribute.String("entity", entity))
+	}
+	queryDuration.Record(ctx, time.Since(started).Seconds(), metric.WithAttributes(attrs...))
+}
+
+// RegisterPoolMetrics reports connection pool usage, so pool saturation shows up as acquired connections
+// reaching the maximum and as a growing number of acquires that had to wait for a connection
+func RegisterPoolMetrics(meter metric.Meter, pool *pgxpool.Pool) error {
+	connections, err := meter.Int64ObservableGauge("scheduling.db.pool.connections",
+		metric.WithDescription("Connections in the pool, by state"))
+	if err != nil {
+		return err
+	}
+
+	maxConnections, err := meter.Int64ObservableGauge("scheduling.db.pool.connections.max",
+		metric.WithDescription("Maximum size of the pool"))
+	if err != nil {
+		return err
+	}
+
+	acquires, err := meter.Int64ObservableCounter("scheduling.db.pool.acquires",
+		metric.WithDescription("Connections acquired from the pool, by outcome"))
+	if err != nil {
+		return err
+	}
+
+	acquireWait, err := meter.Float64ObservableCounter("scheduling.db.pool.acquire.duration",
+		metric.WithDescription("Total time spent acquiring connections from the pool"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stat := pool.Stat()
+
+		o.ObserveInt64(connections, int64(stat.AcquiredConns()), metric.WithAttributes(attribute.String("state", "acquired")))
+		o.ObserveInt64(connections, int64(stat.IdleConns()), metric.WithAttributes(attribute.String("state", "idle")))
+		o.ObserveInt64(connections, int64(stat.ConstructingConns()), metric.WithAttributes(attribute.String("state", "constructing")))
+		o.ObserveInt64(maxConnections, int64(stat.MaxConns()))
+
+		// Acquires that found the pool empty had to wait for a connection to be released or created
+		o.ObserveInt64(acquires, stat.AcquireCount()-stat.EmptyAcquireCount(), metric.WithAttributes(attribute.String("outcome", "immediate")))
+		o.ObserveInt64(acquires, stat.EmptyAcquireCount(), metric.WithAttributes(attribute.String("outcome", "waited")))
+		o.ObserveInt64(acquires, stat.CanceledAcquireCount(), metric.WithAttributes(attribute.String("outcome", "canceled")))
+		o.ObserveFloat64(acquireWait, stat.AcquireDuration().Seconds())
+		return nil
+	}, connections, maxConnections, acquires, acquireWait)
+	return err
+}
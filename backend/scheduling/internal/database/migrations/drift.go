@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SchemaDrift lists the differences between the changesets embedded in the binary and those applied to the database
+type SchemaDrift struct {
+	// Expected is the latest changeset the binary was built with
+	Expected string
+	// Current is the latest applied changeset known to the binary, empty when none is applied
+	Current string
+	// Pending are embedded changesets not applied yet
+	Pending []string
+	// Modified are applied changesets whose script changed after they were applied
+	Modified []string
+	// Unknown are applied changesets missing from the binary, typically applied by a newer release
+	Unknown []string
+}
+
+// InSync reports whether the database schema matches the binary exactly
+func (d *SchemaDrift) InSync() bool {
+	return len(d.Pending) == 0 && len(d.Modified) == 0 && len(d.Unknown) == 0
+}
+
+// Drift compares the applied changesets with the embedded ones without applying anything
+func (r *Runner) Drift(ctx context.Context) (*SchemaDrift, error) {
+	drift := &SchemaDrift{Pending: []string{}, Modified: []string{}, Unknown: []string{}}
+	if len(r.migrations) > 0 {
+		drift.Expected = r.migrations[len(r.migrations)-1].Id
+	}
+
+	err := r.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := r.getApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range r.migrations {
+			a, ok := applied[m.Id]
+			if !ok {
+				drift.Pending = append(drift.Pending, m.Id)
+				continue
+			}
+
+			drift.Current = m.Id
+			if a.Checksum != "" && a.Checksum != m.Checksum {
+				drift.Modified = append(drift.Modified, m.Id)
+			}
+			delete(applied, m.Id)
+		}
+
+		for id := range applied {
+			drift.Unknown = append(drift.Unknown, id)
+		}
+		sort.Strings(drift.Unknown)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return drift, nil
+}
@@ -0,0 +1,334 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	changelog "github.com/maksmelnyk/scheduling/migrations"
+)
+
+const (
+	changelogDir    = "changelog"
+	masterChangelog = "db.changelog-master.xml"
+	downSuffix      = ".down.sql"
+
+	// lockKey serializes runners of several replicas starting at the same time
+	lockKey = 7346215091
+)
+
+// Migration is a single changeset listed in the master changelog
+type Migration struct {
+	Id       string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// MigrationStatus describes whether a changeset has been applied to the database
+type MigrationStatus struct {
+	Id         string
+	Applied    bool
+	AppliedAt  *time.Time
+	Modified   bool
+	Reversible bool
+}
+
+type appliedMigration struct {
+	Id        string    `db:"id"`
+	Checksum  string    `db:"checksum"`
+	AppliedAt time.Time `db:"applied_at"`
+}
+
+type masterFile struct {
+	Includes []struct {
+		File string `xml:"file,attr"`
+	} `xml:"include"`
+}
+
+type Runner struct {
+	db         *sqlx.DB
+	log        logger.Logger
+	migrations []*Migration
+}
+
+// NewRunner loads the embedded changelog in the order defined by the master changelog
+func NewRunner(db *sqlx.DB, log logger.Logger) (*Runner, error) {
+	migrations, err := loadMigrations(changelog.Changelog)
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{db: db, log: log, migrations: migrations}, nil
+}
+
+// Up applies every pending changeset and returns the number of applied ones
+func (r *Runner) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := r.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := r.getApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range r.migrations {
+			if a, ok := applied[m.Id]; ok {
+				if a.Checksum != "" && a.Checksum != m.Checksum {
+					r.log.Warnf("Migration %s changed after it was applied", m.Id)
+				}
+				continue
+			}
+
+			if err := r.apply(ctx, conn, m); err != nil {
+				return fmt.Errorf("apply migration %s: %w", m.Id, err)
+			}
+			r.log.Infof("Applied migration %s", m.Id)
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down reverts the given number of most recently applied changesets and returns the number of reverted ones
+func (r *Runner) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := r.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := r.getApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(r.migrations) - 1; i >= 0 && count < steps; i-- {
+			m := r.migrations[i]
+			if _, ok := applied[m.Id]; !ok {
+				continue
+			}
+			if m.Down == "" {
+				return fmt.Errorf("migration %s has no %s script", m.Id, downSuffix)
+			}
+
+			if err := r.revert(ctx, conn, m); err != nil {
+				return fmt.Errorf("revert migration %s: %w", m.Id, err)
+			}
+			r.log.Infof("Reverted migration %s", m.Id)
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Status reports every changeset of the changelog in application order
+func (r *Runner) Status(ctx context.Context) ([]*MigrationStatus, error) {
+	var statuses []*MigrationStatus
+	err := r.withLock(ctx, func(conn *sqlx.Conn) error {
+		applied, err := r.getApplied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range r.migrations {
+			status := &MigrationStatus{Id: m.Id, Reversible: m.Down != ""}
+			if a, ok := applied[m.Id]; ok {
+				status.Applied = true
+				status.AppliedAt = &a.AppliedAt
+				status.Modified = a.Checksum != "" && a.Checksum != m.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+func (r *Runner) apply(ctx context.Context, conn *sqlx.Conn, m *Migration) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.Up); err != nil {
+		return err
+	}
+
+	const query = `INSERT INTO schema_migration (id, checksum, applied_at) VALUES ($1, $2, $3)`
+	if _, err := tx.ExecContext(ctx, query, m.Id, m.Checksum, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *Runner) revert(ctx context.Context, conn *sqlx.Conn, m *Migration) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.Down); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migration WHERE id = $1`, m.Id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// getApplied returns applied changesets by Id, creating the tracking table on first use. A database
+// previously migrated by Liquibase is baselined from its databasechangelog table so nothing runs twice.
+func (r *Runner) getApplied(ctx context.Context, conn *sqlx.Conn) (map[string]*appliedMigration, error) {
+	var exists bool
+	if err := conn.GetContext(ctx, &exists, `SELECT to_regclass('schema_migration') IS NOT NULL`); err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		if err := r.createTable(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []*appliedMigration
+	if err := conn.SelectContext(ctx, &rows, `SELECT id, checksum, applied_at FROM schema_migration`); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]*appliedMigration, len(rows))
+	for _, a := range rows {
+		applied[a.Id] = a
+	}
+	return applied, nil
+}
+
+func (r *Runner) createTable(ctx context.Context, conn *sqlx.Conn) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	const createQuery = `
+		CREATE TABLE schema_migration (
+			id varchar(255) primary key,
+			checksum varchar(64) not null default '',
+			applied_at timestamptz not null
+		)
+	`
+	if _, err := tx.ExecContext(ctx, createQuery); err != nil {
+		return err
+	}
+
+	var liquibase bool
+	if err := tx.GetContext(ctx, &liquibase, `SELECT to_regclass('databasechangelog') IS NOT NULL`); err != nil {
+		return err
+	}
+
+	if liquibase {
+		var files []string
+		if err := tx.SelectContext(ctx, &files, `SELECT DISTINCT filename FROM databasechangelog`); err != nil {
+			return err
+		}
+
+		known := make(map[string]struct{}, len(r.migrations))
+		for _, m := range r.migrations {
+			known[m.Id] = struct{}{}
+		}
+
+		const baselineQuery = `INSERT INTO schema_migration (id, applied_at) VALUES ($1, $2) ON CONFLICT (id) DO NOTHING`
+		baselined := 0
+		for _, f := range files {
+			id := path.Base(f)
+			if _, ok := known[id]; !ok {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, baselineQuery, id, time.Now().UTC()); err != nil {
+				return err
+			}
+			baselined++
+		}
+		r.log.Infof("Baselined %d migrations from the Liquibase changelog", baselined)
+	}
+
+	return tx.Commit()
+}
+
+// withLock runs fn on a dedicated connection holding a session advisory lock
+func (r *Runner) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := r.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey); err != nil {
+			r.log.Errorf("Failed to release migration lock: %v", err)
+		}
+	}()
+
+	return fn(conn)
+}
+
+func loadMigrations(fsys fs.FS) ([]*Migration, error) {
+	raw, err := fs.ReadFile(fsys, path.Join(changelogDir, masterChangelog))
+	if err != nil {
+		return nil, err
+	}
+
+	var master masterFile
+	if err := xml.Unmarshal(raw, &master); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", masterChangelog, err)
+	}
+
+	migrations := make([]*Migration, 0, len(master.Includes))
+	for _, include := range master.Includes {
+		up, err := fs.ReadFile(fsys, path.Join(changelogDir, include.File))
+		if err != nil {
+			return nil, err
+		}
+
+		down, err := fs.ReadFile(fsys, path.Join(changelogDir, strings.TrimSuffix(include.File, ".sql")+downSuffix))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(up)
+		migrations = append(migrations, &Migration{
+			Id:       include.File,
+			Up:       unwrapTransaction(string(up)),
+			Down:     unwrapTransaction(string(down)),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	return migrations, nil
+}
+
+// unwrapTransaction strips the begin/commit pair of a changeset so it runs inside the runner's own transaction
+func unwrapTransaction(script string) string {
+	body := strings.TrimSpace(script)
+	if len(body) >= len("begin;") && strings.EqualFold(body[:len("begin;")], "begin;") {
+		body = body[len("begin;"):]
+	}
+	if len(body) >= len("commit;") && strings.EqualFold(body[len(body)-len("commit;"):], "commit;") {
+		body = body[:len(body)-len("commit;")]
+	}
+	return strings.TrimSpace(body)
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+)
+
+// Settings read by the row security policies. A session sees and writes the rows of the tenant set on it, or
+// the rows of every tenant when it bypasses tenancy; without either it sees none.
+const (
+	tenantSetting       = "scheduling.tenant_id"
+	tenantBypassSetting = "scheduling.tenant_bypass"
+)
+
+// tenantScopeData keys the scope last applied to a pooled connection, which is only set again when it changes
+const tenantScopeData = "scheduling.tenant_scope"
+
+type allTenantsKey struct{}
+
+// WithAllTenants lets the work within ctx see and write the rows of every tenant, for background jobs,
+// migrations and consumers of messages without a tenant. A tenant stored in ctx still narrows the work to
+// that tenant, so messages and tasks of one tenant stay scoped to it.
+func WithAllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantsKey{}, true)
+}
+
+type tenantScope struct {
+	tenantId   string
+	allTenants bool
+}
+
+// scopeOf returns the tenants the work within ctx may see. A single-tenant deployment sees every row, a
+// multi-tenant one the rows of the tenant in ctx, or of every tenant when ctx bypasses tenancy. Work with
+// neither, such as a request that did not name its tenant, sees nothing.
+func scopeOf(ctx context.Context, tenancyEnabled bool) tenantScope {
+	if !tenancyEnabled {
+		return tenantScope{allTenants: true}
+	}
+	if tenantId, ok := auth.GetTenantID(ctx); ok {
+		return tenantScope{tenantId: tenantId}
+	}
+	allTenants, _ := ctx.Value(allTenantsKey{}).(bool)
+	return tenantScope{allTenants: allTenants}
+}
+
+// applyTenantScope sets the tenant scope of ctx on a connection taken from the pool, before it runs any
+// statement. The settings are kept for the session, which is safe as database/sql keeps no idle connections
+// and takes a connection from the pool for every query or transaction.
+func applyTenantScope(ctx context.Context, conn driver.Conn, tenancyEnabled bool) error {
+	pgxConn := conn.(*stdlib.Conn).Conn()
+	scope := scopeOf(ctx, tenancyEnabled)
+	if applied, ok := pgxConn.PgConn().CustomData()[tenantScopeData].(tenantScope); ok && applied == scope {
+		return nil
+	}
+
+	bypass := ""
+	if scope.allTenants {
+		bypass = "on"
+	}
+	const query = `SELECT set_config('` + tenantSetting + `', $1, false), set_config('` + tenantBypassSetting + `', $2, false)`
+	if _, err := pgxConn.Exec(ctx, query, scope.tenantId, bypass); err != nil {
+		return err
+	}
+	pgxConn.PgConn().CustomData()[tenantScopeData] = scope
+	return nil
+}
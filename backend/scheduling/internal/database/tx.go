@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+)
+
+// Executor runs queries either directly on the db or within a transaction
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error)
+	PrepareNamedContext(ctx context.Context, query string) (*sqlx.NamedStmt, error)
+	QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error)
+	QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row
+}
+
+// Tx is a transaction opened by BeginTx
+type Tx interface {
+	Executor
+	Commit() error
+	Rollback() error
+}
+
+type txKey struct{}
+
+// auditActorSetting carries the user behind a transaction to the change audit triggers
+const auditActorSetting = "scheduling.actor_id"
+
+var savepointSeq atomic.Int64
+
+// WithTx stores a request transaction in ctx. Queries run through Conn and transactions opened with BeginTx
+// within ctx join it, so a whole request commits or rolls back at once.
+func WithTx(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// WithoutTx hides the request transaction of ctx, for work that outlives the request such as background
+// tasks. Queries within the returned context run on the db again.
+func WithoutTx(ctx context.Context) context.Context {
+	if _, ok := txFromContext(ctx); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, txKey{}, (*sqlx.Tx)(nil))
+}
+
+func txFromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok && tx != nil
+}
+
+// Conn returns the request transaction stored in ctx, or db when the request runs without one
+func Conn(ctx context.Context, db *sqlx.DB) Executor {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+// BeginTx opens a transaction on db. Within a request transaction a savepoint is used instead, so a
+// rollback only reverts the work done since BeginTx and a commit leaves the outcome to the request.
+// Options can not be changed for a savepoint and are ignored in that case.
+func BeginTx(ctx context.Context, db *sqlx.DB, opts *sql.TxOptions) (Tx, error) {
+	tx, ok := txFromContext(ctx)
+	if !ok {
+		tx, err := db.BeginTxx(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		if err := SetAuditActor(ctx, tx); err != nil {
+			_ = tx.Rollback()
+			return nil, err
+		}
+		return tx, nil
+	}
+
+	name := fmt.Sprintf("sp_%d", savepointSeq.Add(1))
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return nil, err
+	}
+	return &savepoint{Tx: tx, ctx: ctx, name: name}, nil
+}
+
+// SetAuditActor attributes the changes made in tx to the user behind the request in ctx, so the change
+// audit records who made them. Changes made without a request, or outside a transaction, have no actor.
+func SetAuditActor(ctx context.Context, tx Executor) error {
+	actorId, err := auth.GetActorID(ctx)
+	if err != nil {
+		return nil
+	}
+	_, err = tx.ExecContext(ctx, `SELECT set_config('`+auditActorSetting+`', $1, true)`, actorId.String())
+	return err
+}
+
+// savepoint is a nested transaction within a request transaction
+type savepoint struct {
+	*sqlx.Tx
+	ctx  context.Context
+	name string
+	done bool
+}
+
+func (s *savepoint) Commit() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.Tx.ExecContext(s.ctx, "RELEASE SAVEPOINT "+s.name)
+	return err
+}
+
+func (s *savepoint) Rollback() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.Tx.ExecContext(s.ctx, "ROLLBACK TO SAVEPOINT "+s.name)
+	return err
+}
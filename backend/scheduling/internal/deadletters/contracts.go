@@ -0,0 +1,25 @@
+package deadletters
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// swagger:model DeadLetterResponse
+type DeadLetterResponse struct {
+	Id            int64   `json:"id"`
+	MessageId     string  `json:"messageId"`
+	CorrelationId *string `json:"correlationId"`
+	Type          *string `json:"type"`
+	// Exchange and RoutingKey are where the message was originally published, and where it is requeued to
+	Exchange   string `json:"exchange"`
+	RoutingKey string `json:"routingKey"`
+	// Queue is the consumer queue that rejected the message
+	Queue      string          `json:"queue"`
+	Reason     string          `json:"reason"`
+	DeathCount int64           `json:"deathCount"`
+	Headers    json.RawMessage `json:"headers" swaggertype:"object"`
+	// Body is the payload as text, binary payloads are not decoded
+	Body           string    `json:"body"`
+	DeadLetteredAt time.Time `json:"deadLetteredAt"`
+}
@@ -0,0 +1,137 @@
+package deadletters
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type DeadLetterHandler struct {
+	service *DeadLetterService
+}
+
+func NewDeadLetterHandler(service *DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{service: service}
+}
+
+// GetDeadLetters retrieves the dead-lettered messages.
+// @Summary      Retrieve dead letters
+// @Description  Retrieves the messages rejected by consumers, newest first, with the reason and how often each was dead-lettered.
+// @Tags         DeadLetter
+// @Accept       json
+// @Produce      json
+// @Param        queue  query     string  false  "Consumer queue that rejected the messages"
+// @Param        skip   query     int     false  "Number of messages to skip"
+// @Param        take   query     int     false  "Number of messages to return, at most 100"
+// @Success      200    {array}   DeadLetterResponse  "Dead letters"
+// @Failure      400    {object}  error               "Invalid input parameters"
+// @Router       /api/v1/admin/dlq/messages [get]
+// @Security 	 BearerAuth
+func (h *DeadLetterHandler) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	skip, err := api.ParseIntQuery(w, r, "skip", 0)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	take, err := api.ParseIntQuery(w, r, "take", 20)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var queue *string
+	if value := r.URL.Query().Get("queue"); value != "" {
+		queue = &value
+	}
+
+	letters, err := h.service.GetDeadLetters(r.Context(), queue, skip, take)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, letters)
+}
+
+// GetDeadLetter retrieves a dead-lettered message.
+// @Summary      Retrieve dead letter
+// @Description  Retrieves a message rejected by a consumer with its headers and payload.
+// @Tags         DeadLetter
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                 true  "Dead letter ID"
+// @Success      200  {object}  DeadLetterResponse  "Dead letter"
+// @Failure      404  {object}  error               "Dead letter not found"
+// @Router       /api/v1/admin/dlq/messages/{id} [get]
+// @Security 	 BearerAuth
+func (h *DeadLetterHandler) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	letter, err := h.service.GetDeadLetter(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, letter)
+}
+
+// RequeueDeadLetter replays a dead-lettered message.
+// @Summary      Requeue dead letter
+// @Description  Publishes the message again to the exchange and routing key it was originally published with, then removes it from the dead letters.
+// @Tags         DeadLetter
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Dead letter ID"
+// @Success      204  "Dead letter requeued successfully"
+// @Failure      404  {object}  error  "Dead letter not found"
+// @Router       /api/v1/admin/dlq/messages/{id}/requeue [post]
+// @Security 	 BearerAuth
+func (h *DeadLetterHandler) RequeueDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.RequeueDeadLetter(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PurgeDeadLetter deletes a dead-lettered message.
+// @Summary      Purge dead letter
+// @Description  Drops the message for good without replaying it.
+// @Tags         DeadLetter
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Dead letter ID"
+// @Success      204  "Dead letter purged successfully"
+// @Failure      404  {object}  error  "Dead letter not found"
+// @Router       /api/v1/admin/dlq/messages/{id} [delete]
+// @Security 	 BearerAuth
+func (h *DeadLetterHandler) PurgeDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.PurgeDeadLetter(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
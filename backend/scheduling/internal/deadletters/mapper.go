@@ -0,0 +1,79 @@
+package deadletters
+
+import (
+	"encoding/json"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func MapDeadLetterToEntity(letter *messaging.DeadLetter) (*entities.DeadLetter, error) {
+	headers, err := json.Marshal(letter.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.DeadLetter{
+		MessageId:      letter.MessageId,
+		CorrelationId:  optional(letter.CorrelationId),
+		MessageType:    optional(letter.Type),
+		Exchange:       letter.Exchange,
+		RoutingKey:     letter.RoutingKey,
+		Queue:          letter.Queue,
+		Reason:         letter.Reason,
+		DeathCount:     letter.DeathCount,
+		Headers:        headers,
+		Body:           letter.Body,
+		DeadLetteredAt: letter.DeadLetteredAt,
+	}, nil
+}
+
+func MapEntityToDeadLetter(letter *entities.DeadLetter) (*messaging.DeadLetter, error) {
+	headers, err := messaging.UnmarshalHeaders(letter.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &messaging.DeadLetter{
+		MessageId:      letter.MessageId,
+		Exchange:       letter.Exchange,
+		RoutingKey:     letter.RoutingKey,
+		Queue:          letter.Queue,
+		Reason:         letter.Reason,
+		DeathCount:     letter.DeathCount,
+		Headers:        headers,
+		Body:           letter.Body,
+		DeadLetteredAt: letter.DeadLetteredAt,
+	}
+	if letter.CorrelationId != nil {
+		result.CorrelationId = *letter.CorrelationId
+	}
+	if letter.MessageType != nil {
+		result.Type = *letter.MessageType
+	}
+	return result, nil
+}
+
+func MapDeadLetterToResponse(letter *entities.DeadLetter) *DeadLetterResponse {
+	return &DeadLetterResponse{
+		Id:             letter.Id,
+		MessageId:      letter.MessageId,
+		CorrelationId:  letter.CorrelationId,
+		Type:           letter.MessageType,
+		Exchange:       letter.Exchange,
+		RoutingKey:     letter.RoutingKey,
+		Queue:          letter.Queue,
+		Reason:         letter.Reason,
+		DeathCount:     letter.DeathCount,
+		Headers:        json.RawMessage(letter.Headers),
+		Body:           string(letter.Body),
+		DeadLetteredAt: letter.DeadLetteredAt,
+	}
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,19 @@
+package deadletters
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeDeadLetterService(log logger.Logger, db *sqlx.DB, requeuer Requeuer) *DeadLetterService {
+	repo := NewDeadLetterRepository(db)
+	return NewDeadLetterService(log, repo, requeuer)
+}
+
+func InitializeDeadLetterHTTPHandler(service *DeadLetterService) http.Handler {
+	handler := NewDeadLetterHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,67 @@
+package deadletters
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type DeadLetterRepo struct {
+	db *sqlx.DB
+}
+
+func NewDeadLetterRepository(db *sqlx.DB) *DeadLetterRepo {
+	return &DeadLetterRepo{db: db}
+}
+
+// AddDeadLetter stores a dead-lettered message
+func (r *DeadLetterRepo) AddDeadLetter(ctx context.Context, letter *entities.DeadLetter) error {
+	const query = `
+		INSERT INTO dead_letter (message_id, correlation_id, message_type, exchange, routing_key, queue, reason, death_count, headers, body, dead_lettered_at)
+		VALUES (:message_id, :correlation_id, :message_type, :exchange, :routing_key, :queue, :reason, :death_count, :headers, :body, :dead_lettered_at)
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, letter)
+}
+
+// GetDeadLetters retrieves dead letters newest first, of one consumer queue when a queue is given
+func (r *DeadLetterRepo) GetDeadLetters(ctx context.Context, queue *string, skip int, take int) ([]*entities.DeadLetter, error) {
+	const query = `
+		SELECT id, message_id, correlation_id, message_type, exchange, routing_key, queue, reason, death_count, headers, body, dead_lettered_at
+		FROM dead_letter
+		WHERE $1::varchar IS NULL OR queue = $1
+		ORDER BY dead_lettered_at DESC, id DESC OFFSET $2 LIMIT $3
+	`
+	return database.FetchMultiple[entities.DeadLetter](ctx, r.db, query, queue, skip, take)
+}
+
+// GetDeadLetterById retrieves a single dead letter
+func (r *DeadLetterRepo) GetDeadLetterById(ctx context.Context, id int64) (*entities.DeadLetter, error) {
+	const query = `
+		SELECT id, message_id, correlation_id, message_type, exchange, routing_key, queue, reason, death_count, headers, body, dead_lettered_at
+		FROM dead_letter
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.DeadLetter](ctx, r.db, query, id)
+}
+
+// DeleteDeadLetter removes a dead letter. It returns a not found error when no such dead letter exists.
+func (r *DeadLetterRepo) DeleteDeadLetter(ctx context.Context, id int64) error {
+	const query = `DELETE FROM dead_letter WHERE id = $1`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	if affected == 0 {
+		return apperrors.NewNotFound("Dead letter not found", apperrors.ErrResourceNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package deadletters
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *DeadLetterHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.AdminRole))
+	r.Get("/messages", handler.GetDeadLetters)
+	r.Get("/messages/{id}", handler.GetDeadLetter)
+	r.Post("/messages/{id}/requeue", handler.RequeueDeadLetter)
+	r.Delete("/messages/{id}", handler.PurgeDeadLetter)
+
+	return r
+}
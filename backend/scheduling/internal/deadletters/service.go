@@ -0,0 +1,120 @@
+package deadletters
+
+import (
+	"context"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+const maxPageSize = 100
+
+type DeadLetterRepository interface {
+	AddDeadLetter(ctx context.Context, letter *entities.DeadLetter) error
+	GetDeadLetters(ctx context.Context, queue *string, skip int, take int) ([]*entities.DeadLetter, error)
+	GetDeadLetterById(ctx context.Context, id int64) (*entities.DeadLetter, error)
+	DeleteDeadLetter(ctx context.Context, id int64) error
+}
+
+// Requeuer publishes a dead-lettered message again where it was originally published
+type Requeuer interface {
+	Requeue(ctx context.Context, letter *messaging.DeadLetter) error
+}
+
+// DeadLetterService keeps the messages consumers rejected, so operators can inspect them and either
+// replay them once the cause is fixed or purge them
+type DeadLetterService struct {
+	log      logger.Logger
+	repo     DeadLetterRepository
+	requeuer Requeuer
+}
+
+func NewDeadLetterService(log logger.Logger, repo DeadLetterRepository, requeuer Requeuer) *DeadLetterService {
+	return &DeadLetterService{log: log, repo: repo, requeuer: requeuer}
+}
+
+// StoreDeadLetter keeps a message received from the dead letter queue
+func (s *DeadLetterService) StoreDeadLetter(ctx context.Context, letter *messaging.DeadLetter) error {
+	entity, err := MapDeadLetterToEntity(letter)
+	if err != nil {
+		return err
+	}
+	return s.repo.AddDeadLetter(ctx, entity)
+}
+
+func (s *DeadLetterService) GetDeadLetters(ctx context.Context, queue *string, skip int, take int) ([]*DeadLetterResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if skip < 0 || take <= 0 || take > maxPageSize {
+		return nil, apperrors.NewBadRequestError("Invalid paging parameters", apperrors.ErrParameterParsingFailed)
+	}
+
+	letters, err := s.repo.GetDeadLetters(ctx, queue, skip, take)
+	if err != nil {
+		log.Error("failed to get dead letters", err)
+		return nil, err
+	}
+
+	result := make([]*DeadLetterResponse, 0, len(letters))
+	for _, letter := range letters {
+		result = append(result, MapDeadLetterToResponse(letter))
+	}
+	return result, nil
+}
+
+func (s *DeadLetterService) GetDeadLetter(ctx context.Context, id int64) (*DeadLetterResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	letter, err := s.repo.GetDeadLetterById(ctx, id)
+	if err != nil {
+		log.Error("failed to get dead letter", err)
+		return nil, err
+	}
+	return MapDeadLetterToResponse(letter), nil
+}
+
+// RequeueDeadLetter publishes a dead letter again and removes it once the broker confirmed it. Should the
+// removal fail the message may be requeued twice, consumers drop the second delivery by its message id.
+func (s *DeadLetterService) RequeueDeadLetter(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	entity, err := s.repo.GetDeadLetterById(ctx, id)
+	if err != nil {
+		log.Error("failed to get dead letter", err)
+		return err
+	}
+
+	letter, err := MapEntityToDeadLetter(entity)
+	if err != nil {
+		log.Error("failed to decode dead letter", err)
+		return apperrors.NewInternal(err)
+	}
+
+	if err := s.requeuer.Requeue(ctx, letter); err != nil {
+		log.Error("failed to requeue dead letter", err)
+		return apperrors.NewInternal(err)
+	}
+
+	if err := s.repo.DeleteDeadLetter(ctx, id); err != nil {
+		log.Error("failed to delete requeued dead letter", err)
+		return err
+	}
+
+	log.Infof("Dead letter %d requeued as message %s to %s/%s", id, letter.MessageId, letter.Exchange, letter.RoutingKey)
+	return nil
+}
+
+// PurgeDeadLetter drops a dead letter for good
+func (s *DeadLetterService) PurgeDeadLetter(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.repo.DeleteDeadLetter(ctx, id); err != nil {
+		log.Error("failed to delete dead letter", err)
+		return err
+	}
+
+	log.Infof("Dead letter %d purged", id)
+	return nil
+}
@@ -0,0 +1,50 @@
+package degraded
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Dependencies a request can be served without, reported in the Degraded response header
+const (
+	LearningService = "learning-service"
+	Broker          = "rabbitmq"
+)
+
+type trackerKey struct{}
+
+// Tracker collects the dependencies a request had to do without
+type Tracker struct {
+	mu           sync.Mutex
+	dependencies []string
+}
+
+// WithTracker returns a context in which Mark records degraded dependencies into the returned tracker
+func WithTracker(ctx context.Context) (context.Context, *Tracker) {
+	tracker := &Tracker{}
+	return context.WithValue(ctx, trackerKey{}, tracker), tracker
+}
+
+// Mark records that the request was served without the dependency. It does nothing outside of a tracked request,
+// such as in jobs and consumers.
+func Mark(ctx context.Context, dependency string) {
+	tracker, ok := ctx.Value(trackerKey{}).(*Tracker)
+	if !ok {
+		return
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	if !slices.Contains(tracker.dependencies, dependency) {
+		tracker.dependencies = append(tracker.dependencies, dependency)
+	}
+}
+
+// Header returns the degraded dependencies as a header value, empty when the request was served normally
+func (t *Tracker) Header() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return strings.Join(t.dependencies, ", ")
+}
@@ -0,0 +1,52 @@
+package delegation
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+)
+
+// swagger:model GrantRequest
+type GrantRequest struct {
+	Permissions []string `json:"permissions"`
+}
+
+// swagger:model GrantResponse
+type GrantResponse struct {
+	EducatorId  uuid.UUID `json:"educatorId"`
+	GranteeId   uuid.UUID `json:"granteeId"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func (g *GrantRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if len(g.Permissions) == 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Permissions",
+			Message: "must not be empty",
+		})
+	}
+
+	for _, p := range g.Permissions {
+		if !slices.Contains(auth.DelegatedPermissions, auth.Permission(p)) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "Permissions",
+				Message: "must contain only ManageSchedule or ViewBookings",
+			})
+			break
+		}
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Grant request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
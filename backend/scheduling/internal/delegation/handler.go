@@ -0,0 +1,124 @@
+package delegation
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type GrantHandler struct {
+	service *GrantService
+}
+
+func NewGrantHandler(service *GrantService) *GrantHandler {
+	return &GrantHandler{service: service}
+}
+
+// GetMyGrants retrieves the grants given by the current educator.
+// @Summary      Retrieve my grants
+// @Description  Retrieves the users the educator granted permissions to, newest first.
+// @Tags         Grant
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   GrantResponse  "Grants"
+// @Failure      401  {object}  error          "Unauthorized"
+// @Router       /api/v1/grants [get]
+// @Security 	 BearerAuth
+func (h *GrantHandler) GetMyGrants(w http.ResponseWriter, r *http.Request) {
+	grants, err := h.service.GetMyGrants(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, grants)
+}
+
+// GetReceivedGrants retrieves the grants received by the current user.
+// @Summary      Retrieve received grants
+// @Description  Retrieves the educators that granted the user permissions. Send the educator id in the X-Acting-Educator-Id header to act on their behalf.
+// @Tags         Grant
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   GrantResponse  "Grants"
+// @Failure      401  {object}  error          "Unauthorized"
+// @Router       /api/v1/grants/received [get]
+// @Security 	 BearerAuth
+func (h *GrantHandler) GetReceivedGrants(w http.ResponseWriter, r *http.Request) {
+	grants, err := h.service.GetReceivedGrants(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, grants)
+}
+
+// SetGrant grants permissions on the current educator's account to a user.
+// @Summary      Set grant
+// @Description  Creates or replaces the permissions of a user on the educator's account. ManageSchedule covers schedules, session types, locations and booking changes, ViewBookings covers bookings and attendance. Financial data is never shared.
+// @Tags         Grant
+// @Accept       json
+// @Produce      json
+// @Param        granteeId  path      string        true  "Grantee ID (UUID)"
+// @Param        grant      body      GrantRequest  true  "Granted permissions"
+// @Success      204        "Grant saved successfully"
+// @Failure      400        {object}  error         "Invalid input"
+// @Router       /api/v1/grants/{granteeId} [put]
+// @Security 	 BearerAuth
+func (h *GrantHandler) SetGrant(w http.ResponseWriter, r *http.Request) {
+	granteeId, err := api.ParseUUIDParam(w, r, "granteeId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *GrantRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.SetGrant(r.Context(), granteeId, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeGrant revokes the permissions of a user on the current educator's account.
+// @Summary      Revoke grant
+// @Description  Removes the grant, so the user can no longer act on behalf of the educator.
+// @Tags         Grant
+// @Accept       json
+// @Produce      json
+// @Param        granteeId  path      string  true  "Grantee ID (UUID)"
+// @Success      204        "Grant revoked successfully"
+// @Failure      404        {object}  error   "Grant not found"
+// @Router       /api/v1/grants/{granteeId} [delete]
+// @Security 	 BearerAuth
+func (h *GrantHandler) RevokeGrant(w http.ResponseWriter, r *http.Request) {
+	granteeId, err := api.ParseUUIDParam(w, r, "granteeId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.RevokeGrant(r.Context(), granteeId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
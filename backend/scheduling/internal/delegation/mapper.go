@@ -0,0 +1,38 @@
+package delegation
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToGrant(educatorId uuid.UUID, granteeId uuid.UUID, request *GrantRequest) *entities.ScheduleGrant {
+	now := time.Now().UTC()
+	permissions := slices.Clone(request.Permissions)
+	slices.Sort(permissions)
+
+	return &entities.ScheduleGrant{
+		EducatorId:  educatorId,
+		GranteeId:   granteeId,
+		Permissions: slices.Compact(permissions),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func MapGrantsToResponse(grants []*entities.ScheduleGrant) []*GrantResponse {
+	items := make([]*GrantResponse, 0, len(grants))
+	for _, g := range grants {
+		items = append(items, &GrantResponse{
+			EducatorId:  g.EducatorId,
+			GranteeId:   g.GranteeId,
+			Permissions: append([]string{}, g.Permissions...),
+			CreatedAt:   g.CreatedAt,
+			UpdatedAt:   g.UpdatedAt,
+		})
+	}
+	return items
+}
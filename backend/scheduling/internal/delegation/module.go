@@ -0,0 +1,20 @@
+package delegation
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeGrantService(log logger.Logger, db *sqlx.DB, organizations OrganizationAccessChecker) *GrantService {
+	repo := NewGrantRepository(db)
+	service := NewGrantService(log, repo, organizations)
+	return service
+}
+
+func InitializeGrantHTTPHandler(service *GrantService) http.Handler {
+	handler := NewGrantHandler(service)
+	return Routes(handler)
+}
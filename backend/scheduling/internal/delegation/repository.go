@@ -0,0 +1,84 @@
+package delegation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type GrantRepo struct {
+	db *sqlx.DB
+}
+
+func NewGrantRepository(db *sqlx.DB) *GrantRepo {
+	return &GrantRepo{db: db}
+}
+
+// GetGrant retrieves the grant an educator gave to a user
+func (r *GrantRepo) GetGrant(ctx context.Context, educatorId uuid.UUID, granteeId uuid.UUID) (*entities.ScheduleGrant, error) {
+	const query = `
+		SELECT educator_id, grantee_id, permissions, created_at, updated_at
+		FROM schedule_grant
+		WHERE educator_id = $1 AND grantee_id = $2
+	`
+	return database.FetchSingle[entities.ScheduleGrant](ctx, r.db, query, educatorId, granteeId)
+}
+
+// GetGivenGrants retrieves the grants an educator gave, newest first
+func (r *GrantRepo) GetGivenGrants(ctx context.Context, educatorId uuid.UUID) ([]*entities.ScheduleGrant, error) {
+	const query = `
+		SELECT educator_id, grantee_id, permissions, created_at, updated_at
+		FROM schedule_grant
+		WHERE educator_id = $1
+		ORDER BY created_at DESC
+	`
+	return database.FetchMultiple[entities.ScheduleGrant](ctx, r.db, query, educatorId)
+}
+
+// GetReceivedGrants retrieves the grants a user received, newest first
+func (r *GrantRepo) GetReceivedGrants(ctx context.Context, granteeId uuid.UUID) ([]*entities.ScheduleGrant, error) {
+	const query = `
+		SELECT educator_id, grantee_id, permissions, created_at, updated_at
+		FROM schedule_grant
+		WHERE grantee_id = $1
+		ORDER BY created_at DESC
+	`
+	return database.FetchMultiple[entities.ScheduleGrant](ctx, r.db, query, granteeId)
+}
+
+// UpsertGrant creates a grant or replaces the permissions of an existing one
+func (r *GrantRepo) UpsertGrant(ctx context.Context, grant *entities.ScheduleGrant) error {
+	const query = `
+		INSERT INTO schedule_grant (educator_id, grantee_id, permissions, created_at, updated_at)
+		VALUES (:educator_id, :grantee_id, :permissions, :created_at, :updated_at)
+		ON CONFLICT (educator_id, grantee_id) DO UPDATE
+		SET permissions = EXCLUDED.permissions, updated_at = EXCLUDED.updated_at
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, grant)
+}
+
+// DeleteGrant removes a grant, reporting a not found error when none exists
+func (r *GrantRepo) DeleteGrant(ctx context.Context, educatorId uuid.UUID, granteeId uuid.UUID) error {
+	const query = `
+		DELETE FROM schedule_grant
+		WHERE educator_id = $1 AND grantee_id = $2
+	`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, educatorId, granteeId)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	if affected == 0 {
+		return apperrors.NewNotFound("Grant not found", apperrors.ErrGrantInvalid)
+	}
+	return nil
+}
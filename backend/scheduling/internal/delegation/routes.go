@@ -0,0 +1,22 @@
+package delegation
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *GrantHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/received", handler.GetReceivedGrants)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Get("/", handler.GetMyGrants)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Put("/{granteeId}", handler.SetGrant)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Delete("/{granteeId}", handler.RevokeGrant)
+
+	return r
+}
@@ -0,0 +1,133 @@
+package delegation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type GrantRepository interface {
+	GetGrant(ctx context.Context, educatorId uuid.UUID, granteeId uuid.UUID) (*entities.ScheduleGrant, error)
+	GetGivenGrants(ctx context.Context, educatorId uuid.UUID) ([]*entities.ScheduleGrant, error)
+	GetReceivedGrants(ctx context.Context, granteeId uuid.UUID) ([]*entities.ScheduleGrant, error)
+	UpsertGrant(ctx context.Context, grant *entities.ScheduleGrant) error
+	DeleteGrant(ctx context.Context, educatorId uuid.UUID, granteeId uuid.UUID) error
+}
+
+// OrganizationAccessChecker reports whether a user administers an organization the educator teaches in
+type OrganizationAccessChecker interface {
+	CanActFor(ctx context.Context, adminId uuid.UUID, educatorId uuid.UUID) (bool, error)
+}
+
+type GrantService struct {
+	log           logger.Logger
+	repo          GrantRepository
+	organizations OrganizationAccessChecker
+}
+
+func NewGrantService(log logger.Logger, repo GrantRepository, organizations OrganizationAccessChecker) *GrantService {
+	return &GrantService{log: log, repo: repo, organizations: organizations}
+}
+
+// ActingPermissions resolves what a user may do on behalf of an educator. Organization admins get full
+// access, delegates get the permissions of their grant.
+func (s *GrantService) ActingPermissions(ctx context.Context, actorId uuid.UUID, educatorId uuid.UUID) ([]auth.Permission, error) {
+	isAdmin, err := s.organizations.CanActFor(ctx, actorId, educatorId)
+	if err != nil {
+		return nil, err
+	}
+	if isAdmin {
+		return []auth.Permission{auth.FullAccessPermission}, nil
+	}
+
+	grant, err := s.repo.GetGrant(ctx, educatorId, actorId)
+	if err != nil {
+		var notFound *apperrors.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	permissions := make([]auth.Permission, 0, len(grant.Permissions))
+	for _, p := range grant.Permissions {
+		permissions = append(permissions, auth.Permission(p))
+	}
+	return permissions, nil
+}
+
+func (s *GrantService) GetMyGrants(ctx context.Context) ([]*GrantResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	grants, err := s.repo.GetGivenGrants(ctx, userId)
+	if err != nil {
+		log.Error("failed to get grants", err)
+		return nil, err
+	}
+
+	return MapGrantsToResponse(grants), nil
+}
+
+func (s *GrantService) GetReceivedGrants(ctx context.Context) ([]*GrantResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetActorID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	grants, err := s.repo.GetReceivedGrants(ctx, userId)
+	if err != nil {
+		log.Error("failed to get received grants", err)
+		return nil, err
+	}
+
+	return MapGrantsToResponse(grants), nil
+}
+
+func (s *GrantService) SetGrant(ctx context.Context, granteeId uuid.UUID, request *GrantRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if granteeId == userId {
+		return apperrors.NewUnprocessedEntity("Permissions cannot be granted to yourself", apperrors.ErrGrantInvalid)
+	}
+
+	if err := s.repo.UpsertGrant(ctx, MapRequestToGrant(userId, granteeId, request)); err != nil {
+		log.Error("failed to save grant", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *GrantService) RevokeGrant(ctx context.Context, granteeId uuid.UUID) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if err := s.repo.DeleteGrant(ctx, userId, granteeId); err != nil {
+		log.Error("failed to revoke grant", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package documents
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icsTimeFormat  = "20060102T150405Z"
+	icsLineOctets  = 75
+	icsProductId   = "-//Ora//Scheduling//EN"
+	icsLineBreak   = "\r\n"
+	icsFoldedBreak = "\r\n "
+)
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// RenderCalendarFeed renders an iCalendar (RFC 5545) feed of the events, with all times in UTC
+func (r *Renderer) RenderCalendarFeed(data *CalendarFeed) []byte {
+	var sb strings.Builder
+
+	writeIcsLine(&sb, "BEGIN:VCALENDAR")
+	writeIcsLine(&sb, "VERSION:2.0")
+	writeIcsLine(&sb, "PRODID:"+icsProductId)
+	writeIcsLine(&sb, "CALSCALE:GREGORIAN")
+	writeIcsLine(&sb, "METHOD:PUBLISH")
+	writeIcsLine(&sb, "X-WR-CALNAME:"+icsEscaper.Replace(data.Name))
+
+	for _, event := range data.Events {
+		writeIcsLine(&sb, "BEGIN:VEVENT")
+		writeIcsLine(&sb, "UID:"+event.Uid)
+		writeIcsLine(&sb, "DTSTAMP:"+formatIcsTime(event.UpdatedAt))
+		writeIcsLine(&sb, "DTSTART:"+formatIcsTime(event.StartTime))
+		writeIcsLine(&sb, "DTEND:"+formatIcsTime(event.EndTime))
+		writeIcsLine(&sb, "SUMMARY:"+icsEscaper.Replace(event.Title))
+		if event.Description != "" {
+			writeIcsLine(&sb, "DESCRIPTION:"+icsEscaper.Replace(event.Description))
+		}
+		if event.Location != "" {
+			writeIcsLine(&sb, "LOCATION:"+icsEscaper.Replace(event.Location))
+		}
+		if event.Status != "" {
+			writeIcsLine(&sb, "STATUS:"+event.Status)
+		}
+		writeIcsLine(&sb, "END:VEVENT")
+	}
+
+	writeIcsLine(&sb, "END:VCALENDAR")
+	return []byte(sb.String())
+}
+
+func formatIcsTime(t time.Time) string {
+	return t.UTC().Format(icsTimeFormat)
+}
+
+// writeIcsLine writes a content line folded to at most 75 octets per line, never splitting a UTF-8 sequence
+func writeIcsLine(sb *strings.Builder, line string) {
+	limit := icsLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString(icsFoldedBreak)
+		line = line[cut:]
+		// Continuation lines start with a space that counts towards their length
+		limit = icsLineOctets - 1
+	}
+	sb.WriteString(line)
+	sb.WriteString(icsLineBreak)
+}
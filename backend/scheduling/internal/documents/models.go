@@ -0,0 +1,68 @@
+package documents
+
+import (
+	"time"
+)
+
+// WeeklySchedule is the data rendered into a weekly schedule sheet
+type WeeklySchedule struct {
+	UserId    string
+	WeekStart time.Time
+	WeekEnd   time.Time
+	Days      []*ScheduleDay
+}
+
+// ScheduleDay groups the schedule items of a single calendar day
+type ScheduleDay struct {
+	Date  time.Time
+	Items []*ScheduleItem
+}
+
+// ScheduleItem is a single working period, scheduled event or booking shown on the sheet
+type ScheduleItem struct {
+	Kind      string
+	Title     string
+	StartTime time.Time
+	EndTime   time.Time
+	Details   string
+}
+
+// BookingConfirmation is the data rendered into a booking confirmation document
+type BookingConfirmation struct {
+	BookingId  int64
+	Title      string
+	EducatorId string
+	StudentId  string
+	StartTime  time.Time
+	EndTime    time.Time
+	Status     string
+	Price      float64
+	QRPayload  string
+	IssuedAt   time.Time
+}
+
+// CalendarFeed is the data rendered into an iCalendar feed
+type CalendarFeed struct {
+	Name   string
+	Events []*CalendarEvent
+}
+
+// CalendarEvent is a single session of a calendar feed. Uid stays the same across renders, so
+// subscribed calendars update the event instead of adding a copy.
+type CalendarEvent struct {
+	Uid         string
+	Title       string
+	Description string
+	Location    string
+	Status      string
+	StartTime   time.Time
+	EndTime     time.Time
+	UpdatedAt   time.Time
+}
+
+// Statuses of calendar events
+const (
+	CalendarEventConfirmed = "CONFIRMED"
+	CalendarEventTentative = "TENTATIVE"
+	CalendarEventCancelled = "CANCELLED"
+)
@@ -0,0 +1,126 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+)
+
+const qrCodeSize = 256
+
+// Renderer produces PDF documents from the package templates and iCalendar feeds
+type Renderer struct {
+	templates *template.Template
+}
+
+func NewRenderer() *Renderer {
+	root := template.New("documents")
+	for name, source := range templateSources {
+		template.Must(root.New(name).Parse(source))
+	}
+	return &Renderer{templates: root}
+}
+
+// RenderWeeklySchedule renders a weekly schedule sheet with the items grouped by day
+func (r *Renderer) RenderWeeklySchedule(data *WeeklySchedule) ([]byte, error) {
+	pdf := newDocument()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	title, err := r.execute(weekTitleTemplate, data)
+	if err != nil {
+		return nil, err
+	}
+	writeTitle(pdf, tr(title))
+
+	for _, day := range data.Days {
+		heading, err := r.execute(weekDayTemplate, day)
+		if err != nil {
+			return nil, err
+		}
+
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, 8, tr(heading), "B", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+
+		if len(day.Items) == 0 {
+			pdf.CellFormat(0, 6, "No sessions", "", 1, "L", false, 0, "")
+		}
+		for _, item := range day.Items {
+			line, err := r.execute(weekItemTemplate, item)
+			if err != nil {
+				return nil, err
+			}
+			pdf.MultiCell(0, 6, tr(line), "", "L", false)
+		}
+		pdf.Ln(3)
+	}
+
+	return output(pdf)
+}
+
+// RenderBookingConfirmation renders a booking confirmation with a QR code of the check-in payload
+func (r *Renderer) RenderBookingConfirmation(data *BookingConfirmation) ([]byte, error) {
+	pdf := newDocument()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	title, err := r.execute(confirmTitle, data)
+	if err != nil {
+		return nil, err
+	}
+	writeTitle(pdf, tr(title))
+
+	body, err := r.execute(confirmBody, data)
+	if err != nil {
+		return nil, err
+	}
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.MultiCell(0, 7, tr(body), "", "L", false)
+
+	png, err := qrcode.Encode(data.QRPayload, qrcode.Medium, qrCodeSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode qr code: %w", err)
+	}
+
+	pdf.Ln(5)
+	options := fpdf.ImageOptions{ImageType: "PNG", ReadDpi: false}
+	pdf.RegisterImageOptionsReader("qr", options, bytes.NewReader(png))
+	pdf.ImageOptions("qr", pdf.GetX(), pdf.GetY(), 50, 50, true, options, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.CellFormat(0, 6, "Present this code at check-in", "", 1, "L", false, 0, "")
+
+	return output(pdf)
+}
+
+func (r *Renderer) execute(name string, data any) (string, error) {
+	var sb strings.Builder
+	if err := r.templates.ExecuteTemplate(&sb, name, data); err != nil {
+		return "", fmt.Errorf("failed to execute template %s: %w", name, err)
+	}
+	return sb.String(), nil
+}
+
+func newDocument() *fpdf.Fpdf {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+	return pdf
+}
+
+func writeTitle(pdf *fpdf.Fpdf, title string) {
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, title, "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+}
+
+func output(pdf *fpdf.Fpdf) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,25 @@
+package documents
+
+const (
+	weekTitleTemplate = "weekTitle"
+	weekDayTemplate   = "weekDay"
+	weekItemTemplate  = "weekItem"
+	confirmTitle      = "confirmTitle"
+	confirmBody       = "confirmBody"
+)
+
+// templateSources holds the text templates of every rendered document block
+var templateSources = map[string]string{
+	weekTitleTemplate: `Weekly schedule {{ .WeekStart.Format "02 Jan 2006" }} - {{ .WeekEnd.Format "02 Jan 2006" }}`,
+	weekDayTemplate:   `{{ .Date.Format "Monday, 02 Jan" }}`,
+	weekItemTemplate:  `{{ .StartTime.Format "15:04" }}-{{ .EndTime.Format "15:04" }}  {{ .Kind }}{{ if .Title }}: {{ .Title }}{{ end }}{{ if .Details }} ({{ .Details }}){{ end }}`,
+	confirmTitle:      `Booking confirmation #{{ .BookingId }}`,
+	confirmBody: `Session: {{ if .Title }}{{ .Title }}{{ else }}Individual session{{ end }}
+Date: {{ .StartTime.Format "Monday, 02 January 2006" }}
+Time: {{ .StartTime.Format "15:04" }} - {{ .EndTime.Format "15:04 MST" }}
+Status: {{ .Status }}
+Price: {{ printf "%.2f" .Price }}
+Educator: {{ .EducatorId }}
+Student: {{ .StudentId }}
+Issued: {{ .IssuedAt.Format "02 Jan 2006 15:04 MST" }}`,
+}
@@ -0,0 +1,91 @@
+package escalations
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+)
+
+const (
+	// UnconfirmedCondition matches bookings still pending confirmation
+	UnconfirmedCondition = "UNCONFIRMED"
+	// UnreadMessagesCondition matches bookings with student messages the educator has not read
+	UnreadMessagesCondition = "UNREAD_MESSAGES"
+
+	maxMinutesBeforeStart = 7 * 24 * 60
+)
+
+var supportedConditions = []string{UnconfirmedCondition, UnreadMessagesCondition}
+
+// swagger:model EscalationRuleRequest
+type EscalationRuleRequest struct {
+	Name               string   `json:"name"`
+	Condition          string   `json:"condition"`
+	MinutesBeforeStart int      `json:"minutesBeforeStart"`
+	Channels           []string `json:"channels"`
+	FlagDashboard      bool     `json:"flagDashboard"`
+	Enabled            bool     `json:"enabled"`
+}
+
+// swagger:model EscalationRuleResponse
+type EscalationRuleResponse struct {
+	Id                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	Condition          string    `json:"condition"`
+	MinutesBeforeStart int       `json:"minutesBeforeStart"`
+	Channels           []string  `json:"channels"`
+	FlagDashboard      bool      `json:"flagDashboard"`
+	Enabled            bool      `json:"enabled"`
+	UpdatedAt          time.Time `json:"updatedAt"`
+}
+
+func (e *EscalationRuleRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if strings.TrimSpace(e.Name) == "" || len(e.Name) > 100 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Name",
+			Message: "must be between 1 and 100 characters",
+		})
+	}
+
+	if !slices.Contains(supportedConditions, e.Condition) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Condition",
+			Message: "must be UNCONFIRMED or UNREAD_MESSAGES",
+		})
+	}
+
+	if e.MinutesBeforeStart <= 0 || e.MinutesBeforeStart > maxMinutesBeforeStart {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "MinutesBeforeStart",
+			Message: "must be between 1 minute and 7 days",
+		})
+	}
+
+	for _, c := range e.Channels {
+		if !slices.Contains(notifications.SupportedChannels, c) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "Channels",
+				Message: "must contain only email, push or sms",
+			})
+			break
+		}
+	}
+
+	if len(e.Channels) == 0 && !e.FlagDashboard {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Channels",
+			Message: "must not be empty when FlagDashboard is not set",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Escalation rule request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package escalations
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type EscalationHandler struct {
+	service *EscalationService
+}
+
+func NewEscalationHandler(service *EscalationService) *EscalationHandler {
+	return &EscalationHandler{service: service}
+}
+
+// GetMyRules retrieves the escalation rules of the current educator.
+// @Summary      Retrieve escalation rules
+// @Description  Retrieves the educator's escalation rules, earliest window first.
+// @Tags         Escalation
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   EscalationRuleResponse  "Escalation rules"
+// @Failure      401  {object}  error                   "Unauthorized"
+// @Router       /api/v1/escalation-rules [get]
+// @Security 	 BearerAuth
+func (h *EscalationHandler) GetMyRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.GetMyRules(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, rules)
+}
+
+// AddRule creates an escalation rule.
+// @Summary      Add escalation rule
+// @Description  Creates a rule escalating upcoming bookings that match the condition within 'minutesBeforeStart' of their start, by notifying the educator on the given channels and/or flagging the booking in the dashboard.
+// @Tags         Escalation
+// @Accept       json
+// @Produce      json
+// @Param        rule  body      EscalationRuleRequest   true  "Escalation rule"
+// @Success      201   {object}  EscalationRuleResponse  "Created escalation rule"
+// @Failure      400   {object}  error                   "Invalid input"
+// @Router       /api/v1/escalation-rules [post]
+// @Security 	 BearerAuth
+func (h *EscalationHandler) AddRule(w http.ResponseWriter, r *http.Request) {
+	var request *EscalationRuleRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	rule, err := h.service.AddRule(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, rule)
+}
+
+// UpdateRule updates an escalation rule.
+// @Summary      Update escalation rule
+// @Description  Updates an escalation rule owned by the educator. Bookings it already escalated are not escalated again.
+// @Tags         Escalation
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                    true  "Escalation rule ID"
+// @Param        rule  body      EscalationRuleRequest  true  "Escalation rule"
+// @Success      204   "Escalation rule updated successfully"
+// @Failure      400   {object}  error                  "Invalid input"
+// @Failure      404   {object}  error                  "Escalation rule not found"
+// @Router       /api/v1/escalation-rules/{id} [put]
+// @Security 	 BearerAuth
+func (h *EscalationHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *EscalationRuleRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.UpdateRule(r.Context(), id, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteRule deletes an escalation rule.
+// @Summary      Delete escalation rule
+// @Description  Deletes an escalation rule owned by the educator together with the dashboard flags it raised.
+// @Tags         Escalation
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Escalation rule ID"
+// @Success      204  "Escalation rule deleted successfully"
+// @Failure      404  {object}  error  "Escalation rule not found"
+// @Router       /api/v1/escalation-rules/{id} [delete]
+// @Security 	 BearerAuth
+func (h *EscalationHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.DeleteRule(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
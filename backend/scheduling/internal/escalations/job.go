@@ -0,0 +1,102 @@
+package escalations
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+)
+
+type EscalationJobRepository interface {
+	GetDueEscalations(ctx context.Context, now time.Time, limit int) ([]*DueEscalation, error)
+	RecordEscalation(ctx context.Context, escalation *entities.BookingEscalation) (bool, error)
+}
+
+// Notifier sends user notifications on the given channels, or on the user's preferred ones when none are given
+type Notifier interface {
+	NotifyVia(ctx context.Context, userId uuid.UUID, notificationType string, channels []string, data map[string]string) error
+}
+
+// EscalationJob periodically applies educators' escalation rules to their upcoming bookings
+type EscalationJob struct {
+	log      logger.Logger
+	repo     EscalationJobRepository
+	notifier Notifier
+	cfg      *config.EscalationConfig
+}
+
+func NewEscalationJob(log logger.Logger, repo EscalationJobRepository, notifier Notifier, cfg *config.EscalationConfig) *EscalationJob {
+	return &EscalationJob{log: log, repo: repo, notifier: notifier, cfg: cfg}
+}
+
+// Run applies escalation rules on every interval until the context is cancelled
+func (j *EscalationJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.ApplyEscalations(ctx); err != nil {
+				j.log.Errorf("Failed to apply escalation rules: %v", err)
+			}
+		}
+	}
+}
+
+// ApplyEscalations escalates one batch of bookings matching a rule. Each rule escalates a booking at most
+// once: the escalation is recorded before the educator is notified, so a failed notification is not retried.
+func (j *EscalationJob) ApplyEscalations(ctx context.Context) error {
+	log := logger.FromContext(ctx, j.log)
+
+	now := time.Now().UTC()
+
+	due, err := j.repo.GetDueEscalations(ctx, now, j.cfg.BatchSize)
+	if err != nil {
+		log.Error("failed to get due escalations", err)
+		return err
+	}
+
+	for _, d := range due {
+		recorded, err := j.repo.RecordEscalation(ctx, &entities.BookingEscalation{
+			RuleId:      d.RuleId,
+			BookingId:   d.BookingId,
+			Flagged:     d.FlagDashboard,
+			TriggeredAt: now,
+		})
+		if err != nil {
+			log.Error("failed to record escalation", err)
+			return err
+		}
+		if recorded && len(d.Channels) > 0 {
+			j.notifyEducator(ctx, d)
+		}
+	}
+
+	return nil
+}
+
+// notifyEducator sends the escalation on the rule's channels; failures are logged and do not stop the job
+func (j *EscalationJob) notifyEducator(ctx context.Context, due *DueEscalation) {
+	log := logger.FromContext(ctx, j.log)
+
+	data := map[string]string{
+		"bookingId": strconv.FormatInt(due.BookingId, 10),
+		"title":     due.Title,
+		"startTime": due.StartTime.UTC().Format(time.RFC3339),
+		"rule":      due.RuleName,
+		"condition": due.Condition,
+	}
+
+	if err := j.notifier.NotifyVia(ctx, due.EducatorId, notifications.BookingEscalatedNotification, due.Channels, data); err != nil {
+		log.Errorf("Failed to notify educator about escalated booking %d: %v", due.BookingId, err)
+	}
+}
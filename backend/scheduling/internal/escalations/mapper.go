@@ -0,0 +1,59 @@
+package escalations
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToRule(educatorId uuid.UUID, r *EscalationRuleRequest) *entities.EscalationRule {
+	rule := &entities.EscalationRule{
+		EducatorId: educatorId,
+		CreatedAt:  time.Now().UTC(),
+	}
+	MapRequestWithRule(r, rule)
+	return rule
+}
+
+func MapRequestWithRule(r *EscalationRuleRequest, rule *entities.EscalationRule) {
+	channels := append([]string{}, r.Channels...)
+	slices.Sort(channels)
+
+	rule.Name = strings.TrimSpace(r.Name)
+	rule.Condition = r.Condition
+	rule.MinutesBeforeStart = r.MinutesBeforeStart
+	rule.Channels = slices.Compact(channels)
+	rule.FlagDashboard = r.FlagDashboard
+	rule.Enabled = r.Enabled
+	rule.UpdatedAt = time.Now().UTC()
+}
+
+func MapRuleToResponse(r *entities.EscalationRule) *EscalationRuleResponse {
+	channels := []string(r.Channels)
+	if channels == nil {
+		channels = []string{}
+	}
+
+	return &EscalationRuleResponse{
+		Id:                 r.Id,
+		Name:               r.Name,
+		Condition:          r.Condition,
+		MinutesBeforeStart: r.MinutesBeforeStart,
+		Channels:           channels,
+		FlagDashboard:      r.FlagDashboard,
+		Enabled:            r.Enabled,
+		UpdatedAt:          r.UpdatedAt,
+	}
+}
+
+func MapRulesToResponse(rs []*entities.EscalationRule) []*EscalationRuleResponse {
+	response := make([]*EscalationRuleResponse, len(rs))
+	for i, r := range rs {
+		response[i] = MapRuleToResponse(r)
+	}
+	return response
+}
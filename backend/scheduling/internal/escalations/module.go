@@ -0,0 +1,26 @@
+package escalations
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeEscalationService(log logger.Logger, db *sqlx.DB) *EscalationService {
+	repo := NewEscalationRepository(db)
+	service := NewEscalationService(log, repo)
+	return service
+}
+
+func InitializeEscalationJob(log logger.Logger, db *sqlx.DB, cfg *config.EscalationConfig, notifier Notifier) *EscalationJob {
+	repo := NewEscalationRepository(db)
+	return NewEscalationJob(log, repo, notifier, cfg)
+}
+
+func InitializeEscalationHTTPHandler(service *EscalationService) http.Handler {
+	handler := NewEscalationHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,128 @@
+package escalations
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// DueEscalation holds a booking that currently matches an enabled escalation rule not yet applied to it
+type DueEscalation struct {
+	RuleId        int64          `db:"rule_id"`
+	RuleName      string         `db:"rule_name"`
+	Condition     string         `db:"condition"`
+	Channels      pq.StringArray `db:"channels"`
+	FlagDashboard bool           `db:"flag_dashboard"`
+	BookingId     int64          `db:"booking_id"`
+	EducatorId    uuid.UUID      `db:"educator_id"`
+	Title         string         `db:"title"`
+	StartTime     time.Time      `db:"start_time"`
+}
+
+type EscalationRepo struct {
+	db *sqlx.DB
+}
+
+func NewEscalationRepository(db *sqlx.DB) *EscalationRepo {
+	return &EscalationRepo{db: db}
+}
+
+// GetEducatorRules retrieves escalation rules of an educator
+func (r *EscalationRepo) GetEducatorRules(ctx context.Context, educatorId uuid.UUID) ([]*entities.EscalationRule, error) {
+	const query = `
+		SELECT id, educator_id, name, condition, minutes_before_start, channels, flag_dashboard, enabled, created_at, updated_at
+		FROM escalation_rule
+		WHERE educator_id = $1
+		ORDER BY minutes_before_start DESC, id
+	`
+	return database.FetchMultiple[entities.EscalationRule](ctx, r.db, query, educatorId)
+}
+
+// GetRuleById retrieves an escalation rule by its Id
+func (r *EscalationRepo) GetRuleById(ctx context.Context, id int64) (*entities.EscalationRule, error) {
+	const query = `
+		SELECT id, educator_id, name, condition, minutes_before_start, channels, flag_dashboard, enabled, created_at, updated_at
+		FROM escalation_rule
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.EscalationRule](ctx, r.db, query, id)
+}
+
+// AddRule adds a new escalation rule and returns its Id
+func (r *EscalationRepo) AddRule(ctx context.Context, rule *entities.EscalationRule) (int64, error) {
+	const query = `
+		INSERT INTO escalation_rule (educator_id, name, condition, minutes_before_start, channels, flag_dashboard, enabled, created_at, updated_at)
+		VALUES (:educator_id, :name, :condition, :minutes_before_start, :channels, :flag_dashboard, :enabled, :created_at, :updated_at)
+		RETURNING id
+	`
+	return database.ExecNamedQueryWithResult[int64](ctx, r.db, query, rule)
+}
+
+// UpdateRule updates an existing escalation rule
+func (r *EscalationRepo) UpdateRule(ctx context.Context, rule *entities.EscalationRule) error {
+	const query = `
+		UPDATE escalation_rule
+		SET name = :name, condition = :condition, minutes_before_start = :minutes_before_start, channels = :channels,
+			flag_dashboard = :flag_dashboard, enabled = :enabled, updated_at = :updated_at
+		WHERE id = :id AND educator_id = :educator_id
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, rule)
+}
+
+// DeleteRule deletes an escalation rule together with the escalations it raised
+func (r *EscalationRepo) DeleteRule(ctx context.Context, educatorId uuid.UUID, id int64) error {
+	const query = `DELETE FROM escalation_rule WHERE id = $1 AND educator_id = $2`
+	return database.ExecQuery(ctx, r.db, query, id, educatorId)
+}
+
+// GetDueEscalations retrieves upcoming bookings inside the window of an enabled rule of their educator whose
+// condition holds and that the rule has not escalated yet, soonest first
+func (r *EscalationRepo) GetDueEscalations(ctx context.Context, now time.Time, limit int) ([]*DueEscalation, error) {
+	const query = `
+		SELECT er.id AS rule_id, er.name AS rule_name, er.condition, er.channels, er.flag_dashboard,
+			b.id AS booking_id, b.educator_id, COALESCE(b.title, '') AS title, b.start_time
+		FROM escalation_rule er
+		JOIN booking b ON b.educator_id = er.educator_id
+		WHERE er.enabled
+			AND b.start_time > $1 AND b.start_time <= $1 + make_interval(mins => er.minutes_before_start)
+			AND NOT EXISTS (SELECT 1 FROM booking_escalation be WHERE be.rule_id = er.id AND be.booking_id = b.id)
+			AND (
+				(er.condition = $2 AND b.status = $3)
+				OR (er.condition = $4 AND b.status <> $5 AND EXISTS (
+					SELECT 1 FROM booking_message m
+					WHERE m.booking_id = b.id AND m.sender_id <> b.educator_id
+						AND m.id > COALESCE((SELECT t.last_read_id FROM booking_thread_read t WHERE t.booking_id = b.id AND t.user_id = b.educator_id), 0)
+				))
+			)
+		ORDER BY b.start_time
+		LIMIT $6
+	`
+	return database.FetchMultiple[DueEscalation](ctx, r.db, query,
+		now, UnconfirmedCondition, entities.Pending, UnreadMessagesCondition, entities.Cancelled, limit)
+}
+
+// RecordEscalation stores that a rule escalated a booking; false is returned when it already had
+func (r *EscalationRepo) RecordEscalation(ctx context.Context, escalation *entities.BookingEscalation) (bool, error) {
+	const query = `
+		INSERT INTO booking_escalation (rule_id, booking_id, flagged, triggered_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (rule_id, booking_id) DO NOTHING
+	`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, escalation.RuleId, escalation.BookingId, escalation.Flagged, escalation.TriggeredAt)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
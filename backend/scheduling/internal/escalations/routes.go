@@ -0,0 +1,23 @@
+package escalations
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *EscalationHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.EducatorRole))
+	r.Get("/", handler.GetMyRules)
+	r.Post("/", handler.AddRule)
+	r.Put("/{id}", handler.UpdateRule)
+	r.Delete("/{id}", handler.DeleteRule)
+
+	return r
+}
@@ -0,0 +1,119 @@
+package escalations
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type EscalationRepository interface {
+	GetEducatorRules(ctx context.Context, educatorId uuid.UUID) ([]*entities.EscalationRule, error)
+	GetRuleById(ctx context.Context, id int64) (*entities.EscalationRule, error)
+	AddRule(ctx context.Context, rule *entities.EscalationRule) (int64, error)
+	UpdateRule(ctx context.Context, rule *entities.EscalationRule) error
+	DeleteRule(ctx context.Context, educatorId uuid.UUID, id int64) error
+}
+
+type EscalationService struct {
+	log  logger.Logger
+	repo EscalationRepository
+}
+
+func NewEscalationService(log logger.Logger, repo EscalationRepository) *EscalationService {
+	return &EscalationService{log: log, repo: repo}
+}
+
+func (s *EscalationService) GetMyRules(ctx context.Context) ([]*EscalationRuleResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	rules, err := s.repo.GetEducatorRules(ctx, userId)
+	if err != nil {
+		log.Error("failed to get escalation rules", err)
+		return nil, err
+	}
+
+	return MapRulesToResponse(rules), nil
+}
+
+func (s *EscalationService) AddRule(ctx context.Context, request *EscalationRuleRequest) (*EscalationRuleResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	rule := MapRequestToRule(userId, request)
+	rule.Id, err = s.repo.AddRule(ctx, rule)
+	if err != nil {
+		log.Error("failed to add escalation rule", err)
+		return nil, err
+	}
+
+	return MapRuleToResponse(rule), nil
+}
+
+func (s *EscalationService) UpdateRule(ctx context.Context, id int64, request *EscalationRuleRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	rule, err := s.getOwnRule(ctx, id)
+	if err != nil {
+		log.Error("failed to get escalation rule", err)
+		return err
+	}
+
+	MapRequestWithRule(request, rule)
+
+	if err := s.repo.UpdateRule(ctx, rule); err != nil {
+		log.Error("failed to update escalation rule", err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteRule removes an escalation rule; dashboard flags it raised are removed with it
+func (s *EscalationService) DeleteRule(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	rule, err := s.getOwnRule(ctx, id)
+	if err != nil {
+		log.Error("failed to get escalation rule", err)
+		return err
+	}
+
+	if err := s.repo.DeleteRule(ctx, rule.EducatorId, rule.Id); err != nil {
+		log.Error("failed to delete escalation rule", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *EscalationService) getOwnRule(ctx context.Context, id int64) (*entities.EscalationRule, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	rule, err := s.repo.GetRuleById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.EducatorId != userId {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	return rule, nil
+}
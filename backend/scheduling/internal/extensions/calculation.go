@@ -0,0 +1,31 @@
+package extensions
+
+import (
+	"math"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// extensionPrice returns the price of extending a booking, pro rata of its current price per minute unless
+// the educator set one explicitly
+func extensionPrice(booking *entities.Booking, minutes int, price *float64) float64 {
+	if price != nil {
+		return roundAmount(*price)
+	}
+
+	duration := booking.EndTime.Sub(booking.StartTime).Minutes()
+	if duration <= 0 {
+		return 0
+	}
+	return roundAmount(booking.Price / duration * float64(minutes))
+}
+
+// isRunning reports whether an approved booking has started and not ended yet
+func isRunning(booking *entities.Booking, now time.Time) bool {
+	return booking.Status == entities.Approved && !now.Before(booking.StartTime) && now.Before(booking.EndTime)
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,53 @@
+package extensions
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+const (
+	minExtensionMinutes = 5
+	maxExtensionMinutes = 120
+)
+
+// swagger:model ExtensionRequest
+type ExtensionRequest struct {
+	Minutes int      `json:"minutes"`
+	Price   *float64 `json:"price"`
+}
+
+// swagger:model ExtensionResponse
+type ExtensionResponse struct {
+	Id        int64     `json:"id"`
+	BookingId int64     `json:"bookingId"`
+	Minutes   int       `json:"minutes"`
+	Price     float64   `json:"price"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (e *ExtensionRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if e.Minutes < minExtensionMinutes || e.Minutes > maxExtensionMinutes {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Minutes",
+			Message: "must be between 5 and 120",
+		})
+	}
+
+	if e.Price != nil && *e.Price < 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Price",
+			Message: "must not be negative",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Extension request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
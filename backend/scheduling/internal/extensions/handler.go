@@ -0,0 +1,179 @@
+package extensions
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type ExtensionHandler struct {
+	service *ExtensionService
+}
+
+func NewExtensionHandler(service *ExtensionService) *ExtensionHandler {
+	return &ExtensionHandler{service: service}
+}
+
+// GetBookingExtensions retrieves the extensions of a booking.
+// @Summary      Retrieve booking extensions
+// @Description  Retrieves extensions applied to or offered for the booking, oldest first. Available to the booking's student and educator.
+// @Tags         Extension
+// @Accept       json
+// @Produce      json
+// @Param        bookingId  path      int  true  "Booking ID"
+// @Success      200        {array}   ExtensionResponse  "Extensions"
+// @Failure      403        {object}  error              "Access denied"
+// @Router       /api/v1/extensions/bookings/{bookingId} [get]
+// @Security 	 BearerAuth
+func (h *ExtensionHandler) GetBookingExtensions(w http.ResponseWriter, r *http.Request) {
+	bookingId, err := api.ParseLongParam(w, r, "bookingId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	extensions, err := h.service.GetBookingExtensions(r.Context(), bookingId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, extensions)
+}
+
+// ExtendBooking extends a running session.
+// @Summary      Extend session
+// @Description  Extends the educator's running session into the free time right after it. Without a price the extension is charged pro rata of the booking price; the charge is published as a price adjustment.
+// @Tags         Extension
+// @Accept       json
+// @Produce      json
+// @Param        bookingId  path      int                true  "Booking ID"
+// @Param        extension  body      ExtensionRequest   true  "Extension"
+// @Success      201        {object}  ExtensionResponse  "Applied extension"
+// @Failure      422        {object}  error              "Session cannot be extended"
+// @Router       /api/v1/extensions/bookings/{bookingId} [post]
+// @Security 	 BearerAuth
+func (h *ExtensionHandler) ExtendBooking(w http.ResponseWriter, r *http.Request) {
+	bookingId, err := api.ParseLongParam(w, r, "bookingId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *ExtensionRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	extension, err := h.service.ExtendBooking(r.Context(), bookingId, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, extension)
+}
+
+// OfferExtension offers the student an extension purchase.
+// @Summary      Offer extension
+// @Description  Offers the student of the educator's running session to purchase an extension. It is applied once the student accepts, if the time after the session is still free.
+// @Tags         Extension
+// @Accept       json
+// @Produce      json
+// @Param        bookingId  path      int                true  "Booking ID"
+// @Param        extension  body      ExtensionRequest   true  "Extension"
+// @Success      201        {object}  ExtensionResponse  "Offered extension"
+// @Failure      422        {object}  error              "Session cannot be extended"
+// @Router       /api/v1/extensions/bookings/{bookingId}/offers [post]
+// @Security 	 BearerAuth
+func (h *ExtensionHandler) OfferExtension(w http.ResponseWriter, r *http.Request) {
+	bookingId, err := api.ParseLongParam(w, r, "bookingId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *ExtensionRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	extension, err := h.service.OfferExtension(r.Context(), bookingId, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, extension)
+}
+
+// AcceptExtension accepts an extension offer.
+// @Summary      Accept extension
+// @Description  Applies an extension offered to the student of the booking and publishes its price adjustment.
+// @Tags         Extension
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                true  "Extension ID"
+// @Success      200  {object}  ExtensionResponse  "Applied extension"
+// @Failure      409  {object}  error              "Extension is no longer offered"
+// @Failure      422  {object}  error              "Session cannot be extended"
+// @Router       /api/v1/extensions/{id}/accept [post]
+// @Security 	 BearerAuth
+func (h *ExtensionHandler) AcceptExtension(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	extension, err := h.service.AcceptExtension(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, extension)
+}
+
+// DeclineExtension declines an extension offer.
+// @Summary      Decline extension
+// @Description  Declines an extension offered to the student of the booking.
+// @Tags         Extension
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Extension ID"
+// @Success      204  "Extension declined successfully"
+// @Failure      409  {object}  error  "Extension is no longer offered"
+// @Router       /api/v1/extensions/{id}/decline [post]
+// @Security 	 BearerAuth
+func (h *ExtensionHandler) DeclineExtension(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.DeclineExtension(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,42 @@
+package extensions
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToExtension(booking *entities.Booking, createdBy uuid.UUID, status entities.ExtensionStatus, r *ExtensionRequest) *entities.BookingExtension {
+	now := time.Now().UTC()
+	return &entities.BookingExtension{
+		BookingId: booking.Id,
+		Minutes:   r.Minutes,
+		Price:     extensionPrice(booking, r.Minutes, r.Price),
+		Status:    status,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func MapExtensionToResponse(e *entities.BookingExtension) *ExtensionResponse {
+	return &ExtensionResponse{
+		Id:        e.Id,
+		BookingId: e.BookingId,
+		Minutes:   e.Minutes,
+		Price:     e.Price,
+		Status:    e.Status.String(),
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+}
+
+func MapExtensionsToResponse(es []*entities.BookingExtension) []*ExtensionResponse {
+	response := make([]*ExtensionResponse, len(es))
+	for i, e := range es {
+		response[i] = MapExtensionToResponse(e)
+	}
+	return response
+}
@@ -0,0 +1,21 @@
+package extensions
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializeExtensionService(log logger.Logger, db *sqlx.DB, publisher *messaging.Publisher, notifier Notifier) *ExtensionService {
+	repo := NewExtensionRepository(db)
+	service := NewExtensionService(log, repo, publisher, notifier)
+	return service
+}
+
+func InitializeExtensionHTTPHandler(service *ExtensionService) http.Handler {
+	handler := NewExtensionHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,172 @@
+package extensions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+const bookingColumns = `id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at`
+
+type ExtensionRepo struct {
+	db *sqlx.DB
+}
+
+func NewExtensionRepository(db *sqlx.DB) *ExtensionRepo {
+	return &ExtensionRepo{db: db}
+}
+
+// GetBookingById retrieves the booking an extension applies to
+func (r *ExtensionRepo) GetBookingById(ctx context.Context, id int64) (*entities.Booking, error) {
+	const query = `SELECT ` + bookingColumns + ` FROM booking WHERE id = $1`
+	return database.FetchSingle[entities.Booking](ctx, r.db, query, id)
+}
+
+// GetBookingExtensions retrieves the extensions of a booking, oldest first
+func (r *ExtensionRepo) GetBookingExtensions(ctx context.Context, bookingId int64) ([]*entities.BookingExtension, error) {
+	const query = `
+		SELECT id, booking_id, minutes, price, status, created_by, created_at, updated_at
+		FROM booking_extension
+		WHERE booking_id = $1
+		ORDER BY id
+	`
+	return database.FetchMultiple[entities.BookingExtension](ctx, r.db, query, bookingId)
+}
+
+// GetExtensionById retrieves an extension by its Id
+func (r *ExtensionRepo) GetExtensionById(ctx context.Context, id int64) (*entities.BookingExtension, error) {
+	const query = `
+		SELECT id, booking_id, minutes, price, status, created_by, created_at, updated_at
+		FROM booking_extension
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.BookingExtension](ctx, r.db, query, id)
+}
+
+// AddExtension adds a new extension and returns its Id
+func (r *ExtensionRepo) AddExtension(ctx context.Context, extension *entities.BookingExtension) (int64, error) {
+	const query = `
+		INSERT INTO booking_extension (booking_id, minutes, price, status, created_by, created_at, updated_at)
+		VALUES (:booking_id, :minutes, :price, :status, :created_by, :created_at, :updated_at)
+		RETURNING id
+	`
+	return database.ExecNamedQueryWithResult[int64](ctx, r.db, query, extension)
+}
+
+// DeclineExtension marks an offered extension as declined; false is returned when it was no longer offered
+func (r *ExtensionRepo) DeclineExtension(ctx context.Context, id int64, now time.Time) (bool, error) {
+	const query = `UPDATE booking_extension SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, entities.ExtensionDeclined, now, id, entities.ExtensionOffered)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
+
+// ApplyExtension moves the end of a running booking by the extension and adds its price. The booking is
+// locked while the time right after it is checked against the working period, other bookings and events
+// of the educator and other bookings of the student. An offered extension is marked applied, a new one
+// is stored as applied. The updated booking is returned.
+func (r *ExtensionRepo) ApplyExtension(ctx context.Context, extension *entities.BookingExtension, now time.Time) (*entities.Booking, error) {
+	const lockBookingQuery = `SELECT ` + bookingColumns + ` FROM booking WHERE id = $1 FOR UPDATE`
+	const periodEndQuery = `SELECT end_time FROM working_period WHERE id = $1`
+	const conflictQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM booking
+			WHERE id <> $1 AND status <> $2 AND (educator_id = $3 OR student_id = $4) AND start_time < $6 AND end_time > $5
+		) OR EXISTS (
+			SELECT 1 FROM scheduled_event
+			WHERE user_id = $3 AND start_time < $6 AND end_time > $5 AND deleted_at IS NULL
+		)
+	`
+	const extendBookingQuery = `UPDATE booking SET end_time = $2, price = $3, version = version + 1, updated_at = $4 WHERE id = $1`
+	const applyOfferQuery = `UPDATE booking_extension SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`
+	const addExtensionQuery = `
+		INSERT INTO booking_extension (booking_id, minutes, price, status, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		RETURNING id
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	var booking entities.Booking
+	if err := tx.GetContext(ctx, &booking, lockBookingQuery, extension.BookingId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Booking not found", apperrors.ErrResourceNotFound)
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if !isRunning(&booking, now) {
+		return nil, apperrors.NewUnprocessedEntity("Only a running session can be extended", apperrors.ErrExtensionUnavailable)
+	}
+
+	newEnd := booking.EndTime.Add(time.Duration(extension.Minutes) * time.Minute)
+
+	var periodEnd time.Time
+	if err := tx.GetContext(ctx, &periodEnd, periodEndQuery, booking.WorkingPeriodId); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if newEnd.After(periodEnd) {
+		return nil, apperrors.NewUnprocessedEntity("Extension exceeds the working period", apperrors.ErrExtensionUnavailable)
+	}
+
+	var conflict bool
+	err = tx.GetContext(ctx, &conflict, conflictQuery, booking.Id, entities.Cancelled, booking.EducatorId, booking.StudentId, booking.EndTime, newEnd)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if conflict {
+		return nil, apperrors.NewUnprocessedEntity("The time after the session is not free", apperrors.ErrExtensionUnavailable)
+	}
+
+	booking.EndTime = newEnd
+	booking.Price = roundAmount(booking.Price + extension.Price)
+	booking.UpdatedAt = now
+	if _, err := tx.ExecContext(ctx, extendBookingQuery, booking.Id, booking.EndTime, booking.Price, now); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if extension.Id != 0 {
+		result, err := tx.ExecContext(ctx, applyOfferQuery, entities.ExtensionApplied, now, extension.Id, entities.ExtensionOffered)
+		if err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+		if affected == 0 {
+			return nil, apperrors.NewConflict("Extension is no longer offered", apperrors.ErrExtensionUnavailable)
+		}
+	} else {
+		err := tx.GetContext(ctx, &extension.Id, addExtensionQuery,
+			extension.BookingId, extension.Minutes, extension.Price, entities.ExtensionApplied, extension.CreatedBy, now)
+		if err != nil {
+			return nil, apperrors.NewInternal(err)
+		}
+	}
+	extension.Status = entities.ExtensionApplied
+	extension.UpdatedAt = now
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return &booking, nil
+}
@@ -0,0 +1,23 @@
+package extensions
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *ExtensionHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/bookings/{bookingId}", handler.GetBookingExtensions)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Post("/bookings/{bookingId}", handler.ExtendBooking)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Post("/bookings/{bookingId}/offers", handler.OfferExtension)
+	r.Post("/{id}/accept", handler.AcceptExtension)
+	r.Post("/{id}/decline", handler.DeclineExtension)
+
+	return r
+}
@@ -0,0 +1,266 @@
+package extensions
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+)
+
+type ExtensionRepository interface {
+	GetBookingById(ctx context.Context, id int64) (*entities.Booking, error)
+	GetBookingExtensions(ctx context.Context, bookingId int64) ([]*entities.BookingExtension, error)
+	GetExtensionById(ctx context.Context, id int64) (*entities.BookingExtension, error)
+	AddExtension(ctx context.Context, extension *entities.BookingExtension) (int64, error)
+	DeclineExtension(ctx context.Context, id int64, now time.Time) (bool, error)
+	ApplyExtension(ctx context.Context, extension *entities.BookingExtension, now time.Time) (*entities.Booking, error)
+}
+
+// Notifier sends user notifications according to their notification preferences
+type Notifier interface {
+	Notify(ctx context.Context, userId uuid.UUID, notificationType string, data map[string]string) error
+}
+
+type ExtensionService struct {
+	log       logger.Logger
+	repo      ExtensionRepository
+	publisher *messaging.Publisher
+	notifier  Notifier
+}
+
+func NewExtensionService(log logger.Logger, repo ExtensionRepository, publisher *messaging.Publisher, notifier Notifier) *ExtensionService {
+	return &ExtensionService{log: log, repo: repo, publisher: publisher, notifier: notifier}
+}
+
+func (s *ExtensionService) GetBookingExtensions(ctx context.Context, bookingId int64) ([]*ExtensionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	booking, err := s.repo.GetBookingById(ctx, bookingId)
+	if err != nil {
+		log.Error("failed to get booking", err)
+		return nil, err
+	}
+
+	if booking.StudentId != userId && booking.EducatorId != userId {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	extensions, err := s.repo.GetBookingExtensions(ctx, bookingId)
+	if err != nil {
+		log.Error("failed to get booking extensions", err)
+		return nil, err
+	}
+
+	return MapExtensionsToResponse(extensions), nil
+}
+
+// ExtendBooking extends the educator's running session into the free time right after it. The extension
+// price is charged through a price adjustment event.
+func (s *ExtensionService) ExtendBooking(ctx context.Context, bookingId int64, request *ExtensionRequest) (*ExtensionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, booking, err := s.getOwnRunningBooking(ctx, bookingId)
+	if err != nil {
+		log.Error("failed to get booking", err)
+		return nil, err
+	}
+
+	extension := MapRequestToExtension(booking, userId, entities.ExtensionApplied, request)
+	if err := s.applyExtension(ctx, extension, booking.StudentId); err != nil {
+		return nil, err
+	}
+
+	return MapExtensionToResponse(extension), nil
+}
+
+// OfferExtension offers the student of the educator's running session to purchase an extension. It is
+// applied only once the student accepts, given the time after the session is still free.
+func (s *ExtensionService) OfferExtension(ctx context.Context, bookingId int64, request *ExtensionRequest) (*ExtensionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, booking, err := s.getOwnRunningBooking(ctx, bookingId)
+	if err != nil {
+		log.Error("failed to get booking", err)
+		return nil, err
+	}
+
+	extension := MapRequestToExtension(booking, userId, entities.ExtensionOffered, request)
+	extension.Id, err = s.repo.AddExtension(ctx, extension)
+	if err != nil {
+		log.Error("failed to add extension offer", err)
+		return nil, err
+	}
+
+	s.notify(ctx, booking.StudentId, notifications.ExtensionOfferedNotification, booking, extension)
+
+	return MapExtensionToResponse(extension), nil
+}
+
+// AcceptExtension applies an extension offered to the student
+func (s *ExtensionService) AcceptExtension(ctx context.Context, id int64) (*ExtensionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	extension, booking, err := s.getOfferedExtension(ctx, id)
+	if err != nil {
+		log.Error("failed to get extension offer", err)
+		return nil, err
+	}
+
+	if err := s.applyExtension(ctx, extension, booking.EducatorId); err != nil {
+		return nil, err
+	}
+
+	return MapExtensionToResponse(extension), nil
+}
+
+// DeclineExtension declines an extension offered to the student
+func (s *ExtensionService) DeclineExtension(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	extension, _, err := s.getOfferedExtension(ctx, id)
+	if err != nil {
+		log.Error("failed to get extension offer", err)
+		return err
+	}
+
+	declined, err := s.repo.DeclineExtension(ctx, extension.Id, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to decline extension", err)
+		return err
+	}
+	if !declined {
+		return apperrors.NewConflict("Extension is no longer offered", apperrors.ErrExtensionUnavailable)
+	}
+
+	return nil
+}
+
+// applyExtension extends the booking, then publishes the price adjustment and calendar update and notifies
+// the other participant. Side effect failures are logged and do not undo the extension.
+func (s *ExtensionService) applyExtension(ctx context.Context, extension *entities.BookingExtension, recipient uuid.UUID) error {
+	log := logger.FromContext(ctx, s.log)
+
+	booking, err := s.repo.ApplyExtension(ctx, extension, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to apply extension", err)
+		return err
+	}
+
+	if extension.Price > 0 {
+		err = s.publisher.Publish(
+			ctx,
+			messaging.PriceAdjustmentKey,
+			messaging.NewBookingPriceAdjustedEvent(
+				booking.Id,
+				booking.StudentId.String(),
+				booking.EducatorId.String(),
+				booking.ProductId,
+				extension.Id,
+				extension.Price,
+				booking.Price,
+			),
+		)
+		if err != nil {
+			log.Errorf("Failed to publish price adjustment of booking %d: %v", booking.Id, err)
+		}
+	}
+
+	err = s.publisher.Publish(
+		ctx,
+		messaging.BookingUpdatedKey,
+		messaging.NewBookingUpdatedEvent(
+			booking.Id,
+			booking.StudentId.String(),
+			booking.EducatorId.String(),
+			booking.Title,
+			booking.StartTime.UTC().Format(time.RFC3339),
+			booking.EndTime.UTC().Format(time.RFC3339),
+		),
+	)
+	if err != nil {
+		log.Errorf("Failed to publish update of booking %d: %v", booking.Id, err)
+	}
+
+	s.notify(ctx, recipient, notifications.BookingExtendedNotification, booking, extension)
+
+	return nil
+}
+
+func (s *ExtensionService) getOwnRunningBooking(ctx context.Context, bookingId int64) (uuid.UUID, *entities.Booking, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return uuid.Nil, nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	booking, err := s.repo.GetBookingById(ctx, bookingId)
+	if err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	if booking.EducatorId != userId {
+		return uuid.Nil, nil, apperrors.NewForbidden("Access denied")
+	}
+
+	if !isRunning(booking, time.Now().UTC()) {
+		return uuid.Nil, nil, apperrors.NewUnprocessedEntity("Only a running session can be extended", apperrors.ErrExtensionUnavailable)
+	}
+
+	return userId, booking, nil
+}
+
+func (s *ExtensionService) getOfferedExtension(ctx context.Context, id int64) (*entities.BookingExtension, *entities.Booking, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	extension, err := s.repo.GetExtensionById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	booking, err := s.repo.GetBookingById(ctx, extension.BookingId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if booking.StudentId != userId {
+		return nil, nil, apperrors.NewForbidden("Access denied")
+	}
+
+	if extension.Status != entities.ExtensionOffered {
+		return nil, nil, apperrors.NewConflict("Extension is no longer offered", apperrors.ErrExtensionUnavailable)
+	}
+
+	return extension, booking, nil
+}
+
+// notify informs a participant about an extension; failures are logged and do not fail the extension flow
+func (s *ExtensionService) notify(ctx context.Context, userId uuid.UUID, notificationType string, booking *entities.Booking, extension *entities.BookingExtension) {
+	log := logger.FromContext(ctx, s.log)
+
+	data := map[string]string{
+		"bookingId":   strconv.FormatInt(booking.Id, 10),
+		"extensionId": strconv.FormatInt(extension.Id, 10),
+		"title":       booking.Title,
+		"minutes":     strconv.Itoa(extension.Minutes),
+		"price":       strconv.FormatFloat(extension.Price, 'f', 2, 64),
+	}
+
+	if err := s.notifier.Notify(ctx, userId, notificationType, data); err != nil {
+		log.Errorf("Failed to notify user about extension of booking %d: %v", booking.Id, err)
+	}
+}
@@ -0,0 +1,77 @@
+package favorites
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+const (
+	rebookHorizonWeeks = 8
+	rebookSlotStep     = 15 * time.Minute
+)
+
+// Slot is a free time range within a working period
+type Slot struct {
+	WorkingPeriodId int64
+	StartTime       time.Time
+	EndTime         time.Time
+}
+
+// FindRebookSlot looks for the free slot closest to the weekly pattern of a previous booking: the same
+// weekday and local start time, searched week by week from now. Within a week the start nearest to the
+// usual time wins, so an exact match is preferred whenever it is free.
+func FindRebookSlot(
+	source *entities.Booking,
+	loc *time.Location,
+	now time.Time,
+	periods []*entities.WorkingPeriod,
+	busy []*BusyInterval,
+) *Slot {
+	duration := source.EndTime.Sub(source.StartTime)
+	usual := source.StartTime.In(loc)
+	today := now.In(loc)
+
+	first := time.Date(today.Year(), today.Month(), today.Day(), usual.Hour(), usual.Minute(), 0, 0, loc)
+	first = first.AddDate(0, 0, (int(usual.Weekday())-int(today.Weekday())+7)%7)
+
+	maxSteps := int(24 * time.Hour / rebookSlotStep)
+	for week := range rebookHorizonWeeks {
+		preferred := first.AddDate(0, 0, 7*week)
+		dayStart := time.Date(preferred.Year(), preferred.Month(), preferred.Day(), 0, 0, 0, 0, loc)
+		dayEnd := dayStart.AddDate(0, 0, 1)
+
+		for step := 0; step <= maxSteps; step++ {
+			for _, sign := range []int{1, -1} {
+				if step == 0 && sign < 0 {
+					continue
+				}
+				start := preferred.Add(time.Duration(sign*step) * rebookSlotStep)
+				if start.Before(dayStart) || !start.Before(dayEnd) || start.Before(now) {
+					continue
+				}
+				if slot := freeSlot(start, start.Add(duration), periods, busy); slot != nil {
+					return slot
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// freeSlot returns the slot when it fits a working period and overlaps nothing busy
+func freeSlot(start, end time.Time, periods []*entities.WorkingPeriod, busy []*BusyInterval) *Slot {
+	for _, b := range busy {
+		if timeutils.IsOverlapping(start, end, b.StartTime, b.EndTime) {
+			return nil
+		}
+	}
+
+	for _, p := range periods {
+		if timeutils.IsWithinPeriod(start, end, p.StartTime, p.EndTime) {
+			return &Slot{WorkingPeriodId: p.Id, StartTime: start.UTC(), EndTime: end.UTC()}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package favorites
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// swagger:model FavoriteTeacherResponse
+type FavoriteTeacherResponse struct {
+	EducatorId   uuid.UUID  `json:"educatorId"`
+	IsFavorite   bool       `json:"isFavorite"`
+	BookingCount int        `json:"bookingCount"`
+	LastBookedAt *time.Time `json:"lastBookedAt"`
+}
+
+// swagger:model RebookSuggestionResponse
+type RebookSuggestionResponse struct {
+	SourceBookingId int64     `json:"sourceBookingId"`
+	EducatorId      uuid.UUID `json:"educatorId"`
+	ProductId       int64     `json:"productId"`
+	SessionTypeId   *int64    `json:"sessionTypeId"`
+	WorkingPeriodId int64     `json:"workingPeriodId"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	ExactMatch      bool      `json:"exactMatch"`
+	Timezone        string    `json:"timezone"`
+}
@@ -0,0 +1,123 @@
+package favorites
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type FavoriteHandler struct {
+	service *FavoriteService
+}
+
+func NewFavoriteHandler(service *FavoriteService) *FavoriteHandler {
+	return &FavoriteHandler{service: service}
+}
+
+// GetMyTeachers retrieves the teachers of the current student.
+// @Summary      Retrieve my teachers
+// @Description  Retrieves the educators the student booked or marked as favorite with the booking count and latest session, favorites first.
+// @Tags         Favorite
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   FavoriteTeacherResponse  "Teachers"
+// @Failure      401  {object}  error                    "Unauthorized"
+// @Router       /api/v1/favorites/teachers [get]
+// @Security 	 BearerAuth
+func (h *FavoriteHandler) GetMyTeachers(w http.ResponseWriter, r *http.Request) {
+	teachers, err := h.service.GetMyTeachers(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, teachers)
+}
+
+// AddFavorite marks a teacher as favorite of the current student.
+// @Summary      Add favorite teacher
+// @Description  Marks the educator as favorite. Marking an existing favorite again has no effect.
+// @Tags         Favorite
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string  true  "Educator ID (UUID)"
+// @Success      204         "Favorite saved successfully"
+// @Failure      400         {object}  error   "Invalid input parameters"
+// @Router       /api/v1/favorites/teachers/{educatorId} [put]
+// @Security 	 BearerAuth
+func (h *FavoriteHandler) AddFavorite(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.AddFavorite(r.Context(), educatorId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveFavorite removes a teacher from the favorites of the current student.
+// @Summary      Remove favorite teacher
+// @Description  Removes the favorite mark of the educator. The booking history is kept.
+// @Tags         Favorite
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string  true  "Educator ID (UUID)"
+// @Success      204         "Favorite removed successfully"
+// @Failure      400         {object}  error   "Invalid input parameters"
+// @Router       /api/v1/favorites/teachers/{educatorId} [delete]
+// @Security 	 BearerAuth
+func (h *FavoriteHandler) RemoveFavorite(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.RemoveFavorite(r.Context(), educatorId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetRebookSuggestion suggests a slot for rebooking the usual session of the current student.
+// @Summary      Rebook my usual slot
+// @Description  Finds the free slot nearest to the weekday and local time of a previous private session, the latest one when 'bookingId' is not set, within the next eight weeks.
+// @Tags         Favorite
+// @Accept       json
+// @Produce      json
+// @Param        bookingId  query     int     false  "Booking to repeat"
+// @Param        timezone   query     string  false  "IANA time zone, defaults to the notification preferences"
+// @Success      200        {object}  RebookSuggestionResponse  "Suggested slot"
+// @Failure      404        {object}  error                     "No matching availability"
+// @Router       /api/v1/favorites/rebook [get]
+// @Security 	 BearerAuth
+func (h *FavoriteHandler) GetRebookSuggestion(w http.ResponseWriter, r *http.Request) {
+	var bookingId *int64
+	if value := r.URL.Query().Get("bookingId"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			api.WriteError(w, apperrors.NewBadRequestError("invalid bookingId", apperrors.ErrParameterParsingFailed))
+			return
+		}
+		bookingId = &id
+	}
+
+	suggestion, err := h.service.GetRebookSuggestion(r.Context(), bookingId, r.URL.Query().Get("timezone"))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, suggestion)
+}
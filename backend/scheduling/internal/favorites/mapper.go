@@ -0,0 +1,43 @@
+package favorites
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRelationshipsToResponse(relationships []*TeacherRelationship) []*FavoriteTeacherResponse {
+	items := make([]*FavoriteTeacherResponse, 0, len(relationships))
+	for _, r := range relationships {
+		items = append(items, &FavoriteTeacherResponse{
+			EducatorId:   r.EducatorId,
+			IsFavorite:   r.IsFavorite,
+			BookingCount: r.BookingCount,
+			LastBookedAt: r.LastBookedAt,
+		})
+	}
+	return items
+}
+
+func MapToFavoriteTeacher(studentId uuid.UUID, educatorId uuid.UUID) *entities.FavoriteTeacher {
+	return &entities.FavoriteTeacher{StudentId: studentId, EducatorId: educatorId, CreatedAt: time.Now().UTC()}
+}
+
+func MapSlotToSuggestion(source *entities.Booking, slot *Slot, loc *time.Location) *RebookSuggestionResponse {
+	usual := source.StartTime.In(loc)
+	start := slot.StartTime.In(loc)
+
+	return &RebookSuggestionResponse{
+		SourceBookingId: source.Id,
+		EducatorId:      source.EducatorId,
+		ProductId:       source.ProductId,
+		SessionTypeId:   source.SessionTypeId,
+		WorkingPeriodId: slot.WorkingPeriodId,
+		StartTime:       slot.StartTime,
+		EndTime:         slot.EndTime,
+		ExactMatch:      start.Weekday() == usual.Weekday() && start.Hour() == usual.Hour() && start.Minute() == usual.Minute(),
+		Timezone:        loc.String(),
+	}
+}
@@ -0,0 +1,20 @@
+package favorites
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeFavoriteService(log logger.Logger, db *sqlx.DB, preferences PreferenceProvider) *FavoriteService {
+	repo := NewFavoriteRepository(db)
+	service := NewFavoriteService(log, repo, preferences)
+	return service
+}
+
+func InitializeFavoriteHTTPHandler(service *FavoriteService) http.Handler {
+	handler := NewFavoriteHandler(service)
+	return Routes(handler)
+}
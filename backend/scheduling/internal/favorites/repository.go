@@ -0,0 +1,118 @@
+package favorites
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// TeacherRelationship aggregates the booking history of a student with an educator
+type TeacherRelationship struct {
+	EducatorId   uuid.UUID  `db:"educator_id"`
+	IsFavorite   bool       `db:"is_favorite"`
+	BookingCount int        `db:"booking_count"`
+	LastBookedAt *time.Time `db:"last_booked_at"`
+}
+
+// BusyInterval is a time range of an educator taken by a booking or scheduled event
+type BusyInterval struct {
+	StartTime time.Time `db:"start_time"`
+	EndTime   time.Time `db:"end_time"`
+}
+
+type FavoriteRepo struct {
+	db *sqlx.DB
+}
+
+func NewFavoriteRepository(db *sqlx.DB) *FavoriteRepo {
+	return &FavoriteRepo{db: db}
+}
+
+// GetTeacherRelationships retrieves the educators a student booked or marked as favorite, favorites first
+func (r *FavoriteRepo) GetTeacherRelationships(ctx context.Context, studentId uuid.UUID) ([]*TeacherRelationship, error) {
+	const query = `
+		WITH history AS (
+			SELECT educator_id, COUNT(*) AS booking_count, MAX(start_time) AS last_booked_at
+			FROM booking
+			WHERE student_id = $1 AND status <> $2
+			GROUP BY educator_id
+		), favorite AS (
+			SELECT educator_id FROM favorite_teacher WHERE student_id = $1
+		)
+		SELECT COALESCE(h.educator_id, f.educator_id) AS educator_id, f.educator_id IS NOT NULL AS is_favorite,
+			COALESCE(h.booking_count, 0) AS booking_count, h.last_booked_at
+		FROM history h
+		FULL JOIN favorite f ON f.educator_id = h.educator_id
+		ORDER BY is_favorite DESC, booking_count DESC, last_booked_at DESC NULLS LAST
+	`
+	return database.FetchMultiple[TeacherRelationship](ctx, r.db, query, studentId, entities.Cancelled)
+}
+
+// AddFavorite marks an educator as favorite of a student, keeping an existing mark
+func (r *FavoriteRepo) AddFavorite(ctx context.Context, favorite *entities.FavoriteTeacher) error {
+	const query = `
+		INSERT INTO favorite_teacher (student_id, educator_id, created_at)
+		VALUES (:student_id, :educator_id, :created_at)
+		ON CONFLICT (student_id, educator_id) DO NOTHING
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, favorite)
+}
+
+// RemoveFavorite removes the favorite mark of an educator
+func (r *FavoriteRepo) RemoveFavorite(ctx context.Context, studentId uuid.UUID, educatorId uuid.UUID) error {
+	const query = `
+		DELETE FROM favorite_teacher
+		WHERE student_id = $1 AND educator_id = $2
+	`
+	return database.ExecQuery(ctx, r.db, query, studentId, educatorId)
+}
+
+// GetStudentBookingById retrieves a booking of a student
+func (r *FavoriteRepo) GetStudentBookingById(ctx context.Context, studentId uuid.UUID, id int64) (*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at
+		FROM booking
+		WHERE student_id = $1 AND id = $2
+	`
+	return database.FetchSingle[entities.Booking](ctx, r.db, query, studentId, id)
+}
+
+// GetLastPrivateBooking retrieves the latest booking of a student that was not part of a scheduled event
+func (r *FavoriteRepo) GetLastPrivateBooking(ctx context.Context, studentId uuid.UUID) (*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at
+		FROM booking
+		WHERE student_id = $1 AND scheduled_event_id IS NULL AND status <> $2
+		ORDER BY start_time DESC
+		LIMIT 1
+	`
+	return database.FetchSingle[entities.Booking](ctx, r.db, query, studentId, entities.Cancelled)
+}
+
+// GetWorkingPeriods retrieves working periods of an educator overlapping a range
+func (r *FavoriteRepo) GetWorkingPeriods(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.WorkingPeriod, error) {
+	const query = `
+		SELECT id, user_id, start_time, end_time, created_at, updated_at
+		FROM working_period
+		WHERE user_id = $1 AND end_time > $2 AND start_time < $3 AND deleted_at IS NULL
+		ORDER BY start_time
+	`
+	return database.FetchMultiple[entities.WorkingPeriod](ctx, r.db, query, educatorId, from, to)
+}
+
+// GetBusyIntervals retrieves bookings and scheduled events of an educator overlapping a range
+func (r *FavoriteRepo) GetBusyIntervals(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*BusyInterval, error) {
+	const query = `
+		SELECT start_time, end_time FROM booking
+		WHERE educator_id = $1 AND end_time > $2 AND start_time < $3
+		UNION ALL
+		SELECT start_time, end_time FROM scheduled_event
+		WHERE user_id = $1 AND end_time > $2 AND start_time < $3 AND deleted_at IS NULL
+	`
+	return database.FetchMultiple[BusyInterval](ctx, r.db, query, educatorId, from, to)
+}
@@ -0,0 +1,19 @@
+package favorites
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes(handler *FavoriteHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/teachers", handler.GetMyTeachers)
+	r.Put("/teachers/{educatorId}", handler.AddFavorite)
+	r.Delete("/teachers/{educatorId}", handler.RemoveFavorite)
+	r.Get("/rebook", handler.GetRebookSuggestion)
+
+	return r
+}
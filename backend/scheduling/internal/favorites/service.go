@@ -0,0 +1,173 @@
+package favorites
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+)
+
+type FavoriteRepository interface {
+	GetTeacherRelationships(ctx context.Context, studentId uuid.UUID) ([]*TeacherRelationship, error)
+	AddFavorite(ctx context.Context, favorite *entities.FavoriteTeacher) error
+	RemoveFavorite(ctx context.Context, studentId uuid.UUID, educatorId uuid.UUID) error
+	GetStudentBookingById(ctx context.Context, studentId uuid.UUID, id int64) (*entities.Booking, error)
+	GetLastPrivateBooking(ctx context.Context, studentId uuid.UUID) (*entities.Booking, error)
+	GetWorkingPeriods(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.WorkingPeriod, error)
+	GetBusyIntervals(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*BusyInterval, error)
+}
+
+// PreferenceProvider resolves the notification preferences, including the time zone, of the current user
+type PreferenceProvider interface {
+	GetMyPreferences(ctx context.Context) (*notifications.NotificationPreferenceResponse, error)
+}
+
+type FavoriteService struct {
+	log         logger.Logger
+	repo        FavoriteRepository
+	preferences PreferenceProvider
+}
+
+func NewFavoriteService(log logger.Logger, repo FavoriteRepository, preferences PreferenceProvider) *FavoriteService {
+	return &FavoriteService{log: log, repo: repo, preferences: preferences}
+}
+
+// GetMyTeachers returns the educators the student booked or marked as favorite
+func (s *FavoriteService) GetMyTeachers(ctx context.Context) ([]*FavoriteTeacherResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	relationships, err := s.repo.GetTeacherRelationships(ctx, userId)
+	if err != nil {
+		log.Error("failed to get teacher relationships", err)
+		return nil, err
+	}
+
+	return MapRelationshipsToResponse(relationships), nil
+}
+
+func (s *FavoriteService) AddFavorite(ctx context.Context, educatorId uuid.UUID) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if educatorId == userId {
+		return apperrors.NewUnprocessedEntity("You cannot favorite yourself", apperrors.ErrParameterInvalid)
+	}
+
+	if err := s.repo.AddFavorite(ctx, MapToFavoriteTeacher(userId, educatorId)); err != nil {
+		log.Error("failed to add favorite teacher", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *FavoriteService) RemoveFavorite(ctx context.Context, educatorId uuid.UUID) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if err := s.repo.RemoveFavorite(ctx, userId, educatorId); err != nil {
+		log.Error("failed to remove favorite teacher", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetRebookSuggestion finds the nearest free slot matching the weekly pattern of a previous booking,
+// the student's latest private session when no booking is given. The suggestion is booked through the
+// regular booking flow with a new enrollment.
+func (s *FavoriteService) GetRebookSuggestion(ctx context.Context, bookingId *int64, timezone string) (*RebookSuggestionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	loc, err := s.resolveLocation(ctx, timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var source *entities.Booking
+	if bookingId != nil {
+		source, err = s.repo.GetStudentBookingById(ctx, userId, *bookingId)
+	} else {
+		source, err = s.repo.GetLastPrivateBooking(ctx, userId)
+	}
+	if err != nil {
+		var notFound *apperrors.NotFoundError
+		if !errors.As(err, &notFound) {
+			log.Error("failed to get source booking", err)
+		}
+		return nil, err
+	}
+
+	if source.ScheduledEventId != nil {
+		return nil, apperrors.NewUnprocessedEntity("Scheduled event bookings cannot be rebooked", apperrors.ErrRebookUnavailable)
+	}
+
+	now := time.Now().UTC()
+	to := now.AddDate(0, 0, 7*rebookHorizonWeeks+1)
+
+	periods, err := s.repo.GetWorkingPeriods(ctx, source.EducatorId, now, to)
+	if err != nil {
+		log.Error("failed to get working periods", err)
+		return nil, err
+	}
+
+	busy, err := s.repo.GetBusyIntervals(ctx, source.EducatorId, now, to)
+	if err != nil {
+		log.Error("failed to get busy intervals", err)
+		return nil, err
+	}
+
+	slot := FindRebookSlot(source, loc, now, periods, busy)
+	if slot == nil {
+		return nil, apperrors.NewNotFound("No matching availability found", apperrors.ErrRebookUnavailable)
+	}
+
+	return MapSlotToSuggestion(source, slot, loc), nil
+}
+
+func (s *FavoriteService) resolveLocation(ctx context.Context, timezone string) (*time.Location, error) {
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError("timezone must be a valid IANA time zone", apperrors.ErrParameterParsingFailed)
+		}
+		return loc, nil
+	}
+
+	preferences, err := s.preferences.GetMyPreferences(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("failed to get notification preferences", err)
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(preferences.Timezone)
+	if err != nil {
+		return time.UTC, nil
+	}
+	return loc, nil
+}
@@ -0,0 +1,96 @@
+package feeds
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+const tokenPrefix = "ora:feed:v1:"
+
+// Scopes of calendar feed tokens, a token issued for one feed does not open another
+const (
+	ScopeEducatorSchedule = "schedule"
+	ScopeStudentBookings  = "bookings"
+)
+
+const (
+	pastWindow   = 7 * 24 * time.Hour
+	futureWindow = 90 * 24 * time.Hour
+)
+
+// Window returns the range of sessions included in a feed, recent past sessions stay visible
+// in subscribed calendars for a week
+func Window(now time.Time) (time.Time, time.Time) {
+	return now.Add(-pastWindow), now.Add(futureWindow)
+}
+
+// Signer issues and verifies the tokens of calendar feed subscriptions. Calendar apps can not send
+// a bearer token, so the feed URL carries the user id and an HMAC-SHA256 signature over scope and id.
+// In multi-tenant deployments the token also carries the tenant of the user, covered by the signature,
+// so the feed is served for that tenant without the caller naming it.
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key string) *Signer {
+	return &Signer{key: []byte(key)}
+}
+
+// Sign returns the URL safe feed token of a user of a tenant for the given scope. Without a tenant the
+// token has the form it had before deployments were multi-tenant, so existing subscriptions keep working.
+func (s *Signer) Sign(scope string, tenantId string, userId uuid.UUID) string {
+	id := userId.String()
+	if tenantId == "" {
+		return id + "." + s.signature(scope, id, "")
+	}
+	return id + "." + base64.RawURLEncoding.EncodeToString([]byte(tenantId)) + "." + s.signature(scope, id, tenantId)
+}
+
+// Verify checks the signature of a token for the given scope and returns the user id and tenant it was
+// issued for, the tenant is empty for tokens issued without one
+func (s *Signer) Verify(scope string, token string) (uuid.UUID, string, error) {
+	id, tenantId, signature, ok := splitToken(token)
+	if !ok || !hmac.Equal([]byte(signature), []byte(s.signature(scope, id, tenantId))) {
+		return uuid.Nil, "", apperrors.NewNotFound("Calendar feed not found", apperrors.ErrCalendarFeedInvalid)
+	}
+
+	userId, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, "", apperrors.NewNotFound("Calendar feed not found", apperrors.ErrCalendarFeedInvalid)
+	}
+	return userId, tenantId, nil
+}
+
+func (s *Signer) signature(scope string, id string, tenantId string) string {
+	message := tokenPrefix + scope + ":" + id
+	if tenantId != "" {
+		message += ":" + tenantId
+	}
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(message))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// splitToken splits a token into its id, tenant and signature, the tenant part is optional
+func splitToken(token string) (id string, tenantId string, signature string, ok bool) {
+	parts := strings.Split(token, ".")
+	switch len(parts) {
+	case 2:
+		return parts[0], "", parts[1], true
+	case 3:
+		tenant, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil || len(tenant) == 0 {
+			return "", "", "", false
+		}
+		return parts[0], string(tenant), parts[2], true
+	default:
+		return "", "", "", false
+	}
+}
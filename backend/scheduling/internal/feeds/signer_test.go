@@ -0,0 +1,52 @@
+package feeds
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSignerVerify(t *testing.T) {
+	signer := NewSigner("feed-key")
+	userId := uuid.MustParse("6f1c2a4e-7d0b-4c55-9a3e-2b8f1d6c9e01")
+
+	tenantToken := signer.Sign(ScopeEducatorSchedule, "acme", userId)
+	parts := strings.Split(tenantToken, ".")
+	otherTenant := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte("globex")) + "." + parts[2]
+
+	tests := []struct {
+		name       string
+		scope      string
+		token      string
+		wantTenant string
+		wantErr    bool
+	}{
+		{name: "without tenant", scope: ScopeEducatorSchedule, token: signer.Sign(ScopeEducatorSchedule, "", userId)},
+		{name: "with tenant", scope: ScopeEducatorSchedule, token: tenantToken, wantTenant: "acme"},
+		{name: "other scope", scope: ScopeStudentBookings, token: tenantToken, wantErr: true},
+		{name: "tenant replaced", scope: ScopeEducatorSchedule, token: otherTenant, wantErr: true},
+		{name: "tenant removed", scope: ScopeEducatorSchedule, token: parts[0] + "." + parts[2], wantErr: true},
+		{name: "other key", scope: ScopeEducatorSchedule, token: NewSigner("other-key").Sign(ScopeEducatorSchedule, "acme", userId), wantErr: true},
+		{name: "malformed", scope: ScopeEducatorSchedule, token: "not-a-token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUser, gotTenant, err := signer.Verify(tt.scope, tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Verify(%q) accepted the token", tt.token)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify(%q): %v", tt.token, err)
+			}
+			if gotUser != userId || gotTenant != tt.wantTenant {
+				t.Fatalf("Verify(%q) = %s, %q, want %s, %q", tt.token, gotUser, gotTenant, userId, tt.wantTenant)
+			}
+		})
+	}
+}
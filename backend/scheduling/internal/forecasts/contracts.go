@@ -0,0 +1,23 @@
+package forecasts
+
+import "time"
+
+// swagger:model ForecastResponse
+type ForecastResponse struct {
+	EducatorId string                 `json:"educatorId"`
+	ComputedAt *time.Time             `json:"computedAt"`
+	Days       []*ForecastDayResponse `json:"days"`
+}
+
+// swagger:model ForecastDayResponse
+type ForecastDayResponse struct {
+	Date               string  `json:"date"`
+	AvailableMinutes   int     `json:"availableMinutes"`
+	BookedCount        int     `json:"bookedCount"`
+	BookedMinutes      int     `json:"bookedMinutes"`
+	FillRate           float64 `json:"fillRate"`
+	ExpectedBookings   float64 `json:"expectedBookings"`
+	ExpectedMinutes    int     `json:"expectedMinutes"`
+	ExpectedFillRate   float64 `json:"expectedFillRate"`
+	SuggestedSlotCount int     `json:"suggestedSlotCount"`
+}
@@ -0,0 +1,103 @@
+package forecasts
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type ForecastJobRepository interface {
+	GetForecastEducators(ctx context.Context, since, now, until time.Time) ([]uuid.UUID, error)
+	GetLeadTimeBuckets(ctx context.Context, educatorId uuid.UUID, from, to time.Time, maxLeadDays int) ([]*LeadTimeBucket, error)
+	GetUpcomingDays(ctx context.Context, educatorId uuid.UUID, now time.Time, days int) ([]*UpcomingDay, error)
+	ReplaceForecast(ctx context.Context, educatorId uuid.UUID, forecast []*entities.DemandForecast) error
+	DeleteStaleForecasts(ctx context.Context, computedAt time.Time) error
+}
+
+// ForecastJob recomputes the demand forecast of every active educator. Each run learns the lead times and
+// weekday demand from the bookings of the lookback window and projects them onto the bookings already
+// made for the days up to the horizon.
+type ForecastJob struct {
+	log  logger.Logger
+	repo ForecastJobRepository
+	cfg  *config.ForecastConfig
+}
+
+func NewForecastJob(log logger.Logger, repo ForecastJobRepository, cfg *config.ForecastConfig) *ForecastJob {
+	return &ForecastJob{log: log, repo: repo, cfg: cfg}
+}
+
+// Run recomputes the forecasts on start and on every interval until the context is cancelled
+func (j *ForecastJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := j.ComputeForecasts(ctx); err != nil {
+			j.log.Errorf("Failed to compute demand forecasts: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ComputeForecasts replaces the forecast of every educator with upcoming working time or recent bookings
+// and drops the forecasts of the others. A failing educator is logged and keeps its previous forecast.
+func (j *ForecastJob) ComputeForecasts(ctx context.Context) error {
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -j.cfg.LookbackDays)
+
+	educators, err := j.repo.GetForecastEducators(ctx, since, now, today.AddDate(0, 0, j.cfg.HorizonDays+1))
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, educatorId := range educators {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := j.computeEducator(ctx, educatorId, since, today, now); err != nil {
+			j.log.Errorf("Failed to compute demand forecast of educator %s: %v", educatorId, err)
+			failed++
+		}
+	}
+
+	if failed == 0 {
+		if err := j.repo.DeleteStaleForecasts(ctx, now); err != nil {
+			return err
+		}
+	}
+
+	j.log.Debugf("Computed demand forecasts of %d educators", len(educators)-failed)
+	return nil
+}
+
+func (j *ForecastJob) computeEducator(ctx context.Context, educatorId uuid.UUID, since, today, now time.Time) error {
+	buckets, err := j.repo.GetLeadTimeBuckets(ctx, educatorId, since, today, j.cfg.HorizonDays)
+	if err != nil {
+		return err
+	}
+	model := newDemandModel(buckets, since, today, j.cfg.HorizonDays)
+
+	days, err := j.repo.GetUpcomingDays(ctx, educatorId, now, j.cfg.HorizonDays)
+	if err != nil {
+		return err
+	}
+
+	forecast := make([]*entities.DemandForecast, len(days))
+	for i, day := range days {
+		forecast[i] = model.forecastDay(educatorId, day, i, now)
+	}
+	return j.repo.ReplaceForecast(ctx, educatorId, forecast)
+}
@@ -0,0 +1,34 @@
+package forecasts
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+)
+
+type ForecastHandler struct {
+	service *ForecastService
+}
+
+func NewForecastHandler(service *ForecastService) *ForecastHandler {
+	return &ForecastHandler{service: service}
+}
+
+// GetMyForecast retrieves the demand forecast of the current educator.
+// @Summary      Retrieve my demand forecast
+// @Description  Returns the forecast demand of the current educator for every upcoming day up to the horizon, with days taken in the educator's time zone. Expected bookings add the usual demand of the weekday that is still to come, given how far ahead students usually book, to the bookings already made. Suggested slots are the sessions of average length needed to cover the expected demand beyond the working time. Forecasts are recomputed periodically and computedAt tells when.
+// @Tags         Forecasts
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ForecastResponse  "Demand forecast"
+// @Router       /api/v1/forecasts/my [get]
+// @Security 	 BearerAuth
+func (h *ForecastHandler) GetMyForecast(w http.ResponseWriter, r *http.Request) {
+	forecast, err := h.service.GetMyForecast(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, forecast)
+}
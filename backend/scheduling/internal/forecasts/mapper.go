@@ -0,0 +1,40 @@
+package forecasts
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapForecastToResponse(educatorId uuid.UUID, forecast []*entities.DemandForecast) *ForecastResponse {
+	response := &ForecastResponse{EducatorId: educatorId.String(), Days: make([]*ForecastDayResponse, len(forecast))}
+	for i, f := range forecast {
+		if i == 0 {
+			response.ComputedAt = &f.ComputedAt
+		}
+		response.Days[i] = &ForecastDayResponse{
+			Date:               f.Day.Format(time.DateOnly),
+			AvailableMinutes:   f.AvailableMinutes,
+			BookedCount:        f.BookedCount,
+			BookedMinutes:      f.BookedMinutes,
+			FillRate:           fillRate(f.BookedMinutes, f.AvailableMinutes),
+			ExpectedBookings:   f.ExpectedBookings,
+			ExpectedMinutes:    f.ExpectedMinutes,
+			ExpectedFillRate:   fillRate(f.ExpectedMinutes, f.AvailableMinutes),
+			SuggestedSlotCount: f.SuggestedSlots,
+		}
+	}
+	return response
+}
+
+// fillRate is the share of working time taken, rounded to two decimals. It exceeds 1 when demand outgrows
+// the working time and is 0 on days without any.
+func fillRate(minutes, availableMinutes int) float64 {
+	if availableMinutes <= 0 {
+		return 0
+	}
+	return math.Round(float64(minutes)/float64(availableMinutes)*100) / 100
+}
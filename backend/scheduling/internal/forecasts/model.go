@@ -0,0 +1,103 @@
+package forecasts
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+const (
+	// minHistoryBookings is how many past bookings an educator needs before any demand is forecast on top of
+	// the bookings already made
+	minHistoryBookings = 10
+	// defaultSessionMinutes sizes the suggested slots of educators without booking history
+	defaultSessionMinutes = 60
+)
+
+// demandModel is the booking behaviour of an educator learned from past bookings: how far ahead students
+// book and how many sessions each weekday draws
+type demandModel struct {
+	total int
+	// bookedAhead[d] is how many bookings were made at least d days ahead of their session
+	bookedAhead []int
+	// weekdayRate[w] is the average number of bookings per ISO weekday w, Monday being 1
+	weekdayRate [8]float64
+	avgMinutes  float64
+}
+
+// newDemandModel learns the model from the lead time buckets of a lookback window of whole days
+func newDemandModel(buckets []*LeadTimeBucket, from, to time.Time, maxLeadDays int) *demandModel {
+	m := &demandModel{bookedAhead: make([]int, maxLeadDays+1), avgMinutes: defaultSessionMinutes}
+
+	var weekdayBookings [8]int
+	var minutes float64
+	for _, b := range buckets {
+		m.total += b.Bookings
+		minutes += b.Minutes
+		weekdayBookings[b.Weekday] += b.Bookings
+		for d := 0; d <= min(b.LeadDays, maxLeadDays); d++ {
+			m.bookedAhead[d] += b.Bookings
+		}
+	}
+	if m.total > 0 {
+		m.avgMinutes = minutes / float64(m.total)
+	}
+
+	var weekdays [8]int
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		weekdays[isoWeekday(day)]++
+	}
+	for w := 1; w < len(weekdays); w++ {
+		if weekdays[w] > 0 {
+			m.weekdayRate[w] = float64(weekdayBookings[w]) / float64(weekdays[w])
+		}
+	}
+	return m
+}
+
+// expectedBookings forecasts the final bookings of a day that is daysAhead away and already has booked
+// sessions. The bookings still to come are the usual demand of its weekday times the share that is
+// usually booked later than daysAhead.
+func (m *demandModel) expectedBookings(day time.Time, daysAhead, booked int) float64 {
+	if m.total < minHistoryBookings {
+		return float64(booked)
+	}
+
+	daysAhead = min(max(daysAhead, 0), len(m.bookedAhead)-1)
+	share := float64(m.bookedAhead[daysAhead]) / float64(m.total)
+	return float64(booked) + m.weekdayRate[isoWeekday(day)]*(1-share)
+}
+
+// forecastDay turns the current fill of an upcoming day into its forecast. Suggested slots cover the
+// expected minutes beyond the working time, in sessions of the average length.
+func (m *demandModel) forecastDay(educatorId uuid.UUID, day *UpcomingDay, daysAhead int, now time.Time) *entities.DemandForecast {
+	expected := m.expectedBookings(day.Day, daysAhead, day.BookedCount)
+	expectedMinutes := max(int(math.Round(float64(day.BookedMinutes)+(expected-float64(day.BookedCount))*m.avgMinutes)), day.BookedMinutes)
+
+	suggested := 0
+	if shortfall := expectedMinutes - day.AvailableMinutes; shortfall > 0 {
+		suggested = int(math.Ceil(float64(shortfall) / m.avgMinutes))
+	}
+
+	return &entities.DemandForecast{
+		EducatorId:       educatorId,
+		Day:              day.Day,
+		AvailableMinutes: day.AvailableMinutes,
+		BookedCount:      day.BookedCount,
+		BookedMinutes:    day.BookedMinutes,
+		ExpectedBookings: math.Round(expected*100) / 100,
+		ExpectedMinutes:  expectedMinutes,
+		SuggestedSlots:   suggested,
+		ComputedAt:       now,
+	}
+}
+
+func isoWeekday(day time.Time) int {
+	if day.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(day.Weekday())
+}
@@ -0,0 +1,26 @@
+package forecasts
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeForecastService(log logger.Logger, db *sqlx.DB) *ForecastService {
+	repo := NewForecastRepository(db)
+	service := NewForecastService(log, repo)
+	return service
+}
+
+func InitializeForecastJob(log logger.Logger, db *sqlx.DB, cfg *config.ForecastConfig) *ForecastJob {
+	repo := NewForecastRepository(db)
+	return NewForecastJob(log, repo, cfg)
+}
+
+func InitializeForecastHTTPHandler(service *ForecastService) http.Handler {
+	handler := NewForecastHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,166 @@
+package forecasts
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// LeadTimeBucket counts past bookings of an educator by how many days ahead they were made and by the
+// weekday of the session, in the educator's time zone
+type LeadTimeBucket struct {
+	LeadDays int     `db:"lead_days"`
+	Weekday  int     `db:"weekday"`
+	Bookings int     `db:"bookings"`
+	Minutes  float64 `db:"minutes"`
+}
+
+// UpcomingDay is the current availability and fill of an upcoming day of an educator
+type UpcomingDay struct {
+	Day              time.Time `db:"day"`
+	AvailableMinutes int       `db:"available_minutes"`
+	BookedCount      int       `db:"booked_count"`
+	BookedMinutes    int       `db:"booked_minutes"`
+}
+
+type ForecastRepo struct {
+	db *sqlx.DB
+}
+
+func NewForecastRepository(db *sqlx.DB) *ForecastRepo {
+	return &ForecastRepo{db: db}
+}
+
+// GetEducatorForecast retrieves the forecast days of an educator from the educator's today on, soonest first
+func (r *ForecastRepo) GetEducatorForecast(ctx context.Context, educatorId uuid.UUID, now time.Time) ([]*entities.DemandForecast, error) {
+	const query = `
+		SELECT f.educator_id, f.day, f.available_minutes, f.booked_count, f.booked_minutes, f.expected_bookings,
+			f.expected_minutes, f.suggested_slots, f.computed_at
+		FROM demand_forecast f
+		LEFT JOIN scheduling_policy sp ON sp.educator_id = f.educator_id
+		WHERE f.educator_id = $1 AND f.day >= ($2::timestamptz AT TIME ZONE COALESCE(sp.timezone, 'UTC'))::date
+		ORDER BY f.day
+	`
+	return database.FetchMultiple[entities.DemandForecast](ctx, r.db, query, educatorId, now)
+}
+
+// GetForecastEducators lists the educators with working periods up to the horizon or bookings since the
+// start of the lookback window
+func (r *ForecastRepo) GetForecastEducators(ctx context.Context, since, now, until time.Time) ([]uuid.UUID, error) {
+	const query = `
+		SELECT user_id FROM working_period WHERE start_time >= $2 AND start_time < $3 AND deleted_at IS NULL
+		UNION
+		SELECT educator_id FROM booking WHERE start_time >= $1 AND start_time < $3
+		ORDER BY 1
+	`
+	var ids []uuid.UUID
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &ids, query, since, now, until); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return ids, nil
+}
+
+// GetLeadTimeBuckets aggregates the bookings of an educator that took place within a time range. Lead
+// times are whole days between booking and session, capped at maxLeadDays.
+func (r *ForecastRepo) GetLeadTimeBuckets(ctx context.Context, educatorId uuid.UUID, from, to time.Time, maxLeadDays int) ([]*LeadTimeBucket, error) {
+	const query = `
+		SELECT LEAST(GREATEST(FLOOR(EXTRACT(EPOCH FROM b.start_time - b.created_at) / 86400), 0), $4)::int AS lead_days,
+			EXTRACT(ISODOW FROM b.start_time AT TIME ZONE COALESCE(sp.timezone, 'UTC'))::int AS weekday,
+			COUNT(*) AS bookings,
+			(SUM(EXTRACT(EPOCH FROM b.end_time - b.start_time)) / 60)::float8 AS minutes
+		FROM booking b
+		LEFT JOIN scheduling_policy sp ON sp.educator_id = b.educator_id
+		WHERE b.educator_id = $1 AND b.start_time >= $2 AND b.start_time < $3 AND b.status != $5
+		GROUP BY 1, 2
+	`
+	return database.FetchMultiple[LeadTimeBucket](ctx, r.db, query, educatorId, from, to, maxLeadDays, entities.Cancelled)
+}
+
+// GetUpcomingDays sums the working time and active bookings of every day of an educator from today on,
+// with days taken in the educator's scheduling time zone
+func (r *ForecastRepo) GetUpcomingDays(ctx context.Context, educatorId uuid.UUID, now time.Time, days int) ([]*UpcomingDay, error) {
+	const query = `
+		WITH zone AS (
+			SELECT COALESCE((SELECT timezone FROM scheduling_policy WHERE educator_id = $1), 'UTC') AS tz
+		), bounds AS (
+			SELECT d::date AS day, d AT TIME ZONE zone.tz AS day_start, (d + interval '1 day') AT TIME ZONE zone.tz AS day_end
+			FROM zone, generate_series(
+				($2::timestamptz AT TIME ZONE zone.tz)::date::timestamp,
+				($2::timestamptz AT TIME ZONE zone.tz)::date::timestamp + make_interval(days => $3::int - 1),
+				interval '1 day'
+			) AS d
+		)
+		SELECT b.day,
+			COALESCE((SELECT SUM(EXTRACT(EPOCH FROM wp.end_time - wp.start_time)) / 60 FROM working_period wp
+			 WHERE wp.user_id = $1 AND wp.start_time >= b.day_start AND wp.start_time < b.day_end AND wp.deleted_at IS NULL), 0)::int AS available_minutes,
+			(SELECT COUNT(*) FROM booking bk
+			 WHERE bk.educator_id = $1 AND bk.status != $4 AND bk.start_time >= b.day_start AND bk.start_time < b.day_end) AS booked_count,
+			COALESCE((SELECT SUM(EXTRACT(EPOCH FROM bk.end_time - bk.start_time)) / 60 FROM booking bk
+			 WHERE bk.educator_id = $1 AND bk.status != $4 AND bk.start_time >= b.day_start AND bk.start_time < b.day_end), 0)::int AS booked_minutes
+		FROM bounds b
+		ORDER BY b.day
+	`
+	return database.FetchMultiple[UpcomingDay](ctx, r.db, query, educatorId, now, days, entities.Cancelled)
+}
+
+// ReplaceForecast swaps the whole forecast of an educator for the given days
+func (r *ForecastRepo) ReplaceForecast(ctx context.Context, educatorId uuid.UUID, forecast []*entities.DemandForecast) error {
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM demand_forecast WHERE educator_id = $1`, educatorId); err != nil {
+		return apperrors.NewInternal(err)
+	}
+
+	if len(forecast) > 0 {
+		days := make(pq.StringArray, len(forecast))
+		available := make(pq.Int64Array, len(forecast))
+		bookedCounts := make(pq.Int64Array, len(forecast))
+		bookedMinutes := make(pq.Int64Array, len(forecast))
+		expectedBookings := make(pq.Float64Array, len(forecast))
+		expectedMinutes := make(pq.Int64Array, len(forecast))
+		suggested := make(pq.Int64Array, len(forecast))
+		for i, f := range forecast {
+			days[i] = f.Day.Format(time.DateOnly)
+			available[i] = int64(f.AvailableMinutes)
+			bookedCounts[i] = int64(f.BookedCount)
+			bookedMinutes[i] = int64(f.BookedMinutes)
+			expectedBookings[i] = f.ExpectedBookings
+			expectedMinutes[i] = int64(f.ExpectedMinutes)
+			suggested[i] = int64(f.SuggestedSlots)
+		}
+
+		const query = `
+			INSERT INTO demand_forecast (educator_id, day, available_minutes, booked_count, booked_minutes, expected_bookings,
+				expected_minutes, suggested_slots, computed_at)
+			SELECT $1, t.*, $9
+			FROM unnest($2::date[], $3::int[], $4::int[], $5::int[], $6::numeric[], $7::int[], $8::int[])
+				AS t(day, available_minutes, booked_count, booked_minutes, expected_bookings, expected_minutes, suggested_slots)
+		`
+		_, err := tx.ExecContext(ctx, query, educatorId, days, available, bookedCounts, bookedMinutes, expectedBookings,
+			expectedMinutes, suggested, forecast[0].ComputedAt)
+		if err != nil {
+			return apperrors.NewInternal(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	return nil
+}
+
+// DeleteStaleForecasts removes the forecasts of educators left out of the run that computed at a point in time
+func (r *ForecastRepo) DeleteStaleForecasts(ctx context.Context, computedAt time.Time) error {
+	return database.ExecQuery(ctx, r.db, `DELETE FROM demand_forecast WHERE computed_at < $1`, computedAt)
+}
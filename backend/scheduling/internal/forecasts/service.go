@@ -0,0 +1,45 @@
+package forecasts
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type ForecastRepository interface {
+	GetEducatorForecast(ctx context.Context, educatorId uuid.UUID, now time.Time) ([]*entities.DemandForecast, error)
+}
+
+// ForecastService serves the demand forecasts computed by the forecast job
+type ForecastService struct {
+	log  logger.Logger
+	repo ForecastRepository
+}
+
+func NewForecastService(log logger.Logger, repo ForecastRepository) *ForecastService {
+	return &ForecastService{log: log, repo: repo}
+}
+
+// GetMyForecast retrieves the upcoming days of the current educator's demand forecast
+func (s *ForecastService) GetMyForecast(ctx context.Context) (*ForecastResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	forecast, err := s.repo.GetEducatorForecast(ctx, userId, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to get demand forecast", err)
+		return nil, err
+	}
+
+	return MapForecastToResponse(userId, forecast), nil
+}
@@ -0,0 +1,131 @@
+package formatting
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/i18n"
+)
+
+// Clock is how times of day are written for a user
+type Clock string
+
+const (
+	Clock12h Clock = "12h"
+	Clock24h Clock = "24h"
+
+	defaultLanguage = "en"
+)
+
+// Valid reports whether the clock is one of the supported formats
+func (c Clock) Valid() bool {
+	return c == Clock12h || c == Clock24h
+}
+
+var clockLayouts = map[Clock]string{
+	Clock12h: "3:04 PM",
+	Clock24h: "15:04",
+}
+
+// weekdays holds the day names of the supported languages, Sunday first as in time.Weekday
+var weekdays = map[string][7]string{
+	"en": {"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	"de": {"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	"fr": {"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	"es": {"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	"it": {"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+	"pt": {"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+	"nl": {"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+	"pl": {"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+	"uk": {"неділя", "понеділок", "вівторок", "середа", "четвер", "пʼятниця", "субота"},
+}
+
+// swagger:model FormattedTime
+type FormattedTime struct {
+	// Date is the calendar date in the user's time zone, as YYYY-MM-DD
+	Date    string `json:"date"`
+	Weekday string `json:"weekday"`
+	// Time is the time of day on the user's clock, such as 2:30 PM or 14:30
+	Time string `json:"time"`
+	// Display combines the weekday, date and time for screen readers and plain text clients
+	Display string `json:"display"`
+}
+
+// Preference is the formatting a user has saved, used for whatever a request does not ask for itself
+type Preference struct {
+	Clock    Clock
+	Language string
+}
+
+// Formatter writes times in a user's time zone, clock and language. Times are always returned as
+// timestamps too, the formatted texts spare clients from reimplementing the user's conventions.
+type Formatter struct {
+	loc      *time.Location
+	clock    Clock
+	language string
+}
+
+// NewFormatter resolves the formatting of a request. The clock asked for by the request wins over the
+// preference, the language is the first Accept-Language locale with weekday names, then the preference.
+// Unknown values fall back to 24 hour English.
+func NewFormatter(ctx context.Context, loc *time.Location, clock Clock, preference *Preference) *Formatter {
+	f := &Formatter{loc: loc, clock: Clock24h, language: defaultLanguage}
+
+	switch {
+	case clock.Valid():
+		f.clock = clock
+	case preference != nil && preference.Clock.Valid():
+		f.clock = preference.Clock
+	}
+
+	languages := slices.Clone(i18n.Locales(ctx))
+	if preference != nil {
+		languages = append(languages, i18n.Canonical(preference.Language))
+	}
+	for _, locale := range languages {
+		if lang := baseLanguage(locale); hasWeekdays(lang) {
+			f.language = lang
+			break
+		}
+	}
+	return f
+}
+
+func (f *Formatter) Location() *time.Location {
+	return f.loc
+}
+
+func (f *Formatter) Clock() Clock {
+	return f.clock
+}
+
+func (f *Formatter) Language() string {
+	return f.language
+}
+
+// Format writes a point in time in the user's time zone
+func (f *Formatter) Format(t time.Time) *FormattedTime {
+	local := t.In(f.loc)
+	date := local.Format(time.DateOnly)
+	weekday := weekdays[f.language][local.Weekday()]
+	clock := local.Format(clockLayouts[f.clock])
+
+	return &FormattedTime{
+		Date:    date,
+		Weekday: weekday,
+		Time:    clock,
+		Display: weekday + ", " + date + ", " + clock,
+	}
+}
+
+func baseLanguage(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
+func hasWeekdays(language string) bool {
+	_, ok := weekdays[language]
+	return ok
+}
@@ -0,0 +1,130 @@
+package freezes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// Scopes of a freeze
+const (
+	ScopePlatform     = "PLATFORM"
+	ScopeEducators    = "EDUCATORS"
+	ScopeOrganization = "ORGANIZATION"
+)
+
+// Statuses of a freeze, derived from its window
+const (
+	StatusScheduled = "SCHEDULED"
+	StatusActive    = "ACTIVE"
+	StatusEnded     = "ENDED"
+	StatusLifted    = "LIFTED"
+)
+
+const (
+	// maxFreezeWindow bounds a freeze, longer pauses belong to offboarding or maintenance of the product
+	maxFreezeWindow    = 30 * 24 * time.Hour
+	maxFreezeEducators = 1000
+	maxMessageLength   = 500
+)
+
+// swagger:model BookingFreezeRequest
+type BookingFreezeRequest struct {
+	// Message is returned to clients whose bookings are rejected during the freeze
+	Message string `json:"message"`
+	// Reason is kept for admins only, e.g. the incident or migration the freeze is for
+	Reason string `json:"reason"`
+	// EducatorIds limits the freeze to a cohort of educators
+	EducatorIds []uuid.UUID `json:"educatorIds"`
+	// OrganizationId limits the freeze to the educators of an organization
+	OrganizationId *int64 `json:"organizationId"`
+	// StartsAt defaults to now
+	StartsAt *time.Time `json:"startsAt"`
+	// EndsAt is when bookings thaw without any further action
+	EndsAt time.Time `json:"endsAt"`
+}
+
+// swagger:model BookingFreezeResponse
+type BookingFreezeResponse struct {
+	Id             int64       `json:"id"`
+	Scope          string      `json:"scope"`
+	Status         string      `json:"status"`
+	Message        string      `json:"message"`
+	Reason         string      `json:"reason"`
+	EducatorIds    []uuid.UUID `json:"educatorIds"`
+	OrganizationId *int64      `json:"organizationId"`
+	StartsAt       time.Time   `json:"startsAt"`
+	EndsAt         time.Time   `json:"endsAt"`
+	CreatedBy      uuid.UUID   `json:"createdBy"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	LiftedBy       *uuid.UUID  `json:"liftedBy"`
+	LiftedAt       *time.Time  `json:"liftedAt"`
+}
+
+func (f *BookingFreezeRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	message := strings.TrimSpace(f.Message)
+	if message == "" {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Message",
+			Message: "must not be empty",
+		})
+	}
+
+	if len(message) > maxMessageLength || len(f.Reason) > maxMessageLength {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Message",
+			Message: fmt.Sprintf("message and reason must not exceed %d characters", maxMessageLength),
+		})
+	}
+
+	if len(f.EducatorIds) > 0 && f.OrganizationId != nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "EducatorIds",
+			Message: "must be empty when OrganizationId is set",
+		})
+	}
+
+	if len(f.EducatorIds) > maxFreezeEducators {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "EducatorIds",
+			Message: fmt.Sprintf("must not exceed %d educators", maxFreezeEducators),
+		})
+	}
+
+	now := time.Now()
+	startsAt := now
+	if f.StartsAt != nil {
+		startsAt = *f.StartsAt
+	}
+
+	if f.EndsAt.IsZero() {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "EndsAt",
+			Message: "must not be empty",
+		})
+	} else {
+		if !f.EndsAt.After(now) || !f.EndsAt.After(startsAt) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "EndsAt",
+				Message: "must be after now and after StartsAt",
+			})
+		}
+		if f.EndsAt.Sub(startsAt) > maxFreezeWindow {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "EndsAt",
+				Message: fmt.Sprintf("must be within %d days of StartsAt", int(maxFreezeWindow.Hours()/24)),
+			})
+		}
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Booking freeze request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
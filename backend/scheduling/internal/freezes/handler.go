@@ -0,0 +1,100 @@
+package freezes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type FreezeHandler struct {
+	service *FreezeService
+}
+
+func NewFreezeHandler(service *FreezeService) *FreezeHandler {
+	return &FreezeHandler{service: service}
+}
+
+// GetBookingFreezes retrieves the booking freezes.
+// @Summary      Retrieve booking freezes
+// @Description  Retrieves the scheduled and active booking freezes, soonest first. With 'includeEnded' the freezes ended or lifted within the last 30 days are included.
+// @Tags         BookingFreeze
+// @Accept       json
+// @Produce      json
+// @Param        includeEnded  query     bool  false  "Include ended and lifted freezes"
+// @Success      200           {array}   BookingFreezeResponse  "Booking freezes"
+// @Router       /api/v1/admin/booking-freezes [get]
+// @Security 	 BearerAuth
+func (h *FreezeHandler) GetBookingFreezes(w http.ResponseWriter, r *http.Request) {
+	includeEnded := r.URL.Query().Get("includeEnded") == "true"
+
+	freezes, err := h.service.GetBookingFreezes(r.Context(), includeEnded)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, freezes)
+}
+
+// AddBookingFreeze freezes new bookings for a time window.
+// @Summary      Freeze bookings
+// @Description  Rejects new bookings, booking holds and booking link redemptions from 'startsAt' (default now) until 'endsAt', platform-wide or for the educators listed in 'educatorIds' or belonging to 'organizationId'. Rejected requests get a 422 with the ERROR_BOOKINGS_FROZEN code, the freeze message and its end. Bookings thaw at 'endsAt' on their own, existing bookings are not affected.
+// @Tags         BookingFreeze
+// @Accept       json
+// @Produce      json
+// @Param        request  body      BookingFreezeRequest   true  "Booking freeze"
+// @Success      201      {object}  BookingFreezeResponse  "Booking freeze created"
+// @Failure      400      {object}  error                  "Invalid input"
+// @Failure      404      {object}  error                  "Organization not found"
+// @Router       /api/v1/admin/booking-freezes [post]
+// @Security 	 BearerAuth
+func (h *FreezeHandler) AddBookingFreeze(w http.ResponseWriter, r *http.Request) {
+	var request *BookingFreezeRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	freeze, err := h.service.AddBookingFreeze(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, freeze)
+}
+
+// LiftBookingFreeze lifts a booking freeze before its end.
+// @Summary      Lift booking freeze
+// @Description  Thaws bookings right away instead of at the end of the freeze.
+// @Tags         BookingFreeze
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Booking freeze ID"
+// @Success      204  "Booking freeze lifted"
+// @Failure      404  {object}  error  "Booking freeze not found"
+// @Failure      409  {object}  error  "Booking freeze already ended or lifted"
+// @Router       /api/v1/admin/booking-freezes/{id} [delete]
+// @Security 	 BearerAuth
+func (h *FreezeHandler) LiftBookingFreeze(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	if err := h.service.LiftBookingFreeze(r.Context(), id); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
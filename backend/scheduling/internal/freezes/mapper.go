@@ -0,0 +1,91 @@
+package freezes
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToBookingFreeze(createdBy uuid.UUID, request *BookingFreezeRequest) *entities.BookingFreeze {
+	now := time.Now().UTC()
+	startsAt := now
+	if request.StartsAt != nil {
+		startsAt = request.StartsAt.UTC()
+	}
+
+	educatorIds := make([]string, 0, len(request.EducatorIds))
+	for _, id := range request.EducatorIds {
+		educatorIds = append(educatorIds, id.String())
+	}
+
+	return &entities.BookingFreeze{
+		Message:        strings.TrimSpace(request.Message),
+		Reason:         strings.TrimSpace(request.Reason),
+		EducatorIds:    educatorIds,
+		OrganizationId: request.OrganizationId,
+		StartsAt:       startsAt,
+		EndsAt:         request.EndsAt.UTC(),
+		CreatedBy:      createdBy,
+		CreatedAt:      now,
+	}
+}
+
+func MapBookingFreezeToResponse(f *entities.BookingFreeze, now time.Time) *BookingFreezeResponse {
+	educatorIds := make([]uuid.UUID, 0, len(f.EducatorIds))
+	for _, id := range f.EducatorIds {
+		if parsed, err := uuid.Parse(id); err == nil {
+			educatorIds = append(educatorIds, parsed)
+		}
+	}
+
+	return &BookingFreezeResponse{
+		Id:             f.Id,
+		Scope:          freezeScope(f),
+		Status:         freezeStatus(f, now),
+		Message:        f.Message,
+		Reason:         f.Reason,
+		EducatorIds:    educatorIds,
+		OrganizationId: f.OrganizationId,
+		StartsAt:       f.StartsAt,
+		EndsAt:         f.EndsAt,
+		CreatedBy:      f.CreatedBy,
+		CreatedAt:      f.CreatedAt,
+		LiftedBy:       f.LiftedBy,
+		LiftedAt:       f.LiftedAt,
+	}
+}
+
+func MapBookingFreezesToResponse(fs []*entities.BookingFreeze, now time.Time) []*BookingFreezeResponse {
+	result := make([]*BookingFreezeResponse, 0, len(fs))
+	for _, f := range fs {
+		result = append(result, MapBookingFreezeToResponse(f, now))
+	}
+	return result
+}
+
+func freezeScope(f *entities.BookingFreeze) string {
+	switch {
+	case f.OrganizationId != nil:
+		return ScopeOrganization
+	case len(f.EducatorIds) > 0:
+		return ScopeEducators
+	default:
+		return ScopePlatform
+	}
+}
+
+func freezeStatus(f *entities.BookingFreeze, now time.Time) string {
+	switch {
+	case f.LiftedAt != nil:
+		return StatusLifted
+	case !f.EndsAt.After(now):
+		return StatusEnded
+	case f.StartsAt.After(now):
+		return StatusScheduled
+	default:
+		return StatusActive
+	}
+}
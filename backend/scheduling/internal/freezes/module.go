@@ -0,0 +1,20 @@
+package freezes
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeFreezeService(log logger.Logger, db *sqlx.DB) *FreezeService {
+	repo := NewFreezeRepository(db)
+	service := NewFreezeService(log, repo)
+	return service
+}
+
+func InitializeFreezeHTTPHandler(service *FreezeService) http.Handler {
+	handler := NewFreezeHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,69 @@
+package freezes
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+const freezeColumns = `id, message, reason, educator_ids::text[] AS educator_ids, organization_id, starts_at, ends_at, created_by, created_at, lifted_by, lifted_at`
+
+type FreezeRepo struct {
+	db *sqlx.DB
+}
+
+func NewFreezeRepository(db *sqlx.DB) *FreezeRepo {
+	return &FreezeRepo{db: db}
+}
+
+// GetBookingFreezes retrieves the freezes ending after a moment, including lifted ones, soonest first
+func (r *FreezeRepo) GetBookingFreezes(ctx context.Context, endingAfter time.Time) ([]*entities.BookingFreeze, error) {
+	const query = `SELECT ` + freezeColumns + ` FROM booking_freeze WHERE ends_at > $1 ORDER BY starts_at, id`
+	return database.FetchMultiple[entities.BookingFreeze](ctx, r.db, query, endingAfter)
+}
+
+// GetBookingFreezeById retrieves a freeze
+func (r *FreezeRepo) GetBookingFreezeById(ctx context.Context, id int64) (*entities.BookingFreeze, error) {
+	const query = `SELECT ` + freezeColumns + ` FROM booking_freeze WHERE id = $1`
+	return database.FetchSingle[entities.BookingFreeze](ctx, r.db, query, id)
+}
+
+// OrganizationExists checks whether an organization exists
+func (r *FreezeRepo) OrganizationExists(ctx context.Context, id int64) (bool, error) {
+	const query = `SELECT EXISTS (SELECT 1 FROM organization WHERE id = $1)`
+	return database.CheckExists(ctx, r.db, query, id)
+}
+
+// AddBookingFreeze stores a freeze and returns its Id
+func (r *FreezeRepo) AddBookingFreeze(ctx context.Context, freeze *entities.BookingFreeze) (int64, error) {
+	const query = `
+		INSERT INTO booking_freeze (message, reason, educator_ids, organization_id, starts_at, ends_at, created_by, created_at)
+		VALUES (:message, :reason, :educator_ids, :organization_id, :starts_at, :ends_at, :created_by, :created_at)
+		RETURNING id
+	`
+	return database.ExecNamedQueryWithResult[int64](ctx, r.db, query, freeze)
+}
+
+// LiftBookingFreeze ends a freeze early; false is returned when it was already lifted or has ended
+func (r *FreezeRepo) LiftBookingFreeze(ctx context.Context, id int64, liftedBy uuid.UUID, now time.Time) (bool, error) {
+	const query = `
+		UPDATE booking_freeze SET lifted_by = $2, lifted_at = $3
+		WHERE id = $1 AND lifted_at IS NULL AND ends_at > $3
+	`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, id, liftedBy, now)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
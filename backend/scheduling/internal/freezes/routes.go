@@ -0,0 +1,22 @@
+package freezes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *FreezeHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.AdminRole))
+	r.Get("/", handler.GetBookingFreezes)
+	r.Post("/", handler.AddBookingFreeze)
+	r.Delete("/{id}", handler.LiftBookingFreeze)
+
+	return r
+}
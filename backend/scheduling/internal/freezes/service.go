@@ -0,0 +1,120 @@
+package freezes
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type FreezeRepository interface {
+	GetBookingFreezes(ctx context.Context, endingAfter time.Time) ([]*entities.BookingFreeze, error)
+	GetBookingFreezeById(ctx context.Context, id int64) (*entities.BookingFreeze, error)
+	OrganizationExists(ctx context.Context, id int64) (bool, error)
+	AddBookingFreeze(ctx context.Context, freeze *entities.BookingFreeze) (int64, error)
+	LiftBookingFreeze(ctx context.Context, id int64, liftedBy uuid.UUID, now time.Time) (bool, error)
+}
+
+type FreezeService struct {
+	log  logger.Logger
+	repo FreezeRepository
+}
+
+func NewFreezeService(log logger.Logger, repo FreezeRepository) *FreezeService {
+	return &FreezeService{log: log, repo: repo}
+}
+
+// GetBookingFreezes returns the scheduled and active freezes, and with includeEnded also those ended or
+// lifted within the last maxFreezeWindow
+func (s *FreezeService) GetBookingFreezes(ctx context.Context, includeEnded bool) ([]*BookingFreezeResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	now := time.Now().UTC()
+	endingAfter := now
+	if includeEnded {
+		endingAfter = now.Add(-maxFreezeWindow)
+	}
+
+	freezes, err := s.repo.GetBookingFreezes(ctx, endingAfter)
+	if err != nil {
+		log.Error("failed to get booking freezes", err)
+		return nil, err
+	}
+
+	if !includeEnded {
+		active := freezes[:0]
+		for _, f := range freezes {
+			if f.LiftedAt == nil {
+				active = append(active, f)
+			}
+		}
+		freezes = active
+	}
+
+	return MapBookingFreezesToResponse(freezes, now), nil
+}
+
+// AddBookingFreeze pauses new bookings from StartsAt until EndsAt, after which they thaw on their own.
+// Existing bookings are not affected.
+func (s *FreezeService) AddBookingFreeze(ctx context.Context, request *BookingFreezeRequest) (*BookingFreezeResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if request.OrganizationId != nil {
+		exists, err := s.repo.OrganizationExists(ctx, *request.OrganizationId)
+		if err != nil {
+			log.Error("failed to check organization", err)
+			return nil, err
+		}
+		if !exists {
+			return nil, apperrors.NewNotFound("Organization not found", apperrors.ErrResourceNotFound)
+		}
+	}
+
+	freeze := MapRequestToBookingFreeze(userId, request)
+	id, err := s.repo.AddBookingFreeze(ctx, freeze)
+	if err != nil {
+		log.Error("failed to add booking freeze", err)
+		return nil, err
+	}
+	freeze.Id = id
+
+	log.Warnf("Booking freeze %d by %s from %s until %s: %s", id, userId, freeze.StartsAt.Format(time.RFC3339), freeze.EndsAt.Format(time.RFC3339), freeze.Reason)
+	return MapBookingFreezeToResponse(freeze, time.Now().UTC()), nil
+}
+
+// LiftBookingFreeze thaws bookings before the end of a freeze
+func (s *FreezeService) LiftBookingFreeze(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if _, err := s.repo.GetBookingFreezeById(ctx, id); err != nil {
+		log.Error("failed to get booking freeze", err)
+		return err
+	}
+
+	lifted, err := s.repo.LiftBookingFreeze(ctx, id, userId, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to lift booking freeze", err)
+		return err
+	}
+	if !lifted {
+		return apperrors.NewConflict("Booking freeze has already ended or been lifted", apperrors.ErrBookingsFrozen)
+	}
+
+	log.Infof("Booking freeze %d lifted by %s", id, userId)
+	return nil
+}
@@ -0,0 +1,146 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	pb "github.com/maksmelnyk/scheduling/internal/grpc/schedulingv1"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// RecoveryInterceptor turns a panicking call into an internal error instead of taking the server down
+func RecoveryInterceptor(log logger.Logger) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Errorf("Panic in gRPC call %s: %v", info.FullMethod, r)
+				err = status.Error(codes.Internal, "An unexpected error occurred.")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// LoggingInterceptor puts a request scoped logger into the context and logs failed calls
+func LoggingInterceptor(log logger.Logger) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		reqLog := log.With(logger.Field{Key: "grpc_method", Value: info.FullMethod})
+		resp, err := handler(logger.WithLogger(ctx, reqLog), req)
+		if err != nil {
+			reqLog.Warnf("gRPC call failed: %v", err)
+		}
+		return resp, err
+	}
+}
+
+// AuthInterceptor accepts calls that carry the shared service token as a bearer authorization. Every call
+// is accepted when no token is configured.
+func AuthInterceptor(token string) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		if token == "" {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get("authorization") {
+			supplied, ok := strings.CutPrefix(value, "Bearer ")
+			if ok && subtle.ConstantTimeCompare([]byte(supplied), []byte(token)) == 1 {
+				return handler(ctx, req)
+			}
+		}
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized service")
+	}
+}
+
+// tenantMetadata names the tenant a call is made for, in multi-tenant deployments
+const tenantMetadata = "tenant-id"
+
+// TenantInterceptor serves a call for the tenant named in its metadata. With tenancy enabled calls without
+// one are refused, a single-tenant deployment serves every call for its only tenant.
+func TenantInterceptor(tenancy *config.TenancyConfig) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		if !tenancy.Enabled {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(tenantMetadata)
+		if len(values) == 0 || values[0] == "" {
+			return nil, status.Error(codes.PermissionDenied, "Call has no tenant")
+		}
+		return handler(auth.WithTenant(ctx, values[0]), req)
+	}
+}
+
+// ReadOnlyGuard reports whether the service is in read-only mode
+type ReadOnlyGuard interface {
+	ReadOnly() bool
+}
+
+// writeMethods are the calls that change data, refused in read-only mode
+var writeMethods = map[string]bool{
+	pb.SchedulingService_ConfirmBookingHold_FullMethodName: true,
+}
+
+// ReadOnlyInterceptor refuses the calls that change data while the service is in read-only mode, lookups
+// keep being served
+func ReadOnlyInterceptor(guard ReadOnlyGuard) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		if writeMethods[info.FullMethod] && guard.ReadOnly() {
+			return nil, status.Error(codes.Unavailable, "Service is in read-only mode, retry later")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ErrorInterceptor translates application errors into gRPC statuses, mirroring the HTTP status codes the
+// public API answers with. Internal details are not sent to the caller.
+func ErrorInterceptor() gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, toStatus(err)
+	}
+}
+
+func toStatus(err error) error {
+	switch e := err.(type) {
+	case *apperrors.UnauthorizedError:
+		return status.Error(codes.Unauthenticated, e.Message)
+	case *apperrors.ForbiddenError:
+		return status.Error(codes.PermissionDenied, e.Message)
+	case *apperrors.NotFoundError:
+		return status.Error(codes.NotFound, e.Message)
+	case *apperrors.BadRequestError:
+		return status.Error(codes.InvalidArgument, e.Message)
+	case *apperrors.ValidationError:
+		return status.Error(codes.InvalidArgument, e.Message)
+	case *apperrors.ConflictError:
+		return status.Error(codes.AlreadyExists, e.Message)
+	case *apperrors.UnprocessedEntityError:
+		return status.Error(codes.FailedPrecondition, e.Message)
+	case *apperrors.TooManyRequestsError:
+		return status.Error(codes.ResourceExhausted, e.Message)
+	case *apperrors.ServiceUnavailableError:
+		return status.Error(codes.Unavailable, e.Message)
+	}
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, "Request cancelled")
+	}
+	return status.Error(codes.Internal, "An unexpected error occurred.")
+}
@@ -0,0 +1,84 @@
+package grpc
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	pb "github.com/maksmelnyk/scheduling/internal/grpc/schedulingv1"
+)
+
+func MapWorkingPeriodToMessage(wp *entities.WorkingPeriod) *pb.WorkingPeriod {
+	return &pb.WorkingPeriod{
+		Id:         wp.Id,
+		EducatorId: wp.UserId.String(),
+		StartTime:  timestamppb.New(wp.StartTime),
+		EndTime:    timestamppb.New(wp.EndTime),
+	}
+}
+
+func MapScheduledEventToMessage(se *entities.ScheduledEvent) *pb.ScheduledEvent {
+	return &pb.ScheduledEvent{
+		Id:              se.Id,
+		EducatorId:      se.UserId.String(),
+		ProductId:       se.ProductId,
+		LessonId:        valueOrZero(se.LessonId),
+		WorkingPeriodId: se.WorkingPeriodId,
+		Title:           se.Title,
+		StartTime:       timestamppb.New(se.StartTime),
+		EndTime:         timestamppb.New(se.EndTime),
+		MaxParticipants: int32(se.MaxParticipants),
+		Price:           se.Price,
+	}
+}
+
+func MapScheduleToMessage(workingPeriods []*entities.WorkingPeriod, events []*entities.ScheduledEvent) *pb.GetEducatorScheduleResponse {
+	response := &pb.GetEducatorScheduleResponse{
+		WorkingPeriods:  make([]*pb.WorkingPeriod, len(workingPeriods)),
+		ScheduledEvents: make([]*pb.ScheduledEvent, len(events)),
+	}
+	for i, wp := range workingPeriods {
+		response.WorkingPeriods[i] = MapWorkingPeriodToMessage(wp)
+	}
+	for i, se := range events {
+		response.ScheduledEvents[i] = MapScheduledEventToMessage(se)
+	}
+	return response
+}
+
+func MapBookingToMessage(b *entities.Booking) *pb.Booking {
+	return &pb.Booking{
+		Id:               b.Id,
+		EducatorId:       b.EducatorId.String(),
+		StudentId:        b.StudentId.String(),
+		ProductId:        b.ProductId,
+		ScheduledEventId: valueOrZero(b.ScheduledEventId),
+		EnrollmentId:     valueOrZero(b.EnrollmentId),
+		Title:            b.Title,
+		StartTime:        timestamppb.New(b.StartTime),
+		EndTime:          timestamppb.New(b.EndTime),
+		Status:           mapBookingStatus(b.Status),
+		Price:            b.Price,
+	}
+}
+
+// mapBookingStatus maps to the statuses of the gRPC contract, which folds awaiting payment into pending and
+// the closed statuses into approved
+func mapBookingStatus(status entities.BookingStatus) pb.BookingStatus {
+	switch status {
+	case entities.Pending, entities.AwaitingPayment:
+		return pb.BookingStatus_BOOKING_STATUS_PENDING
+	case entities.Approved, entities.Completed, entities.NoShow:
+		return pb.BookingStatus_BOOKING_STATUS_APPROVED
+	case entities.Cancelled:
+		return pb.BookingStatus_BOOKING_STATUS_CANCELLED
+	default:
+		return pb.BookingStatus_BOOKING_STATUS_UNSPECIFIED
+	}
+}
+
+func valueOrZero(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
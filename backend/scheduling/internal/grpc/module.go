@@ -0,0 +1,28 @@
+package grpc
+
+//go:generate protoc --proto_path=../../proto --go_out=../.. --go_opt=module=github.com/maksmelnyk/scheduling --go-grpc_out=../.. --go-grpc_opt=module=github.com/maksmelnyk/scheduling scheduling/v1/scheduling.proto
+
+import (
+	"github.com/jmoiron/sqlx"
+	gogrpc "google.golang.org/grpc"
+
+	"github.com/maksmelnyk/scheduling/config"
+	pb "github.com/maksmelnyk/scheduling/internal/grpc/schedulingv1"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeGrpcServer(log logger.Logger, db *sqlx.DB, bookings HoldConfirmer, readOnly ReadOnlyGuard, cfg *config.GrpcConfig, tenancy *config.TenancyConfig) *gogrpc.Server {
+	repo := NewLookupRepository(db)
+	server := NewSchedulingServer(log, repo, bookings)
+
+	grpcServer := gogrpc.NewServer(gogrpc.ChainUnaryInterceptor(
+		RecoveryInterceptor(log),
+		LoggingInterceptor(log),
+		AuthInterceptor(cfg.ServiceToken),
+		TenantInterceptor(tenancy),
+		ReadOnlyInterceptor(readOnly),
+		ErrorInterceptor(),
+	))
+	pb.RegisterSchedulingServiceServer(grpcServer, server)
+	return grpcServer
+}
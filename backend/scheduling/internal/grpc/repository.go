@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type LookupRepo struct {
+	db *sqlx.DB
+}
+
+func NewLookupRepository(db *sqlx.DB) *LookupRepo {
+	return &LookupRepo{db: db}
+}
+
+// GetEducatorWorkingPeriods retrieves the working periods of an educator starting within a time range
+func (r *LookupRepo) GetEducatorWorkingPeriods(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.WorkingPeriod, error) {
+	const query = `
+		SELECT id, user_id, start_time, end_time, recurrence_id, created_at, updated_at
+		FROM working_period
+		WHERE user_id = $1 AND start_time >= $2 AND start_time < $3 AND deleted_at IS NULL
+		ORDER BY start_time, id
+	`
+	return database.FetchMultiple[entities.WorkingPeriod](ctx, r.db, query, educatorId, from, to)
+}
+
+// GetEducatorScheduledEvents retrieves the scheduled events of an educator starting within a time range
+func (r *LookupRepo) GetEducatorScheduledEvents(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.ScheduledEvent, error) {
+	const query = `
+		SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, created_at, updated_at
+		FROM scheduled_event
+		WHERE user_id = $1 AND start_time >= $2 AND start_time < $3 AND deleted_at IS NULL
+		ORDER BY start_time, id
+	`
+	return database.FetchMultiple[entities.ScheduledEvent](ctx, r.db, query, educatorId, from, to)
+}
+
+// GetScheduledEventById retrieves a scheduled event of any educator
+func (r *LookupRepo) GetScheduledEventById(ctx context.Context, id int64) (*entities.ScheduledEvent, error) {
+	const query = `
+		SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, created_at, updated_at
+		FROM scheduled_event
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+	return database.FetchSingle[entities.ScheduledEvent](ctx, r.db, query, id)
+}
+
+// CountTakenPlaces counts the active bookings of a scheduled event and the places offered from its waitlist
+// that have not expired yet
+func (r *LookupRepo) CountTakenPlaces(ctx context.Context, scheduledEventId int64, now time.Time) (int, error) {
+	const query = `
+		SELECT (SELECT COUNT(*) FROM booking WHERE scheduled_event_id = $1 AND status != $2)
+			+ (SELECT COUNT(*) FROM waitlist_entry WHERE scheduled_event_id = $1 AND status = $3 AND offer_expires_at > $4)
+	`
+	var count int
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &count, query, scheduledEventId, entities.Cancelled, entities.WaitlistOffered, now); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return count, nil
+}
+
+// GetBookingById retrieves a booking of any educator
+func (r *LookupRepo) GetBookingById(ctx context.Context, id int64) (*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at
+		FROM booking
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.Booking](ctx, r.db, query, id)
+}
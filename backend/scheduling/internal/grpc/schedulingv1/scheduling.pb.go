@@ -0,0 +1,799 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: scheduling/v1/scheduling.proto
+
+package schedulingv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BookingStatus int32
+
+const (
+	BookingStatus_BOOKING_STATUS_UNSPECIFIED BookingStatus = 0
+	BookingStatus_BOOKING_STATUS_PENDING     BookingStatus = 1
+	BookingStatus_BOOKING_STATUS_APPROVED    BookingStatus = 2
+	BookingStatus_BOOKING_STATUS_CANCELLED   BookingStatus = 3
+)
+
+// Enum value maps for BookingStatus.
+var (
+	BookingStatus_name = map[int32]string{
+		0: "BOOKING_STATUS_UNSPECIFIED",
+		1: "BOOKING_STATUS_PENDING",
+		2: "BOOKING_STATUS_APPROVED",
+		3: "BOOKING_STATUS_CANCELLED",
+	}
+	BookingStatus_value = map[string]int32{
+		"BOOKING_STATUS_UNSPECIFIED": 0,
+		"BOOKING_STATUS_PENDING":     1,
+		"BOOKING_STATUS_APPROVED":    2,
+		"BOOKING_STATUS_CANCELLED":   3,
+	}
+)
+
+func (x BookingStatus) Enum() *BookingStatus {
+	p := new(BookingStatus)
+	*p = x
+	return p
+}
+
+func (x BookingStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BookingStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_scheduling_v1_scheduling_proto_enumTypes[0].Descriptor()
+}
+
+func (BookingStatus) Type() protoreflect.EnumType {
+	return &file_scheduling_v1_scheduling_proto_enumTypes[0]
+}
+
+func (x BookingStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BookingStatus.Descriptor instead.
+func (BookingStatus) EnumDescriptor() ([]byte, []int) {
+	return file_scheduling_v1_scheduling_proto_rawDescGZIP(), []int{0}
+}
+
+type GetEducatorScheduleRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EducatorId string                 `protobuf:"bytes,1,opt,name=educator_id,json=educatorId,proto3" json:"educator_id,omitempty"`
+	From       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// to must be after from and at most 31 days later
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEducatorScheduleRequest) Reset() {
+	*x = GetEducatorScheduleRequest{}
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEducatorScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEducatorScheduleRequest) ProtoMessage() {}
+
+func (x *GetEducatorScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEducatorScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetEducatorScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_scheduling_v1_scheduling_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetEducatorScheduleRequest) GetEducatorId() string {
+	if x != nil {
+		return x.EducatorId
+	}
+	return ""
+}
+
+func (x *GetEducatorScheduleRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetEducatorScheduleRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetEducatorScheduleResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WorkingPeriods  []*WorkingPeriod       `protobuf:"bytes,1,rep,name=working_periods,json=workingPeriods,proto3" json:"working_periods,omitempty"`
+	ScheduledEvents []*ScheduledEvent      `protobuf:"bytes,2,rep,name=scheduled_events,json=scheduledEvents,proto3" json:"scheduled_events,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetEducatorScheduleResponse) Reset() {
+	*x = GetEducatorScheduleResponse{}
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEducatorScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEducatorScheduleResponse) ProtoMessage() {}
+
+func (x *GetEducatorScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEducatorScheduleResponse.ProtoReflect.Descriptor instead.
+func (*GetEducatorScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_scheduling_v1_scheduling_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetEducatorScheduleResponse) GetWorkingPeriods() []*WorkingPeriod {
+	if x != nil {
+		return x.WorkingPeriods
+	}
+	return nil
+}
+
+func (x *GetEducatorScheduleResponse) GetScheduledEvents() []*ScheduledEvent {
+	if x != nil {
+		return x.ScheduledEvents
+	}
+	return nil
+}
+
+type WorkingPeriod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EducatorId    string                 `protobuf:"bytes,2,opt,name=educator_id,json=educatorId,proto3" json:"educator_id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkingPeriod) Reset() {
+	*x = WorkingPeriod{}
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkingPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkingPeriod) ProtoMessage() {}
+
+func (x *WorkingPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkingPeriod.ProtoReflect.Descriptor instead.
+func (*WorkingPeriod) Descriptor() ([]byte, []int) {
+	return file_scheduling_v1_scheduling_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WorkingPeriod) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WorkingPeriod) GetEducatorId() string {
+	if x != nil {
+		return x.EducatorId
+	}
+	return ""
+}
+
+func (x *WorkingPeriod) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *WorkingPeriod) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type ScheduledEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EducatorId string                 `protobuf:"bytes,2,opt,name=educator_id,json=educatorId,proto3" json:"educator_id,omitempty"`
+	ProductId  int64                  `protobuf:"varint,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// lesson_id is 0 for events that are not a lesson of a course
+	LessonId        int64                  `protobuf:"varint,4,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	WorkingPeriodId int64                  `protobuf:"varint,5,opt,name=working_period_id,json=workingPeriodId,proto3" json:"working_period_id,omitempty"`
+	Title           string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`
+	StartTime       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	MaxParticipants int32                  `protobuf:"varint,9,opt,name=max_participants,json=maxParticipants,proto3" json:"max_participants,omitempty"`
+	// available_places is only set by GetScheduledEvent
+	AvailablePlaces int32   `protobuf:"varint,10,opt,name=available_places,json=availablePlaces,proto3" json:"available_places,omitempty"`
+	Price           float64 `protobuf:"fixed64,11,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ScheduledEvent) Reset() {
+	*x = ScheduledEvent{}
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledEvent) ProtoMessage() {}
+
+func (x *ScheduledEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledEvent.ProtoReflect.Descriptor instead.
+func (*ScheduledEvent) Descriptor() ([]byte, []int) {
+	return file_scheduling_v1_scheduling_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ScheduledEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ScheduledEvent) GetEducatorId() string {
+	if x != nil {
+		return x.EducatorId
+	}
+	return ""
+}
+
+func (x *ScheduledEvent) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ScheduledEvent) GetLessonId() int64 {
+	if x != nil {
+		return x.LessonId
+	}
+	return 0
+}
+
+func (x *ScheduledEvent) GetWorkingPeriodId() int64 {
+	if x != nil {
+		return x.WorkingPeriodId
+	}
+	return 0
+}
+
+func (x *ScheduledEvent) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ScheduledEvent) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ScheduledEvent) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ScheduledEvent) GetMaxParticipants() int32 {
+	if x != nil {
+		return x.MaxParticipants
+	}
+	return 0
+}
+
+func (x *ScheduledEvent) GetAvailablePlaces() int32 {
+	if x != nil {
+		return x.AvailablePlaces
+	}
+	return 0
+}
+
+func (x *ScheduledEvent) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type GetScheduledEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduledEventRequest) Reset() {
+	*x = GetScheduledEventRequest{}
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduledEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduledEventRequest) ProtoMessage() {}
+
+func (x *GetScheduledEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduledEventRequest.ProtoReflect.Descriptor instead.
+func (*GetScheduledEventRequest) Descriptor() ([]byte, []int) {
+	return file_scheduling_v1_scheduling_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetScheduledEventRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetBookingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBookingRequest) Reset() {
+	*x = GetBookingRequest{}
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookingRequest) ProtoMessage() {}
+
+func (x *GetBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookingRequest.ProtoReflect.Descriptor instead.
+func (*GetBookingRequest) Descriptor() ([]byte, []int) {
+	return file_scheduling_v1_scheduling_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetBookingRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type Booking struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	EducatorId string                 `protobuf:"bytes,2,opt,name=educator_id,json=educatorId,proto3" json:"educator_id,omitempty"`
+	StudentId  string                 `protobuf:"bytes,3,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	ProductId  int64                  `protobuf:"varint,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// scheduled_event_id is 0 for individual sessions
+	ScheduledEventId int64 `protobuf:"varint,5,opt,name=scheduled_event_id,json=scheduledEventId,proto3" json:"scheduled_event_id,omitempty"`
+	// enrollment_id is 0 for bookings made without an enrollment
+	EnrollmentId  int64                  `protobuf:"varint,6,opt,name=enrollment_id,json=enrollmentId,proto3" json:"enrollment_id,omitempty"`
+	Title         string                 `protobuf:"bytes,7,opt,name=title,proto3" json:"title,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Status        BookingStatus          `protobuf:"varint,10,opt,name=status,proto3,enum=scheduling.v1.BookingStatus" json:"status,omitempty"`
+	Price         float64                `protobuf:"fixed64,11,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Booking) Reset() {
+	*x = Booking{}
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Booking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Booking) ProtoMessage() {}
+
+func (x *Booking) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Booking.ProtoReflect.Descriptor instead.
+func (*Booking) Descriptor() ([]byte, []int) {
+	return file_scheduling_v1_scheduling_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Booking) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Booking) GetEducatorId() string {
+	if x != nil {
+		return x.EducatorId
+	}
+	return ""
+}
+
+func (x *Booking) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+func (x *Booking) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *Booking) GetScheduledEventId() int64 {
+	if x != nil {
+		return x.ScheduledEventId
+	}
+	return 0
+}
+
+func (x *Booking) GetEnrollmentId() int64 {
+	if x != nil {
+		return x.EnrollmentId
+	}
+	return 0
+}
+
+func (x *Booking) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Booking) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Booking) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *Booking) GetStatus() BookingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BookingStatus_BOOKING_STATUS_UNSPECIFIED
+}
+
+func (x *Booking) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type ConfirmBookingHoldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        int64                  `protobuf:"varint,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	StudentId     string                 `protobuf:"bytes,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmBookingHoldRequest) Reset() {
+	*x = ConfirmBookingHoldRequest{}
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmBookingHoldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmBookingHoldRequest) ProtoMessage() {}
+
+func (x *ConfirmBookingHoldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scheduling_v1_scheduling_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmBookingHoldRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmBookingHoldRequest) Descriptor() ([]byte, []int) {
+	return file_scheduling_v1_scheduling_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ConfirmBookingHoldRequest) GetHoldId() int64 {
+	if x != nil {
+		return x.HoldId
+	}
+	return 0
+}
+
+func (x *ConfirmBookingHoldRequest) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+var File_scheduling_v1_scheduling_proto protoreflect.FileDescriptor
+
+const file_scheduling_v1_scheduling_proto_rawDesc = "" +
+	"\n" +
+	"\x1escheduling/v1/scheduling.proto\x12\rscheduling.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x99\x01\n" +
+	"\x1aGetEducatorScheduleRequest\x12\x1f\n" +
+	"\veducator_id\x18\x01 \x01(\tR\n" +
+	"educatorId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xae\x01\n" +
+	"\x1bGetEducatorScheduleResponse\x12E\n" +
+	"\x0fworking_periods\x18\x01 \x03(\v2\x1c.scheduling.v1.WorkingPeriodR\x0eworkingPeriods\x12H\n" +
+	"\x10scheduled_events\x18\x02 \x03(\v2\x1d.scheduling.v1.ScheduledEventR\x0fscheduledEvents\"\xb2\x01\n" +
+	"\rWorkingPeriod\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\veducator_id\x18\x02 \x01(\tR\n" +
+	"educatorId\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"\x9d\x03\n" +
+	"\x0eScheduledEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\veducator_id\x18\x02 \x01(\tR\n" +
+	"educatorId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\x03R\tproductId\x12\x1b\n" +
+	"\tlesson_id\x18\x04 \x01(\x03R\blessonId\x12*\n" +
+	"\x11working_period_id\x18\x05 \x01(\x03R\x0fworkingPeriodId\x12\x14\n" +
+	"\x05title\x18\x06 \x01(\tR\x05title\x129\n" +
+	"\n" +
+	"start_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12)\n" +
+	"\x10max_participants\x18\t \x01(\x05R\x0fmaxParticipants\x12)\n" +
+	"\x10available_places\x18\n" +
+	" \x01(\x05R\x0favailablePlaces\x12\x14\n" +
+	"\x05price\x18\v \x01(\x01R\x05price\"*\n" +
+	"\x18GetScheduledEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"#\n" +
+	"\x11GetBookingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x9f\x03\n" +
+	"\aBooking\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\veducator_id\x18\x02 \x01(\tR\n" +
+	"educatorId\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x03 \x01(\tR\tstudentId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\x03R\tproductId\x12,\n" +
+	"\x12scheduled_event_id\x18\x05 \x01(\x03R\x10scheduledEventId\x12#\n" +
+	"\renrollment_id\x18\x06 \x01(\x03R\fenrollmentId\x12\x14\n" +
+	"\x05title\x18\a \x01(\tR\x05title\x129\n" +
+	"\n" +
+	"start_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x124\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x1c.scheduling.v1.BookingStatusR\x06status\x12\x14\n" +
+	"\x05price\x18\v \x01(\x01R\x05price\"S\n" +
+	"\x19ConfirmBookingHoldRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\x03R\x06holdId\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\tR\tstudentId*\x86\x01\n" +
+	"\rBookingStatus\x12\x1e\n" +
+	"\x1aBOOKING_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16BOOKING_STATUS_PENDING\x10\x01\x12\x1b\n" +
+	"\x17BOOKING_STATUS_APPROVED\x10\x02\x12\x1c\n" +
+	"\x18BOOKING_STATUS_CANCELLED\x10\x032\xfe\x02\n" +
+	"\x11SchedulingService\x12l\n" +
+	"\x13GetEducatorSchedule\x12).scheduling.v1.GetEducatorScheduleRequest\x1a*.scheduling.v1.GetEducatorScheduleResponse\x12[\n" +
+	"\x11GetScheduledEvent\x12'.scheduling.v1.GetScheduledEventRequest\x1a\x1d.scheduling.v1.ScheduledEvent\x12F\n" +
+	"\n" +
+	"GetBooking\x12 .scheduling.v1.GetBookingRequest\x1a\x16.scheduling.v1.Booking\x12V\n" +
+	"\x12ConfirmBookingHold\x12(.scheduling.v1.ConfirmBookingHoldRequest\x1a\x16.scheduling.v1.BookingBJZHgithub.com/maksmelnyk/scheduling/internal/grpc/schedulingv1;schedulingv1b\x06proto3"
+
+var (
+	file_scheduling_v1_scheduling_proto_rawDescOnce sync.Once
+	file_scheduling_v1_scheduling_proto_rawDescData []byte
+)
+
+func file_scheduling_v1_scheduling_proto_rawDescGZIP() []byte {
+	file_scheduling_v1_scheduling_proto_rawDescOnce.Do(func() {
+		file_scheduling_v1_scheduling_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scheduling_v1_scheduling_proto_rawDesc), len(file_scheduling_v1_scheduling_proto_rawDesc)))
+	})
+	return file_scheduling_v1_scheduling_proto_rawDescData
+}
+
+var file_scheduling_v1_scheduling_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_scheduling_v1_scheduling_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_scheduling_v1_scheduling_proto_goTypes = []any{
+	(BookingStatus)(0),                  // 0: scheduling.v1.BookingStatus
+	(*GetEducatorScheduleRequest)(nil),  // 1: scheduling.v1.GetEducatorScheduleRequest
+	(*GetEducatorScheduleResponse)(nil), // 2: scheduling.v1.GetEducatorScheduleResponse
+	(*WorkingPeriod)(nil),               // 3: scheduling.v1.WorkingPeriod
+	(*ScheduledEvent)(nil),              // 4: scheduling.v1.ScheduledEvent
+	(*GetScheduledEventRequest)(nil),    // 5: scheduling.v1.GetScheduledEventRequest
+	(*GetBookingRequest)(nil),           // 6: scheduling.v1.GetBookingRequest
+	(*Booking)(nil),                     // 7: scheduling.v1.Booking
+	(*ConfirmBookingHoldRequest)(nil),   // 8: scheduling.v1.ConfirmBookingHoldRequest
+	(*timestamppb.Timestamp)(nil),       // 9: google.protobuf.Timestamp
+}
+var file_scheduling_v1_scheduling_proto_depIdxs = []int32{
+	9,  // 0: scheduling.v1.GetEducatorScheduleRequest.from:type_name -> google.protobuf.Timestamp
+	9,  // 1: scheduling.v1.GetEducatorScheduleRequest.to:type_name -> google.protobuf.Timestamp
+	3,  // 2: scheduling.v1.GetEducatorScheduleResponse.working_periods:type_name -> scheduling.v1.WorkingPeriod
+	4,  // 3: scheduling.v1.GetEducatorScheduleResponse.scheduled_events:type_name -> scheduling.v1.ScheduledEvent
+	9,  // 4: scheduling.v1.WorkingPeriod.start_time:type_name -> google.protobuf.Timestamp
+	9,  // 5: scheduling.v1.WorkingPeriod.end_time:type_name -> google.protobuf.Timestamp
+	9,  // 6: scheduling.v1.ScheduledEvent.start_time:type_name -> google.protobuf.Timestamp
+	9,  // 7: scheduling.v1.ScheduledEvent.end_time:type_name -> google.protobuf.Timestamp
+	9,  // 8: scheduling.v1.Booking.start_time:type_name -> google.protobuf.Timestamp
+	9,  // 9: scheduling.v1.Booking.end_time:type_name -> google.protobuf.Timestamp
+	0,  // 10: scheduling.v1.Booking.status:type_name -> scheduling.v1.BookingStatus
+	1,  // 11: scheduling.v1.SchedulingService.GetEducatorSchedule:input_type -> scheduling.v1.GetEducatorScheduleRequest
+	5,  // 12: scheduling.v1.SchedulingService.GetScheduledEvent:input_type -> scheduling.v1.GetScheduledEventRequest
+	6,  // 13: scheduling.v1.SchedulingService.GetBooking:input_type -> scheduling.v1.GetBookingRequest
+	8,  // 14: scheduling.v1.SchedulingService.ConfirmBookingHold:input_type -> scheduling.v1.ConfirmBookingHoldRequest
+	2,  // 15: scheduling.v1.SchedulingService.GetEducatorSchedule:output_type -> scheduling.v1.GetEducatorScheduleResponse
+	4,  // 16: scheduling.v1.SchedulingService.GetScheduledEvent:output_type -> scheduling.v1.ScheduledEvent
+	7,  // 17: scheduling.v1.SchedulingService.GetBooking:output_type -> scheduling.v1.Booking
+	7,  // 18: scheduling.v1.SchedulingService.ConfirmBookingHold:output_type -> scheduling.v1.Booking
+	15, // [15:19] is the sub-list for method output_type
+	11, // [11:15] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_scheduling_v1_scheduling_proto_init() }
+func file_scheduling_v1_scheduling_proto_init() {
+	if File_scheduling_v1_scheduling_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scheduling_v1_scheduling_proto_rawDesc), len(file_scheduling_v1_scheduling_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_scheduling_v1_scheduling_proto_goTypes,
+		DependencyIndexes: file_scheduling_v1_scheduling_proto_depIdxs,
+		EnumInfos:         file_scheduling_v1_scheduling_proto_enumTypes,
+		MessageInfos:      file_scheduling_v1_scheduling_proto_msgTypes,
+	}.Build()
+	File_scheduling_v1_scheduling_proto = out.File
+	file_scheduling_v1_scheduling_proto_goTypes = nil
+	file_scheduling_v1_scheduling_proto_depIdxs = nil
+}
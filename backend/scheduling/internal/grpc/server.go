@@ -0,0 +1,116 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	pb "github.com/maksmelnyk/scheduling/internal/grpc/schedulingv1"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// maxScheduleRange bounds the time range of a single schedule lookup
+const maxScheduleRange = 31 * 24 * time.Hour
+
+type LookupRepository interface {
+	GetEducatorWorkingPeriods(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.WorkingPeriod, error)
+	GetEducatorScheduledEvents(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.ScheduledEvent, error)
+	GetScheduledEventById(ctx context.Context, id int64) (*entities.ScheduledEvent, error)
+	CountTakenPlaces(ctx context.Context, scheduledEventId int64, now time.Time) (int, error)
+	GetBookingById(ctx context.Context, id int64) (*entities.Booking, error)
+}
+
+// HoldConfirmer converts booking holds into bookings the way a completed payment does
+type HoldConfirmer interface {
+	ConfirmStudentBookingHold(ctx context.Context, id int64, studentId uuid.UUID) (*entities.Booking, error)
+}
+
+// SchedulingServer implements the internal gRPC API. Callers are backend services rather than users, so
+// lookups are not limited to the schedule of a caller.
+type SchedulingServer struct {
+	pb.UnimplementedSchedulingServiceServer
+	log      logger.Logger
+	repo     LookupRepository
+	bookings HoldConfirmer
+}
+
+func NewSchedulingServer(log logger.Logger, repo LookupRepository, bookings HoldConfirmer) *SchedulingServer {
+	return &SchedulingServer{log: log, repo: repo, bookings: bookings}
+}
+
+func (s *SchedulingServer) GetEducatorSchedule(ctx context.Context, request *pb.GetEducatorScheduleRequest) (*pb.GetEducatorScheduleResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	educatorId, err := uuid.Parse(request.GetEducatorId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "educator_id must be a UUID")
+	}
+
+	if !request.GetFrom().IsValid() || !request.GetTo().IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "from and to are required")
+	}
+	from, to := request.GetFrom().AsTime(), request.GetTo().AsTime()
+	if !to.After(from) || to.Sub(from) > maxScheduleRange {
+		return nil, status.Error(codes.InvalidArgument, "to must be after from and at most 31 days later")
+	}
+
+	workingPeriods, err := s.repo.GetEducatorWorkingPeriods(ctx, educatorId, from, to)
+	if err != nil {
+		log.Error("Failed to get working periods", err)
+		return nil, err
+	}
+
+	events, err := s.repo.GetEducatorScheduledEvents(ctx, educatorId, from, to)
+	if err != nil {
+		log.Error("Failed to get scheduled events", err)
+		return nil, err
+	}
+
+	return MapScheduleToMessage(workingPeriods, events), nil
+}
+
+func (s *SchedulingServer) GetScheduledEvent(ctx context.Context, request *pb.GetScheduledEventRequest) (*pb.ScheduledEvent, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	event, err := s.repo.GetScheduledEventById(ctx, request.GetId())
+	if err != nil {
+		log.Error("Failed to get scheduled event", err)
+		return nil, err
+	}
+
+	taken, err := s.repo.CountTakenPlaces(ctx, event.Id, time.Now().UTC())
+	if err != nil {
+		log.Error("Failed to count taken places", err)
+		return nil, err
+	}
+
+	message := MapScheduledEventToMessage(event)
+	message.AvailablePlaces = int32(max(event.MaxParticipants-taken, 0))
+	return message, nil
+}
+
+func (s *SchedulingServer) GetBooking(ctx context.Context, request *pb.GetBookingRequest) (*pb.Booking, error) {
+	booking, err := s.repo.GetBookingById(ctx, request.GetId())
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("Failed to get booking", err)
+		return nil, err
+	}
+	return MapBookingToMessage(booking), nil
+}
+
+func (s *SchedulingServer) ConfirmBookingHold(ctx context.Context, request *pb.ConfirmBookingHoldRequest) (*pb.Booking, error) {
+	studentId, err := uuid.Parse(request.GetStudentId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "student_id must be a UUID")
+	}
+
+	booking, err := s.bookings.ConfirmStudentBookingHold(ctx, request.GetHoldId(), studentId)
+	if err != nil {
+		return nil, err
+	}
+	return MapBookingToMessage(booking), nil
+}
@@ -0,0 +1,93 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of a circuit breaker as exported in the breaker state metric
+type BreakerState int
+
+const (
+	// BreakerClosed lets every call through
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails calls without making them until the cooldown passed
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe through to decide whether to close or open again
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// Breaker opens after consecutive failures and probes the upstream with a single call once the cooldown
+// passed. A successful probe closes it, a failed one opens it for another cooldown.
+type Breaker struct {
+	maxFailures int
+	cooldown    time.Duration
+	mu          sync.Mutex
+	failures    int
+	openedAt    time.Time
+	probing     bool
+}
+
+func NewBreaker(maxFailures int, cooldown time.Duration) *Breaker {
+	return &Breaker{maxFailures: max(maxFailures, 1), cooldown: cooldown}
+}
+
+// Allow reports whether a call may be made right now. Once the cooldown passed only the first caller gets
+// through as the probe, the others are refused until it is recorded.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.maxFailures {
+		return true
+	}
+	if b.probing || time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+
+	b.probing = true
+	return true
+}
+
+// Record updates the breaker with the outcome of an allowed call
+func (b *Breaker) Record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.maxFailures {
+		b.openedAt = time.Now()
+	}
+}
+
+// State returns the current state of the breaker
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.failures < b.maxFailures:
+		return BreakerClosed
+	case b.probing || time.Since(b.openedAt) >= b.cooldown:
+		return BreakerHalfOpen
+	default:
+		return BreakerOpen
+	}
+}
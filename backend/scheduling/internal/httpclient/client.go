@@ -0,0 +1,183 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	ErrCircuitOpen     = errors.New("circuit breaker open")
+	ErrBudgetExhausted = errors.New("timeout budget exhausted")
+)
+
+// Policy is how a client retries and when its breaker opens. Attempts that fail to connect, time out or
+// get a server error are retried with full jitter exponential backoff as long as the budget allows.
+type Policy struct {
+	// MaxAttempts is how many times a request is tried at most, 1 disables retries
+	MaxAttempts int
+	// AttemptTimeout bounds a single attempt, response body included
+	AttemptTimeout time.Duration
+	// Budget bounds all attempts and backoffs of a request together
+	Budget time.Duration
+	// BaseBackoff and MaxBackoff bound the wait before a retry, doubled on every attempt
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// BreakerFailures is how many failed attempts in a row open the breaker for BreakerCooldown
+	BreakerFailures int
+	BreakerCooldown time.Duration
+}
+
+// Client makes requests to a single upstream through a retry policy and a circuit breaker, so a slow or
+// failing upstream costs at most the budget of a request and is not called at all while the breaker is open
+type Client struct {
+	name       string
+	httpClient *http.Client
+	policy     Policy
+	breaker    *Breaker
+	metrics    *clientMetrics
+}
+
+// NewClient wraps an http client for the upstream the name identifies in metrics
+func NewClient(name string, httpClient *http.Client, policy Policy, meter metric.Meter) (*Client, error) {
+	policy.MaxAttempts = max(policy.MaxAttempts, 1)
+	breaker := NewBreaker(policy.BreakerFailures, policy.BreakerCooldown)
+
+	metrics, err := newClientMetrics(meter, name, breaker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register %s client metrics: %w", name, err)
+	}
+
+	return &Client{name: name, httpClient: httpClient, policy: policy, breaker: breaker, metrics: metrics}, nil
+}
+
+// Breaker returns the circuit breaker of the client
+func (c *Client) Breaker() *Breaker {
+	return c.breaker
+}
+
+// Do sends a request like http.Client.Do. The response of the last attempt is returned even for a server
+// error, ErrCircuitOpen when the breaker refused the call and ErrBudgetExhausted when the budget ran out
+// before any attempt got a response. Requests with a body are only retried when it can be rewound.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx, cancel := contextWithBudget(req.Context(), c.policy.Budget)
+
+	var lastErr error
+	for attempt := 0; attempt < c.policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			if !c.wait(ctx, attempt) {
+				break
+			}
+			if req.GetBody == nil && req.Body != nil && req.Body != http.NoBody {
+				break
+			}
+		}
+
+		if !c.breaker.Allow() {
+			c.metrics.recordAttempt(ctx, c.name, "rejected")
+			cancel()
+			return nil, fmt.Errorf("%s: %w", c.name, ErrCircuitOpen)
+		}
+
+		resp, attemptCancel, err := c.attempt(ctx, req, attempt)
+		retryable := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		c.breaker.Record(retryable)
+
+		switch {
+		case err != nil:
+			c.metrics.recordAttempt(ctx, c.name, "error")
+			lastErr = err
+		case retryable && attempt < c.policy.MaxAttempts-1:
+			c.metrics.recordAttempt(ctx, c.name, "server_error")
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			attemptCancel()
+			lastErr = fmt.Errorf("%s responded with status %d", c.name, resp.StatusCode)
+		default:
+			if retryable {
+				c.metrics.recordAttempt(ctx, c.name, "server_error")
+			} else {
+				c.metrics.recordAttempt(ctx, c.name, "success")
+			}
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: func() { attemptCancel(); cancel() }}
+			return resp, nil
+		}
+	}
+
+	defer cancel()
+	if ctx.Err() != nil && req.Context().Err() == nil {
+		return nil, fmt.Errorf("%s: %w: %w", c.name, ErrBudgetExhausted, lastErr)
+	}
+	return nil, lastErr
+}
+
+func (c *Client) attempt(ctx context.Context, req *http.Request, attempt int) (*http.Response, context.CancelFunc, error) {
+	attemptCtx, cancel := contextWithBudget(ctx, c.policy.AttemptTimeout)
+
+	r := req.Clone(attemptCtx)
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		r.Body = body
+	}
+
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return resp, cancel, nil
+}
+
+// wait sleeps before a retry, reporting false when the request context ends or the budget is too short
+// for the backoff
+func (c *Client) wait(ctx context.Context, attempt int) bool {
+	backoff := c.policy.BaseBackoff << (attempt - 1)
+	if c.policy.MaxBackoff > 0 && (backoff > c.policy.MaxBackoff || backoff <= 0) {
+		backoff = c.policy.MaxBackoff
+	}
+	if backoff > 0 {
+		backoff = rand.N(backoff) + 1
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= backoff {
+		return false
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func contextWithBudget(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// cancelOnClose releases the contexts of a request once its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel func()
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
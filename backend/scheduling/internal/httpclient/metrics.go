@@ -0,0 +1,43 @@
+package httpclient
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type clientMetrics struct {
+	attempts metric.Int64Counter
+}
+
+// newClientMetrics registers the attempt counter and the breaker state gauge of a named client. The gauge
+// reports 0 while the breaker is closed, 1 while it is open and 2 while it is half-open.
+func newClientMetrics(meter metric.Meter, name string, breaker *Breaker) (*clientMetrics, error) {
+	attempts, err := meter.Int64Counter("scheduling.http.client.attempts",
+		metric.WithDescription("Outbound request attempts, by client and outcome"))
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := meter.Int64ObservableGauge("scheduling.http.client.breaker.state",
+		metric.WithDescription("Circuit breaker state by client: 0 closed, 1 open, 2 half-open"))
+	if err != nil {
+		return nil, err
+	}
+
+	clientAttr := metric.WithAttributes(attribute.String("client", name))
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(state, int64(breaker.State()), clientAttr)
+		return nil
+	}, state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clientMetrics{attempts: attempts}, nil
+}
+
+func (m *clientMetrics) recordAttempt(ctx context.Context, name string, outcome string) {
+	m.attempts.Add(ctx, 1, metric.WithAttributes(attribute.String("client", name), attribute.String("outcome", outcome)))
+}
@@ -0,0 +1,207 @@
+package i18n
+
+import (
+	"cmp"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+const (
+	maxTranslations       = 30
+	maxTitleLength        = 200
+	maxDescriptionLength  = 2000
+	maxAcceptedLanguages  = 10
+	defaultLanguageWeight = 1.0
+)
+
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+type localesKey struct{}
+
+// WithLocales returns a context carrying the locales preferred by the caller, most preferred first
+func WithLocales(ctx context.Context, locales []string) context.Context {
+	return context.WithValue(ctx, localesKey{}, locales)
+}
+
+// Locales returns the preferred locales of the request, none outside of a request or without Accept-Language
+func Locales(ctx context.Context) []string {
+	locales, _ := ctx.Value(localesKey{}).([]string)
+	return locales
+}
+
+// ParseAcceptLanguage returns the locales of an Accept-Language header by descending quality. Wildcards,
+// invalid tags and tags with zero quality are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	var tags []weighted
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if !localePattern.MatchString(tag) {
+			continue
+		}
+
+		q := defaultLanguageWeight
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+
+		tags = append(tags, weighted{locale: Canonical(tag), q: q})
+		if len(tags) == maxAcceptedLanguages {
+			break
+		}
+	}
+
+	slices.SortStableFunc(tags, func(a, b weighted) int { return cmp.Compare(b.q, a.q) })
+
+	locales := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if !slices.Contains(locales, t.locale) {
+			locales = append(locales, t.locale)
+		}
+	}
+	return locales
+}
+
+// Canonical formats a locale the BCP 47 way: lowercase language, uppercase region and titlecase script,
+// such as en, pt-BR or zh-Hant-TW
+func Canonical(locale string) string {
+	parts := strings.Split(locale, "-")
+	parts[0] = strings.ToLower(parts[0])
+	for i := 1; i < len(parts); i++ {
+		switch {
+		case len(parts[i]) == 2:
+			parts[i] = strings.ToUpper(parts[i])
+		case len(parts[i]) == 4:
+			parts[i] = strings.ToUpper(parts[i][:1]) + strings.ToLower(parts[i][1:])
+		default:
+			parts[i] = strings.ToLower(parts[i])
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+func language(locale string) string {
+	lang, _, _ := strings.Cut(locale, "-")
+	return lang
+}
+
+// Translation is the text of an offering in one locale. An empty description leaves the default one.
+type Translation struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// Translations maps locales to translated texts, stored as a JSONB column
+type Translations map[string]Translation
+
+func (t Translations) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (t *Translations) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	default:
+		return fmt.Errorf("unsupported translations type %T", src)
+	}
+}
+
+// Normalized returns the translations keyed by canonical locale with trimmed texts
+func (t Translations) Normalized() Translations {
+	normalized := make(Translations, len(t))
+	for locale, tr := range t {
+		normalized[Canonical(locale)] = Translation{Title: strings.TrimSpace(tr.Title), Description: strings.TrimSpace(tr.Description)}
+	}
+	return normalized
+}
+
+// Validate checks the locales and text lengths of the translations of a request field
+func (t Translations) Validate(field string) []apperrors.ValidationErrorDetail {
+	var errs []apperrors.ValidationErrorDetail
+
+	if len(t) > maxTranslations {
+		errs = append(errs, apperrors.ValidationErrorDetail{
+			Field:   field,
+			Message: fmt.Sprintf("must have at most %d locales", maxTranslations),
+		})
+	}
+
+	for _, locale := range slices.Sorted(maps.Keys(t)) {
+		tr := t[locale]
+		if !localePattern.MatchString(locale) {
+			errs = append(errs, apperrors.ValidationErrorDetail{
+				Field:   field,
+				Message: fmt.Sprintf("locale %q must be a language tag such as de or pt-BR", locale),
+			})
+		}
+		if title := strings.TrimSpace(tr.Title); title == "" || utf8.RuneCountInString(title) > maxTitleLength {
+			errs = append(errs, apperrors.ValidationErrorDetail{
+				Field:   field + "." + locale + ".Title",
+				Message: fmt.Sprintf("must be between 1 and %d characters", maxTitleLength),
+			})
+		}
+		if utf8.RuneCountInString(tr.Description) > maxDescriptionLength {
+			errs = append(errs, apperrors.ValidationErrorDetail{
+				Field:   field + "." + locale + ".Description",
+				Message: fmt.Sprintf("must be at most %d characters", maxDescriptionLength),
+			})
+		}
+	}
+	return errs
+}
+
+// Translate returns the title and description in the first preferred locale that has a translation, matching
+// a region-specific locale to its language and the other way round. The defaults are returned with an empty
+// locale when none matches.
+func (t Translations) Translate(locales []string, title, description string) (string, string, string) {
+	for _, locale := range locales {
+		if tr, ok := t[locale]; ok {
+			return tr.Title, cmp.Or(tr.Description, description), locale
+		}
+		if tr, ok := t[language(locale)]; ok {
+			return tr.Title, cmp.Or(tr.Description, description), language(locale)
+		}
+		for _, candidate := range slices.Sorted(maps.Keys(t)) {
+			if language(candidate) == language(locale) {
+				tr := t[candidate]
+				return tr.Title, cmp.Or(tr.Description, description), candidate
+			}
+		}
+	}
+	return title, description, ""
+}
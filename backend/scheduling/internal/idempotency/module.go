@@ -0,0 +1,17 @@
+package idempotency
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeIdempotencyStore(db *sqlx.DB) *IdempotencyRepo {
+	return NewIdempotencyRepository(db)
+}
+
+func InitializeIdempotencyRetentionJob(log logger.Logger, db *sqlx.DB, cfg *config.IdempotencyConfig) *IdempotencyRetentionJob {
+	repo := NewIdempotencyRepository(db)
+	return NewIdempotencyRetentionJob(log, repo, cfg)
+}
@@ -0,0 +1,91 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type IdempotencyRepo struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyRepository(db *sqlx.DB) *IdempotencyRepo {
+	return &IdempotencyRepo{db: db}
+}
+
+// ReserveKey claims a key of a user for a request. A key is claimed when it is new, expired, or still
+// in progress since before staleBefore, which is when its previous request is assumed to have died. It
+// returns false with the current record when the key is held by another request.
+func (r *IdempotencyRepo) ReserveKey(ctx context.Context, record *entities.IdempotencyKey, staleBefore time.Time) (bool, *entities.IdempotencyKey, error) {
+	const reserveQuery = `
+		INSERT INTO idempotency_key (user_id, key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, response_headers = NULL, response_body = NULL,
+			created_at = EXCLUDED.created_at, completed_at = NULL, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_key.expires_at <= EXCLUDED.created_at
+			OR (idempotency_key.completed_at IS NULL AND idempotency_key.created_at < $6)
+		RETURNING true
+	`
+	var reserved bool
+	err := database.Conn(ctx, r.db).GetContext(ctx, &reserved, reserveQuery,
+		record.UserId, record.Key, record.Fingerprint, record.CreatedAt, record.ExpiresAt, staleBefore)
+	if err == nil {
+		return true, nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, nil, apperrors.NewInternal(err)
+	}
+
+	const query = `
+		SELECT user_id, key, fingerprint, status_code, response_headers, response_body, created_at, completed_at, expires_at
+		FROM idempotency_key
+		WHERE user_id = $1 AND key = $2
+	`
+	existing, err := database.FetchSingle[entities.IdempotencyKey](ctx, r.db, query, record.UserId, record.Key)
+	if err != nil {
+		return false, nil, err
+	}
+	return false, existing, nil
+}
+
+// CompleteKey stores the response a reserved key was answered with
+func (r *IdempotencyRepo) CompleteKey(ctx context.Context, userId uuid.UUID, key string, statusCode int, headers string, body []byte, now time.Time) error {
+	const query = `
+		UPDATE idempotency_key
+		SET status_code = $3, response_headers = $4, response_body = $5, completed_at = $6
+		WHERE user_id = $1 AND key = $2
+	`
+	return database.ExecQuery(ctx, r.db, query, userId, key, statusCode, headers, body, now)
+}
+
+// ReleaseKey frees a reserved key whose request did not complete, so a retry runs it again
+func (r *IdempotencyRepo) ReleaseKey(ctx context.Context, userId uuid.UUID, key string) error {
+	return database.ExecQuery(ctx, r.db, `DELETE FROM idempotency_key WHERE user_id = $1 AND key = $2 AND completed_at IS NULL`, userId, key)
+}
+
+// PurgeExpired deletes up to limit expired keys and returns how many were deleted
+func (r *IdempotencyRepo) PurgeExpired(ctx context.Context, now time.Time, limit int) (int64, error) {
+	const query = `
+		DELETE FROM idempotency_key
+		WHERE (user_id, key) IN (SELECT user_id, key FROM idempotency_key WHERE expires_at <= $1 LIMIT $2)
+	`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, now, limit)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,58 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const purgeBatchSize = 1000
+
+type RetentionRepository interface {
+	PurgeExpired(ctx context.Context, now time.Time, limit int) (int64, error)
+}
+
+// IdempotencyRetentionJob periodically deletes idempotency keys that can no longer be replayed
+type IdempotencyRetentionJob struct {
+	log  logger.Logger
+	repo RetentionRepository
+	cfg  *config.IdempotencyConfig
+}
+
+func NewIdempotencyRetentionJob(log logger.Logger, repo RetentionRepository, cfg *config.IdempotencyConfig) *IdempotencyRetentionJob {
+	return &IdempotencyRetentionJob{log: log, repo: repo, cfg: cfg}
+}
+
+// Run purges expired keys on every interval until the context is cancelled
+func (j *IdempotencyRetentionJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.PurgeIntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.PurgeExpiredKeys(ctx); err != nil {
+				j.log.Errorf("Failed to purge expired idempotency keys: %v", err)
+			}
+		}
+	}
+}
+
+// PurgeExpiredKeys deletes expired keys in batches until fewer than a full batch remain
+func (j *IdempotencyRetentionJob) PurgeExpiredKeys(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	for {
+		deleted, err := j.repo.PurgeExpired(ctx, now, purgeBatchSize)
+		if err != nil {
+			return err
+		}
+		if deleted < purgeBatchSize {
+			return nil
+		}
+	}
+}
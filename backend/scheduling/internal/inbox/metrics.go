@@ -0,0 +1,81 @@
+package inbox
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/config"
+)
+
+// Outcomes of looking a consumed message up in the inbox
+const (
+	outcomeFirst     = "first"
+	outcomeDuplicate = "duplicate"
+	outcomeLate      = "late"
+)
+
+// redeliveryAgeBuckets span broker redeliveries from seconds after a lost ack up to several days, in seconds
+var redeliveryAgeBuckets = []float64{1, 10, 60, 300, 900, 3600, 6 * 3600, 24 * 3600, 48 * 3600, 72 * 3600, 7 * 24 * 3600}
+
+type inboxMetrics struct {
+	deliveries    metric.Int64Counter
+	duplicates    metric.Int64Counter
+	redeliveryAge metric.Float64Histogram
+}
+
+// newInboxMetrics registers the inbox metrics. The share of duplicate and late deliveries per routing key
+// and the age of redeliveries against the dedup window show whether the window covers how long the broker
+// keeps redelivering.
+func newInboxMetrics(meter metric.Meter, cfg *config.InboxConfig) (*inboxMetrics, error) {
+	deliveries, err := meter.Int64Counter("scheduling.inbox.deliveries",
+		metric.WithDescription("Consumed messages looked up in the inbox, by routing key and outcome: first delivery, duplicate within the dedup window, or late redelivery after it"))
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates, err := meter.Int64Counter("scheduling.inbox.duplicates",
+		metric.WithDescription("Redelivered messages skipped because they were already processed, by routing key"))
+	if err != nil {
+		return nil, err
+	}
+
+	redeliveryAge, err := meter.Float64Histogram("scheduling.inbox.redelivery.age",
+		metric.WithDescription("Time since a redelivered message was first processed, by routing key and outcome"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(redeliveryAgeBuckets...))
+	if err != nil {
+		return nil, err
+	}
+
+	window, err := meter.Int64ObservableGauge("scheduling.inbox.dedup_window",
+		metric.WithDescription("Configured dedup window redeliveries are skipped within"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	windowSeconds := int64(time.Duration(cfg.DedupWindowHours) * time.Hour / time.Second)
+	if _, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(window, windowSeconds)
+		return nil
+	}, window); err != nil {
+		return nil, err
+	}
+
+	return &inboxMetrics{deliveries: deliveries, duplicates: duplicates, redeliveryAge: redeliveryAge}, nil
+}
+
+func (m *inboxMetrics) recordDelivery(ctx context.Context, routingKey string, outcome string, age time.Duration) {
+	attrs := metric.WithAttributes(attribute.String("routing_key", routingKey), attribute.String("outcome", outcome))
+	m.deliveries.Add(ctx, 1, attrs)
+	if outcome == outcomeFirst {
+		return
+	}
+
+	m.redeliveryAge.Record(ctx, age.Seconds(), attrs)
+	if outcome == outcomeDuplicate {
+		m.duplicates.Add(ctx, 1, metric.WithAttributes(attribute.String("routing_key", routingKey)))
+	}
+}
@@ -0,0 +1,25 @@
+package inbox
+
+import (
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeInboxService(log logger.Logger, db *sqlx.DB, cfg *config.InboxConfig, meter metric.Meter) (*InboxService, error) {
+	metrics, err := newInboxMetrics(meter, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := NewInboxRepository(db)
+	service := NewInboxService(log, repo, cfg, metrics)
+	return service, nil
+}
+
+func InitializeInboxRetentionJob(log logger.Logger, db *sqlx.DB, cfg *config.InboxConfig) *InboxRetentionJob {
+	repo := NewInboxRepository(db)
+	return NewInboxRetentionJob(log, repo, cfg)
+}
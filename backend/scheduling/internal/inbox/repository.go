@@ -0,0 +1,63 @@
+package inbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type InboxRepo struct {
+	db *sqlx.DB
+}
+
+func NewInboxRepository(db *sqlx.DB) *InboxRepo {
+	return &InboxRepo{db: db}
+}
+
+// GetProcessedAt retrieves when a message was processed, nil when no record of it is kept
+func (r *InboxRepo) GetProcessedAt(ctx context.Context, messageId string) (*time.Time, error) {
+	const query = `SELECT processed_at FROM processed_message WHERE message_id = $1`
+	var processedAt time.Time
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &processedAt, query, messageId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return &processedAt, nil
+}
+
+// MarkProcessed records a processed message, refreshing the time of an expired record with the same Id
+func (r *InboxRepo) MarkProcessed(ctx context.Context, message *entities.ProcessedMessage) error {
+	const query = `
+		INSERT INTO processed_message (message_id, routing_key, processed_at)
+		VALUES (:message_id, :routing_key, :processed_at)
+		ON CONFLICT (message_id) DO UPDATE
+		SET routing_key = EXCLUDED.routing_key, processed_at = EXCLUDED.processed_at
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, message)
+}
+
+// PurgeProcessed deletes up to limit records processed before the cutoff and returns how many were deleted
+func (r *InboxRepo) PurgeProcessed(ctx context.Context, processedBefore time.Time, limit int) (int64, error) {
+	const query = `
+		DELETE FROM processed_message
+		WHERE message_id IN (SELECT message_id FROM processed_message WHERE processed_at < $1 LIMIT $2)
+	`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, processedBefore, limit)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return deleted, nil
+}
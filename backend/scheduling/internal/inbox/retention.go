@@ -0,0 +1,59 @@
+package inbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const purgeBatchSize = 1000
+
+type RetentionRepository interface {
+	PurgeProcessed(ctx context.Context, processedBefore time.Time, limit int) (int64, error)
+}
+
+// InboxRetentionJob periodically deletes processed message records that fell out of the dedup window and
+// its grace period
+type InboxRetentionJob struct {
+	log  logger.Logger
+	repo RetentionRepository
+	cfg  *config.InboxConfig
+}
+
+func NewInboxRetentionJob(log logger.Logger, repo RetentionRepository, cfg *config.InboxConfig) *InboxRetentionJob {
+	return &InboxRetentionJob{log: log, repo: repo, cfg: cfg}
+}
+
+// Run purges expired records on every interval until the context is cancelled
+func (j *InboxRetentionJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.PurgeIntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.PurgeExpiredMessages(ctx); err != nil {
+				j.log.Errorf("Failed to purge processed messages: %v", err)
+			}
+		}
+	}
+}
+
+// PurgeExpiredMessages deletes expired records in batches until fewer than a full batch remain
+func (j *InboxRetentionJob) PurgeExpiredMessages(ctx context.Context) error {
+	cutoff := retentionCutoff(time.Now().UTC(), j.cfg)
+
+	for {
+		deleted, err := j.repo.PurgeProcessed(ctx, cutoff, purgeBatchSize)
+		if err != nil {
+			return err
+		}
+		if deleted < purgeBatchSize {
+			return nil
+		}
+	}
+}
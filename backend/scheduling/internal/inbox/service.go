@@ -0,0 +1,74 @@
+package inbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type InboxRepository interface {
+	GetProcessedAt(ctx context.Context, messageId string) (*time.Time, error)
+	MarkProcessed(ctx context.Context, message *entities.ProcessedMessage) error
+}
+
+// InboxService deduplicates consumed messages by Id within the configured window, so redelivered
+// messages, such as payment confirmations, are acknowledged without being handled twice
+type InboxService struct {
+	log     logger.Logger
+	repo    InboxRepository
+	cfg     *config.InboxConfig
+	metrics *inboxMetrics
+}
+
+func NewInboxService(log logger.Logger, repo InboxRepository, cfg *config.InboxConfig, metrics *inboxMetrics) *InboxService {
+	return &InboxService{log: log, repo: repo, cfg: cfg, metrics: metrics}
+}
+
+// IsDuplicate reports whether the message was already processed within the dedup window. Redeliveries
+// after the window are handled again and counted as late, a sign the window is shorter than the broker
+// keeps redelivering.
+func (s *InboxService) IsDuplicate(ctx context.Context, messageId string, routingKey string) (bool, error) {
+	processedAt, err := s.repo.GetProcessedAt(ctx, messageId)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now().UTC()
+	if processedAt == nil {
+		s.metrics.recordDelivery(ctx, routingKey, outcomeFirst, 0)
+		return false, nil
+	}
+
+	age := now.Sub(*processedAt)
+	if processedAt.After(dedupCutoff(now, s.cfg)) {
+		s.metrics.recordDelivery(ctx, routingKey, outcomeDuplicate, age)
+		return true, nil
+	}
+
+	logger.FromContext(ctx, s.log).Warnf("Message %s of %s redelivered %s after it was processed, past the dedup window", messageId, routingKey, age.Round(time.Second))
+	s.metrics.recordDelivery(ctx, routingKey, outcomeLate, age)
+	return false, nil
+}
+
+// MarkProcessed records a successfully handled message
+func (s *InboxService) MarkProcessed(ctx context.Context, messageId string, routingKey string) error {
+	return s.repo.MarkProcessed(ctx, &entities.ProcessedMessage{
+		MessageId:   messageId,
+		RoutingKey:  routingKey,
+		ProcessedAt: time.Now().UTC(),
+	})
+}
+
+// dedupCutoff returns the processing time before which records no longer count as duplicates
+func dedupCutoff(now time.Time, cfg *config.InboxConfig) time.Time {
+	return now.Add(-time.Duration(cfg.DedupWindowHours) * time.Hour)
+}
+
+// retentionCutoff returns the processing time before which records are purged, past the dedup window
+// by the grace period so late redeliveries can still be recognized
+func retentionCutoff(now time.Time, cfg *config.InboxConfig) time.Time {
+	return dedupCutoff(now, cfg).Add(-time.Duration(cfg.RetentionGraceHours) * time.Hour)
+}
@@ -0,0 +1,36 @@
+package invoices
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/taxes"
+)
+
+// swagger:model InvoiceResponse
+type InvoiceResponse struct {
+	Id             int64                  `json:"id"`
+	Number         string                 `json:"number"`
+	BookingId      int64                  `json:"bookingId"`
+	EducatorId     uuid.UUID              `json:"educatorId"`
+	StudentId      uuid.UUID              `json:"studentId"`
+	Currency       string                 `json:"currency"`
+	Subtotal       float64                `json:"subtotal"`
+	DiscountAmount float64                `json:"discountAmount"`
+	TaxAmount      float64                `json:"taxAmount"`
+	TotalAmount    float64                `json:"totalAmount"`
+	Tax            *taxes.TaxBreakdown    `json:"tax"`
+	IssuedAt       time.Time              `json:"issuedAt"`
+	Lines          []*InvoiceLineResponse `json:"lines"`
+}
+
+// swagger:model InvoiceLineResponse
+type InvoiceLineResponse struct {
+	Description    string  `json:"description"`
+	Quantity       int     `json:"quantity"`
+	UnitPrice      float64 `json:"unitPrice"`
+	DiscountAmount float64 `json:"discountAmount"`
+	TaxAmount      float64 `json:"taxAmount"`
+	LineTotal      float64 `json:"lineTotal"`
+}
@@ -0,0 +1,106 @@
+package invoices
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type InvoiceHandler struct {
+	service *InvoiceService
+}
+
+func NewInvoiceHandler(service *InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{service: service}
+}
+
+// GetMyInvoices retrieves invoices of the current user.
+// @Summary      Retrieve invoices
+// @Description  Retrieves invoices where the current user is the student or the educator, newest first.
+// @Tags         Invoice
+// @Accept       json
+// @Produce      json
+// @Param        skip  query     int  false  "Number of invoices to skip"
+// @Param        take  query     int  false  "Number of invoices to return"
+// @Success      200   {array}   InvoiceResponse  "Invoices"
+// @Failure      400   {object}  error            "Invalid input parameters"
+// @Router       /api/v1/invoices/my [get]
+// @Security 	 BearerAuth
+func (h *InvoiceHandler) GetMyInvoices(w http.ResponseWriter, r *http.Request) {
+	skip, err := api.ParseIntQuery(w, r, "skip", 0)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	take, err := api.ParseIntQuery(w, r, "take", 20)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	invoices, err := h.service.GetMyInvoices(r.Context(), skip, take)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, invoices)
+}
+
+// GetInvoice retrieves an invoice by its ID.
+// @Summary      Retrieve invoice
+// @Description  Retrieves an invoice with its line items. Available to the student, the educator and admins.
+// @Tags         Invoice
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int              true  "Invoice ID"
+// @Success      200  {object}  InvoiceResponse  "Invoice"
+// @Failure      400  {object}  error            "Invalid input parameters"
+// @Failure      404  {object}  error            "Invoice not found"
+// @Router       /api/v1/invoices/{id} [get]
+// @Security 	 BearerAuth
+func (h *InvoiceHandler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	invoice, err := h.service.GetInvoice(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, invoice)
+}
+
+// GetBookingInvoice retrieves the invoice issued for a booking.
+// @Summary      Retrieve booking invoice
+// @Description  Retrieves the invoice issued when the booking was completed. Available to the student, the educator and admins.
+// @Tags         Invoice
+// @Accept       json
+// @Produce      json
+// @Param        bookingId  path      int              true  "Booking ID"
+// @Success      200        {object}  InvoiceResponse  "Invoice"
+// @Failure      400        {object}  error            "Invalid input parameters"
+// @Failure      404        {object}  error            "Invoice not found"
+// @Router       /api/v1/invoices/bookings/{bookingId} [get]
+// @Security 	 BearerAuth
+func (h *InvoiceHandler) GetBookingInvoice(w http.ResponseWriter, r *http.Request) {
+	bookingId, err := api.ParseLongParam(w, r, "bookingId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	invoice, err := h.service.GetBookingInvoice(r.Context(), bookingId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, invoice)
+}
@@ -0,0 +1,140 @@
+package invoices
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/taxes"
+)
+
+func MapBookingToInvoiceLine(b *entities.Booking, tax *taxes.TaxBreakdown) *entities.InvoiceLine {
+	return &entities.InvoiceLine{
+		Description:    fmt.Sprintf("%s (%s)", b.Title, b.StartTime.UTC().Format("2006-01-02 15:04 MST")),
+		Quantity:       1,
+		UnitPrice:      tax.NetAmount,
+		DiscountAmount: 0,
+		TaxAmount:      tax.TaxAmount,
+		LineTotal:      tax.GrossAmount,
+	}
+}
+
+func MapBookingToInvoice(
+	b *entities.Booking,
+	number string,
+	currency string,
+	issuedAt time.Time,
+	tax *taxes.TaxBreakdown,
+	lines []*entities.InvoiceLine,
+) *entities.Invoice {
+	var subtotal, discount, taxAmount float64
+	for _, l := range lines {
+		subtotal += l.UnitPrice * float64(l.Quantity)
+		discount += l.DiscountAmount
+		taxAmount += l.TaxAmount
+	}
+
+	return &entities.Invoice{
+		Number:         number,
+		BookingId:      b.Id,
+		EducatorId:     b.EducatorId,
+		StudentId:      b.StudentId,
+		Currency:       currency,
+		Subtotal:       roundAmount(subtotal),
+		DiscountAmount: roundAmount(discount),
+		TaxAmount:      roundAmount(taxAmount),
+		TotalAmount:    roundAmount(subtotal - discount + taxAmount),
+		TaxCountry:     tax.CountryCode,
+		TaxName:        tax.TaxName,
+		TaxRate:        tax.RatePercent,
+		ReverseCharge:  tax.ReverseCharge,
+		IssuedAt:       issuedAt,
+		CreatedAt:      issuedAt,
+	}
+}
+
+func MapInvoiceToEventTax(inv *entities.Invoice) messaging.InvoiceTaxBreakdown {
+	return messaging.InvoiceTaxBreakdown{
+		CountryCode:   inv.TaxCountry,
+		TaxName:       inv.TaxName,
+		RatePercent:   inv.TaxRate,
+		ReverseCharge: inv.ReverseCharge,
+	}
+}
+
+func MapLinesToEventItems(lines []*entities.InvoiceLine) []messaging.InvoiceLineItem {
+	items := make([]messaging.InvoiceLineItem, len(lines))
+	for i, l := range lines {
+		items[i] = messaging.InvoiceLineItem{
+			Description:    l.Description,
+			Quantity:       l.Quantity,
+			UnitPrice:      l.UnitPrice,
+			DiscountAmount: l.DiscountAmount,
+			TaxAmount:      l.TaxAmount,
+			LineTotal:      l.LineTotal,
+		}
+	}
+	return items
+}
+
+func MapLineToResponse(l *entities.InvoiceLine) *InvoiceLineResponse {
+	return &InvoiceLineResponse{
+		Description:    l.Description,
+		Quantity:       l.Quantity,
+		UnitPrice:      l.UnitPrice,
+		DiscountAmount: l.DiscountAmount,
+		TaxAmount:      l.TaxAmount,
+		LineTotal:      l.LineTotal,
+	}
+}
+
+func MapInvoiceToTaxBreakdown(inv *entities.Invoice) *taxes.TaxBreakdown {
+	return &taxes.TaxBreakdown{
+		CountryCode:   inv.TaxCountry,
+		TaxName:       inv.TaxName,
+		RatePercent:   inv.TaxRate,
+		ReverseCharge: inv.ReverseCharge,
+		NetAmount:     roundAmount(inv.Subtotal - inv.DiscountAmount),
+		TaxAmount:     inv.TaxAmount,
+		GrossAmount:   inv.TotalAmount,
+	}
+}
+
+func MapInvoiceToResponse(inv *entities.Invoice, lines []*entities.InvoiceLine) *InvoiceResponse {
+	response := &InvoiceResponse{
+		Id:             inv.Id,
+		Number:         inv.Number,
+		BookingId:      inv.BookingId,
+		EducatorId:     inv.EducatorId,
+		StudentId:      inv.StudentId,
+		Currency:       inv.Currency,
+		Subtotal:       inv.Subtotal,
+		DiscountAmount: inv.DiscountAmount,
+		TaxAmount:      inv.TaxAmount,
+		TotalAmount:    inv.TotalAmount,
+		Tax:            MapInvoiceToTaxBreakdown(inv),
+		IssuedAt:       inv.IssuedAt,
+		Lines:          []*InvoiceLineResponse{},
+	}
+
+	for _, l := range lines {
+		if l.InvoiceId == inv.Id {
+			response.Lines = append(response.Lines, MapLineToResponse(l))
+		}
+	}
+	return response
+}
+
+func MapInvoicesToResponse(invs []*entities.Invoice, lines []*entities.InvoiceLine) []*InvoiceResponse {
+	response := make([]*InvoiceResponse, len(invs))
+	for i, inv := range invs {
+		response[i] = MapInvoiceToResponse(inv, lines)
+	}
+	return response
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,28 @@
+package invoices
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializeInvoiceService(
+	log logger.Logger,
+	db *sqlx.DB,
+	cfg *config.InvoiceConfig,
+	publisher *messaging.Publisher,
+	taxes TaxCalculator,
+) *InvoiceService {
+	repo := NewInvoiceRepository(db)
+	service := NewInvoiceService(log, repo, cfg, publisher, taxes)
+	return service
+}
+
+func InitializeInvoiceHTTPHandler(service *InvoiceService) http.Handler {
+	handler := NewInvoiceHandler(service)
+	return Routes(handler)
+}
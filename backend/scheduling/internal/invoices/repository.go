@@ -0,0 +1,119 @@
+package invoices
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type InvoiceRepo struct {
+	db *sqlx.DB
+}
+
+func NewInvoiceRepository(db *sqlx.DB) *InvoiceRepo {
+	return &InvoiceRepo{db: db}
+}
+
+// GetInvoiceById retrieves a single invoice by its Id
+func (r *InvoiceRepo) GetInvoiceById(ctx context.Context, id int64) (*entities.Invoice, error) {
+	const query = `
+		SELECT id, invoice_number, booking_id, educator_id, student_id, currency, subtotal, discount_amount, tax_amount, total_amount, tax_country, tax_name, tax_rate, reverse_charge, issued_at, created_at
+		FROM invoice
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.Invoice](ctx, r.db, query, id)
+}
+
+// GetInvoiceByBookingId retrieves the invoice issued for a booking
+func (r *InvoiceRepo) GetInvoiceByBookingId(ctx context.Context, bookingId int64) (*entities.Invoice, error) {
+	const query = `
+		SELECT id, invoice_number, booking_id, educator_id, student_id, currency, subtotal, discount_amount, tax_amount, total_amount, tax_country, tax_name, tax_rate, reverse_charge, issued_at, created_at
+		FROM invoice
+		WHERE booking_id = $1
+	`
+	return database.FetchSingle[entities.Invoice](ctx, r.db, query, bookingId)
+}
+
+// GetUserInvoices retrieves invoices where the user is either the student or the educator
+func (r *InvoiceRepo) GetUserInvoices(ctx context.Context, userId uuid.UUID, skip int, take int) ([]*entities.Invoice, error) {
+	const query = `
+		SELECT id, invoice_number, booking_id, educator_id, student_id, currency, subtotal, discount_amount, tax_amount, total_amount, tax_country, tax_name, tax_rate, reverse_charge, issued_at, created_at
+		FROM invoice
+		WHERE student_id = $1 OR educator_id = $1
+		ORDER BY issued_at DESC OFFSET $2 LIMIT $3
+	`
+	return database.FetchMultiple[entities.Invoice](ctx, r.db, query, userId, skip, take)
+}
+
+// GetInvoiceLines retrieves line items of the given invoices
+func (r *InvoiceRepo) GetInvoiceLines(ctx context.Context, invoiceIds []int64) ([]*entities.InvoiceLine, error) {
+	const query = `
+		SELECT id, invoice_id, description, quantity, unit_price, discount_amount, tax_amount, line_total
+		FROM invoice_line
+		WHERE invoice_id = ANY($1)
+		ORDER BY id
+	`
+	return database.FetchMultiple[entities.InvoiceLine](ctx, r.db, query, pq.Array(invoiceIds))
+}
+
+// HasBookingInvoice checks if an invoice was already issued for a booking
+func (r *InvoiceRepo) HasBookingInvoice(ctx context.Context, bookingId int64) (bool, error) {
+	const query = `SELECT EXISTS (SELECT 1 FROM invoice WHERE booking_id = $1)`
+	return database.CheckExists(ctx, r.db, query, bookingId)
+}
+
+// NextInvoiceSequence returns the next value of the invoice numbering sequence
+func (r *InvoiceRepo) NextInvoiceSequence(ctx context.Context) (int64, error) {
+	var seq int64
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &seq, `SELECT nextval('invoice_number_seq')`); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return seq, nil
+}
+
+// AddInvoice adds an invoice together with its line items in a single transaction
+func (r *InvoiceRepo) AddInvoice(ctx context.Context, invoice *entities.Invoice, lines []*entities.InvoiceLine) (int64, error) {
+	const invoiceQuery = `
+		INSERT INTO invoice (invoice_number, booking_id, educator_id, student_id, currency, subtotal, discount_amount, tax_amount, total_amount, tax_country, tax_name, tax_rate, reverse_charge, issued_at, created_at)
+		VALUES (:invoice_number, :booking_id, :educator_id, :student_id, :currency, :subtotal, :discount_amount, :tax_amount, :total_amount, :tax_country, :tax_name, :tax_rate, :reverse_charge, :issued_at, :created_at)
+		RETURNING id
+	`
+	const lineQuery = `
+		INSERT INTO invoice_line (invoice_id, description, quantity, unit_price, discount_amount, tax_amount, line_total)
+		VALUES (:invoice_id, :description, :quantity, :unit_price, :discount_amount, :tax_amount, :line_total)
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareNamedContext(ctx, invoiceQuery)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	var id int64
+	if err := stmt.GetContext(ctx, &id, invoice); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	for _, line := range lines {
+		line.InvoiceId = id
+		if _, err := tx.NamedExecContext(ctx, lineQuery, line); err != nil {
+			return 0, apperrors.NewInternal(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return id, nil
+}
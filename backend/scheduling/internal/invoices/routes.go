@@ -0,0 +1,18 @@
+package invoices
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes(handler *InvoiceHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/my", handler.GetMyInvoices)
+	r.Get("/{id}", handler.GetInvoice)
+	r.Get("/bookings/{bookingId}", handler.GetBookingInvoice)
+
+	return r
+}
@@ -0,0 +1,193 @@
+package invoices
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/taxes"
+)
+
+type InvoiceRepository interface {
+	GetInvoiceById(ctx context.Context, id int64) (*entities.Invoice, error)
+	GetInvoiceByBookingId(ctx context.Context, bookingId int64) (*entities.Invoice, error)
+	GetUserInvoices(ctx context.Context, userId uuid.UUID, skip int, take int) ([]*entities.Invoice, error)
+	GetInvoiceLines(ctx context.Context, invoiceIds []int64) ([]*entities.InvoiceLine, error)
+	HasBookingInvoice(ctx context.Context, bookingId int64) (bool, error)
+	NextInvoiceSequence(ctx context.Context) (int64, error)
+	AddInvoice(ctx context.Context, invoice *entities.Invoice, lines []*entities.InvoiceLine) (int64, error)
+}
+
+// TaxCalculator computes the tax breakdown of an amount sold by an educator to a buyer
+type TaxCalculator interface {
+	CalculateTax(ctx context.Context, educatorId uuid.UUID, buyerId uuid.UUID, amount float64) (*taxes.TaxBreakdown, error)
+}
+
+type InvoiceService struct {
+	log       logger.Logger
+	repo      InvoiceRepository
+	cfg       *config.InvoiceConfig
+	publisher *messaging.Publisher
+	taxes     TaxCalculator
+}
+
+func NewInvoiceService(
+	log logger.Logger,
+	repo InvoiceRepository,
+	cfg *config.InvoiceConfig,
+	publisher *messaging.Publisher,
+	taxes TaxCalculator,
+) *InvoiceService {
+	return &InvoiceService{log: log, repo: repo, cfg: cfg, publisher: publisher, taxes: taxes}
+}
+
+// GenerateBookingInvoice issues an invoice for a completed booking and publishes an invoice event.
+// Bookings that already have an invoice are skipped, so the operation is safe to repeat.
+func (s *InvoiceService) GenerateBookingInvoice(ctx context.Context, booking *entities.Booking) error {
+	log := logger.FromContext(ctx, s.log)
+
+	exists, err := s.repo.HasBookingInvoice(ctx, booking.Id)
+	if err != nil {
+		log.Error("failed to check booking invoice", err)
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	tax, err := s.taxes.CalculateTax(ctx, booking.EducatorId, booking.StudentId, booking.Price)
+	if err != nil {
+		log.Error("failed to calculate invoice tax", err)
+		return err
+	}
+
+	seq, err := s.repo.NextInvoiceSequence(ctx)
+	if err != nil {
+		log.Error("failed to get invoice number", err)
+		return err
+	}
+
+	issuedAt := time.Now().UTC()
+	number := fmt.Sprintf("%s-%d-%06d", s.cfg.NumberPrefix, issuedAt.Year(), seq)
+
+	lines := []*entities.InvoiceLine{MapBookingToInvoiceLine(booking, tax)}
+	invoice := MapBookingToInvoice(booking, number, s.cfg.Currency, issuedAt, tax, lines)
+
+	id, err := s.repo.AddInvoice(ctx, invoice, lines)
+	if err != nil {
+		log.Error("failed to add invoice", err)
+		return err
+	}
+	invoice.Id = id
+
+	err = s.publisher.Publish(
+		ctx,
+		messaging.InvoiceGeneratedKey,
+		messaging.NewInvoiceGeneratedEvent(
+			invoice.Id,
+			invoice.Number,
+			invoice.BookingId,
+			invoice.EducatorId.String(),
+			invoice.StudentId.String(),
+			invoice.Currency,
+			invoice.Subtotal,
+			invoice.DiscountAmount,
+			invoice.TaxAmount,
+			invoice.TotalAmount,
+			invoice.IssuedAt.Format(time.RFC3339),
+			MapInvoiceToEventTax(invoice),
+			MapLinesToEventItems(lines),
+		),
+	)
+	if err != nil {
+		log.Error("failed to publish invoice event", err)
+	}
+
+	return nil
+}
+
+func (s *InvoiceService) GetInvoice(ctx context.Context, id int64) (*InvoiceResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	invoice, err := s.repo.GetInvoiceById(ctx, id)
+	if err != nil {
+		log.Error("failed to get invoice", err)
+		return nil, err
+	}
+
+	return s.getInvoiceResponse(ctx, invoice)
+}
+
+func (s *InvoiceService) GetBookingInvoice(ctx context.Context, bookingId int64) (*InvoiceResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	invoice, err := s.repo.GetInvoiceByBookingId(ctx, bookingId)
+	if err != nil {
+		log.Error("failed to get booking invoice", err)
+		return nil, err
+	}
+
+	return s.getInvoiceResponse(ctx, invoice)
+}
+
+func (s *InvoiceService) GetMyInvoices(ctx context.Context, skip int, take int) ([]*InvoiceResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	invoices, err := s.repo.GetUserInvoices(ctx, userId, skip, take)
+	if err != nil {
+		log.Error("failed to get user invoices", err)
+		return nil, err
+	}
+
+	if len(invoices) == 0 {
+		return []*InvoiceResponse{}, nil
+	}
+
+	ids := make([]int64, len(invoices))
+	for i, inv := range invoices {
+		ids[i] = inv.Id
+	}
+
+	lines, err := s.repo.GetInvoiceLines(ctx, ids)
+	if err != nil {
+		log.Error("failed to get invoice lines", err)
+		return nil, err
+	}
+
+	return MapInvoicesToResponse(invoices, lines), nil
+}
+
+// getInvoiceResponse loads invoice lines once the caller is confirmed to be a party of the invoice or an admin
+func (s *InvoiceService) getInvoiceResponse(ctx context.Context, invoice *entities.Invoice) (*InvoiceResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if invoice.StudentId != userId && invoice.EducatorId != userId && !auth.HasRole(ctx, auth.AdminRole) {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	lines, err := s.repo.GetInvoiceLines(ctx, []int64{invoice.Id})
+	if err != nil {
+		log.Error("failed to get invoice lines", err)
+		return nil, err
+	}
+
+	return MapInvoiceToResponse(invoice, lines), nil
+}
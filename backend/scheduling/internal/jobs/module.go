@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializeReminderJob(
+	log logger.Logger,
+	db *sqlx.DB,
+	publisher *messaging.Publisher,
+	cfg *config.ReminderConfig,
+	notificationCfg *config.NotificationConfig,
+) *ReminderJob {
+	repo := NewReminderRepository(db)
+	defaults := &ReminderDefaults{
+		Offsets:  make(pq.Int64Array, len(notificationCfg.DefaultReminderOffsets)),
+		Channels: pq.StringArray(notificationCfg.DefaultChannels),
+		Language: notificationCfg.DefaultLanguage,
+	}
+	for i, offset := range notificationCfg.DefaultReminderOffsets {
+		defaults.Offsets[i] = int64(offset)
+	}
+	return NewReminderJob(log, repo, publisher, cfg, defaults)
+}
+
+// InitializeScheduler schedules the background jobs of the package
+func InitializeScheduler(log logger.Logger, reminders *ReminderJob, cfg *config.ReminderConfig) *Scheduler {
+	scheduler := NewScheduler(log)
+	scheduler.Every("session-reminders", time.Duration(cfg.IntervalSeconds)*time.Second, reminders.SendDueReminders)
+	scheduler.Every("session-reminder-purge", time.Duration(cfg.PurgeIntervalMinutes)*time.Minute, reminders.PurgeReminders)
+	return scheduler
+}
@@ -0,0 +1,144 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+// reminderHorizon is the longest reminder offset users can choose, sessions starting later have no reminder due
+const reminderHorizon = 7 * 24 * time.Hour
+
+type ReminderRepository interface {
+	GetDueReminders(ctx context.Context, now, horizon, catchUpFrom time.Time, defaults *ReminderDefaults, limit int) ([]*DueReminder, error)
+	GetReminderSuppression(ctx context.Context, due *DueReminder) (string, error)
+	RecordReminder(ctx context.Context, due *DueReminder, status string, now time.Time) (bool, error)
+	DeleteRemindersBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// ReminderJob enqueues reminders of upcoming sessions to participants, at the offsets before the session
+// start set in their notification preferences or the configured defaults
+type ReminderJob struct {
+	log       logger.Logger
+	repo      ReminderRepository
+	publisher *messaging.Publisher
+	cfg       *config.ReminderConfig
+	defaults  *ReminderDefaults
+}
+
+func NewReminderJob(
+	log logger.Logger,
+	repo ReminderRepository,
+	publisher *messaging.Publisher,
+	cfg *config.ReminderConfig,
+	defaults *ReminderDefaults,
+) *ReminderJob {
+	return &ReminderJob{log: log, repo: repo, publisher: publisher, cfg: cfg, defaults: defaults}
+}
+
+// SendDueReminders enqueues the reminders that fell due, batch by batch until none is left. Reminders that
+// fell due while the service was down are still sent within the catch-up window, but only the latest one
+// per participant: after missing the day-before reminder, the hour-before one is sent alone.
+func (j *ReminderJob) SendDueReminders(ctx context.Context) error {
+	log := logger.FromContext(ctx, j.log)
+
+	for {
+		now := time.Now().UTC()
+		catchUpFrom := now.Add(-time.Duration(j.cfg.CatchUpMinutes) * time.Minute)
+
+		due, err := j.repo.GetDueReminders(ctx, now, now.Add(reminderHorizon), catchUpFrom, j.defaults, j.cfg.BatchSize)
+		if err != nil {
+			log.Error("failed to get due reminders", err)
+			return err
+		}
+
+		for _, d := range due {
+			// The session is read again right before sending, it may have changed since the batch was read
+			suppression, err := j.repo.GetReminderSuppression(ctx, d)
+			if err != nil {
+				log.Error("failed to check reminder suppression", err)
+				return err
+			}
+			if suppression != "" {
+				if err := j.suppressReminder(ctx, d, suppression, now); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// The reminder is recorded before it is published, so replicas racing for it send it once
+			recorded, err := j.repo.RecordReminder(ctx, d, entities.ReminderSent, now)
+			if err != nil {
+				log.Error("failed to record reminder", err)
+				return err
+			}
+			if recorded {
+				j.publishReminder(ctx, d)
+			}
+		}
+
+		if len(due) < j.cfg.BatchSize || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// suppressReminder records a reminder no longer needed as suppressed so it is not due again. A reminder of a
+// moved session is left unrecorded instead, the next run finds it due at the offsets before the new start.
+func (j *ReminderJob) suppressReminder(ctx context.Context, due *DueReminder, suppression string, now time.Time) error {
+	log := logger.FromContext(ctx, j.log)
+
+	if suppression != entities.ReminderSessionMoved {
+		if _, err := j.repo.RecordReminder(ctx, due, entities.ReminderSuppressed, now); err != nil {
+			log.Error("failed to record suppressed reminder", err)
+			return err
+		}
+	}
+	log.Infof("Suppressed reminder of %s %d to user %s: %s", due.SessionKind, due.SessionId, due.UserId, suppression)
+	return nil
+}
+
+// PurgeReminders removes reminder records past the retention, long after their sessions started
+func (j *ReminderJob) PurgeReminders(ctx context.Context) error {
+	log := logger.FromContext(ctx, j.log)
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -j.cfg.RetentionDays)
+	deleted, err := j.repo.DeleteRemindersBefore(ctx, cutoff)
+	if err != nil {
+		log.Error("failed to purge session reminders", err)
+		return err
+	}
+	if deleted > 0 {
+		log.Infof("Purged %d session reminders older than %d days", deleted, j.cfg.RetentionDays)
+	}
+	return nil
+}
+
+// publishReminder enqueues a reminder; failures are logged and do not stop the job
+func (j *ReminderJob) publishReminder(ctx context.Context, due *DueReminder) {
+	log := logger.FromContext(ctx, j.log)
+
+	err := j.publisher.Publish(
+		ctx,
+		messaging.SessionReminderKey,
+		messaging.NewSessionReminderEvent(
+			due.UserId.String(),
+			due.Role,
+			due.SessionKind,
+			due.SessionId,
+			due.Title,
+			due.StartTime.UTC().Format(time.RFC3339),
+			due.EndTime.UTC().Format(time.RFC3339),
+			due.OffsetMinutes,
+			due.Channels,
+			due.Language,
+		),
+	)
+	if err != nil {
+		log.Errorf("Failed to publish reminder of %s %d to user %s: %v", due.SessionKind, due.SessionId, due.UserId, err)
+	}
+}
@@ -0,0 +1,193 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// DueReminder holds a participant of an upcoming session with the reminder offsets that fell due and were not
+// sent yet. OffsetMinutes is the latest of them, the one to send.
+type DueReminder struct {
+	SessionKind   string         `db:"session_kind"`
+	SessionId     int64          `db:"session_id"`
+	UserId        uuid.UUID      `db:"user_id"`
+	Role          string         `db:"role"`
+	Title         string         `db:"title"`
+	StartTime     time.Time      `db:"start_time"`
+	EndTime       time.Time      `db:"end_time"`
+	Channels      pq.StringArray `db:"channels"`
+	Language      string         `db:"language"`
+	OffsetMinutes int            `db:"offset_minutes"`
+	DueOffsets    pq.Int64Array  `db:"due_offsets"`
+}
+
+// ReminderDefaults are the preferences of users who did not set their own
+type ReminderDefaults struct {
+	Offsets  pq.Int64Array
+	Channels pq.StringArray
+	Language string
+}
+
+type ReminderRepo struct {
+	db *sqlx.DB
+}
+
+func NewReminderRepository(db *sqlx.DB) *ReminderRepo {
+	return &ReminderRepo{db: db}
+}
+
+// GetDueReminders retrieves participants of approved sessions starting before horizon whose reminders fell
+// due after catchUpFrom, soonest session first. Students are reminded of their bookings, educators of their
+// individual bookings and of their scheduled events with approved bookings. Participants without channels
+// are left out.
+func (r *ReminderRepo) GetDueReminders(
+	ctx context.Context,
+	now time.Time,
+	horizon time.Time,
+	catchUpFrom time.Time,
+	defaults *ReminderDefaults,
+	limit int,
+) ([]*DueReminder, error) {
+	const query = `
+		WITH participant AS (
+			SELECT 'BOOKING' AS session_kind, b.id AS session_id, b.student_id AS user_id, 'STUDENT' AS role,
+				COALESCE(b.title, '') AS title, b.start_time, b.end_time
+			FROM booking b
+			WHERE b.status = $1 AND b.start_time > $2 AND b.start_time <= $3
+			UNION ALL
+			SELECT 'BOOKING', b.id, b.educator_id, 'EDUCATOR', COALESCE(b.title, ''), b.start_time, b.end_time
+			FROM booking b
+			WHERE b.status = $1 AND b.scheduled_event_id IS NULL AND b.start_time > $2 AND b.start_time <= $3
+			UNION ALL
+			SELECT 'EVENT', se.id, se.user_id, 'EDUCATOR', se.title, se.start_time, se.end_time
+			FROM scheduled_event se
+			WHERE se.start_time > $2 AND se.start_time <= $3 AND se.deleted_at IS NULL
+				AND EXISTS (SELECT 1 FROM booking b WHERE b.scheduled_event_id = se.id AND b.status = $1)
+		),
+		due AS (
+			SELECT p.*, COALESCE(np.channels, $6::text[]) AS channels, COALESCE(np.language, $7::text) AS language, o.offset_minutes
+			FROM participant p
+			LEFT JOIN notification_preference np ON np.user_id = p.user_id
+			CROSS JOIN LATERAL unnest(COALESCE(np.reminder_offsets, $5::int[])) AS o(offset_minutes)
+			WHERE cardinality(COALESCE(np.channels, $6::text[])) > 0
+				AND p.start_time - make_interval(mins => o.offset_minutes) <= $2
+				AND p.start_time - make_interval(mins => o.offset_minutes) > $4
+				AND NOT EXISTS (
+					SELECT 1 FROM session_reminder sr
+					WHERE sr.session_kind = p.session_kind AND sr.session_id = p.session_id
+						AND sr.user_id = p.user_id AND sr.offset_minutes = o.offset_minutes
+				)
+		)
+		SELECT session_kind, session_id, user_id, role, title, start_time, end_time, channels, language,
+			MIN(offset_minutes) AS offset_minutes, array_agg(offset_minutes) AS due_offsets
+		FROM due
+		GROUP BY session_kind, session_id, user_id, role, title, start_time, end_time, channels, language
+		ORDER BY start_time, session_id
+		LIMIT $8
+	`
+	return database.FetchMultiple[DueReminder](
+		ctx, r.db, query,
+		entities.Approved, now, horizon, catchUpFrom, defaults.Offsets, defaults.Channels, defaults.Language, limit,
+	)
+}
+
+// GetReminderSuppression re-reads the session of a due reminder and returns why the reminder is no longer
+// needed, or an empty string when it still is. A booking is checked for cancellation, a changed start, a
+// rescheduled attendance outcome and a check-in of its student, which also spares its educator the reminder;
+// a scheduled event for deletion, a changed start and having no approved bookings left.
+func (r *ReminderRepo) GetReminderSuppression(ctx context.Context, due *DueReminder) (string, error) {
+	const bookingQuery = `
+		SELECT CASE
+			WHEN b.id IS NULL OR b.status <> $3 THEN $4
+			WHEN b.start_time <> $2 THEN $5
+			WHEN a.outcome = $8 THEN $6
+			WHEN a.checked_in_at IS NOT NULL THEN $7
+			ELSE ''
+		END
+		FROM (SELECT $1::bigint AS id) s
+		LEFT JOIN booking b ON b.id = s.id
+		LEFT JOIN attendance a ON a.booking_id = b.id
+	`
+	const eventQuery = `
+		SELECT CASE
+			WHEN se.id IS NULL OR se.deleted_at IS NOT NULL
+				OR NOT EXISTS (SELECT 1 FROM booking b WHERE b.scheduled_event_id = se.id AND b.status = $3) THEN $4
+			WHEN se.start_time <> $2 THEN $5
+			ELSE ''
+		END
+		FROM (SELECT $1::bigint AS id) s
+		LEFT JOIN scheduled_event se ON se.id = s.id
+	`
+
+	var suppression string
+	var err error
+	if due.SessionKind == entities.ReminderSessionEvent {
+		err = database.Conn(ctx, r.db).GetContext(
+			ctx, &suppression, eventQuery,
+			due.SessionId, due.StartTime, entities.Approved, entities.ReminderSessionCancelled, entities.ReminderSessionMoved,
+		)
+	} else {
+		err = database.Conn(ctx, r.db).GetContext(
+			ctx, &suppression, bookingQuery,
+			due.SessionId, due.StartTime, entities.Approved, entities.ReminderSessionCancelled, entities.ReminderSessionMoved,
+			entities.ReminderSessionRescheduled, entities.ReminderStudentCheckedIn, entities.Rescheduled,
+		)
+	}
+	if err != nil {
+		return "", apperrors.NewInternal(err)
+	}
+	return suppression, nil
+}
+
+// RecordReminder stores the due offsets of a participant, the latest one with the given status, sent or
+// suppressed, and the others as skipped. False is returned when the latest one was already recorded, by
+// another replica in the meantime.
+func (r *ReminderRepo) RecordReminder(ctx context.Context, due *DueReminder, status string, now time.Time) (bool, error) {
+	const query = `
+		INSERT INTO session_reminder (session_kind, session_id, user_id, offset_minutes, due_at, status, created_at)
+		SELECT $1::text, $2::bigint, $3::uuid, o, $4::timestamptz - make_interval(mins => o),
+			CASE WHEN o = $5 THEN $6::text ELSE $7::text END, $8::timestamptz
+		FROM unnest($9::int[]) AS o
+		ON CONFLICT (session_kind, session_id, user_id, offset_minutes) DO NOTHING
+		RETURNING offset_minutes
+	`
+	var recorded []int
+	err := database.Conn(ctx, r.db).SelectContext(
+		ctx, &recorded, query,
+		due.SessionKind, due.SessionId, due.UserId, due.StartTime, due.OffsetMinutes,
+		status, entities.ReminderSkipped, now, due.DueOffsets,
+	)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	for _, offset := range recorded {
+		if offset == due.OffsetMinutes {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DeleteRemindersBefore removes reminder records created before the cutoff and returns how many were removed
+func (r *ReminderRepo) DeleteRemindersBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	const query = `DELETE FROM session_reminder WHERE created_at < $1`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, cutoff)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return affected, nil
+}
@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// Task is a unit of background work, run again on every tick of its schedule
+type Task func(ctx context.Context) error
+
+type scheduledTask struct {
+	name     string
+	interval time.Duration
+	task     Task
+}
+
+// Scheduler runs tasks on fixed intervals, each in its own goroutine so a slow task does not hold back the
+// others. Every task also runs once when the scheduler starts, which catches up on work that fell due while
+// the service was down instead of waiting a full interval.
+type Scheduler struct {
+	log    logger.Logger
+	tasks  []scheduledTask
+	paused func() bool
+}
+
+func NewScheduler(log logger.Logger) *Scheduler {
+	return &Scheduler{log: log}
+}
+
+// Every schedules a task to run on the given interval, tasks must be scheduled before Run
+func (s *Scheduler) Every(name string, interval time.Duration, task Task) {
+	s.tasks = append(s.tasks, scheduledTask{name: name, interval: interval, task: task})
+}
+
+// PauseWhile skips the runs of every task while paused reports true, such as in read-only mode. It must be
+// set before Run.
+func (s *Scheduler) PauseWhile(paused func() bool) {
+	s.paused = paused
+}
+
+// Run runs the scheduled tasks until the context is cancelled and returns once all of them stopped
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, t := range s.tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.runTask(ctx, t)
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *Scheduler) runTask(ctx context.Context, t scheduledTask) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		if s.paused != nil && s.paused() {
+			s.log.Debugf("Job %s skipped, scheduler is paused", t.name)
+		} else if err := t.task(ctx); err != nil && ctx.Err() == nil {
+			s.log.Errorf("Job %s failed: %v", t.name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package locations
+
+import (
+	"math"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance between two locations
+func DistanceKm(a, b *entities.Location) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// TravelBuffer returns the gap required between sessions held at two locations. Sessions at the
+// same place need none; otherwise the estimated travel time is used, but never less than minBuffer.
+func TravelBuffer(a, b *entities.Location, speedKmh float64, minBuffer time.Duration) time.Duration {
+	if a.Id == b.Id {
+		return 0
+	}
+
+	if speedKmh <= 0 {
+		return minBuffer
+	}
+
+	travel := time.Duration(DistanceKm(a, b) / speedKmh * float64(time.Hour)).Round(time.Minute)
+	return max(travel, minBuffer)
+}
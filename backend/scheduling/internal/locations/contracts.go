@@ -0,0 +1,71 @@
+package locations
+
+import (
+	"strings"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// swagger:model LocationRequest
+type LocationRequest struct {
+	Name      string  `json:"name"`
+	Address   string  `json:"address"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Capacity  int     `json:"capacity"`
+}
+
+// swagger:model LocationResponse
+type LocationResponse struct {
+	Id        int64   `json:"id"`
+	Name      string  `json:"name"`
+	Address   string  `json:"address"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Capacity  int     `json:"capacity"`
+}
+
+func (l *LocationRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if strings.TrimSpace(l.Name) == "" || len(l.Name) > 100 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Name",
+			Message: "must be between 1 and 100 characters",
+		})
+	}
+
+	if strings.TrimSpace(l.Address) == "" || len(l.Address) > 300 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Address",
+			Message: "must be between 1 and 300 characters",
+		})
+	}
+
+	if l.Latitude < -90 || l.Latitude > 90 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Latitude",
+			Message: "must be between -90 and 90",
+		})
+	}
+
+	if l.Longitude < -180 || l.Longitude > 180 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Longitude",
+			Message: "must be between -180 and 180",
+		})
+	}
+
+	if l.Capacity <= 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Capacity",
+			Message: "must be greater than 0",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Location request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
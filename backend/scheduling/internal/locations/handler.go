@@ -0,0 +1,172 @@
+package locations
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type LocationHandler struct {
+	service *LocationService
+}
+
+func NewLocationHandler(service *LocationService) *LocationHandler {
+	return &LocationHandler{service: service}
+}
+
+// GetEducatorLocations retrieves the locations of an educator.
+// @Summary      Retrieve educator locations
+// @Description  Retrieves the active in-person session locations of the educator.
+// @Tags         Location
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string              true  "Educator ID (UUID)"
+// @Success      200         {array}   LocationResponse    "Locations"
+// @Failure      400         {object}  error               "Invalid input parameters"
+// @Router       /api/v1/locations/educators/{educatorId} [get]
+// @Security 	 BearerAuth
+func (h *LocationHandler) GetEducatorLocations(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	locations, err := h.service.GetEducatorLocations(r.Context(), educatorId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, locations)
+}
+
+// GetLocationById retrieves a location.
+// @Summary      Retrieve location
+// @Description  Retrieves an active location by its ID.
+// @Tags         Location
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int               true  "Location ID"
+// @Success      200  {object}  LocationResponse  "Location"
+// @Failure      404  {object}  error             "Location not found"
+// @Router       /api/v1/locations/{id} [get]
+// @Security 	 BearerAuth
+func (h *LocationHandler) GetLocationById(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	location, err := h.service.GetLocationById(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, location)
+}
+
+// AddLocation adds a location for in-person sessions.
+// @Summary      Add location
+// @Description  Creates a location with its address, geo coordinates and capacity.
+// @Tags         Location
+// @Accept       json
+// @Produce      json
+// @Param        location  body      LocationRequest   true  "Location details"
+// @Success      201       {object}  LocationResponse  "Created location"
+// @Failure      400       {object}  error             "Invalid input"
+// @Router       /api/v1/locations [post]
+// @Security 	 BearerAuth
+func (h *LocationHandler) AddLocation(w http.ResponseWriter, r *http.Request) {
+	var request *LocationRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	location, err := h.service.AddLocation(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, location)
+}
+
+// UpdateLocation updates a location of the educator.
+// @Summary      Update location
+// @Description  Updates the details of an active location owned by the educator.
+// @Tags         Location
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int              true  "Location ID"
+// @Param        location  body      LocationRequest  true  "Location details"
+// @Success      204       "Location updated successfully"
+// @Failure      400       {object}  error            "Invalid input"
+// @Failure      404       {object}  error            "Location not found"
+// @Router       /api/v1/locations/{id} [put]
+// @Security 	 BearerAuth
+func (h *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *LocationRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.UpdateLocation(r.Context(), id, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ArchiveLocation removes a location from the educator's list.
+// @Summary      Archive location
+// @Description  Hides the location from the educator's list. Slots already held there are kept.
+// @Tags         Location
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Location ID"
+// @Success      204  "Location archived successfully"
+// @Failure      404  {object}  error  "Location not found"
+// @Router       /api/v1/locations/{id} [delete]
+// @Security 	 BearerAuth
+func (h *LocationHandler) ArchiveLocation(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.ArchiveLocation(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package locations
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToLocation(educatorId uuid.UUID, r *LocationRequest) *entities.Location {
+	l := &entities.Location{
+		EducatorId: educatorId,
+		CreatedAt:  time.Now().UTC(),
+	}
+	MapRequestWithLocation(r, l)
+	return l
+}
+
+func MapRequestWithLocation(r *LocationRequest, l *entities.Location) {
+	l.Name = strings.TrimSpace(r.Name)
+	l.Address = strings.TrimSpace(r.Address)
+	l.Latitude = r.Latitude
+	l.Longitude = r.Longitude
+	l.Capacity = r.Capacity
+	l.UpdatedAt = time.Now().UTC()
+}
+
+func MapLocationToResponse(l *entities.Location) *LocationResponse {
+	return &LocationResponse{
+		Id:        l.Id,
+		Name:      l.Name,
+		Address:   l.Address,
+		Latitude:  l.Latitude,
+		Longitude: l.Longitude,
+		Capacity:  l.Capacity,
+	}
+}
+
+func MapLocationsToResponse(ls []*entities.Location) []*LocationResponse {
+	response := make([]*LocationResponse, len(ls))
+	for i, l := range ls {
+		response[i] = MapLocationToResponse(l)
+	}
+	return response
+}
@@ -0,0 +1,20 @@
+package locations
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeLocationService(log logger.Logger, db *sqlx.DB) *LocationService {
+	repo := NewLocationRepository(db)
+	service := NewLocationService(log, repo)
+	return service
+}
+
+func InitializeLocationHTTPHandler(service *LocationService) http.Handler {
+	handler := NewLocationHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,71 @@
+package locations
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type LocationRepo struct {
+	db *sqlx.DB
+}
+
+func NewLocationRepository(db *sqlx.DB) *LocationRepo {
+	return &LocationRepo{db: db}
+}
+
+// GetEducatorLocations retrieves active locations of an educator
+func (r *LocationRepo) GetEducatorLocations(ctx context.Context, educatorId uuid.UUID) ([]*entities.Location, error) {
+	const query = `
+		SELECT id, educator_id, name, address, latitude, longitude, capacity, archived_at, created_at, updated_at
+		FROM location
+		WHERE educator_id = $1 AND archived_at IS NULL
+		ORDER BY name
+	`
+	return database.FetchMultiple[entities.Location](ctx, r.db, query, educatorId)
+}
+
+// GetLocationById retrieves an active location by its Id
+func (r *LocationRepo) GetLocationById(ctx context.Context, id int64) (*entities.Location, error) {
+	const query = `
+		SELECT id, educator_id, name, address, latitude, longitude, capacity, archived_at, created_at, updated_at
+		FROM location
+		WHERE id = $1 AND archived_at IS NULL
+	`
+	return database.FetchSingle[entities.Location](ctx, r.db, query, id)
+}
+
+// AddLocation adds a new location and returns its Id
+func (r *LocationRepo) AddLocation(ctx context.Context, location *entities.Location) (int64, error) {
+	const query = `
+		INSERT INTO location (educator_id, name, address, latitude, longitude, capacity, created_at, updated_at)
+		VALUES (:educator_id, :name, :address, :latitude, :longitude, :capacity, :created_at, :updated_at)
+		RETURNING id
+	`
+	return database.ExecNamedQueryWithResult[int64](ctx, r.db, query, location)
+}
+
+// UpdateLocation updates an existing location
+func (r *LocationRepo) UpdateLocation(ctx context.Context, location *entities.Location) error {
+	const query = `
+		UPDATE location
+		SET name = :name, address = :address, latitude = :latitude, longitude = :longitude, capacity = :capacity, updated_at = :updated_at
+		WHERE id = :id AND educator_id = :educator_id
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, location)
+}
+
+// ArchiveLocation hides a location from the educator's list while keeping it for existing slots
+func (r *LocationRepo) ArchiveLocation(ctx context.Context, educatorId uuid.UUID, id int64) error {
+	const query = `
+		UPDATE location
+		SET archived_at = $3, updated_at = $3
+		WHERE id = $1 AND educator_id = $2 AND archived_at IS NULL
+	`
+	return database.ExecQuery(ctx, r.db, query, id, educatorId, time.Now().UTC())
+}
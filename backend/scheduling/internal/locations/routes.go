@@ -0,0 +1,23 @@
+package locations
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *LocationHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/educators/{educatorId}", handler.GetEducatorLocations)
+	r.Get("/{id}", handler.GetLocationById)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Post("/", handler.AddLocation)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Put("/{id}", handler.UpdateLocation)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Delete("/{id}", handler.ArchiveLocation)
+
+	return r
+}
@@ -0,0 +1,126 @@
+package locations
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type LocationRepository interface {
+	GetEducatorLocations(ctx context.Context, educatorId uuid.UUID) ([]*entities.Location, error)
+	GetLocationById(ctx context.Context, id int64) (*entities.Location, error)
+	AddLocation(ctx context.Context, location *entities.Location) (int64, error)
+	UpdateLocation(ctx context.Context, location *entities.Location) error
+	ArchiveLocation(ctx context.Context, educatorId uuid.UUID, id int64) error
+}
+
+type LocationService struct {
+	log  logger.Logger
+	repo LocationRepository
+}
+
+func NewLocationService(log logger.Logger, repo LocationRepository) *LocationService {
+	return &LocationService{log: log, repo: repo}
+}
+
+func (s *LocationService) GetEducatorLocations(ctx context.Context, educatorId uuid.UUID) ([]*LocationResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	locations, err := s.repo.GetEducatorLocations(ctx, educatorId)
+	if err != nil {
+		log.Error("failed to get locations", err)
+		return nil, err
+	}
+
+	return MapLocationsToResponse(locations), nil
+}
+
+func (s *LocationService) GetLocationById(ctx context.Context, id int64) (*LocationResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	location, err := s.repo.GetLocationById(ctx, id)
+	if err != nil {
+		log.Error("failed to get location", err)
+		return nil, err
+	}
+
+	return MapLocationToResponse(location), nil
+}
+
+func (s *LocationService) AddLocation(ctx context.Context, request *LocationRequest) (*LocationResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	location := MapRequestToLocation(userId, request)
+	location.Id, err = s.repo.AddLocation(ctx, location)
+	if err != nil {
+		log.Error("failed to add location", err)
+		return nil, err
+	}
+
+	return MapLocationToResponse(location), nil
+}
+
+func (s *LocationService) UpdateLocation(ctx context.Context, id int64, request *LocationRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	location, err := s.getOwnLocation(ctx, id)
+	if err != nil {
+		log.Error("failed to get location", err)
+		return err
+	}
+
+	MapRequestWithLocation(request, location)
+
+	if err := s.repo.UpdateLocation(ctx, location); err != nil {
+		log.Error("failed to update location", err)
+		return err
+	}
+
+	return nil
+}
+
+// ArchiveLocation removes a location from the educator's list; slots already held there keep the reference
+func (s *LocationService) ArchiveLocation(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	location, err := s.getOwnLocation(ctx, id)
+	if err != nil {
+		log.Error("failed to get location", err)
+		return err
+	}
+
+	if err := s.repo.ArchiveLocation(ctx, location.EducatorId, location.Id); err != nil {
+		log.Error("failed to archive location", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *LocationService) getOwnLocation(ctx context.Context, id int64) (*entities.Location, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	location, err := s.repo.GetLocationById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if location.EducatorId != userId {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	return location, nil
+}
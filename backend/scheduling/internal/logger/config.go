@@ -39,12 +39,25 @@ var _ Logger = (*AppLogger)(nil)
 
 type AppLogger struct {
 	logger *zap.Logger
+	levels *Levels
+	module string
 }
 
+// NewAppLogger logs to the console and, with a provider, to OpenTelemetry, both at the configured level. The
+// level and the module overrides can be changed at runtime through Levels.
 func NewAppLogger(cfg config.LogConfig, provider *log.LoggerProvider) (*AppLogger, error) {
+	moduleLevels, err := cfg.ParseModuleLevels()
+	if err != nil {
+		return nil, err
+	}
+	levels, err := NewLevels(cfg.Level, moduleLevels)
+	if err != nil {
+		return nil, err
+	}
+
 	consoleEncoder := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
 
-	consoleCore := zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), zapcore.InfoLevel)
+	consoleCore := zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), zapcore.DebugLevel)
 
 	cores := []zapcore.Core{consoleCore}
 
@@ -53,10 +66,25 @@ func NewAppLogger(cfg config.LogConfig, provider *log.LoggerProvider) (*AppLogge
 		cores = append(cores, otelCore)
 	}
 
-	core := zapcore.NewTee(cores...)
+	core := moduleCore(zapcore.NewTee(cores...), levels, "")
 	logger := zap.New(core, zap.AddCaller()).With(zap.String("service_name", cfg.ServiceName))
 
-	return &AppLogger{logger: logger}, nil
+	return &AppLogger{logger: logger, levels: levels}, nil
+}
+
+// Module returns a logger tagged with the module name that logs at the level set for the module, or at the
+// default level while it has none
+func (l *AppLogger) Module(name string) *AppLogger {
+	l.levels.register(name)
+	logger := l.logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return moduleCore(core, l.levels, name)
+	}))
+	return &AppLogger{logger: logger, levels: l.levels, module: name}
+}
+
+// Levels returns the levels shared by this logger and all loggers derived from it
+func (l *AppLogger) Levels() *Levels {
+	return l.levels
 }
 
 func (l *AppLogger) Error(message string, err ...error) {
@@ -106,7 +134,7 @@ func (l *AppLogger) Debugf(format string, args ...any) {
 func (l *AppLogger) With(fields ...Field) Logger {
 	zapFields := mapToZapFields(fields)
 	logger := l.logger.With(zapFields...)
-	return &AppLogger{logger: logger}
+	return &AppLogger{logger: logger, levels: l.levels, module: l.module}
 }
 
 func WithLogger(ctx context.Context, logger Logger) context.Context {
@@ -117,12 +145,26 @@ func FromContext(ctx context.Context, logger Logger) Logger {
 	if ctx == nil {
 		return logger
 	}
-	if logger, ok := ctx.Value(loggerKey).(Logger); ok {
-		return logger
+	if ctxLogger, ok := ctx.Value(loggerKey).(Logger); ok {
+		return inModuleOf(ctxLogger, logger)
 	}
 	return logger
 }
 
+// inModuleOf moves the logger of a request or message into the module of the code logging with it, so the
+// level of that module applies while the fields of the request are kept
+func inModuleOf(ctxLogger Logger, moduleLogger Logger) Logger {
+	c, ok := ctxLogger.(*AppLogger)
+	if !ok {
+		return ctxLogger
+	}
+	m, ok := moduleLogger.(*AppLogger)
+	if !ok || m.module == "" || m.module == c.module {
+		return ctxLogger
+	}
+	return c.Module(m.module)
+}
+
 func mapToZapFields(fields []Field) []zap.Field {
 	zapFields := make([]zap.Field, len(fields))
 	for i, f := range fields {
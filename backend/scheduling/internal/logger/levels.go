@@ -0,0 +1,187 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	ErrUnknownLevel  = errors.New("level must be one of debug, info, warn or error")
+	ErrUnknownModule = errors.New("module not known")
+)
+
+// Levels holds the minimum level logged, as a default and overrides per module, and can be changed at runtime.
+// Changes apply to loggers created before them.
+type Levels struct {
+	level zap.AtomicLevel
+
+	mu        sync.RWMutex
+	modules   map[string]bool
+	overrides map[string]zapcore.Level
+}
+
+// NewLevels starts from the configured default level and module overrides
+func NewLevels(level string, moduleLevels map[string]string) (*Levels, error) {
+	l := &Levels{level: zap.NewAtomicLevel(), modules: map[string]bool{}, overrides: map[string]zapcore.Level{}}
+	if err := l.SetLevel(level); err != nil {
+		return nil, err
+	}
+	for module, moduleLevel := range moduleLevels {
+		parsed, err := parseLevel(moduleLevel)
+		if err != nil {
+			return nil, fmt.Errorf("module %s: %w", module, err)
+		}
+		l.overrides[module] = parsed
+	}
+	return l, nil
+}
+
+// Level returns the default level of modules without an override
+func (l *Levels) Level() string {
+	return l.level.Level().String()
+}
+
+func (l *Levels) SetLevel(level string) error {
+	parsed, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	l.level.SetLevel(parsed)
+	return nil
+}
+
+// Modules returns the names of the modules loggers were created for, sorted
+func (l *Levels) Modules() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	modules := make([]string, 0, len(l.modules))
+	for module := range l.modules {
+		modules = append(modules, module)
+	}
+	slices.Sort(modules)
+	return modules
+}
+
+// ModuleLevels returns the overridden level of each module that has one
+func (l *Levels) ModuleLevels() map[string]string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	levels := make(map[string]string, len(l.overrides))
+	for module, level := range l.overrides {
+		levels[module] = level.String()
+	}
+	return levels
+}
+
+// SetModuleLevel overrides the level of a module a logger was created for
+func (l *Levels) SetModuleLevel(module string, level string) error {
+	parsed, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.modules[module] {
+		return ErrUnknownModule
+	}
+	l.overrides[module] = parsed
+	return nil
+}
+
+// ResetModuleLevel removes the override of a module so it logs at the default level again
+func (l *Levels) ResetModuleLevel(module string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.modules[module] {
+		return ErrUnknownModule
+	}
+	delete(l.overrides, module)
+	return nil
+}
+
+// Enabled reports whether a module logs entries of the given level, the module is empty for the root logger
+func (l *Levels) Enabled(module string, level zapcore.Level) bool {
+	if module != "" {
+		l.mu.RLock()
+		override, ok := l.overrides[module]
+		l.mu.RUnlock()
+		if ok {
+			return override.Enabled(level)
+		}
+	}
+	return l.level.Enabled(level)
+}
+
+func (l *Levels) register(module string) {
+	l.mu.RLock()
+	known := l.modules[module]
+	l.mu.RUnlock()
+	if known {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.modules[module] = true
+}
+
+func parseLevel(level string) (zapcore.Level, error) {
+	switch level {
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info":
+		return zapcore.InfoLevel, nil
+	case "warn":
+		return zapcore.WarnLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	default:
+		return zapcore.InfoLevel, ErrUnknownLevel
+	}
+}
+
+// levelCore filters the entries of a core by the level of its module and tags them with the module
+type levelCore struct {
+	zapcore.Core
+	levels *Levels
+	module string
+}
+
+// moduleCore filters core by the level of module. A core that already filters for another module is
+// unwrapped first, so a module logger derived from another one follows its own level alone.
+func moduleCore(core zapcore.Core, levels *Levels, module string) zapcore.Core {
+	if c, ok := core.(*levelCore); ok {
+		core = c.Core
+	}
+	return &levelCore{Core: core, levels: levels, module: module}
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.levels.Enabled(c.module, level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels, module: c.module}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) {
+		return checked
+	}
+	return checked.AddCore(entry, c)
+}
+
+func (c *levelCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if c.module != "" {
+		fields = append(fields, zap.String("module", c.module))
+	}
+	return c.Core.Write(entry, fields)
+}
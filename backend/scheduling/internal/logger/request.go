@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIdHeader carries the id of a request across services, so their log lines can be joined
+const RequestIdHeader = "X-Request-ID"
+
+const requestIdKey ctxKey = "request_id"
+
+// WithRequestId stores the id of the request being served, outbound calls and events made within ctx forward it
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	if requestId == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIdKey, requestId)
+}
+
+// RequestIdFromContext returns the request id stored in ctx, empty when none
+func RequestIdFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey).(string)
+	return id
+}
+
+// TraceFields returns the trace and span ids of the span in ctx as log fields, none when there is no span
+func TraceFields(ctx context.Context) []Field {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return nil
+	}
+	return []Field{
+		{Key: "trace_id", Value: spanCtx.TraceID().String()},
+		{Key: "span_id", Value: spanCtx.SpanID().String()},
+	}
+}
@@ -0,0 +1,35 @@
+package logging
+
+import "github.com/maksmelnyk/scheduling/internal/apperrors"
+
+// swagger:model LogLevelRequest
+type LogLevelRequest struct {
+	// Level is debug, info, warn or error
+	Level string
+}
+
+// swagger:model LogLevelsResponse
+type LogLevelsResponse struct {
+	Level   string                 `json:"level"`
+	Modules []*ModuleLevelResponse `json:"modules"`
+}
+
+// swagger:model ModuleLevelResponse
+type ModuleLevelResponse struct {
+	Module string `json:"module"`
+	Level  string `json:"level"`
+	// Overridden is false while the module logs at the default level
+	Overridden bool `json:"overridden"`
+}
+
+func (r *LogLevelRequest) Validate() error {
+	switch r.Level {
+	case "debug", "info", "warn", "error":
+		return nil
+	}
+
+	return apperrors.NewValidation("Log level failed validation", apperrors.ErrValidationFailed, []apperrors.ValidationErrorDetail{{
+		Field:   "Level",
+		Message: "must be one of debug, info, warn or error",
+	}})
+}
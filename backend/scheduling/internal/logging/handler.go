@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type LoggingHandler struct {
+	service *LoggingService
+}
+
+func NewLoggingHandler(service *LoggingService) *LoggingHandler {
+	return &LoggingHandler{service: service}
+}
+
+// GetLogLevels retrieves the log levels in effect.
+// @Summary      Retrieve log levels
+// @Description  Returns the default level this instance logs at and the level of every module, overridden or not.
+// @Tags         Logging
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  LogLevelsResponse  "Log levels"
+// @Router       /api/v1/admin/logging [get]
+// @Security 	 BearerAuth
+func (h *LoggingHandler) GetLogLevels(w http.ResponseWriter, r *http.Request) {
+	levels, err := h.service.GetLogLevels(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, levels)
+}
+
+// UpdateLogLevel changes the default log level.
+// @Summary      Update log level
+// @Description  Changes the level of modules without an override on every running instance until they restart. Instances started later log at the configured level.
+// @Tags         Logging
+// @Accept       json
+// @Produce      json
+// @Param        level  body      LogLevelRequest    true  "Log level"
+// @Success      200    {object}  LogLevelsResponse  "Log levels in effect"
+// @Failure      400    {object}  error              "Invalid level"
+// @Router       /api/v1/admin/logging/level [put]
+// @Security 	 BearerAuth
+func (h *LoggingHandler) UpdateLogLevel(w http.ResponseWriter, r *http.Request) {
+	var request *LogLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	levels, err := h.service.UpdateLogLevel(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, levels)
+}
+
+// UpdateModuleLevel overrides the log level of a module.
+// @Summary      Override module log level
+// @Description  Sets the level of a single module on every running instance until they restart, regardless of the default level.
+// @Tags         Logging
+// @Accept       json
+// @Produce      json
+// @Param        module  path      string             true  "Module name"
+// @Param        level   body      LogLevelRequest    true  "Log level"
+// @Success      200     {object}  LogLevelsResponse  "Log levels in effect"
+// @Failure      400     {object}  error              "Invalid level"
+// @Failure      404     {object}  error              "Module not found"
+// @Router       /api/v1/admin/logging/modules/{module} [put]
+// @Security 	 BearerAuth
+func (h *LoggingHandler) UpdateModuleLevel(w http.ResponseWriter, r *http.Request) {
+	var request *LogLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	levels, err := h.service.UpdateModuleLevel(r.Context(), chi.URLParam(r, "module"), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, levels)
+}
+
+// ResetModuleLevel removes the log level override of a module.
+// @Summary      Reset module log level
+// @Description  Removes the level override of a module so it logs at the default level again.
+// @Tags         Logging
+// @Accept       json
+// @Produce      json
+// @Param        module  path      string             true  "Module name"
+// @Success      200     {object}  LogLevelsResponse  "Log levels in effect"
+// @Failure      404     {object}  error              "Module not found"
+// @Router       /api/v1/admin/logging/modules/{module} [delete]
+// @Security 	 BearerAuth
+func (h *LoggingHandler) ResetModuleLevel(w http.ResponseWriter, r *http.Request) {
+	levels, err := h.service.ResetModuleLevel(r.Context(), chi.URLParam(r, "module"))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, levels)
+}
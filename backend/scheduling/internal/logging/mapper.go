@@ -0,0 +1,14 @@
+package logging
+
+// MapLevelsToResponse lists every module a logger was created for with the level it logs at
+func MapLevelsToResponse(level string, modules []string, overrides map[string]string) *LogLevelsResponse {
+	response := &LogLevelsResponse{Level: level, Modules: make([]*ModuleLevelResponse, 0, len(modules))}
+	for _, module := range modules {
+		moduleLevel, overridden := overrides[module]
+		if !overridden {
+			moduleLevel = level
+		}
+		response.Modules = append(response.Modules, &ModuleLevelResponse{Module: module, Level: moduleLevel, Overridden: overridden})
+	}
+	return response
+}
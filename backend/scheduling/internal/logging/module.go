@@ -0,0 +1,18 @@
+package logging
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/broadcast"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeLoggingService(log *logger.AppLogger, broadcaster *broadcast.Broadcaster) *LoggingService {
+	service := NewLoggingService(log, log.Levels(), broadcaster)
+	return service
+}
+
+func InitializeLoggingHTTPHandler(service *LoggingService) http.Handler {
+	handler := NewLoggingHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,23 @@
+package logging
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *LoggingHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.AdminRole))
+	r.Get("/", handler.GetLogLevels)
+	r.Put("/level", handler.UpdateLogLevel)
+	r.Put("/modules/{module}", handler.UpdateModuleLevel)
+	r.Delete("/modules/{module}", handler.ResetModuleLevel)
+
+	return r
+}
@@ -0,0 +1,133 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/broadcast"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// LevelController changes the levels loggers log at, as a default and per module
+type LevelController interface {
+	Level() string
+	SetLevel(level string) error
+	Modules() []string
+	ModuleLevels() map[string]string
+	SetModuleLevel(module string, level string) error
+	ResetModuleLevel(module string) error
+}
+
+// Broadcaster tells the other instances about changed levels
+type Broadcaster interface {
+	Broadcast(ctx context.Context, kind string, data any) error
+}
+
+// LogLevelsSignal carries the levels of an instance to the others
+type LogLevelsSignal struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"`
+}
+
+// LoggingService adjusts log levels without a restart. Changes are broadcast to the running instances but
+// not persisted, instances started later log at the configured levels.
+type LoggingService struct {
+	log         logger.Logger
+	levels      LevelController
+	broadcaster Broadcaster
+}
+
+func NewLoggingService(log logger.Logger, levels LevelController, broadcaster Broadcaster) *LoggingService {
+	return &LoggingService{log: log, levels: levels, broadcaster: broadcaster}
+}
+
+func (s *LoggingService) GetLogLevels(ctx context.Context) (*LogLevelsResponse, error) {
+	return s.current(), nil
+}
+
+// UpdateLogLevel changes the default level, modules with an override keep theirs
+func (s *LoggingService) UpdateLogLevel(ctx context.Context, request *LogLevelRequest) (*LogLevelsResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.levels.SetLevel(request.Level); err != nil {
+		return nil, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterInvalid)
+	}
+
+	log.Infof("Log level changed to %s", request.Level)
+	s.broadcast(ctx)
+	return s.current(), nil
+}
+
+// UpdateModuleLevel overrides the level of a single module
+func (s *LoggingService) UpdateModuleLevel(ctx context.Context, module string, request *LogLevelRequest) (*LogLevelsResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.levels.SetModuleLevel(module, request.Level); err != nil {
+		return nil, mapLevelError(err)
+	}
+
+	log.Infof("Log level of module %s changed to %s", module, request.Level)
+	s.broadcast(ctx)
+	return s.current(), nil
+}
+
+// ResetModuleLevel removes the override of a module so it logs at the default level again
+func (s *LoggingService) ResetModuleLevel(ctx context.Context, module string) (*LogLevelsResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.levels.ResetModuleLevel(module); err != nil {
+		return nil, mapLevelError(err)
+	}
+
+	log.Infof("Log level override of module %s removed", module)
+	s.broadcast(ctx)
+	return s.current(), nil
+}
+
+// ApplyLogLevels takes over the levels broadcast by another instance. Overrides of modules this instance
+// has no logger for are left out.
+func (s *LoggingService) ApplyLogLevels(ctx context.Context, data json.RawMessage) error {
+	var signal LogLevelsSignal
+	if err := json.Unmarshal(data, &signal); err != nil {
+		return err
+	}
+
+	if err := s.levels.SetLevel(signal.Level); err != nil {
+		return err
+	}
+	for module := range s.levels.ModuleLevels() {
+		if _, ok := signal.Modules[module]; !ok {
+			_ = s.levels.ResetModuleLevel(module)
+		}
+	}
+	for module, level := range signal.Modules {
+		if err := s.levels.SetModuleLevel(module, level); err != nil && !errors.Is(err, logger.ErrUnknownModule) {
+			return err
+		}
+	}
+	return nil
+}
+
+// broadcast sends the levels in effect to the other instances; failures are logged, the change still
+// applies to this instance
+func (s *LoggingService) broadcast(ctx context.Context) {
+	log := logger.FromContext(ctx, s.log)
+
+	signal := LogLevelsSignal{Level: s.levels.Level(), Modules: s.levels.ModuleLevels()}
+	if err := s.broadcaster.Broadcast(ctx, broadcast.SignalLogLevels, signal); err != nil {
+		log.Error("failed to broadcast log levels", err)
+	}
+}
+
+func (s *LoggingService) current() *LogLevelsResponse {
+	return MapLevelsToResponse(s.levels.Level(), s.levels.Modules(), s.levels.ModuleLevels())
+}
+
+func mapLevelError(err error) error {
+	if errors.Is(err, logger.ErrUnknownModule) {
+		return apperrors.NewNotFound("Module not found", apperrors.ErrLogModuleUnknown)
+	}
+	return apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterInvalid)
+}
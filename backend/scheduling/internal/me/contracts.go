@@ -0,0 +1,43 @@
+package me
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/formatting"
+)
+
+// Feed item kinds
+const (
+	ConfirmedSessionKind = "confirmed"
+	PendingRequestKind   = "pending"
+)
+
+// swagger:model MySessionsResponse
+type MySessionsResponse struct {
+	Timezone   string               `json:"timezone"`
+	TimeFormat formatting.Clock     `json:"timeFormat"`
+	Language   string               `json:"language"`
+	Total      int                  `json:"total"`
+	Skip       int                  `json:"skip"`
+	Take       int                  `json:"take"`
+	Items      []*MySessionResponse `json:"items"`
+}
+
+// swagger:model MySessionResponse
+type MySessionResponse struct {
+	Kind           string    `json:"kind"`
+	BookingId      int64     `json:"bookingId"`
+	EducatorId     uuid.UUID `json:"educatorId"`
+	ProductId      int64     `json:"productId"`
+	Title          string    `json:"title"`
+	StartTime      time.Time `json:"startTime"`
+	EndTime        time.Time `json:"endTime"`
+	LocalStartTime string    `json:"localStartTime"`
+	LocalEndTime   string    `json:"localEndTime"`
+	// LocalStart and LocalEnd spell out the session times in the user's clock and language
+	LocalStart *formatting.FormattedTime `json:"localStart"`
+	LocalEnd   *formatting.FormattedTime `json:"localEnd"`
+	Price      float64                   `json:"price"`
+}
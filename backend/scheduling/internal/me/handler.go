@@ -0,0 +1,59 @@
+package me
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type MeHandler struct {
+	service *MeService
+}
+
+func NewMeHandler(service *MeService) *MeHandler {
+	return &MeHandler{service: service}
+}
+
+// GetMySessions retrieves the upcoming sessions feed of the current student.
+// @Summary      Retrieve my upcoming sessions
+// @Description  Merges the student's confirmed bookings and pending requests across educators into one feed ordered by start time, with times localized to the given or preferred time zone. Times are also spelled out on the requested or preferred 12h or 24h clock, with weekday names in the Accept-Language or preferred language.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Param        timezone         query     string              false  "IANA time zone, defaults to the notification preferences"
+// @Param        timeFormat       query     string              false  "12h or 24h, defaults to the notification preferences. Also accepted as Prefer: time-format=12h"
+// @Param        Accept-Language  header    string              false  "Preferred languages of the weekday names"
+// @Param        skip             query     int                 false  "Number of items to skip"
+// @Param        take             query     int                 false  "Number of items to return"
+// @Success      200              {object}  MySessionsResponse  "Upcoming sessions"
+// @Failure      400              {object}  error               "Invalid input parameters"
+// @Router       /api/v1/me/sessions [get]
+// @Security 	 BearerAuth
+func (h *MeHandler) GetMySessions(w http.ResponseWriter, r *http.Request) {
+	skip, err := api.ParseIntQuery(w, r, "skip", 0)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	take, err := api.ParseIntQuery(w, r, "take", 20)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	clock, err := api.ParseTimeFormat(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	sessions, err := h.service.GetMySessions(r.Context(), r.URL.Query().Get("timezone"), clock, skip, take)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, sessions)
+}
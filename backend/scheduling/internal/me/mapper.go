@@ -0,0 +1,38 @@
+package me
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/formatting"
+)
+
+func MapBookingToSessionResponse(b *entities.Booking, f *formatting.Formatter) *MySessionResponse {
+	kind := PendingRequestKind
+	if b.Status.IsConfirmed() {
+		kind = ConfirmedSessionKind
+	}
+
+	return &MySessionResponse{
+		Kind:           kind,
+		BookingId:      b.Id,
+		EducatorId:     b.EducatorId,
+		ProductId:      b.ProductId,
+		Title:          b.Title,
+		StartTime:      b.StartTime,
+		EndTime:        b.EndTime,
+		LocalStartTime: b.StartTime.In(f.Location()).Format(time.RFC3339),
+		LocalEndTime:   b.EndTime.In(f.Location()).Format(time.RFC3339),
+		LocalStart:     f.Format(b.StartTime),
+		LocalEnd:       f.Format(b.EndTime),
+		Price:          b.Price,
+	}
+}
+
+func MapBookingsToSessionResponses(bookings []*entities.Booking, f *formatting.Formatter) []*MySessionResponse {
+	response := make([]*MySessionResponse, len(bookings))
+	for i, b := range bookings {
+		response[i] = MapBookingToSessionResponse(b, f)
+	}
+	return response
+}
@@ -0,0 +1,20 @@
+package me
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeMeService(log logger.Logger, db *sqlx.DB, preferences PreferenceProvider) *MeService {
+	repo := NewMeRepository(db)
+	service := NewMeService(log, repo, preferences)
+	return service
+}
+
+func InitializeMeHTTPHandler(service *MeService) http.Handler {
+	handler := NewMeHandler(service)
+	return Routes(handler)
+}
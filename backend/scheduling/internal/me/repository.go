@@ -0,0 +1,46 @@
+package me
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type MeRepo struct {
+	db *sqlx.DB
+}
+
+func NewMeRepository(db *sqlx.DB) *MeRepo {
+	return &MeRepo{db: db}
+}
+
+// GetUpcomingStudentBookings retrieves open bookings of a student that have not ended yet, see entities.OpenStatuses
+func (r *MeRepo) GetUpcomingStudentBookings(ctx context.Context, studentId uuid.UUID, after time.Time, skip int, take int) ([]*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, working_period_id, COALESCE(title, '') AS title, start_time, end_time, status, price, created_at, updated_at
+		FROM booking
+		WHERE student_id = $1 AND status = ANY($2) AND end_time > $3
+		ORDER BY start_time, id OFFSET $4 LIMIT $5
+	`
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, studentId, entities.OpenStatuses, after, skip, take)
+}
+
+// CountUpcomingStudentBookings counts open bookings of a student that have not ended yet
+func (r *MeRepo) CountUpcomingStudentBookings(ctx context.Context, studentId uuid.UUID, after time.Time) (int, error) {
+	const query = `
+		SELECT COUNT(*)
+		FROM booking
+		WHERE student_id = $1 AND status = ANY($2) AND end_time > $3
+	`
+	var count int
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &count, query, studentId, entities.OpenStatuses, after); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return count, nil
+}
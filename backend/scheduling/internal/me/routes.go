@@ -0,0 +1,16 @@
+package me
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes(handler *MeHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/sessions", handler.GetMySessions)
+
+	return r
+}
@@ -0,0 +1,103 @@
+package me
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/formatting"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+)
+
+type MeRepository interface {
+	GetUpcomingStudentBookings(ctx context.Context, studentId uuid.UUID, after time.Time, skip int, take int) ([]*entities.Booking, error)
+	CountUpcomingStudentBookings(ctx context.Context, studentId uuid.UUID, after time.Time) (int, error)
+}
+
+// PreferenceProvider resolves the notification preferences, including the time zone and time format, of the current user
+type PreferenceProvider interface {
+	GetMyPreferences(ctx context.Context) (*notifications.NotificationPreferenceResponse, error)
+}
+
+type MeService struct {
+	log         logger.Logger
+	repo        MeRepository
+	preferences PreferenceProvider
+}
+
+func NewMeService(log logger.Logger, repo MeRepository, preferences PreferenceProvider) *MeService {
+	return &MeService{log: log, repo: repo, preferences: preferences}
+}
+
+// GetMySessions returns the student's upcoming confirmed sessions and pending requests across all
+// educators, localized to the requested time zone and clock or, when absent, the ones from the student's preferences
+func (s *MeService) GetMySessions(ctx context.Context, timezone string, clock formatting.Clock, skip int, take int) (*MySessionsResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	formatter, err := s.resolveFormatter(ctx, timezone, clock)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	total, err := s.repo.CountUpcomingStudentBookings(ctx, userId, now)
+	if err != nil {
+		log.Error("failed to count upcoming bookings", err)
+		return nil, err
+	}
+
+	bookings, err := s.repo.GetUpcomingStudentBookings(ctx, userId, now, skip, take)
+	if err != nil {
+		log.Error("failed to get upcoming bookings", err)
+		return nil, err
+	}
+
+	return &MySessionsResponse{
+		Timezone:   formatter.Location().String(),
+		TimeFormat: formatter.Clock(),
+		Language:   formatter.Language(),
+		Total:      total,
+		Skip:       skip,
+		Take:       take,
+		Items:      MapBookingsToSessionResponses(bookings, formatter),
+	}, nil
+}
+
+// resolveFormatter combines the time zone and clock of the request with the student's preferences. Failing
+// to load the preferences falls back to UTC and the defaults of the formatter.
+func (s *MeService) resolveFormatter(ctx context.Context, timezone string, clock formatting.Clock) (*formatting.Formatter, error) {
+	var loc *time.Location
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return nil, apperrors.NewBadRequestError("timezone must be a valid IANA time zone", apperrors.ErrParameterParsingFailed)
+		}
+	}
+
+	var preference *formatting.Preference
+	preferences, err := s.preferences.GetMyPreferences(ctx)
+	if err != nil {
+		logger.FromContext(ctx, s.log).Error("failed to get notification preferences", err)
+	} else {
+		preference = &formatting.Preference{Clock: formatting.Clock(preferences.TimeFormat), Language: preferences.Language}
+		if loc == nil {
+			loc, _ = time.LoadLocation(preferences.Timezone)
+		}
+	}
+
+	if loc == nil {
+		loc = time.UTC
+	}
+	return formatting.NewFormatter(ctx, loc, clock, preference), nil
+}
@@ -0,0 +1,110 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Content types of message payloads
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// Codec encodes message payloads in one content type. Events are built, checked against their contract,
+// audited and kept in the outbox as JSON; a codec only changes how they travel over the wire, so every
+// codec turns a JSON payload into a message body and back.
+type Codec interface {
+	ContentType() string
+	Encode(payload []byte) ([]byte, error)
+	Decode(body []byte) ([]byte, error)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		ContentTypeJSON:     jsonCodec{},
+		ContentTypeProtobuf: protobufCodec{},
+	}
+)
+
+// RegisterCodec makes a codec available to publish and consume messages of its content type, replacing the
+// one registered for it before
+func RegisterCodec(codec Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[codec.ContentType()] = codec
+}
+
+// CodecFor returns the codec of a content type, parameters such as the charset are ignored. Messages
+// without a content type predate codecs and are JSON.
+func CodecFor(contentType string) (Codec, error) {
+	if contentType == "" {
+		return jsonCodec{}, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type '%s': %w", contentType, err)
+	}
+
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	codec, ok := codecs[mediaType]
+	if !ok {
+		return nil, fmt.Errorf("no codec registered for content type '%s'", mediaType)
+	}
+	return codec, nil
+}
+
+// DecodeBody returns the JSON payload of a delivery, decoded with the codec of its content type
+func DecodeBody(msg amqp.Delivery) ([]byte, error) {
+	codec, err := CodecFor(msg.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Decode(msg.Body)
+}
+
+// Unmarshal reads the payload of a delivery into v, whatever content type it was published in
+func Unmarshal(msg amqp.Delivery, v any) error {
+	payload, err := DecodeBody(msg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, v)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string                   { return ContentTypeJSON }
+func (jsonCodec) Encode(payload []byte) ([]byte, error) { return payload, nil }
+func (jsonCodec) Decode(body []byte) ([]byte, error)    { return body, nil }
+
+// protobufCodec carries payloads as a google.protobuf.Struct, which services in any language read with
+// the well-known types of their protobuf runtime. Numbers travel as doubles, exact up to 2^53.
+type protobufCodec struct{}
+
+func (protobufCodec) ContentType() string { return ContentTypeProtobuf }
+
+func (protobufCodec) Encode(payload []byte) ([]byte, error) {
+	var message structpb.Struct
+	if err := protojson.Unmarshal(payload, &message); err != nil {
+		return nil, fmt.Errorf("failed to encode payload as protobuf: %w", err)
+	}
+	return proto.Marshal(&message)
+}
+
+func (protobufCodec) Decode(body []byte) ([]byte, error) {
+	var message structpb.Struct
+	if err := proto.Unmarshal(body, &message); err != nil {
+		return nil, fmt.Errorf("failed to decode protobuf payload: %w", err)
+	}
+	return protojson.Marshal(&message)
+}
@@ -21,16 +21,58 @@ const (
 	SchedulingDLQRoutingKey = "dlq.scheduling"
 
 	// Routing patterns
-	PaymentToSchedulingPattern = "payment.to.scheduling.#"
+	PaymentToSchedulingPattern  = "payment.to.scheduling.#"
+	ProfileToSchedulingPattern  = "profile.to.scheduling.#"
+	LearningToSchedulingPattern = "learning.to.scheduling.#"
 
 	// Routing keys for publishing
 	BookingCompletedKey = "scheduling.to.learning.booking.completed"
 	EventScheduledKey   = "scheduling.to.learning.event.scheduled"
+	AttendanceKey       = "scheduling.to.learning.attendance.recorded"
+	SessionNoteKey      = "scheduling.to.learning.session.note"
+	PayoutStatementKey  = "scheduling.to.payment.payout.statement"
+	InvoiceGeneratedKey = "scheduling.to.payment.invoice.generated"
+	NotificationKey     = "scheduling.to.notification.requested"
+	SnapshotKey         = "scheduling.to.snapshot.export"
+	BulkCancellationKey = "scheduling.to.payment.bookings.cancelled"
+	PriceAdjustmentKey  = "scheduling.to.payment.booking.price.adjusted"
+	BookingUpdatedKey   = "scheduling.to.calendar.booking.updated"
+	OffboardingKey      = "scheduling.to.learning.educator.offboarding"
+	BookingReassignKey  = "scheduling.to.learning.booking.reassigned"
+	WaitlistKey         = "scheduling.to.learning.waitlist.updated"
+	CancellationKey     = "scheduling.to.payment.booking.cancellation.settled"
+	SessionReminderKey  = "scheduling.to.notification.session.reminder"
+	BookingSLAKey       = "scheduling.to.notification.booking.sla.breached"
+	BookingStatusKey    = "scheduling.to.learning.booking.status.changed"
 
 	// Event types
 	BookingCreationRequested = "BOOKING_CREATION_REQUESTED"
 	BookingCompleted         = "BOOKING_COMPLETED"
 	EventScheduled           = "EVENT_SCHEDULED"
+	AttendanceRecorded       = "ATTENDANCE_RECORDED"
+	SessionNoteUpdated       = "SESSION_NOTE_UPDATED"
+	PayoutStatementGenerated = "PAYOUT_STATEMENT_GENERATED"
+	InvoiceGenerated         = "INVOICE_GENERATED"
+	NotificationRequested    = "NOTIFICATION_REQUESTED"
+	UserDeleted              = "USER_DELETED"
+	ProductCatalogUpdated    = "PRODUCT_CATALOG_UPDATED"
+	EnrollmentCreated        = "ENROLLMENT_CREATED"
+	SnapshotStarted          = "SNAPSHOT_STARTED"
+	SnapshotItem             = "SNAPSHOT_ITEM"
+	SnapshotCompleted        = "SNAPSHOT_COMPLETED"
+	BookingsCancelled        = "BOOKINGS_CANCELLED"
+	BookingPriceAdjusted     = "BOOKING_PRICE_ADJUSTED"
+	BookingUpdated           = "BOOKING_UPDATED"
+	OffboardingStarted       = "EDUCATOR_OFFBOARDING_STARTED"
+	EducatorArchived         = "EDUCATOR_ARCHIVED"
+	BookingReassigned        = "BOOKING_REASSIGNED"
+	BookingHoldPaid          = "BOOKING_HOLD_PAID"
+	WaitlistSpotOffered      = "WAITLIST_SPOT_OFFERED"
+	WaitlistPromoted         = "WAITLIST_PROMOTED"
+	CancellationSettled      = "BOOKING_CANCELLATION_SETTLED"
+	SessionReminderDue       = "SESSION_REMINDER_DUE"
+	BookingSLABreached       = "BOOKING_SLA_BREACHED"
+	BookingStatusChanged     = "BOOKING_STATUS_CHANGED"
 )
 
 type ConnectionProvider struct {
@@ -12,27 +12,63 @@ import (
 	"github.com/maksmelnyk/scheduling/internal/logger"
 )
 
+// Deduplicator tracks processed message Ids, so redelivered messages are acknowledged without being handled again
+type Deduplicator interface {
+	IsDuplicate(ctx context.Context, messageId string, routingKey string) (bool, error)
+	MarkProcessed(ctx context.Context, messageId string, routingKey string) error
+}
+
 type Consumer struct {
 	provider        *ConnectionProvider
 	config          *config.RabbitMqConfig
 	queue           string
 	routingPatterns []string
+	dedup           Deduplicator
+	auditor         EventAuditor
+	metrics         *Metrics
+	tuner           *prefetchTuner
+	paused          func() bool
 	channel         *amqp.Channel
+	sub             *subscription
 	log             *logger.AppLogger
 	mu              sync.Mutex
 	isConsuming     bool
 	stopChan        chan any
 }
 
-func NewConsumer(provider *ConnectionProvider, config *config.RabbitMqConfig, log *logger.AppLogger, routingPatterns []string) *Consumer {
-	return &Consumer{
+// NewConsumer creates a consumer of the scheduling queue. Messages carrying an Id are deduplicated when a
+// deduplicator is given, processed messages are recorded when an auditor is given, and handler durations
+// when metrics are given.
+func NewConsumer(
+	provider *ConnectionProvider,
+	config *config.RabbitMqConfig,
+	log *logger.AppLogger,
+	routingPatterns []string,
+	dedup Deduplicator,
+	auditor EventAuditor,
+	metrics *Metrics,
+) *Consumer {
+	c := &Consumer{
 		provider:        provider,
 		config:          config,
 		queue:           SchedulingQueueName,
 		routingPatterns: routingPatterns,
+		dedup:           dedup,
+		auditor:         auditor,
+		metrics:         metrics,
 		log:             log,
 		stopChan:        make(chan any),
 	}
+	if config.PrefetchAutoTune {
+		c.tuner = newPrefetchTuner(config, log, c.setPrefetch)
+	}
+	return c
+}
+
+// PauseWhile holds back message handling while paused reports true, such as in read-only mode. It must be
+// set before consuming starts.
+func (c *Consumer) PauseWhile(paused func() bool) {
+	c.paused = paused
 }
 
 func (c *Consumer) Initialize(ctx context.Context) error {
@@ -53,7 +89,7 @@ func (c *Consumer) Initialize(ctx context.Context) error {
 		return fmt.Errorf("failed to create channel for consumer: %w", err)
 	}
 
-	err = channel.Qos(c.config.PrefetchCount, 0, false)
+	err = channel.Qos(c.prefetchCount(), 0, false)
 	if err != nil {
 		channel.Close()
 		return fmt.Errorf("failed to set QoS: %w", err)
@@ -140,27 +176,33 @@ func (c *Consumer) StartConsuming(ctx context.Context, messageHandler func(ctx c
 		return fmt.Errorf("failed to get consumer channel: %w", err)
 	}
 
-	messages, err := channel.Consume(
-		c.queue, // queue
-		"",      // consumer tag - auto-generated
-		false,   // auto-ack - set to false for manual acknowledgment
-		false,   // exclusive
-		false,   // no-local
-		false,   // no-wait
-		nil,     // args
-	)
-	if err != nil {
+	sub := newSubscription(channel, c.queue)
+	if err := sub.start(); err != nil {
 		c.mu.Lock()
 		c.isConsuming = false
 		c.mu.Unlock()
-		return fmt.Errorf("failed to start consuming from queue '%s': %w", c.queue, err)
+		return err
 	}
+	defer sub.stop()
+	messages := sub.deliveries
+
+	c.mu.Lock()
+	c.sub = sub
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.sub = nil
+		c.mu.Unlock()
+	}()
 
 	var wg sync.WaitGroup
-	consumerErrors := make(chan error, c.config.ConcurrentConsumers)
 	consumerCtx, cancelConsumers := context.WithCancel(ctx)
 	defer cancelConsumers()
 
+	if c.tuner != nil {
+		go c.tuner.Run(consumerCtx)
+	}
+
 	for i := range c.config.ConcurrentConsumers {
 		wg.Add(1)
 		go func(consumerID int) {
@@ -169,11 +211,27 @@ func (c *Consumer) StartConsuming(ctx context.Context, messageHandler func(ctx c
 
 			for {
 				select {
-				case msg, ok := <-messages:
-					if !ok {
-						c.log.Warnf("Consumer %d: messages channel closed", consumerID)
-						consumerErrors <- fmt.Errorf("consumer %d: messages channel closed", consumerID)
-						return
+				case msg := <-messages:
+					if c.isPaused() {
+						c.requeueWhilePaused(consumerCtx, consumerID, msg)
+						continue
+					}
+
+					if c.isDuplicate(consumerCtx, consumerID, msg) {
+						if err := msg.Ack(false); err != nil {
+							c.log.Errorf("Consumer %d: Failed to ACK duplicate message %s: %v", consumerID, msg.MessageId, err)
+						}
+						continue
+					}
+
+					// A version this replica can not read is dead-lettered right away, retries would not help. It
+					// is requeued from the dead letters once a replica reading it is deployed.
+					if _, err := NegotiateVersion(msg); err != nil {
+						c.log.Errorf("Consumer %d: Rejecting message %s to DLQ: %v", consumerID, msg.MessageId, err)
+						if err := msg.Nack(false, false); err != nil {
+							c.log.Errorf("Consumer %d: Failed to NACK message %s: %v", consumerID, msg.MessageId, err)
+						}
+						continue
 					}
 
 					maxRetries := c.config.RetryCount
@@ -181,12 +239,27 @@ func (c *Consumer) StartConsuming(ctx context.Context, messageHandler func(ctx c
 					maxDelay := time.Duration(c.config.MaxRetryIntervalMs) * time.Millisecond
 					var processingErr error
 
+					// Events published while handling the message continue its correlation and name it as their cause
+					deliveryCtx := WithCorrelation(consumerCtx, deliveryCorrelationId(msg), msg.MessageId)
+					deliveryCtx = withDeliveryLogger(deliveryCtx, c.log, msg)
+					deliveryCtx = withDeliveryTenant(deliveryCtx, msg)
+
 					for attempt := 0; attempt <= maxRetries; attempt++ {
-						msgCtx, cancel := context.WithTimeout(consumerCtx, 30*time.Second)
+						msgCtx, cancel := context.WithTimeout(deliveryCtx, 30*time.Second)
+						started := time.Now()
 						processingErr = messageHandler(msgCtx, msg)
 						cancel()
+						elapsed := time.Since(started)
+						c.metrics.recordConsume(consumerCtx, msg.RoutingKey, elapsed, processingErr)
+						if c.tuner != nil {
+							c.tuner.Observe(elapsed, processingErr)
+						}
 
 						if processingErr == nil {
+							c.markProcessed(consumerCtx, consumerID, msg)
+							if c.auditor != nil {
+								c.auditor.RecordEvent(consumerCtx, newConsumedRecord(msg))
+							}
 							err := msg.Ack(false)
 							if err != nil {
 								c.log.Errorf("Consumer %d: Failed to ACK message %s after successful processing: %v", consumerID, msg.MessageId, err)
@@ -238,7 +311,7 @@ func (c *Consumer) StartConsuming(ctx context.Context, messageHandler func(ctx c
 	}
 
 	select {
-	case err := <-consumerErrors:
+	case err := <-sub.lost:
 		cancelConsumers()
 		c.mu.Lock()
 		c.isConsuming = false
@@ -267,6 +340,84 @@ func (c *Consumer) StartConsuming(ctx context.Context, messageHandler func(ctx c
 	}
 }
 
+// pauseCheckInterval is how often a paused consumer checks whether it may resume
+const pauseCheckInterval = time.Second
+
+func (c *Consumer) isPaused() bool {
+	return c.paused != nil && c.paused()
+}
+
+// requeueWhilePaused hands a message back to the queue unhandled and blocks until the consumer is no longer
+// paused. The messages already prefetched wait unacknowledged meanwhile and are handled once it resumes, or
+// redelivered when the channel closes.
+func (c *Consumer) requeueWhilePaused(ctx context.Context, consumerID int, msg amqp.Delivery) {
+	if err := msg.Nack(false, true); err != nil {
+		c.log.Errorf("Consumer %d: Failed to requeue message %s while paused: %v", consumerID, msg.MessageId, err)
+	}
+	c.log.Debugf("Consumer %d: Paused, requeued message %s", consumerID, msg.MessageId)
+
+	ticker := time.NewTicker(pauseCheckInterval)
+	defer ticker.Stop()
+	for c.isPaused() {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		case <-c.stopChan:
+			return
+		}
+	}
+}
+
+// isDuplicate reports whether a message was already processed. A failed lookup lets the message through,
+// since handling it twice is preferable to dropping it.
+func (c *Consumer) isDuplicate(ctx context.Context, consumerID int, msg amqp.Delivery) bool {
+	if c.dedup == nil || msg.MessageId == "" {
+		return false
+	}
+
+	duplicate, err := c.dedup.IsDuplicate(ctx, msg.MessageId, msg.RoutingKey)
+	if err != nil {
+		c.log.Warnf("Consumer %d: Failed to check message %s for duplicates: %v", consumerID, msg.MessageId, err)
+		return false
+	}
+	if duplicate {
+		c.log.Infof("Consumer %d: Skipping already processed message %s", consumerID, msg.MessageId)
+	}
+	return duplicate
+}
+
+func (c *Consumer) markProcessed(ctx context.Context, consumerID int, msg amqp.Delivery) {
+	if c.dedup == nil || msg.MessageId == "" {
+		return
+	}
+
+	if err := c.dedup.MarkProcessed(ctx, msg.MessageId, msg.RoutingKey); err != nil {
+		c.log.Errorf("Consumer %d: Failed to record processed message %s: %v", consumerID, msg.MessageId, err)
+	}
+}
+
+// prefetchCount returns the prefetch of a new channel, the tuned value when auto-tuning is enabled
+func (c *Consumer) prefetchCount() int {
+	if c.tuner != nil {
+		return c.tuner.Current()
+	}
+	return c.config.PrefetchCount
+}
+
+// setPrefetch changes the prefetch of the running subscription, which re-creates its consumer for the prefetch
+// to take effect; a reopened channel picks up the tuned value
+func (c *Consumer) setPrefetch(prefetch int) error {
+	c.mu.Lock()
+	sub := c.sub
+	c.mu.Unlock()
+
+	if sub == nil {
+		return fmt.Errorf("consumer is not consuming")
+	}
+	return sub.setPrefetch(prefetch)
+}
+
 func (c *Consumer) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -280,9 +431,16 @@ func (c *Consumer) Close() error {
 func (c *Consumer) Shutdown(ctx context.Context) error {
 	c.mu.Lock()
 	isConsuming := c.isConsuming
+	sub := c.sub
 	c.mu.Unlock()
 
 	if isConsuming {
+		// No further deliveries are taken while the ones in hand finish
+		if sub != nil {
+			if err := sub.cancel(); err != nil {
+				c.log.Warnf("Error cancelling consumer: %v", err)
+			}
+		}
 		close(c.stopChan)
 
 		select {
@@ -302,9 +460,6 @@ func (c *Consumer) Shutdown(ctx context.Context) error {
 
 	if c.channel != nil && !c.channel.IsClosed() {
 		c.log.Debugf("Gracefully shutting down RabbitMQ consumer")
-		if err := c.channel.Cancel("", false); err != nil {
-			c.log.Warnf("Error cancelling consumer: %v", err)
-		}
 
 		select {
 		case <-shutdownCtx.Done():
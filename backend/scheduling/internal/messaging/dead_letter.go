@@ -0,0 +1,136 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DeadLetter is a message rejected by a consumer queue, with the exchange and routing key it was
+// originally published with, so it can be requeued where it came from
+type DeadLetter struct {
+	MessageId      string
+	CorrelationId  string
+	Type           string
+	Exchange       string
+	RoutingKey     string
+	Queue          string
+	Reason         string
+	DeathCount     int64
+	Headers        amqp.Table
+	Body           []byte
+	DeadLetteredAt time.Time
+}
+
+// DeadLetterStore keeps dead-lettered messages for operators
+type DeadLetterStore interface {
+	StoreDeadLetter(ctx context.Context, letter *DeadLetter) error
+}
+
+// deathHeaders are set by the broker when it dead-letters a message, they are dropped on requeue
+var deathHeaders = []string{"x-death", "x-first-death-exchange", "x-first-death-queue", "x-first-death-reason",
+	"x-last-death-exchange", "x-last-death-queue", "x-last-death-reason"}
+
+// newDeadLetter keeps the payload as JSON whatever content type it was published in, so operators can read
+// it and a requeue publishes it as JSON. A payload that cannot be decoded is kept as it came.
+func newDeadLetter(msg amqp.Delivery) *DeadLetter {
+	body, err := DecodeBody(msg)
+	if err != nil {
+		body = msg.Body
+	}
+
+	letter := &DeadLetter{
+		MessageId:      msg.MessageId,
+		CorrelationId:  deliveryCorrelationId(msg),
+		Type:           msg.Type,
+		Exchange:       msg.Exchange,
+		RoutingKey:     msg.RoutingKey,
+		Reason:         "unknown",
+		DeathCount:     1,
+		Headers:        amqp.Table{},
+		Body:           body,
+		DeadLetteredAt: time.Now().UTC(),
+	}
+
+	// The most recent death comes first and names the queue the message was rejected from
+	if deaths, ok := msg.Headers["x-death"].([]any); ok && len(deaths) > 0 {
+		if death, ok := deaths[0].(amqp.Table); ok {
+			letter.Queue, _ = death["queue"].(string)
+			letter.Exchange, _ = death["exchange"].(string)
+			if reason, ok := death["reason"].(string); ok {
+				letter.Reason = reason
+			}
+			if count, ok := death["count"].(int64); ok {
+				letter.DeathCount = count
+			}
+			if keys, ok := death["routing-keys"].([]any); ok && len(keys) > 0 {
+				letter.RoutingKey, _ = keys[0].(string)
+			}
+		}
+	}
+
+	for key, value := range msg.Headers {
+		if !isDeathHeader(key) {
+			letter.Headers[key] = value
+		}
+	}
+	if letter.MessageId == "" {
+		letter.MessageId = "unknown"
+	}
+	return letter
+}
+
+func isDeathHeader(key string) bool {
+	for _, header := range deathHeaders {
+		if strings.EqualFold(key, header) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalHeaders decodes stored headers, keeping whole numbers as integers as the broker delivered them
+func UnmarshalHeaders(data []byte) (amqp.Table, error) {
+	var raw map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode dead letter headers: %w", err)
+	}
+
+	headers := amqp.Table{}
+	for key, value := range raw {
+		headers[key] = headerValue(value)
+	}
+	return headers, nil
+}
+
+func headerValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		values := make([]any, len(v))
+		for i, item := range v {
+			values[i] = headerValue(item)
+		}
+		return values
+	case map[string]any:
+		table := amqp.Table{}
+		for key, item := range v {
+			table[key] = headerValue(item)
+		}
+		return table
+	default:
+		return v
+	}
+}
@@ -2,10 +2,10 @@ package messaging
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/maksmelnyk/scheduling/config"
@@ -21,6 +21,7 @@ type DeadLetterConsumer struct {
 	routingKey    string
 	prefetchCount int
 	log           *logger.AppLogger
+	store         DeadLetterStore
 	channel       *amqp091.Channel
 	mu            sync.Mutex
 	isConsuming   bool
@@ -28,7 +29,12 @@ type DeadLetterConsumer struct {
 	consumerTag   string
 }
 
-func NewDeadLetterConsumer(provider *ConnectionProvider, config *config.RabbitMqConfig, log *logger.AppLogger) *DeadLetterConsumer {
+// storeRetryDelay spaces out attempts to keep a dead letter while its store is unavailable
+const storeRetryDelay = 5 * time.Second
+
+// NewDeadLetterConsumer creates the consumer of the dead letter queue. With a store, messages are kept there
+// for operators and stay in the queue until they are stored, without one they are only logged.
+func NewDeadLetterConsumer(provider *ConnectionProvider, config *config.RabbitMqConfig, log *logger.AppLogger, store DeadLetterStore) *DeadLetterConsumer {
 	return &DeadLetterConsumer{
 		provider:      provider,
 		config:        config,
@@ -37,6 +43,7 @@ func NewDeadLetterConsumer(provider *ConnectionProvider, config *config.RabbitMq
 		routingKey:    SchedulingDLQRoutingKey,
 		prefetchCount: 1,
 		log:           log,
+		store:         store,
 		stopChan:      make(chan struct{}),
 	}
 }
@@ -148,7 +155,7 @@ func (c *DeadLetterConsumer) StartConsuming(ctx context.Context) error {
 					c.mu.Unlock()
 					return
 				}
-				c.handleDLQMessage(msg)
+				c.handleDLQMessage(consumerCtx, msg)
 			}
 		}
 	}()
@@ -156,7 +163,7 @@ func (c *DeadLetterConsumer) StartConsuming(ctx context.Context) error {
 	return nil
 }
 
-func (c *DeadLetterConsumer) handleDLQMessage(msg amqp091.Delivery) {
+func (c *DeadLetterConsumer) handleDLQMessage(ctx context.Context, msg amqp091.Delivery) {
 	c.log.Errorf("DLQ Received Message ID: %s, CorrelationID: %s, Type: %v",
 		msg.MessageId, msg.CorrelationId, msg.Type)
 
@@ -188,7 +195,7 @@ func (c *DeadLetterConsumer) handleDLQMessage(msg amqp091.Delivery) {
 	}
 
 	var parsedBody map[string]interface{}
-	err := json.Unmarshal(msg.Body, &parsedBody)
+	err := Unmarshal(msg, &parsedBody)
 	if err == nil {
 		c.log.Warnf("  DLQ Message Body (parsed): %+v", parsedBody)
 	} else {
@@ -197,7 +204,20 @@ func (c *DeadLetterConsumer) handleDLQMessage(msg amqp091.Delivery) {
 	}
 
 	// Alerting (TODO: Implement)
-	// Persistence (TODO: Implement)
+	if c.store != nil {
+		if err := c.store.StoreDeadLetter(ctx, newDeadLetter(msg)); err != nil {
+			c.log.Errorf("  Failed to store DLQ message %s, returning it to the queue: %v", msg.MessageId, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(storeRetryDelay):
+			}
+			if err := msg.Nack(false, true); err != nil {
+				c.log.Errorf("  Failed to NACK DLQ message %s: %v", msg.MessageId, err)
+			}
+			return
+		}
+	}
+
 	// ACK the message from DLQ
 	err = msg.Ack(false)
 	if err != nil {
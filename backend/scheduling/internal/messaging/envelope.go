@@ -0,0 +1,214 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// EnvelopeVersion is the version of events without a registered contract, see EventContract
+const EnvelopeVersion = 1
+
+const (
+	ActorUser   = "user"
+	ActorSystem = "system"
+)
+
+// Actor identifies who caused an event, a user for API calls and the service itself for jobs and consumers
+type Actor struct {
+	Type string `json:"type"`
+	Id   string `json:"id,omitempty"`
+}
+
+type envelopeKey string
+
+const (
+	correlationIdKey envelopeKey = "correlation_id"
+	causationIdKey   envelopeKey = "causation_id"
+)
+
+// WithCorrelation stores the correlation and causation ids that events published within ctx inherit
+func WithCorrelation(ctx context.Context, correlationId string, causationId string) context.Context {
+	if correlationId != "" {
+		ctx = context.WithValue(ctx, correlationIdKey, correlationId)
+	}
+	if causationId != "" {
+		ctx = context.WithValue(ctx, causationIdKey, causationId)
+	}
+	return ctx
+}
+
+// CorrelationIdFromContext returns the correlation id stored in ctx, empty when none
+func CorrelationIdFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIdKey).(string)
+	return id
+}
+
+func newBaseEvent(eventType string) BaseEvent {
+	now := time.Now().UTC().Format(time.RFC3339)
+	return BaseEvent{
+		EventId:    uuid.New().String(),
+		EventType:  eventType,
+		Version:    contractVersion(eventType),
+		OccurredAt: now,
+		Timestamp:  now,
+	}
+}
+
+// newCorrelatedBaseEvent creates an envelope whose correlation id groups a known set of events,
+// such as the batches of one operation, instead of the one taken from the context
+func newCorrelatedBaseEvent(eventType string, correlationId string) BaseEvent {
+	base := newBaseEvent(eventType)
+	base.CorrelationId = correlationId
+	return base
+}
+
+// stampEnvelope completes the envelope metadata from the publishing context
+func stampEnvelope(ctx context.Context, envelope *BaseEvent) {
+	if envelope.EventId == "" {
+		envelope.EventId = uuid.New().String()
+	}
+	if envelope.Version == 0 {
+		envelope.Version = contractVersion(envelope.EventType)
+	}
+	if envelope.SchemaId == "" {
+		if contract, ok := publishedContract(envelope.EventType); ok && contract.Version == envelope.Version {
+			envelope.SchemaId = contract.SchemaId()
+		}
+	}
+	if envelope.OccurredAt == "" {
+		envelope.OccurredAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	if envelope.Timestamp == "" {
+		envelope.Timestamp = envelope.OccurredAt
+	}
+
+	if envelope.CorrelationId == "" {
+		envelope.CorrelationId = CorrelationIdFromContext(ctx)
+	}
+	if envelope.CorrelationId == "" {
+		envelope.CorrelationId = uuid.New().String()
+	}
+	if envelope.CausationId == "" {
+		envelope.CausationId, _ = ctx.Value(causationIdKey).(string)
+	}
+
+	if envelope.Tenant == "" {
+		envelope.Tenant, _ = auth.GetTenantID(ctx)
+	}
+
+	if envelope.Actor == nil {
+		if actorId, err := auth.GetActorID(ctx); err == nil {
+			envelope.Actor = &Actor{Type: ActorUser, Id: actorId.String()}
+		} else {
+			envelope.Actor = &Actor{Type: ActorSystem}
+		}
+	}
+}
+
+const (
+	DirectionPublished = "published"
+	DirectionConsumed  = "consumed"
+)
+
+// EventRecord is the envelope of a published or consumed message as kept in the audit trail
+type EventRecord struct {
+	EventId       string
+	EventType     string
+	RoutingKey    string
+	Direction     string
+	CorrelationId string
+	CausationId   string
+	Actor         *Actor
+	OccurredAt    time.Time
+}
+
+// EventAuditor records message envelopes, so a whole flow can be traced by its correlation id
+type EventAuditor interface {
+	RecordEvent(ctx context.Context, record *EventRecord)
+}
+
+func newPublishedRecord(routingKey string, envelope *BaseEvent) *EventRecord {
+	occurredAt, err := time.Parse(time.RFC3339, envelope.OccurredAt)
+	if err != nil {
+		occurredAt = time.Now().UTC()
+	}
+	return &EventRecord{
+		EventId:       envelope.EventId,
+		EventType:     envelope.EventType,
+		RoutingKey:    routingKey,
+		Direction:     DirectionPublished,
+		CorrelationId: envelope.CorrelationId,
+		CausationId:   envelope.CausationId,
+		Actor:         envelope.Actor,
+		OccurredAt:    occurredAt,
+	}
+}
+
+func newConsumedRecord(msg amqp.Delivery) *EventRecord {
+	eventType, _ := msg.Headers["__TypeId__"].(string)
+	causationId, _ := msg.Headers["causationId"].(string)
+
+	occurredAt := msg.Timestamp
+	if occurredAt.IsZero() {
+		occurredAt = time.Now().UTC()
+	}
+	return &EventRecord{
+		EventId:       msg.MessageId,
+		EventType:     eventType,
+		RoutingKey:    msg.RoutingKey,
+		Direction:     DirectionConsumed,
+		CorrelationId: deliveryCorrelationId(msg),
+		CausationId:   causationId,
+		OccurredAt:    occurredAt.UTC(),
+	}
+}
+
+// requestIdHeader forwards the id of the HTTP request that published an event, see logger.RequestIdHeader
+const requestIdHeader = "request_id"
+
+// withDeliveryLogger continues the request id of a delivery and logs its handling with the ids that tie
+// it to the flow it belongs to
+func withDeliveryLogger(ctx context.Context, log logger.Logger, msg amqp.Delivery) context.Context {
+	fields := []logger.Field{
+		{Key: "message_id", Value: msg.MessageId},
+		{Key: "correlation_id", Value: deliveryCorrelationId(msg)},
+	}
+	if requestId, _ := msg.Headers[requestIdHeader].(string); requestId != "" {
+		ctx = logger.WithRequestId(ctx, requestId)
+		fields = append(fields, logger.Field{Key: "request_id", Value: requestId})
+	}
+	return logger.WithLogger(ctx, log.With(fields...))
+}
+
+// tenantHeader names the tenant an event was published for, in multi-tenant deployments
+const tenantHeader = "tenant_id"
+
+// withDeliveryTenant handles a delivery for the tenant it was published for, so its writes stay within it
+// and the events it causes are published for it too
+func withDeliveryTenant(ctx context.Context, msg amqp.Delivery) context.Context {
+	if tenantId, _ := msg.Headers[tenantHeader].(string); tenantId != "" {
+		return auth.WithTenant(ctx, tenantId)
+	}
+	return ctx
+}
+
+// tenantRoutingKey suffixes a routing key with the tenant, bindings ending in '#' match it as the plain key
+func tenantRoutingKey(routingKey string, tenantId string) string {
+	return routingKey + "." + tenantId
+}
+
+// deliveryCorrelationId reads the correlation id of a delivery from its properties, falling back to the
+// header used by services that do not set the AMQP property
+func deliveryCorrelationId(msg amqp.Delivery) string {
+	if msg.CorrelationId != "" {
+		return msg.CorrelationId
+	}
+	id, _ := msg.Headers["correlation_id"].(string)
+	return id
+}
@@ -1,22 +1,25 @@
 package messaging
 
-import (
-	"time"
-
-	"github.com/google/uuid"
-)
-
 type EventBase interface {
 	GetEventId() string
 	GetEventType() string
 	GetCorrelationId() string
 	GetTimestamp() string
+	Envelope() *BaseEvent
 }
 
+// BaseEvent is the envelope shared by every published message. Fields stay at the top level of the
+// payload next to the event data, so consumers reading the original fields keep working.
 type BaseEvent struct {
 	EventId       string `json:"eventId"`
 	EventType     string `json:"eventType"`
+	Version       int    `json:"version"`
+	SchemaId      string `json:"schemaId,omitempty"`
 	CorrelationId string `json:"correlationId"`
+	CausationId   string `json:"causationId,omitempty"`
+	Actor         *Actor `json:"actor,omitempty"`
+	Tenant        string `json:"tenant,omitempty"`
+	OccurredAt    string `json:"occurredAt"`
 	Timestamp     string `json:"timestamp"`
 }
 
@@ -24,6 +27,7 @@ func (b BaseEvent) GetEventId() string       { return b.EventId }
 func (b BaseEvent) GetEventType() string     { return b.EventType }
 func (b BaseEvent) GetCorrelationId() string { return b.CorrelationId }
 func (b BaseEvent) GetTimestamp() string     { return b.Timestamp }
+func (b *BaseEvent) Envelope() *BaseEvent    { return b }
 
 type EventScheduledEvent struct {
 	BaseEvent
@@ -38,12 +42,7 @@ func NewEventScheduledEvent(
 	endTime string,
 ) *EventScheduledEvent {
 	return &EventScheduledEvent{
-		BaseEvent: BaseEvent{
-			EventId:       uuid.New().String(),
-			EventType:     EventScheduled,
-			CorrelationId: uuid.New().String(),
-			Timestamp:     time.Now().UTC().Format(time.RFC3339),
-		},
+		BaseEvent: newBaseEvent(EventScheduled),
 		ProductId: productId,
 		StartTime: startTime,
 		EndTime:   endTime,
@@ -58,12 +57,7 @@ type BookingCompletedEvent struct {
 
 func NewBookingCompletedEvent(userId string, enrollmentId int64) *BookingCompletedEvent {
 	return &BookingCompletedEvent{
-		BaseEvent: BaseEvent{
-			EventId:       uuid.New().String(),
-			EventType:     BookingCompleted,
-			CorrelationId: uuid.New().String(),
-			Timestamp:     time.Now().UTC().Format(time.RFC3339),
-		},
+		BaseEvent:    newBaseEvent(BookingCompleted),
 		UserId:       userId,
 		EnrollmentId: enrollmentId,
 	}
@@ -78,14 +72,670 @@ type BookingCreationRequestedEvent struct {
 
 func NewBookingCreationRequestedEvent(userId, educatorId string, scheduledEventId *int64, lessonIds []int64) *BookingCreationRequestedEvent {
 	return &BookingCreationRequestedEvent{
-		BaseEvent: BaseEvent{
-			EventId:       uuid.New().String(),
-			EventType:     BookingCreationRequested,
-			CorrelationId: uuid.New().String(),
-			Timestamp:     time.Now().UTC().Format(time.RFC3339),
-		},
+		BaseEvent:        newBaseEvent(BookingCreationRequested),
 		UserId:           userId,
 		ScheduledEventId: scheduledEventId,
 		LessonIds:        lessonIds,
 	}
 }
+
+type PayoutStatementGeneratedEvent struct {
+	BaseEvent
+	StatementId  int64   `json:"statementId"`
+	EducatorId   string  `json:"educatorId"`
+	PeriodStart  string  `json:"periodStart"`
+	PeriodEnd    string  `json:"periodEnd"`
+	SessionCount int     `json:"sessionCount"`
+	GrossAmount  float64 `json:"grossAmount"`
+	FeeAmount    float64 `json:"feeAmount"`
+	NetAmount    float64 `json:"netAmount"`
+	Currency     string  `json:"currency"`
+}
+
+func NewPayoutStatementGeneratedEvent(
+	statementId int64,
+	educatorId string,
+	periodStart string,
+	periodEnd string,
+	sessionCount int,
+	grossAmount float64,
+	feeAmount float64,
+	netAmount float64,
+	currency string,
+) *PayoutStatementGeneratedEvent {
+	return &PayoutStatementGeneratedEvent{
+		BaseEvent:    newBaseEvent(PayoutStatementGenerated),
+		StatementId:  statementId,
+		EducatorId:   educatorId,
+		PeriodStart:  periodStart,
+		PeriodEnd:    periodEnd,
+		SessionCount: sessionCount,
+		GrossAmount:  grossAmount,
+		FeeAmount:    feeAmount,
+		NetAmount:    netAmount,
+		Currency:     currency,
+	}
+}
+
+type InvoiceLineItem struct {
+	Description    string  `json:"description"`
+	Quantity       int     `json:"quantity"`
+	UnitPrice      float64 `json:"unitPrice"`
+	DiscountAmount float64 `json:"discountAmount"`
+	TaxAmount      float64 `json:"taxAmount"`
+	LineTotal      float64 `json:"lineTotal"`
+}
+
+type InvoiceTaxBreakdown struct {
+	CountryCode   *string `json:"countryCode"`
+	TaxName       *string `json:"taxName"`
+	RatePercent   float64 `json:"ratePercent"`
+	ReverseCharge bool    `json:"reverseCharge"`
+}
+
+type InvoiceGeneratedEvent struct {
+	BaseEvent
+	InvoiceId      int64               `json:"invoiceId"`
+	InvoiceNumber  string              `json:"invoiceNumber"`
+	BookingId      int64               `json:"bookingId"`
+	EducatorId     string              `json:"educatorId"`
+	StudentId      string              `json:"studentId"`
+	Currency       string              `json:"currency"`
+	Subtotal       float64             `json:"subtotal"`
+	DiscountAmount float64             `json:"discountAmount"`
+	TaxAmount      float64             `json:"taxAmount"`
+	TotalAmount    float64             `json:"totalAmount"`
+	IssuedAt       string              `json:"issuedAt"`
+	Tax            InvoiceTaxBreakdown `json:"tax"`
+	Lines          []InvoiceLineItem   `json:"lines"`
+}
+
+func NewInvoiceGeneratedEvent(
+	invoiceId int64,
+	invoiceNumber string,
+	bookingId int64,
+	educatorId string,
+	studentId string,
+	currency string,
+	subtotal float64,
+	discountAmount float64,
+	taxAmount float64,
+	totalAmount float64,
+	issuedAt string,
+	tax InvoiceTaxBreakdown,
+	lines []InvoiceLineItem,
+) *InvoiceGeneratedEvent {
+	return &InvoiceGeneratedEvent{
+		BaseEvent:      newBaseEvent(InvoiceGenerated),
+		InvoiceId:      invoiceId,
+		InvoiceNumber:  invoiceNumber,
+		BookingId:      bookingId,
+		EducatorId:     educatorId,
+		StudentId:      studentId,
+		Currency:       currency,
+		Subtotal:       subtotal,
+		DiscountAmount: discountAmount,
+		TaxAmount:      taxAmount,
+		TotalAmount:    totalAmount,
+		IssuedAt:       issuedAt,
+		Tax:            tax,
+		Lines:          lines,
+	}
+}
+
+type NotificationRequestedEvent struct {
+	BaseEvent
+	UserId           string            `json:"userId"`
+	NotificationType string            `json:"notificationType"`
+	Channels         []string          `json:"channels"`
+	Language         string            `json:"language"`
+	DeliverAt        string            `json:"deliverAt"`
+	Data             map[string]string `json:"data"`
+}
+
+func NewNotificationRequestedEvent(
+	userId string,
+	notificationType string,
+	channels []string,
+	language string,
+	deliverAt string,
+	data map[string]string,
+) *NotificationRequestedEvent {
+	return &NotificationRequestedEvent{
+		BaseEvent:        newBaseEvent(NotificationRequested),
+		UserId:           userId,
+		NotificationType: notificationType,
+		Channels:         channels,
+		Language:         language,
+		DeliverAt:        deliverAt,
+		Data:             data,
+	}
+}
+
+type AttendanceRecordedEvent struct {
+	BaseEvent
+	BookingId    int64  `json:"bookingId"`
+	EducatorId   string `json:"educatorId"`
+	StudentId    string `json:"studentId"`
+	EnrollmentId *int64 `json:"enrollmentId"`
+	ProductId    int64  `json:"productId"`
+	Outcome      string `json:"outcome"`
+	StartTime    string `json:"startTime"`
+}
+
+func NewAttendanceRecordedEvent(
+	bookingId int64,
+	educatorId string,
+	studentId string,
+	enrollmentId *int64,
+	productId int64,
+	outcome string,
+	startTime string,
+) *AttendanceRecordedEvent {
+	return &AttendanceRecordedEvent{
+		BaseEvent:    newBaseEvent(AttendanceRecorded),
+		BookingId:    bookingId,
+		EducatorId:   educatorId,
+		StudentId:    studentId,
+		EnrollmentId: enrollmentId,
+		ProductId:    productId,
+		Outcome:      outcome,
+		StartTime:    startTime,
+	}
+}
+
+type SessionNoteUpdatedEvent struct {
+	BaseEvent
+	NoteId       int64  `json:"noteId"`
+	BookingId    int64  `json:"bookingId"`
+	EducatorId   string `json:"educatorId"`
+	StudentId    string `json:"studentId"`
+	EnrollmentId *int64 `json:"enrollmentId"`
+	ProductId    int64  `json:"productId"`
+	Content      string `json:"content"`
+	Version      int    `json:"version"`
+	SessionStart string `json:"sessionStart"`
+}
+
+func NewSessionNoteUpdatedEvent(
+	noteId int64,
+	bookingId int64,
+	educatorId string,
+	studentId string,
+	enrollmentId *int64,
+	productId int64,
+	content string,
+	version int,
+	sessionStart string,
+) *SessionNoteUpdatedEvent {
+	return &SessionNoteUpdatedEvent{
+		BaseEvent:    newBaseEvent(SessionNoteUpdated),
+		NoteId:       noteId,
+		BookingId:    bookingId,
+		EducatorId:   educatorId,
+		StudentId:    studentId,
+		EnrollmentId: enrollmentId,
+		ProductId:    productId,
+		Content:      content,
+		Version:      version,
+		SessionStart: sessionStart,
+	}
+}
+
+type UserDeletedEvent struct {
+	BaseEvent
+	UserId string `json:"userId"`
+}
+
+func NewUserDeletedEvent(userId string) *UserDeletedEvent {
+	return &UserDeletedEvent{
+		BaseEvent: newBaseEvent(UserDeleted),
+		UserId:    userId,
+	}
+}
+
+type CatalogLesson struct {
+	LessonId    int64 `json:"lessonId"`
+	DurationMin int   `json:"durationMin"`
+}
+
+type ProductCatalogUpdatedEvent struct {
+	BaseEvent
+	ProductId       int64           `json:"productId"`
+	EducatorId      string          `json:"educatorId"`
+	Type            int             `json:"type"`
+	Title           string          `json:"title"`
+	Price           float64         `json:"price"`
+	DurationMin     *int            `json:"durationMin"`
+	MaxParticipants int             `json:"maxParticipants"`
+	Lessons         []CatalogLesson `json:"lessons"`
+	Version         int64           `json:"version"`
+	Deleted         bool            `json:"deleted"`
+}
+
+type EnrollmentCreatedEvent struct {
+	BaseEvent
+	EnrollmentId     int64  `json:"enrollmentId"`
+	UserId           string `json:"userId"`
+	ProductId        int64  `json:"productId"`
+	ScheduledEventId *int64 `json:"scheduledEventId"`
+}
+
+// BookingHoldPaidEvent reports that the payment securing a booking hold has completed
+type BookingHoldPaidEvent struct {
+	BaseEvent
+	HoldId int64  `json:"holdId"`
+	UserId string `json:"userId"`
+}
+
+// SnapshotMarkerEvent opens (SNAPSHOT_STARTED) or closes (SNAPSHOT_COMPLETED) a snapshot stream. Consumers
+// rebuild from the items in between and then follow live events published after CapturedAt.
+type SnapshotMarkerEvent struct {
+	BaseEvent
+	SnapshotId string `json:"snapshotId"`
+	Sequence   int    `json:"sequence"`
+	TotalItems int    `json:"totalItems"`
+	CapturedAt string `json:"capturedAt"`
+}
+
+func NewSnapshotMarkerEvent(eventType string, snapshotId string, sequence int, totalItems int, capturedAt string) *SnapshotMarkerEvent {
+	return &SnapshotMarkerEvent{
+		BaseEvent:  newCorrelatedBaseEvent(eventType, snapshotId),
+		SnapshotId: snapshotId,
+		Sequence:   sequence,
+		TotalItems: totalItems,
+		CapturedAt: capturedAt,
+	}
+}
+
+type SnapshotWorkingPeriod struct {
+	Id        int64  `json:"id"`
+	UserId    string `json:"userId"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+}
+
+type SnapshotScheduledEvent struct {
+	Id              int64   `json:"id"`
+	UserId          string  `json:"userId"`
+	ProductId       int64   `json:"productId"`
+	LessonId        *int64  `json:"lessonId"`
+	SessionTypeId   *int64  `json:"sessionTypeId"`
+	LocationId      *int64  `json:"locationId"`
+	WorkingPeriodId int64   `json:"workingPeriodId"`
+	Title           string  `json:"title"`
+	StartTime       string  `json:"startTime"`
+	EndTime         string  `json:"endTime"`
+	MaxParticipants int     `json:"maxParticipants"`
+	Price           float64 `json:"price"`
+}
+
+type SnapshotBooking struct {
+	Id               int64   `json:"id"`
+	EducatorId       string  `json:"educatorId"`
+	StudentId        string  `json:"studentId"`
+	ProductId        int64   `json:"productId"`
+	EnrollmentId     *int64  `json:"enrollmentId"`
+	ScheduledEventId *int64  `json:"scheduledEventId"`
+	SessionTypeId    *int64  `json:"sessionTypeId"`
+	WorkingPeriodId  int64   `json:"workingPeriodId"`
+	Title            string  `json:"title"`
+	StartTime        string  `json:"startTime"`
+	EndTime          string  `json:"endTime"`
+	Status           string  `json:"status"`
+	Price            float64 `json:"price"`
+}
+
+// SnapshotItemEvent carries exactly one of the snapshot entities, identified by EntityType
+type SnapshotItemEvent struct {
+	BaseEvent
+	SnapshotId     string                  `json:"snapshotId"`
+	Sequence       int                     `json:"sequence"`
+	EntityType     string                  `json:"entityType"`
+	WorkingPeriod  *SnapshotWorkingPeriod  `json:"workingPeriod,omitempty"`
+	ScheduledEvent *SnapshotScheduledEvent `json:"scheduledEvent,omitempty"`
+	Booking        *SnapshotBooking        `json:"booking,omitempty"`
+}
+
+func NewSnapshotItemEvent(snapshotId string, sequence int, entityType string) *SnapshotItemEvent {
+	return &SnapshotItemEvent{
+		BaseEvent:  newCorrelatedBaseEvent(SnapshotItem, snapshotId),
+		SnapshotId: snapshotId,
+		Sequence:   sequence,
+		EntityType: entityType,
+	}
+}
+
+type CancelledBooking struct {
+	BookingId    int64   `json:"bookingId"`
+	StudentId    string  `json:"studentId"`
+	ProductId    int64   `json:"productId"`
+	EnrollmentId *int64  `json:"enrollmentId"`
+	StartTime    string  `json:"startTime"`
+	Price        float64 `json:"price"`
+	Refundable   bool    `json:"refundable"`
+}
+
+// BookingsCancelledEvent carries one batch of bookings cancelled by a bulk cancellation. Batches of the
+// same operation share the correlation id.
+type BookingsCancelledEvent struct {
+	BaseEvent
+	EducatorId   string              `json:"educatorId"`
+	Reason       *string             `json:"reason"`
+	BatchNumber  int                 `json:"batchNumber"`
+	TotalBatches int                 `json:"totalBatches"`
+	Bookings     []*CancelledBooking `json:"bookings"`
+}
+
+func NewBookingsCancelledEvent(
+	operationId string,
+	educatorId string,
+	reason *string,
+	batchNumber int,
+	totalBatches int,
+	bookings []*CancelledBooking,
+) *BookingsCancelledEvent {
+	return &BookingsCancelledEvent{
+		BaseEvent:    newCorrelatedBaseEvent(BookingsCancelled, operationId),
+		EducatorId:   educatorId,
+		Reason:       reason,
+		BatchNumber:  batchNumber,
+		TotalBatches: totalBatches,
+		Bookings:     bookings,
+	}
+}
+
+// BookingPriceAdjustedEvent asks the payment service to charge the difference of a booking whose price changed
+type BookingPriceAdjustedEvent struct {
+	BaseEvent
+	BookingId   int64   `json:"bookingId"`
+	StudentId   string  `json:"studentId"`
+	EducatorId  string  `json:"educatorId"`
+	ProductId   int64   `json:"productId"`
+	ExtensionId int64   `json:"extensionId"`
+	Amount      float64 `json:"amount"`
+	NewPrice    float64 `json:"newPrice"`
+}
+
+func NewBookingPriceAdjustedEvent(
+	bookingId int64,
+	studentId string,
+	educatorId string,
+	productId int64,
+	extensionId int64,
+	amount float64,
+	newPrice float64,
+) *BookingPriceAdjustedEvent {
+	return &BookingPriceAdjustedEvent{
+		BaseEvent:   newBaseEvent(BookingPriceAdjusted),
+		BookingId:   bookingId,
+		StudentId:   studentId,
+		EducatorId:  educatorId,
+		ProductId:   productId,
+		ExtensionId: extensionId,
+		Amount:      amount,
+		NewPrice:    newPrice,
+	}
+}
+
+// BookingUpdatedEvent carries the current time of a booking for calendar sync after it changed
+type BookingUpdatedEvent struct {
+	BaseEvent
+	BookingId  int64  `json:"bookingId"`
+	StudentId  string `json:"studentId"`
+	EducatorId string `json:"educatorId"`
+	Title      string `json:"title"`
+	StartTime  string `json:"startTime"`
+	EndTime    string `json:"endTime"`
+}
+
+func NewBookingUpdatedEvent(bookingId int64, studentId, educatorId, title, startTime, endTime string) *BookingUpdatedEvent {
+	return &BookingUpdatedEvent{
+		BaseEvent:  newBaseEvent(BookingUpdated),
+		BookingId:  bookingId,
+		StudentId:  studentId,
+		EducatorId: educatorId,
+		Title:      title,
+		StartTime:  startTime,
+		EndTime:    endTime,
+	}
+}
+
+// EducatorOffboardingEvent reports the progress of a teacher offboarding. Started events carry the wind-down
+// mode, archived events the final counts.
+type EducatorOffboardingEvent struct {
+	BaseEvent
+	EducatorId         string  `json:"educatorId"`
+	Mode               string  `json:"mode"`
+	ReassignTo         *string `json:"reassignTo"`
+	BookingsReassigned int     `json:"bookingsReassigned"`
+	BookingsKept       int     `json:"bookingsKept"`
+}
+
+func NewEducatorOffboardingEvent(
+	eventType string,
+	educatorId string,
+	mode string,
+	reassignTo *string,
+	bookingsReassigned int,
+	bookingsKept int,
+) *EducatorOffboardingEvent {
+	return &EducatorOffboardingEvent{
+		BaseEvent:          newCorrelatedBaseEvent(eventType, educatorId),
+		EducatorId:         educatorId,
+		Mode:               mode,
+		ReassignTo:         reassignTo,
+		BookingsReassigned: bookingsReassigned,
+		BookingsKept:       bookingsKept,
+	}
+}
+
+// BookingReassignedEvent reports a booking moved to another educator during an offboarding
+type BookingReassignedEvent struct {
+	BaseEvent
+	BookingId      int64  `json:"bookingId"`
+	StudentId      string `json:"studentId"`
+	FromEducatorId string `json:"fromEducatorId"`
+	ToEducatorId   string `json:"toEducatorId"`
+	ProductId      int64  `json:"productId"`
+	EnrollmentId   *int64 `json:"enrollmentId"`
+	StartTime      string `json:"startTime"`
+	EndTime        string `json:"endTime"`
+}
+
+func NewBookingReassignedEvent(
+	bookingId int64,
+	studentId string,
+	fromEducatorId string,
+	toEducatorId string,
+	productId int64,
+	enrollmentId *int64,
+	startTime string,
+	endTime string,
+) *BookingReassignedEvent {
+	return &BookingReassignedEvent{
+		BaseEvent:      newCorrelatedBaseEvent(BookingReassigned, fromEducatorId),
+		BookingId:      bookingId,
+		StudentId:      studentId,
+		FromEducatorId: fromEducatorId,
+		ToEducatorId:   toEducatorId,
+		ProductId:      productId,
+		EnrollmentId:   enrollmentId,
+		StartTime:      startTime,
+		EndTime:        endTime,
+	}
+}
+
+// WaitlistEntryEvent reports a freed place of a scheduled event offered to or booked for the next waitlisted
+// student. BookingId is set once the student is promoted, OfferExpiresAt while the offer can be claimed.
+type WaitlistEntryEvent struct {
+	BaseEvent
+	EntryId          int64   `json:"entryId"`
+	ScheduledEventId int64   `json:"scheduledEventId"`
+	StudentId        string  `json:"studentId"`
+	BookingId        *int64  `json:"bookingId"`
+	OfferExpiresAt   *string `json:"offerExpiresAt"`
+}
+
+func NewWaitlistSpotOfferedEvent(entryId int64, scheduledEventId int64, studentId string, offerExpiresAt string) *WaitlistEntryEvent {
+	return &WaitlistEntryEvent{
+		BaseEvent:        newBaseEvent(WaitlistSpotOffered),
+		EntryId:          entryId,
+		ScheduledEventId: scheduledEventId,
+		StudentId:        studentId,
+		OfferExpiresAt:   &offerExpiresAt,
+	}
+}
+
+func NewWaitlistPromotedEvent(entryId int64, scheduledEventId int64, studentId string, bookingId int64) *WaitlistEntryEvent {
+	return &WaitlistEntryEvent{
+		BaseEvent:        newBaseEvent(WaitlistPromoted),
+		EntryId:          entryId,
+		ScheduledEventId: scheduledEventId,
+		StudentId:        studentId,
+		BookingId:        &bookingId,
+	}
+}
+
+// CancellationSettledEvent tells the payment service how much of a cancelled booking's price is refunded
+// and how much is kept as a late cancellation penalty. RuleId is the cancellation rule that applied, if any.
+type CancellationSettledEvent struct {
+	BaseEvent
+	BookingId     int64   `json:"bookingId"`
+	StudentId     string  `json:"studentId"`
+	EducatorId    string  `json:"educatorId"`
+	ProductId     int64   `json:"productId"`
+	CancelledBy   string  `json:"cancelledBy"`
+	RuleId        *int64  `json:"ruleId"`
+	Price         float64 `json:"price"`
+	RefundAmount  float64 `json:"refundAmount"`
+	PenaltyAmount float64 `json:"penaltyAmount"`
+}
+
+func NewCancellationSettledEvent(
+	bookingId int64,
+	studentId string,
+	educatorId string,
+	productId int64,
+	cancelledBy string,
+	ruleId *int64,
+	price float64,
+	refundAmount float64,
+	penaltyAmount float64,
+) *CancellationSettledEvent {
+	return &CancellationSettledEvent{
+		BaseEvent:     newBaseEvent(CancellationSettled),
+		BookingId:     bookingId,
+		StudentId:     studentId,
+		EducatorId:    educatorId,
+		ProductId:     productId,
+		CancelledBy:   cancelledBy,
+		RuleId:        ruleId,
+		Price:         price,
+		RefundAmount:  refundAmount,
+		PenaltyAmount: penaltyAmount,
+	}
+}
+
+// SessionReminderEvent asks the notification service to remind a participant of an upcoming session, on
+// the channels and in the language of the participant's preferences
+type SessionReminderEvent struct {
+	BaseEvent
+	UserId        string   `json:"userId"`
+	Role          string   `json:"role"`
+	SessionKind   string   `json:"sessionKind"`
+	SessionId     int64    `json:"sessionId"`
+	Title         string   `json:"title"`
+	StartTime     string   `json:"startTime"`
+	EndTime       string   `json:"endTime"`
+	OffsetMinutes int      `json:"offsetMinutes"`
+	Channels      []string `json:"channels"`
+	Language      string   `json:"language"`
+}
+
+func NewSessionReminderEvent(
+	userId string,
+	role string,
+	sessionKind string,
+	sessionId int64,
+	title string,
+	startTime string,
+	endTime string,
+	offsetMinutes int,
+	channels []string,
+	language string,
+) *SessionReminderEvent {
+	return &SessionReminderEvent{
+		BaseEvent:     newBaseEvent(SessionReminderDue),
+		UserId:        userId,
+		Role:          role,
+		SessionKind:   sessionKind,
+		SessionId:     sessionId,
+		Title:         title,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		OffsetMinutes: offsetMinutes,
+		Channels:      channels,
+		Language:      language,
+	}
+}
+
+// BookingSLABreachedEvent alerts that a booking has been pending longer than its SLA allows. EntityId is
+// the booking awaiting approval, or the hold awaiting payment.
+type BookingSLABreachedEvent struct {
+	BaseEvent
+	State          string `json:"state"`
+	EntityId       int64  `json:"entityId"`
+	EducatorId     string `json:"educatorId"`
+	StudentId      string `json:"studentId"`
+	StartTime      string `json:"startTime"`
+	PendingSince   string `json:"pendingSince"`
+	SLAMinutes     int    `json:"slaMinutes"`
+	ElapsedMinutes int    `json:"elapsedMinutes"`
+}
+
+func NewBookingSLABreachedEvent(
+	state string,
+	entityId int64,
+	educatorId string,
+	studentId string,
+	startTime string,
+	pendingSince string,
+	slaMinutes int,
+	elapsedMinutes int,
+) *BookingSLABreachedEvent {
+	return &BookingSLABreachedEvent{
+		BaseEvent:      newBaseEvent(BookingSLABreached),
+		State:          state,
+		EntityId:       entityId,
+		EducatorId:     educatorId,
+		StudentId:      studentId,
+		StartTime:      startTime,
+		PendingSince:   pendingSince,
+		SLAMinutes:     slaMinutes,
+		ElapsedMinutes: elapsedMinutes,
+	}
+}
+
+// BookingStatusChangedEvent reports a transition of the booking state machine, one event per transition
+type BookingStatusChangedEvent struct {
+	BaseEvent
+	BookingId  int64  `json:"bookingId"`
+	EducatorId string `json:"educatorId"`
+	StudentId  string `json:"studentId"`
+	FromStatus string `json:"fromStatus"`
+	ToStatus   string `json:"toStatus"`
+	ChangedAt  string `json:"changedAt"`
+}
+
+func NewBookingStatusChangedEvent(bookingId int64, educatorId, studentId, fromStatus, toStatus, changedAt string) *BookingStatusChangedEvent {
+	return &BookingStatusChangedEvent{
+		BaseEvent:  newBaseEvent(BookingStatusChanged),
+		BookingId:  bookingId,
+		EducatorId: educatorId,
+		StudentId:  studentId,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		ChangedAt:  changedAt,
+	}
+}
@@ -2,25 +2,35 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"github.com/maksmelnyk/scheduling/internal/booking"
+	"github.com/maksmelnyk/scheduling/internal/catalog"
 	"github.com/maksmelnyk/scheduling/internal/logger"
 	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/userdeletion"
 )
 
 type MessageHandler struct {
-	log            *logger.AppLogger
-	bookingService *booking.BookingService
+	log                 *logger.AppLogger
+	bookingService      *booking.BookingService
+	userDeletionService *userdeletion.UserDeletionService
+	catalogService      *catalog.CatalogService
 }
 
-func NewMessageHandler(log *logger.AppLogger, bookingService *booking.BookingService) *MessageHandler {
+func NewMessageHandler(
+	log *logger.AppLogger,
+	bookingService *booking.BookingService,
+	userDeletionService *userdeletion.UserDeletionService,
+	catalogService *catalog.CatalogService,
+) *MessageHandler {
 	return &MessageHandler{
-		log:            log,
-		bookingService: bookingService,
+		log:                 log,
+		bookingService:      bookingService,
+		userDeletionService: userDeletionService,
+		catalogService:      catalogService,
 	}
 }
 
@@ -34,6 +44,14 @@ func (mp *MessageHandler) HandleIncomingMessage(ctx context.Context, msg amqp.De
 	switch eventType {
 	case messaging.BookingCreationRequested:
 		return handleBookingCreationRequestedEvent(ctx, msg, mp, eventType)
+	case messaging.UserDeleted:
+		return handleUserDeletedEvent(ctx, msg, mp, eventType)
+	case messaging.ProductCatalogUpdated:
+		return handleProductCatalogUpdatedEvent(ctx, msg, mp, eventType)
+	case messaging.EnrollmentCreated:
+		return handleEnrollmentCreatedEvent(ctx, msg, mp, eventType)
+	case messaging.BookingHoldPaid:
+		return handleBookingHoldPaidEvent(ctx, msg, mp, eventType)
 	default:
 		mp.log.Warnf("Received unknown message type: '%s' for message %s", eventType, msg.MessageId)
 		return fmt.Errorf("unknown message type: %s", eventType)
@@ -42,7 +60,7 @@ func (mp *MessageHandler) HandleIncomingMessage(ctx context.Context, msg amqp.De
 
 func handleBookingCreationRequestedEvent(ctx context.Context, msg amqp.Delivery, mp *MessageHandler, eventType string) error {
 	var event messaging.BookingCreationRequestedEvent
-	if err := json.Unmarshal(msg.Body, &event); err != nil {
+	if err := messaging.Unmarshal(msg, &event); err != nil {
 		mp.log.Errorf("Failed to unmarshal %s message %s: %v", eventType, msg.MessageId, err)
 		return fmt.Errorf("failed to unmarshal %s message: %w", eventType, err)
 	}
@@ -56,3 +74,71 @@ func handleBookingCreationRequestedEvent(ctx context.Context, msg amqp.Delivery,
 	mp.log.Infof("Successfully processed %s message %s (EventID: %s)", eventType, msg.MessageId, event.EventId)
 	return nil
 }
+
+func handleUserDeletedEvent(ctx context.Context, msg amqp.Delivery, mp *MessageHandler, eventType string) error {
+	var event messaging.UserDeletedEvent
+	if err := messaging.Unmarshal(msg, &event); err != nil {
+		mp.log.Errorf("Failed to unmarshal %s message %s: %v", eventType, msg.MessageId, err)
+		return fmt.Errorf("failed to unmarshal %s message: %w", eventType, err)
+	}
+
+	err := mp.userDeletionService.HandleUserDeleted(ctx, &event)
+	if err != nil {
+		mp.log.Errorf("Failed to clean up deleted user for message %s (EventID: %s): %v", msg.MessageId, event.EventId, err)
+		return fmt.Errorf("failed to process user deletion for event %s: %w", event.EventId, err)
+	}
+
+	mp.log.Infof("Successfully processed %s message %s (EventID: %s)", eventType, msg.MessageId, event.EventId)
+	return nil
+}
+
+func handleProductCatalogUpdatedEvent(ctx context.Context, msg amqp.Delivery, mp *MessageHandler, eventType string) error {
+	var event messaging.ProductCatalogUpdatedEvent
+	if err := messaging.Unmarshal(msg, &event); err != nil {
+		mp.log.Errorf("Failed to unmarshal %s message %s: %v", eventType, msg.MessageId, err)
+		return fmt.Errorf("failed to unmarshal %s message: %w", eventType, err)
+	}
+
+	err := mp.catalogService.ApplyProductUpdated(ctx, &event)
+	if err != nil {
+		mp.log.Errorf("Failed to update catalog for message %s (EventID: %s): %v", msg.MessageId, event.EventId, err)
+		return fmt.Errorf("failed to process catalog update for event %s: %w", event.EventId, err)
+	}
+
+	mp.log.Infof("Successfully processed %s message %s (EventID: %s)", eventType, msg.MessageId, event.EventId)
+	return nil
+}
+
+func handleEnrollmentCreatedEvent(ctx context.Context, msg amqp.Delivery, mp *MessageHandler, eventType string) error {
+	var event messaging.EnrollmentCreatedEvent
+	if err := messaging.Unmarshal(msg, &event); err != nil {
+		mp.log.Errorf("Failed to unmarshal %s message %s: %v", eventType, msg.MessageId, err)
+		return fmt.Errorf("failed to unmarshal %s message: %w", eventType, err)
+	}
+
+	err := mp.catalogService.ApplyEnrollmentCreated(ctx, &event)
+	if err != nil {
+		mp.log.Errorf("Failed to store enrollment for message %s (EventID: %s): %v", msg.MessageId, event.EventId, err)
+		return fmt.Errorf("failed to process enrollment for event %s: %w", event.EventId, err)
+	}
+
+	mp.log.Infof("Successfully processed %s message %s (EventID: %s)", eventType, msg.MessageId, event.EventId)
+	return nil
+}
+
+func handleBookingHoldPaidEvent(ctx context.Context, msg amqp.Delivery, mp *MessageHandler, eventType string) error {
+	var event messaging.BookingHoldPaidEvent
+	if err := messaging.Unmarshal(msg, &event); err != nil {
+		mp.log.Errorf("Failed to unmarshal %s message %s: %v", eventType, msg.MessageId, err)
+		return fmt.Errorf("failed to unmarshal %s message: %w", eventType, err)
+	}
+
+	err := mp.bookingService.ConfirmPaidBookingHold(ctx, &event)
+	if err != nil {
+		mp.log.Errorf("Failed to confirm booking hold for message %s (EventID: %s): %v", msg.MessageId, event.EventId, err)
+		return fmt.Errorf("failed to process booking hold payment for event %s: %w", event.EventId, err)
+	}
+
+	mp.log.Infof("Successfully processed %s message %s (EventID: %s)", eventType, msg.MessageId, event.EventId)
+	return nil
+}
@@ -0,0 +1,61 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+)
+
+// Metrics records broker round trips of the publisher and handler durations of the consumer
+type Metrics struct {
+	publishDuration metric.Float64Histogram
+	consumeDuration metric.Float64Histogram
+}
+
+func NewMetrics(meter metric.Meter) (*Metrics, error) {
+	publishDuration, err := meter.Float64Histogram("scheduling.messaging.publish.duration",
+		metric.WithDescription("Time from publishing a message to its broker confirmation, by routing key and outcome"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	consumeDuration, err := meter.Float64Histogram("scheduling.messaging.consume.duration",
+		metric.WithDescription("Time spent handling a consumed message per attempt, by routing key and outcome"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metrics{publishDuration: publishDuration, consumeDuration: consumeDuration}, nil
+}
+
+func (m *Metrics) recordPublish(ctx context.Context, routingKey string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.publishDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("routing_key", routingKey), attribute.String("outcome", outcomeOf(err))))
+}
+
+func (m *Metrics) recordConsume(ctx context.Context, routingKey string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.consumeDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("routing_key", routingKey), attribute.String("outcome", outcomeOf(err))))
+}
+
+func outcomeOf(err error) string {
+	if err != nil {
+		return outcomeFailure
+	}
+	return outcomeSuccess
+}
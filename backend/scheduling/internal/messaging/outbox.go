@@ -0,0 +1,38 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OutboxMessage is a fully stamped event that could not be published and waits in the outbox
+type OutboxMessage struct {
+	EventId    string
+	EventType  string
+	RoutingKey string
+	Body       []byte
+	// NotBefore delays the first relay attempt, it is attempted right away when zero
+	NotBefore time.Time
+}
+
+// Outbox stores events the broker did not accept, so they are published later instead of being lost
+type Outbox interface {
+	Enqueue(ctx context.Context, message *OutboxMessage) error
+}
+
+// Republish publishes an outbox message with the envelope it was stamped with on the first attempt
+func (p *Publisher) Republish(ctx context.Context, message *OutboxMessage) error {
+	var envelope BaseEvent
+	if err := json.Unmarshal(message.Body, &envelope); err != nil {
+		return fmt.Errorf("failed to read envelope of outbox message %s: %w", message.EventId, err)
+	}
+	// The version field of the payload can hold event data, the schema id names the version published
+	for _, contract := range publishedContracts {
+		if contract.SchemaId() == envelope.SchemaId {
+			envelope.Version = contract.Version
+		}
+	}
+	return p.publishTimed(ctx, message.RoutingKey, &envelope, message.Body)
+}
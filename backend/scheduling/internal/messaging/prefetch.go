@@ -0,0 +1,127 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// errorRateThreshold is the share of failed handler calls in a window above which the prefetch is cut
+const errorRateThreshold = 0.1
+
+// prefetchTuner adjusts the consumer prefetch from observed handler latency and errors. It grows the
+// prefetch by one while handlers are fast and the window was saturated, and halves it as soon as
+// handlers slow down past the target or start failing, so a degraded database is not flooded.
+type prefetchTuner struct {
+	log      *logger.AppLogger
+	min      int
+	max      int
+	target   time.Duration
+	interval time.Duration
+	apply    func(prefetch int) error
+
+	mu       sync.Mutex
+	current  int
+	calls    int
+	failures int
+	latency  time.Duration
+}
+
+func newPrefetchTuner(cfg *config.RabbitMqConfig, log *logger.AppLogger, apply func(prefetch int) error) *prefetchTuner {
+	initial := min(max(cfg.PrefetchCount, cfg.PrefetchMin), cfg.PrefetchMax)
+	return &prefetchTuner{
+		log:      log,
+		min:      cfg.PrefetchMin,
+		max:      cfg.PrefetchMax,
+		target:   time.Duration(cfg.PrefetchTargetLatencyMs) * time.Millisecond,
+		interval: time.Duration(cfg.PrefetchTuneSeconds) * time.Second,
+		apply:    apply,
+		current:  initial,
+	}
+}
+
+// Current returns the prefetch to apply to a newly opened channel
+func (t *prefetchTuner) Current() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.current
+}
+
+// Observe records the outcome of a single handler call
+func (t *prefetchTuner) Observe(duration time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.calls++
+	t.latency += duration
+	if err != nil {
+		t.failures++
+	}
+}
+
+// Run re-evaluates the prefetch on every interval until the context is cancelled
+func (t *prefetchTuner) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.tune()
+		}
+	}
+}
+
+func (t *prefetchTuner) tune() {
+	t.mu.Lock()
+	calls, failures, latency, current := t.calls, t.failures, t.latency, t.current
+	t.calls, t.failures, t.latency = 0, 0, 0
+	t.mu.Unlock()
+
+	next := nextPrefetch(current, calls, failures, latency, t.target, t.min, t.max)
+	if next == current {
+		return
+	}
+
+	if err := t.apply(next); err != nil {
+		t.log.Warnf("Failed to change consumer prefetch from %d to %d: %v", current, next, err)
+		return
+	}
+
+	t.mu.Lock()
+	t.current = next
+	t.mu.Unlock()
+
+	t.log.Infof("Consumer prefetch changed from %d to %d (calls=%d, failures=%d, avg latency=%s)",
+		current, next, calls, failures, averageLatency(latency, calls))
+}
+
+// nextPrefetch applies additive increase and multiplicative decrease to the prefetch of the last window.
+// An idle window keeps the current value, since there is nothing to learn from it.
+func nextPrefetch(current, calls, failures int, latency, target time.Duration, lower, upper int) int {
+	if calls == 0 {
+		return current
+	}
+
+	avg := averageLatency(latency, calls)
+	if float64(failures)/float64(calls) > errorRateThreshold || avg > target {
+		return max(current/2, lower)
+	}
+
+	if avg < target/2 && calls >= current {
+		return min(current+1, upper)
+	}
+	return current
+}
+
+func averageLatency(total time.Duration, calls int) time.Duration {
+	if calls == 0 {
+		return 0
+	}
+	return total / time.Duration(calls)
+}
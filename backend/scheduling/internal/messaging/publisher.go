@@ -7,10 +7,10 @@ import (
 	"sync"
 	"time"
 
-	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/degraded"
 	"github.com/maksmelnyk/scheduling/internal/logger"
 )
 
@@ -20,18 +20,50 @@ type Publisher struct {
 	timeout  time.Duration
 	channel  *amqp.Channel
 	log      *logger.AppLogger
-	mu       sync.Mutex
+	auditor  EventAuditor
+	outbox   Outbox
+	metrics  *Metrics
+	throttle *Throttle
+	deferred Outbox
+	// contentTypes maps the event types published in another content type than JSON
+	contentTypes      map[string]string
+	tenantRoutingKeys bool
+	mu                sync.Mutex
 }
 
-func NewPublisher(provider *ConnectionProvider, config *config.RabbitMqConfig, log *logger.AppLogger) *Publisher {
+// NewPublisher creates a publisher to the configured exchange. Confirmed messages are recorded when an auditor is given,
+// and publish latencies when metrics are given. With an outbox, events the broker does not accept are queued to it
+// and the publish succeeds in degraded mode.
+func NewPublisher(
+	provider *ConnectionProvider,
+	config *config.RabbitMqConfig,
+	log *logger.AppLogger,
+	auditor EventAuditor,
+	outbox Outbox,
+	metrics *Metrics,
+) *Publisher {
+	// The content types are validated with the configuration on startup
+	contentTypes, _ := config.ParseEventContentTypes()
 	return &Publisher{
-		provider: provider,
-		exchange: config.Exchange,
-		timeout:  time.Duration(config.PublishConfirmTimeoutMs) * time.Millisecond,
-		log:      log,
+		provider:          provider,
+		exchange:          config.Exchange,
+		timeout:           time.Duration(config.PublishConfirmTimeoutMs) * time.Millisecond,
+		log:               log,
+		auditor:           auditor,
+		outbox:            outbox,
+		metrics:           metrics,
+		contentTypes:      contentTypes,
+		tenantRoutingKeys: config.TenantRoutingKeys,
 	}
 }
 
+// ThrottleWith caps the publish rate of event types, events over the cap are queued to the outbox for the
+// relay to publish once their type has room again. It must be set before the first publish.
+func (p *Publisher) ThrottleWith(throttle *Throttle, outbox Outbox) {
+	p.throttle = throttle
+	p.deferred = outbox
+}
+
 func (p *Publisher) Initialize(ctx context.Context) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -86,29 +118,158 @@ func (p *Publisher) GetChannel(ctx context.Context) (*amqp.Channel, error) {
 }
 
 func (p *Publisher) Publish(ctx context.Context, routingKey string, event EventBase) error {
-	channel, err := p.GetChannel(ctx)
+	envelope := event.Envelope()
+	stampEnvelope(ctx, envelope)
+
+	body, err := json.Marshal(event)
 	if err != nil {
-		return fmt.Errorf("failed to get publisher channel: %w", err)
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	// An incompatible event is a bug of this service, it is neither published nor queued to the outbox
+	if err := checkCompatibility(envelope, body); err != nil {
+		return err
+	}
+
+	if allowed, wait := p.throttle.Allow(ctx, envelope.EventType); !allowed && p.deferThrottled(ctx, routingKey, envelope, body, wait) {
+		return nil
+	}
+
+	err = p.publishTimed(ctx, routingKey, envelope, body)
+	if err == nil || p.outbox == nil {
+		return err
+	}
+
+	message := &OutboxMessage{EventId: envelope.EventId, EventType: envelope.EventType, RoutingKey: routingKey, Body: body}
+	if outboxErr := p.outbox.Enqueue(ctx, message); outboxErr != nil {
+		p.log.Errorf("Failed to queue event %s to the outbox: %v", envelope.EventId, outboxErr)
+		return err
+	}
+
+	p.log.Warnf("Event %s queued to the outbox, publish failed: %v", envelope.EventId, err)
+	degraded.Mark(ctx, degraded.Broker)
+	return nil
+}
+
+// deferThrottled queues an event over the cap of its type to the outbox, to be relayed once the wait is
+// over. False is returned when it could not be queued, the event is then published right away.
+func (p *Publisher) deferThrottled(ctx context.Context, routingKey string, envelope *BaseEvent, body []byte, wait time.Duration) bool {
+	if p.deferred == nil {
+		return false
+	}
+
+	message := &OutboxMessage{
+		EventId:    envelope.EventId,
+		EventType:  envelope.EventType,
+		RoutingKey: routingKey,
+		Body:       body,
+		NotBefore:  time.Now().UTC().Add(wait),
+	}
+	if err := p.deferred.Enqueue(ctx, message); err != nil {
+		p.log.Errorf("Failed to defer throttled event %s to the outbox, publishing it now: %v", envelope.EventId, err)
+		return false
+	}
+
+	p.log.Debugf("Event %s of throttled type %s deferred to the outbox", envelope.EventId, envelope.EventType)
+	return true
+}
+
+func (p *Publisher) publishTimed(ctx context.Context, routingKey string, envelope *BaseEvent, body []byte) error {
+	started := time.Now()
+	err := p.publish(ctx, routingKey, envelope, body)
+	p.metrics.recordPublish(ctx, routingKey, time.Since(started), err)
+	return err
+}
+
+func (p *Publisher) publish(ctx context.Context, routingKey string, envelope *BaseEvent, body []byte) error {
+	codec := p.codecOf(envelope.EventType)
+	encoded, err := codec.Encode(body)
+	if err != nil {
+		return err
 	}
 
 	headers := amqp.Table{
-		"__TypeId__": event.GetEventType(),
+		"__TypeId__":     envelope.EventType,
+		versionHeader:    envelope.Version,
+		"correlation_id": envelope.CorrelationId,
+	}
+	if envelope.SchemaId != "" {
+		headers[schemaIdHeader] = envelope.SchemaId
+	}
+	if envelope.CausationId != "" {
+		headers["causationId"] = envelope.CausationId
+	}
+	if requestId := logger.RequestIdFromContext(ctx); requestId != "" {
+		headers[requestIdHeader] = requestId
+	}
+	if envelope.Tenant != "" {
+		headers[tenantHeader] = envelope.Tenant
+		if p.tenantRoutingKeys {
+			routingKey = tenantRoutingKey(routingKey, envelope.Tenant)
+		}
 	}
 
-	body, err := json.Marshal(event)
+	props := amqp.Publishing{
+		DeliveryMode:  amqp.Persistent,
+		ContentType:   codec.ContentType(),
+		Timestamp:     time.Now().UTC(),
+		Type:          envelope.EventType,
+		MessageId:     envelope.EventId,
+		CorrelationId: envelope.CorrelationId,
+		Body:          encoded,
+		Headers:       headers,
+	}
+
+	if err := p.publishConfirmed(ctx, p.exchange, routingKey, props); err != nil {
+		return err
+	}
+	if p.auditor != nil {
+		p.auditor.RecordEvent(ctx, newPublishedRecord(routingKey, envelope))
+	}
+	return nil
+}
+
+// codecOf returns the codec an event type is published with, JSON unless another content type is set for it
+func (p *Publisher) codecOf(eventType string) Codec {
+	contentType, ok := p.contentTypes[eventType]
+	if !ok {
+		return jsonCodec{}
+	}
+	codec, err := CodecFor(contentType)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+		p.log.Warnf("Publishing %s as JSON: %v", eventType, err)
+		return jsonCodec{}
+	}
+	return codec
+}
+
+// Requeue publishes a dead-lettered message again to the exchange and routing key it was originally
+// published with, keeping its id, correlation and headers but not the broker's death history
+func (p *Publisher) Requeue(ctx context.Context, letter *DeadLetter) error {
+	headers := amqp.Table{}
+	for key, value := range letter.Headers {
+		if !isDeathHeader(key) {
+			headers[key] = value
+		}
 	}
 
 	props := amqp.Publishing{
 		DeliveryMode:  amqp.Persistent,
 		ContentType:   "application/json",
 		Timestamp:     time.Now().UTC(),
-		MessageId:     uuid.New().String(),
-		CorrelationId: uuid.New().String(),
-		Body:          body,
+		Type:          letter.Type,
+		MessageId:     letter.MessageId,
+		CorrelationId: letter.CorrelationId,
+		Body:          letter.Body,
 		Headers:       headers,
 	}
+	return p.publishConfirmed(ctx, letter.Exchange, letter.RoutingKey, props)
+}
+
+func (p *Publisher) publishConfirmed(ctx context.Context, exchange string, routingKey string, props amqp.Publishing) error {
+	channel, err := p.GetChannel(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get publisher channel: %w", err)
+	}
 
 	confirmCtx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
@@ -118,7 +279,7 @@ func (p *Publisher) Publish(ctx context.Context, routingKey string, event EventB
 
 	err = channel.PublishWithContext(
 		ctx,
-		p.exchange,
+		exchange,
 		routingKey,
 		true,
 		false,
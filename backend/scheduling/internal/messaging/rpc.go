@@ -0,0 +1,274 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// rpcErrorHeader carries the error message of a failed remote call in the reply headers
+const rpcErrorHeader = "__Error__"
+
+var (
+	// ErrRpcTimeout is returned when no reply arrives within the request timeout
+	ErrRpcTimeout = errors.New("rpc request timed out")
+	// ErrRpcClosed is returned for calls waiting on a reply channel that has been closed
+	ErrRpcClosed = errors.New("rpc reply channel closed")
+)
+
+// RpcError is returned when the remote service replied with an error instead of a result
+type RpcError struct {
+	Message string
+}
+
+func (e *RpcError) Error() string {
+	return "rpc call failed: " + e.Message
+}
+
+// RpcClient sends requests over the exchange and waits for replies on an exclusive reply queue.
+// Replies are matched to their pending calls by correlation id.
+type RpcClient struct {
+	provider   *ConnectionProvider
+	exchange   string
+	timeout    time.Duration
+	log        *logger.AppLogger
+	mu         sync.Mutex
+	channel    *amqp.Channel
+	replyQueue string
+	pending    map[string]chan amqp.Delivery
+}
+
+func NewRpcClient(provider *ConnectionProvider, config *config.RabbitMqConfig, log *logger.AppLogger) *RpcClient {
+	return &RpcClient{
+		provider: provider,
+		exchange: config.Exchange,
+		timeout:  time.Duration(config.RpcTimeoutMs) * time.Millisecond,
+		log:      log,
+		pending:  make(map[string]chan amqp.Delivery),
+	}
+}
+
+func (c *RpcClient) Initialize(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.initialize(ctx)
+}
+
+func (c *RpcClient) initialize(ctx context.Context) error {
+	if c.channel != nil && !c.channel.IsClosed() {
+		return nil
+	}
+
+	conn, err := c.provider.GetConnection(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection for rpc client: %w", err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("failed to create channel for rpc client: %w", err)
+	}
+
+	err = declareExchange(channel, c.exchange, "topic")
+	if err != nil {
+		channel.Close()
+		return fmt.Errorf("failed to declare exchange '%s': %w", c.exchange, err)
+	}
+
+	queue, err := channel.QueueDeclare(
+		"",    // name, generated by the broker
+		false, // durable
+		true,  // delete when unused
+		true,  // exclusive
+		false, // no-wait
+		nil,   // arguments
+	)
+	if err != nil {
+		channel.Close()
+		return fmt.Errorf("failed to declare rpc reply queue: %w", err)
+	}
+
+	replies, err := channel.Consume(queue.Name, "", true, true, false, false, nil)
+	if err != nil {
+		channel.Close()
+		return fmt.Errorf("failed to consume rpc reply queue '%s': %w", queue.Name, err)
+	}
+
+	go c.dispatchReplies(channel, replies)
+
+	c.channel = channel
+	c.replyQueue = queue.Name
+	return nil
+}
+
+// Call publishes a request to the routing key and decodes the matching reply into response.
+// It gives up after the configured timeout or when the context is done, whichever comes first.
+func (c *RpcClient) Call(ctx context.Context, routingKey string, request EventBase, response any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rpc request: %w", err)
+	}
+
+	correlationId := uuid.New().String()
+	replies := make(chan amqp.Delivery, 1)
+
+	channel, replyQueue, err := c.register(ctx, correlationId, replies)
+	if err != nil {
+		return err
+	}
+	defer c.unregister(correlationId)
+
+	callCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	props := amqp.Publishing{
+		ContentType:   "application/json",
+		Timestamp:     time.Now().UTC(),
+		MessageId:     uuid.New().String(),
+		CorrelationId: correlationId,
+		ReplyTo:       replyQueue,
+		Expiration:    strconv.FormatInt(c.timeout.Milliseconds(), 10),
+		Body:          body,
+		Headers: amqp.Table{
+			"__TypeId__": request.GetEventType(),
+		},
+	}
+	if requestId := logger.RequestIdFromContext(ctx); requestId != "" {
+		props.Headers[requestIdHeader] = requestId
+	}
+
+	if err := channel.PublishWithContext(callCtx, c.exchange, routingKey, false, false, props); err != nil {
+		return fmt.Errorf("failed to publish rpc request: %w", err)
+	}
+
+	select {
+	case reply, ok := <-replies:
+		if !ok {
+			return ErrRpcClosed
+		}
+		if message, isError := reply.Headers[rpcErrorHeader].(string); isError {
+			return &RpcError{Message: message}
+		}
+		if response == nil {
+			return nil
+		}
+		if err := json.Unmarshal(reply.Body, response); err != nil {
+			return fmt.Errorf("failed to unmarshal rpc reply: %w", err)
+		}
+		return nil
+	case <-callCtx.Done():
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return ErrRpcTimeout
+	}
+}
+
+// Reply answers an rpc request received by a consumer. A non-nil replyErr is sent back to the caller
+// instead of the response. Requests without a reply queue are ignored.
+func (c *RpcClient) Reply(ctx context.Context, request amqp.Delivery, response any, replyErr error) error {
+	if request.ReplyTo == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	if err := c.initialize(ctx); err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	channel := c.channel
+	c.mu.Unlock()
+
+	props := amqp.Publishing{
+		ContentType:   "application/json",
+		Timestamp:     time.Now().UTC(),
+		MessageId:     uuid.New().String(),
+		CorrelationId: request.CorrelationId,
+	}
+
+	if replyErr != nil {
+		props.Headers = amqp.Table{rpcErrorHeader: replyErr.Error()}
+	} else {
+		body, err := json.Marshal(response)
+		if err != nil {
+			return fmt.Errorf("failed to marshal rpc reply: %w", err)
+		}
+		props.Body = body
+	}
+
+	if err := channel.PublishWithContext(ctx, "", request.ReplyTo, false, false, props); err != nil {
+		return fmt.Errorf("failed to publish rpc reply: %w", err)
+	}
+	return nil
+}
+
+func (c *RpcClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.channel != nil && !c.channel.IsClosed() {
+		return c.channel.Close()
+	}
+	return nil
+}
+
+func (c *RpcClient) register(ctx context.Context, correlationId string, replies chan amqp.Delivery) (*amqp.Channel, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.initialize(ctx); err != nil {
+		return nil, "", fmt.Errorf("failed to get rpc channel: %w", err)
+	}
+
+	c.pending[correlationId] = replies
+	return c.channel, c.replyQueue, nil
+}
+
+func (c *RpcClient) unregister(correlationId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.pending, correlationId)
+}
+
+// dispatchReplies routes replies to waiting calls and fails all of them once the channel closes,
+// since the exclusive reply queue is gone together with it
+func (c *RpcClient) dispatchReplies(channel *amqp.Channel, replies <-chan amqp.Delivery) {
+	for reply := range replies {
+		c.mu.Lock()
+		waiting, ok := c.pending[reply.CorrelationId]
+		if ok {
+			delete(c.pending, reply.CorrelationId)
+		}
+		c.mu.Unlock()
+
+		if !ok {
+			c.log.Warnf("Received rpc reply with unknown correlation id %s", reply.CorrelationId)
+			continue
+		}
+		waiting <- reply
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.channel == channel {
+		c.channel = nil
+		c.log.Warn("Rpc client channel closed")
+		for correlationId, waiting := range c.pending {
+			close(waiting)
+			delete(c.pending, correlationId)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Header names carrying the contract of a message. The headers, and the schema id of the payload, are
+// authoritative for the version: the version field of some payloads, such as session notes and catalog
+// products, holds event data instead.
+const (
+	versionHeader  = "eventVersion"
+	schemaIdHeader = "schemaId"
+)
+
+var (
+	// ErrIncompatibleEvent is returned when a published event does not match its registered contract
+	ErrIncompatibleEvent = errors.New("event does not match its contract")
+	// ErrUnsupportedVersion is returned for a consumed message whose version this service can not read
+	ErrUnsupportedVersion = errors.New("unsupported event version")
+)
+
+// EventContract is one version of the payload of a published event type. A change that removes or
+// renames a field, or changes its meaning, is a new version; new fields can be added within a version.
+type EventContract struct {
+	Type    string
+	Version int
+	// Fields are the data fields every payload of the version carries at the top level, next to the envelope
+	Fields []string
+}
+
+// SchemaId identifies the contract of a payload, such as BOOKING_COMPLETED.v1
+func (c EventContract) SchemaId() string {
+	return fmt.Sprintf("%s.v%d", c.Type, c.Version)
+}
+
+// ConsumedContract is the range of versions of an event type this service reads
+type ConsumedContract struct {
+	Type       string
+	MinVersion int
+	MaxVersion int
+}
+
+var publishedContracts = []EventContract{
+	{Type: EventScheduled, Version: 1, Fields: []string{"productId", "startTime", "endTime"}},
+	{Type: BookingCompleted, Version: 1, Fields: []string{"userId", "enrollmentId"}},
+	{Type: AttendanceRecorded, Version: 1, Fields: []string{"bookingId", "educatorId", "studentId", "enrollmentId", "productId", "outcome", "startTime"}},
+	{Type: SessionNoteUpdated, Version: 1, Fields: []string{"noteId", "bookingId", "educatorId", "studentId", "enrollmentId", "productId", "content", "version", "sessionStart"}},
+	{Type: PayoutStatementGenerated, Version: 1, Fields: []string{"statementId", "educatorId", "periodStart", "periodEnd", "sessionCount", "grossAmount", "feeAmount", "netAmount", "currency"}},
+	{Type: InvoiceGenerated, Version: 1, Fields: []string{"invoiceId", "invoiceNumber", "bookingId", "educatorId", "studentId", "currency", "subtotal", "discountAmount", "taxAmount", "totalAmount", "issuedAt", "tax", "lines"}},
+	{Type: NotificationRequested, Version: 1, Fields: []string{"userId", "notificationType", "channels", "language", "deliverAt", "data"}},
+	{Type: SnapshotStarted, Version: 1, Fields: []string{"snapshotId", "sequence", "totalItems", "capturedAt"}},
+	{Type: SnapshotItem, Version: 1, Fields: []string{"snapshotId", "sequence", "entityType"}},
+	{Type: SnapshotCompleted, Version: 1, Fields: []string{"snapshotId", "sequence", "totalItems", "capturedAt"}},
+	{Type: BookingsCancelled, Version: 1, Fields: []string{"educatorId", "reason", "batchNumber", "totalBatches", "bookings"}},
+	{Type: BookingPriceAdjusted, Version: 1, Fields: []string{"bookingId", "studentId", "educatorId", "productId", "extensionId", "amount", "newPrice"}},
+	{Type: BookingUpdated, Version: 1, Fields: []string{"bookingId", "studentId", "educatorId", "title", "startTime", "endTime"}},
+	{Type: OffboardingStarted, Version: 1, Fields: []string{"educatorId", "mode", "reassignTo", "bookingsReassigned", "bookingsKept"}},
+	{Type: EducatorArchived, Version: 1, Fields: []string{"educatorId", "mode", "reassignTo", "bookingsReassigned", "bookingsKept"}},
+	{Type: BookingReassigned, Version: 1, Fields: []string{"bookingId", "studentId", "fromEducatorId", "toEducatorId", "productId", "enrollmentId", "startTime", "endTime"}},
+	{Type: WaitlistSpotOffered, Version: 1, Fields: []string{"entryId", "scheduledEventId", "studentId", "bookingId", "offerExpiresAt"}},
+	{Type: WaitlistPromoted, Version: 1, Fields: []string{"entryId", "scheduledEventId", "studentId", "bookingId", "offerExpiresAt"}},
+	{Type: CancellationSettled, Version: 1, Fields: []string{"bookingId", "studentId", "educatorId", "productId", "cancelledBy", "ruleId", "price", "refundAmount", "penaltyAmount"}},
+	{Type: SessionReminderDue, Version: 1, Fields: []string{"userId", "role", "sessionKind", "sessionId", "title", "startTime", "endTime", "offsetMinutes", "channels", "language"}},
+	{Type: BookingSLABreached, Version: 1, Fields: []string{"state", "entityId", "educatorId", "studentId", "startTime", "pendingSince", "slaMinutes", "elapsedMinutes"}},
+	{Type: BookingStatusChanged, Version: 1, Fields: []string{"bookingId", "educatorId", "studentId", "fromStatus", "toStatus", "changedAt"}},
+}
+
+// consumedContracts lists the versions read of every consumed event type. Producers roll out a new
+// version only once every replica reads it, until then they keep publishing the previous one.
+var consumedContracts = []ConsumedContract{
+	{Type: BookingCreationRequested, MinVersion: 1, MaxVersion: 1},
+	{Type: UserDeleted, MinVersion: 1, MaxVersion: 1},
+	{Type: ProductCatalogUpdated, MinVersion: 1, MaxVersion: 1},
+	{Type: EnrollmentCreated, MinVersion: 1, MaxVersion: 1},
+	{Type: BookingHoldPaid, MinVersion: 1, MaxVersion: 1},
+}
+
+// PublishedContracts returns the current contract of every published event type
+func PublishedContracts() []EventContract {
+	return slices.Clone(publishedContracts)
+}
+
+// ConsumedContracts returns the versions read of every consumed event type
+func ConsumedContracts() []ConsumedContract {
+	return slices.Clone(consumedContracts)
+}
+
+func publishedContract(eventType string) (EventContract, bool) {
+	for _, c := range publishedContracts {
+		if c.Type == eventType {
+			return c, true
+		}
+	}
+	return EventContract{}, false
+}
+
+// contractVersion returns the registered version of an event type, EnvelopeVersion when it has no contract
+func contractVersion(eventType string) int {
+	if contract, ok := publishedContract(eventType); ok {
+		return contract.Version
+	}
+	return EnvelopeVersion
+}
+
+// checkCompatibility verifies that a stamped event is registered with the version it carries and that
+// its payload has every field of the contract
+func checkCompatibility(envelope *BaseEvent, body []byte) error {
+	contract, ok := publishedContract(envelope.EventType)
+	if !ok {
+		return fmt.Errorf("%w: no contract registered for %s", ErrIncompatibleEvent, envelope.EventType)
+	}
+	if envelope.Version != contract.Version {
+		return fmt.Errorf("%w: %s version %d, registered version is %d", ErrIncompatibleEvent, envelope.EventType, envelope.Version, contract.Version)
+	}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return fmt.Errorf("%w: %v", ErrIncompatibleEvent, err)
+	}
+	for _, field := range contract.Fields {
+		if _, ok := payload[field]; !ok {
+			return fmt.Errorf("%w: %s misses field %s", ErrIncompatibleEvent, contract.SchemaId(), field)
+		}
+	}
+	return nil
+}
+
+// NegotiateVersion returns the version of a consumed message, checked against the versions this service
+// reads of its type. Messages without a version header predate versioning and are version 1.
+func NegotiateVersion(msg amqp.Delivery) (int, error) {
+	eventType, _ := msg.Headers["__TypeId__"].(string)
+	version := deliveryVersion(msg)
+
+	for _, c := range consumedContracts {
+		if c.Type != eventType {
+			continue
+		}
+		if version < c.MinVersion || version > c.MaxVersion {
+			return version, fmt.Errorf("%w: %s version %d, versions %d to %d are read", ErrUnsupportedVersion, eventType, version, c.MinVersion, c.MaxVersion)
+		}
+		return version, nil
+	}
+	// Unknown types are left to the message handler, which rejects them
+	return version, nil
+}
+
+func deliveryVersion(msg amqp.Delivery) int {
+	switch v := msg.Headers[versionHeader].(type) {
+	case int:
+		return v
+	case int8:
+		return int(v)
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,136 @@
+package messaging
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// consumerChannel is the part of an AMQP channel a subscription consumes on
+type consumerChannel interface {
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+	Cancel(consumer string, noWait bool) error
+}
+
+var consumerTagSeq atomic.Int64
+
+// subscription consumes a queue on a channel and hands the deliveries on to the workers. RabbitMQ applies a
+// prefetch set on a channel only to the consumers created after it, so a prefetch change re-creates the
+// consumer: the new one is started before the old one is cancelled, and the deliveries the old one already
+// received are still handed on and acknowledged on the same channel.
+type subscription struct {
+	channel    consumerChannel
+	queue      string
+	deliveries chan amqp.Delivery
+	// lost receives an error when the current consumer stops without being replaced, as when the channel closes
+	lost     chan error
+	done     chan struct{}
+	stopOnce sync.Once
+
+	mu  sync.Mutex
+	tag string
+}
+
+func newSubscription(channel consumerChannel, queue string) *subscription {
+	return &subscription{
+		channel:    channel,
+		queue:      queue,
+		deliveries: make(chan amqp.Delivery),
+		lost:       make(chan error, 1),
+		done:       make(chan struct{}),
+	}
+}
+
+// start creates the first consumer of the subscription
+func (s *subscription) start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.consume()
+}
+
+// setPrefetch applies a prefetch to the channel and replaces the consumer by one the prefetch applies to
+func (s *subscription) setPrefetch(prefetch int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tag == "" {
+		return fmt.Errorf("subscription to queue '%s' is cancelled", s.queue)
+	}
+	if err := s.channel.Qos(prefetch, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	previous := s.tag
+	if err := s.consume(); err != nil {
+		return err
+	}
+	if err := s.channel.Cancel(previous, false); err != nil {
+		return fmt.Errorf("failed to cancel consumer '%s': %w", previous, err)
+	}
+	return nil
+}
+
+// consume starts a consumer with a new tag and makes it the current one, s.mu must be held
+func (s *subscription) consume() error {
+	tag := fmt.Sprintf("%s-%d", s.queue, consumerTagSeq.Add(1))
+	messages, err := s.channel.Consume(
+		s.queue, // queue
+		tag,     // consumer tag
+		false,   // auto-ack - set to false for manual acknowledgment
+		false,   // exclusive
+		false,   // no-local
+		false,   // no-wait
+		nil,     // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start consuming from queue '%s': %w", s.queue, err)
+	}
+
+	s.tag = tag
+	go s.forward(tag, messages)
+	return nil
+}
+
+// forward hands the deliveries of one consumer on until it stops. A replaced consumer stops quietly, the
+// current one stopping means the subscription was lost.
+func (s *subscription) forward(tag string, messages <-chan amqp.Delivery) {
+	for msg := range messages {
+		select {
+		case s.deliveries <- msg:
+		case <-s.done:
+			return
+		}
+	}
+
+	s.mu.Lock()
+	current := s.tag == tag
+	s.mu.Unlock()
+	if current {
+		select {
+		case s.lost <- fmt.Errorf("consumer '%s' of queue '%s' stopped", tag, s.queue):
+		default:
+		}
+	}
+}
+
+// cancel stops the current consumer, its remaining deliveries are no longer handed on
+func (s *subscription) cancel() error {
+	s.mu.Lock()
+	tag := s.tag
+	s.tag = ""
+	s.mu.Unlock()
+
+	s.stop()
+	if tag == "" {
+		return nil
+	}
+	return s.channel.Cancel(tag, false)
+}
+
+// stop ends the forwarding of the consumers, unacknowledged deliveries are redelivered once the channel closes
+func (s *subscription) stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
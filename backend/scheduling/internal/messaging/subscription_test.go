@@ -0,0 +1,148 @@
+package messaging
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// fakeChannel records the calls made on it and hands out a delivery channel per consumer
+type fakeChannel struct {
+	mu        sync.Mutex
+	calls     []string
+	prefetch  int
+	consumers map[string]chan amqp.Delivery
+	// prefetchOf is the prefetch in effect when each consumer was created
+	prefetchOf map[string]int
+}
+
+func newFakeChannel(prefetch int) *fakeChannel {
+	return &fakeChannel{
+		prefetch:   prefetch,
+		consumers:  make(map[string]chan amqp.Delivery),
+		prefetchOf: make(map[string]int),
+	}
+}
+
+func (f *fakeChannel) Qos(prefetchCount, _ int, _ bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, fmt.Sprintf("qos %d", prefetchCount))
+	f.prefetch = prefetchCount
+	return nil
+}
+
+func (f *fakeChannel) Consume(_, consumer string, _, _, _, _ bool, _ amqp.Table) (<-chan amqp.Delivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "consume "+consumer)
+	deliveries := make(chan amqp.Delivery, 1)
+	f.consumers[consumer] = deliveries
+	f.prefetchOf[consumer] = f.prefetch
+	return deliveries, nil
+}
+
+func (f *fakeChannel) Cancel(consumer string, _ bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, "cancel "+consumer)
+	if deliveries, ok := f.consumers[consumer]; ok {
+		close(deliveries)
+		delete(f.consumers, consumer)
+	}
+	return nil
+}
+
+func (f *fakeChannel) snapshot() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *fakeChannel) deliver(t *testing.T, consumer string, msg amqp.Delivery) {
+	t.Helper()
+	f.mu.Lock()
+	deliveries, ok := f.consumers[consumer]
+	f.mu.Unlock()
+	if !ok {
+		t.Fatalf("consumer %q is not active", consumer)
+	}
+	deliveries <- msg
+}
+
+func receive(t *testing.T, s *subscription) amqp.Delivery {
+	t.Helper()
+	select {
+	case msg := <-s.deliveries:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no delivery handed on")
+		return amqp.Delivery{}
+	}
+}
+
+func TestSubscriptionSetPrefetchRecreatesConsumer(t *testing.T) {
+	channel := newFakeChannel(10)
+	s := newSubscription(channel, "scheduling")
+	if err := s.start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer s.stop()
+	first := s.tag
+
+	channel.deliver(t, first, amqp.Delivery{MessageId: "before"})
+	if msg := receive(t, s); msg.MessageId != "before" {
+		t.Fatalf("got delivery %q, want %q", msg.MessageId, "before")
+	}
+
+	if err := s.setPrefetch(25); err != nil {
+		t.Fatalf("setPrefetch: %v", err)
+	}
+	second := s.tag
+	if second == first {
+		t.Fatalf("consumer tag %q was reused after the prefetch change", second)
+	}
+
+	want := []string{"consume " + first, "qos 25", "consume " + second, "cancel " + first}
+	got := channel.snapshot()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("channel calls = %v, want %v", got, want)
+	}
+	if prefetch := channel.prefetchOf[second]; prefetch != 25 {
+		t.Fatalf("new consumer was created with prefetch %d, want 25", prefetch)
+	}
+
+	channel.deliver(t, second, amqp.Delivery{MessageId: "after"})
+	if msg := receive(t, s); msg.MessageId != "after" {
+		t.Fatalf("got delivery %q, want %q", msg.MessageId, "after")
+	}
+
+	select {
+	case err := <-s.lost:
+		t.Fatalf("replacing the consumer reported it lost: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSubscriptionReportsLostConsumer(t *testing.T) {
+	channel := newFakeChannel(10)
+	s := newSubscription(channel, "scheduling")
+	if err := s.start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer s.stop()
+
+	// The channel closing stops the current consumer without it being replaced
+	channel.mu.Lock()
+	close(channel.consumers[s.tag])
+	channel.mu.Unlock()
+
+	select {
+	case <-s.lost:
+	case <-time.After(time.Second):
+		t.Fatal("a stopped consumer was not reported lost")
+	}
+}
@@ -0,0 +1,55 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/ratelimit"
+)
+
+const throttleKeyPrefix = "event-throttle:"
+
+// Throttle caps the publish rate of the event types it has a limit for, each type in its own bucket so a
+// flood of one type does not hold back the others. Types without a limit are never throttled. A nil
+// throttle allows every event.
+type Throttle struct {
+	log      logger.Logger
+	limiter  ratelimit.Limiter
+	policies map[string]ratelimit.Policy
+}
+
+func NewThrottle(log logger.Logger, limiter ratelimit.Limiter, cfg *config.EventThrottleConfig) (*Throttle, error) {
+	limits, err := cfg.ParseTypeLimits()
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(map[string]ratelimit.Policy, len(limits))
+	for eventType, perMinute := range limits {
+		burst := max(perMinute*cfg.BurstSeconds/60, 1)
+		policies[eventType] = ratelimit.PerMinute(perMinute, burst)
+	}
+	return &Throttle{log: log, limiter: limiter, policies: policies}, nil
+}
+
+// Allow takes a token for an event of the given type. When none is left the event has to wait, and the
+// time until the next token is returned. Events are allowed while the limiter fails, rather than delayed
+// for as long as it does.
+func (t *Throttle) Allow(ctx context.Context, eventType string) (bool, time.Duration) {
+	if t == nil {
+		return true, 0
+	}
+	policy, ok := t.policies[eventType]
+	if !ok {
+		return true, 0
+	}
+
+	allowed, wait, err := t.limiter.Allow(ctx, throttleKeyPrefix+eventType, policy)
+	if err != nil {
+		logger.FromContext(ctx, t.log).Error("failed to check event throttle", err)
+		return true, 0
+	}
+	return allowed, wait
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// ActingEducatorHeader selects the educator whose account the caller manages in the request
+const ActingEducatorHeader = "X-Acting-Educator-Id"
+
+// ActingAccessResolver resolves the permissions a user holds on behalf of an educator, none when denied
+type ActingAccessResolver interface {
+	ActingPermissions(ctx context.Context, actorId uuid.UUID, educatorId uuid.UUID) ([]auth.Permission, error)
+}
+
+type actingPolicy struct {
+	prefix string
+	read   auth.Permission
+	write  auth.Permission
+}
+
+// actingPolicies lists the endpoints delegates may reach and the permission each method needs.
+// Anything else, including financial data, is limited to full access.
+var actingPolicies = []actingPolicy{
+	{prefix: "/api/v1/schedules", read: auth.ManageSchedulePermission, write: auth.ManageSchedulePermission},
+	{prefix: "/api/v1/session-types", read: auth.ManageSchedulePermission, write: auth.ManageSchedulePermission},
+	{prefix: "/api/v1/locations", read: auth.ManageSchedulePermission, write: auth.ManageSchedulePermission},
+	{prefix: "/api/v1/bookings", read: auth.ViewBookingsPermission, write: auth.ManageSchedulePermission},
+	{prefix: "/api/v1/attendance", read: auth.ViewBookingsPermission, write: auth.ManageSchedulePermission},
+	{prefix: "/api/v1/bulk-cancellations", read: auth.ManageSchedulePermission, write: auth.ManageSchedulePermission},
+	{prefix: "/api/v1/escalation-rules", read: auth.ManageSchedulePermission, write: auth.ManageSchedulePermission},
+	{prefix: "/api/v1/extensions", read: auth.ViewBookingsPermission, write: auth.ManageSchedulePermission},
+	{prefix: "/api/v1/availability", read: auth.ManageSchedulePermission, write: auth.ManageSchedulePermission},
+}
+
+// ActingEducatorMiddleware lets organization admins and delegates use educator endpoints on behalf of
+// an educator. When the header is set and the caller holds permissions for the educator, the educator
+// becomes the request user with the educator role, while the caller stays available as the actor.
+// Requests without the header are left untouched.
+func ActingEducatorMiddleware(resolver ActingAccessResolver, log *logger.AppLogger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get(ActingEducatorHeader)
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userId, err := auth.GetUserID(r.Context())
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			educatorId, err := uuid.Parse(header)
+			if err != nil {
+				api.WriteError(w, apperrors.NewBadRequestError("Invalid acting educator id", apperrors.ErrParameterParsingFailed, err))
+				return
+			}
+
+			if educatorId == userId {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			permissions, err := resolver.ActingPermissions(r.Context(), userId, educatorId)
+			if err != nil {
+				log.Error("failed to resolve acting permissions", err)
+				api.WriteError(w, err)
+				return
+			}
+			if len(permissions) == 0 || !actingAllowed(r, permissions) {
+				api.WriteError(w, apperrors.NewForbidden("Access denied"))
+				return
+			}
+
+			role := auth.DelegateRole
+			if slices.Contains(permissions, auth.FullAccessPermission) {
+				role = auth.OrganizationAdminRole
+			}
+
+			ctx := context.WithValue(r.Context(), auth.ActorIdKey, userId)
+			ctx = context.WithValue(ctx, auth.UserIdKey, educatorId)
+			ctx = context.WithValue(ctx, auth.UserRolesKey, []any{auth.EducatorRole, role})
+			ctx = context.WithValue(ctx, auth.PermissionsKey, permissions)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// actingAllowed applies the acting policies to a request
+func actingAllowed(r *http.Request, permissions []auth.Permission) bool {
+	if slices.Contains(permissions, auth.FullAccessPermission) {
+		return true
+	}
+
+	for _, policy := range actingPolicies {
+		if !strings.HasPrefix(r.URL.Path, policy.prefix) {
+			continue
+		}
+		required := policy.write
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			required = policy.read
+		}
+		return slices.Contains(permissions, required)
+	}
+	return false
+}
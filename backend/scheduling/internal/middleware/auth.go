@@ -3,18 +3,23 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"path"
 	"strings"
 
 	"github.com/maksmelnyk/scheduling/internal/api"
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
 
 	"github.com/google/uuid"
+	"github.com/maksmelnyk/scheduling/config"
 	"github.com/maksmelnyk/scheduling/internal/auth"
 	"github.com/maksmelnyk/scheduling/internal/logger"
 )
 
-// AuthMiddleware validates JWT tokens
-func AuthMiddleware(validator *auth.JWTValidator, log *logger.AppLogger, publicRoutes []string) func(next http.Handler) http.Handler {
+// AuthMiddleware validates JWT tokens. With tenancy enabled every authenticated request is served for the
+// tenant of its token. Public routes are served without a tenant: the modules take it from the signed feed
+// or link token or the API key the request carries, and requests without one see no tenant's schedules and
+// bookings.
+func AuthMiddleware(validator *auth.JWTValidator, log *logger.AppLogger, publicRoutes []string, tenancy *config.TenancyConfig) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if r.Method == http.MethodOptions {
@@ -22,11 +27,9 @@ func AuthMiddleware(validator *auth.JWTValidator, log *logger.AppLogger, publicR
 				return
 			}
 
-			for _, route := range publicRoutes {
-				if strings.HasPrefix(r.URL.Path, route) {
-					next.ServeHTTP(w, r)
-					return
-				}
+			if isPublicRoute(r.URL.Path, publicRoutes) {
+				next.ServeHTTP(w, r)
+				return
 			}
 
 			authHeader := r.Header.Get("Authorization")
@@ -43,7 +46,7 @@ func AuthMiddleware(validator *auth.JWTValidator, log *logger.AppLogger, publicR
 				return
 			}
 
-			claims, err := validator.ValidateToken(tokenString)
+			claims, err := validator.ValidateToken(r.Context(), tokenString)
 			if err != nil {
 				log.Error("invalid token", err)
 				api.WriteError(w, apperrors.NewUnauthorized("Invalid token", err))
@@ -59,12 +62,18 @@ func AuthMiddleware(validator *auth.JWTValidator, log *logger.AppLogger, publicR
 
 			ctx := context.WithValue(r.Context(), auth.UserIdKey, userId)
 
-			realmAccess, ok := claims["realm_access"].(map[string]any)
-			if ok {
-				roles, ok := realmAccess["roles"].([]any)
-				if ok {
-					ctx = context.WithValue(ctx, auth.UserRolesKey, roles)
+			if roles := validator.Roles(claims); len(roles) > 0 {
+				ctx = context.WithValue(ctx, auth.UserRolesKey, roles)
+			}
+
+			if tenancy.Enabled {
+				tenantId, _ := claims[tenancy.Claim].(string)
+				if tenantId == "" {
+					log.Errorf("token of user %s has no %s claim", userId, tenancy.Claim)
+					api.WriteError(w, apperrors.NewForbidden("Token has no tenant"))
+					return
 				}
+				ctx = auth.WithTenant(ctx, tenantId)
 			}
 
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -72,18 +81,17 @@ func AuthMiddleware(validator *auth.JWTValidator, log *logger.AppLogger, publicR
 	}
 }
 
-// RoleAuthMiddleware checks if the user has the required role
-func RoleAuthMiddleware(requiredRole string) func(next http.Handler) http.Handler {
+// RequireRole allows the request when the user has any of the given realm or client roles
+func RequireRole(roles ...string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			roles, ok := r.Context().Value(auth.UserRolesKey).([]any)
-			if !ok {
+			if _, ok := r.Context().Value(auth.UserRolesKey).([]any); !ok {
 				api.WriteError(w, apperrors.NewUnauthorized("Invalid token"))
 				return
 			}
 
 			for _, role := range roles {
-				if role == requiredRole {
+				if auth.HasRole(r.Context(), role) {
 					next.ServeHTTP(w, r)
 					return
 				}
@@ -93,3 +101,42 @@ func RoleAuthMiddleware(requiredRole string) func(next http.Handler) http.Handle
 		})
 	}
 }
+
+// RequirePermission allows the request only when the user holds every given permission, through their roles
+// or the grant they act under
+func RequirePermission(permissions ...auth.Permission) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.Context().Value(auth.UserRolesKey).([]any); !ok {
+				api.WriteError(w, apperrors.NewUnauthorized("Invalid token"))
+				return
+			}
+
+			for _, permission := range permissions {
+				if !auth.HasPermission(r.Context(), permission) {
+					api.WriteError(w, apperrors.NewForbidden("Access denied"))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isPublicRoute matches a path against the public routes, which are path prefixes unless they contain
+// a '*' wildcard, in which case the whole path has to match the pattern
+func isPublicRoute(urlPath string, publicRoutes []string) bool {
+	for _, route := range publicRoutes {
+		if strings.Contains(route, "*") {
+			if matched, _ := path.Match(route, urlPath); matched {
+				return true
+			}
+			continue
+		}
+		if strings.HasPrefix(urlPath, route) {
+			return true
+		}
+	}
+	return false
+}
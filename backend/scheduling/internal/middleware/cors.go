@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/cors"
+
+	"github.com/maksmelnyk/scheduling/config"
+)
+
+// CORSMiddleware answers cross-origin requests of the routes it is mounted on with the given policy
+func CORSMiddleware(policy *config.CORSPolicy) func(next http.Handler) http.Handler {
+	return cors.New(cors.Options{
+		AllowedOrigins:   policy.AllowOrigin,
+		AllowedMethods:   policy.AllowMethods,
+		AllowedHeaders:   policy.AllowHeaders,
+		AllowCredentials: policy.AllowCredentials,
+	}).Handler
+}
+
+// RouteMiddleware applies one middleware to the given routes of a mount and another to the rest of it.
+// Routes match by prefix or, when they contain a wildcard, as a path pattern. A nil middleware leaves
+// its requests untouched.
+func RouteMiddleware(routes []string, matching, others func(next http.Handler) http.Handler) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		onMatch, onOthers := next, next
+		if matching != nil {
+			onMatch = matching(next)
+		}
+		if others != nil {
+			onOthers = others(next)
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isPublicRoute(r.URL.Path, routes) {
+				onMatch.ServeHTTP(w, r)
+				return
+			}
+			onOthers.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/degraded"
+)
+
+// DegradedHeader lists the dependencies a response was produced without, such as cached catalog data
+// served while the learning service is down
+const DegradedHeader = "Degraded"
+
+type degradedWriter struct {
+	http.ResponseWriter
+	tracker     *degraded.Tracker
+	wroteHeader bool
+}
+
+func (w *degradedWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if value := w.tracker.Header(); value != "" {
+			w.Header().Set(DegradedHeader, value)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *degradedWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// DegradedMiddleware tracks the dependencies each request is served without and reports them in the
+// Degraded header of the response
+func DegradedMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, tracker := degraded.WithTracker(r.Context())
+		next.ServeHTTP(&degradedWriter{ResponseWriter: w, tracker: tracker}, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+	"path"
+)
+
+// ExposureMiddleware lets a listener serve only its part of the router. The internal listener serves the
+// internal path prefixes alone, the public one every path but them; others are answered as not found, as
+// if the route did not exist there. Paths are cleaned first, so dot segments cannot reach an internal path
+// through the public listener.
+func ExposureMiddleware(internalPaths []string, internal bool) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isPublicRoute(path.Clean(r.URL.Path), internalPaths) != internal {
+				http.NotFound(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// WriteShedder reports whether the database currently refuses writes, such as during a primary failover
+type WriteShedder interface {
+	ShedsWrites() bool
+}
+
+// FailoverMiddleware answers write requests with 503 while the database reconnects to a new primary.
+// Reads keep being served, from the pool connections that are still usable.
+func FailoverMiddleware(shedder WriteShedder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+			default:
+				if shedder.ShedsWrites() {
+					w.Header().Set("Retry-After", "5")
+					api.WriteError(w, apperrors.NewServiceUnavailable("Database failover in progress, retry shortly"))
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
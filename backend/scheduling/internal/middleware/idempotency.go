@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// IdempotencyKeyHeader lets clients retry a mutating request without applying it twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	maxIdempotentBodyBytes   = 1 << 20
+)
+
+// replayedHeaders are the response headers stored with a key and sent again on replays
+var replayedHeaders = []string{"Content-Type", "Content-Disposition", "Location"}
+
+// IdempotencyStore keeps the idempotency keys of users with the responses their requests were answered with
+type IdempotencyStore interface {
+	ReserveKey(ctx context.Context, record *entities.IdempotencyKey, staleBefore time.Time) (bool, *entities.IdempotencyKey, error)
+	CompleteKey(ctx context.Context, userId uuid.UUID, key string, statusCode int, headers string, body []byte, now time.Time) error
+	ReleaseKey(ctx context.Context, userId uuid.UUID, key string) error
+}
+
+// IdempotencyMiddleware makes mutating requests carrying an Idempotency-Key safe to retry. The first request
+// with a key runs and its response is stored; retries with the same key and request get that response again,
+// marked with an Idempotent-Replayed header, while the same key with a different request is rejected. Keys
+// are scoped to the signed in user. Server errors are not stored, so retrying them runs the request again.
+func IdempotencyMiddleware(store IdempotencyStore, cfg *config.IdempotencyConfig, log *logger.AppLogger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userId, err := auth.GetActorID(r.Context())
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				api.WriteError(w, apperrors.NewBadRequestError("Idempotency-Key must be at most 255 characters", apperrors.ErrIdempotencyKeyInvalid))
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBodyBytes+1))
+			if err != nil {
+				api.WriteError(w, apperrors.NewBadRequestError("Failed to read request body", apperrors.ErrParameterParsingFailed, err))
+				return
+			}
+			if len(body) > maxIdempotentBodyBytes {
+				api.WriteError(w, apperrors.NewBadRequestError("Request body is too large for an idempotent request", apperrors.ErrIdempotencyKeyInvalid))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			reqLog := logger.FromContext(r.Context(), log)
+			now := time.Now().UTC()
+			record := &entities.IdempotencyKey{
+				UserId:      userId,
+				Key:         key,
+				Fingerprint: requestFingerprint(r, body),
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(time.Duration(cfg.TTLHours) * time.Hour),
+			}
+
+			reserved, existing, err := store.ReserveKey(r.Context(), record, now.Add(-time.Duration(cfg.LockTimeoutSeconds)*time.Second))
+			if err != nil {
+				reqLog.Error("failed to reserve idempotency key", err)
+				api.WriteError(w, err)
+				return
+			}
+			if !reserved {
+				replayResponse(w, existing, record.Fingerprint)
+				return
+			}
+
+			// The key is stored or released even when the client went away, a retry is likely to follow
+			storeCtx := context.WithoutCancel(r.Context())
+			completed := false
+			defer func() {
+				if !completed {
+					if err := store.ReleaseKey(storeCtx, userId, key); err != nil {
+						reqLog.Error("failed to release idempotency key", err)
+					}
+				}
+			}()
+
+			bw := &bufferedWriter{header: make(http.Header)}
+			next.ServeHTTP(bw, r)
+			if bw.statusCode == 0 {
+				bw.statusCode = http.StatusOK
+			}
+
+			if bw.statusCode < http.StatusInternalServerError {
+				err := store.CompleteKey(storeCtx, userId, key, bw.statusCode, storedHeaders(bw.header), bw.body.Bytes(), time.Now().UTC())
+				if err != nil {
+					reqLog.Error("failed to store idempotent response", err)
+				} else {
+					completed = true
+				}
+			}
+			bw.flush(w)
+		})
+	}
+}
+
+// replayResponse answers a request whose key is held by an earlier request
+func replayResponse(w http.ResponseWriter, existing *entities.IdempotencyKey, fingerprint string) {
+	if existing.Fingerprint != fingerprint {
+		api.WriteError(w, apperrors.NewUnprocessedEntity("Idempotency-Key was already used for a different request", apperrors.ErrIdempotencyKeyReused))
+		return
+	}
+	if existing.CompletedAt == nil || existing.StatusCode == nil {
+		api.WriteError(w, apperrors.NewConflict("A request with this Idempotency-Key is still in progress", apperrors.ErrIdempotencyKeyInProgress))
+		return
+	}
+
+	if existing.ResponseHeaders != nil {
+		var headers map[string][]string
+		if err := json.Unmarshal([]byte(*existing.ResponseHeaders), &headers); err == nil {
+			for name, values := range headers {
+				for _, value := range values {
+					w.Header().Add(name, value)
+				}
+			}
+		}
+	}
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(*existing.StatusCode)
+	_, _ = w.Write(existing.ResponseBody)
+}
+
+// requestFingerprint identifies what a request asks for, so a key cannot replay the response of another one
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write([]byte(r.Header.Get(ActingEducatorHeader) + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func storedHeaders(header http.Header) string {
+	stored := make(map[string][]string)
+	for _, name := range replayedHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			stored[name] = values
+		}
+	}
+	data, _ := json.Marshal(stored)
+	return string(data)
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/i18n"
+)
+
+// LocaleMiddleware stores the locales of the Accept-Language header in the request context so offerings are
+// returned in the caller's language. Responses vary by the header for caches.
+func LocaleMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Language")
+
+		locales := i18n.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+		if len(locales) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocales(r.Context(), locales)))
+	})
+}
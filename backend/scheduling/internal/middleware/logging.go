@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
 )
 
 type statusWriter struct {
@@ -21,6 +22,27 @@ func (w *statusWriter) WriteHeader(code int) {
 	w.ResponseWriter.WriteHeader(code)
 }
 
+// CorrelationIdHeader lets callers tie the events published by a request to their own trace
+const CorrelationIdHeader = "X-Correlation-Id"
+
+// maxRequestIdLength bounds the request ids accepted from callers, longer ones are replaced
+const maxRequestIdLength = 128
+
+// requestIdFromHeader keeps the request id set by a caller, such as the gateway, when it is a short
+// printable token, and generates one otherwise
+func requestIdFromHeader(r *http.Request) string {
+	requestId := r.Header.Get(logger.RequestIdHeader)
+	if requestId == "" || len(requestId) > maxRequestIdLength {
+		return uuid.New().String()
+	}
+	for _, c := range requestId {
+		if c <= ' ' || c > '~' {
+			return uuid.New().String()
+		}
+	}
+	return requestId
+}
+
 func getUserIdFromToken(authHeader string) string {
 	if authHeader != "" {
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
@@ -42,16 +64,27 @@ func LoggingMiddleware(log *logger.AppLogger) func(http.Handler) http.Handler {
 			start := time.Now()
 			sw := &statusWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
-			midLogger := log.With(
-				logger.Field{Key: "request_id", Value: uuid.New().String()},
-				logger.Field{Key: "http_method", Value: r.Method},
-				logger.Field{Key: "http_path", Value: r.URL.Path},
-				logger.Field{Key: "http_query", Value: r.URL.RawQuery},
-				logger.Field{Key: "client_ip", Value: r.RemoteAddr},
-				logger.Field{Key: "user_id", Value: getUserIdFromToken(r.Header.Get("Authorization"))},
-			)
+			requestId := requestIdFromHeader(r)
+			correlationId := r.Header.Get(CorrelationIdHeader)
+			if correlationId == "" {
+				correlationId = requestId
+			}
+
+			midLogger := log.With(append([]logger.Field{
+				{Key: "request_id", Value: requestId},
+				{Key: "correlation_id", Value: correlationId},
+				{Key: "http_method", Value: r.Method},
+				{Key: "http_path", Value: r.URL.Path},
+				{Key: "http_query", Value: r.URL.RawQuery},
+				{Key: "client_ip", Value: r.RemoteAddr},
+				{Key: "user_id", Value: getUserIdFromToken(r.Header.Get("Authorization"))},
+			}, logger.TraceFields(r.Context())...)...)
 
-			r = r.WithContext(logger.WithLogger(r.Context(), midLogger))
+			w.Header().Set(logger.RequestIdHeader, requestId)
+			w.Header().Set(CorrelationIdHeader, correlationId)
+			ctx := messaging.WithCorrelation(r.Context(), correlationId, "")
+			ctx = logger.WithRequestId(ctx, requestId)
+			r = r.WithContext(logger.WithLogger(ctx, midLogger))
 
 			next.ServeHTTP(sw, r)
 
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/ratelimit"
+)
+
+// RateLimitRoute limits the requests of a method to the paths matching Pattern, see path.Match. Routes
+// sharing a Name share their buckets.
+type RateLimitRoute struct {
+	Name    string
+	Method  string
+	Pattern string
+	Policy  ratelimit.Policy
+}
+
+// RateLimitMiddleware rejects requests of a client beyond the policy of their route with 429 and a
+// Retry-After header, so a single client can not exhaust the database connections. Every request is counted
+// against the bucket of its IP address and, once signed in, against the bucket of the calling user too, so
+// neither many accounts on one address nor one account on many addresses get past the limit. It has to run
+// after AuthMiddleware. The X-Forwarded-For header is only read from trustedProxies, none trusts the peer
+// address alone. Requests are let through when the limiter fails.
+func RateLimitMiddleware(limiter ratelimit.Limiter, routes []RateLimitRoute, trustedProxies []netip.Prefix, log *logger.AppLogger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, ok := matchRateLimitRoute(r, routes)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var retryAfter time.Duration
+			for _, client := range rateLimitClients(r, trustedProxies) {
+				allowed, wait, err := limiter.Allow(r.Context(), route.Name+":"+client, route.Policy)
+				if err != nil {
+					logger.FromContext(r.Context(), log).Error("failed to check rate limit", err)
+					continue
+				}
+				if !allowed {
+					retryAfter = max(retryAfter, wait, time.Nanosecond)
+				}
+			}
+			if retryAfter > 0 {
+				seconds := max(int(math.Ceil(retryAfter.Seconds())), 1)
+				api.WriteError(w, apperrors.NewTooManyRequests("Too many requests, retry later", seconds))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ParseTrustedProxies parses the CIDRs of the proxies whose X-Forwarded-For header is trusted
+func ParseTrustedProxies(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+func matchRateLimitRoute(r *http.Request, routes []RateLimitRoute) (RateLimitRoute, bool) {
+	urlPath := strings.TrimSuffix(r.URL.Path, "/")
+	for _, route := range routes {
+		if route.Method != r.Method {
+			continue
+		}
+		if matched, _ := path.Match(route.Pattern, urlPath); matched {
+			return route, true
+		}
+	}
+	return RateLimitRoute{}, false
+}
+
+// rateLimitClients lists the buckets a request is counted against: the IP address of the caller and, once
+// signed in, the real user while acting on behalf of an educator
+func rateLimitClients(r *http.Request, trustedProxies []netip.Prefix) []string {
+	clients := []string{"ip:" + clientIP(r, trustedProxies)}
+	if actorId, err := auth.GetActorID(r.Context()); err == nil {
+		clients = append(clients, "user:"+actorId.String())
+	}
+	return clients
+}
+
+// clientIP returns the address of the caller. Behind trusted proxies it is the rightmost X-Forwarded-For hop
+// not added by one of them, the hops left of it are set by the client and can not be relied on.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	peer, err := netip.ParseAddr(host)
+	if err != nil || !isTrustedProxy(peer, trustedProxies) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			// A malformed hop was not written by a trusted proxy, the one right of it is the last known one
+			return peer.String()
+		}
+		if !isTrustedProxy(hop, trustedProxies) {
+			return hop.String()
+		}
+		peer = hop
+	}
+	return peer.String()
+}
+
+func isTrustedProxy(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "::1/128"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{"no header", "203.0.113.7:4321", nil, "203.0.113.7"},
+		{"header from an untrusted peer is ignored", "203.0.113.7:4321", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"single hop added by the gateway", "10.0.0.2:80", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"spoofed leftmost hop is skipped", "10.0.0.2:80", []string{"1.2.3.4, 198.51.100.1"}, "198.51.100.1"},
+		{"trusted hops are walked past", "10.0.0.2:80", []string{"1.2.3.4, 198.51.100.1, 10.1.2.3"}, "198.51.100.1"},
+		{"headers are joined in order", "10.0.0.2:80", []string{"1.2.3.4", "198.51.100.1"}, "198.51.100.1"},
+		{"only trusted hops", "10.0.0.2:80", []string{"10.9.9.9"}, "10.9.9.9"},
+		{"malformed hop stops the walk", "10.0.0.2:80", []string{"198.51.100.1, junk, 10.1.2.3"}, "10.1.2.3"},
+		{"ipv6 peer", "[::1]:80", []string{"2001:db8::5"}, "2001:db8::5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if got := clientIP(r, proxies); got != tt.want {
+				t.Fatalf("clientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIPWithoutTrustedProxies(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "10.0.0.2:80"
+	r.Header.Set("X-Forwarded-For", "198.51.100.1")
+	if got := clientIP(r, nil); got != "10.0.0.2" {
+		t.Fatalf("clientIP() = %q, want the peer address", got)
+	}
+}
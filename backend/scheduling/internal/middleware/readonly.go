@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// ReadOnlyGuard reports whether the service is in read-only mode
+type ReadOnlyGuard interface {
+	ReadOnly() bool
+}
+
+// ReadOnlyMiddleware answers write requests with 503 while the service is in read-only mode, reads keep being
+// served. Paths under the exempt prefixes stay writable, such as the switch of the mode itself.
+func ReadOnlyMiddleware(guard ReadOnlyGuard, retryAfterSeconds int, exemptPrefixes []string) func(http.Handler) http.Handler {
+	retryAfter := strconv.Itoa(retryAfterSeconds)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+			default:
+				if guard.ReadOnly() && !hasAnyPrefix(r.URL.Path, exemptPrefixes) {
+					w.Header().Set("Retry-After", retryAfter)
+					api.WriteError(w, apperrors.NewServiceUnavailable("Service is in read-only mode, retry later"))
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
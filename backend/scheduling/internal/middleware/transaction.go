@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"bytes"
+	"maps"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// bufferedWriter holds back a response until the request transaction is finished, so a failed commit
+// can still be reported to the client instead of a success
+type bufferedWriter struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *bufferedWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.statusCode == 0 {
+		w.statusCode = code
+	}
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) flush(dst http.ResponseWriter) {
+	maps.Copy(dst.Header(), w.header)
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	dst.WriteHeader(w.statusCode)
+	_, _ = dst.Write(w.body.Bytes())
+}
+
+// TransactionMiddleware runs every mutating request in a single database transaction. The transaction
+// is committed when the handler responds with a success status and rolled back on an error status or a
+// panic. Queries made through the database helpers join it, and transactions opened by repositories
+// become savepoints. Changes made in it are attributed to the caller in the change audit. A failed
+// statement aborts the whole transaction, so handlers using it must not ignore query errors. Messages
+// published by the handler are not part of the transaction. Safe requests are passed through untouched.
+func TransactionMiddleware(db *sqlx.DB, log *logger.AppLogger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			reqLog := logger.FromContext(r.Context(), log)
+
+			tx, err := db.BeginTxx(r.Context(), nil)
+			if err != nil {
+				reqLog.Error("failed to begin request transaction", err)
+				api.WriteError(w, apperrors.NewInternal(err))
+				return
+			}
+			if err := database.SetAuditActor(r.Context(), tx); err != nil {
+				_ = tx.Rollback()
+				reqLog.Error("failed to set audit actor", err)
+				api.WriteError(w, apperrors.NewInternal(err))
+				return
+			}
+
+			committed := false
+			defer func() {
+				if !committed {
+					_ = tx.Rollback()
+				}
+			}()
+
+			bw := &bufferedWriter{header: make(http.Header)}
+			next.ServeHTTP(bw, r.WithContext(database.WithTx(r.Context(), tx)))
+
+			if bw.statusCode >= http.StatusBadRequest {
+				bw.flush(w)
+				return
+			}
+
+			if err := tx.Commit(); err != nil {
+				reqLog.Error("failed to commit request transaction", err)
+				api.WriteError(w, apperrors.NewInternal(err))
+				return
+			}
+			committed = true
+			bw.flush(w)
+		})
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/taskrunner"
+)
+
+// BackgroundNotifier sends the notifications raised while serving a request in background tasks, so the
+// request neither waits for the preference lookup and the broker confirm nor fails with them. Notify
+// always succeeds, failures are logged by the task.
+type BackgroundNotifier struct {
+	service *NotificationService
+	runner  *taskrunner.Runner
+}
+
+func NewBackgroundNotifier(service *NotificationService, runner *taskrunner.Runner) *BackgroundNotifier {
+	return &BackgroundNotifier{service: service, runner: runner}
+}
+
+func (n *BackgroundNotifier) Notify(ctx context.Context, userId uuid.UUID, notificationType string, data map[string]string) error {
+	n.runner.Go(ctx, "notify", func(ctx context.Context) error {
+		if err := n.service.Notify(ctx, userId, notificationType, data); err != nil {
+			return fmt.Errorf("notify user %s of %s: %w", userId, notificationType, err)
+		}
+		return nil
+	})
+	return nil
+}
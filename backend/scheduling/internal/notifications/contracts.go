@@ -0,0 +1,137 @@
+package notifications
+
+import (
+	"slices"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/formatting"
+)
+
+const (
+	BookingConfirmedNotification  = "BOOKING_CONFIRMED"
+	BookingCancelledNotification  = "BOOKING_CANCELLED"
+	BookingMessageNotification    = "BOOKING_MESSAGE"
+	BookingEscalatedNotification  = "BOOKING_ESCALATED"
+	ExtensionOfferedNotification  = "BOOKING_EXTENSION_OFFERED"
+	BookingExtendedNotification   = "BOOKING_EXTENDED"
+	BookingReassignedNotification = "BOOKING_REASSIGNED"
+	WaitlistOfferedNotification   = "WAITLIST_SPOT_OFFERED"
+	WaitlistPromotedNotification  = "WAITLIST_PROMOTED"
+	PaymentRequestedNotification  = "BOOKING_PAYMENT_REQUESTED"
+)
+
+const (
+	EmailChannel = "email"
+	PushChannel  = "push"
+	SmsChannel   = "sms"
+
+	maxReminderOffsets   = 5
+	maxReminderOffsetMin = 7 * 24 * 60
+)
+
+var SupportedChannels = []string{EmailChannel, PushChannel, SmsChannel}
+
+// swagger:model NotificationPreferenceResponse
+type NotificationPreferenceResponse struct {
+	Channels        []string  `json:"channels"`
+	ReminderOffsets []int     `json:"reminderOffsets"`
+	QuietHoursStart *string   `json:"quietHoursStart"`
+	QuietHoursEnd   *string   `json:"quietHoursEnd"`
+	Timezone        string    `json:"timezone"`
+	Language        string    `json:"language"`
+	TimeFormat      string    `json:"timeFormat"`
+	IsDefault       bool      `json:"isDefault"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// swagger:model NotificationPreferenceRequest
+type NotificationPreferenceRequest struct {
+	Channels        []string `json:"channels"`
+	ReminderOffsets []int    `json:"reminderOffsets"`
+	QuietHoursStart *string  `json:"quietHoursStart"`
+	QuietHoursEnd   *string  `json:"quietHoursEnd"`
+	Timezone        string   `json:"timezone"`
+	Language        string   `json:"language"`
+	// TimeFormat is the clock times are written in for the user, 12h or 24h. Defaults to 24h.
+	TimeFormat string `json:"timeFormat"`
+}
+
+func (n *NotificationPreferenceRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	for _, c := range n.Channels {
+		if !slices.Contains(SupportedChannels, c) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "Channels",
+				Message: "must contain only email, push or sms",
+			})
+			break
+		}
+	}
+
+	if len(n.ReminderOffsets) > maxReminderOffsets {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "ReminderOffsets",
+			Message: "must contain at most 5 offsets",
+		})
+	}
+
+	for _, o := range n.ReminderOffsets {
+		if o <= 0 || o > maxReminderOffsetMin {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "ReminderOffsets",
+				Message: "must be between 1 minute and 7 days",
+			})
+			break
+		}
+	}
+
+	if (n.QuietHoursStart == nil) != (n.QuietHoursEnd == nil) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "QuietHoursStart",
+			Message: "must be set together with QuietHoursEnd",
+		})
+	}
+
+	if n.QuietHoursStart != nil && !isClockTime(*n.QuietHoursStart) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "QuietHoursStart",
+			Message: "must be in HH:MM format",
+		})
+	}
+
+	if n.QuietHoursEnd != nil && !isClockTime(*n.QuietHoursEnd) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "QuietHoursEnd",
+			Message: "must be in HH:MM format",
+		})
+	}
+
+	if _, err := time.LoadLocation(n.Timezone); n.Timezone == "" || err != nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Timezone",
+			Message: "must be a valid IANA time zone",
+		})
+	}
+
+	if len(n.Language) < 2 || len(n.Language) > 5 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Language",
+			Message: "must be a language tag such as en or en-US",
+		})
+	}
+
+	if n.TimeFormat != "" && !formatting.Clock(n.TimeFormat).Valid() {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "TimeFormat",
+			Message: "must be 12h or 24h",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Notification preference request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
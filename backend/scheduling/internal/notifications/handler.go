@@ -0,0 +1,88 @@
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type NotificationHandler struct {
+	service *NotificationService
+}
+
+func NewNotificationHandler(service *NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// GetMyPreferences retrieves notification preferences of the current user.
+// @Summary      Retrieve notification preferences
+// @Description  Retrieves the user's channels, reminder offsets, quiet hours and language, falling back to the service defaults.
+// @Tags         Notification
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  NotificationPreferenceResponse  "Notification preferences"
+// @Router       /api/v1/notifications/preferences [get]
+// @Security 	 BearerAuth
+func (h *NotificationHandler) GetMyPreferences(w http.ResponseWriter, r *http.Request) {
+	preferences, err := h.service.GetMyPreferences(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, preferences)
+}
+
+// SetMyPreferences sets notification preferences of the current user.
+// @Summary      Set notification preferences
+// @Description  Creates or replaces the user's notification preferences. An empty channel list disables notifications.
+// @Tags         Notification
+// @Accept       json
+// @Produce      json
+// @Param        preferences  body      NotificationPreferenceRequest  true  "Notification preferences"
+// @Success      204          "Notification preferences saved successfully"
+// @Failure      400          {object}  error                          "Invalid input"
+// @Router       /api/v1/notifications/preferences [put]
+// @Security 	 BearerAuth
+func (h *NotificationHandler) SetMyPreferences(w http.ResponseWriter, r *http.Request) {
+	var request *NotificationPreferenceRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.SetMyPreferences(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResetMyPreferences resets notification preferences of the current user.
+// @Summary      Reset notification preferences
+// @Description  Removes the user's stored notification preferences so the service defaults apply again.
+// @Tags         Notification
+// @Accept       json
+// @Produce      json
+// @Success      204  "Notification preferences reset successfully"
+// @Router       /api/v1/notifications/preferences [delete]
+// @Security 	 BearerAuth
+func (h *NotificationHandler) ResetMyPreferences(w http.ResponseWriter, r *http.Request) {
+	err := h.service.ResetMyPreferences(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,71 @@
+package notifications
+
+import (
+	"cmp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/formatting"
+)
+
+func MapPreferenceToResponse(p *entities.NotificationPreference, isDefault bool) *NotificationPreferenceResponse {
+	offsets := make([]int, len(p.ReminderOffsets))
+	for i, o := range p.ReminderOffsets {
+		offsets[i] = int(o)
+	}
+
+	channels := []string(p.Channels)
+	if channels == nil {
+		channels = []string{}
+	}
+
+	return &NotificationPreferenceResponse{
+		Channels:        channels,
+		ReminderOffsets: offsets,
+		QuietHoursStart: p.QuietHoursStart,
+		QuietHoursEnd:   p.QuietHoursEnd,
+		Timezone:        p.Timezone,
+		Language:        p.Language,
+		TimeFormat:      p.TimeFormat,
+		IsDefault:       isDefault,
+		UpdatedAt:       p.UpdatedAt,
+	}
+}
+
+func MapRequestToPreference(userId uuid.UUID, r *NotificationPreferenceRequest) *entities.NotificationPreference {
+	return &entities.NotificationPreference{
+		UserId:          userId,
+		Channels:        pq.StringArray(r.Channels),
+		ReminderOffsets: toInt64Array(r.ReminderOffsets),
+		QuietHoursStart: r.QuietHoursStart,
+		QuietHoursEnd:   r.QuietHoursEnd,
+		Timezone:        r.Timezone,
+		Language:        r.Language,
+		TimeFormat:      cmp.Or(r.TimeFormat, string(formatting.Clock24h)),
+		CreatedAt:       time.Now().UTC(),
+		UpdatedAt:       time.Now().UTC(),
+	}
+}
+
+func MapConfigToDefaultPreference(userId uuid.UUID, cfg *config.NotificationConfig) *entities.NotificationPreference {
+	return &entities.NotificationPreference{
+		UserId:          userId,
+		Channels:        pq.StringArray(cfg.DefaultChannels),
+		ReminderOffsets: toInt64Array(cfg.DefaultReminderOffsets),
+		Timezone:        cfg.DefaultTimezone,
+		Language:        cfg.DefaultLanguage,
+		TimeFormat:      cfg.DefaultTimeFormat,
+	}
+}
+
+func toInt64Array(values []int) pq.Int64Array {
+	result := make(pq.Int64Array, len(values))
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+	return result
+}
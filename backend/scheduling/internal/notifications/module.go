@@ -0,0 +1,27 @@
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializeNotificationService(
+	log logger.Logger,
+	db *sqlx.DB,
+	cfg *config.NotificationConfig,
+	publisher *messaging.Publisher,
+) *NotificationService {
+	repo := NewNotificationRepository(db)
+	service := NewNotificationService(log, repo, cfg, publisher)
+	return service
+}
+
+func InitializeNotificationHTTPHandler(service *NotificationService) http.Handler {
+	handler := NewNotificationHandler(service)
+	return Routes(handler)
+}
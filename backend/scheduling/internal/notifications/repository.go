@@ -0,0 +1,49 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type NotificationRepo struct {
+	db *sqlx.DB
+}
+
+func NewNotificationRepository(db *sqlx.DB) *NotificationRepo {
+	return &NotificationRepo{db: db}
+}
+
+// GetPreferences retrieves stored notification preferences of the given users
+func (r *NotificationRepo) GetPreferences(ctx context.Context, userIds []uuid.UUID) ([]*entities.NotificationPreference, error) {
+	const query = `
+		SELECT user_id, channels, reminder_offsets, quiet_hours_start, quiet_hours_end, timezone, language, time_format, created_at, updated_at
+		FROM notification_preference
+		WHERE user_id = ANY($1)
+	`
+	return database.FetchMultiple[entities.NotificationPreference](ctx, r.db, query, pq.Array(userIds))
+}
+
+// UpsertPreference creates or replaces notification preferences of a user
+func (r *NotificationRepo) UpsertPreference(ctx context.Context, preference *entities.NotificationPreference) error {
+	const query = `
+		INSERT INTO notification_preference (user_id, channels, reminder_offsets, quiet_hours_start, quiet_hours_end, timezone, language, time_format, created_at, updated_at)
+		VALUES (:user_id, :channels, :reminder_offsets, :quiet_hours_start, :quiet_hours_end, :timezone, :language, :time_format, :created_at, :updated_at)
+		ON CONFLICT (user_id) DO UPDATE
+		SET channels = EXCLUDED.channels, reminder_offsets = EXCLUDED.reminder_offsets, quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end, timezone = EXCLUDED.timezone, language = EXCLUDED.language,
+			time_format = EXCLUDED.time_format, updated_at = EXCLUDED.updated_at
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, preference)
+}
+
+// DeletePreference removes stored preferences of a user so defaults apply again
+func (r *NotificationRepo) DeletePreference(ctx context.Context, userId uuid.UUID) error {
+	const query = `DELETE FROM notification_preference WHERE user_id = $1`
+	return database.ExecQuery(ctx, r.db, query, userId)
+}
@@ -0,0 +1,18 @@
+package notifications
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes(handler *NotificationHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/preferences", handler.GetMyPreferences)
+	r.Put("/preferences", handler.SetMyPreferences)
+	r.Delete("/preferences", handler.ResetMyPreferences)
+
+	return r
+}
@@ -0,0 +1,64 @@
+package notifications
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+const clockLayout = "15:04"
+
+func isClockTime(value string) bool {
+	_, err := time.Parse(clockLayout, value)
+	return err == nil
+}
+
+// quietWindow returns the quiet hours interval that contains t, if any, in the user's time zone
+func quietWindow(t time.Time, p *entities.NotificationPreference) (time.Time, time.Time, bool) {
+	if p.QuietHoursStart == nil || p.QuietHoursEnd == nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	start, errStart := time.Parse(clockLayout, *p.QuietHoursStart)
+	end, errEnd := time.Parse(clockLayout, *p.QuietHoursEnd)
+	if errStart != nil || errEnd != nil || start.Equal(end) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	local := t.In(loc)
+	// Check the window starting on the previous day as well, for quiet hours spanning midnight
+	for _, dayOffset := range []int{-1, 0} {
+		day := time.Date(local.Year(), local.Month(), local.Day()+dayOffset, 0, 0, 0, 0, loc)
+		windowStart := day.Add(time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute)
+		windowEnd := day.Add(time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute)
+		if !windowEnd.After(windowStart) {
+			windowEnd = windowEnd.AddDate(0, 0, 1)
+		}
+		if !local.Before(windowStart) && local.Before(windowEnd) {
+			return windowStart.UTC(), windowEnd.UTC(), true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// deferPastQuietHours moves a notification falling into quiet hours to the moment they end
+func deferPastQuietHours(t time.Time, p *entities.NotificationPreference) time.Time {
+	if _, end, ok := quietWindow(t, p); ok {
+		return end
+	}
+	return t
+}
+
+// advanceBeforeQuietHours moves a reminder falling into quiet hours to just before they start,
+// so that it still arrives ahead of the session
+func advanceBeforeQuietHours(t time.Time, p *entities.NotificationPreference) time.Time {
+	if start, _, ok := quietWindow(t, p); ok {
+		return start.Add(-time.Minute)
+	}
+	return t
+}
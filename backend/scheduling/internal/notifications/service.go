@@ -0,0 +1,165 @@
+package notifications
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+type NotificationRepository interface {
+	GetPreferences(ctx context.Context, userIds []uuid.UUID) ([]*entities.NotificationPreference, error)
+	UpsertPreference(ctx context.Context, preference *entities.NotificationPreference) error
+	DeletePreference(ctx context.Context, userId uuid.UUID) error
+}
+
+type NotificationService struct {
+	log       logger.Logger
+	repo      NotificationRepository
+	cfg       *config.NotificationConfig
+	publisher *messaging.Publisher
+}
+
+func NewNotificationService(
+	log logger.Logger,
+	repo NotificationRepository,
+	cfg *config.NotificationConfig,
+	publisher *messaging.Publisher,
+) *NotificationService {
+	return &NotificationService{log: log, repo: repo, cfg: cfg, publisher: publisher}
+}
+
+func (s *NotificationService) GetMyPreferences(ctx context.Context) (*NotificationPreferenceResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	preference, isDefault, err := s.getPreference(ctx, userId)
+	if err != nil {
+		log.Error("failed to get notification preferences", err)
+		return nil, err
+	}
+
+	return MapPreferenceToResponse(preference, isDefault), nil
+}
+
+func (s *NotificationService) SetMyPreferences(ctx context.Context, request *NotificationPreferenceRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if err := s.repo.UpsertPreference(ctx, MapRequestToPreference(userId, request)); err != nil {
+		log.Error("failed to save notification preferences", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *NotificationService) ResetMyPreferences(ctx context.Context) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if err := s.repo.DeletePreference(ctx, userId); err != nil {
+		log.Error("failed to reset notification preferences", err)
+		return err
+	}
+
+	return nil
+}
+
+// Notify publishes a notification request for the user's enabled channels and language. Users without
+// channels receive nothing; notifications raised during quiet hours are deferred until they end.
+func (s *NotificationService) Notify(ctx context.Context, userId uuid.UUID, notificationType string, data map[string]string) error {
+	return s.NotifyVia(ctx, userId, notificationType, nil, data)
+}
+
+// NotifyVia publishes a notification request like Notify, but on the given channels instead of the
+// user's enabled ones when any are given. Language and quiet hours still follow the user's preferences.
+func (s *NotificationService) NotifyVia(ctx context.Context, userId uuid.UUID, notificationType string, channels []string, data map[string]string) error {
+	log := logger.FromContext(ctx, s.log)
+
+	preference, _, err := s.getPreference(ctx, userId)
+	if err != nil {
+		log.Error("failed to get notification preferences", err)
+		return err
+	}
+
+	if len(channels) == 0 {
+		channels = preference.Channels
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+
+	deliverAt := deferPastQuietHours(time.Now().UTC(), preference)
+
+	return s.publisher.Publish(
+		ctx,
+		messaging.NotificationKey,
+		messaging.NewNotificationRequestedEvent(
+			userId.String(),
+			notificationType,
+			channels,
+			preference.Language,
+			deliverAt.Format(time.RFC3339),
+			data,
+		),
+	)
+}
+
+// GetReminderTimes returns when reminders of a session starting at sessionStart should be sent to the user,
+// based on the configured offsets and shifted ahead of quiet hours. Times already in the past are skipped.
+func (s *NotificationService) GetReminderTimes(ctx context.Context, userId uuid.UUID, sessionStart time.Time) ([]time.Time, error) {
+	preference, _, err := s.getPreference(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(preference.Channels) == 0 {
+		return []time.Time{}, nil
+	}
+
+	now := time.Now().UTC()
+	times := []time.Time{}
+	for _, offset := range preference.ReminderOffsets {
+		at := advanceBeforeQuietHours(sessionStart.Add(-time.Duration(offset)*time.Minute), preference)
+		if at.After(now) {
+			times = append(times, at)
+		}
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times, nil
+}
+
+// getPreference returns the stored preferences of a user or the configured defaults
+func (s *NotificationService) getPreference(ctx context.Context, userId uuid.UUID) (*entities.NotificationPreference, bool, error) {
+	preferences, err := s.repo.GetPreferences(ctx, []uuid.UUID{userId})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(preferences) == 0 {
+		return MapConfigToDefaultPreference(userId, s.cfg), true, nil
+	}
+	return preferences[0], false, nil
+}
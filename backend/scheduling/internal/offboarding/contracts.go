@@ -0,0 +1,70 @@
+package offboarding
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// Wind-down modes of an offboarding
+const (
+	ModeComplete = "COMPLETE"
+	ModeReassign = "REASSIGN"
+)
+
+// swagger:model OffboardingRequest
+type OffboardingRequest struct {
+	Mode       string     `json:"mode"`
+	ReassignTo *uuid.UUID `json:"reassignTo"`
+}
+
+// swagger:model OffboardingStatusResponse
+type OffboardingStatusResponse struct {
+	EducatorId         uuid.UUID  `json:"educatorId"`
+	Mode               string     `json:"mode"`
+	ReassignTo         *uuid.UUID `json:"reassignTo"`
+	Status             string     `json:"status"`
+	InitiatedBy        uuid.UUID  `json:"initiatedBy"`
+	ReassignDone       bool       `json:"reassignDone"`
+	BookingsReassigned int        `json:"bookingsReassigned"`
+	BookingsKept       int        `json:"bookingsKept"`
+	RemainingBookings  int        `json:"remainingBookings"`
+	EventsReleased     int        `json:"eventsReleased"`
+	PeriodsReleased    int        `json:"periodsReleased"`
+	StartedAt          time.Time  `json:"startedAt"`
+	UpdatedAt          time.Time  `json:"updatedAt"`
+	ArchivedAt         *time.Time `json:"archivedAt"`
+}
+
+func (o *OffboardingRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	switch o.Mode {
+	case ModeComplete:
+		if o.ReassignTo != nil {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "ReassignTo",
+				Message: "must be empty for COMPLETE mode",
+			})
+		}
+	case ModeReassign:
+		if o.ReassignTo == nil || *o.ReassignTo == uuid.Nil {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "ReassignTo",
+				Message: "must not be empty for REASSIGN mode",
+			})
+		}
+	default:
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Mode",
+			Message: "must be one of COMPLETE, REASSIGN",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Offboarding request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
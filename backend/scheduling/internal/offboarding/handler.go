@@ -0,0 +1,85 @@
+package offboarding
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type OffboardingHandler struct {
+	service *OffboardingService
+}
+
+func NewOffboardingHandler(service *OffboardingService) *OffboardingHandler {
+	return &OffboardingHandler{service: service}
+}
+
+// StartOffboarding starts the offboarding of an educator.
+// @Summary      Start educator offboarding
+// @Description  Stops new bookings for the educator and winds down the existing ones: COMPLETE lets them finish, REASSIGN moves upcoming individual bookings to 'reassignTo' where that educator is free. The schedule is archived once no bookings remain.
+// @Tags         Offboarding
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string                     true  "Educator ID (UUID)"
+// @Param        request     body      OffboardingRequest         true  "Offboarding request"
+// @Success      201         {object}  OffboardingStatusResponse  "Offboarding started"
+// @Failure      400         {object}  error                      "Invalid input"
+// @Failure      409         {object}  error                      "Offboarding already started"
+// @Router       /api/v1/offboardings/{educatorId} [post]
+// @Security 	 BearerAuth
+func (h *OffboardingHandler) StartOffboarding(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *OffboardingRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	status, err := h.service.StartOffboarding(r.Context(), educatorId, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, status)
+}
+
+// GetOffboardingStatus retrieves the offboarding progress of an educator.
+// @Summary      Retrieve educator offboarding status
+// @Description  Retrieves the wind-down progress of an educator offboarding. Available to admins and the educator.
+// @Tags         Offboarding
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string                     true  "Educator ID (UUID)"
+// @Success      200         {object}  OffboardingStatusResponse  "Offboarding status"
+// @Failure      404         {object}  error                      "Offboarding not found"
+// @Router       /api/v1/offboardings/{educatorId} [get]
+// @Security 	 BearerAuth
+func (h *OffboardingHandler) GetOffboardingStatus(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	status, err := h.service.GetOffboardingStatus(r.Context(), educatorId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, status)
+}
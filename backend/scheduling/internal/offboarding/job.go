@@ -0,0 +1,192 @@
+package offboarding
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+)
+
+type OffboardingJobRepository interface {
+	GetActiveOffboardings(ctx context.Context, limit int) ([]*entities.TeacherOffboarding, error)
+	GetReassignableBookings(ctx context.Context, educatorId uuid.UUID, afterId int64, now time.Time, limit int) ([]*entities.Booking, error)
+	ReassignBooking(ctx context.Context, booking *entities.Booking, targetId uuid.UUID, now time.Time) (bool, error)
+	RecordReassignProgress(ctx context.Context, educatorId uuid.UUID, lastBookingId int64, reassigned int, kept int, done bool, now time.Time) error
+	TouchOffboarding(ctx context.Context, educatorId uuid.UUID, now time.Time) error
+	ArchiveSchedule(ctx context.Context, educatorId uuid.UUID, now time.Time) (*ArchiveResult, error)
+}
+
+// Notifier sends user notifications
+type Notifier interface {
+	Notify(ctx context.Context, userId uuid.UUID, notificationType string, data map[string]string) error
+}
+
+// OffboardingJob periodically winds down the bookings of offboarded educators and archives their schedules
+type OffboardingJob struct {
+	log       logger.Logger
+	repo      OffboardingJobRepository
+	publisher *messaging.Publisher
+	notifier  Notifier
+	cfg       *config.OffboardingConfig
+}
+
+func NewOffboardingJob(
+	log logger.Logger,
+	repo OffboardingJobRepository,
+	publisher *messaging.Publisher,
+	notifier Notifier,
+	cfg *config.OffboardingConfig,
+) *OffboardingJob {
+	return &OffboardingJob{log: log, repo: repo, publisher: publisher, notifier: notifier, cfg: cfg}
+}
+
+// Run processes active offboardings on every interval until the context is cancelled
+func (j *OffboardingJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.IntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.ProcessOffboardings(ctx); err != nil {
+				j.log.Errorf("Failed to process offboardings: %v", err)
+			}
+		}
+	}
+}
+
+// ProcessOffboardings advances every active offboarding by one step. In REASSIGN mode one batch of upcoming
+// individual bookings is moved to the target educator; bookings the target cannot take stay with the
+// offboarded educator and complete as in COMPLETE mode. Once no bookings remain, the schedule is archived.
+func (j *OffboardingJob) ProcessOffboardings(ctx context.Context) error {
+	log := logger.FromContext(ctx, j.log)
+
+	offboardings, err := j.repo.GetActiveOffboardings(ctx, j.cfg.BatchSize)
+	if err != nil {
+		log.Error("failed to get active offboardings", err)
+		return err
+	}
+
+	for _, o := range offboardings {
+		now := time.Now().UTC()
+
+		if o.Mode == ModeReassign && !o.ReassignDone {
+			if err := j.reassignBookings(ctx, o, now); err != nil {
+				log.Error("failed to reassign bookings", err)
+				return err
+			}
+			continue
+		}
+
+		result, err := j.repo.ArchiveSchedule(ctx, o.EducatorId, now)
+		if err != nil {
+			log.Error("failed to archive schedule", err)
+			return err
+		}
+		if result == nil {
+			if err := j.repo.TouchOffboarding(ctx, o.EducatorId, now); err != nil {
+				log.Error("failed to update offboarding", err)
+				return err
+			}
+			continue
+		}
+
+		err = j.publisher.Publish(
+			ctx,
+			messaging.OffboardingKey,
+			messaging.NewEducatorOffboardingEvent(
+				messaging.EducatorArchived,
+				o.EducatorId.String(),
+				o.Mode,
+				reassignTo(o),
+				o.BookingsReassigned,
+				o.BookingsKept,
+			),
+		)
+		if err != nil {
+			log.Errorf("Failed to publish educator archived event: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// reassignBookings moves one batch of upcoming bookings to the target educator and records the progress
+func (j *OffboardingJob) reassignBookings(ctx context.Context, o *entities.TeacherOffboarding, now time.Time) error {
+	bookings, err := j.repo.GetReassignableBookings(ctx, o.EducatorId, o.LastBookingId, now, j.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	lastId := o.LastBookingId
+	reassigned, kept := 0, 0
+	for _, b := range bookings {
+		moved, err := j.repo.ReassignBooking(ctx, b, *o.ReassignTo, now)
+		if err != nil {
+			return err
+		}
+
+		lastId = b.Id
+		if !moved {
+			kept++
+			continue
+		}
+
+		reassigned++
+		j.publishReassigned(ctx, b, o.EducatorId)
+		j.notifyReassigned(ctx, b)
+	}
+
+	done := len(bookings) < j.cfg.BatchSize
+	return j.repo.RecordReassignProgress(ctx, o.EducatorId, lastId, reassigned, kept, done, now)
+}
+
+// publishReassigned publishes the reassignment event; failures are logged and do not stop the job
+func (j *OffboardingJob) publishReassigned(ctx context.Context, booking *entities.Booking, fromEducatorId uuid.UUID) {
+	log := logger.FromContext(ctx, j.log)
+
+	err := j.publisher.Publish(
+		ctx,
+		messaging.BookingReassignKey,
+		messaging.NewBookingReassignedEvent(
+			booking.Id,
+			booking.StudentId.String(),
+			fromEducatorId.String(),
+			booking.EducatorId.String(),
+			booking.ProductId,
+			booking.EnrollmentId,
+			booking.StartTime.Format(time.RFC3339),
+			booking.EndTime.Format(time.RFC3339),
+		),
+	)
+	if err != nil {
+		log.Errorf("Failed to publish booking reassigned event for booking %d: %v", booking.Id, err)
+	}
+}
+
+// notifyReassigned notifies the student and the new educator; failures are logged and do not stop the job
+func (j *OffboardingJob) notifyReassigned(ctx context.Context, booking *entities.Booking) {
+	log := logger.FromContext(ctx, j.log)
+
+	data := map[string]string{
+		"bookingId":  strconv.FormatInt(booking.Id, 10),
+		"title":      booking.Title,
+		"startTime":  booking.StartTime.UTC().Format(time.RFC3339),
+		"educatorId": booking.EducatorId.String(),
+	}
+
+	for _, userId := range []uuid.UUID{booking.StudentId, booking.EducatorId} {
+		if err := j.notifier.Notify(ctx, userId, notifications.BookingReassignedNotification, data); err != nil {
+			log.Errorf("Failed to notify user about reassigned booking %d: %v", booking.Id, err)
+		}
+	}
+}
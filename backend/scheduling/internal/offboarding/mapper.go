@@ -0,0 +1,49 @@
+package offboarding
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToOffboarding(educatorId uuid.UUID, initiatedBy uuid.UUID, request *OffboardingRequest) *entities.TeacherOffboarding {
+	now := time.Now().UTC()
+	return &entities.TeacherOffboarding{
+		EducatorId:  educatorId,
+		Mode:        request.Mode,
+		ReassignTo:  request.ReassignTo,
+		Status:      entities.OffboardingWindingDown,
+		InitiatedBy: initiatedBy,
+		StartedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func MapOffboardingToResponse(o *entities.TeacherOffboarding, remainingBookings int) *OffboardingStatusResponse {
+	return &OffboardingStatusResponse{
+		EducatorId:         o.EducatorId,
+		Mode:               o.Mode,
+		ReassignTo:         o.ReassignTo,
+		Status:             o.Status.String(),
+		InitiatedBy:        o.InitiatedBy,
+		ReassignDone:       o.ReassignDone,
+		BookingsReassigned: o.BookingsReassigned,
+		BookingsKept:       o.BookingsKept,
+		RemainingBookings:  remainingBookings,
+		EventsReleased:     o.EventsReleased,
+		PeriodsReleased:    o.PeriodsReleased,
+		StartedAt:          o.StartedAt,
+		UpdatedAt:          o.UpdatedAt,
+		ArchivedAt:         o.ArchivedAt,
+	}
+}
+
+func reassignTo(o *entities.TeacherOffboarding) *string {
+	if o.ReassignTo == nil {
+		return nil
+	}
+	id := o.ReassignTo.String()
+	return &id
+}
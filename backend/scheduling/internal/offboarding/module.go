@@ -0,0 +1,33 @@
+package offboarding
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializeOffboardingService(log logger.Logger, db *sqlx.DB, publisher *messaging.Publisher) *OffboardingService {
+	repo := NewOffboardingRepository(db)
+	service := NewOffboardingService(log, repo, publisher)
+	return service
+}
+
+func InitializeOffboardingJob(
+	log logger.Logger,
+	db *sqlx.DB,
+	cfg *config.OffboardingConfig,
+	publisher *messaging.Publisher,
+	notifier Notifier,
+) *OffboardingJob {
+	repo := NewOffboardingRepository(db)
+	return NewOffboardingJob(log, repo, publisher, notifier, cfg)
+}
+
+func InitializeOffboardingHTTPHandler(service *OffboardingService) http.Handler {
+	handler := NewOffboardingHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,269 @@
+package offboarding
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+const offboardingColumns = `educator_id, mode, reassign_to, status, initiated_by, last_booking_id, reassign_done, bookings_reassigned, bookings_kept, events_released, periods_released, started_at, updated_at, archived_at`
+
+const bookingColumns = `id, educator_id, student_id, product_id, scheduled_event_id, session_type_id, enrollment_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at`
+
+// ArchiveResult holds the schedule entries released when an educator is archived
+type ArchiveResult struct {
+	EventsReleased  int
+	PeriodsReleased int
+}
+
+type OffboardingRepo struct {
+	db *sqlx.DB
+}
+
+func NewOffboardingRepository(db *sqlx.DB) *OffboardingRepo {
+	return &OffboardingRepo{db: db}
+}
+
+// GetOffboarding retrieves the offboarding of an educator
+func (r *OffboardingRepo) GetOffboarding(ctx context.Context, educatorId uuid.UUID) (*entities.TeacherOffboarding, error) {
+	const query = `SELECT ` + offboardingColumns + ` FROM teacher_offboarding WHERE educator_id = $1`
+	return database.FetchSingle[entities.TeacherOffboarding](ctx, r.db, query, educatorId)
+}
+
+// IsEducatorOffboarding checks whether an offboarding has been started for the educator
+func (r *OffboardingRepo) IsEducatorOffboarding(ctx context.Context, educatorId uuid.UUID) (bool, error) {
+	const query = `SELECT COUNT(*) > 0 FROM teacher_offboarding WHERE educator_id = $1`
+	return database.CheckExists(ctx, r.db, query, educatorId)
+}
+
+// AddOffboarding stores a new offboarding; false is returned when the educator already has one
+func (r *OffboardingRepo) AddOffboarding(ctx context.Context, offboarding *entities.TeacherOffboarding) (bool, error) {
+	const query = `
+		INSERT INTO teacher_offboarding (educator_id, mode, reassign_to, status, initiated_by, started_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (educator_id) DO NOTHING
+	`
+	result, err := database.Conn(ctx, r.db).ExecContext(
+		ctx,
+		query,
+		offboarding.EducatorId,
+		offboarding.Mode,
+		offboarding.ReassignTo,
+		offboarding.Status,
+		offboarding.InitiatedBy,
+		offboarding.StartedAt,
+		offboarding.UpdatedAt,
+	)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
+
+// CountRemainingBookings counts non-cancelled bookings of an educator that have not ended yet
+func (r *OffboardingRepo) CountRemainingBookings(ctx context.Context, educatorId uuid.UUID, now time.Time) (int, error) {
+	const query = `SELECT COUNT(*) FROM booking WHERE educator_id = $1 AND status <> $2 AND end_time > $3`
+	var count int
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &count, query, educatorId, entities.Cancelled, now); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return count, nil
+}
+
+// GetActiveOffboardings retrieves offboardings that are still winding down, least recently processed first
+func (r *OffboardingRepo) GetActiveOffboardings(ctx context.Context, limit int) ([]*entities.TeacherOffboarding, error) {
+	const query = `
+		SELECT ` + offboardingColumns + `
+		FROM teacher_offboarding
+		WHERE status = $1
+		ORDER BY updated_at
+		LIMIT $2
+	`
+	return database.FetchMultiple[entities.TeacherOffboarding](ctx, r.db, query, entities.OffboardingWindingDown, limit)
+}
+
+// GetReassignableBookings retrieves upcoming individual bookings of an educator after the given booking Id
+func (r *OffboardingRepo) GetReassignableBookings(ctx context.Context, educatorId uuid.UUID, afterId int64, now time.Time, limit int) ([]*entities.Booking, error) {
+	const query = `
+		SELECT ` + bookingColumns + `
+		FROM booking
+		WHERE educator_id = $1 AND id > $2 AND status <> $3 AND scheduled_event_id IS NULL AND start_time > $4
+		ORDER BY id
+		LIMIT $5
+	`
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, educatorId, afterId, entities.Cancelled, now, limit)
+}
+
+// ReassignBooking moves a booking to a working period of the target educator that covers it and is free
+// of the target's bookings and events. False is returned when no such period exists or the booking no
+// longer belongs to the educator.
+func (r *OffboardingRepo) ReassignBooking(ctx context.Context, booking *entities.Booking, targetId uuid.UUID, now time.Time) (bool, error) {
+	const lockTargetQuery = `SELECT id FROM working_period WHERE user_id = $1 AND deleted_at IS NULL ORDER BY id FOR UPDATE`
+	const periodQuery = `
+		SELECT wp.id
+		FROM working_period wp
+		WHERE wp.user_id = $1 AND wp.start_time <= $2 AND wp.end_time >= $3 AND wp.deleted_at IS NULL
+		AND NOT EXISTS (
+			SELECT 1 FROM booking b
+			WHERE b.educator_id = $1 AND b.status <> $4 AND b.start_time < $3 AND b.end_time > $2
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM scheduled_event se
+			WHERE se.user_id = $1 AND se.start_time < $3 AND se.end_time > $2 AND se.deleted_at IS NULL
+		)
+		ORDER BY wp.start_time
+		LIMIT 1
+	`
+	const reassignQuery = `
+		UPDATE booking SET educator_id = $3, working_period_id = $4, version = version + 1, updated_at = $5
+		WHERE id = $1 AND educator_id = $2 AND status <> $6
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	var locked []int64
+	if err := tx.SelectContext(ctx, &locked, lockTargetQuery, targetId); err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	var periodId int64
+	err = tx.GetContext(ctx, &periodId, periodQuery, targetId, booking.StartTime, booking.EndTime, entities.Cancelled)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, apperrors.NewInternal(err)
+	}
+
+	result, err := tx.ExecContext(ctx, reassignQuery, booking.Id, booking.EducatorId, targetId, periodId, now, entities.Cancelled)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	booking.EducatorId = targetId
+	booking.WorkingPeriodId = periodId
+	booking.UpdatedAt = now
+	return true, nil
+}
+
+// RecordReassignProgress advances the reassignment cursor of an offboarding and adds to its counters
+func (r *OffboardingRepo) RecordReassignProgress(ctx context.Context, educatorId uuid.UUID, lastBookingId int64, reassigned int, kept int, done bool, now time.Time) error {
+	const query = `
+		UPDATE teacher_offboarding
+		SET last_booking_id = GREATEST(last_booking_id, $2), bookings_reassigned = bookings_reassigned + $3,
+			bookings_kept = bookings_kept + $4, reassign_done = $5, updated_at = $6
+		WHERE educator_id = $1
+	`
+	return database.ExecQuery(ctx, r.db, query, educatorId, lastBookingId, reassigned, kept, done, now)
+}
+
+// TouchOffboarding marks an offboarding as processed so the job moves on to the next ones
+func (r *OffboardingRepo) TouchOffboarding(ctx context.Context, educatorId uuid.UUID, now time.Time) error {
+	const query = `UPDATE teacher_offboarding SET updated_at = $2 WHERE educator_id = $1`
+	return database.ExecQuery(ctx, r.db, query, educatorId, now)
+}
+
+// ArchiveSchedule releases the future schedule of an educator without bookings left and marks the
+// offboarding archived. Nil is returned when the offboarding is already archived or bookings remain.
+func (r *OffboardingRepo) ArchiveSchedule(ctx context.Context, educatorId uuid.UUID, now time.Time) (*ArchiveResult, error) {
+	const lockQuery = `SELECT status FROM teacher_offboarding WHERE educator_id = $1 FOR UPDATE`
+	const remainingQuery = `SELECT COUNT(*) FROM booking WHERE educator_id = $1 AND status <> $2 AND end_time > $3`
+	deleteEventsQuery := database.WithTombstones(database.TombstoneScheduledEvent, `
+		UPDATE scheduled_event se SET deleted_at = current_timestamp
+		WHERE se.user_id = $1 AND se.start_time > $2 AND se.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM booking b WHERE b.scheduled_event_id = se.id)
+	`)
+	deletePeriodsQuery := database.WithTombstones(database.TombstoneWorkingPeriod, `
+		UPDATE working_period wp SET deleted_at = current_timestamp
+		WHERE wp.user_id = $1 AND wp.start_time > $2 AND wp.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM booking b WHERE b.working_period_id = wp.id)
+		AND NOT EXISTS (SELECT 1 FROM scheduled_event se WHERE se.working_period_id = wp.id AND se.deleted_at IS NULL)
+	`)
+	const archiveQuery = `
+		UPDATE teacher_offboarding
+		SET status = $2, events_released = $3, periods_released = $4, updated_at = $5, archived_at = $5
+		WHERE educator_id = $1
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	var status entities.OffboardingStatus
+	if err := tx.GetContext(ctx, &status, lockQuery, educatorId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	if status != entities.OffboardingWindingDown {
+		return nil, nil
+	}
+
+	var remaining int
+	if err := tx.GetContext(ctx, &remaining, remainingQuery, educatorId, entities.Cancelled, now); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if remaining > 0 {
+		return nil, nil
+	}
+
+	events, err := tx.ExecContext(ctx, deleteEventsQuery, educatorId, now)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	eventsReleased, err := events.RowsAffected()
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	periods, err := tx.ExecContext(ctx, deletePeriodsQuery, educatorId, now)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	periodsReleased, err := periods.RowsAffected()
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, archiveQuery, educatorId, entities.OffboardingArchived, eventsReleased, periodsReleased, now); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	return &ArchiveResult{EventsReleased: int(eventsReleased), PeriodsReleased: int(periodsReleased)}, nil
+}
@@ -0,0 +1,20 @@
+package offboarding
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *OffboardingHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/{educatorId}", handler.GetOffboardingStatus)
+	r.With(middleware.RequireRole(auth.AdminRole)).Post("/{educatorId}", handler.StartOffboarding)
+
+	return r
+}
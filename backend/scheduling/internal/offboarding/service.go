@@ -0,0 +1,107 @@
+package offboarding
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+type OffboardingRepository interface {
+	GetOffboarding(ctx context.Context, educatorId uuid.UUID) (*entities.TeacherOffboarding, error)
+	IsEducatorOffboarding(ctx context.Context, educatorId uuid.UUID) (bool, error)
+	AddOffboarding(ctx context.Context, offboarding *entities.TeacherOffboarding) (bool, error)
+	CountRemainingBookings(ctx context.Context, educatorId uuid.UUID, now time.Time) (int, error)
+}
+
+type OffboardingService struct {
+	log       logger.Logger
+	repo      OffboardingRepository
+	publisher *messaging.Publisher
+}
+
+func NewOffboardingService(log logger.Logger, repo OffboardingRepository, publisher *messaging.Publisher) *OffboardingService {
+	return &OffboardingService{log: log, repo: repo, publisher: publisher}
+}
+
+// StartOffboarding stops new bookings for an educator and hands the wind-down of the existing ones to the
+// offboarding job. An educator can only be offboarded once.
+func (s *OffboardingService) StartOffboarding(ctx context.Context, educatorId uuid.UUID, request *OffboardingRequest) (*OffboardingStatusResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if request.ReassignTo != nil {
+		if *request.ReassignTo == educatorId {
+			return nil, apperrors.NewUnprocessedEntity("Bookings cannot be reassigned to the offboarded educator", apperrors.ErrEducatorOffboarding)
+		}
+
+		offboarding, err := s.repo.IsEducatorOffboarding(ctx, *request.ReassignTo)
+		if err != nil {
+			log.Error("failed to check target educator offboarding", err)
+			return nil, err
+		}
+		if offboarding {
+			return nil, apperrors.NewUnprocessedEntity("Target educator is being offboarded", apperrors.ErrEducatorOffboarding)
+		}
+	}
+
+	offboarding := MapRequestToOffboarding(educatorId, userId, request)
+	added, err := s.repo.AddOffboarding(ctx, offboarding)
+	if err != nil {
+		log.Error("failed to add offboarding", err)
+		return nil, err
+	}
+	if !added {
+		return nil, apperrors.NewConflict("Educator offboarding already started", apperrors.ErrEducatorOffboarding)
+	}
+
+	err = s.publisher.Publish(
+		ctx,
+		messaging.OffboardingKey,
+		messaging.NewEducatorOffboardingEvent(messaging.OffboardingStarted, educatorId.String(), offboarding.Mode, reassignTo(offboarding), 0, 0),
+	)
+	if err != nil {
+		log.Errorf("Failed to publish offboarding started event: %v", err)
+	}
+
+	return s.GetOffboardingStatus(ctx, educatorId)
+}
+
+// GetOffboardingStatus retrieves the progress of an educator offboarding. It is visible to admins and
+// the offboarded educator.
+func (s *OffboardingService) GetOffboardingStatus(ctx context.Context, educatorId uuid.UUID) (*OffboardingStatusResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if userId != educatorId && !auth.HasRole(ctx, auth.AdminRole) {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	offboarding, err := s.repo.GetOffboarding(ctx, educatorId)
+	if err != nil {
+		log.Error("failed to get offboarding", err)
+		return nil, err
+	}
+
+	remaining, err := s.repo.CountRemainingBookings(ctx, educatorId, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to count remaining bookings", err)
+		return nil, err
+	}
+
+	return MapOffboardingToResponse(offboarding, remaining), nil
+}
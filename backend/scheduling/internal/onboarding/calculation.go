@@ -0,0 +1,88 @@
+package onboarding
+
+import (
+	"slices"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+const (
+	shortSessionMinutes     = 30
+	shortSessionBuffer      = 5
+	longSessionBuffer       = 10
+	minNoticeHours          = 24
+	cancellationNoticeHours = 24
+)
+
+type period struct {
+	start time.Duration
+	end   time.Duration
+}
+
+// dailyPeriods splits the working hours around the breaks and drops the pieces too short for the
+// shortest session. The request is expected to be validated.
+func dailyPeriods(request *AvailabilityWizardRequest) []period {
+	dayStart, _ := timeutils.ParseClock(request.DayStart)
+	dayEnd, _ := timeutils.ParseClock(request.DayEnd)
+
+	breaks := make([]period, 0, len(request.Breaks))
+	for _, b := range request.Breaks {
+		start, _ := timeutils.ParseClock(b.Start)
+		end, _ := timeutils.ParseClock(b.End)
+		breaks = append(breaks, period{start: start, end: end})
+	}
+	slices.SortFunc(breaks, func(a, b period) int { return int(a.start - b.start) })
+
+	minLength := time.Duration(slices.Min(request.SessionLengths)) * time.Minute
+
+	var periods []period
+	cursor := dayStart
+	for _, b := range breaks {
+		if b.start > cursor && b.start-cursor >= minLength {
+			periods = append(periods, period{start: cursor, end: b.start})
+		}
+		cursor = max(cursor, b.end)
+	}
+	if dayEnd > cursor && dayEnd-cursor >= minLength {
+		periods = append(periods, period{start: cursor, end: dayEnd})
+	}
+	return periods
+}
+
+// generateWorkingPeriods lays the daily pattern over every selected weekday in the horizon,
+// converting the teacher's wall clock times to UTC. Periods already started are left out.
+func generateWorkingPeriods(request *AvailabilityWizardRequest, loc *time.Location, firstDay time.Time, weeks int, now time.Time) []*WorkingPeriodResponse {
+	daily := dailyPeriods(request)
+
+	var result []*WorkingPeriodResponse
+	for i := range weeks * 7 {
+		day := time.Date(firstDay.Year(), firstDay.Month(), firstDay.Day()+i, 0, 0, 0, 0, loc)
+		if !slices.Contains(request.Days, int(day.Weekday())) {
+			continue
+		}
+		for _, p := range daily {
+			start := wallClock(day, p.start, loc)
+			if start.Before(now) {
+				continue
+			}
+			result = append(result, &WorkingPeriodResponse{StartTime: start, EndTime: wallClock(day, p.end, loc)})
+		}
+	}
+	return result
+}
+
+// wallClock resolves an offset from midnight on a local day, so that DST transitions keep the
+// configured hours instead of shifting them
+func wallClock(day time.Time, offset time.Duration, loc *time.Location) time.Time {
+	minutes := int(offset / time.Minute)
+	return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, loc).UTC()
+}
+
+// defaultBufferMinutes picks a shorter gap between sessions for teachers offering short sessions
+func defaultBufferMinutes(sessionLengths []int) int {
+	if slices.Min(sessionLengths) <= shortSessionMinutes {
+		return shortSessionBuffer
+	}
+	return longSessionBuffer
+}
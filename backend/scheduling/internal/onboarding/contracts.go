@@ -0,0 +1,179 @@
+package onboarding
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+const (
+	dateLayout       = "2006-01-02"
+	defaultWeeks     = 4
+	maxWeeks         = 12
+	minSessionLength = 15
+	maxSessionLength = 480
+)
+
+// swagger:model AvailabilityWizardRequest
+type AvailabilityWizardRequest struct {
+	Timezone       string          `json:"timezone"`
+	Days           []int           `json:"days"`
+	DayStart       string          `json:"dayStart"`
+	DayEnd         string          `json:"dayEnd"`
+	SessionLengths []int           `json:"sessionLengths"`
+	Breaks         []*BreakRequest `json:"breaks"`
+	StartDate      *string         `json:"startDate"`
+	Weeks          int             `json:"weeks"`
+}
+
+// swagger:model BreakRequest
+type BreakRequest struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// swagger:model AvailabilityWizardResponse
+type AvailabilityWizardResponse struct {
+	WorkingPeriods []*WorkingPeriodResponse  `json:"workingPeriods"`
+	Skipped        int                       `json:"skipped"`
+	Policy         *SchedulingPolicyResponse `json:"policy"`
+}
+
+// swagger:model OnboardingWorkingPeriodResponse
+type WorkingPeriodResponse struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// swagger:model SchedulingPolicyResponse
+type SchedulingPolicyResponse struct {
+	EducatorId              uuid.UUID `json:"educatorId"`
+	Timezone                string    `json:"timezone"`
+	SessionLengths          []int     `json:"sessionLengths"`
+	BufferMinutes           int       `json:"bufferMinutes"`
+	MinNoticeHours          int       `json:"minNoticeHours"`
+	BookingHorizonDays      int       `json:"bookingHorizonDays"`
+	CancellationNoticeHours int       `json:"cancellationNoticeHours"`
+}
+
+func (a *AvailabilityWizardRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if _, err := time.LoadLocation(a.Timezone); a.Timezone == "" || err != nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Timezone",
+			Message: "must be a valid IANA time zone",
+		})
+	}
+
+	if len(a.Days) == 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Days",
+			Message: "must contain at least one day",
+		})
+	}
+
+	for _, d := range a.Days {
+		if d < int(time.Sunday) || d > int(time.Saturday) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "Days",
+				Message: "must contain days between 0 (Sunday) and 6 (Saturday)",
+			})
+			break
+		}
+	}
+
+	dayStart, errStart := timeutils.ParseClock(a.DayStart)
+	if errStart != nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "DayStart",
+			Message: "must be in HH:MM format",
+		})
+	}
+
+	dayEnd, errEnd := timeutils.ParseClock(a.DayEnd)
+	if errEnd != nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "DayEnd",
+			Message: "must be in HH:MM format",
+		})
+	}
+
+	hoursValid := errStart == nil && errEnd == nil
+	if hoursValid && dayStart >= dayEnd {
+		hoursValid = false
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "DayStart",
+			Message: "must be before DayEnd",
+		})
+	}
+
+	if len(a.SessionLengths) == 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "SessionLengths",
+			Message: "must contain at least one session length",
+		})
+	}
+
+	for _, l := range a.SessionLengths {
+		if l < minSessionLength || l > maxSessionLength {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "SessionLengths",
+				Message: "must be between 15 and 480 minutes",
+			})
+			break
+		}
+	}
+
+	if hoursValid && len(a.SessionLengths) > 0 && time.Duration(slices.Min(a.SessionLengths))*time.Minute > dayEnd-dayStart {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "SessionLengths",
+			Message: "must fit within the working hours",
+		})
+	}
+
+	for _, b := range a.Breaks {
+		start, errStart := timeutils.ParseClock(b.Start)
+		end, errEnd := timeutils.ParseClock(b.End)
+		if errStart != nil || errEnd != nil {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "Breaks",
+				Message: "must have start and end in HH:MM format",
+			})
+			break
+		}
+		if start >= end || (hoursValid && (start < dayStart || end > dayEnd)) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "Breaks",
+				Message: "must start before they end and lie within the working hours",
+			})
+			break
+		}
+	}
+
+	if a.StartDate != nil {
+		if _, err := time.Parse(dateLayout, *a.StartDate); err != nil {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "StartDate",
+				Message: "must be in YYYY-MM-DD format",
+			})
+		}
+	}
+
+	if a.Weeks < 0 || a.Weeks > maxWeeks {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Weeks",
+			Message: "must be between 1 and 12",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Availability wizard request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
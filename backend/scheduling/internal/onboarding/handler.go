@@ -0,0 +1,103 @@
+package onboarding
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type OnboardingHandler struct {
+	service *OnboardingService
+}
+
+func NewOnboardingHandler(service *OnboardingService) *OnboardingHandler {
+	return &OnboardingHandler{service: service}
+}
+
+// PreviewAvailability previews the schedule generated from the teacher's preferences.
+// @Summary      Preview onboarding availability
+// @Description  Computes the working periods and default policies the wizard would create, without saving them.
+// @Tags         Onboarding
+// @Accept       json
+// @Produce      json
+// @Param        preferences  body      AvailabilityWizardRequest   true  "Availability preferences"
+// @Success      200          {object}  AvailabilityWizardResponse  "Generated schedule"
+// @Failure      400          {object}  error                       "Invalid input"
+// @Router       /api/v1/onboarding/availability/preview [post]
+// @Security 	 BearerAuth
+func (h *OnboardingHandler) PreviewAvailability(w http.ResponseWriter, r *http.Request) {
+	var request *AvailabilityWizardRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	response, err := h.service.PreviewAvailability(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, response)
+}
+
+// ApplyAvailability creates the initial schedule from the teacher's preferences.
+// @Summary      Apply onboarding availability
+// @Description  Generates recurring working periods for the selected days and hours, split around breaks, and saves default scheduling policies. Periods overlapping existing working periods are skipped.
+// @Tags         Onboarding
+// @Accept       json
+// @Produce      json
+// @Param        preferences  body      AvailabilityWizardRequest   true  "Availability preferences"
+// @Success      200          {object}  AvailabilityWizardResponse  "Created schedule"
+// @Failure      400          {object}  error                       "Invalid input"
+// @Router       /api/v1/onboarding/availability [post]
+// @Security 	 BearerAuth
+func (h *OnboardingHandler) ApplyAvailability(w http.ResponseWriter, r *http.Request) {
+	var request *AvailabilityWizardRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	response, err := h.service.ApplyAvailability(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, response)
+}
+
+// GetMySchedulingPolicy retrieves the scheduling policy of the current educator.
+// @Summary      Retrieve scheduling policy
+// @Description  Retrieves the session lengths, buffer, notice and booking horizon policies of the educator.
+// @Tags         Onboarding
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  SchedulingPolicyResponse  "Scheduling policy"
+// @Failure      404  {object}  error                     "Scheduling policy not found"
+// @Router       /api/v1/onboarding/policy [get]
+// @Security 	 BearerAuth
+func (h *OnboardingHandler) GetMySchedulingPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.service.GetMySchedulingPolicy(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, policy)
+}
@@ -0,0 +1,64 @@
+package onboarding
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToSchedulingPolicy(educatorId uuid.UUID, r *AvailabilityWizardRequest, weeks int) *entities.SchedulingPolicy {
+	lengths := slices.Clone(r.SessionLengths)
+	slices.Sort(lengths)
+	lengths = slices.Compact(lengths)
+
+	sessionLengths := make([]int64, len(lengths))
+	for i, l := range lengths {
+		sessionLengths[i] = int64(l)
+	}
+
+	return &entities.SchedulingPolicy{
+		EducatorId:              educatorId,
+		Timezone:                r.Timezone,
+		SessionLengths:          sessionLengths,
+		BufferMinutes:           defaultBufferMinutes(lengths),
+		MinNoticeHours:          minNoticeHours,
+		BookingHorizonDays:      weeks * 7,
+		CancellationNoticeHours: cancellationNoticeHours,
+		CreatedAt:               time.Now().UTC(),
+		UpdatedAt:               time.Now().UTC(),
+	}
+}
+
+func MapSchedulingPolicyToResponse(p *entities.SchedulingPolicy) *SchedulingPolicyResponse {
+	sessionLengths := make([]int, len(p.SessionLengths))
+	for i, l := range p.SessionLengths {
+		sessionLengths[i] = int(l)
+	}
+
+	return &SchedulingPolicyResponse{
+		EducatorId:              p.EducatorId,
+		Timezone:                p.Timezone,
+		SessionLengths:          sessionLengths,
+		BufferMinutes:           p.BufferMinutes,
+		MinNoticeHours:          p.MinNoticeHours,
+		BookingHorizonDays:      p.BookingHorizonDays,
+		CancellationNoticeHours: p.CancellationNoticeHours,
+	}
+}
+
+func MapPeriodsToWorkingPeriods(educatorId uuid.UUID, periods []*WorkingPeriodResponse) []*entities.WorkingPeriod {
+	result := make([]*entities.WorkingPeriod, len(periods))
+	for i, p := range periods {
+		result[i] = &entities.WorkingPeriod{
+			UserId:    educatorId,
+			StartTime: p.StartTime,
+			EndTime:   p.EndTime,
+			CreatedAt: time.Now().UTC(),
+			UpdatedAt: time.Now().UTC(),
+		}
+	}
+	return result
+}
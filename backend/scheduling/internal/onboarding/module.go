@@ -0,0 +1,20 @@
+package onboarding
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeOnboardingService(log logger.Logger, db *sqlx.DB) *OnboardingService {
+	repo := NewOnboardingRepository(db)
+	service := NewOnboardingService(log, repo)
+	return service
+}
+
+func InitializeOnboardingHTTPHandler(service *OnboardingService) http.Handler {
+	handler := NewOnboardingHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,78 @@
+package onboarding
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type OnboardingRepo struct {
+	db *sqlx.DB
+}
+
+func NewOnboardingRepository(db *sqlx.DB) *OnboardingRepo {
+	return &OnboardingRepo{db: db}
+}
+
+// GetOverlappingWorkingPeriods retrieves working periods of a user intersecting a date range
+func (r *OnboardingRepo) GetOverlappingWorkingPeriods(ctx context.Context, userId uuid.UUID, fromDate, toDate time.Time) ([]*entities.WorkingPeriod, error) {
+	const query = `
+		SELECT id, user_id, start_time, end_time, created_at, updated_at
+		FROM working_period
+		WHERE user_id = $1 AND start_time < $3 AND end_time > $2 AND deleted_at IS NULL
+	`
+	return database.FetchMultiple[entities.WorkingPeriod](ctx, r.db, query, userId, fromDate, toDate)
+}
+
+// GetSchedulingPolicy retrieves the scheduling policy of an educator
+func (r *OnboardingRepo) GetSchedulingPolicy(ctx context.Context, educatorId uuid.UUID) (*entities.SchedulingPolicy, error) {
+	const query = `
+		SELECT educator_id, timezone, session_lengths, buffer_minutes, min_notice_hours, booking_horizon_days, cancellation_notice_hours, created_at, updated_at
+		FROM scheduling_policy
+		WHERE educator_id = $1
+	`
+	return database.FetchSingle[entities.SchedulingPolicy](ctx, r.db, query, educatorId)
+}
+
+// ApplyOnboarding adds the generated working periods and creates or replaces the scheduling policy in one transaction
+func (r *OnboardingRepo) ApplyOnboarding(ctx context.Context, workingPeriods []*entities.WorkingPeriod, policy *entities.SchedulingPolicy) error {
+	const workingPeriodQuery = `
+		INSERT INTO working_period (user_id, start_time, end_time, created_at, updated_at)
+		VALUES (:user_id, :start_time, :end_time, :created_at, :updated_at)
+	`
+	const policyQuery = `
+		INSERT INTO scheduling_policy (educator_id, timezone, session_lengths, buffer_minutes, min_notice_hours, booking_horizon_days, cancellation_notice_hours, created_at, updated_at)
+		VALUES (:educator_id, :timezone, :session_lengths, :buffer_minutes, :min_notice_hours, :booking_horizon_days, :cancellation_notice_hours, :created_at, :updated_at)
+		ON CONFLICT (educator_id) DO UPDATE
+		SET timezone = EXCLUDED.timezone, session_lengths = EXCLUDED.session_lengths, buffer_minutes = EXCLUDED.buffer_minutes,
+			min_notice_hours = EXCLUDED.min_notice_hours, booking_horizon_days = EXCLUDED.booking_horizon_days,
+			cancellation_notice_hours = EXCLUDED.cancellation_notice_hours, updated_at = EXCLUDED.updated_at
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	for _, wp := range workingPeriods {
+		if _, err := tx.NamedExecContext(ctx, workingPeriodQuery, wp); err != nil {
+			return apperrors.NewInternal(err)
+		}
+	}
+
+	if _, err := tx.NamedExecContext(ctx, policyQuery, policy); err != nil {
+		return apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package onboarding
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *OnboardingHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.EducatorRole))
+	r.Post("/availability/preview", handler.PreviewAvailability)
+	r.Post("/availability", handler.ApplyAvailability)
+	r.Get("/policy", handler.GetMySchedulingPolicy)
+
+	return r
+}
@@ -0,0 +1,140 @@
+package onboarding
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+type OnboardingRepository interface {
+	GetOverlappingWorkingPeriods(ctx context.Context, userId uuid.UUID, fromDate, toDate time.Time) ([]*entities.WorkingPeriod, error)
+	GetSchedulingPolicy(ctx context.Context, educatorId uuid.UUID) (*entities.SchedulingPolicy, error)
+	ApplyOnboarding(ctx context.Context, workingPeriods []*entities.WorkingPeriod, policy *entities.SchedulingPolicy) error
+}
+
+type OnboardingService struct {
+	log  logger.Logger
+	repo OnboardingRepository
+}
+
+func NewOnboardingService(log logger.Logger, repo OnboardingRepository) *OnboardingService {
+	return &OnboardingService{log: log, repo: repo}
+}
+
+// PreviewAvailability computes the schedule and policy the wizard would create without saving them
+func (s *OnboardingService) PreviewAvailability(ctx context.Context, request *AvailabilityWizardRequest) (*AvailabilityWizardResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	response, _, _, err := s.buildAvailability(ctx, userId, request)
+	if err != nil {
+		log.Error("failed to build availability", err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ApplyAvailability creates the recurring working periods and default policy generated from the
+// teacher's preferences. Periods overlapping existing working periods are skipped, so the wizard
+// can be re-run to extend the schedule.
+func (s *OnboardingService) ApplyAvailability(ctx context.Context, request *AvailabilityWizardRequest) (*AvailabilityWizardResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	response, workingPeriods, policy, err := s.buildAvailability(ctx, userId, request)
+	if err != nil {
+		log.Error("failed to build availability", err)
+		return nil, err
+	}
+
+	if err := s.repo.ApplyOnboarding(ctx, workingPeriods, policy); err != nil {
+		log.Error("failed to apply onboarding", err)
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *OnboardingService) GetMySchedulingPolicy(ctx context.Context) (*SchedulingPolicyResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	policy, err := s.repo.GetSchedulingPolicy(ctx, userId)
+	if err != nil {
+		log.Error("failed to get scheduling policy", err)
+		return nil, err
+	}
+
+	return MapSchedulingPolicyToResponse(policy), nil
+}
+
+func (s *OnboardingService) buildAvailability(
+	ctx context.Context,
+	userId uuid.UUID,
+	request *AvailabilityWizardRequest,
+) (*AvailabilityWizardResponse, []*entities.WorkingPeriod, *entities.SchedulingPolicy, error) {
+	loc, _ := time.LoadLocation(request.Timezone)
+	now := time.Now().UTC()
+
+	firstDay := now.In(loc).AddDate(0, 0, 1)
+	if request.StartDate != nil {
+		firstDay, _ = time.ParseInLocation(dateLayout, *request.StartDate, loc)
+	}
+
+	weeks := request.Weeks
+	if weeks == 0 {
+		weeks = defaultWeeks
+	}
+
+	generated := generateWorkingPeriods(request, loc, firstDay, weeks, now)
+	response := &AvailabilityWizardResponse{WorkingPeriods: []*WorkingPeriodResponse{}}
+
+	if len(generated) > 0 {
+		existing, err := s.repo.GetOverlappingWorkingPeriods(ctx, userId, generated[0].StartTime, generated[len(generated)-1].EndTime)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for _, p := range generated {
+			if overlapsAny(p, existing) {
+				response.Skipped++
+				continue
+			}
+			response.WorkingPeriods = append(response.WorkingPeriods, p)
+		}
+	}
+
+	policy := MapRequestToSchedulingPolicy(userId, request, weeks)
+	response.Policy = MapSchedulingPolicyToResponse(policy)
+
+	return response, MapPeriodsToWorkingPeriods(userId, response.WorkingPeriods), policy, nil
+}
+
+func overlapsAny(p *WorkingPeriodResponse, existing []*entities.WorkingPeriod) bool {
+	for _, wp := range existing {
+		if timeutils.IsOverlapping(p.StartTime, p.EndTime, wp.StartTime, wp.EndTime) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package organizations
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// Member roles as exposed by the API
+const (
+	AdminMemberRole   = "Admin"
+	TeacherMemberRole = "Teacher"
+)
+
+// swagger:model OrganizationRequest
+type OrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+// swagger:model OrganizationResponse
+type OrganizationResponse struct {
+	Id        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// swagger:model OrganizationMemberRequest
+type OrganizationMemberRequest struct {
+	UserId uuid.UUID `json:"userId"`
+	Role   string    `json:"role"`
+}
+
+// swagger:model OrganizationMemberResponse
+type OrganizationMemberResponse struct {
+	UserId    uuid.UUID `json:"userId"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// swagger:model OrganizationBookingResponse
+type OrganizationBookingResponse struct {
+	Id         int64     `json:"id"`
+	EducatorId uuid.UUID `json:"educatorId"`
+	StudentId  uuid.UUID `json:"studentId"`
+	ProductId  int64     `json:"productId"`
+	Title      string    `json:"title"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	Status     string    `json:"status"`
+	Price      float64   `json:"price"`
+}
+
+func (o *OrganizationRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	name := strings.TrimSpace(o.Name)
+	if name == "" || len(name) > 200 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Name",
+			Message: "must be between 1 and 200 characters",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Organization request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
+
+func (o *OrganizationMemberRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if o.UserId == uuid.Nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "UserId",
+			Message: "must not be empty",
+		})
+	}
+
+	if o.Role != AdminMemberRole && o.Role != TeacherMemberRole {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Role",
+			Message: "must be Admin or Teacher",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Organization member request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package organizations
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type OrganizationHandler struct {
+	service *OrganizationService
+}
+
+func NewOrganizationHandler(service *OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{service: service}
+}
+
+// GetMyOrganizations retrieves the organizations of the current user.
+// @Summary      Retrieve my organizations
+// @Description  Retrieves the organizations the user belongs to together with their role in each.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   OrganizationResponse  "Organizations"
+// @Failure      401  {object}  error                 "Unauthorized"
+// @Router       /api/v1/organizations [get]
+// @Security 	 BearerAuth
+func (h *OrganizationHandler) GetMyOrganizations(w http.ResponseWriter, r *http.Request) {
+	organizations, err := h.service.GetMyOrganizations(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, organizations)
+}
+
+// CreateOrganization creates an organization managed by the current user.
+// @Summary      Create organization
+// @Description  Creates an agency organization with the current user as its first admin.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Param        organization  body      OrganizationRequest   true  "Organization"
+// @Success      201           {object}  OrganizationResponse  "Created organization"
+// @Failure      400           {object}  error                 "Invalid input"
+// @Router       /api/v1/organizations [post]
+// @Security 	 BearerAuth
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var request *OrganizationRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	organization, err := h.service.CreateOrganization(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, organization)
+}
+
+// GetMembers retrieves the members of an organization.
+// @Summary      Retrieve organization members
+// @Description  Retrieves admins and teachers of the organization. Only organization admins have access.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                          true  "Organization ID"
+// @Success      200  {array}   OrganizationMemberResponse  "Members"
+// @Failure      403  {object}  error                       "Access denied"
+// @Router       /api/v1/organizations/{id}/members [get]
+// @Security 	 BearerAuth
+func (h *OrganizationHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	members, err := h.service.GetMembers(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, members)
+}
+
+// SetMember adds a member to an organization or changes their role.
+// @Summary      Set organization member
+// @Description  Adds a teacher or admin to the organization, or changes the role of an existing member. Only organization admins have access.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                        true  "Organization ID"
+// @Param        member  body      OrganizationMemberRequest  true  "Member"
+// @Success      204     "Member saved successfully"
+// @Failure      409     {object}  error                      "Organization must keep an admin"
+// @Router       /api/v1/organizations/{id}/members [put]
+// @Security 	 BearerAuth
+func (h *OrganizationHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *OrganizationMemberRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.SetMember(r.Context(), id, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveMember removes a member from an organization.
+// @Summary      Remove organization member
+// @Description  Removes a teacher or admin from the organization. Only organization admins have access.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true  "Organization ID"
+// @Param        userId  path      string  true  "User ID (UUID)"
+// @Success      204     "Member removed successfully"
+// @Failure      409     {object}  error   "Organization must keep an admin"
+// @Router       /api/v1/organizations/{id}/members/{userId} [delete]
+// @Security 	 BearerAuth
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	userId, err := api.ParseUUIDParam(w, r, "userId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.RemoveMember(r.Context(), id, userId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetOrganizationBookings retrieves bookings across the teachers of an organization.
+// @Summary      Retrieve organization bookings
+// @Description  Retrieves bookings of all teachers of the organization starting within the 'fromDate' and 'toDate' range. Only organization admins have access.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int     true   "Organization ID"
+// @Param        fromDate  query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate    query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        skip      query     int     false  "Number of bookings to skip"
+// @Param        take      query     int     false  "Number of bookings to return"
+// @Success      200       {array}   OrganizationBookingResponse  "Bookings"
+// @Failure      403       {object}  error                        "Access denied"
+// @Router       /api/v1/organizations/{id}/bookings [get]
+// @Security 	 BearerAuth
+func (h *OrganizationHandler) GetOrganizationBookings(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	fromDate, err := api.ParseTimeQuery(w, r, "fromDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	toDate, err := api.ParseTimeQuery(w, r, "toDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	skip, err := api.ParseIntQuery(w, r, "skip", 0)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	take, err := api.ParseIntQuery(w, r, "take", 50)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	bookings, err := h.service.GetOrganizationBookings(r.Context(), id, fromDate, toDate, skip, take)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, bookings)
+}
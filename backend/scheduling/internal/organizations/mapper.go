@@ -0,0 +1,68 @@
+package organizations
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToOrganization(request *OrganizationRequest, createdBy uuid.UUID) *entities.Organization {
+	now := time.Now().UTC()
+	return &entities.Organization{
+		Name:      strings.TrimSpace(request.Name),
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func MapMembershipsToResponse(memberships []*Membership) []*OrganizationResponse {
+	items := make([]*OrganizationResponse, 0, len(memberships))
+	for _, m := range memberships {
+		items = append(items, &OrganizationResponse{Id: m.Id, Name: m.Name, Role: m.Role.String(), CreatedAt: m.CreatedAt})
+	}
+	return items
+}
+
+func MapRequestToMember(organizationId int64, request *OrganizationMemberRequest) *entities.OrganizationMember {
+	role := entities.OrganizationTeacher
+	if request.Role == AdminMemberRole {
+		role = entities.OrganizationAdmin
+	}
+
+	return &entities.OrganizationMember{
+		OrganizationId: organizationId,
+		UserId:         request.UserId,
+		Role:           role,
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+func MapMembersToResponse(members []*entities.OrganizationMember) []*OrganizationMemberResponse {
+	items := make([]*OrganizationMemberResponse, 0, len(members))
+	for _, m := range members {
+		items = append(items, &OrganizationMemberResponse{UserId: m.UserId, Role: m.Role.String(), CreatedAt: m.CreatedAt})
+	}
+	return items
+}
+
+func MapBookingsToResponse(bookings []*entities.Booking) []*OrganizationBookingResponse {
+	items := make([]*OrganizationBookingResponse, 0, len(bookings))
+	for _, b := range bookings {
+		items = append(items, &OrganizationBookingResponse{
+			Id:         b.Id,
+			EducatorId: b.EducatorId,
+			StudentId:  b.StudentId,
+			ProductId:  b.ProductId,
+			Title:      b.Title,
+			StartTime:  b.StartTime,
+			EndTime:    b.EndTime,
+			Status:     b.Status.String(),
+			Price:      b.Price,
+		})
+	}
+	return items
+}
@@ -0,0 +1,20 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeOrganizationService(log logger.Logger, db *sqlx.DB) *OrganizationService {
+	repo := NewOrganizationRepository(db)
+	service := NewOrganizationService(log, repo)
+	return service
+}
+
+func InitializeOrganizationHTTPHandler(service *OrganizationService) http.Handler {
+	handler := NewOrganizationHandler(service)
+	return Routes(handler)
+}
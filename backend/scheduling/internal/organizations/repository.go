@@ -0,0 +1,163 @@
+package organizations
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// Membership holds an organization together with the role of a member in it
+type Membership struct {
+	Id        int64                     `db:"id"`
+	Name      string                    `db:"name"`
+	Role      entities.OrganizationRole `db:"role"`
+	CreatedAt time.Time                 `db:"created_at"`
+}
+
+type OrganizationRepo struct {
+	db *sqlx.DB
+}
+
+func NewOrganizationRepository(db *sqlx.DB) *OrganizationRepo {
+	return &OrganizationRepo{db: db}
+}
+
+// GetUserMemberships retrieves the organizations a user belongs to
+func (r *OrganizationRepo) GetUserMemberships(ctx context.Context, userId uuid.UUID) ([]*Membership, error) {
+	const query = `
+		SELECT o.id, o.name, m.role, o.created_at
+		FROM organization o
+		JOIN organization_member m ON m.organization_id = o.id
+		WHERE m.user_id = $1
+		ORDER BY o.name
+	`
+	return database.FetchMultiple[Membership](ctx, r.db, query, userId)
+}
+
+// GetMember retrieves the membership of a user in an organization
+func (r *OrganizationRepo) GetMember(ctx context.Context, organizationId int64, userId uuid.UUID) (*entities.OrganizationMember, error) {
+	const query = `
+		SELECT organization_id, user_id, role, created_at
+		FROM organization_member
+		WHERE organization_id = $1 AND user_id = $2
+	`
+	return database.FetchSingle[entities.OrganizationMember](ctx, r.db, query, organizationId, userId)
+}
+
+// GetMembers retrieves the members of an organization, admins first
+func (r *OrganizationRepo) GetMembers(ctx context.Context, organizationId int64) ([]*entities.OrganizationMember, error) {
+	const query = `
+		SELECT organization_id, user_id, role, created_at
+		FROM organization_member
+		WHERE organization_id = $1
+		ORDER BY role, created_at
+	`
+	return database.FetchMultiple[entities.OrganizationMember](ctx, r.db, query, organizationId)
+}
+
+// AddOrganization adds a new organization with its creator as the first admin and returns its Id
+func (r *OrganizationRepo) AddOrganization(ctx context.Context, organization *entities.Organization) (int64, error) {
+	const organizationQuery = `
+		INSERT INTO organization (name, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	const memberQuery = `
+		INSERT INTO organization_member (organization_id, user_id, role, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.GetContext(ctx, &id, organizationQuery, organization.Name, organization.CreatedBy, organization.CreatedAt, organization.UpdatedAt)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, memberQuery, id, organization.CreatedBy, entities.OrganizationAdmin, organization.CreatedAt); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return id, nil
+}
+
+// UpsertMember adds a member to an organization or changes the role of an existing one
+func (r *OrganizationRepo) UpsertMember(ctx context.Context, member *entities.OrganizationMember) error {
+	const query = `
+		INSERT INTO organization_member (organization_id, user_id, role, created_at)
+		VALUES (:organization_id, :user_id, :role, :created_at)
+		ON CONFLICT (organization_id, user_id) DO UPDATE
+		SET role = EXCLUDED.role
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, member)
+}
+
+// RemoveMember removes a member from an organization
+func (r *OrganizationRepo) RemoveMember(ctx context.Context, organizationId int64, userId uuid.UUID) error {
+	const query = `
+		DELETE FROM organization_member
+		WHERE organization_id = $1 AND user_id = $2
+	`
+	return database.ExecQuery(ctx, r.db, query, organizationId, userId)
+}
+
+// CountAdmins counts the admins of an organization
+func (r *OrganizationRepo) CountAdmins(ctx context.Context, organizationId int64) (int, error) {
+	const query = `
+		SELECT COUNT(*) FROM organization_member
+		WHERE organization_id = $1 AND role = $2
+	`
+	var count int
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &count, query, organizationId, entities.OrganizationAdmin); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return count, nil
+}
+
+// IsAdminOfTeacher checks whether a user administers an organization the educator teaches in
+func (r *OrganizationRepo) IsAdminOfTeacher(ctx context.Context, adminId uuid.UUID, educatorId uuid.UUID) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM organization_member a
+			JOIN organization_member t ON t.organization_id = a.organization_id
+			WHERE a.user_id = $1 AND a.role = $3 AND t.user_id = $2 AND t.role = $4
+		)
+	`
+	return database.CheckExists(ctx, r.db, query, adminId, educatorId, entities.OrganizationAdmin, entities.OrganizationTeacher)
+}
+
+// GetTeacherBookings retrieves bookings of the organization's teachers starting within a range
+func (r *OrganizationRepo) GetTeacherBookings(
+	ctx context.Context,
+	organizationId int64,
+	from time.Time,
+	to time.Time,
+	skip int,
+	take int,
+) ([]*entities.Booking, error) {
+	const query = `
+		SELECT b.id, b.educator_id, b.student_id, b.product_id, b.scheduled_event_id, b.session_type_id, b.enrollment_id,
+			b.working_period_id, b.title, b.start_time, b.end_time, b.status, b.price, b.version, b.created_at, b.updated_at
+		FROM booking b
+		JOIN organization_member m ON m.user_id = b.educator_id
+		WHERE m.organization_id = $1 AND m.role = $2 AND b.start_time >= $3 AND b.start_time < $4
+		ORDER BY b.start_time, b.id
+		OFFSET $5 LIMIT $6
+	`
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, organizationId, entities.OrganizationTeacher, from, to, skip, take)
+}
@@ -0,0 +1,21 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes(handler *OrganizationHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes, organization roles are checked by the service
+	r.Get("/", handler.GetMyOrganizations)
+	r.Post("/", handler.CreateOrganization)
+	r.Get("/{id}/members", handler.GetMembers)
+	r.Put("/{id}/members", handler.SetMember)
+	r.Delete("/{id}/members/{userId}", handler.RemoveMember)
+	r.Get("/{id}/bookings", handler.GetOrganizationBookings)
+
+	return r
+}
@@ -0,0 +1,230 @@
+package organizations
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type OrganizationRepository interface {
+	GetUserMemberships(ctx context.Context, userId uuid.UUID) ([]*Membership, error)
+	GetMember(ctx context.Context, organizationId int64, userId uuid.UUID) (*entities.OrganizationMember, error)
+	GetMembers(ctx context.Context, organizationId int64) ([]*entities.OrganizationMember, error)
+	AddOrganization(ctx context.Context, organization *entities.Organization) (int64, error)
+	UpsertMember(ctx context.Context, member *entities.OrganizationMember) error
+	RemoveMember(ctx context.Context, organizationId int64, userId uuid.UUID) error
+	CountAdmins(ctx context.Context, organizationId int64) (int, error)
+	IsAdminOfTeacher(ctx context.Context, adminId uuid.UUID, educatorId uuid.UUID) (bool, error)
+	GetTeacherBookings(ctx context.Context, organizationId int64, from, to time.Time, skip int, take int) ([]*entities.Booking, error)
+}
+
+type OrganizationService struct {
+	log  logger.Logger
+	repo OrganizationRepository
+}
+
+func NewOrganizationService(log logger.Logger, repo OrganizationRepository) *OrganizationService {
+	return &OrganizationService{log: log, repo: repo}
+}
+
+// CanActFor reports whether a user administers an organization the educator teaches in, which gives
+// them full access to the educator's account
+func (s *OrganizationService) CanActFor(ctx context.Context, adminId uuid.UUID, educatorId uuid.UUID) (bool, error) {
+	return s.repo.IsAdminOfTeacher(ctx, adminId, educatorId)
+}
+
+// CreateOrganization creates an organization with the current user as its first admin
+func (s *OrganizationService) CreateOrganization(ctx context.Context, request *OrganizationRequest) (*OrganizationResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetActorID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	organization := MapRequestToOrganization(request, userId)
+	id, err := s.repo.AddOrganization(ctx, organization)
+	if err != nil {
+		log.Error("failed to add organization", err)
+		return nil, err
+	}
+
+	return &OrganizationResponse{
+		Id:        id,
+		Name:      organization.Name,
+		Role:      entities.OrganizationAdmin.String(),
+		CreatedAt: organization.CreatedAt,
+	}, nil
+}
+
+func (s *OrganizationService) GetMyOrganizations(ctx context.Context) ([]*OrganizationResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetActorID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	memberships, err := s.repo.GetUserMemberships(ctx, userId)
+	if err != nil {
+		log.Error("failed to get organizations", err)
+		return nil, err
+	}
+
+	return MapMembershipsToResponse(memberships), nil
+}
+
+func (s *OrganizationService) GetMembers(ctx context.Context, organizationId int64) ([]*OrganizationMemberResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.requireAdmin(ctx, organizationId); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.GetMembers(ctx, organizationId)
+	if err != nil {
+		log.Error("failed to get organization members", err)
+		return nil, err
+	}
+
+	return MapMembersToResponse(members), nil
+}
+
+// SetMember adds a user to the organization or changes their role. The last admin cannot be demoted.
+func (s *OrganizationService) SetMember(ctx context.Context, organizationId int64, request *OrganizationMemberRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.requireAdmin(ctx, organizationId); err != nil {
+		return err
+	}
+
+	member := MapRequestToMember(organizationId, request)
+	if member.Role != entities.OrganizationAdmin {
+		if err := s.ensureOtherAdmin(ctx, organizationId, member.UserId); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.UpsertMember(ctx, member); err != nil {
+		log.Error("failed to save organization member", err)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from the organization. The last admin cannot be removed.
+func (s *OrganizationService) RemoveMember(ctx context.Context, organizationId int64, userId uuid.UUID) error {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.requireAdmin(ctx, organizationId); err != nil {
+		return err
+	}
+
+	if err := s.ensureOtherAdmin(ctx, organizationId, userId); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveMember(ctx, organizationId, userId); err != nil {
+		log.Error("failed to remove organization member", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetOrganizationBookings retrieves bookings across all teachers of the organization
+func (s *OrganizationService) GetOrganizationBookings(
+	ctx context.Context,
+	organizationId int64,
+	from time.Time,
+	to time.Time,
+	skip int,
+	take int,
+) ([]*OrganizationBookingResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if !from.Before(to) {
+		return nil, apperrors.NewBadRequestError("fromDate must be before toDate", apperrors.ErrParameterParsingFailed)
+	}
+
+	if err := s.requireAdmin(ctx, organizationId); err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.repo.GetTeacherBookings(ctx, organizationId, from, to, skip, take)
+	if err != nil {
+		log.Error("failed to get organization bookings", err)
+		return nil, err
+	}
+
+	return MapBookingsToResponse(bookings), nil
+}
+
+// requireAdmin allows organization admins and platform admins, teachers of the organization are denied
+func (s *OrganizationService) requireAdmin(ctx context.Context, organizationId int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetActorID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if auth.HasRole(ctx, auth.AdminRole) {
+		return nil
+	}
+
+	member, err := s.repo.GetMember(ctx, organizationId, userId)
+	if err != nil {
+		var notFound *apperrors.NotFoundError
+		if errors.As(err, &notFound) {
+			return apperrors.NewForbidden("Access denied")
+		}
+		log.Error("failed to get organization member", err)
+		return err
+	}
+
+	if member.Role != entities.OrganizationAdmin {
+		return apperrors.NewForbidden("Access denied")
+	}
+
+	return nil
+}
+
+// ensureOtherAdmin fails when the user is the only admin left in the organization
+func (s *OrganizationService) ensureOtherAdmin(ctx context.Context, organizationId int64, userId uuid.UUID) error {
+	log := logger.FromContext(ctx, s.log)
+
+	member, err := s.repo.GetMember(ctx, organizationId, userId)
+	if err != nil {
+		var notFound *apperrors.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		log.Error("failed to get organization member", err)
+		return err
+	}
+
+	if member.Role != entities.OrganizationAdmin {
+		return nil
+	}
+
+	admins, err := s.repo.CountAdmins(ctx, organizationId)
+	if err != nil {
+		log.Error("failed to count organization admins", err)
+		return err
+	}
+
+	if admins <= 1 {
+		return apperrors.NewConflict("Organization must keep at least one admin", apperrors.ErrOrganizationMember)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package outbox
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeRelayJob(log logger.Logger, db *sqlx.DB, publisher Republisher, throttle Throttle, cfg *config.DegradationConfig) *RelayJob {
+	repo := NewOutboxRepository(db)
+	return NewRelayJob(log, repo, publisher, throttle, cfg)
+}
@@ -0,0 +1,144 @@
+package outbox
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+const (
+	claimLease = 2 * time.Minute
+	maxBackoff = time.Hour
+)
+
+type RelayRepository interface {
+	ClaimDueMessages(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*entities.OutboxMessage, error)
+	DeleteMessage(ctx context.Context, id int64) error
+	RecordFailure(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error
+	ReleaseMessages(ctx context.Context, ids []int64, nextAttemptAt time.Time) error
+}
+
+type Republisher interface {
+	Republish(ctx context.Context, message *messaging.OutboxMessage) error
+}
+
+type Throttle interface {
+	Allow(ctx context.Context, eventType string) (bool, time.Duration)
+}
+
+// RelayJob periodically publishes the events queued to the outbox while the broker was unavailable or
+// their type was over its throttle cap. A message may be published twice when a relay stops between
+// publish and delete, consumers deduplicate by event id.
+type RelayJob struct {
+	log       logger.Logger
+	repo      RelayRepository
+	publisher Republisher
+	throttle  Throttle
+	cfg       *config.DegradationConfig
+}
+
+func NewRelayJob(log logger.Logger, repo RelayRepository, publisher Republisher, throttle Throttle, cfg *config.DegradationConfig) *RelayJob {
+	return &RelayJob{log: log, repo: repo, publisher: publisher, throttle: throttle, cfg: cfg}
+}
+
+// Run relays due messages on every interval until the context is cancelled
+func (j *RelayJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.OutboxRelayIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.RelayMessages(ctx); err != nil {
+				j.log.Errorf("Failed to relay outbox messages: %v", err)
+			}
+		}
+	}
+}
+
+// RelayMessages publishes due messages in the order they were queued, batch by batch until none is left.
+// A run stops at the first failure, since the broker is most likely still unavailable; the rest is retried
+// on the next run.
+func (j *RelayJob) RelayMessages(ctx context.Context) error {
+	for {
+		full, err := j.relayBatch(ctx)
+		if err != nil || !full || ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+// relayBatch publishes one batch of due messages and reports whether the batch was full and relayed without
+// a failure, so another one may be due. A message whose type is over its throttle cap is put back until the
+// cap has room, without counting an attempt, and the messages of other types go ahead of it.
+func (j *RelayJob) relayBatch(ctx context.Context) (bool, error) {
+	now := time.Now().UTC()
+	messages, err := j.repo.ClaimDueMessages(ctx, now, now.Add(claimLease), j.cfg.OutboxBatchSize)
+	if err != nil {
+		return false, err
+	}
+	slices.SortFunc(messages, func(a, b *entities.OutboxMessage) int { return int(a.Id - b.Id) })
+
+	relayed, throttled := 0, 0
+	for i, m := range messages {
+		if allowed, wait := j.throttle.Allow(ctx, m.EventType); !allowed {
+			throttled++
+			if err := j.repo.ReleaseMessages(ctx, []int64{m.Id}, now.Add(wait)); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		err := j.publisher.Republish(ctx, &messaging.OutboxMessage{EventId: m.EventId, EventType: m.EventType, RoutingKey: m.RoutingKey, Body: m.Body})
+		if err != nil {
+			j.log.Warnf("Failed to relay outbox event %s (attempt %d): %v", m.EventId, m.Attempts+1, err)
+			j.logRelayed(relayed, throttled)
+			if err := j.repo.RecordFailure(ctx, m.Id, err.Error(), now.Add(backoff(j.cfg, m.Attempts))); err != nil {
+				return false, err
+			}
+
+			// Claimed messages not attempted in this run are released for the next one
+			rest := make([]int64, 0, len(messages)-i-1)
+			for _, r := range messages[i+1:] {
+				rest = append(rest, r.Id)
+			}
+			return false, j.repo.ReleaseMessages(ctx, rest, now)
+		}
+
+		if err := j.repo.DeleteMessage(ctx, m.Id); err != nil {
+			return false, err
+		}
+		relayed++
+	}
+
+	j.logRelayed(relayed, throttled)
+	return len(messages) == j.cfg.OutboxBatchSize, nil
+}
+
+func (j *RelayJob) logRelayed(relayed int, throttled int) {
+	if relayed > 0 {
+		j.log.Infof("Relayed %d outbox events", relayed)
+	}
+	if throttled > 0 {
+		j.log.Debugf("Put back %d outbox events of throttled types", throttled)
+	}
+}
+
+// backoff doubles the wait between attempts of a message, starting at the relay interval
+func backoff(cfg *config.DegradationConfig, attempts int) time.Duration {
+	wait := time.Duration(cfg.OutboxRelayIntervalSeconds) * time.Second
+	for range attempts {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return wait
+}
@@ -0,0 +1,80 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+type OutboxRepo struct {
+	db *sqlx.DB
+}
+
+func NewOutboxRepository(db *sqlx.DB) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+// Enqueue stores an event the broker did not accept or that was throttled. Within a request transaction the
+// event is only kept when the request commits.
+func (r *OutboxRepo) Enqueue(ctx context.Context, message *messaging.OutboxMessage) error {
+	const query = `
+		INSERT INTO event_outbox (event_id, event_type, routing_key, body, next_attempt_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5, current_timestamp))
+	`
+	var notBefore *time.Time
+	if !message.NotBefore.IsZero() {
+		notBefore = &message.NotBefore
+	}
+	return database.ExecQuery(ctx, r.db, query, message.EventId, message.EventType, message.RoutingKey, message.Body, notBefore)
+}
+
+// ClaimDueMessages leases up to limit messages due for a publish attempt until leaseUntil, so concurrent
+// relays skip them. Messages are returned oldest first.
+func (r *OutboxRepo) ClaimDueMessages(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*entities.OutboxMessage, error) {
+	const query = `
+		WITH due AS (
+			SELECT id FROM event_outbox
+			WHERE next_attempt_at <= $1
+			ORDER BY id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE event_outbox o SET next_attempt_at = $2
+		FROM due
+		WHERE o.id = due.id
+		RETURNING o.id, o.event_id, COALESCE(o.event_type, '') AS event_type, o.routing_key, o.body, o.attempts, o.last_error, o.next_attempt_at, o.created_at
+	`
+	var messages []*entities.OutboxMessage
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &messages, query, now, leaseUntil, limit); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return messages, nil
+}
+
+// DeleteMessage removes a message once it was published
+func (r *OutboxRepo) DeleteMessage(ctx context.Context, id int64) error {
+	const query = `DELETE FROM event_outbox WHERE id = $1`
+	return database.ExecQuery(ctx, r.db, query, id)
+}
+
+// RecordFailure keeps a message for another attempt at nextAttemptAt
+func (r *OutboxRepo) RecordFailure(ctx context.Context, id int64, lastError string, nextAttemptAt time.Time) error {
+	const query = `UPDATE event_outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1`
+	return database.ExecQuery(ctx, r.db, query, id, lastError, nextAttemptAt)
+}
+
+// ReleaseMessages returns claimed messages to the queue without counting an attempt
+func (r *OutboxRepo) ReleaseMessages(ctx context.Context, ids []int64, nextAttemptAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	const query = `UPDATE event_outbox SET next_attempt_at = $2 WHERE id = ANY($1)`
+	return database.ExecQuery(ctx, r.db, query, pq.Array(ids), nextAttemptAt)
+}
@@ -0,0 +1,57 @@
+package payments
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the payment service while the breaker is open
+var ErrCircuitOpen = errors.New("payment service circuit breaker is open")
+
+// circuitBreaker stops calls after consecutive failures and lets a single trial call through once the cooldown passed
+type circuitBreaker struct {
+	maxFailures int
+	cooldown    time.Duration
+	mu          sync.Mutex
+	failures    int
+	openedAt    time.Time
+	trial       bool
+}
+
+func newCircuitBreaker(maxFailures int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{maxFailures: maxFailures, cooldown: cooldown}
+}
+
+// allow reports whether a call may be made right now
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.maxFailures {
+		return true
+	}
+	if b.trial || time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+
+	b.trial = true
+	return true
+}
+
+// record updates the breaker state with the outcome of a call
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if err == nil {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.maxFailures {
+		b.openedAt = time.Now()
+	}
+}
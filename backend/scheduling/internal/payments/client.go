@@ -0,0 +1,126 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+)
+
+var (
+	Pending   = "pending"
+	Completed = "completed"
+	Failed    = "failed"
+)
+
+// ErrPaymentNotFound is returned when the payment service has no payment for the requested purchase
+var ErrPaymentNotFound = errors.New("payment not found")
+
+type PaymentStatusResponse struct {
+	Id       int64   `json:"id"`
+	Status   string  `json:"status"`
+	Price    float64 `json:"price,string"`
+	Currency string  `json:"currency"`
+}
+
+// TokenSource provides the authorization header used for service-to-service calls
+type TokenSource interface {
+	AuthorizationHeader(ctx context.Context) (string, error)
+}
+
+type PaymentServiceClient struct {
+	baseURL    string
+	httpClient *http.Client
+	tokens     TokenSource
+	breaker    *circuitBreaker
+}
+
+func NewPaymentServiceClient(cfg config.ExternalServiceConfig, httpClient *http.Client, tokens TokenSource) *PaymentServiceClient {
+	return &PaymentServiceClient{
+		baseURL:    cfg.PaymentServiceUrl,
+		httpClient: httpClient,
+		tokens:     tokens,
+		breaker:    newCircuitBreaker(cfg.PaymentBreakerFailures, time.Duration(cfg.PaymentBreakerCooldownSeconds)*time.Second),
+	}
+}
+
+// GetPaymentStatus queries the latest payment of a user for a product directly from the payment service.
+// It returns ErrCircuitOpen without a request while the payment service is considered unavailable.
+func (s *PaymentServiceClient) GetPaymentStatus(
+	ctx context.Context,
+	userId uuid.UUID,
+	productId int64,
+	scheduledEventId *int64,
+) (*PaymentStatusResponse, error) {
+	if !s.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	response, err := s.getPaymentStatus(ctx, userId, productId, scheduledEventId)
+	if errors.Is(err, ErrPaymentNotFound) {
+		s.breaker.record(nil)
+	} else {
+		s.breaker.record(err)
+	}
+	return response, err
+}
+
+func (s *PaymentServiceClient) getPaymentStatus(
+	ctx context.Context,
+	userId uuid.UUID,
+	productId int64,
+	scheduledEventId *int64,
+) (*PaymentStatusResponse, error) {
+	authHeader, err := s.tokens.AuthorizationHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fullURL, err := url.JoinPath(s.baseURL, "api/v1/payments/status")
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("userId", userId.String())
+	query.Set("productId", strconv.FormatInt(productId, 10))
+	if scheduledEventId != nil {
+		query.Set("scheduledEventId", strconv.FormatInt(*scheduledEventId, 10))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fullURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", authHeader)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrPaymentNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("Request failed with status:" + resp.Status)
+	}
+
+	var response PaymentStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("Failed to decode response: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,84 @@
+package payouts
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// commission is a commission rule in integer units: the percent in hundredths, so 12.5% is 1250, and the
+// fixed fee per session in cents
+type commission struct {
+	basisPoints int64
+	fixedFee    int64
+}
+
+func newCommission(percent, fixedFee float64) commission {
+	return commission{basisPoints: toCents(percent), fixedFee: toCents(fixedFee)}
+}
+
+// calculatePayout returns the platform fee and the educator net amount in cents for a gross total in cents.
+// The percentage fee is rounded half up to the cent, the fee never exceeds the gross total.
+func calculatePayout(gross int64, sessionCount int, rule commission) (int64, int64) {
+	fee := (gross*rule.basisPoints+5000)/10000 + rule.fixedFee*int64(sessionCount)
+	fee = min(fee, gross)
+	return fee, gross - fee
+}
+
+// toCents converts an amount with two decimals, as stored in numeric(12, 2) columns, to cents
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// fromCents converts cents to the amount stored and returned by the API
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+// completedBefore caps the period end so sessions that have not finished yet are not counted
+func completedBefore(to time.Time) time.Time {
+	now := time.Now().UTC()
+	if to.After(now) {
+		return now
+	}
+	return to
+}
+
+func (s *PayoutService) defaultCommission() commission {
+	return newCommission(s.cfg.CommissionPercent, s.cfg.FixedFee)
+}
+
+func (s *PayoutService) getCommissionRule(ctx context.Context, educatorId uuid.UUID) (commission, error) {
+	rules, err := s.repo.GetCommissionRules(ctx, []uuid.UUID{educatorId})
+	if err != nil {
+		return commission{}, err
+	}
+
+	if len(rules) == 0 {
+		return s.defaultCommission(), nil
+	}
+	return newCommission(rules[0].CommissionPercent, rules[0].FixedFee), nil
+}
+
+func (s *PayoutService) getCommissionRules(ctx context.Context, totals []*SessionTotals) (map[uuid.UUID]commission, error) {
+	educatorIds := make([]uuid.UUID, len(totals))
+	for i, t := range totals {
+		educatorIds[i] = t.EducatorId
+	}
+
+	rules, err := s.repo.GetCommissionRules(ctx, educatorIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID]commission, len(totals))
+	for _, id := range educatorIds {
+		result[id] = s.defaultCommission()
+	}
+	for _, r := range rules {
+		result[r.EducatorId] = newCommission(r.CommissionPercent, r.FixedFee)
+	}
+	return result, nil
+}
@@ -0,0 +1,38 @@
+package payouts
+
+import "testing"
+
+func TestCalculatePayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		gross    int64
+		sessions int
+		rule     commission
+		fee, net int64
+	}{
+		{"percentage only", 10000, 2, newCommission(15, 0), 1500, 8500},
+		{"fixed fee per session", 10000, 3, newCommission(10, 1.5), 1450, 8550},
+		{"rounds half up to the cent", 1012, 1, newCommission(12.5, 0), 127, 885},
+		{"fee capped at gross", 500, 2, newCommission(0, 5), 500, 0},
+		{"no sessions", 0, 0, newCommission(20, 2), 0, 0},
+		// 0.1 + 0.2 sums to 0.30000000000000004 as float64, in cents it is exact
+		{"sums without float drift", toCents(0.1) + toCents(0.2), 2, newCommission(10, 0), 3, 27},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee, net := calculatePayout(tt.gross, tt.sessions, tt.rule)
+			if fee != tt.fee || net != tt.net {
+				t.Fatalf("calculatePayout(%d, %d, %+v) = %d, %d, want %d, %d", tt.gross, tt.sessions, tt.rule, fee, net, tt.fee, tt.net)
+			}
+		})
+	}
+}
+
+func TestCentsRoundTrip(t *testing.T) {
+	for _, amount := range []float64{0, 0.01, 0.29, 19.99, 1234567.89} {
+		if got := fromCents(toCents(amount)); got != amount {
+			t.Fatalf("fromCents(toCents(%v)) = %v", amount, got)
+		}
+	}
+}
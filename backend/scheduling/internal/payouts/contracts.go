@@ -0,0 +1,120 @@
+package payouts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// swagger:model PayoutReportResponse
+type PayoutReportResponse struct {
+	EducatorId   uuid.UUID                `json:"educatorId"`
+	PeriodStart  time.Time                `json:"periodStart"`
+	PeriodEnd    time.Time                `json:"periodEnd"`
+	SessionCount int                      `json:"sessionCount"`
+	GrossAmount  float64                  `json:"grossAmount"`
+	FeeAmount    float64                  `json:"feeAmount"`
+	NetAmount    float64                  `json:"netAmount"`
+	Currency     string                   `json:"currency"`
+	Sessions     []*PayoutSessionResponse `json:"sessions"`
+}
+
+// swagger:model PayoutSessionResponse
+type PayoutSessionResponse struct {
+	BookingId int64     `json:"bookingId"`
+	ProductId int64     `json:"productId"`
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Price     float64   `json:"price"`
+}
+
+// swagger:model PayoutStatementResponse
+type PayoutStatementResponse struct {
+	Id           int64     `json:"id"`
+	EducatorId   uuid.UUID `json:"educatorId"`
+	PeriodStart  time.Time `json:"periodStart"`
+	PeriodEnd    time.Time `json:"periodEnd"`
+	SessionCount int       `json:"sessionCount"`
+	GrossAmount  float64   `json:"grossAmount"`
+	FeeAmount    float64   `json:"feeAmount"`
+	NetAmount    float64   `json:"netAmount"`
+	Currency     string    `json:"currency"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// swagger:model GenerateStatementsRequest
+type GenerateStatementsRequest struct {
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+}
+
+// swagger:model GenerateStatementsResponse
+type GenerateStatementsResponse struct {
+	Generated int `json:"generated"`
+	Skipped   int `json:"skipped"`
+}
+
+// swagger:model CommissionRuleRequest
+type CommissionRuleRequest struct {
+	CommissionPercent float64 `json:"commissionPercent"`
+	FixedFee          float64 `json:"fixedFee"`
+}
+
+func (g *GenerateStatementsRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if g.PeriodStart.IsZero() {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "PeriodStart",
+			Message: "must not be empty",
+		})
+	}
+
+	if g.PeriodEnd.IsZero() {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "PeriodEnd",
+			Message: "must not be empty",
+		})
+	}
+
+	if !g.PeriodStart.IsZero() && !g.PeriodEnd.IsZero() {
+		if !g.PeriodStart.Before(g.PeriodEnd) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "PeriodStart",
+				Message: "must be before PeriodEnd",
+			})
+		}
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Payout statement request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
+
+func (c *CommissionRuleRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if c.CommissionPercent < 0 || c.CommissionPercent > 100 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "CommissionPercent",
+			Message: "must be between 0 and 100",
+		})
+	}
+
+	if c.FixedFee < 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "FixedFee",
+			Message: "must not be negative",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Commission rule request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package payouts
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type PayoutHandler struct {
+	service *PayoutService
+}
+
+func NewPayoutHandler(service *PayoutService) *PayoutHandler {
+	return &PayoutHandler{service: service}
+}
+
+// GetPayoutReport retrieves the payout report of the current educator.
+// @Summary      Retrieve payout report
+// @Description  Computes gross, fee and net amounts for the educator's completed sessions within the 'fromDate' and 'toDate' range.
+// @Tags         Payout
+// @Accept       json
+// @Produce      json
+// @Param        fromDate  query     string  true  "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate    query     string  true  "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Success      200       {object}  PayoutReportResponse  "Payout report"
+// @Failure      400       {object}  error                 "Invalid input parameters"
+// @Router       /api/v1/payouts/report [get]
+// @Security 	 BearerAuth
+func (h *PayoutHandler) GetPayoutReport(w http.ResponseWriter, r *http.Request) {
+	fromDate, err := api.ParseTimeQuery(w, r, "fromDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	toDate, err := api.ParseTimeQuery(w, r, "toDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	report, err := h.service.GetMyPayoutReport(r.Context(), fromDate, toDate)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, report)
+}
+
+// GetMyStatements retrieves payout statements of the current educator.
+// @Summary      Retrieve payout statements
+// @Description  Retrieves generated payout statements of the educator, newest first.
+// @Tags         Payout
+// @Accept       json
+// @Produce      json
+// @Param        skip  query     int  false  "Number of statements to skip"
+// @Param        take  query     int  false  "Number of statements to return"
+// @Success      200   {array}   PayoutStatementResponse  "Payout statements"
+// @Failure      400   {object}  error                    "Invalid input parameters"
+// @Router       /api/v1/payouts/statements [get]
+// @Security 	 BearerAuth
+func (h *PayoutHandler) GetMyStatements(w http.ResponseWriter, r *http.Request) {
+	skip, err := api.ParseIntQuery(w, r, "skip", 0)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	take, err := api.ParseIntQuery(w, r, "take", 20)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	statements, err := h.service.GetMyStatements(r.Context(), skip, take)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, statements)
+}
+
+// GenerateStatements generates payout statements for a closed period.
+// @Summary      Generate payout statements
+// @Description  Creates payout statements for every educator with completed sessions in the period and publishes a statement event for each. Educators with a statement for the same period are skipped, a period overlapping another statement is rejected.
+// @Tags         Payout
+// @Accept       json
+// @Produce      json
+// @Param        period  body      GenerateStatementsRequest   true  "Payout period"
+// @Success      200     {object}  GenerateStatementsResponse  "Generation summary"
+// @Failure      400     {object}  error                       "Invalid input"
+// @Failure      409     {object}  error                       "Period overlaps an existing statement"
+// @Router       /api/v1/payouts/statements [post]
+// @Security 	 BearerAuth
+func (h *PayoutHandler) GenerateStatements(w http.ResponseWriter, r *http.Request) {
+	var request *GenerateStatementsRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	response, err := h.service.GenerateStatements(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, response)
+}
+
+// SetCommissionRule sets the commission rule of an educator.
+// @Summary      Set commission rule
+// @Description  Creates or replaces the commission override applied to the educator's payouts instead of the platform default.
+// @Tags         Payout
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string                 true  "Educator ID (UUID)"
+// @Param        rule        body      CommissionRuleRequest  true  "Commission rule"
+// @Success      204         "Commission rule saved successfully"
+// @Failure      400         {object}  error                  "Invalid input"
+// @Router       /api/v1/payouts/commission-rules/{educatorId} [put]
+// @Security 	 BearerAuth
+func (h *PayoutHandler) SetCommissionRule(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *CommissionRuleRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.SetCommissionRule(r.Context(), educatorId, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+package payouts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapBookingToPayoutSession(b *entities.Booking) *PayoutSessionResponse {
+	return &PayoutSessionResponse{
+		BookingId: b.Id,
+		ProductId: b.ProductId,
+		Title:     b.Title,
+		StartTime: b.StartTime,
+		EndTime:   b.EndTime,
+		Price:     b.Price,
+	}
+}
+
+func MapBookingsToPayoutSessions(bs []*entities.Booking) []*PayoutSessionResponse {
+	if len(bs) == 0 {
+		return []*PayoutSessionResponse{}
+	}
+
+	response := make([]*PayoutSessionResponse, len(bs))
+	for i, b := range bs {
+		response[i] = MapBookingToPayoutSession(b)
+	}
+	return response
+}
+
+func MapStatementToResponse(ps *entities.PayoutStatement) *PayoutStatementResponse {
+	return &PayoutStatementResponse{
+		Id:           ps.Id,
+		EducatorId:   ps.EducatorId,
+		PeriodStart:  ps.PeriodStart,
+		PeriodEnd:    ps.PeriodEnd,
+		SessionCount: ps.SessionCount,
+		GrossAmount:  ps.GrossAmount,
+		FeeAmount:    ps.FeeAmount,
+		NetAmount:    ps.NetAmount,
+		Currency:     ps.Currency,
+		CreatedAt:    ps.CreatedAt,
+	}
+}
+
+func MapStatementsToResponse(pss []*entities.PayoutStatement) []*PayoutStatementResponse {
+	if len(pss) == 0 {
+		return []*PayoutStatementResponse{}
+	}
+
+	response := make([]*PayoutStatementResponse, len(pss))
+	for i, ps := range pss {
+		response[i] = MapStatementToResponse(ps)
+	}
+	return response
+}
+
+func MapTotalsToStatement(t *SessionTotals, r *GenerateStatementsRequest, fee, net int64, currency string) *entities.PayoutStatement {
+	return &entities.PayoutStatement{
+		EducatorId:   t.EducatorId,
+		PeriodStart:  r.PeriodStart,
+		PeriodEnd:    r.PeriodEnd,
+		SessionCount: t.SessionCount,
+		GrossAmount:  fromCents(t.GrossCents),
+		FeeAmount:    fromCents(fee),
+		NetAmount:    fromCents(net),
+		Currency:     currency,
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+func MapRequestToCommissionRule(educatorId uuid.UUID, r *CommissionRuleRequest) *entities.CommissionRule {
+	return &entities.CommissionRule{
+		EducatorId:        educatorId,
+		CommissionPercent: r.CommissionPercent,
+		FixedFee:          r.FixedFee,
+		CreatedAt:         time.Now().UTC(),
+		UpdatedAt:         time.Now().UTC(),
+	}
+}
@@ -0,0 +1,27 @@
+package payouts
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializePayoutService(
+	log logger.Logger,
+	db *sqlx.DB,
+	cfg *config.PayoutConfig,
+	publisher *messaging.Publisher,
+) *PayoutService {
+	repo := NewPayoutRepository(db)
+	service := NewPayoutService(log, repo, cfg, publisher)
+	return service
+}
+
+func InitializePayoutHTTPHandler(service *PayoutService) http.Handler {
+	handler := NewPayoutHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,151 @@
+package payouts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// SessionTotals holds aggregated completed session figures for a single educator, the gross amount in cents
+type SessionTotals struct {
+	EducatorId   uuid.UUID `db:"educator_id"`
+	SessionCount int       `db:"session_count"`
+	GrossCents   int64     `db:"gross_cents"`
+}
+
+type PayoutRepo struct {
+	db *sqlx.DB
+}
+
+func NewPayoutRepository(db *sqlx.DB) *PayoutRepo {
+	return &PayoutRepo{db: db}
+}
+
+// GetCompletedBookings retrieves approved bookings of an educator that ended within a period
+func (r *PayoutRepo) GetCompletedBookings(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at
+		FROM booking
+		WHERE educator_id = $1 AND status = ANY($2) AND end_time >= $3 AND end_time < $4
+		ORDER BY end_time
+	`
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, educatorId, entities.ConfirmedStatuses, from, to)
+}
+
+// GetCompletedSessionTotals aggregates approved bookings per educator that ended within a period. Prices are
+// summed as numeric, so the gross amount is exact.
+func (r *PayoutRepo) GetCompletedSessionTotals(ctx context.Context, from, to time.Time) ([]*SessionTotals, error) {
+	const query = `
+		SELECT educator_id, COUNT(*) AS session_count, (COALESCE(SUM(price), 0) * 100)::bigint AS gross_cents
+		FROM booking
+		WHERE status = ANY($1) AND end_time >= $2 AND end_time < $3
+		GROUP BY educator_id
+	`
+	return database.FetchMultiple[SessionTotals](ctx, r.db, query, entities.ConfirmedStatuses, from, to)
+}
+
+// GetCommissionRules retrieves commission overrides for the given educators
+func (r *PayoutRepo) GetCommissionRules(ctx context.Context, educatorIds []uuid.UUID) ([]*entities.CommissionRule, error) {
+	const query = `
+		SELECT educator_id, commission_percent, fixed_fee, created_at, updated_at
+		FROM commission_rule
+		WHERE educator_id = ANY($1)
+	`
+	return database.FetchMultiple[entities.CommissionRule](ctx, r.db, query, pq.Array(educatorIds))
+}
+
+// GetOverlappingStatements retrieves statements whose period overlaps the given one, the same period included
+func (r *PayoutRepo) GetOverlappingStatements(ctx context.Context, periodStart, periodEnd time.Time) ([]*entities.PayoutStatement, error) {
+	const query = `
+		SELECT id, educator_id, period_start, period_end, session_count, gross_amount, fee_amount, net_amount, currency, created_at
+		FROM payout_statement
+		WHERE period_start < $2 AND period_end > $1
+		ORDER BY educator_id, period_start
+	`
+	return database.FetchMultiple[entities.PayoutStatement](ctx, r.db, query, periodStart, periodEnd)
+}
+
+// GetEducatorStatements retrieves statements of an educator, newest first
+func (r *PayoutRepo) GetEducatorStatements(ctx context.Context, educatorId uuid.UUID, skip int, take int) ([]*entities.PayoutStatement, error) {
+	const query = `
+		SELECT id, educator_id, period_start, period_end, session_count, gross_amount, fee_amount, net_amount, currency, created_at
+		FROM payout_statement
+		WHERE educator_id = $1
+		ORDER BY period_start DESC OFFSET $2 LIMIT $3
+	`
+	return database.FetchMultiple[entities.PayoutStatement](ctx, r.db, query, educatorId, skip, take)
+}
+
+// AddPayoutStatement adds a new payout statement and returns its Id. The statements of the educator are locked
+// while the period is checked, so concurrent generations cannot pay a session twice. It returns false when the
+// educator already has a statement for the same period, and a conflict when another statement overlaps it.
+func (r *PayoutRepo) AddPayoutStatement(ctx context.Context, statement *entities.PayoutStatement) (int64, bool, error) {
+	const lockQuery = `SELECT pg_advisory_xact_lock(hashtextextended('payout_statement:' || $1::text, 0))`
+	const overlapQuery = `
+		SELECT id, educator_id, period_start, period_end, session_count, gross_amount, fee_amount, net_amount, currency, created_at
+		FROM payout_statement
+		WHERE educator_id = $1 AND period_start < $3 AND period_end > $2
+		ORDER BY period_start
+		LIMIT 1
+	`
+	const insertQuery = `
+		INSERT INTO payout_statement (educator_id, period_start, period_end, session_count, gross_amount, fee_amount, net_amount, currency, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return 0, false, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, lockQuery, statement.EducatorId); err != nil {
+		return 0, false, apperrors.NewInternal(err)
+	}
+
+	var existing entities.PayoutStatement
+	err = tx.GetContext(ctx, &existing, overlapQuery, statement.EducatorId, statement.PeriodStart, statement.PeriodEnd)
+	if err == nil {
+		if existing.PeriodStart.Equal(statement.PeriodStart) && existing.PeriodEnd.Equal(statement.PeriodEnd) {
+			return 0, false, nil
+		}
+		return 0, false, overlappingStatementError(&existing)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, false, apperrors.NewInternal(err)
+	}
+
+	var id int64
+	err = tx.GetContext(ctx, &id, insertQuery,
+		statement.EducatorId, statement.PeriodStart, statement.PeriodEnd, statement.SessionCount,
+		statement.GrossAmount, statement.FeeAmount, statement.NetAmount, statement.Currency, statement.CreatedAt)
+	if err != nil {
+		return 0, false, apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, apperrors.NewInternal(err)
+	}
+	return id, true, nil
+}
+
+// UpsertCommissionRule creates or replaces the commission override of an educator
+func (r *PayoutRepo) UpsertCommissionRule(ctx context.Context, rule *entities.CommissionRule) error {
+	const query = `
+		INSERT INTO commission_rule (educator_id, commission_percent, fixed_fee, created_at, updated_at)
+		VALUES (:educator_id, :commission_percent, :fixed_fee, :created_at, :updated_at)
+		ON CONFLICT (educator_id) DO UPDATE
+		SET commission_percent = EXCLUDED.commission_percent, fixed_fee = EXCLUDED.fixed_fee, updated_at = EXCLUDED.updated_at
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, rule)
+}
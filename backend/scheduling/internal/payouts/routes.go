@@ -0,0 +1,22 @@
+package payouts
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *PayoutHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.With(middleware.RequireRole(auth.EducatorRole)).Get("/report", handler.GetPayoutReport)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Get("/statements", handler.GetMyStatements)
+	r.With(middleware.RequireRole(auth.AdminRole)).Post("/statements", handler.GenerateStatements)
+	r.With(middleware.RequireRole(auth.AdminRole)).Put("/commission-rules/{educatorId}", handler.SetCommissionRule)
+
+	return r
+}
@@ -0,0 +1,208 @@
+package payouts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+type PayoutRepository interface {
+	GetCompletedBookings(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.Booking, error)
+	GetCompletedSessionTotals(ctx context.Context, from, to time.Time) ([]*SessionTotals, error)
+	GetCommissionRules(ctx context.Context, educatorIds []uuid.UUID) ([]*entities.CommissionRule, error)
+	GetOverlappingStatements(ctx context.Context, periodStart, periodEnd time.Time) ([]*entities.PayoutStatement, error)
+	GetEducatorStatements(ctx context.Context, educatorId uuid.UUID, skip int, take int) ([]*entities.PayoutStatement, error)
+	AddPayoutStatement(ctx context.Context, statement *entities.PayoutStatement) (int64, bool, error)
+	UpsertCommissionRule(ctx context.Context, rule *entities.CommissionRule) error
+}
+
+type PayoutService struct {
+	log       logger.Logger
+	repo      PayoutRepository
+	cfg       *config.PayoutConfig
+	publisher *messaging.Publisher
+}
+
+func NewPayoutService(
+	log logger.Logger,
+	repo PayoutRepository,
+	cfg *config.PayoutConfig,
+	publisher *messaging.Publisher,
+) *PayoutService {
+	return &PayoutService{log: log, repo: repo, cfg: cfg, publisher: publisher}
+}
+
+func (s *PayoutService) GetMyPayoutReport(ctx context.Context, from time.Time, to time.Time) (*PayoutReportResponse, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	return s.GetPayoutReport(ctx, userId, from, to)
+}
+
+func (s *PayoutService) GetPayoutReport(ctx context.Context, educatorId uuid.UUID, from time.Time, to time.Time) (*PayoutReportResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if !from.Before(to) {
+		return nil, apperrors.NewBadRequestError("fromDate must be before toDate", apperrors.ErrPayoutPeriod)
+	}
+
+	bookings, err := s.repo.GetCompletedBookings(ctx, educatorId, from, completedBefore(to))
+	if err != nil {
+		log.Error("failed to get completed bookings", err)
+		return nil, err
+	}
+
+	rule, err := s.getCommissionRule(ctx, educatorId)
+	if err != nil {
+		log.Error("failed to get commission rule", err)
+		return nil, err
+	}
+
+	var gross int64
+	for _, b := range bookings {
+		gross += toCents(b.Price)
+	}
+	fee, net := calculatePayout(gross, len(bookings), rule)
+
+	return &PayoutReportResponse{
+		EducatorId:   educatorId,
+		PeriodStart:  from,
+		PeriodEnd:    to,
+		SessionCount: len(bookings),
+		GrossAmount:  fromCents(gross),
+		FeeAmount:    fromCents(fee),
+		NetAmount:    fromCents(net),
+		Currency:     s.cfg.Currency,
+		Sessions:     MapBookingsToPayoutSessions(bookings),
+	}, nil
+}
+
+func (s *PayoutService) GetMyStatements(ctx context.Context, skip int, take int) ([]*PayoutStatementResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	statements, err := s.repo.GetEducatorStatements(ctx, userId, skip, take)
+	if err != nil {
+		log.Error("failed to get payout statements", err)
+		return nil, err
+	}
+
+	return MapStatementsToResponse(statements), nil
+}
+
+// GenerateStatements creates a payout statement for every educator with completed sessions in the period
+// and publishes a statement event per created record. Educators that already have a statement for the
+// exact same period are skipped, so the operation is safe to repeat. A period overlapping another statement
+// of an educator is rejected before any statement is created, so no session is paid out twice.
+func (s *PayoutService) GenerateStatements(ctx context.Context, request *GenerateStatementsRequest) (*GenerateStatementsResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if request.PeriodEnd.After(time.Now().UTC()) {
+		return nil, apperrors.NewUnprocessedEntity("Payout period has not ended yet", apperrors.ErrPayoutPeriod)
+	}
+
+	totals, err := s.repo.GetCompletedSessionTotals(ctx, request.PeriodStart, request.PeriodEnd)
+	if err != nil {
+		log.Error("failed to get completed session totals", err)
+		return nil, err
+	}
+
+	existing, err := s.repo.GetOverlappingStatements(ctx, request.PeriodStart, request.PeriodEnd)
+	if err != nil {
+		log.Error("failed to get existing payout statements", err)
+		return nil, err
+	}
+
+	generated := make(map[uuid.UUID]struct{}, len(existing))
+	for _, st := range existing {
+		if !st.PeriodStart.Equal(request.PeriodStart) || !st.PeriodEnd.Equal(request.PeriodEnd) {
+			return nil, overlappingStatementError(st)
+		}
+		generated[st.EducatorId] = struct{}{}
+	}
+
+	rules, err := s.getCommissionRules(ctx, totals)
+	if err != nil {
+		log.Error("failed to get commission rules", err)
+		return nil, err
+	}
+
+	response := &GenerateStatementsResponse{}
+	for _, t := range totals {
+		if _, ok := generated[t.EducatorId]; ok {
+			response.Skipped++
+			continue
+		}
+
+		fee, net := calculatePayout(t.GrossCents, t.SessionCount, rules[t.EducatorId])
+		statement := MapTotalsToStatement(t, request, fee, net, s.cfg.Currency)
+
+		id, created, err := s.repo.AddPayoutStatement(ctx, statement)
+		if err != nil {
+			log.Error("failed to add payout statement", err)
+			return nil, err
+		}
+		// A concurrent generation for the same period created the statement first
+		if !created {
+			response.Skipped++
+			continue
+		}
+		statement.Id = id
+		response.Generated++
+
+		err = s.publisher.Publish(
+			ctx,
+			messaging.PayoutStatementKey,
+			messaging.NewPayoutStatementGeneratedEvent(
+				statement.Id,
+				statement.EducatorId.String(),
+				statement.PeriodStart.Format(time.RFC3339),
+				statement.PeriodEnd.Format(time.RFC3339),
+				statement.SessionCount,
+				statement.GrossAmount,
+				statement.FeeAmount,
+				statement.NetAmount,
+				statement.Currency,
+			),
+		)
+		if err != nil {
+			log.Error("failed to publish payout statement event", err)
+		}
+	}
+
+	return response, nil
+}
+
+func overlappingStatementError(st *entities.PayoutStatement) error {
+	return apperrors.NewConflict(
+		fmt.Sprintf("Payout period overlaps statement %d from %s to %s of educator %s", st.Id,
+			st.PeriodStart.Format(time.RFC3339), st.PeriodEnd.Format(time.RFC3339), st.EducatorId),
+		apperrors.ErrPayoutPeriod,
+	)
+}
+
+func (s *PayoutService) SetCommissionRule(ctx context.Context, educatorId uuid.UUID, request *CommissionRuleRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.repo.UpsertCommissionRule(ctx, MapRequestToCommissionRule(educatorId, request)); err != nil {
+		log.Error("failed to save commission rule", err)
+		return err
+	}
+
+	return nil
+}
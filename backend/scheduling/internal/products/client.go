@@ -8,10 +8,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/httpclient"
 )
 
+// ErrUnavailable is returned when the learning service can not be reached within the retry budget, fails
+// with a server error or is skipped while its circuit breaker is open
+var ErrUnavailable = errors.New("learning service unavailable")
+
 var (
 	Unschedulable = "UNSCHEDULABLE"
 	Valid         = "VALID"
@@ -24,10 +30,11 @@ type ProductSchedulingMetadataRequest struct {
 }
 
 type ProductSchedulingMetadataResponse struct {
-	State           string `json:"state"`
-	ErrorMessage    string `json:"errorMessage"`
-	Title           string `json:"title"`
-	MaxParticipants int    `json:"maxParticipants"`
+	State           string  `json:"state"`
+	ErrorMessage    string  `json:"errorMessage"`
+	Title           string  `json:"title"`
+	MaxParticipants int     `json:"maxParticipants"`
+	Price           float64 `json:"price"`
 }
 
 type EnrollmentBookingMetadataRequest struct {
@@ -35,25 +42,39 @@ type EnrollmentBookingMetadataRequest struct {
 }
 
 type EnrollmentBookingMetadataResponse struct {
-	IsValid      bool   `json:"isValid"`
-	ErrorMessage string `json:"errorMessage"`
-	EducatorId   string `json:"educatorId"`
-	ProductId    *int64 `json:"productId"`
-	Title        string `json:"title"`
+	IsValid      bool    `json:"isValid"`
+	ErrorMessage string  `json:"errorMessage"`
+	EducatorId   string  `json:"educatorId"`
+	ProductId    *int64  `json:"productId"`
+	Title        string  `json:"title"`
+	Price        float64 `json:"price"`
 }
 
 type ProductServiceClient struct {
 	baseURL    string
-	httpClient *http.Client
+	httpClient *httpclient.Client
 }
 
-func NewProductServiceClient(cfg config.ExternalServiceConfig, httpClient *http.Client) *ProductServiceClient {
+func NewProductServiceClient(cfg config.ExternalServiceConfig, httpClient *httpclient.Client) *ProductServiceClient {
 	return &ProductServiceClient{
 		baseURL:    cfg.LearningServiceUrl,
 		httpClient: httpClient,
 	}
 }
 
+// LearningPolicy is the retry and circuit breaker policy of calls to the learning service
+func LearningPolicy(cfg config.ExternalServiceConfig) httpclient.Policy {
+	return httpclient.Policy{
+		MaxAttempts:     cfg.LearningMaxAttempts,
+		AttemptTimeout:  time.Duration(cfg.LearningAttemptTimeoutMs) * time.Millisecond,
+		Budget:          time.Duration(cfg.LearningBudgetMs) * time.Millisecond,
+		BaseBackoff:     time.Duration(cfg.LearningBackoffMs) * time.Millisecond,
+		MaxBackoff:      time.Duration(cfg.LearningMaxBackoffMs) * time.Millisecond,
+		BreakerFailures: cfg.LearningBreakerFailures,
+		BreakerCooldown: time.Duration(cfg.LearningBreakerCooldownSeconds) * time.Second,
+	}
+}
+
 func (s *ProductServiceClient) GetSchedulingMetadata(
 	ctx context.Context,
 	productId int64,
@@ -73,7 +94,7 @@ func (s *ProductServiceClient) GetSchedulingMetadata(
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fullURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", fullURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, err
 	}
@@ -83,10 +104,13 @@ func (s *ProductServiceClient) GetSchedulingMetadata(
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, fmt.Errorf("%w: request failed with status %s", ErrUnavailable, resp.Status)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, errors.New("Request failed with status:" + resp.Status)
 	}
@@ -115,7 +139,7 @@ func (s *ProductServiceClient) GetBookingMetadata(
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fullURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", fullURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, err
 	}
@@ -125,10 +149,13 @@ func (s *ProductServiceClient) GetBookingMetadata(
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, fmt.Errorf("%w: request failed with status %s", ErrUnavailable, resp.Status)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, errors.New("Request failed with status:" + resp.Status)
 	}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// maxBuckets bounds the buckets kept in memory, full ones are dropped beyond it
+const maxBuckets = 10000
+
+// Policy is a token bucket refilled at Rate tokens per second and holding up to Burst tokens. Every
+// request takes a token and is rejected when none is left.
+type Policy struct {
+	Rate  float64
+	Burst int
+}
+
+// PerMinute returns the policy allowing perMinute requests a minute, burst of them at once
+func PerMinute(perMinute int, burst int) Policy {
+	return Policy{Rate: float64(perMinute) / 60, Burst: burst}
+}
+
+// refill returns how long an empty bucket takes to fill up again
+func (p Policy) refill() time.Duration {
+	return time.Duration(float64(p.Burst) / p.Rate * float64(time.Second))
+}
+
+// Limiter takes a token from the bucket of a key. When none is left the request is rejected and the time
+// until the next token is returned.
+type Limiter interface {
+	Allow(ctx context.Context, key string, policy Policy) (bool, time.Duration, error)
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// MemoryLimiter keeps the buckets in memory, so every replica enforces the limits on its own share of the
+// traffic
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+func (l *MemoryLimiter) Allow(_ context.Context, key string, policy Policy) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxBuckets {
+			l.prune(now, policy)
+		}
+		b = &bucket{tokens: float64(policy.Burst), updated: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(policy.Burst), b.tokens+now.Sub(b.updated).Seconds()*policy.Rate)
+	b.updated = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / policy.Rate * float64(time.Second))
+		return false, wait, nil
+	}
+	b.tokens--
+	return true, 0, nil
+}
+
+// prune drops the buckets idle long enough to have filled up, they allow as much as a new one
+func (l *MemoryLimiter) prune(now time.Time, policy Policy) {
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= policy.refill() {
+			delete(l.buckets, key)
+		}
+	}
+}
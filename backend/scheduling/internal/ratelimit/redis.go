@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/cache"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const keyPrefix = "ratelimit:"
+
+// takeTokenScript refills and takes a token from a bucket kept as a hash of its tokens and the time it was
+// last updated, in milliseconds of the Redis clock so replicas with skewed clocks share one bucket. It
+// returns zero when a token was taken and the milliseconds until the next one otherwise.
+const takeTokenScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1]) or burst
+local updated = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - updated) * rate)
+
+local wait = 0
+if tokens < 1 then
+	wait = math.ceil((1 - tokens) / rate)
+else
+	tokens = tokens - 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate))
+return wait
+`
+
+// RedisLimiter keeps the buckets in Redis, so the limits hold across replicas. While Redis fails the
+// buckets are kept in memory instead, limiting every replica on its own rather than not at all.
+type RedisLimiter struct {
+	log      logger.Logger
+	client   *cache.RedisClient
+	fallback *MemoryLimiter
+}
+
+func NewRedisLimiter(log logger.Logger, client *cache.RedisClient) *RedisLimiter {
+	return &RedisLimiter{log: log, client: client, fallback: NewMemoryLimiter()}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, policy Policy) (bool, time.Duration, error) {
+	// The script works in milliseconds
+	rate := strconv.FormatFloat(policy.Rate/1000, 'g', -1, 64)
+	reply, err := l.client.Eval(ctx, takeTokenScript, []string{keyPrefix + key}, rate, strconv.Itoa(policy.Burst))
+	if err == nil {
+		wait, ok := reply.(int64)
+		if !ok {
+			err = fmt.Errorf("unexpected rate limit reply %v", reply)
+		} else {
+			return wait == 0, time.Duration(wait) * time.Millisecond, nil
+		}
+	}
+
+	logger.FromContext(ctx, l.log).Warnf("Shared rate limit unavailable, limiting in memory: %v", err)
+	return l.fallback.Allow(ctx, key, policy)
+}
@@ -0,0 +1,40 @@
+package readonly
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// maxReasonLength keeps reasons short enough to show to operators at a glance
+const maxReasonLength = 500
+
+// swagger:model ReadOnlyModeRequest
+type ReadOnlyModeRequest struct {
+	Enabled bool    `json:"enabled"`
+	Reason  *string `json:"reason"`
+}
+
+// swagger:model ReadOnlyModeResponse
+type ReadOnlyModeResponse struct {
+	Enabled bool    `json:"enabled"`
+	Reason  *string `json:"reason"`
+	// Since is when the mode was last switched, or this instance started in it
+	Since time.Time `json:"since"`
+}
+
+func (r *ReadOnlyModeRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if r.Reason != nil && len(*r.Reason) > maxReasonLength {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Reason",
+			Message: "must be at most 500 characters",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Read-only mode failed validation", apperrors.ErrValidationFailed, errors)
+	}
+	return nil
+}
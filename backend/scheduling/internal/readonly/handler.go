@@ -0,0 +1,68 @@
+package readonly
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type ReadOnlyHandler struct {
+	service *ReadOnlyService
+}
+
+func NewReadOnlyHandler(service *ReadOnlyService) *ReadOnlyHandler {
+	return &ReadOnlyHandler{service: service}
+}
+
+// GetReadOnlyMode retrieves the read-only mode in effect.
+// @Summary      Retrieve read-only mode
+// @Description  Returns whether this instance refuses writes, why and since when.
+// @Tags         ReadOnly
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  ReadOnlyModeResponse  "Read-only mode"
+// @Router       /api/v1/admin/read-only [get]
+// @Security 	 BearerAuth
+func (h *ReadOnlyHandler) GetReadOnlyMode(w http.ResponseWriter, r *http.Request) {
+	mode, err := h.service.GetReadOnlyMode(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, mode)
+}
+
+// UpdateReadOnlyMode switches the read-only mode.
+// @Summary      Switch read-only mode
+// @Description  Switches every running instance into or out of read-only mode until they restart, such as while the database is restored from a backup or a region fails over. In read-only mode reads are served, writes are answered with 503, the gRPC hold confirmation is unavailable, consumed messages are requeued and scheduled jobs are skipped. This endpoint stays writable. Instances started later take the configured mode.
+// @Tags         ReadOnly
+// @Accept       json
+// @Produce      json
+// @Param        mode  body      ReadOnlyModeRequest   true  "Read-only mode"
+// @Success      200   {object}  ReadOnlyModeResponse  "Read-only mode in effect"
+// @Failure      400   {object}  error                 "Invalid input"
+// @Router       /api/v1/admin/read-only [put]
+// @Security 	 BearerAuth
+func (h *ReadOnlyHandler) UpdateReadOnlyMode(w http.ResponseWriter, r *http.Request) {
+	var request *ReadOnlyModeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	mode, err := h.service.UpdateReadOnlyMode(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, mode)
+}
@@ -0,0 +1,9 @@
+package readonly
+
+func MapStateToResponse(state State) *ReadOnlyModeResponse {
+	var reason *string
+	if state.Reason != "" {
+		reason = &state.Reason
+	}
+	return &ReadOnlyModeResponse{Enabled: state.Enabled, Reason: reason, Since: state.Since}
+}
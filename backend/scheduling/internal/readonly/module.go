@@ -0,0 +1,18 @@
+package readonly
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/broadcast"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeReadOnlyService(log logger.Logger, mode *Switch, broadcaster *broadcast.Broadcaster) *ReadOnlyService {
+	service := NewReadOnlyService(log, mode, broadcaster)
+	return service
+}
+
+func InitializeReadOnlyHTTPHandler(service *ReadOnlyService) http.Handler {
+	handler := NewReadOnlyHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,24 @@
+package readonly
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+// AdminPath is mounted with the read-only mode switch, it stays writable so the mode can be switched off
+const AdminPath = "/api/v1/admin/read-only"
+
+func Routes(handler *ReadOnlyHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.AdminRole))
+	r.Get("/", handler.GetReadOnlyMode)
+	r.Put("/", handler.UpdateReadOnlyMode)
+
+	return r
+}
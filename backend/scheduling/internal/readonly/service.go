@@ -0,0 +1,68 @@
+package readonly
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/broadcast"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// Broadcaster tells the other instances about the switched mode
+type Broadcaster interface {
+	Broadcast(ctx context.Context, kind string, data any) error
+}
+
+// ReadOnlyService switches the read-only mode at runtime. Switches are broadcast to the running instances
+// but not persisted, instances started later take the configured mode.
+type ReadOnlyService struct {
+	log         logger.Logger
+	mode        *Switch
+	broadcaster Broadcaster
+}
+
+func NewReadOnlyService(log logger.Logger, mode *Switch, broadcaster Broadcaster) *ReadOnlyService {
+	return &ReadOnlyService{log: log, mode: mode, broadcaster: broadcaster}
+}
+
+func (s *ReadOnlyService) GetReadOnlyMode(ctx context.Context) (*ReadOnlyModeResponse, error) {
+	return MapStateToResponse(s.mode.State()), nil
+}
+
+// UpdateReadOnlyMode switches the mode on this instance and broadcasts it to the others
+func (s *ReadOnlyService) UpdateReadOnlyMode(ctx context.Context, request *ReadOnlyModeRequest) (*ReadOnlyModeResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	reason := ""
+	if request.Reason != nil {
+		reason = *request.Reason
+	}
+
+	if s.mode.Set(request.Enabled, reason, time.Now().UTC()) {
+		if request.Enabled {
+			log.Warnf("Read-only mode switched on: %s", reason)
+		} else {
+			log.Warn("Read-only mode switched off")
+		}
+	}
+
+	state := s.mode.State()
+	if err := s.broadcaster.Broadcast(ctx, broadcast.SignalReadOnly, state); err != nil {
+		log.Error("failed to broadcast read-only mode", err)
+	}
+	return MapStateToResponse(state), nil
+}
+
+// ApplyReadOnlyMode takes over the mode broadcast by another instance
+func (s *ReadOnlyService) ApplyReadOnlyMode(ctx context.Context, data json.RawMessage) error {
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+
+	if s.mode.Set(state.Enabled, state.Reason, state.Since) {
+		logger.FromContext(ctx, s.log).Warnf("Read-only mode switched by another instance, enabled: %t", state.Enabled)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package readonly
+
+import (
+	"sync"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+)
+
+// State is the read-only mode of the service, with the reason it was switched on and when it was last switched
+type State struct {
+	Enabled bool      `json:"enabled"`
+	Reason  string    `json:"reason"`
+	Since   time.Time `json:"since"`
+}
+
+// Switch holds the read-only mode the HTTP routes, the gRPC server, the consumer and the job scheduler check
+// before they change data. It is safe for concurrent use.
+type Switch struct {
+	mu    sync.RWMutex
+	state State
+}
+
+// NewSwitch starts in the mode of the configuration
+func NewSwitch(cfg *config.ReadOnlyConfig) *Switch {
+	state := State{Enabled: cfg.Enabled, Since: time.Now().UTC()}
+	if cfg.Enabled {
+		state.Reason = cfg.Reason
+	}
+	return &Switch{state: state}
+}
+
+// ReadOnly reports whether writes are refused
+func (s *Switch) ReadOnly() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.state.Enabled
+}
+
+func (s *Switch) State() State {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.state
+}
+
+// Set switches the mode. The reason is kept while the mode is on and dropped when it is switched off. False
+// is returned when the mode was already in effect, its reason is updated still.
+func (s *Switch) Set(enabled bool, reason string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !enabled {
+		reason = ""
+	}
+	changed := s.state.Enabled != enabled
+	s.state.Reason = reason
+	if changed {
+		s.state.Enabled = enabled
+		s.state.Since = now
+	}
+	return changed
+}
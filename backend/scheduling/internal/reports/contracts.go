@@ -0,0 +1,98 @@
+package reports
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// swagger:model UtilizationReportResponse
+type UtilizationReportResponse struct {
+	PeriodStart time.Time                      `json:"periodStart"`
+	PeriodEnd   time.Time                      `json:"periodEnd"`
+	Educators   []*EducatorUtilizationResponse `json:"educators"`
+}
+
+// swagger:model EducatorUtilizationResponse
+type EducatorUtilizationResponse struct {
+	EducatorId         uuid.UUID `json:"educatorId"`
+	AvailableMinutes   float64   `json:"availableMinutes"`
+	BookedMinutes      float64   `json:"bookedMinutes"`
+	UtilizationPercent float64   `json:"utilizationPercent"`
+}
+
+// swagger:model CancellationReportResponse
+type CancellationReportResponse struct {
+	PeriodStart time.Time                       `json:"periodStart"`
+	PeriodEnd   time.Time                       `json:"periodEnd"`
+	Educators   []*EducatorCancellationResponse `json:"educators"`
+}
+
+// swagger:model EducatorCancellationResponse
+type EducatorCancellationResponse struct {
+	EducatorId          uuid.UUID `json:"educatorId"`
+	TotalBookings       int       `json:"totalBookings"`
+	CancelledBookings   int       `json:"cancelledBookings"`
+	CancellationPercent float64   `json:"cancellationPercent"`
+}
+
+// swagger:model CancellationReasonReportResponse
+type CancellationReasonReportResponse struct {
+	PeriodStart   time.Time                              `json:"periodStart"`
+	PeriodEnd     time.Time                              `json:"periodEnd"`
+	Cancellations int                                    `json:"cancellations"`
+	Reasons       []*CancellationReasonResponse          `json:"reasons"`
+	Educators     []*EducatorCancellationReasonsResponse `json:"educators"`
+}
+
+// swagger:model EducatorCancellationReasonsResponse
+type EducatorCancellationReasonsResponse struct {
+	EducatorId    uuid.UUID                     `json:"educatorId"`
+	Cancellations int                           `json:"cancellations"`
+	Reasons       []*CancellationReasonResponse `json:"reasons"`
+}
+
+// swagger:model CancellationReasonResponse
+type CancellationReasonResponse struct {
+	Reason        int     `json:"reason"`
+	Name          string  `json:"name"`
+	Cancellations int     `json:"cancellations"`
+	Percent       float64 `json:"percent"`
+}
+
+// swagger:model RevenueReportResponse
+type RevenueReportResponse struct {
+	PeriodStart time.Time                `json:"periodStart"`
+	PeriodEnd   time.Time                `json:"periodEnd"`
+	Interval    string                   `json:"interval"`
+	Currency    string                   `json:"currency"`
+	Periods     []*RevenuePeriodResponse `json:"periods"`
+}
+
+// swagger:model RevenuePeriodResponse
+type RevenuePeriodResponse struct {
+	PeriodStart  time.Time `json:"periodStart"`
+	SessionCount int       `json:"sessionCount"`
+	GrossAmount  float64   `json:"grossAmount"`
+}
+
+// swagger:model RetentionReportResponse
+type RetentionReportResponse struct {
+	PeriodStart time.Time                  `json:"periodStart"`
+	PeriodEnd   time.Time                  `json:"periodEnd"`
+	Cohorts     []*CohortRetentionResponse `json:"cohorts"`
+}
+
+// swagger:model CohortRetentionResponse
+type CohortRetentionResponse struct {
+	CohortMonth time.Time                 `json:"cohortMonth"`
+	Students    int                       `json:"students"`
+	Retention   []*RetentionPointResponse `json:"retention"`
+}
+
+// swagger:model RetentionPointResponse
+type RetentionPointResponse struct {
+	MonthOffset      int     `json:"monthOffset"`
+	ActiveStudents   int     `json:"activeStudents"`
+	RetentionPercent float64 `json:"retentionPercent"`
+}
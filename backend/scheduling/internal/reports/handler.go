@@ -0,0 +1,176 @@
+package reports
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type ReportHandler struct {
+	service *ReportService
+}
+
+func NewReportHandler(service *ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+// GetUtilizationReport retrieves booked versus available time per educator.
+// @Summary      Retrieve teacher utilization
+// @Description  Compares each educator's working period time with time occupied by scheduled events and approved bookings starting within the 'fromDate' and 'toDate' range.
+// @Tags         Report
+// @Accept       json
+// @Produce      json
+// @Param        fromDate  query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate    query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Success      200       {object}  UtilizationReportResponse  "Utilization report"
+// @Failure      400       {object}  error                      "Invalid input parameters"
+// @Router       /api/v1/reports/utilization [get]
+// @Security 	 BearerAuth
+func (h *ReportHandler) GetUtilizationReport(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parsePeriod(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	report, err := h.service.GetUtilizationReport(r.Context(), from, to)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, report)
+}
+
+// GetCancellationReport retrieves booking cancellation rates per educator.
+// @Summary      Retrieve cancellation rates
+// @Description  Counts bookings and cancellations per educator for bookings starting within the 'fromDate' and 'toDate' range.
+// @Tags         Report
+// @Accept       json
+// @Produce      json
+// @Param        fromDate  query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate    query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Success      200       {object}  CancellationReportResponse  "Cancellation report"
+// @Failure      400       {object}  error                       "Invalid input parameters"
+// @Router       /api/v1/reports/cancellations [get]
+// @Security 	 BearerAuth
+func (h *ReportHandler) GetCancellationReport(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parsePeriod(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	report, err := h.service.GetCancellationReport(r.Context(), from, to)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, report)
+}
+
+// GetCancellationReasonReport retrieves student cancellation reasons platform-wide and per educator.
+// @Summary      Retrieve cancellation reasons
+// @Description  Breaks student cancellations made within the 'fromDate' and 'toDate' range down by reason of the cancellation taxonomy, platform-wide and per educator.
+// @Tags         Report
+// @Accept       json
+// @Produce      json
+// @Param        fromDate  query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate    query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Success      200       {object}  CancellationReasonReportResponse  "Cancellation reason report"
+// @Failure      400       {object}  error                             "Invalid input parameters"
+// @Router       /api/v1/reports/cancellation-reasons [get]
+// @Security 	 BearerAuth
+func (h *ReportHandler) GetCancellationReasonReport(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parsePeriod(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	report, err := h.service.GetCancellationReasonReport(r.Context(), from, to)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, report)
+}
+
+// GetRevenueReport retrieves session revenue grouped by period.
+// @Summary      Retrieve revenue by period
+// @Description  Aggregates revenue of approved sessions that ended within the 'fromDate' and 'toDate' range into day, week or month buckets.
+// @Tags         Report
+// @Accept       json
+// @Produce      json
+// @Param        fromDate  query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate    query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        interval  query     string  false  "Bucket size: day, week or month (default month)"
+// @Success      200       {object}  RevenueReportResponse  "Revenue report"
+// @Failure      400       {object}  error                  "Invalid input parameters"
+// @Router       /api/v1/reports/revenue [get]
+// @Security 	 BearerAuth
+func (h *ReportHandler) GetRevenueReport(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parsePeriod(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	interval := r.URL.Query().Get("interval")
+	if interval == "" {
+		interval = "month"
+	}
+
+	report, err := h.service.GetRevenueReport(r.Context(), interval, from, to)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, report)
+}
+
+// GetRetentionReport retrieves monthly student cohort retention.
+// @Summary      Retrieve cohort retention
+// @Description  Groups students by the month of their first approved booking within the 'fromDate' and 'toDate' range and reports how many stay active in following months.
+// @Tags         Report
+// @Accept       json
+// @Produce      json
+// @Param        fromDate  query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate    query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Success      200       {object}  RetentionReportResponse  "Retention report"
+// @Failure      400       {object}  error                    "Invalid input parameters"
+// @Router       /api/v1/reports/retention [get]
+// @Security 	 BearerAuth
+func (h *ReportHandler) GetRetentionReport(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parsePeriod(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	report, err := h.service.GetRetentionReport(r.Context(), from, to)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, report)
+}
+
+func parsePeriod(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, error) {
+	from, err := api.ParseTimeQuery(w, r, "fromDate", time.RFC3339)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	to, err := api.ParseTimeQuery(w, r, "toDate", time.RFC3339)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, to, nil
+}
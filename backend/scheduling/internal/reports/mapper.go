@@ -0,0 +1,140 @@
+package reports
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapUtilizationRowsToResponse(rows []*UtilizationRow) []*EducatorUtilizationResponse {
+	response := make([]*EducatorUtilizationResponse, len(rows))
+	for i, r := range rows {
+		response[i] = &EducatorUtilizationResponse{
+			EducatorId:         r.EducatorId,
+			AvailableMinutes:   roundValue(r.AvailableMinutes),
+			BookedMinutes:      roundValue(r.BookedMinutes),
+			UtilizationPercent: percent(r.BookedMinutes, r.AvailableMinutes),
+		}
+	}
+	return response
+}
+
+func MapCancellationRowsToResponse(rows []*CancellationRow) []*EducatorCancellationResponse {
+	response := make([]*EducatorCancellationResponse, len(rows))
+	for i, r := range rows {
+		response[i] = &EducatorCancellationResponse{
+			EducatorId:          r.EducatorId,
+			TotalBookings:       r.TotalBookings,
+			CancelledBookings:   r.CancelledBookings,
+			CancellationPercent: percent(float64(r.CancelledBookings), float64(r.TotalBookings)),
+		}
+	}
+	return response
+}
+
+// reasonCounts holds the cancellations of every reason of the taxonomy
+type reasonCounts [entities.ReasonOther + 1]int
+
+// MapCancellationReasonRowsToReport sums ordered educator rows into the platform-wide breakdown and one
+// breakdown per educator. Every reason of the taxonomy is listed, those without cancellations with zero.
+func MapCancellationReasonRowsToReport(rows []*CancellationReasonRow) *CancellationReasonReportResponse {
+	var platform reasonCounts
+	educators := []*EducatorCancellationReasonsResponse{}
+
+	var current reasonCounts
+	for i, r := range rows {
+		if r.Reason >= 0 && r.Reason <= entities.ReasonOther {
+			platform[r.Reason] += r.Cancellations
+			current[r.Reason] += r.Cancellations
+		}
+
+		if i == len(rows)-1 || rows[i+1].EducatorId != r.EducatorId {
+			educators = append(educators, mapEducatorReasonCounts(r.EducatorId, current))
+			current = reasonCounts{}
+		}
+	}
+
+	total, reasons := mapReasonCounts(platform)
+	return &CancellationReasonReportResponse{Cancellations: total, Reasons: reasons, Educators: educators}
+}
+
+// MapCancellationReasonRowsToEducator sums the rows of a single educator into their breakdown
+func MapCancellationReasonRowsToEducator(educatorId uuid.UUID, rows []*CancellationReasonRow) *EducatorCancellationReasonsResponse {
+	var counts reasonCounts
+	for _, r := range rows {
+		if r.Reason >= 0 && r.Reason <= entities.ReasonOther {
+			counts[r.Reason] += r.Cancellations
+		}
+	}
+	return mapEducatorReasonCounts(educatorId, counts)
+}
+
+func mapEducatorReasonCounts(educatorId uuid.UUID, counts reasonCounts) *EducatorCancellationReasonsResponse {
+	total, reasons := mapReasonCounts(counts)
+	return &EducatorCancellationReasonsResponse{EducatorId: educatorId, Cancellations: total, Reasons: reasons}
+}
+
+func mapReasonCounts(counts reasonCounts) (int, []*CancellationReasonResponse) {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+
+	reasons := make([]*CancellationReasonResponse, len(counts))
+	for i, c := range counts {
+		reasons[i] = &CancellationReasonResponse{
+			Reason:        i,
+			Name:          entities.CancellationReason(i).String(),
+			Cancellations: c,
+			Percent:       percent(float64(c), float64(total)),
+		}
+	}
+	return total, reasons
+}
+
+func MapRevenueRowsToResponse(rows []*RevenueRow) []*RevenuePeriodResponse {
+	response := make([]*RevenuePeriodResponse, len(rows))
+	for i, r := range rows {
+		response[i] = &RevenuePeriodResponse{
+			PeriodStart:  r.PeriodStart,
+			SessionCount: r.SessionCount,
+			GrossAmount:  roundValue(r.GrossAmount),
+		}
+	}
+	return response
+}
+
+// MapRetentionRowsToResponse folds ordered cohort rows into cohorts; offset 0 is the cohort size
+func MapRetentionRowsToResponse(rows []*RetentionRow) []*CohortRetentionResponse {
+	response := []*CohortRetentionResponse{}
+
+	var current *CohortRetentionResponse
+	for _, r := range rows {
+		if current == nil || !current.CohortMonth.Equal(r.CohortMonth) {
+			current = &CohortRetentionResponse{CohortMonth: r.CohortMonth, Retention: []*RetentionPointResponse{}}
+			response = append(response, current)
+		}
+		if r.MonthOffset == 0 {
+			current.Students = r.ActiveStudents
+		}
+		current.Retention = append(current.Retention, &RetentionPointResponse{
+			MonthOffset:      r.MonthOffset,
+			ActiveStudents:   r.ActiveStudents,
+			RetentionPercent: percent(float64(r.ActiveStudents), float64(current.Students)),
+		})
+	}
+	return response
+}
+
+func percent(part, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return roundValue(part / total * 100)
+}
+
+func roundValue(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,31 @@
+package reports
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/cache"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeReportService(log logger.Logger, db *sqlx.DB, cfg *config.ReportConfig, currency string, meter metric.Meter) (*ReportService, error) {
+	repo := NewReportRepository(db)
+	reports, err := cache.New[string, any]("reports", cache.Options{
+		TTL:        time.Duration(cfg.CacheTTLSeconds) * time.Second,
+		MaxEntries: cfg.CacheMaxEntries,
+	}, meter)
+	if err != nil {
+		return nil, err
+	}
+	service := NewReportService(log, repo, currency, reports)
+	return service, nil
+}
+
+func InitializeReportHTTPHandler(service *ReportService) http.Handler {
+	handler := NewReportHandler(service)
+	return Routes(handler)
+}
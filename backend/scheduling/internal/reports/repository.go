@@ -0,0 +1,149 @@
+package reports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// UtilizationRow holds available and booked minutes of a single educator
+type UtilizationRow struct {
+	EducatorId       uuid.UUID `db:"educator_id"`
+	AvailableMinutes float64   `db:"available_minutes"`
+	BookedMinutes    float64   `db:"booked_minutes"`
+}
+
+// CancellationRow holds booking totals and cancellations of a single educator
+type CancellationRow struct {
+	EducatorId        uuid.UUID `db:"educator_id"`
+	TotalBookings     int       `db:"total_bookings"`
+	CancelledBookings int       `db:"cancelled_bookings"`
+}
+
+// CancellationReasonRow holds the number of student cancellations of a single educator for a single reason
+type CancellationReasonRow struct {
+	EducatorId    uuid.UUID                   `db:"educator_id"`
+	Reason        entities.CancellationReason `db:"reason"`
+	Cancellations int                         `db:"cancellations"`
+}
+
+// RevenueRow holds completed session revenue of a single period bucket
+type RevenueRow struct {
+	PeriodStart  time.Time `db:"period_start"`
+	SessionCount int       `db:"session_count"`
+	GrossAmount  float64   `db:"gross_amount"`
+}
+
+// RetentionRow holds the number of cohort students active a given number of months after their first booking
+type RetentionRow struct {
+	CohortMonth    time.Time `db:"cohort_month"`
+	MonthOffset    int       `db:"month_offset"`
+	ActiveStudents int       `db:"active_students"`
+}
+
+type ReportRepo struct {
+	db *sqlx.DB
+}
+
+func NewReportRepository(db *sqlx.DB) *ReportRepo {
+	return &ReportRepo{db: db}
+}
+
+// GetUtilization compares working period time with time occupied by scheduled events and individual approved bookings
+func (r *ReportRepo) GetUtilization(ctx context.Context, from, to time.Time) ([]*UtilizationRow, error) {
+	const query = `
+		WITH available AS (
+			SELECT user_id AS educator_id, SUM(EXTRACT(EPOCH FROM (LEAST(end_time, $2) - GREATEST(start_time, $1))) / 60) AS minutes
+			FROM working_period
+			WHERE start_time < $2 AND end_time > $1 AND deleted_at IS NULL
+			GROUP BY user_id
+		), booked AS (
+			SELECT educator_id, SUM(EXTRACT(EPOCH FROM (end_time - start_time)) / 60) AS minutes
+			FROM (
+				SELECT educator_id, start_time, end_time
+				FROM booking
+				WHERE status = ANY($3) AND scheduled_event_id IS NULL AND start_time >= $1 AND start_time < $2
+				UNION ALL
+				SELECT user_id, start_time, end_time
+				FROM scheduled_event
+				WHERE start_time >= $1 AND start_time < $2 AND deleted_at IS NULL
+			) occupied
+			GROUP BY educator_id
+		)
+		SELECT a.educator_id, a.minutes AS available_minutes, COALESCE(b.minutes, 0) AS booked_minutes
+		FROM available a
+		LEFT JOIN booked b ON b.educator_id = a.educator_id
+		ORDER BY a.educator_id
+	`
+	return database.FetchMultiple[UtilizationRow](ctx, r.db, query, from, to, entities.ConfirmedStatuses)
+}
+
+// GetCancellations counts bookings and cancelled bookings per educator that started within a period
+func (r *ReportRepo) GetCancellations(ctx context.Context, from, to time.Time) ([]*CancellationRow, error) {
+	const query = `
+		SELECT educator_id, COUNT(*) AS total_bookings, COUNT(*) FILTER (WHERE status = $3) AS cancelled_bookings
+		FROM booking
+		WHERE start_time >= $1 AND start_time < $2
+		GROUP BY educator_id
+		ORDER BY educator_id
+	`
+	return database.FetchMultiple[CancellationRow](ctx, r.db, query, from, to, entities.Cancelled)
+}
+
+// GetCancellationReasons counts student cancellations made within a period per educator and reason, of a
+// single educator when one is given
+func (r *ReportRepo) GetCancellationReasons(ctx context.Context, educatorId *uuid.UUID, from, to time.Time) ([]*CancellationReasonRow, error) {
+	const query = `
+		SELECT b.educator_id, cr.reason, COUNT(*) AS cancellations
+		FROM booking_cancellation_reason cr
+		JOIN booking b ON b.id = cr.booking_id
+		WHERE cr.created_at >= $1 AND cr.created_at < $2 AND ($3::uuid IS NULL OR b.educator_id = $3)
+		GROUP BY b.educator_id, cr.reason
+		ORDER BY b.educator_id, cr.reason
+	`
+	return database.FetchMultiple[CancellationReasonRow](ctx, r.db, query, from, to, educatorId)
+}
+
+// GetRevenue aggregates approved bookings that ended within a period into day, week or month buckets
+func (r *ReportRepo) GetRevenue(ctx context.Context, interval string, from, to time.Time) ([]*RevenueRow, error) {
+	const query = `
+		SELECT date_trunc($1, end_time AT TIME ZONE 'UTC') AS period_start, COUNT(*) AS session_count, COALESCE(SUM(price), 0) AS gross_amount
+		FROM booking
+		WHERE status = ANY($2) AND end_time >= $3 AND end_time < $4
+		GROUP BY 1
+		ORDER BY 1
+	`
+	return database.FetchMultiple[RevenueRow](ctx, r.db, query, interval, entities.ConfirmedStatuses, from, to)
+}
+
+// GetRetention groups students by the month of their first approved booking and counts how many of them
+// had approved bookings in each following month
+func (r *ReportRepo) GetRetention(ctx context.Context, from, to time.Time) ([]*RetentionRow, error) {
+	const query = `
+		WITH cohort AS (
+			SELECT student_id, date_trunc('month', MIN(start_time) AT TIME ZONE 'UTC') AS cohort_month
+			FROM booking
+			WHERE status = ANY($1)
+			GROUP BY student_id
+		), activity AS (
+			SELECT DISTINCT student_id, date_trunc('month', start_time AT TIME ZONE 'UTC') AS active_month
+			FROM booking
+			WHERE status = ANY($1)
+		)
+		SELECT c.cohort_month,
+			CAST((EXTRACT(YEAR FROM a.active_month) - EXTRACT(YEAR FROM c.cohort_month)) * 12
+				+ EXTRACT(MONTH FROM a.active_month) - EXTRACT(MONTH FROM c.cohort_month) AS int) AS month_offset,
+			COUNT(*) AS active_students
+		FROM cohort c
+		JOIN activity a ON a.student_id = c.student_id
+		WHERE c.cohort_month >= date_trunc('month', CAST($2 AS timestamptz) AT TIME ZONE 'UTC') AND c.cohort_month < CAST($3 AS timestamptz) AT TIME ZONE 'UTC'
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`
+	return database.FetchMultiple[RetentionRow](ctx, r.db, query, entities.ConfirmedStatuses, from, to)
+}
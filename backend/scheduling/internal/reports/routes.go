@@ -0,0 +1,24 @@
+package reports
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *ReportHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.AdminRole))
+	r.Get("/utilization", handler.GetUtilizationReport)
+	r.Get("/cancellations", handler.GetCancellationReport)
+	r.Get("/cancellation-reasons", handler.GetCancellationReasonReport)
+	r.Get("/revenue", handler.GetRevenueReport)
+	r.Get("/retention", handler.GetRetentionReport)
+
+	return r
+}
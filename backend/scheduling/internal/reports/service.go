@@ -0,0 +1,197 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/cache"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type ReportRepository interface {
+	GetUtilization(ctx context.Context, from, to time.Time) ([]*UtilizationRow, error)
+	GetCancellations(ctx context.Context, from, to time.Time) ([]*CancellationRow, error)
+	GetCancellationReasons(ctx context.Context, educatorId *uuid.UUID, from, to time.Time) ([]*CancellationReasonRow, error)
+	GetRevenue(ctx context.Context, interval string, from, to time.Time) ([]*RevenueRow, error)
+	GetRetention(ctx context.Context, from, to time.Time) ([]*RetentionRow, error)
+}
+
+type ReportService struct {
+	log      logger.Logger
+	repo     ReportRepository
+	currency string
+	cache    *cache.Cache[string, any]
+}
+
+func NewReportService(
+	log logger.Logger,
+	repo ReportRepository,
+	currency string,
+	reports *cache.Cache[string, any],
+) *ReportService {
+	return &ReportService{log: log, repo: repo, currency: currency, cache: reports}
+}
+
+func (s *ReportService) GetUtilizationReport(ctx context.Context, from, to time.Time) (*UtilizationReportResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+
+	report, err := s.cache.GetOrLoad(ctx, cacheKey(ctx, "utilization", from, to), func(ctx context.Context) (any, error) {
+		rows, err := s.repo.GetUtilization(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return &UtilizationReportResponse{PeriodStart: from, PeriodEnd: to, Educators: MapUtilizationRowsToResponse(rows)}, nil
+	})
+	if err != nil {
+		log.Error("failed to get utilization report", err)
+		return nil, err
+	}
+	return report.(*UtilizationReportResponse), nil
+}
+
+func (s *ReportService) GetCancellationReport(ctx context.Context, from, to time.Time) (*CancellationReportResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+
+	report, err := s.cache.GetOrLoad(ctx, cacheKey(ctx, "cancellations", from, to), func(ctx context.Context) (any, error) {
+		rows, err := s.repo.GetCancellations(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return &CancellationReportResponse{PeriodStart: from, PeriodEnd: to, Educators: MapCancellationRowsToResponse(rows)}, nil
+	})
+	if err != nil {
+		log.Error("failed to get cancellation report", err)
+		return nil, err
+	}
+	return report.(*CancellationReportResponse), nil
+}
+
+// GetCancellationReasonReport breaks student cancellations made within a period down by reason, platform-wide
+// and per educator
+func (s *ReportService) GetCancellationReasonReport(ctx context.Context, from, to time.Time) (*CancellationReasonReportResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+
+	report, err := s.cache.GetOrLoad(ctx, cacheKey(ctx, "cancellation-reasons", from, to), func(ctx context.Context) (any, error) {
+		rows, err := s.repo.GetCancellationReasons(ctx, nil, from, to)
+		if err != nil {
+			return nil, err
+		}
+		report := MapCancellationReasonRowsToReport(rows)
+		report.PeriodStart, report.PeriodEnd = from, to
+		return report, nil
+	})
+	if err != nil {
+		log.Error("failed to get cancellation reason report", err)
+		return nil, err
+	}
+	return report.(*CancellationReasonReportResponse), nil
+}
+
+// GetEducatorCancellationReasons breaks student cancellations of a single educator made within a period down
+// by reason
+func (s *ReportService) GetEducatorCancellationReasons(ctx context.Context, educatorId uuid.UUID, from, to time.Time) (*EducatorCancellationReasonsResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+
+	report, err := s.cache.GetOrLoad(ctx, cacheKey(ctx, "cancellation-reasons:"+educatorId.String(), from, to), func(ctx context.Context) (any, error) {
+		rows, err := s.repo.GetCancellationReasons(ctx, &educatorId, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return MapCancellationReasonRowsToEducator(educatorId, rows), nil
+	})
+	if err != nil {
+		log.Error("failed to get educator cancellation reasons", err)
+		return nil, err
+	}
+	return report.(*EducatorCancellationReasonsResponse), nil
+}
+
+func (s *ReportService) GetRevenueReport(ctx context.Context, interval string, from, to time.Time) (*RevenueReportResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+
+	if interval != "day" && interval != "week" && interval != "month" {
+		return nil, apperrors.NewBadRequestError("interval must be one of day, week or month", apperrors.ErrParameterInvalid)
+	}
+
+	report, err := s.cache.GetOrLoad(ctx, cacheKey(ctx, "revenue:"+interval, from, to), func(ctx context.Context) (any, error) {
+		rows, err := s.repo.GetRevenue(ctx, interval, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return &RevenueReportResponse{
+			PeriodStart: from,
+			PeriodEnd:   to,
+			Interval:    interval,
+			Currency:    s.currency,
+			Periods:     MapRevenueRowsToResponse(rows),
+		}, nil
+	})
+	if err != nil {
+		log.Error("failed to get revenue report", err)
+		return nil, err
+	}
+	return report.(*RevenueReportResponse), nil
+}
+
+func (s *ReportService) GetRetentionReport(ctx context.Context, from, to time.Time) (*RetentionReportResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+
+	report, err := s.cache.GetOrLoad(ctx, cacheKey(ctx, "retention", from, to), func(ctx context.Context) (any, error) {
+		rows, err := s.repo.GetRetention(ctx, from, to)
+		if err != nil {
+			return nil, err
+		}
+		return &RetentionReportResponse{PeriodStart: from, PeriodEnd: to, Cohorts: MapRetentionRowsToResponse(rows)}, nil
+	})
+	if err != nil {
+		log.Error("failed to get retention report", err)
+		return nil, err
+	}
+	return report.(*RetentionReportResponse), nil
+}
+
+func validatePeriod(from, to time.Time) error {
+	if !from.Before(to) {
+		return apperrors.NewBadRequestError("fromDate must be before toDate", apperrors.ErrReportPeriod)
+	}
+	return nil
+}
+
+// cacheKey names a report of a period, per tenant since a report only covers the rows of the tenant it was
+// loaded for
+func cacheKey(ctx context.Context, report string, from, to time.Time) string {
+	key := fmt.Sprintf("%s:%d:%d", report, from.Unix(), to.Unix())
+	if tenantId, ok := auth.GetTenantID(ctx); ok {
+		key = tenantId + ":" + key
+	}
+	return key
+}
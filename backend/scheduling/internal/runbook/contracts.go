@@ -0,0 +1,21 @@
+package runbook
+
+import "time"
+
+// swagger:model AnomalyResponse
+type AnomalyResponse struct {
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Count       int    `json:"count"`
+	// OldestAt is when the oldest occurrence began, empty when there is none
+	OldestAt *time.Time `json:"oldestAt"`
+	// Link is the endpoint listing the occurrences, empty when there is none
+	Link *string `json:"link"`
+}
+
+// swagger:model AnomalyReportResponse
+type AnomalyReportResponse struct {
+	Since       time.Time          `json:"since"`
+	GeneratedAt time.Time          `json:"generatedAt"`
+	Anomalies   []*AnomalyResponse `json:"anomalies"`
+}
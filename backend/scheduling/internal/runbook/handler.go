@@ -0,0 +1,43 @@
+package runbook
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type RunbookHandler struct {
+	service *RunbookService
+}
+
+func NewRunbookHandler(service *RunbookService) *RunbookHandler {
+	return &RunbookHandler{service: service}
+}
+
+// GetAnomalies retrieves the recent anomalies of the service.
+// @Summary      Retrieve anomalies
+// @Description  Returns the anomalies detected by the service with their counts, when the oldest occurrence began and the endpoint listing the occurrences where there is one. Poison messages are counted within the window, the other kinds as they stand now.
+// @Tags         Runbook
+// @Accept       json
+// @Produce      json
+// @Param        sinceHours  query     int                    false  "Window in hours for poison messages, default 24, at most 720"
+// @Success      200         {object}  AnomalyReportResponse  "Anomalies"
+// @Failure      400         {object}  error                  "Invalid input parameters"
+// @Router       /api/v1/admin/anomalies [get]
+// @Security 	 BearerAuth
+func (h *RunbookHandler) GetAnomalies(w http.ResponseWriter, r *http.Request) {
+	sinceHours, err := api.ParseIntQuery(w, r, "sinceHours", DefaultSinceHours)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	report, err := h.service.GetAnomalies(r.Context(), sinceHours)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, report)
+}
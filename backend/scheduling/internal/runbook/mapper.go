@@ -0,0 +1,11 @@
+package runbook
+
+func MapAnomalyToResponse(kind string, description string, link *string, row *AnomalyRow) *AnomalyResponse {
+	return &AnomalyResponse{
+		Kind:        kind,
+		Description: description,
+		Count:       row.Count,
+		OldestAt:    row.OldestAt,
+		Link:        link,
+	}
+}
@@ -0,0 +1,20 @@
+package runbook
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeRunbookService(log logger.Logger, db *sqlx.DB, expiryCfg *config.BookingExpiryConfig) *RunbookService {
+	repo := NewRunbookRepository(db)
+	return NewRunbookService(log, repo, expiryCfg)
+}
+
+func InitializeRunbookHTTPHandler(service *RunbookService) http.Handler {
+	handler := NewRunbookHandler(service)
+	return Routes(handler)
+}
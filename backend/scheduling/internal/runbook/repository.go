@@ -0,0 +1,68 @@
+package runbook
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// AnomalyRow holds how many occurrences of an anomaly were found and when the oldest one began
+type AnomalyRow struct {
+	Count    int        `db:"count"`
+	OldestAt *time.Time `db:"oldest_at"`
+}
+
+type RunbookRepo struct {
+	db *sqlx.DB
+}
+
+func NewRunbookRepository(db *sqlx.DB) *RunbookRepo {
+	return &RunbookRepo{db: db}
+}
+
+// CountDeadLetters counts the messages dead-lettered since the given time
+func (r *RunbookRepo) CountDeadLetters(ctx context.Context, since time.Time) (*AnomalyRow, error) {
+	const query = `
+		SELECT COUNT(*) AS count, MIN(dead_lettered_at) AS oldest_at
+		FROM dead_letter
+		WHERE dead_lettered_at >= $1
+	`
+	return database.FetchSingle[AnomalyRow](ctx, r.db, query, since)
+}
+
+// CountFailingOutboxEvents counts the events the relay has failed to publish at least once
+func (r *RunbookRepo) CountFailingOutboxEvents(ctx context.Context) (*AnomalyRow, error) {
+	const query = `
+		SELECT COUNT(*) AS count, MIN(created_at) AS oldest_at
+		FROM event_outbox
+		WHERE attempts > 0
+	`
+	return database.FetchSingle[AnomalyRow](ctx, r.db, query)
+}
+
+// CountOverduePendingBookings counts pending bookings still unresolved well after their confirmation
+// deadline or start, which the expiry job should have confirmed or cancelled. The deadline of the
+// booking's session type applies, the default TTL otherwise.
+func (r *RunbookRepo) CountOverduePendingBookings(ctx context.Context, defaultTTLMinutes int, overdueBefore time.Time) (*AnomalyRow, error) {
+	const query = `
+		SELECT COUNT(*) AS count, MIN(b.created_at) AS oldest_at
+		FROM booking b
+		LEFT JOIN session_type st ON st.id = b.session_type_id
+		WHERE b.status = $1 AND (b.created_at + make_interval(mins => COALESCE(st.confirmation_deadline_minutes, $2)) < $3 OR b.start_time <= $3)
+	`
+	return database.FetchSingle[AnomalyRow](ctx, r.db, query, entities.Pending, defaultTTLMinutes, overdueBefore)
+}
+
+// CountStalledOffboardings counts offboardings still winding down without progress since the given time
+func (r *RunbookRepo) CountStalledOffboardings(ctx context.Context, stalledBefore time.Time) (*AnomalyRow, error) {
+	const query = `
+		SELECT COUNT(*) AS count, MIN(started_at) AS oldest_at
+		FROM teacher_offboarding
+		WHERE status = $1 AND updated_at < $2
+	`
+	return database.FetchSingle[AnomalyRow](ctx, r.db, query, entities.OffboardingWindingDown, stalledBefore)
+}
@@ -0,0 +1,96 @@
+package runbook
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const (
+	DefaultSinceHours = 24
+	maxSinceHours     = 30 * 24
+
+	// staleAfter is how long a background process may lag before it is reported, well above the intervals
+	// the jobs run at
+	staleAfter = time.Hour
+
+	deadLettersLink = "/api/v1/admin/dlq/messages"
+)
+
+// Kinds of anomalies
+const (
+	KindPoisonMessages         = "poison_messages"
+	KindFailingOutboxEvents    = "failing_outbox_events"
+	KindOverduePendingBookings = "overdue_pending_bookings"
+	KindStalledOffboardings    = "stalled_offboardings"
+)
+
+type RunbookRepository interface {
+	CountDeadLetters(ctx context.Context, since time.Time) (*AnomalyRow, error)
+	CountFailingOutboxEvents(ctx context.Context) (*AnomalyRow, error)
+	CountOverduePendingBookings(ctx context.Context, defaultTTLMinutes int, overdueBefore time.Time) (*AnomalyRow, error)
+	CountStalledOffboardings(ctx context.Context, stalledBefore time.Time) (*AnomalyRow, error)
+}
+
+// RunbookService gathers the anomalies the service detects into one report, the entry point of triage
+type RunbookService struct {
+	log       logger.Logger
+	repo      RunbookRepository
+	expiryCfg *config.BookingExpiryConfig
+}
+
+func NewRunbookService(log logger.Logger, repo RunbookRepository, expiryCfg *config.BookingExpiryConfig) *RunbookService {
+	return &RunbookService{log: log, repo: repo, expiryCfg: expiryCfg}
+}
+
+// GetAnomalies reports the messages dead-lettered within the last hours, and the events, bookings and
+// offboardings currently stuck. Every kind is listed, with a zero count when nothing was found.
+func (s *RunbookService) GetAnomalies(ctx context.Context, sinceHours int) (*AnomalyReportResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if sinceHours <= 0 || sinceHours > maxSinceHours {
+		return nil, apperrors.NewBadRequestError("Invalid anomaly window", apperrors.ErrParameterParsingFailed)
+	}
+
+	now := time.Now().UTC()
+	since := now.Add(-time.Duration(sinceHours) * time.Hour)
+	link := deadLettersLink
+
+	poison, err := s.repo.CountDeadLetters(ctx, since)
+	if err != nil {
+		log.Error("failed to count dead letters", err)
+		return nil, err
+	}
+
+	outbox, err := s.repo.CountFailingOutboxEvents(ctx)
+	if err != nil {
+		log.Error("failed to count failing outbox events", err)
+		return nil, err
+	}
+
+	pending, err := s.repo.CountOverduePendingBookings(ctx, s.expiryCfg.PendingTTLMinutes, now.Add(-staleAfter))
+	if err != nil {
+		log.Error("failed to count overdue pending bookings", err)
+		return nil, err
+	}
+
+	offboardings, err := s.repo.CountStalledOffboardings(ctx, now.Add(-staleAfter))
+	if err != nil {
+		log.Error("failed to count stalled offboardings", err)
+		return nil, err
+	}
+
+	return &AnomalyReportResponse{
+		Since:       since,
+		GeneratedAt: now,
+		Anomalies: []*AnomalyResponse{
+			MapAnomalyToResponse(KindPoisonMessages, "Messages rejected by consumers and dead-lettered", &link, poison),
+			MapAnomalyToResponse(KindFailingOutboxEvents, "Events the outbox relay failed to publish to the broker", nil, outbox),
+			MapAnomalyToResponse(KindOverduePendingBookings, "Pending bookings the expiry job has not resolved past their deadline", nil, pending),
+			MapAnomalyToResponse(KindStalledOffboardings, "Teacher offboardings winding down without progress", nil, offboardings),
+		},
+	}, nil
+}
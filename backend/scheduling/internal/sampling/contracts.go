@@ -0,0 +1,52 @@
+package sampling
+
+import "github.com/maksmelnyk/scheduling/internal/apperrors"
+
+// swagger:model SamplingPolicyRequest
+type SamplingPolicyRequest struct {
+	// Strategy is always, never, ratio, parent_ratio or tail
+	Strategy string
+	Ratio    float64
+	// RouteRatios overrides the ratio for request paths starting with a prefix
+	RouteRatios map[string]float64
+}
+
+// swagger:model SamplingPolicyResponse
+type SamplingPolicyResponse struct {
+	Strategy    string             `json:"strategy"`
+	Ratio       float64            `json:"ratio"`
+	RouteRatios map[string]float64 `json:"routeRatios"`
+}
+
+func (r *SamplingPolicyRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if r.Strategy == "" {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Strategy",
+			Message: "must not be empty",
+		})
+	}
+
+	if r.Ratio < 0 || r.Ratio > 1 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Ratio",
+			Message: "must be between 0 and 1",
+		})
+	}
+
+	for prefix, ratio := range r.RouteRatios {
+		if ratio < 0 || ratio > 1 {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "RouteRatios." + prefix,
+				Message: "must be between 0 and 1",
+			})
+		}
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Sampling policy failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
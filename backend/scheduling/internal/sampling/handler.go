@@ -0,0 +1,70 @@
+package sampling
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type SamplingHandler struct {
+	service *SamplingService
+}
+
+func NewSamplingHandler(service *SamplingService) *SamplingHandler {
+	return &SamplingHandler{service: service}
+}
+
+// GetSamplingPolicy retrieves the trace sampling policy in effect.
+// @Summary      Retrieve trace sampling policy
+// @Description  Returns the sampling strategy, ratio and route overrides this instance records traces with.
+// @Tags         Sampling
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  SamplingPolicyResponse  "Sampling policy"
+// @Failure      404  {object}  error                   "Tracing is disabled"
+// @Router       /api/v1/admin/sampling [get]
+// @Security 	 BearerAuth
+func (h *SamplingHandler) GetSamplingPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.service.GetSamplingPolicy(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, policy)
+}
+
+// UpdateSamplingPolicy replaces the trace sampling policy.
+// @Summary      Update trace sampling policy
+// @Description  Replaces the sampling strategy, ratio and route overrides of every running instance until they restart. Instances started later sample with the configured policy.
+// @Tags         Sampling
+// @Accept       json
+// @Produce      json
+// @Param        policy  body      SamplingPolicyRequest   true  "Sampling policy"
+// @Success      200     {object}  SamplingPolicyResponse  "Sampling policy in effect"
+// @Failure      400     {object}  error                   "Invalid policy"
+// @Failure      404     {object}  error                   "Tracing is disabled"
+// @Router       /api/v1/admin/sampling [put]
+// @Security 	 BearerAuth
+func (h *SamplingHandler) UpdateSamplingPolicy(w http.ResponseWriter, r *http.Request) {
+	var request *SamplingPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	policy, err := h.service.UpdateSamplingPolicy(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, policy)
+}
@@ -0,0 +1,23 @@
+package sampling
+
+import "github.com/maksmelnyk/scheduling/internal/telemetry"
+
+func MapRequestToSamplingPolicy(r *SamplingPolicyRequest) telemetry.SamplingPolicy {
+	return telemetry.SamplingPolicy{
+		Strategy:    r.Strategy,
+		Ratio:       r.Ratio,
+		RouteRatios: r.RouteRatios,
+	}
+}
+
+func MapSamplingPolicyToResponse(p telemetry.SamplingPolicy) *SamplingPolicyResponse {
+	routes := p.RouteRatios
+	if routes == nil {
+		routes = map[string]float64{}
+	}
+	return &SamplingPolicyResponse{
+		Strategy:    p.Strategy,
+		Ratio:       p.Ratio,
+		RouteRatios: routes,
+	}
+}
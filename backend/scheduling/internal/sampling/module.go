@@ -0,0 +1,23 @@
+package sampling
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/broadcast"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/telemetry"
+)
+
+// InitializeSamplingService wires the runtime sampling adjustment, disabled when the sampler is nil
+// because tracing is off
+func InitializeSamplingService(log logger.Logger, sampler *telemetry.Sampler, broadcaster *broadcast.Broadcaster) *SamplingService {
+	if sampler == nil {
+		return NewSamplingService(log, nil, broadcaster)
+	}
+	return NewSamplingService(log, sampler, broadcaster)
+}
+
+func InitializeSamplingHTTPHandler(service *SamplingService) http.Handler {
+	handler := NewSamplingHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,75 @@
+package sampling
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/broadcast"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/telemetry"
+)
+
+// PolicySampler applies a trace sampling policy that can be replaced at runtime
+type PolicySampler interface {
+	Policy() telemetry.SamplingPolicy
+	Update(policy telemetry.SamplingPolicy) error
+}
+
+// Broadcaster tells the other instances about a changed policy
+type Broadcaster interface {
+	Broadcast(ctx context.Context, kind string, data any) error
+}
+
+// SamplingService adjusts trace sampling without a restart. Changes are broadcast to the running instances
+// but not persisted, instances started later sample with the configured policy.
+type SamplingService struct {
+	log         logger.Logger
+	sampler     PolicySampler
+	broadcaster Broadcaster
+}
+
+func NewSamplingService(log logger.Logger, sampler PolicySampler, broadcaster Broadcaster) *SamplingService {
+	return &SamplingService{log: log, sampler: sampler, broadcaster: broadcaster}
+}
+
+func (s *SamplingService) GetSamplingPolicy(ctx context.Context) (*SamplingPolicyResponse, error) {
+	if s.sampler == nil {
+		return nil, apperrors.NewNotFound("Tracing is disabled", apperrors.ErrTracingDisabled)
+	}
+
+	return MapSamplingPolicyToResponse(s.sampler.Policy()), nil
+}
+
+// UpdateSamplingPolicy replaces the sampling policy for traces started from now on
+func (s *SamplingService) UpdateSamplingPolicy(ctx context.Context, request *SamplingPolicyRequest) (*SamplingPolicyResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if s.sampler == nil {
+		return nil, apperrors.NewNotFound("Tracing is disabled", apperrors.ErrTracingDisabled)
+	}
+
+	policy := MapRequestToSamplingPolicy(request)
+	if err := s.sampler.Update(policy); err != nil {
+		return nil, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterInvalid)
+	}
+
+	log.Infof("Trace sampling changed to %s with ratio %g and %d route overrides", policy.Strategy, policy.Ratio, len(policy.RouteRatios))
+	if err := s.broadcaster.Broadcast(ctx, broadcast.SignalSampling, policy); err != nil {
+		log.Error("failed to broadcast sampling policy", err)
+	}
+	return MapSamplingPolicyToResponse(s.sampler.Policy()), nil
+}
+
+// ApplySamplingPolicy takes over the policy broadcast by another instance, ignored while tracing is disabled
+func (s *SamplingService) ApplySamplingPolicy(ctx context.Context, data json.RawMessage) error {
+	if s.sampler == nil {
+		return nil
+	}
+
+	var policy telemetry.SamplingPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return err
+	}
+	return s.sampler.Update(policy)
+}
@@ -0,0 +1,70 @@
+package schedule
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+// maxSessionBufferMinutes bounds each buffer, longer breaks belong between working periods
+const maxSessionBufferMinutes = 240
+
+// GetMySessionBuffers returns the gaps the current educator keeps free before and after each session
+func (s *ScheduleService) GetMySessionBuffers(ctx context.Context) (*SessionBuffersResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	policies, err := s.repo.GetSchedulingPolicies(ctx, []uuid.UUID{userId})
+	if err != nil {
+		log.Error("failed to get scheduling policy", err)
+		return nil, err
+	}
+
+	response := &SessionBuffersResponse{}
+	if len(policies) > 0 {
+		response = MapSchedulingPolicyToSessionBuffers(policies[0])
+	}
+	return response, nil
+}
+
+// UpdateMySessionBuffers sets the gaps the current educator keeps free before and after each session. They
+// apply to new bookings and scheduled events and to the availability search, existing sessions are kept.
+func (s *ScheduleService) UpdateMySessionBuffers(ctx context.Context, request *SessionBuffersRequest) (*SessionBuffersResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	policy := MapRequestToSchedulingPolicy(userId, request)
+	if err := s.repo.UpsertSessionBuffers(ctx, policy); err != nil {
+		log.Error("failed to update session buffers", err)
+		return nil, err
+	}
+
+	return MapSchedulingPolicyToSessionBuffers(policy), nil
+}
+
+// sessionBuffers loads the buffers of several educators, educators without a scheduling policy have none
+func (s *ScheduleService) sessionBuffers(ctx context.Context, educatorIds []uuid.UUID) (map[uuid.UUID]timeutils.Buffers, error) {
+	policies, err := s.repo.GetSchedulingPolicies(ctx, educatorIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uuid.UUID]timeutils.Buffers, len(policies))
+	for _, p := range policies {
+		result[p.EducatorId] = timeutils.NewBuffers(p.BufferBeforeMinutes, p.BufferMinutes)
+	}
+	return result, nil
+}
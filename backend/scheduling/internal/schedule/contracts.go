@@ -1,10 +1,14 @@
 package schedule
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/i18n"
+	"github.com/maksmelnyk/scheduling/internal/locations"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
 )
 
 // swagger:model ScheduleResponse
@@ -12,25 +16,39 @@ type ScheduleResponse struct {
 	WorkingPeriods  []*WorkingPeriodResponse
 	ScheduledEvents []*ScheduledEventResponse
 	Bookings        []*BookingResponse
+	Locations       []*locations.LocationResponse
+	Timezone        string
 }
 
 // swagger:model WorkingPeriodResponse
 type WorkingPeriodResponse struct {
-	Id        int64     `json:"id"`
-	StartTime time.Time `json:"startTime"`
-	EndTime   time.Time `json:"endTime"`
+	Id           int64     `json:"id"`
+	StartTime    time.Time `json:"startTime"`
+	EndTime      time.Time `json:"endTime"`
+	RecurrenceId *int64    `json:"recurrenceId"`
+	// Version to send in the If-Match header of updates and deletes
+	Version int64 `json:"version"`
 }
 
 // swagger:model ScheduledEventResponse
 type ScheduledEventResponse struct {
-	Id              int64     `json:"id"`
-	ProductId       int64     `json:"productId"`
-	LessonId        *int64    `json:"lessonId"`
-	Title           string    `json:"title"`
-	WorkingPeriodId int64     `json:"workingPeriodId"`
-	StartTime       time.Time `json:"startTime"`
-	EndTime         time.Time `json:"endTime"`
-	MaxParticipants int       `json:"maxParticipants"`
+	Id            int64  `json:"id"`
+	ProductId     int64  `json:"productId"`
+	LessonId      *int64 `json:"lessonId"`
+	SessionTypeId *int64 `json:"sessionTypeId"`
+	LocationId    *int64 `json:"locationId"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	// Locale of the title and description, empty for the product's title
+	Locale          string            `json:"locale"`
+	WorkingPeriodId int64             `json:"workingPeriodId"`
+	StartTime       time.Time         `json:"startTime"`
+	EndTime         time.Time         `json:"endTime"`
+	MaxParticipants int               `json:"maxParticipants"`
+	Price           float64           `json:"price"`
+	Translations    i18n.Translations `json:"translations"`
+	// Version to send in the If-Match header of deletes
+	Version int64 `json:"version"`
 }
 
 // swagger:model BookingResponse
@@ -41,10 +59,20 @@ type BookingResponse struct {
 	ProductId        int64     `json:"productId"`
 	EnrollmentId     *int64    `json:"enrollmentId"`
 	ScheduledEventId *int64    `json:"scheduledEventId"`
+	SessionTypeId    *int64    `json:"sessionTypeId"`
 	WorkingPeriodId  int64     `json:"workingPeriodId"`
 	StartTime        time.Time `json:"startTime"`
 	EndTime          time.Time `json:"endTime"`
 	Status           int       `json:"status"`
+	Price            float64   `json:"price"`
+	// Version to send in the If-Match header of status updates
+	Version int64 `json:"version"`
+}
+
+// swagger:model CalendarFeedResponse
+type CalendarFeedResponse struct {
+	// Url is the path of the iCalendar feed including its signed token, to subscribe to from a calendar app
+	Url string `json:"url"`
 }
 
 // swagger:model ScheduledEventRequest
@@ -52,8 +80,14 @@ type ScheduledEventRequest struct {
 	WorkingPeriodId int64     `json:"workingPeriodId"`
 	ProductId       int64     `json:"productId"`
 	LessonId        *int64    `json:"lessonId"`
+	SessionTypeId   *int64    `json:"sessionTypeId"`
+	LocationId      *int64    `json:"locationId"`
 	StartTime       time.Time `json:"startTime"`
 	EndTime         time.Time `json:"endTime"`
+	// Capacity limits the participants below the product's maximum, which is used when it is omitted
+	Capacity *int `json:"capacity"`
+	// Translations maps locales such as de or pt-BR to the translated title and description
+	Translations i18n.Translations `json:"translations"`
 }
 
 // swagger:model WorkingPeriodRequest
@@ -62,6 +96,54 @@ type WorkingPeriodRequest struct {
 	EndTime   time.Time `json:"endTime"`
 }
 
+// swagger:model WorkingPeriodRecurrenceRequest
+type WorkingPeriodRecurrenceRequest struct {
+	RRule      string `json:"rrule"`
+	StartClock string `json:"startClock"`
+	EndClock   string `json:"endClock"`
+	Timezone   string `json:"timezone"`
+	StartsOn   string `json:"startsOn"`
+}
+
+// swagger:model WorkingPeriodRecurrenceResponse
+type WorkingPeriodRecurrenceResponse struct {
+	Id         int64     `json:"id"`
+	RRule      string    `json:"rrule"`
+	StartClock string    `json:"startClock"`
+	EndClock   string    `json:"endClock"`
+	Timezone   string    `json:"timezone"`
+	StartsOn   string    `json:"startsOn"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// swagger:model RecurrenceExpansionResponse
+type RecurrenceExpansionResponse struct {
+	Recurrence *WorkingPeriodRecurrenceResponse `json:"recurrence"`
+	Created    int                              `json:"created"`
+	Skipped    []*SkippedOccurrenceResponse     `json:"skipped"`
+}
+
+// swagger:model SkippedOccurrenceResponse
+type SkippedOccurrenceResponse struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Reason    string    `json:"reason"`
+}
+
+// swagger:model SessionBuffersRequest
+type SessionBuffersRequest struct {
+	// BeforeMinutes is kept free before each session to prepare
+	BeforeMinutes int `json:"beforeMinutes"`
+	// AfterMinutes is kept free after each session to wrap up or travel
+	AfterMinutes int `json:"afterMinutes"`
+}
+
+// swagger:model SessionBuffersResponse
+type SessionBuffersResponse struct {
+	BeforeMinutes int `json:"beforeMinutes"`
+	AfterMinutes  int `json:"afterMinutes"`
+}
+
 // swagger:model ScheduledEventMetadataRequest
 type ScheduledEventMetadataRequest struct {
 	ProductId        int64   `json:"productId"`
@@ -114,6 +196,15 @@ func (s *ScheduledEventRequest) Validate() error {
 		}
 	}
 
+	if s.Capacity != nil && *s.Capacity <= 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Capacity",
+			Message: "must be greater than 0",
+		})
+	}
+
+	errors = append(errors, s.Translations.Validate("Translations")...)
+
 	if len(errors) > 0 {
 		return apperrors.NewValidation("Scheduled event request data failed validation", apperrors.ErrValidationFailed, errors)
 	}
@@ -160,3 +251,121 @@ func (w *WorkingPeriodRequest) Validate() error {
 
 	return nil
 }
+
+func (b *SessionBuffersRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if b.BeforeMinutes < 0 || b.BeforeMinutes > maxSessionBufferMinutes {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "BeforeMinutes",
+			Message: fmt.Sprintf("must be between 0 and %d", maxSessionBufferMinutes),
+		})
+	}
+
+	if b.AfterMinutes < 0 || b.AfterMinutes > maxSessionBufferMinutes {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "AfterMinutes",
+			Message: fmt.Sprintf("must be between 0 and %d", maxSessionBufferMinutes),
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Session buffers request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
+
+func (w *WorkingPeriodRecurrenceRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if _, err := parseRRule(w.RRule); err != nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "RRule",
+			Message: err.Error(),
+		})
+	}
+
+	start, errStart := timeutils.ParseClock(w.StartClock)
+	if errStart != nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "StartClock",
+			Message: "must be a time in HH:MM format",
+		})
+	}
+
+	end, errEnd := timeutils.ParseClock(w.EndClock)
+	if errEnd != nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "EndClock",
+			Message: "must be a time in HH:MM format",
+		})
+	}
+
+	if errStart == nil && errEnd == nil && end <= start {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "EndClock",
+			Message: "must be after StartClock",
+		})
+	}
+
+	if _, err := time.LoadLocation(w.Timezone); err != nil || w.Timezone == "" {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Timezone",
+			Message: "must be a valid IANA time zone",
+		})
+	}
+
+	startsOn, err := time.Parse(dateLayout, w.StartsOn)
+	if err != nil {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "StartsOn",
+			Message: "must be a date in YYYY-MM-DD format",
+		})
+	} else if startsOn.Before(time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "StartsOn",
+			Message: "must not be in the past",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Working period recurrence request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
+
+// AvailabilitySearchQuery holds the filters and time-of-day preferences of an availability search as given
+// in the query string
+type AvailabilitySearchQuery struct {
+	FromDate    time.Time
+	ToDate      time.Time
+	DurationMin int
+	ProductId   *int64
+	Subject     string
+	EducatorIds []uuid.UUID
+	Weekdays    []int
+	// EarliestTime and LatestTime bound the preferred local time of day in HH:MM format
+	EarliestTime string
+	LatestTime   string
+	PerEducator  int
+	Limit        int
+}
+
+// swagger:model AvailabilitySearchResponse
+type AvailabilitySearchResponse struct {
+	// Educators is how many educators matched the filters and were searched
+	Educators int                      `json:"educators"`
+	Slots     []*AvailableSlotResponse `json:"slots"`
+}
+
+// swagger:model AvailableSlotResponse
+type AvailableSlotResponse struct {
+	EducatorId      uuid.UUID `json:"educatorId"`
+	WorkingPeriodId int64     `json:"workingPeriodId"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	// Score ranks the slot from 0 to 1 by how well it fits the preferred time of day and how soon it starts
+	Score float64 `json:"score"`
+}
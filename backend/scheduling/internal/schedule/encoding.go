@@ -0,0 +1,71 @@
+package schedule
+
+import (
+	"strconv"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+)
+
+// Responses of the booking listing and the availability search are encoded without reflection, they are
+// the most requested reads and the largest responses
+
+func (b *BookingResponse) AppendJSON(dst []byte) []byte {
+	if b == nil {
+		return append(dst, "null"...)
+	}
+	dst = append(dst, `{"id":`...)
+	dst = strconv.AppendInt(dst, b.Id, 10)
+	dst = append(dst, `,"educatorId":`...)
+	dst = api.AppendUUID(dst, b.EducatorId)
+	dst = append(dst, `,"studentId":`...)
+	dst = api.AppendUUID(dst, b.StudentId)
+	dst = append(dst, `,"productId":`...)
+	dst = strconv.AppendInt(dst, b.ProductId, 10)
+	dst = append(dst, `,"enrollmentId":`...)
+	dst = api.AppendInt64Ptr(dst, b.EnrollmentId)
+	dst = append(dst, `,"scheduledEventId":`...)
+	dst = api.AppendInt64Ptr(dst, b.ScheduledEventId)
+	dst = append(dst, `,"sessionTypeId":`...)
+	dst = api.AppendInt64Ptr(dst, b.SessionTypeId)
+	dst = append(dst, `,"workingPeriodId":`...)
+	dst = strconv.AppendInt(dst, b.WorkingPeriodId, 10)
+	dst = append(dst, `,"startTime":`...)
+	dst = api.AppendTime(dst, b.StartTime)
+	dst = append(dst, `,"endTime":`...)
+	dst = api.AppendTime(dst, b.EndTime)
+	dst = append(dst, `,"status":`...)
+	dst = strconv.AppendInt(dst, int64(b.Status), 10)
+	dst = append(dst, `,"price":`...)
+	dst = api.AppendFloat(dst, b.Price)
+	dst = append(dst, `,"version":`...)
+	dst = strconv.AppendInt(dst, b.Version, 10)
+	return append(dst, '}')
+}
+
+func (s *AvailableSlotResponse) AppendJSON(dst []byte) []byte {
+	if s == nil {
+		return append(dst, "null"...)
+	}
+	dst = append(dst, `{"educatorId":`...)
+	dst = api.AppendUUID(dst, s.EducatorId)
+	dst = append(dst, `,"workingPeriodId":`...)
+	dst = strconv.AppendInt(dst, s.WorkingPeriodId, 10)
+	dst = append(dst, `,"startTime":`...)
+	dst = api.AppendTime(dst, s.StartTime)
+	dst = append(dst, `,"endTime":`...)
+	dst = api.AppendTime(dst, s.EndTime)
+	dst = append(dst, `,"score":`...)
+	dst = api.AppendFloat(dst, s.Score)
+	return append(dst, '}')
+}
+
+func (r *AvailabilitySearchResponse) AppendJSON(dst []byte) []byte {
+	if r == nil {
+		return append(dst, "null"...)
+	}
+	dst = append(dst, `{"educators":`...)
+	dst = strconv.AppendInt(dst, int64(r.Educators), 10)
+	dst = append(dst, `,"slots":`...)
+	dst = api.AppendArray(dst, r.Slots)
+	return append(dst, '}')
+}
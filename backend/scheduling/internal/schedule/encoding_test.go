@@ -0,0 +1,118 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTestSlots(n int) []*AvailableSlotResponse {
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	slots := make([]*AvailableSlotResponse, n)
+	for i := range slots {
+		slots[i] = &AvailableSlotResponse{
+			EducatorId:      uuid.New(),
+			WorkingPeriodId: int64(i + 1),
+			StartTime:       start.Add(time.Duration(i) * 30 * time.Minute),
+			EndTime:         start.Add(time.Duration(i+1) * 30 * time.Minute),
+			Score:           float64(n-i) / float64(n),
+		}
+	}
+	return slots
+}
+
+func TestAvailabilitySearchResponseMatchesEncodingJson(t *testing.T) {
+	zone := time.FixedZone("UTC-3", -3*3600)
+	cases := map[string]*AvailabilitySearchResponse{
+		"nil response": nil,
+		"nil slots":    {Educators: 3},
+		"empty slots":  {Educators: 0, Slots: []*AvailableSlotResponse{}},
+		"nil slot":     {Educators: 1, Slots: []*AvailableSlotResponse{nil}},
+		"slots":        {Educators: 2, Slots: newTestSlots(3)},
+		"time formatting": {Educators: 1, Slots: []*AvailableSlotResponse{{
+			EducatorId:      uuid.New(),
+			WorkingPeriodId: 7,
+			StartTime:       time.Date(2026, 10, 14, 9, 0, 0, 120000000, zone),
+			EndTime:         time.Date(2026, 10, 14, 9, 45, 0, 1, time.UTC),
+			Score:           1e-7,
+		}}},
+		"zero values": {Slots: []*AvailableSlotResponse{{}}},
+	}
+
+	for name, response := range cases {
+		t.Run(name, func(t *testing.T) {
+			want, err := json.Marshal(response)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			if got := response.AppendJSON(nil); string(got) != string(want) {
+				t.Errorf("AppendJSON\n got: %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestBookingResponseMatchesEncodingJson(t *testing.T) {
+	enrollmentId, sessionTypeId := int64(11), int64(-2)
+	cases := map[string]*BookingResponse{
+		"nil booking": nil,
+		"nil references": {
+			Id:              1,
+			EducatorId:      uuid.New(),
+			StudentId:       uuid.New(),
+			ProductId:       3,
+			WorkingPeriodId: 4,
+			StartTime:       time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC),
+			EndTime:         time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC),
+			Status:          2,
+			Price:           49.9,
+			Version:         5,
+		},
+		"all references": {
+			Id:               2,
+			EnrollmentId:     &enrollmentId,
+			ScheduledEventId: &enrollmentId,
+			SessionTypeId:    &sessionTypeId,
+			StartTime:        time.Date(2026, 10, 14, 9, 0, 0, 999999999, time.FixedZone("", 2*3600)),
+			EndTime:          time.Date(2026, 10, 14, 9, 30, 0, 0, time.Local),
+			Price:            1e21,
+		},
+	}
+
+	for name, response := range cases {
+		t.Run(name, func(t *testing.T) {
+			want, err := json.Marshal(response)
+			if err != nil {
+				t.Fatalf("json.Marshal: %v", err)
+			}
+			if got := response.AppendJSON(nil); string(got) != string(want) {
+				t.Errorf("AppendJSON\n got: %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkAvailabilitySearchResponse(b *testing.B) {
+	for _, n := range []int{10, 500} {
+		response := &AvailabilitySearchResponse{Educators: 25, Slots: newTestSlots(n)}
+
+		b.Run(fmt.Sprintf("AppendJSON/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			buf := make([]byte, 0, 4096)
+			for b.Loop() {
+				buf = response.AppendJSON(buf[:0])
+			}
+		})
+		b.Run(fmt.Sprintf("encoding/json/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := json.Marshal(response); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -2,9 +2,13 @@ package schedule
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/maksmelnyk/scheduling/internal/api"
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
 )
@@ -19,13 +23,14 @@ func NewScheduleHandler(service *ScheduleService) *ScheduleHandler {
 
 // GetUserSchedule retrieves a user's schedule within a specified date range.
 // @Summary      Retrieve user schedule
-// @Description  Retrieves the schedule for a given user using a date range defined by 'fromDate' and 'toDate' query parameters.
+// @Description  Retrieves the schedule for a given user using a date range defined by 'fromDate' and 'toDate' query parameters. Scheduled event titles and descriptions are translated to the first locale of the Accept-Language header each event is translated to.
 // @Tags         Schedule
 // @Accept       json
 // @Produce      json
 // @Param        userId    path      string  true  "User ID (UUID)"
-// @Param        fromDate  query     string  true  "Start date in YYYY-MM-DDTHH:MM:SSZ format"
-// @Param        toDate    query     string  true  "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        fromDate  query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format, or YYYY-MM-DDTHH:MM:SS in the requested time zone"
+// @Param        toDate    query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format, or YYYY-MM-DDTHH:MM:SS in the requested time zone"
+// @Param        timezone  query     string  false  "IANA time zone of returned times, also accepted as 'Prefer: timezone=' header; defaults to UTC"
 // @Success      200       {object}  ScheduleResponse  "User schedule data"
 // @Failure      400       {object}  error         	   "Invalid input parameters"
 // @Router       /api/v1/schedules/{userId} [get]
@@ -37,19 +42,25 @@ func (h *ScheduleHandler) GetUserSchedule(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	fromDate, err := api.ParseTimeQuery(w, r, "fromDate", time.RFC3339)
+	loc, err := api.ParseTimezone(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	fromDate, err := api.ParseLocalTimeQuery(w, r, "fromDate", loc)
 	if err != nil {
 		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
 		return
 	}
 
-	toDate, err := api.ParseTimeQuery(w, r, "toDate", time.RFC3339)
+	toDate, err := api.ParseLocalTimeQuery(w, r, "toDate", loc)
 	if err != nil {
 		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
 		return
 	}
 
-	schedule, err := h.service.GetScheduleByUserId(r.Context(), userId, fromDate, toDate)
+	schedule, err := h.service.GetScheduleByUserId(r.Context(), userId, fromDate, toDate, loc)
 	if err != nil {
 		api.WriteError(w, err)
 		return
@@ -58,6 +69,243 @@ func (h *ScheduleHandler) GetUserSchedule(w http.ResponseWriter, r *http.Request
 	api.WriteJson(w, http.StatusOK, schedule)
 }
 
+// SearchAvailability searches free slots across educators.
+// @Summary      Search availability across educators
+// @Description  Returns free slots of the given duration within the educators' working periods, ranked by how well they fit the preferred time of day and how soon they start. Educators can be narrowed down by ID, by a product they teach or by a subject matching their product titles. The range is at most 14 days and starts no earlier than now. Under a slot policy the duration must be one it allows and slots start on its alignment.
+// @Tags         Schedule
+// @Accept       json
+// @Produce      json
+// @Param        fromDate      query     string    true   "Start date in YYYY-MM-DDTHH:MM:SSZ format, or YYYY-MM-DDTHH:MM:SS in the requested time zone"
+// @Param        toDate        query     string    true   "End date in YYYY-MM-DDTHH:MM:SSZ format, or YYYY-MM-DDTHH:MM:SS in the requested time zone"
+// @Param        durationMin   query     int       true   "Slot duration in minutes, between 15 and 480 and allowed by the slot policy"
+// @Param        productId     query     int       false  "Only educators teaching the product"
+// @Param        subject       query     string    false  "Only educators with a product whose title contains the subject"
+// @Param        educatorId    query     []string  false  "Only the given educators (UUID), repeatable"  collectionFormat(multi)
+// @Param        weekday       query     []int     false  "Only slots starting on the given local weekdays, 0 (Sunday) to 6 (Saturday), repeatable"  collectionFormat(multi)
+// @Param        earliestTime  query     string    false  "Preferred earliest local start time in HH:MM format"
+// @Param        latestTime    query     string    false  "Preferred latest local end time in HH:MM format"
+// @Param        perEducator   query     int       false  "Maximum slots per educator, defaults to 5"
+// @Param        limit         query     int       false  "Maximum slots, at most 200, defaults to 50"
+// @Param        timezone      query     string    false  "IANA time zone of returned times and time-of-day preferences, also accepted as 'Prefer: timezone=' header; defaults to UTC"
+// @Success      200           {object}  AvailabilitySearchResponse  "Ranked free slots"
+// @Failure      400           {object}  error                       "Invalid input parameters"
+// @Failure      422           {object}  error                       "Duration not allowed by the slot policy"
+// @Router       /api/v1/schedules/availability/search [get]
+// @Security 	 BearerAuth
+func (h *ScheduleHandler) SearchAvailability(w http.ResponseWriter, r *http.Request) {
+	loc, err := api.ParseTimezone(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	query, err := parseAvailabilitySearchQuery(w, r, loc)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	slots, err := h.service.SearchAvailability(r.Context(), query, loc)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, slots)
+}
+
+func parseAvailabilitySearchQuery(w http.ResponseWriter, r *http.Request, loc *time.Location) (*AvailabilitySearchQuery, error) {
+	values := r.URL.Query()
+	query := &AvailabilitySearchQuery{
+		Subject:      values.Get("subject"),
+		EarliestTime: values.Get("earliestTime"),
+		LatestTime:   values.Get("latestTime"),
+	}
+
+	var err error
+	if query.FromDate, err = api.ParseLocalTimeQuery(w, r, "fromDate", loc); err != nil {
+		return nil, err
+	}
+	if query.ToDate, err = api.ParseLocalTimeQuery(w, r, "toDate", loc); err != nil {
+		return nil, err
+	}
+	if query.DurationMin, err = api.ParseIntQuery(w, r, "durationMin", 0); err != nil {
+		return nil, err
+	}
+	if query.PerEducator, err = api.ParseIntQuery(w, r, "perEducator", 0); err != nil {
+		return nil, err
+	}
+	if query.Limit, err = api.ParseIntQuery(w, r, "limit", 0); err != nil {
+		return nil, err
+	}
+
+	if values.Get("productId") != "" {
+		productId, err := api.ParseLongQuery(w, r, "productId")
+		if err != nil {
+			return nil, err
+		}
+		query.ProductId = &productId
+	}
+
+	for _, value := range values["educatorId"] {
+		educatorId, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid format for query parameter 'educatorId', expected a valid UUID, received: '%s'", value)
+		}
+		query.EducatorIds = append(query.EducatorIds, educatorId)
+	}
+
+	for _, value := range values["weekday"] {
+		weekday, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid format for query parameter 'weekday', expected an integer, received: '%s'", value)
+		}
+		query.Weekdays = append(query.Weekdays, weekday)
+	}
+
+	return query, nil
+}
+
+// GetWeeklyScheduleDocument renders a user's weekly schedule sheet.
+// @Summary      Download weekly schedule PDF
+// @Description  Renders the user's working periods, scheduled events and bookings of the week starting at 'weekStart' as a PDF document.
+// @Tags         Schedule
+// @Accept       json
+// @Produce      application/pdf
+// @Param        userId     path      string  true  "User ID (UUID)"
+// @Param        weekStart  query     string  true   "First day of the week in YYYY-MM-DD format"
+// @Param        timezone   query     string  false  "IANA time zone the week is laid out in, also accepted as 'Prefer: timezone=' header; defaults to UTC"
+// @Success      200        {file}    file    "Weekly schedule PDF"
+// @Failure      400        {object}  error   "Invalid input parameters"
+// @Router       /api/v1/schedules/{userId}/week.pdf [get]
+// @Security 	 BearerAuth
+func (h *ScheduleHandler) GetWeeklyScheduleDocument(w http.ResponseWriter, r *http.Request) {
+	userId, err := api.ParseUUIDParam(w, r, "userId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	loc, err := api.ParseTimezone(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	weekStart, err := api.ParseTimeQuery(w, r, "weekStart", time.DateOnly)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	document, err := h.service.GetWeeklyScheduleDocument(r.Context(), userId, weekStart, loc)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteFile(w, "application/pdf", "schedule-"+weekStart.Format(time.DateOnly)+".pdf", document)
+}
+
+// GetCalendarFeed renders an educator's sessions as an iCalendar feed.
+// @Summary      Educator iCalendar feed
+// @Description  Renders the scheduled events and individual bookings of the educator from a week ago to 90 days ahead as an iCalendar feed. Authenticated by the signed feed token instead of a bearer token, for subscriptions from calendar apps.
+// @Tags         Schedule
+// @Produce      text/calendar
+// @Param        educatorId  path      string  true  "Educator ID (UUID)"
+// @Param        token       query     string  true  "Signed feed token"
+// @Success      200         {file}    file    "iCalendar feed"
+// @Failure      404         {object}  error   "Calendar feed not found"
+// @Router       /api/v1/schedules/{educatorId}/calendar.ics [get]
+func (h *ScheduleHandler) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	feed, err := h.service.GetCalendarFeed(r.Context(), educatorId, r.URL.Query().Get("token"))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteFile(w, "text/calendar; charset=utf-8", "schedule.ics", feed)
+}
+
+// GetMyCalendarFeed retrieves the calendar feed subscription link of the current educator.
+// @Summary      Retrieve my calendar feed link
+// @Description  Returns the iCalendar feed URL of the educator's schedule with its signed token. Anyone holding the link can read the feed.
+// @Tags         Schedule
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  CalendarFeedResponse  "Calendar feed link"
+// @Failure      401  {object}  error                 "Unauthorized"
+// @Router       /api/v1/schedules/my/calendar-feed [get]
+// @Security 	 BearerAuth
+func (h *ScheduleHandler) GetMyCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.service.GetMyCalendarFeed(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, feed)
+}
+
+// GetMySessionBuffers returns the session buffers of the current educator.
+// @Summary      Get my session buffers
+// @Description  Returns the minutes the current educator keeps free before and after each session.
+// @Tags         Schedule
+// @Produce      json
+// @Success      200  {object}  SessionBuffersResponse  "Session buffers"
+// @Failure      401  {object}  error                   "Unauthorized"
+// @Router       /api/v1/schedules/my/buffers [get]
+// @Security 	 BearerAuth
+func (h *ScheduleHandler) GetMySessionBuffers(w http.ResponseWriter, r *http.Request) {
+	buffers, err := h.service.GetMySessionBuffers(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, buffers)
+}
+
+// UpdateMySessionBuffers sets the session buffers of the current educator.
+// @Summary      Update my session buffers
+// @Description  Sets the minutes the current educator keeps free before and after each session, e.g. to prepare or travel between lessons. New bookings and scheduled events must leave the buffer after the earlier session plus the buffer before the later one free between them, and the availability search only offers slots that do. Existing sessions are kept.
+// @Tags         Schedule
+// @Accept       json
+// @Produce      json
+// @Param        buffers  body      SessionBuffersRequest   true  "Session buffers"
+// @Success      200      {object}  SessionBuffersResponse  "Updated session buffers"
+// @Failure      400      {object}  error                   "Invalid input"
+// @Failure      401      {object}  error                   "Unauthorized"
+// @Router       /api/v1/schedules/my/buffers [put]
+// @Security 	 BearerAuth
+func (h *ScheduleHandler) UpdateMySessionBuffers(w http.ResponseWriter, r *http.Request) {
+	var request *SessionBuffersRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	buffers, err := h.service.UpdateMySessionBuffers(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, buffers)
+}
+
 // GetScheduledEventMetadata retrieves metadata for a scheduled event.
 // @Summary      Retrieve scheduled event metadata
 // @Description  Retrieves the schedule for a given user using a date range defined by 'fromDate' and 'toDate' query parameters.
@@ -93,6 +341,7 @@ func (h *ScheduleHandler) GetScheduledEventMetadata(w http.ResponseWriter, r *ht
 // @Accept       json
 // @Produce      json
 // @Param        workingPeriod  body      WorkingPeriodRequest  true  "Working period details"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
 // @Success      201            "Working period created successfully"
 // @Failure      400            {object}  error                "Invalid input"
 // @Router       /api/v1/schedules/working-periods [post]
@@ -127,8 +376,11 @@ func (h *ScheduleHandler) AddWorkingPeriod(w http.ResponseWriter, r *http.Reques
 // @Produce      json
 // @Param        id             path      int                  true  "Working period ID"
 // @Param        workingPeriod  body      WorkingPeriodRequest true  "Updated working period details"
+// @Param        If-Match  header  string  true  "Version of the working period the update applies to"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
 // @Success      204            "Working period updated successfully"
 // @Failure      400            {object}  error                "Invalid input"
+// @Failure      409            {object}  error                "Working period was modified in the meantime"
 // @Router       /api/v1/schedules/working-periods/{id} [put]
 // @Security 	 BearerAuth
 func (h *ScheduleHandler) UpdateWorkingPeriod(w http.ResponseWriter, r *http.Request) {
@@ -138,6 +390,12 @@ func (h *ScheduleHandler) UpdateWorkingPeriod(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	version, err := api.ParseIfMatch(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrVersionRequired))
+		return
+	}
+
 	var request *WorkingPeriodRequest
 	err = json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
@@ -150,7 +408,7 @@ func (h *ScheduleHandler) UpdateWorkingPeriod(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	err = h.service.UpdateWorkingPeriod(r.Context(), id, request)
+	err = h.service.UpdateWorkingPeriod(r.Context(), id, version, request)
 	if err != nil {
 		api.WriteError(w, err)
 		return
@@ -165,8 +423,11 @@ func (h *ScheduleHandler) UpdateWorkingPeriod(w http.ResponseWriter, r *http.Req
 // @Tags         Schedule
 // @Produce      json
 // @Param        id  path  int  true  "Working period ID"
+// @Param        If-Match  header  string  true  "Version of the working period the delete applies to"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
 // @Success      204 "Working period deleted successfully"
 // @Failure      400 {object}  error   "Invalid input"
+// @Failure      409 {object}  error   "Working period was modified in the meantime"
 // @Router       /api/v1/schedules/working-periods/{id} [delete]
 // @Security 	 BearerAuth
 func (h *ScheduleHandler) DeleteWorkingPeriod(w http.ResponseWriter, r *http.Request) {
@@ -176,7 +437,92 @@ func (h *ScheduleHandler) DeleteWorkingPeriod(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	err = h.service.DeleteWorkingPeriod(r.Context(), id)
+	version, err := api.ParseIfMatch(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrVersionRequired))
+		return
+	}
+
+	err = h.service.DeleteWorkingPeriod(r.Context(), id, version)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMyWorkingPeriodRecurrences retrieves recurring working periods of the current educator.
+// @Summary      Retrieve working period recurrences
+// @Description  Retrieves the recurring working period definitions of the educator, newest first.
+// @Tags         Schedule
+// @Produce      json
+// @Success      200  {array}   WorkingPeriodRecurrenceResponse  "Working period recurrences"
+// @Router       /api/v1/schedules/working-periods/recurrences [get]
+// @Security 	 BearerAuth
+func (h *ScheduleHandler) GetMyWorkingPeriodRecurrences(w http.ResponseWriter, r *http.Request) {
+	recurrences, err := h.service.GetMyWorkingPeriodRecurrences(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, recurrences)
+}
+
+// AddWorkingPeriodRecurrence adds a recurring working period for an educator.
+// @Summary      Add working period recurrence
+// @Description  Stores a recurring working period defined by an RRULE (DAILY or WEEKLY with INTERVAL, BYDAY and UNTIL or COUNT, e.g. "FREQ=WEEKLY;BYDAY=MO;UNTIL=20270630") with local start and end times, and creates a working period for each occurrence. Occurrences in the past or overlapping existing working periods are skipped and reported.
+// @Tags         Schedule
+// @Accept       json
+// @Produce      json
+// @Param        recurrence  body      WorkingPeriodRecurrenceRequest  true  "Recurrence details"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      201         {object}  RecurrenceExpansionResponse     "Recurrence created"
+// @Failure      400         {object}  error                           "Invalid input"
+// @Router       /api/v1/schedules/working-periods/recurrences [post]
+// @Security 	 BearerAuth
+func (h *ScheduleHandler) AddWorkingPeriodRecurrence(w http.ResponseWriter, r *http.Request) {
+	var request *WorkingPeriodRecurrenceRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	response, err := h.service.AddWorkingPeriodRecurrence(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, response)
+}
+
+// DeleteWorkingPeriodRecurrence deletes a working period recurrence.
+// @Summary      Delete working period recurrence
+// @Description  Deletes the recurrence and its future working periods without bookings or events. Periods that are in use are kept as standalone working periods.
+// @Tags         Schedule
+// @Produce      json
+// @Param        id  path  int  true  "Recurrence ID"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
+// @Success      204 "Recurrence deleted successfully"
+// @Failure      404 {object}  error   "Recurrence not found"
+// @Router       /api/v1/schedules/working-periods/recurrences/{id} [delete]
+// @Security 	 BearerAuth
+func (h *ScheduleHandler) DeleteWorkingPeriodRecurrence(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.DeleteWorkingPeriodRecurrence(r.Context(), id)
 	if err != nil {
 		api.WriteError(w, err)
 		return
@@ -187,12 +533,13 @@ func (h *ScheduleHandler) DeleteWorkingPeriod(w http.ResponseWriter, r *http.Req
 
 // AddScheduledEvent adds a scheduled event to a working period.
 // @Summary      Add scheduled event
-// @Description  Creates a new scheduled event for a specific working period using the provided event details.
+// @Description  Creates a new scheduled event for a specific working period using the provided event details. Capacity defaults to the maximum participants of the product and may only lower it. The event must last a duration allowed by the slot policy and start on its alignment.
 // @Tags         Schedule
 // @Accept       json
 // @Produce      json
 // @Param        workingPeriodId  path      int                    true  "Working period ID"
 // @Param        event            body      ScheduledEventRequest  true  "Scheduled event details"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
 // @Success      201              "Scheduled event created successfully"
 // @Failure      400              {object}  error                  "Invalid input"
 // @Router       /api/v1/schedules/working-periods/{workingPeriodId}/events [post]
@@ -231,8 +578,11 @@ func (h *ScheduleHandler) AddScheduledEvent(w http.ResponseWriter, r *http.Reque
 // @Tags         Schedule
 // @Produce      json
 // @Param        id  path  int  true	"Event ID"
+// @Param        If-Match  header  string  true  "Version of the scheduled event the delete applies to"
+// @Param        Idempotency-Key  header  string  false  "Retries with the same key replay the first response instead of repeating the request"
 // @Success      204 "Scheduled event	deleted successfully"
 // @Failure      400 {object}   error	"Invalid input"
+// @Failure      409 {object}   error	"Scheduled event was modified in the meantime"
 // @Router       /api/v1/schedules/events/{id} [delete]
 // @Security 	 BearerAuth
 func (h *ScheduleHandler) DeleteScheduledEvent(w http.ResponseWriter, r *http.Request) {
@@ -242,7 +592,13 @@ func (h *ScheduleHandler) DeleteScheduledEvent(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	err = h.service.DeleteScheduledEvent(r.Context(), id)
+	version, err := api.ParseIfMatch(w, r)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrVersionRequired))
+		return
+	}
+
+	err = h.service.DeleteScheduledEvent(r.Context(), id, version)
 	if err != nil {
 		api.WriteError(w, err)
 		return
@@ -1,18 +1,25 @@
 package schedule
 
 import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/documents"
 )
 
 func MapWorkingPeriodToResponse(wp *entities.WorkingPeriod) *WorkingPeriodResponse {
 	return &WorkingPeriodResponse{
-		Id:        wp.Id,
-		StartTime: wp.StartTime,
-		EndTime:   wp.EndTime,
+		Id:           wp.Id,
+		StartTime:    wp.StartTime,
+		EndTime:      wp.EndTime,
+		RecurrenceId: wp.RecurrenceId,
+		Version:      wp.Version,
 	}
 }
 
@@ -31,40 +38,112 @@ func MapWorkingPeriodsToResponse(wps []*entities.WorkingPeriod) []*WorkingPeriod
 func MapRequestToWorkingPeriod(userId uuid.UUID, wpr *WorkingPeriodRequest) *entities.WorkingPeriod {
 	return &entities.WorkingPeriod{
 		UserId:    userId,
-		StartTime: wpr.StartTime,
-		EndTime:   wpr.EndTime,
+		StartTime: wpr.StartTime.UTC(),
+		EndTime:   wpr.EndTime.UTC(),
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
 	}
 }
 
 func MapRequestWithWorkingPeriod(wpr *WorkingPeriodRequest, wp *entities.WorkingPeriod) {
-	wp.StartTime = wpr.StartTime
-	wp.EndTime = wpr.EndTime
+	wp.StartTime = wpr.StartTime.UTC()
+	wp.EndTime = wpr.EndTime.UTC()
 	wp.UpdatedAt = time.Now().UTC()
 }
 
-func MapScheduledEventToResponse(se *entities.ScheduledEvent) *ScheduledEventResponse {
+func MapRequestToRecurrence(userId uuid.UUID, r *WorkingPeriodRecurrenceRequest) *entities.WorkingPeriodRecurrence {
+	startsOn, _ := time.Parse(dateLayout, r.StartsOn)
+	return &entities.WorkingPeriodRecurrence{
+		UserId:     userId,
+		RRule:      strings.ToUpper(strings.TrimSpace(r.RRule)),
+		StartClock: r.StartClock,
+		EndClock:   r.EndClock,
+		Timezone:   r.Timezone,
+		StartsOn:   startsOn,
+		CreatedAt:  time.Now().UTC(),
+		UpdatedAt:  time.Now().UTC(),
+	}
+}
+
+func MapOccurrenceToWorkingPeriod(userId uuid.UUID, o occurrence) *entities.WorkingPeriod {
+	return &entities.WorkingPeriod{
+		UserId:    userId,
+		StartTime: o.start,
+		EndTime:   o.end,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+}
+
+func MapRecurrenceToResponse(r *entities.WorkingPeriodRecurrence) *WorkingPeriodRecurrenceResponse {
+	return &WorkingPeriodRecurrenceResponse{
+		Id:         r.Id,
+		RRule:      r.RRule,
+		StartClock: r.StartClock,
+		EndClock:   r.EndClock,
+		Timezone:   r.Timezone,
+		StartsOn:   r.StartsOn.Format(dateLayout),
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+func MapRecurrencesToResponse(rs []*entities.WorkingPeriodRecurrence) []*WorkingPeriodRecurrenceResponse {
+	response := make([]*WorkingPeriodRecurrenceResponse, len(rs))
+	for i, r := range rs {
+		response[i] = MapRecurrenceToResponse(r)
+	}
+	return response
+}
+
+// MapRequestToSchedulingPolicy sets the buffers an educator keeps before and after their sessions
+func MapRequestToSchedulingPolicy(userId uuid.UUID, r *SessionBuffersRequest) *entities.SchedulingPolicy {
+	return &entities.SchedulingPolicy{
+		EducatorId:          userId,
+		BufferBeforeMinutes: r.BeforeMinutes,
+		BufferMinutes:       r.AfterMinutes,
+		CreatedAt:           time.Now().UTC(),
+		UpdatedAt:           time.Now().UTC(),
+	}
+}
+
+func MapSchedulingPolicyToSessionBuffers(p *entities.SchedulingPolicy) *SessionBuffersResponse {
+	return &SessionBuffersResponse{
+		BeforeMinutes: p.BufferBeforeMinutes,
+		AfterMinutes:  p.BufferMinutes,
+	}
+}
+
+// MapScheduledEventToResponse returns the scheduled event with its title and description in the first of the
+// preferred locales it is translated to
+func MapScheduledEventToResponse(se *entities.ScheduledEvent, locales []string) *ScheduledEventResponse {
+	title, description, locale := se.Translations.Translate(locales, se.Title, "")
 	return &ScheduledEventResponse{
 		Id:              se.Id,
 		ProductId:       se.ProductId,
 		LessonId:        se.LessonId,
-		Title:           se.Title,
+		SessionTypeId:   se.SessionTypeId,
+		LocationId:      se.LocationId,
+		Title:           title,
+		Description:     description,
+		Locale:          locale,
 		WorkingPeriodId: se.WorkingPeriodId,
 		StartTime:       se.StartTime,
 		EndTime:         se.EndTime,
 		MaxParticipants: se.MaxParticipants,
+		Price:           se.Price,
+		Translations:    se.Translations,
+		Version:         se.Version,
 	}
 }
 
-func MapScheduledEventsToResponse(ses []*entities.ScheduledEvent) []*ScheduledEventResponse {
+func MapScheduledEventsToResponse(ses []*entities.ScheduledEvent, locales []string) []*ScheduledEventResponse {
 	if len(ses) == 0 {
 		return []*ScheduledEventResponse{}
 	}
 
 	response := make([]*ScheduledEventResponse, len(ses))
 	for i, se := range ses {
-		response[i] = MapScheduledEventToResponse(se)
+		response[i] = MapScheduledEventToResponse(se, locales)
 	}
 	return response
 }
@@ -75,16 +154,21 @@ func MapRequestToScheduledEvent(
 	workingPeriodId int64,
 	title string,
 	maxParticipants int,
+	price float64,
 ) *entities.ScheduledEvent {
 	return &entities.ScheduledEvent{
 		ProductId:       ser.ProductId,
 		LessonId:        ser.LessonId,
+		SessionTypeId:   ser.SessionTypeId,
+		LocationId:      ser.LocationId,
 		WorkingPeriodId: workingPeriodId,
 		UserId:          userId,
 		Title:           title,
 		MaxParticipants: maxParticipants,
-		StartTime:       ser.StartTime,
-		EndTime:         ser.EndTime,
+		Price:           price,
+		Translations:    ser.Translations.Normalized(),
+		StartTime:       ser.StartTime.UTC(),
+		EndTime:         ser.EndTime.UTC(),
 		CreatedAt:       time.Now().UTC(),
 		UpdatedAt:       time.Now().UTC(),
 	}
@@ -98,10 +182,13 @@ func MapBookingToResponse(b *entities.Booking) *BookingResponse {
 		EnrollmentId:     b.EnrollmentId,
 		ProductId:        b.ProductId,
 		ScheduledEventId: b.ScheduledEventId,
+		SessionTypeId:    b.SessionTypeId,
 		WorkingPeriodId:  b.WorkingPeriodId,
 		StartTime:        b.StartTime,
 		EndTime:          b.EndTime,
 		Status:           int(b.Status),
+		Price:            b.Price,
+		Version:          b.Version,
 	}
 }
 
@@ -116,3 +203,133 @@ func MapBookingsToResponse(bs []*entities.Booking) []*BookingResponse {
 	}
 	return response
 }
+
+// LocalizeSchedule converts the times of a schedule to the given time zone
+func LocalizeSchedule(s *ScheduleResponse, loc *time.Location) {
+	s.Timezone = loc.String()
+	for _, wp := range s.WorkingPeriods {
+		wp.StartTime, wp.EndTime = wp.StartTime.In(loc), wp.EndTime.In(loc)
+	}
+	for _, se := range s.ScheduledEvents {
+		se.StartTime, se.EndTime = se.StartTime.In(loc), se.EndTime.In(loc)
+	}
+	for _, b := range s.Bookings {
+		b.StartTime, b.EndTime = b.StartTime.In(loc), b.EndTime.In(loc)
+	}
+}
+
+// MapScheduleToWeeklyDocument lays the schedule out into seven days starting at weekStart, items ordered by
+// start time. Days follow the calendar of weekStart's time zone, so they may be shorter or longer than
+// 24 hours around DST transitions.
+func MapScheduleToWeeklyDocument(userId uuid.UUID, weekStart time.Time, s *ScheduleResponse) *documents.WeeklySchedule {
+	days := make([]*documents.ScheduleDay, 7)
+	for i := range days {
+		days[i] = &documents.ScheduleDay{Date: weekStart.AddDate(0, 0, i)}
+	}
+
+	weekEnd := weekStart.AddDate(0, 0, len(days))
+	add := func(item *documents.ScheduleItem) {
+		if item.StartTime.Before(weekStart) || !item.StartTime.Before(weekEnd) {
+			return
+		}
+		for i := len(days) - 1; i >= 0; i-- {
+			if !item.StartTime.Before(days[i].Date) {
+				days[i].Items = append(days[i].Items, item)
+				return
+			}
+		}
+	}
+
+	for _, wp := range s.WorkingPeriods {
+		add(&documents.ScheduleItem{Kind: "Available", StartTime: wp.StartTime, EndTime: wp.EndTime})
+	}
+	for _, se := range s.ScheduledEvents {
+		add(&documents.ScheduleItem{
+			Kind:      "Event",
+			Title:     se.Title,
+			StartTime: se.StartTime,
+			EndTime:   se.EndTime,
+			Details:   fmt.Sprintf("up to %d participants", se.MaxParticipants),
+		})
+	}
+	for _, b := range s.Bookings {
+		if b.ScheduledEventId != nil {
+			continue
+		}
+		add(&documents.ScheduleItem{
+			Kind:      "Booking",
+			StartTime: b.StartTime,
+			EndTime:   b.EndTime,
+			Details:   entities.BookingStatus(b.Status).String(),
+		})
+	}
+
+	for _, d := range days {
+		sort.Slice(d.Items, func(i, j int) bool { return d.Items[i].StartTime.Before(d.Items[j].StartTime) })
+	}
+
+	return &documents.WeeklySchedule{
+		UserId:    userId.String(),
+		WeekStart: weekStart,
+		WeekEnd:   weekStart.AddDate(0, 0, 6),
+		Days:      days,
+	}
+}
+
+// MapScheduleToCalendarFeed lists the scheduled events and individual bookings of an educator as feed events
+func MapScheduleToCalendarFeed(events []*entities.ScheduledEvent, bookings []*entities.Booking, places []*entities.Location) *documents.CalendarFeed {
+	placeById := make(map[int64]*entities.Location, len(places))
+	for _, p := range places {
+		placeById[p.Id] = p
+	}
+
+	feed := &documents.CalendarFeed{Name: "Ora schedule", Events: make([]*documents.CalendarEvent, 0, len(events)+len(bookings))}
+	for _, e := range events {
+		event := &documents.CalendarEvent{
+			Uid:         fmt.Sprintf("scheduled-event-%d@ora", e.Id),
+			Title:       e.Title,
+			Description: fmt.Sprintf("Group session, up to %d participants", e.MaxParticipants),
+			Status:      documents.CalendarEventConfirmed,
+			StartTime:   e.StartTime,
+			EndTime:     e.EndTime,
+			UpdatedAt:   e.UpdatedAt,
+		}
+		if e.LocationId != nil {
+			if p, ok := placeById[*e.LocationId]; ok {
+				event.Location = p.Name + ", " + p.Address
+			}
+		}
+		feed.Events = append(feed.Events, event)
+	}
+	for _, b := range bookings {
+		feed.Events = append(feed.Events, MapBookingToCalendarEvent(b))
+	}
+
+	sort.Slice(feed.Events, func(i, j int) bool { return feed.Events[i].StartTime.Before(feed.Events[j].StartTime) })
+	return feed
+}
+
+// MapBookingToCalendarEvent shows a booking as a feed event, cancelled bookings stay in the feed as cancelled
+// so subscribed calendars remove them
+func MapBookingToCalendarEvent(b *entities.Booking) *documents.CalendarEvent {
+	status := documents.CalendarEventConfirmed
+	switch b.Status {
+	case entities.Pending, entities.AwaitingPayment:
+		status = documents.CalendarEventTentative
+	case entities.Cancelled:
+		status = documents.CalendarEventCancelled
+	}
+
+	return &documents.CalendarEvent{
+		Uid:       fmt.Sprintf("booking-%d@ora", b.Id),
+		Title:     b.Title,
+		Status:    status,
+		StartTime: b.StartTime,
+		EndTime:   b.EndTime,
+		UpdatedAt: b.UpdatedAt,
+	}
+}
+
+func MapCalendarFeedToResponse(path string, token string) *CalendarFeedResponse {
+	return &CalendarFeedResponse{Url: path + "?token=" + url.QueryEscape(token)}
+}
@@ -6,21 +6,26 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/documents"
+	"github.com/maksmelnyk/scheduling/internal/feeds"
 	"github.com/maksmelnyk/scheduling/internal/logger"
 	"github.com/maksmelnyk/scheduling/internal/messaging"
-	"github.com/maksmelnyk/scheduling/internal/products"
 )
 
 func InitializeScheduleService(
 	log logger.Logger,
 	db *sqlx.DB,
-	cfg *config.ExternalServiceConfig,
-	httpClient *http.Client,
+	products ProductMetadataProvider,
 	publisher *messaging.Publisher,
+	renderer *documents.Renderer,
+	feeds *feeds.Signer,
+	travel *config.LocationConfig,
+	cache ScheduleCache,
+	webhooks WebhookDispatcher,
+	granularity SlotGranularityProvider,
 ) *ScheduleService {
 	repo := NewScheduleRepository(db)
-	client := products.NewProductServiceClient(*cfg, httpClient)
-	service := NewScheduleService(log, repo, client, publisher)
+	service := NewScheduleService(log, repo, travel, products, publisher, renderer, feeds, cache, webhooks, granularity)
 	return service
 }
 
@@ -0,0 +1,180 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxRecurrenceOccurrences bounds how many working periods a single recurrence may materialize
+	maxRecurrenceOccurrences = 366
+	// maxRecurrenceSpan bounds how far after its first day a recurrence may run
+	maxRecurrenceSpan = 366 * 24 * time.Hour
+	dateLayout        = "2006-01-02"
+)
+
+// Reasons an occurrence of a recurrence is not materialized
+const (
+	SkippedInPast  = "IN_PAST"
+	SkippedOverlap = "OVERLAPS_WORKING_PERIOD"
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// recurrenceRule is the supported subset of an RFC 5545 RRULE: DAILY or WEEKLY frequency with an optional
+// INTERVAL and BYDAY, bounded by UNTIL or COUNT. Weeks start on Monday.
+type recurrenceRule struct {
+	freq     string
+	interval int
+	byDay    []time.Weekday
+	until    *time.Time
+	count    int
+}
+
+type occurrence struct {
+	start time.Time
+	end   time.Time
+}
+
+// parseRRule parses an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20270630"
+func parseRRule(value string) (*recurrenceRule, error) {
+	rule := &recurrenceRule{interval: 1}
+
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid rule part '%s'", part)
+		}
+
+		switch key {
+		case "FREQ":
+			if val != "DAILY" && val != "WEEKLY" {
+				return nil, fmt.Errorf("unsupported frequency '%s', expected DAILY or WEEKLY", val)
+			}
+			rule.freq = val
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 || interval > 52 {
+				return nil, errors.New("INTERVAL must be between 1 and 52")
+			}
+			rule.interval = interval
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, ok := weekdayCodes[code]
+				if !ok {
+					return nil, fmt.Errorf("invalid weekday '%s'", code)
+				}
+				rule.byDay = append(rule.byDay, day)
+			}
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.until = &until
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 || count > maxRecurrenceOccurrences {
+				return nil, fmt.Errorf("COUNT must be between 1 and %d", maxRecurrenceOccurrences)
+			}
+			rule.count = count
+		default:
+			return nil, fmt.Errorf("unsupported rule part '%s'", key)
+		}
+	}
+
+	if rule.freq == "" {
+		return nil, errors.New("FREQ is required")
+	}
+	if rule.until == nil && rule.count == 0 {
+		return nil, errors.New("UNTIL or COUNT is required")
+	}
+	if rule.until != nil && rule.count > 0 {
+		return nil, errors.New("UNTIL and COUNT must not be combined")
+	}
+	if rule.freq == "DAILY" && len(rule.byDay) > 0 {
+		return nil, errors.New("BYDAY is only supported with WEEKLY frequency")
+	}
+	return rule, nil
+}
+
+// parseUntil accepts a date (YYYYMMDD) or a UTC date-time (YYYYMMDDTHHMMSSZ); only the date is used
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102", "20060102T150405Z"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL '%s', expected YYYYMMDD", value)
+}
+
+// expand materializes the occurrences of the rule from startsOn, each running between the local wall
+// clock times in loc. startsOn is a calendar date; the rule stops at the earliest of its own bound and
+// the maximum span.
+func (r *recurrenceRule) expand(startsOn time.Time, startClock, endClock time.Duration, loc *time.Location) []occurrence {
+	first := time.Date(startsOn.Year(), startsOn.Month(), startsOn.Day(), 0, 0, 0, 0, time.UTC)
+	last := first.Add(maxRecurrenceSpan)
+	if r.until != nil && r.until.Before(last) {
+		last = *r.until
+	}
+
+	byDay := r.byDay
+	if r.freq == "WEEKLY" && len(byDay) == 0 {
+		byDay = []time.Weekday{first.Weekday()}
+	}
+	// Weekly intervals are counted from the Monday of the first week
+	firstMonday := first.AddDate(0, 0, -((int(first.Weekday()) + 6) % 7))
+
+	var result []occurrence
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !r.matches(day, first, firstMonday, byDay) {
+			continue
+		}
+
+		local := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+		result = append(result, occurrence{
+			start: localClock(local, startClock, loc),
+			end:   localClock(local, endClock, loc),
+		})
+		if len(result) == maxRecurrenceOccurrences || (r.count > 0 && len(result) == r.count) {
+			break
+		}
+	}
+	return result
+}
+
+func (r *recurrenceRule) matches(day, first, firstMonday time.Time, byDay []time.Weekday) bool {
+	if r.freq == "DAILY" {
+		return int(day.Sub(first).Hours()/24)%r.interval == 0
+	}
+
+	week := int(day.Sub(firstMonday).Hours() / (24 * 7))
+	if week%r.interval != 0 {
+		return false
+	}
+	for _, d := range byDay {
+		if d == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// localClock resolves an offset from midnight on a local day, so that DST transitions keep the wall clock
+func localClock(day time.Time, offset time.Duration, loc *time.Location) time.Time {
+	h := int(offset.Hours())
+	m := int(offset.Minutes()) % 60
+	return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, loc).UTC()
+}
@@ -8,10 +8,18 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
 	"github.com/maksmelnyk/scheduling/internal/database"
 	"github.com/maksmelnyk/scheduling/internal/database/entities"
 )
 
+// BusyInterval holds the time of an educator taken by a booking, a booking hold or a scheduled event
+type BusyInterval struct {
+	EducatorId uuid.UUID `db:"educator_id"`
+	StartTime  time.Time `db:"start_time"`
+	EndTime    time.Time `db:"end_time"`
+}
+
 type ScheduleRepo struct {
 	db *sqlx.DB
 }
@@ -23,9 +31,9 @@ func NewScheduleRepository(db *sqlx.DB) *ScheduleRepo {
 // GetWorkingPeriods retrieves working periods for a specific user within a date range
 func (r *ScheduleRepo) GetWorkingPeriods(ctx context.Context, userId uuid.UUID, fromDate, toDate time.Time) ([]*entities.WorkingPeriod, error) {
 	const query = `
-        SELECT id, user_id, start_time, end_time, created_at, updated_at
+        SELECT id, user_id, start_time, end_time, recurrence_id, version, created_at, updated_at
         FROM working_period
-        WHERE user_id = $1 AND start_time >= $2 AND end_time <= $3
+        WHERE user_id = $1 AND start_time >= $2 AND end_time <= $3 AND deleted_at IS NULL
     `
 	return database.FetchMultiple[entities.WorkingPeriod](ctx, r.db, query, userId, fromDate, toDate)
 }
@@ -33,9 +41,9 @@ func (r *ScheduleRepo) GetWorkingPeriods(ctx context.Context, userId uuid.UUID,
 // GetScheduledEvents retrieves scheduled events for working periods
 func (r *ScheduleRepo) GetWorkingPeriodScheduledEvents(ctx context.Context, workingPeriodIds []int64) ([]*entities.ScheduledEvent, error) {
 	const query = `
-        SELECT id, user_id, product_id, lesson_id, title, working_period_id, start_time, end_time, max_participants, created_at, updated_at
+        SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, translations, version, created_at, updated_at
         FROM scheduled_event
-        WHERE working_period_id = ANY($1)
+        WHERE working_period_id = ANY($1) AND deleted_at IS NULL
     `
 	return database.FetchMultiple[entities.ScheduledEvent](ctx, r.db, query, pq.Array(workingPeriodIds))
 }
@@ -43,7 +51,7 @@ func (r *ScheduleRepo) GetWorkingPeriodScheduledEvents(ctx context.Context, work
 // GetBookings retrieves bookings for working period
 func (r *ScheduleRepo) GetWorkingPeriodBookings(ctx context.Context, workingPeriodIds []int64) ([]*entities.Booking, error) {
 	const query = `
-        SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, working_period_id, start_time, end_time, status, created_at, updated_at
+        SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, start_time, end_time, status, price, version, created_at, updated_at
         FROM booking
         WHERE working_period_id = ANY($1)
     `
@@ -53,9 +61,9 @@ func (r *ScheduleRepo) GetWorkingPeriodBookings(ctx context.Context, workingPeri
 // GetWorkingPeriodById retrieves a single working period by its ID
 func (r *ScheduleRepo) GetWorkingPeriodById(ctx context.Context, userId uuid.UUID, id int64) (*entities.WorkingPeriod, error) {
 	const query = `
-        SELECT id, user_id, start_time, end_time, created_at, updated_at
+        SELECT id, user_id, start_time, end_time, version, created_at, updated_at
         FROM working_period
-        WHERE user_id = $1 AND id = $2
+        WHERE user_id = $1 AND id = $2 AND deleted_at IS NULL
     `
 	return database.FetchSingle[entities.WorkingPeriod](ctx, r.db, query, userId, id)
 }
@@ -63,15 +71,15 @@ func (r *ScheduleRepo) GetWorkingPeriodById(ctx context.Context, userId uuid.UUI
 // GetScheduledEventById retrieves a single scheduled event by its ID
 func (r *ScheduleRepo) GetScheduledEventById(ctx context.Context, userId uuid.UUID, id int64) (*entities.ScheduledEvent, error) {
 	const query = `
-		SELECT id, user_id, product_id, lesson_id, title, working_period_id, start_time, end_time, max_participants, created_at, updated_at
+		SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, version, created_at, updated_at
 		FROM scheduled_event
-		WHERE session_id = $1 AND id = $2
+		WHERE session_id = $1 AND id = $2 AND deleted_at IS NULL
 	`
 	return database.FetchSingle[entities.ScheduledEvent](ctx, r.db, query, userId, id)
 }
 
 func (r *ScheduleRepo) GetScheduledEventLessonIds(ctx context.Context, productId int64) ([]int64, error) {
-	const query = `SELECT lesson_id FROM scheduled_event WHERE product_id = $1`
+	const query = `SELECT lesson_id FROM scheduled_event WHERE product_id = $1 AND deleted_at IS NULL`
 	ptrResults, err := database.FetchMultiple[int64](ctx, r.db, query, productId)
 	if err != nil {
 		return nil, err
@@ -87,7 +95,7 @@ func (r *ScheduleRepo) GetScheduledEventLessonIds(ctx context.Context, productId
 }
 
 func (r *ScheduleRepo) ProductScheduledEventExists(ctx context.Context, id int64, productId int64) (bool, error) {
-	const query = `SELECT EXISTS (SELECT 1 FROM scheduled_event WHERE id = $1 AND product_id = $2)`
+	const query = `SELECT EXISTS (SELECT 1 FROM scheduled_event WHERE id = $1 AND product_id = $2 AND deleted_at IS NULL)`
 	return database.CheckExists(ctx, r.db, query, id, productId)
 }
 
@@ -96,7 +104,7 @@ func (r *ScheduleRepo) HasLinkedEvents(ctx context.Context, workingPeriodId int6
 	const query = `
 		SELECT 
 			EXISTS (SELECT 1 FROM booking WHERE working_period_id = $1) OR 
-			EXISTS (SELECT 1 FROM scheduled_event WHERE working_period_id = $1);
+			EXISTS (SELECT 1 FROM scheduled_event WHERE working_period_id = $1 AND deleted_at IS NULL);
 	`
 	return database.CheckExists(ctx, r.db, query, workingPeriodId)
 }
@@ -118,40 +126,308 @@ func (r *ScheduleRepo) AddWorkingPeriod(ctx context.Context, workingPeriod *enti
 	return database.ExecNamedQuery(ctx, r.db, query, workingPeriod)
 }
 
-// UpdateWorkingPeriod updates an existing working period
-func (r *ScheduleRepo) UpdateWorkingPeriod(ctx context.Context, workingPeriod *entities.WorkingPeriod) error {
+// UpdateWorkingPeriod updates an existing working period while it still has the version it was read with.
+// It returns false when the working period has been modified in the meantime.
+func (r *ScheduleRepo) UpdateWorkingPeriod(ctx context.Context, workingPeriod *entities.WorkingPeriod) (bool, error) {
 	const query = `
         UPDATE working_period
-        SET start_time = :start_time, end_time = :end_time, updated_at = :updated_at
-        WHERE id = :id
+        SET start_time = :start_time, end_time = :end_time, version = version + 1, updated_at = :updated_at
+        WHERE id = :id AND version = :version AND deleted_at IS NULL
     `
-	return database.ExecNamedQuery(ctx, r.db, query, workingPeriod)
+	result, err := database.Conn(ctx, r.db).NamedExecContext(ctx, query, workingPeriod)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
 }
 
 // AddScheduledEvent adds a new scheduled event
 func (r *ScheduleRepo) AddScheduledEvent(ctx context.Context, scheduledEvent *entities.ScheduledEvent) error {
 	const query = `
-		INSERT INTO scheduled_event (user_id, product_id, lesson_id, title, working_period_id, start_time, end_time, max_participants, created_at, updated_at)
-		VALUES (:user_id, :product_id, :lesson_id, :title, :working_period_id, :start_time, :end_time, :max_participants, :created_at, :updated_at)
+		INSERT INTO scheduled_event (user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, translations, created_at, updated_at)
+		VALUES (:user_id, :product_id, :lesson_id, :session_type_id, :location_id, :title, :working_period_id, :start_time, :end_time, :max_participants, :price, :translations, :created_at, :updated_at)
 		RETURNING id
 	`
 	return database.ExecNamedQuery(ctx, r.db, query, scheduledEvent)
 }
 
-// DeleteWorkingPeriod deletes a working period by its ID
-func (r *ScheduleRepo) DeleteWorkingPeriod(ctx context.Context, userId uuid.UUID, id int64) error {
+// DeleteWorkingPeriod soft deletes a working period by its ID while it still has the given version. It
+// returns false when the working period has been modified in the meantime.
+func (r *ScheduleRepo) DeleteWorkingPeriod(ctx context.Context, userId uuid.UUID, id int64, version int64) (bool, error) {
+	query := database.WithTombstones(database.TombstoneWorkingPeriod, `
+        UPDATE working_period SET deleted_at = current_timestamp
+        WHERE user_id = $1 AND id = $2 AND version = $3 AND deleted_at IS NULL
+    `)
+	return r.execAffected(ctx, query, userId, id, version)
+}
+
+// DeleteScheduledEvent soft deletes a scheduled event by its ID while it still has the given version. It
+// returns false when the scheduled event has been modified in the meantime.
+func (r *ScheduleRepo) DeleteScheduledEvent(ctx context.Context, userId uuid.UUID, id int64, version int64) (bool, error) {
+	query := database.WithTombstones(database.TombstoneScheduledEvent, `
+		UPDATE scheduled_event SET deleted_at = current_timestamp
+		WHERE user_id = $1 AND id = $2 AND version = $3 AND deleted_at IS NULL
+	`)
+	return r.execAffected(ctx, query, userId, id, version)
+}
+
+// execAffected runs a statement and reports whether it affected any row
+func (r *ScheduleRepo) execAffected(ctx context.Context, query string, args ...any) (bool, error) {
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return affected > 0, nil
+}
+
+// SessionTypeExists checks that an active session type belongs to the educator
+func (r *ScheduleRepo) SessionTypeExists(ctx context.Context, educatorId uuid.UUID, id int64) (bool, error) {
+	const query = `SELECT EXISTS (SELECT 1 FROM session_type WHERE id = $1 AND educator_id = $2 AND archived_at IS NULL)`
+	return database.CheckExists(ctx, r.db, query, id, educatorId)
+}
+
+// GetLocationById retrieves an active location of the educator
+func (r *ScheduleRepo) GetLocationById(ctx context.Context, educatorId uuid.UUID, id int64) (*entities.Location, error) {
 	const query = `
-        DELETE FROM working_period
-        WHERE user_id = $1 AND id = $2
-    `
-	return database.ExecQuery(ctx, r.db, query, userId, id)
+		SELECT id, educator_id, name, address, latitude, longitude, capacity, archived_at, created_at, updated_at
+		FROM location
+		WHERE id = $1 AND educator_id = $2 AND archived_at IS NULL
+	`
+	return database.FetchSingle[entities.Location](ctx, r.db, query, id, educatorId)
+}
+
+// GetLocationsByIds retrieves locations by their Ids, including archived ones
+func (r *ScheduleRepo) GetLocationsByIds(ctx context.Context, ids []int64) ([]*entities.Location, error) {
+	const query = `
+		SELECT id, educator_id, name, address, latitude, longitude, capacity, archived_at, created_at, updated_at
+		FROM location
+		WHERE id = ANY($1)
+	`
+	return database.FetchMultiple[entities.Location](ctx, r.db, query, pq.Array(ids))
+}
+
+// GetUserScheduledEventsWithin retrieves scheduled events of a user intersecting a date range
+func (r *ScheduleRepo) GetUserScheduledEventsWithin(ctx context.Context, userId uuid.UUID, fromDate, toDate time.Time) ([]*entities.ScheduledEvent, error) {
+	const query = `
+		SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, version, created_at, updated_at
+		FROM scheduled_event
+		WHERE user_id = $1 AND start_time < $3 AND end_time > $2 AND deleted_at IS NULL
+	`
+	return database.FetchMultiple[entities.ScheduledEvent](ctx, r.db, query, userId, fromDate, toDate)
+}
+
+// GetEducatorBookingsWithin retrieves individual bookings of an educator intersecting a date range. Bookings
+// of scheduled events are left out, the event itself represents them.
+func (r *ScheduleRepo) GetEducatorBookingsWithin(ctx context.Context, educatorId uuid.UUID, fromDate, toDate time.Time) ([]*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at
+		FROM booking
+		WHERE educator_id = $1 AND scheduled_event_id IS NULL AND start_time < $3 AND end_time > $2
+	`
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, educatorId, fromDate, toDate)
+}
+
+// GetOverlappingWorkingPeriods retrieves working periods of a user intersecting a date range
+func (r *ScheduleRepo) GetOverlappingWorkingPeriods(ctx context.Context, userId uuid.UUID, fromDate, toDate time.Time) ([]*entities.WorkingPeriod, error) {
+	const query = `
+		SELECT id, user_id, start_time, end_time, recurrence_id, version, created_at, updated_at
+		FROM working_period
+		WHERE user_id = $1 AND start_time < $3 AND end_time > $2 AND deleted_at IS NULL
+	`
+	return database.FetchMultiple[entities.WorkingPeriod](ctx, r.db, query, userId, fromDate, toDate)
+}
+
+// GetRecurrences retrieves working period recurrences of a user, newest first
+func (r *ScheduleRepo) GetRecurrences(ctx context.Context, userId uuid.UUID) ([]*entities.WorkingPeriodRecurrence, error) {
+	const query = `
+		SELECT id, user_id, rrule, start_clock, end_clock, timezone, starts_on, created_at, updated_at
+		FROM working_period_recurrence
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+	return database.FetchMultiple[entities.WorkingPeriodRecurrence](ctx, r.db, query, userId)
+}
+
+// AddRecurrence stores a recurrence together with the working periods it materializes and returns its Id
+func (r *ScheduleRepo) AddRecurrence(ctx context.Context, recurrence *entities.WorkingPeriodRecurrence, workingPeriods []*entities.WorkingPeriod) (int64, error) {
+	const recurrenceQuery = `
+		INSERT INTO working_period_recurrence (user_id, rrule, start_clock, end_clock, timezone, starts_on, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+	const periodQuery = `
+		INSERT INTO working_period (user_id, start_time, end_time, recurrence_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = tx.GetContext(
+		ctx,
+		&id,
+		recurrenceQuery,
+		recurrence.UserId,
+		recurrence.RRule,
+		recurrence.StartClock,
+		recurrence.EndClock,
+		recurrence.Timezone,
+		recurrence.StartsOn,
+		recurrence.CreatedAt,
+		recurrence.UpdatedAt,
+	)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	for _, wp := range workingPeriods {
+		if _, err := tx.ExecContext(ctx, periodQuery, wp.UserId, wp.StartTime, wp.EndTime, id, wp.CreatedAt, wp.UpdatedAt); err != nil {
+			return 0, apperrors.NewInternal(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return id, nil
+}
+
+// DeleteRecurrence deletes a recurrence of a user and the future working periods it materialized that have
+// no bookings or events. Other periods are kept as standalone ones. False is returned when the recurrence
+// does not exist.
+func (r *ScheduleRepo) DeleteRecurrence(ctx context.Context, userId uuid.UUID, id int64, now time.Time) (bool, error) {
+	periodsQuery := database.WithTombstones(database.TombstoneWorkingPeriod, `
+		UPDATE working_period wp SET deleted_at = current_timestamp
+		WHERE wp.recurrence_id = $1 AND wp.user_id = $2 AND wp.start_time > $3 AND wp.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM booking b WHERE b.working_period_id = wp.id)
+		AND NOT EXISTS (SELECT 1 FROM scheduled_event se WHERE se.working_period_id = wp.id AND se.deleted_at IS NULL)
+	`)
+	const recurrenceQuery = `DELETE FROM working_period_recurrence WHERE id = $1 AND user_id = $2`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, periodsQuery, id, userId, now); err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+
+	result, err := tx.ExecContext(ctx, recurrenceQuery, id, userId)
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, apperrors.NewInternal(err)
+	}
+	return true, nil
+}
+
+// GetAvailableEducators retrieves up to limit educators with working periods overlapping a range, optionally
+// restricted to the given educators and to those teaching a product or a product whose title matches the
+// subject. Educators leaving the platform are left out.
+func (r *ScheduleRepo) GetAvailableEducators(
+	ctx context.Context,
+	educatorIds []uuid.UUID,
+	productId *int64,
+	subject *string,
+	fromDate, toDate time.Time,
+	limit int,
+) ([]uuid.UUID, error) {
+	const query = `
+		SELECT DISTINCT wp.user_id
+		FROM working_period wp
+		WHERE wp.start_time < $5 AND wp.end_time > $4 AND wp.deleted_at IS NULL
+			AND ($1::uuid[] IS NULL OR wp.user_id = ANY($1))
+			AND ($2::bigint IS NULL OR EXISTS (
+				SELECT 1 FROM catalog_product p
+				WHERE p.product_id = $2 AND p.educator_id = wp.user_id AND p.deleted_at IS NULL
+			))
+			AND ($3::text IS NULL OR EXISTS (
+				SELECT 1 FROM catalog_product p
+				WHERE p.educator_id = wp.user_id AND p.deleted_at IS NULL AND p.title ILIKE '%' || $3 || '%'
+			))
+			AND NOT EXISTS (SELECT 1 FROM teacher_offboarding o WHERE o.educator_id = wp.user_id)
+		ORDER BY wp.user_id
+		LIMIT $6
+	`
+	var educators []uuid.UUID
+	err := database.Conn(ctx, r.db).SelectContext(ctx, &educators, query, pq.Array(educatorIds), productId, subject, fromDate, toDate, limit)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return educators, nil
+}
+
+// GetEducatorsWorkingPeriods retrieves working periods of several educators overlapping a range
+func (r *ScheduleRepo) GetEducatorsWorkingPeriods(ctx context.Context, educatorIds []uuid.UUID, fromDate, toDate time.Time) ([]*entities.WorkingPeriod, error) {
+	const query = `
+		SELECT id, user_id, start_time, end_time, recurrence_id, version, created_at, updated_at
+		FROM working_period
+		WHERE user_id = ANY($1) AND start_time < $3 AND end_time > $2 AND deleted_at IS NULL
+		ORDER BY user_id, start_time
+	`
+	return database.FetchMultiple[entities.WorkingPeriod](ctx, r.db, query, pq.Array(educatorIds), fromDate, toDate)
+}
+
+// GetEducatorsBusyIntervals retrieves the active bookings, unexpired booking holds and scheduled events of
+// several educators overlapping a range
+func (r *ScheduleRepo) GetEducatorsBusyIntervals(ctx context.Context, educatorIds []uuid.UUID, fromDate, toDate, now time.Time) ([]*BusyInterval, error) {
+	const query = `
+		SELECT educator_id, start_time, end_time FROM booking
+		WHERE educator_id = ANY($1) AND status <> $5 AND start_time < $3 AND end_time > $2
+		UNION ALL
+		SELECT educator_id, start_time, end_time FROM booking_hold
+		WHERE educator_id = ANY($1) AND expires_at > $4 AND start_time < $3 AND end_time > $2
+		UNION ALL
+		SELECT user_id, start_time, end_time FROM scheduled_event
+		WHERE user_id = ANY($1) AND start_time < $3 AND end_time > $2 AND deleted_at IS NULL
+	`
+	return database.FetchMultiple[BusyInterval](ctx, r.db, query, pq.Array(educatorIds), fromDate, toDate, now, entities.Cancelled)
+}
+
+// GetSchedulingPolicies retrieves the session buffers of several educators, educators without a scheduling
+// policy are left out
+func (r *ScheduleRepo) GetSchedulingPolicies(ctx context.Context, educatorIds []uuid.UUID) ([]*entities.SchedulingPolicy, error) {
+	const query = `
+		SELECT educator_id, buffer_before_minutes, buffer_minutes
+		FROM scheduling_policy
+		WHERE educator_id = ANY($1)
+	`
+	return database.FetchMultiple[entities.SchedulingPolicy](ctx, r.db, query, pq.Array(educatorIds))
 }
 
-// DeleteScheduledEvent deletes a scheduled event by its ID
-func (r *ScheduleRepo) DeleteScheduledEvent(ctx context.Context, userId uuid.UUID, id int64) error {
+// UpsertSessionBuffers sets the session buffers of an educator, creating the scheduling policy with defaults
+// for the other settings when there is none yet
+func (r *ScheduleRepo) UpsertSessionBuffers(ctx context.Context, policy *entities.SchedulingPolicy) error {
 	const query = `
-		DELETE FROM scheduled_event
-		WHERE user_id = $1 AND id = $2
+		INSERT INTO scheduling_policy (educator_id, buffer_before_minutes, buffer_minutes, created_at, updated_at)
+		VALUES (:educator_id, :buffer_before_minutes, :buffer_minutes, :created_at, :updated_at)
+		ON CONFLICT (educator_id) DO UPDATE
+		SET buffer_before_minutes = EXCLUDED.buffer_before_minutes, buffer_minutes = EXCLUDED.buffer_minutes, updated_at = EXCLUDED.updated_at
 	`
-	return database.ExecQuery(ctx, r.db, query, userId, id)
+	return database.ExecNamedQuery(ctx, r.db, query, policy)
 }
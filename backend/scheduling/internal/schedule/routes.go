@@ -9,17 +9,29 @@ import (
 	"github.com/maksmelnyk/scheduling/internal/middleware"
 )
 
+// CalendarFeedPublicPath is served without authentication, see AuthMiddleware public routes
+const CalendarFeedPublicPath = "/api/v1/schedules/*/calendar.ics"
+
 func Routes(handler *ScheduleHandler) http.Handler {
 	r := chi.NewRouter()
 
 	// Define routes
+	r.Get("/availability/search", handler.SearchAvailability)
 	r.Get("/{userId}", handler.GetUserSchedule)
+	r.Get("/{userId}/week.pdf", handler.GetWeeklyScheduleDocument)
+	r.Get("/{educatorId}/calendar.ics", handler.GetCalendarFeed)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Get("/my/calendar-feed", handler.GetMyCalendarFeed)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Get("/my/buffers", handler.GetMySessionBuffers)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Put("/my/buffers", handler.UpdateMySessionBuffers)
 	r.Post("/scheduled-events/metadata", handler.GetScheduledEventMetadata)
-	r.With(middleware.RoleAuthMiddleware(auth.EducatorRole)).Post("/working-periods", handler.AddWorkingPeriod)
-	r.With(middleware.RoleAuthMiddleware(auth.EducatorRole)).Put("/working-periods/{id}", handler.UpdateWorkingPeriod)
-	r.With(middleware.RoleAuthMiddleware(auth.EducatorRole)).Delete("/working-periods/{id}", handler.DeleteWorkingPeriod)
-	r.With(middleware.RoleAuthMiddleware(auth.EducatorRole)).Post("/working-periods/{workingPeriodId}/events", handler.AddScheduledEvent)
-	r.With(middleware.RoleAuthMiddleware(auth.EducatorRole)).Delete("/events/{id}", handler.DeleteScheduledEvent)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Post("/working-periods", handler.AddWorkingPeriod)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Put("/working-periods/{id}", handler.UpdateWorkingPeriod)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Delete("/working-periods/{id}", handler.DeleteWorkingPeriod)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Get("/working-periods/recurrences", handler.GetMyWorkingPeriodRecurrences)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Post("/working-periods/recurrences", handler.AddWorkingPeriodRecurrence)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Delete("/working-periods/recurrences/{id}", handler.DeleteWorkingPeriodRecurrence)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Post("/working-periods/{workingPeriodId}/events", handler.AddScheduledEvent)
+	r.With(middleware.RequirePermission(auth.ManageSchedulePermission)).Delete("/events/{id}", handler.DeleteScheduledEvent)
 
 	return r
 }
@@ -0,0 +1,277 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+const (
+	// searchStep is the granularity slot start times are laid out on without a slot policy alignment
+	searchStep          = 15 * time.Minute
+	maxSearchRange      = 14 * 24 * time.Hour
+	maxSearchEducators  = 100
+	minSearchDuration   = 15
+	maxSearchDuration   = 8 * 60
+	defaultSearchLimit  = 50
+	maxSearchLimit      = 200
+	defaultPerEducator  = 5
+	maxPerEducator      = 50
+	preferenceTolerance = 3 * time.Hour
+)
+
+// Weights of the slot score, fitting the preferred time of day matters more than starting early
+const (
+	preferenceWeight = 0.7
+	earlinessWeight  = 0.3
+)
+
+// preferredWindow is the local time of day a searcher prefers slots to lie within
+type preferredWindow struct {
+	from time.Duration
+	to   time.Duration
+}
+
+// SearchAvailability returns free slots of the given duration across the educators matching the filters,
+// ranked by how well they fit the preferred time of day and how soon they start. Slots are free when they
+// lie within a working period and overlap no booking, booking hold or scheduled event, keeping the educator's
+// session buffers clear of them. Each educator
+// contributes at most PerEducator slots, so a single open calendar does not crowd out the others.
+func (s *ScheduleService) SearchAvailability(ctx context.Context, query *AvailabilitySearchQuery, loc *time.Location) (*AvailabilitySearchResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	now := time.Now().UTC()
+	window, err := validateAvailabilitySearch(query)
+	if err != nil {
+		return nil, err
+	}
+
+	granularity, err := s.granularity.SlotGranularity(ctx)
+	if err != nil {
+		log.Error("failed to get slot policy", err)
+		return nil, err
+	}
+	duration := time.Duration(query.DurationMin) * time.Minute
+	if reason := granularity.DurationViolation(duration); reason != "" {
+		return nil, apperrors.NewUnprocessedEntity(reason, apperrors.ErrSlotGranularity)
+	}
+
+	from, to := query.FromDate.UTC(), query.ToDate.UTC()
+	if from.Before(now) {
+		from = now
+	}
+	response := &AvailabilitySearchResponse{Slots: []*AvailableSlotResponse{}}
+	if !from.Before(to) {
+		return response, nil
+	}
+
+	var subject *string
+	if value := strings.TrimSpace(query.Subject); value != "" {
+		escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+		subject = &escaped
+	}
+
+	educators, err := s.repo.GetAvailableEducators(ctx, query.EducatorIds, query.ProductId, subject, from, to, maxSearchEducators)
+	if err != nil {
+		log.Error("failed to get available educators", err)
+		return nil, err
+	}
+	response.Educators = len(educators)
+	if len(educators) == 0 {
+		return response, nil
+	}
+
+	periods, err := s.repo.GetEducatorsWorkingPeriods(ctx, educators, from, to)
+	if err != nil {
+		log.Error("failed to get working periods", err)
+		return nil, err
+	}
+
+	// Sessions just outside the range still need their buffers kept clear
+	margin := 2 * maxSessionBufferMinutes * time.Minute
+	busy, err := s.repo.GetEducatorsBusyIntervals(ctx, educators, from.Add(-margin), to.Add(margin), now)
+	if err != nil {
+		log.Error("failed to get busy intervals", err)
+		return nil, err
+	}
+	busyByEducator := make(map[uuid.UUID][]*BusyInterval, len(educators))
+	for _, b := range busy {
+		busyByEducator[b.EducatorId] = append(busyByEducator[b.EducatorId], b)
+	}
+
+	buffers, err := s.sessionBuffers(ctx, educators)
+	if err != nil {
+		log.Error("failed to get session buffers", err)
+		return nil, err
+	}
+
+	step := granularity.Step(searchStep)
+	var slots []*AvailableSlotResponse
+	for _, p := range periods {
+		for _, slot := range freeSlots(p.StartTime, p.EndTime, busyByEducator[p.UserId], buffers[p.UserId], from, to, duration, step) {
+			start := slot.In(loc)
+			if len(query.Weekdays) > 0 && !slices.Contains(query.Weekdays, int(start.Weekday())) {
+				continue
+			}
+			slots = append(slots, &AvailableSlotResponse{
+				EducatorId:      p.UserId,
+				WorkingPeriodId: p.Id,
+				StartTime:       start,
+				EndTime:         start.Add(duration),
+				Score:           scoreSlot(start, duration, window, from, to),
+			})
+		}
+	}
+
+	response.Slots = rankSlots(slots, query.PerEducator, query.Limit)
+	return response, nil
+}
+
+// validateAvailabilitySearch checks the search and applies the default limits, returning the preferred
+// time of day when one is given
+func validateAvailabilitySearch(query *AvailabilitySearchQuery) (*preferredWindow, error) {
+	if !query.FromDate.Before(query.ToDate) {
+		return nil, apperrors.NewBadRequestError("fromDate must be before toDate", apperrors.ErrParameterInvalid)
+	}
+	if query.ToDate.Sub(query.FromDate) > maxSearchRange {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Search range must not exceed %d days", int(maxSearchRange.Hours()/24)), apperrors.ErrParameterInvalid)
+	}
+	if query.DurationMin < minSearchDuration || query.DurationMin > maxSearchDuration {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Duration must be between %d and %d minutes", minSearchDuration, maxSearchDuration), apperrors.ErrParameterInvalid)
+	}
+	for _, day := range query.Weekdays {
+		if day < int(time.Sunday) || day > int(time.Saturday) {
+			return nil, apperrors.NewBadRequestError("Weekdays must be between 0 (Sunday) and 6 (Saturday)", apperrors.ErrParameterInvalid)
+		}
+	}
+
+	if query.Limit == 0 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit < 0 || query.Limit > maxSearchLimit {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Limit must be between 1 and %d", maxSearchLimit), apperrors.ErrParameterInvalid)
+	}
+	if query.PerEducator == 0 {
+		query.PerEducator = defaultPerEducator
+	}
+	if query.PerEducator < 0 || query.PerEducator > maxPerEducator {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("perEducator must be between 1 and %d", maxPerEducator), apperrors.ErrParameterInvalid)
+	}
+
+	if query.EarliestTime == "" && query.LatestTime == "" {
+		return nil, nil
+	}
+	window := &preferredWindow{from: 0, to: 24 * time.Hour}
+	if query.EarliestTime != "" {
+		clock, err := timeutils.ParseClock(query.EarliestTime)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError("earliestTime must be in HH:MM format", apperrors.ErrParameterInvalid)
+		}
+		window.from = clock
+	}
+	if query.LatestTime != "" {
+		clock, err := timeutils.ParseClock(query.LatestTime)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError("latestTime must be in HH:MM format", apperrors.ErrParameterInvalid)
+		}
+		window.to = clock
+	}
+	if window.from >= window.to {
+		return nil, apperrors.NewBadRequestError("earliestTime must be before latestTime", apperrors.ErrParameterInvalid)
+	}
+	return window, nil
+}
+
+// freeSlots lays start times of slots of the given duration over a working period, on the given step,
+// leaving out anything before 'from', after 'to' or closer to a busy interval than the buffers allow
+func freeSlots(periodStart, periodEnd time.Time, busy []*BusyInterval, buffers timeutils.Buffers, from, to time.Time, duration, step time.Duration) []time.Time {
+	start := periodStart
+	if start.Before(from) {
+		start = from
+	}
+	if truncated := start.Truncate(step); truncated.Before(start) {
+		start = truncated.Add(step)
+	}
+
+	end := periodEnd
+	if end.After(to) {
+		end = to
+	}
+
+	var result []time.Time
+	for ; !start.Add(duration).After(end); start = start.Add(step) {
+		slotEnd := start.Add(duration)
+		free := true
+		for _, b := range busy {
+			if buffers.Conflicts(start, slotEnd, b.StartTime, b.EndTime) {
+				free = false
+				break
+			}
+		}
+		if free {
+			result = append(result, start)
+		}
+	}
+	return result
+}
+
+// scoreSlot rates a slot from 0 to 1. A slot within the preferred time of day fits fully, one outside
+// loses fit with its distance to the window until the tolerance is reached. Without a preference every
+// slot fits. Sooner slots rate higher.
+func scoreSlot(start time.Time, duration time.Duration, window *preferredWindow, from, to time.Time) float64 {
+	fit := 1.0
+	if window != nil {
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+		slotFrom := start.Sub(day)
+		slotTo := slotFrom + duration
+
+		var distance time.Duration
+		if slotFrom < window.from {
+			distance = window.from - slotFrom
+		} else if slotTo > window.to {
+			distance = slotTo - window.to
+		}
+		fit = math.Max(0, 1-float64(distance)/float64(preferenceTolerance))
+	}
+
+	earliness := 1 - float64(start.Sub(from))/float64(to.Sub(from))
+	return math.Round((preferenceWeight*fit+earlinessWeight*earliness)*100) / 100
+}
+
+// rankSlots orders slots by score, then start time, and keeps the best perEducator slots of each educator
+// up to the limit
+func rankSlots(slots []*AvailableSlotResponse, perEducator int, limit int) []*AvailableSlotResponse {
+	sort.SliceStable(slots, func(i, j int) bool {
+		if slots[i].Score != slots[j].Score {
+			return slots[i].Score > slots[j].Score
+		}
+		if !slots[i].StartTime.Equal(slots[j].StartTime) {
+			return slots[i].StartTime.Before(slots[j].StartTime)
+		}
+		return slots[i].EducatorId.String() < slots[j].EducatorId.String()
+	})
+
+	result := make([]*AvailableSlotResponse, 0, min(len(slots), limit))
+	taken := make(map[uuid.UUID]int)
+	for _, slot := range slots {
+		if len(result) == limit {
+			break
+		}
+		if taken[slot.EducatorId] == perEducator {
+			continue
+		}
+		taken[slot.EducatorId]++
+		result = append(result, slot)
+	}
+	return result
+}
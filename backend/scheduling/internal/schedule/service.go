@@ -3,19 +3,29 @@ package schedule
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"slices"
 
+	"github.com/maksmelnyk/scheduling/config"
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
 	"github.com/maksmelnyk/scheduling/internal/auth"
 	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/documents"
+	"github.com/maksmelnyk/scheduling/internal/feeds"
+	"github.com/maksmelnyk/scheduling/internal/i18n"
+	"github.com/maksmelnyk/scheduling/internal/locations"
 	"github.com/maksmelnyk/scheduling/internal/logger"
 	"github.com/maksmelnyk/scheduling/internal/messaging"
 	"github.com/maksmelnyk/scheduling/internal/products"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+	"github.com/maksmelnyk/scheduling/internal/webhooks"
 )
 
 type ScheduleRepository interface {
@@ -29,29 +39,103 @@ type ScheduleRepository interface {
 	HasLinkedEvents(ctx context.Context, workingPeriodId int64) (bool, error)
 	HasLinkedBookings(ctx context.Context, scheduledEventId int64) (bool, error)
 	AddWorkingPeriod(ctx context.Context, workingPeriod *entities.WorkingPeriod) error
-	UpdateWorkingPeriod(ctx context.Context, workingPeriod *entities.WorkingPeriod) error
+	UpdateWorkingPeriod(ctx context.Context, workingPeriod *entities.WorkingPeriod) (bool, error)
 	AddScheduledEvent(ctx context.Context, scheduledEvent *entities.ScheduledEvent) error
-	DeleteWorkingPeriod(ctx context.Context, userId uuid.UUID, id int64) error
-	DeleteScheduledEvent(ctx context.Context, userId uuid.UUID, id int64) error
+	DeleteWorkingPeriod(ctx context.Context, userId uuid.UUID, id int64, version int64) (bool, error)
+	DeleteScheduledEvent(ctx context.Context, userId uuid.UUID, id int64, version int64) (bool, error)
+	SessionTypeExists(ctx context.Context, educatorId uuid.UUID, id int64) (bool, error)
+	GetLocationById(ctx context.Context, educatorId uuid.UUID, id int64) (*entities.Location, error)
+	GetLocationsByIds(ctx context.Context, ids []int64) ([]*entities.Location, error)
+	GetUserScheduledEventsWithin(ctx context.Context, userId uuid.UUID, fromDate, toDate time.Time) ([]*entities.ScheduledEvent, error)
+	GetEducatorBookingsWithin(ctx context.Context, educatorId uuid.UUID, fromDate, toDate time.Time) ([]*entities.Booking, error)
+	GetOverlappingWorkingPeriods(ctx context.Context, userId uuid.UUID, fromDate, toDate time.Time) ([]*entities.WorkingPeriod, error)
+	GetRecurrences(ctx context.Context, userId uuid.UUID) ([]*entities.WorkingPeriodRecurrence, error)
+	AddRecurrence(ctx context.Context, recurrence *entities.WorkingPeriodRecurrence, workingPeriods []*entities.WorkingPeriod) (int64, error)
+	DeleteRecurrence(ctx context.Context, userId uuid.UUID, id int64, now time.Time) (bool, error)
+	GetAvailableEducators(ctx context.Context, educatorIds []uuid.UUID, productId *int64, subject *string, fromDate, toDate time.Time, limit int) ([]uuid.UUID, error)
+	GetEducatorsWorkingPeriods(ctx context.Context, educatorIds []uuid.UUID, fromDate, toDate time.Time) ([]*entities.WorkingPeriod, error)
+	GetEducatorsBusyIntervals(ctx context.Context, educatorIds []uuid.UUID, fromDate, toDate, now time.Time) ([]*BusyInterval, error)
+	GetSchedulingPolicies(ctx context.Context, educatorIds []uuid.UUID) ([]*entities.SchedulingPolicy, error)
+	UpsertSessionBuffers(ctx context.Context, policy *entities.SchedulingPolicy) error
+}
+
+// ScheduleCache shares built schedules between requests and instances, with entries owned by the educator
+// so every change to a schedule can drop all of its cached ranges
+type ScheduleCache interface {
+	Get(ctx context.Context, owner, key string, dest any) bool
+	Set(ctx context.Context, owner, key string, value any)
+	Invalidate(ctx context.Context, owner string)
+}
+
+// SlotGranularityProvider returns the session granularity set by the platform slot policy
+type SlotGranularityProvider interface {
+	SlotGranularity(ctx context.Context) (timeutils.SlotGranularity, error)
+}
+
+// ProductMetadataProvider validates products against the learning catalog before events are scheduled
+type ProductMetadataProvider interface {
+	GetSchedulingMetadata(ctx context.Context, productId int64, lessonId *int64, durationMin int, authHeader string) (*products.ProductSchedulingMetadataResponse, error)
+}
+
+// WebhookDispatcher queues events for the webhook subscriptions of an educator
+type WebhookDispatcher interface {
+	Dispatch(ctx context.Context, educatorId uuid.UUID, eventType string, data any)
 }
 
 type ScheduleService struct {
-	log       logger.Logger
-	repo      ScheduleRepository
-	client    *products.ProductServiceClient
-	publisher *messaging.Publisher
+	log         logger.Logger
+	repo        ScheduleRepository
+	travel      *config.LocationConfig
+	products    ProductMetadataProvider
+	publisher   *messaging.Publisher
+	renderer    *documents.Renderer
+	feeds       *feeds.Signer
+	cache       ScheduleCache
+	webhooks    WebhookDispatcher
+	granularity SlotGranularityProvider
 }
 
 func NewScheduleService(
 	log logger.Logger,
 	repo ScheduleRepository,
-	client *products.ProductServiceClient,
+	travel *config.LocationConfig,
+	products ProductMetadataProvider,
 	publisher *messaging.Publisher,
+	renderer *documents.Renderer,
+	feeds *feeds.Signer,
+	cache ScheduleCache,
+	webhooks WebhookDispatcher,
+	granularity SlotGranularityProvider,
 ) *ScheduleService {
-	return &ScheduleService{log: log, repo: repo, client: client, publisher: publisher}
+	return &ScheduleService{log: log, repo: repo, travel: travel, products: products, publisher: publisher, renderer: renderer, feeds: feeds, cache: cache, webhooks: webhooks, granularity: granularity}
+}
+
+// GetScheduleByUserId returns the schedule of a user within a date range, with times in the given time zone.
+// Schedules are cached per range and locales before they are localized.
+func (s *ScheduleService) GetScheduleByUserId(
+	ctx context.Context,
+	userId uuid.UUID,
+	fromDate time.Time,
+	toDate time.Time,
+	loc *time.Location,
+) (*ScheduleResponse, error) {
+	key := fmt.Sprintf("%d:%d:%s", fromDate.Unix(), toDate.Unix(), strings.Join(i18n.Locales(ctx), ","))
+
+	var schedule ScheduleResponse
+	if !s.cache.Get(ctx, userId.String(), key, &schedule) {
+		built, err := s.buildSchedule(ctx, userId, fromDate, toDate)
+		if err != nil {
+			return nil, err
+		}
+		s.cache.Set(ctx, userId.String(), key, built)
+		schedule = *built
+	}
+
+	LocalizeSchedule(&schedule, loc)
+	return &schedule, nil
 }
 
-func (s *ScheduleService) GetScheduleByUserId(ctx context.Context, userId uuid.UUID, fromDate time.Time, toDate time.Time) (*ScheduleResponse, error) {
+func (s *ScheduleService) buildSchedule(ctx context.Context, userId uuid.UUID, fromDate, toDate time.Time) (*ScheduleResponse, error) {
 	log := logger.FromContext(ctx, s.log)
 
 	workingPeriods, err := s.repo.GetWorkingPeriods(ctx, userId, fromDate, toDate)
@@ -81,15 +165,103 @@ func (s *ScheduleService) GetScheduleByUserId(ctx context.Context, userId uuid.U
 		return nil, err
 	}
 
+	eventLocations, err := s.getEventLocations(ctx, scheduledEvents)
+	if err != nil {
+		log.Error("failed to get locations", err)
+		return nil, err
+	}
+
 	schedule := &ScheduleResponse{
 		WorkingPeriods:  MapWorkingPeriodsToResponse(workingPeriods),
-		ScheduledEvents: MapScheduledEventsToResponse(scheduledEvents),
+		ScheduledEvents: MapScheduledEventsToResponse(scheduledEvents, i18n.Locales(ctx)),
 		Bookings:        MapBookingsToResponse(bookings),
+		Locations:       locations.MapLocationsToResponse(slices.Collect(maps.Values(eventLocations))),
 	}
 
 	return schedule, nil
 }
 
+// GetWeeklyScheduleDocument renders the user's schedule of the week starting on the weekStart date as a PDF
+// sheet, laid out in the given time zone
+func (s *ScheduleService) GetWeeklyScheduleDocument(ctx context.Context, userId uuid.UUID, weekStart time.Time, loc *time.Location) ([]byte, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	weekStart = time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, loc)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	schedule, err := s.GetScheduleByUserId(ctx, userId, weekStart, weekEnd, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := s.renderer.RenderWeeklySchedule(MapScheduleToWeeklyDocument(userId, weekStart, schedule))
+	if err != nil {
+		log.Error("failed to render weekly schedule", err)
+		return nil, apperrors.NewInternal(err)
+	}
+
+	return document, nil
+}
+
+// GetCalendarFeed renders the sessions of an educator around now as an iCalendar feed. Calendar apps
+// subscribe without a bearer token, so access is granted by the signed feed token of the educator.
+func (s *ScheduleService) GetCalendarFeed(ctx context.Context, educatorId uuid.UUID, token string) ([]byte, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, tenantId, err := s.feeds.Verify(feeds.ScopeEducatorSchedule, token)
+	if err != nil {
+		return nil, err
+	}
+	if userId != educatorId {
+		return nil, apperrors.NewNotFound("Calendar feed not found", apperrors.ErrCalendarFeedInvalid)
+	}
+	if tenantId != "" {
+		ctx = auth.WithTenant(ctx, tenantId)
+	}
+
+	from, to := feeds.Window(time.Now().UTC())
+	events, err := s.repo.GetUserScheduledEventsWithin(ctx, educatorId, from, to)
+	if err != nil {
+		log.Error("failed to get scheduled events", err)
+		return nil, err
+	}
+
+	bookings, err := s.repo.GetEducatorBookingsWithin(ctx, educatorId, from, to)
+	if err != nil {
+		log.Error("failed to get bookings", err)
+		return nil, err
+	}
+
+	locationIds := make([]int64, 0)
+	for _, e := range events {
+		if e.LocationId != nil {
+			locationIds = append(locationIds, *e.LocationId)
+		}
+	}
+
+	places := []*entities.Location{}
+	if len(locationIds) > 0 {
+		places, err = s.repo.GetLocationsByIds(ctx, locationIds)
+		if err != nil {
+			log.Error("failed to get locations", err)
+			return nil, err
+		}
+	}
+
+	return s.renderer.RenderCalendarFeed(MapScheduleToCalendarFeed(events, bookings, places)), nil
+}
+
+// GetMyCalendarFeed returns the subscription link of the current educator's calendar feed
+func (s *ScheduleService) GetMyCalendarFeed(ctx context.Context) (*CalendarFeedResponse, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	tenantId, _ := auth.GetTenantID(ctx)
+	token := s.feeds.Sign(feeds.ScopeEducatorSchedule, tenantId, userId)
+	return MapCalendarFeedToResponse("/api/v1/schedules/"+userId.String()+"/calendar.ics", token), nil
+}
+
 func (s *ScheduleService) GetScheduledEventMetadata(ctx context.Context, request *ScheduledEventMetadataRequest) (*ScheduledEventMetadataResponse, error) {
 	log := logger.FromContext(ctx, s.log)
 
@@ -158,11 +330,13 @@ func (s *ScheduleService) AddWorkingPeriod(ctx context.Context, request *Working
 		log.Error("failed to add working period", err)
 		return err
 	}
+	s.cache.Invalidate(ctx, userId.String())
+	s.dispatchScheduleUpdated(ctx, userId, webhooks.ChangeWorkingPeriodCreated, nil)
 
 	return nil
 }
 
-func (s *ScheduleService) UpdateWorkingPeriod(ctx context.Context, id int64, request *WorkingPeriodRequest) error {
+func (s *ScheduleService) UpdateWorkingPeriod(ctx context.Context, id int64, version int64, request *WorkingPeriodRequest) error {
 	log := logger.FromContext(ctx, s.log)
 
 	userId, err := auth.GetUserID(ctx)
@@ -176,6 +350,10 @@ func (s *ScheduleService) UpdateWorkingPeriod(ctx context.Context, id int64, req
 		return err
 	}
 
+	if workingPeriod.Version != version {
+		return staleWorkingPeriodError()
+	}
+
 	workingPeriods, err := s.repo.GetWorkingPeriods(ctx, userId, request.StartTime, request.EndTime)
 	if err != nil {
 		log.Error("failed to get working periods", err)
@@ -194,16 +372,21 @@ func (s *ScheduleService) UpdateWorkingPeriod(ctx context.Context, id int64, req
 
 	MapRequestWithWorkingPeriod(request, workingPeriod)
 
-	err = s.repo.UpdateWorkingPeriod(ctx, workingPeriod)
+	updated, err := s.repo.UpdateWorkingPeriod(ctx, workingPeriod)
 	if err != nil {
 		log.Error("failed to update working period", err)
 		return err
 	}
+	if !updated {
+		return staleWorkingPeriodError()
+	}
+	s.cache.Invalidate(ctx, userId.String())
+	s.dispatchScheduleUpdated(ctx, userId, webhooks.ChangeWorkingPeriodUpdated, &id)
 
 	return nil
 }
 
-func (s *ScheduleService) DeleteWorkingPeriod(ctx context.Context, id int64) error {
+func (s *ScheduleService) DeleteWorkingPeriod(ctx context.Context, id int64, version int64) error {
 	log := logger.FromContext(ctx, s.log)
 
 	userId, err := auth.GetUserID(ctx)
@@ -211,16 +394,140 @@ func (s *ScheduleService) DeleteWorkingPeriod(ctx context.Context, id int64) err
 		return apperrors.NewUnauthorized("Unauthorized user", err)
 	}
 
+	workingPeriod, err := s.repo.GetWorkingPeriodById(ctx, userId, id)
+	if err != nil {
+		log.Error("failed to get working period by id", err)
+		return err
+	}
+
+	if workingPeriod.Version != version {
+		return staleWorkingPeriodError()
+	}
+
 	if err := s.hasLinkedEvents(ctx, id); err != nil {
 		log.Error("failed to check if booking exists", err)
 		return err
 	}
 
-	err = s.repo.DeleteWorkingPeriod(ctx, userId, id)
+	deleted, err := s.repo.DeleteWorkingPeriod(ctx, userId, id, version)
 	if err != nil {
 		log.Error("failed to delete working period", err)
 		return err
 	}
+	if !deleted {
+		return staleWorkingPeriodError()
+	}
+	s.cache.Invalidate(ctx, userId.String())
+	s.dispatchScheduleUpdated(ctx, userId, webhooks.ChangeWorkingPeriodDeleted, &id)
+
+	return nil
+}
+
+// AddWorkingPeriodRecurrence stores a recurring working period definition and materializes its occurrences
+// as working periods. Occurrences that already started or overlap an existing working period are skipped
+// and reported instead of failing the whole recurrence.
+func (s *ScheduleService) AddWorkingPeriodRecurrence(ctx context.Context, request *WorkingPeriodRecurrenceRequest) (*RecurrenceExpansionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	rule, err := parseRRule(request.RRule)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError(err.Error(), apperrors.ErrValidationFailed)
+	}
+
+	loc, _ := time.LoadLocation(request.Timezone)
+	startClock, _ := timeutils.ParseClock(request.StartClock)
+	endClock, _ := timeutils.ParseClock(request.EndClock)
+
+	recurrence := MapRequestToRecurrence(userId, request)
+	occurrences := rule.expand(recurrence.StartsOn, startClock, endClock, loc)
+
+	response := &RecurrenceExpansionResponse{Skipped: []*SkippedOccurrenceResponse{}}
+	var existing []*entities.WorkingPeriod
+	if len(occurrences) > 0 {
+		existing, err = s.repo.GetOverlappingWorkingPeriods(ctx, userId, occurrences[0].start, occurrences[len(occurrences)-1].end)
+		if err != nil {
+			log.Error("failed to get working periods", err)
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC()
+	var workingPeriods []*entities.WorkingPeriod
+	for _, o := range occurrences {
+		reason := ""
+		if o.start.Before(now) {
+			reason = SkippedInPast
+		} else if s.validateWorkingPeriodOverlap(existing, nil, o.start, o.end) != nil {
+			reason = SkippedOverlap
+		}
+
+		if reason != "" {
+			response.Skipped = append(response.Skipped, &SkippedOccurrenceResponse{StartTime: o.start, EndTime: o.end, Reason: reason})
+			continue
+		}
+		workingPeriods = append(workingPeriods, MapOccurrenceToWorkingPeriod(userId, o))
+	}
+
+	if len(workingPeriods) == 0 {
+		return nil, apperrors.NewUnprocessedEntity("Recurrence produces no working periods", apperrors.ErrWorkingPeriodHours)
+	}
+
+	recurrence.Id, err = s.repo.AddRecurrence(ctx, recurrence, workingPeriods)
+	if err != nil {
+		log.Error("failed to add working period recurrence", err)
+		return nil, err
+	}
+	s.cache.Invalidate(ctx, userId.String())
+	s.dispatchScheduleUpdated(ctx, userId, webhooks.ChangeRecurrenceCreated, &recurrence.Id)
+
+	response.Recurrence = MapRecurrenceToResponse(recurrence)
+	response.Created = len(workingPeriods)
+	return response, nil
+}
+
+func (s *ScheduleService) GetMyWorkingPeriodRecurrences(ctx context.Context) ([]*WorkingPeriodRecurrenceResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	recurrences, err := s.repo.GetRecurrences(ctx, userId)
+	if err != nil {
+		log.Error("failed to get working period recurrences", err)
+		return nil, err
+	}
+
+	return MapRecurrencesToResponse(recurrences), nil
+}
+
+// DeleteWorkingPeriodRecurrence removes a recurrence and its future working periods that are still free;
+// periods with bookings or events stay as standalone working periods
+func (s *ScheduleService) DeleteWorkingPeriodRecurrence(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	deleted, err := s.repo.DeleteRecurrence(ctx, userId, id, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to delete working period recurrence", err)
+		return err
+	}
+
+	if !deleted {
+		return apperrors.NewNotFound("Working period recurrence not found", apperrors.ErrResourceNotFound)
+	}
+	s.cache.Invalidate(ctx, userId.String())
+	s.dispatchScheduleUpdated(ctx, userId, webhooks.ChangeRecurrenceDeleted, &id)
 
 	return nil
 }
@@ -249,13 +556,23 @@ func (s *ScheduleService) AddScheduledEvent(
 		return err
 	}
 
-	if err := s.checkScheduledEventConflicts(ctx, workingPeriodId, request.StartTime, request.EndTime); err != nil {
+	if err := s.validateSlotGranularity(ctx, request.StartTime, request.EndTime); err != nil {
+		log.Error("Scheduled event breaks the slot policy", err)
+		return err
+	}
+
+	if err := s.checkScheduledEventConflicts(ctx, userId, workingPeriodId, request.StartTime, request.EndTime); err != nil {
 		log.Error("Invalid booking time", err)
 		return err
 	}
 
+	if err := s.validateSessionType(ctx, userId, request.SessionTypeId); err != nil {
+		log.Error("Invalid session type", err)
+		return err
+	}
+
 	durationMin := int(math.Round(request.EndTime.Sub(request.StartTime).Minutes()))
-	pi, err := s.client.GetSchedulingMetadata(ctx, request.ProductId, request.LessonId, durationMin, authHeader)
+	pi, err := s.products.GetSchedulingMetadata(ctx, request.ProductId, request.LessonId, durationMin, authHeader)
 	if err != nil {
 		return err
 	}
@@ -269,11 +586,26 @@ func (s *ScheduleService) AddScheduledEvent(
 		return apperrors.NewUnprocessedEntity("Product is not schedulable", apperrors.ErrProductNotSchedulable)
 	}
 
-	err = s.repo.AddScheduledEvent(ctx, MapRequestToScheduledEvent(request, userId, workingPeriodId, pi.Title, pi.MaxParticipants))
+	capacity := pi.MaxParticipants
+	if request.Capacity != nil {
+		if *request.Capacity > pi.MaxParticipants {
+			return apperrors.NewUnprocessedEntity("Capacity exceeds the product's maximum participants", apperrors.ErrScheduledEventCapacity)
+		}
+		capacity = *request.Capacity
+	}
+
+	if err := s.validateLocation(ctx, userId, request, capacity); err != nil {
+		log.Error("Invalid scheduled event location", err)
+		return err
+	}
+
+	err = s.repo.AddScheduledEvent(ctx, MapRequestToScheduledEvent(request, userId, workingPeriodId, pi.Title, capacity, pi.Price))
 	if err != nil {
 		log.Error("failed to add scheduled event", err)
 		return err
 	}
+	s.cache.Invalidate(ctx, userId.String())
+	s.dispatchScheduleUpdated(ctx, userId, webhooks.ChangeScheduledEventCreated, nil)
 
 	s.publisher.Publish(
 		ctx,
@@ -288,7 +620,7 @@ func (s *ScheduleService) AddScheduledEvent(
 	return nil
 }
 
-func (s *ScheduleService) DeleteScheduledEvent(ctx context.Context, id int64) error {
+func (s *ScheduleService) DeleteScheduledEvent(ctx context.Context, id int64, version int64) error {
 	log := logger.FromContext(ctx, s.log)
 
 	userId, err := auth.GetUserID(ctx)
@@ -296,12 +628,16 @@ func (s *ScheduleService) DeleteScheduledEvent(ctx context.Context, id int64) er
 		return apperrors.NewUnauthorized("Unauthorized user", err)
 	}
 
-	_, err = s.repo.GetScheduledEventById(ctx, userId, id)
+	scheduledEvent, err := s.repo.GetScheduledEventById(ctx, userId, id)
 	if err != nil {
 		log.Error("failed to get scheduled event by id", err)
 		return err
 	}
 
+	if scheduledEvent.Version != version {
+		return staleScheduledEventError()
+	}
+
 	hasBooking, err := s.repo.HasLinkedBookings(ctx, id)
 	if err != nil {
 		log.Error("failed to check if booking exists", err)
@@ -313,11 +649,33 @@ func (s *ScheduleService) DeleteScheduledEvent(ctx context.Context, id int64) er
 		return apperrors.NewConflict("Cannot delete scheduled event with linked bookings", apperrors.ErrScheduledEventHasBooking)
 	}
 
-	err = s.repo.DeleteScheduledEvent(ctx, userId, id)
+	deleted, err := s.repo.DeleteScheduledEvent(ctx, userId, id, version)
 	if err != nil {
 		log.Error("failed to delete scheduled event", err)
 		return err
 	}
+	if !deleted {
+		return staleScheduledEventError()
+	}
+	s.cache.Invalidate(ctx, userId.String())
+	s.dispatchScheduleUpdated(ctx, userId, webhooks.ChangeScheduledEventDeleted, &id)
 
 	return nil
 }
+
+func staleWorkingPeriodError() error {
+	return apperrors.NewConflict("Working period was modified, reload it and retry", apperrors.ErrVersionConflict)
+}
+
+func staleScheduledEventError() error {
+	return apperrors.NewConflict("Scheduled event was modified, reload it and retry", apperrors.ErrVersionConflict)
+}
+
+// dispatchScheduleUpdated sends a schedule.updated event to the webhook subscriptions of the educator
+func (s *ScheduleService) dispatchScheduleUpdated(ctx context.Context, educatorId uuid.UUID, change string, entityId *int64) {
+	s.webhooks.Dispatch(ctx, educatorId, webhooks.EventScheduleUpdated, &webhooks.ScheduleData{
+		EducatorId: educatorId.String(),
+		Change:     change,
+		EntityId:   entityId,
+	})
+}
@@ -3,13 +3,20 @@ package schedule
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/maksmelnyk/scheduling/internal/apperrors"
 	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/locations"
 	"github.com/maksmelnyk/scheduling/internal/timeutils"
 )
 
+// maxTravelWindow bounds how far around a new event other events are checked for travel time
+const maxTravelWindow = 12 * time.Hour
+
 func (s *ScheduleService) validateScheduledEventTiming(start, end time.Time, wpStart, wpEnd time.Time) error {
 	if !timeutils.IsWithinPeriod(start, end, wpStart, wpEnd) {
 		return apperrors.NewUnprocessedEntity("Scheduled event outside working hours", apperrors.ErrScheduledEventHours)
@@ -17,14 +24,36 @@ func (s *ScheduleService) validateScheduledEventTiming(start, end time.Time, wpS
 	return nil
 }
 
-func (s *ScheduleService) checkScheduledEventConflicts(ctx context.Context, workingPeriodId int64, start, end time.Time) error {
+// validateSlotGranularity checks that a scheduled event lasts a duration allowed by the platform slot policy
+// and starts on its alignment
+func (s *ScheduleService) validateSlotGranularity(ctx context.Context, start, end time.Time) error {
+	granularity, err := s.granularity.SlotGranularity(ctx)
+	if err != nil {
+		return fmt.Errorf("get slot policy: %w", err)
+	}
+
+	if reason := granularity.Violation(start, end); reason != "" {
+		return apperrors.NewUnprocessedEntity(reason, apperrors.ErrSlotGranularity)
+	}
+	return nil
+}
+
+// checkScheduledEventConflicts rejects events overlapping the bookings or other events of the working period,
+// or leaving less than the educator's buffers between them
+func (s *ScheduleService) checkScheduledEventConflicts(ctx context.Context, educatorId uuid.UUID, workingPeriodId int64, start, end time.Time) error {
+	buffers, err := s.sessionBuffers(ctx, []uuid.UUID{educatorId})
+	if err != nil {
+		return fmt.Errorf("get session buffers: %w", err)
+	}
+	buffer := buffers[educatorId]
+
 	bookings, err := s.repo.GetWorkingPeriodBookings(ctx, []int64{workingPeriodId})
 	if err != nil {
 		return fmt.Errorf("get bookings: %w", err)
 	}
 	for _, b := range bookings {
-		if timeutils.IsOverlapping(start, end, b.StartTime, b.EndTime) {
-			return apperrors.NewUnprocessedEntity("Scheduled event overlaps with a booking", apperrors.ErrScheduledEventHours)
+		if buffer.Conflicts(start, end, b.StartTime, b.EndTime) {
+			return apperrors.NewUnprocessedEntity("Scheduled event overlaps with a booking or its buffer", apperrors.ErrScheduledEventHours)
 		}
 	}
 
@@ -33,8 +62,8 @@ func (s *ScheduleService) checkScheduledEventConflicts(ctx context.Context, work
 		return fmt.Errorf("get scheduled events: %w", err)
 	}
 	for _, e := range events {
-		if timeutils.IsOverlapping(start, end, e.StartTime, e.EndTime) {
-			return apperrors.NewUnprocessedEntity("Scheduled event overlaps with another scheduled event", apperrors.ErrScheduledEventHours)
+		if buffer.Conflicts(start, end, e.StartTime, e.EndTime) {
+			return apperrors.NewUnprocessedEntity("Scheduled event overlaps with another scheduled event or its buffer", apperrors.ErrScheduledEventHours)
 		}
 	}
 
@@ -53,6 +82,95 @@ func (s *ScheduleService) validateWorkingPeriodOverlap(existing []*entities.Work
 	return nil
 }
 
+func (s *ScheduleService) validateSessionType(ctx context.Context, educatorId uuid.UUID, sessionTypeId *int64) error {
+	if sessionTypeId == nil {
+		return nil
+	}
+
+	exists, err := s.repo.SessionTypeExists(ctx, educatorId, *sessionTypeId)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return apperrors.NewUnprocessedEntity("Session type does not exist", apperrors.ErrSessionTypeInvalid)
+	}
+	return nil
+}
+
+// validateLocation checks that the event's location belongs to the educator, fits the event's
+// participants and leaves enough time to travel from and to the educator's other in-person sessions
+func (s *ScheduleService) validateLocation(ctx context.Context, educatorId uuid.UUID, request *ScheduledEventRequest, capacity int) error {
+	if request.LocationId == nil {
+		return nil
+	}
+
+	location, err := s.repo.GetLocationById(ctx, educatorId, *request.LocationId)
+	if err != nil {
+		return err
+	}
+
+	if capacity > location.Capacity {
+		return apperrors.NewUnprocessedEntity("Location capacity is below the event's capacity", apperrors.ErrLocationInvalid)
+	}
+
+	events, err := s.repo.GetUserScheduledEventsWithin(ctx, educatorId, request.StartTime.Add(-maxTravelWindow), request.EndTime.Add(maxTravelWindow))
+	if err != nil {
+		return fmt.Errorf("get scheduled events: %w", err)
+	}
+
+	eventLocations, err := s.getEventLocations(ctx, events)
+	if err != nil {
+		return fmt.Errorf("get locations: %w", err)
+	}
+
+	minBuffer := time.Duration(s.travel.MinTravelBufferMinutes) * time.Minute
+	for _, e := range events {
+		if e.LocationId == nil {
+			continue
+		}
+		other, ok := eventLocations[*e.LocationId]
+		if !ok {
+			continue
+		}
+
+		buffer := locations.TravelBuffer(location, other, s.travel.TravelSpeedKmh, minBuffer)
+		if timeutils.IsOverlapping(request.StartTime.Add(-buffer), request.EndTime.Add(buffer), e.StartTime, e.EndTime) {
+			return apperrors.NewUnprocessedEntity(
+				fmt.Sprintf("Scheduled event needs %d minutes to travel from or to %s", int(buffer.Minutes()), other.Name),
+				apperrors.ErrLocationTravelBuffer,
+			)
+		}
+	}
+
+	return nil
+}
+
+// getEventLocations loads the locations referenced by the events keyed by their Id
+func (s *ScheduleService) getEventLocations(ctx context.Context, events []*entities.ScheduledEvent) (map[int64]*entities.Location, error) {
+	var ids []int64
+	for _, e := range events {
+		if e.LocationId != nil && !slices.Contains(ids, *e.LocationId) {
+			ids = append(ids, *e.LocationId)
+		}
+	}
+
+	result := make(map[int64]*entities.Location, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	ls, err := s.repo.GetLocationsByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range ls {
+		result[l.Id] = l
+	}
+	return result, nil
+}
+
 func (s *ScheduleService) hasLinkedEvents(ctx context.Context, id int64) error {
 	hasEvent, err := s.repo.HasLinkedEvents(ctx, id)
 	if err != nil {
@@ -0,0 +1,36 @@
+package schema
+
+import "time"
+
+// swagger:model SchemaDriftResponse
+type SchemaDriftResponse struct {
+	InSync    bool      `json:"inSync"`
+	Expected  string    `json:"expected"`
+	Current   string    `json:"current"`
+	Pending   []string  `json:"pending"`
+	Modified  []string  `json:"modified"`
+	Unknown   []string  `json:"unknown"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// swagger:model EventContractsResponse
+type EventContractsResponse struct {
+	Published []*PublishedContractResponse `json:"published"`
+	Consumed  []*ConsumedContractResponse  `json:"consumed"`
+}
+
+// swagger:model PublishedContractResponse
+type PublishedContractResponse struct {
+	Type     string `json:"type"`
+	Version  int    `json:"version"`
+	SchemaId string `json:"schemaId"`
+	// Fields are the data fields every payload of the version carries
+	Fields []string `json:"fields"`
+}
+
+// swagger:model ConsumedContractResponse
+type ConsumedContractResponse struct {
+	Type       string `json:"type"`
+	MinVersion int    `json:"minVersion"`
+	MaxVersion int    `json:"maxVersion"`
+}
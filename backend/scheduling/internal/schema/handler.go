@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+)
+
+type SchemaHandler struct {
+	service *SchemaService
+}
+
+func NewSchemaHandler(service *SchemaService) *SchemaHandler {
+	return &SchemaHandler{service: service}
+}
+
+// GetSchemaDrift reports differences between the database schema and the binary's migrations.
+// @Summary      Retrieve schema drift
+// @Description  Compares the applied migrations with those embedded in the running binary, listing pending, modified and unknown ones. The check also refreshes the readiness state.
+// @Tags         Schema
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  SchemaDriftResponse  "Schema drift"
+// @Failure      500  {object}  error                "Schema check failed"
+// @Router       /api/v1/schema/drift [get]
+// @Security 	 BearerAuth
+func (h *SchemaHandler) GetSchemaDrift(w http.ResponseWriter, r *http.Request) {
+	drift, err := h.service.GetSchemaDrift(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, drift)
+}
+
+// GetEventContracts lists the message contracts of the service.
+// @Summary      Retrieve event contracts
+// @Description  Lists the current version, schema id and required data fields of every published event type, and the versions read of every consumed one. Messages of other versions are dead-lettered.
+// @Tags         Schema
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  EventContractsResponse  "Event contracts"
+// @Router       /api/v1/schema/events [get]
+// @Security 	 BearerAuth
+func (h *SchemaHandler) GetEventContracts(w http.ResponseWriter, r *http.Request) {
+	api.WriteJson(w, http.StatusOK, h.service.GetEventContracts())
+}
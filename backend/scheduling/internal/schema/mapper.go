@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/migrations"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func MapDriftToResponse(drift *migrations.SchemaDrift, checkedAt time.Time) *SchemaDriftResponse {
+	return &SchemaDriftResponse{
+		InSync:    drift.InSync(),
+		Expected:  drift.Expected,
+		Current:   drift.Current,
+		Pending:   drift.Pending,
+		Modified:  drift.Modified,
+		Unknown:   drift.Unknown,
+		CheckedAt: checkedAt,
+	}
+}
+
+func MapContractsToResponse(published []messaging.EventContract, consumed []messaging.ConsumedContract) *EventContractsResponse {
+	response := &EventContractsResponse{
+		Published: make([]*PublishedContractResponse, 0, len(published)),
+		Consumed:  make([]*ConsumedContractResponse, 0, len(consumed)),
+	}
+	for _, c := range published {
+		response.Published = append(response.Published, &PublishedContractResponse{
+			Type:     c.Type,
+			Version:  c.Version,
+			SchemaId: c.SchemaId(),
+			Fields:   c.Fields,
+		})
+	}
+	for _, c := range consumed {
+		response.Consumed = append(response.Consumed, &ConsumedContractResponse{
+			Type:       c.Type,
+			MinVersion: c.MinVersion,
+			MaxVersion: c.MaxVersion,
+		})
+	}
+	return response
+}
@@ -0,0 +1,24 @@
+package schema
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/migrations"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeSchemaService(log logger.Logger, db *sqlx.DB, cfg *config.MigrationConfig) (*SchemaService, error) {
+	runner, err := migrations.NewRunner(db, log)
+	if err != nil {
+		return nil, err
+	}
+	return NewSchemaService(log, runner, cfg), nil
+}
+
+func InitializeSchemaHTTPHandler(service *SchemaService) http.Handler {
+	handler := NewSchemaHandler(service)
+	return Routes(handler)
+}
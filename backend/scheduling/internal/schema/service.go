@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/migrations"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+// DriftDetector compares the database schema with the changesets embedded in the binary
+type DriftDetector interface {
+	Drift(ctx context.Context) (*migrations.SchemaDrift, error)
+}
+
+// SchemaService keeps the result of the last schema check. Readiness fails while the schema does not
+// match the binary, so traffic is not routed to a replica that would run queries against the wrong tables.
+type SchemaService struct {
+	log      logger.Logger
+	detector DriftDetector
+	cfg      *config.MigrationConfig
+
+	mu        sync.RWMutex
+	drift     *migrations.SchemaDrift
+	checkedAt time.Time
+}
+
+func NewSchemaService(log logger.Logger, detector DriftDetector, cfg *config.MigrationConfig) *SchemaService {
+	return &SchemaService{log: log, detector: detector, cfg: cfg}
+}
+
+// Verify checks the schema and records the result, logging every difference found
+func (s *SchemaService) Verify(ctx context.Context) (*migrations.SchemaDrift, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	drift, err := s.detector.Drift(ctx)
+	if err != nil {
+		log.Error("failed to check schema version", err)
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.drift = drift
+	s.checkedAt = time.Now().UTC()
+	s.mu.Unlock()
+
+	if !drift.InSync() {
+		log.Errorf("Database schema does not match the binary: expected %s, current %s, pending %v, modified %v, unknown %v",
+			drift.Expected, drift.Current, drift.Pending, drift.Modified, drift.Unknown)
+	}
+	return drift, nil
+}
+
+// Ready reports whether the last check found the schema in sync. It is always true when verification is disabled.
+func (s *SchemaService) Ready() bool {
+	if !s.cfg.VerifyOnStartup {
+		return true
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.drift != nil && s.drift.InSync()
+}
+
+// GetSchemaDrift checks the schema again, so readiness recovers once missing migrations have been applied
+func (s *SchemaService) GetSchemaDrift(ctx context.Context) (*SchemaDriftResponse, error) {
+	drift, err := s.Verify(ctx)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return MapDriftToResponse(drift, s.checkedAt), nil
+}
+
+// GetEventContracts returns the registered contracts of the published events and the versions read of
+// the consumed ones, so integrations can check a payload change against them before rolling it out
+func (s *SchemaService) GetEventContracts() *EventContractsResponse {
+	return MapContractsToResponse(messaging.PublishedContracts(), messaging.ConsumedContracts())
+}
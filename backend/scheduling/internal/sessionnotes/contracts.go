@@ -0,0 +1,58 @@
+package sessionnotes
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// swagger:model SessionNoteRequest
+type SessionNoteRequest struct {
+	Content string `json:"content"`
+}
+
+// swagger:model SessionNoteResponse
+type SessionNoteResponse struct {
+	Id         int64     `json:"id"`
+	BookingId  int64     `json:"bookingId"`
+	EducatorId uuid.UUID `json:"educatorId"`
+	StudentId  uuid.UUID `json:"studentId"`
+	Content    string    `json:"content"`
+	Version    int       `json:"version"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// swagger:model SessionNoteRevisionResponse
+type SessionNoteRevisionResponse struct {
+	Version  int       `json:"version"`
+	Content  string    `json:"content"`
+	EditedAt time.Time `json:"editedAt"`
+}
+
+func (s *SessionNoteRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if strings.TrimSpace(s.Content) == "" {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Content",
+			Message: "must not be empty",
+		})
+	}
+
+	if len(s.Content) > 10000 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Content",
+			Message: "must not exceed 10000 characters",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Session note request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package sessionnotes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type SessionNoteHandler struct {
+	service *SessionNoteService
+}
+
+func NewSessionNoteHandler(service *SessionNoteService) *SessionNoteHandler {
+	return &SessionNoteHandler{service: service}
+}
+
+// SaveSessionNote creates or edits the note of a booking.
+// @Summary      Save session note
+// @Description  Creates or edits the educator's post-session note on an approved booking. Every edit is kept as a revision and the new version is published to the learning service.
+// @Tags         SessionNote
+// @Accept       json
+// @Produce      json
+// @Param        bookingId  path      int                  true  "Booking ID"
+// @Param        note       body      SessionNoteRequest   true  "Note content"
+// @Success      200        {object}  SessionNoteResponse  "Saved note"
+// @Failure      400        {object}  error                "Invalid input"
+// @Failure      404        {object}  error                "Booking not found"
+// @Router       /api/v1/session-notes/bookings/{bookingId} [put]
+// @Security 	 BearerAuth
+func (h *SessionNoteHandler) SaveSessionNote(w http.ResponseWriter, r *http.Request) {
+	bookingId, err := api.ParseLongParam(w, r, "bookingId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *SessionNoteRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	note, err := h.service.SaveSessionNote(r.Context(), bookingId, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, note)
+}
+
+// GetSessionNote retrieves the current note of a booking.
+// @Summary      Retrieve session note
+// @Description  Retrieves the latest version of the booking note. Available to both parties and admins.
+// @Tags         SessionNote
+// @Accept       json
+// @Produce      json
+// @Param        bookingId  path      int                  true  "Booking ID"
+// @Success      200        {object}  SessionNoteResponse  "Session note"
+// @Failure      404        {object}  error                "Session note not found"
+// @Router       /api/v1/session-notes/bookings/{bookingId} [get]
+// @Security 	 BearerAuth
+func (h *SessionNoteHandler) GetSessionNote(w http.ResponseWriter, r *http.Request) {
+	bookingId, err := api.ParseLongParam(w, r, "bookingId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	note, err := h.service.GetSessionNote(r.Context(), bookingId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, note)
+}
+
+// GetSessionNoteHistory retrieves the edit history of a booking note.
+// @Summary      Retrieve session note history
+// @Description  Retrieves every stored version of the booking note, newest first. Available to the educator and admins.
+// @Tags         SessionNote
+// @Accept       json
+// @Produce      json
+// @Param        bookingId  path      int                            true  "Booking ID"
+// @Success      200        {array}   SessionNoteRevisionResponse    "Note revisions"
+// @Failure      404        {object}  error                          "Session note not found"
+// @Router       /api/v1/session-notes/bookings/{bookingId}/history [get]
+// @Security 	 BearerAuth
+func (h *SessionNoteHandler) GetSessionNoteHistory(w http.ResponseWriter, r *http.Request) {
+	bookingId, err := api.ParseLongParam(w, r, "bookingId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	revisions, err := h.service.GetSessionNoteHistory(r.Context(), bookingId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, revisions)
+}
@@ -0,0 +1,44 @@
+package sessionnotes
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToSessionNote(b *entities.Booking, r *SessionNoteRequest) *entities.SessionNote {
+	return &entities.SessionNote{
+		BookingId:  b.Id,
+		EducatorId: b.EducatorId,
+		StudentId:  b.StudentId,
+		Content:    r.Content,
+		Version:    1,
+		CreatedAt:  time.Now().UTC(),
+		UpdatedAt:  time.Now().UTC(),
+	}
+}
+
+func MapSessionNoteToResponse(n *entities.SessionNote) *SessionNoteResponse {
+	return &SessionNoteResponse{
+		Id:         n.Id,
+		BookingId:  n.BookingId,
+		EducatorId: n.EducatorId,
+		StudentId:  n.StudentId,
+		Content:    n.Content,
+		Version:    n.Version,
+		CreatedAt:  n.CreatedAt,
+		UpdatedAt:  n.UpdatedAt,
+	}
+}
+
+func MapRevisionsToResponse(revisions []*entities.SessionNoteRevision) []*SessionNoteRevisionResponse {
+	response := make([]*SessionNoteRevisionResponse, len(revisions))
+	for i, r := range revisions {
+		response[i] = &SessionNoteRevisionResponse{
+			Version:  r.Version,
+			Content:  r.Content,
+			EditedAt: r.EditedAt,
+		}
+	}
+	return response
+}
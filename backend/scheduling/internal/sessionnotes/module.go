@@ -0,0 +1,21 @@
+package sessionnotes
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializeSessionNoteService(log logger.Logger, db *sqlx.DB, publisher *messaging.Publisher) *SessionNoteService {
+	repo := NewSessionNoteRepository(db)
+	service := NewSessionNoteService(log, repo, publisher)
+	return service
+}
+
+func InitializeSessionNoteHTTPHandler(service *SessionNoteService) http.Handler {
+	handler := NewSessionNoteHandler(service)
+	return Routes(handler)
+}
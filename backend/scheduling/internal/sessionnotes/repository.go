@@ -0,0 +1,97 @@
+package sessionnotes
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type SessionNoteRepo struct {
+	db *sqlx.DB
+}
+
+func NewSessionNoteRepository(db *sqlx.DB) *SessionNoteRepo {
+	return &SessionNoteRepo{db: db}
+}
+
+// GetEducatorBookingById retrieves a single booking by its Id and EducatorId
+func (r *SessionNoteRepo) GetEducatorBookingById(ctx context.Context, educatorId uuid.UUID, id int64) (*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at
+		FROM booking
+		WHERE id = $1 AND educator_id = $2
+	`
+	return database.FetchSingle[entities.Booking](ctx, r.db, query, id, educatorId)
+}
+
+// GetSessionNoteByBookingId retrieves the current note of a booking
+func (r *SessionNoteRepo) GetSessionNoteByBookingId(ctx context.Context, bookingId int64) (*entities.SessionNote, error) {
+	const query = `
+		SELECT id, booking_id, educator_id, student_id, content, version, created_at, updated_at
+		FROM session_note
+		WHERE booking_id = $1
+	`
+	return database.FetchSingle[entities.SessionNote](ctx, r.db, query, bookingId)
+}
+
+// GetSessionNoteRevisions retrieves all stored versions of a note, newest first
+func (r *SessionNoteRepo) GetSessionNoteRevisions(ctx context.Context, noteId int64) ([]*entities.SessionNoteRevision, error) {
+	const query = `
+		SELECT id, note_id, version, content, edited_at
+		FROM session_note_revision
+		WHERE note_id = $1
+		ORDER BY version DESC
+	`
+	return database.FetchMultiple[entities.SessionNoteRevision](ctx, r.db, query, noteId)
+}
+
+// SaveSessionNote creates or edits the note of a booking, bumping its version and storing the
+// new content as a revision. The note's Id and Version are updated in place.
+func (r *SessionNoteRepo) SaveSessionNote(ctx context.Context, note *entities.SessionNote) error {
+	const noteQuery = `
+		INSERT INTO session_note (booking_id, educator_id, student_id, content, version, created_at, updated_at)
+		VALUES (:booking_id, :educator_id, :student_id, :content, :version, :created_at, :updated_at)
+		ON CONFLICT (booking_id) DO UPDATE
+		SET content = EXCLUDED.content, version = session_note.version + 1, updated_at = EXCLUDED.updated_at
+		RETURNING id, version, created_at
+	`
+	const revisionQuery = `
+		INSERT INTO session_note_revision (note_id, version, content, edited_at)
+		VALUES (:note_id, :version, :content, :edited_at)
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareNamedContext(ctx, noteQuery)
+	if err != nil {
+		return apperrors.NewInternal(err)
+	}
+
+	if err := stmt.QueryRowxContext(ctx, note).Scan(&note.Id, &note.Version, &note.CreatedAt); err != nil {
+		return apperrors.NewInternal(err)
+	}
+
+	revision := &entities.SessionNoteRevision{
+		NoteId:   note.Id,
+		Version:  note.Version,
+		Content:  note.Content,
+		EditedAt: note.UpdatedAt,
+	}
+	if _, err := tx.NamedExecContext(ctx, revisionQuery, revision); err != nil {
+		return apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apperrors.NewInternal(err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package sessionnotes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *SessionNoteHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/bookings/{bookingId}", handler.GetSessionNote)
+	r.Get("/bookings/{bookingId}/history", handler.GetSessionNoteHistory)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Put("/bookings/{bookingId}", handler.SaveSessionNote)
+
+	return r
+}
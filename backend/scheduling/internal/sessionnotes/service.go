@@ -0,0 +1,129 @@
+package sessionnotes
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+type SessionNoteRepository interface {
+	GetEducatorBookingById(ctx context.Context, educatorId uuid.UUID, id int64) (*entities.Booking, error)
+	GetSessionNoteByBookingId(ctx context.Context, bookingId int64) (*entities.SessionNote, error)
+	GetSessionNoteRevisions(ctx context.Context, noteId int64) ([]*entities.SessionNoteRevision, error)
+	SaveSessionNote(ctx context.Context, note *entities.SessionNote) error
+}
+
+type SessionNoteService struct {
+	log       logger.Logger
+	repo      SessionNoteRepository
+	publisher *messaging.Publisher
+}
+
+func NewSessionNoteService(log logger.Logger, repo SessionNoteRepository, publisher *messaging.Publisher) *SessionNoteService {
+	return &SessionNoteService{log: log, repo: repo, publisher: publisher}
+}
+
+// SaveSessionNote creates or edits the educator's note on an approved booking and publishes the
+// new version for the learning service
+func (s *SessionNoteService) SaveSessionNote(ctx context.Context, bookingId int64, request *SessionNoteRequest) (*SessionNoteResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	booking, err := s.repo.GetEducatorBookingById(ctx, userId, bookingId)
+	if err != nil {
+		log.Error("failed to get booking", err)
+		return nil, err
+	}
+
+	if !booking.Status.IsConfirmed() {
+		return nil, apperrors.NewUnprocessedEntity("Session notes can be written for approved bookings only", apperrors.ErrSessionNoteNotAllowed)
+	}
+
+	note := MapRequestToSessionNote(booking, request)
+	if err := s.repo.SaveSessionNote(ctx, note); err != nil {
+		log.Error("failed to save session note", err)
+		return nil, err
+	}
+
+	err = s.publisher.Publish(
+		ctx,
+		messaging.SessionNoteKey,
+		messaging.NewSessionNoteUpdatedEvent(
+			note.Id,
+			booking.Id,
+			booking.EducatorId.String(),
+			booking.StudentId.String(),
+			booking.EnrollmentId,
+			booking.ProductId,
+			note.Content,
+			note.Version,
+			booking.StartTime.Format(time.RFC3339),
+		),
+	)
+	if err != nil {
+		log.Error("failed to publish session note event", err)
+	}
+
+	return MapSessionNoteToResponse(note), nil
+}
+
+// GetSessionNote retrieves the current note of a booking; available to both parties and admins
+func (s *SessionNoteService) GetSessionNote(ctx context.Context, bookingId int64) (*SessionNoteResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	note, err := s.getAccessibleNote(ctx, bookingId, true)
+	if err != nil {
+		log.Error("failed to get session note", err)
+		return nil, err
+	}
+
+	return MapSessionNoteToResponse(note), nil
+}
+
+// GetSessionNoteHistory retrieves every stored version of a booking note; available to the educator and admins
+func (s *SessionNoteService) GetSessionNoteHistory(ctx context.Context, bookingId int64) ([]*SessionNoteRevisionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	note, err := s.getAccessibleNote(ctx, bookingId, false)
+	if err != nil {
+		log.Error("failed to get session note", err)
+		return nil, err
+	}
+
+	revisions, err := s.repo.GetSessionNoteRevisions(ctx, note.Id)
+	if err != nil {
+		log.Error("failed to get session note revisions", err)
+		return nil, err
+	}
+
+	return MapRevisionsToResponse(revisions), nil
+}
+
+func (s *SessionNoteService) getAccessibleNote(ctx context.Context, bookingId int64, allowStudent bool) (*entities.SessionNote, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	note, err := s.repo.GetSessionNoteByBookingId(ctx, bookingId)
+	if err != nil {
+		return nil, err
+	}
+
+	if userId != note.EducatorId && (!allowStudent || userId != note.StudentId) && !auth.HasRole(ctx, auth.AdminRole) {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	return note, nil
+}
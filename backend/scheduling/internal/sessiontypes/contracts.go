@@ -0,0 +1,101 @@
+package sessiontypes
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/i18n"
+)
+
+var colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// swagger:model SessionTypeRequest
+type SessionTypeRequest struct {
+	Name                        string  `json:"name"`
+	Description                 string  `json:"description"`
+	DurationMinutes             int     `json:"durationMinutes"`
+	DefaultPrice                float64 `json:"defaultPrice"`
+	DeliveryMode                int     `json:"deliveryMode"`
+	Color                       string  `json:"color"`
+	ConfirmationDeadlineMinutes *int    `json:"confirmationDeadlineMinutes"`
+	// Translations maps locales such as de or pt-BR to the translated name, as title, and description
+	Translations i18n.Translations `json:"translations"`
+}
+
+// swagger:model SessionTypeResponse
+type SessionTypeResponse struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Locale of the name and description, empty for the educator's default texts
+	Locale                      string            `json:"locale"`
+	DurationMinutes             int               `json:"durationMinutes"`
+	DefaultPrice                float64           `json:"defaultPrice"`
+	DeliveryMode                int               `json:"deliveryMode"`
+	Color                       string            `json:"color"`
+	ConfirmationDeadlineMinutes *int              `json:"confirmationDeadlineMinutes"`
+	Translations                i18n.Translations `json:"translations"`
+}
+
+func (s *SessionTypeRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if strings.TrimSpace(s.Name) == "" || len(s.Name) > 100 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Name",
+			Message: "must be between 1 and 100 characters",
+		})
+	}
+
+	if len(s.Description) > 2000 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Description",
+			Message: "must be at most 2000 characters",
+		})
+	}
+
+	if s.DurationMinutes < 5 || s.DurationMinutes > 480 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "DurationMinutes",
+			Message: "must be between 5 and 480 minutes",
+		})
+	}
+
+	if s.DefaultPrice < 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "DefaultPrice",
+			Message: "must not be negative",
+		})
+	}
+
+	if s.DeliveryMode < int(entities.Online) || s.DeliveryMode > int(entities.InPerson) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "DeliveryMode",
+			Message: "must be 0 (online) or 1 (in person)",
+		})
+	}
+
+	if !colorPattern.MatchString(s.Color) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Color",
+			Message: "must be a hex color such as #3366FF",
+		})
+	}
+
+	if s.ConfirmationDeadlineMinutes != nil && (*s.ConfirmationDeadlineMinutes < 5 || *s.ConfirmationDeadlineMinutes > 10080) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "ConfirmationDeadlineMinutes",
+			Message: "must be between 5 and 10080 minutes",
+		})
+	}
+
+	errors = append(errors, s.Translations.Validate("Translations")...)
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Session type request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package sessiontypes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type SessionTypeHandler struct {
+	service *SessionTypeService
+}
+
+func NewSessionTypeHandler(service *SessionTypeService) *SessionTypeHandler {
+	return &SessionTypeHandler{service: service}
+}
+
+// GetEducatorSessionTypes retrieves the session type catalog of an educator.
+// @Summary      Retrieve educator session types
+// @Description  Retrieves the active session types of the educator, shortest first. Names and descriptions are translated to the first locale of the Accept-Language header the session type is translated to.
+// @Tags         SessionType
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string                 true  "Educator ID (UUID)"
+// @Success      200         {array}   SessionTypeResponse    "Session types"
+// @Failure      400         {object}  error                  "Invalid input parameters"
+// @Router       /api/v1/session-types/educators/{educatorId} [get]
+// @Security 	 BearerAuth
+func (h *SessionTypeHandler) GetEducatorSessionTypes(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	sessionTypes, err := h.service.GetEducatorSessionTypes(r.Context(), educatorId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, sessionTypes)
+}
+
+// GetSessionTypeById retrieves a session type.
+// @Summary      Retrieve session type
+// @Description  Retrieves an active session type by its ID, with its name and description translated to the first locale of the Accept-Language header it is translated to.
+// @Tags         SessionType
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                  true  "Session type ID"
+// @Success      200  {object}  SessionTypeResponse  "Session type"
+// @Failure      404  {object}  error                "Session type not found"
+// @Router       /api/v1/session-types/{id} [get]
+// @Security 	 BearerAuth
+func (h *SessionTypeHandler) GetSessionTypeById(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	sessionType, err := h.service.GetSessionTypeById(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, sessionType)
+}
+
+// AddSessionType adds a session type to the educator's catalog.
+// @Summary      Add session type
+// @Description  Creates a session type with its duration, default price, delivery mode, display color and translations of its name and description. An optional confirmation deadline overrides how long pending bookings of the type may await payment or approval.
+// @Tags         SessionType
+// @Accept       json
+// @Produce      json
+// @Param        sessionType  body      SessionTypeRequest   true  "Session type details"
+// @Success      201          {object}  SessionTypeResponse  "Created session type"
+// @Failure      400          {object}  error                "Invalid input"
+// @Router       /api/v1/session-types [post]
+// @Security 	 BearerAuth
+func (h *SessionTypeHandler) AddSessionType(w http.ResponseWriter, r *http.Request) {
+	var request *SessionTypeRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	sessionType, err := h.service.AddSessionType(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, sessionType)
+}
+
+// UpdateSessionType updates a session type of the educator.
+// @Summary      Update session type
+// @Description  Updates the details of an active session type owned by the educator.
+// @Tags         SessionType
+// @Accept       json
+// @Produce      json
+// @Param        id           path      int                 true  "Session type ID"
+// @Param        sessionType  body      SessionTypeRequest  true  "Session type details"
+// @Success      204          "Session type updated successfully"
+// @Failure      400          {object}  error               "Invalid input"
+// @Failure      404          {object}  error               "Session type not found"
+// @Router       /api/v1/session-types/{id} [put]
+// @Security 	 BearerAuth
+func (h *SessionTypeHandler) UpdateSessionType(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *SessionTypeRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.UpdateSessionType(r.Context(), id, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ArchiveSessionType removes a session type from the educator's catalog.
+// @Summary      Archive session type
+// @Description  Hides the session type from the catalog. Slots and bookings already referencing it are kept.
+// @Tags         SessionType
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Session type ID"
+// @Success      204  "Session type archived successfully"
+// @Failure      404  {object}  error  "Session type not found"
+// @Router       /api/v1/session-types/{id} [delete]
+// @Security 	 BearerAuth
+func (h *SessionTypeHandler) ArchiveSessionType(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.ArchiveSessionType(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
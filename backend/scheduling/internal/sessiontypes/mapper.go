@@ -0,0 +1,58 @@
+package sessiontypes
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToSessionType(educatorId uuid.UUID, r *SessionTypeRequest) *entities.SessionType {
+	st := &entities.SessionType{
+		EducatorId: educatorId,
+		CreatedAt:  time.Now().UTC(),
+	}
+	MapRequestWithSessionType(r, st)
+	return st
+}
+
+func MapRequestWithSessionType(r *SessionTypeRequest, st *entities.SessionType) {
+	st.Name = strings.TrimSpace(r.Name)
+	st.Description = strings.TrimSpace(r.Description)
+	st.Translations = r.Translations.Normalized()
+	st.DurationMinutes = r.DurationMinutes
+	st.DefaultPrice = math.Round(r.DefaultPrice*100) / 100
+	st.DeliveryMode = entities.DeliveryMode(r.DeliveryMode)
+	st.Color = strings.ToUpper(r.Color)
+	st.ConfirmationDeadlineMinutes = r.ConfirmationDeadlineMinutes
+	st.UpdatedAt = time.Now().UTC()
+}
+
+// MapSessionTypeToResponse returns the session type with its name and description in the first of the
+// preferred locales it is translated to
+func MapSessionTypeToResponse(st *entities.SessionType, locales []string) *SessionTypeResponse {
+	name, description, locale := st.Translations.Translate(locales, st.Name, st.Description)
+	return &SessionTypeResponse{
+		Id:                          st.Id,
+		Name:                        name,
+		Description:                 description,
+		Locale:                      locale,
+		DurationMinutes:             st.DurationMinutes,
+		DefaultPrice:                st.DefaultPrice,
+		DeliveryMode:                int(st.DeliveryMode),
+		Color:                       st.Color,
+		ConfirmationDeadlineMinutes: st.ConfirmationDeadlineMinutes,
+		Translations:                st.Translations,
+	}
+}
+
+func MapSessionTypesToResponse(sts []*entities.SessionType, locales []string) []*SessionTypeResponse {
+	response := make([]*SessionTypeResponse, len(sts))
+	for i, st := range sts {
+		response[i] = MapSessionTypeToResponse(st, locales)
+	}
+	return response
+}
@@ -0,0 +1,20 @@
+package sessiontypes
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeSessionTypeService(log logger.Logger, db *sqlx.DB) *SessionTypeService {
+	repo := NewSessionTypeRepository(db)
+	service := NewSessionTypeService(log, repo)
+	return service
+}
+
+func InitializeSessionTypeHTTPHandler(service *SessionTypeService) http.Handler {
+	handler := NewSessionTypeHandler(service)
+	return Routes(handler)
+}
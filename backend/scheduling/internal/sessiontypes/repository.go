@@ -0,0 +1,72 @@
+package sessiontypes
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type SessionTypeRepo struct {
+	db *sqlx.DB
+}
+
+func NewSessionTypeRepository(db *sqlx.DB) *SessionTypeRepo {
+	return &SessionTypeRepo{db: db}
+}
+
+// GetEducatorSessionTypes retrieves active session types of an educator
+func (r *SessionTypeRepo) GetEducatorSessionTypes(ctx context.Context, educatorId uuid.UUID) ([]*entities.SessionType, error) {
+	const query = `
+		SELECT id, educator_id, name, description, duration_minutes, default_price, delivery_mode, color, confirmation_deadline_minutes, translations, archived_at, created_at, updated_at
+		FROM session_type
+		WHERE educator_id = $1 AND archived_at IS NULL
+		ORDER BY duration_minutes, name
+	`
+	return database.FetchMultiple[entities.SessionType](ctx, r.db, query, educatorId)
+}
+
+// GetSessionTypeById retrieves an active session type by its Id
+func (r *SessionTypeRepo) GetSessionTypeById(ctx context.Context, id int64) (*entities.SessionType, error) {
+	const query = `
+		SELECT id, educator_id, name, description, duration_minutes, default_price, delivery_mode, color, confirmation_deadline_minutes, translations, archived_at, created_at, updated_at
+		FROM session_type
+		WHERE id = $1 AND archived_at IS NULL
+	`
+	return database.FetchSingle[entities.SessionType](ctx, r.db, query, id)
+}
+
+// AddSessionType adds a new session type and returns its Id
+func (r *SessionTypeRepo) AddSessionType(ctx context.Context, sessionType *entities.SessionType) (int64, error) {
+	const query = `
+		INSERT INTO session_type (educator_id, name, description, duration_minutes, default_price, delivery_mode, color, confirmation_deadline_minutes, translations, created_at, updated_at)
+		VALUES (:educator_id, :name, :description, :duration_minutes, :default_price, :delivery_mode, :color, :confirmation_deadline_minutes, :translations, :created_at, :updated_at)
+		RETURNING id
+	`
+	return database.ExecNamedQueryWithResult[int64](ctx, r.db, query, sessionType)
+}
+
+// UpdateSessionType updates an existing session type
+func (r *SessionTypeRepo) UpdateSessionType(ctx context.Context, sessionType *entities.SessionType) error {
+	const query = `
+		UPDATE session_type
+		SET name = :name, description = :description, duration_minutes = :duration_minutes, default_price = :default_price, delivery_mode = :delivery_mode, color = :color,
+			confirmation_deadline_minutes = :confirmation_deadline_minutes, translations = :translations, updated_at = :updated_at
+		WHERE id = :id AND educator_id = :educator_id
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, sessionType)
+}
+
+// ArchiveSessionType hides a session type from the catalog while keeping it for existing slots and bookings
+func (r *SessionTypeRepo) ArchiveSessionType(ctx context.Context, educatorId uuid.UUID, id int64) error {
+	const query = `
+		UPDATE session_type
+		SET archived_at = $3, updated_at = $3
+		WHERE id = $1 AND educator_id = $2 AND archived_at IS NULL
+	`
+	return database.ExecQuery(ctx, r.db, query, id, educatorId, time.Now().UTC())
+}
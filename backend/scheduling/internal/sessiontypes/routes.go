@@ -0,0 +1,23 @@
+package sessiontypes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *SessionTypeHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/educators/{educatorId}", handler.GetEducatorSessionTypes)
+	r.Get("/{id}", handler.GetSessionTypeById)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Post("/", handler.AddSessionType)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Put("/{id}", handler.UpdateSessionType)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Delete("/{id}", handler.ArchiveSessionType)
+
+	return r
+}
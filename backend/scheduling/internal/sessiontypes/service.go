@@ -0,0 +1,127 @@
+package sessiontypes
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/i18n"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type SessionTypeRepository interface {
+	GetEducatorSessionTypes(ctx context.Context, educatorId uuid.UUID) ([]*entities.SessionType, error)
+	GetSessionTypeById(ctx context.Context, id int64) (*entities.SessionType, error)
+	AddSessionType(ctx context.Context, sessionType *entities.SessionType) (int64, error)
+	UpdateSessionType(ctx context.Context, sessionType *entities.SessionType) error
+	ArchiveSessionType(ctx context.Context, educatorId uuid.UUID, id int64) error
+}
+
+type SessionTypeService struct {
+	log  logger.Logger
+	repo SessionTypeRepository
+}
+
+func NewSessionTypeService(log logger.Logger, repo SessionTypeRepository) *SessionTypeService {
+	return &SessionTypeService{log: log, repo: repo}
+}
+
+func (s *SessionTypeService) GetEducatorSessionTypes(ctx context.Context, educatorId uuid.UUID) ([]*SessionTypeResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	sessionTypes, err := s.repo.GetEducatorSessionTypes(ctx, educatorId)
+	if err != nil {
+		log.Error("failed to get session types", err)
+		return nil, err
+	}
+
+	return MapSessionTypesToResponse(sessionTypes, i18n.Locales(ctx)), nil
+}
+
+func (s *SessionTypeService) GetSessionTypeById(ctx context.Context, id int64) (*SessionTypeResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	sessionType, err := s.repo.GetSessionTypeById(ctx, id)
+	if err != nil {
+		log.Error("failed to get session type", err)
+		return nil, err
+	}
+
+	return MapSessionTypeToResponse(sessionType, i18n.Locales(ctx)), nil
+}
+
+func (s *SessionTypeService) AddSessionType(ctx context.Context, request *SessionTypeRequest) (*SessionTypeResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	sessionType := MapRequestToSessionType(userId, request)
+	sessionType.Id, err = s.repo.AddSessionType(ctx, sessionType)
+	if err != nil {
+		log.Error("failed to add session type", err)
+		return nil, err
+	}
+
+	return MapSessionTypeToResponse(sessionType, i18n.Locales(ctx)), nil
+}
+
+func (s *SessionTypeService) UpdateSessionType(ctx context.Context, id int64, request *SessionTypeRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	sessionType, err := s.getOwnSessionType(ctx, id)
+	if err != nil {
+		log.Error("failed to get session type", err)
+		return err
+	}
+
+	MapRequestWithSessionType(request, sessionType)
+
+	if err := s.repo.UpdateSessionType(ctx, sessionType); err != nil {
+		log.Error("failed to update session type", err)
+		return err
+	}
+
+	return nil
+}
+
+// ArchiveSessionType removes a session type from the catalog; slots and bookings referencing it keep the reference
+func (s *SessionTypeService) ArchiveSessionType(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	sessionType, err := s.getOwnSessionType(ctx, id)
+	if err != nil {
+		log.Error("failed to get session type", err)
+		return err
+	}
+
+	if err := s.repo.ArchiveSessionType(ctx, sessionType.EducatorId, sessionType.Id); err != nil {
+		log.Error("failed to archive session type", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *SessionTypeService) getOwnSessionType(ctx context.Context, id int64) (*entities.SessionType, error) {
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	sessionType, err := s.repo.GetSessionTypeById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if sessionType.EducatorId != userId {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	return sessionType, nil
+}
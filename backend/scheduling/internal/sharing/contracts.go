@@ -0,0 +1,91 @@
+package sharing
+
+import (
+	"strings"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// swagger:model ShareLinkRequest
+type ShareLinkRequest struct {
+	Label          *string   `json:"label"`
+	SessionTypeIds []int64   `json:"sessionTypeIds"`
+	FromDate       time.Time `json:"fromDate"`
+	ToDate         time.Time `json:"toDate"`
+}
+
+// swagger:model ShareLinkResponse
+type ShareLinkResponse struct {
+	Id             int64      `json:"id"`
+	Label          *string    `json:"label"`
+	SessionTypeIds []int64    `json:"sessionTypeIds"`
+	FromDate       time.Time  `json:"fromDate"`
+	ToDate         time.Time  `json:"toDate"`
+	Token          string     `json:"token"`
+	RevokedAt      *time.Time `json:"revokedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// swagger:model SharedScheduleResponse
+type SharedScheduleResponse struct {
+	EducatorId      string                  `json:"educatorId"`
+	Label           *string                 `json:"label"`
+	FromDate        time.Time               `json:"fromDate"`
+	ToDate          time.Time               `json:"toDate"`
+	WorkingPeriods  []*SharedPeriodResponse `json:"workingPeriods"`
+	ScheduledEvents []*SharedEventResponse  `json:"scheduledEvents"`
+	BusySlots       []*SharedPeriodResponse `json:"busySlots"`
+}
+
+// swagger:model SharedPeriodResponse
+type SharedPeriodResponse struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// swagger:model SharedEventResponse
+type SharedEventResponse struct {
+	Id              int64     `json:"id"`
+	SessionTypeId   *int64    `json:"sessionTypeId"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	Locale          string    `json:"locale"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	MaxParticipants int       `json:"maxParticipants"`
+}
+
+func (s *ShareLinkRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if s.Label != nil && len(strings.TrimSpace(*s.Label)) > 100 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Label",
+			Message: "must be at most 100 characters",
+		})
+	}
+
+	if s.FromDate.IsZero() || s.ToDate.IsZero() || !s.FromDate.Before(s.ToDate) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "FromDate",
+			Message: "must be set and before ToDate",
+		})
+	}
+
+	for _, id := range s.SessionTypeIds {
+		if id <= 0 {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "SessionTypeIds",
+				Message: "must contain only positive ids",
+			})
+			break
+		}
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Share link request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
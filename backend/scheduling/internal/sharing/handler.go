@@ -0,0 +1,119 @@
+package sharing
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type ShareLinkHandler struct {
+	service *ShareLinkService
+}
+
+func NewShareLinkHandler(service *ShareLinkService) *ShareLinkHandler {
+	return &ShareLinkHandler{service: service}
+}
+
+// GetMyShareLinks retrieves the share links of the current educator.
+// @Summary      Retrieve my share links
+// @Description  Retrieves all share links of the educator, including revoked ones, newest first.
+// @Tags         ShareLink
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   ShareLinkResponse  "Share links"
+// @Failure      401  {object}  error              "Unauthorized"
+// @Router       /api/v1/share-links [get]
+// @Security 	 BearerAuth
+func (h *ShareLinkHandler) GetMyShareLinks(w http.ResponseWriter, r *http.Request) {
+	links, err := h.service.GetMyShareLinks(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, links)
+}
+
+// CreateShareLink creates a public link to a subset of the educator's availability.
+// @Summary      Create share link
+// @Description  Creates a signed link exposing working periods, busy slots and scheduled events of the selected session types within the date range.
+// @Tags         ShareLink
+// @Accept       json
+// @Produce      json
+// @Param        link  body      ShareLinkRequest   true  "Share link scope"
+// @Success      201   {object}  ShareLinkResponse  "Created share link"
+// @Failure      400   {object}  error              "Invalid input"
+// @Router       /api/v1/share-links [post]
+// @Security 	 BearerAuth
+func (h *ShareLinkHandler) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	var request *ShareLinkRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	link, err := h.service.CreateShareLink(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, link)
+}
+
+// RevokeShareLink revokes a share link of the current educator.
+// @Summary      Revoke share link
+// @Description  Revokes the share link, so its token no longer resolves.
+// @Tags         ShareLink
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Share link ID"
+// @Success      204  "Share link revoked successfully"
+// @Failure      404  {object}  error  "Share link not found"
+// @Router       /api/v1/share-links/{id} [delete]
+// @Security 	 BearerAuth
+func (h *ShareLinkHandler) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.RevokeShareLink(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSharedSchedule retrieves the availability exposed by a share link.
+// @Summary      Retrieve shared schedule
+// @Description  Resolves a share link token without authentication and returns the read-only availability it exposes from now until the end of its range. Scheduled event titles and descriptions are translated by the Accept-Language header.
+// @Tags         ShareLink
+// @Accept       json
+// @Produce      json
+// @Param        token  path      string                  true  "Share link token"
+// @Success      200    {object}  SharedScheduleResponse  "Shared schedule"
+// @Failure      404    {object}  error                   "Share link not found"
+// @Router       /api/v1/share-links/public/{token} [get]
+func (h *ShareLinkHandler) GetSharedSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.service.GetSharedSchedule(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, schedule)
+}
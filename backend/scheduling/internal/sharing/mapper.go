@@ -0,0 +1,87 @@
+package sharing
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToShareLink(request *ShareLinkRequest, educatorId uuid.UUID) *entities.ShareLink {
+	var label *string
+	if request.Label != nil {
+		trimmed := strings.TrimSpace(*request.Label)
+		label = &trimmed
+	}
+
+	sessionTypeIds := request.SessionTypeIds
+	if sessionTypeIds == nil {
+		sessionTypeIds = []int64{}
+	}
+
+	return &entities.ShareLink{
+		EducatorId:     educatorId,
+		Label:          label,
+		SessionTypeIds: pq.Int64Array(sessionTypeIds),
+		FromDate:       request.FromDate.UTC(),
+		ToDate:         request.ToDate.UTC(),
+		CreatedAt:      time.Now().UTC(),
+	}
+}
+
+func MapShareLinkToResponse(link *entities.ShareLink, token string) *ShareLinkResponse {
+	return &ShareLinkResponse{
+		Id:             link.Id,
+		Label:          link.Label,
+		SessionTypeIds: link.SessionTypeIds,
+		FromDate:       link.FromDate,
+		ToDate:         link.ToDate,
+		Token:          token,
+		RevokedAt:      link.RevokedAt,
+		CreatedAt:      link.CreatedAt,
+	}
+}
+
+func MapWorkingPeriodsToShared(periods []*entities.WorkingPeriod, from, to time.Time) []*SharedPeriodResponse {
+	result := make([]*SharedPeriodResponse, 0, len(periods))
+	for _, wp := range periods {
+		start, end := wp.StartTime, wp.EndTime
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		result = append(result, &SharedPeriodResponse{StartTime: start, EndTime: end})
+	}
+	return result
+}
+
+func MapBookingsToBusySlots(bookings []*entities.Booking) []*SharedPeriodResponse {
+	result := make([]*SharedPeriodResponse, 0, len(bookings))
+	for _, b := range bookings {
+		result = append(result, &SharedPeriodResponse{StartTime: b.StartTime, EndTime: b.EndTime})
+	}
+	return result
+}
+
+func MapScheduledEventsToShared(events []*entities.ScheduledEvent, locales []string) []*SharedEventResponse {
+	result := make([]*SharedEventResponse, 0, len(events))
+	for _, se := range events {
+		title, description, locale := se.Translations.Translate(locales, se.Title, "")
+		result = append(result, &SharedEventResponse{
+			Id:              se.Id,
+			SessionTypeId:   se.SessionTypeId,
+			Title:           title,
+			Description:     description,
+			Locale:          locale,
+			StartTime:       se.StartTime,
+			EndTime:         se.EndTime,
+			MaxParticipants: se.MaxParticipants,
+		})
+	}
+	return result
+}
@@ -0,0 +1,21 @@
+package sharing
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeShareLinkService(log logger.Logger, db *sqlx.DB, cfg *config.SharingConfig) *ShareLinkService {
+	repo := NewShareLinkRepository(db)
+	service := NewShareLinkService(log, repo, cfg, NewSigner(cfg.SigningKey))
+	return service
+}
+
+func InitializeShareLinkHTTPHandler(service *ShareLinkService) http.Handler {
+	handler := NewShareLinkHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,103 @@
+package sharing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type ShareLinkRepo struct {
+	db *sqlx.DB
+}
+
+func NewShareLinkRepository(db *sqlx.DB) *ShareLinkRepo {
+	return &ShareLinkRepo{db: db}
+}
+
+// GetShareLinkById retrieves a share link, including revoked ones
+func (r *ShareLinkRepo) GetShareLinkById(ctx context.Context, id int64) (*entities.ShareLink, error) {
+	const query = `
+		SELECT id, educator_id, label, session_type_ids, from_date, to_date, revoked_at, created_at
+		FROM share_link
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.ShareLink](ctx, r.db, query, id)
+}
+
+// GetEducatorShareLinks retrieves the share links of an educator, newest first
+func (r *ShareLinkRepo) GetEducatorShareLinks(ctx context.Context, educatorId uuid.UUID) ([]*entities.ShareLink, error) {
+	const query = `
+		SELECT id, educator_id, label, session_type_ids, from_date, to_date, revoked_at, created_at
+		FROM share_link
+		WHERE educator_id = $1
+		ORDER BY created_at DESC
+	`
+	return database.FetchMultiple[entities.ShareLink](ctx, r.db, query, educatorId)
+}
+
+// AddShareLink adds a new share link and returns its Id
+func (r *ShareLinkRepo) AddShareLink(ctx context.Context, link *entities.ShareLink) (int64, error) {
+	const query = `
+		INSERT INTO share_link (educator_id, label, session_type_ids, from_date, to_date, created_at)
+		VALUES (:educator_id, :label, :session_type_ids, :from_date, :to_date, :created_at)
+		RETURNING id
+	`
+	return database.ExecNamedQueryWithResult[int64](ctx, r.db, query, link)
+}
+
+// RevokeShareLink revokes an active share link of an educator
+func (r *ShareLinkRepo) RevokeShareLink(ctx context.Context, educatorId uuid.UUID, id int64, revokedAt time.Time) error {
+	const query = `UPDATE share_link SET revoked_at = $3 WHERE id = $1 AND educator_id = $2 AND revoked_at IS NULL`
+	return database.ExecQuery(ctx, r.db, query, id, educatorId, revokedAt)
+}
+
+// GetWorkingPeriods retrieves working periods of an educator overlapping a range
+func (r *ShareLinkRepo) GetWorkingPeriods(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.WorkingPeriod, error) {
+	const query = `
+		SELECT id, user_id, start_time, end_time, created_at, updated_at
+		FROM working_period
+		WHERE user_id = $1 AND start_time < $3 AND end_time > $2 AND deleted_at IS NULL
+		ORDER BY start_time
+	`
+	return database.FetchMultiple[entities.WorkingPeriod](ctx, r.db, query, educatorId, from, to)
+}
+
+// GetScheduledEvents retrieves scheduled events within a range, limited to the given session types when any are set
+func (r *ShareLinkRepo) GetScheduledEvents(ctx context.Context, educatorId uuid.UUID, from, to time.Time, sessionTypeIds []int64) ([]*entities.ScheduledEvent, error) {
+	const query = `
+		SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, translations, created_at, updated_at
+		FROM scheduled_event
+		WHERE user_id = $1 AND start_time >= $2 AND end_time <= $3 AND deleted_at IS NULL
+		AND (cardinality($4::bigint[]) = 0 OR session_type_id = ANY($4))
+		ORDER BY start_time
+	`
+	return database.FetchMultiple[entities.ScheduledEvent](ctx, r.db, query, educatorId, from, to, pq.Array(sessionTypeIds))
+}
+
+// GetBusyBookings retrieves the educator's open bookings within a range, see entities.OpenStatuses
+func (r *ShareLinkRepo) GetBusyBookings(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at
+		FROM booking
+		WHERE educator_id = $1 AND start_time < $3 AND end_time > $2 AND status = ANY($4) AND scheduled_event_id IS NULL
+		ORDER BY start_time
+	`
+	return database.FetchMultiple[entities.Booking](ctx, r.db, query, educatorId, from, to, entities.OpenStatuses)
+}
+
+// SessionTypesOwned checks that every given session type belongs to the educator
+func (r *ShareLinkRepo) SessionTypesOwned(ctx context.Context, educatorId uuid.UUID, ids []int64) (bool, error) {
+	const query = `
+		SELECT NOT EXISTS (
+			SELECT 1 FROM unnest($2::bigint[]) AS requested(id)
+			WHERE NOT EXISTS (SELECT 1 FROM session_type st WHERE st.id = requested.id AND st.educator_id = $1)
+		)
+	`
+	return database.CheckExists(ctx, r.db, query, educatorId, pq.Array(ids))
+}
@@ -0,0 +1,25 @@
+package sharing
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+// PublicPathPrefix is served without authentication, see AuthMiddleware public routes
+const PublicPathPrefix = "/api/v1/share-links/public"
+
+func Routes(handler *ShareLinkHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/public/{token}", handler.GetSharedSchedule)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Get("/", handler.GetMyShareLinks)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Post("/", handler.CreateShareLink)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Delete("/{id}", handler.RevokeShareLink)
+
+	return r
+}
@@ -0,0 +1,205 @@
+package sharing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/i18n"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type ShareLinkRepository interface {
+	GetShareLinkById(ctx context.Context, id int64) (*entities.ShareLink, error)
+	GetEducatorShareLinks(ctx context.Context, educatorId uuid.UUID) ([]*entities.ShareLink, error)
+	AddShareLink(ctx context.Context, link *entities.ShareLink) (int64, error)
+	RevokeShareLink(ctx context.Context, educatorId uuid.UUID, id int64, revokedAt time.Time) error
+	SessionTypesOwned(ctx context.Context, educatorId uuid.UUID, ids []int64) (bool, error)
+	GetWorkingPeriods(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.WorkingPeriod, error)
+	GetScheduledEvents(ctx context.Context, educatorId uuid.UUID, from, to time.Time, sessionTypeIds []int64) ([]*entities.ScheduledEvent, error)
+	GetBusyBookings(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.Booking, error)
+}
+
+type ShareLinkService struct {
+	log    logger.Logger
+	repo   ShareLinkRepository
+	cfg    *config.SharingConfig
+	signer *Signer
+}
+
+func NewShareLinkService(log logger.Logger, repo ShareLinkRepository, cfg *config.SharingConfig, signer *Signer) *ShareLinkService {
+	return &ShareLinkService{log: log, repo: repo, cfg: cfg, signer: signer}
+}
+
+func (s *ShareLinkService) CreateShareLink(ctx context.Context, request *ShareLinkRequest) (*ShareLinkResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if request.ToDate.Sub(request.FromDate) > time.Duration(s.cfg.MaxRangeDays)*24*time.Hour {
+		return nil, apperrors.NewUnprocessedEntity("Shared date range is too long", apperrors.ErrShareLinkInvalid)
+	}
+
+	if len(request.SessionTypeIds) > 0 {
+		owned, err := s.repo.SessionTypesOwned(ctx, userId, request.SessionTypeIds)
+		if err != nil {
+			log.Error("failed to check session types", err)
+			return nil, err
+		}
+		if !owned {
+			return nil, apperrors.NewUnprocessedEntity("Session type not found", apperrors.ErrSessionTypeInvalid)
+		}
+	}
+
+	link := MapRequestToShareLink(request, userId)
+	id, err := s.repo.AddShareLink(ctx, link)
+	if err != nil {
+		log.Error("failed to add share link", err)
+		return nil, err
+	}
+	link.Id = id
+
+	tenantId, _ := auth.GetTenantID(ctx)
+	return MapShareLinkToResponse(link, s.signer.Sign(tenantId, link.Id)), nil
+}
+
+func (s *ShareLinkService) GetMyShareLinks(ctx context.Context) ([]*ShareLinkResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	links, err := s.repo.GetEducatorShareLinks(ctx, userId)
+	if err != nil {
+		log.Error("failed to get share links", err)
+		return nil, err
+	}
+
+	tenantId, _ := auth.GetTenantID(ctx)
+	result := make([]*ShareLinkResponse, 0, len(links))
+	for _, link := range links {
+		result = append(result, MapShareLinkToResponse(link, s.signer.Sign(tenantId, link.Id)))
+	}
+	return result, nil
+}
+
+func (s *ShareLinkService) RevokeShareLink(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	link, err := s.repo.GetShareLinkById(ctx, id)
+	if err != nil {
+		log.Error("failed to get share link", err)
+		return err
+	}
+
+	if link.EducatorId != userId {
+		return apperrors.NewForbidden("Access denied")
+	}
+
+	if err := s.repo.RevokeShareLink(ctx, userId, id, time.Now().UTC()); err != nil {
+		log.Error("failed to revoke share link", err)
+		return err
+	}
+	return nil
+}
+
+// GetSharedSchedule resolves a public link token into the read-only availability it exposes. Revoked
+// links and tokens with an invalid signature are reported as not found.
+func (s *ShareLinkService) GetSharedSchedule(ctx context.Context, token string) (*SharedScheduleResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	id, tenantId, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	if tenantId != "" {
+		ctx = auth.WithTenant(ctx, tenantId)
+	}
+
+	link, err := s.repo.GetShareLinkById(ctx, id)
+	if err != nil {
+		log.Error("failed to get share link", err)
+		return nil, err
+	}
+
+	if link.RevokedAt != nil {
+		return nil, apperrors.NewNotFound("Share link not found", apperrors.ErrShareLinkInvalid)
+	}
+
+	from := link.FromDate
+	if now := time.Now().UTC(); now.After(from) {
+		from = now
+	}
+
+	response, err := s.GetAvailability(ctx, link.EducatorId, from, link.ToDate, link.SessionTypeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Label = link.Label
+	response.FromDate = link.FromDate
+	return response, nil
+}
+
+// GetAvailability returns the public read-only availability of an educator within a range. Scheduled
+// events are limited to the given session types when any are set.
+func (s *ShareLinkService) GetAvailability(
+	ctx context.Context,
+	educatorId uuid.UUID,
+	from time.Time,
+	to time.Time,
+	sessionTypeIds []int64,
+) (*SharedScheduleResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	response := &SharedScheduleResponse{
+		EducatorId:      educatorId.String(),
+		FromDate:        from,
+		ToDate:          to,
+		WorkingPeriods:  []*SharedPeriodResponse{},
+		ScheduledEvents: []*SharedEventResponse{},
+		BusySlots:       []*SharedPeriodResponse{},
+	}
+
+	if !from.Before(to) {
+		return response, nil
+	}
+
+	periods, err := s.repo.GetWorkingPeriods(ctx, educatorId, from, to)
+	if err != nil {
+		log.Error("failed to get working periods", err)
+		return nil, err
+	}
+
+	events, err := s.repo.GetScheduledEvents(ctx, educatorId, from, to, sessionTypeIds)
+	if err != nil {
+		log.Error("failed to get scheduled events", err)
+		return nil, err
+	}
+
+	bookings, err := s.repo.GetBusyBookings(ctx, educatorId, from, to)
+	if err != nil {
+		log.Error("failed to get bookings", err)
+		return nil, err
+	}
+
+	response.WorkingPeriods = MapWorkingPeriodsToShared(periods, from, to)
+	response.ScheduledEvents = MapScheduledEventsToShared(events, i18n.Locales(ctx))
+	response.BusySlots = MapBookingsToBusySlots(bookings)
+	return response, nil
+}
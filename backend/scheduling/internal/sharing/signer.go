@@ -0,0 +1,76 @@
+package sharing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+const tokenPrefix = "ora:share:v1:"
+
+// Signer issues and verifies the tokens of public schedule links. A token carries the link id
+// and an HMAC-SHA256 signature, so it cannot be guessed or altered to point at another link.
+// In multi-tenant deployments the token also carries the tenant of the link, covered by the signature.
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key string) *Signer {
+	return &Signer{key: []byte(key)}
+}
+
+// Sign returns the URL safe token of a share link of a tenant. Without a tenant the token has the form it
+// had before deployments were multi-tenant, so links already handed out keep working.
+func (s *Signer) Sign(tenantId string, linkId int64) string {
+	id := strconv.FormatInt(linkId, 10)
+	if tenantId == "" {
+		return id + "." + s.signature(id, "")
+	}
+	return id + "." + base64.RawURLEncoding.EncodeToString([]byte(tenantId)) + "." + s.signature(id, tenantId)
+}
+
+// Verify checks the signature of a token and returns the share link id and tenant it was issued for, the
+// tenant is empty for tokens issued without one
+func (s *Signer) Verify(token string) (int64, string, error) {
+	id, tenantId, signature, ok := splitToken(token)
+	if !ok || !hmac.Equal([]byte(signature), []byte(s.signature(id, tenantId))) {
+		return 0, "", apperrors.NewNotFound("Share link not found", apperrors.ErrShareLinkInvalid)
+	}
+
+	linkId, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, "", apperrors.NewNotFound("Share link not found", apperrors.ErrShareLinkInvalid)
+	}
+	return linkId, tenantId, nil
+}
+
+func (s *Signer) signature(id string, tenantId string) string {
+	message := tokenPrefix + id
+	if tenantId != "" {
+		message += ":" + tenantId
+	}
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(message))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// splitToken splits a token into its id, tenant and signature, the tenant part is optional
+func splitToken(token string) (id string, tenantId string, signature string, ok bool) {
+	parts := strings.Split(token, ".")
+	switch len(parts) {
+	case 2:
+		return parts[0], "", parts[1], true
+	case 3:
+		tenant, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil || len(tenant) == 0 {
+			return "", "", "", false
+		}
+		return parts[0], string(tenant), parts[2], true
+	default:
+		return "", "", "", false
+	}
+}
@@ -0,0 +1,78 @@
+package slotpolicy
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+const (
+	minDurationMinutes = 15
+	maxDurationMinutes = 8 * 60
+	maxDurations       = 20
+)
+
+// alignments divide an hour, so aligned start times fall on the same minutes of every hour
+var alignments = []int{0, 5, 10, 15, 20, 30, 60}
+
+// swagger:model SlotPolicyRequest
+type SlotPolicyRequest struct {
+	// AllowedDurations lists the minutes a session may last, e.g. [15, 30, 45, 60]; empty allows any duration
+	AllowedDurations []int `json:"allowedDurations"`
+	// AlignmentMinutes is the step start times are aligned on from the full hour, e.g. 30 for :00 and :30;
+	// zero allows any start time
+	AlignmentMinutes int `json:"alignmentMinutes"`
+}
+
+// swagger:model SlotPolicyResponse
+type SlotPolicyResponse struct {
+	AllowedDurations []int      `json:"allowedDurations"`
+	AlignmentMinutes int        `json:"alignmentMinutes"`
+	UpdatedBy        *uuid.UUID `json:"updatedBy"`
+	UpdatedAt        *time.Time `json:"updatedAt"`
+}
+
+func (s *SlotPolicyRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if len(s.AllowedDurations) > maxDurations {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "AllowedDurations",
+			Message: fmt.Sprintf("must not contain more than %d durations", maxDurations),
+		})
+	}
+
+	for i, d := range s.AllowedDurations {
+		if d < minDurationMinutes || d > maxDurationMinutes {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "AllowedDurations",
+				Message: fmt.Sprintf("must be between %d and %d minutes", minDurationMinutes, maxDurationMinutes),
+			})
+			break
+		}
+		if slices.Contains(s.AllowedDurations[:i], d) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "AllowedDurations",
+				Message: "must not contain duplicates",
+			})
+			break
+		}
+	}
+
+	if !slices.Contains(alignments, s.AlignmentMinutes) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "AlignmentMinutes",
+			Message: "must be one of 0, 5, 10, 15, 20, 30 or 60",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Slot policy request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
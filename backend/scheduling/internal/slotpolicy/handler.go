@@ -0,0 +1,87 @@
+package slotpolicy
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type SlotPolicyHandler struct {
+	service *SlotPolicyService
+}
+
+func NewSlotPolicyHandler(service *SlotPolicyService) *SlotPolicyHandler {
+	return &SlotPolicyHandler{service: service}
+}
+
+// GetSlotPolicy retrieves the slot policy.
+// @Summary      Retrieve slot policy
+// @Description  Retrieves the platform-wide durations sessions may last and the minutes their start times are aligned on. Empty durations and a zero alignment mean no policy is set.
+// @Tags         SlotPolicy
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  SlotPolicyResponse  "Slot policy"
+// @Router       /api/v1/admin/slot-policy [get]
+// @Security 	 BearerAuth
+func (h *SlotPolicyHandler) GetSlotPolicy(w http.ResponseWriter, r *http.Request) {
+	policy, err := h.service.GetSlotPolicy(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, policy)
+}
+
+// SaveSlotPolicy sets the slot policy.
+// @Summary      Set slot policy
+// @Description  Restricts new bookings, booking holds, booking link redemptions and scheduled events to the durations in 'allowedDurations' and to start times aligned on 'alignmentMinutes' from the full hour in UTC, e.g. 30 for :00 and :30. Violations get a 422 with the ERROR_SLOT_GRANULARITY code. The availability search and group session suggestions only offer slots that comply. Existing sessions are kept.
+// @Tags         SlotPolicy
+// @Accept       json
+// @Produce      json
+// @Param        request  body      SlotPolicyRequest   true  "Slot policy"
+// @Success      200      {object}  SlotPolicyResponse  "Slot policy saved"
+// @Failure      400      {object}  error               "Invalid input"
+// @Router       /api/v1/admin/slot-policy [put]
+// @Security 	 BearerAuth
+func (h *SlotPolicyHandler) SaveSlotPolicy(w http.ResponseWriter, r *http.Request) {
+	var request *SlotPolicyRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	policy, err := h.service.SaveSlotPolicy(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, policy)
+}
+
+// ClearSlotPolicy removes the slot policy.
+// @Summary      Clear slot policy
+// @Description  Lets sessions last any time and start at any minute again.
+// @Tags         SlotPolicy
+// @Accept       json
+// @Produce      json
+// @Success      204  "Slot policy cleared"
+// @Router       /api/v1/admin/slot-policy [delete]
+// @Security 	 BearerAuth
+func (h *SlotPolicyHandler) ClearSlotPolicy(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.ClearSlotPolicy(r.Context()); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
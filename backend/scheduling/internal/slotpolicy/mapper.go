@@ -0,0 +1,40 @@
+package slotpolicy
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToSlotPolicy(userId uuid.UUID, r *SlotPolicyRequest) *entities.SlotPolicy {
+	durations := make(pq.Int64Array, len(r.AllowedDurations))
+	for i, d := range r.AllowedDurations {
+		durations[i] = int64(d)
+	}
+	slices.Sort(durations)
+
+	now := time.Now().UTC()
+	return &entities.SlotPolicy{
+		AllowedDurations: durations,
+		AlignmentMinutes: r.AlignmentMinutes,
+		UpdatedBy:        &userId,
+		UpdatedAt:        &now,
+	}
+}
+
+func MapSlotPolicyToResponse(p *entities.SlotPolicy) *SlotPolicyResponse {
+	durations := make([]int, len(p.AllowedDurations))
+	for i, d := range p.AllowedDurations {
+		durations[i] = int(d)
+	}
+	return &SlotPolicyResponse{
+		AllowedDurations: durations,
+		AlignmentMinutes: p.AlignmentMinutes,
+		UpdatedBy:        p.UpdatedBy,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
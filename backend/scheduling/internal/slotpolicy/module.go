@@ -0,0 +1,20 @@
+package slotpolicy
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeSlotPolicyService(log logger.Logger, db *sqlx.DB) *SlotPolicyService {
+	repo := NewSlotPolicyRepository(db)
+	service := NewSlotPolicyService(log, repo)
+	return service
+}
+
+func InitializeSlotPolicyHTTPHandler(service *SlotPolicyService) http.Handler {
+	handler := NewSlotPolicyHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,49 @@
+package slotpolicy
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type SlotPolicyRepo struct {
+	db *sqlx.DB
+}
+
+func NewSlotPolicyRepository(db *sqlx.DB) *SlotPolicyRepo {
+	return &SlotPolicyRepo{db: db}
+}
+
+// GetSlotPolicy retrieves the slot policy, an unrestricted one while none is set
+func (r *SlotPolicyRepo) GetSlotPolicy(ctx context.Context) (*entities.SlotPolicy, error) {
+	const query = `
+		SELECT COALESCE(p.allowed_durations, '{}') AS allowed_durations, COALESCE(p.alignment_minutes, 0) AS alignment_minutes,
+			p.updated_by, p.updated_at
+		FROM (SELECT 1) AS d
+		LEFT JOIN slot_policy p ON p.id = 1
+	`
+	return database.FetchSingle[entities.SlotPolicy](ctx, r.db, query)
+}
+
+// UpsertSlotPolicy replaces the slot policy and returns the stored one
+func (r *SlotPolicyRepo) UpsertSlotPolicy(ctx context.Context, policy *entities.SlotPolicy) (*entities.SlotPolicy, error) {
+	const query = `
+		INSERT INTO slot_policy (id, allowed_durations, alignment_minutes, updated_by, updated_at)
+		VALUES (1, $1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE
+		SET allowed_durations = EXCLUDED.allowed_durations, alignment_minutes = EXCLUDED.alignment_minutes,
+			updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+		RETURNING allowed_durations, alignment_minutes, updated_by, updated_at
+	`
+	return database.FetchSingle[entities.SlotPolicy](ctx, r.db, query,
+		policy.AllowedDurations, policy.AlignmentMinutes, policy.UpdatedBy, policy.UpdatedAt)
+}
+
+// DeleteSlotPolicy removes the slot policy, lifting the restrictions on sessions
+func (r *SlotPolicyRepo) DeleteSlotPolicy(ctx context.Context) error {
+	const query = `DELETE FROM slot_policy WHERE id = 1`
+	return database.ExecQuery(ctx, r.db, query)
+}
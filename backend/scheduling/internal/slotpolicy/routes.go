@@ -0,0 +1,22 @@
+package slotpolicy
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *SlotPolicyHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.AdminRole))
+	r.Get("/", handler.GetSlotPolicy)
+	r.Put("/", handler.SaveSlotPolicy)
+	r.Delete("/", handler.ClearSlotPolicy)
+
+	return r
+}
@@ -0,0 +1,86 @@
+package slotpolicy
+
+import (
+	"context"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+type SlotPolicyRepository interface {
+	GetSlotPolicy(ctx context.Context) (*entities.SlotPolicy, error)
+	UpsertSlotPolicy(ctx context.Context, policy *entities.SlotPolicy) (*entities.SlotPolicy, error)
+	DeleteSlotPolicy(ctx context.Context) error
+}
+
+type SlotPolicyService struct {
+	log  logger.Logger
+	repo SlotPolicyRepository
+}
+
+func NewSlotPolicyService(log logger.Logger, repo SlotPolicyRepository) *SlotPolicyService {
+	return &SlotPolicyService{log: log, repo: repo}
+}
+
+// GetSlotPolicy returns the platform-wide slot policy
+func (s *SlotPolicyService) GetSlotPolicy(ctx context.Context) (*SlotPolicyResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	policy, err := s.repo.GetSlotPolicy(ctx)
+	if err != nil {
+		log.Error("failed to get slot policy", err)
+		return nil, err
+	}
+	return MapSlotPolicyToResponse(policy), nil
+}
+
+// SlotGranularity returns the granularity the slot policy sets for new sessions, the modules creating
+// sessions validate against it
+func (s *SlotPolicyService) SlotGranularity(ctx context.Context) (timeutils.SlotGranularity, error) {
+	policy, err := s.repo.GetSlotPolicy(ctx)
+	if err != nil {
+		return timeutils.SlotGranularity{}, err
+	}
+	return timeutils.NewSlotGranularity(policy.AllowedDurations, policy.AlignmentMinutes), nil
+}
+
+// SaveSlotPolicy replaces the slot policy. It applies to sessions created from now on, existing bookings
+// and scheduled events are kept.
+func (s *SlotPolicyService) SaveSlotPolicy(ctx context.Context, request *SlotPolicyRequest) (*SlotPolicyResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	policy, err := s.repo.UpsertSlotPolicy(ctx, MapRequestToSlotPolicy(userId, request))
+	if err != nil {
+		log.Error("failed to save slot policy", err)
+		return nil, err
+	}
+
+	log.Infof("Slot policy set by %s: durations %v, alignment %d minutes", userId, policy.AllowedDurations, policy.AlignmentMinutes)
+	return MapSlotPolicyToResponse(policy), nil
+}
+
+// ClearSlotPolicy removes the slot policy, sessions may then last any time and start at any minute
+func (s *SlotPolicyService) ClearSlotPolicy(ctx context.Context) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if err := s.repo.DeleteSlotPolicy(ctx); err != nil {
+		log.Error("failed to clear slot policy", err)
+		return err
+	}
+
+	log.Infof("Slot policy cleared by %s", userId)
+	return nil
+}
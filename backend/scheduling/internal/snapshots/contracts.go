@@ -0,0 +1,19 @@
+package snapshots
+
+import "time"
+
+const (
+	WorkingPeriodEntity  = "WORKING_PERIOD"
+	ScheduledEventEntity = "SCHEDULED_EVENT"
+	BookingEntity        = "BOOKING"
+)
+
+// swagger:model SnapshotResponse
+type SnapshotResponse struct {
+	SnapshotId      string    `json:"snapshotId"`
+	CapturedAt      time.Time `json:"capturedAt"`
+	WorkingPeriods  int       `json:"workingPeriods"`
+	ScheduledEvents int       `json:"scheduledEvents"`
+	Bookings        int       `json:"bookings"`
+	TotalItems      int       `json:"totalItems"`
+}
@@ -0,0 +1,35 @@
+package snapshots
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+)
+
+type SnapshotHandler struct {
+	service *SnapshotService
+}
+
+func NewSnapshotHandler(service *SnapshotService) *SnapshotHandler {
+	return &SnapshotHandler{service: service}
+}
+
+// ExportSnapshot publishes a full snapshot of current schedules and bookings.
+// @Summary      Export snapshot
+// @Description  Publishes all working periods, scheduled events and bookings that have not ended yet as a bounded event stream between SNAPSHOT_STARTED and SNAPSHOT_COMPLETED markers.
+// @Tags         Snapshot
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  SnapshotResponse  "Snapshot summary"
+// @Failure      500  {object}  error             "Snapshot could not be published"
+// @Router       /api/v1/snapshots [post]
+// @Security 	 BearerAuth
+func (h *SnapshotHandler) ExportSnapshot(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.ExportSnapshot(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, response)
+}
@@ -0,0 +1,52 @@
+package snapshots
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func MapWorkingPeriodToSnapshot(wp *entities.WorkingPeriod) *messaging.SnapshotWorkingPeriod {
+	return &messaging.SnapshotWorkingPeriod{
+		Id:        wp.Id,
+		UserId:    wp.UserId.String(),
+		StartTime: wp.StartTime.UTC().Format(time.RFC3339),
+		EndTime:   wp.EndTime.UTC().Format(time.RFC3339),
+	}
+}
+
+func MapScheduledEventToSnapshot(se *entities.ScheduledEvent) *messaging.SnapshotScheduledEvent {
+	return &messaging.SnapshotScheduledEvent{
+		Id:              se.Id,
+		UserId:          se.UserId.String(),
+		ProductId:       se.ProductId,
+		LessonId:        se.LessonId,
+		SessionTypeId:   se.SessionTypeId,
+		LocationId:      se.LocationId,
+		WorkingPeriodId: se.WorkingPeriodId,
+		Title:           se.Title,
+		StartTime:       se.StartTime.UTC().Format(time.RFC3339),
+		EndTime:         se.EndTime.UTC().Format(time.RFC3339),
+		MaxParticipants: se.MaxParticipants,
+		Price:           se.Price,
+	}
+}
+
+func MapBookingToSnapshot(b *entities.Booking) *messaging.SnapshotBooking {
+	return &messaging.SnapshotBooking{
+		Id:               b.Id,
+		EducatorId:       b.EducatorId.String(),
+		StudentId:        b.StudentId.String(),
+		ProductId:        b.ProductId,
+		EnrollmentId:     b.EnrollmentId,
+		ScheduledEventId: b.ScheduledEventId,
+		SessionTypeId:    b.SessionTypeId,
+		WorkingPeriodId:  b.WorkingPeriodId,
+		Title:            b.Title,
+		StartTime:        b.StartTime.UTC().Format(time.RFC3339),
+		EndTime:          b.EndTime.UTC().Format(time.RFC3339),
+		Status:           b.Status.String(),
+		Price:            b.Price,
+	}
+}
@@ -0,0 +1,21 @@
+package snapshots
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializeSnapshotService(log logger.Logger, db *sqlx.DB, publisher *messaging.Publisher) *SnapshotService {
+	repo := NewSnapshotRepository(db)
+	service := NewSnapshotService(log, repo, publisher)
+	return service
+}
+
+func InitializeSnapshotHTTPHandler(service *SnapshotService) http.Handler {
+	handler := NewSnapshotHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,77 @@
+package snapshots
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// Snapshot holds the schedules and bookings that had not ended when it was captured
+type Snapshot struct {
+	CapturedAt      time.Time
+	WorkingPeriods  []*entities.WorkingPeriod
+	ScheduledEvents []*entities.ScheduledEvent
+	Bookings        []*entities.Booking
+}
+
+type SnapshotRepo struct {
+	db *sqlx.DB
+}
+
+func NewSnapshotRepository(db *sqlx.DB) *SnapshotRepo {
+	return &SnapshotRepo{db: db}
+}
+
+// GetSnapshot reads all current schedules and bookings in one repeatable read transaction,
+// so the three sets are consistent with each other and with the captured time
+func (r *SnapshotRepo) GetSnapshot(ctx context.Context) (*Snapshot, error) {
+	const periodsQuery = `
+		SELECT id, user_id, start_time, end_time, created_at, updated_at
+		FROM working_period
+		WHERE end_time > $1 AND deleted_at IS NULL
+		ORDER BY id
+	`
+	const eventsQuery = `
+		SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, created_at, updated_at
+		FROM scheduled_event
+		WHERE end_time > $1 AND deleted_at IS NULL
+		ORDER BY id
+	`
+	const bookingsQuery = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at
+		FROM booking
+		WHERE end_time > $1
+		ORDER BY id
+	`
+
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	snapshot := &Snapshot{}
+	if err := tx.GetContext(ctx, &snapshot.CapturedAt, `SELECT now()`); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if err := tx.SelectContext(ctx, &snapshot.WorkingPeriods, periodsQuery, snapshot.CapturedAt); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if err := tx.SelectContext(ctx, &snapshot.ScheduledEvents, eventsQuery, snapshot.CapturedAt); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	if err := tx.SelectContext(ctx, &snapshot.Bookings, bookingsQuery, snapshot.CapturedAt); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	snapshot.CapturedAt = snapshot.CapturedAt.UTC()
+	return snapshot, nil
+}
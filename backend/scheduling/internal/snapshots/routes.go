@@ -0,0 +1,19 @@
+package snapshots
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *SnapshotHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.With(middleware.RequireRole(auth.AdminRole)).Post("/", handler.ExportSnapshot)
+
+	return r
+}
@@ -0,0 +1,99 @@
+package snapshots
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+type SnapshotRepository interface {
+	GetSnapshot(ctx context.Context) (*Snapshot, error)
+}
+
+type SnapshotService struct {
+	log       logger.Logger
+	repo      SnapshotRepository
+	publisher *messaging.Publisher
+}
+
+func NewSnapshotService(log logger.Logger, repo SnapshotRepository, publisher *messaging.Publisher) *SnapshotService {
+	return &SnapshotService{log: log, repo: repo, publisher: publisher}
+}
+
+// ExportSnapshot publishes a bounded stream of current schedules and bookings. The stream starts with
+// a SNAPSHOT_STARTED marker, carries one SNAPSHOT_ITEM per entity with increasing sequence numbers and
+// ends with a SNAPSHOT_COMPLETED marker. A stream without the completed marker must be discarded.
+func (s *SnapshotService) ExportSnapshot(ctx context.Context) (*SnapshotResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	snapshot, err := s.repo.GetSnapshot(ctx)
+	if err != nil {
+		log.Error("failed to read snapshot", err)
+		return nil, err
+	}
+
+	snapshotId := uuid.New().String()
+	capturedAt := snapshot.CapturedAt.Format(time.RFC3339Nano)
+	total := len(snapshot.WorkingPeriods) + len(snapshot.ScheduledEvents) + len(snapshot.Bookings)
+
+	publish := func(event messaging.EventBase) error {
+		if err := s.publisher.Publish(ctx, messaging.SnapshotKey, event); err != nil {
+			log.Error("failed to publish snapshot event", err)
+			return apperrors.NewInternal(err)
+		}
+		return nil
+	}
+
+	sequence := 0
+	if err := publish(messaging.NewSnapshotMarkerEvent(messaging.SnapshotStarted, snapshotId, sequence, total, capturedAt)); err != nil {
+		return nil, err
+	}
+
+	for _, wp := range snapshot.WorkingPeriods {
+		sequence++
+		item := messaging.NewSnapshotItemEvent(snapshotId, sequence, WorkingPeriodEntity)
+		item.WorkingPeriod = MapWorkingPeriodToSnapshot(wp)
+		if err := publish(item); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, se := range snapshot.ScheduledEvents {
+		sequence++
+		item := messaging.NewSnapshotItemEvent(snapshotId, sequence, ScheduledEventEntity)
+		item.ScheduledEvent = MapScheduledEventToSnapshot(se)
+		if err := publish(item); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, b := range snapshot.Bookings {
+		sequence++
+		item := messaging.NewSnapshotItemEvent(snapshotId, sequence, BookingEntity)
+		item.Booking = MapBookingToSnapshot(b)
+		if err := publish(item); err != nil {
+			return nil, err
+		}
+	}
+
+	sequence++
+	if err := publish(messaging.NewSnapshotMarkerEvent(messaging.SnapshotCompleted, snapshotId, sequence, total, capturedAt)); err != nil {
+		return nil, err
+	}
+
+	log.Infof("Exported snapshot %s with %d items", snapshotId, total)
+
+	return &SnapshotResponse{
+		SnapshotId:      snapshotId,
+		CapturedAt:      snapshot.CapturedAt,
+		WorkingPeriods:  len(snapshot.WorkingPeriods),
+		ScheduledEvents: len(snapshot.ScheduledEvents),
+		Bookings:        len(snapshot.Bookings),
+		TotalItems:      total,
+	}, nil
+}
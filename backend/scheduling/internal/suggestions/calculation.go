@@ -0,0 +1,189 @@
+package suggestions
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+// candidateStep is the granularity candidate start times are laid out on without a slot policy alignment
+const candidateStep = 30 * time.Minute
+
+// Local hours considered convenient for a session, with a tolerated margin on both sides
+const (
+	convenientFrom = 9 * time.Hour
+	convenientTo   = 20 * time.Hour
+	toleratedFrom  = 7 * time.Hour
+	toleratedTo    = 22 * time.Hour
+)
+
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+type candidate struct {
+	start    time.Time
+	end      time.Time
+	periodId int64
+}
+
+// timezoneGroup holds the number of participants sharing a time zone
+type timezoneGroup struct {
+	name     string
+	loc      *time.Location
+	students int
+}
+
+// candidates lays free slots of the given duration over the working periods, on the given step, leaving
+// out anything before 'from', after 'to' or overlapping a busy interval
+func candidates(periods []*entities.WorkingPeriod, busy []interval, from, to time.Time, duration, step time.Duration) []candidate {
+	var result []candidate
+	for _, p := range periods {
+		start := p.StartTime
+		if start.Before(from) {
+			start = from
+		}
+		start = start.Truncate(step)
+		if start.Before(p.StartTime) || start.Before(from) {
+			start = start.Add(step)
+		}
+
+		end := p.EndTime
+		if end.After(to) {
+			end = to
+		}
+
+		for ; !start.Add(duration).After(end); start = start.Add(step) {
+			slotEnd := start.Add(duration)
+			if !overlapsAny(start, slotEnd, busy) {
+				result = append(result, candidate{start: start, end: slotEnd, periodId: p.Id})
+			}
+		}
+	}
+	return result
+}
+
+func overlapsAny(start, end time.Time, busy []interval) bool {
+	for _, b := range busy {
+		if timeutils.IsOverlapping(start, end, b.start, b.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// convenience scores a session in a time zone: 1 when it lies within convenient local hours, 0.5 when
+// it only lies within the tolerated ones and 0 otherwise
+func convenience(start, end time.Time, loc *time.Location) float64 {
+	localStart := start.In(loc)
+	day := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, loc)
+	from := localStart.Sub(day)
+	to := from + end.Sub(start)
+
+	switch {
+	case from >= convenientFrom && to <= convenientTo:
+		return 1
+	case from >= toleratedFrom && to <= toleratedTo:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// rank scores every candidate against the participants' time zones and returns the best ones. Candidates
+// are ordered by the convenience of the worst-off time zone first, so that no group of students is left
+// with a night session while others get a perfect one, then by the average convenience per student and
+// finally by start time.
+func rank(slots []candidate, groups []*timezoneGroup, take int) []*SuggestedTimeSlot {
+	students := 0
+	for _, g := range groups {
+		students += g.students
+	}
+
+	result := make([]*SuggestedTimeSlot, 0, len(slots))
+	for _, c := range slots {
+		slot := &SuggestedTimeSlot{
+			StartTime:       c.start,
+			EndTime:         c.end,
+			WorkingPeriodId: c.periodId,
+			Score:           1,
+			WorstScore:      1,
+			Timezones:       make([]*TimezoneFitResult, 0, len(groups)),
+		}
+
+		total := 0.0
+		for _, g := range groups {
+			score := convenience(c.start, c.end, g.loc)
+			total += score * float64(g.students)
+			slot.WorstScore = math.Min(slot.WorstScore, score)
+			slot.Timezones = append(slot.Timezones, &TimezoneFitResult{
+				Timezone:    g.name,
+				Students:    g.students,
+				LocalStart:  c.start.In(g.loc).Format(time.DateTime),
+				LocalEnd:    c.end.In(g.loc).Format(time.DateTime),
+				Convenience: score,
+			})
+		}
+		if students > 0 {
+			slot.Score = math.Round(total/float64(students)*100) / 100
+		}
+		result = append(result, slot)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].WorstScore != result[j].WorstScore {
+			return result[i].WorstScore > result[j].WorstScore
+		}
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+
+	if len(result) > take {
+		result = result[:take]
+	}
+	return result
+}
+
+// groupTimezones counts participants per time zone; unknown or invalid zones are reported separately
+func groupTimezones(students []uuid.UUID, timezones map[uuid.UUID]string) ([]*timezoneGroup, int) {
+	groups := make(map[string]*timezoneGroup)
+	unknown := 0
+	for _, id := range students {
+		name, ok := timezones[id]
+		if !ok || name == "" {
+			unknown++
+			continue
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			unknown++
+			continue
+		}
+		g, ok := groups[name]
+		if !ok {
+			g = &timezoneGroup{name: name, loc: loc}
+			groups[name] = g
+		}
+		g.students++
+	}
+
+	result := make([]*timezoneGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].students != result[j].students {
+			return result[i].students > result[j].students
+		}
+		return result[i].name < result[j].name
+	})
+	return result, unknown
+}
@@ -0,0 +1,92 @@
+package suggestions
+
+import (
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+const (
+	defaultTake     = 5
+	maxTake         = 20
+	maxRangeDays    = 31
+	maxDurationMins = 8 * 60
+)
+
+// swagger:model GroupSessionSuggestionRequest
+type GroupSessionSuggestionRequest struct {
+	ProductId       int64     `json:"productId"`
+	DurationMinutes int       `json:"durationMinutes"`
+	FromDate        time.Time `json:"fromDate"`
+	ToDate          time.Time `json:"toDate"`
+	Take            int       `json:"take"`
+}
+
+// swagger:model GroupSessionSuggestionsResponse
+type GroupSessionSuggestionsResponse struct {
+	Participants     int                  `json:"participants"`
+	UnknownTimezones int                  `json:"unknownTimezones"`
+	Suggestions      []*SuggestedTimeSlot `json:"suggestions"`
+}
+
+// swagger:model SuggestedTimeSlot
+type SuggestedTimeSlot struct {
+	StartTime       time.Time            `json:"startTime"`
+	EndTime         time.Time            `json:"endTime"`
+	WorkingPeriodId int64                `json:"workingPeriodId"`
+	Score           float64              `json:"score"`
+	WorstScore      float64              `json:"worstScore"`
+	Timezones       []*TimezoneFitResult `json:"timezones"`
+}
+
+// swagger:model TimezoneFitResult
+type TimezoneFitResult struct {
+	Timezone    string  `json:"timezone"`
+	Students    int     `json:"students"`
+	LocalStart  string  `json:"localStart"`
+	LocalEnd    string  `json:"localEnd"`
+	Convenience float64 `json:"convenience"`
+}
+
+func (g *GroupSessionSuggestionRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if g.ProductId <= 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "ProductId",
+			Message: "must be a positive number",
+		})
+	}
+
+	if g.DurationMinutes <= 0 || g.DurationMinutes > maxDurationMins {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "DurationMinutes",
+			Message: "must be between 1 and 480",
+		})
+	}
+
+	if !g.FromDate.Before(g.ToDate) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "FromDate",
+			Message: "must be before ToDate",
+		})
+	} else if g.ToDate.Sub(g.FromDate) > maxRangeDays*24*time.Hour {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "ToDate",
+			Message: "must be within 31 days of FromDate",
+		})
+	}
+
+	if g.Take < 1 || g.Take > maxTake {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Take",
+			Message: "must be between 1 and 20",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Group session suggestion request failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
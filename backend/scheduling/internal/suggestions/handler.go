@@ -0,0 +1,85 @@
+package suggestions
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type SuggestionHandler struct {
+	service *SuggestionService
+}
+
+func NewSuggestionHandler(service *SuggestionService) *SuggestionHandler {
+	return &SuggestionHandler{service: service}
+}
+
+// SuggestGroupSessionTimes proposes times for a group session of the current educator.
+// @Summary      Suggest group session times
+// @Description  Scores free times within the educator's working periods by local-time convenience for the students booked on the product's group sessions, and returns the fairest options first: by the convenience of the worst-off time zone, then by the average per student. Under a slot policy the duration must be one it allows and times start on its alignment.
+// @Tags         Suggestion
+// @Accept       json
+// @Produce      json
+// @Param        productId        query     int     true   "Product ID"
+// @Param        durationMinutes  query     int     true   "Session duration in minutes"
+// @Param        fromDate         query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate           query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        take             query     int     false  "Number of suggestions to return"
+// @Success      200              {object}  GroupSessionSuggestionsResponse  "Suggested times"
+// @Failure      400              {object}  error                            "Invalid input parameters"
+// @Failure      422              {object}  error                            "Duration not allowed by the slot policy"
+// @Router       /api/v1/suggestions/group-sessions [get]
+// @Security 	 BearerAuth
+func (h *SuggestionHandler) SuggestGroupSessionTimes(w http.ResponseWriter, r *http.Request) {
+	productId, err := api.ParseLongQuery(w, r, "productId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	duration, err := api.ParseIntQuery(w, r, "durationMinutes", 0)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	fromDate, err := api.ParseTimeQuery(w, r, "fromDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	toDate, err := api.ParseTimeQuery(w, r, "toDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	take, err := api.ParseIntQuery(w, r, "take", defaultTake)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	request := &GroupSessionSuggestionRequest{
+		ProductId:       productId,
+		DurationMinutes: duration,
+		FromDate:        fromDate,
+		ToDate:          toDate,
+		Take:            take,
+	}
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	response, err := h.service.SuggestGroupSessionTimes(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, response)
+}
@@ -0,0 +1,9 @@
+package suggestions
+
+func MapBusyIntervals(busy []*BusyInterval) []interval {
+	result := make([]interval, 0, len(busy))
+	for _, b := range busy {
+		result = append(result, interval{start: b.StartTime, end: b.EndTime})
+	}
+	return result
+}
@@ -0,0 +1,20 @@
+package suggestions
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeSuggestionService(log logger.Logger, db *sqlx.DB, granularity SlotGranularityProvider) *SuggestionService {
+	repo := NewSuggestionRepository(db)
+	service := NewSuggestionService(log, repo, granularity)
+	return service
+}
+
+func InitializeSuggestionHTTPHandler(service *SuggestionService) http.Handler {
+	handler := NewSuggestionHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,77 @@
+package suggestions
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// StudentTimezone holds the time zone a student set in their notification preferences
+type StudentTimezone struct {
+	UserId   uuid.UUID `db:"user_id"`
+	Timezone string    `db:"timezone"`
+}
+
+// BusyInterval holds the time taken by a booking or a scheduled event
+type BusyInterval struct {
+	StartTime time.Time `db:"start_time"`
+	EndTime   time.Time `db:"end_time"`
+}
+
+type SuggestionRepo struct {
+	db *sqlx.DB
+}
+
+func NewSuggestionRepository(db *sqlx.DB) *SuggestionRepo {
+	return &SuggestionRepo{db: db}
+}
+
+// GetGroupStudents retrieves students with active bookings on the educator's group sessions of a product
+func (r *SuggestionRepo) GetGroupStudents(ctx context.Context, educatorId uuid.UUID, productId int64) ([]uuid.UUID, error) {
+	const query = `
+		SELECT DISTINCT student_id
+		FROM booking
+		WHERE educator_id = $1 AND product_id = $2 AND scheduled_event_id IS NOT NULL AND status <> $3
+	`
+	var students []uuid.UUID
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &students, query, educatorId, productId, entities.Cancelled); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return students, nil
+}
+
+// GetTimezones retrieves the time zones of the given users
+func (r *SuggestionRepo) GetTimezones(ctx context.Context, userIds []uuid.UUID) ([]*StudentTimezone, error) {
+	const query = `SELECT user_id, timezone FROM notification_preference WHERE user_id = ANY($1)`
+	return database.FetchMultiple[StudentTimezone](ctx, r.db, query, pq.Array(userIds))
+}
+
+// GetWorkingPeriods retrieves working periods of an educator overlapping a range
+func (r *SuggestionRepo) GetWorkingPeriods(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.WorkingPeriod, error) {
+	const query = `
+		SELECT id, user_id, start_time, end_time, created_at, updated_at
+		FROM working_period
+		WHERE user_id = $1 AND start_time < $3 AND end_time > $2 AND deleted_at IS NULL
+		ORDER BY start_time
+	`
+	return database.FetchMultiple[entities.WorkingPeriod](ctx, r.db, query, educatorId, from, to)
+}
+
+// GetBusyIntervals retrieves active bookings and scheduled events of an educator overlapping a range
+func (r *SuggestionRepo) GetBusyIntervals(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*BusyInterval, error) {
+	const query = `
+		SELECT start_time, end_time FROM booking
+		WHERE educator_id = $1 AND status <> $4 AND start_time < $3 AND end_time > $2
+		UNION ALL
+		SELECT start_time, end_time FROM scheduled_event
+		WHERE user_id = $1 AND start_time < $3 AND end_time > $2 AND deleted_at IS NULL
+	`
+	return database.FetchMultiple[BusyInterval](ctx, r.db, query, educatorId, from, to, entities.Cancelled)
+}
@@ -0,0 +1,20 @@
+package suggestions
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *SuggestionHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.EducatorRole))
+	r.Get("/group-sessions", handler.SuggestGroupSessionTimes)
+
+	return r
+}
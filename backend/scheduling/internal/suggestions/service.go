@@ -0,0 +1,109 @@
+package suggestions
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/timeutils"
+)
+
+type SuggestionRepository interface {
+	GetGroupStudents(ctx context.Context, educatorId uuid.UUID, productId int64) ([]uuid.UUID, error)
+	GetTimezones(ctx context.Context, userIds []uuid.UUID) ([]*StudentTimezone, error)
+	GetWorkingPeriods(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*entities.WorkingPeriod, error)
+	GetBusyIntervals(ctx context.Context, educatorId uuid.UUID, from, to time.Time) ([]*BusyInterval, error)
+}
+
+// SlotGranularityProvider returns the session granularity set by the platform slot policy
+type SlotGranularityProvider interface {
+	SlotGranularity(ctx context.Context) (timeutils.SlotGranularity, error)
+}
+
+type SuggestionService struct {
+	log         logger.Logger
+	repo        SuggestionRepository
+	granularity SlotGranularityProvider
+}
+
+func NewSuggestionService(log logger.Logger, repo SuggestionRepository, granularity SlotGranularityProvider) *SuggestionService {
+	return &SuggestionService{log: log, repo: repo, granularity: granularity}
+}
+
+// SuggestGroupSessionTimes proposes free times within the educator's working periods for the next group
+// session of a product, scored by how convenient they are in the time zones of the students booked on
+// the product's group sessions. Students without a known time zone are counted but not scored.
+func (s *SuggestionService) SuggestGroupSessionTimes(ctx context.Context, request *GroupSessionSuggestionRequest) (*GroupSessionSuggestionsResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	from := request.FromDate.UTC()
+	if now := time.Now().UTC(); from.Before(now) {
+		from = now
+	}
+	to := request.ToDate.UTC()
+
+	granularity, err := s.granularity.SlotGranularity(ctx)
+	if err != nil {
+		log.Error("failed to get slot policy", err)
+		return nil, err
+	}
+	duration := time.Duration(request.DurationMinutes) * time.Minute
+	if reason := granularity.DurationViolation(duration); reason != "" {
+		return nil, apperrors.NewUnprocessedEntity(reason, apperrors.ErrSlotGranularity)
+	}
+
+	students, err := s.repo.GetGroupStudents(ctx, userId, request.ProductId)
+	if err != nil {
+		log.Error("failed to get group students", err)
+		return nil, err
+	}
+
+	timezones := make(map[uuid.UUID]string, len(students))
+	if len(students) > 0 {
+		preferences, err := s.repo.GetTimezones(ctx, students)
+		if err != nil {
+			log.Error("failed to get student time zones", err)
+			return nil, err
+		}
+		for _, p := range preferences {
+			timezones[p.UserId] = p.Timezone
+		}
+	}
+	groups, unknown := groupTimezones(students, timezones)
+
+	response := &GroupSessionSuggestionsResponse{
+		Participants:     len(students),
+		UnknownTimezones: unknown,
+		Suggestions:      []*SuggestedTimeSlot{},
+	}
+	if !from.Before(to) {
+		return response, nil
+	}
+
+	periods, err := s.repo.GetWorkingPeriods(ctx, userId, from, to)
+	if err != nil {
+		log.Error("failed to get working periods", err)
+		return nil, err
+	}
+
+	busy, err := s.repo.GetBusyIntervals(ctx, userId, from, to)
+	if err != nil {
+		log.Error("failed to get busy intervals", err)
+		return nil, err
+	}
+
+	slots := candidates(periods, MapBusyIntervals(busy), from, to, duration, granularity.Step(candidateStep))
+	response.Suggestions = rank(slots, groups, request.Take)
+
+	return response, nil
+}
@@ -0,0 +1,108 @@
+package taskrunner
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// taskTimeout bounds a background task, so a hung dependency can not hold up shutdown for good
+const taskTimeout = time.Minute
+
+// Task is fire-and-forget work spawned while serving a request
+type Task func(ctx context.Context) error
+
+// Runner runs tasks in background goroutines in place of naked go statements. A task keeps the trace,
+// logger, request id and caller of the request that spawned it, but neither its cancellation nor its
+// transaction, both of which end with the request. Panics are recovered and logged, and Shutdown waits
+// for the tasks still running.
+type Runner struct {
+	log    logger.Logger
+	tracer trace.Tracer
+
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	stopped bool
+}
+
+func NewRunner(log logger.Logger, tracer trace.Tracer) *Runner {
+	return &Runner{log: log, tracer: tracer}
+}
+
+// Go runs a task in the background. Once Shutdown started the task runs right away in the caller's
+// goroutine instead, so work spawned by the last requests is not lost.
+func (r *Runner) Go(ctx context.Context, name string, task Task) {
+	taskCtx := Detach(ctx)
+
+	r.mu.Lock()
+	if r.stopped {
+		r.mu.Unlock()
+		r.run(taskCtx, name, task)
+		return
+	}
+	r.wg.Add(1)
+	r.mu.Unlock()
+
+	go func() {
+		defer r.wg.Done()
+		r.run(taskCtx, name, task)
+	}()
+}
+
+// Shutdown stops accepting background tasks and waits for the running ones until ctx is done
+func (r *Runner) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("background tasks still running: %w", ctx.Err())
+	}
+}
+
+func (r *Runner) run(ctx context.Context, name string, task Task) {
+	ctx, cancel := context.WithTimeout(ctx, taskTimeout)
+	defer cancel()
+
+	ctx, span := r.tracer.Start(ctx, "task "+name, trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+
+	log := logger.FromContext(ctx, r.log).With(logger.Field{Key: "task", Value: name})
+	ctx = logger.WithLogger(ctx, log)
+
+	defer func() {
+		if p := recover(); p != nil {
+			span.SetStatus(codes.Error, "panic")
+			log.Errorf("Panic in background task %s: %v\n%s", name, p, debug.Stack())
+		}
+	}()
+
+	if err := task(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Errorf("Background task %s failed: %v", name, err)
+	}
+}
+
+// Detach returns a context carrying the values of ctx, such as its span, logger, request id and caller,
+// that is neither cancelled with ctx nor runs queries in its request transaction
+func Detach(ctx context.Context) context.Context {
+	return database.WithoutTx(context.WithoutCancel(ctx))
+}
@@ -0,0 +1,50 @@
+package taxes
+
+import (
+	"math"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// calculateTax applies the educator's jurisdiction rule to a net amount. The charge is reverse charged,
+// i.e. no tax is collected, when the rule allows it and the buyer is a registered business from another country.
+func calculateTax(amount float64, seller *entities.TaxProfile, buyer *entities.TaxProfile, rule *entities.TaxRule) *TaxBreakdown {
+	net := roundAmount(amount)
+	breakdown := &TaxBreakdown{NetAmount: net, GrossAmount: net}
+
+	if seller == nil {
+		return breakdown
+	}
+	breakdown.CountryCode = &seller.CountryCode
+
+	if rule == nil {
+		return breakdown
+	}
+	breakdown.TaxName = &rule.TaxName
+	breakdown.RatePercent = rule.RatePercent
+
+	if rule.ReverseCharge && buyer != nil && buyer.TaxId != nil && buyer.CountryCode != seller.CountryCode {
+		breakdown.ReverseCharge = true
+		return breakdown
+	}
+
+	breakdown.TaxAmount = roundAmount(net * rule.RatePercent / 100)
+	breakdown.GrossAmount = roundAmount(net + breakdown.TaxAmount)
+	return breakdown
+}
+
+func isCountryCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
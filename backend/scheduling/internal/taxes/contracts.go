@@ -0,0 +1,97 @@
+package taxes
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// swagger:model TaxBreakdown
+type TaxBreakdown struct {
+	CountryCode   *string `json:"countryCode"`
+	TaxName       *string `json:"taxName"`
+	RatePercent   float64 `json:"ratePercent"`
+	ReverseCharge bool    `json:"reverseCharge"`
+	NetAmount     float64 `json:"netAmount"`
+	TaxAmount     float64 `json:"taxAmount"`
+	GrossAmount   float64 `json:"grossAmount"`
+}
+
+// swagger:model TaxRuleResponse
+type TaxRuleResponse struct {
+	CountryCode   string    `json:"countryCode"`
+	TaxName       string    `json:"taxName"`
+	RatePercent   float64   `json:"ratePercent"`
+	ReverseCharge bool      `json:"reverseCharge"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// swagger:model TaxRuleRequest
+type TaxRuleRequest struct {
+	TaxName       string  `json:"taxName"`
+	RatePercent   float64 `json:"ratePercent"`
+	ReverseCharge bool    `json:"reverseCharge"`
+}
+
+// swagger:model TaxProfileResponse
+type TaxProfileResponse struct {
+	UserId      uuid.UUID `json:"userId"`
+	CountryCode string    `json:"countryCode"`
+	TaxId       *string   `json:"taxId"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// swagger:model TaxProfileRequest
+type TaxProfileRequest struct {
+	CountryCode string  `json:"countryCode"`
+	TaxId       *string `json:"taxId"`
+}
+
+func (t *TaxRuleRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if t.TaxName == "" {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "TaxName",
+			Message: "must not be empty",
+		})
+	}
+
+	if t.RatePercent < 0 || t.RatePercent > 100 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "RatePercent",
+			Message: "must be between 0 and 100",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Tax rule request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
+
+func (t *TaxProfileRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if !isCountryCode(t.CountryCode) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "CountryCode",
+			Message: "must be an ISO 3166-1 alpha-2 code",
+		})
+	}
+
+	if t.TaxId != nil && *t.TaxId == "" {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "TaxId",
+			Message: "must not be empty when provided",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Tax profile request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package taxes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type TaxHandler struct {
+	service *TaxService
+}
+
+func NewTaxHandler(service *TaxService) *TaxHandler {
+	return &TaxHandler{service: service}
+}
+
+// GetMyTaxProfile retrieves the tax profile of the current user.
+// @Summary      Retrieve tax profile
+// @Description  Retrieves the country and optional tax registration ID used to determine the user's tax jurisdiction.
+// @Tags         Tax
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  TaxProfileResponse  "Tax profile"
+// @Failure      404  {object}  error               "Tax profile not found"
+// @Router       /api/v1/taxes/profile [get]
+// @Security 	 BearerAuth
+func (h *TaxHandler) GetMyTaxProfile(w http.ResponseWriter, r *http.Request) {
+	profile, err := h.service.GetMyTaxProfile(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, profile)
+}
+
+// SetMyTaxProfile sets the tax profile of the current user.
+// @Summary      Set tax profile
+// @Description  Creates or replaces the user's tax country and registration ID. Educators are taxed by their country; buyers with a registration ID may be reverse charged.
+// @Tags         Tax
+// @Accept       json
+// @Produce      json
+// @Param        profile  body      TaxProfileRequest  true  "Tax profile"
+// @Success      204      "Tax profile saved successfully"
+// @Failure      400      {object}  error              "Invalid input"
+// @Router       /api/v1/taxes/profile [put]
+// @Security 	 BearerAuth
+func (h *TaxHandler) SetMyTaxProfile(w http.ResponseWriter, r *http.Request) {
+	var request *TaxProfileRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.SetMyTaxProfile(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetTaxRules retrieves all configured tax rules.
+// @Summary      Retrieve tax rules
+// @Description  Retrieves VAT/GST rules configured per country.
+// @Tags         Tax
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   TaxRuleResponse  "Tax rules"
+// @Router       /api/v1/taxes/rules [get]
+// @Security 	 BearerAuth
+func (h *TaxHandler) GetTaxRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.GetTaxRules(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, rules)
+}
+
+// SetTaxRule sets the tax rule of a country.
+// @Summary      Set tax rule
+// @Description  Creates or replaces the tax rate applied to educators from the country and whether reverse charge applies to cross-border business buyers.
+// @Tags         Tax
+// @Accept       json
+// @Produce      json
+// @Param        countryCode  path      string          true  "ISO 3166-1 alpha-2 country code"
+// @Param        rule         body      TaxRuleRequest  true  "Tax rule"
+// @Success      204          "Tax rule saved successfully"
+// @Failure      400          {object}  error           "Invalid input"
+// @Router       /api/v1/taxes/rules/{countryCode} [put]
+// @Security 	 BearerAuth
+func (h *TaxHandler) SetTaxRule(w http.ResponseWriter, r *http.Request) {
+	countryCode := chi.URLParam(r, "countryCode")
+
+	var request *TaxRuleRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.SetTaxRule(r.Context(), countryCode, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
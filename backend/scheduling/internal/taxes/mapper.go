@@ -0,0 +1,61 @@
+package taxes
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapTaxRuleToResponse(t *entities.TaxRule) *TaxRuleResponse {
+	return &TaxRuleResponse{
+		CountryCode:   t.CountryCode,
+		TaxName:       t.TaxName,
+		RatePercent:   t.RatePercent,
+		ReverseCharge: t.ReverseCharge,
+		UpdatedAt:     t.UpdatedAt,
+	}
+}
+
+func MapTaxRulesToResponse(ts []*entities.TaxRule) []*TaxRuleResponse {
+	if len(ts) == 0 {
+		return []*TaxRuleResponse{}
+	}
+
+	response := make([]*TaxRuleResponse, len(ts))
+	for i, t := range ts {
+		response[i] = MapTaxRuleToResponse(t)
+	}
+	return response
+}
+
+func MapTaxProfileToResponse(p *entities.TaxProfile) *TaxProfileResponse {
+	return &TaxProfileResponse{
+		UserId:      p.UserId,
+		CountryCode: p.CountryCode,
+		TaxId:       p.TaxId,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+func MapRequestToTaxRule(countryCode string, r *TaxRuleRequest) *entities.TaxRule {
+	return &entities.TaxRule{
+		CountryCode:   countryCode,
+		TaxName:       r.TaxName,
+		RatePercent:   r.RatePercent,
+		ReverseCharge: r.ReverseCharge,
+		CreatedAt:     time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+	}
+}
+
+func MapRequestToTaxProfile(userId uuid.UUID, r *TaxProfileRequest) *entities.TaxProfile {
+	return &entities.TaxProfile{
+		UserId:      userId,
+		CountryCode: r.CountryCode,
+		TaxId:       r.TaxId,
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	}
+}
@@ -0,0 +1,20 @@
+package taxes
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeTaxService(log logger.Logger, db *sqlx.DB) *TaxService {
+	repo := NewTaxRepository(db)
+	service := NewTaxService(log, repo)
+	return service
+}
+
+func InitializeTaxHTTPHandler(service *TaxService) http.Handler {
+	handler := NewTaxHandler(service)
+	return Routes(handler)
+}
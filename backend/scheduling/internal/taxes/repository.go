@@ -0,0 +1,72 @@
+package taxes
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type TaxRepo struct {
+	db *sqlx.DB
+}
+
+func NewTaxRepository(db *sqlx.DB) *TaxRepo {
+	return &TaxRepo{db: db}
+}
+
+// GetAllTaxRules retrieves every configured tax rule ordered by country
+func (r *TaxRepo) GetAllTaxRules(ctx context.Context) ([]*entities.TaxRule, error) {
+	const query = `
+		SELECT country_code, tax_name, rate_percent, reverse_charge, created_at, updated_at
+		FROM tax_rule
+		ORDER BY country_code
+	`
+	return database.FetchMultiple[entities.TaxRule](ctx, r.db, query)
+}
+
+// GetTaxRules retrieves tax rules for the given countries
+func (r *TaxRepo) GetTaxRules(ctx context.Context, countryCodes []string) ([]*entities.TaxRule, error) {
+	const query = `
+		SELECT country_code, tax_name, rate_percent, reverse_charge, created_at, updated_at
+		FROM tax_rule
+		WHERE country_code = ANY($1)
+	`
+	return database.FetchMultiple[entities.TaxRule](ctx, r.db, query, pq.Array(countryCodes))
+}
+
+// GetTaxProfiles retrieves tax profiles of the given users
+func (r *TaxRepo) GetTaxProfiles(ctx context.Context, userIds []uuid.UUID) ([]*entities.TaxProfile, error) {
+	const query = `
+		SELECT user_id, country_code, tax_id, created_at, updated_at
+		FROM tax_profile
+		WHERE user_id = ANY($1)
+	`
+	return database.FetchMultiple[entities.TaxProfile](ctx, r.db, query, pq.Array(userIds))
+}
+
+// UpsertTaxRule creates or replaces the tax rule of a country
+func (r *TaxRepo) UpsertTaxRule(ctx context.Context, rule *entities.TaxRule) error {
+	const query = `
+		INSERT INTO tax_rule (country_code, tax_name, rate_percent, reverse_charge, created_at, updated_at)
+		VALUES (:country_code, :tax_name, :rate_percent, :reverse_charge, :created_at, :updated_at)
+		ON CONFLICT (country_code) DO UPDATE
+		SET tax_name = EXCLUDED.tax_name, rate_percent = EXCLUDED.rate_percent, reverse_charge = EXCLUDED.reverse_charge, updated_at = EXCLUDED.updated_at
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, rule)
+}
+
+// UpsertTaxProfile creates or replaces the tax profile of a user
+func (r *TaxRepo) UpsertTaxProfile(ctx context.Context, profile *entities.TaxProfile) error {
+	const query = `
+		INSERT INTO tax_profile (user_id, country_code, tax_id, created_at, updated_at)
+		VALUES (:user_id, :country_code, :tax_id, :created_at, :updated_at)
+		ON CONFLICT (user_id) DO UPDATE
+		SET country_code = EXCLUDED.country_code, tax_id = EXCLUDED.tax_id, updated_at = EXCLUDED.updated_at
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, profile)
+}
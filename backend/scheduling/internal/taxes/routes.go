@@ -0,0 +1,22 @@
+package taxes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *TaxHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/profile", handler.GetMyTaxProfile)
+	r.Put("/profile", handler.SetMyTaxProfile)
+	r.With(middleware.RequireRole(auth.AdminRole)).Get("/rules", handler.GetTaxRules)
+	r.With(middleware.RequireRole(auth.AdminRole)).Put("/rules/{countryCode}", handler.SetTaxRule)
+
+	return r
+}
@@ -0,0 +1,131 @@
+package taxes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type TaxRepository interface {
+	GetAllTaxRules(ctx context.Context) ([]*entities.TaxRule, error)
+	GetTaxRules(ctx context.Context, countryCodes []string) ([]*entities.TaxRule, error)
+	GetTaxProfiles(ctx context.Context, userIds []uuid.UUID) ([]*entities.TaxProfile, error)
+	UpsertTaxRule(ctx context.Context, rule *entities.TaxRule) error
+	UpsertTaxProfile(ctx context.Context, profile *entities.TaxProfile) error
+}
+
+type TaxService struct {
+	log  logger.Logger
+	repo TaxRepository
+}
+
+func NewTaxService(log logger.Logger, repo TaxRepository) *TaxService {
+	return &TaxService{log: log, repo: repo}
+}
+
+// CalculateTax computes the tax breakdown of a net amount sold by an educator to a buyer. Educators without
+// a tax profile, or whose country has no configured rule, are not charged any tax.
+func (s *TaxService) CalculateTax(ctx context.Context, educatorId uuid.UUID, buyerId uuid.UUID, amount float64) (*TaxBreakdown, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	profiles, err := s.repo.GetTaxProfiles(ctx, []uuid.UUID{educatorId, buyerId})
+	if err != nil {
+		log.Error("failed to get tax profiles", err)
+		return nil, err
+	}
+
+	var seller, buyer *entities.TaxProfile
+	for _, p := range profiles {
+		switch p.UserId {
+		case educatorId:
+			seller = p
+		case buyerId:
+			buyer = p
+		}
+	}
+
+	var rule *entities.TaxRule
+	if seller != nil {
+		rules, err := s.repo.GetTaxRules(ctx, []string{seller.CountryCode})
+		if err != nil {
+			log.Error("failed to get tax rules", err)
+			return nil, err
+		}
+		if len(rules) > 0 {
+			rule = rules[0]
+		}
+	}
+
+	return calculateTax(amount, seller, buyer, rule), nil
+}
+
+func (s *TaxService) GetTaxRules(ctx context.Context) ([]*TaxRuleResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	rules, err := s.repo.GetAllTaxRules(ctx)
+	if err != nil {
+		log.Error("failed to get tax rules", err)
+		return nil, err
+	}
+
+	return MapTaxRulesToResponse(rules), nil
+}
+
+func (s *TaxService) SetTaxRule(ctx context.Context, countryCode string, request *TaxRuleRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	countryCode = strings.ToUpper(countryCode)
+	if !isCountryCode(countryCode) {
+		return apperrors.NewBadRequestError("Invalid country code", apperrors.ErrParameterInvalid)
+	}
+
+	if err := s.repo.UpsertTaxRule(ctx, MapRequestToTaxRule(countryCode, request)); err != nil {
+		log.Error("failed to save tax rule", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *TaxService) GetMyTaxProfile(ctx context.Context) (*TaxProfileResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	profiles, err := s.repo.GetTaxProfiles(ctx, []uuid.UUID{userId})
+	if err != nil {
+		log.Error("failed to get tax profile", err)
+		return nil, err
+	}
+
+	if len(profiles) == 0 {
+		return nil, apperrors.NewNotFound("Tax profile not found", apperrors.ErrResourceNotFound)
+	}
+
+	return MapTaxProfileToResponse(profiles[0]), nil
+}
+
+func (s *TaxService) SetMyTaxProfile(ctx context.Context, request *TaxProfileRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if err := s.repo.UpsertTaxProfile(ctx, MapRequestToTaxProfile(userId, request)); err != nil {
+		log.Error("failed to save tax profile", err)
+		return err
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -22,6 +23,10 @@ type Telemetry struct {
 	Logger        *logger.AppLogger
 	Tracer        trace.Tracer
 	Meter         metric.Meter
+	// MetricsHandler serves the Prometheus scrape endpoint, nil when Prometheus metrics are disabled
+	MetricsHandler http.Handler
+	// Sampler decides which traces are recorded and can be adjusted at runtime, nil when tracing is disabled
+	Sampler *Sampler
 }
 
 func parseEndpoint(endpoint string) (string, error) {
@@ -58,7 +63,8 @@ func initConn(endpoint string) (*grpc.ClientConn, error) {
 func Init(ctx context.Context, cfg config.Config) (*Telemetry, error) {
 	tel := &Telemetry{}
 
-	if !cfg.Telemetry.EnableOtelLogging && !cfg.Telemetry.EnableOtelMetrics && !cfg.Telemetry.EnableOtelTracing {
+	exportsOtel := cfg.Telemetry.EnableOtelLogging || cfg.Telemetry.EnableOtelMetrics || cfg.Telemetry.EnableOtelTracing
+	if !exportsOtel && !cfg.Telemetry.EnablePrometheusMetrics {
 		log, err := logger.NewAppLogger(cfg.Log, nil)
 		if err != nil {
 			return tel, err
@@ -67,9 +73,13 @@ func Init(ctx context.Context, cfg config.Config) (*Telemetry, error) {
 		return tel, nil
 	}
 
-	conn, err := initConn(cfg.Telemetry.OtelEndpoint)
-	if err != nil {
-		return nil, err
+	var conn *grpc.ClientConn
+	if exportsOtel {
+		c, err := initConn(cfg.Telemetry.OtelEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		conn = c
 	}
 
 	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.Server.Name)))
@@ -94,7 +104,17 @@ func Init(ctx context.Context, cfg config.Config) (*Telemetry, error) {
 	tel.Logger = log
 
 	if cfg.Telemetry.EnableOtelTracing {
-		tp, err := InitTracerProvider(ctx, res, conn)
+		policy, err := NewSamplingPolicy(&cfg.Telemetry)
+		if err != nil {
+			return nil, err
+		}
+		sampler, err := NewSampler(policy)
+		if err != nil {
+			return nil, err
+		}
+		tel.Sampler = sampler
+
+		tp, err := InitTracerProvider(ctx, res, conn, sampler)
 		if err != nil {
 			return nil, err
 		}
@@ -102,13 +122,19 @@ func Init(ctx context.Context, cfg config.Config) (*Telemetry, error) {
 		tel.Tracer = tp.Tracer(cfg.Server.Name)
 	}
 
-	if cfg.Telemetry.EnableOtelMetrics {
-		mp, err := InitMeterProvider(ctx, res, conn)
+	if cfg.Telemetry.EnableOtelMetrics || cfg.Telemetry.EnablePrometheusMetrics {
+		var metricsConn *grpc.ClientConn
+		if cfg.Telemetry.EnableOtelMetrics {
+			metricsConn = conn
+		}
+
+		mp, handler, err := InitMeterProvider(ctx, res, metricsConn, cfg.Telemetry.EnablePrometheusMetrics)
 		if err != nil {
 			return nil, err
 		}
 		tel.shutdownFuncs = append(tel.shutdownFuncs, mp.Shutdown)
 		tel.Meter = mp.Meter(cfg.Server.Name)
+		tel.MetricsHandler = handler
 	}
 
 	return tel, nil
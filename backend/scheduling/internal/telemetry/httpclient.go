@@ -0,0 +1,115 @@
+package telemetry
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+// correlationIdHeader matches middleware.CorrelationIdHeader, which the telemetry package cannot import
+const correlationIdHeader = "X-Correlation-Id"
+
+// NewHTTPClient creates the shared outbound HTTP client with a tuned connection pool. Requests are traced
+// and recorded in the otelhttp client metrics, which carry the upstream host as server.address, and
+// forward the request and correlation ids of the request being served.
+func NewHTTPClient(cfg *config.HttpClientConfig) (*http.Client, error) {
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       time.Duration(cfg.IdleConnTimeoutSeconds) * time.Second,
+		TLSHandshakeTimeout:   time.Duration(cfg.TLSHandshakeTimeoutSeconds) * time.Second,
+		ExpectContinueTimeout: time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+
+	return &http.Client{
+		Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+		Transport: otelhttp.NewTransport(&correlationTransport{next: transport},
+			otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string { return r.Method + " " + r.URL.Host }),
+			otelhttp.WithMeterProvider(otel.GetMeterProvider()),
+			otelhttp.WithTracerProvider(otel.GetTracerProvider()),
+		),
+	}, nil
+}
+
+// correlationTransport sets the request and correlation ids of ctx on outbound requests that carry none
+type correlationTransport struct {
+	next http.RoundTripper
+}
+
+func (t *correlationTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ids := map[string]string{
+		logger.RequestIdHeader: logger.RequestIdFromContext(r.Context()),
+		correlationIdHeader:    messaging.CorrelationIdFromContext(r.Context()),
+	}
+
+	cloned := false
+	for header, id := range ids {
+		if id == "" || r.Header.Get(header) != "" {
+			continue
+		}
+		// A round tripper must not modify the caller's request
+		if !cloned {
+			r = r.Clone(r.Context())
+			cloned = true
+		}
+		r.Header.Set(header, id)
+	}
+	return t.next.RoundTrip(r)
+}
+
+// newTLSConfig applies the minimum TLS version and trusts the configured CA bundle on top of the system pool
+func newTLSConfig(cfg *config.HttpClientConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	switch cfg.TLSMinVersion {
+	case "1.2":
+		tlsConfig.MinVersion = tls.VersionTLS12
+	case "1.3":
+		tlsConfig.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported TLS min version '%s', expected 1.2 or 1.3", cfg.TLSMinVersion)
+	}
+
+	if cfg.TLSCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(cfg.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA file '%s'", cfg.TLSCAFile)
+	}
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
@@ -3,25 +3,47 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"google.golang.org/grpc"
 )
 
-func InitMeterProvider(ctx context.Context, res *resource.Resource, conn *grpc.ClientConn) (*metric.MeterProvider, error) {
-	me, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithGRPCConn(conn))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create metrics exporter: %w", err)
+// InitMeterProvider creates the meter provider with an OTLP reader when conn is given and a Prometheus reader
+// when enabled. The returned handler serves the Prometheus scrape and is nil unless Prometheus is enabled.
+func InitMeterProvider(ctx context.Context, res *resource.Resource, conn *grpc.ClientConn, enablePrometheus bool) (*metric.MeterProvider, http.Handler, error) {
+	opts := []metric.Option{metric.WithResource(res)}
+
+	if conn != nil {
+		me, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithGRPCConn(conn))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create metrics exporter: %w", err)
+		}
+		opts = append(opts, metric.WithReader(metric.NewPeriodicReader(me)))
+	}
+
+	var handler http.Handler
+	if enablePrometheus {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+		pe, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+		}
+		opts = append(opts, metric.WithReader(pe))
+		handler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 	}
 
-	mp := metric.NewMeterProvider(
-		metric.WithReader(metric.NewPeriodicReader(me)),
-		metric.WithResource(res),
-	)
+	mp := metric.NewMeterProvider(opts...)
 	otel.SetMeterProvider(mp)
 
-	return mp, nil
+	return mp, handler, nil
 }
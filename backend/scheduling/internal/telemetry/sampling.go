@@ -0,0 +1,172 @@
+package telemetry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Sampling strategies of traces started by the service
+const (
+	// SamplerAlways records every trace
+	SamplerAlways = "always"
+	// SamplerNever records no trace
+	SamplerNever = "never"
+	// SamplerRatio records a share of the traces, ignoring the decision of the caller
+	SamplerRatio = "ratio"
+	// SamplerParentRatio follows the decision of the caller and records a share of the traces started here
+	SamplerParentRatio = "parent_ratio"
+	// SamplerTail records every trace and leaves the decision to the collector, hinting the share to keep
+	SamplerTail = "tail"
+)
+
+var localParentSampler = trace.ParentBased(trace.NeverSample())
+
+// tailRatioAttribute carries the configured share to the tail sampling policy of the collector
+const tailRatioAttribute = attribute.Key("sampling.tail_ratio")
+
+// SamplingPolicy is the sampling strategy and the share of traces it records, optionally overridden for
+// routes by path prefix, such as the availability queries that are too costly to trace in full
+type SamplingPolicy struct {
+	Strategy    string
+	Ratio       float64
+	RouteRatios map[string]float64
+}
+
+func (p *SamplingPolicy) validate() error {
+	switch p.Strategy {
+	case SamplerAlways, SamplerNever, SamplerRatio, SamplerParentRatio, SamplerTail:
+	default:
+		return fmt.Errorf("unknown sampling strategy '%s'", p.Strategy)
+	}
+	if p.Ratio < 0 || p.Ratio > 1 {
+		return fmt.Errorf("sampling ratio %g must be between 0 and 1", p.Ratio)
+	}
+	for prefix, ratio := range p.RouteRatios {
+		if !strings.HasPrefix(prefix, "/") {
+			return fmt.Errorf("sampling route '%s' must start with /", prefix)
+		}
+		if ratio < 0 || ratio > 1 {
+			return fmt.Errorf("sampling ratio %g of route '%s' must be between 0 and 1", ratio, prefix)
+		}
+	}
+	return nil
+}
+
+// compiledPolicy holds the samplers of a policy, rebuilt whenever the policy changes
+type compiledPolicy struct {
+	policy   SamplingPolicy
+	base     trace.Sampler
+	routes   []string
+	samplers map[string]trace.Sampler
+}
+
+// Sampler applies a sampling policy that can be replaced while the service runs
+type Sampler struct {
+	current atomic.Pointer[compiledPolicy]
+}
+
+func NewSampler(policy SamplingPolicy) (*Sampler, error) {
+	s := &Sampler{}
+	if err := s.Update(policy); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Policy returns the policy in effect
+func (s *Sampler) Policy() SamplingPolicy {
+	return s.current.Load().policy
+}
+
+// Update replaces the policy for traces started from now on
+func (s *Sampler) Update(policy SamplingPolicy) error {
+	if err := policy.validate(); err != nil {
+		return err
+	}
+
+	compiled := &compiledPolicy{
+		policy:   policy,
+		base:     newStrategySampler(policy.Strategy, policy.Ratio),
+		samplers: make(map[string]trace.Sampler, len(policy.RouteRatios)),
+	}
+	for prefix, ratio := range policy.RouteRatios {
+		compiled.routes = append(compiled.routes, prefix)
+		compiled.samplers[prefix] = newStrategySampler(policy.Strategy, ratio)
+	}
+	// The longest matching prefix wins
+	sort.Slice(compiled.routes, func(i, j int) bool { return len(compiled.routes[i]) > len(compiled.routes[j]) })
+
+	s.current.Store(compiled)
+	return nil
+}
+
+func (s *Sampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	// Spans within the service follow the decision taken for the span that started the local trace,
+	// so a trace is never recorded partially
+	if parent := oteltrace.SpanContextFromContext(p.ParentContext); parent.IsValid() && !parent.IsRemote() {
+		return localParentSampler.ShouldSample(p)
+	}
+
+	compiled := s.current.Load()
+
+	// Server spans are named 'METHOD path', see the span name formatter of the HTTP middleware
+	_, path, _ := strings.Cut(p.Name, " ")
+	for _, prefix := range compiled.routes {
+		if strings.HasPrefix(path, prefix) {
+			return compiled.samplers[prefix].ShouldSample(p)
+		}
+	}
+	return compiled.base.ShouldSample(p)
+}
+
+func (s *Sampler) Description() string {
+	policy := s.Policy()
+	return fmt.Sprintf("Sampler{strategy=%s,ratio=%g,routes=%d}", policy.Strategy, policy.Ratio, len(policy.RouteRatios))
+}
+
+func newStrategySampler(strategy string, ratio float64) trace.Sampler {
+	switch strategy {
+	case SamplerNever:
+		return trace.NeverSample()
+	case SamplerRatio:
+		return trace.TraceIDRatioBased(ratio)
+	case SamplerParentRatio:
+		return trace.ParentBased(trace.TraceIDRatioBased(ratio))
+	case SamplerTail:
+		return &tailHintSampler{ratio: ratio}
+	default:
+		return trace.AlwaysSample()
+	}
+}
+
+// tailHintSampler records every span and tags it with the share the collector should keep
+type tailHintSampler struct {
+	ratio float64
+}
+
+func (s *tailHintSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	result := trace.AlwaysSample().ShouldSample(p)
+	result.Attributes = append(result.Attributes, tailRatioAttribute.Float64(s.ratio))
+	return result
+}
+
+func (s *tailHintSampler) Description() string {
+	return fmt.Sprintf("TailHintSampler{ratio=%g}", s.ratio)
+}
+
+// NewSamplingPolicy builds the sampling policy configured for the environment
+func NewSamplingPolicy(cfg *config.TelemetryConfig) (SamplingPolicy, error) {
+	routes, err := cfg.RouteRatios()
+	if err != nil {
+		return SamplingPolicy{}, err
+	}
+	policy := SamplingPolicy{Strategy: cfg.TraceSampler, Ratio: cfg.TraceSampleRatio, RouteRatios: routes}
+	return policy, policy.validate()
+}
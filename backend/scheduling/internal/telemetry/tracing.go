@@ -12,7 +12,7 @@ import (
 	"google.golang.org/grpc"
 )
 
-func InitTracerProvider(ctx context.Context, res *resource.Resource, conn *grpc.ClientConn) (*trace.TracerProvider, error) {
+func InitTracerProvider(ctx context.Context, res *resource.Resource, conn *grpc.ClientConn, sampler trace.Sampler) (*trace.TracerProvider, error) {
 	te, err := otlptracegrpc.New(ctx, otlptracegrpc.WithGRPCConn(conn))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
@@ -20,7 +20,7 @@ func InitTracerProvider(ctx context.Context, res *resource.Resource, conn *grpc.
 
 	bsp := trace.NewBatchSpanProcessor(te)
 	tp := trace.NewTracerProvider(
-		trace.WithSampler(trace.AlwaysSample()),
+		trace.WithSampler(sampler),
 		trace.WithResource(res),
 		trace.WithSpanProcessor(bsp),
 	)
@@ -0,0 +1,50 @@
+package threads
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// swagger:model MessageRequest
+type MessageRequest struct {
+	Body string `json:"body"`
+}
+
+// swagger:model MessageResponse
+type MessageResponse struct {
+	Id        int64     `json:"id"`
+	BookingId int64     `json:"bookingId"`
+	SenderId  uuid.UUID `json:"senderId"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// swagger:model UnreadThreadResponse
+type UnreadThreadResponse struct {
+	BookingId     int64     `json:"bookingId"`
+	Title         string    `json:"title"`
+	StartTime     time.Time `json:"startTime"`
+	UnreadCount   int       `json:"unreadCount"`
+	LastMessageAt time.Time `json:"lastMessageAt"`
+}
+
+func (m *MessageRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if strings.TrimSpace(m.Body) == "" {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Body",
+			Message: "must not be empty",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Message request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
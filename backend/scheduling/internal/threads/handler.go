@@ -0,0 +1,118 @@
+package threads
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type ThreadHandler struct {
+	service *ThreadService
+}
+
+func NewThreadHandler(service *ThreadService) *ThreadHandler {
+	return &ThreadHandler{service: service}
+}
+
+// GetMessages retrieves the messages of a booking thread.
+// @Summary      Retrieve booking messages
+// @Description  Retrieves messages of the booking thread posted after 'afterId', oldest first, and marks them as read for the current participant.
+// @Tags         Thread
+// @Accept       json
+// @Produce      json
+// @Param        bookingId  path      int  true   "Booking ID"
+// @Param        afterId    query     int  false  "Return messages posted after this message"
+// @Param        take       query     int  false  "Number of messages to return"
+// @Success      200        {array}   MessageResponse  "Messages"
+// @Failure      403        {object}  error            "Access denied"
+// @Router       /api/v1/threads/{bookingId}/messages [get]
+// @Security 	 BearerAuth
+func (h *ThreadHandler) GetMessages(w http.ResponseWriter, r *http.Request) {
+	bookingId, err := api.ParseLongParam(w, r, "bookingId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	afterId, err := api.ParseIntQuery(w, r, "afterId", 0)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	take, err := api.ParseIntQuery(w, r, "take", 50)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	messages, err := h.service.GetMessages(r.Context(), bookingId, int64(afterId), take)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, messages)
+}
+
+// SendMessage posts a message to a booking thread.
+// @Summary      Send booking message
+// @Description  Posts a message from the student or educator of the booking and notifies the other participant. Threads of cancelled bookings and of sessions past the retention period are closed.
+// @Tags         Thread
+// @Accept       json
+// @Produce      json
+// @Param        bookingId  path      int              true  "Booking ID"
+// @Param        message    body      MessageRequest   true  "Message"
+// @Success      201        {object}  MessageResponse  "Posted message"
+// @Failure      422        {object}  error            "Thread is closed"
+// @Router       /api/v1/threads/{bookingId}/messages [post]
+// @Security 	 BearerAuth
+func (h *ThreadHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
+	bookingId, err := api.ParseLongParam(w, r, "bookingId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *MessageRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	message, err := h.service.SendMessage(r.Context(), bookingId, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, message)
+}
+
+// GetMyUnreadThreads retrieves the booking threads with unread messages.
+// @Summary      Retrieve unread threads
+// @Description  Retrieves the threads of the user's bookings with messages from the other participant not read yet, most recent first.
+// @Tags         Thread
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   UnreadThreadResponse  "Unread threads"
+// @Failure      401  {object}  error                 "Unauthorized"
+// @Router       /api/v1/threads/unread [get]
+// @Security 	 BearerAuth
+func (h *ThreadHandler) GetMyUnreadThreads(w http.ResponseWriter, r *http.Request) {
+	threads, err := h.service.GetMyUnreadThreads(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, threads)
+}
@@ -0,0 +1,45 @@
+package threads
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToMessage(bookingId int64, senderId uuid.UUID, request *MessageRequest) *entities.BookingMessage {
+	return &entities.BookingMessage{
+		BookingId: bookingId,
+		SenderId:  senderId,
+		Body:      strings.TrimSpace(request.Body),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func MapMessageToResponse(m *entities.BookingMessage) *MessageResponse {
+	return &MessageResponse{Id: m.Id, BookingId: m.BookingId, SenderId: m.SenderId, Body: m.Body, CreatedAt: m.CreatedAt}
+}
+
+func MapMessagesToResponse(messages []*entities.BookingMessage) []*MessageResponse {
+	items := make([]*MessageResponse, 0, len(messages))
+	for _, m := range messages {
+		items = append(items, MapMessageToResponse(m))
+	}
+	return items
+}
+
+func MapUnreadThreadsToResponse(threads []*UnreadThread) []*UnreadThreadResponse {
+	items := make([]*UnreadThreadResponse, 0, len(threads))
+	for _, t := range threads {
+		items = append(items, &UnreadThreadResponse{
+			BookingId:     t.BookingId,
+			Title:         t.Title,
+			StartTime:     t.StartTime,
+			UnreadCount:   t.UnreadCount,
+			LastMessageAt: t.LastMessageAt,
+		})
+	}
+	return items
+}
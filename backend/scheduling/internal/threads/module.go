@@ -0,0 +1,26 @@
+package threads
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeThreadService(log logger.Logger, db *sqlx.DB, cfg *config.ThreadConfig, notifier Notifier) *ThreadService {
+	repo := NewThreadRepository(db)
+	service := NewThreadService(log, repo, cfg, notifier)
+	return service
+}
+
+func InitializeThreadHTTPHandler(service *ThreadService) http.Handler {
+	handler := NewThreadHandler(service)
+	return Routes(handler)
+}
+
+func InitializeThreadRetentionJob(log logger.Logger, db *sqlx.DB, cfg *config.ThreadConfig) *ThreadRetentionJob {
+	repo := NewThreadRepository(db)
+	return NewThreadRetentionJob(log, repo, cfg)
+}
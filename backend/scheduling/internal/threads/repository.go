@@ -0,0 +1,130 @@
+package threads
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// UnreadThread holds the unread message count of a booking thread for one participant
+type UnreadThread struct {
+	BookingId     int64     `db:"booking_id"`
+	Title         string    `db:"title"`
+	StartTime     time.Time `db:"start_time"`
+	UnreadCount   int       `db:"unread_count"`
+	LastMessageAt time.Time `db:"last_message_at"`
+}
+
+type ThreadRepo struct {
+	db *sqlx.DB
+}
+
+func NewThreadRepository(db *sqlx.DB) *ThreadRepo {
+	return &ThreadRepo{db: db}
+}
+
+// GetBookingById retrieves the booking a thread is attached to
+func (r *ThreadRepo) GetBookingById(ctx context.Context, id int64) (*entities.Booking, error) {
+	const query = `
+		SELECT id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at
+		FROM booking
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.Booking](ctx, r.db, query, id)
+}
+
+// GetMessages retrieves messages of a booking thread posted after a message id, oldest first
+func (r *ThreadRepo) GetMessages(ctx context.Context, bookingId int64, afterId int64, take int) ([]*entities.BookingMessage, error) {
+	const query = `
+		SELECT id, booking_id, sender_id, body, created_at
+		FROM booking_message
+		WHERE booking_id = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+	return database.FetchMultiple[entities.BookingMessage](ctx, r.db, query, bookingId, afterId, take)
+}
+
+// AddMessage adds a message to a booking thread and returns its Id
+func (r *ThreadRepo) AddMessage(ctx context.Context, message *entities.BookingMessage) (int64, error) {
+	const query = `
+		INSERT INTO booking_message (booking_id, sender_id, body, created_at)
+		VALUES (:booking_id, :sender_id, :body, :created_at)
+		RETURNING id
+	`
+	return database.ExecNamedQueryWithResult[int64](ctx, r.db, query, message)
+}
+
+// MarkRead moves the read marker of a participant forward, it never moves back
+func (r *ThreadRepo) MarkRead(ctx context.Context, read *entities.BookingThreadRead) error {
+	const query = `
+		INSERT INTO booking_thread_read (booking_id, user_id, last_read_id, updated_at)
+		VALUES (:booking_id, :user_id, :last_read_id, :updated_at)
+		ON CONFLICT (booking_id, user_id) DO UPDATE
+		SET last_read_id = GREATEST(booking_thread_read.last_read_id, EXCLUDED.last_read_id), updated_at = EXCLUDED.updated_at
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, read)
+}
+
+// GetUnreadThreads retrieves the threads of a user's bookings with messages from the other participant
+// they have not read yet, most recent activity first
+func (r *ThreadRepo) GetUnreadThreads(ctx context.Context, userId uuid.UUID) ([]*UnreadThread, error) {
+	const query = `
+		SELECT b.id AS booking_id, b.title, b.start_time, COUNT(m.id) AS unread_count, MAX(m.created_at) AS last_message_at
+		FROM booking b
+		JOIN booking_message m ON m.booking_id = b.id AND m.sender_id <> $1
+		LEFT JOIN booking_thread_read tr ON tr.booking_id = b.id AND tr.user_id = $1
+		WHERE (b.student_id = $1 OR b.educator_id = $1) AND m.id > COALESCE(tr.last_read_id, 0)
+		GROUP BY b.id, b.title, b.start_time
+		ORDER BY last_message_at DESC
+	`
+	return database.FetchMultiple[UnreadThread](ctx, r.db, query, userId)
+}
+
+// PurgeMessages deletes up to limit messages of bookings that ended before the cutoff, together with the
+// read markers of threads left empty, and returns how many messages were deleted
+func (r *ThreadRepo) PurgeMessages(ctx context.Context, endedBefore time.Time, limit int) (int64, error) {
+	const messagesQuery = `
+		DELETE FROM booking_message
+		WHERE id IN (
+			SELECT m.id FROM booking_message m
+			JOIN booking b ON b.id = m.booking_id
+			WHERE b.end_time < $1
+			LIMIT $2
+		)
+	`
+	const readsQuery = `
+		DELETE FROM booking_thread_read tr
+		WHERE NOT EXISTS (SELECT 1 FROM booking_message m WHERE m.booking_id = tr.booking_id)
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, messagesQuery, endedBefore, limit)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, readsQuery); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return deleted, nil
+}
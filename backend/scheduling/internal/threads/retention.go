@@ -0,0 +1,63 @@
+package threads
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const purgeBatchSize = 1000
+
+type RetentionRepository interface {
+	PurgeMessages(ctx context.Context, endedBefore time.Time, limit int) (int64, error)
+}
+
+// ThreadRetentionJob periodically deletes messages of bookings that ended past the retention period
+type ThreadRetentionJob struct {
+	log  logger.Logger
+	repo RetentionRepository
+	cfg  *config.ThreadConfig
+}
+
+func NewThreadRetentionJob(log logger.Logger, repo RetentionRepository, cfg *config.ThreadConfig) *ThreadRetentionJob {
+	return &ThreadRetentionJob{log: log, repo: repo, cfg: cfg}
+}
+
+// Run purges expired messages on every interval until the context is cancelled
+func (j *ThreadRetentionJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.PurgeIntervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.PurgeExpiredMessages(ctx); err != nil {
+				j.log.Errorf("Failed to purge booking messages: %v", err)
+			}
+		}
+	}
+}
+
+// PurgeExpiredMessages deletes expired messages in batches until fewer than a full batch remain
+func (j *ThreadRetentionJob) PurgeExpiredMessages(ctx context.Context) error {
+	cutoff := retentionCutoff(time.Now().UTC(), j.cfg)
+
+	for {
+		deleted, err := j.repo.PurgeMessages(ctx, cutoff, purgeBatchSize)
+		if err != nil {
+			return err
+		}
+		if deleted < purgeBatchSize {
+			return nil
+		}
+	}
+}
+
+// retentionCutoff returns the end time before which booking threads are closed and purged
+func retentionCutoff(now time.Time, cfg *config.ThreadConfig) time.Time {
+	return now.AddDate(0, 0, -cfg.RetentionDays)
+}
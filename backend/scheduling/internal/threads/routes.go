@@ -0,0 +1,18 @@
+package threads
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func Routes(handler *ThreadHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/unread", handler.GetMyUnreadThreads)
+	r.Get("/{bookingId}/messages", handler.GetMessages)
+	r.Post("/{bookingId}/messages", handler.SendMessage)
+
+	return r
+}
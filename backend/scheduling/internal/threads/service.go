@@ -0,0 +1,177 @@
+package threads
+
+import (
+	"context"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+)
+
+const previewLength = 100
+
+type ThreadRepository interface {
+	GetBookingById(ctx context.Context, id int64) (*entities.Booking, error)
+	GetMessages(ctx context.Context, bookingId int64, afterId int64, take int) ([]*entities.BookingMessage, error)
+	AddMessage(ctx context.Context, message *entities.BookingMessage) (int64, error)
+	MarkRead(ctx context.Context, read *entities.BookingThreadRead) error
+	GetUnreadThreads(ctx context.Context, userId uuid.UUID) ([]*UnreadThread, error)
+}
+
+// Notifier sends user notifications according to their notification preferences
+type Notifier interface {
+	Notify(ctx context.Context, userId uuid.UUID, notificationType string, data map[string]string) error
+}
+
+type ThreadService struct {
+	log      logger.Logger
+	repo     ThreadRepository
+	cfg      *config.ThreadConfig
+	notifier Notifier
+}
+
+func NewThreadService(log logger.Logger, repo ThreadRepository, cfg *config.ThreadConfig, notifier Notifier) *ThreadService {
+	return &ThreadService{log: log, repo: repo, cfg: cfg, notifier: notifier}
+}
+
+// GetMessages returns the messages of a booking thread posted after a message id and marks them as read
+// for the participant. Admins may read threads without moving the read marker.
+func (s *ThreadService) GetMessages(ctx context.Context, bookingId int64, afterId int64, take int) ([]*MessageResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	booking, err := s.repo.GetBookingById(ctx, bookingId)
+	if err != nil {
+		log.Error("failed to get booking", err)
+		return nil, err
+	}
+
+	participant := isParticipant(booking, userId)
+	if !participant && !auth.HasRole(ctx, auth.AdminRole) {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	messages, err := s.repo.GetMessages(ctx, bookingId, afterId, take)
+	if err != nil {
+		log.Error("failed to get messages", err)
+		return nil, err
+	}
+
+	if participant && len(messages) > 0 {
+		read := &entities.BookingThreadRead{
+			BookingId:  bookingId,
+			UserId:     userId,
+			LastReadId: messages[len(messages)-1].Id,
+			UpdatedAt:  time.Now().UTC(),
+		}
+		if err := s.repo.MarkRead(ctx, read); err != nil {
+			log.Error("failed to mark thread as read", err)
+		}
+	}
+
+	return MapMessagesToResponse(messages), nil
+}
+
+// SendMessage posts a message to a booking thread and notifies the other participant. Threads of
+// cancelled bookings and of sessions past the retention period are closed.
+func (s *ThreadService) SendMessage(ctx context.Context, bookingId int64, request *MessageRequest) (*MessageResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if utf8.RuneCountInString(request.Body) > s.cfg.MaxMessageLength {
+		return nil, apperrors.NewUnprocessedEntity("Message is too long", apperrors.ErrParameterInvalid)
+	}
+
+	booking, err := s.repo.GetBookingById(ctx, bookingId)
+	if err != nil {
+		log.Error("failed to get booking", err)
+		return nil, err
+	}
+
+	if !isParticipant(booking, userId) {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+
+	if booking.Status == entities.Cancelled || booking.EndTime.Before(retentionCutoff(time.Now().UTC(), s.cfg)) {
+		return nil, apperrors.NewUnprocessedEntity("Booking thread is closed", apperrors.ErrThreadClosed)
+	}
+
+	message := MapRequestToMessage(bookingId, userId, request)
+	message.Id, err = s.repo.AddMessage(ctx, message)
+	if err != nil {
+		log.Error("failed to add message", err)
+		return nil, err
+	}
+
+	read := &entities.BookingThreadRead{BookingId: bookingId, UserId: userId, LastReadId: message.Id, UpdatedAt: message.CreatedAt}
+	if err := s.repo.MarkRead(ctx, read); err != nil {
+		log.Error("failed to mark thread as read", err)
+	}
+
+	s.notifyCounterpart(ctx, booking, message)
+
+	return MapMessageToResponse(message), nil
+}
+
+// GetMyUnreadThreads returns the booking threads of the current user with unread messages
+func (s *ThreadService) GetMyUnreadThreads(ctx context.Context) ([]*UnreadThreadResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	threads, err := s.repo.GetUnreadThreads(ctx, userId)
+	if err != nil {
+		log.Error("failed to get unread threads", err)
+		return nil, err
+	}
+
+	return MapUnreadThreadsToResponse(threads), nil
+}
+
+func (s *ThreadService) notifyCounterpart(ctx context.Context, booking *entities.Booking, message *entities.BookingMessage) {
+	log := logger.FromContext(ctx, s.log)
+
+	recipient := booking.StudentId
+	if recipient == message.SenderId {
+		recipient = booking.EducatorId
+	}
+
+	preview := message.Body
+	if runes := []rune(preview); len(runes) > previewLength {
+		preview = string(runes[:previewLength]) + "…"
+	}
+
+	data := map[string]string{
+		"bookingId": strconv.FormatInt(booking.Id, 10),
+		"title":     booking.Title,
+		"senderId":  message.SenderId.String(),
+		"preview":   preview,
+	}
+
+	if err := s.notifier.Notify(ctx, recipient, notifications.BookingMessageNotification, data); err != nil {
+		log.Errorf("Failed to notify user about message on booking %d: %v", booking.Id, err)
+	}
+}
+
+func isParticipant(booking *entities.Booking, userId uuid.UUID) bool {
+	return booking.StudentId == userId || booking.EducatorId == userId
+}
@@ -1,6 +1,11 @@
 package timeutils
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 func IsOverlapping(startA, endA, startB, endB time.Time) bool {
 	return startA.Before(endB) && endA.After(startB)
@@ -10,3 +15,99 @@ func IsWithinPeriod(start, end, periodStart, periodEnd time.Time) bool {
 	return (start.Equal(periodStart) || start.After(periodStart)) &&
 		(end.Equal(periodEnd) || end.Before(periodEnd))
 }
+
+// ParseClock parses a wall clock time in HH:MM format into the offset from midnight
+func ParseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Buffers are the gaps an educator keeps free before and after each session
+type Buffers struct {
+	Before time.Duration
+	After  time.Duration
+}
+
+func NewBuffers(beforeMinutes, afterMinutes int) Buffers {
+	return Buffers{Before: time.Duration(beforeMinutes) * time.Minute, After: time.Duration(afterMinutes) * time.Minute}
+}
+
+// Gap is the least time between two sessions, the buffer after the first plus the buffer before the second
+func (b Buffers) Gap() time.Duration {
+	return b.Before + b.After
+}
+
+// Conflicts reports whether two sessions overlap or are closer than the gap, the buffers are not bound
+// to working periods so a session can still start or end with one
+func (b Buffers) Conflicts(startA, endA, startB, endB time.Time) bool {
+	return IsOverlapping(startA.Add(-b.Gap()), endA.Add(b.Gap()), startB, endB)
+}
+
+// SlotGranularity restricts how long sessions last and when they start. Without durations a session may
+// last any time, without an alignment it may start at any minute.
+type SlotGranularity struct {
+	Durations []time.Duration
+	Alignment time.Duration
+}
+
+func NewSlotGranularity(durationMinutes []int64, alignmentMinutes int) SlotGranularity {
+	durations := make([]time.Duration, len(durationMinutes))
+	for i, d := range durationMinutes {
+		durations[i] = time.Duration(d) * time.Minute
+	}
+	return SlotGranularity{Durations: durations, Alignment: time.Duration(alignmentMinutes) * time.Minute}
+}
+
+// AllowsDuration reports whether a session may last the given duration
+func (g SlotGranularity) AllowsDuration(d time.Duration) bool {
+	if len(g.Durations) == 0 {
+		return true
+	}
+	for _, allowed := range g.Durations {
+		if d == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAligned reports whether a session may start at the given time. Alignments divide an hour, so they are
+// counted from the full hour in UTC, which is the full hour in every time zone offset by whole hours.
+func (g SlotGranularity) IsAligned(t time.Time) bool {
+	return g.Alignment == 0 || t.Truncate(g.Alignment).Equal(t)
+}
+
+// Violation tells why a session breaks the granularity, or returns an empty string when it complies
+func (g SlotGranularity) Violation(start, end time.Time) string {
+	if reason := g.DurationViolation(end.Sub(start)); reason != "" {
+		return reason
+	}
+	if !g.IsAligned(start) {
+		return fmt.Sprintf("Session must start on a multiple of %d minutes past the hour", int(g.Alignment.Minutes()))
+	}
+	return ""
+}
+
+// DurationViolation tells why a session may not last the given duration, or returns an empty string when
+// it may
+func (g SlotGranularity) DurationViolation(d time.Duration) string {
+	if g.AllowsDuration(d) {
+		return ""
+	}
+	minutes := make([]string, len(g.Durations))
+	for i, allowed := range g.Durations {
+		minutes[i] = strconv.Itoa(int(allowed.Minutes()))
+	}
+	return fmt.Sprintf("Session duration must be one of %s minutes", strings.Join(minutes, ", "))
+}
+
+// Step returns the alignment start times are laid out on, or the given default without one
+func (g SlotGranularity) Step(fallback time.Duration) time.Duration {
+	if g.Alignment == 0 {
+		return fallback
+	}
+	return g.Alignment
+}
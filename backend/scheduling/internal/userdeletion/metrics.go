@@ -0,0 +1,46 @@
+package userdeletion
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	outcomeProcessed = "processed"
+	outcomeDuplicate = "duplicate"
+	outcomeFailed    = "failed"
+)
+
+type deletionMetrics struct {
+	events            metric.Int64Counter
+	bookingsCancelled metric.Int64Counter
+	eventsReleased    metric.Int64Counter
+}
+
+func newDeletionMetrics(meter metric.Meter) (*deletionMetrics, error) {
+	events, err := meter.Int64Counter("scheduling.user_deletion.events",
+		metric.WithDescription("User deleted events handled, by outcome"))
+	if err != nil {
+		return nil, err
+	}
+
+	bookingsCancelled, err := meter.Int64Counter("scheduling.user_deletion.bookings_cancelled",
+		metric.WithDescription("Upcoming bookings cancelled because a participant was deleted"))
+	if err != nil {
+		return nil, err
+	}
+
+	eventsReleased, err := meter.Int64Counter("scheduling.user_deletion.events_released",
+		metric.WithDescription("Future scheduled events released because their educator was deleted"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &deletionMetrics{events: events, bookingsCancelled: bookingsCancelled, eventsReleased: eventsReleased}, nil
+}
+
+func (m *deletionMetrics) recordOutcome(ctx context.Context, outcome string) {
+	m.events.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+}
@@ -0,0 +1,19 @@
+package userdeletion
+
+import (
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeUserDeletionService(log logger.Logger, db *sqlx.DB, meter metric.Meter, notifier Notifier) (*UserDeletionService, error) {
+	metrics, err := newDeletionMetrics(meter)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := NewUserDeletionRepository(db)
+	service := NewUserDeletionService(log, repo, notifier, metrics)
+	return service, nil
+}
@@ -0,0 +1,145 @@
+package userdeletion
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type UserDeletionRepo struct {
+	db *sqlx.DB
+}
+
+func NewUserDeletionRepository(db *sqlx.DB) *UserDeletionRepo {
+	return &UserDeletionRepo{db: db}
+}
+
+// DeleteUserData cancels the user's upcoming bookings, releases their future schedule and strips personal
+// data from their history in a single transaction. The deletion is recorded per user, so it returns false
+// without changing anything when the user has already been processed. Invoices are kept untouched, since
+// they are financial records. The cancelled bookings are returned so their counterparts can be notified.
+func (r *UserDeletionRepo) DeleteUserData(ctx context.Context, deletion *entities.UserDeletion) ([]*entities.Booking, bool, error) {
+	const claimQuery = `
+		INSERT INTO user_deletion (user_id, event_id, processed_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO NOTHING
+	`
+	const cancelBookingsQuery = `
+		UPDATE booking
+		SET status = $1, version = version + 1, updated_at = $2
+		WHERE (student_id = $3 OR educator_id = $3) AND status = ANY($4) AND start_time > $2
+		RETURNING id, educator_id, student_id, enrollment_id, product_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, version, created_at, updated_at
+	`
+	releaseEventsQuery := database.WithTombstones(database.TombstoneScheduledEvent, `
+		UPDATE scheduled_event SET deleted_at = current_timestamp
+		WHERE user_id = $1 AND start_time > $2 AND deleted_at IS NULL
+	`)
+	releasePeriodsQuery := database.WithTombstones(database.TombstoneWorkingPeriod, `
+		UPDATE working_period wp SET deleted_at = current_timestamp
+		WHERE wp.user_id = $1 AND wp.start_time > $2 AND wp.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM scheduled_event se WHERE se.working_period_id = wp.id AND se.deleted_at IS NULL)
+	`)
+	cleanupQueries := []string{
+		`UPDATE session_note SET content = '', updated_at = $2 WHERE student_id = $1 OR educator_id = $1`,
+		`UPDATE attendance SET note = NULL, updated_at = $2 WHERE (student_id = $1 OR educator_id = $1) AND note IS NOT NULL`,
+		`UPDATE location SET address = '', latitude = 0, longitude = 0, archived_at = COALESCE(archived_at, $2), updated_at = $2 WHERE educator_id = $1`,
+		`UPDATE session_type SET archived_at = COALESCE(archived_at, $2), updated_at = $2 WHERE educator_id = $1`,
+	}
+	deleteQueries := []string{
+		`DELETE FROM session_note_revision WHERE note_id IN (SELECT id FROM session_note WHERE student_id = $1 OR educator_id = $1)`,
+		`DELETE FROM notification_preference WHERE user_id = $1`,
+		`DELETE FROM tax_profile WHERE user_id = $1`,
+		`DELETE FROM scheduling_policy WHERE educator_id = $1`,
+		`DELETE FROM widget_config WHERE educator_id = $1`,
+		`DELETE FROM organization_member WHERE user_id = $1`,
+		`DELETE FROM schedule_grant WHERE educator_id = $1 OR grantee_id = $1`,
+		`DELETE FROM favorite_teacher WHERE student_id = $1 OR educator_id = $1`,
+		`DELETE FROM booking_thread_read WHERE user_id = $1`,
+		`DELETE FROM booking_message WHERE sender_id = $1`,
+		`DELETE FROM escalation_rule WHERE educator_id = $1`,
+		`DELETE FROM availability_rule_set WHERE educator_id = $1`,
+		`DELETE FROM teacher_offboarding WHERE educator_id = $1`,
+		`DELETE FROM working_period_recurrence WHERE user_id = $1`,
+		`DELETE FROM cancellation_rule WHERE educator_id = $1`,
+		`DELETE FROM demand_forecast WHERE educator_id = $1`,
+		`DELETE FROM idempotency_key WHERE user_id = $1`,
+		`DELETE FROM booking_link_use WHERE student_id = $1`,
+		`DELETE FROM booking_link WHERE educator_id = $1`,
+		`UPDATE booking_cancellation_reason SET note = NULL WHERE note IS NOT NULL AND booking_id IN (SELECT id FROM booking WHERE student_id = $1)`,
+	}
+	const summaryQuery = `UPDATE user_deletion SET bookings_cancelled = $2, events_released = $3 WHERE user_id = $1`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return nil, false, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, claimQuery, deletion.UserId, deletion.EventId, deletion.ProcessedAt)
+	if err != nil {
+		return nil, false, apperrors.NewInternal(err)
+	}
+	claimed, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, apperrors.NewInternal(err)
+	}
+	if claimed == 0 {
+		return nil, false, nil
+	}
+
+	var cancelled []*entities.Booking
+	err = tx.SelectContext(ctx, &cancelled, cancelBookingsQuery,
+		entities.Cancelled, deletion.ProcessedAt, deletion.UserId, entities.OpenStatuses)
+	if err != nil {
+		return nil, false, apperrors.NewInternal(err)
+	}
+
+	released, err := execAffected(ctx, tx, releaseEventsQuery, deletion.UserId, deletion.ProcessedAt)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := execAffected(ctx, tx, releasePeriodsQuery, deletion.UserId, deletion.ProcessedAt); err != nil {
+		return nil, false, err
+	}
+
+	for _, query := range cleanupQueries {
+		if _, err := tx.ExecContext(ctx, query, deletion.UserId, deletion.ProcessedAt); err != nil {
+			return nil, false, apperrors.NewInternal(err)
+		}
+	}
+	for _, query := range deleteQueries {
+		if _, err := tx.ExecContext(ctx, query, deletion.UserId); err != nil {
+			return nil, false, apperrors.NewInternal(err)
+		}
+	}
+
+	deletion.BookingsCancelled = len(cancelled)
+	deletion.EventsReleased = int(released)
+	if _, err := tx.ExecContext(ctx, summaryQuery, deletion.UserId, deletion.BookingsCancelled, deletion.EventsReleased); err != nil {
+		return nil, false, apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, apperrors.NewInternal(err)
+	}
+	return cancelled, true, nil
+}
+
+func execAffected(ctx context.Context, tx database.Tx, query string, userId uuid.UUID, now time.Time) (int64, error) {
+	result, err := tx.ExecContext(ctx, query, userId, now)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return affected, nil
+}
@@ -0,0 +1,96 @@
+package userdeletion
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+)
+
+type UserDeletionRepository interface {
+	DeleteUserData(ctx context.Context, deletion *entities.UserDeletion) ([]*entities.Booking, bool, error)
+}
+
+// Notifier sends user notifications according to their notification preferences
+type Notifier interface {
+	Notify(ctx context.Context, userId uuid.UUID, notificationType string, data map[string]string) error
+}
+
+type UserDeletionService struct {
+	log      logger.Logger
+	repo     UserDeletionRepository
+	notifier Notifier
+	metrics  *deletionMetrics
+}
+
+func NewUserDeletionService(log logger.Logger, repo UserDeletionRepository, notifier Notifier, metrics *deletionMetrics) *UserDeletionService {
+	return &UserDeletionService{log: log, repo: repo, notifier: notifier, metrics: metrics}
+}
+
+// HandleUserDeleted cleans up the scheduling data of a user removed by the identity service. Redelivered
+// or repeated events for the same user are acknowledged without doing the cleanup again.
+func (s *UserDeletionService) HandleUserDeleted(ctx context.Context, event *messaging.UserDeletedEvent) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := uuid.Parse(event.UserId)
+	if err != nil {
+		s.metrics.recordOutcome(ctx, outcomeFailed)
+		return apperrors.NewBadRequestError("Invalid user id", apperrors.ErrParameterParsingFailed)
+	}
+
+	deletion := &entities.UserDeletion{
+		UserId:      userId,
+		EventId:     event.EventId,
+		ProcessedAt: time.Now().UTC(),
+	}
+
+	cancelled, processed, err := s.repo.DeleteUserData(ctx, deletion)
+	if err != nil {
+		s.metrics.recordOutcome(ctx, outcomeFailed)
+		log.Error("failed to delete user data", err)
+		return err
+	}
+
+	if !processed {
+		s.metrics.recordOutcome(ctx, outcomeDuplicate)
+		log.Infof("Deletion of user %s has already been processed, skipping event %s", userId, event.EventId)
+		return nil
+	}
+
+	s.metrics.recordOutcome(ctx, outcomeProcessed)
+	s.metrics.bookingsCancelled.Add(ctx, int64(deletion.BookingsCancelled))
+	s.metrics.eventsReleased.Add(ctx, int64(deletion.EventsReleased))
+
+	for _, b := range cancelled {
+		s.notifyCounterpart(ctx, b, userId)
+	}
+
+	return nil
+}
+
+// notifyCounterpart tells the remaining participant that the session was cancelled; failures are logged only
+func (s *UserDeletionService) notifyCounterpart(ctx context.Context, booking *entities.Booking, deletedUserId uuid.UUID) {
+	log := logger.FromContext(ctx, s.log)
+
+	recipient := booking.StudentId
+	if recipient == deletedUserId {
+		recipient = booking.EducatorId
+	}
+
+	data := map[string]string{
+		"bookingId": strconv.FormatInt(booking.Id, 10),
+		"title":     booking.Title,
+		"startTime": booking.StartTime.UTC().Format(time.RFC3339),
+	}
+
+	if err := s.notifier.Notify(ctx, recipient, notifications.BookingCancelledNotification, data); err != nil {
+		log.Errorf("Failed to notify user about cancelled booking %d: %v", booking.Id, err)
+	}
+}
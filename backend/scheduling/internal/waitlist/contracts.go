@@ -0,0 +1,16 @@
+package waitlist
+
+import "time"
+
+// swagger:model WaitlistEntryResponse
+type WaitlistEntryResponse struct {
+	Id               int64      `json:"id"`
+	ScheduledEventId int64      `json:"scheduledEventId"`
+	Title            string     `json:"title"`
+	StartTime        time.Time  `json:"startTime"`
+	EndTime          time.Time  `json:"endTime"`
+	Status           string     `json:"status"`
+	Position         int        `json:"position"`
+	OfferExpiresAt   *time.Time `json:"offerExpiresAt"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,117 @@
+package waitlist
+
+import (
+	"net/http"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type WaitlistHandler struct {
+	service *WaitlistService
+}
+
+func NewWaitlistHandler(service *WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{service: service}
+}
+
+// GetMyWaitlists retrieves the current user's waitlist entries.
+// @Summary      Retrieve my waitlists
+// @Description  Retrieves the waiting and offered waitlist entries of the current user for upcoming scheduled events, soonest first. Position is 1 for the next student in line and 0 while a place is offered.
+// @Tags         Waitlist
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   WaitlistEntryResponse  "Waitlist entries"
+// @Router       /api/v1/waitlists/my [get]
+// @Security 	 BearerAuth
+func (h *WaitlistHandler) GetMyWaitlists(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.service.GetMyWaitlists(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, entries)
+}
+
+// JoinWaitlist joins the waitlist of a scheduled event.
+// @Summary      Join waitlist
+// @Description  Queues the current user for a fully booked scheduled event. When a booking of the event is cancelled the first student in line is offered the place for the claim window, or booked directly when no window is configured.
+// @Tags         Waitlist
+// @Accept       json
+// @Produce      json
+// @Param        scheduledEventId  path      int                    true  "Scheduled event ID"
+// @Success      201               {object}  WaitlistEntryResponse  "Waitlist entry"
+// @Failure      409               {object}  error                  "Already booked or on the waitlist"
+// @Failure      422               {object}  error                  "Scheduled event has free places or has started"
+// @Router       /api/v1/waitlists/events/{scheduledEventId} [post]
+// @Security 	 BearerAuth
+func (h *WaitlistHandler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	scheduledEventId, err := api.ParseLongParam(w, r, "scheduledEventId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	entry, err := h.service.JoinWaitlist(r.Context(), scheduledEventId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, entry)
+}
+
+// ClaimWaitlistOffer claims a place offered from a waitlist.
+// @Summary      Claim waitlist offer
+// @Description  Books the place offered to the current user from a waitlist into a pending booking, before the offer expires.
+// @Tags         Waitlist
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                       true  "Waitlist entry ID"
+// @Success      201  {object}  schedule.BookingResponse  "Created booking"
+// @Failure      409  {object}  error                     "No place is offered"
+// @Failure      422  {object}  error                     "Offer has expired"
+// @Router       /api/v1/waitlists/{id}/claim [post]
+// @Security 	 BearerAuth
+func (h *WaitlistHandler) ClaimWaitlistOffer(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	booking, err := h.service.ClaimWaitlistOffer(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, booking)
+}
+
+// LeaveWaitlist leaves a waitlist.
+// @Summary      Leave waitlist
+// @Description  Removes the current user from a waitlist. A place offered to them is passed on to the next student in line.
+// @Tags         Waitlist
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Waitlist entry ID"
+// @Success      204  "Waitlist left successfully"
+// @Failure      404  {object}  error  "Waitlist entry not found"
+// @Router       /api/v1/waitlists/{id} [delete]
+// @Security 	 BearerAuth
+func (h *WaitlistHandler) LeaveWaitlist(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	if err := h.service.LeaveWaitlist(r.Context(), id); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,23 @@
+package waitlist
+
+func MapPositionToResponse(p *WaitlistPosition) *WaitlistEntryResponse {
+	return &WaitlistEntryResponse{
+		Id:               p.Id,
+		ScheduledEventId: p.ScheduledEventId,
+		Title:            p.Title,
+		StartTime:        p.StartTime,
+		EndTime:          p.EndTime,
+		Status:           p.Status.String(),
+		Position:         p.Position,
+		OfferExpiresAt:   p.OfferExpiresAt,
+		CreatedAt:        p.CreatedAt,
+	}
+}
+
+func MapPositionsToResponse(ps []*WaitlistPosition) []*WaitlistEntryResponse {
+	response := make([]*WaitlistEntryResponse, len(ps))
+	for i, p := range ps {
+		response[i] = MapPositionToResponse(p)
+	}
+	return response
+}
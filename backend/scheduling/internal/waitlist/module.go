@@ -0,0 +1,32 @@
+package waitlist
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+)
+
+func InitializeWaitlistService(
+	log logger.Logger,
+	db *sqlx.DB,
+	publisher *messaging.Publisher,
+	notifier Notifier,
+	cfg *config.WaitlistConfig,
+) *WaitlistService {
+	repo := NewWaitlistRepository(db)
+	return NewWaitlistService(log, repo, publisher, notifier, cfg)
+}
+
+func InitializeOfferSweepJob(log logger.Logger, db *sqlx.DB, service *WaitlistService, cfg *config.WaitlistConfig) *OfferSweepJob {
+	repo := NewWaitlistRepository(db)
+	return NewOfferSweepJob(log, repo, service, cfg)
+}
+
+func InitializeWaitlistHTTPHandler(service *WaitlistService) http.Handler {
+	handler := NewWaitlistHandler(service)
+	return Routes(handler)
+}
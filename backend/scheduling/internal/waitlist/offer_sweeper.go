@@ -0,0 +1,74 @@
+package waitlist
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+type OfferSweepRepository interface {
+	ExpireOffers(ctx context.Context, now time.Time, limit int) ([]*entities.WaitlistEntry, error)
+}
+
+// QueueAdvancer hands the free places of a scheduled event to the next waitlisted students
+type QueueAdvancer interface {
+	PromoteNext(ctx context.Context, scheduledEventId int64) error
+}
+
+// OfferSweepJob periodically expires unclaimed waitlist offers and passes their places on
+type OfferSweepJob struct {
+	log   logger.Logger
+	repo  OfferSweepRepository
+	queue QueueAdvancer
+	cfg   *config.WaitlistConfig
+}
+
+func NewOfferSweepJob(log logger.Logger, repo OfferSweepRepository, queue QueueAdvancer, cfg *config.WaitlistConfig) *OfferSweepJob {
+	return &OfferSweepJob{log: log, repo: repo, queue: queue, cfg: cfg}
+}
+
+// Run expires lapsed offers on every interval until the context is cancelled
+func (j *OfferSweepJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.SweepIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.ExpireOffers(ctx); err != nil {
+				j.log.Errorf("Failed to expire waitlist offers: %v", err)
+			}
+		}
+	}
+}
+
+// ExpireOffers expires one batch of lapsed offers, then offers each freed place to the next student waiting
+// for the same scheduled event. A failure to advance one queue does not stop the others.
+func (j *OfferSweepJob) ExpireOffers(ctx context.Context) error {
+	entries, err := j.repo.ExpireOffers(ctx, time.Now().UTC(), j.cfg.SweepBatchSize)
+	if err != nil {
+		return err
+	}
+
+	advanced := make(map[int64]bool)
+	for _, e := range entries {
+		if advanced[e.ScheduledEventId] {
+			continue
+		}
+		advanced[e.ScheduledEventId] = true
+
+		if err := j.queue.PromoteNext(ctx, e.ScheduledEventId); err != nil {
+			j.log.Errorf("Failed to advance waitlist of scheduled event %d: %v", e.ScheduledEventId, err)
+		}
+	}
+
+	if len(entries) > 0 {
+		j.log.Infof("Expired %d waitlist offers", len(entries))
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+package waitlist
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+const entryColumns = `id, scheduled_event_id, student_id, status, offer_expires_at, booking_id, created_at, updated_at`
+
+const lockEventQuery = `
+	SELECT id, user_id, product_id, lesson_id, session_type_id, location_id, title, working_period_id, start_time, end_time, max_participants, price, created_at, updated_at
+	FROM scheduled_event
+	WHERE id = $1 AND deleted_at IS NULL
+	FOR UPDATE
+`
+
+// freePlacesQuery counts the places of a scheduled event not taken by a booking or an unexpired offer
+const freePlacesQuery = `
+	SELECT e.max_participants
+		- (SELECT COUNT(*) FROM booking b WHERE b.scheduled_event_id = e.id AND b.status <> $2)
+		- (SELECT COUNT(*) FROM waitlist_entry w WHERE w.scheduled_event_id = e.id AND w.status = $3 AND w.offer_expires_at > $4)
+	FROM scheduled_event e
+	WHERE e.id = $1
+`
+
+const insertBookingQuery = `
+	INSERT INTO booking (educator_id, student_id, product_id, enrollment_id, scheduled_event_id, session_type_id, working_period_id, title, start_time, end_time, status, price, created_at, updated_at)
+	VALUES ($1, $2, $3, NULL, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+	RETURNING id
+`
+
+// WaitlistPosition is a waitlist entry with its scheduled event and the number of students waiting ahead
+type WaitlistPosition struct {
+	entities.WaitlistEntry
+	Title     string    `db:"title"`
+	StartTime time.Time `db:"start_time"`
+	EndTime   time.Time `db:"end_time"`
+	Position  int       `db:"position"`
+}
+
+type WaitlistRepo struct {
+	db *sqlx.DB
+}
+
+func NewWaitlistRepository(db *sqlx.DB) *WaitlistRepo {
+	return &WaitlistRepo{db: db}
+}
+
+// positionSelect selects waitlist entries as positions, $1 is the waiting and $2 the offered status
+const positionSelect = `
+	SELECT w.id, w.scheduled_event_id, w.student_id, w.status, w.offer_expires_at, w.booking_id, w.created_at, w.updated_at,
+		e.title, e.start_time, e.end_time,
+		CASE WHEN w.status = $1 THEN (
+			SELECT COUNT(*) FROM waitlist_entry q
+			WHERE q.scheduled_event_id = w.scheduled_event_id AND q.status = $1 AND (q.created_at, q.id) <= (w.created_at, w.id)
+		) ELSE 0 END AS position
+	FROM waitlist_entry w
+	JOIN scheduled_event e ON e.id = w.scheduled_event_id
+`
+
+// GetStudentEntries retrieves the waiting and offered entries of a student for upcoming scheduled events,
+// soonest first. Position is 1 for the first waiting student and 0 for offered entries.
+func (r *WaitlistRepo) GetStudentEntries(ctx context.Context, studentId uuid.UUID, now time.Time) ([]*WaitlistPosition, error) {
+	const query = positionSelect + `
+		WHERE w.student_id = $3 AND w.status IN ($1, $2) AND e.start_time > $4
+		ORDER BY e.start_time, w.id
+	`
+	return database.FetchMultiple[WaitlistPosition](ctx, r.db, query, entities.WaitlistWaiting, entities.WaitlistOffered, studentId, now)
+}
+
+// GetStudentEntry retrieves a waiting or offered entry of a student with its position
+func (r *WaitlistRepo) GetStudentEntry(ctx context.Context, id int64, studentId uuid.UUID) (*WaitlistPosition, error) {
+	const query = positionSelect + `WHERE w.id = $3 AND w.student_id = $4 AND w.status IN ($1, $2)`
+	return database.FetchSingle[WaitlistPosition](ctx, r.db, query, entities.WaitlistWaiting, entities.WaitlistOffered, id, studentId)
+}
+
+// AddEntry queues a student for a scheduled event and returns the entry Id. The event is locked while it
+// is checked to be fully booked and the student to be neither booked nor already waiting for it.
+func (r *WaitlistRepo) AddEntry(ctx context.Context, entry *entities.WaitlistEntry, now time.Time) (int64, error) {
+	const bookedQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM booking WHERE scheduled_event_id = $1 AND student_id = $2 AND status <> $3
+		)
+	`
+	const waitingQuery = `
+		SELECT EXISTS (
+			SELECT 1 FROM waitlist_entry WHERE scheduled_event_id = $1 AND student_id = $2 AND status IN ($3, $4)
+		)
+	`
+	const insertQuery = `
+		INSERT INTO waitlist_entry (scheduled_event_id, student_id, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		RETURNING id
+	`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	event, err := lockScheduledEvent(ctx, tx, entry.ScheduledEventId)
+	if err != nil {
+		return 0, err
+	}
+
+	if !event.StartTime.After(now) {
+		return 0, apperrors.NewUnprocessedEntity("Scheduled event has already started", apperrors.ErrWaitlistUnavailable)
+	}
+
+	var booked bool
+	if err := tx.GetContext(ctx, &booked, bookedQuery, event.Id, entry.StudentId, entities.Cancelled); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	if booked {
+		return 0, apperrors.NewConflict("Scheduled event is already booked", apperrors.ErrBookingAlreadyExists)
+	}
+
+	var waiting bool
+	err = tx.GetContext(ctx, &waiting, waitingQuery, event.Id, entry.StudentId, entities.WaitlistWaiting, entities.WaitlistOffered)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	if waiting {
+		return 0, apperrors.NewConflict("Already on the waitlist", apperrors.ErrWaitlistUnavailable)
+	}
+
+	free, err := freePlaces(ctx, tx, event.Id, now)
+	if err != nil {
+		return 0, err
+	}
+	if free > 0 {
+		return 0, apperrors.NewUnprocessedEntity("Scheduled event still has free places", apperrors.ErrWaitlistUnavailable)
+	}
+
+	var id int64
+	if err := tx.GetContext(ctx, &id, insertQuery, event.Id, entry.StudentId, entities.WaitlistWaiting, now); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return id, nil
+}
+
+// DeleteEntry removes a waiting or offered entry of a student and returns it, nil when none was found
+func (r *WaitlistRepo) DeleteEntry(ctx context.Context, id int64, studentId uuid.UUID) (*entities.WaitlistEntry, error) {
+	const query = `
+		DELETE FROM waitlist_entry
+		WHERE id = $1 AND student_id = $2 AND status IN ($3, $4)
+		RETURNING ` + entryColumns
+	var entry entities.WaitlistEntry
+	err := database.Conn(ctx, r.db).GetContext(ctx, &entry, query, id, studentId, entities.WaitlistWaiting, entities.WaitlistOffered)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return &entry, nil
+}
+
+// AdvanceQueue hands a free place of a scheduled event to the first waiting student. Without an offer
+// expiry the student is booked directly and the booking is returned, otherwise the place is offered until
+// then. The event is locked so concurrent cancellations cannot hand out the same place twice; nil is
+// returned when no place is free, nobody is waiting or the event has started.
+func (r *WaitlistRepo) AdvanceQueue(
+	ctx context.Context,
+	scheduledEventId int64,
+	offerExpiresAt *time.Time,
+	now time.Time,
+) (*entities.WaitlistEntry, *entities.Booking, error) {
+	const nextEntryQuery = `
+		SELECT ` + entryColumns + `
+		FROM waitlist_entry
+		WHERE scheduled_event_id = $1 AND status = $2
+		ORDER BY created_at, id
+		LIMIT 1
+		FOR UPDATE
+	`
+	const offerQuery = `UPDATE waitlist_entry SET status = $2, offer_expires_at = $3, updated_at = $4 WHERE id = $1`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return nil, nil, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	event, err := lockScheduledEvent(ctx, tx, scheduledEventId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !event.StartTime.After(now) {
+		return nil, nil, nil
+	}
+
+	free, err := freePlaces(ctx, tx, event.Id, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if free <= 0 {
+		return nil, nil, nil
+	}
+
+	var entry entities.WaitlistEntry
+	if err := tx.GetContext(ctx, &entry, nextEntryQuery, event.Id, entities.WaitlistWaiting); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, apperrors.NewInternal(err)
+	}
+
+	var booking *entities.Booking
+	if offerExpiresAt == nil {
+		booking, err = promoteEntry(ctx, tx, &entry, event, now)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if _, err := tx.ExecContext(ctx, offerQuery, entry.Id, entities.WaitlistOffered, *offerExpiresAt, now); err != nil {
+			return nil, nil, apperrors.NewInternal(err)
+		}
+		entry.Status = entities.WaitlistOffered
+		entry.OfferExpiresAt = offerExpiresAt
+		entry.UpdatedAt = now
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, apperrors.NewInternal(err)
+	}
+	return &entry, booking, nil
+}
+
+// ClaimOffer books the place offered to a student and marks the entry promoted. The entry is locked so
+// a concurrent claim or sweep cannot consume the offer twice.
+func (r *WaitlistRepo) ClaimOffer(ctx context.Context, id int64, studentId uuid.UUID, now time.Time) (*entities.WaitlistEntry, *entities.Booking, error) {
+	const lockEntryQuery = `SELECT ` + entryColumns + ` FROM waitlist_entry WHERE id = $1 AND student_id = $2 FOR UPDATE`
+
+	tx, err := database.BeginTx(ctx, r.db, nil)
+	if err != nil {
+		return nil, nil, apperrors.NewInternal(err)
+	}
+	defer tx.Rollback()
+
+	var entry entities.WaitlistEntry
+	if err := tx.GetContext(ctx, &entry, lockEntryQuery, id, studentId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, apperrors.NewNotFound("Waitlist entry not found", apperrors.ErrResourceNotFound)
+		}
+		return nil, nil, apperrors.NewInternal(err)
+	}
+
+	if entry.Status != entities.WaitlistOffered {
+		return nil, nil, apperrors.NewConflict("No place is offered for this waitlist entry", apperrors.ErrWaitlistUnavailable)
+	}
+	if !entry.OfferExpiresAt.After(now) {
+		return nil, nil, apperrors.NewUnprocessedEntity("Waitlist offer has expired", apperrors.ErrWaitlistUnavailable)
+	}
+
+	event, err := lockScheduledEvent(ctx, tx, entry.ScheduledEventId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !event.StartTime.After(now) {
+		return nil, nil, apperrors.NewUnprocessedEntity("Scheduled event has already started", apperrors.ErrWaitlistUnavailable)
+	}
+
+	booking, err := promoteEntry(ctx, tx, &entry, event, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, apperrors.NewInternal(err)
+	}
+	return &entry, booking, nil
+}
+
+// ExpireOffers marks one batch of lapsed offers expired and returns them. Locked rows are skipped, so
+// offers being claimed right now are left alone.
+func (r *WaitlistRepo) ExpireOffers(ctx context.Context, now time.Time, limit int) ([]*entities.WaitlistEntry, error) {
+	const query = `
+		UPDATE waitlist_entry SET status = $1, updated_at = $2
+		WHERE id IN (
+			SELECT id FROM waitlist_entry
+			WHERE status = $3 AND offer_expires_at <= $2
+			ORDER BY offer_expires_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + entryColumns
+	return database.FetchMultiple[entities.WaitlistEntry](ctx, r.db, query, entities.WaitlistExpired, now, entities.WaitlistOffered, limit)
+}
+
+// promoteEntry books the scheduled event for the student of an entry and marks the entry promoted
+func promoteEntry(
+	ctx context.Context,
+	tx database.Tx,
+	entry *entities.WaitlistEntry,
+	event *entities.ScheduledEvent,
+	now time.Time,
+) (*entities.Booking, error) {
+	const promoteQuery = `UPDATE waitlist_entry SET status = $2, booking_id = $3, offer_expires_at = NULL, updated_at = $4 WHERE id = $1`
+
+	booking := &entities.Booking{
+		EducatorId:       event.UserId,
+		StudentId:        entry.StudentId,
+		ProductId:        event.ProductId,
+		ScheduledEventId: &event.Id,
+		SessionTypeId:    event.SessionTypeId,
+		WorkingPeriodId:  event.WorkingPeriodId,
+		Title:            event.Title,
+		StartTime:        event.StartTime,
+		EndTime:          event.EndTime,
+		Status:           entities.Pending,
+		Price:            event.Price,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	err := tx.GetContext(ctx, &booking.Id, insertBookingQuery,
+		booking.EducatorId, booking.StudentId, booking.ProductId, booking.ScheduledEventId, booking.SessionTypeId,
+		booking.WorkingPeriodId, booking.Title, booking.StartTime, booking.EndTime, booking.Status, booking.Price, now)
+	if err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, promoteQuery, entry.Id, entities.WaitlistPromoted, booking.Id, now); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	entry.Status = entities.WaitlistPromoted
+	entry.BookingId = &booking.Id
+	entry.OfferExpiresAt = nil
+	entry.UpdatedAt = now
+
+	return booking, nil
+}
+
+func lockScheduledEvent(ctx context.Context, tx database.Tx, id int64) (*entities.ScheduledEvent, error) {
+	var event entities.ScheduledEvent
+	if err := tx.GetContext(ctx, &event, lockEventQuery, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewNotFound("Scheduled event not found", apperrors.ErrResourceNotFound)
+		}
+		return nil, apperrors.NewInternal(err)
+	}
+	return &event, nil
+}
+
+func freePlaces(ctx context.Context, tx database.Tx, scheduledEventId int64, now time.Time) (int, error) {
+	var free int
+	err := tx.GetContext(ctx, &free, freePlacesQuery, scheduledEventId, entities.Cancelled, entities.WaitlistOffered, now)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return free, nil
+}
@@ -0,0 +1,23 @@
+package waitlist
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *WaitlistHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequirePermission(auth.BookSessionsPermission))
+	r.Get("/my", handler.GetMyWaitlists)
+	r.Post("/events/{scheduledEventId}", handler.JoinWaitlist)
+	r.Post("/{id}/claim", handler.ClaimWaitlistOffer)
+	r.Delete("/{id}", handler.LeaveWaitlist)
+
+	return r
+}
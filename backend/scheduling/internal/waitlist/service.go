@@ -0,0 +1,221 @@
+package waitlist
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/messaging"
+	"github.com/maksmelnyk/scheduling/internal/notifications"
+	"github.com/maksmelnyk/scheduling/internal/schedule"
+)
+
+type WaitlistRepository interface {
+	GetStudentEntries(ctx context.Context, studentId uuid.UUID, now time.Time) ([]*WaitlistPosition, error)
+	GetStudentEntry(ctx context.Context, id int64, studentId uuid.UUID) (*WaitlistPosition, error)
+	AddEntry(ctx context.Context, entry *entities.WaitlistEntry, now time.Time) (int64, error)
+	DeleteEntry(ctx context.Context, id int64, studentId uuid.UUID) (*entities.WaitlistEntry, error)
+	AdvanceQueue(ctx context.Context, scheduledEventId int64, offerExpiresAt *time.Time, now time.Time) (*entities.WaitlistEntry, *entities.Booking, error)
+	ClaimOffer(ctx context.Context, id int64, studentId uuid.UUID, now time.Time) (*entities.WaitlistEntry, *entities.Booking, error)
+	ExpireOffers(ctx context.Context, now time.Time, limit int) ([]*entities.WaitlistEntry, error)
+}
+
+// Notifier sends user notifications according to their notification preferences
+type Notifier interface {
+	Notify(ctx context.Context, userId uuid.UUID, notificationType string, data map[string]string) error
+}
+
+type WaitlistService struct {
+	log       logger.Logger
+	repo      WaitlistRepository
+	publisher *messaging.Publisher
+	notifier  Notifier
+	cfg       *config.WaitlistConfig
+}
+
+func NewWaitlistService(
+	log logger.Logger,
+	repo WaitlistRepository,
+	publisher *messaging.Publisher,
+	notifier Notifier,
+	cfg *config.WaitlistConfig,
+) *WaitlistService {
+	return &WaitlistService{log: log, repo: repo, publisher: publisher, notifier: notifier, cfg: cfg}
+}
+
+// GetMyWaitlists retrieves the waitlists the current user is waiting on or holds an offer from
+func (s *WaitlistService) GetMyWaitlists(ctx context.Context) ([]*WaitlistEntryResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	entries, err := s.repo.GetStudentEntries(ctx, userId, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to get waitlist entries", err)
+		return nil, err
+	}
+
+	return MapPositionsToResponse(entries), nil
+}
+
+// JoinWaitlist queues the current user for a fully booked scheduled event
+func (s *WaitlistService) JoinWaitlist(ctx context.Context, scheduledEventId int64) (*WaitlistEntryResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	entry := &entities.WaitlistEntry{ScheduledEventId: scheduledEventId, StudentId: userId, Status: entities.WaitlistWaiting}
+	id, err := s.repo.AddEntry(ctx, entry, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to join waitlist", err)
+		return nil, err
+	}
+
+	position, err := s.repo.GetStudentEntry(ctx, id, userId)
+	if err != nil {
+		log.Error("failed to get waitlist entry", err)
+		return nil, err
+	}
+
+	return MapPositionToResponse(position), nil
+}
+
+// LeaveWaitlist removes the current user from a waitlist. A place offered to them is passed on to the
+// next student in line.
+func (s *WaitlistService) LeaveWaitlist(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	entry, err := s.repo.DeleteEntry(ctx, id, userId)
+	if err != nil {
+		log.Error("failed to leave waitlist", err)
+		return err
+	}
+
+	if entry == nil {
+		return apperrors.NewNotFound("Waitlist entry not found", apperrors.ErrResourceNotFound)
+	}
+
+	if entry.Status == entities.WaitlistOffered {
+		if err := s.PromoteNext(ctx, entry.ScheduledEventId); err != nil {
+			log.Errorf("Failed to pass on the waitlist place of scheduled event %d: %v", entry.ScheduledEventId, err)
+		}
+	}
+	return nil
+}
+
+// ClaimWaitlistOffer books the place offered to the current user into a pending booking
+func (s *WaitlistService) ClaimWaitlistOffer(ctx context.Context, id int64) (*schedule.BookingResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	entry, booking, err := s.repo.ClaimOffer(ctx, id, userId, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to claim waitlist offer", err)
+		return nil, err
+	}
+
+	s.publishPromoted(ctx, entry, booking)
+	return schedule.MapBookingToResponse(booking), nil
+}
+
+// PromoteNext hands the free places of a scheduled event to the students waiting for it, in the order they
+// joined. With a claim window each student is offered the place until the window closes, otherwise they
+// are booked directly. Publish and notification failures are logged only.
+func (s *WaitlistService) PromoteNext(ctx context.Context, scheduledEventId int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	for {
+		now := time.Now().UTC()
+
+		var offerExpiresAt *time.Time
+		if s.cfg.ClaimWindowMinutes > 0 {
+			expiresAt := now.Add(time.Duration(s.cfg.ClaimWindowMinutes) * time.Minute)
+			offerExpiresAt = &expiresAt
+		}
+
+		entry, booking, err := s.repo.AdvanceQueue(ctx, scheduledEventId, offerExpiresAt, now)
+		if err != nil {
+			return err
+		}
+		if entry == nil {
+			return nil
+		}
+
+		if booking != nil {
+			log.Infof("Waitlist entry %d promoted to booking %d", entry.Id, booking.Id)
+			s.publishPromoted(ctx, entry, booking)
+		} else {
+			log.Infof("Waitlist entry %d offered a place until %s", entry.Id, entry.OfferExpiresAt.Format(time.RFC3339))
+			s.publishOffered(ctx, entry)
+		}
+	}
+}
+
+func (s *WaitlistService) publishOffered(ctx context.Context, entry *entities.WaitlistEntry) {
+	log := logger.FromContext(ctx, s.log)
+
+	expiresAt := entry.OfferExpiresAt.UTC().Format(time.RFC3339)
+	err := s.publisher.Publish(
+		ctx,
+		messaging.WaitlistKey,
+		messaging.NewWaitlistSpotOfferedEvent(entry.Id, entry.ScheduledEventId, entry.StudentId.String(), expiresAt),
+	)
+	if err != nil {
+		log.Errorf("Failed to publish waitlist offer %d: %v", entry.Id, err)
+	}
+
+	s.notify(ctx, entry, notifications.WaitlistOfferedNotification, map[string]string{"offerExpiresAt": expiresAt})
+}
+
+func (s *WaitlistService) publishPromoted(ctx context.Context, entry *entities.WaitlistEntry, booking *entities.Booking) {
+	log := logger.FromContext(ctx, s.log)
+
+	err := s.publisher.Publish(
+		ctx,
+		messaging.WaitlistKey,
+		messaging.NewWaitlistPromotedEvent(entry.Id, entry.ScheduledEventId, entry.StudentId.String(), booking.Id),
+	)
+	if err != nil {
+		log.Errorf("Failed to publish waitlist promotion %d: %v", entry.Id, err)
+	}
+
+	s.notify(ctx, entry, notifications.WaitlistPromotedNotification, map[string]string{
+		"bookingId": strconv.FormatInt(booking.Id, 10),
+		"title":     booking.Title,
+		"startTime": booking.StartTime.UTC().Format(time.RFC3339),
+	})
+}
+
+// notify informs a waitlisted student; failures are logged and do not fail the waitlist flow
+func (s *WaitlistService) notify(ctx context.Context, entry *entities.WaitlistEntry, notificationType string, data map[string]string) {
+	log := logger.FromContext(ctx, s.log)
+
+	data["waitlistEntryId"] = strconv.FormatInt(entry.Id, 10)
+	data["scheduledEventId"] = strconv.FormatInt(entry.ScheduledEventId, 10)
+
+	if err := s.notifier.Notify(ctx, entry.StudentId, notificationType, data); err != nil {
+		log.Errorf("Failed to notify student about waitlist entry %d: %v", entry.Id, err)
+	}
+}
@@ -0,0 +1,116 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// swagger:model WebhookSubscriptionRequest
+type WebhookSubscriptionRequest struct {
+	Url string `json:"url"`
+	// EventTypes are the events sent to the URL, out of booking.created, booking.cancelled and schedule.updated
+	EventTypes []string `json:"eventTypes"`
+	// Fields is the payload template, the data fields sent to the URL. All fields are sent when empty.
+	Fields []string `json:"fields"`
+	Active *bool    `json:"active"`
+}
+
+// swagger:model WebhookSubscriptionResponse
+type WebhookSubscriptionResponse struct {
+	Id         int64    `json:"id"`
+	Url        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Fields     []string `json:"fields"`
+	Active     bool     `json:"active"`
+	// Secret signs the deliveries, it is only returned when the subscription is created
+	Secret    *string   `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// swagger:model WebhookDeliveryResponse
+type WebhookDeliveryResponse struct {
+	Id             int64           `json:"id"`
+	EventId        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode *int            `json:"lastStatusCode"`
+	LastError      *string         `json:"lastError"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// swagger:model WebhookDeadLetterResponse
+type WebhookDeadLetterResponse struct {
+	Id             int64 `json:"id"`
+	SubscriptionId int64 `json:"subscriptionId"`
+	// DeliveryId is the delivery that ran out of attempts, it stays in the delivery log as failed
+	DeliveryId     int64           `json:"deliveryId"`
+	EventId        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode *int            `json:"lastStatusCode"`
+	LastError      *string         `json:"lastError"`
+	DeadLetteredAt time.Time       `json:"deadLetteredAt"`
+}
+
+// swagger:model WebhookRedeliveryResponse
+type WebhookRedeliveryResponse struct {
+	// Redelivered is the number of dead letters queued for delivery again
+	Redelivered int64 `json:"redelivered"`
+}
+
+// Validate checks the request. Plain http URLs are accepted only when allowInsecure is set.
+func (r *WebhookSubscriptionRequest) Validate(allowInsecure bool) error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if u, err := url.Parse(r.Url); err != nil || u.Host == "" || (u.Scheme != "https" && !(allowInsecure && u.Scheme == "http")) {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Url",
+			Message: "must be an absolute https URL",
+		})
+	} else if len(r.Url) > 2048 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "Url",
+			Message: "must be at most 2048 characters",
+		})
+	}
+
+	if len(r.EventTypes) == 0 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "EventTypes",
+			Message: "must not be empty",
+		})
+	}
+	for _, eventType := range r.EventTypes {
+		if !slices.Contains(EventTypes(), eventType) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "EventTypes",
+				Message: "unknown event type " + eventType,
+			})
+		}
+	}
+
+	for _, field := range r.Fields {
+		if !knownField(r.EventTypes, field) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "Fields",
+				Message: "no subscribed event has the field " + field,
+			})
+		}
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Webhook subscription request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// Deliveries that run out of attempts are parked as dead letters, the HTTP counterpart of the AMQP dead
+// letters. Subscribers inspect and redeliver their own once their endpoint is fixed, admins those of every
+// subscription. A redelivery is queued as a new delivery of the same event id, so subscribers that did
+// receive an earlier attempt drop it as a duplicate.
+
+// GetMyDeadLetters returns a page of the dead letters of a subscription of the educator, newest first
+func (s *WebhookService) GetMyDeadLetters(ctx context.Context, subscriptionId int64, skip int, take int) ([]*WebhookDeadLetterResponse, error) {
+	if _, err := s.getMySubscription(ctx, subscriptionId); err != nil {
+		return nil, err
+	}
+	return s.GetDeadLetters(ctx, &subscriptionId, nil, skip, take)
+}
+
+// RedeliverMyDeadLetter queues a dead letter of a subscription of the educator for delivery again
+func (s *WebhookService) RedeliverMyDeadLetter(ctx context.Context, subscriptionId int64, id int64) error {
+	if _, err := s.getMyDeadLetter(ctx, subscriptionId, id); err != nil {
+		return err
+	}
+	return s.RedeliverDeadLetter(ctx, id)
+}
+
+// RedeliverMyDeadLetters queues all dead letters of a subscription of the educator for delivery again
+func (s *WebhookService) RedeliverMyDeadLetters(ctx context.Context, subscriptionId int64) (*WebhookRedeliveryResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if _, err := s.getMySubscription(ctx, subscriptionId); err != nil {
+		return nil, err
+	}
+
+	redelivered, err := s.repo.RedeliverSubscriptionDeadLetters(ctx, subscriptionId, time.Now().UTC())
+	if err != nil {
+		log.Error("failed to redeliver webhook dead letters", err)
+		return nil, err
+	}
+
+	log.Infof("Redelivering %d webhook dead letters of subscription %d", redelivered, subscriptionId)
+	return &WebhookRedeliveryResponse{Redelivered: redelivered}, nil
+}
+
+// PurgeMyDeadLetter drops a dead letter of a subscription of the educator for good
+func (s *WebhookService) PurgeMyDeadLetter(ctx context.Context, subscriptionId int64, id int64) error {
+	if _, err := s.getMyDeadLetter(ctx, subscriptionId, id); err != nil {
+		return err
+	}
+	return s.PurgeDeadLetter(ctx, id)
+}
+
+// GetDeadLetters returns a page of dead letters newest first, of one subscription or educator when given
+func (s *WebhookService) GetDeadLetters(ctx context.Context, subscriptionId *int64, educatorId *uuid.UUID, skip int, take int) ([]*WebhookDeadLetterResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if skip < 0 || take <= 0 || take > maxDeliveryPageSize {
+		return nil, apperrors.NewBadRequestError("Invalid paging parameters", apperrors.ErrParameterParsingFailed)
+	}
+
+	deadLetters, err := s.repo.GetDeadLetters(ctx, subscriptionId, educatorId, skip, take)
+	if err != nil {
+		log.Error("failed to get webhook dead letters", err)
+		return nil, err
+	}
+	return MapWebhookDeadLettersToResponse(deadLetters), nil
+}
+
+func (s *WebhookService) GetDeadLetter(ctx context.Context, id int64) (*WebhookDeadLetterResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	deadLetter, err := s.repo.GetDeadLetterById(ctx, id)
+	if err != nil {
+		log.Error("failed to get webhook dead letter", err)
+		return nil, err
+	}
+	return MapWebhookDeadLetterToResponse(deadLetter), nil
+}
+
+// RedeliverDeadLetter queues a dead letter for delivery again and removes it. Deliveries of an inactive
+// subscription wait until it is activated again.
+func (s *WebhookService) RedeliverDeadLetter(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.repo.RedeliverDeadLetter(ctx, id, time.Now().UTC()); err != nil {
+		log.Error("failed to redeliver webhook dead letter", err)
+		return err
+	}
+
+	log.Infof("Webhook dead letter %d queued for redelivery", id)
+	return nil
+}
+
+// PurgeDeadLetter drops a dead letter for good
+func (s *WebhookService) PurgeDeadLetter(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := s.repo.DeleteDeadLetter(ctx, id); err != nil {
+		log.Error("failed to delete webhook dead letter", err)
+		return err
+	}
+
+	log.Infof("Webhook dead letter %d purged", id)
+	return nil
+}
+
+// getMyDeadLetter retrieves a dead letter of a subscription of the educator, dead letters of other
+// subscriptions are reported as not found
+func (s *WebhookService) getMyDeadLetter(ctx context.Context, subscriptionId int64, id int64) (*entities.WebhookDeadLetter, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if _, err := s.getMySubscription(ctx, subscriptionId); err != nil {
+		return nil, err
+	}
+
+	deadLetter, err := s.repo.GetDeadLetterById(ctx, id)
+	if err != nil {
+		log.Error("failed to get webhook dead letter", err)
+		return nil, err
+	}
+	if deadLetter.SubscriptionId != subscriptionId {
+		return nil, apperrors.NewNotFound("Webhook dead letter not found", apperrors.ErrResourceNotFound)
+	}
+	return deadLetter, nil
+}
@@ -0,0 +1,150 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// maxErrorBodySize bounds the part of a rejected response kept in the delivery log
+const maxErrorBodySize = 512
+
+type DeliveryRepository interface {
+	ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*entities.DueWebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id int64, statusCode int, deliveredAt time.Time) error
+	RecordFailure(ctx context.Context, id int64, statusCode *int, lastError string, nextAttemptAt time.Time) error
+	DeadLetterDelivery(ctx context.Context, id int64, statusCode *int, lastError string, deadLetteredAt time.Time) error
+	DeleteDeliveriesBefore(ctx context.Context, cutoff time.Time) error
+}
+
+// DeliveryJob periodically posts the queued webhook deliveries to their subscribers. A delivery may be
+// posted twice when the job stops between the post and recording it, subscribers deduplicate by event id.
+type DeliveryJob struct {
+	log    logger.Logger
+	repo   DeliveryRepository
+	client *http.Client
+	cfg    *config.WebhookConfig
+}
+
+func NewDeliveryJob(log logger.Logger, repo DeliveryRepository, httpClient *http.Client, cfg *config.WebhookConfig) *DeliveryJob {
+	// Redirects are not followed, the signed body must reach the registered URL only
+	client := *httpClient
+	client.Timeout = time.Duration(cfg.TimeoutMs) * time.Millisecond
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	return &DeliveryJob{log: log, repo: repo, client: &client, cfg: cfg}
+}
+
+// Run delivers due webhooks on every interval until the context is cancelled
+func (j *DeliveryJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.DeliveryIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.DeliverDue(ctx); err != nil {
+				j.log.Errorf("Failed to deliver webhooks: %v", err)
+			}
+			cutoff := time.Now().UTC().AddDate(0, 0, -j.cfg.RetentionDays)
+			if err := j.repo.DeleteDeliveriesBefore(ctx, cutoff); err != nil {
+				j.log.Errorf("Failed to purge webhook deliveries: %v", err)
+			}
+		}
+	}
+}
+
+// DeliverDue posts a batch of due deliveries. A failed delivery is retried with an exponential backoff
+// until it runs out of attempts, then it is marked failed and parked in the dead letters for redelivery.
+func (j *DeliveryJob) DeliverDue(ctx context.Context) error {
+	now := time.Now().UTC()
+	// The lease outlasts a full batch of timed out posts, so no other worker picks the batch up meanwhile
+	lease := time.Duration(j.cfg.TimeoutMs*j.cfg.BatchSize)*time.Millisecond + time.Minute
+	deliveries, err := j.repo.ClaimDueDeliveries(ctx, now, now.Add(lease), j.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	delivered := 0
+	for _, d := range deliveries {
+		statusCode, err := j.post(ctx, d)
+		if err == nil {
+			if err := j.repo.MarkDelivered(ctx, d.Id, statusCode, time.Now().UTC()); err != nil {
+				return err
+			}
+			delivered++
+			continue
+		}
+
+		var code *int
+		if statusCode != 0 {
+			code = &statusCode
+		}
+
+		attempts := d.Attempts + 1
+		if attempts >= j.cfg.MaxAttempts {
+			j.log.Warnf("Webhook delivery %d to subscription %d dead-lettered after %d attempts: %v", d.Id, d.SubscriptionId, attempts, err)
+			if err := j.repo.DeadLetterDelivery(ctx, d.Id, code, err.Error(), time.Now().UTC()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		nextAttemptAt := time.Now().UTC().Add(j.backoff(d.Attempts))
+		if err := j.repo.RecordFailure(ctx, d.Id, code, err.Error(), nextAttemptAt); err != nil {
+			return err
+		}
+	}
+
+	if delivered > 0 {
+		j.log.Infof("Delivered %d webhooks", delivered)
+	}
+	return nil
+}
+
+// post sends a delivery and returns the status code of the response, zero when none was received
+func (j *DeliveryJob) post(ctx context.Context, d *entities.DueWebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Url, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIdHeader, d.EventId.String())
+	req.Header.Set(EventTypeHeader, d.EventType)
+	req.Header.Set(SignatureHeader, Sign(d.Secret, time.Now(), d.Payload))
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return resp.StatusCode, fmt.Errorf("subscriber responded %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// backoff doubles the wait between attempts of a delivery, from the configured backoff up to its maximum
+func (j *DeliveryJob) backoff(attempts int) time.Duration {
+	wait := time.Duration(j.cfg.BackoffSeconds) * time.Second
+	maxWait := time.Duration(j.cfg.MaxBackoffSeconds) * time.Second
+	for range attempts {
+		wait *= 2
+		if wait >= maxWait {
+			return maxWait
+		}
+	}
+	return wait
+}
@@ -0,0 +1,85 @@
+package webhooks
+
+import (
+	"slices"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// Event types subscribers can filter on
+const (
+	EventBookingCreated   = "booking.created"
+	EventBookingCancelled = "booking.cancelled"
+	EventScheduleUpdated  = "schedule.updated"
+)
+
+// Changes reported by schedule.updated events
+const (
+	ChangeWorkingPeriodCreated  = "working_period.created"
+	ChangeWorkingPeriodUpdated  = "working_period.updated"
+	ChangeWorkingPeriodDeleted  = "working_period.deleted"
+	ChangeRecurrenceCreated     = "recurrence.created"
+	ChangeRecurrenceDeleted     = "recurrence.deleted"
+	ChangeScheduledEventCreated = "scheduled_event.created"
+	ChangeScheduledEventDeleted = "scheduled_event.deleted"
+)
+
+// BookingData is the data of booking events
+type BookingData struct {
+	Id               int64     `json:"id"`
+	EducatorId       string    `json:"educatorId"`
+	StudentId        string    `json:"studentId"`
+	ProductId        int64     `json:"productId"`
+	ScheduledEventId *int64    `json:"scheduledEventId"`
+	SessionTypeId    *int64    `json:"sessionTypeId"`
+	Title            string    `json:"title"`
+	StartTime        time.Time `json:"startTime"`
+	EndTime          time.Time `json:"endTime"`
+	Status           string    `json:"status"`
+	Price            float64   `json:"price"`
+}
+
+// ScheduleData is the data of schedule events, EntityId is set when the changed entity is known
+type ScheduleData struct {
+	EducatorId string `json:"educatorId"`
+	Change     string `json:"change"`
+	EntityId   *int64 `json:"entityId"`
+}
+
+// eventFields are the data fields of each event type a payload template may select
+var eventFields = map[string][]string{
+	EventBookingCreated:   {"id", "educatorId", "studentId", "productId", "scheduledEventId", "sessionTypeId", "title", "startTime", "endTime", "status", "price"},
+	EventBookingCancelled: {"id", "educatorId", "studentId", "productId", "scheduledEventId", "sessionTypeId", "title", "startTime", "endTime", "status", "price"},
+	EventScheduleUpdated:  {"educatorId", "change", "entityId"},
+}
+
+// EventTypes lists the event types subscribers can filter on
+func EventTypes() []string {
+	return []string{EventBookingCreated, EventBookingCancelled, EventScheduleUpdated}
+}
+
+func knownField(eventTypes []string, field string) bool {
+	for _, eventType := range eventTypes {
+		if slices.Contains(eventFields[eventType], field) {
+			return true
+		}
+	}
+	return false
+}
+
+func NewBookingData(b *entities.Booking) *BookingData {
+	return &BookingData{
+		Id:               b.Id,
+		EducatorId:       b.EducatorId.String(),
+		StudentId:        b.StudentId.String(),
+		ProductId:        b.ProductId,
+		ScheduledEventId: b.ScheduledEventId,
+		SessionTypeId:    b.SessionTypeId,
+		Title:            b.Title,
+		StartTime:        b.StartTime,
+		EndTime:          b.EndTime,
+		Status:           b.Status.String(),
+		Price:            b.Price,
+	}
+}
@@ -0,0 +1,447 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+type WebhookHandler struct {
+	service *WebhookService
+}
+
+func NewWebhookHandler(service *WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// GetMySubscriptions retrieves the webhook subscriptions of the current educator.
+// @Summary      Retrieve my webhook subscriptions
+// @Description  Retrieves the webhook subscriptions of the educator, including inactive ones. Secrets are not returned.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Success      200  {array}   WebhookSubscriptionResponse  "Webhook subscriptions"
+// @Failure      401  {object}  error                        "Unauthorized"
+// @Router       /api/v1/webhooks [get]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) GetMySubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.service.GetMySubscriptions(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, subscriptions)
+}
+
+// CreateSubscription registers a webhook subscription for the current educator.
+// @Summary      Create webhook subscription
+// @Description  Registers an https URL that receives the selected booking and schedule events of the educator. Deliveries are signed with the returned secret in the X-Webhook-Signature header as 't=<unix time>,v1=<hex HMAC-SHA256 of "<t>.<body>">'. The secret is only returned here.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        subscription  body      WebhookSubscriptionRequest   true  "Webhook URL, event types and payload fields"
+// @Success      201           {object}  WebhookSubscriptionResponse  "Created webhook subscription with its secret"
+// @Failure      400           {object}  error                        "Invalid input"
+// @Failure      422           {object}  error                        "Subscription limit reached"
+// @Router       /api/v1/webhooks [post]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var request *WebhookSubscriptionRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	subscription, err := h.service.CreateSubscription(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, subscription)
+}
+
+// UpdateSubscription updates a webhook subscription of the current educator.
+// @Summary      Update webhook subscription
+// @Description  Replaces the URL, event types, payload fields and active flag of the subscription. The secret is kept.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        id            path      int                          true  "Webhook subscription ID"
+// @Param        subscription  body      WebhookSubscriptionRequest   true  "Webhook URL, event types and payload fields"
+// @Success      200           {object}  WebhookSubscriptionResponse  "Updated webhook subscription"
+// @Failure      400           {object}  error                        "Invalid input"
+// @Failure      404           {object}  error                        "Webhook subscription not found"
+// @Router       /api/v1/webhooks/{id} [put]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var request *WebhookSubscriptionRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	subscription, err := h.service.UpdateSubscription(r.Context(), id, request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, subscription)
+}
+
+// DeleteSubscription deletes a webhook subscription of the current educator.
+// @Summary      Delete webhook subscription
+// @Description  Deletes the subscription together with its delivery log. Pending deliveries and dead letters are dropped.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Webhook subscription ID"
+// @Success      204  "Webhook subscription deleted successfully"
+// @Failure      404  {object}  error  "Webhook subscription not found"
+// @Router       /api/v1/webhooks/{id} [delete]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.DeleteSubscription(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeliveries retrieves the delivery log of a webhook subscription.
+// @Summary      Retrieve webhook deliveries
+// @Description  Retrieves the deliveries of the subscription, newest first, with their payload, status, attempts and the last response or error.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int  true   "Webhook subscription ID"
+// @Param        skip  query     int  false  "Number of deliveries to skip"
+// @Param        take  query     int  false  "Number of deliveries to return, at most 100"
+// @Success      200   {array}   WebhookDeliveryResponse  "Webhook deliveries"
+// @Failure      400   {object}  error                    "Invalid input parameters"
+// @Failure      404   {object}  error                    "Webhook subscription not found"
+// @Router       /api/v1/webhooks/{id}/deliveries [get]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	skip, err := api.ParseIntQuery(w, r, "skip", 0)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	take, err := api.ParseIntQuery(w, r, "take", 20)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(r.Context(), id, skip, take)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, deliveries)
+}
+
+// GetMyDeadLetters retrieves the dead letters of a webhook subscription.
+// @Summary      Retrieve webhook dead letters
+// @Description  Retrieves the deliveries of the subscription that ran out of attempts, newest first, with their payload and the last response or error.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int  true   "Webhook subscription ID"
+// @Param        skip  query     int  false  "Number of dead letters to skip"
+// @Param        take  query     int  false  "Number of dead letters to return, at most 100"
+// @Success      200   {array}   WebhookDeadLetterResponse  "Webhook dead letters"
+// @Failure      400   {object}  error                      "Invalid input parameters"
+// @Failure      404   {object}  error                      "Webhook subscription not found"
+// @Router       /api/v1/webhooks/{id}/dead-letters [get]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) GetMyDeadLetters(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	skip, err := api.ParseIntQuery(w, r, "skip", 0)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	take, err := api.ParseIntQuery(w, r, "take", 20)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	deadLetters, err := h.service.GetMyDeadLetters(r.Context(), id, skip, take)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, deadLetters)
+}
+
+// RedeliverMyDeadLetters redelivers all dead letters of a webhook subscription.
+// @Summary      Redeliver webhook dead letters
+// @Description  Queues every dead letter of the subscription for delivery again with the same event id, oldest first, and removes them from the dead letters. Deliveries start over with a full set of attempts.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                        true  "Webhook subscription ID"
+// @Success      200  {object}  WebhookRedeliveryResponse  "Number of redelivered dead letters"
+// @Failure      404  {object}  error                      "Webhook subscription not found"
+// @Router       /api/v1/webhooks/{id}/dead-letters/redeliver [post]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) RedeliverMyDeadLetters(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	response, err := h.service.RedeliverMyDeadLetters(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, response)
+}
+
+// RedeliverMyDeadLetter redelivers a dead letter of a webhook subscription.
+// @Summary      Redeliver webhook dead letter
+// @Description  Queues the dead letter for delivery again with the same event id and removes it from the dead letters.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        id            path      int    true  "Webhook subscription ID"
+// @Param        deadLetterId  path      int    true  "Webhook dead letter ID"
+// @Success      204  "Dead letter queued for redelivery"
+// @Failure      404  {object}  error  "Webhook dead letter not found"
+// @Router       /api/v1/webhooks/{id}/dead-letters/{deadLetterId}/redeliver [post]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) RedeliverMyDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	deadLetterId, err := api.ParseLongParam(w, r, "deadLetterId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.RedeliverMyDeadLetter(r.Context(), id, deadLetterId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PurgeMyDeadLetter deletes a dead letter of a webhook subscription.
+// @Summary      Purge webhook dead letter
+// @Description  Drops the dead letter for good without redelivering it.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        id            path      int    true  "Webhook subscription ID"
+// @Param        deadLetterId  path      int    true  "Webhook dead letter ID"
+// @Success      204  "Dead letter purged successfully"
+// @Failure      404  {object}  error  "Webhook dead letter not found"
+// @Router       /api/v1/webhooks/{id}/dead-letters/{deadLetterId} [delete]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) PurgeMyDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	deadLetterId, err := api.ParseLongParam(w, r, "deadLetterId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.PurgeMyDeadLetter(r.Context(), id, deadLetterId)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeadLetters retrieves the webhook dead letters of all subscriptions.
+// @Summary      Retrieve all webhook dead letters
+// @Description  Retrieves the webhook deliveries that ran out of attempts, newest first, optionally of one subscription or educator.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        subscriptionId  query     int     false  "Webhook subscription ID"
+// @Param        educatorId      query     string  false  "Educator ID (UUID)"
+// @Param        skip            query     int     false  "Number of dead letters to skip"
+// @Param        take            query     int     false  "Number of dead letters to return, at most 100"
+// @Success      200             {array}   WebhookDeadLetterResponse  "Webhook dead letters"
+// @Failure      400             {object}  error                      "Invalid input parameters"
+// @Router       /api/v1/admin/webhooks/dead-letters [get]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	skip, err := api.ParseIntQuery(w, r, "skip", 0)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	take, err := api.ParseIntQuery(w, r, "take", 20)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var subscriptionId *int64
+	if r.URL.Query().Get("subscriptionId") != "" {
+		id, err := api.ParseLongQuery(w, r, "subscriptionId")
+		if err != nil {
+			api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+			return
+		}
+		subscriptionId = &id
+	}
+
+	var educatorId *uuid.UUID
+	if r.URL.Query().Get("educatorId") != "" {
+		id, err := api.ParseUUIDQuery(w, r, "educatorId")
+		if err != nil {
+			api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+			return
+		}
+		educatorId = &id
+	}
+
+	deadLetters, err := h.service.GetDeadLetters(r.Context(), subscriptionId, educatorId, skip, take)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, deadLetters)
+}
+
+// GetDeadLetter retrieves a webhook dead letter.
+// @Summary      Retrieve webhook dead letter
+// @Description  Retrieves a webhook delivery that ran out of attempts with its payload and the last response or error.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                        true  "Webhook dead letter ID"
+// @Success      200  {object}  WebhookDeadLetterResponse  "Webhook dead letter"
+// @Failure      404  {object}  error                      "Webhook dead letter not found"
+// @Router       /api/v1/admin/webhooks/dead-letters/{id} [get]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	deadLetter, err := h.service.GetDeadLetter(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, deadLetter)
+}
+
+// RedeliverDeadLetter redelivers a webhook dead letter.
+// @Summary      Redeliver webhook dead letter (admin)
+// @Description  Queues the dead letter for delivery again with the same event id and removes it from the dead letters. Deliveries of an inactive subscription wait until it is activated again.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Webhook dead letter ID"
+// @Success      204  "Dead letter queued for redelivery"
+// @Failure      404  {object}  error  "Webhook dead letter not found"
+// @Router       /api/v1/admin/webhooks/dead-letters/{id}/redeliver [post]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) RedeliverDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.RedeliverDeadLetter(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PurgeDeadLetter deletes a webhook dead letter.
+// @Summary      Purge webhook dead letter (admin)
+// @Description  Drops the dead letter for good without redelivering it.
+// @Tags         Webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int    true  "Webhook dead letter ID"
+// @Success      204  "Dead letter purged successfully"
+// @Failure      404  {object}  error  "Webhook dead letter not found"
+// @Router       /api/v1/admin/webhooks/dead-letters/{id} [delete]
+// @Security 	 BearerAuth
+func (h *WebhookHandler) PurgeDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := api.ParseLongParam(w, r, "id")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	err = h.service.PurgeDeadLetter(r.Context(), id)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,110 @@
+package webhooks
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapRequestToWebhookSubscription(request *WebhookSubscriptionRequest, educatorId uuid.UUID, secret string) *entities.WebhookSubscription {
+	now := time.Now().UTC()
+	active := true
+	if request.Active != nil {
+		active = *request.Active
+	}
+
+	return &entities.WebhookSubscription{
+		EducatorId: educatorId,
+		Url:        request.Url,
+		Secret:     secret,
+		EventTypes: sortedSet(request.EventTypes),
+		Fields:     sortedSet(request.Fields),
+		Active:     active,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// sortedSet sorts and deduplicates values, an empty set is stored as an empty array rather than NULL
+func sortedSet(values []string) pq.StringArray {
+	return slices.Compact(append(pq.StringArray{}, slices.Sorted(slices.Values(values))...))
+}
+
+func MapWebhookSubscriptionToResponse(s *entities.WebhookSubscription) *WebhookSubscriptionResponse {
+	fields := []string(s.Fields)
+	if fields == nil {
+		fields = []string{}
+	}
+
+	return &WebhookSubscriptionResponse{
+		Id:         s.Id,
+		Url:        s.Url,
+		EventTypes: s.EventTypes,
+		Fields:     fields,
+		Active:     s.Active,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
+
+func MapWebhookSubscriptionsToResponse(subscriptions []*entities.WebhookSubscription) []*WebhookSubscriptionResponse {
+	result := make([]*WebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		result = append(result, MapWebhookSubscriptionToResponse(s))
+	}
+	return result
+}
+
+func MapWebhookDeliveryToResponse(d *entities.WebhookDelivery) *WebhookDeliveryResponse {
+	response := &WebhookDeliveryResponse{
+		Id:             d.Id,
+		EventId:        d.EventId.String(),
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.Status == entities.WebhookDeliveryPending {
+		response.NextAttemptAt = &d.NextAttemptAt
+	}
+	return response
+}
+
+func MapWebhookDeliveriesToResponse(deliveries []*entities.WebhookDelivery) []*WebhookDeliveryResponse {
+	result := make([]*WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, MapWebhookDeliveryToResponse(d))
+	}
+	return result
+}
+
+func MapWebhookDeadLetterToResponse(d *entities.WebhookDeadLetter) *WebhookDeadLetterResponse {
+	return &WebhookDeadLetterResponse{
+		Id:             d.Id,
+		SubscriptionId: d.SubscriptionId,
+		DeliveryId:     d.DeliveryId,
+		EventId:        d.EventId.String(),
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeadLetteredAt: d.DeadLetteredAt,
+	}
+}
+
+func MapWebhookDeadLettersToResponse(deadLetters []*entities.WebhookDeadLetter) []*WebhookDeadLetterResponse {
+	result := make([]*WebhookDeadLetterResponse, 0, len(deadLetters))
+	for _, d := range deadLetters {
+		result = append(result, MapWebhookDeadLetterToResponse(d))
+	}
+	return result
+}
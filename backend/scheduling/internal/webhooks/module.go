@@ -0,0 +1,31 @@
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeWebhookService(log logger.Logger, db *sqlx.DB, cfg *config.WebhookConfig) *WebhookService {
+	repo := NewWebhookRepository(db)
+	service := NewWebhookService(log, repo, cfg)
+	return service
+}
+
+func InitializeWebhookHTTPHandler(service *WebhookService) http.Handler {
+	handler := NewWebhookHandler(service)
+	return Routes(handler)
+}
+
+func InitializeAdminWebhookHTTPHandler(service *WebhookService) http.Handler {
+	handler := NewWebhookHandler(service)
+	return AdminRoutes(handler)
+}
+
+func InitializeDeliveryJob(log logger.Logger, db *sqlx.DB, httpClient *http.Client, cfg *config.WebhookConfig) *DeliveryJob {
+	repo := NewWebhookRepository(db)
+	return NewDeliveryJob(log, repo, httpClient, cfg)
+}
@@ -0,0 +1,266 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+type WebhookRepo struct {
+	db *sqlx.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+// GetSubscriptionById retrieves a webhook subscription, including inactive ones
+func (r *WebhookRepo) GetSubscriptionById(ctx context.Context, id int64) (*entities.WebhookSubscription, error) {
+	const query = `
+		SELECT id, educator_id, url, secret, event_types, fields, active, created_at, updated_at
+		FROM webhook_subscription
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.WebhookSubscription](ctx, r.db, query, id)
+}
+
+// GetEducatorSubscriptions retrieves the webhook subscriptions of an educator, oldest first
+func (r *WebhookRepo) GetEducatorSubscriptions(ctx context.Context, educatorId uuid.UUID) ([]*entities.WebhookSubscription, error) {
+	const query = `
+		SELECT id, educator_id, url, secret, event_types, fields, active, created_at, updated_at
+		FROM webhook_subscription
+		WHERE educator_id = $1
+		ORDER BY id
+	`
+	return database.FetchMultiple[entities.WebhookSubscription](ctx, r.db, query, educatorId)
+}
+
+// CountEducatorSubscriptions counts the webhook subscriptions of an educator
+func (r *WebhookRepo) CountEducatorSubscriptions(ctx context.Context, educatorId uuid.UUID) (int, error) {
+	const query = `SELECT COUNT(*) FROM webhook_subscription WHERE educator_id = $1`
+	var count int
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &count, query, educatorId); err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return count, nil
+}
+
+// GetActiveSubscriptionsForEvent retrieves the active subscriptions of an educator to an event type
+func (r *WebhookRepo) GetActiveSubscriptionsForEvent(ctx context.Context, educatorId uuid.UUID, eventType string) ([]*entities.WebhookSubscription, error) {
+	const query = `
+		SELECT id, educator_id, url, secret, event_types, fields, active, created_at, updated_at
+		FROM webhook_subscription
+		WHERE educator_id = $1 AND active AND $2 = ANY(event_types)
+	`
+	return database.FetchMultiple[entities.WebhookSubscription](ctx, r.db, query, educatorId, eventType)
+}
+
+// AddSubscription adds a new webhook subscription and returns its Id
+func (r *WebhookRepo) AddSubscription(ctx context.Context, subscription *entities.WebhookSubscription) (int64, error) {
+	const query = `
+		INSERT INTO webhook_subscription (educator_id, url, secret, event_types, fields, active, created_at, updated_at)
+		VALUES (:educator_id, :url, :secret, :event_types, :fields, :active, :created_at, :updated_at)
+		RETURNING id
+	`
+	return database.ExecNamedQueryWithResult[int64](ctx, r.db, query, subscription)
+}
+
+// UpdateSubscription replaces the endpoint, filters and template of a subscription, the secret is kept
+func (r *WebhookRepo) UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	const query = `
+		UPDATE webhook_subscription
+		SET url = :url, event_types = :event_types, fields = :fields, active = :active, updated_at = :updated_at
+		WHERE id = :id
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, subscription)
+}
+
+// DeleteSubscription removes a subscription together with its delivery log
+func (r *WebhookRepo) DeleteSubscription(ctx context.Context, id int64) error {
+	const query = `DELETE FROM webhook_subscription WHERE id = $1`
+	return database.ExecQuery(ctx, r.db, query, id)
+}
+
+// AddDelivery queues an event for delivery to a subscription
+func (r *WebhookRepo) AddDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	const query = `
+		INSERT INTO webhook_delivery (subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		VALUES (:subscription_id, :event_id, :event_type, :payload, :status, :next_attempt_at, :created_at)
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, delivery)
+}
+
+// GetSubscriptionDeliveries retrieves a page of the delivery log of a subscription, newest first
+func (r *WebhookRepo) GetSubscriptionDeliveries(ctx context.Context, subscriptionId int64, skip int, take int) ([]*entities.WebhookDelivery, error) {
+	const query = `
+		SELECT id, subscription_id, event_id, event_type, payload, status, attempts, last_status_code, last_error, next_attempt_at, delivered_at, created_at
+		FROM webhook_delivery
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC, id DESC
+		OFFSET $2 LIMIT $3
+	`
+	return database.FetchMultiple[entities.WebhookDelivery](ctx, r.db, query, subscriptionId, skip, take)
+}
+
+// ClaimDueDeliveries leases up to limit pending deliveries of active subscriptions until leaseUntil, so
+// concurrent workers skip them. Deliveries are returned oldest first.
+func (r *WebhookRepo) ClaimDueDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int) ([]*entities.DueWebhookDelivery, error) {
+	const query = `
+		WITH due AS (
+			SELECT d.id FROM webhook_delivery d
+			JOIN webhook_subscription s ON s.id = d.subscription_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND s.active
+			ORDER BY d.id
+			LIMIT $3
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_delivery d SET next_attempt_at = $2
+		FROM due, webhook_subscription s
+		WHERE d.id = due.id AND s.id = d.subscription_id
+		RETURNING d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.last_status_code,
+			d.last_error, d.next_attempt_at, d.delivered_at, d.created_at, s.url, s.secret
+	`
+	var deliveries []*entities.DueWebhookDelivery
+	if err := database.Conn(ctx, r.db).SelectContext(ctx, &deliveries, query, now, leaseUntil, limit); err != nil {
+		return nil, apperrors.NewInternal(err)
+	}
+	return deliveries, nil
+}
+
+// MarkDelivered records the successful attempt of a delivery
+func (r *WebhookRepo) MarkDelivered(ctx context.Context, id int64, statusCode int, deliveredAt time.Time) error {
+	const query = `
+		UPDATE webhook_delivery
+		SET status = 'delivered', attempts = attempts + 1, last_status_code = $2, last_error = NULL, delivered_at = $3
+		WHERE id = $1
+	`
+	return database.ExecQuery(ctx, r.db, query, id, statusCode, deliveredAt)
+}
+
+// RecordFailure records a failed attempt of a delivery, retried at nextAttemptAt
+func (r *WebhookRepo) RecordFailure(ctx context.Context, id int64, statusCode *int, lastError string, nextAttemptAt time.Time) error {
+	const query = `
+		UPDATE webhook_delivery
+		SET attempts = attempts + 1, last_status_code = $2, last_error = $3, next_attempt_at = $4
+		WHERE id = $1
+	`
+	return database.ExecQuery(ctx, r.db, query, id, statusCode, lastError, nextAttemptAt)
+}
+
+// DeadLetterDelivery records the last failed attempt of a delivery, marks it failed and parks a copy in the
+// dead letters, in one statement so a failed delivery is never lost
+func (r *WebhookRepo) DeadLetterDelivery(ctx context.Context, id int64, statusCode *int, lastError string, deadLetteredAt time.Time) error {
+	const query = `
+		WITH failed AS (
+			UPDATE webhook_delivery
+			SET status = 'failed', attempts = attempts + 1, last_status_code = $2, last_error = $3
+			WHERE id = $1
+			RETURNING id, subscription_id, event_id, event_type, payload, attempts, last_status_code, last_error
+		)
+		INSERT INTO webhook_dead_letter (delivery_id, subscription_id, event_id, event_type, payload, attempts, last_status_code, last_error, dead_lettered_at)
+		SELECT id, subscription_id, event_id, event_type, payload, attempts, last_status_code, last_error, $4
+		FROM failed
+	`
+	return database.ExecQuery(ctx, r.db, query, id, statusCode, lastError, deadLetteredAt)
+}
+
+// GetDeadLetters retrieves dead letters newest first, of one subscription or of the subscriptions of one
+// educator when given
+func (r *WebhookRepo) GetDeadLetters(ctx context.Context, subscriptionId *int64, educatorId *uuid.UUID, skip int, take int) ([]*entities.WebhookDeadLetter, error) {
+	const query = `
+		SELECT dl.id, dl.delivery_id, dl.subscription_id, dl.event_id, dl.event_type, dl.payload, dl.attempts,
+			dl.last_status_code, dl.last_error, dl.dead_lettered_at
+		FROM webhook_dead_letter dl
+		JOIN webhook_subscription s ON s.id = dl.subscription_id
+		WHERE ($1::bigint IS NULL OR dl.subscription_id = $1) AND ($2::uuid IS NULL OR s.educator_id = $2)
+		ORDER BY dl.dead_lettered_at DESC, dl.id DESC
+		OFFSET $3 LIMIT $4
+	`
+	return database.FetchMultiple[entities.WebhookDeadLetter](ctx, r.db, query, subscriptionId, educatorId, skip, take)
+}
+
+// GetDeadLetterById retrieves a single dead letter
+func (r *WebhookRepo) GetDeadLetterById(ctx context.Context, id int64) (*entities.WebhookDeadLetter, error) {
+	const query = `
+		SELECT id, delivery_id, subscription_id, event_id, event_type, payload, attempts, last_status_code, last_error, dead_lettered_at
+		FROM webhook_dead_letter
+		WHERE id = $1
+	`
+	return database.FetchSingle[entities.WebhookDeadLetter](ctx, r.db, query, id)
+}
+
+// RedeliverDeadLetter queues a dead letter again as a new pending delivery of the same event and removes
+// it, in one statement. It returns a not found error when no such dead letter exists.
+func (r *WebhookRepo) RedeliverDeadLetter(ctx context.Context, id int64, now time.Time) error {
+	const query = `
+		WITH redelivered AS (
+			DELETE FROM webhook_dead_letter WHERE id = $1
+			RETURNING subscription_id, event_id, event_type, payload
+		)
+		INSERT INTO webhook_delivery (subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		SELECT subscription_id, event_id, event_type, payload, 'pending', $2, $2
+		FROM redelivered
+	`
+	affected, err := r.execAffected(ctx, query, id, now)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return apperrors.NewNotFound("Webhook dead letter not found", apperrors.ErrResourceNotFound)
+	}
+	return nil
+}
+
+// RedeliverSubscriptionDeadLetters queues all dead letters of a subscription again, oldest first, and
+// returns how many were queued
+func (r *WebhookRepo) RedeliverSubscriptionDeadLetters(ctx context.Context, subscriptionId int64, now time.Time) (int64, error) {
+	const query = `
+		WITH redelivered AS (
+			DELETE FROM webhook_dead_letter WHERE subscription_id = $1
+			RETURNING id, subscription_id, event_id, event_type, payload
+		)
+		INSERT INTO webhook_delivery (subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		SELECT subscription_id, event_id, event_type, payload, 'pending', $2, $2
+		FROM redelivered
+		ORDER BY id
+	`
+	return r.execAffected(ctx, query, subscriptionId, now)
+}
+
+// DeleteDeadLetter removes a dead letter. It returns a not found error when no such dead letter exists.
+func (r *WebhookRepo) DeleteDeadLetter(ctx context.Context, id int64) error {
+	const query = `DELETE FROM webhook_dead_letter WHERE id = $1`
+	affected, err := r.execAffected(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return apperrors.NewNotFound("Webhook dead letter not found", apperrors.ErrResourceNotFound)
+	}
+	return nil
+}
+
+func (r *WebhookRepo) execAffected(ctx context.Context, query string, args ...any) (int64, error) {
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return affected, nil
+}
+
+// DeleteDeliveriesBefore removes the delivered and failed deliveries created before the cutoff
+func (r *WebhookRepo) DeleteDeliveriesBefore(ctx context.Context, cutoff time.Time) error {
+	const query = `DELETE FROM webhook_delivery WHERE status <> 'pending' AND created_at < $1`
+	return database.ExecQuery(ctx, r.db, query, cutoff)
+}
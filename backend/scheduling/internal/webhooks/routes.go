@@ -0,0 +1,41 @@
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+func Routes(handler *WebhookHandler) http.Handler {
+	r := chi.NewRouter()
+	r.Use(middleware.RequireRole(auth.EducatorRole))
+
+	// Define routes
+	r.Get("/", handler.GetMySubscriptions)
+	r.Post("/", handler.CreateSubscription)
+	r.Put("/{id}", handler.UpdateSubscription)
+	r.Delete("/{id}", handler.DeleteSubscription)
+	r.Get("/{id}/deliveries", handler.GetDeliveries)
+	r.Get("/{id}/dead-letters", handler.GetMyDeadLetters)
+	r.Post("/{id}/dead-letters/redeliver", handler.RedeliverMyDeadLetters)
+	r.Post("/{id}/dead-letters/{deadLetterId}/redeliver", handler.RedeliverMyDeadLetter)
+	r.Delete("/{id}/dead-letters/{deadLetterId}", handler.PurgeMyDeadLetter)
+
+	return r
+}
+
+func AdminRoutes(handler *WebhookHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Use(middleware.RequireRole(auth.AdminRole))
+	r.Get("/dead-letters", handler.GetDeadLetters)
+	r.Get("/dead-letters/{id}", handler.GetDeadLetter)
+	r.Post("/dead-letters/{id}/redeliver", handler.RedeliverDeadLetter)
+	r.Delete("/dead-letters/{id}", handler.PurgeDeadLetter)
+
+	return r
+}
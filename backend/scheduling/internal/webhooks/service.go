@@ -0,0 +1,260 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const maxDeliveryPageSize = 100
+
+type WebhookRepository interface {
+	GetSubscriptionById(ctx context.Context, id int64) (*entities.WebhookSubscription, error)
+	GetEducatorSubscriptions(ctx context.Context, educatorId uuid.UUID) ([]*entities.WebhookSubscription, error)
+	CountEducatorSubscriptions(ctx context.Context, educatorId uuid.UUID) (int, error)
+	GetActiveSubscriptionsForEvent(ctx context.Context, educatorId uuid.UUID, eventType string) ([]*entities.WebhookSubscription, error)
+	AddSubscription(ctx context.Context, subscription *entities.WebhookSubscription) (int64, error)
+	UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id int64) error
+	AddDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	GetSubscriptionDeliveries(ctx context.Context, subscriptionId int64, skip int, take int) ([]*entities.WebhookDelivery, error)
+	GetDeadLetters(ctx context.Context, subscriptionId *int64, educatorId *uuid.UUID, skip int, take int) ([]*entities.WebhookDeadLetter, error)
+	GetDeadLetterById(ctx context.Context, id int64) (*entities.WebhookDeadLetter, error)
+	RedeliverDeadLetter(ctx context.Context, id int64, now time.Time) error
+	RedeliverSubscriptionDeadLetters(ctx context.Context, subscriptionId int64, now time.Time) (int64, error)
+	DeleteDeadLetter(ctx context.Context, id int64) error
+}
+
+type WebhookService struct {
+	log  logger.Logger
+	repo WebhookRepository
+	cfg  *config.WebhookConfig
+}
+
+func NewWebhookService(log logger.Logger, repo WebhookRepository, cfg *config.WebhookConfig) *WebhookService {
+	return &WebhookService{log: log, repo: repo, cfg: cfg}
+}
+
+// eventPayload is the body posted to subscribers
+type eventPayload struct {
+	Id         uuid.UUID                  `json:"id"`
+	Type       string                     `json:"type"`
+	OccurredAt time.Time                  `json:"occurredAt"`
+	Data       map[string]json.RawMessage `json:"data"`
+}
+
+func (s *WebhookService) GetMySubscriptions(ctx context.Context) ([]*WebhookSubscriptionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	subscriptions, err := s.repo.GetEducatorSubscriptions(ctx, userId)
+	if err != nil {
+		log.Error("failed to get webhook subscriptions", err)
+		return nil, err
+	}
+	return MapWebhookSubscriptionsToResponse(subscriptions), nil
+}
+
+// CreateSubscription registers a URL for events of the educator. The signing secret is generated and
+// returned once.
+func (s *WebhookService) CreateSubscription(ctx context.Context, request *WebhookSubscriptionRequest) (*WebhookSubscriptionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if err := request.Validate(s.cfg.AllowInsecureUrls); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountEducatorSubscriptions(ctx, userId)
+	if err != nil {
+		log.Error("failed to count webhook subscriptions", err)
+		return nil, err
+	}
+	if count >= s.cfg.MaxSubscriptions {
+		return nil, apperrors.NewUnprocessedEntity(fmt.Sprintf("At most %d webhook subscriptions are allowed", s.cfg.MaxSubscriptions), apperrors.ErrWebhookLimitReached)
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		log.Error("failed to generate webhook secret", err)
+		return nil, apperrors.NewInternal(err)
+	}
+
+	subscription := MapRequestToWebhookSubscription(request, userId, secret)
+	id, err := s.repo.AddSubscription(ctx, subscription)
+	if err != nil {
+		log.Error("failed to add webhook subscription", err)
+		return nil, err
+	}
+	subscription.Id = id
+
+	response := MapWebhookSubscriptionToResponse(subscription)
+	response.Secret = &secret
+	return response, nil
+}
+
+// UpdateSubscription replaces the URL, filters and template of a subscription, the secret is kept
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id int64, request *WebhookSubscriptionRequest) (*WebhookSubscriptionResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if err := request.Validate(s.cfg.AllowInsecureUrls); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.getMySubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := MapRequestToWebhookSubscription(request, existing.EducatorId, existing.Secret)
+	subscription.Id = existing.Id
+	subscription.CreatedAt = existing.CreatedAt
+	if err := s.repo.UpdateSubscription(ctx, subscription); err != nil {
+		log.Error("failed to update webhook subscription", err)
+		return nil, err
+	}
+
+	return MapWebhookSubscriptionToResponse(subscription), nil
+}
+
+// DeleteSubscription removes a subscription, pending deliveries and dead letters are dropped with its
+// delivery log
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id int64) error {
+	log := logger.FromContext(ctx, s.log)
+
+	if _, err := s.getMySubscription(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteSubscription(ctx, id); err != nil {
+		log.Error("failed to delete webhook subscription", err)
+		return err
+	}
+	return nil
+}
+
+// GetDeliveries returns a page of the delivery log of a subscription, newest first
+func (s *WebhookService) GetDeliveries(ctx context.Context, id int64, skip int, take int) ([]*WebhookDeliveryResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if skip < 0 || take <= 0 || take > maxDeliveryPageSize {
+		return nil, apperrors.NewBadRequestError("Invalid paging parameters", apperrors.ErrParameterParsingFailed)
+	}
+
+	if _, err := s.getMySubscription(ctx, id); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.GetSubscriptionDeliveries(ctx, id, skip, take)
+	if err != nil {
+		log.Error("failed to get webhook deliveries", err)
+		return nil, err
+	}
+	return MapWebhookDeliveriesToResponse(deliveries), nil
+}
+
+func (s *WebhookService) getMySubscription(ctx context.Context, id int64) (*entities.WebhookSubscription, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	subscription, err := s.repo.GetSubscriptionById(ctx, id)
+	if err != nil {
+		log.Error("failed to get webhook subscription", err)
+		return nil, err
+	}
+	if subscription.EducatorId != userId {
+		return nil, apperrors.NewForbidden("Access denied")
+	}
+	return subscription, nil
+}
+
+// Dispatch queues an event of an educator for every active subscription to its type. Within a request
+// transaction the deliveries are only kept when the request commits. Failures are logged and never fail
+// the change that raised the event.
+func (s *WebhookService) Dispatch(ctx context.Context, educatorId uuid.UUID, eventType string, data any) {
+	log := logger.FromContext(ctx, s.log)
+
+	subscriptions, err := s.repo.GetActiveSubscriptionsForEvent(ctx, educatorId, eventType)
+	if err != nil {
+		log.Error("failed to get webhook subscriptions", err)
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		log.Error("failed to encode webhook event data", err)
+		return
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		log.Error("failed to decode webhook event data", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, subscription := range subscriptions {
+		// Each subscription gets its own event id, subscribers deduplicate retries by it
+		eventId := uuid.New()
+		payload, err := json.Marshal(&eventPayload{
+			Id:         eventId,
+			Type:       eventType,
+			OccurredAt: now,
+			Data:       applyTemplate(fields, subscription.Fields),
+		})
+		if err != nil {
+			log.Error("failed to encode webhook payload", err)
+			return
+		}
+
+		delivery := &entities.WebhookDelivery{
+			SubscriptionId: subscription.Id,
+			EventId:        eventId,
+			EventType:      eventType,
+			Payload:        payload,
+			Status:         entities.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}
+		if err := s.repo.AddDelivery(ctx, delivery); err != nil {
+			log.Errorf("failed to queue webhook delivery for subscription %d: %v", subscription.Id, err)
+		}
+	}
+}
+
+// applyTemplate keeps the data fields selected by a subscription, all of them when it selects none
+func applyTemplate(data map[string]json.RawMessage, selected []string) map[string]json.RawMessage {
+	if len(selected) == 0 {
+		return data
+	}
+	result := make(map[string]json.RawMessage, len(selected))
+	for _, field := range selected {
+		if value, ok := data[field]; ok {
+			result[field] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,138 @@
+package widgets
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+// swagger:model WidgetConfigRequest
+type WidgetConfigRequest struct {
+	Enabled            bool     `json:"enabled"`
+	AllowedOrigins     []string `json:"allowedOrigins"`
+	RateLimitPerMinute int      `json:"rateLimitPerMinute"`
+}
+
+// swagger:model WidgetConfigResponse
+type WidgetConfigResponse struct {
+	Enabled            bool       `json:"enabled"`
+	AllowedOrigins     []string   `json:"allowedOrigins"`
+	RateLimitPerMinute int        `json:"rateLimitPerMinute"`
+	HasApiKey          bool       `json:"hasApiKey"`
+	UpdatedAt          *time.Time `json:"updatedAt"`
+}
+
+// swagger:model WidgetApiKeyResponse
+type WidgetApiKeyResponse struct {
+	ApiKey string `json:"apiKey"`
+}
+
+// swagger:model AvailabilityChangesResponse
+type AvailabilityChangesResponse struct {
+	EducatorId uuid.UUID                     `json:"educatorId"`
+	Cursor     string                        `json:"cursor"`
+	HasMore    bool                          `json:"hasMore"`
+	FullSync   bool                          `json:"fullSync"`
+	Changes    []*AvailabilityChangeResponse `json:"changes"`
+}
+
+// swagger:model AvailabilityChangeResponse
+type AvailabilityChangeResponse struct {
+	Kind            string    `json:"kind"`
+	Id              int64     `json:"id"`
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	Removed         bool      `json:"removed"`
+	Title           *string   `json:"title"`
+	SessionTypeId   *int64    `json:"sessionTypeId"`
+	MaxParticipants *int      `json:"maxParticipants"`
+	ChangedAt       time.Time `json:"changedAt"`
+}
+
+// Granularities of API usage buckets
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+)
+
+// swagger:model ApiUsageResponse
+type ApiUsageResponse struct {
+	FromDate    time.Time `json:"fromDate"`
+	ToDate      time.Time `json:"toDate"`
+	Granularity string    `json:"granularity"`
+	// UpdatedEvery is how many seconds the usage may lag behind the requests made
+	UpdatedEvery int                    `json:"updatedEvery"`
+	Keys         []*ApiKeyUsageResponse `json:"keys"`
+}
+
+// swagger:model ApiKeyUsageResponse
+type ApiKeyUsageResponse struct {
+	// KeyId identifies a key across rotations, it is not the key itself
+	KeyId string `json:"keyId"`
+	// Current tells whether the key is the one currently issued
+	Current     bool  `json:"current"`
+	Requests    int64 `json:"requests"`
+	Errors      int64 `json:"errors"`
+	RateLimited int64 `json:"rateLimited"`
+	// ErrorRate is the share of requests that failed, rate limited ones included
+	ErrorRate float64                   `json:"errorRate"`
+	Buckets   []*ApiUsageBucketResponse `json:"buckets"`
+}
+
+// swagger:model ApiUsageBucketResponse
+type ApiUsageBucketResponse struct {
+	Start       time.Time `json:"start"`
+	Endpoint    string    `json:"endpoint"`
+	Requests    int64     `json:"requests"`
+	Errors      int64     `json:"errors"`
+	RateLimited int64     `json:"rateLimited"`
+}
+
+func (w *WidgetConfigRequest) Validate() error {
+	var errors []apperrors.ValidationErrorDetail
+
+	if len(w.AllowedOrigins) > 20 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "AllowedOrigins",
+			Message: "must contain at most 20 origins",
+		})
+	}
+
+	for _, origin := range w.AllowedOrigins {
+		if !isOrigin(origin) {
+			errors = append(errors, apperrors.ValidationErrorDetail{
+				Field:   "AllowedOrigins",
+				Message: "must contain only origins in scheme://host[:port] format",
+			})
+			break
+		}
+	}
+
+	if w.RateLimitPerMinute < 0 || w.RateLimitPerMinute > 1000 {
+		errors = append(errors, apperrors.ValidationErrorDetail{
+			Field:   "RateLimitPerMinute",
+			Message: "must be between 0 and 1000",
+		})
+	}
+
+	if len(errors) > 0 {
+		return apperrors.NewValidation("Widget config request data failed validation", apperrors.ErrValidationFailed, errors)
+	}
+
+	return nil
+}
+
+// isOrigin reports whether a value is a bare http(s) origin without path, query or credentials
+func isOrigin(value string) bool {
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || u.User != nil {
+		return false
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return false
+	}
+	return (u.Path == "" || u.Path == "/") && u.RawQuery == "" && u.Fragment == ""
+}
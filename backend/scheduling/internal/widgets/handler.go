@@ -0,0 +1,295 @@
+package widgets
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/internal/api"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+)
+
+const apiKeyHeader = "X-Api-Key"
+
+type WidgetHandler struct {
+	service *WidgetService
+}
+
+func NewWidgetHandler(service *WidgetService) *WidgetHandler {
+	return &WidgetHandler{service: service}
+}
+
+// GetMyWidgetConfig retrieves the widget config of the current educator.
+// @Summary      Retrieve my widget config
+// @Description  Retrieves the embeddable widget settings of the educator. The API key itself is never returned.
+// @Tags         Widget
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  WidgetConfigResponse  "Widget config"
+// @Failure      401  {object}  error                 "Unauthorized"
+// @Router       /api/v1/widgets/config [get]
+// @Security 	 BearerAuth
+func (h *WidgetHandler) GetMyWidgetConfig(w http.ResponseWriter, r *http.Request) {
+	cfg, err := h.service.GetMyWidgetConfig(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, cfg)
+}
+
+// UpdateMyWidgetConfig updates the widget config of the current educator.
+// @Summary      Update my widget config
+// @Description  Enables or disables the embeddable widget and sets its allowed origins and per minute rate limit. A zero limit applies the platform default.
+// @Tags         Widget
+// @Accept       json
+// @Produce      json
+// @Param        config  body      WidgetConfigRequest  true  "Widget config"
+// @Success      204     "Widget config saved successfully"
+// @Failure      400     {object}  error                "Invalid input"
+// @Router       /api/v1/widgets/config [put]
+// @Security 	 BearerAuth
+func (h *WidgetHandler) UpdateMyWidgetConfig(w http.ResponseWriter, r *http.Request) {
+	var request *WidgetConfigRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrJsonDecodingFailed))
+		return
+	}
+
+	if err := request.Validate(); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	err = h.service.UpdateMyWidgetConfig(r.Context(), request)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RotateApiKey issues a new widget API key for the current educator.
+// @Summary      Rotate widget API key
+// @Description  Generates a new API key for the embeddable widget and invalidates the previous one. The key is only returned by this call.
+// @Tags         Widget
+// @Accept       json
+// @Produce      json
+// @Success      201  {object}  WidgetApiKeyResponse  "New API key"
+// @Failure      401  {object}  error                 "Unauthorized"
+// @Router       /api/v1/widgets/config/api-key [post]
+// @Security 	 BearerAuth
+func (h *WidgetHandler) RotateApiKey(w http.ResponseWriter, r *http.Request) {
+	key, err := h.service.RotateApiKey(r.Context())
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusCreated, key)
+}
+
+// GetMyApiUsage retrieves the API key usage of the current educator.
+// @Summary      Retrieve my API key usage
+// @Description  Returns request, error and rate limit counts of the educator's widget API keys per endpoint and per hour or day. Counts are recorded in batches and lag behind by up to 'updatedEvery' seconds.
+// @Tags         Widget
+// @Accept       json
+// @Produce      json
+// @Param        fromDate     query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate       query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        granularity  query     string  false  "Bucket size, hour or day (default day)"
+// @Success      200          {object}  ApiUsageResponse  "API key usage"
+// @Failure      400          {object}  error             "Invalid input"
+// @Failure      401          {object}  error             "Unauthorized"
+// @Router       /api/v1/widgets/config/api-key/usage [get]
+// @Security 	 BearerAuth
+func (h *WidgetHandler) GetMyApiUsage(w http.ResponseWriter, r *http.Request) {
+	fromDate, err := api.ParseTimeQuery(w, r, "fromDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	toDate, err := api.ParseTimeQuery(w, r, "toDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	usage, err := h.service.GetMyApiUsage(r.Context(), fromDate, toDate, r.URL.Query().Get("granularity"))
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, usage)
+}
+
+// GetWidgetAvailability retrieves the availability of an educator for an embedded widget.
+// @Summary      Retrieve widget availability
+// @Description  Returns the read-only availability of an educator without authentication. Requests must come from an allowed origin or carry the educator's API key in the 'apiKey' query parameter or the X-Api-Key header, and are rate limited per educator.
+// @Tags         Widget
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string  true   "Educator ID (UUID)"
+// @Param        fromDate    query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate      query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        apiKey      query     string  false  "Widget API key"
+// @Success      200         {object}  sharing.SharedScheduleResponse  "Availability"
+// @Failure      403         {object}  error                           "Origin not allowed"
+// @Failure      429         {object}  error                           "Rate limit exceeded"
+// @Router       /api/v1/widgets/public/educators/{educatorId}/availability [get]
+func (h *WidgetHandler) GetWidgetAvailability(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "Origin")
+
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	fromDate, err := api.ParseTimeQuery(w, r, "fromDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	toDate, err := api.ParseTimeQuery(w, r, "toDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	availability, allowedOrigin, err := h.service.GetWidgetAvailability(
+		r.Context(), educatorId, r.Header.Get("Origin"), apiKeyFromRequest(r), fromDate, toDate,
+	)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	if allowedOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+	}
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(h.service.CacheMaxAge()))
+	api.WriteJson(w, http.StatusOK, availability)
+}
+
+// PollAvailabilityChanges retrieves availability changes of an educator for integrations.
+// @Summary      Poll availability changes
+// @Description  Returns availability entries added, updated or removed since the 'cursor' returned by the previous poll, or a full sync of current entries without one. Requires the educator's API key in the X-Api-Key header or the 'apiKey' query parameter and is limited to a few polls per minute. Supports conditional requests with If-Modified-Since.
+// @Tags         Widget
+// @Accept       json
+// @Produce      json
+// @Param        educatorId  path      string  true   "Educator ID (UUID)"
+// @Param        cursor      query     string  false  "Cursor returned by the previous poll"
+// @Param        apiKey      query     string  false  "Widget API key"
+// @Success      200         {object}  AvailabilityChangesResponse  "Availability changes"
+// @Success      304         "Not modified"
+// @Failure      401         {object}  error                        "Invalid API key"
+// @Failure      429         {object}  error                        "Rate limit exceeded"
+// @Router       /api/v1/widgets/public/educators/{educatorId}/availability/changes [get]
+func (h *WidgetHandler) PollAvailabilityChanges(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	var ifModifiedSince *time.Time
+	if value := r.Header.Get("If-Modified-Since"); value != "" {
+		if parsed, err := http.ParseTime(value); err == nil {
+			ifModifiedSince = &parsed
+		}
+	}
+
+	changes, lastModified, err := h.service.PollAvailabilityChanges(
+		r.Context(), educatorId, apiKeyFromRequest(r), r.URL.Query().Get("cursor"), ifModifiedSince,
+	)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if lastModified != nil {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if changes == nil {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	api.WriteJson(w, http.StatusOK, changes)
+}
+
+// GetApiUsage retrieves the API key usage of an educator for integrations.
+// @Summary      Retrieve API key usage
+// @Description  Returns request, error and rate limit counts of the educator's widget API keys per endpoint and per hour or day, so integrators can follow their consumption. Requires the educator's current API key in the X-Api-Key header or the 'apiKey' query parameter and counts towards the widget rate limit.
+// @Tags         Widget
+// @Accept       json
+// @Produce      json
+// @Param        educatorId   path      string  true   "Educator ID (UUID)"
+// @Param        fromDate     query     string  true   "Start date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        toDate       query     string  true   "End date in YYYY-MM-DDTHH:MM:SSZ format"
+// @Param        granularity  query     string  false  "Bucket size, hour or day (default day)"
+// @Param        apiKey       query     string  false  "Widget API key"
+// @Success      200          {object}  ApiUsageResponse  "API key usage"
+// @Failure      401          {object}  error             "Invalid API key"
+// @Failure      429          {object}  error             "Rate limit exceeded"
+// @Router       /api/v1/widgets/public/educators/{educatorId}/api-usage [get]
+func (h *WidgetHandler) GetApiUsage(w http.ResponseWriter, r *http.Request) {
+	educatorId, err := api.ParseUUIDParam(w, r, "educatorId")
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	fromDate, err := api.ParseTimeQuery(w, r, "fromDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	toDate, err := api.ParseTimeQuery(w, r, "toDate", time.RFC3339)
+	if err != nil {
+		api.WriteError(w, apperrors.NewBadRequestError(err.Error(), apperrors.ErrParameterParsingFailed))
+		return
+	}
+
+	usage, err := h.service.GetApiUsage(
+		r.Context(), educatorId, apiKeyFromRequest(r), fromDate, toDate, r.URL.Query().Get("granularity"),
+	)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "private, no-cache")
+	api.WriteJson(w, http.StatusOK, usage)
+}
+
+// PreflightWidgetAvailability answers CORS preflight requests of embedded widgets. Access is
+// enforced on the actual request, so any origin may send the API key header.
+func (h *WidgetHandler) PreflightWidgetAvailability(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "Origin")
+	if origin := r.Header.Get("Origin"); origin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Methods", http.MethodGet)
+		w.Header().Set("Access-Control-Allow-Headers", apiKeyHeader)
+		w.Header().Set("Access-Control-Max-Age", "600")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// apiKeyFromRequest reads the widget API key from its header, falling back to the query parameter
+func apiKeyFromRequest(r *http.Request) string {
+	if apiKey := r.Header.Get(apiKeyHeader); apiKey != "" {
+		return apiKey
+	}
+	return r.URL.Query().Get("apiKey")
+}
@@ -0,0 +1,59 @@
+package widgets
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// RateLimiter counts widget requests per educator in fixed one minute windows. State is kept in
+// memory, so every replica enforces the limit on its own share of the traffic.
+type RateLimiter struct {
+	mu      sync.Mutex
+	windows map[uuid.UUID]*window
+	now     func() time.Time
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{windows: make(map[uuid.UUID]*window), now: time.Now}
+}
+
+// Allow records a request of an educator and reports whether it fits the per minute limit. When it
+// does not, the number of seconds until the current window closes is returned.
+func (l *RateLimiter) Allow(educatorId uuid.UUID, limit int) (bool, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	w, ok := l.windows[educatorId]
+	if !ok || now.Sub(w.start) >= time.Minute {
+		if len(l.windows) > 10000 {
+			l.prune(now)
+		}
+		w = &window{start: now}
+		l.windows[educatorId] = w
+	}
+
+	if w.count >= limit {
+		retryAfter := int(time.Minute-now.Sub(w.start))/int(time.Second) + 1
+		return false, retryAfter
+	}
+
+	w.count++
+	return true, 0
+}
+
+// prune drops windows that already closed
+func (l *RateLimiter) prune(now time.Time) {
+	for id, w := range l.windows {
+		if now.Sub(w.start) >= time.Minute {
+			delete(l.windows, id)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package widgets
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+func MapWidgetConfigToResponse(cfg *entities.WidgetConfig) *WidgetConfigResponse {
+	if cfg == nil {
+		return &WidgetConfigResponse{AllowedOrigins: []string{}}
+	}
+
+	return &WidgetConfigResponse{
+		Enabled:            cfg.Enabled,
+		AllowedOrigins:     append([]string{}, cfg.AllowedOrigins...),
+		RateLimitPerMinute: cfg.RateLimitPerMinute,
+		HasApiKey:          cfg.ApiKeyHash != nil,
+		UpdatedAt:          &cfg.UpdatedAt,
+	}
+}
+
+func MapRequestToWidgetConfig(educatorId uuid.UUID, request *WidgetConfigRequest) *entities.WidgetConfig {
+	now := time.Now().UTC()
+	origins := make([]string, 0, len(request.AllowedOrigins))
+	for _, origin := range request.AllowedOrigins {
+		origins = append(origins, normalizeOrigin(origin))
+	}
+
+	return &entities.WidgetConfig{
+		EducatorId:         educatorId,
+		Enabled:            request.Enabled,
+		AllowedOrigins:     origins,
+		RateLimitPerMinute: request.RateLimitPerMinute,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+}
+
+func MapAvailabilityChangesToResponse(changes []*AvailabilityChange) []*AvailabilityChangeResponse {
+	response := make([]*AvailabilityChangeResponse, 0, len(changes))
+	for _, c := range changes {
+		response = append(response, &AvailabilityChangeResponse{
+			Kind:            c.Kind,
+			Id:              c.Id,
+			StartTime:       c.StartTime,
+			EndTime:         c.EndTime,
+			Removed:         c.Removed,
+			Title:           c.Title,
+			SessionTypeId:   c.SessionTypeId,
+			MaxParticipants: c.MaxParticipants,
+			ChangedAt:       c.ChangedAt,
+		})
+	}
+	return response
+}
+
+// normalizeOrigin lowercases an origin and drops the trailing slash, as browsers send it in the Origin header
+func normalizeOrigin(origin string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(origin)), "/")
+}
+
+// MapApiUsageToResponse folds usage rows ordered by key into per key totals, marking the key of currentKeyId
+func MapApiUsageToResponse(rows []*entities.WidgetApiUsage, currentKeyId string) []*ApiKeyUsageResponse {
+	response := []*ApiKeyUsageResponse{}
+
+	var current *ApiKeyUsageResponse
+	for _, r := range rows {
+		if current == nil || current.KeyId != r.KeyId {
+			current = &ApiKeyUsageResponse{KeyId: r.KeyId, Current: r.KeyId == currentKeyId, Buckets: []*ApiUsageBucketResponse{}}
+			response = append(response, current)
+		}
+		current.Requests += r.Requests
+		current.Errors += r.Errors
+		current.RateLimited += r.RateLimited
+		current.Buckets = append(current.Buckets, &ApiUsageBucketResponse{
+			Start:       r.BucketStart,
+			Endpoint:    r.Endpoint,
+			Requests:    r.Requests,
+			Errors:      r.Errors,
+			RateLimited: r.RateLimited,
+		})
+	}
+
+	for _, k := range response {
+		if k.Requests > 0 {
+			k.ErrorRate = float64(k.Errors+k.RateLimited) / float64(k.Requests)
+		}
+	}
+	return response
+}
@@ -0,0 +1,37 @@
+package widgets
+
+import (
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+func InitializeWidgetService(
+	log logger.Logger,
+	db *sqlx.DB,
+	cfg *config.WidgetConfig,
+	availability AvailabilityProvider,
+	usage *UsageRecorder,
+) *WidgetService {
+	repo := NewWidgetRepository(db)
+	service := NewWidgetService(log, repo, cfg, availability, NewRateLimiter(), NewRateLimiter(), usage)
+	return service
+}
+
+func InitializeUsageRecorder(log logger.Logger, db *sqlx.DB, cfg *config.WidgetConfig) *UsageRecorder {
+	repo := NewWidgetRepository(db)
+	return NewUsageRecorder(log, repo, cfg)
+}
+
+func InitializeTombstonePurgeJob(log logger.Logger, db *sqlx.DB, cfg *config.WidgetConfig) *TombstonePurgeJob {
+	repo := NewWidgetRepository(db)
+	return NewTombstonePurgeJob(log, repo, cfg)
+}
+
+func InitializeWidgetHTTPHandler(service *WidgetService) http.Handler {
+	handler := NewWidgetHandler(service)
+	return Routes(handler)
+}
@@ -0,0 +1,156 @@
+package widgets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// settleLag keeps the newest changes out of a poll, so rows committed late with an earlier timestamp are not
+// skipped by a cursor that already moved past them
+const settleLag = 2 * time.Second
+
+// ChangeCursor is a position in the availability change feed of an educator
+type ChangeCursor struct {
+	ChangedAt  time.Time
+	KindOrder  int
+	PositionId int64
+}
+
+// endKindOrder sorts after every change kind, so a cursor at a timestamp skips all changes made at it
+const endKindOrder = 4
+
+// startCursor is positioned before every change
+var startCursor = &ChangeCursor{ChangedAt: time.Unix(0, 0).UTC(), KindOrder: -1}
+
+func encodeCursor(c *ChangeCursor) string {
+	raw := fmt.Sprintf("%d:%d:%d", c.ChangedAt.UnixMicro(), c.KindOrder, c.PositionId)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(value string) (*ChangeCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var micros, positionId int64
+	var kindOrder int
+	if _, err := fmt.Sscanf(string(raw), "%d:%d:%d", &micros, &kindOrder, &positionId); err != nil {
+		return nil, err
+	}
+
+	return &ChangeCursor{ChangedAt: time.UnixMicro(micros).UTC(), KindOrder: kindOrder, PositionId: positionId}, nil
+}
+
+// PollAvailabilityChanges returns the availability changes of an educator since the cursor for clients
+// holding the educator's API key. Without a cursor, or with one older than the tombstone retention, a full
+// sync of current entries is returned instead. When nothing changed since ifModifiedSince no response is
+// returned. The last modification time, nil when there never was one, is returned on success.
+func (s *WidgetService) PollAvailabilityChanges(
+	ctx context.Context,
+	educatorId uuid.UUID,
+	apiKey string,
+	cursor string,
+	ifModifiedSince *time.Time,
+) (response *AvailabilityChangesResponse, lastModified *time.Time, err error) {
+	log := logger.FromContext(ctx, s.log)
+
+	ctx, keyHash, err := s.authorizePolling(ctx, educatorId, apiKey)
+	if keyHash != "" {
+		defer func() { s.usage.Record(educatorId, keyHash, UsageEndpointChanges, err) }()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	after := startCursor
+	if cursor != "" {
+		decoded, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, nil, apperrors.NewBadRequestError("Invalid cursor", apperrors.ErrParameterParsingFailed)
+		}
+		after = decoded
+	}
+
+	now := time.Now().UTC()
+	upTo := now.Add(-settleLag)
+
+	lastModified, err = s.repo.GetAvailabilityLastModified(ctx, educatorId)
+	if err != nil {
+		log.Error("failed to get availability last modified", err)
+		return nil, nil, err
+	}
+	if lastModified != nil {
+		if lastModified.After(upTo) {
+			lastModified = &upTo
+		}
+		if ifModifiedSince != nil && !lastModified.Truncate(time.Second).After(*ifModifiedSince) {
+			return nil, lastModified, nil
+		}
+	}
+
+	fullSync := after == startCursor || !after.ChangedAt.After(now.AddDate(0, 0, -s.cfg.TombstoneRetentionDays))
+	if fullSync {
+		after = startCursor
+	}
+
+	changes, err := s.repo.GetAvailabilityChanges(ctx, educatorId, after, upTo, now, !fullSync, s.cfg.PollMaxChanges+1)
+	if err != nil {
+		log.Error("failed to get availability changes", err)
+		return nil, nil, err
+	}
+
+	hasMore := len(changes) > s.cfg.PollMaxChanges
+	next := &ChangeCursor{ChangedAt: upTo, KindOrder: endKindOrder}
+	if hasMore {
+		changes = changes[:s.cfg.PollMaxChanges]
+		last := changes[len(changes)-1]
+		next = &ChangeCursor{ChangedAt: last.ChangedAt, KindOrder: last.KindOrder, PositionId: last.PositionId}
+	} else if after.ChangedAt.After(upTo) {
+		next = after
+	}
+
+	return &AvailabilityChangesResponse{
+		EducatorId: educatorId,
+		Cursor:     encodeCursor(next),
+		HasMore:    hasMore,
+		FullSync:   fullSync,
+		Changes:    MapAvailabilityChangesToResponse(changes),
+	}, lastModified, nil
+}
+
+// authorizePolling allows change feed requests carrying a valid API key of an enabled widget and applies
+// the polling limit of the educator. The context scoped to the tenant of the key and the hash of a valid
+// key are returned, the hash also when the limit is exceeded.
+func (s *WidgetService) authorizePolling(ctx context.Context, educatorId uuid.UUID, apiKey string) (context.Context, string, error) {
+	if apiKey == "" {
+		return ctx, "", apperrors.NewUnauthorized("API key required")
+	}
+
+	ctx, err := s.widgetTenant(ctx, educatorId, apiKey)
+	if err != nil {
+		return ctx, "", err
+	}
+
+	cfg, err := s.getEnabledWidgetConfig(ctx, educatorId)
+	if err != nil {
+		return ctx, "", err
+	}
+
+	if !validApiKey(cfg.ApiKeyHash, apiKey) {
+		return ctx, "", apperrors.NewUnauthorized("Invalid API key")
+	}
+
+	if ok, retryAfter := s.pollLimiter.Allow(educatorId, s.cfg.PollRateLimitPerMinute); !ok {
+		return ctx, *cfg.ApiKeyHash, apperrors.NewTooManyRequests("Polling rate limit exceeded", retryAfter)
+	}
+
+	return ctx, *cfg.ApiKeyHash, nil
+}
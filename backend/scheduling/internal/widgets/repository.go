@@ -0,0 +1,222 @@
+package widgets
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+)
+
+// AvailabilityChange is a single added, updated or removed availability entry of an educator. Position
+// orders changes with equal timestamps: live rows sort by kind and id, removals by tombstone id.
+type AvailabilityChange struct {
+	Kind            string    `db:"kind"`
+	KindOrder       int       `db:"kind_order"`
+	PositionId      int64     `db:"position_id"`
+	Id              int64     `db:"id"`
+	StartTime       time.Time `db:"start_time"`
+	EndTime         time.Time `db:"end_time"`
+	Removed         bool      `db:"removed"`
+	Title           *string   `db:"title"`
+	SessionTypeId   *int64    `db:"session_type_id"`
+	MaxParticipants *int      `db:"max_participants"`
+	ChangedAt       time.Time `db:"changed_at"`
+}
+
+type lastModified struct {
+	LastModified *time.Time `db:"last_modified"`
+}
+
+type WidgetRepo struct {
+	db *sqlx.DB
+}
+
+func NewWidgetRepository(db *sqlx.DB) *WidgetRepo {
+	return &WidgetRepo{db: db}
+}
+
+// GetWidgetConfig retrieves the widget config of an educator
+func (r *WidgetRepo) GetWidgetConfig(ctx context.Context, educatorId uuid.UUID) (*entities.WidgetConfig, error) {
+	const query = `
+		SELECT educator_id, enabled, allowed_origins, api_key_hash, rate_limit_per_minute, created_at, updated_at
+		FROM widget_config
+		WHERE educator_id = $1
+	`
+	return database.FetchSingle[entities.WidgetConfig](ctx, r.db, query, educatorId)
+}
+
+// GetWidgetTenant retrieves the tenant of an educator's widget config. Public widget requests do not
+// know their tenant yet, so the config is looked up across tenants.
+func (r *WidgetRepo) GetWidgetTenant(ctx context.Context, educatorId uuid.UUID) (string, error) {
+	const query = `SELECT tenant_id FROM widget_config WHERE educator_id = $1`
+
+	ctx = database.WithAllTenants(ctx)
+	var tenantId string
+	if err := database.Conn(ctx, r.db).GetContext(ctx, &tenantId, query, educatorId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", apperrors.NewNotFound("Widget config not found", apperrors.ErrResourceNotFound, err)
+		}
+		return "", apperrors.NewInternal(err)
+	}
+	return tenantId, nil
+}
+
+// UpsertWidgetConfig creates or replaces the widget settings of an educator, keeping its API key
+func (r *WidgetRepo) UpsertWidgetConfig(ctx context.Context, cfg *entities.WidgetConfig) error {
+	const query = `
+		INSERT INTO widget_config (educator_id, enabled, allowed_origins, rate_limit_per_minute, created_at, updated_at)
+		VALUES (:educator_id, :enabled, :allowed_origins, :rate_limit_per_minute, :created_at, :updated_at)
+		ON CONFLICT (educator_id) DO UPDATE
+		SET enabled = EXCLUDED.enabled, allowed_origins = EXCLUDED.allowed_origins,
+			rate_limit_per_minute = EXCLUDED.rate_limit_per_minute, updated_at = EXCLUDED.updated_at
+	`
+	return database.ExecNamedQuery(ctx, r.db, query, cfg)
+}
+
+// SetApiKeyHash replaces the API key hash of an educator, creating a disabled config when none exists
+func (r *WidgetRepo) SetApiKeyHash(ctx context.Context, educatorId uuid.UUID, hash string, updatedAt time.Time) error {
+	const query = `
+		INSERT INTO widget_config (educator_id, api_key_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (educator_id) DO UPDATE
+		SET api_key_hash = EXCLUDED.api_key_hash, updated_at = EXCLUDED.updated_at
+	`
+	return database.ExecQuery(ctx, r.db, query, educatorId, hash, updatedAt)
+}
+
+// GetAvailabilityChanges retrieves availability changes of an educator after the cursor position and up to
+// a point in time, ordered by position. Entries that already ended are left out, as are removals unless
+// requested.
+func (r *WidgetRepo) GetAvailabilityChanges(
+	ctx context.Context,
+	educatorId uuid.UUID,
+	after *ChangeCursor,
+	upTo time.Time,
+	now time.Time,
+	includeRemoved bool,
+	limit int,
+) ([]*AvailabilityChange, error) {
+	const query = `
+		SELECT kind, kind_order, position_id, id, start_time, end_time, removed, title, session_type_id, max_participants, changed_at
+		FROM (
+			SELECT 'WORKING_PERIOD' AS kind, 0 AS kind_order, id AS position_id, id, start_time, end_time, false AS removed,
+				NULL::varchar AS title, NULL::bigint AS session_type_id, NULL::int AS max_participants, updated_at AS changed_at
+			FROM working_period
+			WHERE user_id = $1 AND end_time > $2 AND deleted_at IS NULL
+			UNION ALL
+			SELECT 'SCHEDULED_EVENT', 1, id, id, start_time, end_time, false, title, session_type_id, max_participants, updated_at
+			FROM scheduled_event
+			WHERE user_id = $1 AND end_time > $2 AND deleted_at IS NULL
+			UNION ALL
+			SELECT 'BUSY_SLOT', 2, id, id, start_time, end_time, status = $3, NULL, NULL, NULL, updated_at
+			FROM booking
+			WHERE educator_id = $1 AND scheduled_event_id IS NULL AND end_time > $2
+			UNION ALL
+			SELECT kind, 3, id, entity_id, start_time, end_time, true, NULL, NULL, NULL, deleted_at
+			FROM availability_tombstone
+			WHERE educator_id = $1 AND end_time > $2
+		) changes
+		WHERE changed_at <= $4 AND (changed_at, kind_order, position_id) > ($5, $6, $7) AND (NOT removed OR $8)
+		ORDER BY changed_at, kind_order, position_id
+		LIMIT $9
+	`
+	return database.FetchMultiple[AvailabilityChange](
+		ctx, r.db, query,
+		educatorId, now, entities.Cancelled, upTo, after.ChangedAt, after.KindOrder, after.PositionId, includeRemoved, limit,
+	)
+}
+
+// GetAvailabilityLastModified returns when the availability of an educator last changed, nil when it never did
+func (r *WidgetRepo) GetAvailabilityLastModified(ctx context.Context, educatorId uuid.UUID) (*time.Time, error) {
+	const query = `
+		SELECT GREATEST(
+			(SELECT MAX(updated_at) FROM working_period WHERE user_id = $1),
+			(SELECT MAX(updated_at) FROM scheduled_event WHERE user_id = $1),
+			(SELECT MAX(updated_at) FROM booking WHERE educator_id = $1 AND scheduled_event_id IS NULL),
+			(SELECT MAX(deleted_at) FROM availability_tombstone WHERE educator_id = $1)
+		) AS last_modified
+	`
+	result, err := database.FetchSingle[lastModified](ctx, r.db, query, educatorId)
+	if err != nil {
+		return nil, err
+	}
+	return result.LastModified, nil
+}
+
+// PurgeTombstones deletes up to limit tombstones recorded before the cutoff and returns how many were deleted
+func (r *WidgetRepo) PurgeTombstones(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	const query = `
+		DELETE FROM availability_tombstone
+		WHERE id IN (SELECT id FROM availability_tombstone WHERE deleted_at < $1 LIMIT $2)
+	`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return deleted, nil
+}
+
+// AddApiUsage adds request counts to the usage buckets, creating the missing ones
+func (r *WidgetRepo) AddApiUsage(ctx context.Context, usage []*entities.WidgetApiUsage) error {
+	const query = `
+		INSERT INTO widget_api_usage (educator_id, key_id, endpoint, bucket_start, requests, errors, rate_limited)
+		SELECT * FROM unnest($1::uuid[], $2::text[], $3::text[], $4::timestamptz[], $5::bigint[], $6::bigint[], $7::bigint[])
+		ON CONFLICT (educator_id, key_id, endpoint, bucket_start) DO UPDATE
+		SET requests = widget_api_usage.requests + EXCLUDED.requests,
+			errors = widget_api_usage.errors + EXCLUDED.errors,
+			rate_limited = widget_api_usage.rate_limited + EXCLUDED.rate_limited
+	`
+	var educatorIds, keyIds, endpoints, bucketStarts pq.StringArray
+	var requests, failures, rateLimited pq.Int64Array
+	for _, u := range usage {
+		educatorIds = append(educatorIds, u.EducatorId.String())
+		keyIds = append(keyIds, u.KeyId)
+		endpoints = append(endpoints, u.Endpoint)
+		bucketStarts = append(bucketStarts, u.BucketStart.UTC().Format(time.RFC3339))
+		requests = append(requests, u.Requests)
+		failures = append(failures, u.Errors)
+		rateLimited = append(rateLimited, u.RateLimited)
+	}
+	return database.ExecQuery(ctx, r.db, query, educatorIds, keyIds, endpoints, bucketStarts, requests, failures, rateLimited)
+}
+
+// GetApiUsage retrieves the usage of an educator's API keys within a range, summed per key, endpoint and
+// granularity, a date_trunc field
+func (r *WidgetRepo) GetApiUsage(ctx context.Context, educatorId uuid.UUID, fromDate, toDate time.Time, granularity string) ([]*entities.WidgetApiUsage, error) {
+	const query = `
+		SELECT educator_id, key_id, endpoint,
+			date_trunc($4, bucket_start AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS bucket_start,
+			SUM(requests)::bigint AS requests, SUM(errors)::bigint AS errors, SUM(rate_limited)::bigint AS rate_limited
+		FROM widget_api_usage
+		WHERE educator_id = $1 AND bucket_start >= $2 AND bucket_start < $3
+		GROUP BY educator_id, key_id, endpoint, 4
+		ORDER BY key_id, bucket_start, endpoint
+	`
+	return database.FetchMultiple[entities.WidgetApiUsage](ctx, r.db, query, educatorId, fromDate, toDate, granularity)
+}
+
+// DeleteApiUsageBefore removes usage buckets starting before the cutoff and returns how many were removed
+func (r *WidgetRepo) DeleteApiUsageBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	const query = `DELETE FROM widget_api_usage WHERE bucket_start < $1`
+	result, err := database.Conn(ctx, r.db).ExecContext(ctx, query, cutoff)
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, apperrors.NewInternal(err)
+	}
+	return deleted, nil
+}
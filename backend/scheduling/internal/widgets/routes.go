@@ -0,0 +1,30 @@
+package widgets
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/middleware"
+)
+
+// PublicPathPrefix is served without authentication and outside the CORS policies of the route groups, since
+// allowed origins are configured per educator
+const PublicPathPrefix = "/api/v1/widgets/public"
+
+func Routes(handler *WidgetHandler) http.Handler {
+	r := chi.NewRouter()
+
+	// Define routes
+	r.Get("/public/educators/{educatorId}/availability", handler.GetWidgetAvailability)
+	r.Options("/public/educators/{educatorId}/availability", handler.PreflightWidgetAvailability)
+	r.Get("/public/educators/{educatorId}/availability/changes", handler.PollAvailabilityChanges)
+	r.Get("/public/educators/{educatorId}/api-usage", handler.GetApiUsage)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Get("/config", handler.GetMyWidgetConfig)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Put("/config", handler.UpdateMyWidgetConfig)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Post("/config/api-key", handler.RotateApiKey)
+	r.With(middleware.RequireRole(auth.EducatorRole)).Get("/config/api-key/usage", handler.GetMyApiUsage)
+
+	return r
+}
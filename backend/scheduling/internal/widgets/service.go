@@ -0,0 +1,383 @@
+package widgets
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/auth"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+	"github.com/maksmelnyk/scheduling/internal/sharing"
+)
+
+type WidgetRepository interface {
+	GetWidgetConfig(ctx context.Context, educatorId uuid.UUID) (*entities.WidgetConfig, error)
+	GetWidgetTenant(ctx context.Context, educatorId uuid.UUID) (string, error)
+	UpsertWidgetConfig(ctx context.Context, cfg *entities.WidgetConfig) error
+	SetApiKeyHash(ctx context.Context, educatorId uuid.UUID, hash string, updatedAt time.Time) error
+	GetAvailabilityChanges(
+		ctx context.Context,
+		educatorId uuid.UUID,
+		after *ChangeCursor,
+		upTo time.Time,
+		now time.Time,
+		includeRemoved bool,
+		limit int,
+	) ([]*AvailabilityChange, error)
+	GetAvailabilityLastModified(ctx context.Context, educatorId uuid.UUID) (*time.Time, error)
+	GetApiUsage(ctx context.Context, educatorId uuid.UUID, fromDate, toDate time.Time, granularity string) ([]*entities.WidgetApiUsage, error)
+}
+
+// AvailabilityProvider resolves the public availability of an educator
+type AvailabilityProvider interface {
+	GetAvailability(ctx context.Context, educatorId uuid.UUID, from, to time.Time, sessionTypeIds []int64) (*sharing.SharedScheduleResponse, error)
+}
+
+type WidgetService struct {
+	log          logger.Logger
+	repo         WidgetRepository
+	cfg          *config.WidgetConfig
+	availability AvailabilityProvider
+	limiter      *RateLimiter
+	pollLimiter  *RateLimiter
+	usage        *UsageRecorder
+}
+
+func NewWidgetService(
+	log logger.Logger,
+	repo WidgetRepository,
+	cfg *config.WidgetConfig,
+	availability AvailabilityProvider,
+	limiter *RateLimiter,
+	pollLimiter *RateLimiter,
+	usage *UsageRecorder,
+) *WidgetService {
+	return &WidgetService{log: log, repo: repo, cfg: cfg, availability: availability, limiter: limiter, pollLimiter: pollLimiter, usage: usage}
+}
+
+// CacheMaxAge returns how long public widget responses may be cached
+func (s *WidgetService) CacheMaxAge() int {
+	return s.cfg.CacheMaxAgeSeconds
+}
+
+func (s *WidgetService) GetMyWidgetConfig(ctx context.Context) (*WidgetConfigResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	cfg, err := s.repo.GetWidgetConfig(ctx, userId)
+	if err != nil {
+		var notFound *apperrors.NotFoundError
+		if errors.As(err, &notFound) {
+			return MapWidgetConfigToResponse(nil), nil
+		}
+		log.Error("failed to get widget config", err)
+		return nil, err
+	}
+
+	return MapWidgetConfigToResponse(cfg), nil
+}
+
+func (s *WidgetService) UpdateMyWidgetConfig(ctx context.Context, request *WidgetConfigRequest) error {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	if err := s.repo.UpsertWidgetConfig(ctx, MapRequestToWidgetConfig(userId, request)); err != nil {
+		log.Error("failed to save widget config", err)
+		return err
+	}
+
+	return nil
+}
+
+// RotateApiKey issues a new widget API key for the current educator, invalidating the previous one.
+// Only a hash is stored, so the key is returned once. In multi-tenant deployments the key starts with the
+// tenant of the educator, which public requests carrying it are served for.
+func (s *WidgetService) RotateApiKey(ctx context.Context) (*WidgetApiKeyResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Error("failed to generate widget api key", err)
+		return nil, apperrors.NewInternal(err)
+	}
+	key := base64.RawURLEncoding.EncodeToString(secret)
+	if tenantId, ok := auth.GetTenantID(ctx); ok {
+		key = base64.RawURLEncoding.EncodeToString([]byte(tenantId)) + "." + key
+	}
+
+	if err := s.repo.SetApiKeyHash(ctx, userId, hashApiKey(key), time.Now().UTC()); err != nil {
+		log.Error("failed to save widget api key", err)
+		return nil, err
+	}
+
+	return &WidgetApiKeyResponse{ApiKey: key}, nil
+}
+
+// GetWidgetAvailability returns the availability of an educator for an embedded widget. The request is
+// allowed with a valid API key or from one of the educator's allowed origins, and counts towards the
+// educator's per minute limit. The origin to echo in CORS headers is returned, empty when none applies.
+// Requests with a valid API key are counted in the key's usage.
+func (s *WidgetService) GetWidgetAvailability(
+	ctx context.Context,
+	educatorId uuid.UUID,
+	origin string,
+	apiKey string,
+	from time.Time,
+	to time.Time,
+) (response *sharing.SharedScheduleResponse, allowedOrigin string, err error) {
+	ctx, err = s.widgetTenant(ctx, educatorId, apiKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cfg, err := s.getEnabledWidgetConfig(ctx, educatorId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	origin = normalizeOrigin(origin)
+	originAllowed := origin != "" && slices.Contains(cfg.AllowedOrigins, origin)
+
+	if apiKey != "" {
+		if !validApiKey(cfg.ApiKeyHash, apiKey) {
+			return nil, "", apperrors.NewUnauthorized("Invalid API key")
+		}
+		defer func() { s.usage.Record(educatorId, *cfg.ApiKeyHash, UsageEndpointAvailability, err) }()
+	} else if !originAllowed {
+		return nil, "", apperrors.NewForbidden("Origin not allowed")
+	}
+
+	if !from.Before(to) {
+		return nil, "", apperrors.NewBadRequestError("fromDate must be before toDate", apperrors.ErrParameterParsingFailed)
+	}
+	if to.Sub(from) > time.Duration(s.cfg.MaxRangeDays)*24*time.Hour {
+		return nil, "", apperrors.NewUnprocessedEntity("Requested date range is too long", apperrors.ErrParameterParsingFailed)
+	}
+
+	limit := cfg.RateLimitPerMinute
+	if limit == 0 {
+		limit = s.cfg.DefaultRateLimitPerMinute
+	}
+	if ok, retryAfter := s.limiter.Allow(educatorId, limit); !ok {
+		return nil, "", apperrors.NewTooManyRequests("Widget rate limit exceeded", retryAfter)
+	}
+
+	if now := time.Now().UTC(); now.After(from) {
+		from = now
+	}
+
+	response, err = s.availability.GetAvailability(ctx, educatorId, from, to, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if originAllowed {
+		allowedOrigin = origin
+	} else if apiKey != "" {
+		allowedOrigin = "*"
+	}
+
+	return response, allowedOrigin, nil
+}
+
+// GetMyApiUsage returns the usage of the current educator's API keys
+func (s *WidgetService) GetMyApiUsage(ctx context.Context, from, to time.Time, granularity string) (*ApiUsageResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	userId, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Unauthorized user", err)
+	}
+
+	cfg, err := s.repo.GetWidgetConfig(ctx, userId)
+	if err != nil {
+		var notFound *apperrors.NotFoundError
+		if !errors.As(err, &notFound) {
+			log.Error("failed to get widget config", err)
+			return nil, err
+		}
+		cfg = nil
+	}
+
+	var keyHash *string
+	if cfg != nil {
+		keyHash = cfg.ApiKeyHash
+	}
+	return s.apiUsage(ctx, userId, keyHash, from, to, granularity)
+}
+
+// GetApiUsage returns the usage of an educator's API keys to a caller holding the current key, so
+// integrators can follow their consumption. The request counts towards the widget limit and the usage.
+func (s *WidgetService) GetApiUsage(
+	ctx context.Context,
+	educatorId uuid.UUID,
+	apiKey string,
+	from time.Time,
+	to time.Time,
+	granularity string,
+) (response *ApiUsageResponse, err error) {
+	if apiKey == "" {
+		return nil, apperrors.NewUnauthorized("API key required")
+	}
+
+	ctx, err = s.widgetTenant(ctx, educatorId, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := s.getEnabledWidgetConfig(ctx, educatorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validApiKey(cfg.ApiKeyHash, apiKey) {
+		return nil, apperrors.NewUnauthorized("Invalid API key")
+	}
+	defer func() { s.usage.Record(educatorId, *cfg.ApiKeyHash, UsageEndpointUsage, err) }()
+
+	limit := cfg.RateLimitPerMinute
+	if limit == 0 {
+		limit = s.cfg.DefaultRateLimitPerMinute
+	}
+	if ok, retryAfter := s.limiter.Allow(educatorId, limit); !ok {
+		return nil, apperrors.NewTooManyRequests("Widget rate limit exceeded", retryAfter)
+	}
+
+	return s.apiUsage(ctx, educatorId, cfg.ApiKeyHash, from, to, granularity)
+}
+
+func (s *WidgetService) apiUsage(
+	ctx context.Context,
+	educatorId uuid.UUID,
+	keyHash *string,
+	from time.Time,
+	to time.Time,
+	granularity string,
+) (*ApiUsageResponse, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if granularity == "" {
+		granularity = GranularityDay
+	}
+	if granularity != GranularityHour && granularity != GranularityDay {
+		return nil, apperrors.NewBadRequestError("granularity must be one of hour, day", apperrors.ErrParameterInvalid)
+	}
+	if !from.Before(to) {
+		return nil, apperrors.NewBadRequestError("fromDate must be before toDate", apperrors.ErrParameterInvalid)
+	}
+	if to.Sub(from) > time.Duration(s.cfg.UsageRetentionDays)*24*time.Hour {
+		return nil, apperrors.NewBadRequestError("Requested date range is longer than the usage retention", apperrors.ErrParameterInvalid)
+	}
+
+	rows, err := s.repo.GetApiUsage(ctx, educatorId, from.UTC(), to.UTC(), granularity)
+	if err != nil {
+		log.Error("failed to get api usage", err)
+		return nil, err
+	}
+
+	currentKeyId := ""
+	if keyHash != nil {
+		currentKeyId = usageKeyId(*keyHash)
+	}
+
+	return &ApiUsageResponse{
+		FromDate:     from.UTC(),
+		ToDate:       to.UTC(),
+		Granularity:  granularity,
+		UpdatedEvery: s.cfg.UsageFlushSeconds,
+		Keys:         MapApiUsageToResponse(rows, currentKeyId),
+	}, nil
+}
+
+// getEnabledWidgetConfig retrieves the widget config of an educator, treating a disabled widget as missing
+func (s *WidgetService) getEnabledWidgetConfig(ctx context.Context, educatorId uuid.UUID) (*entities.WidgetConfig, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	cfg, err := s.repo.GetWidgetConfig(ctx, educatorId)
+	if err != nil {
+		var notFound *apperrors.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, apperrors.NewNotFound("Widget not found", apperrors.ErrWidgetOriginNotAllowed)
+		}
+		log.Error("failed to get widget config", err)
+		return nil, err
+	}
+
+	if !cfg.Enabled {
+		return nil, apperrors.NewNotFound("Widget not found", apperrors.ErrWidgetOriginNotAllowed)
+	}
+
+	return cfg, nil
+}
+
+// widgetTenant scopes ctx to the tenant a public widget request is served for. An API key carries the tenant
+// it was issued for, requests without a key, which are only allowed from the educator's origins, are served
+// for the tenant of the educator's widget config. Keys issued without a tenant leave ctx unscoped.
+func (s *WidgetService) widgetTenant(ctx context.Context, educatorId uuid.UUID, apiKey string) (context.Context, error) {
+	log := logger.FromContext(ctx, s.log)
+
+	if apiKey != "" {
+		if tenantId := apiKeyTenant(apiKey); tenantId != "" {
+			return auth.WithTenant(ctx, tenantId), nil
+		}
+		return ctx, nil
+	}
+
+	tenantId, err := s.repo.GetWidgetTenant(ctx, educatorId)
+	if err != nil {
+		var notFound *apperrors.NotFoundError
+		if errors.As(err, &notFound) {
+			return ctx, apperrors.NewNotFound("Widget not found", apperrors.ErrWidgetOriginNotAllowed)
+		}
+		log.Error("failed to get widget tenant", err)
+		return ctx, err
+	}
+	return auth.WithTenant(ctx, tenantId), nil
+}
+
+// apiKeyTenant returns the tenant an API key was issued for, empty for keys issued without one
+func apiKeyTenant(key string) string {
+	prefix, _, ok := strings.Cut(key, ".")
+	if !ok {
+		return ""
+	}
+	tenantId, err := base64.RawURLEncoding.DecodeString(prefix)
+	if err != nil {
+		return ""
+	}
+	return string(tenantId)
+}
+
+func validApiKey(hash *string, key string) bool {
+	return hash != nil && subtle.ConstantTimeCompare([]byte(hashApiKey(key)), []byte(*hash)) == 1
+}
+
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
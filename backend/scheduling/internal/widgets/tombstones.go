@@ -0,0 +1,58 @@
+package widgets
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+const tombstonePurgeBatchSize = 1000
+
+type TombstoneRepository interface {
+	PurgeTombstones(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+}
+
+// TombstonePurgeJob periodically deletes removal records that polling clients can no longer ask for
+type TombstonePurgeJob struct {
+	log  logger.Logger
+	repo TombstoneRepository
+	cfg  *config.WidgetConfig
+}
+
+func NewTombstonePurgeJob(log logger.Logger, repo TombstoneRepository, cfg *config.WidgetConfig) *TombstonePurgeJob {
+	return &TombstonePurgeJob{log: log, repo: repo, cfg: cfg}
+}
+
+// Run purges expired tombstones on every interval until the context is cancelled
+func (j *TombstonePurgeJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(j.cfg.TombstonePurgeMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.PurgeExpiredTombstones(ctx); err != nil {
+				j.log.Errorf("Failed to purge availability tombstones: %v", err)
+			}
+		}
+	}
+}
+
+// PurgeExpiredTombstones deletes expired tombstones in batches until fewer than a full batch remain
+func (j *TombstonePurgeJob) PurgeExpiredTombstones(ctx context.Context) error {
+	cutoff := time.Now().UTC().AddDate(0, 0, -j.cfg.TombstoneRetentionDays)
+
+	for {
+		deleted, err := j.repo.PurgeTombstones(ctx, cutoff, tombstonePurgeBatchSize)
+		if err != nil {
+			return err
+		}
+		if deleted < tombstonePurgeBatchSize {
+			return nil
+		}
+	}
+}
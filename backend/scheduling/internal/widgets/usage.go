@@ -0,0 +1,151 @@
+package widgets
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/maksmelnyk/scheduling/config"
+	"github.com/maksmelnyk/scheduling/internal/apperrors"
+	"github.com/maksmelnyk/scheduling/internal/database/entities"
+	"github.com/maksmelnyk/scheduling/internal/logger"
+)
+
+// Endpoints API key usage is counted for
+const (
+	UsageEndpointAvailability = "availability"
+	UsageEndpointChanges      = "changes"
+	UsageEndpointUsage        = "usage"
+)
+
+// keyIdLength is the length of the key hash prefix identifying a key in usage, enough to tell rotated keys
+// apart without exposing the hash
+const keyIdLength = 12
+
+// maxPendingUsage bounds the counters kept in memory while writes fail, newer requests are dropped beyond it
+const maxPendingUsage = 100000
+
+type UsageRepository interface {
+	AddApiUsage(ctx context.Context, usage []*entities.WidgetApiUsage) error
+	DeleteApiUsageBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type usageKey struct {
+	educatorId  uuid.UUID
+	keyId       string
+	endpoint    string
+	bucketStart time.Time
+}
+
+// UsageRecorder counts the requests of API key callers in memory, per key, endpoint and hour, and writes the
+// counts on every flush so requests do not each cost a write
+type UsageRecorder struct {
+	log  logger.Logger
+	repo UsageRepository
+	cfg  *config.WidgetConfig
+
+	mu      sync.Mutex
+	pending map[usageKey]*entities.WidgetApiUsage
+}
+
+func NewUsageRecorder(log logger.Logger, repo UsageRepository, cfg *config.WidgetConfig) *UsageRecorder {
+	return &UsageRecorder{log: log, repo: repo, cfg: cfg, pending: make(map[usageKey]*entities.WidgetApiUsage)}
+}
+
+// Record counts a request made with a key, as rate limited when it was rejected by a limit and as an error
+// when it failed otherwise
+func (u *UsageRecorder) Record(educatorId uuid.UUID, keyHash string, endpoint string, err error) {
+	key := usageKey{
+		educatorId:  educatorId,
+		keyId:       usageKeyId(keyHash),
+		endpoint:    endpoint,
+		bucketStart: time.Now().UTC().Truncate(time.Hour),
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage, ok := u.pending[key]
+	if !ok {
+		if len(u.pending) >= maxPendingUsage {
+			return
+		}
+		usage = &entities.WidgetApiUsage{EducatorId: key.educatorId, KeyId: key.keyId, Endpoint: key.endpoint, BucketStart: key.bucketStart}
+		u.pending[key] = usage
+	}
+
+	usage.Requests++
+	var tooManyRequests *apperrors.TooManyRequestsError
+	switch {
+	case err == nil:
+	case errors.As(err, &tooManyRequests):
+		usage.RateLimited++
+	default:
+		usage.Errors++
+	}
+}
+
+// Flush writes the counted usage. When the write fails the counts are kept for the next flush.
+func (u *UsageRecorder) Flush(ctx context.Context) error {
+	u.mu.Lock()
+	pending := u.pending
+	u.pending = make(map[usageKey]*entities.WidgetApiUsage, len(pending))
+	u.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	usage := make([]*entities.WidgetApiUsage, 0, len(pending))
+	for _, p := range pending {
+		usage = append(usage, p)
+	}
+
+	if err := u.repo.AddApiUsage(ctx, usage); err != nil {
+		u.restore(pending)
+		return err
+	}
+	return nil
+}
+
+// PurgeUsage removes usage past the retention
+func (u *UsageRecorder) PurgeUsage(ctx context.Context) error {
+	deleted, err := u.repo.DeleteApiUsageBefore(ctx, time.Now().UTC().AddDate(0, 0, -u.cfg.UsageRetentionDays))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		u.log.Infof("Purged %d widget API usage buckets", deleted)
+	}
+	return nil
+}
+
+// restore merges counts that could not be written back into the pending ones
+func (u *UsageRecorder) restore(failed map[usageKey]*entities.WidgetApiUsage) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for key, f := range failed {
+		usage, ok := u.pending[key]
+		if !ok {
+			if len(u.pending) >= maxPendingUsage {
+				continue
+			}
+			u.pending[key] = f
+			continue
+		}
+		usage.Requests += f.Requests
+		usage.Errors += f.Errors
+		usage.RateLimited += f.RateLimited
+	}
+}
+
+func usageKeyId(keyHash string) string {
+	if len(keyHash) > keyIdLength {
+		return keyHash[:keyIdLength]
+	}
+	return keyHash
+}
@@ -0,0 +1,9 @@
+begin;
+
+drop table if exists booking;
+
+drop table if exists scheduled_event;
+
+drop table if exists working_period;
+
+commit;
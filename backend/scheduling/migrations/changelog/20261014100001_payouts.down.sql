@@ -0,0 +1,13 @@
+begin;
+
+drop table if exists payout_statement;
+
+drop table if exists commission_rule;
+
+drop index if exists idx_booking_status_end_time;
+
+alter table scheduled_event drop column if exists price;
+
+alter table booking drop column if exists price;
+
+commit;
@@ -0,0 +1,31 @@
+begin;
+
+alter table booking add column if not exists price numeric(12, 2) not null default 0;
+alter table scheduled_event add column if not exists price numeric(12, 2) not null default 0;
+
+create table if not exists commission_rule (
+   educator_id          uuid           primary key,
+   commission_percent   numeric(5, 2)  not null,
+   fixed_fee            numeric(12, 2) not null default 0,
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create table if not exists payout_statement (
+   id                   bigint         generated always as identity primary key,
+   educator_id          uuid           not null,
+   period_start         timestamptz    not null,
+   period_end           timestamptz    not null,
+   session_count        int            not null,
+   gross_amount         numeric(12, 2) not null,
+   fee_amount           numeric(12, 2) not null,
+   net_amount           numeric(12, 2) not null,
+   currency             text           not null,
+   created_at           timestamptz    not null default current_timestamp,
+   unique (educator_id, period_start, period_end)
+);
+
+create index if not exists idx_booking_status_end_time on booking (status, end_time);
+create index if not exists idx_payout_statement_educator_id on payout_statement (educator_id);
+
+commit;
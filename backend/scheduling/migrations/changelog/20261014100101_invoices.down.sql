@@ -0,0 +1,9 @@
+begin;
+
+drop table if exists invoice_line;
+
+drop table if exists invoice;
+
+drop sequence if exists invoice_number_seq;
+
+commit;
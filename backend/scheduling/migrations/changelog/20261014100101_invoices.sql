@@ -0,0 +1,35 @@
+begin;
+
+create sequence if not exists invoice_number_seq;
+
+create table if not exists invoice (
+   id                   bigint         generated always as identity primary key,
+   invoice_number       text           not null    unique,
+   booking_id           bigint         not null    unique    references booking ( id ),
+   educator_id          uuid           not null,
+   student_id           uuid           not null,
+   currency             text           not null,
+   subtotal             numeric(12, 2) not null,
+   discount_amount      numeric(12, 2) not null default 0,
+   tax_amount           numeric(12, 2) not null default 0,
+   total_amount         numeric(12, 2) not null,
+   issued_at            timestamptz    not null,
+   created_at           timestamptz    not null default current_timestamp
+);
+
+create table if not exists invoice_line (
+   id                   bigint         generated always as identity primary key,
+   invoice_id           bigint         not null    references invoice ( id ),
+   description          text           not null,
+   quantity             int            not null,
+   unit_price           numeric(12, 2) not null,
+   discount_amount      numeric(12, 2) not null default 0,
+   tax_amount           numeric(12, 2) not null default 0,
+   line_total           numeric(12, 2) not null
+);
+
+create index if not exists idx_invoice_educator_id on invoice (educator_id);
+create index if not exists idx_invoice_student_id on invoice (student_id);
+create index if not exists idx_invoice_line_invoice_id on invoice_line (invoice_id);
+
+commit;
@@ -0,0 +1,15 @@
+begin;
+
+alter table invoice drop column if exists reverse_charge;
+
+alter table invoice drop column if exists tax_rate;
+
+alter table invoice drop column if exists tax_name;
+
+alter table invoice drop column if exists tax_country;
+
+drop table if exists tax_profile;
+
+drop table if exists tax_rule;
+
+commit;
@@ -0,0 +1,25 @@
+begin;
+
+create table if not exists tax_rule (
+   country_code         text           primary key,
+   tax_name             text           not null,
+   rate_percent         numeric(5, 2)  not null,
+   reverse_charge       boolean        not null default false,
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create table if not exists tax_profile (
+   user_id              uuid           primary key,
+   country_code         text           not null,
+   tax_id               text,
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+alter table invoice add column if not exists tax_country text;
+alter table invoice add column if not exists tax_name text;
+alter table invoice add column if not exists tax_rate numeric(5, 2) not null default 0;
+alter table invoice add column if not exists reverse_charge boolean not null default false;
+
+commit;
@@ -0,0 +1,15 @@
+begin;
+
+create table if not exists notification_preference (
+   user_id              uuid           primary key,
+   channels             text[]         not null default '{}',
+   reminder_offsets     int[]          not null default '{}',
+   quiet_hours_start    text,
+   quiet_hours_end      text,
+   timezone             text           not null default 'UTC',
+   language             text           not null default 'en',
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+commit;
@@ -0,0 +1,15 @@
+begin;
+
+create table if not exists attendance (
+   booking_id           bigint         primary key    references booking ( id ),
+   educator_id          uuid           not null,
+   student_id           uuid           not null,
+   outcome              int            not null,
+   note                 text,
+   recorded_at          timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_attendance_educator_student on attendance (educator_id, student_id);
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+drop table if exists session_note_revision;
+
+drop table if exists session_note;
+
+commit;
@@ -0,0 +1,25 @@
+begin;
+
+create table if not exists session_note (
+   id                   bigint         generated always as identity primary key,
+   booking_id           bigint         not null    unique    references booking ( id ),
+   educator_id          uuid           not null,
+   student_id           uuid           not null,
+   content              text           not null,
+   version              int            not null,
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create table if not exists session_note_revision (
+   id                   bigint         generated always as identity primary key,
+   note_id              bigint         not null    references session_note ( id ),
+   version              int            not null,
+   content              text           not null,
+   edited_at            timestamptz    not null default current_timestamp,
+   unique (note_id, version)
+);
+
+create index if not exists idx_session_note_student_id on session_note (student_id);
+
+commit;
@@ -0,0 +1,15 @@
+begin;
+
+create table if not exists scheduling_policy (
+   educator_id                 uuid           primary key,
+   timezone                    text           not null default 'UTC',
+   session_lengths             int[]          not null default '{}',
+   buffer_minutes              int            not null default 0,
+   min_notice_hours            int            not null default 0,
+   booking_horizon_days        int            not null default 0,
+   cancellation_notice_hours   int            not null default 0,
+   created_at                  timestamptz    not null default current_timestamp,
+   updated_at                  timestamptz    not null default current_timestamp
+);
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+alter table attendance drop column if exists checked_in_at;
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+alter table attendance add column if not exists checked_in_at timestamptz;
+
+commit;
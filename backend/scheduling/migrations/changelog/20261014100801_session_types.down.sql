@@ -0,0 +1,9 @@
+begin;
+
+alter table booking drop column if exists session_type_id;
+
+alter table scheduled_event drop column if exists session_type_id;
+
+drop table if exists session_type;
+
+commit;
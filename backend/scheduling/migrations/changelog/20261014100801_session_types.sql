@@ -0,0 +1,21 @@
+begin;
+
+create table if not exists session_type (
+   id                   bigint         generated always as identity primary key,
+   educator_id          uuid           not null,
+   name                 text           not null,
+   duration_minutes     int            not null,
+   default_price        numeric(12,2)  not null default 0,
+   delivery_mode        int            not null default 0,
+   color                text           not null,
+   archived_at          timestamptz,
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_session_type_educator_id on session_type (educator_id);
+
+alter table scheduled_event add column if not exists session_type_id bigint references session_type ( id );
+alter table booking add column if not exists session_type_id bigint references session_type ( id );
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+alter table scheduled_event drop column if exists location_id;
+
+drop table if exists location;
+
+commit;
@@ -0,0 +1,20 @@
+begin;
+
+create table if not exists location (
+   id                   bigint         generated always as identity primary key,
+   educator_id          uuid           not null,
+   name                 text           not null,
+   address              text           not null,
+   latitude             double precision not null,
+   longitude            double precision not null,
+   capacity             int            not null,
+   archived_at          timestamptz,
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_location_educator_id on location (educator_id);
+
+alter table scheduled_event add column if not exists location_id bigint references location ( id );
+
+commit;
@@ -0,0 +1,11 @@
+begin;
+
+create table if not exists user_deletion (
+   user_id              uuid           primary key,
+   event_id             text           not null,
+   bookings_cancelled   int            not null default 0,
+   events_released      int            not null default 0,
+   processed_at         timestamptz    not null default current_timestamp
+);
+
+commit;
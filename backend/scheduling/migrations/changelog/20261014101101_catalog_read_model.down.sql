@@ -0,0 +1,9 @@
+begin;
+
+drop table if exists catalog_enrollment;
+
+drop table if exists catalog_lesson;
+
+drop table if exists catalog_product;
+
+commit;
@@ -0,0 +1,33 @@
+begin;
+
+create table if not exists catalog_product (
+   product_id           bigint         primary key,
+   educator_id          uuid           not null,
+   product_type         int            not null,
+   title                text           not null,
+   price                numeric(12, 2) not null default 0,
+   duration_min         int,
+   max_participants     int            not null default 0,
+   version              bigint         not null,
+   deleted_at           timestamptz,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create table if not exists catalog_lesson (
+   lesson_id            bigint         primary key,
+   product_id           bigint         not null    references catalog_product ( product_id ) on delete cascade,
+   duration_min         int            not null
+);
+
+create table if not exists catalog_enrollment (
+   enrollment_id        bigint         primary key,
+   user_id              uuid           not null,
+   product_id           bigint         not null,
+   scheduled_event_id   bigint,
+   created_at           timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_catalog_lesson_product_id on catalog_lesson (product_id);
+create index if not exists idx_catalog_enrollment_user_id on catalog_enrollment (user_id);
+
+commit;
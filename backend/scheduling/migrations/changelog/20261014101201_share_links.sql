@@ -0,0 +1,16 @@
+begin;
+
+create table if not exists share_link (
+   id                   bigint         generated always as identity primary key,
+   educator_id          uuid           not null,
+   label                text,
+   session_type_ids     bigint[]       not null default '{}',
+   from_date            timestamptz    not null,
+   to_date              timestamptz    not null,
+   revoked_at           timestamptz,
+   created_at           timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_share_link_educator_id on share_link (educator_id);
+
+commit;
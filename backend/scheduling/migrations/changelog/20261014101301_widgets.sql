@@ -0,0 +1,13 @@
+begin;
+
+create table if not exists widget_config (
+   educator_id             uuid           primary key,
+   enabled                 boolean        not null default false,
+   allowed_origins         text[]         not null default '{}',
+   api_key_hash            text,
+   rate_limit_per_minute   int            not null default 0,
+   created_at              timestamptz    not null default current_timestamp,
+   updated_at              timestamptz    not null default current_timestamp
+);
+
+commit;
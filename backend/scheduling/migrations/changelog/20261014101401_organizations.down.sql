@@ -0,0 +1,7 @@
+begin;
+
+drop table if exists organization_member;
+
+drop table if exists organization;
+
+commit;
@@ -0,0 +1,21 @@
+begin;
+
+create table if not exists organization (
+   id                   bigint         generated always as identity primary key,
+   name                 text           not null,
+   created_by           uuid           not null,
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create table if not exists organization_member (
+   organization_id      bigint         not null references organization (id) on delete cascade,
+   user_id              uuid           not null,
+   role                 int            not null,
+   created_at           timestamptz    not null default current_timestamp,
+   primary key (organization_id, user_id)
+);
+
+create index if not exists idx_organization_member_user_id on organization_member (user_id);
+
+commit;
@@ -0,0 +1,14 @@
+begin;
+
+create table if not exists schedule_grant (
+   educator_id          uuid           not null,
+   grantee_id           uuid           not null,
+   permissions          text[]         not null default '{}',
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp,
+   primary key (educator_id, grantee_id)
+);
+
+create index if not exists idx_schedule_grant_grantee_id on schedule_grant (grantee_id);
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+drop index if exists idx_booking_student_id_educator_id;
+
+drop table if exists favorite_teacher;
+
+commit;
@@ -0,0 +1,12 @@
+begin;
+
+create table if not exists favorite_teacher (
+   student_id           uuid           not null,
+   educator_id          uuid           not null,
+   created_at           timestamptz    not null default current_timestamp,
+   primary key (student_id, educator_id)
+);
+
+create index if not exists idx_booking_student_id_educator_id on booking (student_id, educator_id);
+
+commit;
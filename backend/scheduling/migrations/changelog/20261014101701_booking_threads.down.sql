@@ -0,0 +1,7 @@
+begin;
+
+drop table if exists booking_thread_read;
+
+drop table if exists booking_message;
+
+commit;
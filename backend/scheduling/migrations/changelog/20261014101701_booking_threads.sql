@@ -0,0 +1,21 @@
+begin;
+
+create table if not exists booking_message (
+   id                   bigint         generated always as identity primary key,
+   booking_id           bigint         not null    references booking ( id ),
+   sender_id            uuid           not null,
+   body                 text           not null,
+   created_at           timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_booking_message_booking_id on booking_message (booking_id, id);
+
+create table if not exists booking_thread_read (
+   booking_id           bigint         not null    references booking ( id ),
+   user_id              uuid           not null,
+   last_read_id         bigint         not null,
+   updated_at           timestamptz    not null default current_timestamp,
+   primary key (booking_id, user_id)
+);
+
+commit;
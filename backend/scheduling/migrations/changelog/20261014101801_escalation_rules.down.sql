@@ -0,0 +1,7 @@
+begin;
+
+drop table if exists booking_escalation;
+
+drop table if exists escalation_rule;
+
+commit;
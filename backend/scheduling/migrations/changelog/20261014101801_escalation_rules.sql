@@ -0,0 +1,28 @@
+begin;
+
+create table if not exists escalation_rule (
+   id                   bigint         generated always as identity primary key,
+   educator_id          uuid           not null,
+   name                 varchar(100)   not null,
+   condition            varchar(32)    not null,
+   minutes_before_start int            not null,
+   channels             text[]         not null default '{}',
+   flag_dashboard       boolean        not null default false,
+   enabled              boolean        not null default true,
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_escalation_rule_educator_id on escalation_rule (educator_id);
+
+create table if not exists booking_escalation (
+   rule_id              bigint         not null    references escalation_rule ( id ) on delete cascade,
+   booking_id           bigint         not null    references booking ( id ),
+   flagged              boolean        not null,
+   triggered_at         timestamptz    not null default current_timestamp,
+   primary key (rule_id, booking_id)
+);
+
+create index if not exists idx_booking_escalation_booking_id on booking_escalation (booking_id);
+
+commit;
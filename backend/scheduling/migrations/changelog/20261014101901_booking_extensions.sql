@@ -0,0 +1,16 @@
+begin;
+
+create table if not exists booking_extension (
+   id                   bigint         generated always as identity primary key,
+   booking_id           bigint         not null    references booking ( id ),
+   minutes              int            not null,
+   price                numeric(12, 2) not null default 0,
+   status               int            not null,
+   created_by           uuid           not null,
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_booking_extension_booking_id on booking_extension (booking_id);
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+drop table if exists blackout_date;
+
+drop table if exists availability_rule;
+
+drop table if exists availability_rule_set;
+
+commit;
@@ -0,0 +1,28 @@
+begin;
+
+create table if not exists availability_rule_set (
+   educator_id          uuid           primary key,
+   timezone             varchar(64)    not null,
+   weeks                int            not null,
+   created_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+create table if not exists availability_rule (
+   id                   bigint         generated always as identity primary key,
+   educator_id          uuid           not null    references availability_rule_set ( educator_id ) on delete cascade,
+   day_of_week          smallint       not null,
+   start_clock          varchar(5)     not null,
+   end_clock            varchar(5)     not null
+);
+
+create index if not exists idx_availability_rule_educator_id on availability_rule (educator_id);
+
+create table if not exists blackout_date (
+   educator_id          uuid           not null    references availability_rule_set ( educator_id ) on delete cascade,
+   blackout_date        date           not null,
+   reason               varchar(200),
+   primary key (educator_id, blackout_date)
+);
+
+commit;
@@ -0,0 +1,22 @@
+begin;
+
+create table if not exists teacher_offboarding (
+   educator_id          uuid           primary key,
+   mode                 varchar(16)    not null,
+   reassign_to          uuid,
+   status               int            not null,
+   initiated_by         uuid           not null,
+   last_booking_id      bigint         not null default 0,
+   reassign_done        boolean        not null default false,
+   bookings_reassigned  int            not null default 0,
+   bookings_kept        int            not null default 0,
+   events_released      int            not null default 0,
+   periods_released     int            not null default 0,
+   started_at           timestamptz    not null default current_timestamp,
+   updated_at           timestamptz    not null default current_timestamp,
+   archived_at          timestamptz
+);
+
+create index if not exists idx_teacher_offboarding_status on teacher_offboarding (status);
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+alter table session_type drop column if exists confirmation_deadline_minutes;
+
+commit;
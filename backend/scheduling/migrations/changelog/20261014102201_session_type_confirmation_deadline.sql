@@ -0,0 +1,5 @@
+begin;
+
+alter table session_type add column if not exists confirmation_deadline_minutes int;
+
+commit;
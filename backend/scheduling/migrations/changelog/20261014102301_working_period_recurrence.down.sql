@@ -0,0 +1,7 @@
+begin;
+
+alter table working_period drop column if exists recurrence_id;
+
+drop table if exists working_period_recurrence;
+
+commit;
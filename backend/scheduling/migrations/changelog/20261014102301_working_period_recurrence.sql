@@ -0,0 +1,21 @@
+begin;
+
+create table if not exists working_period_recurrence (
+   id            bigserial      primary key,
+   user_id       uuid           not null,
+   rrule         varchar(255)   not null,
+   start_clock   varchar(5)     not null,
+   end_clock     varchar(5)     not null,
+   timezone      varchar(64)    not null,
+   starts_on     date           not null,
+   created_at    timestamptz    not null default current_timestamp,
+   updated_at    timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_working_period_recurrence_user_id on working_period_recurrence (user_id);
+
+alter table working_period add column if not exists recurrence_id bigint references working_period_recurrence (id) on delete set null;
+
+create index if not exists idx_working_period_recurrence_id on working_period (recurrence_id);
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+drop index if exists idx_scheduled_event_user_updated_at;
+
+drop index if exists idx_working_period_user_updated_at;
+
+drop table if exists availability_tombstone;
+
+commit;
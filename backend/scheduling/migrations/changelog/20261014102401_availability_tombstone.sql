@@ -0,0 +1,19 @@
+begin;
+
+create table if not exists availability_tombstone (
+   id            bigserial     primary key,
+   educator_id   uuid          not null,
+   kind          varchar(32)   not null,
+   entity_id     bigint        not null,
+   start_time    timestamptz   not null,
+   end_time      timestamptz   not null,
+   deleted_at    timestamptz   not null default current_timestamp
+);
+
+create index if not exists idx_availability_tombstone_educator_deleted_at on availability_tombstone (educator_id, deleted_at);
+
+create index if not exists idx_working_period_user_updated_at on working_period (user_id, updated_at);
+
+create index if not exists idx_scheduled_event_user_updated_at on scheduled_event (user_id, updated_at);
+
+commit;
@@ -0,0 +1,11 @@
+begin;
+
+create table if not exists processed_message (
+   message_id     varchar(255)   primary key,
+   routing_key    varchar(255)   not null,
+   processed_at   timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_processed_message_processed_at on processed_message (processed_at);
+
+commit;
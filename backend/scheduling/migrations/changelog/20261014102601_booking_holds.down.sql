@@ -0,0 +1,5 @@
+begin;
+
+drop table if exists booking_hold;
+
+commit;
@@ -0,0 +1,22 @@
+begin;
+
+create table if not exists booking_hold (
+   id                  bigserial        primary key,
+   educator_id         uuid             not null,
+   student_id          uuid             not null,
+   enrollment_id       bigint           not null,
+   product_id          bigint           not null,
+   working_period_id   bigint           not null references working_period ( id ) on delete cascade,
+   session_type_id     bigint           references session_type ( id ),
+   title               text             not null,
+   start_time          timestamptz      not null,
+   end_time            timestamptz      not null,
+   price               numeric(12, 2)   not null default 0,
+   expires_at          timestamptz      not null,
+   created_at          timestamptz      not null default current_timestamp
+);
+
+create index if not exists idx_booking_hold_working_period_id on booking_hold (working_period_id);
+create index if not exists idx_booking_hold_expires_at on booking_hold (expires_at);
+
+commit;
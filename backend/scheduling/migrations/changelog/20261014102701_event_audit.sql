@@ -0,0 +1,21 @@
+begin;
+
+create table if not exists event_audit (
+   id               bigserial      primary key,
+   event_id         varchar(255)   not null,
+   event_type       varchar(255)   not null,
+   routing_key      varchar(255)   not null,
+   direction        varchar(16)    not null,
+   correlation_id   varchar(255)   not null,
+   causation_id     varchar(255),
+   actor_type       varchar(16),
+   actor_id         varchar(255),
+   occurred_at      timestamptz    not null,
+   recorded_at      timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_event_audit_correlation_id on event_audit (correlation_id, occurred_at);
+create index if not exists idx_event_audit_causation_id on event_audit (causation_id);
+create index if not exists idx_event_audit_event_id on event_audit (event_id);
+
+commit;
@@ -0,0 +1,10 @@
+begin;
+
+drop index if exists idx_availability_tombstone_deleted_at;
+drop index if exists idx_booking_updated_at;
+drop index if exists idx_scheduled_event_updated_at;
+drop index if exists idx_working_period_updated_at;
+drop table if exists calendar_projection_state;
+drop table if exists calendar_day_summary;
+
+commit;
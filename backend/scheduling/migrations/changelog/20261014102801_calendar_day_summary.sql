@@ -0,0 +1,24 @@
+begin;
+
+create table if not exists calendar_day_summary (
+   educator_id      uuid           not null,
+   day              date           not null,
+   slot_count       int            not null,
+   booked_count     int            not null,
+   updated_at       timestamptz    not null default current_timestamp,
+   primary key (educator_id, day)
+);
+
+create table if not exists calendar_projection_state (
+   id                smallint       primary key check ( id = 1 ),
+   last_changed_at   timestamptz
+);
+
+insert into calendar_projection_state (id, last_changed_at) values (1, null) on conflict (id) do nothing;
+
+create index if not exists idx_working_period_updated_at on working_period (updated_at);
+create index if not exists idx_scheduled_event_updated_at on scheduled_event (updated_at);
+create index if not exists idx_booking_updated_at on booking (updated_at);
+create index if not exists idx_availability_tombstone_deleted_at on availability_tombstone (deleted_at);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+drop index if exists idx_event_outbox_next_attempt_at;
+drop table if exists event_outbox;
+
+commit;
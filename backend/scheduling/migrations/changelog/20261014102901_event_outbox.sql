@@ -0,0 +1,16 @@
+begin;
+
+create table if not exists event_outbox (
+   id                bigserial      primary key,
+   event_id          varchar(255)   not null,
+   routing_key       varchar(255)   not null,
+   body              jsonb          not null,
+   attempts          int            not null default 0,
+   last_error        text,
+   next_attempt_at   timestamptz    not null default current_timestamp,
+   created_at        timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_event_outbox_next_attempt_at on event_outbox (next_attempt_at);
+
+commit;
@@ -0,0 +1,20 @@
+begin;
+
+create table if not exists waitlist_entry (
+   id                   bigserial        primary key,
+   scheduled_event_id   bigint           not null references scheduled_event ( id ) on delete cascade,
+   student_id           uuid             not null,
+   status               int              not null,
+   offer_expires_at     timestamptz,
+   booking_id           bigint           references booking ( id ),
+   created_at           timestamptz      not null default current_timestamp,
+   updated_at           timestamptz      not null default current_timestamp
+);
+
+-- A student waits at most once per scheduled event, waiting (0) and offered (1) entries are active
+create unique index if not exists uq_waitlist_entry_active on waitlist_entry (scheduled_event_id, student_id) where status in (0, 1);
+create index if not exists idx_waitlist_entry_queue on waitlist_entry (scheduled_event_id, created_at, id) where status = 0;
+create index if not exists idx_waitlist_entry_offer_expires_at on waitlist_entry (offer_expires_at) where status = 1;
+create index if not exists idx_waitlist_entry_student_id on waitlist_entry (student_id);
+
+commit;
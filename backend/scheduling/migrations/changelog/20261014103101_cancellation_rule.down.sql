@@ -0,0 +1,5 @@
+begin;
+
+drop table if exists cancellation_rule;
+
+commit;
@@ -0,0 +1,15 @@
+begin;
+
+create table if not exists cancellation_rule (
+   id                        bigserial        primary key,
+   educator_id               uuid             not null,
+   session_type_id           bigint           references session_type ( id ) on delete cascade,
+   min_notice_hours          int              not null default 0,
+   free_cancellation_hours   int              not null default 0,
+   penalty_percent           numeric(5, 2)    not null default 0,
+   created_at                timestamptz      not null default current_timestamp,
+   updated_at                timestamptz      not null default current_timestamp,
+   constraint uq_cancellation_rule_educator_session_type unique nulls not distinct (educator_id, session_type_id)
+);
+
+commit;
@@ -0,0 +1,18 @@
+begin;
+
+create table if not exists demand_forecast (
+   educator_id         uuid             not null,
+   day                 date             not null,
+   available_minutes   int              not null default 0,
+   booked_count        int              not null default 0,
+   booked_minutes      int              not null default 0,
+   expected_bookings   numeric(8, 2)    not null default 0,
+   expected_minutes    int              not null default 0,
+   suggested_slots     int              not null default 0,
+   computed_at         timestamptz      not null default current_timestamp,
+   primary key (educator_id, day)
+);
+
+create index if not exists idx_demand_forecast_computed_at on demand_forecast (computed_at);
+
+commit;
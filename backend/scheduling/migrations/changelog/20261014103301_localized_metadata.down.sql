@@ -0,0 +1,9 @@
+begin;
+
+alter table scheduled_event drop column if exists translations;
+
+alter table session_type drop column if exists translations;
+
+alter table session_type drop column if exists description;
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+alter table session_type add column if not exists description text not null default '';
+
+alter table session_type add column if not exists translations jsonb not null default '{}';
+
+alter table scheduled_event add column if not exists translations jsonb not null default '{}';
+
+commit;
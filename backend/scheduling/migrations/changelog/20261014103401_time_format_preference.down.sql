@@ -0,0 +1,5 @@
+begin;
+
+alter table notification_preference drop column if exists time_format;
+
+commit;
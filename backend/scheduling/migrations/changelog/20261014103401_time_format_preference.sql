@@ -0,0 +1,5 @@
+begin;
+
+alter table notification_preference add column if not exists time_format text not null default '24h';
+
+commit;
@@ -0,0 +1,18 @@
+begin;
+
+create table if not exists idempotency_key (
+   user_id            uuid          not null,
+   key                text          not null,
+   fingerprint        text          not null,
+   status_code        int,
+   response_headers   jsonb,
+   response_body      bytea,
+   created_at         timestamptz   not null default current_timestamp,
+   completed_at       timestamptz,
+   expires_at         timestamptz   not null,
+   primary key (user_id, key)
+);
+
+create index if not exists idx_idempotency_key_expires_at on idempotency_key (expires_at);
+
+commit;
@@ -0,0 +1,17 @@
+begin;
+
+create table if not exists webhook_subscription (
+   id            bigserial      primary key,
+   educator_id   uuid           not null,
+   url           text           not null,
+   event_types   text[]         not null,
+   -- Top level fields of the event data sent to the subscriber, all of them when empty
+   fields        text[]         not null default '{}',
+   active        boolean        not null default true,
+   created_at    timestamptz    not null default current_timestamp,
+   updated_at    timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_webhook_subscription_educator_id on webhook_subscription (educator_id);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+drop table if exists booking_link_use;
+drop table if exists booking_link;
+
+commit;
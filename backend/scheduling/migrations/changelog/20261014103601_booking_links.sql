@@ -0,0 +1,30 @@
+begin;
+
+create table if not exists booking_link (
+   id                   bigint         generated always as identity primary key,
+   educator_id          uuid           not null,
+   working_period_id    bigint         not null references working_period ( id ) on delete cascade,
+   session_type_id      bigint         references session_type ( id ) on delete set null,
+   label                text,
+   start_time           timestamptz    not null,
+   end_time             timestamptz    not null,
+   max_uses             integer        not null,
+   uses                 integer        not null default 0,
+   expires_at           timestamptz    not null,
+   revoked_at           timestamptz,
+   created_at           timestamptz    not null default current_timestamp,
+   constraint chk_booking_link_uses check (uses >= 0 and uses <= max_uses)
+);
+
+create index if not exists idx_booking_link_educator_id on booking_link (educator_id);
+
+-- One row per redemption, so a student can not replay a link to book it twice
+create table if not exists booking_link_use (
+   link_id              bigint         not null references booking_link ( id ) on delete cascade,
+   student_id           uuid           not null,
+   booking_id           bigint         not null references booking ( id ) on delete cascade,
+   used_at              timestamptz    not null default current_timestamp,
+   primary key (link_id, student_id)
+);
+
+commit;
@@ -0,0 +1,20 @@
+begin;
+
+create table if not exists dead_letter (
+   id                bigserial      primary key,
+   message_id        varchar(255)   not null,
+   correlation_id    varchar(255),
+   message_type      varchar(255),
+   exchange          varchar(255)   not null,
+   routing_key       varchar(255)   not null,
+   queue             varchar(255)   not null,
+   reason            varchar(64)    not null,
+   death_count       bigint         not null default 1,
+   headers           jsonb          not null default '{}',
+   body              bytea          not null,
+   dead_lettered_at  timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_dead_letter_dead_lettered_at on dead_letter (dead_lettered_at desc);
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+alter table event_audit drop column if exists signature;
+
+alter table event_audit drop column if exists previous_signature;
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+-- Entries recorded before signing was introduced keep null signatures and are reported as unsigned
+alter table event_audit add column if not exists previous_signature varchar(64);
+alter table event_audit add column if not exists signature varchar(64);
+
+commit;
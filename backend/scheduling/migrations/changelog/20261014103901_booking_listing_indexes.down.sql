@@ -0,0 +1,11 @@
+begin;
+
+drop index if exists idx_booking_created_at;
+
+drop index if exists idx_booking_start_time;
+
+drop index if exists idx_booking_student_id_start_time;
+
+drop index if exists idx_booking_educator_id_start_time;
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+-- Keyset pages of booking listings seek on the sort column and id within an educator or student
+create index if not exists idx_booking_educator_id_start_time on booking (educator_id, start_time, id);
+create index if not exists idx_booking_student_id_start_time on booking (student_id, start_time, id);
+create index if not exists idx_booking_start_time on booking (start_time, id);
+create index if not exists idx_booking_created_at on booking (created_at, id);
+
+commit;
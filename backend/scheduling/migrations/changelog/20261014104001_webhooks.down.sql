@@ -0,0 +1,7 @@
+begin;
+
+drop table if exists webhook_delivery;
+
+alter table webhook_subscription drop column if exists secret;
+
+commit;
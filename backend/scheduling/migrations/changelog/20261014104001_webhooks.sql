@@ -0,0 +1,26 @@
+begin;
+
+-- Subscriptions created before deliveries were signed get a random secret, subscribers re-create them to learn one
+alter table webhook_subscription add column if not exists secret varchar(64) not null default md5(random()::text) || md5(random()::text);
+alter table webhook_subscription alter column secret drop default;
+
+create table if not exists webhook_delivery (
+   id                 bigserial      primary key,
+   subscription_id    bigint         not null references webhook_subscription (id) on delete cascade,
+   event_id           uuid           not null,
+   event_type         varchar(64)    not null,
+   payload            jsonb          not null,
+   status             varchar(16)    not null default 'pending',
+   attempts           int            not null default 0,
+   last_status_code   int,
+   last_error         text,
+   next_attempt_at    timestamptz    not null default current_timestamp,
+   delivered_at       timestamptz,
+   created_at         timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_webhook_delivery_due on webhook_delivery (next_attempt_at) where status = 'pending';
+create index if not exists idx_webhook_delivery_subscription_id on webhook_delivery (subscription_id, created_at desc);
+create index if not exists idx_webhook_delivery_created_at on webhook_delivery (created_at);
+
+commit;
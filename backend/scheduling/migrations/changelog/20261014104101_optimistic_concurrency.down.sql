@@ -0,0 +1,7 @@
+begin;
+
+alter table booking drop column if exists version;
+alter table scheduled_event drop column if exists version;
+alter table working_period drop column if exists version;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+-- Every update bumps the version, writes that name an older version are rejected as stale
+alter table working_period add column if not exists version bigint not null default 1;
+alter table scheduled_event add column if not exists version bigint not null default 1;
+alter table booking add column if not exists version bigint not null default 1;
+
+commit;
@@ -0,0 +1,7 @@
+begin;
+
+drop index if exists idx_scheduled_event_start_time;
+
+drop table if exists session_reminder;
+
+commit;
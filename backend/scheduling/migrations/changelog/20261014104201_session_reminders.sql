@@ -0,0 +1,20 @@
+begin;
+
+-- Reminders sent or skipped per session, participant and offset, so each is enqueued once across replicas
+-- and restarts
+create table if not exists session_reminder (
+    id bigserial primary key,
+    session_kind varchar(20) not null,
+    session_id bigint not null,
+    user_id uuid not null,
+    offset_minutes int not null,
+    due_at timestamptz not null,
+    status varchar(20) not null,
+    created_at timestamptz not null,
+    constraint uq_session_reminder unique (session_kind, session_id, user_id, offset_minutes)
+);
+
+create index if not exists idx_session_reminder_created_at on session_reminder (created_at);
+create index if not exists idx_scheduled_event_start_time on scheduled_event (start_time);
+
+commit;
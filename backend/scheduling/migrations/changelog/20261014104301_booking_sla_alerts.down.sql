@@ -0,0 +1,7 @@
+begin;
+
+drop index if exists idx_booking_pending_created_at;
+
+drop table if exists booking_sla_alert;
+
+commit;
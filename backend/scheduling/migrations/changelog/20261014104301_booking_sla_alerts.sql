@@ -0,0 +1,16 @@
+begin;
+
+-- Bookings and holds an SLA breach alert was raised for, so each breach is alerted once
+create table if not exists booking_sla_alert (
+    id bigserial primary key,
+    state varchar(30) not null,
+    entity_id bigint not null,
+    raised_at timestamptz not null,
+    constraint uq_booking_sla_alert unique (state, entity_id)
+);
+
+create index if not exists idx_booking_sla_alert_raised_at on booking_sla_alert (raised_at);
+-- Pending bookings are scanned oldest first for breaches and the pending age metrics
+create index if not exists idx_booking_pending_created_at on booking (created_at) where status = 0;
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+alter table scheduling_policy drop column if exists buffer_before_minutes;
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+-- buffer_minutes is kept free after each session, buffer_before_minutes before it
+alter table scheduling_policy add column if not exists buffer_before_minutes int not null default 0;
+
+commit;
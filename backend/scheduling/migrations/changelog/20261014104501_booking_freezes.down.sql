@@ -0,0 +1,7 @@
+begin;
+
+drop index if exists idx_booking_freeze_ends_at;
+
+drop table if exists booking_freeze;
+
+commit;
@@ -0,0 +1,20 @@
+begin;
+
+-- A freeze without educators and organization applies platform-wide
+create table if not exists booking_freeze (
+   id                   bigint         generated always as identity primary key,
+   message              text           not null,
+   reason               text           not null default '',
+   educator_ids         uuid[]         not null default '{}',
+   organization_id      bigint         references organization (id) on delete cascade,
+   starts_at            timestamptz    not null,
+   ends_at              timestamptz    not null,
+   created_by           uuid           not null,
+   created_at           timestamptz    not null default current_timestamp,
+   lifted_by            uuid,
+   lifted_at            timestamptz
+);
+
+create index if not exists idx_booking_freeze_ends_at on booking_freeze (ends_at) where lifted_at is null;
+
+commit;
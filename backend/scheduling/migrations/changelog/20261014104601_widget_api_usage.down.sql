@@ -0,0 +1,7 @@
+begin;
+
+drop index if exists idx_widget_api_usage_bucket_start;
+
+drop table if exists widget_api_usage;
+
+commit;
@@ -0,0 +1,17 @@
+begin;
+
+-- Hourly request counts of widget API keys, key_id is a prefix of the key hash so usage stays apart across rotations
+create table if not exists widget_api_usage (
+   educator_id          uuid           not null,
+   key_id               text           not null,
+   endpoint             text           not null,
+   bucket_start         timestamptz    not null,
+   requests             bigint         not null default 0,
+   errors               bigint         not null default 0,
+   rate_limited         bigint         not null default 0,
+   primary key (educator_id, key_id, endpoint, bucket_start)
+);
+
+create index if not exists idx_widget_api_usage_bucket_start on widget_api_usage (bucket_start);
+
+commit;
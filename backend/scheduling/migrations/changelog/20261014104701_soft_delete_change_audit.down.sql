@@ -0,0 +1,27 @@
+begin;
+
+drop trigger if exists trg_change_audit_append_only on change_audit;
+drop function if exists reject_change_audit_change();
+
+drop trigger if exists trg_booking_change_audit on booking;
+drop trigger if exists trg_scheduled_event_change_audit on scheduled_event;
+drop trigger if exists trg_working_period_change_audit on working_period;
+drop function if exists record_change_audit();
+
+drop table if exists change_audit;
+
+drop trigger if exists trg_scheduled_event_soft_delete on scheduled_event;
+drop trigger if exists trg_working_period_soft_delete on working_period;
+drop function if exists release_soft_deleted_scheduled_event();
+drop function if exists release_soft_deleted_working_period();
+
+delete from scheduled_event where deleted_at is not null;
+delete from working_period where deleted_at is not null;
+
+drop index if exists idx_scheduled_event_user_start_time_active;
+drop index if exists idx_working_period_user_start_time_active;
+
+alter table scheduled_event drop column if exists deleted_at;
+alter table working_period drop column if exists deleted_at;
+
+commit;
@@ -0,0 +1,115 @@
+begin;
+
+alter table working_period add column if not exists deleted_at timestamptz;
+alter table scheduled_event add column if not exists deleted_at timestamptz;
+
+create index if not exists idx_working_period_user_start_time_active on working_period (user_id, start_time) where deleted_at is null;
+create index if not exists idx_scheduled_event_user_start_time_active on scheduled_event (user_id, start_time) where deleted_at is null;
+
+-- Soft deletes keep the cascades hard deletes had
+create or replace function release_soft_deleted_working_period() returns trigger as $$
+begin
+   delete from booking_hold where working_period_id = new.id;
+   delete from booking_link where working_period_id = new.id;
+   return null;
+end;
+$$ language plpgsql;
+
+create or replace function release_soft_deleted_scheduled_event() returns trigger as $$
+begin
+   delete from waitlist_entry where scheduled_event_id = new.id;
+   return null;
+end;
+$$ language plpgsql;
+
+create trigger trg_working_period_soft_delete
+   after update of deleted_at on working_period
+   for each row when (old.deleted_at is null and new.deleted_at is not null)
+   execute function release_soft_deleted_working_period();
+
+create trigger trg_scheduled_event_soft_delete
+   after update of deleted_at on scheduled_event
+   for each row when (old.deleted_at is null and new.deleted_at is not null)
+   execute function release_soft_deleted_scheduled_event();
+
+create table if not exists change_audit (
+   id            bigserial      primary key,
+   entity_type   varchar(32)    not null,
+   entity_id     bigint         not null,
+   action        varchar(16)    not null,
+   educator_id   uuid,
+   student_id    uuid,
+   actor_id      uuid,
+   old_values    jsonb,
+   new_values    jsonb,
+   changed_at    timestamptz    not null default clock_timestamp()
+);
+
+create index if not exists idx_change_audit_entity on change_audit (entity_type, entity_id, id);
+create index if not exists idx_change_audit_educator_id on change_audit (educator_id, id);
+create index if not exists idx_change_audit_student_id on change_audit (student_id, id);
+create index if not exists idx_change_audit_actor_id on change_audit (actor_id, id);
+create index if not exists idx_change_audit_changed_at on change_audit (changed_at);
+
+-- Records every change of an audited row. Updates keep only the columns that changed, and a soft delete is
+-- recorded as a delete. The actor is the user set on the transaction, none for system changes.
+create or replace function record_change_audit() returns trigger as $$
+declare
+   old_row    jsonb := case when tg_op <> 'INSERT' then to_jsonb(old) end;
+   new_row    jsonb := case when tg_op <> 'DELETE' then to_jsonb(new) end;
+   row_data   jsonb := coalesce(new_row, old_row);
+   action     text  := case tg_op when 'INSERT' then 'CREATE' else tg_op end;
+begin
+   if tg_op = 'UPDATE' then
+      select jsonb_object_agg(o.key, o.value), jsonb_object_agg(o.key, n.value)
+      into old_row, new_row
+      from jsonb_each(to_jsonb(old)) o
+      join jsonb_each(to_jsonb(new)) n on n.key = o.key
+      where o.value is distinct from n.value;
+
+      if new_row is null then
+         return null;
+      end if;
+      if jsonb_typeof(new_row->'deleted_at') = 'string' then
+         action := 'DELETE';
+      end if;
+   end if;
+
+   insert into change_audit (entity_type, entity_id, action, educator_id, student_id, actor_id, old_values, new_values)
+   values (
+      tg_argv[0],
+      (row_data->>'id')::bigint,
+      action,
+      coalesce(row_data->>'educator_id', row_data->>'user_id')::uuid,
+      (row_data->>'student_id')::uuid,
+      nullif(current_setting('scheduling.actor_id', true), '')::uuid,
+      old_row,
+      new_row
+   );
+   return null;
+end;
+$$ language plpgsql;
+
+create trigger trg_working_period_change_audit
+   after insert or update or delete on working_period
+   for each row execute function record_change_audit('WORKING_PERIOD');
+
+create trigger trg_scheduled_event_change_audit
+   after insert or update or delete on scheduled_event
+   for each row execute function record_change_audit('SCHEDULED_EVENT');
+
+create trigger trg_booking_change_audit
+   after insert or update or delete on booking
+   for each row execute function record_change_audit('BOOKING');
+
+create or replace function reject_change_audit_change() returns trigger as $$
+begin
+   raise exception 'change_audit is append-only';
+end;
+$$ language plpgsql;
+
+create trigger trg_change_audit_append_only
+   before update or delete or truncate on change_audit
+   for each statement execute function reject_change_audit_change();
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+drop table if exists webhook_dead_letter;
+
+commit;
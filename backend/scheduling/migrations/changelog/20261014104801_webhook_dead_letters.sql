@@ -0,0 +1,20 @@
+begin;
+
+-- Deliveries that ran out of attempts, kept until they are redelivered or purged
+create table if not exists webhook_dead_letter (
+   id                 bigserial      primary key,
+   delivery_id        bigint         not null,
+   subscription_id    bigint         not null references webhook_subscription (id) on delete cascade,
+   event_id           uuid           not null,
+   event_type         varchar(64)    not null,
+   payload            jsonb          not null,
+   attempts           int            not null,
+   last_status_code   int,
+   last_error         text,
+   dead_lettered_at   timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_webhook_dead_letter_subscription_id on webhook_dead_letter (subscription_id, dead_lettered_at desc);
+create index if not exists idx_webhook_dead_letter_dead_lettered_at on webhook_dead_letter (dead_lettered_at desc);
+
+commit;
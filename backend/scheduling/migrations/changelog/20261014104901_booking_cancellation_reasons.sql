@@ -0,0 +1,13 @@
+begin;
+
+-- Reasons students give for cancelling their bookings, from the cancellation reason taxonomy
+create table if not exists booking_cancellation_reason (
+   booking_id   bigint         primary key references booking (id) on delete cascade,
+   reason       smallint       not null,
+   note         text,
+   created_at   timestamptz    not null default current_timestamp
+);
+
+create index if not exists idx_booking_cancellation_reason_created_at on booking_cancellation_reason (created_at);
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+drop trigger if exists trg_booking_status_transition_update on booking;
+drop trigger if exists trg_booking_status_transition_insert on booking;
+drop function if exists record_booking_status_transition();
+drop table if exists booking_status_transition;
+
+commit;
@@ -0,0 +1,38 @@
+begin;
+
+create table if not exists booking_status_transition (
+   id            bigserial      primary key,
+   booking_id    bigint         not null references booking (id) on delete cascade,
+   from_status   int,
+   to_status     int            not null,
+   actor_id      uuid,
+   changed_at    timestamptz    not null default clock_timestamp()
+);
+
+create index if not exists idx_booking_status_transition_booking_id on booking_status_transition (booking_id, id);
+
+-- Records the status a booking is created with and every later change of it, whichever code path made it.
+-- The actor is the user set on the transaction, as for the change audit.
+create or replace function record_booking_status_transition() returns trigger as $$
+begin
+   insert into booking_status_transition (booking_id, from_status, to_status, actor_id)
+   values (
+      new.id,
+      case when tg_op = 'UPDATE' then old.status end,
+      new.status,
+      nullif(current_setting('scheduling.actor_id', true), '')::uuid
+   );
+   return null;
+end;
+$$ language plpgsql;
+
+create trigger trg_booking_status_transition_insert
+   after insert on booking
+   for each row execute function record_booking_status_transition();
+
+create trigger trg_booking_status_transition_update
+   after update of status on booking
+   for each row when (old.status is distinct from new.status)
+   execute function record_booking_status_transition();
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+alter table event_outbox drop column if exists event_type;
+
+commit;
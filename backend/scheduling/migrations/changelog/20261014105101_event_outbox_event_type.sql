@@ -0,0 +1,5 @@
+begin;
+
+alter table event_outbox add column if not exists event_type varchar(255);
+
+commit;
@@ -0,0 +1,40 @@
+begin;
+
+drop trigger if exists trg_booking_hold_tenant on booking_hold;
+drop trigger if exists trg_booking_tenant on booking;
+drop trigger if exists trg_scheduled_event_tenant on scheduled_event;
+drop function if exists inherit_working_period_tenant();
+
+drop policy if exists tenant_isolation on scheduling_policy;
+alter table scheduling_policy no force row level security;
+alter table scheduling_policy disable row level security;
+alter table scheduling_policy drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on booking_hold;
+alter table booking_hold no force row level security;
+alter table booking_hold disable row level security;
+alter table booking_hold drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on booking;
+alter table booking no force row level security;
+alter table booking disable row level security;
+alter table booking drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on scheduled_event;
+alter table scheduled_event no force row level security;
+alter table scheduled_event disable row level security;
+alter table scheduled_event drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on working_period_recurrence;
+alter table working_period_recurrence no force row level security;
+alter table working_period_recurrence disable row level security;
+alter table working_period_recurrence drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on working_period;
+alter table working_period no force row level security;
+alter table working_period disable row level security;
+alter table working_period drop column if exists tenant_id;
+
+drop function if exists current_tenant_id();
+
+commit;
@@ -0,0 +1,77 @@
+begin;
+
+-- Rows belong to the tenant set on the transaction, rows created without one and the rows that predate
+-- tenancy to the 'default' tenant
+create or replace function current_tenant_id() returns varchar as $$
+   select nullif(current_setting('scheduling.tenant_id', true), '');
+$$ language sql stable;
+
+alter table working_period add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table working_period_recurrence add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table scheduled_event add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table booking add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table booking_hold add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table scheduling_policy add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+
+-- A transaction with a tenant only sees and writes rows of that tenant. Work without one, such as background
+-- jobs, sees every tenant. Row security is forced, so it applies to the owner of the tables as well.
+alter table working_period enable row level security;
+alter table working_period force row level security;
+create policy tenant_isolation on working_period
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+alter table working_period_recurrence enable row level security;
+alter table working_period_recurrence force row level security;
+create policy tenant_isolation on working_period_recurrence
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+alter table scheduled_event enable row level security;
+alter table scheduled_event force row level security;
+create policy tenant_isolation on scheduled_event
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+alter table booking enable row level security;
+alter table booking force row level security;
+create policy tenant_isolation on booking
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+alter table booking_hold enable row level security;
+alter table booking_hold force row level security;
+create policy tenant_isolation on booking_hold
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+alter table scheduling_policy enable row level security;
+alter table scheduling_policy force row level security;
+create policy tenant_isolation on scheduling_policy
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+-- Rows created without a tenant, such as bookings made by background jobs and consumers, belong to the
+-- tenant of their working period instead of the default one
+create or replace function inherit_working_period_tenant() returns trigger as $$
+begin
+   if current_tenant_id() is null then
+      select wp.tenant_id into new.tenant_id from working_period wp where wp.id = new.working_period_id;
+   end if;
+   return new;
+end;
+$$ language plpgsql;
+
+create trigger trg_scheduled_event_tenant
+   before insert on scheduled_event
+   for each row execute function inherit_working_period_tenant();
+
+create trigger trg_booking_tenant
+   before insert on booking
+   for each row execute function inherit_working_period_tenant();
+
+create trigger trg_booking_hold_tenant
+   before insert on booking_hold
+   for each row execute function inherit_working_period_tenant();
+
+commit;
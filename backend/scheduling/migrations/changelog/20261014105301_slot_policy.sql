@@ -0,0 +1,12 @@
+begin;
+
+-- Holds a single row, the platform-wide slot granularity; without it sessions are not restricted
+create table if not exists slot_policy (
+   id                   smallint       primary key default 1 check (id = 1),
+   allowed_durations    integer[]      not null default '{}',
+   alignment_minutes    integer        not null default 0,
+   updated_by           uuid           not null,
+   updated_at           timestamptz    not null default current_timestamp
+);
+
+commit;
@@ -0,0 +1,29 @@
+begin;
+
+alter policy tenant_isolation on working_period
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+alter policy tenant_isolation on working_period_recurrence
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+alter policy tenant_isolation on scheduled_event
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+alter policy tenant_isolation on booking
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+alter policy tenant_isolation on booking_hold
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+alter policy tenant_isolation on scheduling_policy
+   using (current_tenant_id() is null or tenant_id = current_tenant_id())
+   with check (current_tenant_id() is null or tenant_id = current_tenant_id());
+
+drop function if exists tenant_row_visible(varchar);
+
+commit;
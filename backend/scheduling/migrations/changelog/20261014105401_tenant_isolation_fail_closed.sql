@@ -0,0 +1,35 @@
+begin;
+
+-- Row security fails closed: a session sees and writes the rows of the tenant set on it, or of every tenant
+-- when it explicitly bypasses tenancy as background jobs and migrations do. A session with neither sees no
+-- rows, rather than those of every tenant.
+create or replace function tenant_row_visible(row_tenant_id varchar) returns boolean as $$
+   select row_tenant_id = current_tenant_id()
+      or (current_tenant_id() is null and current_setting('scheduling.tenant_bypass', true) = 'on');
+$$ language sql stable;
+
+alter policy tenant_isolation on working_period
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter policy tenant_isolation on working_period_recurrence
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter policy tenant_isolation on scheduled_event
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter policy tenant_isolation on booking
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter policy tenant_isolation on booking_hold
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter policy tenant_isolation on scheduling_policy
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+commit;
@@ -0,0 +1,32 @@
+begin;
+
+drop trigger if exists trg_booking_status_chain_release on booking;
+drop function if exists release_booking_status_chain();
+
+drop trigger if exists trg_booking_status_transition_sign_only on booking_status_transition;
+drop trigger if exists trg_change_audit_sign_only on change_audit;
+drop trigger if exists trg_change_audit_append_only on change_audit;
+drop function if exists reject_audit_row_change();
+
+create trigger trg_change_audit_append_only
+   before update or delete or truncate on change_audit
+   for each statement execute function reject_change_audit_change();
+
+drop index if exists idx_booking_status_transition_unsigned;
+drop index if exists idx_booking_status_transition_chain;
+alter table booking_status_transition drop column if exists signature;
+alter table booking_status_transition drop column if exists previous_signature;
+alter table booking_status_transition drop column if exists chain_sequence;
+
+drop index if exists idx_change_audit_unsigned;
+drop index if exists idx_change_audit_chain;
+alter table change_audit drop column if exists signature;
+alter table change_audit drop column if exists previous_signature;
+alter table change_audit drop column if exists chain_sequence;
+
+drop index if exists idx_event_audit_chain;
+alter table event_audit drop column if exists chain_sequence;
+
+drop table if exists audit_chain_head;
+
+commit;
@@ -0,0 +1,74 @@
+begin;
+
+-- Audit trails are chained per entity instead of through one chain, so appends for different entities never
+-- wait on each other: messages per correlation, changes per audited row and status transitions per booking.
+-- The head of every chain is kept signed, so entries removed from the end of a chain are detected.
+create table if not exists audit_chain_head (
+   chain_type       varchar(32)    not null,
+   chain_key        varchar(255)   not null,
+   sequence         bigint         not null,
+   signature        varchar(64)    not null,
+   head_signature   varchar(64)    not null,
+   updated_at       timestamptz    not null default current_timestamp,
+   primary key (chain_type, chain_key)
+);
+
+-- Messages signed before keep a null sequence and stay on the single chain they were signed on
+alter table event_audit add column if not exists chain_sequence bigint;
+
+create index if not exists idx_event_audit_chain on event_audit (correlation_id, chain_sequence) where chain_sequence is not null;
+
+-- Changes and status transitions are written by triggers and signed shortly after by the sealing job
+alter table change_audit add column if not exists chain_sequence bigint;
+alter table change_audit add column if not exists previous_signature varchar(64);
+alter table change_audit add column if not exists signature varchar(64);
+
+create index if not exists idx_change_audit_chain on change_audit (entity_type, entity_id, chain_sequence) where chain_sequence is not null;
+create index if not exists idx_change_audit_unsigned on change_audit (id) where signature is null;
+
+alter table booking_status_transition add column if not exists chain_sequence bigint;
+alter table booking_status_transition add column if not exists previous_signature varchar(64);
+alter table booking_status_transition add column if not exists signature varchar(64);
+
+create index if not exists idx_booking_status_transition_chain on booking_status_transition (booking_id, chain_sequence) where chain_sequence is not null;
+create index if not exists idx_booking_status_transition_unsigned on booking_status_transition (id) where signature is null;
+
+-- Audit rows stay append-only, an unsigned row may only be updated once to be signed
+create or replace function reject_audit_row_change() returns trigger as $$
+begin
+   if old.signature is not null
+      or to_jsonb(old) - array['chain_sequence', 'previous_signature', 'signature']
+         is distinct from to_jsonb(new) - array['chain_sequence', 'previous_signature', 'signature'] then
+      raise exception '% is append-only', tg_table_name;
+   end if;
+   return new;
+end;
+$$ language plpgsql;
+
+drop trigger if exists trg_change_audit_append_only on change_audit;
+
+create trigger trg_change_audit_append_only
+   before delete or truncate on change_audit
+   for each statement execute function reject_change_audit_change();
+
+create trigger trg_change_audit_sign_only
+   before update on change_audit
+   for each row execute function reject_audit_row_change();
+
+create trigger trg_booking_status_transition_sign_only
+   before update on booking_status_transition
+   for each row execute function reject_audit_row_change();
+
+-- A booking removed with its status transitions takes the head of their chain with it
+create or replace function release_booking_status_chain() returns trigger as $$
+begin
+   delete from audit_chain_head where chain_type = 'BOOKING_STATUS' and chain_key = old.id::text;
+   return null;
+end;
+$$ language plpgsql;
+
+create trigger trg_booking_status_chain_release
+   after delete on booking
+   for each row execute function release_booking_status_chain();
+
+commit;
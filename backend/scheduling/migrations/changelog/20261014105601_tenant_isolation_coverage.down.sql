@@ -0,0 +1,250 @@
+begin;
+
+drop policy if exists tenant_isolation on widget_api_usage;
+alter table widget_api_usage no force row level security;
+alter table widget_api_usage disable row level security;
+drop trigger if exists trg_widget_api_usage_tenant on widget_api_usage;
+alter table widget_api_usage drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on organization_member;
+alter table organization_member no force row level security;
+alter table organization_member disable row level security;
+drop trigger if exists trg_organization_member_tenant on organization_member;
+alter table organization_member drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on catalog_enrollment;
+alter table catalog_enrollment no force row level security;
+alter table catalog_enrollment disable row level security;
+drop trigger if exists trg_catalog_enrollment_tenant on catalog_enrollment;
+alter table catalog_enrollment drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on catalog_lesson;
+alter table catalog_lesson no force row level security;
+alter table catalog_lesson disable row level security;
+drop trigger if exists trg_catalog_lesson_tenant on catalog_lesson;
+alter table catalog_lesson drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on blackout_date;
+alter table blackout_date no force row level security;
+alter table blackout_date disable row level security;
+drop trigger if exists trg_blackout_date_tenant on blackout_date;
+alter table blackout_date drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on availability_rule;
+alter table availability_rule no force row level security;
+alter table availability_rule disable row level security;
+drop trigger if exists trg_availability_rule_tenant on availability_rule;
+alter table availability_rule drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on webhook_dead_letter;
+alter table webhook_dead_letter no force row level security;
+alter table webhook_dead_letter disable row level security;
+drop trigger if exists trg_webhook_dead_letter_tenant on webhook_dead_letter;
+alter table webhook_dead_letter drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on webhook_delivery;
+alter table webhook_delivery no force row level security;
+alter table webhook_delivery disable row level security;
+drop trigger if exists trg_webhook_delivery_tenant on webhook_delivery;
+alter table webhook_delivery drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on booking_link_use;
+alter table booking_link_use no force row level security;
+alter table booking_link_use disable row level security;
+drop trigger if exists trg_booking_link_use_tenant on booking_link_use;
+alter table booking_link_use drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on booking_link;
+alter table booking_link no force row level security;
+alter table booking_link disable row level security;
+drop trigger if exists trg_booking_link_tenant on booking_link;
+alter table booking_link drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on waitlist_entry;
+alter table waitlist_entry no force row level security;
+alter table waitlist_entry disable row level security;
+drop trigger if exists trg_waitlist_entry_tenant on waitlist_entry;
+alter table waitlist_entry drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on session_note_revision;
+alter table session_note_revision no force row level security;
+alter table session_note_revision disable row level security;
+drop trigger if exists trg_session_note_revision_tenant on session_note_revision;
+alter table session_note_revision drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on invoice_line;
+alter table invoice_line no force row level security;
+alter table invoice_line disable row level security;
+drop trigger if exists trg_invoice_line_tenant on invoice_line;
+alter table invoice_line drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on booking_cancellation_reason;
+alter table booking_cancellation_reason no force row level security;
+alter table booking_cancellation_reason disable row level security;
+drop trigger if exists trg_booking_cancellation_reason_tenant on booking_cancellation_reason;
+alter table booking_cancellation_reason drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on booking_extension;
+alter table booking_extension no force row level security;
+alter table booking_extension disable row level security;
+drop trigger if exists trg_booking_extension_tenant on booking_extension;
+alter table booking_extension drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on booking_escalation;
+alter table booking_escalation no force row level security;
+alter table booking_escalation disable row level security;
+drop trigger if exists trg_booking_escalation_tenant on booking_escalation;
+alter table booking_escalation drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on booking_thread_read;
+alter table booking_thread_read no force row level security;
+alter table booking_thread_read disable row level security;
+drop trigger if exists trg_booking_thread_read_tenant on booking_thread_read;
+alter table booking_thread_read drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on booking_message;
+alter table booking_message no force row level security;
+alter table booking_message disable row level security;
+drop trigger if exists trg_booking_message_tenant on booking_message;
+alter table booking_message drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on session_note;
+alter table session_note no force row level security;
+alter table session_note disable row level security;
+drop trigger if exists trg_session_note_tenant on session_note;
+alter table session_note drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on attendance;
+alter table attendance no force row level security;
+alter table attendance disable row level security;
+drop trigger if exists trg_attendance_tenant on attendance;
+alter table attendance drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on invoice;
+alter table invoice no force row level security;
+alter table invoice disable row level security;
+drop trigger if exists trg_invoice_tenant on invoice;
+alter table invoice drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on organization;
+alter table organization no force row level security;
+alter table organization disable row level security;
+alter table organization drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on notification_preference;
+alter table notification_preference no force row level security;
+alter table notification_preference disable row level security;
+drop trigger if exists trg_notification_preference_tenant on notification_preference;
+alter table notification_preference drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on tax_profile;
+alter table tax_profile no force row level security;
+alter table tax_profile disable row level security;
+drop trigger if exists trg_tax_profile_tenant on tax_profile;
+alter table tax_profile drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on webhook_subscription;
+alter table webhook_subscription no force row level security;
+alter table webhook_subscription disable row level security;
+drop trigger if exists trg_webhook_subscription_tenant on webhook_subscription;
+alter table webhook_subscription drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on demand_forecast;
+alter table demand_forecast no force row level security;
+alter table demand_forecast disable row level security;
+drop trigger if exists trg_demand_forecast_tenant on demand_forecast;
+alter table demand_forecast drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on calendar_day_summary;
+alter table calendar_day_summary no force row level security;
+alter table calendar_day_summary disable row level security;
+drop trigger if exists trg_calendar_day_summary_tenant on calendar_day_summary;
+alter table calendar_day_summary drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on availability_tombstone;
+alter table availability_tombstone no force row level security;
+alter table availability_tombstone disable row level security;
+drop trigger if exists trg_availability_tombstone_tenant on availability_tombstone;
+alter table availability_tombstone drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on teacher_offboarding;
+alter table teacher_offboarding no force row level security;
+alter table teacher_offboarding disable row level security;
+drop trigger if exists trg_teacher_offboarding_tenant on teacher_offboarding;
+alter table teacher_offboarding drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on availability_rule_set;
+alter table availability_rule_set no force row level security;
+alter table availability_rule_set disable row level security;
+drop trigger if exists trg_availability_rule_set_tenant on availability_rule_set;
+alter table availability_rule_set drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on escalation_rule;
+alter table escalation_rule no force row level security;
+alter table escalation_rule disable row level security;
+drop trigger if exists trg_escalation_rule_tenant on escalation_rule;
+alter table escalation_rule drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on favorite_teacher;
+alter table favorite_teacher no force row level security;
+alter table favorite_teacher disable row level security;
+drop trigger if exists trg_favorite_teacher_tenant on favorite_teacher;
+alter table favorite_teacher drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on schedule_grant;
+alter table schedule_grant no force row level security;
+alter table schedule_grant disable row level security;
+drop trigger if exists trg_schedule_grant_tenant on schedule_grant;
+alter table schedule_grant drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on widget_config;
+alter table widget_config no force row level security;
+alter table widget_config disable row level security;
+drop trigger if exists trg_widget_config_tenant on widget_config;
+alter table widget_config drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on share_link;
+alter table share_link no force row level security;
+alter table share_link disable row level security;
+drop trigger if exists trg_share_link_tenant on share_link;
+alter table share_link drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on catalog_product;
+alter table catalog_product no force row level security;
+alter table catalog_product disable row level security;
+drop trigger if exists trg_catalog_product_tenant on catalog_product;
+alter table catalog_product drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on cancellation_rule;
+alter table cancellation_rule no force row level security;
+alter table cancellation_rule disable row level security;
+drop trigger if exists trg_cancellation_rule_tenant on cancellation_rule;
+alter table cancellation_rule drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on location;
+alter table location no force row level security;
+alter table location disable row level security;
+drop trigger if exists trg_location_tenant on location;
+alter table location drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on session_type;
+alter table session_type no force row level security;
+alter table session_type disable row level security;
+drop trigger if exists trg_session_type_tenant on session_type;
+alter table session_type drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on payout_statement;
+alter table payout_statement no force row level security;
+alter table payout_statement disable row level security;
+drop trigger if exists trg_payout_statement_tenant on payout_statement;
+alter table payout_statement drop column if exists tenant_id;
+
+drop policy if exists tenant_isolation on commission_rule;
+alter table commission_rule no force row level security;
+alter table commission_rule disable row level security;
+drop trigger if exists trg_commission_rule_tenant on commission_rule;
+alter table commission_rule drop column if exists tenant_id;
+
+drop function if exists inherit_tenant();
+
+commit;
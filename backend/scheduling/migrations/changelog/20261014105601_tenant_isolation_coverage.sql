@@ -0,0 +1,520 @@
+begin;
+
+-- Backfilling reads rows of every tenant
+select set_config('scheduling.tenant_bypass', 'on', true);
+
+-- Every table holding the data of an educator or a student belongs to a tenant, not only the schedule and its
+-- bookings. Platform settings, message bookkeeping, the audit trails and the job bookkeeping of reminders and
+-- alerts are not tenant data. Status transitions are only read through their booking, whose row security
+-- already applies, and stay signed as they were recorded.
+alter table commission_rule add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table payout_statement add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table session_type add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table location add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table cancellation_rule add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table catalog_product add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table share_link add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table widget_config add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table schedule_grant add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table favorite_teacher add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table escalation_rule add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table availability_rule_set add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table teacher_offboarding add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table availability_tombstone add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table calendar_day_summary add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table demand_forecast add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table webhook_subscription add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table tax_profile add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table notification_preference add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table organization add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table invoice add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table attendance add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table session_note add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table booking_message add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table booking_thread_read add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table booking_escalation add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table booking_extension add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table booking_cancellation_reason add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table invoice_line add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table session_note_revision add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table waitlist_entry add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table booking_link add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table booking_link_use add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table webhook_delivery add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table webhook_dead_letter add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table availability_rule add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table blackout_date add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table catalog_lesson add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table catalog_enrollment add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table organization_member add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+alter table widget_api_usage add column if not exists tenant_id varchar(64) not null default coalesce(current_tenant_id(), 'default');
+
+-- Rows created without a tenant, by background jobs and consumers, belong to the tenant of the row they derive
+-- from: tg_argv names its table, the column matched there, the column of the new row holding the value and
+-- its type. Rows of an educator without a parent take the tenant of the educator's working periods.
+create or replace function inherit_tenant() returns trigger as $$
+declare
+   parent_tenant_id varchar;
+begin
+   if current_tenant_id() is null then
+      execute format('select tenant_id from %I where %I = $1::%s limit 1', tg_argv[0], tg_argv[1], tg_argv[3])
+         into parent_tenant_id
+         using to_jsonb(new)->>tg_argv[2];
+      new.tenant_id := coalesce(parent_tenant_id, new.tenant_id);
+   end if;
+   return new;
+end;
+$$ language plpgsql;
+
+-- Rows that predate this change take the tenant of the row they derive from, parents before their children
+create temporary table educator_tenant on commit drop as
+   select distinct on (user_id) user_id, tenant_id from working_period order by user_id, id;
+
+update commission_rule t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update payout_statement t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update session_type t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update location t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update cancellation_rule t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update catalog_product t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update share_link t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update widget_config t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update schedule_grant t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update favorite_teacher t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update escalation_rule t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update availability_rule_set t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update teacher_offboarding t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update availability_tombstone t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update calendar_day_summary t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update demand_forecast t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update webhook_subscription t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.educator_id;
+update tax_profile t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.user_id;
+update notification_preference t set tenant_id = e.tenant_id from educator_tenant e where e.user_id = t.user_id;
+update invoice t set tenant_id = p.tenant_id from booking p where p.id = t.booking_id;
+update attendance t set tenant_id = p.tenant_id from booking p where p.id = t.booking_id;
+update session_note t set tenant_id = p.tenant_id from booking p where p.id = t.booking_id;
+update booking_message t set tenant_id = p.tenant_id from booking p where p.id = t.booking_id;
+update booking_thread_read t set tenant_id = p.tenant_id from booking p where p.id = t.booking_id;
+update booking_escalation t set tenant_id = p.tenant_id from booking p where p.id = t.booking_id;
+update booking_extension t set tenant_id = p.tenant_id from booking p where p.id = t.booking_id;
+update booking_cancellation_reason t set tenant_id = p.tenant_id from booking p where p.id = t.booking_id;
+update invoice_line t set tenant_id = p.tenant_id from invoice p where p.id = t.invoice_id;
+update session_note_revision t set tenant_id = p.tenant_id from session_note p where p.id = t.note_id;
+update waitlist_entry t set tenant_id = p.tenant_id from scheduled_event p where p.id = t.scheduled_event_id;
+update booking_link t set tenant_id = p.tenant_id from working_period p where p.id = t.working_period_id;
+update booking_link_use t set tenant_id = p.tenant_id from booking_link p where p.id = t.link_id;
+update webhook_delivery t set tenant_id = p.tenant_id from webhook_subscription p where p.id = t.subscription_id;
+update webhook_dead_letter t set tenant_id = p.tenant_id from webhook_subscription p where p.id = t.subscription_id;
+update availability_rule t set tenant_id = p.tenant_id from availability_rule_set p where p.educator_id = t.educator_id;
+update blackout_date t set tenant_id = p.tenant_id from availability_rule_set p where p.educator_id = t.educator_id;
+update catalog_lesson t set tenant_id = p.tenant_id from catalog_product p where p.product_id = t.product_id;
+update catalog_enrollment t set tenant_id = p.tenant_id from catalog_product p where p.product_id = t.product_id;
+update organization_member t set tenant_id = p.tenant_id from organization p where p.id = t.organization_id;
+update widget_api_usage t set tenant_id = p.tenant_id from widget_config p where p.educator_id = t.educator_id;
+
+create trigger trg_commission_rule_tenant
+   before insert on commission_rule
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_payout_statement_tenant
+   before insert on payout_statement
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_session_type_tenant
+   before insert on session_type
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_location_tenant
+   before insert on location
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_cancellation_rule_tenant
+   before insert on cancellation_rule
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_catalog_product_tenant
+   before insert on catalog_product
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_share_link_tenant
+   before insert on share_link
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_widget_config_tenant
+   before insert on widget_config
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_schedule_grant_tenant
+   before insert on schedule_grant
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_favorite_teacher_tenant
+   before insert on favorite_teacher
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_escalation_rule_tenant
+   before insert on escalation_rule
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_availability_rule_set_tenant
+   before insert on availability_rule_set
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_teacher_offboarding_tenant
+   before insert on teacher_offboarding
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_availability_tombstone_tenant
+   before insert on availability_tombstone
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_calendar_day_summary_tenant
+   before insert on calendar_day_summary
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_demand_forecast_tenant
+   before insert on demand_forecast
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_webhook_subscription_tenant
+   before insert on webhook_subscription
+   for each row execute function inherit_tenant('working_period', 'user_id', 'educator_id', 'uuid');
+
+create trigger trg_tax_profile_tenant
+   before insert on tax_profile
+   for each row execute function inherit_tenant('working_period', 'user_id', 'user_id', 'uuid');
+
+create trigger trg_notification_preference_tenant
+   before insert on notification_preference
+   for each row execute function inherit_tenant('working_period', 'user_id', 'user_id', 'uuid');
+
+create trigger trg_invoice_tenant
+   before insert on invoice
+   for each row execute function inherit_tenant('booking', 'id', 'booking_id', 'bigint');
+
+create trigger trg_attendance_tenant
+   before insert on attendance
+   for each row execute function inherit_tenant('booking', 'id', 'booking_id', 'bigint');
+
+create trigger trg_session_note_tenant
+   before insert on session_note
+   for each row execute function inherit_tenant('booking', 'id', 'booking_id', 'bigint');
+
+create trigger trg_booking_message_tenant
+   before insert on booking_message
+   for each row execute function inherit_tenant('booking', 'id', 'booking_id', 'bigint');
+
+create trigger trg_booking_thread_read_tenant
+   before insert on booking_thread_read
+   for each row execute function inherit_tenant('booking', 'id', 'booking_id', 'bigint');
+
+create trigger trg_booking_escalation_tenant
+   before insert on booking_escalation
+   for each row execute function inherit_tenant('booking', 'id', 'booking_id', 'bigint');
+
+create trigger trg_booking_extension_tenant
+   before insert on booking_extension
+   for each row execute function inherit_tenant('booking', 'id', 'booking_id', 'bigint');
+
+create trigger trg_booking_cancellation_reason_tenant
+   before insert on booking_cancellation_reason
+   for each row execute function inherit_tenant('booking', 'id', 'booking_id', 'bigint');
+
+create trigger trg_invoice_line_tenant
+   before insert on invoice_line
+   for each row execute function inherit_tenant('invoice', 'id', 'invoice_id', 'bigint');
+
+create trigger trg_session_note_revision_tenant
+   before insert on session_note_revision
+   for each row execute function inherit_tenant('session_note', 'id', 'note_id', 'bigint');
+
+create trigger trg_waitlist_entry_tenant
+   before insert on waitlist_entry
+   for each row execute function inherit_tenant('scheduled_event', 'id', 'scheduled_event_id', 'bigint');
+
+create trigger trg_booking_link_tenant
+   before insert on booking_link
+   for each row execute function inherit_tenant('working_period', 'id', 'working_period_id', 'bigint');
+
+create trigger trg_booking_link_use_tenant
+   before insert on booking_link_use
+   for each row execute function inherit_tenant('booking_link', 'id', 'link_id', 'bigint');
+
+create trigger trg_webhook_delivery_tenant
+   before insert on webhook_delivery
+   for each row execute function inherit_tenant('webhook_subscription', 'id', 'subscription_id', 'bigint');
+
+create trigger trg_webhook_dead_letter_tenant
+   before insert on webhook_dead_letter
+   for each row execute function inherit_tenant('webhook_subscription', 'id', 'subscription_id', 'bigint');
+
+create trigger trg_availability_rule_tenant
+   before insert on availability_rule
+   for each row execute function inherit_tenant('availability_rule_set', 'educator_id', 'educator_id', 'uuid');
+
+create trigger trg_blackout_date_tenant
+   before insert on blackout_date
+   for each row execute function inherit_tenant('availability_rule_set', 'educator_id', 'educator_id', 'uuid');
+
+create trigger trg_catalog_lesson_tenant
+   before insert on catalog_lesson
+   for each row execute function inherit_tenant('catalog_product', 'product_id', 'product_id', 'bigint');
+
+create trigger trg_catalog_enrollment_tenant
+   before insert on catalog_enrollment
+   for each row execute function inherit_tenant('catalog_product', 'product_id', 'product_id', 'bigint');
+
+create trigger trg_organization_member_tenant
+   before insert on organization_member
+   for each row execute function inherit_tenant('organization', 'id', 'organization_id', 'bigint');
+
+create trigger trg_widget_api_usage_tenant
+   before insert on widget_api_usage
+   for each row execute function inherit_tenant('widget_config', 'educator_id', 'educator_id', 'uuid');
+
+alter table commission_rule enable row level security;
+alter table commission_rule force row level security;
+create policy tenant_isolation on commission_rule
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table payout_statement enable row level security;
+alter table payout_statement force row level security;
+create policy tenant_isolation on payout_statement
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table session_type enable row level security;
+alter table session_type force row level security;
+create policy tenant_isolation on session_type
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table location enable row level security;
+alter table location force row level security;
+create policy tenant_isolation on location
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table cancellation_rule enable row level security;
+alter table cancellation_rule force row level security;
+create policy tenant_isolation on cancellation_rule
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table catalog_product enable row level security;
+alter table catalog_product force row level security;
+create policy tenant_isolation on catalog_product
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table share_link enable row level security;
+alter table share_link force row level security;
+create policy tenant_isolation on share_link
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table widget_config enable row level security;
+alter table widget_config force row level security;
+create policy tenant_isolation on widget_config
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table schedule_grant enable row level security;
+alter table schedule_grant force row level security;
+create policy tenant_isolation on schedule_grant
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table favorite_teacher enable row level security;
+alter table favorite_teacher force row level security;
+create policy tenant_isolation on favorite_teacher
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table escalation_rule enable row level security;
+alter table escalation_rule force row level security;
+create policy tenant_isolation on escalation_rule
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table availability_rule_set enable row level security;
+alter table availability_rule_set force row level security;
+create policy tenant_isolation on availability_rule_set
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table teacher_offboarding enable row level security;
+alter table teacher_offboarding force row level security;
+create policy tenant_isolation on teacher_offboarding
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table availability_tombstone enable row level security;
+alter table availability_tombstone force row level security;
+create policy tenant_isolation on availability_tombstone
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table calendar_day_summary enable row level security;
+alter table calendar_day_summary force row level security;
+create policy tenant_isolation on calendar_day_summary
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table demand_forecast enable row level security;
+alter table demand_forecast force row level security;
+create policy tenant_isolation on demand_forecast
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table webhook_subscription enable row level security;
+alter table webhook_subscription force row level security;
+create policy tenant_isolation on webhook_subscription
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table tax_profile enable row level security;
+alter table tax_profile force row level security;
+create policy tenant_isolation on tax_profile
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table notification_preference enable row level security;
+alter table notification_preference force row level security;
+create policy tenant_isolation on notification_preference
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table organization enable row level security;
+alter table organization force row level security;
+create policy tenant_isolation on organization
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table invoice enable row level security;
+alter table invoice force row level security;
+create policy tenant_isolation on invoice
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table attendance enable row level security;
+alter table attendance force row level security;
+create policy tenant_isolation on attendance
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table session_note enable row level security;
+alter table session_note force row level security;
+create policy tenant_isolation on session_note
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table booking_message enable row level security;
+alter table booking_message force row level security;
+create policy tenant_isolation on booking_message
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table booking_thread_read enable row level security;
+alter table booking_thread_read force row level security;
+create policy tenant_isolation on booking_thread_read
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table booking_escalation enable row level security;
+alter table booking_escalation force row level security;
+create policy tenant_isolation on booking_escalation
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table booking_extension enable row level security;
+alter table booking_extension force row level security;
+create policy tenant_isolation on booking_extension
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table booking_cancellation_reason enable row level security;
+alter table booking_cancellation_reason force row level security;
+create policy tenant_isolation on booking_cancellation_reason
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table invoice_line enable row level security;
+alter table invoice_line force row level security;
+create policy tenant_isolation on invoice_line
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table session_note_revision enable row level security;
+alter table session_note_revision force row level security;
+create policy tenant_isolation on session_note_revision
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table waitlist_entry enable row level security;
+alter table waitlist_entry force row level security;
+create policy tenant_isolation on waitlist_entry
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table booking_link enable row level security;
+alter table booking_link force row level security;
+create policy tenant_isolation on booking_link
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table booking_link_use enable row level security;
+alter table booking_link_use force row level security;
+create policy tenant_isolation on booking_link_use
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table webhook_delivery enable row level security;
+alter table webhook_delivery force row level security;
+create policy tenant_isolation on webhook_delivery
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table webhook_dead_letter enable row level security;
+alter table webhook_dead_letter force row level security;
+create policy tenant_isolation on webhook_dead_letter
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table availability_rule enable row level security;
+alter table availability_rule force row level security;
+create policy tenant_isolation on availability_rule
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table blackout_date enable row level security;
+alter table blackout_date force row level security;
+create policy tenant_isolation on blackout_date
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table catalog_lesson enable row level security;
+alter table catalog_lesson force row level security;
+create policy tenant_isolation on catalog_lesson
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table catalog_enrollment enable row level security;
+alter table catalog_enrollment force row level security;
+create policy tenant_isolation on catalog_enrollment
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table organization_member enable row level security;
+alter table organization_member force row level security;
+create policy tenant_isolation on organization_member
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+alter table widget_api_usage enable row level security;
+alter table widget_api_usage force row level security;
+create policy tenant_isolation on widget_api_usage
+   using (tenant_row_visible(tenant_id))
+   with check (tenant_row_visible(tenant_id));
+
+commit;
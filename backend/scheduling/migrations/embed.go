@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// Changelog holds the SQL changesets together with the Liquibase master changelog that orders them
+//
+//go:embed changelog/*.sql changelog/*.xml
+var Changelog embed.FS